@@ -129,6 +129,26 @@ func oauthCLIFlags() []cli.Flag {
 			Name:  "group-team-map-removal",
 			Usage: "Activate automatic team membership removal depending on groups",
 		},
+		&cli.StringFlag{
+			Name:  "group-sync-provider",
+			Value: "",
+			Usage: "Group API to synchronize the team memberships with periodically: azuread, keycloak or scim",
+		},
+		&cli.StringFlag{
+			Name:  "group-sync-url",
+			Value: "",
+			Usage: "Base URL of the group API",
+		},
+		&cli.StringFlag{
+			Name:  "group-sync-token-url",
+			Value: "",
+			Usage: "Token endpoint of the client credentials grant for the group API",
+		},
+		&cli.StringFlag{
+			Name:  "group-sync-token",
+			Value: "",
+			Usage: "Static token for the group API, used if no token endpoint is set",
+		},
 	}
 }
 
@@ -185,6 +205,10 @@ func parseOAuth2Config(c *cli.Command) *oauth2.Source {
 		RestrictedGroup:               c.String("restricted-group"),
 		GroupTeamMap:                  c.String("group-team-map"),
 		GroupTeamMapRemoval:           c.Bool("group-team-map-removal"),
+		GroupSyncProvider:             c.String("group-sync-provider"),
+		GroupSyncURL:                  c.String("group-sync-url"),
+		GroupSyncTokenURL:             c.String("group-sync-token-url"),
+		GroupSyncToken:                c.String("group-sync-token"),
 		SSHPublicKeyClaimName:         c.String("ssh-public-key-claim-name"),
 		FullNameClaimName:             c.String("full-name-claim-name"),
 	}
@@ -202,6 +226,9 @@ func (a *authService) runAddOauth(ctx context.Context, c *cli.Command) error {
 			return fmt.Errorf("invalid Auto Discovery URL: %s (this must be a valid URL starting with http:// or https://)", config.OpenIDConnectAutoDiscoveryURL)
 		}
 	}
+	if !oauth2.IsValidGroupSyncProvider(config.GroupSyncProvider) {
+		return fmt.Errorf("invalid group sync provider: %s", config.GroupSyncProvider)
+	}
 
 	return a.createAuthSource(ctx, &auth_model.Source{
 		Type:            auth_model.OAuth2,
@@ -278,6 +305,18 @@ func (a *authService) runUpdateOauth(ctx context.Context, c *cli.Command) error
 	if c.IsSet("group-team-map-removal") {
 		oAuth2Config.GroupTeamMapRemoval = c.Bool("group-team-map-removal")
 	}
+	if c.IsSet("group-sync-provider") {
+		oAuth2Config.GroupSyncProvider = c.String("group-sync-provider")
+	}
+	if c.IsSet("group-sync-url") {
+		oAuth2Config.GroupSyncURL = c.String("group-sync-url")
+	}
+	if c.IsSet("group-sync-token-url") {
+		oAuth2Config.GroupSyncTokenURL = c.String("group-sync-token-url")
+	}
+	if c.IsSet("group-sync-token") {
+		oAuth2Config.GroupSyncToken = c.String("group-sync-token")
+	}
 	if c.IsSet("ssh-public-key-claim-name") {
 		oAuth2Config.SSHPublicKeyClaimName = c.String("ssh-public-key-claim-name")
 	}
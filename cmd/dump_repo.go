@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"code.gitea.io/gitea/modules/git"
@@ -76,6 +77,11 @@ var CmdDumpRepository = &cli.Command{
 			Usage: `Which items will be migrated, one or more units should be separated as comma.
 wiki, issues, labels, releases, release_assets, milestones, pull_requests, comments are allowed. Empty means all units.`,
 		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "gitea",
+			Usage: `The format of the dumped data, "gitea" or "github-archive". "github-archive" writes a GitHub migration archive to repo_dir`,
+		},
 	},
 }
 
@@ -178,14 +184,32 @@ func runDumpRepository(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	if err := migrations.DumpRepository(
-		ctx,
-		repoDir,
-		cmd.String("owner_name"),
-		opts,
-	); err != nil {
-		log.Fatal("Failed to dump repository: %v", err)
-		return err
+	switch cmd.String("format") {
+	case "gitea":
+		if err := migrations.DumpRepository(
+			ctx,
+			repoDir,
+			cmd.String("owner_name"),
+			opts,
+		); err != nil {
+			log.Fatal("Failed to dump repository: %v", err)
+			return err
+		}
+	case "github-archive":
+		if err := os.MkdirAll(repoDir, os.ModePerm); err != nil {
+			return err
+		}
+		if err := migrations.ExportGitHubArchive(
+			ctx,
+			filepath.Join(repoDir, "migration_archive.tar.gz"),
+			cmd.String("owner_name"),
+			opts,
+		); err != nil {
+			log.Fatal("Failed to export repository: %v", err)
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid format: %q", cmd.String("format"))
 	}
 
 	log.Trace("Dump finished!!!")
@@ -32,6 +32,7 @@ var (
 			microcmdGenerateInternalToken,
 			microcmdGenerateLfsJwtSecret,
 			microcmdGenerateSecretKey,
+			microcmdGenerateVAPIDKeys,
 		},
 	}
 
@@ -53,6 +54,12 @@ var (
 		Usage:  "Generate a new SECRET_KEY",
 		Action: runGenerateSecretKey,
 	}
+
+	microcmdGenerateVAPIDKeys = &cli.Command{
+		Name:   "VAPID_KEYS",
+		Usage:  "Generate a new VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY pair for web push",
+		Action: runGenerateVAPIDKeys,
+	}
 )
 
 func runGenerateInternalToken(_ context.Context, c *cli.Command) error {
@@ -100,3 +107,14 @@ func runGenerateSecretKey(_ context.Context, c *cli.Command) error {
 
 	return nil
 }
+
+func runGenerateVAPIDKeys(_ context.Context, c *cli.Command) error {
+	publicKey, privateKey, err := generate.NewVAPIDKeys()
+	if err != nil {
+		return err
+	}
+
+	// codeql[disable-next-line=go/clear-text-logging]
+	fmt.Printf("VAPID_PUBLIC_KEY = %s\nVAPID_PRIVATE_KEY = %s\n", publicKey, privateKey)
+	return nil
+}
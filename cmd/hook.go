@@ -197,6 +197,7 @@ Gitea or set your environment appropriately.`, "")
 		PullRequestID:                   prID,
 		DeployKeyID:                     deployKeyID,
 		ActionPerm:                      actionPerm,
+		IsWiki:                          isWiki,
 	}
 
 	scanner := bufio.NewScanner(os.Stdin)
@@ -223,11 +224,6 @@ Gitea or set your environment appropriately.`, "")
 	supportProcReceive := git.DefaultFeatures().SupportProcReceive
 
 	for scanner.Scan() {
-		// TODO: support news feeds for wiki
-		if isWiki {
-			continue
-		}
-
 		fields := bytes.Fields(scanner.Bytes())
 		if len(fields) != 3 {
 			continue
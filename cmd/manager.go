@@ -23,6 +23,7 @@ var (
 			subcmdShutdown,
 			subcmdRestart,
 			subcmdReloadTemplates,
+			subcmdReloadConfig,
 			subcmdFlushQueues,
 			subcmdLogging,
 			subCmdProcesses,
@@ -58,6 +59,16 @@ var (
 		},
 		Action: runReloadTemplates,
 	}
+	subcmdReloadConfig = &cli.Command{
+		Name:  "reload-config",
+		Usage: "Reload the log levels, the mailer, the webhook allowed hosts, the OAuth2 clients and the API rate limits from the config file in the running process",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name: "debug",
+			},
+		},
+		Action: runReloadConfig,
+	}
 	subcmdFlushQueues = &cli.Command{
 		Name:   "flush-queues",
 		Usage:  "Flush queues in the running process",
@@ -127,6 +138,12 @@ func runReloadTemplates(ctx context.Context, c *cli.Command) error {
 	return handleCliResponseExtra(extra)
 }
 
+func runReloadConfig(ctx context.Context, c *cli.Command) error {
+	setup(ctx, c.Bool("debug"))
+	extra := private.ReloadConfig(ctx)
+	return handleCliResponseExtra(extra)
+}
+
 func runFlushQueues(ctx context.Context, c *cli.Command) error {
 	setup(ctx, c.Bool("debug"))
 	extra := private.FlushQueues(ctx, c.Duration("timeout"), c.Bool("non-blocking"))
@@ -5,13 +5,16 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/migrations/online"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/versioned_migration"
 
 	"github.com/urfave/cli/v3"
+	"xorm.io/xorm"
 )
 
 // CmdMigrate represents the available migrate sub-command.
@@ -20,6 +23,16 @@ var CmdMigrate = &cli.Command{
 	Usage:       "Migrate the database",
 	Description: `This is a command for migrating the database, so that you can run "gitea admin create user" before starting the server.`,
 	Action:      runMigrate,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "status",
+			Usage: "Show the progress of the background migrations after migrating",
+		},
+		&cli.BoolFlag{
+			Name:  "contract",
+			Usage: "Run the contract steps of the migrations, only when all instances run the current version and all background migrations are done",
+		},
+	},
 }
 
 func runMigrate(ctx context.Context, c *cli.Command) error {
@@ -33,10 +46,39 @@ func runMigrate(ctx context.Context, c *cli.Command) error {
 	log.Info("Log path: %s", setting.Log.RootPath)
 	log.Info("Configuration file: %s", setting.CustomConf)
 
-	if err := db.InitEngineWithMigration(context.Background(), versioned_migration.Migrate); err != nil {
+	var engine *xorm.Engine
+	if err := db.InitEngineWithMigration(context.Background(), func(ctx context.Context, x *xorm.Engine) error {
+		engine = x
+		return versioned_migration.Migrate(ctx, x)
+	}); err != nil {
 		log.Fatal("Failed to initialize ORM engine: %v", err)
 		return err
 	}
 
+	if c.Bool("contract") {
+		if err := versioned_migration.Contract(ctx, engine); err != nil {
+			log.Fatal("Failed to run the contract steps: %v", err)
+			return err
+		}
+	}
+
+	if c.Bool("status") {
+		ms, err := online.ListBackgroundMigrations(engine)
+		if err != nil {
+			return err
+		}
+		for _, m := range ms {
+			kind := "backfill"
+			if m.IsContract {
+				kind = "contract"
+			}
+			fmt.Printf("%s\t%s\t%s\t%.1f%%", m.Name, kind, m.Status, m.Progress())
+			if m.ErrorMessage != "" {
+				fmt.Printf("\t%s", m.ErrorMessage)
+			}
+			fmt.Println()
+		}
+	}
+
 	return nil
 }
@@ -42,7 +42,7 @@ var CmdMigrateStorage = &cli.Command{
 			Name:    "storage",
 			Aliases: []string{"s"},
 			Value:   "",
-			Usage:   "New storage type: local (default), minio or azureblob",
+			Usage:   "New storage type: local (default), minio, azureblob or gcs",
 		},
 		&cli.StringFlag{
 			Name:    "path",
@@ -115,6 +115,16 @@ var CmdMigrateStorage = &cli.Command{
 			Value: "",
 			Usage: "Azure Blob storage account key",
 		},
+		&cli.StringFlag{
+			Name:  "azureblob-sas-token",
+			Value: "",
+			Usage: "Azure Blob storage SAS token, used if there is no account key",
+		},
+		&cli.StringFlag{
+			Name:  "azureblob-managed-identity-client-id",
+			Value: "",
+			Usage: "Azure Blob storage client ID of the user-assigned managed identity, the managed identity is used if there is neither an account key nor a SAS token",
+		},
 		&cli.StringFlag{
 			Name:  "azureblob-container",
 			Value: "",
@@ -125,6 +135,32 @@ var CmdMigrateStorage = &cli.Command{
 			Value: "",
 			Usage: "Azure Blob storage base path",
 		},
+		// Google Cloud Storage special configurations
+		&cli.StringFlag{
+			Name:  "gcs-endpoint",
+			Value: "https://storage.googleapis.com",
+			Usage: "GCS endpoint",
+		},
+		&cli.StringFlag{
+			Name:  "gcs-bucket",
+			Value: "",
+			Usage: "GCS bucket",
+		},
+		&cli.StringFlag{
+			Name:  "gcs-base-path",
+			Value: "",
+			Usage: "GCS base path",
+		},
+		&cli.StringFlag{
+			Name:  "gcs-credentials-file",
+			Value: "",
+			Usage: "GCS service account key or workload identity federation configuration, the application default credentials are used if it is empty",
+		},
+		&cli.StringFlag{
+			Name:  "gcs-kms-key-name",
+			Value: "",
+			Usage: "GCS customer-managed encryption key",
+		},
 	},
 }
 
@@ -271,11 +307,25 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 			ctx,
 			&setting.Storage{
 				AzureBlobConfig: setting.AzureBlobStorageConfig{
-					Endpoint:    cmd.String("azureblob-endpoint"),
-					AccountName: cmd.String("azureblob-account-name"),
-					AccountKey:  cmd.String("azureblob-account-key"),
-					Container:   cmd.String("azureblob-container"),
-					BasePath:    cmd.String("azureblob-base-path"),
+					Endpoint:                cmd.String("azureblob-endpoint"),
+					AccountName:             cmd.String("azureblob-account-name"),
+					AccountKey:              cmd.String("azureblob-account-key"),
+					SASToken:                strings.TrimPrefix(cmd.String("azureblob-sas-token"), "?"),
+					ManagedIdentityClientID: cmd.String("azureblob-managed-identity-client-id"),
+					Container:               cmd.String("azureblob-container"),
+					BasePath:                cmd.String("azureblob-base-path"),
+				},
+			})
+	case string(setting.GCSStorageType):
+		dstStorage, err = storage.NewGCSStorage(
+			ctx,
+			&setting.Storage{
+				GCSConfig: setting.GCSStorageConfig{
+					Endpoint:        cmd.String("gcs-endpoint"),
+					Bucket:          cmd.String("gcs-bucket"),
+					BasePath:        cmd.String("gcs-base-path"),
+					CredentialsFile: cmd.String("gcs-credentials-file"),
+					KMSKeyName:      cmd.String("gcs-kms-key-name"),
 				},
 			})
 	default:
@@ -125,7 +125,7 @@ func showWebStartupMessage(msg string) {
 	if setting.DefaultUILocation != time.Local {
 		log.Info("Default UI Location is %v", setting.DefaultUILocation.String())
 	}
-	if setting.MailService != nil {
+	if setting.GetMailService() != nil {
 		log.Info("Mail Service Enabled: RegisterEmailConfirm=%v, Service.EnableNotifyMail=%v", setting.Service.RegisterEmailConfirm, setting.Service.EnableNotifyMail)
 	}
 }
@@ -77,6 +77,12 @@ func CreateArtifact(ctx context.Context, t *ActionTask, artifactName, artifactPa
 	if err := t.LoadJob(ctx); err != nil {
 		return nil, err
 	}
+	// the retention policy limits how long the runner may keep the artifact
+	if policy, err := GetEffectiveArtifactRetention(ctx, t.OwnerID, t.RepoID); err != nil {
+		return nil, err
+	} else if policy != nil && policy.MaxAgeDays > 0 && expiredDays > policy.MaxAgeDays {
+		expiredDays = policy.MaxAgeDays
+	}
 	artifact, err := getArtifactByNameAndPath(ctx, t.Job.RunID, artifactName, artifactPath)
 	if errors.Is(err, util.ErrNotExist) {
 		artifact := &ActionArtifact{
@@ -0,0 +1,207 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionArtifactRetention is an artifact retention policy, a zero limit means there is no such limit
+//
+// It can be:
+//  1. org/user level policy, OwnerID is org/user ID and RepoID is 0
+//  2. repo level policy, OwnerID is 0 and RepoID is repo ID
+//
+// The limits of a repo level policy take precedence over the limits of its owner's policy,
+// the limits which are not set by the repo level policy are inherited.
+type ActionArtifactRetention struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(owner_repo)"`
+	RepoID  int64 `xorm:"UNIQUE(owner_repo)"`
+	// MaxAgeDays is the maximum number of days an artifact is kept
+	MaxAgeDays int64 `xorm:"NOT NULL DEFAULT 0"`
+	// MaxTotalSize is the maximum size in bytes of all the artifacts of a repository, the oldest are deleted first
+	MaxTotalSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	// KeepLastN is the number of the latest runs of each workflow whose artifacts are kept
+	KeepLastN int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionArtifactRetention))
+}
+
+// IsEmpty returns whether the policy doesn't limit anything
+func (r *ActionArtifactRetention) IsEmpty() bool {
+	return r.MaxAgeDays <= 0 && r.MaxTotalSize <= 0 && r.KeepLastN <= 0
+}
+
+// GetArtifactRetention returns the retention policy defined for the owner or the repository
+func GetArtifactRetention(ctx context.Context, ownerID, repoID int64) (*ActionArtifactRetention, error) {
+	if repoID != 0 {
+		ownerID = 0
+	}
+	r := &ActionArtifactRetention{}
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("artifact retention policy doesn't exist")
+	}
+	return r, nil
+}
+
+// GetEffectiveArtifactRetention returns the retention policy which applies to the artifacts of a repository,
+// it's nil if neither the repository nor its owner has a policy.
+func GetEffectiveArtifactRetention(ctx context.Context, ownerID, repoID int64) (*ActionArtifactRetention, error) {
+	var policies []*ActionArtifactRetention
+	if err := db.GetEngine(ctx).Where(builder.Or(
+		builder.Eq{"owner_id": 0, "repo_id": repoID},
+		builder.Eq{"owner_id": ownerID, "repo_id": 0},
+	)).Find(&policies); err != nil {
+		return nil, err
+	}
+
+	var ret *ActionArtifactRetention
+	for _, p := range policies {
+		if p.RepoID == 0 {
+			continue
+		}
+		ret = &ActionArtifactRetention{RepoID: repoID, MaxAgeDays: p.MaxAgeDays, MaxTotalSize: p.MaxTotalSize, KeepLastN: p.KeepLastN}
+	}
+	for _, p := range policies {
+		if p.RepoID != 0 {
+			continue
+		}
+		if ret == nil {
+			ret = &ActionArtifactRetention{RepoID: repoID}
+		}
+		ret.MaxAgeDays = util.Iif(ret.MaxAgeDays > 0, ret.MaxAgeDays, p.MaxAgeDays)
+		ret.MaxTotalSize = util.Iif(ret.MaxTotalSize > 0, ret.MaxTotalSize, p.MaxTotalSize)
+		ret.KeepLastN = util.Iif(ret.KeepLastN > 0, ret.KeepLastN, p.KeepLastN)
+	}
+	if ret != nil && ret.IsEmpty() {
+		return nil, nil
+	}
+	return ret, nil
+}
+
+// SetArtifactRetention creates or updates the retention policy of the owner or the repository
+func SetArtifactRetention(ctx context.Context, r *ActionArtifactRetention) error {
+	if r.RepoID != 0 {
+		r.OwnerID = 0
+	}
+	if r.MaxAgeDays < 0 || r.MaxTotalSize < 0 || r.KeepLastN < 0 {
+		return util.NewInvalidArgumentErrorf("artifact retention limits must not be negative")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetArtifactRetention(ctx, r.OwnerID, r.RepoID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+		if existing == nil {
+			return db.Insert(ctx, r)
+		}
+		r.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(r.ID).Cols("max_age_days", "max_total_size", "keep_last_n").Update(r)
+		return err
+	})
+}
+
+// DeleteArtifactRetention deletes the retention policy of the owner or the repository
+func DeleteArtifactRetention(ctx context.Context, ownerID, repoID int64) error {
+	if repoID != 0 {
+		ownerID = 0
+	}
+	_, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Delete(new(ActionArtifactRetention))
+	return err
+}
+
+// storedArtifactStatuses are the statuses of the artifacts whose files are in the storage
+var storedArtifactStatuses = []ArtifactStatus{ArtifactStatusUploadPending, ArtifactStatusUploadConfirmed}
+
+// ArtifactUsage is the storage consumed by the artifacts of a repository
+type ArtifactUsage struct {
+	RepoID int64
+	Repo   *repo_model.Repository `xorm:"-"`
+	Count  int64
+	Size   int64
+}
+
+// GetArtifactUsages returns the storage consumed by the artifacts of the repository or of each repository of the owner
+func GetArtifactUsages(ctx context.Context, ownerID, repoID int64) ([]*ArtifactUsage, error) {
+	cond := builder.In("status", storedArtifactStatuses)
+	if repoID != 0 {
+		cond = cond.And(builder.Eq{"repo_id": repoID})
+	} else if ownerID != 0 {
+		cond = cond.And(builder.Eq{"owner_id": ownerID})
+	}
+	usages := make([]*ArtifactUsage, 0, 10)
+	if err := db.GetEngine(ctx).Table("action_artifact").Where(cond).
+		GroupBy("repo_id").
+		Select("repo_id, count(*) as count, sum(file_compressed_size) as size").
+		OrderBy("size DESC").
+		Find(&usages); err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]int64, 0, len(usages))
+	for _, u := range usages {
+		repoIDs = append(repoIDs, u.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*ArtifactUsage, 0, len(usages))
+	for _, u := range usages {
+		if u.Repo = repos[u.RepoID]; u.Repo != nil {
+			ret = append(ret, u)
+		}
+	}
+	return ret, nil
+}
+
+// FindReposWithArtifacts returns the repositories which have stored artifacts, mapped to their owners
+func FindReposWithArtifacts(ctx context.Context) (map[int64]int64, error) {
+	var arts []*ActionArtifact
+	if err := db.GetEngine(ctx).Where(builder.In("status", storedArtifactStatuses)).
+		Distinct("repo_id", "owner_id").Find(&arts); err != nil {
+		return nil, err
+	}
+	repos := make(map[int64]int64, len(arts))
+	for _, art := range arts {
+		repos[art.RepoID] = art.OwnerID
+	}
+	return repos, nil
+}
+
+// ListConfirmedArtifactsOfRepo returns the uploaded artifacts of a repository, the newest first
+func ListConfirmedArtifactsOfRepo(ctx context.Context, repoID int64) ([]*ActionArtifact, error) {
+	arts := make([]*ActionArtifact, 0, 10)
+	return arts, db.GetEngine(ctx).Where("repo_id = ? AND status = ?", repoID, ArtifactStatusUploadConfirmed).
+		Desc("run_id", "id").Find(&arts)
+}
+
+// ExpireArtifactsByID makes the artifacts expire now, they are deleted by the next artifacts cleanup
+func ExpireArtifactsByID(ctx context.Context, ids []int64) error {
+	for chunk := range slices.Chunk(ids, 500) {
+		if _, err := db.GetEngine(ctx).In("id", chunk).Where("status = ?", ArtifactStatusUploadConfirmed).
+			Cols("expired_unix").Update(&ActionArtifact{ExpiredUnix: timeutil.TimeStampNow() - 1}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEffectiveArtifactRetention(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	policy, err := GetEffectiveArtifactRetention(t.Context(), 1, 4)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	require.NoError(t, SetArtifactRetention(t.Context(), &ActionArtifactRetention{OwnerID: 1, MaxAgeDays: 30, KeepLastN: 5}))
+	require.NoError(t, SetArtifactRetention(t.Context(), &ActionArtifactRetention{RepoID: 4, MaxAgeDays: 7, MaxTotalSize: 1024}))
+	assert.Error(t, SetArtifactRetention(t.Context(), &ActionArtifactRetention{RepoID: 4, MaxAgeDays: -1}))
+
+	// the repository's limits take precedence, the others are inherited from the owner
+	policy, err = GetEffectiveArtifactRetention(t.Context(), 1, 4)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, policy.MaxAgeDays)
+	assert.EqualValues(t, 1024, policy.MaxTotalSize)
+	assert.EqualValues(t, 5, policy.KeepLastN)
+
+	// updating a policy doesn't create another one
+	require.NoError(t, SetArtifactRetention(t.Context(), &ActionArtifactRetention{RepoID: 4, MaxAgeDays: 3}))
+	unittest.AssertCount(t, &ActionArtifactRetention{RepoID: 4}, 1)
+
+	require.NoError(t, DeleteArtifactRetention(t.Context(), 0, 4))
+	policy, err = GetEffectiveArtifactRetention(t.Context(), 1, 4)
+	require.NoError(t, err)
+	assert.EqualValues(t, 30, policy.MaxAgeDays)
+	assert.EqualValues(t, 0, policy.MaxTotalSize)
+}
+
+func TestGetArtifactUsages(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	usages, err := GetArtifactUsages(t.Context(), 0, 0)
+	require.NoError(t, err)
+	if assert.Len(t, usages, 2) {
+		assert.EqualValues(t, 4, usages[0].RepoID)
+		assert.EqualValues(t, 5, usages[0].Count)
+		assert.EqualValues(t, 5*1024, usages[0].Size)
+		assert.Equal(t, "repo4", usages[0].Repo.Name)
+	}
+
+	usages, err = GetArtifactUsages(t.Context(), 0, 2)
+	require.NoError(t, err)
+	if assert.Len(t, usages, 1) {
+		assert.EqualValues(t, 3, usages[0].Count)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionCache is a cache saved by actions/cache.
+//
+// A cache belongs to the git ref (Scope) of the run which saved it, e.g. `refs/heads/main` or `refs/pull/1/head`.
+// Runs can restore the caches of their own ref, of the base branch of their pull request and of the default branch,
+// but they only save caches for their own ref. So the caches of a pull request are isolated from other pull requests.
+type ActionCache struct {
+	ID       int64  `xorm:"pk autoincr"`
+	RepoID   int64  `xorm:"INDEX(repo_scope)"`
+	Scope    string `xorm:"INDEX(repo_scope) VARCHAR(255)"`
+	CacheKey string `xorm:"VARCHAR(512) NOT NULL"` // lower case, like GitHub the keys are case-insensitive
+	Version  string `xorm:"VARCHAR(255) NOT NULL"`
+	// Size is the size in bytes of the uploaded archive
+	Size int64 `xorm:"NOT NULL DEFAULT 0"`
+	// Complete is false until the upload of the archive is committed, only complete caches can be restored
+	Complete bool `xorm:"INDEX NOT NULL DEFAULT false"`
+	// HitCount is the number of times the cache has been restored
+	HitCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UsedUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionCache))
+}
+
+// StoragePath returns the path of the archive in the cache storage
+func (c *ActionCache) StoragePath() string {
+	return fmt.Sprintf("%d/%d", c.RepoID, c.ID)
+}
+
+// ChunkStoragePath returns the path of an uploaded chunk of the archive, start is the offset of the chunk
+func (c *ActionCache) ChunkStoragePath(start int64) string {
+	return fmt.Sprintf("tmp/%d/%d/%020d", c.RepoID, c.ID, start)
+}
+
+// ChunksStorageDir returns the directory of the uploaded chunks of the archive
+func (c *ActionCache) ChunksStorageDir() string {
+	return fmt.Sprintf("tmp/%d/%d/", c.RepoID, c.ID)
+}
+
+// FindCacheOptions are the options to find the caches of a repository
+type FindCacheOptions struct {
+	db.ListOptions
+	RepoID   int64
+	Scopes   []string
+	CacheKey string
+	Version  string
+	Complete optional.Option[bool]
+}
+
+func (opts FindCacheOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.Scopes) > 0 {
+		cond = cond.And(builder.In("scope", opts.Scopes))
+	}
+	if opts.CacheKey != "" {
+		cond = cond.And(builder.Eq{"cache_key": strings.ToLower(opts.CacheKey)})
+	}
+	if opts.Version != "" {
+		cond = cond.And(builder.Eq{"version": opts.Version})
+	}
+	if opts.Complete.Has() {
+		cond = cond.And(builder.Eq{"complete": opts.Complete.Value()})
+	}
+	return cond
+}
+
+func (opts FindCacheOptions) ToOrders() string {
+	return "`created_unix` DESC, `id` DESC"
+}
+
+// GetCacheByID returns the cache of the repository, or of any repository if repoID is 0
+func GetCacheByID(ctx context.Context, repoID, id int64) (*ActionCache, error) {
+	cond := builder.Eq{"id": id}
+	if repoID > 0 {
+		cond["repo_id"] = repoID
+	}
+	c := &ActionCache{}
+	has, err := db.GetEngine(ctx).Where(cond).Get(c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("cache with id %d doesn't exist", id)
+	}
+	return c, nil
+}
+
+// FindRestorableCache returns the cache restored for the keys, the first key is the primary key and the others are restore keys.
+// The scopes are searched in order, like actions/cache the primary key must match exactly,
+// then each key is used as a prefix and the newest matching cache wins.
+func FindRestorableCache(ctx context.Context, repoID int64, scopes, keys []string, version string) (*ActionCache, error) {
+	if len(scopes) == 0 || len(keys) == 0 {
+		return nil, nil
+	}
+	caches, err := db.Find[ActionCache](ctx, FindCacheOptions{
+		RepoID:   repoID,
+		Scopes:   scopes,
+		Version:  version,
+		Complete: optional.Some(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matchCache(caches, scopes, keys), nil
+}
+
+// matchCache returns the cache matching the keys in the first possible scope, caches are sorted from the newest
+func matchCache(caches []*ActionCache, scopes, keys []string) *ActionCache {
+	for _, scope := range scopes {
+		for _, c := range caches {
+			if c.Scope == scope && c.CacheKey == strings.ToLower(keys[0]) {
+				return c
+			}
+		}
+		for _, key := range keys {
+			key = strings.ToLower(key)
+			for _, c := range caches {
+				if c.Scope == scope && strings.HasPrefix(c.CacheKey, key) {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ReserveCache creates a cache waiting for the upload of its archive.
+// A complete cache with the same key and version can't be overwritten in the same scope,
+// an incomplete one is replaced, the caller should delete the chunks uploaded for it.
+func ReserveCache(ctx context.Context, c *ActionCache) (replaced *ActionCache, err error) {
+	c.CacheKey = strings.ToLower(c.CacheKey)
+	c.Complete = false
+	c.Size = 0
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := db.Find[ActionCache](ctx, FindCacheOptions{
+			RepoID:   c.RepoID,
+			Scopes:   []string{c.Scope},
+			CacheKey: c.CacheKey,
+			Version:  c.Version,
+		})
+		if err != nil {
+			return err
+		}
+		for _, e := range existing {
+			if e.Complete {
+				return util.NewAlreadyExistErrorf("cache %q already exists", c.CacheKey)
+			}
+		}
+		for _, e := range existing {
+			if _, err := db.DeleteByID[ActionCache](ctx, e.ID); err != nil {
+				return err
+			}
+			replaced = e
+		}
+		return db.Insert(ctx, c)
+	})
+	return replaced, err
+}
+
+// CommitCache marks the cache complete after its archive has been uploaded
+func CommitCache(ctx context.Context, c *ActionCache, size int64) error {
+	c.Size = size
+	c.Complete = true
+	c.UsedUnix = timeutil.TimeStampNow()
+	n, err := db.GetEngine(ctx).ID(c.ID).Where("complete = ?", false).Cols("size", "complete", "used_unix").Update(c)
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return util.NewInvalidArgumentErrorf("cache %d has been committed", c.ID)
+	}
+	return nil
+}
+
+// MarkCacheUsed counts a hit of the cache
+func MarkCacheUsed(ctx context.Context, c *ActionCache) error {
+	c.HitCount++
+	c.UsedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(c.ID).Incr("hit_count").Cols("used_unix").Update(&ActionCache{UsedUnix: c.UsedUnix})
+	return err
+}
+
+// DeleteCaches deletes the cache records, the archives must be deleted from the storage by the caller
+func DeleteCaches(ctx context.Context, ids []int64) error {
+	for chunk := range slices.Chunk(ids, 500) {
+		if _, err := db.GetEngine(ctx).In("id", chunk).Delete(new(ActionCache)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindCachesToEvict returns the caches which should be evicted: the incomplete caches created before abandonedBefore,
+// the caches not used since unusedBefore, and the least recently used caches of each repository exceeding maxRepoSize.
+func FindCachesToEvict(ctx context.Context, abandonedBefore, unusedBefore timeutil.TimeStamp, maxRepoSize int64) ([]*ActionCache, error) {
+	evicted := make([]*ActionCache, 0, 10)
+	if err := db.GetEngine(ctx).Where(builder.Or(
+		builder.Eq{"complete": false}.And(builder.Lt{"created_unix": abandonedBefore}),
+		builder.Eq{"complete": true}.And(builder.Lt{"used_unix": unusedBefore}),
+	)).Find(&evicted); err != nil {
+		return nil, err
+	}
+	if maxRepoSize < 0 {
+		return evicted, nil
+	}
+
+	var repoIDs []int64
+	if err := db.GetEngine(ctx).Table("action_cache").Where(builder.Eq{"complete": true}.And(builder.Gte{"used_unix": unusedBefore})).
+		GroupBy("repo_id").Having(fmt.Sprintf("sum(size) > %d", maxRepoSize)).Cols("repo_id").Find(&repoIDs); err != nil {
+		return nil, err
+	}
+	for _, repoID := range repoIDs {
+		var caches []*ActionCache
+		if err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "complete": true}.And(builder.Gte{"used_unix": unusedBefore})).
+			Desc("used_unix", "id").Find(&caches); err != nil {
+			return nil, err
+		}
+		evicted = append(evicted, cachesExceedingSize(caches, maxRepoSize)...)
+	}
+	return evicted, nil
+}
+
+// cachesExceedingSize returns the caches which don't fit in the size, caches are sorted from the most recently used
+func cachesExceedingSize(caches []*ActionCache, maxSize int64) []*ActionCache {
+	var total int64
+	for i, c := range caches {
+		total += c.Size
+		if total > maxSize {
+			return caches[i:]
+		}
+	}
+	return nil
+}
+
+// CacheUsage is the storage consumed by the caches of a repository
+type CacheUsage struct {
+	Count int64
+	Size  int64
+	Hits  int64
+}
+
+// GetCacheUsage returns the storage consumed by the complete caches of the repository, or of all repositories if repoID is 0
+func GetCacheUsage(ctx context.Context, repoID int64) (*CacheUsage, error) {
+	cond := builder.Eq{"complete": true}
+	if repoID > 0 {
+		cond["repo_id"] = repoID
+	}
+	usage := &CacheUsage{}
+	if _, err := db.GetEngine(ctx).Table("action_cache").Where(cond).
+		Select("count(*) as count, coalesce(sum(size), 0) as size, coalesce(sum(hit_count), 0) as hits").
+		Get(usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
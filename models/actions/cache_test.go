@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCache(t *testing.T) {
+	// sorted from the newest
+	caches := []*ActionCache{
+		{ID: 4, Scope: "refs/heads/main", CacheKey: "linux-go-new"},
+		{ID: 3, Scope: "refs/pull/1/head", CacheKey: "linux-go-pr"},
+		{ID: 2, Scope: "refs/heads/main", CacheKey: "linux-go-abc"},
+		{ID: 1, Scope: "refs/heads/main", CacheKey: "linux-node"},
+	}
+	scopes := []string{"refs/pull/1/head", "refs/heads/main"}
+
+	// the exact match of the primary key wins over the prefix matches
+	assert.EqualValues(t, 2, matchCache(caches, scopes[1:], []string{"Linux-Go-ABC", "linux-"}).ID)
+	// the scope of the pull request is searched first
+	assert.EqualValues(t, 3, matchCache(caches, scopes, []string{"linux-go-abc", "linux-go-"}).ID)
+	// the newest prefix match
+	assert.EqualValues(t, 4, matchCache(caches, scopes[1:], []string{"none", "linux-go-"}).ID)
+	// the restore keys are tried in order
+	assert.EqualValues(t, 1, matchCache(caches, scopes[1:], []string{"none", "linux-node", "linux-"}).ID)
+	assert.Nil(t, matchCache(caches, []string{"refs/pull/2/head"}, []string{"linux-"}))
+}
+
+func TestReserveAndCommitCache(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	c := &ActionCache{RepoID: 4, Scope: "refs/heads/master", CacheKey: "Key", Version: "v1"}
+	replaced, err := ReserveCache(t.Context(), c)
+	require.NoError(t, err)
+	assert.Nil(t, replaced)
+	assert.Equal(t, "key", c.CacheKey)
+
+	// an incomplete cache can't be restored and is replaced by a new upload
+	found, err := FindRestorableCache(t.Context(), 4, []string{"refs/heads/master"}, []string{"key"}, "v1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	c2 := &ActionCache{RepoID: 4, Scope: "refs/heads/master", CacheKey: "key", Version: "v1"}
+	replaced, err = ReserveCache(t.Context(), c2)
+	require.NoError(t, err)
+	assert.Equal(t, c.ID, replaced.ID)
+
+	require.NoError(t, CommitCache(t.Context(), c2, 100))
+	assert.Error(t, CommitCache(t.Context(), c2, 100))
+	found, err = FindRestorableCache(t.Context(), 4, []string{"refs/heads/master"}, []string{"key"}, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, c2.ID, found.ID)
+	require.NoError(t, MarkCacheUsed(t.Context(), found))
+	unittest.AssertExistsAndLoadBean(t, &ActionCache{ID: c2.ID, HitCount: 1})
+
+	// a complete cache can't be overwritten in its scope
+	_, err = ReserveCache(t.Context(), &ActionCache{RepoID: 4, Scope: "refs/heads/master", CacheKey: "key", Version: "v1"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	_, err = ReserveCache(t.Context(), &ActionCache{RepoID: 4, Scope: "refs/pull/1/head", CacheKey: "key", Version: "v1"})
+	assert.NoError(t, err)
+
+	usage, err := GetCacheUsage(t.Context(), 4)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, usage.Count)
+	assert.EqualValues(t, 100, usage.Size)
+	assert.EqualValues(t, 1, usage.Hits)
+}
+
+func TestFindCachesToEvict(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	caches := []*ActionCache{
+		{RepoID: 4, Scope: "refs/heads/master", CacheKey: "unused", Version: "v1", Complete: true, Size: 10, UsedUnix: now - 10*timeutil.Day},
+		{RepoID: 4, Scope: "refs/heads/master", CacheKey: "old", Version: "v1", Complete: true, Size: 60, UsedUnix: now - 2*timeutil.Day},
+		{RepoID: 4, Scope: "refs/heads/master", CacheKey: "new", Version: "v1", Complete: true, Size: 60, UsedUnix: now},
+		{RepoID: 1, Scope: "refs/heads/master", CacheKey: "small", Version: "v1", Complete: true, Size: 60, UsedUnix: now},
+	}
+	for _, c := range caches {
+		_, err := ReserveCache(t.Context(), &ActionCache{RepoID: c.RepoID, Scope: c.Scope, CacheKey: c.CacheKey, Version: c.Version})
+		require.NoError(t, err)
+	}
+	for _, c := range caches {
+		_, err := unittest.GetXORMEngine().Where("cache_key = ?", c.CacheKey).Cols("complete", "size", "used_unix").Update(c)
+		require.NoError(t, err)
+	}
+
+	evicted, err := FindCachesToEvict(t.Context(), now-timeutil.Day, now-7*timeutil.Day, 100)
+	require.NoError(t, err)
+	keys := make([]string, 0, len(evicted))
+	for _, c := range evicted {
+		keys = append(keys, c.CacheKey)
+	}
+	assert.ElementsMatch(t, []string{"unused", "old"}, keys)
+
+	evicted, err = FindCachesToEvict(t.Context(), now-timeutil.Day, now-7*timeutil.Day, -1)
+	require.NoError(t, err)
+	assert.Len(t, evicted, 1)
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"sort"
+
+	"code.gitea.io/gitea/models/db"
+
+	"xorm.io/builder"
+)
+
+// activeStatuses are the statuses of runs and jobs which are not done yet
+var activeStatuses = []Status{StatusWaiting, StatusRunning, StatusBlocked}
+
+// IsRunConcurrencyBlocked returns whether the run has to wait because an earlier run of its concurrency group isn't done yet.
+// A run which has not been inserted yet (ID is 0) waits for all the other runs of the group.
+func IsRunConcurrencyBlocked(ctx context.Context, run *ActionRun) (bool, error) {
+	if run.ConcurrencyGroup == "" {
+		return false, nil
+	}
+	cond := builder.Eq{"repo_id": run.RepoID, "concurrency_group": run.ConcurrencyGroup}.
+		And(builder.In("status", activeStatuses))
+	if run.ID > 0 {
+		cond = cond.And(builder.Lt{"id": run.ID})
+	}
+	return db.GetEngine(ctx).Where(cond).Exist(new(ActionRun))
+}
+
+// IsJobConcurrencyGroupBusy returns whether another job of the job level concurrency group is waiting for a runner or running
+func IsJobConcurrencyGroupBusy(ctx context.Context, repoID int64, group string, excludeJobID int64) (bool, error) {
+	if group == "" {
+		return false, nil
+	}
+	cond := builder.Eq{"repo_id": repoID, "concurrency_group": group}.
+		And(builder.In("status", []Status{StatusWaiting, StatusRunning})).
+		And(builder.Neq{"id": excludeJobID})
+	return db.GetEngine(ctx).Where(cond).Exist(new(ActionRunJob))
+}
+
+// CancelConcurrentRuns cancels all the runs of the workflow level concurrency group which are not done yet,
+// it's used for `cancel-in-progress: true`.
+func CancelConcurrentRuns(ctx context.Context, repoID int64, group string) ([]*ActionRunJob, error) {
+	runs, err := db.Find[ActionRun](ctx, FindRunOptions{
+		RepoID:           repoID,
+		ConcurrencyGroup: group,
+		Status:           activeStatuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cancelJobsOfRuns(ctx, runs)
+}
+
+// CancelPendingConcurrentRuns cancels the runs of the concurrency group which are still waiting for their turn,
+// only the newest run of a group stays pending like GitHub does.
+func CancelPendingConcurrentRuns(ctx context.Context, repoID int64, group string) ([]*ActionRunJob, error) {
+	runs, err := db.Find[ActionRun](ctx, FindRunOptions{
+		RepoID:           repoID,
+		ConcurrencyGroup: group,
+		Status:           []Status{StatusBlocked},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]*ActionRun, 0, len(runs))
+	for _, run := range runs {
+		// runs blocked by jobs waiting for their needs have started already
+		if run.Started == 0 && !run.NeedApproval {
+			pending = append(pending, run)
+		}
+	}
+	return cancelJobsOfRuns(ctx, pending)
+}
+
+// CancelConcurrentJobs cancels all the jobs of the job level concurrency group which are not done yet
+func CancelConcurrentJobs(ctx context.Context, repoID int64, group string) ([]*ActionRunJob, error) {
+	jobs, err := db.Find[ActionRunJob](ctx, FindRunJobOptions{
+		RepoID:           repoID,
+		ConcurrencyGroup: group,
+		Statuses:         activeStatuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cancelledJobs := make([]*ActionRunJob, 0, len(jobs))
+	for _, job := range jobs {
+		cancelled, err := cancelJob(ctx, job)
+		if err != nil {
+			return cancelledJobs, err
+		}
+		if cancelled {
+			cancelledJobs = append(cancelledJobs, job)
+		}
+	}
+	return cancelledJobs, nil
+}
+
+// ConcurrencyGroup is an active concurrency group of a repository
+type ConcurrencyGroup struct {
+	Name string
+	// IsJobLevel is true for groups defined by `jobs.<job_id>.concurrency`
+	IsJobLevel bool
+	// InProgress are the IDs of the runs holding the group
+	InProgress []int64
+	// Queued are the IDs of the runs waiting for the group
+	Queued []int64
+}
+
+// FindActiveConcurrencyGroups returns the concurrency groups of the repository which have runs or jobs not done yet
+func FindActiveConcurrencyGroups(ctx context.Context, repoID int64) ([]*ConcurrencyGroup, error) {
+	groups := make(map[string]*ConcurrencyGroup)
+	getGroup := func(name string, isJobLevel bool) *ConcurrencyGroup {
+		key := name
+		if isJobLevel {
+			key = "job:" + name
+		}
+		if groups[key] == nil {
+			groups[key] = &ConcurrencyGroup{Name: name, IsJobLevel: isJobLevel}
+		}
+		return groups[key]
+	}
+
+	runs, err := db.Find[ActionRun](ctx, FindRunOptions{RepoID: repoID, Status: activeStatuses, ListOptions: db.ListOptionsAll})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+	for _, run := range runs {
+		if run.ConcurrencyGroup == "" {
+			continue
+		}
+		group := getGroup(run.ConcurrencyGroup, false)
+		if run.Status == StatusBlocked && run.Started == 0 {
+			group.Queued = append(group.Queued, run.ID)
+		} else {
+			group.InProgress = append(group.InProgress, run.ID)
+		}
+	}
+
+	jobs, err := db.Find[ActionRunJob](ctx, FindRunJobOptions{RepoID: repoID, Statuses: activeStatuses, ListOptions: db.ListOptionsAll})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	for _, job := range jobs {
+		if job.ConcurrencyGroup == "" {
+			continue
+		}
+		group := getGroup(job.ConcurrencyGroup, true)
+		if job.Status == StatusBlocked {
+			group.Queued = append(group.Queued, job.RunID)
+		} else {
+			group.InProgress = append(group.InProgress, job.RunID)
+		}
+	}
+
+	ret := make([]*ConcurrencyGroup, 0, len(groups))
+	for _, group := range groups {
+		ret = append(ret, group)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+		return !ret[i].IsJobLevel
+	})
+	return ret, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// run 793 is running and holds the group, run 794 waits for it
+	_, err := db.GetEngine(t.Context()).ID(793).Cols("concurrency_group").Update(&ActionRun{ConcurrencyGroup: "deploy"})
+	require.NoError(t, err)
+	_, err = db.GetEngine(t.Context()).ID(794).Cols("concurrency_group", "status", "started").Update(&ActionRun{ConcurrencyGroup: "deploy", Status: StatusBlocked})
+	require.NoError(t, err)
+	// job 196 of run 793 is waiting for a runner
+	_, err = db.GetEngine(t.Context()).ID(196).Cols("concurrency_group").Update(&ActionRunJob{ConcurrencyGroup: "db"})
+	require.NoError(t, err)
+
+	run793 := unittest.AssertExistsAndLoadBean(t, &ActionRun{ID: 793})
+	run794 := unittest.AssertExistsAndLoadBean(t, &ActionRun{ID: 794})
+
+	blocked, err := IsRunConcurrencyBlocked(t.Context(), run793)
+	require.NoError(t, err)
+	assert.False(t, blocked)
+	blocked, err = IsRunConcurrencyBlocked(t.Context(), run794)
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	blocked, err = IsRunConcurrencyBlocked(t.Context(), &ActionRun{RepoID: 4, ConcurrencyGroup: "deploy"})
+	require.NoError(t, err)
+	assert.True(t, blocked)
+
+	busy, err := IsJobConcurrencyGroupBusy(t.Context(), 4, "db", 0)
+	require.NoError(t, err)
+	assert.True(t, busy)
+	busy, err = IsJobConcurrencyGroupBusy(t.Context(), 4, "db", 196)
+	require.NoError(t, err)
+	assert.False(t, busy)
+
+	groups, err := FindActiveConcurrencyGroups(t.Context(), 4)
+	require.NoError(t, err)
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, &ConcurrencyGroup{Name: "db", IsJobLevel: true, InProgress: []int64{793}}, groups[0])
+		assert.Equal(t, &ConcurrencyGroup{Name: "deploy", InProgress: []int64{793}, Queued: []int64{794}}, groups[1])
+	}
+
+	// only the queued run is cancelled
+	jobs, err := CancelPendingConcurrentRuns(t.Context(), 4, "deploy")
+	require.NoError(t, err)
+	for _, job := range jobs {
+		assert.EqualValues(t, 794, job.RunID)
+	}
+	run793 = unittest.AssertExistsAndLoadBean(t, &ActionRun{ID: 793})
+	assert.Equal(t, StatusRunning, run793.Status)
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionEnvironment is a deployment environment of a repository, e.g. staging or production.
+// The jobs referencing the environment with `environment:` can use its secrets,
+// and they don't start until the protection rules of the environment are satisfied.
+type ActionEnvironment struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name      string `xorm:"VARCHAR(255) NOT NULL"`
+	LowerName string `xorm:"UNIQUE(repo_name) VARCHAR(255) NOT NULL"`
+	// WaitTimer is the number of minutes a job waits before it starts
+	WaitTimer int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ReviewerIDs and ReviewerTeamIDs are the users and teams who can approve the jobs, one approval is required if any is set
+	ReviewerIDs     []int64 `xorm:"JSON TEXT"`
+	ReviewerTeamIDs []int64 `xorm:"JSON TEXT"`
+	// PreventSelfReview keeps the user who triggered the run from approving its jobs
+	PreventSelfReview bool `xorm:"NOT NULL DEFAULT false"`
+	// DeploymentBranches are the glob patterns of the branch and tag names which can deploy to the environment,
+	// every ref can deploy if it's empty
+	DeploymentBranches []string           `xorm:"JSON TEXT"`
+	CreatedUnix        timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix        timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// MaxEnvironmentWaitTimer is the longest wait timer of an environment in minutes, 30 days like GitHub
+const MaxEnvironmentWaitTimer = 43200
+
+// DeploymentReviewState is the decision of a reviewer of an environment
+type DeploymentReviewState string
+
+const (
+	DeploymentReviewApproved DeploymentReviewState = "approved"
+	DeploymentReviewRejected DeploymentReviewState = "rejected"
+)
+
+// ActionDeploymentReview is the approval or rejection of the jobs of a run which deploy to an environment
+type ActionDeploymentReview struct {
+	ID            int64                 `xorm:"pk autoincr"`
+	RepoID        int64                 `xorm:"INDEX NOT NULL"`
+	RunID         int64                 `xorm:"INDEX(run_environment) NOT NULL"`
+	EnvironmentID int64                 `xorm:"INDEX(run_environment) NOT NULL"`
+	ReviewerID    int64                 `xorm:"NOT NULL"`
+	State         DeploymentReviewState `xorm:"VARCHAR(20) NOT NULL"`
+	Comment       string                `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp    `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+	db.RegisterModel(new(ActionDeploymentReview))
+}
+
+// NeedReview returns whether a reviewer has to approve the jobs deploying to the environment
+func (env *ActionEnvironment) NeedReview() bool {
+	return len(env.ReviewerIDs) > 0 || len(env.ReviewerTeamIDs) > 0
+}
+
+// IsProtected returns whether the environment has any protection rule
+func (env *ActionEnvironment) IsProtected() bool {
+	return env.NeedReview() || env.WaitTimer > 0 || len(env.DeploymentBranches) > 0
+}
+
+// CanDeployRef returns whether the runs of the git ref can deploy to the environment.
+// The patterns match the names of branches and tags, so the runs of pull requests can't deploy if there are patterns.
+func (env *ActionEnvironment) CanDeployRef(ref string) bool {
+	if len(env.DeploymentBranches) == 0 {
+		return true
+	}
+	refName := git.RefName(ref)
+	var name string
+	switch {
+	case refName.IsBranch():
+		name = refName.BranchName()
+	case refName.IsTag():
+		name = refName.TagName()
+	default:
+		return false
+	}
+	for _, pattern := range env.DeploymentBranches {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			g = glob.MustCompile(glob.QuoteMeta(pattern), '/')
+		}
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindEnvironmentsOptions are the options to find the environments of a repository
+type FindEnvironmentsOptions struct {
+	db.ListOptions
+	RepoID    int64
+	LowerName string
+}
+
+func (opts FindEnvironmentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.LowerName != "" {
+		cond = cond.And(builder.Eq{"lower_name": opts.LowerName})
+	}
+	return cond
+}
+
+func (opts FindEnvironmentsOptions) ToOrders() string {
+	return "`lower_name` ASC"
+}
+
+// GetEnvironmentByName returns the environment of the repository, the names are case-insensitive
+func GetEnvironmentByName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env := &ActionEnvironment{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "lower_name": strings.ToLower(name)}).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment %q doesn't exist", name)
+	}
+	return env, nil
+}
+
+// GetEnvironmentByID returns the environment of the repository
+func GetEnvironmentByID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	env := &ActionEnvironment{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "id": id}).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment with id %d doesn't exist", id)
+	}
+	return env, nil
+}
+
+// CreateOrUpdateEnvironment saves the protection rules of the environment, it's created if it doesn't exist
+func CreateOrUpdateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	env.LowerName = strings.ToLower(env.Name)
+	if env.WaitTimer < 0 || env.WaitTimer > MaxEnvironmentWaitTimer {
+		return util.NewInvalidArgumentErrorf("wait timer must be between 0 and %d minutes", MaxEnvironmentWaitTimer)
+	}
+	for _, pattern := range env.DeploymentBranches {
+		if strings.TrimSpace(pattern) == "" {
+			return util.NewInvalidArgumentErrorf("deployment branch patterns can't be empty")
+		}
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetEnvironmentByName(ctx, env.RepoID, env.Name)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+		if existing == nil {
+			return db.Insert(ctx, env)
+		}
+		env.ID = existing.ID
+		env.CreatedUnix = existing.CreatedUnix
+		_, err = db.GetEngine(ctx).ID(env.ID).AllCols().Update(env)
+		return err
+	})
+}
+
+// DeleteEnvironment deletes the environment and its reviews, its secrets must be deleted by the caller
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("environment_id = ?", env.ID).Delete(new(ActionDeploymentReview)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
+
+// FindDeploymentReviews returns the reviews of the jobs of the run, from the oldest
+func FindDeploymentReviews(ctx context.Context, runID int64) ([]*ActionDeploymentReview, error) {
+	reviews := make([]*ActionDeploymentReview, 0, 2)
+	return reviews, db.GetEngine(ctx).Where("run_id = ?", runID).Asc("id").Find(&reviews)
+}
+
+// GetDeploymentReviewState returns the latest decision for the jobs of the run deploying to the environment,
+// it's empty if nobody has reviewed them yet
+func GetDeploymentReviewState(ctx context.Context, runID, environmentID int64) (DeploymentReviewState, error) {
+	review := &ActionDeploymentReview{}
+	has, err := db.GetEngine(ctx).Where("run_id = ? AND environment_id = ?", runID, environmentID).Desc("id").Get(review)
+	if err != nil || !has {
+		return "", err
+	}
+	return review.State, nil
+}
+
+// DeleteDeploymentReviewsOfRun deletes the reviews of the jobs of the run, e.g. when the jobs are rerun
+func DeleteDeploymentReviewsOfRun(ctx context.Context, runID int64) error {
+	_, err := db.GetEngine(ctx).Where("run_id = ?", runID).Delete(new(ActionDeploymentReview))
+	return err
+}
+
+// InsertDeploymentReview records the decision of a reviewer
+func InsertDeploymentReview(ctx context.Context, review *ActionDeploymentReview) error {
+	if review.State != DeploymentReviewApproved && review.State != DeploymentReviewRejected {
+		return util.NewInvalidArgumentErrorf("invalid review state %q", review.State)
+	}
+	return db.Insert(ctx, review)
+}
+
+// CheckEnvironmentProtection returns the status a job deploying to an environment can move to when its needs are done:
+// StatusWaiting if it can be picked by a runner, StatusBlocked if it waits for a review or the wait timer,
+// and StatusFailure if its ref can't deploy to the environment or it has been rejected.
+// The wait timer starts when the job is checked for the first time, job.WaitTimerUntil is set and should be saved by the caller.
+func CheckEnvironmentProtection(ctx context.Context, run *ActionRun, job *ActionRunJob) (Status, error) {
+	if job.Environment == "" {
+		return StatusWaiting, nil
+	}
+	env, err := GetEnvironmentByName(ctx, job.RepoID, job.Environment)
+	if errors.Is(err, util.ErrNotExist) {
+		return StatusWaiting, nil
+	} else if err != nil {
+		return StatusUnknown, err
+	}
+	if !env.CanDeployRef(run.Ref) {
+		return StatusFailure, nil
+	}
+	status := StatusWaiting
+	if env.NeedReview() {
+		state, err := GetDeploymentReviewState(ctx, run.ID, env.ID)
+		if err != nil {
+			return StatusUnknown, err
+		}
+		switch state {
+		case DeploymentReviewRejected:
+			return StatusFailure, nil
+		case DeploymentReviewApproved:
+		default:
+			status = StatusBlocked
+		}
+	}
+	if env.WaitTimer > 0 {
+		now := timeutil.TimeStampNow()
+		if job.WaitTimerUntil == 0 {
+			job.WaitTimerUntil = now.Add(env.WaitTimer * 60)
+		}
+		if now < job.WaitTimerUntil {
+			status = StatusBlocked
+		}
+	}
+	return status, nil
+}
+
+// FindRunsWithElapsedWaitTimers returns the ids of the runs having blocked jobs whose wait timer has elapsed
+func FindRunsWithElapsedWaitTimers(ctx context.Context) (container.Set[int64], error) {
+	var runIDs []int64
+	if err := db.GetEngine(ctx).Table("action_run_job").Cols("run_id").Where(builder.Eq{"status": StatusBlocked}.
+		And(builder.Gt{"wait_timer_until": 0}).And(builder.Lte{"wait_timer_until": timeutil.TimeStampNow()})).
+		Find(&runIDs); err != nil {
+		return nil, err
+	}
+	return container.SetOf(runIDs...), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentCanDeployRef(t *testing.T) {
+	env := &ActionEnvironment{}
+	assert.True(t, env.CanDeployRef("refs/pull/1/head"))
+
+	env.DeploymentBranches = []string{"main", "release/*", "v*"}
+	assert.True(t, env.CanDeployRef("refs/heads/main"))
+	assert.True(t, env.CanDeployRef("refs/heads/release/1.0"))
+	assert.False(t, env.CanDeployRef("refs/heads/release/1.0/fix"))
+	assert.True(t, env.CanDeployRef("refs/tags/v1.0.0"))
+	assert.False(t, env.CanDeployRef("refs/heads/feature"))
+	assert.False(t, env.CanDeployRef("refs/pull/1/head"))
+}
+
+func TestCreateOrUpdateEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	env := &ActionEnvironment{RepoID: 4, Name: "Production", WaitTimer: 5}
+	require.NoError(t, CreateOrUpdateEnvironment(t.Context(), env))
+
+	// the names are case-insensitive
+	updated := &ActionEnvironment{RepoID: 4, Name: "production", ReviewerIDs: []int64{2}}
+	require.NoError(t, CreateOrUpdateEnvironment(t.Context(), updated))
+	assert.Equal(t, env.ID, updated.ID)
+	got, err := GetEnvironmentByName(t.Context(), 4, "PRODUCTION")
+	require.NoError(t, err)
+	assert.Zero(t, got.WaitTimer)
+	assert.Equal(t, []int64{2}, got.ReviewerIDs)
+
+	err = CreateOrUpdateEnvironment(t.Context(), &ActionEnvironment{RepoID: 4, Name: "staging", WaitTimer: MaxEnvironmentWaitTimer + 1})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, DeleteEnvironment(t.Context(), got))
+	_, err = GetEnvironmentByName(t.Context(), 4, "production")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
+
+func TestCheckEnvironmentProtection(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	run := unittest.AssertExistsAndLoadBean(t, &ActionRun{ID: 791})
+	job := &ActionRunJob{RepoID: run.RepoID, RunID: run.ID, Environment: "production"}
+
+	// the jobs can deploy to unknown environments
+	status, err := CheckEnvironmentProtection(t.Context(), run, job)
+	require.NoError(t, err)
+	assert.Equal(t, StatusWaiting, status)
+
+	env := &ActionEnvironment{RepoID: run.RepoID, Name: "production", DeploymentBranches: []string{"release/*"}}
+	require.NoError(t, CreateOrUpdateEnvironment(t.Context(), env))
+	status, err = CheckEnvironmentProtection(t.Context(), run, job)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailure, status)
+
+	env.DeploymentBranches = []string{"master"}
+	env.ReviewerIDs = []int64{2}
+	env.WaitTimer = 10
+	require.NoError(t, CreateOrUpdateEnvironment(t.Context(), env))
+	status, err = CheckEnvironmentProtection(t.Context(), run, job)
+	require.NoError(t, err)
+	assert.Equal(t, StatusBlocked, status)
+	// the wait timer has started
+	assert.Greater(t, job.WaitTimerUntil, timeutil.TimeStampNow())
+
+	require.NoError(t, InsertDeploymentReview(t.Context(), &ActionDeploymentReview{RepoID: run.RepoID, RunID: run.ID, EnvironmentID: env.ID, ReviewerID: 2, State: DeploymentReviewApproved}))
+	status, err = CheckEnvironmentProtection(t.Context(), run, job)
+	require.NoError(t, err)
+	assert.Equal(t, StatusBlocked, status, "the job waits for the timer after the approval")
+
+	job.WaitTimerUntil = timeutil.TimeStampNow() - 1
+	status, err = CheckEnvironmentProtection(t.Context(), run, job)
+	require.NoError(t, err)
+	assert.Equal(t, StatusWaiting, status)
+
+	require.NoError(t, InsertDeploymentReview(t.Context(), &ActionDeploymentReview{RepoID: run.RepoID, RunID: run.ID, EnvironmentID: env.ID, ReviewerID: 2, State: DeploymentReviewRejected}))
+	status, err = CheckEnvironmentProtection(t.Context(), run, job)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailure, status)
+}
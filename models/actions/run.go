@@ -48,6 +48,10 @@ type ActionRun struct {
 	EventPayload      string                       `xorm:"LONGTEXT"`
 	TriggerEvent      string                       // the trigger event defined in the `on` configuration of the triggered workflow
 	Status            Status                       `xorm:"index"`
+	ErrorMessage      string                       `xorm:"TEXT"`  // why the run failed before any of its jobs could start, e.g. an invalid reusable workflow
+	ConcurrencyGroup  string                       `xorm:"index"` // the evaluated workflow level `concurrency.group`
+	ConcurrencyCancel bool                         // the evaluated workflow level `concurrency.cancel-in-progress`
+	JobEnvironments   map[string]string            `xorm:"-"`                 // the deployment environments of the jobs by job id, read by the caller before the run is inserted
 	Version           int                          `xorm:"version default 0"` // Status could be updated concomitantly, so an optimistic lock is needed
 	// Started and Stopped is used for recording last run time, if rerun happened, they will be reset to 0
 	Started timeutil.TimeStamp
@@ -226,7 +230,12 @@ func CancelPreviousJobs(ctx context.Context, repoID int64, ref, workflowID strin
 		return nil, nil
 	}
 
-	cancelledJobs := make([]*ActionRunJob, 0, total)
+	return cancelJobsOfRuns(ctx, runs)
+}
+
+// cancelJobsOfRuns cancels all the jobs of the runs which are not done yet
+func cancelJobsOfRuns(ctx context.Context, runs []*ActionRun) ([]*ActionRunJob, error) {
+	cancelledJobs := make([]*ActionRunJob, 0, len(runs))
 
 	// Iterate over each found run and cancel its associated jobs.
 	for _, run := range runs {
@@ -240,43 +249,50 @@ func CancelPreviousJobs(ctx context.Context, repoID int64, ref, workflowID strin
 
 		// Iterate over each job and attempt to cancel it.
 		for _, job := range jobs {
-			// Skip jobs that are already in a terminal state (completed, cancelled, etc.).
-			status := job.Status
-			if status.IsDone() {
-				continue
+			cancelled, err := cancelJob(ctx, job)
+			if err != nil {
+				return cancelledJobs, err
+			}
+			if cancelled {
+				cancelledJobs = append(cancelledJobs, job)
 			}
+		}
+	}
 
-			// If the job has no associated task (probably an error), set its status to 'Cancelled' and stop it.
-			if job.TaskID == 0 {
-				job.Status = StatusCancelled
-				job.Stopped = timeutil.TimeStampNow()
+	// Return nil to indicate successful cancellation of all running and waiting jobs.
+	return cancelledJobs, nil
+}
 
-				// Update the job's status and stopped time in the database.
-				n, err := UpdateRunJob(ctx, job, builder.Eq{"task_id": 0}, "status", "stopped")
-				if err != nil {
-					return cancelledJobs, err
-				}
+// cancelJob cancels the job if it isn't done yet
+func cancelJob(ctx context.Context, job *ActionRunJob) (bool, error) {
+	// Skip jobs that are already in a terminal state (completed, cancelled, etc.).
+	if job.Status.IsDone() {
+		return false, nil
+	}
 
-				// If the update affected 0 rows, it means the job has changed in the meantime, so we need to try again.
-				if n == 0 {
-					return cancelledJobs, errors.New("job has changed, try again")
-				}
+	// If the job has no associated task (probably an error), set its status to 'Cancelled' and stop it.
+	if job.TaskID == 0 {
+		job.Status = StatusCancelled
+		job.Stopped = timeutil.TimeStampNow()
 
-				cancelledJobs = append(cancelledJobs, job)
-				// Continue with the next job.
-				continue
-			}
+		// Update the job's status and stopped time in the database.
+		n, err := UpdateRunJob(ctx, job, builder.Eq{"task_id": 0}, "status", "stopped")
+		if err != nil {
+			return false, err
+		}
 
-			// If the job has an associated task, try to stop the task, effectively cancelling the job.
-			if err := StopTask(ctx, job.TaskID, StatusCancelled); err != nil {
-				return cancelledJobs, err
-			}
-			cancelledJobs = append(cancelledJobs, job)
+		// If the update affected 0 rows, it means the job has changed in the meantime, so we need to try again.
+		if n == 0 {
+			return false, errors.New("job has changed, try again")
 		}
+		return true, nil
 	}
 
-	// Return nil to indicate successful cancellation of all running and waiting jobs.
-	return cancelledJobs, nil
+	// If the job has an associated task, try to stop the task, effectively cancelling the job.
+	if err := StopTask(ctx, job.TaskID, StatusCancelled); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // InsertRun inserts a run
@@ -290,6 +306,17 @@ func InsertRun(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWork
 		run.Index = index
 		run.Title = util.EllipsisDisplayString(run.Title, 255)
 
+		// the run has to wait until the other runs in its concurrency group are done
+		var runBlocked bool
+		if !run.Status.IsDone() {
+			if runBlocked, err = IsRunConcurrencyBlocked(ctx, run); err != nil {
+				return err
+			}
+		}
+		if runBlocked {
+			run.Status = StatusBlocked
+		}
+
 		if err := db.Insert(ctx, run); err != nil {
 			return err
 		}
@@ -311,18 +338,29 @@ func InsertRun(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWork
 		for _, v := range jobs {
 			id, job := v.Job()
 			needs := job.Needs()
+			// the job level concurrency has been evaluated by the caller, the raw values are replaced by the results
+			var concurrencyGroup string
+			var concurrencyCancel bool
+			if job.RawConcurrency != nil {
+				concurrencyGroup, concurrencyCancel = job.RawConcurrency.Group, job.RawConcurrency.CancelInProgress == "true"
+			}
 			if err := v.SetJob(id, job.EraseNeeds()); err != nil {
 				return err
 			}
 			payload, _ := v.Marshal()
 			status := StatusWaiting
-			if len(needs) > 0 || run.NeedApproval {
+			if run.Status.IsDone() {
+				// the run has failed before it started, its jobs share its status
+				status = run.Status
+			} else if len(needs) > 0 || run.NeedApproval || runBlocked {
+				status = StatusBlocked
+			} else if busy, err := IsJobConcurrencyGroupBusy(ctx, run.RepoID, concurrencyGroup, 0); err != nil {
+				return err
+			} else if busy {
 				status = StatusBlocked
-			} else {
-				hasWaiting = true
 			}
 			job.Name = util.EllipsisDisplayString(job.Name, 255)
-			runJobs = append(runJobs, &ActionRunJob{
+			runJob := &ActionRunJob{
 				RunID:             run.ID,
 				RepoID:            run.RepoID,
 				OwnerID:           run.OwnerID,
@@ -334,10 +372,22 @@ func InsertRun(ctx context.Context, run *ActionRun, jobs []*jobparser.SingleWork
 				Needs:             needs,
 				RunsOn:            job.RunsOn(),
 				Status:            status,
-			})
-		}
-		if err := db.Insert(ctx, runJobs); err != nil {
-			return err
+				ConcurrencyGroup:  concurrencyGroup,
+				ConcurrencyCancel: concurrencyCancel,
+				Environment:       run.JobEnvironments[id],
+			}
+			if status == StatusWaiting {
+				// the job deploying to a protected environment waits for the approval and the wait timer
+				if runJob.Status, err = CheckEnvironmentProtection(ctx, run, runJob); err != nil {
+					return err
+				}
+				hasWaiting = hasWaiting || runJob.Status == StatusWaiting
+			}
+			// insert the jobs one by one, so the following jobs of the same concurrency group see this one
+			if err := db.Insert(ctx, runJob); err != nil {
+				return err
+			}
+			runJobs = append(runJobs, runJob)
 		}
 
 		// if there is a job in the waiting status, increase tasks version.
@@ -14,6 +14,8 @@ import (
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 
+	"github.com/nektos/act/pkg/jobparser"
+	"gopkg.in/yaml.v3"
 	"xorm.io/builder"
 )
 
@@ -35,10 +37,16 @@ type ActionRunJob struct {
 	RunsOn            []string `xorm:"JSON TEXT"`
 	TaskID            int64    // the latest task of the job
 	Status            Status   `xorm:"index"`
+	ConcurrencyGroup  string   `xorm:"index"` // the evaluated job level `concurrency.group`
+	ConcurrencyCancel bool     // the evaluated job level `concurrency.cancel-in-progress`
 	Started           timeutil.TimeStamp
 	Stopped           timeutil.TimeStamp
 	Created           timeutil.TimeStamp `xorm:"created"`
 	Updated           timeutil.TimeStamp `xorm:"updated index"`
+
+	Environment string `xorm:"VARCHAR(255)"` // the name of the deployment environment of the job
+	// WaitTimerUntil is the time the wait timer of the environment elapses, it's set when the needs of the job are done
+	WaitTimerUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
 }
 
 func init() {
@@ -49,6 +57,54 @@ func (job *ActionRunJob) Duration() time.Duration {
 	return calculateDuration(job.Started, job.Stopped, job.Status)
 }
 
+// Matrix returns the values of the matrix combination the job runs with, it's nil if the job isn't expanded from a matrix
+func (job *ActionRunJob) Matrix() (map[string]any, error) {
+	workflow := &jobparser.SingleWorkflow{}
+	if err := yaml.Unmarshal(job.WorkflowPayload, workflow); err != nil {
+		return nil, err
+	}
+	_, workflowJob := workflow.Job()
+	if workflowJob == nil || workflowJob.Strategy.RawMatrix.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	// the matrix of an expanded job has a single value for every key
+	var values map[string][]any
+	if err := workflowJob.Strategy.RawMatrix.Decode(&values); err != nil {
+		return nil, err
+	}
+	matrix := make(map[string]any, len(values))
+	for k, v := range values {
+		if len(v) > 0 {
+			matrix[k] = v[0]
+		}
+	}
+	return matrix, nil
+}
+
+// CanRequestIDToken checks whether the job may request an OIDC ID token, like GitHub it needs the "id-token: write"
+// permission which is granted by the job or, if the job has no permissions, by the workflow
+func (job *ActionRunJob) CanRequestIDToken() (bool, error) {
+	workflow := &jobparser.SingleWorkflow{}
+	if err := yaml.Unmarshal(job.WorkflowPayload, workflow); err != nil {
+		return false, err
+	}
+	permissions := workflow.RawPermissions
+	if _, workflowJob := workflow.Job(); workflowJob != nil && !workflowJob.RawPermissions.IsZero() {
+		permissions = workflowJob.RawPermissions
+	}
+	switch permissions.Kind {
+	case yaml.ScalarNode:
+		return permissions.Value == "write-all", nil
+	case yaml.MappingNode:
+		var scopes map[string]string
+		if err := permissions.Decode(&scopes); err != nil {
+			return false, err
+		}
+		return scopes["id-token"] == "write", nil
+	}
+	return false, nil
+}
+
 func (job *ActionRunJob) LoadRun(ctx context.Context) error {
 	if job.Run == nil {
 		run, err := GetRunByRepoAndID(ctx, job.RepoID, job.RunID)
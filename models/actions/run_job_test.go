@@ -0,0 +1,48 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/nektos/act/pkg/jobparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionRunJobMatrix(t *testing.T) {
+	workflows, err := jobparser.Parse([]byte(`
+name: test
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        os: [linux, windows]
+        go: ["1.25"]
+    steps:
+      - run: echo
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`))
+	require.NoError(t, err)
+	require.Len(t, workflows, 3)
+
+	var matrices []map[string]any
+	for _, w := range workflows {
+		payload, err := w.Marshal()
+		require.NoError(t, err)
+		matrix, err := (&ActionRunJob{WorkflowPayload: payload}).Matrix()
+		require.NoError(t, err)
+		matrices = append(matrices, matrix)
+	}
+	assert.ElementsMatch(t, []map[string]any{
+		{"os": "linux", "go": "1.25"},
+		{"os": "windows", "go": "1.25"},
+		nil,
+	}, matrices)
+}
@@ -73,6 +73,8 @@ type FindRunOptions struct {
 	Approved      bool // not util.OptionalBool, it works only when it's true
 	Status        []Status
 	CommitSHA     string
+	// ConcurrencyGroup filters the runs of a workflow level concurrency group
+	ConcurrencyGroup string
 }
 
 func (opts FindRunOptions) ToConds() builder.Cond {
@@ -101,6 +103,9 @@ func (opts FindRunOptions) ToConds() builder.Cond {
 	if opts.CommitSHA != "" {
 		cond = cond.And(builder.Eq{"`action_run`.commit_sha": opts.CommitSHA})
 	}
+	if opts.ConcurrencyGroup != "" {
+		cond = cond.And(builder.Eq{"`action_run`.concurrency_group": opts.ConcurrencyGroup})
+	}
 	return cond
 }
 
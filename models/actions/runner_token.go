@@ -12,6 +12,8 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
 )
 
 // ActionRunnerToken represents runner tokens
@@ -35,6 +37,12 @@ type ActionRunnerToken struct {
 	Repo     *repo_model.Repository `xorm:"-"`
 	IsActive bool                   // true means it can be used
 
+	// A just-in-time token registers a single ephemeral runner with the given labels, it's deactivated once used.
+	// It doesn't invalidate the other tokens of the same owner or repository.
+	IsJIT   bool               `xorm:"is_jit NOT NULL DEFAULT false"`
+	Labels  []string           `xorm:"TEXT"`
+	Expires timeutil.TimeStamp `xorm:"index"`
+
 	Created timeutil.TimeStamp `xorm:"created"`
 	Updated timeutil.TimeStamp `xorm:"updated"`
 	Deleted timeutil.TimeStamp `xorm:"deleted"`
@@ -85,7 +93,7 @@ func NewRunnerTokenWithValue(ctx context.Context, ownerID, repoID int64, token s
 	}
 
 	return runnerToken, db.WithTx(ctx, func(ctx context.Context) error {
-		if _, err := db.GetEngine(ctx).Where("owner_id =? AND repo_id = ?", ownerID, repoID).Cols("is_active").Update(&ActionRunnerToken{
+		if _, err := db.GetEngine(ctx).Where("owner_id =? AND repo_id = ? AND is_jit = ?", ownerID, repoID, false).Cols("is_active").Update(&ActionRunnerToken{
 			IsActive: false,
 		}); err != nil {
 			return err
@@ -113,7 +121,7 @@ func GetLatestRunnerToken(ctx context.Context, ownerID, repoID int64) (*ActionRu
 	}
 
 	var runnerToken ActionRunnerToken
-	has, err := db.GetEngine(ctx).Where("owner_id=? AND repo_id=?", ownerID, repoID).
+	has, err := db.GetEngine(ctx).Where("owner_id=? AND repo_id=? AND is_jit=?", ownerID, repoID, false).
 		OrderBy("id DESC").Get(&runnerToken)
 	if err != nil {
 		return nil, err
@@ -122,3 +130,49 @@ func GetLatestRunnerToken(ctx context.Context, ownerID, repoID int64) (*ActionRu
 	}
 	return &runnerToken, nil
 }
+
+// NewRunnerJITToken creates a single-use token which registers an ephemeral runner with the labels until it expires
+func NewRunnerJITToken(ctx context.Context, ownerID, repoID int64, labels []string, expires timeutil.TimeStamp) (*ActionRunnerToken, error) {
+	if ownerID != 0 && repoID != 0 {
+		ownerID = 0
+	}
+	token, err := util.CryptoRandomString(40)
+	if err != nil {
+		return nil, err
+	}
+	runnerToken := &ActionRunnerToken{
+		OwnerID:  ownerID,
+		RepoID:   repoID,
+		IsActive: true,
+		Token:    token,
+		IsJIT:    true,
+		Labels:   labels,
+		Expires:  expires,
+	}
+	return runnerToken, db.Insert(ctx, runnerToken)
+}
+
+// IsExpired returns whether a just-in-time token can't be used anymore because it has expired
+func (t *ActionRunnerToken) IsExpired() bool {
+	return t.IsJIT && t.Expires > 0 && t.Expires <= timeutil.TimeStampNow()
+}
+
+// UseRunnerJITToken deactivates a just-in-time token, it fails if the token has been used concurrently
+func UseRunnerJITToken(ctx context.Context, t *ActionRunnerToken) error {
+	affected, err := db.GetEngine(ctx).Where("id = ? AND is_jit = ? AND is_active = ?", t.ID, true, true).
+		Cols("is_active").Update(&ActionRunnerToken{IsActive: false})
+	if err != nil {
+		return err
+	} else if affected == 0 {
+		return fmt.Errorf("runner token %d has been used: %w", t.ID, util.ErrPermissionDenied)
+	}
+	t.IsActive = false
+	return nil
+}
+
+// DeleteExpiredRunnerJITTokens deletes the just-in-time tokens which have been used or have expired
+func DeleteExpiredRunnerJITTokens(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Where("is_jit = ?", true).
+		And(builder.Or(builder.Eq{"is_active": false}, builder.Lte{"expires": timeutil.TimeStampNow()})).
+		Unscoped().Delete(new(ActionRunnerToken))
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -37,3 +38,31 @@ func TestUpdateRunnerToken(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedToken, token)
 }
+
+func TestRunnerJITToken(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	latest := unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: 3})
+
+	token, err := NewRunnerJITToken(t.Context(), 1, 0, []string{"ubuntu-latest"}, timeutil.TimeStampNow().Add(60))
+	assert.NoError(t, err)
+	assert.True(t, token.IsActive)
+	assert.False(t, token.IsExpired())
+
+	// just-in-time tokens neither replace nor invalidate the registration token
+	expectedToken, err := GetLatestRunnerToken(t.Context(), 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, latest, expectedToken)
+
+	assert.NoError(t, UseRunnerJITToken(t.Context(), token))
+	assert.False(t, token.IsActive)
+	assert.Error(t, UseRunnerJITToken(t.Context(), token))
+
+	expired, err := NewRunnerJITToken(t.Context(), 1, 0, []string{"ubuntu-latest"}, timeutil.TimeStampNow().Add(-1))
+	assert.NoError(t, err)
+	assert.True(t, expired.IsExpired())
+
+	deleted, err := DeleteExpiredRunnerJITTokens(t.Context())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, deleted)
+	unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: 3})
+}
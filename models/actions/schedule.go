@@ -80,7 +80,7 @@ func CreateScheduleTask(ctx context.Context, rows []*ActionSchedule) error {
 					continue // skip to the next spec if there's an error
 				}
 
-				specRow.Next = timeutil.TimeStamp(schedule.Next(now).Unix())
+				specRow.Next = specRow.NextTime(schedule, now)
 
 				// Insert the new schedule spec row
 				if err = db.Insert(ctx, specRow); err != nil {
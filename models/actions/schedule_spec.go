@@ -5,11 +5,14 @@ package actions
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
 	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
 
 	"github.com/robfig/cron/v3"
@@ -59,6 +62,24 @@ func (s *ActionScheduleSpec) Parse() (cron.Schedule, error) {
 	return specSchedule, nil
 }
 
+// Jitter returns the delay of the spec within [actions] SCHEDULE_JITTER.
+// It's derived from the schedule and the spec, so the specs firing at the same time are spread but each one keeps its delay.
+func (s *ActionScheduleSpec) Jitter() time.Duration {
+	jitter := setting.Actions.ScheduleJitter.Truncate(time.Second)
+	if jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%d/%d/%s", s.RepoID, s.ScheduleID, s.Spec)
+	return time.Duration(h.Sum64()%uint64(jitter/time.Second)) * time.Second
+}
+
+// NextTime returns the first time after t the spec fires, including its jitter
+func (s *ActionScheduleSpec) NextTime(schedule cron.Schedule, t time.Time) timeutil.TimeStamp {
+	jitter := s.Jitter()
+	return timeutil.TimeStamp(schedule.Next(t.Add(-jitter)).Add(jitter).Unix())
+}
+
 func init() {
 	db.RegisterModel(new(ActionScheduleSpec))
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
 
 	"github.com/stretchr/testify/assert"
@@ -67,3 +68,22 @@ func TestActionScheduleSpec_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestActionScheduleSpec_NextTime(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2024-07-31T23:59:00Z")
+	require.NoError(t, err)
+
+	s := &ActionScheduleSpec{RepoID: 1, ScheduleID: 2, Spec: "0 0 * * *"}
+	schedule, err := s.Parse()
+	require.NoError(t, err)
+	assert.Equal(t, "2024-08-01T00:00:00Z", s.NextTime(schedule, now).AsTime().UTC().Format(time.RFC3339))
+
+	defer test.MockVariableValue(&setting.Actions.ScheduleJitter, 30*time.Minute)()
+	jitter := s.Jitter()
+	assert.Less(t, jitter, 30*time.Minute)
+	assert.Equal(t, jitter, s.Jitter())
+	next := s.NextTime(schedule, now).AsTime().UTC()
+	assert.Equal(t, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC).Add(jitter), next)
+	// the next run after a delayed run is on the next day with the same delay
+	assert.Equal(t, next.Add(24*time.Hour), s.NextTime(schedule, next.Add(time.Minute)).AsTime().UTC())
+}
@@ -8,6 +8,9 @@ import (
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
@@ -216,6 +219,62 @@ func GetRunningTaskByToken(ctx context.Context, token string) (*ActionTask, erro
 	return nil, errNotExist
 }
 
+// runnerJobsCond returns the condition of the jobs which can be picked by a runner of the owner or repository
+func runnerJobsCond(ownerID, repoID int64) builder.Cond {
+	jobCond := builder.NewCond()
+	if repoID != 0 {
+		jobCond = builder.Eq{"repo_id": repoID}
+	} else if ownerID != 0 {
+		jobCond = builder.In("repo_id", builder.Select("`repository`.id").From("repository").
+			Join("INNER", "repo_unit", "`repository`.id = `repo_unit`.repo_id").
+			Where(builder.Eq{"`repository`.owner_id": ownerID, "`repo_unit`.type": unit.TypeActions}))
+	}
+	if jobCond.IsValid() {
+		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
+	}
+	return jobCond
+}
+
+// PendingJobs is the number of jobs with the same `runs-on` labels waiting for a runner
+type PendingJobs struct {
+	Labels []string
+	Count  int64
+}
+
+// CountPendingJobs counts the jobs waiting for a runner of the owner or repository by their labels,
+// autoscalers use it to decide how many runners with which labels have to be started.
+func CountPendingJobs(ctx context.Context, ownerID, repoID int64) ([]*PendingJobs, error) {
+	var jobs []*ActionRunJob
+	if err := db.GetEngine(ctx).Cols("id", "runs_on").Where("task_id=? AND status=?", 0, StatusWaiting).
+		And(runnerJobsCond(ownerID, repoID)).Find(&jobs); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]*PendingJobs)
+	for _, job := range jobs {
+		labels := slices.Clone(job.RunsOn)
+		sort.Strings(labels)
+		labels = slices.Compact(labels)
+		key := strings.Join(labels, "\n")
+		if counts[key] == nil {
+			counts[key] = &PendingJobs{Labels: labels}
+		}
+		counts[key].Count++
+	}
+
+	ret := make([]*PendingJobs, 0, len(counts))
+	for _, c := range counts {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return strings.Join(ret[i].Labels, ",") < strings.Join(ret[j].Labels, ",")
+	})
+	return ret, nil
+}
+
 func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask, bool, error) {
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
@@ -225,20 +284,8 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 
 	e := db.GetEngine(ctx)
 
-	jobCond := builder.NewCond()
-	if runner.RepoID != 0 {
-		jobCond = builder.Eq{"repo_id": runner.RepoID}
-	} else if runner.OwnerID != 0 {
-		jobCond = builder.In("repo_id", builder.Select("`repository`.id").From("repository").
-			Join("INNER", "repo_unit", "`repository`.id = `repo_unit`.repo_id").
-			Where(builder.Eq{"`repository`.owner_id": runner.OwnerID, "`repo_unit`.type": unit.TypeActions}))
-	}
-	if jobCond.IsValid() {
-		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
-	}
-
 	var jobs []*ActionRunJob
-	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).Asc("updated", "id").Find(&jobs); err != nil {
+	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(runnerJobsCond(runner.OwnerID, runner.RepoID)).Asc("updated", "id").Find(&jobs); err != nil {
 		return nil, false, err
 	}
 
@@ -203,10 +203,10 @@ func (actions ActionList) LoadIssues(ctx context.Context) error {
 	return nil
 }
 
-// GetFeeds returns actions according to the provided options
-func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, int64, error) {
+// feedsCondition returns the condition of the actions of the provided options
+func feedsCondition(ctx context.Context, opts GetFeedsOptions) (builder.Cond, error) {
 	if opts.RequestedUser == nil && opts.RequestedTeam == nil && opts.RequestedRepo == nil {
-		return nil, 0, errors.New("need at least one of these filters: RequestedUser, RequestedTeam, RequestedRepo")
+		return nil, errors.New("need at least one of these filters: RequestedUser, RequestedTeam, RequestedRepo")
 	}
 
 	var err error
@@ -232,9 +232,18 @@ func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, int64, err
 	} else {
 		cond, err = ActivityQueryCondition(ctx, opts)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 	}
+	return cond, nil
+}
+
+// GetFeeds returns actions according to the provided options
+func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, int64, error) {
+	cond, err := feedsCondition(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	actions := make([]*Action, 0, opts.PageSize)
 	var count int64
@@ -283,6 +292,33 @@ func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, int64, err
 	return actions, count, nil
 }
 
+// GetFeedsAfter returns at most limit actions created after the action afterID according to the provided options,
+// the oldest first. The IDs of the actions are the cursors of the streams of activities.
+func GetFeedsAfter(ctx context.Context, opts GetFeedsOptions, afterID int64, limit int) (ActionList, error) {
+	cond, err := feedsCondition(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]*Action, 0, limit)
+	if err := db.GetEngine(ctx).Where(cond.And(builder.Gt{"`action`.id": afterID})).
+		Asc("`action`.id").Limit(limit).Find(&actions); err != nil {
+		return nil, fmt.Errorf("Find: %w", err)
+	}
+
+	if err := ActionList(actions).LoadAttributes(ctx); err != nil {
+		return nil, fmt.Errorf("LoadAttributes: %w", err)
+	}
+	return actions, nil
+}
+
+// GetLatestActionID returns the ID of the latest action, or 0 if there isn't any action
+func GetLatestActionID(ctx context.Context) (int64, error) {
+	var id int64
+	_, err := db.GetEngine(ctx).Table("action").Select("COALESCE(MAX(id), 0)").Get(&id)
+	return id, err
+}
+
 func CountUserFeeds(ctx context.Context, userID int64) (int64, error) {
 	return db.GetEngine(ctx).Where("user_id = ?", userID).
 		And("is_deleted = ?", false).
@@ -0,0 +1,67 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// EmailDigestItem is a change of an issue or a pull request waiting to be sent to a user in the digest of the notification emails
+type EmailDigestItem struct {
+	ID      int64 `xorm:"pk autoincr"`
+	UserID  int64 `xorm:"INDEX NOT NULL"`
+	RepoID  int64 `xorm:"INDEX NOT NULL"`
+	IssueID int64 `xorm:"NOT NULL"`
+	DoerID  int64 `xorm:"NOT NULL DEFAULT 0"`
+	// Action describes the change, like "comment" or "close"
+	Action      string             `xorm:"VARCHAR(32) NOT NULL"`
+	Link        string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(EmailDigestItem))
+}
+
+// AddEmailDigestItems adds changes to the digests of users
+func AddEmailDigestItems(ctx context.Context, items []*EmailDigestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return db.Insert(ctx, items)
+}
+
+// GetEmailDigestUsers returns the users with pending digest items and the creation time of their oldest item
+func GetEmailDigestUsers(ctx context.Context) (map[int64]timeutil.TimeStamp, error) {
+	rows := make([]struct {
+		UserID     int64
+		OldestUnix timeutil.TimeStamp
+	}, 0, 10)
+	if err := db.GetEngine(ctx).Table("email_digest_item").
+		Select("user_id, MIN(created_unix) AS oldest_unix").
+		GroupBy("user_id").
+		Find(&rows); err != nil {
+		return nil, err
+	}
+	users := make(map[int64]timeutil.TimeStamp, len(rows))
+	for _, row := range rows {
+		users[row.UserID] = row.OldestUnix
+	}
+	return users, nil
+}
+
+// GetEmailDigestItems returns the pending digest items of a user, oldest first
+func GetEmailDigestItems(ctx context.Context, userID int64) ([]*EmailDigestItem, error) {
+	items := make([]*EmailDigestItem, 0, 10)
+	return items, db.GetEngine(ctx).Where("user_id = ?", userID).OrderBy("id").Find(&items)
+}
+
+// DeleteEmailDigestItems deletes the digest items of a user up to an item once they have been sent
+func DeleteEmailDigestItems(ctx context.Context, userID, maxID int64) error {
+	_, err := db.GetEngine(ctx).Where("user_id = ? AND id <= ?", userID, maxID).Delete(new(EmailDigestItem))
+	return err
+}
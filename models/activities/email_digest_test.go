@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities_test
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailDigestItems(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	require.NoError(t, activities_model.AddEmailDigestItems(t.Context(), []*activities_model.EmailDigestItem{
+		{UserID: 2, RepoID: 1, IssueID: 1, DoerID: 1, Action: "comment"},
+		{UserID: 2, RepoID: 1, IssueID: 2, DoerID: 1, Action: "close"},
+		{UserID: 4, RepoID: 1, IssueID: 1, DoerID: 1, Action: "comment"},
+	}))
+
+	users, err := activities_model.GetEmailDigestUsers(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.NotZero(t, users[2])
+	assert.NotZero(t, users[4])
+
+	items, err := activities_model.GetEmailDigestItems(t.Context(), 2)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "comment", items[0].Action)
+	assert.Equal(t, "close", items[1].Action)
+
+	// only the sent items are deleted
+	require.NoError(t, activities_model.AddEmailDigestItems(t.Context(), []*activities_model.EmailDigestItem{{UserID: 2, RepoID: 1, IssueID: 3, Action: "reopen"}}))
+	require.NoError(t, activities_model.DeleteEmailDigestItems(t.Context(), 2, items[1].ID))
+	items, err = activities_model.GetEmailDigestItems(t.Context(), 2)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "reopen", items[0].Action)
+	unittest.AssertExistsAndLoadBean(t, &activities_model.EmailDigestItem{UserID: 4})
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// FederatedFollower is a remote ActivityPub actor following a local user or repository
+type FederatedFollower struct {
+	ID int64 `xorm:"pk autoincr"`
+	// UserID is the followed user, or 0 when a repository is followed
+	UserID int64 `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	// RepoID is the followed repository, or 0 when a user is followed
+	RepoID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	ActorIRI string `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+	Inbox    string `xorm:"TEXT NOT NULL"`
+	// SharedInbox is the inbox of the instance of the actor, used to deliver an activity once to all its followers there
+	SharedInbox string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(FederatedFollower))
+}
+
+// DeliveryInbox returns the inbox activities are delivered to for the follower
+func (f *FederatedFollower) DeliveryInbox() string {
+	if f.SharedInbox != "" {
+		return f.SharedInbox
+	}
+	return f.Inbox
+}
+
+// AddFederatedFollower adds a follower of a user or a repository, the inboxes of an actor already following are updated
+func AddFederatedFollower(ctx context.Context, f *FederatedFollower) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing := new(FederatedFollower)
+		has, err := db.GetEngine(ctx).Where("user_id = ? AND repo_id = ? AND actor_iri = ?", f.UserID, f.RepoID, f.ActorIRI).Get(existing)
+		if err != nil {
+			return err
+		}
+		if has {
+			f.ID, f.CreatedUnix = existing.ID, existing.CreatedUnix
+			_, err = db.GetEngine(ctx).ID(f.ID).Cols("inbox", "shared_inbox").Update(f)
+			return err
+		}
+		return db.Insert(ctx, f)
+	})
+}
+
+// RemoveFederatedFollower removes an actor from the followers of a user or a repository
+func RemoveFederatedFollower(ctx context.Context, userID, repoID int64, actorIRI string) error {
+	_, err := db.GetEngine(ctx).Where("user_id = ? AND repo_id = ? AND actor_iri = ?", userID, repoID, actorIRI).Delete(new(FederatedFollower))
+	return err
+}
+
+// FindFederatedFollowersOptions represents the options to find the followers of a user or a repository
+type FindFederatedFollowersOptions struct {
+	db.ListOptions
+	UserID int64
+	RepoID int64
+}
+
+func (opts FindFederatedFollowersOptions) ToConds() builder.Cond {
+	return builder.Eq{"user_id": opts.UserID, "repo_id": opts.RepoID}
+}
+
+func (opts FindFederatedFollowersOptions) ToOrders() string {
+	return "id"
+}
+
+// GetFederatedFollowerInboxes returns the distinct inboxes to deliver the activities of a user or a repository to
+func GetFederatedFollowerInboxes(ctx context.Context, userID, repoID int64) ([]string, error) {
+	followers := make([]*FederatedFollower, 0, 10)
+	if err := db.GetEngine(ctx).Where("user_id = ? AND repo_id = ?", userID, repoID).OrderBy("id").Find(&followers); err != nil {
+		return nil, err
+	}
+
+	inboxes := make([]string, 0, len(followers))
+	seen := make(map[string]bool, len(followers))
+	for _, f := range followers {
+		inbox := f.DeliveryInbox()
+		if !seen[inbox] {
+			seen[inbox] = true
+			inboxes = append(inboxes, inbox)
+		}
+	}
+	return inboxes, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities_test
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederatedFollowers(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	alice := &activities_model.FederatedFollower{RepoID: 1, ActorIRI: "https://a.example.com/users/alice", Inbox: "https://a.example.com/users/alice/inbox", SharedInbox: "https://a.example.com/inbox"}
+	require.NoError(t, activities_model.AddFederatedFollower(ctx, alice))
+	bob := &activities_model.FederatedFollower{RepoID: 1, ActorIRI: "https://a.example.com/users/bob", Inbox: "https://a.example.com/users/bob/inbox", SharedInbox: "https://a.example.com/inbox"}
+	require.NoError(t, activities_model.AddFederatedFollower(ctx, bob))
+	carol := &activities_model.FederatedFollower{RepoID: 1, ActorIRI: "https://b.example.com/carol", Inbox: "https://b.example.com/carol/inbox"}
+	require.NoError(t, activities_model.AddFederatedFollower(ctx, carol))
+	// the same actor following a user
+	require.NoError(t, activities_model.AddFederatedFollower(ctx, &activities_model.FederatedFollower{UserID: 1, ActorIRI: carol.ActorIRI, Inbox: carol.Inbox}))
+
+	// following again updates the inboxes
+	again := &activities_model.FederatedFollower{RepoID: 1, ActorIRI: carol.ActorIRI, Inbox: "https://b.example.com/carol/inbox2"}
+	require.NoError(t, activities_model.AddFederatedFollower(ctx, again))
+	assert.Equal(t, carol.ID, again.ID)
+
+	inboxes, err := activities_model.GetFederatedFollowerInboxes(ctx, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.example.com/inbox", "https://b.example.com/carol/inbox2"}, inboxes)
+
+	followers, total, err := db.FindAndCount[activities_model.FederatedFollower](ctx, activities_model.FindFederatedFollowersOptions{RepoID: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+	assert.Len(t, followers, 3)
+
+	require.NoError(t, activities_model.RemoveFederatedFollower(ctx, 0, 1, alice.ActorIRI))
+	inboxes, err = activities_model.GetFederatedFollowerInboxes(ctx, 0, 1)
+	require.NoError(t, err)
+	assert.Len(t, inboxes, 2)
+
+	inboxes, err = activities_model.GetFederatedFollowerInboxes(ctx, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{carol.Inbox}, inboxes)
+}
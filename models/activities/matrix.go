@@ -0,0 +1,101 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/matrix"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// MatrixEvent is a type of event users may opt in to receive Matrix direct messages for
+type MatrixEvent string
+
+const (
+	MatrixEventMention       MatrixEvent = "mention"
+	MatrixEventReviewRequest MatrixEvent = "review_request"
+)
+
+// MatrixEvents are all the types of events of Matrix notifications
+var MatrixEvents = []MatrixEvent{MatrixEventMention, MatrixEventReviewRequest}
+
+// MatrixSettings are the Matrix notification settings of a user
+type MatrixSettings struct {
+	// MatrixID is the Matrix user ID the direct messages are sent to, like @alice:example.org
+	MatrixID string
+	// RoomID is the room of the direct messages, created with the first message
+	RoomID string
+	Events []MatrixEvent
+}
+
+// GetMatrixSettings returns the Matrix notification settings of a user
+func GetMatrixSettings(ctx context.Context, userID int64) (*MatrixSettings, error) {
+	settings, err := user_model.GetSettings(ctx, userID, []string{user_model.SettingsKeyMatrixID, user_model.SettingsKeyMatrixRoomID, user_model.SettingsKeyMatrixEvents})
+	if err != nil {
+		return nil, err
+	}
+	value := func(key string) string {
+		if s, ok := settings[key]; ok {
+			return s.SettingValue
+		}
+		return ""
+	}
+
+	s := &MatrixSettings{
+		MatrixID: value(user_model.SettingsKeyMatrixID),
+		RoomID:   value(user_model.SettingsKeyMatrixRoomID),
+		Events:   make([]MatrixEvent, 0, len(MatrixEvents)),
+	}
+	for e := range strings.SplitSeq(value(user_model.SettingsKeyMatrixEvents), ",") {
+		if slices.Contains(MatrixEvents, MatrixEvent(e)) {
+			s.Events = append(s.Events, MatrixEvent(e))
+		}
+	}
+	return s, nil
+}
+
+// UpdateMatrixSettings sets the Matrix user ID of a user and the types of events the user opts in to receive Matrix notifications for,
+// the room of the direct messages is forgotten when the Matrix user ID changes
+func UpdateMatrixSettings(ctx context.Context, userID int64, matrixID string, events []MatrixEvent) error {
+	if matrixID != "" && !matrix.IsValidUserID(matrixID) {
+		return util.NewInvalidArgumentErrorf("invalid Matrix user ID %q", matrixID)
+	}
+	values := make([]string, 0, len(events))
+	for _, e := range events {
+		if !slices.Contains(MatrixEvents, e) {
+			return util.NewInvalidArgumentErrorf("unknown Matrix event %q", e)
+		}
+	}
+	for _, e := range MatrixEvents {
+		if slices.Contains(events, e) {
+			values = append(values, string(e))
+		}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		old, err := user_model.GetUserSetting(ctx, userID, user_model.SettingsKeyMatrixID)
+		if err != nil {
+			return err
+		}
+		if old != matrixID {
+			if err := user_model.DeleteUserSetting(ctx, userID, user_model.SettingsKeyMatrixRoomID); err != nil {
+				return err
+			}
+			if err := user_model.SetUserSetting(ctx, userID, user_model.SettingsKeyMatrixID, matrixID); err != nil {
+				return err
+			}
+		}
+		return user_model.SetUserSetting(ctx, userID, user_model.SettingsKeyMatrixEvents, strings.Join(values, ","))
+	})
+}
+
+// SetMatrixRoomID sets the room of the direct messages of the Matrix notifications of a user
+func SetMatrixRoomID(ctx context.Context, userID int64, roomID string) error {
+	return user_model.SetUserSetting(ctx, userID, user_model.SettingsKeyMatrixRoomID, roomID)
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities_test
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixSettings(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	settings, err := activities_model.GetMatrixSettings(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, settings.MatrixID)
+	assert.Empty(t, settings.Events)
+
+	require.NoError(t, activities_model.UpdateMatrixSettings(t.Context(), 2, "@user2:example.org", []activities_model.MatrixEvent{activities_model.MatrixEventReviewRequest, activities_model.MatrixEventMention}))
+	require.NoError(t, activities_model.SetMatrixRoomID(t.Context(), 2, "!room:example.org"))
+	settings, err = activities_model.GetMatrixSettings(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, &activities_model.MatrixSettings{
+		MatrixID: "@user2:example.org",
+		RoomID:   "!room:example.org",
+		Events:   []activities_model.MatrixEvent{activities_model.MatrixEventMention, activities_model.MatrixEventReviewRequest},
+	}, settings)
+
+	// the room is kept while the Matrix user ID doesn't change
+	require.NoError(t, activities_model.UpdateMatrixSettings(t.Context(), 2, "@user2:example.org", nil))
+	settings, err = activities_model.GetMatrixSettings(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "!room:example.org", settings.RoomID)
+	assert.Empty(t, settings.Events)
+
+	require.NoError(t, activities_model.UpdateMatrixSettings(t.Context(), 2, "@other:example.org", nil))
+	settings, err = activities_model.GetMatrixSettings(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, settings.RoomID)
+
+	err = activities_model.UpdateMatrixSettings(t.Context(), 2, "user2", nil)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = activities_model.UpdateMatrixSettings(t.Context(), 2, "", []activities_model.MatrixEvent{"ci_failure"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -101,7 +101,11 @@ func createOrUpdateIssueNotifications(ctx context.Context, issueID, commentID, n
 		}
 		toNotify.AddMultiple(issueWatches...)
 		if !(issue.IsPull && issues_model.HasWorkInProgressPrefix(issue.Title)) {
-			repoWatches, err := repo_model.GetRepoWatchersIDs(ctx, issue.RepoID)
+			event := repo_model.WatchEventIssues
+			if issue.IsPull {
+				event = repo_model.WatchEventPullRequests
+			}
+			repoWatches, err := repo_model.GetRepoWatchersIDs(ctx, issue.RepoID, event)
 			if err != nil {
 				return err
 			}
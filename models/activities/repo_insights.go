@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// DurationPercentiles represents the distribution of durations in seconds
+type DurationPercentiles struct {
+	Count int64
+	P50   int64
+	P75   int64
+	P90   int64
+	P95   int64
+}
+
+// newDurationPercentiles returns the percentiles of the durations by the nearest-rank method
+func newDurationPercentiles(durations []int64) DurationPercentiles {
+	if len(durations) == 0 {
+		return DurationPercentiles{}
+	}
+	slices.Sort(durations)
+	percentile := func(p int) int64 {
+		rank := (p*len(durations) + 99) / 100
+		return durations[max(rank, 1)-1]
+	}
+	return DurationPercentiles{
+		Count: int64(len(durations)),
+		P50:   percentile(50),
+		P75:   percentile(75),
+		P90:   percentile(90),
+		P95:   percentile(95),
+	}
+}
+
+// PullRequestInsights represents the pull request metrics of repositories in a period
+type PullRequestInsights struct {
+	Opened             int64
+	Merged             int64
+	ClosedWithoutMerge int64
+	// LeadTime is the time from opening to merging of the pull requests merged in the period
+	LeadTime DurationPercentiles
+	// ReviewTurnaround is the time from opening to the first review of the pull requests first reviewed in the period
+	ReviewTurnaround DurationPercentiles
+}
+
+// IssueInsights represents the issue metrics of repositories in a period
+type IssueInsights struct {
+	Opened int64
+	Closed int64
+	// OpenedAndClosed is the number of the issues opened in the period which are closed
+	OpenedAndClosed int64
+	// TimeToClose is the time from opening to closing of the issues closed in the period
+	TimeToClose DurationPercentiles
+}
+
+// GetPullRequestInsights returns the pull request metrics of the repositories matching the condition on "issue.repo_id"
+func GetPullRequestInsights(ctx context.Context, repoCond builder.Cond, since, before timeutil.TimeStamp) (*PullRequestInsights, error) {
+	insights := &PullRequestInsights{}
+	pullCond := builder.And(repoCond, builder.Eq{"issue.is_pull": true})
+
+	var err error
+	insights.Opened, err = db.GetEngine(ctx).Table("issue").
+		Where(pullCond).And("issue.created_unix >= ? AND issue.created_unix < ?", since, before).Count()
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []struct {
+		CreatedUnix timeutil.TimeStamp
+		MergedUnix  timeutil.TimeStamp
+	}
+	if err := db.GetEngine(ctx).Table("pull_request").
+		Join("INNER", "issue", "pull_request.issue_id = issue.id").
+		Where(pullCond).And("pull_request.has_merged = ?", true).
+		And("pull_request.merged_unix >= ? AND pull_request.merged_unix < ?", since, before).
+		Select("issue.created_unix, pull_request.merged_unix").
+		Find(&merged); err != nil {
+		return nil, err
+	}
+	leadTimes := make([]int64, 0, len(merged))
+	for _, pr := range merged {
+		leadTimes = append(leadTimes, max(0, int64(pr.MergedUnix-pr.CreatedUnix)))
+	}
+	insights.Merged = int64(len(merged))
+	insights.LeadTime = newDurationPercentiles(leadTimes)
+
+	insights.ClosedWithoutMerge, err = db.GetEngine(ctx).Table("pull_request").
+		Join("INNER", "issue", "pull_request.issue_id = issue.id").
+		Where(pullCond).And("pull_request.has_merged = ?", false).And("issue.is_closed = ?", true).
+		And("issue.closed_unix >= ? AND issue.closed_unix < ?", since, before).Count()
+	if err != nil {
+		return nil, err
+	}
+
+	// the reviews of the poster and the requests of reviews aren't reviews of other people
+	var reviewed []struct {
+		CreatedUnix     timeutil.TimeStamp
+		FirstReviewUnix timeutil.TimeStamp
+	}
+	if err := db.GetEngine(ctx).Table("issue").
+		Join("INNER", "review", "review.issue_id = issue.id").
+		Where(pullCond).
+		And(builder.In("review.type", issues_model.ReviewTypeApprove, issues_model.ReviewTypeComment, issues_model.ReviewTypeReject)).
+		And("review.reviewer_id <> issue.poster_id").
+		GroupBy("issue.id, issue.created_unix").
+		Having(fmt.Sprintf("MIN(review.created_unix) >= %d AND MIN(review.created_unix) < %d", since, before)).
+		Select("issue.created_unix, MIN(review.created_unix) AS first_review_unix").
+		Find(&reviewed); err != nil {
+		return nil, err
+	}
+	turnarounds := make([]int64, 0, len(reviewed))
+	for _, pr := range reviewed {
+		turnarounds = append(turnarounds, max(0, int64(pr.FirstReviewUnix-pr.CreatedUnix)))
+	}
+	insights.ReviewTurnaround = newDurationPercentiles(turnarounds)
+	return insights, nil
+}
+
+// GetIssueInsights returns the issue metrics of the repositories matching the condition on "issue.repo_id"
+func GetIssueInsights(ctx context.Context, repoCond builder.Cond, since, before timeutil.TimeStamp) (*IssueInsights, error) {
+	insights := &IssueInsights{}
+	issueCond := builder.And(repoCond, builder.Eq{"issue.is_pull": false})
+	openedCond := builder.And(issueCond, builder.Gte{"issue.created_unix": since}, builder.Lt{"issue.created_unix": before})
+
+	var err error
+	if insights.Opened, err = db.GetEngine(ctx).Table("issue").Where(openedCond).Count(); err != nil {
+		return nil, err
+	}
+	if insights.OpenedAndClosed, err = db.GetEngine(ctx).Table("issue").Where(openedCond).And("issue.is_closed = ?", true).Count(); err != nil {
+		return nil, err
+	}
+
+	var closed []struct {
+		CreatedUnix timeutil.TimeStamp
+		ClosedUnix  timeutil.TimeStamp
+	}
+	if err := db.GetEngine(ctx).Table("issue").
+		Where(issueCond).And("issue.is_closed = ?", true).
+		And("issue.closed_unix >= ? AND issue.closed_unix < ?", since, before).
+		Select("issue.created_unix, issue.closed_unix").
+		Find(&closed); err != nil {
+		return nil, err
+	}
+	durations := make([]int64, 0, len(closed))
+	for _, issue := range closed {
+		durations = append(durations, max(0, int64(issue.ClosedUnix-issue.CreatedUnix)))
+	}
+	insights.Closed = int64(len(closed))
+	insights.TimeToClose = newDurationPercentiles(durations)
+	return insights, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/builder"
+)
+
+func TestNewDurationPercentiles(t *testing.T) {
+	assert.Equal(t, DurationPercentiles{}, newDurationPercentiles(nil))
+	assert.Equal(t, DurationPercentiles{Count: 1, P50: 7, P75: 7, P90: 7, P95: 7}, newDurationPercentiles([]int64{7}))
+
+	durations := make([]int64, 0, 20)
+	for i := int64(20); i > 0; i-- {
+		durations = append(durations, i*10)
+	}
+	assert.Equal(t, DurationPercentiles{Count: 20, P50: 100, P75: 150, P90: 180, P95: 190}, newDurationPercentiles(durations))
+}
+
+func TestGetInsights(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the issues are created in a period without fixtures
+	const start = timeutil.TimeStamp(2000000000)
+	newIssue := func(index int64, isPull bool, created, closed timeutil.TimeStamp) *issues_model.Issue {
+		issue := &issues_model.Issue{RepoID: 1, Index: 1000 + index, PosterID: 2, IsPull: isPull, CreatedUnix: created, IsClosed: closed > 0, ClosedUnix: closed}
+		_, err := db.GetEngine(t.Context()).NoAutoTime().Insert(issue)
+		require.NoError(t, err)
+		return issue
+	}
+	newPull := func(issue *issues_model.Issue, merged timeutil.TimeStamp) {
+		_, err := db.GetEngine(t.Context()).NoAutoTime().Insert(&issues_model.PullRequest{IssueID: issue.ID, BaseRepoID: 1, HeadRepoID: 1, HasMerged: merged > 0, MergedUnix: merged})
+		require.NoError(t, err)
+	}
+	newReview := func(issue *issues_model.Issue, reviewerID int64, reviewType issues_model.ReviewType, created timeutil.TimeStamp) {
+		_, err := db.GetEngine(t.Context()).NoAutoTime().Insert(&issues_model.Review{IssueID: issue.ID, ReviewerID: reviewerID, Type: reviewType, CreatedUnix: created})
+		require.NoError(t, err)
+	}
+
+	pullA := newIssue(1, true, start, start+3600)
+	newPull(pullA, start+3600)
+	newReview(pullA, 1, issues_model.ReviewTypeApprove, start+600)
+	pullB := newIssue(2, true, start+100, start+7300)
+	newPull(pullB, start+7300)
+	newReview(pullB, 2, issues_model.ReviewTypeComment, start+200) // the review of the poster isn't counted
+	newReview(pullB, 4, issues_model.ReviewTypeRequest, start+300) // the request of a review isn't counted
+	newReview(pullB, 4, issues_model.ReviewTypeComment, start+1100)
+	pullC := newIssue(3, true, start+200, start+500)
+	newPull(pullC, 0)
+	newIssue(4, false, start, start+86400)
+	newIssue(5, false, start+10, 0)
+
+	cond := builder.Eq{"issue.repo_id": 1}
+	pulls, err := GetPullRequestInsights(t.Context(), cond, start, start+10*86400)
+	assert.NoError(t, err)
+	assert.Equal(t, &PullRequestInsights{
+		Opened:             3,
+		Merged:             2,
+		ClosedWithoutMerge: 1,
+		LeadTime:           DurationPercentiles{Count: 2, P50: 3600, P75: 7200, P90: 7200, P95: 7200},
+		ReviewTurnaround:   DurationPercentiles{Count: 2, P50: 600, P75: 1000, P90: 1000, P95: 1000},
+	}, pulls)
+
+	issues, err := GetIssueInsights(t.Context(), cond, start, start+10*86400)
+	assert.NoError(t, err)
+	assert.Equal(t, &IssueInsights{
+		Opened:          2,
+		Closed:          1,
+		OpenedAndClosed: 1,
+		TimeToClose:     DurationPercentiles{Count: 1, P50: 86400, P75: 86400, P90: 86400, P95: 86400},
+	}, issues)
+
+	// the issues outside of the period aren't counted
+	issues, err = GetIssueInsights(t.Context(), cond, start+100, start+1000)
+	assert.NoError(t, err)
+	assert.Equal(t, &IssueInsights{}, issues)
+}
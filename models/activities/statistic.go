@@ -6,6 +6,7 @@ package activities
 import (
 	"context"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	"code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
@@ -34,9 +35,11 @@ type Statistic struct {
 		Milestone, Label, HookTask,
 		Team, UpdateTask, Project,
 		ProjectColumn, Attachment,
-		Branches, Tags, CommitStatus int64
+		Branches, Tags, CommitStatus,
+		ActionCache, ActionCacheSize, ActionCacheHit int64
 		IssueByLabel      []IssueByLabelCount
 		IssueByRepository []IssueByRepositoryCount
+		StorageTier       []StorageTierCount
 	}
 }
 
@@ -53,6 +56,14 @@ type IssueByRepositoryCount struct {
 	Repository string
 }
 
+// StorageTierCount contains the number and the size of the objects in a tier of a storage
+type StorageTierCount struct {
+	Storage string
+	Tier    string
+	Count   int64
+	Size    int64
+}
+
 // GetStatistic returns the database statistics
 func GetStatistic(ctx context.Context) (stats Statistic) {
 	e := db.GetEngine(ctx)
@@ -131,5 +142,35 @@ func GetStatistic(ctx context.Context) (stats Statistic) {
 	stats.Counter.Attachment, _ = e.Count(new(repo_model.Attachment))
 	stats.Counter.Project, _ = e.Count(new(project_model.Project))
 	stats.Counter.ProjectColumn, _ = e.Count(new(project_model.Column))
+
+	if setting.LFS.ColdTier != nil {
+		if usages, err := git_model.GetLFSStorageTierUsage(ctx); err == nil {
+			stats.Counter.StorageTier = append(stats.Counter.StorageTier, storageTierCounts("lfs", usages)...)
+		}
+	}
+	if setting.RepoArchive.ColdTier != nil {
+		if usages, err := repo_model.GetRepoArchiveStorageTierUsage(ctx); err == nil {
+			stats.Counter.StorageTier = append(stats.Counter.StorageTier, storageTierCounts("repo-archive", usages)...)
+		}
+	}
+
+	if setting.Actions.CacheEnabled {
+		if usage, err := actions_model.GetCacheUsage(ctx, 0); err == nil {
+			stats.Counter.ActionCache, stats.Counter.ActionCacheSize, stats.Counter.ActionCacheHit = usage.Count, usage.Size, usage.Hits
+		}
+	}
 	return stats
 }
+
+// storageTierCounts returns the counts of both tiers of the storage, including the empty ones
+func storageTierCounts(storage string, usages []*repo_model.StorageTierUsage) []StorageTierCount {
+	counts := []StorageTierCount{{Storage: storage, Tier: "hot"}, {Storage: storage, Tier: "cold"}}
+	for _, usage := range usages {
+		count := &counts[0]
+		if usage.IsCold {
+			count = &counts[1]
+		}
+		count.Count, count.Size = usage.Count, usage.Size
+	}
+	return counts
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// WebPushEvent is a type of event users may opt in to receive web push notifications for
+type WebPushEvent string
+
+const (
+	WebPushEventMention       WebPushEvent = "mention"
+	WebPushEventReviewRequest WebPushEvent = "review_request"
+	WebPushEventCIFailure     WebPushEvent = "ci_failure"
+)
+
+// WebPushEvents are all the types of events of web push notifications
+var WebPushEvents = []WebPushEvent{WebPushEventMention, WebPushEventReviewRequest, WebPushEventCIFailure}
+
+// maxWebPushSubscriptions is the maximum number of browsers a user may subscribe
+const maxWebPushSubscriptions = 20
+
+// WebPushSubscription is the push subscription of a browser of a user
+type WebPushSubscription struct {
+	ID     int64 `xorm:"pk autoincr"`
+	UserID int64 `xorm:"INDEX NOT NULL"`
+	// Endpoint is the URL of the push service to send the notifications to
+	Endpoint string `xorm:"TEXT NOT NULL"`
+	// P256dh and Auth are the base64 encoded public key and authentication secret to encrypt the notifications
+	P256dh      string             `xorm:"NOT NULL"`
+	Auth        string             `xorm:"NOT NULL"`
+	UserAgent   string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(WebPushSubscription))
+}
+
+// SaveWebPushSubscription creates a subscription of a user, or renews the keys of an existing subscription with the same endpoint
+func SaveWebPushSubscription(ctx context.Context, sub *WebPushSubscription) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing := new(WebPushSubscription)
+		has, err := db.GetEngine(ctx).Where("user_id = ? AND endpoint = ?", sub.UserID, sub.Endpoint).Get(existing)
+		if err != nil {
+			return err
+		}
+		if has {
+			sub.ID, sub.CreatedUnix = existing.ID, existing.CreatedUnix
+			_, err = db.GetEngine(ctx).ID(sub.ID).Cols("p256dh", "auth", "user_agent").Update(sub)
+			return err
+		}
+
+		count, err := db.GetEngine(ctx).Where("user_id = ?", sub.UserID).Count(new(WebPushSubscription))
+		if err != nil {
+			return err
+		} else if count >= maxWebPushSubscriptions {
+			return util.NewInvalidArgumentErrorf("a user can't subscribe more than %d browsers", maxWebPushSubscriptions)
+		}
+		return db.Insert(ctx, sub)
+	})
+}
+
+// GetWebPushSubscriptions returns the subscriptions of a user
+func GetWebPushSubscriptions(ctx context.Context, userID int64) ([]*WebPushSubscription, error) {
+	subs := make([]*WebPushSubscription, 0, 2)
+	return subs, db.GetEngine(ctx).Where("user_id = ?", userID).OrderBy("id").Find(&subs)
+}
+
+// GetWebPushSubscriptionByID returns a subscription of a user
+func GetWebPushSubscriptionByID(ctx context.Context, userID, id int64) (*WebPushSubscription, error) {
+	sub := new(WebPushSubscription)
+	has, err := db.GetEngine(ctx).Where("id = ? AND user_id = ?", id, userID).Get(sub)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("web push subscription %d does not exist", id)
+	}
+	return sub, nil
+}
+
+// DeleteWebPushSubscription deletes a subscription
+func DeleteWebPushSubscription(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[WebPushSubscription](ctx, id)
+	return err
+}
+
+// GetWebPushEvents returns the types of events a user opted in to receive web push notifications for
+func GetWebPushEvents(ctx context.Context, userID int64) ([]WebPushEvent, error) {
+	value, err := user_model.GetUserSetting(ctx, userID, user_model.SettingsKeyWebPushEvents)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]WebPushEvent, 0, len(WebPushEvents))
+	for e := range strings.SplitSeq(value, ",") {
+		if slices.Contains(WebPushEvents, WebPushEvent(e)) {
+			events = append(events, WebPushEvent(e))
+		}
+	}
+	return events, nil
+}
+
+// SetWebPushEvents sets the types of events a user opts in to receive web push notifications for
+func SetWebPushEvents(ctx context.Context, userID int64, events []WebPushEvent) error {
+	values := make([]string, 0, len(events))
+	for _, e := range WebPushEvents {
+		if slices.Contains(events, e) {
+			values = append(values, string(e))
+		}
+	}
+	for _, e := range events {
+		if !slices.Contains(WebPushEvents, e) {
+			return util.NewInvalidArgumentErrorf("unknown web push event %q", e)
+		}
+	}
+	return user_model.SetUserSetting(ctx, userID, user_model.SettingsKeyWebPushEvents, strings.Join(values, ","))
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activities_test
+
+import (
+	"fmt"
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebPushSubscriptions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	sub := &activities_model.WebPushSubscription{UserID: 2, Endpoint: "https://push.example.com/1", P256dh: "key", Auth: "auth"}
+	require.NoError(t, activities_model.SaveWebPushSubscription(t.Context(), sub))
+
+	// subscribing the same endpoint again renews its keys
+	renewed := &activities_model.WebPushSubscription{UserID: 2, Endpoint: "https://push.example.com/1", P256dh: "key2", Auth: "auth2"}
+	require.NoError(t, activities_model.SaveWebPushSubscription(t.Context(), renewed))
+	assert.Equal(t, sub.ID, renewed.ID)
+
+	subs, err := activities_model.GetWebPushSubscriptions(t.Context(), 2)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, "key2", subs[0].P256dh)
+	assert.Equal(t, "auth2", subs[0].Auth)
+
+	_, err = activities_model.GetWebPushSubscriptionByID(t.Context(), 1, sub.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	for i := 2; i <= 20; i++ {
+		require.NoError(t, activities_model.SaveWebPushSubscription(t.Context(), &activities_model.WebPushSubscription{UserID: 2, Endpoint: fmt.Sprintf("https://push.example.com/%d", i)}))
+	}
+	err = activities_model.SaveWebPushSubscription(t.Context(), &activities_model.WebPushSubscription{UserID: 2, Endpoint: "https://push.example.com/21"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, activities_model.DeleteWebPushSubscription(t.Context(), sub.ID))
+	unittest.AssertNotExistsBean(t, &activities_model.WebPushSubscription{ID: sub.ID})
+}
+
+func TestWebPushEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	events, err := activities_model.GetWebPushEvents(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, activities_model.SetWebPushEvents(t.Context(), 2, []activities_model.WebPushEvent{activities_model.WebPushEventCIFailure, activities_model.WebPushEventMention}))
+	events, err = activities_model.GetWebPushEvents(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []activities_model.WebPushEvent{activities_model.WebPushEventMention, activities_model.WebPushEventCIFailure}, events)
+
+	err = activities_model.SetWebPushEvents(t.Context(), 2, []activities_model.WebPushEvent{"unknown"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ApprovalAction is a destructive admin operation which has to be approved by a second administrator
+type ApprovalAction string
+
+const (
+	ApprovalActionDeleteUser       ApprovalAction = "delete_user"
+	ApprovalActionOffboardUser     ApprovalAction = "offboard_user"
+	ApprovalActionAnonymizeUser    ApprovalAction = "anonymize_user"
+	ApprovalActionDeleteOrg        ApprovalAction = "delete_org"
+	ApprovalActionDeleteRepo       ApprovalAction = "delete_repo"
+	ApprovalActionCreateAuthSource ApprovalAction = "create_auth_source"
+	ApprovalActionEditAuthSource   ApprovalAction = "edit_auth_source"
+	ApprovalActionDeleteAuthSource ApprovalAction = "delete_auth_source"
+)
+
+// ApprovalStatus is the state of an approval request
+type ApprovalStatus string
+
+const (
+	// ApprovalStatusPending means the request waits for a second administrator
+	ApprovalStatusPending ApprovalStatus = "pending"
+	// ApprovalStatusApproved means the request has been approved and the operation has been executed
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	// ApprovalStatusRejected means the request has been rejected
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+	// ApprovalStatusExpired means nobody has decided about the request in time
+	ApprovalStatusExpired ApprovalStatus = "expired"
+	// ApprovalStatusFailed means the request has been approved but the operation has failed
+	ApprovalStatusFailed ApprovalStatus = "failed"
+)
+
+// ApprovalRequest is a pending or decided destructive admin operation
+type ApprovalRequest struct {
+	ID          int64            `xorm:"pk autoincr"`
+	Action      ApprovalAction   `xorm:"VARCHAR(50) NOT NULL"`
+	TargetID    int64            `xorm:"NOT NULL"`
+	TargetName  string           `xorm:"NOT NULL"` // the name of the target when the request was created, the target may be renamed or deleted later
+	Payload     string           `xorm:"TEXT"`     // JSON encoded parameters of the operation
+	RequesterID int64            `xorm:"INDEX NOT NULL"`
+	Requester   *user_model.User `xorm:"-"`
+	ApproverID  int64            `xorm:"NOT NULL DEFAULT 0"` // the administrator who has approved or rejected the request
+	Approver    *user_model.User `xorm:"-"`
+	Status      ApprovalStatus   `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	Message     string           `xorm:"TEXT"` // the error of a failed operation
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	DecidedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(ApprovalRequest))
+}
+
+// ErrApprovalRequestNotExist represents an "approval request not exist" error
+var ErrApprovalRequestNotExist = util.NewNotExistErrorf("approval request does not exist")
+
+// IsPending checks whether the request waits for a decision
+func (r *ApprovalRequest) IsPending() bool {
+	return r.Status == ApprovalStatusPending
+}
+
+func loadApprovalRequestUser(ctx context.Context, id int64) (*user_model.User, error) {
+	u, err := user_model.GetUserByID(ctx, id)
+	if user_model.IsErrUserNotExist(err) {
+		return user_model.NewGhostUser(), nil
+	}
+	return u, err
+}
+
+// LoadAttributes loads the requester and the approver of the request
+func (r *ApprovalRequest) LoadAttributes(ctx context.Context) (err error) {
+	if r.Requester == nil {
+		if r.Requester, err = loadApprovalRequestUser(ctx, r.RequesterID); err != nil {
+			return err
+		}
+	}
+	if r.Approver == nil && r.ApproverID > 0 {
+		if r.Approver, err = loadApprovalRequestUser(ctx, r.ApproverID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateApprovalRequest inserts a pending approval request
+func CreateApprovalRequest(ctx context.Context, r *ApprovalRequest) error {
+	r.Status = ApprovalStatusPending
+	return db.Insert(ctx, r)
+}
+
+// GetApprovalRequestByID returns the approval request
+func GetApprovalRequestByID(ctx context.Context, id int64) (*ApprovalRequest, error) {
+	r := &ApprovalRequest{}
+	has, err := db.GetEngine(ctx).ID(id).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrApprovalRequestNotExist
+	}
+	return r, nil
+}
+
+// UpdateApprovalRequestDecision stores the decision about a pending request, it returns false if the request has been
+// decided meanwhile
+func UpdateApprovalRequestDecision(ctx context.Context, r *ApprovalRequest) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(r.ID).Where(builder.Eq{"status": ApprovalStatusPending}).
+		Cols("approver_id", "status", "message", "decided_unix").Update(r)
+	return n > 0, err
+}
+
+// UpdateApprovalRequestResult stores the result of the operation of an approved request
+func UpdateApprovalRequestResult(ctx context.Context, r *ApprovalRequest) error {
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("status", "message").Update(r)
+	return err
+}
+
+// ExpireApprovalRequests marks the pending requests which haven't been decided in time as expired
+func ExpireApprovalRequests(ctx context.Context) error {
+	_, err := db.GetEngine(ctx).
+		Where(builder.Eq{"status": ApprovalStatusPending}.And(builder.Lte{"expires_unix": timeutil.TimeStampNow()})).
+		Cols("status").
+		Update(&ApprovalRequest{Status: ApprovalStatusExpired})
+	return err
+}
+
+// FindApprovalRequestsOptions are the options of finding approval requests
+type FindApprovalRequestsOptions struct {
+	db.ListOptions
+	Status ApprovalStatus
+}
+
+// ToConds implements db.FindOptions
+func (opts FindApprovalRequestsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.Status != "" {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindApprovalRequestsOptions) ToOrders() string {
+	return "id DESC"
+}
@@ -113,6 +113,15 @@ func RegisterTypeConfig(typ Type, exemplar Config) {
 	}
 }
 
+// NewSourceConfig returns an empty config of the type, it's nil if no config is registered for the type
+func NewSourceConfig(typ Type) Config {
+	constructor, ok := registeredConfigs[typ]
+	if !ok {
+		return nil
+	}
+	return constructor()
+}
+
 // Source represents an external way for authorizing users.
 type Source struct {
 	ID              int64 `xorm:"pk autoincr"`
@@ -114,6 +114,8 @@ type LFSMetaObject struct {
 	RepositoryID int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
 	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix  timeutil.TimeStamp `xorm:"INDEX updated"`
+	AccessedUnix timeutil.TimeStamp `xorm:"INDEX"`
+	IsCold       bool               `xorm:"INDEX NOT NULL DEFAULT false"` // the content has been moved to the cold tier of the LFS storage
 }
 
 func init() {
@@ -409,3 +411,43 @@ func MarkLFSMetaObject(ctx context.Context, id int64) error {
 	}
 	return err
 }
+
+// lfsAccessUpdateInterval avoids writing to the database on every download of an LFS object
+const lfsAccessUpdateInterval = 3600
+
+// UpdateLFSObjectAccessed records that the content of the LFS object has been accessed
+func UpdateLFSObjectAccessed(ctx context.Context, oid string) error {
+	now := timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).Where("oid = ?", oid).And("accessed_unix < ?", now-lfsAccessUpdateInterval).
+		Cols("accessed_unix").NoAutoTime().Update(&LFSMetaObject{AccessedUnix: now})
+	return err
+}
+
+// FindLFSObjectsToTransition returns the LFS objects in the given tier which should be moved to the other tier, ordered by oid.
+// A hot object is moved to the cold tier if it hasn't been created or accessed since the cutoff,
+// a cold object is moved back to the hot tier if it has been accessed since the cutoff.
+func FindLFSObjectsToTransition(ctx context.Context, isCold bool, cutoff timeutil.TimeStamp, afterOid string, limit int) ([]lfs.Pointer, error) {
+	sess := db.GetEngine(ctx).Table("lfs_meta_object").Select("oid, size").
+		Where("is_cold = ?", isCold).And("oid > ?", afterOid).
+		GroupBy("oid, size")
+	if isCold {
+		sess.Having(fmt.Sprintf("MAX(accessed_unix) >= %d", cutoff))
+	} else {
+		sess.Having(fmt.Sprintf("MAX(created_unix) < %d AND MAX(accessed_unix) < %d", cutoff, cutoff))
+	}
+	pointers := make([]lfs.Pointer, 0, limit)
+	return pointers, sess.OrderBy("oid ASC").Limit(limit).Find(&pointers)
+}
+
+// SetLFSObjectCold records the tier the content of the LFS object is stored in
+func SetLFSObjectCold(ctx context.Context, oid string, isCold bool) error {
+	_, err := db.GetEngine(ctx).Where("oid = ?", oid).Cols("is_cold").NoAutoTime().Update(&LFSMetaObject{IsCold: isCold})
+	return err
+}
+
+// GetLFSStorageTierUsage returns the usage of the hot and the cold tier of the LFS storage
+func GetLFSStorageTierUsage(ctx context.Context) ([]*repo_model.StorageTierUsage, error) {
+	usages := make([]*repo_model.StorageTierUsage, 0, 2)
+	return usages, db.GetEngine(ctx).SQL("SELECT is_cold, COUNT(*) AS count, SUM(size) AS size FROM " +
+		"(SELECT DISTINCT oid, size, is_cold FROM lfs_meta_object) AS lfs_object GROUP BY is_cold").Find(&usages)
+}
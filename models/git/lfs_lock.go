@@ -18,6 +18,8 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
 )
 
 // LFSLock represents a git lfs lock of repository.
@@ -28,6 +30,8 @@ type LFSLock struct {
 	Owner   *user_model.User `xorm:"-"`
 	Path    string           `xorm:"TEXT"`
 	Created time.Time        `xorm:"created"`
+
+	Repo *repo_model.Repository `xorm:"-"`
 }
 
 func init() {
@@ -134,6 +138,33 @@ func GetLFSLockByRepoID(ctx context.Context, repoID int64, page, pageSize int) (
 	return lfsLocks, e.Find(&lfsLocks, &LFSLock{RepoID: repoID})
 }
 
+// FindLFSLocksOptions represents the options to find LFS locks
+type FindLFSLocksOptions struct {
+	db.ListOptions
+	RepoID  int64
+	OwnerID int64
+	IDs     []int64
+}
+
+func (opts FindLFSLocksOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("id", opts.IDs))
+	}
+	return cond
+}
+
+// ToOrders lists the oldest locks first
+func (opts FindLFSLocksOptions) ToOrders() string {
+	return "created ASC, id ASC"
+}
+
 // GetTreePathLock returns LSF lock for the treePath
 func GetTreePathLock(ctx context.Context, repoID int64, treePath string) (*LFSLock, error) {
 	if !setting.LFS.StartServer {
@@ -181,6 +212,15 @@ func DeleteLFSLockByID(ctx context.Context, id int64, repo *repo_model.Repositor
 	})
 }
 
+// DeleteLFSLocks deletes the locks with the given IDs, regardless of their owners.
+func DeleteLFSLocks(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("id", ids).Delete(new(LFSLock))
+	return err
+}
+
 // CheckLFSAccessForRepo check needed access mode base on action
 func CheckLFSAccessForRepo(ctx context.Context, ownerID int64, repo *repo_model.Repository, mode perm.AccessMode) error {
 	if ownerID == 0 {
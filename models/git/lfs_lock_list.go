@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/container"
 )
@@ -52,3 +53,25 @@ func (locks LFSLockList) LoadOwner(ctx context.Context) error {
 
 	return nil
 }
+
+// LoadRepos loads the repositories of the locks
+func (locks LFSLockList) LoadRepos(ctx context.Context) error {
+	if len(locks) == 0 {
+		return nil
+	}
+
+	repoIDs := container.FilterSlice(locks, func(lock *LFSLock) (int64, bool) {
+		return lock.RepoID, lock.Repo == nil
+	})
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return fmt.Errorf("find repositories: %w", err)
+	}
+	for _, lock := range locks {
+		if lock.Repo == nil {
+			lock.Repo = repos[lock.RepoID]
+		}
+	}
+
+	return nil
+}
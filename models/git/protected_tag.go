@@ -15,6 +15,16 @@ import (
 	"code.gitea.io/gitea/modules/timeutil"
 )
 
+// semverTagPattern matches a semantic version (https://semver.org) with an optional "v" prefix
+var semverTagPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?` +
+	`(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?$`)
+
+// IsSemverTagName returns true if the tag name is a semantic version like "v1.2.3" or "1.2.3-rc.1"
+func IsSemverTagName(name string) bool {
+	return semverTagPattern.MatchString(name)
+}
+
 // ProtectedTag struct
 type ProtectedTag struct {
 	ID               int64 `xorm:"pk autoincr"`
@@ -24,6 +34,12 @@ type ProtectedTag struct {
 	GlobPattern      glob.Glob      `xorm:"-"`
 	AllowlistUserIDs []int64        `xorm:"JSON TEXT"`
 	AllowlistTeamIDs []int64        `xorm:"JSON TEXT"`
+	// RequireAnnotated rejects lightweight tags, RequireSigned additionally requires a verified GPG or SSH signature
+	RequireAnnotated bool `xorm:"NOT NULL DEFAULT false"`
+	RequireSigned    bool `xorm:"NOT NULL DEFAULT false"`
+	// EnforceSemver requires the tag names to be semantic versions, released tags can't be moved or deleted
+	// so a version is never re-created with other contents
+	EnforceSemver bool `xorm:"NOT NULL DEFAULT false"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -147,3 +163,27 @@ func IsUserAllowedToControlTag(ctx context.Context, tags []*ProtectedTag, tagNam
 
 	return isAllowed, nil
 }
+
+// TagRequirements are the requirements of all the protected tags matching a tag, unlike the allowlists they apply to every user
+type TagRequirements struct {
+	RequireAnnotated bool
+	RequireSigned    bool
+	EnforceSemver    bool
+}
+
+// GetTagRequirements merges the requirements of the protected tags matching the tag name
+func GetTagRequirements(tags []*ProtectedTag, tagName string) (*TagRequirements, error) {
+	reqs := &TagRequirements{}
+	for _, tag := range tags {
+		if err := tag.EnsureCompiledPattern(); err != nil {
+			return nil, err
+		}
+		if !tag.matchString(tagName) {
+			continue
+		}
+		reqs.RequireAnnotated = reqs.RequireAnnotated || tag.RequireAnnotated || tag.RequireSigned
+		reqs.RequireSigned = reqs.RequireSigned || tag.RequireSigned
+		reqs.EnforceSemver = reqs.EnforceSemver || tag.EnforceSemver
+	}
+	return reqs, nil
+}
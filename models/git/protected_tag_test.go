@@ -162,3 +162,36 @@ func TestIsUserAllowedToControlTag(t *testing.T) {
 		}
 	})
 }
+
+func TestIsSemverTagName(t *testing.T) {
+	for _, name := range []string{"v1.2.3", "1.0.0", "v0.1.0-rc.1", "v1.2.3+build.5", "1.0.0-alpha-1.2+sha.abc"} {
+		assert.True(t, git_model.IsSemverTagName(name), name)
+	}
+	for _, name := range []string{"v1.2", "1", "v01.2.3", "release-1.2.3", "v1.2.3-", "v1.2.3-01", "vv1.2.3", "1.2.3.4"} {
+		assert.False(t, git_model.IsSemverTagName(name), name)
+	}
+}
+
+func TestGetTagRequirements(t *testing.T) {
+	tags := []*git_model.ProtectedTag{
+		{NamePattern: "v*", RequireSigned: true},
+		{NamePattern: `/^v\d/`, EnforceSemver: true},
+		{NamePattern: "nightly*", RequireAnnotated: true},
+	}
+
+	reqs, err := git_model.GetTagRequirements(tags, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, git_model.TagRequirements{RequireAnnotated: true, RequireSigned: true, EnforceSemver: true}, *reqs)
+
+	reqs, err = git_model.GetTagRequirements(tags, "vnext")
+	assert.NoError(t, err)
+	assert.Equal(t, git_model.TagRequirements{RequireAnnotated: true, RequireSigned: true}, *reqs)
+
+	reqs, err = git_model.GetTagRequirements(tags, "nightly-20260101")
+	assert.NoError(t, err)
+	assert.Equal(t, git_model.TagRequirements{RequireAnnotated: true}, *reqs)
+
+	reqs, err = git_model.GetTagRequirements(tags, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, git_model.TagRequirements{}, *reqs)
+}
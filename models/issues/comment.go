@@ -305,6 +305,9 @@ type Comment struct {
 	ReviewID    int64   `xorm:"index"`
 	Invalidated bool
 
+	// IsHidden is set by a moderator, a hidden comment is left out of the views and the API until it's unhidden
+	IsHidden bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// Reference an issue or pull from another comment, issue or PR
 	// All information is about the origin of the reference
 	RefRepoID    int64                 `xorm:"index"` // Repo where the referencing
@@ -345,6 +348,11 @@ func (c *Comment) LoadIssue(ctx context.Context) (err error) {
 	return err
 }
 
+// IsHiddenByModerator checks whether a moderator has hidden the comment or its issue, the issue must be loaded
+func (c *Comment) IsHiddenByModerator() bool {
+	return c.IsHidden || (c.Issue != nil && c.Issue.IsHidden)
+}
+
 // BeforeInsert will be invoked by XORM before inserting a record
 func (c *Comment) BeforeInsert() {
 	c.PatchQuoted = c.Patch
@@ -1007,6 +1015,9 @@ type FindCommentsOptions struct {
 	IssueIDs    []int64
 	Invalidated optional.Option[bool]
 	IsPull      optional.Option[bool]
+	// HideModerated leaves out the comments hidden by a moderator, it's set by the views and the API
+	// unless the doer may see the hidden content
+	HideModerated bool
 }
 
 // ToConds implements FindOptions interface
@@ -1041,6 +1052,13 @@ func (opts FindCommentsOptions) ToConds() builder.Cond {
 	if opts.Invalidated.Has() {
 		cond = cond.And(builder.Eq{"comment.invalidated": opts.Invalidated.Value()})
 	}
+	if opts.HideModerated {
+		cond = cond.And(builder.Eq{"comment.is_hidden": false})
+		if opts.RepoID > 0 {
+			// the issue is joined to list the comments of the repository
+			cond = cond.And(builder.Eq{"issue.is_hidden": false})
+		}
+	}
 	if opts.IsPull.Has() {
 		cond = cond.And(builder.Eq{"issue.is_pull": opts.IsPull.Value()})
 	}
@@ -1082,6 +1100,17 @@ func UpdateCommentInvalidate(ctx context.Context, c *Comment) error {
 	return err
 }
 
+// UpdateCommentHidden updates comment is_hidden column and the number of comments of the issue,
+// the hidden comments aren't counted
+func UpdateCommentHidden(ctx context.Context, c *Comment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(c.ID).Cols("is_hidden").Update(c); err != nil {
+			return err
+		}
+		return UpdateIssueNumComments(ctx, c.IssueID)
+	})
+}
+
 // UpdateComment updates information of comment.
 func UpdateComment(ctx context.Context, c *Comment, contentVersion int, doer *user_model.User) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
@@ -1234,7 +1263,7 @@ func (c *Comment) HasOriginalAuthor() bool {
 
 func UpdateIssueNumCommentsBuilder(issueID int64) *builder.Builder {
 	subQuery := builder.Select("COUNT(*)").From("`comment`").Where(
-		builder.Eq{"issue_id": issueID}.And(
+		builder.Eq{"issue_id": issueID, "is_hidden": false}.And(
 			builder.In("`type`", ConversationCountedCommentType()),
 		))
 
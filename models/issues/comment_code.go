@@ -5,6 +5,7 @@ package issues
 
 import (
 	"context"
+	"slices"
 	"strconv"
 
 	"code.gitea.io/gitea/models/db"
@@ -18,20 +19,39 @@ import (
 // CodeComments represents comments on code by using this structure: FILENAME -> LINE (+ == proposed; - == previous) -> COMMENTS
 type CodeComments map[string]map[int64][]*Comment
 
-// FetchCodeComments will return a 2d-map: ["Path"]["Line"] = Comments at line
-func FetchCodeComments(ctx context.Context, issue *Issue, currentUser *user_model.User, showOutdatedComments bool) (CodeComments, error) {
-	return fetchCodeCommentsByReview(ctx, issue, currentUser, nil, showOutdatedComments)
+// RemoveHidden removes the comments hidden by a moderator and the lines left without comments
+func (cc CodeComments) RemoveHidden() {
+	for treePath, lines := range cc {
+		for line, comments := range lines {
+			comments = slices.DeleteFunc(comments, func(c *Comment) bool { return c.IsHidden })
+			if len(comments) == 0 {
+				delete(lines, line)
+			} else {
+				lines[line] = comments
+			}
+		}
+		if len(lines) == 0 {
+			delete(cc, treePath)
+		}
+	}
+}
+
+// FetchCodeComments will return a 2d-map: ["Path"]["Line"] = Comments at line,
+// the comments hidden by a moderator are left out if hideModerated is set
+func FetchCodeComments(ctx context.Context, issue *Issue, currentUser *user_model.User, showOutdatedComments, hideModerated bool) (CodeComments, error) {
+	return fetchCodeCommentsByReview(ctx, issue, currentUser, nil, showOutdatedComments, hideModerated)
 }
 
-func fetchCodeCommentsByReview(ctx context.Context, issue *Issue, currentUser *user_model.User, review *Review, showOutdatedComments bool) (CodeComments, error) {
+func fetchCodeCommentsByReview(ctx context.Context, issue *Issue, currentUser *user_model.User, review *Review, showOutdatedComments, hideModerated bool) (CodeComments, error) {
 	pathToLineToComment := make(CodeComments)
 	if review == nil {
 		review = &Review{ID: 0}
 	}
 	opts := FindCommentsOptions{
-		Type:     CommentTypeCode,
-		IssueID:  issue.ID,
-		ReviewID: review.ID,
+		Type:          CommentTypeCode,
+		IssueID:       issue.ID,
+		ReviewID:      review.ID,
+		HideModerated: hideModerated,
 	}
 
 	comments, err := findCodeComments(ctx, opts, issue, currentUser, review, showOutdatedComments)
@@ -125,13 +145,15 @@ func findCodeComments(ctx context.Context, opts FindCommentsOptions, issue *Issu
 	return comments[:n], nil
 }
 
-// FetchCodeCommentsByLine fetches the code comments for a given treePath and line number
-func FetchCodeCommentsByLine(ctx context.Context, issue *Issue, currentUser *user_model.User, treePath string, line int64, showOutdatedComments bool) (CommentList, error) {
+// FetchCodeCommentsByLine fetches the code comments for a given treePath and line number,
+// the comments hidden by a moderator are left out if hideModerated is set
+func FetchCodeCommentsByLine(ctx context.Context, issue *Issue, currentUser *user_model.User, treePath string, line int64, showOutdatedComments, hideModerated bool) (CommentList, error) {
 	opts := FindCommentsOptions{
-		Type:     CommentTypeCode,
-		IssueID:  issue.ID,
-		TreePath: treePath,
-		Line:     line,
+		Type:          CommentTypeCode,
+		IssueID:       issue.ID,
+		TreePath:      treePath,
+		Line:          line,
+		HideModerated: hideModerated,
 	}
 	return findCodeComments(ctx, opts, issue, currentUser, nil, showOutdatedComments)
 }
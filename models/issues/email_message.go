@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// IssueEmailMessage records the Message-ID of an incoming email which created or commented on an issue,
+// the emails referencing it are threaded into the same issue
+type IssueEmailMessage struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"INDEX NOT NULL"`
+	MessageID   string             `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueEmailMessage))
+}
+
+// AddIssueEmailMessage records the Message-ID of an email handled for an issue, a Message-ID too long to be stored is ignored
+func AddIssueEmailMessage(ctx context.Context, issueID int64, messageID string) error {
+	if len(messageID) > 255 {
+		return nil
+	}
+	return db.Insert(ctx, &IssueEmailMessage{IssueID: issueID, MessageID: messageID})
+}
+
+// GetIssueIDByEmailMessageIDs returns the issue of the first recorded email among the Message-IDs, 0 if none is known
+func GetIssueIDByEmailMessageIDs(ctx context.Context, messageIDs []string) (int64, error) {
+	if len(messageIDs) == 0 {
+		return 0, nil
+	}
+	msgs := make([]*IssueEmailMessage, 0, len(messageIDs))
+	if err := db.GetEngine(ctx).In("message_id", messageIDs).Find(&msgs); err != nil {
+		return 0, err
+	}
+	for _, id := range messageIDs {
+		for _, msg := range msgs {
+			if msg.MessageID == id {
+				return msg.IssueID, nil
+			}
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueEmailMessage(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issueID, err := issues_model.GetIssueIDByEmailMessageIDs(t.Context(), nil)
+	assert.NoError(t, err)
+	assert.Zero(t, issueID)
+
+	assert.NoError(t, issues_model.AddIssueEmailMessage(t.Context(), 1, "first@example.com"))
+	assert.NoError(t, issues_model.AddIssueEmailMessage(t.Context(), 2, "second@example.com"))
+	assert.Error(t, issues_model.AddIssueEmailMessage(t.Context(), 3, "second@example.com"))
+
+	issueID, err = issues_model.GetIssueIDByEmailMessageIDs(t.Context(), []string{"unknown@example.com", "second@example.com", "first@example.com"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, issueID)
+
+	issueID, err = issues_model.GetIssueIDByEmailMessageIDs(t.Context(), []string{"unknown@example.com"})
+	assert.NoError(t, err)
+	assert.Zero(t, issueID)
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// FederatedIssue links a pull request offered by a remote fork, or the issue tracking a pull request offered to a remote origin,
+// to its counterpart on the other instance. The comments of either side are sent to the other one.
+type FederatedIssue struct {
+	ID      int64 `xorm:"pk autoincr"`
+	IssueID int64 `xorm:"UNIQUE NOT NULL"`
+	// TicketIRI is the pull request on the origin repository, the comments of both sides are attached to it.
+	// It is empty on the fork until the origin accepted the offer.
+	TicketIRI string `xorm:"VARCHAR(255) INDEX"`
+	// OfferIRI is the Offer activity sent by the fork to open the pull request
+	OfferIRI string `xorm:"VARCHAR(255) INDEX NOT NULL"`
+	// RemoteActorIRI is the actor on the other instance: the author of the pull request on the origin, the origin repository on the fork
+	RemoteActorIRI string `xorm:"VARCHAR(255) NOT NULL"`
+	RemoteInbox    string `xorm:"TEXT NOT NULL"`
+	// HeadRepoIRI and HeadBranch are the Repository actor of the fork and the branch the commits of the pull request come from
+	HeadRepoIRI string             `xorm:"VARCHAR(255) NOT NULL"`
+	HeadBranch  string             `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(FederatedIssue))
+}
+
+func getFederatedIssue(ctx context.Context, cond builder.Cond) (*FederatedIssue, error) {
+	fi := new(FederatedIssue)
+	has, err := db.GetEngine(ctx).Where(cond).Get(fi)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("federated issue does not exist")
+	}
+	return fi, nil
+}
+
+// GetFederatedIssueByIssueID returns the remote counterpart of an issue
+func GetFederatedIssueByIssueID(ctx context.Context, issueID int64) (*FederatedIssue, error) {
+	return getFederatedIssue(ctx, builder.Eq{"issue_id": issueID})
+}
+
+// GetFederatedIssuesByTicketIRI returns the federated issues of a pull request on the origin repository,
+// there are two of them when the origin and the fork are on the same instance
+func GetFederatedIssuesByTicketIRI(ctx context.Context, ticketIRI string) ([]*FederatedIssue, error) {
+	issues := make([]*FederatedIssue, 0, 1)
+	return issues, db.GetEngine(ctx).Where("ticket_iri = ?", ticketIRI).Find(&issues)
+}
+
+// GetFederatedIssueByOfferIRI returns the federated issue opened by an Offer activity whose other side is a remote actor
+func GetFederatedIssueByOfferIRI(ctx context.Context, offerIRI, remoteActorIRI string) (*FederatedIssue, error) {
+	return getFederatedIssue(ctx, builder.Eq{"offer_iri": offerIRI, "remote_actor_iri": remoteActorIRI})
+}
+
+// GetOpenFederatedIssuesByHead returns the open federated issues of a repository whose commits come from a branch of a fork.
+// The Repository actor of the fork isn't checked if headRepoIRI is empty.
+func GetOpenFederatedIssuesByHead(ctx context.Context, repoID int64, headRepoIRI, headBranch string) ([]*FederatedIssue, error) {
+	cond := builder.Eq{
+		"issue.repo_id":               repoID,
+		"issue.is_closed":             false,
+		"federated_issue.head_branch": headBranch,
+	}
+	if headRepoIRI != "" {
+		cond["federated_issue.head_repo_iri"] = headRepoIRI
+	}
+	issues := make([]*FederatedIssue, 0, 1)
+	return issues, db.GetEngine(ctx).Table("federated_issue").
+		Join("INNER", "issue", "issue.id = federated_issue.issue_id").
+		Where(cond).
+		Find(&issues)
+}
+
+// NewFederatedIssue links an issue to its remote counterpart
+func NewFederatedIssue(ctx context.Context, fi *FederatedIssue) error {
+	return db.Insert(ctx, fi)
+}
+
+// UpdateFederatedIssueTicket sets the pull request on the origin repository of a federated issue
+func UpdateFederatedIssueTicket(ctx context.Context, fi *FederatedIssue) error {
+	_, err := db.GetEngine(ctx).ID(fi.ID).Cols("ticket_iri").Update(fi)
+	return err
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// HeldContentType is the kind of the held content
+type HeldContentType int
+
+const (
+	// HeldContentIssue is a new issue
+	HeldContentIssue HeldContentType = iota + 1
+	// HeldContentComment is a new comment of an issue or a pull request
+	HeldContentComment
+	// HeldContentIssueEdit is the new title and content of an existing issue or pull request
+	HeldContentIssueEdit
+	// HeldContentCommentEdit is the new content of an existing comment
+	HeldContentCommentEdit
+)
+
+// HeldContent reasons of the built-in rules, the reasons of the external classifier are kept in ClassifierReason
+const (
+	HeldReasonAccountAge       = "account_age"
+	HeldReasonLinkDensity      = "link_density"
+	HeldReasonDuplicateContent = "duplicate_content"
+	HeldReasonClassifier       = "classifier"
+)
+
+// HeldContent is an issue, a comment or an edit of them which has been held as suspected spam, it is published once
+// an admin approves it
+type HeldContent struct {
+	ID     int64                  `xorm:"pk autoincr"`
+	Type   HeldContentType        `xorm:"NOT NULL"`
+	RepoID int64                  `xorm:"INDEX NOT NULL"`
+	Repo   *repo_model.Repository `xorm:"-"`
+	// IssueID is the issue of a held comment or the edited issue
+	IssueID int64  `xorm:"NOT NULL DEFAULT 0"`
+	Issue   *Issue `xorm:"-"`
+	// CommentID is the edited comment
+	CommentID        int64            `xorm:"NOT NULL DEFAULT 0"`
+	PosterID         int64            `xorm:"INDEX NOT NULL"`
+	Poster           *user_model.User `xorm:"-"`
+	Title            string
+	Content          string   `xorm:"LONGTEXT"`
+	Attachments      []string `xorm:"JSON TEXT"`
+	Reasons          []string `xorm:"JSON TEXT"`
+	ClassifierReason string   `xorm:"TEXT"`
+
+	// TitleEdited and ContentEdited are the fields changed by a held issue edit, only they are applied on approval
+	TitleEdited   bool `xorm:"NOT NULL DEFAULT false"`
+	ContentEdited bool `xorm:"NOT NULL DEFAULT false"`
+
+	// the labels, the milestone, the assignees and the project of a held issue
+	LabelIDs    []int64 `xorm:"JSON TEXT"`
+	MilestoneID int64   `xorm:"NOT NULL DEFAULT 0"`
+	AssigneeIDs []int64 `xorm:"JSON TEXT"`
+	ProjectID   int64   `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(HeldContent))
+}
+
+// ErrHeldContentNotExist represents a "held content not exist" error
+var ErrHeldContentNotExist = util.NewNotExistErrorf("held content does not exist")
+
+// TrStr returns the translation key of the type
+func (h *HeldContent) TrStr() string {
+	switch h.Type {
+	case HeldContentIssue:
+		return "admin.held_content.type_issue"
+	case HeldContentIssueEdit:
+		return "admin.held_content.type_issue_edit"
+	case HeldContentCommentEdit:
+		return "admin.held_content.type_comment_edit"
+	}
+	return "admin.held_content.type_comment"
+}
+
+// LoadAttributes loads the repository, the issue and the poster, which may have been deleted in the meantime
+func (h *HeldContent) LoadAttributes(ctx context.Context) (err error) {
+	if h.Repo == nil {
+		if h.Repo, err = repo_model.GetRepositoryByID(ctx, h.RepoID); err != nil && !repo_model.IsErrRepoNotExist(err) {
+			return err
+		}
+	}
+	if h.Issue == nil && h.Type != HeldContentIssue {
+		if h.Issue, err = GetIssueByID(ctx, h.IssueID); err != nil && !IsErrIssueNotExist(err) {
+			return err
+		}
+		if h.Issue != nil {
+			h.Issue.Repo = h.Repo
+		}
+	}
+	if h.Poster == nil {
+		if h.Poster, err = user_model.GetPossibleUserByID(ctx, h.PosterID); err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return err
+			}
+			h.Poster = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
+
+// InsertHeldContent holds the content
+func InsertHeldContent(ctx context.Context, h *HeldContent) error {
+	return db.Insert(ctx, h)
+}
+
+// GetHeldContentByID returns the held content
+func GetHeldContentByID(ctx context.Context, id int64) (*HeldContent, error) {
+	h := &HeldContent{}
+	has, err := db.GetEngine(ctx).ID(id).Get(h)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrHeldContentNotExist
+	}
+	return h, nil
+}
+
+// DeleteHeldContentByID deletes the held content and returns whether it has still existed
+func DeleteHeldContentByID(ctx context.Context, id int64) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(id).Delete(new(HeldContent))
+	return n > 0, err
+}
+
+// CountPostedContents counts the issues, comments and held contents which the poster has posted with exactly the
+// content since the time, it's used to detect duplicates
+func CountPostedContents(ctx context.Context, posterID int64, content string, since timeutil.TimeStamp) (int64, error) {
+	cond := builder.Eq{"poster_id": posterID, "content": content}.And(builder.Gte{"created_unix": since})
+	issues, err := db.GetEngine(ctx).Where(cond).Count(new(Issue))
+	if err != nil {
+		return 0, err
+	}
+	comments, err := db.GetEngine(ctx).Where(cond).And(builder.Eq{"type": CommentTypeComment}).Count(new(Comment))
+	if err != nil {
+		return 0, err
+	}
+	held, err := db.GetEngine(ctx).Where(cond).Count(new(HeldContent))
+	if err != nil {
+		return 0, err
+	}
+	return issues + comments + held, nil
+}
+
+// FindHeldContentsOptions are the options of finding held contents
+type FindHeldContentsOptions struct {
+	db.ListOptions
+	PosterID int64
+}
+
+// ToConds implements db.FindOptions
+func (opts FindHeldContentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.PosterID > 0 {
+		cond = cond.And(builder.Eq{"poster_id": opts.PosterID})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindHeldContentsOptions) ToOrders() string {
+	return "id DESC"
+}
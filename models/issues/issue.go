@@ -117,6 +117,9 @@ type Issue struct {
 	// with write access
 	IsLocked bool `xorm:"NOT NULL DEFAULT false"`
 
+	// IsHidden is set by a moderator, a hidden issue is left out of the views and the API until it's unhidden
+	IsHidden bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// For view issue page.
 	ShowRole RoleDescriptor `xorm:"-"`
 
@@ -0,0 +1,348 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// IssueFieldType is the type of the values of a custom field
+type IssueFieldType string
+
+const (
+	IssueFieldTypeText   IssueFieldType = "text"
+	IssueFieldTypeNumber IssueFieldType = "number"
+	IssueFieldTypeEnum   IssueFieldType = "enum"
+	IssueFieldTypeDate   IssueFieldType = "date"
+	IssueFieldTypeUser   IssueFieldType = "user"
+)
+
+// IssueFieldTypes are the supported types of custom fields
+var IssueFieldTypes = []IssueFieldType{IssueFieldTypeText, IssueFieldTypeNumber, IssueFieldTypeEnum, IssueFieldTypeDate, IssueFieldTypeUser}
+
+// issueFieldTextMaxLength is the maximum length of the value of a text field
+const issueFieldTextMaxLength = 255
+
+// IssueField is a custom field of the issues and pull requests of a repository,
+// or of all the repositories of an organization
+type IssueField struct {
+	ID          int64          `xorm:"pk autoincr"`
+	RepoID      int64          `xorm:"INDEX NOT NULL DEFAULT 0"`
+	OrgID       int64          `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string         `xorm:"NOT NULL"`
+	Description string         `xorm:"TEXT"`
+	Type        IssueFieldType `xorm:"VARCHAR(10) NOT NULL"`
+	// Options are the allowed values of an enum field
+	Options     []string           `xorm:"TEXT JSON"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// IssueFieldValue is the value of a custom field of an issue
+type IssueFieldValue struct {
+	ID      int64 `xorm:"pk autoincr"`
+	IssueID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	FieldID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	// Value is normalized according to the type of the field, it is the ID of the user of a user field
+	Value string `xorm:"VARCHAR(255) NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueField))
+	db.RegisterModel(new(IssueFieldValue))
+}
+
+// IsValid returns whether the type is supported
+func (t IssueFieldType) IsValid() bool {
+	return slices.Contains(IssueFieldTypes, t)
+}
+
+// BelongsToOrg returns whether the field belongs to an organization
+func (f *IssueField) BelongsToOrg() bool {
+	return f.OrgID > 0
+}
+
+// NormalizeValue validates a value of the field and returns the value to store.
+// The value of a user field is a username, the ID of the user is stored.
+func (f *IssueField) NormalizeValue(ctx context.Context, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	switch f.Type {
+	case IssueFieldTypeText:
+		if len([]rune(value)) > issueFieldTextMaxLength {
+			return "", util.NewInvalidArgumentErrorf("the value of %s is longer than %d characters", f.Name, issueFieldTextMaxLength)
+		}
+		return value, nil
+	case IssueFieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("the value of %s is not a number: %s", f.Name, value)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case IssueFieldTypeEnum:
+		if !slices.Contains(f.Options, value) {
+			return "", util.NewInvalidArgumentErrorf("the value of %s is not one of its options: %s", f.Name, value)
+		}
+		return value, nil
+	case IssueFieldTypeDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return "", util.NewInvalidArgumentErrorf("the value of %s is not a date (YYYY-MM-DD): %s", f.Name, value)
+		}
+		return value, nil
+	case IssueFieldTypeUser:
+		user, err := user_model.GetUserByName(ctx, value)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return "", util.NewInvalidArgumentErrorf("the value of %s is not a user: %s", f.Name, value)
+			}
+			return "", err
+		}
+		return strconv.FormatInt(user.ID, 10), nil
+	}
+	return "", util.NewInvalidArgumentErrorf("unknown type of %s: %s", f.Name, f.Type)
+}
+
+func (f *IssueField) validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of the field is empty")
+	}
+	if !f.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("unknown type of %s: %s", f.Name, f.Type)
+	}
+	if f.Type != IssueFieldTypeEnum {
+		f.Options = nil
+		return nil
+	}
+
+	options := make(container.Set[string], len(f.Options))
+	for i, option := range f.Options {
+		f.Options[i] = strings.TrimSpace(option)
+		if f.Options[i] == "" || len([]rune(f.Options[i])) > issueFieldTextMaxLength {
+			return util.NewInvalidArgumentErrorf("invalid option of %s: %q", f.Name, option)
+		}
+		if !options.Add(f.Options[i]) {
+			return util.NewInvalidArgumentErrorf("duplicate option of %s: %s", f.Name, f.Options[i])
+		}
+	}
+	if len(f.Options) == 0 {
+		return util.NewInvalidArgumentErrorf("the enum field %s has no options", f.Name)
+	}
+	return nil
+}
+
+// checkIssueFieldNameAvailable checks that no other field of the same repository or organization has the name
+func checkIssueFieldNameAvailable(ctx context.Context, f *IssueField) error {
+	exist, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": f.RepoID, "org_id": f.OrgID, "lower(name)": strings.ToLower(f.Name)}.
+		And(builder.Neq{"id": f.ID})).Exist(new(IssueField))
+	if err != nil {
+		return err
+	}
+	if exist {
+		return util.NewAlreadyExistErrorf("a field named %s already exists", f.Name)
+	}
+	return nil
+}
+
+// NewIssueField creates a custom field of a repository or an organization
+func NewIssueField(ctx context.Context, f *IssueField) error {
+	if (f.RepoID > 0) == (f.OrgID > 0) {
+		return util.NewInvalidArgumentErrorf("the field must belong to either a repository or an organization")
+	}
+	if err := f.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkIssueFieldNameAvailable(ctx, f); err != nil {
+			return err
+		}
+		return db.Insert(ctx, f)
+	})
+}
+
+// UpdateIssueField updates the name, the description and the options of a custom field.
+// The values which aren't options of an enum field anymore are removed.
+func UpdateIssueField(ctx context.Context, f *IssueField) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkIssueFieldNameAvailable(ctx, f); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "description", "options").Update(f); err != nil {
+			return err
+		}
+		if f.Type != IssueFieldTypeEnum {
+			return nil
+		}
+		_, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID}.And(builder.NotIn("value", f.Options))).
+			Delete(new(IssueFieldValue))
+		return err
+	})
+}
+
+// DeleteIssueField deletes a custom field and its values
+func DeleteIssueField(ctx context.Context, f *IssueField) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &IssueFieldValue{FieldID: f.ID}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[IssueField](ctx, f.ID)
+		return err
+	})
+}
+
+// DeleteIssueFields deletes the custom fields of a repository or an organization and their values
+func DeleteIssueFields(ctx context.Context, repoID, orgID int64) error {
+	cond := builder.Eq{"repo_id": repoID, "org_id": orgID}
+	if _, err := db.GetEngine(ctx).In("field_id", builder.Select("id").From("issue_field").Where(cond)).
+		Delete(new(IssueFieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(cond).Delete(new(IssueField))
+	return err
+}
+
+// GetIssueFieldByID returns a custom field of a repository or an organization
+func GetIssueFieldByID(ctx context.Context, repoID, orgID, id int64) (*IssueField, error) {
+	f, exist, err := db.Get[IssueField](ctx, builder.Eq{"id": id, "repo_id": repoID, "org_id": orgID})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, util.NewNotExistErrorf("issue field %d does not exist", id)
+	}
+	return f, nil
+}
+
+// GetIssueFields returns the custom fields of a repository or an organization sorted by name
+func GetIssueFields(ctx context.Context, repoID, orgID int64) ([]*IssueField, error) {
+	fields := make([]*IssueField, 0, 10)
+	return fields, db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "org_id": orgID}).Asc("name").Find(&fields)
+}
+
+// GetIssueFieldsOfRepo returns the custom fields of a repository and of its organization sorted by name
+func GetIssueFieldsOfRepo(ctx context.Context, repo *repo_model.Repository) ([]*IssueField, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	var cond builder.Cond = builder.Eq{"repo_id": repo.ID}
+	if repo.Owner.IsOrganization() {
+		cond = cond.Or(builder.Eq{"org_id": repo.OwnerID})
+	}
+	fields := make([]*IssueField, 0, 10)
+	return fields, db.GetEngine(ctx).Where(cond).Asc("name").Asc("id").Find(&fields)
+}
+
+// GetIssueFieldValues returns the values of the custom fields of some issues by issue ID and field ID
+func GetIssueFieldValues(ctx context.Context, issueIDs ...int64) (map[int64]map[int64]string, error) {
+	values := make([]*IssueFieldValue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).In("issue_id", issueIDs).Find(&values); err != nil {
+		return nil, err
+	}
+	res := make(map[int64]map[int64]string, len(issueIDs))
+	for _, v := range values {
+		if res[v.IssueID] == nil {
+			res[v.IssueID] = make(map[int64]string)
+		}
+		res[v.IssueID][v.FieldID] = v.Value
+	}
+	return res, nil
+}
+
+// GetIssueFieldDisplayValues returns the values of the custom fields of some issues by issue ID and field ID,
+// the values of user fields are the names of the users instead of their IDs.
+func GetIssueFieldDisplayValues(ctx context.Context, fields []*IssueField, issueIDs ...int64) (map[int64]map[int64]string, error) {
+	values, err := GetIssueFieldValues(ctx, issueIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	userFieldIDs := make(container.Set[int64])
+	for _, f := range fields {
+		if f.Type == IssueFieldTypeUser {
+			userFieldIDs.Add(f.ID)
+		}
+	}
+	userIDs := make(container.Set[int64])
+	for _, issueValues := range values {
+		for fieldID, value := range issueValues {
+			if userFieldIDs.Contains(fieldID) {
+				userID, _ := strconv.ParseInt(value, 10, 64)
+				userIDs.Add(userID)
+			}
+		}
+	}
+	if len(userIDs) == 0 {
+		return values, nil
+	}
+
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs.Values())
+	if err != nil {
+		return nil, err
+	}
+	for _, issueValues := range values {
+		for fieldID, value := range issueValues {
+			if !userFieldIDs.Contains(fieldID) {
+				continue
+			}
+			userID, _ := strconv.ParseInt(value, 10, 64)
+			if user, ok := users[userID]; ok {
+				issueValues[fieldID] = user.Name
+			} else {
+				issueValues[fieldID] = user_model.NewGhostUser().Name
+			}
+		}
+	}
+	return values, nil
+}
+
+// SetIssueFieldValue sets the normalized value of a custom field of an issue, the empty value removes it
+func SetIssueFieldValue(ctx context.Context, issueID, fieldID int64, value string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if value == "" {
+			_, err := db.DeleteByBean(ctx, &IssueFieldValue{IssueID: issueID, FieldID: fieldID})
+			return err
+		}
+		updated, err := db.GetEngine(ctx).Where(builder.Eq{"issue_id": issueID, "field_id": fieldID}).
+			Cols("value").Update(&IssueFieldValue{Value: value})
+		if err != nil {
+			return err
+		} else if updated > 0 {
+			return nil
+		}
+		exist, err := db.GetEngine(ctx).Exist(&IssueFieldValue{IssueID: issueID, FieldID: fieldID})
+		if err != nil || exist {
+			// the value is unchanged
+			return err
+		}
+		return db.Insert(ctx, &IssueFieldValue{IssueID: issueID, FieldID: fieldID, Value: value})
+	})
+}
+
+// issueFieldValuesCond returns the condition of the issues having all the values of custom fields
+func issueFieldValuesCond(fieldValues map[int64]string) builder.Cond {
+	cond := builder.NewCond()
+	for fieldID, value := range fieldValues {
+		cond = cond.And(builder.In("issue.id", builder.Select("issue_id").From("issue_field_value").
+			Where(builder.Eq{"field_id": fieldID, "value": value})))
+	}
+	return cond
+}
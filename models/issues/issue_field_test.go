@@ -0,0 +1,126 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueField(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	severity := &issues_model.IssueField{RepoID: repo.ID, Name: "Severity", Type: issues_model.IssueFieldTypeEnum, Options: []string{"low", "high"}}
+	require.NoError(t, issues_model.NewIssueField(t.Context(), severity))
+	customer := &issues_model.IssueField{OrgID: repo.OwnerID, Name: " Customer ", Type: issues_model.IssueFieldTypeText}
+	require.NoError(t, issues_model.NewIssueField(t.Context(), customer))
+	assert.Equal(t, "Customer", customer.Name)
+	owner := &issues_model.IssueField{OrgID: repo.OwnerID, Name: "Owner", Type: issues_model.IssueFieldTypeUser}
+	require.NoError(t, issues_model.NewIssueField(t.Context(), owner))
+
+	for _, f := range []*issues_model.IssueField{
+		{RepoID: repo.ID, Name: "severity", Type: issues_model.IssueFieldTypeText},
+		{RepoID: repo.ID, Name: "Unknown", Type: "unknown"},
+		{RepoID: repo.ID, Name: "Empty", Type: issues_model.IssueFieldTypeEnum},
+		{RepoID: repo.ID, Name: "Duplicate", Type: issues_model.IssueFieldTypeEnum, Options: []string{"a", "a"}},
+		{RepoID: repo.ID, OrgID: repo.OwnerID, Name: "Both", Type: issues_model.IssueFieldTypeText},
+	} {
+		assert.Error(t, issues_model.NewIssueField(t.Context(), f), f.Name)
+	}
+
+	fields, err := issues_model.GetIssueFieldsOfRepo(t.Context(), repo)
+	require.NoError(t, err)
+	if assert.Len(t, fields, 3) {
+		assert.Equal(t, "Customer", fields[0].Name)
+		assert.True(t, fields[0].BelongsToOrg())
+		assert.Equal(t, "Owner", fields[1].Name)
+		assert.Equal(t, "Severity", fields[2].Name)
+	}
+
+	t.Run("NormalizeValue", func(t *testing.T) {
+		number := &issues_model.IssueField{Name: "Number", Type: issues_model.IssueFieldTypeNumber}
+		date := &issues_model.IssueField{Name: "Date", Type: issues_model.IssueFieldTypeDate}
+		for _, c := range []struct {
+			field    *issues_model.IssueField
+			value    string
+			expected string
+		}{
+			{severity, "high", "high"},
+			{severity, "", ""},
+			{number, " 1.50 ", "1.5"},
+			{date, "2026-01-31", "2026-01-31"},
+			{owner, "user2", "2"},
+		} {
+			value, err := c.field.NormalizeValue(t.Context(), c.value)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, value)
+		}
+		for _, c := range []struct {
+			field *issues_model.IssueField
+			value string
+		}{
+			{severity, "medium"},
+			{number, "one"},
+			{date, "31/01/2026"},
+			{owner, "not-a-user"},
+		} {
+			_, err := c.field.NormalizeValue(t.Context(), c.value)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 6, severity.ID, "high"))
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 6, owner.ID, "2"))
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 12, severity.ID, "low"))
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 12, severity.ID, "high"))
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 12, severity.ID, "high"))
+
+		values, err := issues_model.GetIssueFieldDisplayValues(t.Context(), fields, 6, 12)
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]map[int64]string{
+			6:  {severity.ID: "high", owner.ID: "user2"},
+			12: {severity.ID: "high"},
+		}, values)
+
+		issues, err := issues_model.Issues(t.Context(), &issues_model.IssuesOptions{
+			RepoIDs:     []int64{repo.ID},
+			FieldValues: map[int64]string{severity.ID: "high", owner.ID: "2"},
+		})
+		require.NoError(t, err)
+		if assert.Len(t, issues, 1) {
+			assert.EqualValues(t, 6, issues[0].ID)
+		}
+
+		// the values which aren't options anymore are removed
+		severity.Options = []string{"low", "critical"}
+		require.NoError(t, issues_model.UpdateIssueField(t.Context(), severity))
+		values, err = issues_model.GetIssueFieldValues(t.Context(), 6, 12)
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]map[int64]string{6: {owner.ID: "2"}}, values)
+
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 6, owner.ID, ""))
+		unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{IssueID: 6})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, issues_model.SetIssueFieldValue(t.Context(), 6, customer.ID, "ACME"))
+		require.NoError(t, issues_model.DeleteIssueFields(t.Context(), 0, repo.OwnerID))
+		unittest.AssertNotExistsBean(t, &issues_model.IssueField{ID: customer.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{FieldID: customer.ID})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: severity.ID})
+
+		require.NoError(t, issues_model.DeleteIssueField(t.Context(), severity))
+		_, err := issues_model.GetIssueFieldByID(t.Context(), repo.ID, 0, severity.ID)
+		assert.ErrorIs(t, err, util.ErrNotExist)
+	})
+}
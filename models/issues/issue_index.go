@@ -21,3 +21,9 @@ func RecalculateIssueIndexForRepo(ctx context.Context, repoID int64) error {
 		return db.SyncMaxResourceIndex(ctx, "issue_index", repoID, maxIndex)
 	})
 }
+
+// GetIssueIndexesOfRepo returns the indexes of the issues and the pull requests of a repository
+func GetIssueIndexesOfRepo(ctx context.Context, repoID int64) ([]int64, error) {
+	indexes := make([]int64, 0, 10)
+	return indexes, db.GetEngine(ctx).Table("issue").Where("repo_id=?", repoID).Cols("`index`").Find(&indexes)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// issueSavedSearchNameMaxLength is the maximum length of the name of a saved search
+const issueSavedSearchNameMaxLength = 50
+
+// IssueSavedSearchQueryKeys are the parameters of the issue list kept in a saved search
+var IssueSavedSearchQueryKeys = []string{
+	"q", "type", "state", "sort", "labels", "archived_labels", "milestone", "project", "assignee", "poster", "field",
+}
+
+// IssueSavedSearch is a named filter of the issue list of a repository.
+// A personal search belongs to a user, a shared search belongs to an organization
+// and applies to one of its repositories or to all of them.
+type IssueSavedSearch struct {
+	ID      int64  `xorm:"pk autoincr"`
+	OwnerID int64  `xorm:"INDEX NOT NULL"`
+	RepoID  int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	IsPull  bool   `xorm:"NOT NULL DEFAULT false"`
+	Name    string `xorm:"NOT NULL"`
+	// Query is the normalized query string of the issue list
+	Query       string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueSavedSearch))
+}
+
+// NormalizeIssueSavedSearchQuery keeps the filter parameters of a query string of the issue list
+// in a stable order and drops the empty and unknown ones, e.g. the page.
+func NormalizeIssueSavedSearchQuery(query string) (string, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(query), "?"))
+	if err != nil {
+		return "", util.NewInvalidArgumentErrorf("invalid query: %v", err)
+	}
+	normalized := make(url.Values, len(IssueSavedSearchQueryKeys))
+	for _, key := range IssueSavedSearchQueryKeys {
+		for _, value := range values[key] {
+			if value = strings.TrimSpace(value); value != "" {
+				normalized.Add(key, value)
+			}
+		}
+	}
+	return normalized.Encode(), nil
+}
+
+// IsShared returns whether the search is shared by an organization
+func (s *IssueSavedSearch) IsShared(repo *repo_model.Repository) bool {
+	return s.OwnerID == repo.OwnerID && repo.Owner != nil && repo.Owner.IsOrganization()
+}
+
+func (s *IssueSavedSearch) validate() (err error) {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of the search is empty")
+	}
+	if len([]rune(s.Name)) > issueSavedSearchNameMaxLength {
+		return util.NewInvalidArgumentErrorf("the name of the search is longer than %d characters", issueSavedSearchNameMaxLength)
+	}
+	s.Query, err = NormalizeIssueSavedSearchQuery(s.Query)
+	return err
+}
+
+// checkIssueSavedSearchNameAvailable checks that no other search of the same owner and repository has the name
+func checkIssueSavedSearchNameAvailable(ctx context.Context, s *IssueSavedSearch) error {
+	exist, err := db.GetEngine(ctx).Where(builder.Eq{
+		"owner_id":    s.OwnerID,
+		"repo_id":     s.RepoID,
+		"is_pull":     s.IsPull,
+		"lower(name)": strings.ToLower(s.Name),
+	}.And(builder.Neq{"id": s.ID})).Exist(new(IssueSavedSearch))
+	if err != nil {
+		return err
+	}
+	if exist {
+		return util.NewAlreadyExistErrorf("a search named %s already exists", s.Name)
+	}
+	return nil
+}
+
+// NewIssueSavedSearch creates a saved search
+func NewIssueSavedSearch(ctx context.Context, s *IssueSavedSearch) error {
+	if s.OwnerID <= 0 {
+		return util.NewInvalidArgumentErrorf("the search must have an owner")
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkIssueSavedSearchNameAvailable(ctx, s); err != nil {
+			return err
+		}
+		return db.Insert(ctx, s)
+	})
+}
+
+// UpdateIssueSavedSearch updates the name and the query of a saved search
+func UpdateIssueSavedSearch(ctx context.Context, s *IssueSavedSearch) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkIssueSavedSearchNameAvailable(ctx, s); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(s.ID).Cols("name", "query").Update(s)
+		return err
+	})
+}
+
+// DeleteIssueSavedSearch deletes a saved search
+func DeleteIssueSavedSearch(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[IssueSavedSearch](ctx, id)
+	return err
+}
+
+// GetIssueSavedSearchByID returns a saved search of an owner
+func GetIssueSavedSearchByID(ctx context.Context, ownerID, id int64) (*IssueSavedSearch, error) {
+	s, exist, err := db.Get[IssueSavedSearch](ctx, builder.Eq{"id": id, "owner_id": ownerID})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, util.NewNotExistErrorf("saved search %d does not exist", id)
+	}
+	return s, nil
+}
+
+// GetIssueSavedSearches returns the saved searches of an owner sorted by name
+func GetIssueSavedSearches(ctx context.Context, ownerID int64) ([]*IssueSavedSearch, error) {
+	searches := make([]*IssueSavedSearch, 0, 10)
+	return searches, db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID}).Asc("name").Asc("id").Find(&searches)
+}
+
+// issueSavedSearchesOfRepoCond returns the condition of the searches of a repository
+// saved by a user and shared by the organization of the repository
+func issueSavedSearchesOfRepoCond(ctx context.Context, repo *repo_model.Repository, doerID int64) (builder.Cond, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	cond := builder.NewCond()
+	if doerID > 0 {
+		cond = cond.Or(builder.Eq{"owner_id": doerID, "repo_id": repo.ID})
+	}
+	if repo.Owner.IsOrganization() {
+		cond = cond.Or(builder.Eq{"owner_id": repo.OwnerID}.And(builder.In("repo_id", 0, repo.ID)))
+	}
+	if !cond.IsValid() {
+		// an anonymous user of a repository of a user has no searches
+		cond = builder.Expr("1 = 0")
+	}
+	return cond, nil
+}
+
+// GetIssueSavedSearchOfRepo returns a search of a repository saved by a user or shared by its organization
+func GetIssueSavedSearchOfRepo(ctx context.Context, repo *repo_model.Repository, doerID, id int64) (*IssueSavedSearch, error) {
+	cond, err := issueSavedSearchesOfRepoCond(ctx, repo, doerID)
+	if err != nil {
+		return nil, err
+	}
+	s, exist, err := db.Get[IssueSavedSearch](ctx, cond.And(builder.Eq{"id": id}))
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, util.NewNotExistErrorf("saved search %d does not exist", id)
+	}
+	return s, nil
+}
+
+// GetIssueSavedSearchesOfRepo returns the searches of the issues or the pull requests of a repository
+// saved by a user and shared by the organization of the repository, sorted by name.
+func GetIssueSavedSearchesOfRepo(ctx context.Context, repo *repo_model.Repository, doerID int64, isPull optional.Option[bool]) ([]*IssueSavedSearch, error) {
+	cond, err := issueSavedSearchesOfRepoCond(ctx, repo, doerID)
+	if err != nil {
+		return nil, err
+	}
+	if isPull.Has() {
+		cond = cond.And(builder.Eq{"is_pull": isPull.Value()})
+	}
+	searches := make([]*IssueSavedSearch, 0, 10)
+	return searches, db.GetEngine(ctx).Where(cond).Asc("name").Asc("id").Find(&searches)
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeIssueSavedSearchQuery(t *testing.T) {
+	for query, expected := range map[string]string{
+		"":                                     "",
+		"?page=2":                              "",
+		"state=closed&labels=1,2&page=3&q=bug": "labels=1%2C2&q=bug&state=closed",
+		"field=Severity:high&field=Team:web":   "field=Severity%3Ahigh&field=Team%3Aweb",
+		"poster=&assignee=-1&unknown=1":        "assignee=-1",
+	} {
+		normalized, err := issues_model.NormalizeIssueSavedSearchQuery(query)
+		require.NoError(t, err)
+		assert.Equal(t, expected, normalized, query)
+	}
+
+	_, err := issues_model.NormalizeIssueSavedSearchQuery("q=%zz")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestIssueSavedSearch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	otherRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	personal := &issues_model.IssueSavedSearch{OwnerID: 2, RepoID: repo.ID, Name: " Mine ", Query: "type=assigned&page=2"}
+	require.NoError(t, issues_model.NewIssueSavedSearch(t.Context(), personal))
+	assert.Equal(t, "Mine", personal.Name)
+	assert.Equal(t, "type=assigned", personal.Query)
+	shared := &issues_model.IssueSavedSearch{OwnerID: repo.OwnerID, Name: "Bugs", Query: "labels=1"}
+	require.NoError(t, issues_model.NewIssueSavedSearch(t.Context(), shared))
+	sharedPulls := &issues_model.IssueSavedSearch{OwnerID: repo.OwnerID, IsPull: true, Name: "Bugs", Query: "labels=1"}
+	require.NoError(t, issues_model.NewIssueSavedSearch(t.Context(), sharedPulls))
+	require.NoError(t, issues_model.NewIssueSavedSearch(t.Context(), &issues_model.IssueSavedSearch{OwnerID: 2, RepoID: otherRepo.ID, Name: "Other"}))
+
+	for _, s := range []*issues_model.IssueSavedSearch{
+		{OwnerID: 2, RepoID: repo.ID, Name: "mine"},
+		{OwnerID: 2, RepoID: repo.ID, Name: " "},
+		{RepoID: repo.ID, Name: "No owner"},
+	} {
+		assert.Error(t, issues_model.NewIssueSavedSearch(t.Context(), s), s.Name)
+	}
+
+	searches, err := issues_model.GetIssueSavedSearchesOfRepo(t.Context(), repo, 2, optional.Some(false))
+	require.NoError(t, err)
+	if assert.Len(t, searches, 2) {
+		assert.Equal(t, shared.ID, searches[0].ID)
+		assert.True(t, searches[0].IsShared(repo))
+		assert.Equal(t, personal.ID, searches[1].ID)
+		assert.False(t, searches[1].IsShared(repo))
+	}
+
+	searches, err = issues_model.GetIssueSavedSearchesOfRepo(t.Context(), repo, 0, optional.None[bool]())
+	require.NoError(t, err)
+	assert.Len(t, searches, 2)
+
+	searches, err = issues_model.GetIssueSavedSearchesOfRepo(t.Context(), otherRepo, 0, optional.None[bool]())
+	require.NoError(t, err)
+	assert.Empty(t, searches)
+
+	_, err = issues_model.GetIssueSavedSearchOfRepo(t.Context(), repo, 4, personal.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	s, err := issues_model.GetIssueSavedSearchOfRepo(t.Context(), repo, 4, shared.ID)
+	require.NoError(t, err)
+
+	s.Name = "Mine"
+	assert.NoError(t, issues_model.UpdateIssueSavedSearch(t.Context(), s))
+	sharedPulls.Name = "mine"
+	sharedPulls.Query = "state=closed&page=1"
+	require.NoError(t, issues_model.UpdateIssueSavedSearch(t.Context(), sharedPulls))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSavedSearch{ID: sharedPulls.ID, Name: "mine", Query: "state=closed"})
+
+	require.NoError(t, issues_model.DeleteIssueSavedSearch(t.Context(), personal.ID))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSavedSearch{ID: personal.ID})
+}
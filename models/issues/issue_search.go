@@ -48,9 +48,11 @@ type IssuesOptions struct { //nolint:revive // export stutter
 	IssueIDs           []int64
 	UpdatedAfterUnix   int64
 	UpdatedBeforeUnix  int64
+	FieldValues        map[int64]string // normalized values of custom fields by field ID
 	// prioritize issues from this repo
 	PriorityRepoID int64
 	IsArchived     optional.Option[bool]
+	HideModerated  bool               // leave out the issues hidden by a moderator, it's set by the lists of the views
 	Owner          *user_model.User   // issues permission scope, it could be an organization or a user
 	Team           *organization.Team // issues permission scope
 	Doer           *user_model.User   // issues permission scope
@@ -242,6 +244,10 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyRepoConditions(sess, opts)
 
+	if opts.HideModerated {
+		sess.And("issue.is_hidden=?", false)
+	}
+
 	if opts.IsClosed.Has() {
 		sess.And("issue.is_closed=?", opts.IsClosed.Value())
 	}
@@ -278,6 +284,10 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyProjectColumnCondition(sess, opts)
 
+	if len(opts.FieldValues) > 0 {
+		sess.And(issueFieldValuesCond(opts.FieldValues))
+	}
+
 	if opts.IsPull.Has() {
 		sess.And("issue.is_pull=?", opts.IsPull.Value())
 	}
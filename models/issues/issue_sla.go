@@ -0,0 +1,241 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// IssueSLAKind is the kind of the target of an SLA rule
+type IssueSLAKind string
+
+const (
+	// IssueSLAKindResponse is the time to the first comment of another user than the poster
+	IssueSLAKindResponse IssueSLAKind = "response"
+	// IssueSLAKindResolution is the time to the closing of the issue
+	IssueSLAKindResolution IssueSLAKind = "resolution"
+)
+
+// IssueSLAState is the state of the target of an SLA rule for an issue
+type IssueSLAState string
+
+const (
+	IssueSLAStatePending  IssueSLAState = "pending"
+	IssueSLAStateMet      IssueSLAState = "met"
+	IssueSLAStateBreached IssueSLAState = "breached"
+)
+
+// IssueSLARule is a service level rule of the issues of a repository having some labels.
+// An issue matching a rule must get a response and be resolved in time,
+// and it's nudged when it has had no activity for some days.
+type IssueSLARule struct {
+	ID     int64  `xorm:"pk autoincr"`
+	RepoID int64  `xorm:"INDEX NOT NULL"`
+	Name   string `xorm:"NOT NULL"`
+	// LabelIDs are the labels an issue must have to match the rule, none for all the issues
+	LabelIDs []int64 `xorm:"TEXT JSON"`
+	// ExemptLabelIDs are the labels of the issues excluded from the rule
+	ExemptLabelIDs  []int64            `xorm:"TEXT JSON"`
+	ResponseHours   int                `xorm:"NOT NULL DEFAULT 0"`
+	ResolutionHours int                `xorm:"NOT NULL DEFAULT 0"`
+	StaleDays       int                `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix     timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// IssueSLANotice records a reminder or a breach of an SLA rule sent for an issue, so that it's sent once
+type IssueSLANotice struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	RuleID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Kind        IssueSLAKind       `xorm:"UNIQUE(s) VARCHAR(10) NOT NULL"`
+	IsBreach    bool               `xorm:"UNIQUE(s) NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueSLARule))
+	db.RegisterModel(new(IssueSLANotice))
+}
+
+// IssueSLAStatus is the state of the target of an SLA rule for an issue
+type IssueSLAStatus struct {
+	Rule  *IssueSLARule
+	Kind  IssueSLAKind
+	State IssueSLAState
+	// Due is the deadline of the target
+	Due timeutil.TimeStamp
+	// Completed is the time of the response or of the resolution, 0 if it isn't completed yet
+	Completed timeutil.TimeStamp
+}
+
+// Matches returns whether the rule applies to an issue with the labels
+func (r *IssueSLARule) Matches(labelIDs []int64) bool {
+	for _, id := range r.LabelIDs {
+		if !slices.Contains(labelIDs, id) {
+			return false
+		}
+	}
+	for _, id := range r.ExemptLabelIDs {
+		if slices.Contains(labelIDs, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchLabelIDs returns the label filter of the issues matching the rule, the exempt labels are negative
+func (r *IssueSLARule) SearchLabelIDs() []int64 {
+	labelIDs := slices.Clone(r.LabelIDs)
+	for _, id := range r.ExemptLabelIDs {
+		labelIDs = append(labelIDs, -id)
+	}
+	return labelIDs
+}
+
+// Status returns the state of a target of the rule for an issue created at a time,
+// nil if the rule has no target of the kind
+func (r *IssueSLARule) Status(kind IssueSLAKind, created, completed timeutil.TimeStamp, now time.Time) *IssueSLAStatus {
+	hours := r.ResponseHours
+	if kind == IssueSLAKindResolution {
+		hours = r.ResolutionHours
+	}
+	if hours <= 0 {
+		return nil
+	}
+
+	status := &IssueSLAStatus{
+		Rule:      r,
+		Kind:      kind,
+		Due:       created.Add(int64(hours) * 3600),
+		Completed: completed,
+	}
+	switch {
+	case completed > 0 && completed <= status.Due:
+		status.State = IssueSLAStateMet
+	case completed > 0 || timeutil.TimeStamp(now.Unix()) > status.Due:
+		status.State = IssueSLAStateBreached
+	default:
+		status.State = IssueSLAStatePending
+	}
+	return status
+}
+
+func (r *IssueSLARule) validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of the rule is empty")
+	}
+	if r.ResponseHours < 0 || r.ResolutionHours < 0 || r.StaleDays < 0 {
+		return util.NewInvalidArgumentErrorf("the times of %s must not be negative", r.Name)
+	}
+	if r.ResponseHours == 0 && r.ResolutionHours == 0 && r.StaleDays == 0 {
+		return util.NewInvalidArgumentErrorf("the rule %s has no response time, resolution time or stale days", r.Name)
+	}
+	for _, id := range r.LabelIDs {
+		if slices.Contains(r.ExemptLabelIDs, id) {
+			return util.NewInvalidArgumentErrorf("the label %d of %s is both required and exempt", id, r.Name)
+		}
+	}
+	return nil
+}
+
+// NewIssueSLARule creates an SLA rule of a repository
+func NewIssueSLARule(ctx context.Context, r *IssueSLARule) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, r)
+}
+
+// UpdateIssueSLARule updates an SLA rule, the reminders and breaches already sent aren't sent again
+func UpdateIssueSLARule(ctx context.Context, r *IssueSLARule) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(r.ID).
+		Cols("name", "label_i_ds", "exempt_label_i_ds", "response_hours", "resolution_hours", "stale_days").
+		Update(r)
+	return err
+}
+
+// DeleteIssueSLARule deletes an SLA rule and its notices
+func DeleteIssueSLARule(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &IssueSLANotice{RuleID: id}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[IssueSLARule](ctx, id)
+		return err
+	})
+}
+
+// DeleteIssueSLARules deletes the SLA rules of a repository and their notices
+func DeleteIssueSLARules(ctx context.Context, repoID int64) error {
+	if _, err := db.GetEngine(ctx).In("rule_id", builder.Select("id").From("issue_sla_rule").Where(builder.Eq{"repo_id": repoID})).
+		Delete(new(IssueSLANotice)); err != nil {
+		return err
+	}
+	_, err := db.DeleteByBean(ctx, &IssueSLARule{RepoID: repoID})
+	return err
+}
+
+// GetIssueSLARuleByID returns an SLA rule of a repository
+func GetIssueSLARuleByID(ctx context.Context, repoID, id int64) (*IssueSLARule, error) {
+	r, exist, err := db.Get[IssueSLARule](ctx, builder.Eq{"id": id, "repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, util.NewNotExistErrorf("issue SLA rule %d does not exist", id)
+	}
+	return r, nil
+}
+
+// GetIssueSLARules returns the SLA rules of a repository sorted by name
+func GetIssueSLARules(ctx context.Context, repoID int64) ([]*IssueSLARule, error) {
+	rules := make([]*IssueSLARule, 0, 5)
+	return rules, db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}).Asc("name").Asc("id").Find(&rules)
+}
+
+// GetRepoIDsWithIssueSLARules returns the IDs of the repositories having SLA rules
+func GetRepoIDsWithIssueSLARules(ctx context.Context) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).Table("issue_sla_rule").Distinct("repo_id").Asc("repo_id").Find(&repoIDs)
+}
+
+// GetIssueFirstResponseTime returns the time of the first comment or review of another user than the poster of an issue,
+// 0 if there is none
+func GetIssueFirstResponseTime(ctx context.Context, issue *Issue) (timeutil.TimeStamp, error) {
+	comment := new(Comment)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"issue_id": issue.ID}.
+		And(builder.In("type", CommentTypeComment, CommentTypeReview, CommentTypeCode)).
+		And(builder.Neq{"poster_id": issue.PosterID}).
+		And(builder.Gt{"poster_id": 0})).
+		Asc("created_unix").Get(comment)
+	if err != nil || !has {
+		return 0, err
+	}
+	return comment.CreatedUnix, nil
+}
+
+// AddIssueSLANotice records a reminder or a breach sent for an issue, it returns false if it was already sent
+func AddIssueSLANotice(ctx context.Context, issueID, ruleID int64, kind IssueSLAKind, isBreach bool) (bool, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (bool, error) {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"issue_id": issueID, "rule_id": ruleID, "kind": kind, "is_breach": isBreach}).
+			Exist(new(IssueSLANotice))
+		if err != nil || exist {
+			return false, err
+		}
+		return true, db.Insert(ctx, &IssueSLANotice{IssueID: issueID, RuleID: ruleID, Kind: kind, IsBreach: isBreach})
+	})
+}
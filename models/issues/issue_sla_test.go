@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueSLARuleStatus(t *testing.T) {
+	rule := &issues_model.IssueSLARule{ResponseHours: 2, ResolutionHours: 0}
+	created := timeutil.TimeStamp(1000)
+	due := created.Add(2 * 3600)
+
+	assert.Nil(t, rule.Status(issues_model.IssueSLAKindResolution, created, 0, time.Unix(1000, 0)))
+
+	status := rule.Status(issues_model.IssueSLAKindResponse, created, 0, time.Unix(int64(due)-1, 0))
+	assert.Equal(t, issues_model.IssueSLAStatePending, status.State)
+	assert.Equal(t, due, status.Due)
+	assert.Equal(t, issues_model.IssueSLAStateBreached, rule.Status(issues_model.IssueSLAKindResponse, created, 0, time.Unix(int64(due)+1, 0)).State)
+	assert.Equal(t, issues_model.IssueSLAStateMet, rule.Status(issues_model.IssueSLAKindResponse, created, due, time.Unix(int64(due)+1, 0)).State)
+	assert.Equal(t, issues_model.IssueSLAStateBreached, rule.Status(issues_model.IssueSLAKindResponse, created, due+1, time.Unix(int64(due)+1, 0)).State)
+}
+
+func TestIssueSLARuleMatches(t *testing.T) {
+	rule := &issues_model.IssueSLARule{LabelIDs: []int64{1}, ExemptLabelIDs: []int64{4}}
+	assert.True(t, rule.Matches([]int64{1, 2}))
+	assert.False(t, rule.Matches([]int64{2}))
+	assert.False(t, rule.Matches([]int64{1, 4}))
+	assert.Equal(t, []int64{1, -4}, rule.SearchLabelIDs())
+	assert.True(t, (&issues_model.IssueSLARule{}).Matches(nil))
+}
+
+func TestIssueSLARule(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, rule := range []*issues_model.IssueSLARule{
+		{RepoID: 1, Name: " ", ResponseHours: 1},
+		{RepoID: 1, Name: "Negative", ResponseHours: -1},
+		{RepoID: 1, Name: "Empty"},
+		{RepoID: 1, Name: "Both", ResponseHours: 1, LabelIDs: []int64{1}, ExemptLabelIDs: []int64{1}},
+	} {
+		assert.ErrorIs(t, issues_model.NewIssueSLARule(t.Context(), rule), util.ErrInvalidArgument, rule.Name)
+	}
+
+	rule := &issues_model.IssueSLARule{RepoID: 1, Name: " Bugs ", LabelIDs: []int64{1}, ResponseHours: 4}
+	require.NoError(t, issues_model.NewIssueSLARule(t.Context(), rule))
+	assert.Equal(t, "Bugs", rule.Name)
+
+	rule.ResolutionHours, rule.ExemptLabelIDs = 48, []int64{2}
+	require.NoError(t, issues_model.UpdateIssueSLARule(t.Context(), rule))
+	rule, err := issues_model.GetIssueSLARuleByID(t.Context(), 1, rule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 48, rule.ResolutionHours)
+	assert.Equal(t, []int64{1}, rule.LabelIDs)
+	assert.Equal(t, []int64{2}, rule.ExemptLabelIDs)
+	rule.ExemptLabelIDs = nil
+	require.NoError(t, issues_model.UpdateIssueSLARule(t.Context(), rule))
+	_, err = issues_model.GetIssueSLARuleByID(t.Context(), 2, rule.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	repoIDs, err := issues_model.GetRepoIDsWithIssueSLARules(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, repoIDs)
+
+	// the first comment of issue 1 is posted by another user than the poster
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	responded, err := issues_model.GetIssueFirstResponseTime(t.Context(), issue)
+	require.NoError(t, err)
+	assert.Equal(t, timeutil.TimeStamp(946684811), responded)
+
+	added, err := issues_model.AddIssueSLANotice(t.Context(), issue.ID, rule.ID, issues_model.IssueSLAKindResponse, false)
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = issues_model.AddIssueSLANotice(t.Context(), issue.ID, rule.ID, issues_model.IssueSLAKindResponse, false)
+	require.NoError(t, err)
+	assert.False(t, added)
+	added, err = issues_model.AddIssueSLANotice(t.Context(), issue.ID, rule.ID, issues_model.IssueSLAKindResponse, true)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	require.NoError(t, issues_model.DeleteIssueSLARules(t.Context(), 1))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSLARule{ID: rule.ID})
+	unittest.AssertCount(t, &issues_model.IssueSLANotice{RuleID: rule.ID}, 0)
+}
@@ -164,6 +164,10 @@ func applyIssuesOptions(sess *xorm.Session, opts *IssuesOptions, issueIDs []int6
 		applyReviewedCondition(sess, opts.ReviewedID)
 	}
 
+	if len(opts.FieldValues) > 0 {
+		sess.And(issueFieldValuesCond(opts.FieldValues))
+	}
+
 	if opts.IsPull.Has() {
 		sess.And("issue.is_pull=?", opts.IsPull.Value())
 	}
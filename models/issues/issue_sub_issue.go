@@ -0,0 +1,199 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSubIssueHasParent represents an error where an issue added as a sub-issue already has a parent
+type ErrSubIssueHasParent struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueHasParent checks if an error is a ErrSubIssueHasParent.
+func IsErrSubIssueHasParent(err error) bool {
+	_, ok := err.(ErrSubIssueHasParent)
+	return ok
+}
+
+func (err ErrSubIssueHasParent) Error() string {
+	return fmt.Sprintf("issue already has a parent issue [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueHasParent) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrCircularSubIssue represents an error where an issue would become a sub-issue of itself or of its own sub-issues
+type ErrCircularSubIssue struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("issue can't be a sub-issue of itself or of its sub-issues [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// SubIssue links an issue to its parent issue, an issue has at most one parent
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// SubIssueProgress counts the closed issues of a tree of sub-issues
+type SubIssueProgress struct {
+	Total  int
+	Closed int
+}
+
+// Percent returns the percentage of the closed issues
+func (p SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Closed * 100 / p.Total
+}
+
+// SubIssueNode is an issue of a tree of sub-issues with its own sub-issues
+type SubIssueNode struct {
+	Issue     *Issue
+	SubIssues SubIssueNodes
+}
+
+// SubIssueNodes is a list of sub-issues
+type SubIssueNodes []*SubIssueNode
+
+// Progress returns the progress of the sub-issues at all depths
+func (nodes SubIssueNodes) Progress() SubIssueProgress {
+	var progress SubIssueProgress
+	for _, node := range nodes {
+		progress.Total++
+		if node.Issue.IsClosed {
+			progress.Closed++
+		}
+		sub := node.SubIssues.Progress()
+		progress.Total += sub.Total
+		progress.Closed += sub.Closed
+	}
+	return progress
+}
+
+// getParentIssueID returns the ID of the parent of an issue, 0 if it has none
+func getParentIssueID(ctx context.Context, issueID int64) (int64, error) {
+	var parentID int64
+	_, err := db.GetEngine(ctx).Table("sub_issue").Where(builder.Eq{"issue_id": issueID}).Select("parent_id").Get(&parentID)
+	return parentID, err
+}
+
+// AddSubIssue makes an issue a sub-issue of a parent issue
+func AddSubIssue(ctx context.Context, parent, issue *Issue) error {
+	if parent.IsPull || issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests can't have or be sub-issues")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		parentID, err := getParentIssueID(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if parentID > 0 {
+			return ErrSubIssueHasParent{IssueID: issue.ID, ParentID: parentID}
+		}
+
+		// the issue must not be the parent or one of its ancestors
+		visited := make(container.Set[int64])
+		for ancestorID := parent.ID; ancestorID > 0 && visited.Add(ancestorID); {
+			if ancestorID == issue.ID {
+				return ErrCircularSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+			}
+			if ancestorID, err = getParentIssueID(ctx, ancestorID); err != nil {
+				return err
+			}
+		}
+
+		return db.Insert(ctx, &SubIssue{ParentID: parent.ID, IssueID: issue.ID})
+	})
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of a parent issue
+func RemoveSubIssue(ctx context.Context, parent, issue *Issue) error {
+	deleted, err := db.GetEngine(ctx).Where(builder.Eq{"parent_id": parent.ID, "issue_id": issue.ID}).Delete(new(SubIssue))
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return util.NewNotExistErrorf("issue %d is not a sub-issue of issue %d", issue.ID, parent.ID)
+	}
+	return nil
+}
+
+// GetParentIssue returns the parent of an issue, nil if it has none
+func GetParentIssue(ctx context.Context, issue *Issue) (*Issue, error) {
+	parentID, err := getParentIssueID(ctx, issue.ID)
+	if err != nil || parentID == 0 {
+		return nil, err
+	}
+	return GetIssueByID(ctx, parentID)
+}
+
+// GetSubIssueTree returns the sub-issues of an issue at all depths, in the order they were added
+func GetSubIssueTree(ctx context.Context, issue *Issue) (SubIssueNodes, error) {
+	nodes := map[int64]*SubIssueNode{issue.ID: {Issue: issue}}
+	for parentIDs := []int64{issue.ID}; len(parentIDs) > 0; {
+		links := make([]*SubIssue, 0, len(parentIDs))
+		if err := db.GetEngine(ctx).In("parent_id", parentIDs).Asc("id").Find(&links); err != nil {
+			return nil, err
+		}
+		issueIDs := make([]int64, 0, len(links))
+		for _, link := range links {
+			issueIDs = append(issueIDs, link.IssueID)
+		}
+		issues, err := GetIssuesByIDs(ctx, issueIDs)
+		if err != nil {
+			return nil, err
+		}
+		issuesByID := make(map[int64]*Issue, len(issues))
+		for _, issue := range issues {
+			issuesByID[issue.ID] = issue
+		}
+
+		parentIDs = parentIDs[:0]
+		for _, link := range links {
+			sub, ok := issuesByID[link.IssueID]
+			if _, visited := nodes[link.IssueID]; !ok || visited {
+				continue
+			}
+			node := &SubIssueNode{Issue: sub}
+			nodes[sub.ID] = node
+			nodes[link.ParentID].SubIssues = append(nodes[link.ParentID].SubIssues, node)
+			parentIDs = append(parentIDs, sub.ID)
+		}
+	}
+	return nodes[issue.ID].SubIssues, nil
+}
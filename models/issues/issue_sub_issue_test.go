@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue4 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	issue7 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+
+	// 1 -> 5 -> 7, 1 -> 4
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), issue1, issue5))
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), issue5, issue7))
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), issue1, issue4))
+
+	err := issues_model.AddSubIssue(t.Context(), issue4, issue7)
+	assert.True(t, issues_model.IsErrSubIssueHasParent(err))
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = issues_model.AddSubIssue(t.Context(), issue7, issue1)
+	assert.True(t, issues_model.IsErrCircularSubIssue(err))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = issues_model.AddSubIssue(t.Context(), issue1, issue1)
+	assert.True(t, issues_model.IsErrCircularSubIssue(err))
+	assert.ErrorIs(t, issues_model.AddSubIssue(t.Context(), issue1, pull), util.ErrInvalidArgument)
+
+	parent, err := issues_model.GetParentIssue(t.Context(), issue7)
+	require.NoError(t, err)
+	assert.Equal(t, issue5.ID, parent.ID)
+	parent, err = issues_model.GetParentIssue(t.Context(), issue1)
+	require.NoError(t, err)
+	assert.Nil(t, parent)
+
+	tree, err := issues_model.GetSubIssueTree(t.Context(), issue1)
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	assert.Equal(t, issue5.ID, tree[0].Issue.ID)
+	require.Len(t, tree[0].SubIssues, 1)
+	assert.Equal(t, issue7.ID, tree[0].SubIssues[0].Issue.ID)
+	assert.Equal(t, issue4.ID, tree[1].Issue.ID)
+	assert.Empty(t, tree[1].SubIssues)
+	// issues 4 and 5 are closed
+	progress := tree.Progress()
+	assert.Equal(t, issues_model.SubIssueProgress{Total: 3, Closed: 2}, progress)
+	assert.Equal(t, 66, progress.Percent())
+
+	assert.ErrorIs(t, issues_model.RemoveSubIssue(t.Context(), issue1, issue7), util.ErrNotExist)
+	require.NoError(t, issues_model.RemoveSubIssue(t.Context(), issue5, issue7))
+	require.NoError(t, issues_model.AddSubIssue(t.Context(), issue4, issue7))
+}
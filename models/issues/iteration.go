@@ -0,0 +1,421 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// Iteration is a date-bounded time box, a sprint, of the issues of a repository
+// or of all the repositories of an organization
+type Iteration struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	OrgID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	Description string             `xorm:"TEXT"`
+	StartUnix   timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	EndUnix     timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	// Capacity is the planned work of the iteration in seconds of time estimate, 0 if it isn't planned
+	Capacity    int64              `xorm:"NOT NULL DEFAULT 0"`
+	IsClosed    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	ClosedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// IterationIssue records the time an issue was part of an iteration, an issue is in at most one iteration at a time
+type IterationIssue struct {
+	ID          int64 `xorm:"pk autoincr"`
+	IterationID int64 `xorm:"INDEX NOT NULL"`
+	IssueID     int64 `xorm:"INDEX NOT NULL"`
+	// CarriedFromID is the iteration the open issue was carried over from when it was closed
+	CarriedFromID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	AddedUnix     timeutil.TimeStamp `xorm:"NOT NULL"`
+	// RemovedUnix is the time the issue was removed from the iteration, 0 while it's in the iteration
+	RemovedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(Iteration))
+	db.RegisterModel(new(IterationIssue))
+}
+
+// BelongsToOrg returns whether the iteration belongs to an organization
+func (it *Iteration) BelongsToOrg() bool {
+	return it.OrgID > 0
+}
+
+// IsUsableInRepo returns whether the issues of a repository can be part of the iteration
+func (it *Iteration) IsUsableInRepo(repo *repo_model.Repository) bool {
+	return it.RepoID == repo.ID || (it.OrgID > 0 && it.OrgID == repo.OwnerID)
+}
+
+// lastTime returns the end of the iteration or the time it was closed, not after now
+func (it *Iteration) lastTime(now time.Time) timeutil.TimeStamp {
+	last := min(it.EndUnix, timeutil.TimeStamp(now.Unix()))
+	if it.IsClosed {
+		last = min(last, it.ClosedUnix)
+	}
+	return last
+}
+
+func (it *Iteration) validate() error {
+	it.Name = strings.TrimSpace(it.Name)
+	if it.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of the iteration is empty")
+	}
+	if it.StartUnix <= 0 || it.EndUnix <= it.StartUnix {
+		return util.NewInvalidArgumentErrorf("the iteration %s must end after it starts", it.Name)
+	}
+	if it.Capacity < 0 {
+		return util.NewInvalidArgumentErrorf("the capacity of %s must not be negative", it.Name)
+	}
+	return nil
+}
+
+// NewIteration creates an iteration of a repository or an organization
+func NewIteration(ctx context.Context, it *Iteration) error {
+	if (it.RepoID > 0) == (it.OrgID > 0) {
+		return util.NewInvalidArgumentErrorf("the iteration must belong to either a repository or an organization")
+	}
+	if err := it.validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, it)
+}
+
+// UpdateIteration updates the name, the description, the dates and the capacity of an iteration
+func UpdateIteration(ctx context.Context, it *Iteration) error {
+	if err := it.validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(it.ID).Cols("name", "description", "start_unix", "end_unix", "capacity").Update(it)
+	return err
+}
+
+// DeleteIteration deletes an iteration and the records of its issues, the issues go back to the backlog
+func DeleteIteration(ctx context.Context, it *Iteration) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &IterationIssue{IterationID: it.ID}); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"carried_from_id": it.ID}).
+			Cols("carried_from_id").Update(&IterationIssue{}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[Iteration](ctx, it.ID)
+		return err
+	})
+}
+
+// DeleteIterations deletes the iterations of a repository or an organization and the records of their issues
+func DeleteIterations(ctx context.Context, repoID, orgID int64) error {
+	cond := builder.Eq{"repo_id": repoID, "org_id": orgID}
+	if _, err := db.GetEngine(ctx).In("iteration_id", builder.Select("id").From("iteration").Where(cond)).
+		Delete(new(IterationIssue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(cond).Delete(new(Iteration))
+	return err
+}
+
+// GetIterationByID returns an iteration of a repository or an organization
+func GetIterationByID(ctx context.Context, repoID, orgID, id int64) (*Iteration, error) {
+	it, exist, err := db.Get[Iteration](ctx, builder.Eq{"id": id, "repo_id": repoID, "org_id": orgID})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, util.NewNotExistErrorf("iteration %d does not exist", id)
+	}
+	return it, nil
+}
+
+// GetIterationByIDForRepo returns an iteration of a repository or of its organization
+func GetIterationByIDForRepo(ctx context.Context, repo *repo_model.Repository, id int64) (*Iteration, error) {
+	it, exist, err := db.Get[Iteration](ctx, builder.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	} else if !exist || !it.IsUsableInRepo(repo) {
+		return nil, util.NewNotExistErrorf("iteration %d does not exist", id)
+	}
+	return it, nil
+}
+
+// GetIterations returns the iterations of a repository or an organization sorted by start date
+func GetIterations(ctx context.Context, repoID, orgID int64, isClosed optional.Option[bool]) ([]*Iteration, error) {
+	cond := builder.Eq{"repo_id": repoID, "org_id": orgID}
+	if isClosed.Has() {
+		cond["is_closed"] = isClosed.Value()
+	}
+	iterations := make([]*Iteration, 0, 10)
+	return iterations, db.GetEngine(ctx).Where(cond).Asc("start_unix").Asc("id").Find(&iterations)
+}
+
+// GetIterationsOfRepo returns the iterations of a repository and of its organization sorted by start date
+func GetIterationsOfRepo(ctx context.Context, repo *repo_model.Repository, isClosed optional.Option[bool]) ([]*Iteration, error) {
+	var cond builder.Cond = builder.Eq{"repo_id": repo.ID}.Or(builder.Eq{"org_id": repo.OwnerID})
+	if isClosed.Has() {
+		cond = cond.And(builder.Eq{"is_closed": isClosed.Value()})
+	}
+	iterations := make([]*Iteration, 0, 10)
+	return iterations, db.GetEngine(ctx).Where(cond).Asc("start_unix").Asc("id").Find(&iterations)
+}
+
+// GetIssueIteration returns the iteration an issue is part of, nil if it's in the backlog
+func GetIssueIteration(ctx context.Context, issueID int64) (*Iteration, error) {
+	it := new(Iteration)
+	has, err := db.GetEngine(ctx).In("id", builder.Select("iteration_id").From("iteration_issue").
+		Where(builder.Eq{"issue_id": issueID, "removed_unix": 0})).Get(it)
+	if err != nil || !has {
+		return nil, err
+	}
+	return it, nil
+}
+
+// removeIssueFromIteration ends the time an issue is part of its current iteration
+func removeIssueFromIteration(ctx context.Context, issueID int64, now timeutil.TimeStamp) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"issue_id": issueID, "removed_unix": 0}).
+		Cols("removed_unix").Update(&IterationIssue{RemovedUnix: now})
+	return err
+}
+
+// SetIssueIteration moves an issue to an open iteration, or to the backlog if the iteration is nil
+func SetIssueIteration(ctx context.Context, issue *Issue, it *Iteration) error {
+	if it != nil && it.IsClosed {
+		return util.NewInvalidArgumentErrorf("the iteration %s is closed", it.Name)
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		current, err := GetIssueIteration(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if current != nil && it != nil && current.ID == it.ID {
+			return nil
+		}
+
+		now := timeutil.TimeStampNow()
+		if err := removeIssueFromIteration(ctx, issue.ID, now); err != nil {
+			return err
+		}
+		if it == nil {
+			return nil
+		}
+		return db.Insert(ctx, &IterationIssue{IterationID: it.ID, IssueID: issue.ID, AddedUnix: now})
+	})
+}
+
+// CloseIteration closes an iteration, its open issues are carried over to another open iteration
+// or go back to the backlog if carryOverTo is nil
+func CloseIteration(ctx context.Context, it, carryOverTo *Iteration) error {
+	if it.IsClosed {
+		return util.NewInvalidArgumentErrorf("the iteration %s is already closed", it.Name)
+	}
+	if carryOverTo != nil && (carryOverTo.ID == it.ID || carryOverTo.IsClosed) {
+		return util.NewInvalidArgumentErrorf("the open issues can only be carried over to another open iteration")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		openIssueIDs := make([]int64, 0, 10)
+		if err := db.GetEngine(ctx).Table("iteration_issue").Select("issue_id").
+			Where(builder.Eq{"iteration_id": it.ID, "removed_unix": 0}).
+			In("issue_id", builder.Select("id").From("issue").Where(builder.Eq{"is_closed": false})).
+			Find(&openIssueIDs); err != nil {
+			return err
+		}
+
+		now := timeutil.TimeStampNow()
+		if len(openIssueIDs) > 0 {
+			if _, err := db.GetEngine(ctx).Where(builder.Eq{"iteration_id": it.ID, "removed_unix": 0}).In("issue_id", openIssueIDs).
+				Cols("removed_unix").Update(&IterationIssue{RemovedUnix: now}); err != nil {
+				return err
+			}
+		}
+		if carryOverTo != nil && len(openIssueIDs) > 0 {
+			links := make([]*IterationIssue, 0, len(openIssueIDs))
+			for _, issueID := range openIssueIDs {
+				links = append(links, &IterationIssue{IterationID: carryOverTo.ID, IssueID: issueID, CarriedFromID: it.ID, AddedUnix: now})
+			}
+			if err := db.Insert(ctx, links); err != nil {
+				return err
+			}
+		}
+
+		it.IsClosed = true
+		it.ClosedUnix = now
+		_, err := db.GetEngine(ctx).ID(it.ID).Cols("is_closed", "closed_unix").Update(it)
+		return err
+	})
+}
+
+// repoIssuesCond returns the condition of the records of the issues of a repository, of all the issues if repoID is 0
+func repoIssuesCond(repoID int64) builder.Cond {
+	if repoID == 0 {
+		return builder.NewCond()
+	}
+	return builder.In("issue_id", builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID}))
+}
+
+// findIterationIssues returns the records of the issues of some iterations and the issues by ID,
+// only the issues of a repository if repoID isn't 0
+func findIterationIssues(ctx context.Context, iterationIDs []int64, repoID int64) ([]*IterationIssue, map[int64]*Issue, error) {
+	links := make([]*IterationIssue, 0, 10)
+	if err := db.GetEngine(ctx).In("iteration_id", iterationIDs).And(repoIssuesCond(repoID)).
+		Asc("id").Find(&links); err != nil {
+		return nil, nil, err
+	}
+
+	issueIDs := make(container.Set[int64], len(links))
+	for _, link := range links {
+		issueIDs.Add(link.IssueID)
+	}
+	issues, err := GetIssuesByIDs(ctx, issueIDs.Values())
+	if err != nil {
+		return nil, nil, err
+	}
+	issuesByID := make(map[int64]*Issue, len(issues))
+	for _, issue := range issues {
+		issuesByID[issue.ID] = issue
+	}
+	return links, issuesByID, nil
+}
+
+// GetIterationIssues returns the issues which are part of an iteration, only the issues of a repository if repoID isn't 0
+func GetIterationIssues(ctx context.Context, it *Iteration, repoID int64) (IssueList, error) {
+	links, issuesByID, err := findIterationIssues(ctx, []int64{it.ID}, repoID)
+	if err != nil {
+		return nil, err
+	}
+	issues := make(IssueList, 0, len(links))
+	for _, link := range links {
+		if issue, ok := issuesByID[link.IssueID]; ok && link.RemovedUnix == 0 {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// IterationProgress is the remaining and the completed work of an iteration at a time
+type IterationProgress struct {
+	Time              timeutil.TimeStamp
+	OpenIssues        int
+	ClosedIssues      int
+	RemainingEstimate int64
+	CompletedEstimate int64
+}
+
+// GetIterationBurndown returns the progress of an iteration at its start, at the same time of each following day
+// and at its end, or until now if it isn't over. Only the issues of a repository are counted if repoID isn't 0.
+func GetIterationBurndown(ctx context.Context, it *Iteration, repoID int64, now time.Time) ([]*IterationProgress, error) {
+	links, issuesByID, err := findIterationIssues(ctx, []int64{it.ID}, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	progressAt := func(t timeutil.TimeStamp) *IterationProgress {
+		progress := &IterationProgress{Time: t}
+		for _, link := range links {
+			issue, ok := issuesByID[link.IssueID]
+			if !ok || link.AddedUnix > t || (link.RemovedUnix > 0 && link.RemovedUnix <= t) {
+				continue
+			}
+			if issue.IsClosed && issue.ClosedUnix <= t {
+				progress.ClosedIssues++
+				progress.CompletedEstimate += issue.TimeEstimate
+			} else {
+				progress.OpenIssues++
+				progress.RemainingEstimate += issue.TimeEstimate
+			}
+		}
+		return progress
+	}
+
+	const day = timeutil.TimeStamp(24 * 60 * 60)
+	last := it.lastTime(now)
+	if last < it.StartUnix {
+		return []*IterationProgress{}, nil
+	}
+	burndown := make([]*IterationProgress, 0, (last-it.StartUnix)/day+2)
+	for t := it.StartUnix; t < last; t += day {
+		burndown = append(burndown, progressAt(t))
+	}
+	burndown = append(burndown, progressAt(last))
+	return burndown, nil
+}
+
+// IterationVelocity is the committed and the completed work of an iteration
+type IterationVelocity struct {
+	Iteration *Iteration
+	// CommittedIssues counts the issues which have been part of the iteration
+	CommittedIssues   int
+	CommittedEstimate int64
+	// CompletedIssues counts the issues which were closed in the iteration
+	CompletedIssues   int
+	CompletedEstimate int64
+	// CarriedOverIssues counts the open issues carried over to another iteration when it was closed
+	CarriedOverIssues int
+}
+
+// GetIterationsVelocity returns the velocity of some iterations, only the issues of a repository are counted if repoID isn't 0
+func GetIterationsVelocity(ctx context.Context, iterations []*Iteration, repoID int64, now time.Time) ([]*IterationVelocity, error) {
+	velocities := make([]*IterationVelocity, 0, len(iterations))
+	byID := make(map[int64]*IterationVelocity, len(iterations))
+	iterationIDs := make([]int64, 0, len(iterations))
+	for _, it := range iterations {
+		velocity := &IterationVelocity{Iteration: it}
+		velocities = append(velocities, velocity)
+		byID[it.ID] = velocity
+		iterationIDs = append(iterationIDs, it.ID)
+	}
+	if len(iterations) == 0 {
+		return velocities, nil
+	}
+
+	links, issuesByID, err := findIterationIssues(ctx, iterationIDs, repoID)
+	if err != nil {
+		return nil, err
+	}
+	committed := make(map[int64]container.Set[int64], len(iterations))
+	for _, link := range links {
+		velocity, issue := byID[link.IterationID], issuesByID[link.IssueID]
+		if issue == nil {
+			continue
+		}
+		if committed[link.IterationID] == nil {
+			committed[link.IterationID] = make(container.Set[int64])
+		}
+		if committed[link.IterationID].Add(issue.ID) {
+			velocity.CommittedIssues++
+			velocity.CommittedEstimate += issue.TimeEstimate
+		}
+		if link.RemovedUnix == 0 && issue.IsClosed && issue.ClosedUnix <= velocity.Iteration.lastTime(now) {
+			velocity.CompletedIssues++
+			velocity.CompletedEstimate += issue.TimeEstimate
+		}
+	}
+
+	type carriedOver struct {
+		CarriedFromID int64
+		Count         int
+	}
+	counts := make([]*carriedOver, 0, len(iterations))
+	if err := db.GetEngine(ctx).Table("iteration_issue").In("carried_from_id", iterationIDs).And(repoIssuesCond(repoID)).
+		GroupBy("carried_from_id").Select("carried_from_id, COUNT(*) AS count").Find(&counts); err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		byID[c.CarriedFromID].CarriedOverIssues = c.Count
+	}
+	return velocities, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteration(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	const day = 24 * 60 * 60
+	now := time.Now()
+	start := timeutil.TimeStamp(now.Unix() - 3*day - 3600)
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	assert.ErrorIs(t, issues_model.NewIteration(t.Context(), &issues_model.Iteration{RepoID: 1, OrgID: 3, Name: "Sprint", StartUnix: start, EndUnix: start + day}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, issues_model.NewIteration(t.Context(), &issues_model.Iteration{RepoID: 1, Name: "Sprint", StartUnix: start, EndUnix: start}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, issues_model.NewIteration(t.Context(), &issues_model.Iteration{RepoID: 1, Name: " ", StartUnix: start, EndUnix: start + day}), util.ErrInvalidArgument)
+
+	sprint1 := &issues_model.Iteration{RepoID: 1, Name: "Sprint 1", StartUnix: start, EndUnix: start + 7*day, Capacity: 4 * 3600}
+	require.NoError(t, issues_model.NewIteration(t.Context(), sprint1))
+	sprint2 := &issues_model.Iteration{RepoID: 1, Name: "Sprint 2", StartUnix: start + 7*day, EndUnix: start + 14*day}
+	require.NoError(t, issues_model.NewIteration(t.Context(), sprint2))
+
+	iterations, err := issues_model.GetIterationsOfRepo(t.Context(), repo1, optional.Some(false))
+	require.NoError(t, err)
+	require.Len(t, iterations, 2)
+	assert.Equal(t, "Sprint 1", iterations[0].Name)
+
+	// issue 1 is open, issue 5 was closed during the second day of the iteration
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue1.TimeEstimate = 3600
+	require.NoError(t, issues_model.UpdateIssueCols(t.Context(), issue1, "time_estimate"))
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	issue5.TimeEstimate, issue5.ClosedUnix = 7200, start+day+day/2
+	require.NoError(t, issues_model.UpdateIssueCols(t.Context(), issue5, "time_estimate", "closed_unix"))
+
+	require.NoError(t, issues_model.SetIssueIteration(t.Context(), issue1, sprint2))
+	require.NoError(t, issues_model.SetIssueIteration(t.Context(), issue1, sprint1))
+	require.NoError(t, issues_model.SetIssueIteration(t.Context(), issue1, sprint1))
+	require.NoError(t, issues_model.SetIssueIteration(t.Context(), issue5, sprint1))
+	it, err := issues_model.GetIssueIteration(t.Context(), issue1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sprint1.ID, it.ID)
+	unittest.AssertCount(t, &issues_model.IterationIssue{IterationID: sprint1.ID}, 2)
+	unittest.AssertCount(t, &issues_model.IterationIssue{IterationID: sprint2.ID}, 1)
+
+	// the issues were planned at the start of the iteration
+	_, err = db.GetEngine(t.Context()).Where("iteration_id = ?", sprint1.ID).Cols("added_unix").
+		Update(&issues_model.IterationIssue{AddedUnix: start})
+	require.NoError(t, err)
+
+	burndown, err := issues_model.GetIterationBurndown(t.Context(), sprint1, 0, now)
+	require.NoError(t, err)
+	require.Len(t, burndown, 5)
+	assert.Equal(t, start, burndown[0].Time)
+	assert.Equal(t, 2, burndown[0].OpenIssues)
+	assert.EqualValues(t, 3*3600, burndown[0].RemainingEstimate)
+	assert.Equal(t, 1, burndown[2].OpenIssues)
+	assert.Equal(t, 1, burndown[2].ClosedIssues)
+	assert.EqualValues(t, 3600, burndown[2].RemainingEstimate)
+	assert.EqualValues(t, 7200, burndown[2].CompletedEstimate)
+	assert.Equal(t, timeutil.TimeStamp(now.Unix()), burndown[4].Time)
+
+	burndown, err = issues_model.GetIterationBurndown(t.Context(), sprint1, 2, now)
+	require.NoError(t, err)
+	assert.Zero(t, burndown[0].OpenIssues)
+
+	// the open issue is carried over to the next iteration
+	require.NoError(t, issues_model.CloseIteration(t.Context(), sprint1, sprint2))
+	assert.True(t, sprint1.IsClosed)
+	assert.ErrorIs(t, issues_model.CloseIteration(t.Context(), sprint1, nil), util.ErrInvalidArgument)
+	assert.ErrorIs(t, issues_model.SetIssueIteration(t.Context(), issue1, sprint1), util.ErrInvalidArgument)
+	it, err = issues_model.GetIssueIteration(t.Context(), issue1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sprint2.ID, it.ID)
+
+	issues, err := issues_model.GetIterationIssues(t.Context(), sprint1, 0)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.EqualValues(t, 5, issues[0].ID)
+
+	velocities, err := issues_model.GetIterationsVelocity(t.Context(), []*issues_model.Iteration{sprint1}, 0, now)
+	require.NoError(t, err)
+	require.Len(t, velocities, 1)
+	assert.Equal(t, 2, velocities[0].CommittedIssues)
+	assert.EqualValues(t, 3*3600, velocities[0].CommittedEstimate)
+	assert.Equal(t, 1, velocities[0].CompletedIssues)
+	assert.EqualValues(t, 7200, velocities[0].CompletedEstimate)
+	assert.Equal(t, 1, velocities[0].CarriedOverIssues)
+
+	require.NoError(t, issues_model.SetIssueIteration(t.Context(), issue1, nil))
+	it, err = issues_model.GetIssueIteration(t.Context(), issue1.ID)
+	require.NoError(t, err)
+	assert.Nil(t, it)
+
+	require.NoError(t, issues_model.DeleteIteration(t.Context(), sprint1))
+	unittest.AssertNotExistsBean(t, &issues_model.Iteration{ID: sprint1.ID})
+	unittest.AssertCount(t, &issues_model.IterationIssue{IterationID: sprint1.ID}, 0)
+	unittest.AssertCount(t, &issues_model.IterationIssue{CarriedFromID: sprint1.ID}, 0)
+
+	require.NoError(t, issues_model.DeleteIterations(t.Context(), 1, 0))
+	unittest.AssertCount(t, &issues_model.Iteration{}, 0)
+	unittest.AssertCount(t, &issues_model.IterationIssue{}, 0)
+}
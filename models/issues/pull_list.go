@@ -29,6 +29,8 @@ type PullRequestsOptions struct {
 	MilestoneID int64
 	PosterID    int64
 	BaseBranch  string
+	// HideModerated leaves out the pull requests hidden by a moderator
+	HideModerated bool
 }
 
 func listPullRequestStatement(ctx context.Context, baseRepoID int64, opts *PullRequestsOptions) *xorm.Session {
@@ -39,6 +41,9 @@ func listPullRequestStatement(ctx context.Context, baseRepoID int64, opts *PullR
 	}
 
 	sess.Join("INNER", "issue", "pull_request.issue_id = issue.id")
+	if opts.HideModerated {
+		sess.And("issue.is_hidden=?", false)
+	}
 	switch opts.State {
 	case "closed", "open":
 		sess.And("issue.is_closed=?", opts.State == "closed")
@@ -343,3 +348,12 @@ func GetPullRequestByIssueIDs(ctx context.Context, issueIDs []int64) (PullReques
 		In("issue_id", issueIDs).
 		Find(&prs)
 }
+
+// GetMergedPullRequestsByMergedCommits returns the merged pull requests of the repository whose merged commits are in the list
+func GetMergedPullRequestsByMergedCommits(ctx context.Context, repoID int64, commitIDs []string) (PullRequestList, error) {
+	prs := make([]*PullRequest, 0, len(commitIDs))
+	return prs, db.GetEngine(ctx).
+		Where("base_repo_id = ? AND has_merged = ?", repoID, true).
+		In("merged_commit_id", commitIDs).
+		Find(&prs)
+}
@@ -12,6 +12,8 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
 )
 
 // Stopwatch represents a stopwatch for time tracking.
@@ -85,6 +87,15 @@ func GetUserStopwatches(ctx context.Context, userID int64, listOptions db.ListOp
 	return sws, nil
 }
 
+// GetRepoStopwatches return the running stopwatches of all the users on the issues of a repository
+func GetRepoStopwatches(ctx context.Context, repoID int64) ([]*Stopwatch, error) {
+	sws := make([]*Stopwatch, 0, 8)
+	return sws, db.GetEngine(ctx).
+		Where(builder.In("stopwatch.issue_id", builder.Select("id").From("issue").Where(builder.Eq{"repo_id": repoID}))).
+		OrderBy("stopwatch.created_unix").
+		Find(&sws)
+}
+
 // CountUserStopwatches return count of the user's all stopwatches
 func CountUserStopwatches(ctx context.Context, userID int64) (int64, error) {
 	return db.GetEngine(ctx).Where("user_id = ?", userID).Count(&Stopwatch{})
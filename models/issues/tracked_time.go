@@ -89,6 +89,7 @@ type FindTrackedTimesOptions struct {
 	IssueID           int64
 	UserID            int64
 	RepositoryID      int64
+	OwnerID           int64
 	MilestoneID       int64
 	CreatedAfterUnix  int64
 	CreatedBeforeUnix int64
@@ -106,6 +107,9 @@ func (opts *FindTrackedTimesOptions) ToConds() builder.Cond {
 	if opts.RepositoryID != 0 {
 		cond = cond.And(builder.Eq{"issue.repo_id": opts.RepositoryID})
 	}
+	if opts.OwnerID != 0 {
+		cond = cond.And(builder.In("issue.repo_id", builder.Select("id").From("repository").Where(builder.Eq{"owner_id": opts.OwnerID})))
+	}
 	if opts.MilestoneID != 0 {
 		cond = cond.And(builder.Eq{"issue.milestone_id": opts.MilestoneID})
 	}
@@ -118,8 +122,12 @@ func (opts *FindTrackedTimesOptions) ToConds() builder.Cond {
 	return cond
 }
 
+func (opts *FindTrackedTimesOptions) needsIssueJoin() bool {
+	return opts.RepositoryID > 0 || opts.OwnerID > 0 || opts.MilestoneID > 0
+}
+
 func (opts *FindTrackedTimesOptions) ToJoins() []db.JoinFunc {
-	if opts.RepositoryID > 0 || opts.MilestoneID > 0 {
+	if opts.needsIssueJoin() {
 		return []db.JoinFunc{
 			func(e db.Engine) error {
 				e.Join("INNER", "issue", "issue.id = tracked_time.issue_id")
@@ -133,7 +141,7 @@ func (opts *FindTrackedTimesOptions) ToJoins() []db.JoinFunc {
 // toSession will convert the given options to a xorm Session by using the conditions from toCond and joining with issue table if required
 func (opts *FindTrackedTimesOptions) toSession(e db.Engine) db.Engine {
 	sess := e
-	if opts.RepositoryID > 0 || opts.MilestoneID > 0 {
+	if opts.needsIssueJoin() {
 		sess = e.Join("INNER", "issue", "issue.id = tracked_time.issue_id")
 	}
 
@@ -155,7 +163,7 @@ func GetTrackedTimes(ctx context.Context, options *FindTrackedTimesOptions) (tra
 // CountTrackedTimes returns count of tracked times that fit to the given options.
 func CountTrackedTimes(ctx context.Context, opts *FindTrackedTimesOptions) (int64, error) {
 	sess := db.GetEngine(ctx).Where(opts.ToConds())
-	if opts.RepositoryID > 0 || opts.MilestoneID > 0 {
+	if opts.needsIssueJoin() {
 		sess = sess.Join("INNER", "issue", "issue.id = tracked_time.issue_id")
 	}
 	return sess.Count(&TrackedTime{})
@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"sort"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// TrackedTimeGroupBy is the dimension the tracked times of a report are summed by
+type TrackedTimeGroupBy string
+
+const (
+	TrackedTimeGroupByUser      TrackedTimeGroupBy = "user"
+	TrackedTimeGroupByRepo      TrackedTimeGroupBy = "repo"
+	TrackedTimeGroupByMilestone TrackedTimeGroupBy = "milestone"
+	TrackedTimeGroupByIssue     TrackedTimeGroupBy = "issue"
+)
+
+// TrackedTimeReportOptions represent the filters of a tracked time report. If an ID is 0 it will be ignored.
+type TrackedTimeReportOptions struct {
+	RepoID            int64
+	OwnerID           int64
+	UserID            int64
+	GroupBy           TrackedTimeGroupBy
+	CreatedAfterUnix  int64
+	CreatedBeforeUnix int64
+}
+
+// TrackedTimeSum is the tracked time summed for a user, a repository, a milestone or an issue,
+// only the fields of the dimension of the report are filled
+type TrackedTimeSum struct {
+	UserID        int64
+	UserName      string
+	RepoID        int64
+	RepoOwnerName string
+	RepoName      string
+	MilestoneID   int64
+	MilestoneName string
+	IssueID       int64
+	IssueIndex    int64
+	IssueTitle    string
+	SumTime       int64
+}
+
+func (opts *TrackedTimeReportOptions) toConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"tracked_time.deleted": false})
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"issue.repo_id": opts.RepoID})
+	}
+	if opts.OwnerID != 0 {
+		cond = cond.And(builder.Eq{"repository.owner_id": opts.OwnerID})
+	}
+	if opts.UserID != 0 {
+		cond = cond.And(builder.Eq{"tracked_time.user_id": opts.UserID})
+	}
+	if opts.CreatedAfterUnix != 0 {
+		cond = cond.And(builder.Gte{"tracked_time.created_unix": opts.CreatedAfterUnix})
+	}
+	if opts.CreatedBeforeUnix != 0 {
+		cond = cond.And(builder.Lte{"tracked_time.created_unix": opts.CreatedBeforeUnix})
+	}
+	return cond
+}
+
+// GetTrackedTimeReport returns the tracked times that fit to the given options summed by the dimension of the report,
+// sorted by the most tracked time first
+func GetTrackedTimeReport(ctx context.Context, opts *TrackedTimeReportOptions) ([]*TrackedTimeSum, error) {
+	const repoColumns = "repository.id, repository.owner_name, repository.name"
+	var columns, groupBy string
+	switch opts.GroupBy {
+	case TrackedTimeGroupByUser:
+		columns = "`user`.id AS user_id, `user`.name AS user_name"
+		groupBy = "`user`.id, `user`.name"
+	case TrackedTimeGroupByRepo:
+		columns = "repository.id AS repo_id, repository.owner_name AS repo_owner_name, repository.name AS repo_name"
+		groupBy = repoColumns
+	case TrackedTimeGroupByMilestone:
+		columns = "repository.id AS repo_id, repository.owner_name AS repo_owner_name, repository.name AS repo_name, milestone.id AS milestone_id, milestone.name AS milestone_name"
+		groupBy = repoColumns + ", milestone.id, milestone.name"
+	case TrackedTimeGroupByIssue:
+		columns = "repository.id AS repo_id, repository.owner_name AS repo_owner_name, repository.name AS repo_name, issue.id AS issue_id, issue.`index` AS issue_index, issue.name AS issue_title"
+		groupBy = repoColumns + ", issue.id, issue.`index`, issue.name"
+	default:
+		return nil, util.NewInvalidArgumentErrorf("unknown tracked time report dimension %q", opts.GroupBy)
+	}
+
+	results := make([]*TrackedTimeSum, 0, 10)
+	if err := db.GetEngine(ctx).
+		Select(columns+", SUM(tracked_time.time) AS sum_time").
+		Table("tracked_time").
+		Join("INNER", "issue", "tracked_time.issue_id = issue.id").
+		Join("INNER", "repository", "issue.repo_id = repository.id").
+		Join("LEFT", "milestone", "issue.milestone_id = milestone.id").
+		Join("INNER", "`user`", "tracked_time.user_id = `user`.id").
+		Where(opts.toConds()).
+		GroupBy(groupBy).
+		Find(&results); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].SumTime != results[j].SumTime {
+			return results[i].SumTime > results[j].SumTime
+		}
+		return results[i].UserName+results[i].RepoName+results[i].MilestoneName+results[i].IssueTitle <
+			results[j].UserName+results[j].RepoName+results[j].MilestoneName+results[j].IssueTitle
+	})
+	return results, nil
+}
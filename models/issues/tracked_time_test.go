@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -131,3 +132,50 @@ func TestGetIssueTotalTrackedTime(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, 3682, ttt)
 }
+
+func TestGetTrackedTimeReport(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	sums, err := issues_model.GetTrackedTimeReport(t.Context(), &issues_model.TrackedTimeReportOptions{RepoID: 1, GroupBy: issues_model.TrackedTimeGroupByUser})
+	assert.NoError(t, err)
+	if assert.Len(t, sums, 2) {
+		assert.Equal(t, "user2", sums[0].UserName)
+		assert.EqualValues(t, 3663, sums[0].SumTime)
+		assert.Equal(t, "user1", sums[1].UserName)
+		assert.EqualValues(t, 420, sums[1].SumTime)
+	}
+
+	sums, err = issues_model.GetTrackedTimeReport(t.Context(), &issues_model.TrackedTimeReportOptions{RepoID: 1, GroupBy: issues_model.TrackedTimeGroupByMilestone})
+	assert.NoError(t, err)
+	if assert.Len(t, sums, 2) {
+		assert.EqualValues(t, 1, sums[0].MilestoneID)
+		assert.EqualValues(t, 3682, sums[0].SumTime)
+		assert.Zero(t, sums[1].MilestoneID)
+		assert.EqualValues(t, 401, sums[1].SumTime)
+	}
+
+	sums, err = issues_model.GetTrackedTimeReport(t.Context(), &issues_model.TrackedTimeReportOptions{RepoID: 1, UserID: 1, GroupBy: issues_model.TrackedTimeGroupByIssue})
+	assert.NoError(t, err)
+	if assert.Len(t, sums, 2) {
+		assert.EqualValues(t, 1, sums[0].IssueIndex)
+		assert.EqualValues(t, 400, sums[0].SumTime)
+		assert.Equal(t, "repo1", sums[0].RepoName)
+	}
+
+	// the times tracked by the deleted user of the issue of repo2 are left out
+	sums, err = issues_model.GetTrackedTimeReport(t.Context(), &issues_model.TrackedTimeReportOptions{OwnerID: 2, GroupBy: issues_model.TrackedTimeGroupByRepo, CreatedBeforeUnix: 947688814})
+	assert.NoError(t, err)
+	if assert.Len(t, sums, 2) {
+		assert.Equal(t, "repo1", sums[0].RepoName)
+		assert.EqualValues(t, 4083, sums[0].SumTime)
+		assert.Equal(t, "repo2", sums[1].RepoName)
+		assert.EqualValues(t, 74, sums[1].SumTime)
+	}
+
+	_, err = issues_model.GetTrackedTimeReport(t.Context(), &issues_model.TrackedTimeReportOptions{GroupBy: "label"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	times, err := issues_model.GetTrackedTimes(t.Context(), &issues_model.FindTrackedTimesOptions{OwnerID: 2})
+	assert.NoError(t, err)
+	assert.Len(t, times, 8)
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 
+	"code.gitea.io/gitea/models/migrations/online"
 	"code.gitea.io/gitea/models/migrations/v1_10"
 	"code.gitea.io/gitea/models/migrations/v1_11"
 	"code.gitea.io/gitea/models/migrations/v1_12"
@@ -394,10 +395,82 @@ func prepareMigrationTasks() []*migration {
 		// Gitea 1.24.0 ends at database version 321
 		newMigration(321, "Use LONGTEXT for some columns and fix review_state.updated_files column", v1_25.UseLongTextInSomeColumnsAndFixBugs),
 		newMigration(322, "Extend comment tree_path length limit", v1_25.ExtendCommentTreePathLength),
+		newMigration(323, "Add quota_limit table", v1_25.AddQuotaLimitTable),
+		newMigration(324, "Add concurrency columns to action_run and action_run_job", v1_25.AddActionsConcurrency),
+		newMigration(325, "Add just-in-time runner registration tokens", v1_25.AddRunnerJITTokens),
+		newMigration(326, "Add action_artifact_retention table", v1_25.AddActionArtifactRetentionTable),
+		newMigration(327, "Add action_cache table", v1_25.AddActionCacheTable),
+		newMigration(328, "Add provider to secret", v1_25.AddProviderToSecret),
+		newMigration(329, "Add actions deployment environments", v1_25.AddActionEnvironments),
+		newMigration(330, "Add repository and prerelease options to package cleanup rules", v1_25.AddRepoAndPrereleaseToPackageCleanupRule),
+		newMigration(331, "Add package scan tables", v1_25.AddPackageScanTables),
+		newMigration(332, "Add retry columns to hook task", v1_25.AddRetryToHookTask),
+		newMigration(333, "Add issue custom field tables", v1_25.AddIssueFieldTables),
+		newMigration(334, "Add issue saved search table", v1_25.AddIssueSavedSearchTable),
+		newMigration(335, "Add issue SLA tables", v1_25.AddIssueSLATables),
+		newMigration(336, "Add sub-issue table", v1_25.AddSubIssueTable),
+		newMigration(337, "Add iteration tables", v1_25.AddIterationTables),
+		newMigration(338, "Add time tracking rule table", v1_25.AddTimeTrackingRuleTable),
+		newMigration(339, "Add web push subscription table", v1_25.AddWebPushSubscriptionTable),
+		newMigration(340, "Add email digest item table", v1_25.AddEmailDigestItemTable),
+		newMigration(341, "Add event columns to watch table", v1_25.AddWatchEventColumns),
+		newMigration(342, "Add incoming email alias and issue email message tables", v1_25.AddIncomingEmailTables),
+		newMigration(343, "Add repo symbol table", v1_25.AddRepoSymbolTable),
+		newMigration(344, "Add indexed time and last failure to repo indexer status", v1_25.AddIndexerFailureToRepoIndexerStatus),
+		newMigration(345, "Add storage tier to LFS meta objects and repo archivers", v1_25.AddStorageTierToLFSMetaObjectAndRepoArchiver),
+		newMigration(346, "Add cluster lease table", v1_25.AddClusterLeaseTable),
+		newMigration(347, "Add ref filter, skip tags and max size columns to mirror table", v1_25.AddMirrorRefFilterColumns),
+		newMigration(348, "Add branch filter, LFS and last success columns to push mirror table", v1_25.AddPushMirrorFilterAndStatusColumns),
+		newMigration(349, "Add federated follower table", v1_25.AddFederatedFollowerTable),
+		newMigration(350, "Add federated user, fork and issue tables", v1_25.AddFederatedPullRequestTables),
+		newMigration(351, "Add protected wiki page table", v1_25.AddProtectedWikiPageTable),
+		newMigration(352, "Add repo dependency table", v1_25.AddRepoDependencyTable),
+		newMigration(353, "Add license policy table and licenses of repo dependencies", v1_25.AddLicensePolicyTable),
+		newMigration(354, "Add sign-off policy table", v1_25.AddSignOffPolicyTable),
+		newMigration(355, "Add tag requirements to protected tag", v1_25.AddTagRequirementsToProtectedTag),
+		newMigration(356, "Add attachment upload table", v1_25.AddAttachmentUploadTable),
+		newMigration(357, "Add malware quarantine", v1_25.AddMalwareQuarantine),
+		newMigration(358, "Add disallow_impersonation to user", v1_25.AddDisallowImpersonationToUser),
+		newMigration(359, "Add announcement and announcement_dismissal tables", v1_25.AddAnnouncementTables),
+		newMigration(360, "Add parent_id to team and is_inherited to team_user", v1_25.AddTeamParentAndInheritedTeamUser),
+		newMigration(361, "Add org_role and org_role_assignment tables", v1_25.AddOrgRoleTables),
+		newMigration(362, "Add terms_document and terms_acceptance tables", v1_25.AddTermsTables),
+		newMigration(363, "Add approval_request table", v1_25.AddApprovalRequestTable),
+		newMigration(364, "Add held_content table", v1_25.AddHeldContentTable),
+		newMigration(365, "Add abuse_report and resolution_template tables", v1_25.AddAbuseReportTables),
+		newMigration(366, "Add error_message column to action_run table", v1_25.AddErrorMessageToActionRun),
+		newMigration(367, "Add edit and issue meta columns to held_content table", v1_25.AddEditAndIssueMetasToHeldContent),
+		newMigration(368, "Add is_hidden column to issue and comment tables", v1_25.AddIsHiddenToIssueAndComment),
+		newMigration(369, "Add previous_visibility column to abuse_report table", v1_25.AddPreviousVisibilityToAbuseReport),
 	}
 	return preparedMigrations
 }
 
+// prepareBackfills returns the backfills filling the existing rows after the migrations above, they run in the background.
+// Add new backfills to the bottom of the list, a backfill can be removed once the contract depending on it has been released.
+func prepareBackfills() []*online.Backfill {
+	return []*online.Backfill{}
+}
+
+// prepareContracts returns the contracts which are run by "gitea migrate --contract" after all backfills are done.
+// Add new contracts to the bottom of the list.
+func prepareContracts() []*online.Contract {
+	return []*online.Contract{}
+}
+
+// RunBackfills runs the backfills of the migrations which haven't been done
+func RunBackfills(ctx context.Context, x *xorm.Engine) error {
+	return online.RunBackfills(ctx, x, prepareBackfills())
+}
+
+// RunContracts runs the contracts of the migrations which haven't been done, the database must be up to date
+func RunContracts(ctx context.Context, x *xorm.Engine) error {
+	if err := EnsureUpToDate(ctx, x); err != nil {
+		return err
+	}
+	return online.RunContracts(ctx, x, prepareBackfills(), prepareContracts())
+}
+
 // GetCurrentDBVersion returns the current db version
 func GetCurrentDBVersion(x *xorm.Engine) (int64, error) {
 	if err := x.Sync(new(Version)); err != nil {
@@ -0,0 +1,314 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package online implements the steps of the expand/contract migrations which can't be done while upgrading:
+//
+//  1. Expand: the versioned migrations only make backward compatible changes (eg: add a column or a table,
+//     create an index with CreateIndex) so the instances running the previous version keep working.
+//  2. Backfill: the data of the existing rows are filled by batches in the background after the upgrade,
+//     the rows added after the upgrade are written by the new code.
+//  3. Contract: the changes breaking the previous version (eg: drop the old columns) are made
+//     by "gitea migrate --contract" once all the instances run the new version and all backfills are done.
+package online
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// Backfill fills the data of the existing rows of a table in batches of their ids
+type Backfill struct {
+	Name      string
+	Table     string
+	BatchSize int64 // default 1000
+	// Fn handles the rows whose id is in (minID, maxID], it should be idempotent because a batch can be handled again
+	Fn func(ctx context.Context, x *xorm.Engine, minID, maxID int64) error
+}
+
+// Contract makes the changes which would break the previous version
+type Contract struct {
+	Name string
+	Fn   func(ctx context.Context, x *xorm.Engine) error
+}
+
+type Status int
+
+const (
+	StatusPending Status = iota
+	StatusRunning
+	StatusDone
+	StatusFailed
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusRunning:
+		return "running"
+	case StatusDone:
+		return "done"
+	case StatusFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// BackgroundMigration records the progress of a backfill or a contract
+type BackgroundMigration struct {
+	ID         int64  `xorm:"pk autoincr"`
+	Name       string `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	IsContract bool   `xorm:"NOT NULL DEFAULT false"`
+	Status     Status `xorm:"NOT NULL DEFAULT 0"`
+	// LastID is the id of the last row handled by a backfill, MaxID is the max id of the table when it started
+	LastID       int64              `xorm:"NOT NULL DEFAULT 0"`
+	MaxID        int64              `xorm:"NOT NULL DEFAULT 0"`
+	ErrorMessage string             `xorm:"TEXT"`
+	LeaseOwner   string             `xorm:"VARCHAR(255)"`
+	LeaseUntil   timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+}
+
+// Progress returns the percentage of the rows handled by a backfill
+func (m *BackgroundMigration) Progress() float64 {
+	if m.Status == StatusDone {
+		return 100
+	} else if m.MaxID <= 0 {
+		return 0
+	}
+	return float64(m.LastID) * 100 / float64(m.MaxID)
+}
+
+var (
+	// leaseDuration is how long an instance keeps running a backfill without renewing its lease,
+	// the other instances take the backfill over when an instance stops
+	leaseDuration = 5 * time.Minute
+	// batchInterval is the time to wait between the batches to limit the load of the database
+	batchInterval = 50 * time.Millisecond
+	leaseOwner    = func() string {
+		hostname, _ := os.Hostname()
+		random, _ := util.CryptoRandomString(8)
+		return fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), random)
+	}()
+)
+
+func getOrCreateRecord(x *xorm.Engine, name string, isContract bool) (*BackgroundMigration, error) {
+	for range 2 {
+		m := &BackgroundMigration{}
+		has, err := x.Where("name = ?", name).Get(m)
+		if err != nil {
+			return nil, err
+		} else if has {
+			return m, nil
+		}
+		// another instance may insert the record at the same time, read it again if the insertion fails
+		if _, err := x.Insert(&BackgroundMigration{Name: name, IsContract: isContract}); err != nil {
+			log.Debug("Insert background migration %q: %v", name, err)
+		}
+	}
+	return nil, fmt.Errorf("unable to create the record of background migration %q", name)
+}
+
+// ListBackgroundMigrations returns the records of the backfills and the contracts
+func ListBackgroundMigrations(x *xorm.Engine) ([]*BackgroundMigration, error) {
+	if err := x.Sync(new(BackgroundMigration)); err != nil {
+		return nil, err
+	}
+	var ms []*BackgroundMigration
+	return ms, x.OrderBy("id").Find(&ms)
+}
+
+// claimLease makes the instance run a backfill if no other instance is running it
+func claimLease(x *xorm.Engine, m *BackgroundMigration) (bool, error) {
+	now := timeutil.TimeStampNow()
+	res, err := x.Exec("UPDATE background_migration SET lease_owner = ?, lease_until = ?, status = ? WHERE id = ? AND status <> ? AND (lease_owner = ? OR lease_until < ?)",
+		leaseOwner, now.AddDuration(leaseDuration), StatusRunning, m.ID, StatusDone, leaseOwner, now)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected == 1, err
+}
+
+// RunBackfills runs the backfills which haven't been done, one after another.
+// The backfills can be run by several instances at the same time, each backfill is only run by one instance.
+func RunBackfills(ctx context.Context, x *xorm.Engine, backfills []*Backfill) error {
+	if err := x.Sync(new(BackgroundMigration)); err != nil {
+		return err
+	}
+	for _, b := range backfills {
+		if err := runBackfill(ctx, x, b); err != nil {
+			return fmt.Errorf("backfill %q: %w", b.Name, err)
+		}
+	}
+	return nil
+}
+
+func runBackfill(ctx context.Context, x *xorm.Engine, b *Backfill) error {
+	m, err := getOrCreateRecord(x, b.Name, false)
+	if err != nil {
+		return err
+	}
+	if m.Status == StatusDone {
+		return nil
+	}
+	if claimed, err := claimLease(x, m); err != nil || !claimed {
+		return err
+	}
+
+	if m.MaxID == 0 {
+		if _, err := x.SQL(fmt.Sprintf("SELECT COALESCE(MAX(id), 0) FROM %s", x.Quote(b.Table))).Get(&m.MaxID); err != nil {
+			return err
+		}
+		if _, err := x.ID(m.ID).Cols("max_id").Update(m); err != nil {
+			return err
+		}
+	}
+	log.Info("Backfill[%s]: starting at id %d of %d", b.Name, m.LastID, m.MaxID)
+
+	batchSize := util.IfZero(b.BatchSize, 1000)
+	lastLogged := time.Now()
+	for m.LastID < m.MaxID {
+		select {
+		case <-ctx.Done():
+			return releaseLease(x, m, StatusPending, "")
+		default:
+		}
+
+		to := min(m.LastID+batchSize, m.MaxID)
+		if err := b.Fn(ctx, x, m.LastID, to); err != nil {
+			if releaseErr := releaseLease(x, m, StatusFailed, err.Error()); releaseErr != nil {
+				log.Error("Backfill[%s]: unable to record the failure: %v", b.Name, releaseErr)
+			}
+			return err
+		}
+
+		// renew the lease with the progress, stop if another instance took the backfill over
+		m.LastID = to
+		res, err := x.Exec("UPDATE background_migration SET last_id = ?, lease_until = ?, updated_unix = ? WHERE id = ? AND lease_owner = ?",
+			m.LastID, timeutil.TimeStampNow().AddDuration(leaseDuration), timeutil.TimeStampNow(), m.ID, leaseOwner)
+		if err != nil {
+			return err
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected != 1 {
+			log.Warn("Backfill[%s]: the lease was taken over by another instance", b.Name)
+			return nil
+		}
+
+		if time.Since(lastLogged) >= 10*time.Second {
+			log.Info("Backfill[%s]: %.1f%% done (id %d of %d)", b.Name, m.Progress(), m.LastID, m.MaxID)
+			lastLogged = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(batchInterval):
+		}
+	}
+
+	log.Info("Backfill[%s]: done", b.Name)
+	return releaseLease(x, m, StatusDone, "")
+}
+
+func releaseLease(x *xorm.Engine, m *BackgroundMigration, status Status, errMsg string) error {
+	m.Status, m.ErrorMessage = status, errMsg
+	_, err := x.Exec("UPDATE background_migration SET status = ?, error_message = ?, lease_owner = '', lease_until = 0, updated_unix = ? WHERE id = ? AND lease_owner = ?",
+		status, errMsg, timeutil.TimeStampNow(), m.ID, leaseOwner)
+	return err
+}
+
+// RunContracts runs the contracts which haven't been done, it fails if any backfill isn't done
+func RunContracts(ctx context.Context, x *xorm.Engine, backfills []*Backfill, contracts []*Contract) error {
+	if err := x.Sync(new(BackgroundMigration)); err != nil {
+		return err
+	}
+	for _, b := range backfills {
+		m, err := getOrCreateRecord(x, b.Name, false)
+		if err != nil {
+			return err
+		}
+		if m.Status != StatusDone {
+			return fmt.Errorf("backfill %q is %s (%.1f%%), the contract steps can only be run after all backfills are done", b.Name, m.Status, m.Progress())
+		}
+	}
+
+	for _, c := range contracts {
+		m, err := getOrCreateRecord(x, c.Name, true)
+		if err != nil {
+			return err
+		}
+		if m.Status == StatusDone {
+			continue
+		}
+		log.Info("Contract[%s]: running", c.Name)
+		status, errMsg := StatusDone, ""
+		err = c.Fn(ctx, x)
+		if err != nil {
+			status, errMsg = StatusFailed, err.Error()
+		}
+		if _, updateErr := x.Exec("UPDATE background_migration SET status = ?, error_message = ?, updated_unix = ? WHERE id = ?", status, errMsg, timeutil.TimeStampNow(), m.ID); updateErr != nil {
+			return errors.Join(err, updateErr)
+		}
+		if err != nil {
+			return fmt.Errorf("contract %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// CreateIndex creates an index without blocking the writes to the table when the database supports it,
+// it should be used by the expand migrations to create the indexes of large tables
+func CreateIndex(ctx context.Context, x *xorm.Engine, tableName string, index *schemas.Index) error {
+	dbType := x.Dialect().URI().DBType
+	indexes, err := x.Dialect().GetIndexes(x.DB(), ctx, tableName)
+	if err != nil {
+		return err
+	}
+	for _, existing := range indexes {
+		if existing.XName(tableName) != index.XName(tableName) {
+			continue
+		}
+		if dbType != schemas.POSTGRES {
+			return nil
+		}
+		// a failed concurrent index creation leaves an invalid index in PostgreSQL, it must be created again
+		var valid bool
+		if _, err := x.SQL("SELECT i.indisvalid FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid WHERE c.relname = ?", index.XName(tableName)).Get(&valid); err != nil {
+			return err
+		}
+		if valid {
+			return nil
+		}
+		if _, err := x.Exec(x.Dialect().DropIndexSQL(tableName, index)); err != nil {
+			return err
+		}
+	}
+
+	sql := x.Dialect().CreateIndexSQL(tableName, index)
+	switch dbType {
+	case schemas.POSTGRES:
+		sql = strings.Replace(sql, " INDEX ", " INDEX CONCURRENTLY ", 1)
+	case schemas.MYSQL:
+		sql += " ALGORITHM=INPLACE LOCK=NONE"
+	case schemas.MSSQL:
+		// online index operations are only available in some editions of SQL Server
+		if _, err := x.Exec(sql + " WITH (ONLINE = ON)"); err == nil {
+			return nil
+		}
+	}
+	_, err = x.Exec(sql)
+	return err
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package online
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"code.gitea.io/gitea/models/migrations/base"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+type OnlineMigrationItem struct {
+	ID    int64 `xorm:"pk autoincr"`
+	Name  string
+	Lower string
+}
+
+func TestBackfillAndContract(t *testing.T) {
+	defer test.MockVariableValue(&batchInterval, 0)()
+	x, deferable := base.PrepareTestEnv(t, 0, new(OnlineMigrationItem))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		_, err := x.Insert(&OnlineMigrationItem{Name: name})
+		require.NoError(t, err)
+	}
+
+	var batches [][2]int64
+	failing := true
+	backfill := &Backfill{
+		Name:      "fill lower name",
+		Table:     "online_migration_item",
+		BatchSize: 2,
+		Fn: func(ctx context.Context, x *xorm.Engine, minID, maxID int64) error {
+			if failing && minID > 0 {
+				return errors.New("failed")
+			}
+			batches = append(batches, [2]int64{minID, maxID})
+			_, err := x.Exec("UPDATE online_migration_item SET lower = LOWER(name) WHERE id > ? AND id <= ?", minID, maxID)
+			return err
+		},
+	}
+	contracted := false
+	contract := &Contract{
+		Name: "drop name",
+		Fn: func(ctx context.Context, x *xorm.Engine) error {
+			contracted = true
+			return nil
+		},
+	}
+
+	// the failed backfill is recorded and resumed from the last handled batch
+	assert.Error(t, RunBackfills(t.Context(), x, []*Backfill{backfill}))
+	ms, err := ListBackgroundMigrations(x)
+	require.NoError(t, err)
+	require.Len(t, ms, 1)
+	assert.Equal(t, StatusFailed, ms[0].Status)
+	assert.Equal(t, "failed", ms[0].ErrorMessage)
+	assert.EqualValues(t, 2, ms[0].LastID)
+	assert.InDelta(t, 40, ms[0].Progress(), 0.01)
+
+	assert.Error(t, RunContracts(t.Context(), x, []*Backfill{backfill}, []*Contract{contract}))
+	assert.False(t, contracted)
+
+	failing = false
+	require.NoError(t, RunBackfills(t.Context(), x, []*Backfill{backfill}))
+	assert.Equal(t, [][2]int64{{0, 2}, {2, 4}, {4, 5}}, batches)
+	var lowers []string
+	require.NoError(t, x.Table("online_migration_item").Cols("lower").OrderBy("id").Find(&lowers))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, lowers)
+
+	ms, err = ListBackgroundMigrations(x)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDone, ms[0].Status)
+	assert.InDelta(t, 100, ms[0].Progress(), 0.01)
+
+	// the done backfills are not run again
+	require.NoError(t, RunBackfills(t.Context(), x, []*Backfill{backfill}))
+	assert.Len(t, batches, 3)
+
+	require.NoError(t, RunContracts(t.Context(), x, []*Backfill{backfill}, []*Contract{contract}))
+	assert.True(t, contracted)
+	contracted = false
+	require.NoError(t, RunContracts(t.Context(), x, []*Backfill{backfill}, []*Contract{contract}))
+	assert.False(t, contracted)
+}
+
+func TestBackfillLease(t *testing.T) {
+	x, deferable := base.PrepareTestEnv(t, 0, new(OnlineMigrationItem))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+	require.NoError(t, x.Sync(new(BackgroundMigration)))
+
+	m, err := getOrCreateRecord(x, "leased", false)
+	require.NoError(t, err)
+	claimed, err := claimLease(x, m)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// another instance can't run the backfill until the lease expires
+	defer test.MockVariableValue(&leaseOwner, "other")()
+	claimed, err = claimLease(x, m)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	_, err = x.Exec("UPDATE background_migration SET lease_until = 1 WHERE id = ?", m.ID)
+	require.NoError(t, err)
+	claimed, err = claimLease(x, m)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestCreateIndex(t *testing.T) {
+	x, deferable := base.PrepareTestEnv(t, 0, new(OnlineMigrationItem))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+
+	index := schemas.NewIndex("lower", schemas.IndexType)
+	index.AddColumn("lower")
+	require.NoError(t, CreateIndex(t.Context(), x, "online_migration_item", index))
+	// creating an existing index does nothing
+	require.NoError(t, CreateIndex(t.Context(), x, "online_migration_item", index))
+
+	indexes, err := x.Dialect().GetIndexes(x.DB(), t.Context(), "online_migration_item")
+	require.NoError(t, err)
+	assert.Contains(t, indexes, "lower")
+}
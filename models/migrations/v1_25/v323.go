@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddQuotaLimitTable(x *xorm.Engine) error {
+	type QuotaLimit struct {
+		ID              int64              `xorm:"pk autoincr"`
+		OwnerID         int64              `xorm:"UNIQUE NOT NULL"`
+		TotalSize       int64              `xorm:"NOT NULL DEFAULT -1"`
+		GitSize         int64              `xorm:"NOT NULL DEFAULT -1"`
+		LFSSize         int64              `xorm:"NOT NULL DEFAULT -1"`
+		PackagesSize    int64              `xorm:"NOT NULL DEFAULT -1"`
+		AttachmentsSize int64              `xorm:"NOT NULL DEFAULT -1"`
+		ArtifactsSize   int64              `xorm:"NOT NULL DEFAULT -1"`
+		CreatedUnix     timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix     timeutil.TimeStamp `xorm:"updated NOT NULL"`
+	}
+
+	return x.Sync(new(QuotaLimit))
+}
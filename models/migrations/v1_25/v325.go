@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRunnerJITTokens(x *xorm.Engine) error {
+	type ActionRunnerToken struct {
+		IsJIT   bool               `xorm:"is_jit NOT NULL DEFAULT false"`
+		Labels  []string           `xorm:"TEXT"`
+		Expires timeutil.TimeStamp `xorm:"index"`
+	}
+	// the struct only has the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(ActionRunnerToken))
+	return err
+}
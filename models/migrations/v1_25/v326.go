@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionArtifactRetentionTable(x *xorm.Engine) error {
+	type ActionArtifactRetention struct {
+		ID           int64 `xorm:"pk autoincr"`
+		OwnerID      int64 `xorm:"UNIQUE(owner_repo)"`
+		RepoID       int64 `xorm:"UNIQUE(owner_repo)"`
+		MaxAgeDays   int64 `xorm:"NOT NULL DEFAULT 0"`
+		MaxTotalSize int64 `xorm:"NOT NULL DEFAULT 0"`
+		KeepLastN    int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(ActionArtifactRetention))
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionCacheTable(x *xorm.Engine) error {
+	type ActionCache struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX(repo_scope)"`
+		Scope       string             `xorm:"INDEX(repo_scope) VARCHAR(255)"`
+		CacheKey    string             `xorm:"VARCHAR(512) NOT NULL"`
+		Version     string             `xorm:"VARCHAR(255) NOT NULL"`
+		Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+		Complete    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		HitCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UsedUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(ActionCache))
+}
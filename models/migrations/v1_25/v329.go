@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionEnvironments(x *xorm.Engine) error {
+	type ActionEnvironment struct {
+		ID                 int64    `xorm:"pk autoincr"`
+		RepoID             int64    `xorm:"UNIQUE(repo_name) NOT NULL"`
+		Name               string   `xorm:"VARCHAR(255) NOT NULL"`
+		LowerName          string   `xorm:"UNIQUE(repo_name) VARCHAR(255) NOT NULL"`
+		WaitTimer          int64    `xorm:"NOT NULL DEFAULT 0"`
+		ReviewerIDs        []int64  `xorm:"JSON TEXT"`
+		ReviewerTeamIDs    []int64  `xorm:"JSON TEXT"`
+		PreventSelfReview  bool     `xorm:"NOT NULL DEFAULT false"`
+		DeploymentBranches []string `xorm:"JSON TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+	}
+	type ActionDeploymentReview struct {
+		ID            int64              `xorm:"pk autoincr"`
+		RepoID        int64              `xorm:"INDEX NOT NULL"`
+		RunID         int64              `xorm:"INDEX(run_environment) NOT NULL"`
+		EnvironmentID int64              `xorm:"INDEX(run_environment) NOT NULL"`
+		ReviewerID    int64              `xorm:"NOT NULL"`
+		State         string             `xorm:"VARCHAR(20) NOT NULL"`
+		Comment       string             `xorm:"TEXT"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+	if err := x.Sync(new(ActionEnvironment), new(ActionDeploymentReview)); err != nil {
+		return err
+	}
+
+	type ActionRunJob struct {
+		Environment    string             `xorm:"VARCHAR(255)"`
+		WaitTimerUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains: true,
+		IgnoreIndices:    true,
+	}, new(ActionRunJob)); err != nil {
+		return err
+	}
+
+	// the unique index of the secrets is recreated with the environment
+	type Secret struct {
+		OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+	return x.Sync(new(Secret))
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageScanTables(x *xorm.Engine) error {
+	type PackageScan struct {
+		ID          int64              `xorm:"pk autoincr"`
+		VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+		Status      int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		MaxSeverity int                `xorm:"NOT NULL DEFAULT 0"`
+		Error       string             `xorm:"TEXT"`
+		ScannedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	type PackageVulnerability struct {
+		ID               int64  `xorm:"pk autoincr"`
+		VersionID        int64  `xorm:"INDEX NOT NULL"`
+		VulnerabilityID  string `xorm:"NOT NULL"`
+		Target           string `xorm:"TEXT"`
+		PackageName      string `xorm:"NOT NULL"`
+		InstalledVersion string `xorm:"NOT NULL DEFAULT ''"`
+		FixedVersion     string `xorm:"NOT NULL DEFAULT ''"`
+		Severity         int    `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Title            string `xorm:"TEXT"`
+		URL              string `xorm:"TEXT"`
+	}
+
+	return x.Sync(new(PackageScan), new(PackageVulnerability))
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRetryToHookTask(x *xorm.Engine) error {
+	type HookTask struct {
+		Attempt      int                `xorm:"NOT NULL DEFAULT 1"`
+		DeliverAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IsDeadLetter bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+
+	// the struct only has the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(HookTask))
+	return err
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIssueSavedSearchTable(x *xorm.Engine) error {
+	type IssueSavedSearch struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IsPull      bool               `xorm:"NOT NULL DEFAULT false"`
+		Name        string             `xorm:"NOT NULL"`
+		Query       string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+	}
+
+	return x.Sync(new(IssueSavedSearch))
+}
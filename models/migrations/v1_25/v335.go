@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIssueSLATables(x *xorm.Engine) error {
+	type IssueSLARule struct {
+		ID              int64              `xorm:"pk autoincr"`
+		RepoID          int64              `xorm:"INDEX NOT NULL"`
+		Name            string             `xorm:"NOT NULL"`
+		LabelIDs        []int64            `xorm:"TEXT JSON"`
+		ExemptLabelIDs  []int64            `xorm:"TEXT JSON"`
+		ResponseHours   int                `xorm:"NOT NULL DEFAULT 0"`
+		ResolutionHours int                `xorm:"NOT NULL DEFAULT 0"`
+		StaleDays       int                `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix     timeutil.TimeStamp `xorm:"INDEX created"`
+		UpdatedUnix     timeutil.TimeStamp `xorm:"INDEX updated"`
+	}
+
+	type IssueSLANotice struct {
+		ID          int64              `xorm:"pk autoincr"`
+		IssueID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		RuleID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Kind        string             `xorm:"UNIQUE(s) VARCHAR(10) NOT NULL"`
+		IsBreach    bool               `xorm:"UNIQUE(s) NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(IssueSLARule), new(IssueSLANotice))
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSubIssueTable(x *xorm.Engine) error {
+	type SubIssue struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ParentID    int64              `xorm:"INDEX NOT NULL"`
+		IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(SubIssue))
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIterationTables(x *xorm.Engine) error {
+	type Iteration struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		OrgID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name        string             `xorm:"NOT NULL"`
+		Description string             `xorm:"TEXT"`
+		StartUnix   timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		EndUnix     timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		Capacity    int64              `xorm:"NOT NULL DEFAULT 0"`
+		IsClosed    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		ClosedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+	}
+
+	type IterationIssue struct {
+		ID            int64              `xorm:"pk autoincr"`
+		IterationID   int64              `xorm:"INDEX NOT NULL"`
+		IssueID       int64              `xorm:"INDEX NOT NULL"`
+		CarriedFromID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		AddedUnix     timeutil.TimeStamp `xorm:"NOT NULL"`
+		RemovedUnix   timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(Iteration), new(IterationIssue))
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddTimeTrackingRuleTable(x *xorm.Engine) error {
+	type TimeTrackingRule struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OrgID       int64              `xorm:"UNIQUE(s) NOT NULL"`
+		RepoID      int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		TeamIDs     []int64            `xorm:"TEXT JSON"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(TimeTrackingRule))
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddWebPushSubscriptionTable(x *xorm.Engine) error {
+	type WebPushSubscription struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UserID      int64              `xorm:"INDEX NOT NULL"`
+		Endpoint    string             `xorm:"TEXT NOT NULL"`
+		P256dh      string             `xorm:"NOT NULL"`
+		Auth        string             `xorm:"NOT NULL"`
+		UserAgent   string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(WebPushSubscription))
+}
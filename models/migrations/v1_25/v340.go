@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddEmailDigestItemTable(x *xorm.Engine) error {
+	type EmailDigestItem struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UserID      int64              `xorm:"INDEX NOT NULL"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		IssueID     int64              `xorm:"NOT NULL"`
+		DoerID      int64              `xorm:"NOT NULL DEFAULT 0"`
+		Action      string             `xorm:"VARCHAR(32) NOT NULL"`
+		Link        string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(EmailDigestItem))
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import "xorm.io/xorm"
+
+func AddWatchEventColumns(x *xorm.Engine) error {
+	type Watch struct {
+		Issues       bool `xorm:"NOT NULL DEFAULT true"`
+		PullRequests bool `xorm:"NOT NULL DEFAULT true"`
+		Releases     bool `xorm:"NOT NULL DEFAULT true"`
+		CIFailures   bool `xorm:"'ci_failures' NOT NULL DEFAULT false"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(Watch))
+	return err
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIncomingEmailTables(x *xorm.Engine) error {
+	type IncomingEmailAlias struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+		Alias       string             `xorm:"VARCHAR(64) UNIQUE NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type IssueEmailMessage struct {
+		ID          int64              `xorm:"pk autoincr"`
+		IssueID     int64              `xorm:"INDEX NOT NULL"`
+		MessageID   string             `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(IncomingEmailAlias), new(IssueEmailMessage))
+}
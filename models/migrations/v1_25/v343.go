@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddRepoSymbolTable(x *xorm.Engine) error {
+	type RepoSymbol struct {
+		ID        int64  `xorm:"pk autoincr"`
+		RepoID    int64  `xorm:"INDEX(name) INDEX(path) NOT NULL"`
+		CommitID  string `xorm:"VARCHAR(64)"`
+		Name      string `xorm:"VARCHAR(255) INDEX(name) NOT NULL"`
+		Kind      string `xorm:"VARCHAR(50)"`
+		Language  string `xorm:"VARCHAR(50)"`
+		Path      string `xorm:"VARCHAR(255) INDEX(path) NOT NULL"`
+		Line      int    `xorm:"NOT NULL"`
+		EndLine   int    `xorm:"NOT NULL DEFAULT 0"`
+		Scope     string `xorm:"VARCHAR(255)"`
+		ScopeKind string `xorm:"VARCHAR(50)"`
+	}
+
+	return x.Sync(new(RepoSymbol))
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddIndexerFailureToRepoIndexerStatus(x *xorm.Engine) error {
+	type RepoIndexerStatus struct {
+		IndexedUnix timeutil.TimeStamp
+		LastError   string             `xorm:"TEXT"`
+		FailedUnix  timeutil.TimeStamp `xorm:"INDEX"`
+	}
+
+	// the struct only has the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(RepoIndexerStatus))
+	return err
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddStorageTierToLFSMetaObjectAndRepoArchiver(x *xorm.Engine) error {
+	type LFSMetaObject struct {
+		AccessedUnix timeutil.TimeStamp `xorm:"INDEX"`
+		IsCold       bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+
+	type RepoArchiver struct {
+		Size         int64              `xorm:"NOT NULL DEFAULT 0"`
+		AccessedUnix timeutil.TimeStamp `xorm:"INDEX"`
+		IsCold       bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+
+	// the structs only have the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(LFSMetaObject), new(RepoArchiver))
+	return err
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddClusterLeaseTable(x *xorm.Engine) error {
+	type ClusterLease struct {
+		ID         string             `xorm:"pk varchar(200)"`
+		Owner      string             `xorm:"VARCHAR(255) NOT NULL"`
+		LeaseUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(ClusterLease))
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import "xorm.io/xorm"
+
+func AddMirrorRefFilterColumns(x *xorm.Engine) error {
+	type Mirror struct {
+		RefFilter string `xorm:"TEXT"`
+		SkipTags  bool   `xorm:"NOT NULL DEFAULT false"`
+		MaxSize   int64  `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(Mirror))
+	return err
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPushMirrorFilterAndStatusColumns(x *xorm.Engine) error {
+	type PushMirror struct {
+		LastSuccessUnix timeutil.TimeStamp `xorm:"last_success"`
+		BranchFilter    string             `xorm:"TEXT"`
+		LFS             bool               `xorm:"lfs_enabled NOT NULL DEFAULT true"`
+	}
+
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreIndices:    true,
+		IgnoreConstrains: true,
+	}, new(PushMirror)); err != nil {
+		return err
+	}
+
+	// the push mirrors synced without error have succeeded at their last update
+	_, err := x.Exec("UPDATE `push_mirror` SET last_success = last_update WHERE last_error = '' OR last_error IS NULL")
+	return err
+}
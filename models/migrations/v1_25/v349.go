@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddFederatedFollowerTable(x *xorm.Engine) error {
+	type FederatedFollower struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		ActorIRI    string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		Inbox       string             `xorm:"TEXT NOT NULL"`
+		SharedInbox string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(FederatedFollower))
+}
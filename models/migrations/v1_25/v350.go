@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddFederatedPullRequestTables(x *xorm.Engine) error {
+	type FederatedUser struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UserID      int64              `xorm:"UNIQUE NOT NULL"`
+		ActorIRI    string             `xorm:"UNIQUE VARCHAR(255) NOT NULL"`
+		Inbox       string             `xorm:"TEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type FederatedFork struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+		OriginIRI   string             `xorm:"VARCHAR(255) NOT NULL"`
+		OriginInbox string             `xorm:"TEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type FederatedIssue struct {
+		ID             int64              `xorm:"pk autoincr"`
+		IssueID        int64              `xorm:"UNIQUE NOT NULL"`
+		TicketIRI      string             `xorm:"VARCHAR(255) INDEX"`
+		OfferIRI       string             `xorm:"VARCHAR(255) INDEX NOT NULL"`
+		RemoteActorIRI string             `xorm:"VARCHAR(255) NOT NULL"`
+		RemoteInbox    string             `xorm:"TEXT NOT NULL"`
+		HeadRepoIRI    string             `xorm:"VARCHAR(255) NOT NULL"`
+		HeadBranch     string             `xorm:"VARCHAR(255) NOT NULL"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(FederatedUser), new(FederatedFork), new(FederatedIssue))
+}
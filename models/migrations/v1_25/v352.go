@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRepoDependencyTable(x *xorm.Engine) error {
+	type RepoDependency struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		CommitID    string             `xorm:"VARCHAR(64)"`
+		Ecosystem   string             `xorm:"VARCHAR(20) INDEX(lookup) NOT NULL"`
+		Name        string             `xorm:"VARCHAR(255) NOT NULL"`
+		LowerName   string             `xorm:"VARCHAR(255) INDEX(lookup) NOT NULL"`
+		Version     string             `xorm:"VARCHAR(255)"`
+		Requirement string             `xorm:"VARCHAR(255)"`
+		Scope       string             `xorm:"VARCHAR(20)"`
+		IsDirect    bool               `xorm:"NOT NULL DEFAULT false"`
+		Manifest    string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(RepoDependency))
+}
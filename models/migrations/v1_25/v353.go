@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddLicensePolicyTable(x *xorm.Engine) error {
+	type RepoDependency struct {
+		License string `xorm:"VARCHAR(255)"`
+	}
+
+	type LicensePolicy struct {
+		ID             int64              `xorm:"pk autoincr"`
+		OrgID          int64              `xorm:"UNIQUE NOT NULL"`
+		DeniedLicenses []string           `xorm:"TEXT JSON"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	// the RepoDependency struct only has the new column, its existing indices mustn't be dropped
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(RepoDependency)); err != nil {
+		return err
+	}
+
+	if err := x.Sync(new(LicensePolicy)); err != nil {
+		return err
+	}
+
+	// rebuild the dependency graphs to record the licenses of the dependencies, 4 is RepoIndexerTypeDependency
+	_, err := x.Table("repo_indexer_status").Where("indexer_type = ?", 4).Delete()
+	return err
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddTagRequirementsToProtectedTag(x *xorm.Engine) error {
+	type ProtectedTag struct {
+		RequireAnnotated bool `xorm:"NOT NULL DEFAULT false"`
+		RequireSigned    bool `xorm:"NOT NULL DEFAULT false"`
+		EnforceSemver    bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	// the struct only has the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(ProtectedTag))
+	return err
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddAttachmentUploadTable(x *xorm.Engine) error {
+	type AttachmentUpload struct {
+		ID            string `xorm:"pk"`
+		RepoID        int64  `xorm:"INDEX NOT NULL"`
+		IssueID       int64  `xorm:"NOT NULL DEFAULT 0"`
+		ReleaseID     int64  `xorm:"NOT NULL DEFAULT 0"`
+		UploaderID    int64  `xorm:"INDEX NOT NULL"`
+		Name          string
+		Size          int64              `xorm:"NOT NULL DEFAULT 0"`
+		BytesReceived int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(AttachmentUpload))
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddMalwareQuarantine(x *xorm.Engine) error {
+	type Attachment struct {
+		IsQuarantined bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	type QuarantinedFile struct {
+		ID           int64 `xorm:"pk autoincr"`
+		Subject      int   `xorm:"NOT NULL"`
+		AttachmentID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID       int64 `xorm:"NOT NULL DEFAULT 0"`
+		UserID       int64 `xorm:"NOT NULL DEFAULT 0"`
+		UploaderID   int64 `xorm:"NOT NULL DEFAULT 0"`
+		Name         string
+		Size         int64 `xorm:"NOT NULL DEFAULT 0"`
+		Signature    string
+		CreatedUnix  timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	// the Attachment struct only has the new column, its existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(Attachment), new(QuarantinedFile))
+	return err
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import "xorm.io/xorm"
+
+func AddDisallowImpersonationToUser(x *xorm.Engine) error {
+	type User struct {
+		DisallowImpersonation bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	// the struct only has the new column, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(User))
+	return err
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddAnnouncementTables(x *xorm.Engine) error {
+	type Announcement struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+		Content     string             `xorm:"TEXT NOT NULL"`
+		Audience    string             `xorm:"VARCHAR(20) NOT NULL"`
+		OrgID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Dismissible bool               `xorm:"NOT NULL DEFAULT true"`
+		StartsUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		EndsUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type AnnouncementDismissal struct {
+		ID             int64              `xorm:"pk autoincr"`
+		AnnouncementID int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID         int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(Announcement), new(AnnouncementDismissal))
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import "xorm.io/xorm"
+
+func AddTeamParentAndInheritedTeamUser(x *xorm.Engine) error {
+	type Team struct {
+		ParentID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+
+	type TeamUser struct {
+		IsInherited bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	// the structs only have the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(Team), new(TeamUser))
+	return err
+}
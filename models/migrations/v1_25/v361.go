@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddOrgRoleTables(x *xorm.Engine) error {
+	type OrgRole struct {
+		ID            int64              `xorm:"pk autoincr"`
+		OrgID         int64              `xorm:"UNIQUE(s) NOT NULL"`
+		LowerName     string             `xorm:"UNIQUE(s) NOT NULL"`
+		Name          string             `xorm:"NOT NULL"`
+		Description   string             `xorm:"TEXT"`
+		Permissions   []string           `xorm:"TEXT JSON"`
+		HasRepoAccess bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type OrgRoleAssignment struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OrgID       int64              `xorm:"INDEX NOT NULL"`
+		RoleID      int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		TeamID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(OrgRole), new(OrgRoleAssignment))
+}
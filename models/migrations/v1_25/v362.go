@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddTermsTables(x *xorm.Engine) error {
+	type TermsDocument struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Kind        string             `xorm:"VARCHAR(20) UNIQUE(s) NOT NULL"`
+		Version     string             `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+		Title       string             `xorm:"NOT NULL"`
+		Content     string             `xorm:"TEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	type TermsAcceptance struct {
+		ID          int64              `xorm:"pk autoincr"`
+		DocumentID  int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	return x.Sync(new(TermsDocument), new(TermsAcceptance))
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddApprovalRequestTable(x *xorm.Engine) error {
+	type ApprovalRequest struct {
+		ID          int64  `xorm:"pk autoincr"`
+		Action      string `xorm:"VARCHAR(50) NOT NULL"`
+		TargetID    int64  `xorm:"NOT NULL"`
+		TargetName  string `xorm:"NOT NULL"`
+		Payload     string `xorm:"TEXT"`
+		RequesterID int64  `xorm:"INDEX NOT NULL"`
+		ApproverID  int64  `xorm:"NOT NULL DEFAULT 0"`
+		Status      string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		Message     string `xorm:"TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+		ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		DecidedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(ApprovalRequest))
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddHeldContentTable(x *xorm.Engine) error {
+	type HeldContent struct {
+		ID               int64 `xorm:"pk autoincr"`
+		Type             int   `xorm:"NOT NULL"`
+		RepoID           int64 `xorm:"INDEX NOT NULL"`
+		IssueID          int64 `xorm:"NOT NULL DEFAULT 0"`
+		PosterID         int64 `xorm:"INDEX NOT NULL"`
+		Title            string
+		Content          string   `xorm:"LONGTEXT"`
+		Attachments      []string `xorm:"JSON TEXT"`
+		Reasons          []string `xorm:"JSON TEXT"`
+		ClassifierReason string   `xorm:"TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	return x.Sync(new(HeldContent))
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddAbuseReportTables(x *xorm.Engine) error {
+	type AbuseReport struct {
+		ID                int64  `xorm:"pk autoincr"`
+		Status            string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		ReporterID        int64  `xorm:"INDEX NOT NULL"`
+		ContentType       string `xorm:"VARCHAR(20) INDEX(content) NOT NULL"`
+		ContentID         int64  `xorm:"INDEX(content) NOT NULL"`
+		OwnerID           int64  `xorm:"INDEX NOT NULL"`
+		RepoID            int64  `xorm:"NOT NULL DEFAULT 0"`
+		ContentSnapshot   string `xorm:"LONGTEXT"`
+		Category          string `xorm:"VARCHAR(20) NOT NULL"`
+		Remarks           string `xorm:"TEXT"`
+		Action            string `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+		ResolutionMessage string `xorm:"TEXT"`
+		ResolverID        int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix  timeutil.TimeStamp `xorm:"INDEX created"`
+		ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type ResolutionTemplate struct {
+		ID      int64  `xorm:"pk autoincr"`
+		Name    string `xorm:"UNIQUE NOT NULL"`
+		Action  string `xorm:"VARCHAR(20) NOT NULL"`
+		Message string `xorm:"TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(AbuseReport), new(ResolutionTemplate))
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddErrorMessageToActionRun(x *xorm.Engine) error {
+	type ActionRun struct {
+		ErrorMessage string `xorm:"TEXT"`
+	}
+	// the ActionRun struct only has the new column, its existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(ActionRun))
+	return err
+}
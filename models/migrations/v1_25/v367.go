@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddEditAndIssueMetasToHeldContent(x *xorm.Engine) error {
+	type HeldContent struct {
+		CommentID     int64   `xorm:"NOT NULL DEFAULT 0"`
+		TitleEdited   bool    `xorm:"NOT NULL DEFAULT false"`
+		ContentEdited bool    `xorm:"NOT NULL DEFAULT false"`
+		LabelIDs      []int64 `xorm:"JSON TEXT"`
+		MilestoneID   int64   `xorm:"NOT NULL DEFAULT 0"`
+		AssigneeIDs   []int64 `xorm:"JSON TEXT"`
+		ProjectID     int64   `xorm:"NOT NULL DEFAULT 0"`
+	}
+	// the HeldContent struct only has the new columns, its existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(HeldContent))
+	return err
+}
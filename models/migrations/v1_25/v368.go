@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddIsHiddenToIssueAndComment(x *xorm.Engine) error {
+	type Issue struct {
+		IsHidden bool `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+	type Comment struct {
+		IsHidden bool `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+	// the structs only have the new columns, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(Issue), new(Comment))
+	return err
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddPreviousVisibilityToAbuseReport(x *xorm.Engine) error {
+	type AbuseReport struct {
+		PreviousVisibility string `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	}
+	// the struct only has the new column, the existing indices mustn't be dropped
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(AbuseReport))
+	return err
+}
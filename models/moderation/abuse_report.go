@@ -0,0 +1,323 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ReportContentType is the kind of the reported content
+type ReportContentType string
+
+const (
+	ReportContentUser    ReportContentType = "user"
+	ReportContentRepo    ReportContentType = "repo"
+	ReportContentIssue   ReportContentType = "issue"
+	ReportContentComment ReportContentType = "comment"
+)
+
+// IsValid checks whether the type is known
+func (t ReportContentType) IsValid() bool {
+	switch t {
+	case ReportContentUser, ReportContentRepo, ReportContentIssue, ReportContentComment:
+		return true
+	}
+	return false
+}
+
+// ReportCategory is the kind of abuse the reporter complains about
+type ReportCategory string
+
+const (
+	ReportCategorySpam          ReportCategory = "spam"
+	ReportCategoryMalware       ReportCategory = "malware"
+	ReportCategoryHarassment    ReportCategory = "harassment"
+	ReportCategoryIllegal       ReportCategory = "illegal"
+	ReportCategoryInappropriate ReportCategory = "inappropriate"
+	ReportCategoryOther         ReportCategory = "other"
+)
+
+// ReportCategories are the categories in the order they are offered to the reporter
+var ReportCategories = []ReportCategory{
+	ReportCategorySpam,
+	ReportCategoryMalware,
+	ReportCategoryHarassment,
+	ReportCategoryIllegal,
+	ReportCategoryInappropriate,
+	ReportCategoryOther,
+}
+
+// IsValid checks whether the category is known
+func (c ReportCategory) IsValid() bool {
+	for _, category := range ReportCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportStatus is the state of a report
+type ReportStatus string
+
+const (
+	// ReportStatusOpen means the report waits for a moderator
+	ReportStatusOpen ReportStatus = "open"
+	// ReportStatusResolved means a moderator has handled the report, the taken action is kept in the report
+	ReportStatusResolved ReportStatus = "resolved"
+)
+
+// IsValid checks whether the status is known
+func (s ReportStatus) IsValid() bool {
+	return s == ReportStatusOpen || s == ReportStatusResolved
+}
+
+// ModerationAction is what a moderator does about the reported content
+type ModerationAction string
+
+const (
+	// ModerationActionNone dismisses the report
+	ModerationActionNone ModerationAction = "none"
+	// ModerationActionHide hides the reported issue, pull request or comment until a moderator unhides it, makes the
+	// reported repository private or makes the profile of the reported user private
+	ModerationActionHide ModerationAction = "hide"
+	// ModerationActionWarn sends the message of the resolution to the owner of the reported content
+	ModerationActionWarn ModerationAction = "warn"
+	// ModerationActionSuspend prohibits the owner of the reported content from signing in
+	ModerationActionSuspend ModerationAction = "suspend"
+)
+
+// ModerationActions are the actions in the order they are offered to the moderator
+var ModerationActions = []ModerationAction{
+	ModerationActionNone,
+	ModerationActionHide,
+	ModerationActionWarn,
+	ModerationActionSuspend,
+}
+
+// IsValid checks whether the action is known
+func (a ModerationAction) IsValid() bool {
+	for _, action := range ModerationActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// AbuseReport is a report about an issue, a comment, a repository or a user which violates the rules of the instance
+type AbuseReport struct {
+	ID          int64             `xorm:"pk autoincr"`
+	Status      ReportStatus      `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	ReporterID  int64             `xorm:"INDEX NOT NULL"`
+	Reporter    *user_model.User  `xorm:"-"`
+	ContentType ReportContentType `xorm:"VARCHAR(20) INDEX(content) NOT NULL"`
+	ContentID   int64             `xorm:"INDEX(content) NOT NULL"`
+	// OwnerID is the reported user, the owner of the reported repository or the poster of the reported issue or
+	// comment, it's the account which is warned or suspended
+	OwnerID int64            `xorm:"INDEX NOT NULL"`
+	Owner   *user_model.User `xorm:"-"`
+	// RepoID is the reported repository or the repository of the reported issue or comment
+	RepoID int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ContentSnapshot is a copy of the content when it was reported, the content may be changed or hidden later
+	ContentSnapshot string         `xorm:"LONGTEXT"`
+	ContentLink     string         `xorm:"-"` // empty if the content doesn't exist anymore
+	IsContentHidden bool           `xorm:"-"` // whether the reported content is hidden by a moderator and can be unhidden
+	Category        ReportCategory `xorm:"VARCHAR(20) NOT NULL"`
+	Remarks         string         `xorm:"TEXT"`
+
+	Action            ModerationAction `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	ResolutionMessage string           `xorm:"TEXT"`
+	ResolverID        int64            `xorm:"NOT NULL DEFAULT 0"`
+	Resolver          *user_model.User `xorm:"-"`
+	// PreviousVisibility is the visibility of the reported user or repository before the hide action made it private,
+	// it's restored when the content is unhidden
+	PreviousVisibility string `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+
+	CreatedUnix  timeutil.TimeStamp `xorm:"INDEX created"`
+	ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(AbuseReport))
+}
+
+// ErrAbuseReportNotExist represents an "abuse report not exist" error
+var ErrAbuseReportNotExist = util.NewNotExistErrorf("abuse report does not exist")
+
+// IsOpen checks whether the report waits for a moderator
+func (r *AbuseReport) IsOpen() bool {
+	return r.Status == ReportStatusOpen
+}
+
+func loadAbuseReportUser(ctx context.Context, id int64) (*user_model.User, error) {
+	u, err := user_model.GetUserByID(ctx, id)
+	if user_model.IsErrUserNotExist(err) {
+		return user_model.NewGhostUser(), nil
+	}
+	return u, err
+}
+
+// LoadAttributes loads the reporter, the owner, the resolver and the link of the content
+func (r *AbuseReport) LoadAttributes(ctx context.Context) (err error) {
+	if r.Reporter == nil {
+		if r.Reporter, err = loadAbuseReportUser(ctx, r.ReporterID); err != nil {
+			return err
+		}
+	}
+	if r.Owner == nil {
+		if r.Owner, err = loadAbuseReportUser(ctx, r.OwnerID); err != nil {
+			return err
+		}
+	}
+	if r.Resolver == nil && r.ResolverID > 0 {
+		if r.Resolver, err = loadAbuseReportUser(ctx, r.ResolverID); err != nil {
+			return err
+		}
+	}
+	if r.ContentLink == "" {
+		return r.loadContentLink(ctx)
+	}
+	return nil
+}
+
+func (r *AbuseReport) loadContentLink(ctx context.Context) error {
+	switch r.ContentType {
+	case ReportContentUser:
+		if !r.Owner.IsGhost() {
+			r.ContentLink = r.Owner.HomeLink()
+			r.IsContentHidden = r.Owner.Visibility.IsPrivate() && r.CanRestoreVisibility()
+		}
+	case ReportContentRepo:
+		repo, err := repo_model.GetRepositoryByID(ctx, r.ContentID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		r.ContentLink = repo.Link()
+		r.IsContentHidden = repo.IsPrivate && r.CanRestoreVisibility()
+	case ReportContentIssue:
+		issue, err := issues_model.GetIssueByID(ctx, r.ContentID)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		r.ContentLink = issue.Link()
+		r.IsContentHidden = issue.IsHidden
+	case ReportContentComment:
+		comment, err := issues_model.GetCommentByID(ctx, r.ContentID)
+		if err != nil {
+			if issues_model.IsErrCommentNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		r.ContentLink = comment.Link(ctx)
+		r.IsContentHidden = comment.IsHidden
+	}
+	return nil
+}
+
+// CanRestoreVisibility checks whether the hide action of the report made the reported user or repository private, so
+// unhiding it restores the previous visibility
+func (r *AbuseReport) CanRestoreVisibility() bool {
+	return r.Action == ModerationActionHide && r.PreviousVisibility != "" &&
+		r.PreviousVisibility != structs.VisibleTypePrivate.String()
+}
+
+// CreateAbuseReport inserts an open report
+func CreateAbuseReport(ctx context.Context, r *AbuseReport) error {
+	r.Status = ReportStatusOpen
+	return db.Insert(ctx, r)
+}
+
+// GetAbuseReportByID returns the report
+func GetAbuseReportByID(ctx context.Context, id int64) (*AbuseReport, error) {
+	r := &AbuseReport{}
+	has, err := db.GetEngine(ctx).ID(id).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAbuseReportNotExist
+	}
+	return r, nil
+}
+
+// HasOpenAbuseReport checks whether the user has already reported the content and the report hasn't been resolved yet
+func HasOpenAbuseReport(ctx context.Context, reporterID int64, contentType ReportContentType, contentID int64) (bool, error) {
+	return db.GetEngine(ctx).Where(builder.Eq{
+		"reporter_id":  reporterID,
+		"content_type": contentType,
+		"content_id":   contentID,
+		"status":       ReportStatusOpen,
+	}).Exist(new(AbuseReport))
+}
+
+// ResolveOpenAbuseReports stores the resolution of the report in all open reports of the same content, it returns
+// false if the report has been resolved meanwhile
+func ResolveOpenAbuseReports(ctx context.Context, r *AbuseReport) (bool, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (bool, error) {
+		cols := []string{"status", "action", "resolution_message", "resolver_id", "resolved_unix", "previous_visibility"}
+		n, err := db.GetEngine(ctx).ID(r.ID).Where(builder.Eq{"status": ReportStatusOpen}).Cols(cols...).Update(r)
+		if err != nil || n == 0 {
+			return false, err
+		}
+		resolution := *r
+		resolution.ID = 0
+		_, err = db.GetEngine(ctx).
+			Where(builder.Eq{"content_type": r.ContentType, "content_id": r.ContentID, "status": ReportStatusOpen}).
+			Cols(cols...).
+			Update(&resolution)
+		return err == nil, err
+	})
+}
+
+// FindAbuseReportsOptions are the options of finding abuse reports
+type FindAbuseReportsOptions struct {
+	db.ListOptions
+	Status      ReportStatus
+	ContentType ReportContentType
+	ContentID   int64
+	OwnerID     int64
+}
+
+// ToConds implements db.FindOptions
+func (opts FindAbuseReportsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.Status != "" {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	if opts.ContentType != "" {
+		cond = cond.And(builder.Eq{"content_type": opts.ContentType})
+	}
+	if opts.ContentID > 0 {
+		cond = cond.And(builder.Eq{"content_id": opts.ContentID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindAbuseReportsOptions) ToOrders() string {
+	return "id DESC"
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ResolutionTemplate is a predefined resolution of reports, it's used for the recurring cases
+type ResolutionTemplate struct {
+	ID     int64            `xorm:"pk autoincr"`
+	Name   string           `xorm:"UNIQUE NOT NULL"`
+	Action ModerationAction `xorm:"VARCHAR(20) NOT NULL"`
+	// Message is sent to the owner of the reported content if the action is warn or suspend
+	Message string `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ResolutionTemplate))
+}
+
+// ErrResolutionTemplateNotExist represents a "resolution template not exist" error
+var ErrResolutionTemplateNotExist = util.NewNotExistErrorf("resolution template does not exist")
+
+// CreateResolutionTemplate inserts the template, the name has to be unique
+func CreateResolutionTemplate(ctx context.Context, t *ResolutionTemplate) error {
+	if !t.Action.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid moderation action %q", t.Action)
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where(builder.Eq{"name": t.Name}).Exist(new(ResolutionTemplate))
+		if err != nil {
+			return err
+		} else if has {
+			return util.NewAlreadyExistErrorf("resolution template %q already exists", t.Name)
+		}
+		return db.Insert(ctx, t)
+	})
+}
+
+// GetResolutionTemplateByID returns the template
+func GetResolutionTemplateByID(ctx context.Context, id int64) (*ResolutionTemplate, error) {
+	t := &ResolutionTemplate{}
+	has, err := db.GetEngine(ctx).ID(id).Get(t)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrResolutionTemplateNotExist
+	}
+	return t, nil
+}
+
+// DeleteResolutionTemplateByID deletes the template, the reports resolved with it keep their resolution
+func DeleteResolutionTemplateByID(ctx context.Context, id int64) error {
+	n, err := db.GetEngine(ctx).ID(id).Delete(new(ResolutionTemplate))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrResolutionTemplateNotExist
+	}
+	return nil
+}
+
+// FindResolutionTemplatesOptions are the options of finding resolution templates
+type FindResolutionTemplatesOptions struct {
+	db.ListOptions
+}
+
+// ToConds implements db.FindOptions
+func (opts FindResolutionTemplatesOptions) ToConds() builder.Cond {
+	return builder.NewCond()
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindResolutionTemplatesOptions) ToOrders() string {
+	return "name ASC"
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// LicensePolicy denies licenses in the repositories of an organization,
+// the licenses of the code and of the dependencies of the repositories are checked against it
+type LicensePolicy struct {
+	ID    int64 `xorm:"pk autoincr"`
+	OrgID int64 `xorm:"UNIQUE NOT NULL"`
+	// DeniedLicenses are SPDX license identifiers
+	DeniedLicenses []string           `xorm:"TEXT JSON"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(LicensePolicy))
+}
+
+// DeniedLicenseSet returns the normalized identifiers of the denied licenses
+func (p *LicensePolicy) DeniedLicenseSet() container.Set[string] {
+	denied := make(container.Set[string], len(p.DeniedLicenses))
+	for _, license := range p.DeniedLicenses {
+		denied.Add(sbom.NormalizeLicenseID(license))
+	}
+	return denied
+}
+
+// GetLicensePolicy returns the license policy of an organization
+func GetLicensePolicy(ctx context.Context, orgID int64) (*LicensePolicy, error) {
+	p := new(LicensePolicy)
+	has, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Get(p)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("license policy of organization %d does not exist", orgID)
+	}
+	return p, nil
+}
+
+// SetLicensePolicy creates or updates the license policy of an organization
+func SetLicensePolicy(ctx context.Context, orgID int64, deniedLicenses []string) (*LicensePolicy, error) {
+	denied := make(container.Set[string], len(deniedLicenses))
+	for _, license := range deniedLicenses {
+		if license = strings.TrimSpace(license); license != "" {
+			if strings.ContainsAny(license, " ()") {
+				return nil, util.NewInvalidArgumentErrorf("%q isn't a SPDX license identifier", license)
+			}
+			denied.Add(license)
+		}
+	}
+	if len(denied) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("a license policy must deny licenses")
+	}
+	values := denied.Values()
+	slices.Sort(values)
+
+	return db.WithTx2(ctx, func(ctx context.Context) (*LicensePolicy, error) {
+		p, err := GetLicensePolicy(ctx, orgID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+		if p == nil {
+			p = &LicensePolicy{OrgID: orgID, DeniedLicenses: values}
+			return p, db.Insert(ctx, p)
+		}
+		p.DeniedLicenses = values
+		_, err = db.GetEngine(ctx).ID(p.ID).Cols("denied_licenses").Update(p)
+		return p, err
+	})
+}
+
+// DeleteLicensePolicy deletes the license policy of an organization
+func DeleteLicensePolicy(ctx context.Context, orgID int64) error {
+	_, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Delete(new(LicensePolicy))
+	return err
+}
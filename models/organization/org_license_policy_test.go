@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicensePolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	_, err := organization.GetLicensePolicy(t.Context(), 3)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	_, err = organization.SetLicensePolicy(t.Context(), 3, []string{" ", ""})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = organization.SetLicensePolicy(t.Context(), 3, []string{"MIT OR GPL-3.0"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	p, err := organization.SetLicensePolicy(t.Context(), 3, []string{"GPL-3.0-or-later", " AGPL-3.0", "GPL-3.0-or-later"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AGPL-3.0", "GPL-3.0-or-later"}, p.DeniedLicenses)
+	assert.Equal(t, container.SetOf("agpl-3.0", "gpl-3.0"), p.DeniedLicenseSet())
+
+	// setting the policy again replaces the denied licenses
+	_, err = organization.SetLicensePolicy(t.Context(), 3, []string{"SSPL-1.0"})
+	require.NoError(t, err)
+	p, err = organization.GetLicensePolicy(t.Context(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SSPL-1.0"}, p.DeniedLicenses)
+	unittest.AssertCount(t, &organization.LicensePolicy{OrgID: 3}, 1)
+
+	require.NoError(t, organization.DeleteLicensePolicy(t.Context(), 3))
+	unittest.AssertNotExistsBean(t, &organization.LicensePolicy{OrgID: 3})
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// OrgRolePermission is a permission of the catalog which custom organization roles are composed from
+type OrgRolePermission string //nolint:revive // export stutter
+
+const (
+	// OrgRolePermissionCreateRepo allows to create repositories in the organization
+	OrgRolePermissionCreateRepo OrgRolePermission = "repo.create"
+	// OrgRolePermissionAdminRepo grants administrator access to all repositories of the organization
+	OrgRolePermissionAdminRepo OrgRolePermission = "repo.admin"
+)
+
+// orgRoleUnitPermissions are the permissions which grant access to a unit of all repositories of the organization
+var orgRoleUnitPermissions = map[OrgRolePermission]struct {
+	Unit unit.Type
+	Mode perm.AccessMode
+}{
+	"code.read":     {unit.TypeCode, perm.AccessModeRead},
+	"code.write":    {unit.TypeCode, perm.AccessModeWrite},
+	"issues.read":   {unit.TypeIssues, perm.AccessModeRead},
+	"issues.write":  {unit.TypeIssues, perm.AccessModeWrite},
+	"pulls.read":    {unit.TypePullRequests, perm.AccessModeRead},
+	"pulls.write":   {unit.TypePullRequests, perm.AccessModeWrite},
+	"releases.read": {unit.TypeReleases, perm.AccessModeRead},
+	// creating a release creates its tag
+	"releases.write": {unit.TypeReleases, perm.AccessModeWrite},
+	"wiki.read":      {unit.TypeWiki, perm.AccessModeRead},
+	"wiki.write":     {unit.TypeWiki, perm.AccessModeWrite},
+	"projects.read":  {unit.TypeProjects, perm.AccessModeRead},
+	"projects.write": {unit.TypeProjects, perm.AccessModeWrite},
+	"packages.read":  {unit.TypePackages, perm.AccessModeRead},
+	"packages.write": {unit.TypePackages, perm.AccessModeWrite},
+	"actions.read":   {unit.TypeActions, perm.AccessModeRead},
+	"actions.write":  {unit.TypeActions, perm.AccessModeWrite},
+}
+
+// OrgRolePermissionCatalog returns all permissions custom organization roles can be composed from
+func OrgRolePermissionCatalog() []OrgRolePermission {
+	catalog := make([]OrgRolePermission, 0, len(orgRoleUnitPermissions)+2)
+	for p := range orgRoleUnitPermissions {
+		catalog = append(catalog, p)
+	}
+	slices.Sort(catalog)
+	return append(catalog, OrgRolePermissionCreateRepo, OrgRolePermissionAdminRepo)
+}
+
+// IsValid checks whether the permission is in the catalog
+func (p OrgRolePermission) IsValid() bool {
+	_, ok := orgRoleUnitPermissions[p]
+	return ok || p == OrgRolePermissionCreateRepo || p == OrgRolePermissionAdminRepo
+}
+
+// OrgRole is a custom role of an organization, it grants its permissions on all repositories of the organization to
+// the users it is assigned to directly or through a team
+type OrgRole struct { //nolint:revive // export stutter
+	ID          int64               `xorm:"pk autoincr"`
+	OrgID       int64               `xorm:"UNIQUE(s) NOT NULL"`
+	LowerName   string              `xorm:"UNIQUE(s) NOT NULL"`
+	Name        string              `xorm:"NOT NULL"`
+	Description string              `xorm:"TEXT"`
+	Permissions []OrgRolePermission `xorm:"TEXT JSON"`
+	// HasRepoAccess is true if the role grants access to any unit of the repositories, it makes the private
+	// repositories visible to the users of the role
+	HasRepoAccess bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// OrgRoleAssignment assigns a custom role to a member or a team of the organization
+type OrgRoleAssignment struct { //nolint:revive // export stutter
+	ID          int64              `xorm:"pk autoincr"`
+	OrgID       int64              `xorm:"INDEX NOT NULL"`
+	RoleID      int64              `xorm:"UNIQUE(s) NOT NULL"`
+	UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	TeamID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgRole))
+	db.RegisterModel(new(OrgRoleAssignment))
+}
+
+// ErrOrgRoleNotExist represents a "org role not exist" error
+var ErrOrgRoleNotExist = util.NewNotExistErrorf("organization role does not exist")
+
+// ErrOrgRoleAlreadyExist represents a "org role already exist" error
+var ErrOrgRoleAlreadyExist = util.NewAlreadyExistErrorf("organization role already exists")
+
+// HasPermission checks whether the role contains the permission
+func (r *OrgRole) HasPermission(p OrgRolePermission) bool {
+	return slices.Contains(r.Permissions, p)
+}
+
+// SetPermissions validates and sets the permissions of the role
+func (r *OrgRole) SetPermissions(permissions []OrgRolePermission) error {
+	set := make(container.Set[OrgRolePermission], len(permissions))
+	r.Permissions = make([]OrgRolePermission, 0, len(permissions))
+	r.HasRepoAccess = false
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return util.NewInvalidArgumentErrorf("unknown permission %q", p)
+		}
+		if !set.Add(p) {
+			continue
+		}
+		r.Permissions = append(r.Permissions, p)
+		_, isUnitPermission := orgRoleUnitPermissions[p]
+		r.HasRepoAccess = r.HasRepoAccess || isUnitPermission || p == OrgRolePermissionAdminRepo
+	}
+	slices.Sort(r.Permissions)
+	return nil
+}
+
+func checkOrgRoleName(ctx context.Context, r *OrgRole) error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return util.NewInvalidArgumentErrorf("empty role name")
+	}
+	r.LowerName = strings.ToLower(r.Name)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"org_id": r.OrgID, "lower_name": r.LowerName}.And(builder.Neq{"id": r.ID})).Exist(new(OrgRole))
+	if err != nil {
+		return err
+	} else if has {
+		return ErrOrgRoleAlreadyExist
+	}
+	return nil
+}
+
+// CreateOrgRole creates a custom role of the organization
+func CreateOrgRole(ctx context.Context, r *OrgRole) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkOrgRoleName(ctx, r); err != nil {
+			return err
+		}
+		return db.Insert(ctx, r)
+	})
+}
+
+// UpdateOrgRole updates the name, the description and the permissions of the role
+func UpdateOrgRole(ctx context.Context, r *OrgRole) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkOrgRoleName(ctx, r); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(r.ID).Cols("name", "lower_name", "description", "permissions", "has_repo_access").Update(r)
+		return err
+	})
+}
+
+// DeleteOrgRole deletes the role and its assignments
+func DeleteOrgRole(ctx context.Context, r *OrgRole) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("role_id = ?", r.ID).Delete(new(OrgRoleAssignment)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(r.ID).Delete(new(OrgRole))
+		return err
+	})
+}
+
+// GetOrgRoleByID returns the role of the organization
+func GetOrgRoleByID(ctx context.Context, orgID, id int64) (*OrgRole, error) {
+	r := &OrgRole{}
+	has, err := db.GetEngine(ctx).Where("org_id = ? AND id = ?", orgID, id).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrOrgRoleNotExist
+	}
+	return r, nil
+}
+
+// FindOrgRoles returns all roles of the organization
+func FindOrgRoles(ctx context.Context, orgID int64) ([]*OrgRole, error) {
+	roles := make([]*OrgRole, 0, 5)
+	return roles, db.GetEngine(ctx).Where("org_id = ?", orgID).OrderBy("lower_name").Find(&roles)
+}
+
+// GetOrgRoleAssignments returns the assignments of the role
+func GetOrgRoleAssignments(ctx context.Context, roleID int64) ([]*OrgRoleAssignment, error) {
+	assignments := make([]*OrgRoleAssignment, 0, 10)
+	return assignments, db.GetEngine(ctx).Where("role_id = ?", roleID).OrderBy("id").Find(&assignments)
+}
+
+// AssignOrgRole assigns the role to the user or the team, only one of them must be given
+func AssignOrgRole(ctx context.Context, r *OrgRole, userID, teamID int64) error {
+	assignment := &OrgRoleAssignment{OrgID: r.OrgID, RoleID: r.ID, UserID: userID, TeamID: teamID}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Exist(&OrgRoleAssignment{RoleID: r.ID, UserID: userID, TeamID: teamID})
+		if err != nil || has {
+			return err
+		}
+		return db.Insert(ctx, assignment)
+	})
+}
+
+// UnassignOrgRole removes the role from the user or the team
+func UnassignOrgRole(ctx context.Context, r *OrgRole, userID, teamID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"role_id": r.ID, "user_id": userID, "team_id": teamID}).Delete(new(OrgRoleAssignment))
+	return err
+}
+
+// userOrgRoleCond selects the assignments of the user, directly or through the teams
+func userOrgRoleCond(userID int64) builder.Cond {
+	return builder.Eq{"org_role_assignment.user_id": userID}.
+		Or(builder.In("org_role_assignment.team_id", builder.Select("team_id").From("team_user").Where(builder.Eq{"uid": userID})))
+}
+
+// GetUserOrgRoles returns the roles the user has in the organization, directly or through the teams
+func GetUserOrgRoles(ctx context.Context, orgID, userID int64) ([]*OrgRole, error) {
+	roles := make([]*OrgRole, 0, 5)
+	return roles, db.GetEngine(ctx).
+		Where(builder.In("id", builder.Select("role_id").From("org_role_assignment").
+			Where(builder.Eq{"org_role_assignment.org_id": orgID}.And(userOrgRoleCond(userID))))).
+		OrderBy("lower_name").
+		Find(&roles)
+}
+
+// OrgRoleRepoOwnerBuilder returns the organizations whose repositories the user can access through the roles
+func OrgRoleRepoOwnerBuilder(userID int64) *builder.Builder {
+	return builder.Select("org_role_assignment.org_id").
+		From("org_role_assignment").
+		Join("INNER", "org_role", "org_role.id = org_role_assignment.role_id").
+		Where(builder.Eq{"org_role.has_repo_access": true}.And(userOrgRoleCond(userID)))
+}
+
+// OrgRolePermissions are the permissions of the roles of a user
+type OrgRolePermissions []*OrgRole //nolint:revive // export stutter
+
+// Has checks whether one of the roles contains the permission
+func (roles OrgRolePermissions) Has(p OrgRolePermission) bool {
+	for _, r := range roles {
+		if r.HasPermission(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnitAccessMode returns the highest access mode of the unit the roles grant
+func (roles OrgRolePermissions) UnitAccessMode(tp unit.Type) perm.AccessMode {
+	mode := perm.AccessModeNone
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if up, ok := orgRoleUnitPermissions[p]; ok && up.Unit == tp {
+				mode = max(mode, up.Mode)
+			}
+		}
+	}
+	return mode
+}
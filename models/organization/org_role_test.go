@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRoles(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	role := &organization.OrgRole{OrgID: 3, Name: "Release Manager"}
+	assert.ErrorIs(t, role.SetPermissions([]organization.OrgRolePermission{"settings.write"}), util.ErrInvalidArgument)
+	require.NoError(t, role.SetPermissions([]organization.OrgRolePermission{"releases.write", "code.read", "releases.write", organization.OrgRolePermissionCreateRepo}))
+	assert.Equal(t, []organization.OrgRolePermission{"code.read", "releases.write", "repo.create"}, role.Permissions)
+	assert.True(t, role.HasRepoAccess)
+	require.NoError(t, organization.CreateOrgRole(t.Context(), role))
+	assert.ErrorIs(t, organization.CreateOrgRole(t.Context(), &organization.OrgRole{OrgID: 3, Name: "release manager"}), util.ErrAlreadyExist)
+
+	creator := &organization.OrgRole{OrgID: 3, Name: "Creator"}
+	require.NoError(t, creator.SetPermissions([]organization.OrgRolePermission{organization.OrgRolePermissionCreateRepo}))
+	assert.False(t, creator.HasRepoAccess)
+	require.NoError(t, organization.CreateOrgRole(t.Context(), creator))
+
+	// user4 is a member of team 2 of org3, which can't create repositories
+	canCreate, err := organization.CanCreateOrgRepo(t.Context(), 3, 4)
+	require.NoError(t, err)
+	assert.False(t, canCreate)
+
+	require.NoError(t, organization.AssignOrgRole(t.Context(), role, 0, 2))
+	require.NoError(t, organization.AssignOrgRole(t.Context(), role, 4, 0))
+	require.NoError(t, organization.AssignOrgRole(t.Context(), role, 4, 0))
+	require.NoError(t, organization.AssignOrgRole(t.Context(), creator, 4, 0))
+	assignments, err := organization.GetOrgRoleAssignments(t.Context(), role.ID)
+	require.NoError(t, err)
+	assert.Len(t, assignments, 2)
+
+	roles, err := organization.GetUserOrgRoles(t.Context(), 3, 4)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, creator.ID, roles[0].ID)
+	assert.Equal(t, role.ID, roles[1].ID)
+	assert.Equal(t, perm.AccessModeWrite, organization.OrgRolePermissions(roles).UnitAccessMode(unit.TypeReleases))
+	assert.Equal(t, perm.AccessModeRead, organization.OrgRolePermissions(roles).UnitAccessMode(unit.TypeCode))
+	assert.Equal(t, perm.AccessModeNone, organization.OrgRolePermissions(roles).UnitAccessMode(unit.TypeIssues))
+
+	canCreate, err = organization.CanCreateOrgRepo(t.Context(), 3, 4)
+	require.NoError(t, err)
+	assert.True(t, canCreate)
+
+	// the role is still granted through team 2
+	require.NoError(t, organization.UnassignOrgRole(t.Context(), role, 4, 0))
+	require.NoError(t, organization.DeleteOrgRole(t.Context(), creator))
+	roles, err = organization.GetUserOrgRoles(t.Context(), 3, 4)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, role.ID, roles[0].ID)
+	unittest.AssertNotExistsBean(t, &organization.OrgRoleAssignment{RoleID: creator.ID})
+
+	// user28 is a member of team 12, not of team 2
+	roles, err = organization.GetUserOrgRoles(t.Context(), 3, 28)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+}
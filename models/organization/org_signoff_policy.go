@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// SignOffPolicy requires the commits of the pull requests of all repositories of an organization
+// to be signed off by their authors (DCO), in addition to the repositories requiring it themselves
+type SignOffPolicy struct {
+	ID    int64 `xorm:"pk autoincr"`
+	OrgID int64 `xorm:"UNIQUE NOT NULL"`
+	// ExemptUsers are the names of the users whose commits don't need to be signed off, e.g. bots
+	ExemptUsers []string           `xorm:"TEXT JSON"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(SignOffPolicy))
+}
+
+// GetSignOffPolicy returns the sign-off policy of an organization
+func GetSignOffPolicy(ctx context.Context, orgID int64) (*SignOffPolicy, error) {
+	p := new(SignOffPolicy)
+	has, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Get(p)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("sign-off policy of organization %d does not exist", orgID)
+	}
+	return p, nil
+}
+
+// SetSignOffPolicy creates or updates the sign-off policy of an organization
+func SetSignOffPolicy(ctx context.Context, orgID int64, exemptUsers []string) (*SignOffPolicy, error) {
+	exempt := make(container.Set[string], len(exemptUsers))
+	for _, name := range exemptUsers {
+		if name = strings.TrimSpace(name); name != "" {
+			exempt.Add(name)
+		}
+	}
+	values := exempt.Values()
+	slices.Sort(values)
+
+	return db.WithTx2(ctx, func(ctx context.Context) (*SignOffPolicy, error) {
+		p, err := GetSignOffPolicy(ctx, orgID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+		if p == nil {
+			p = &SignOffPolicy{OrgID: orgID, ExemptUsers: values}
+			return p, db.Insert(ctx, p)
+		}
+		p.ExemptUsers = values
+		_, err = db.GetEngine(ctx).ID(p.ID).Cols("exempt_users").Update(p)
+		return p, err
+	})
+}
+
+// DeleteSignOffPolicy deletes the sign-off policy of an organization
+func DeleteSignOffPolicy(ctx context.Context, orgID int64) error {
+	_, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Delete(new(SignOffPolicy))
+	return err
+}
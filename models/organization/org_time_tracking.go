@@ -0,0 +1,124 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// TimeTrackingRule restricts who may track time on the issues of the repositories of an organization.
+// The owners of the organization always may, the members of the teams of the rule may too.
+type TimeTrackingRule struct {
+	ID    int64 `xorm:"pk autoincr"`
+	OrgID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// RepoID is 0 for the rule of the repositories of the organization without their own rule
+	RepoID      int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	TeamIDs     []int64            `xorm:"TEXT JSON"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(TimeTrackingRule))
+}
+
+func (r *TimeTrackingRule) validate(ctx context.Context) error {
+	r.TeamIDs = container.SetOf(r.TeamIDs...).Values()
+	if len(r.TeamIDs) == 0 {
+		return nil
+	}
+	count, err := db.GetEngine(ctx).In("id", r.TeamIDs).Where("org_id = ?", r.OrgID).Count(new(Team))
+	if err != nil {
+		return err
+	}
+	if count != int64(len(r.TeamIDs)) {
+		return util.NewInvalidArgumentErrorf("the teams of a time tracking rule must belong to the organization")
+	}
+	return nil
+}
+
+// NewTimeTrackingRule creates a time tracking rule of an organization, a repository has at most one rule
+func NewTimeTrackingRule(ctx context.Context, r *TimeTrackingRule) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.validate(ctx); err != nil {
+			return err
+		}
+		exist, err := db.GetEngine(ctx).Exist(&TimeTrackingRule{OrgID: r.OrgID, RepoID: r.RepoID})
+		if err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("a time tracking rule already exists for this repository")
+		}
+		return db.Insert(ctx, r)
+	})
+}
+
+// UpdateTimeTrackingRule updates the teams of a time tracking rule
+func UpdateTimeTrackingRule(ctx context.Context, r *TimeTrackingRule) error {
+	if err := r.validate(ctx); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("team_i_ds").Update(r)
+	return err
+}
+
+// DeleteTimeTrackingRule deletes a time tracking rule
+func DeleteTimeTrackingRule(ctx context.Context, r *TimeTrackingRule) error {
+	_, err := db.DeleteByID[TimeTrackingRule](ctx, r.ID)
+	return err
+}
+
+// GetTimeTrackingRuleByID returns a time tracking rule of an organization
+func GetTimeTrackingRuleByID(ctx context.Context, orgID, id int64) (*TimeTrackingRule, error) {
+	r := new(TimeTrackingRule)
+	has, err := db.GetEngine(ctx).Where("id = ? AND org_id = ?", id, orgID).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("time tracking rule %d does not exist", id)
+	}
+	return r, nil
+}
+
+// GetTimeTrackingRules returns the time tracking rules of an organization, the default rule first
+func GetTimeTrackingRules(ctx context.Context, orgID int64) ([]*TimeTrackingRule, error) {
+	rules := make([]*TimeTrackingRule, 0, 5)
+	return rules, db.GetEngine(ctx).Where("org_id = ?", orgID).OrderBy("repo_id").Find(&rules)
+}
+
+// CanTrackTime returns whether the time tracking rules of an organization let a user track time on a repository.
+// Without rule everyone the repository settings allow to may.
+func CanTrackTime(ctx context.Context, orgID, repoID, userID int64) (bool, error) {
+	rules := make([]*TimeTrackingRule, 0, 2)
+	if err := db.GetEngine(ctx).Where(builder.Eq{"org_id": orgID}.And(builder.In("repo_id", repoID, 0))).
+		OrderBy("repo_id DESC").Find(&rules); err != nil {
+		return false, err
+	}
+	if len(rules) == 0 {
+		return true, nil
+	}
+	if userID == 0 {
+		return false, nil
+	}
+
+	isOwner, err := IsOrganizationOwner(ctx, orgID, userID)
+	if err != nil {
+		return false, fmt.Errorf("IsOrganizationOwner: %w", err)
+	} else if isOwner {
+		return true, nil
+	}
+	// the rule of the repository takes precedence over the default rule
+	if len(rules[0].TeamIDs) == 0 {
+		return false, nil
+	}
+	return IsUserInTeams(ctx, userID, rules[0].TeamIDs)
+}
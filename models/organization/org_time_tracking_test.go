@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTrackingRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// without rule, everyone may track time
+	canTrack, err := organization.CanTrackTime(t.Context(), 3, 3, 5)
+	require.NoError(t, err)
+	assert.True(t, canTrack)
+
+	// team 5 belongs to org17
+	assert.ErrorIs(t, organization.NewTimeTrackingRule(t.Context(), &organization.TimeTrackingRule{OrgID: 3, TeamIDs: []int64{5}}), util.ErrInvalidArgument)
+
+	defaultRule := &organization.TimeTrackingRule{OrgID: 3, TeamIDs: []int64{2, 2}}
+	require.NoError(t, organization.NewTimeTrackingRule(t.Context(), defaultRule))
+	assert.Equal(t, []int64{2}, defaultRule.TeamIDs)
+	assert.ErrorIs(t, organization.NewTimeTrackingRule(t.Context(), &organization.TimeTrackingRule{OrgID: 3}), util.ErrAlreadyExist)
+
+	// user4 is a member of team 2, user5 isn't a member of org3
+	canTrack, err = organization.CanTrackTime(t.Context(), 3, 3, 4)
+	require.NoError(t, err)
+	assert.True(t, canTrack)
+	canTrack, err = organization.CanTrackTime(t.Context(), 3, 3, 5)
+	require.NoError(t, err)
+	assert.False(t, canTrack)
+
+	// the rule of the repository takes precedence, the owners always may track time
+	repoRule := &organization.TimeTrackingRule{OrgID: 3, RepoID: 3}
+	require.NoError(t, organization.NewTimeTrackingRule(t.Context(), repoRule))
+	canTrack, err = organization.CanTrackTime(t.Context(), 3, 3, 4)
+	require.NoError(t, err)
+	assert.False(t, canTrack)
+	canTrack, err = organization.CanTrackTime(t.Context(), 3, 3, 2)
+	require.NoError(t, err)
+	assert.True(t, canTrack)
+	canTrack, err = organization.CanTrackTime(t.Context(), 3, 5, 4)
+	require.NoError(t, err)
+	assert.True(t, canTrack)
+
+	rules, err := organization.GetTimeTrackingRules(t.Context(), 3)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, defaultRule.ID, rules[0].ID)
+
+	repoRule.TeamIDs = []int64{2}
+	require.NoError(t, organization.UpdateTimeTrackingRule(t.Context(), repoRule))
+	repoRule, err = organization.GetTimeTrackingRuleByID(t.Context(), 3, repoRule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, repoRule.TeamIDs)
+	_, err = organization.GetTimeTrackingRuleByID(t.Context(), 6, repoRule.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	require.NoError(t, organization.DeleteTimeTrackingRule(t.Context(), repoRule))
+	unittest.AssertNotExistsBean(t, &organization.TimeTrackingRule{ID: repoRule.ID})
+}
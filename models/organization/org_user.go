@@ -94,6 +94,17 @@ func IsOrganizationMember(ctx context.Context, orgID, uid int64) (bool, error) {
 		Exist()
 }
 
+// IsMemberOfOrgDisallowingImpersonation returns true if the user is a member of an organization which has opted
+// its members out of the impersonation by instance admins.
+func IsMemberOfOrgDisallowingImpersonation(ctx context.Context, uid int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Table("org_user").
+		Join("INNER", "`user`", "`user`.id = org_user.org_id").
+		Where("org_user.uid=?", uid).
+		And("`user`.disallow_impersonation=?", true).
+		Exist()
+}
+
 // IsPublicMembership returns true if the given user's membership of given org is public.
 func IsPublicMembership(ctx context.Context, orgID, uid int64) (bool, error) {
 	return db.GetEngine(ctx).
@@ -106,12 +117,20 @@ func IsPublicMembership(ctx context.Context, orgID, uid int64) (bool, error) {
 
 // CanCreateOrgRepo returns true if user can create repo in organization
 func CanCreateOrgRepo(ctx context.Context, orgID, uid int64) (bool, error) {
-	return db.GetEngine(ctx).
+	has, err := db.GetEngine(ctx).
 		Where(builder.Eq{"team.can_create_org_repo": true}).
 		Join("INNER", "team_user", "team_user.team_id = team.id").
 		And("team_user.uid = ?", uid).
 		And("team_user.org_id = ?", orgID).
 		Exist(new(Team))
+	if err != nil || has {
+		return has, err
+	}
+	roles, err := GetUserOrgRoles(ctx, orgID, uid)
+	if err != nil {
+		return false, err
+	}
+	return OrgRolePermissions(roles).Has(OrgRolePermissionCreateRepo), nil
 }
 
 // IsUserOrgOwner returns true if user is in the owner team of given organization.
@@ -84,6 +84,8 @@ type Team struct {
 	Units                   []*TeamUnit `xorm:"-"`
 	IncludesAllRepositories bool        `xorm:"NOT NULL DEFAULT false"`
 	CanCreateOrgRepo        bool        `xorm:"NOT NULL DEFAULT false"`
+	// ParentID is the parent team, the members of a team are inherited by all its ancestors
+	ParentID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
 }
 
 func init() {
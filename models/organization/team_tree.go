@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package organization
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrTeamMemberInherited is returned if an inherited member is removed from a team directly
+var ErrTeamMemberInherited = util.NewInvalidArgumentErrorf("the user is a member of a child team, remove the user from the child team instead")
+
+// LoadParent returns the parent team, nil for a top level team
+func (t *Team) LoadParent(ctx context.Context) (*Team, error) {
+	if t.ParentID == 0 {
+		return nil, nil
+	}
+	return GetTeamByID(ctx, t.ParentID)
+}
+
+// GetTeamChildren returns the direct child teams of the team
+func GetTeamChildren(ctx context.Context, teamID int64) (TeamList, error) {
+	teams := make(TeamList, 0, 5)
+	return teams, db.GetEngine(ctx).Where("parent_id = ?", teamID).OrderBy("name").Find(&teams)
+}
+
+// GetTeamUser returns the membership of the user in the team, nil if the user isn't a member
+func GetTeamUser(ctx context.Context, teamID, userID int64) (*TeamUser, error) {
+	tu := &TeamUser{}
+	has, err := db.GetEngine(ctx).Where("team_id = ? AND uid = ?", teamID, userID).Get(tu)
+	if err != nil || !has {
+		return nil, err
+	}
+	return tu, nil
+}
+
+// ValidateTeamParent checks whether the team can be moved below the parent team, parentID 0 moves it to the top level
+func ValidateTeamParent(ctx context.Context, t *Team, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	candidates, err := FindTeamParentCandidates(ctx, t)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		if candidate.ID == parentID {
+			return nil
+		}
+	}
+	return util.NewInvalidArgumentErrorf("the team can't be moved below the team %d", parentID)
+}
+
+// FindTeamParentCandidates returns the teams of the organization the team can be moved below. The owners team can't
+// be part of the tree and a team can't be moved below itself or one of its descendants. t.ID is 0 for a new team.
+func FindTeamParentCandidates(ctx context.Context, t *Team) (TeamList, error) {
+	if t.IsOwnerTeam() {
+		return nil, nil
+	}
+	teams, err := FindOrgTeams(ctx, t.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[int64]int64, len(teams))
+	for _, team := range teams {
+		parents[team.ID] = team.ParentID
+	}
+	isDescendant := func(id int64) bool {
+		for depth := 0; id != 0 && depth <= len(teams); id, depth = parents[id], depth+1 {
+			if id == t.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidates := make(TeamList, 0, len(teams))
+	for _, team := range teams {
+		if team.IsOwnerTeam() || (t.ID > 0 && isDescendant(team.ID)) {
+			continue
+		}
+		candidates = append(candidates, team)
+	}
+	return candidates, nil
+}
@@ -18,6 +18,8 @@ type TeamUser struct {
 	OrgID  int64 `xorm:"INDEX"`
 	TeamID int64 `xorm:"UNIQUE(s)"`
 	UID    int64 `xorm:"UNIQUE(s)"`
+	// IsInherited is true if the user isn't a member of the team itself but of one of its child teams
+	IsInherited bool `xorm:"NOT NULL DEFAULT false"`
 }
 
 // IsTeamMember returns true if given user is a member of team.
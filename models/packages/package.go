@@ -43,7 +43,9 @@ const (
 	TypeGeneric   Type = "generic"
 	TypeGo        Type = "go"
 	TypeHelm      Type = "helm"
+	TypeHomebrew  Type = "homebrew"
 	TypeMaven     Type = "maven"
+	TypeNix       Type = "nix"
 	TypeNpm       Type = "npm"
 	TypeNuGet     Type = "nuget"
 	TypePub       Type = "pub"
@@ -51,6 +53,7 @@ const (
 	TypeRpm       Type = "rpm"
 	TypeRubyGems  Type = "rubygems"
 	TypeSwift     Type = "swift"
+	TypeTerraform Type = "terraform"
 	TypeVagrant   Type = "vagrant"
 )
 
@@ -68,7 +71,9 @@ var TypeList = []Type{
 	TypeGeneric,
 	TypeGo,
 	TypeHelm,
+	TypeHomebrew,
 	TypeMaven,
+	TypeNix,
 	TypeNpm,
 	TypeNuGet,
 	TypePub,
@@ -76,6 +81,7 @@ var TypeList = []Type{
 	TypeRpm,
 	TypeRubyGems,
 	TypeSwift,
+	TypeTerraform,
 	TypeVagrant,
 }
 
@@ -108,8 +114,12 @@ func (pt Type) Name() string {
 		return "Go"
 	case TypeHelm:
 		return "Helm"
+	case TypeHomebrew:
+		return "Homebrew"
 	case TypeMaven:
 		return "Maven"
+	case TypeNix:
+		return "Nix"
 	case TypeNpm:
 		return "npm"
 	case TypeNuGet:
@@ -124,6 +134,8 @@ func (pt Type) Name() string {
 		return "RubyGems"
 	case TypeSwift:
 		return "Swift"
+	case TypeTerraform:
+		return "Terraform"
 	case TypeVagrant:
 		return "Vagrant"
 	}
@@ -159,8 +171,12 @@ func (pt Type) SVGName() string {
 		return "gitea-go"
 	case TypeHelm:
 		return "gitea-helm"
+	case TypeHomebrew:
+		return "gitea-homebrew"
 	case TypeMaven:
 		return "gitea-maven"
+	case TypeNix:
+		return "gitea-nix"
 	case TypeNpm:
 		return "gitea-npm"
 	case TypeNuGet:
@@ -175,6 +191,8 @@ func (pt Type) SVGName() string {
 		return "gitea-rubygems"
 	case TypeSwift:
 		return "gitea-swift"
+	case TypeTerraform:
+		return "gitea-terraform"
 	case TypeVagrant:
 		return "gitea-vagrant"
 	}
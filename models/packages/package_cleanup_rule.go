@@ -9,6 +9,7 @@ import (
 	"regexp"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 
@@ -23,19 +24,23 @@ func init() {
 
 // PackageCleanupRule represents a rule which describes when to clean up package versions
 type PackageCleanupRule struct {
-	ID                   int64              `xorm:"pk autoincr"`
-	Enabled              bool               `xorm:"INDEX NOT NULL DEFAULT false"`
-	OwnerID              int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
-	Type                 Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	KeepCount            int                `xorm:"NOT NULL DEFAULT 0"`
-	KeepPattern          string             `xorm:"NOT NULL DEFAULT ''"`
-	KeepPatternMatcher   *regexp.Regexp     `xorm:"-"`
-	RemoveDays           int                `xorm:"NOT NULL DEFAULT 0"`
-	RemovePattern        string             `xorm:"NOT NULL DEFAULT ''"`
-	RemovePatternMatcher *regexp.Regexp     `xorm:"-"`
-	MatchFullName        bool               `xorm:"NOT NULL DEFAULT false"`
-	CreatedUnix          timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
-	UpdatedUnix          timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	ID                   int64                  `xorm:"pk autoincr"`
+	Enabled              bool                   `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID              int64                  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	RepoID               int64                  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Repo                 *repo_model.Repository `xorm:"-"`
+	Type                 Type                   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	KeepCount            int                    `xorm:"NOT NULL DEFAULT 0"`
+	KeepPattern          string                 `xorm:"NOT NULL DEFAULT ''"`
+	KeepPatternMatcher   *regexp.Regexp         `xorm:"-"`
+	RemoveDays           int                    `xorm:"NOT NULL DEFAULT 0"`
+	RemovePattern        string                 `xorm:"NOT NULL DEFAULT ''"`
+	RemovePatternMatcher *regexp.Regexp         `xorm:"-"`
+	MatchFullName        bool                   `xorm:"NOT NULL DEFAULT false"`
+	RemovePrereleaseDays int                    `xorm:"NOT NULL DEFAULT 0"`
+	RemoveUntaggedDays   int                    `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix          timeutil.TimeStamp     `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix          timeutil.TimeStamp     `xorm:"updated NOT NULL DEFAULT 0"`
 }
 
 func (pcr *PackageCleanupRule) CompiledPattern() error {
@@ -62,6 +67,15 @@ func (pcr *PackageCleanupRule) CompiledPattern() error {
 	return nil
 }
 
+// LoadRepo loads the repository the rule is limited to
+func (pcr *PackageCleanupRule) LoadRepo(ctx context.Context) (err error) {
+	if pcr.Repo != nil || pcr.RepoID == 0 {
+		return nil
+	}
+	pcr.Repo, err = repo_model.GetRepositoryByID(ctx, pcr.RepoID)
+	return err
+}
+
 func InsertCleanupRule(ctx context.Context, pcr *PackageCleanupRule) (*PackageCleanupRule, error) {
 	return pcr, db.Insert(ctx, pcr)
 }
@@ -94,12 +108,28 @@ func DeleteCleanupRuleByID(ctx context.Context, ruleID int64) error {
 	return err
 }
 
-func HasOwnerCleanupRuleForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+// DeleteCleanupRulesByRepoID deletes the rules limited to the repository
+func DeleteCleanupRulesByRepoID(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&PackageCleanupRule{})
+	return err
+}
+
+func HasOwnerCleanupRuleForPackageType(ctx context.Context, ownerID, repoID int64, packageType Type) (bool, error) {
 	return db.GetEngine(ctx).
-		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Where("owner_id = ? AND repo_id = ? AND type = ?", ownerID, repoID, packageType).
 		Exist(&PackageCleanupRule{})
 }
 
+// GetRepoIDsWithCleanupRule returns the ids of the repositories which have their own rule for the package type
+func GetRepoIDsWithCleanupRule(ctx context.Context, ownerID int64, packageType Type) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).
+		Table("package_cleanup_rule").
+		Where("owner_id = ? AND type = ? AND repo_id <> 0", ownerID, packageType).
+		Cols("repo_id").
+		Find(&repoIDs)
+}
+
 func IterateEnabledCleanupRules(ctx context.Context, callback func(context.Context, *PackageCleanupRule) error) error {
 	return db.Iterate(
 		ctx,
@@ -0,0 +1,213 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrPackageScanNotExist = util.NewNotExistErrorf("package scan does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageScan))
+	db.RegisterModel(new(PackageVulnerability))
+}
+
+// ScanStatus is the state of the vulnerability scan of a package version
+type ScanStatus int
+
+const (
+	ScanStatusPending ScanStatus = iota
+	ScanStatusDone
+	ScanStatusFailed
+	ScanStatusSkipped
+)
+
+func (s ScanStatus) String() string {
+	switch s {
+	case ScanStatusDone:
+		return "done"
+	case ScanStatusFailed:
+		return "failed"
+	case ScanStatusSkipped:
+		return "skipped"
+	default:
+		return "pending"
+	}
+}
+
+// Severity is the severity of a vulnerability, the zero value means no vulnerability
+type Severity int
+
+const (
+	SeverityNone Severity = iota
+	SeverityUnknown
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// ParseSeverity parses a severity reported by the scanner, unrecognized values are SeverityUnknown
+func ParseSeverity(s string) Severity {
+	if s == "" {
+		return SeverityNone
+	}
+	if i := slices.Index(setting.PackageScanSeverities, strings.ToUpper(s)); i != -1 {
+		return Severity(i + 1)
+	}
+	return SeverityUnknown
+}
+
+func (s Severity) String() string {
+	if s == SeverityNone {
+		return ""
+	}
+	return setting.PackageScanSeverities[s-1]
+}
+
+// PackageScan is the result of the last vulnerability scan of a package version
+type PackageScan struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+	Status      ScanStatus         `xorm:"INDEX NOT NULL DEFAULT 0"`
+	MaxSeverity Severity           `xorm:"NOT NULL DEFAULT 0"`
+	Error       string             `xorm:"TEXT"`
+	ScannedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// PackageVulnerability is a vulnerability found by the scan of a package version
+type PackageVulnerability struct {
+	ID               int64    `xorm:"pk autoincr"`
+	VersionID        int64    `xorm:"INDEX NOT NULL"`
+	VulnerabilityID  string   `xorm:"NOT NULL"`
+	Target           string   `xorm:"TEXT"`
+	PackageName      string   `xorm:"NOT NULL"`
+	InstalledVersion string   `xorm:"NOT NULL DEFAULT ''"`
+	FixedVersion     string   `xorm:"NOT NULL DEFAULT ''"`
+	Severity         Severity `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Title            string   `xorm:"TEXT"`
+	URL              string   `xorm:"TEXT"`
+}
+
+// GetScanByVersionID gets the scan of the package version
+func GetScanByVersionID(ctx context.Context, versionID int64) (*PackageScan, error) {
+	ps := &PackageScan{}
+	has, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Get(ps)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageScanNotExist
+	}
+	return ps, nil
+}
+
+// SetScanStatus creates or updates the scan of the package version without touching the findings
+func SetScanStatus(ctx context.Context, versionID int64, status ScanStatus, errorMessage string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		return upsertScan(ctx, &PackageScan{VersionID: versionID, Status: status, Error: errorMessage}, false)
+	})
+}
+
+// SetScanResult replaces the findings of the package version and marks the scan done
+func SetScanResult(ctx context.Context, versionID int64, vulnerabilities []*PackageVulnerability) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageVulnerability{}); err != nil {
+			return err
+		}
+
+		ps := &PackageScan{
+			VersionID:   versionID,
+			Status:      ScanStatusDone,
+			ScannedUnix: timeutil.TimeStampNow(),
+		}
+		for _, v := range vulnerabilities {
+			v.ID = 0
+			v.VersionID = versionID
+			ps.MaxSeverity = max(ps.MaxSeverity, v.Severity)
+		}
+		if len(vulnerabilities) > 0 {
+			if err := db.Insert(ctx, vulnerabilities); err != nil {
+				return err
+			}
+		}
+		return upsertScan(ctx, ps, true)
+	})
+}
+
+func upsertScan(ctx context.Context, ps *PackageScan, withResult bool) error {
+	existing, err := GetScanByVersionID(ctx, ps.VersionID)
+	if err != nil {
+		if errors.Is(err, ErrPackageScanNotExist) {
+			return db.Insert(ctx, ps)
+		}
+		return err
+	}
+
+	cols := []string{"status", "error"}
+	if withResult {
+		cols = append(cols, "max_severity", "scanned_unix")
+	}
+	_, err = db.GetEngine(ctx).ID(existing.ID).Cols(cols...).Update(ps)
+	return err
+}
+
+// GetVulnerabilitiesByVersionID gets the findings of the package version ordered by severity
+func GetVulnerabilitiesByVersionID(ctx context.Context, versionID int64) ([]*PackageVulnerability, error) {
+	vulns := make([]*PackageVulnerability, 0, 10)
+	return vulns, db.GetEngine(ctx).
+		Where("version_id = ?", versionID).
+		Desc("severity").
+		Asc("package_name", "vulnerability_id").
+		Find(&vulns)
+}
+
+// DeleteScanByVersionID deletes the scan and the findings of the package version
+func DeleteScanByVersionID(ctx context.Context, versionID int64) error {
+	if _, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageVulnerability{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageScan{})
+	return err
+}
+
+// FindVersionIDsToScan returns the ids of the package versions which were never scanned or whose last scan is older than the time,
+// ordered by id and starting after the id
+func FindVersionIDsToScan(ctx context.Context, packageTypes []Type, olderThan timeutil.TimeStamp, afterID int64, limit int) ([]int64, error) {
+	cond := builder.Eq{"package_version.is_internal": false}.
+		And(builder.Gt{"package_version.id": afterID}).
+		And(builder.Or(
+			builder.IsNull{"package_scan.id"},
+			builder.Eq{"package_scan.status": ScanStatusPending},
+			builder.Eq{"package_scan.status": ScanStatusFailed},
+			builder.Eq{"package_scan.status": ScanStatusDone}.And(builder.Lt{"package_scan.scanned_unix": olderThan}),
+		))
+	if len(packageTypes) > 0 {
+		cond = cond.And(builder.In("package.type", packageTypes))
+	}
+
+	ids := make([]int64, 0, limit)
+	return ids, db.GetEngine(ctx).
+		Table("package_version").
+		Select("package_version.id").
+		Join("INNER", "package", "package.id = package_version.package_id").
+		Join("LEFT", "package_scan", "package_scan.version_id = package_version.id").
+		Where(cond).
+		Asc("package_version.id").
+		Limit(limit).
+		Find(&ids)
+}
@@ -324,9 +324,15 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 	if err != nil {
 		return perm, err
 	}
-	if len(teams) == 0 {
+	// get units mode from the custom roles of the organization
+	roles, err := organization.GetUserOrgRoles(ctx, repo.OwnerID, user.ID)
+	if err != nil {
+		return perm, err
+	}
+	if len(teams) == 0 && len(roles) == 0 {
 		return perm, nil
 	}
+	rolePermissions := organization.OrgRolePermissions(roles)
 
 	perm.unitsMode = make(map[unit.Type]perm_model.AccessMode)
 
@@ -345,6 +351,11 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 			return perm, nil
 		}
 	}
+	if rolePermissions.Has(organization.OrgRolePermissionAdminRepo) {
+		perm.AccessMode = max(perm.AccessMode, perm_model.AccessModeAdmin)
+		perm.unitsMode = nil
+		return perm, nil
+	}
 
 	for _, u := range repo.Units {
 		for _, team := range teams {
@@ -352,6 +363,7 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 			unitAccessMode := max(perm.unitsMode[u.Type], minAccessMode, teamMode)
 			perm.unitsMode[u.Type] = unitAccessMode
 		}
+		perm.unitsMode[u.Type] = max(perm.unitsMode[u.Type], minAccessMode, rolePermissions.UnitAccessMode(u.Type))
 	}
 
 	return perm, err
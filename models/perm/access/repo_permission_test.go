@@ -231,3 +231,39 @@ func TestGetUserRepoPermission(t *testing.T) {
 		assert.Equal(t, perm_model.AccessModeWrite, perm.unitsMode[unit.TypeIssues])
 	})
 }
+
+func TestGetUserRepoPermissionWithOrgRole(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+	repo5 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 5}) // org private repo, user4 has no team access
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	perm, err := GetUserRepoPermission(ctx, repo5, user)
+	require.NoError(t, err)
+	assert.False(t, perm.HasAnyUnitAccess())
+
+	role := &organization.OrgRole{OrgID: repo5.OwnerID, Name: "Release Manager"}
+	require.NoError(t, role.SetPermissions([]organization.OrgRolePermission{"code.read", "releases.write"}))
+	require.NoError(t, organization.CreateOrgRole(ctx, role))
+	require.NoError(t, organization.AssignOrgRole(ctx, role, user.ID, 0))
+	t.Run("DoerWithRoleOnPrivateRepo", func(t *testing.T) {
+		perm, err := GetUserRepoPermission(ctx, repo5, user)
+		require.NoError(t, err)
+		assert.Equal(t, perm_model.AccessModeNone, perm.AccessMode)
+		assert.True(t, perm.CanRead(unit.TypeCode))
+		assert.False(t, perm.CanWrite(unit.TypeCode))
+		assert.True(t, perm.CanWrite(unit.TypeReleases))
+		assert.False(t, perm.CanRead(unit.TypeIssues))
+		assert.False(t, perm.IsAdmin())
+	})
+
+	require.NoError(t, role.SetPermissions([]organization.OrgRolePermission{organization.OrgRolePermissionAdminRepo}))
+	require.NoError(t, organization.UpdateOrgRole(ctx, role))
+	t.Run("DoerWithAdminRoleOnPrivateRepo", func(t *testing.T) {
+		perm, err := GetUserRepoPermission(ctx, repo5, user)
+		require.NoError(t, err)
+		assert.True(t, perm.IsAdmin())
+		assert.False(t, perm.IsOwner())
+		assert.True(t, perm.CanWrite(unit.TypeIssues))
+	})
+}
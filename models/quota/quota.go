@@ -0,0 +1,216 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Category is a kind of storage which is accounted by the quota
+type Category string
+
+const (
+	CategoryGit         Category = "git"
+	CategoryLFS         Category = "lfs"
+	CategoryPackages    Category = "packages"
+	CategoryAttachments Category = "attachments"
+	CategoryArtifacts   Category = "artifacts"
+)
+
+// Categories are all the categories accounted by the quota
+var Categories = []Category{CategoryGit, CategoryLFS, CategoryPackages, CategoryAttachments, CategoryArtifacts}
+
+// Limit represents the storage limits of an owner (user or organization), a negative size means no limit.
+// Owners without a Limit record use the defaults from the settings.
+type Limit struct {
+	ID              int64
+	OwnerID         int64              `xorm:"UNIQUE NOT NULL"`
+	TotalSize       int64              `xorm:"NOT NULL DEFAULT -1"`
+	GitSize         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LFSSize         int64              `xorm:"NOT NULL DEFAULT -1"`
+	PackagesSize    int64              `xorm:"NOT NULL DEFAULT -1"`
+	AttachmentsSize int64              `xorm:"NOT NULL DEFAULT -1"`
+	ArtifactsSize   int64              `xorm:"NOT NULL DEFAULT -1"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix     timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// TableName sets the table name for the limit
+func (Limit) TableName() string {
+	return "quota_limit"
+}
+
+func init() {
+	db.RegisterModel(new(Limit))
+}
+
+// DefaultLimit returns the limit of owners without an override
+func DefaultLimit(ownerID int64) *Limit {
+	return &Limit{
+		OwnerID:         ownerID,
+		TotalSize:       setting.Quota.DefaultTotalSize,
+		GitSize:         setting.Quota.DefaultGitSize,
+		LFSSize:         setting.Quota.DefaultLFSSize,
+		PackagesSize:    setting.Quota.DefaultPackagesSize,
+		AttachmentsSize: setting.Quota.DefaultAttachmentsSize,
+		ArtifactsSize:   setting.Quota.DefaultArtifactsSize,
+	}
+}
+
+// SizeOf returns the limit of the category
+func (l *Limit) SizeOf(category Category) int64 {
+	switch category {
+	case CategoryGit:
+		return l.GitSize
+	case CategoryLFS:
+		return l.LFSSize
+	case CategoryPackages:
+		return l.PackagesSize
+	case CategoryAttachments:
+		return l.AttachmentsSize
+	case CategoryArtifacts:
+		return l.ArtifactsSize
+	}
+	return -1
+}
+
+// GetOverriddenLimit returns the limit set by an admin for the owner, or nil if there is none
+func GetOverriddenLimit(ctx context.Context, ownerID int64) (*Limit, error) {
+	l := &Limit{}
+	has, err := db.GetEngine(ctx).Where("owner_id=?", ownerID).Get(l)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return l, nil
+}
+
+// GetLimit returns the effective limit of the owner
+func GetLimit(ctx context.Context, ownerID int64) (*Limit, error) {
+	l, err := GetOverriddenLimit(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	} else if l == nil {
+		return DefaultLimit(ownerID), nil
+	}
+	return l, nil
+}
+
+// SetLimit creates or updates the limit override of an owner
+func SetLimit(ctx context.Context, l *Limit) error {
+	if l.OwnerID <= 0 {
+		return util.NewInvalidArgumentErrorf("invalid owner id")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetOverriddenLimit(ctx, l.OwnerID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			l.ID = 0
+			return db.Insert(ctx, l)
+		}
+		l.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(l.ID).AllCols().NoAutoTime().Update(l)
+		return err
+	})
+}
+
+// DeleteLimit removes the limit override of an owner, so the defaults apply again
+func DeleteLimit(ctx context.Context, ownerID int64) error {
+	_, err := db.GetEngine(ctx).Where("owner_id=?", ownerID).Delete(new(Limit))
+	return err
+}
+
+// ErrQuotaExceeded represents a "QuotaExceeded" kind of error.
+type ErrQuotaExceeded struct {
+	OwnerID  int64
+	Category Category
+	Limit    int64
+	Used     int64
+}
+
+// IsErrQuotaExceeded checks if an error is a ErrQuotaExceeded.
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(ErrQuotaExceeded)
+	return ok
+}
+
+func (err ErrQuotaExceeded) Error() string {
+	if err.Category == "" {
+		return fmt.Sprintf("storage quota exceeded: %d of %d bytes used", err.Used, err.Limit)
+	}
+	return fmt.Sprintf("%s storage quota exceeded: %d of %d bytes used", err.Category, err.Used, err.Limit)
+}
+
+func (err ErrQuotaExceeded) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
+// CheckExceeded checks whether adding the given size to the category would exceed the owner's quota.
+// A zero size checks whether the owner is already over the quota.
+func CheckExceeded(ctx context.Context, ownerID int64, category Category, size int64) error {
+	if !setting.Quota.Enabled || ownerID <= 0 {
+		return nil
+	}
+
+	limit, err := GetLimit(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	categoryLimit := limit.SizeOf(category)
+	if categoryLimit < 0 && limit.TotalSize < 0 {
+		return nil
+	}
+
+	usage, err := GetUsage(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if used := usage.SizeOf(category); categoryLimit >= 0 && used+size > categoryLimit {
+		return ErrQuotaExceeded{OwnerID: ownerID, Category: category, Limit: categoryLimit, Used: used}
+	}
+	if used := usage.Total(); limit.TotalSize >= 0 && used+size > limit.TotalSize {
+		return ErrQuotaExceeded{OwnerID: ownerID, Limit: limit.TotalSize, Used: used}
+	}
+	return nil
+}
+
+// GetRemainingSize returns how many bytes can still be added to the category by the owner, -1 means no limit
+func GetRemainingSize(ctx context.Context, ownerID int64, category Category) (int64, error) {
+	if !setting.Quota.Enabled || ownerID <= 0 {
+		return -1, nil
+	}
+
+	limit, err := GetLimit(ctx, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	categoryLimit := limit.SizeOf(category)
+	if categoryLimit < 0 && limit.TotalSize < 0 {
+		return -1, nil
+	}
+
+	usage, err := GetUsage(ctx, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	remaining, unlimited := int64(0), true
+	if categoryLimit >= 0 {
+		remaining, unlimited = max(categoryLimit-usage.SizeOf(category), 0), false
+	}
+	if limit.TotalSize >= 0 {
+		if totalRemaining := max(limit.TotalSize-usage.Total(), 0); unlimited || totalRemaining < remaining {
+			remaining = totalRemaining
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	quota_model "code.gitea.io/gitea/models/quota"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Quota.DefaultGitSize, 100)()
+
+	l, err := quota_model.GetLimit(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 100, l.GitSize)
+	assert.EqualValues(t, -1, l.TotalSize)
+
+	assert.NoError(t, quota_model.SetLimit(t.Context(), &quota_model.Limit{OwnerID: 2, TotalSize: 10, GitSize: -1, LFSSize: -1, PackagesSize: -1, AttachmentsSize: -1, ArtifactsSize: -1}))
+	assert.NoError(t, quota_model.SetLimit(t.Context(), &quota_model.Limit{OwnerID: 2, TotalSize: 20, GitSize: 5, LFSSize: -1, PackagesSize: -1, AttachmentsSize: -1, ArtifactsSize: -1}))
+	unittest.AssertCount(t, &quota_model.Limit{OwnerID: 2}, 1)
+
+	l, err = quota_model.GetLimit(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 20, l.TotalSize)
+	assert.EqualValues(t, 5, l.SizeOf(quota_model.CategoryGit))
+
+	assert.NoError(t, quota_model.DeleteLimit(t.Context(), 2))
+	l, err = quota_model.GetOverriddenLimit(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+}
+
+func TestCheckExceeded(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	limit := &quota_model.Limit{OwnerID: 2, TotalSize: -1, GitSize: -1, LFSSize: -1, PackagesSize: -1, AttachmentsSize: 1, ArtifactsSize: -1}
+	assert.NoError(t, quota_model.SetLimit(t.Context(), limit))
+
+	// the quota is not enforced while disabled
+	defer test.MockVariableValue(&setting.Quota.Enabled, false)()
+	assert.NoError(t, quota_model.CheckExceeded(t.Context(), 2, quota_model.CategoryAttachments, 1024))
+
+	setting.Quota.Enabled = true
+	err := quota_model.CheckExceeded(t.Context(), 2, quota_model.CategoryAttachments, 1024)
+	assert.True(t, quota_model.IsErrQuotaExceeded(err))
+	assert.NoError(t, quota_model.CheckExceeded(t.Context(), 2, quota_model.CategoryPackages, 1024))
+
+	remaining, err := quota_model.GetRemainingSize(t.Context(), 2, quota_model.CategoryPackages)
+	assert.NoError(t, err)
+	assert.EqualValues(t, -1, remaining)
+}
+
+func TestGetRemainingSize(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Quota.Enabled, true)()
+
+	// the git category of user 2 is over its limit while the total is not
+	_, err := db.GetEngine(t.Context()).ID(1).Cols("git_size").Update(&repo_model.Repository{GitSize: 100})
+	assert.NoError(t, err)
+	usage, err := quota_model.GetUsage(t.Context(), 2)
+	assert.NoError(t, err)
+	limit := &quota_model.Limit{OwnerID: 2, TotalSize: usage.Total() + 1000, GitSize: 50, LFSSize: -1, PackagesSize: -1, AttachmentsSize: -1, ArtifactsSize: -1}
+	assert.NoError(t, quota_model.SetLimit(t.Context(), limit))
+
+	remaining, err := quota_model.GetRemainingSize(t.Context(), 2, quota_model.CategoryGit)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, remaining)
+
+	// a category without limit is bound by the total
+	remaining, err = quota_model.GetRemainingSize(t.Context(), 2, quota_model.CategoryLFS)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1000, remaining)
+
+	limit.GitSize = 5000
+	assert.NoError(t, quota_model.SetLimit(t.Context(), limit))
+	remaining, err = quota_model.GetRemainingSize(t.Context(), 2, quota_model.CategoryGit)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1000, remaining)
+}
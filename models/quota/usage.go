@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+
+	"xorm.io/builder"
+)
+
+// Usage represents the storage used by an owner in bytes
+type Usage struct {
+	Git         int64
+	LFS         int64
+	Packages    int64
+	Attachments int64
+	Artifacts   int64
+}
+
+// SizeOf returns the used size of the category
+func (u *Usage) SizeOf(category Category) int64 {
+	switch category {
+	case CategoryGit:
+		return u.Git
+	case CategoryLFS:
+		return u.LFS
+	case CategoryPackages:
+		return u.Packages
+	case CategoryAttachments:
+		return u.Attachments
+	case CategoryArtifacts:
+		return u.Artifacts
+	}
+	return 0
+}
+
+// Total returns the total used size
+func (u *Usage) Total() int64 {
+	return u.Git + u.LFS + u.Packages + u.Attachments + u.Artifacts
+}
+
+// GetUsage calculates the storage used by the owner
+func GetUsage(ctx context.Context, ownerID int64) (*Usage, error) {
+	var err error
+	u := &Usage{}
+	e := db.GetEngine(ctx)
+	ownerRepos := builder.Select("id").From("repository").Where(builder.Eq{"owner_id": ownerID})
+
+	if u.Git, err = e.Where("owner_id=?", ownerID).SumInt(new(repo_model.Repository), "git_size"); err != nil {
+		return nil, err
+	}
+	if u.LFS, err = e.Where(builder.In("repository_id", ownerRepos)).SumInt(new(git_model.LFSMetaObject), "size"); err != nil {
+		return nil, err
+	}
+	if u.Packages, err = packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{OwnerID: ownerID}); err != nil {
+		return nil, err
+	}
+	if u.Attachments, err = e.Where(builder.In("repo_id", ownerRepos)).SumInt(new(repo_model.Attachment), "size"); err != nil {
+		return nil, err
+	}
+	if u.Artifacts, err = e.Where("owner_id=?", ownerID).
+		And(builder.In("status", actions_model.ArtifactStatusUploadPending, actions_model.ArtifactStatusUploadConfirmed)).
+		SumInt(new(actions_model.ActionArtifact), "file_compressed_size"); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
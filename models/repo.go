@@ -209,6 +209,7 @@ func CheckRepoStats(ctx context.Context) error {
 					"`issue`.num_comments": builder.Select("COUNT(*)").From("`comment`").Where(
 						builder.Expr("issue_id = `issue`.id").And(
 							builder.In("type", issues_model.ConversationCountedCommentType()),
+							builder.Eq{"is_hidden": false},
 						),
 					),
 				},
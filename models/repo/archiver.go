@@ -29,12 +29,15 @@ const (
 
 // RepoArchiver represents all archivers
 type RepoArchiver struct { //revive:disable-line:exported
-	ID          int64           `xorm:"pk autoincr"`
-	RepoID      int64           `xorm:"index unique(s)"`
-	Type        git.ArchiveType `xorm:"unique(s)"`
-	Status      ArchiverStatus
-	CommitID    string             `xorm:"VARCHAR(64) unique(s)"`
-	CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL created"`
+	ID           int64           `xorm:"pk autoincr"`
+	RepoID       int64           `xorm:"index unique(s)"`
+	Type         git.ArchiveType `xorm:"unique(s)"`
+	Status       ArchiverStatus
+	CommitID     string             `xorm:"VARCHAR(64) unique(s)"`
+	Size         int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL created"`
+	AccessedUnix timeutil.TimeStamp `xorm:"INDEX"`
+	IsCold       bool               `xorm:"INDEX NOT NULL DEFAULT false"` // the archive has been moved to the cold tier of the archive storage
 }
 
 func init() {
@@ -87,12 +90,61 @@ func ExistsRepoArchiverWithStoragePath(ctx context.Context, storagePath string)
 	return db.GetEngine(ctx).Exist(archiver)
 }
 
-// UpdateRepoArchiverStatus updates archiver's status
+// UpdateRepoArchiverStatus updates archiver's status and size
 func UpdateRepoArchiverStatus(ctx context.Context, archiver *RepoArchiver) error {
-	_, err := db.GetEngine(ctx).ID(archiver.ID).Cols("status").Update(archiver)
+	_, err := db.GetEngine(ctx).ID(archiver.ID).Cols("status", "size").Update(archiver)
 	return err
 }
 
+// archiverAccessUpdateInterval avoids writing to the database on every download of an archive
+const archiverAccessUpdateInterval = 3600
+
+// UpdateRepoArchiverAccessed records that the archive has been downloaded
+func UpdateRepoArchiverAccessed(ctx context.Context, archiver *RepoArchiver) error {
+	now := timeutil.TimeStampNow()
+	if archiver.AccessedUnix >= now-archiverAccessUpdateInterval {
+		return nil
+	}
+	archiver.AccessedUnix = now
+	_, err := db.GetEngine(ctx).ID(archiver.ID).Cols("accessed_unix").Update(archiver)
+	return err
+}
+
+// FindRepoArchiversToTransition returns the ready archives in the given tier which should be moved to the other tier, ordered by id.
+// A hot archive is moved to the cold tier if it hasn't been created or downloaded since the cutoff,
+// a cold archive is moved back to the hot tier if it has been downloaded since the cutoff.
+func FindRepoArchiversToTransition(ctx context.Context, isCold bool, cutoff timeutil.TimeStamp, afterID int64, limit int) ([]*RepoArchiver, error) {
+	cond := builder.Eq{"status": ArchiverReady, "is_cold": isCold}.And(builder.Gt{"id": afterID})
+	if isCold {
+		cond = cond.And(builder.Gte{"accessed_unix": cutoff})
+	} else {
+		cond = cond.And(builder.Lt{"created_unix": cutoff}, builder.Lt{"accessed_unix": cutoff})
+	}
+	archivers := make([]*RepoArchiver, 0, limit)
+	return archivers, db.GetEngine(ctx).Where(cond).OrderBy("id ASC").Limit(limit).Find(&archivers)
+}
+
+// SetRepoArchiverCold records the tier the archive is stored in and its size
+func SetRepoArchiverCold(ctx context.Context, archiver *RepoArchiver, isCold bool) error {
+	archiver.IsCold = isCold
+	_, err := db.GetEngine(ctx).ID(archiver.ID).Cols("is_cold", "size").Update(archiver)
+	return err
+}
+
+// StorageTierUsage represents the number and the size of the objects in a tier of a storage
+type StorageTierUsage struct {
+	IsCold bool
+	Count  int64
+	Size   int64
+}
+
+// GetRepoArchiveStorageTierUsage returns the usage of the hot and the cold tier of the archive storage
+func GetRepoArchiveStorageTierUsage(ctx context.Context) ([]*StorageTierUsage, error) {
+	usages := make([]*StorageTierUsage, 0, 2)
+	return usages, db.GetEngine(ctx).Table("repo_archiver").Select("is_cold, COUNT(*) AS count, SUM(size) AS size").
+		Where("status = ?", ArchiverReady).GroupBy("is_cold").Find(&usages)
+}
+
 // DeleteAllRepoArchives deletes all repo archives records
 func DeleteAllRepoArchives(ctx context.Context) error {
 	// 1=1 to enforce delete all data, otherwise it will delete nothing
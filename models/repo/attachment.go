@@ -32,6 +32,7 @@ type Attachment struct {
 	DownloadCount     int64              `xorm:"DEFAULT 0"`
 	Size              int64              `xorm:"DEFAULT 0"`
 	CreatedUnix       timeutil.TimeStamp `xorm:"created"`
+	IsQuarantined     bool               `xorm:"NOT NULL DEFAULT false"` // malware has been found, only admins can download it
 	CustomDownloadURL string             `xorm:"-"`
 }
 
@@ -243,6 +244,12 @@ func UpdateAttachment(ctx context.Context, atta *Attachment) error {
 	return err
 }
 
+// SetAttachmentQuarantined sets or clears the quarantine of the attachment
+func SetAttachmentQuarantined(ctx context.Context, id int64, quarantined bool) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("is_quarantined").Update(&Attachment{IsQuarantined: quarantined})
+	return err
+}
+
 // DeleteAttachmentsByRelease deletes all attachments associated with the given release.
 func DeleteAttachmentsByRelease(ctx context.Context, releaseID int64) error {
 	_, err := db.GetEngine(ctx).Where("release_id = ?", releaseID).Delete(&Attachment{})
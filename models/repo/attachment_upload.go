@@ -0,0 +1,86 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrAttachmentUploadNotExist indicates a resumable attachment upload not exist error
+var ErrAttachmentUploadNotExist = util.NewNotExistErrorf("attachment upload does not exist")
+
+// AttachmentUpload represents a resumable upload of an issue or release attachment
+// whose content is received in chunks before the attachment is created.
+type AttachmentUpload struct {
+	ID            string `xorm:"pk"`
+	RepoID        int64  `xorm:"INDEX NOT NULL"`
+	IssueID       int64  `xorm:"NOT NULL DEFAULT 0"`
+	ReleaseID     int64  `xorm:"NOT NULL DEFAULT 0"`
+	UploaderID    int64  `xorm:"INDEX NOT NULL"`
+	Name          string
+	Size          int64              `xorm:"NOT NULL DEFAULT 0"`
+	BytesReceived int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(AttachmentUpload))
+}
+
+// IsComplete returns true if all the bytes of the upload have been received
+func (u *AttachmentUpload) IsComplete() bool {
+	return u.BytesReceived >= u.Size
+}
+
+// CreateAttachmentUpload inserts a resumable attachment upload with a random id
+func CreateAttachmentUpload(ctx context.Context, u *AttachmentUpload) error {
+	id, err := util.CryptoRandomString(25)
+	if err != nil {
+		return err
+	}
+	u.ID = strings.ToLower(id)
+
+	return db.Insert(ctx, u)
+}
+
+// GetAttachmentUploadByID gets a resumable attachment upload by id
+func GetAttachmentUploadByID(ctx context.Context, id string) (*AttachmentUpload, error) {
+	u := &AttachmentUpload{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(u)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrAttachmentUploadNotExist
+	}
+	return u, nil
+}
+
+// UpdateAttachmentUploadBytesReceived updates the number of received bytes of the upload
+func UpdateAttachmentUploadBytesReceived(ctx context.Context, u *AttachmentUpload) error {
+	_, err := db.GetEngine(ctx).ID(u.ID).Cols("bytes_received").Update(u)
+	return err
+}
+
+// DeleteAttachmentUploadByID deletes the resumable attachment upload
+func DeleteAttachmentUploadByID(ctx context.Context, id string) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(&AttachmentUpload{})
+	return err
+}
+
+// FindExpiredAttachmentUploads gets all the uploads which haven't received data for the given duration
+func FindExpiredAttachmentUploads(ctx context.Context, olderThan time.Duration) ([]*AttachmentUpload, error) {
+	uploads := make([]*AttachmentUpload, 0, 10)
+	return uploads, db.GetEngine(ctx).
+		Where("updated_unix < ?", time.Now().Add(-olderThan).Unix()).
+		Find(&uploads)
+}
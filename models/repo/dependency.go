@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// RepoDependency represents a dependency of the default branch of a repository, which is declared by a manifest or found in a lockfile
+type RepoDependency struct { //revive:disable-line:exported
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"INDEX NOT NULL"`
+	CommitID  string `xorm:"VARCHAR(64)"`
+	Ecosystem string `xorm:"VARCHAR(20) INDEX(lookup) NOT NULL"`
+	Name      string `xorm:"VARCHAR(255) NOT NULL"`
+	// LowerName is the lower case normalized name of the package in the ecosystem, by which dependents are looked up
+	LowerName   string `xorm:"VARCHAR(255) INDEX(lookup) NOT NULL"`
+	Version     string `xorm:"VARCHAR(255)"`
+	Requirement string `xorm:"VARCHAR(255)"`
+	Scope       string `xorm:"VARCHAR(20)"`
+	IsDirect    bool   `xorm:"NOT NULL DEFAULT false"`
+	// License is the SPDX license expression of the dependency if the lockfile records it
+	License string `xorm:"VARCHAR(255)"`
+	// Manifest is the path of the manifest or the lockfile the dependency was found in
+	Manifest    string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+
+	Repo *Repository `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoDependency))
+}
+
+// RepoDependencyList defines a list of dependencies
+type RepoDependencyList []*RepoDependency //revive:disable-line:exported
+
+// LoadRepos loads the repositories of the dependencies
+func (deps RepoDependencyList) LoadRepos(ctx context.Context) error {
+	repoIDs := make([]int64, 0, len(deps))
+	for _, dep := range deps {
+		repoIDs = append(repoIDs, dep.RepoID)
+	}
+	repos, err := GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		dep.Repo = repos[dep.RepoID]
+	}
+	return nil
+}
+
+// FindRepoDependenciesOptions represents the options to find dependencies
+type FindRepoDependenciesOptions struct {
+	db.ListOptions
+	RepoID int64
+	// RepoCond is a condition on "repository" which limits the repositories of the dependencies
+	RepoCond   builder.Cond
+	Ecosystem  string
+	LowerName  string
+	Keyword    string
+	DirectOnly bool
+}
+
+// ToConds implements db.FindOptions
+func (opts FindRepoDependenciesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.RepoCond != nil {
+		cond = cond.And(builder.In("repo_id", builder.Select("id").From("repository").Where(opts.RepoCond)))
+	}
+	if opts.Ecosystem != "" {
+		cond = cond.And(builder.Eq{"ecosystem": opts.Ecosystem})
+	}
+	if opts.LowerName != "" {
+		cond = cond.And(builder.Eq{"lower_name": opts.LowerName})
+	}
+	if opts.Keyword != "" {
+		cond = cond.And(builder.Like{"lower_name", opts.Keyword})
+	}
+	if opts.DirectOnly {
+		cond = cond.And(builder.Eq{"is_direct": true})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindRepoDependenciesOptions) ToOrders() string {
+	return "repo_id, is_direct DESC, ecosystem, lower_name, version"
+}
+
+// GetRepoDependencyEcosystems returns the ecosystems of the dependencies of a repository
+func GetRepoDependencyEcosystems(ctx context.Context, repoID int64) ([]string, error) {
+	ecosystems := make([]string, 0, 5)
+	return ecosystems, db.GetEngine(ctx).Table("repo_dependency").Where("repo_id = ?", repoID).
+		Distinct("ecosystem").Asc("ecosystem").Find(&ecosystems)
+}
+
+// UpdateRepoDependencies replaces the dependencies of the repository by the dependencies of the commit
+func UpdateRepoDependencies(ctx context.Context, repo *Repository, commitID string, deps []*RepoDependency) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", repo.ID).Delete(new(RepoDependency)); err != nil {
+			return err
+		}
+		for _, dep := range deps {
+			dep.RepoID = repo.ID
+			dep.CommitID = commitID
+		}
+		// insert in batches to not exceed the limit of the parameters of a statement
+		for chunk := range slices.Chunk(deps, 100) {
+			if err := db.Insert(ctx, chunk); err != nil {
+				return err
+			}
+		}
+		return UpdateIndexerStatus(ctx, repo, RepoIndexerTypeDependency, commitID)
+	})
+}
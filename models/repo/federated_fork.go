@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// FederatedFork is a repository forked from a repository of another instance, the pull requests of the fork are offered to it over ActivityPub
+type FederatedFork struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// OriginIRI is the ForgeFed Repository actor of the origin repository
+	OriginIRI   string             `xorm:"VARCHAR(255) NOT NULL"`
+	OriginInbox string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(FederatedFork))
+}
+
+// GetFederatedFork returns the remote origin of a repository forked from another instance
+func GetFederatedFork(ctx context.Context, repoID int64) (*FederatedFork, error) {
+	fork := new(FederatedFork)
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(fork)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("repository %d is not forked from a remote repository", repoID)
+	}
+	return fork, nil
+}
+
+// AddFederatedFork records the remote origin of a repository
+func AddFederatedFork(ctx context.Context, fork *FederatedFork) error {
+	return db.Insert(ctx, fork)
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var validIncomingEmailAlias = regexp.MustCompile(`\A[a-z0-9][a-z0-9._-]{0,63}\z`)
+
+// IncomingEmailAlias is the alias in the address new issues of a repository are created by email at
+type IncomingEmailAlias struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+	Alias       string             `xorm:"VARCHAR(64) UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IncomingEmailAlias))
+}
+
+// GetIncomingEmailAlias returns the incoming email alias of a repository
+func GetIncomingEmailAlias(ctx context.Context, repoID int64) (*IncomingEmailAlias, error) {
+	alias := &IncomingEmailAlias{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(alias)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("repository %d has no incoming email alias", repoID)
+	}
+	return alias, nil
+}
+
+// GetIncomingEmailAliasByAlias returns the incoming email alias with the given name
+func GetIncomingEmailAliasByAlias(ctx context.Context, name string) (*IncomingEmailAlias, error) {
+	alias := &IncomingEmailAlias{}
+	has, err := db.GetEngine(ctx).Where("alias = ?", name).Get(alias)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("incoming email alias %q does not exist", name)
+	}
+	return alias, nil
+}
+
+// SetIncomingEmailAlias sets the incoming email alias of a repository, a random alias is generated when name is empty
+func SetIncomingEmailAlias(ctx context.Context, repoID int64, name string) (*IncomingEmailAlias, error) {
+	if name == "" {
+		b, err := util.CryptoRandomBytes(8)
+		if err != nil {
+			return nil, err
+		}
+		name = hex.EncodeToString(b)
+	} else if !validIncomingEmailAlias.MatchString(name) {
+		return nil, util.NewInvalidArgumentErrorf("invalid incoming email alias %q, only lowercase letters, digits, dots, dashes and underscores are allowed", name)
+	}
+
+	return db.WithTx2(ctx, func(ctx context.Context) (*IncomingEmailAlias, error) {
+		existing, err := GetIncomingEmailAliasByAlias(ctx, name)
+		if err == nil {
+			if existing.RepoID != repoID {
+				return nil, util.NewAlreadyExistErrorf("incoming email alias %q is already used", name)
+			}
+			return existing, nil
+		} else if !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+
+		alias, err := GetIncomingEmailAlias(ctx, repoID)
+		if errors.Is(err, util.ErrNotExist) {
+			alias = &IncomingEmailAlias{RepoID: repoID, Alias: name}
+			return alias, db.Insert(ctx, alias)
+		} else if err != nil {
+			return nil, err
+		}
+		alias.Alias = name
+		_, err = db.GetEngine(ctx).ID(alias.ID).Cols("alias").Update(alias)
+		return alias, err
+	})
+}
+
+// DeleteIncomingEmailAlias stops the creation of issues by email for a repository
+func DeleteIncomingEmailAlias(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(new(IncomingEmailAlias))
+	return err
+}
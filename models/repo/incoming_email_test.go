@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomingEmailAlias(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	_, err := repo_model.GetIncomingEmailAlias(t.Context(), 1)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	alias, err := repo_model.SetIncomingEmailAlias(t.Context(), 1, "")
+	assert.NoError(t, err)
+	assert.Len(t, alias.Alias, 16)
+
+	alias, err = repo_model.SetIncomingEmailAlias(t.Context(), 1, "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, "repo1", alias.Alias)
+	unittest.AssertCount(t, &repo_model.IncomingEmailAlias{RepoID: 1}, 1)
+
+	alias, err = repo_model.GetIncomingEmailAliasByAlias(t.Context(), "repo1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, alias.RepoID)
+
+	_, err = repo_model.SetIncomingEmailAlias(t.Context(), 2, "repo1")
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	_, err = repo_model.SetIncomingEmailAlias(t.Context(), 2, "Repo 2")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = repo_model.SetIncomingEmailAlias(t.Context(), 2, "-repo2")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	assert.NoError(t, repo_model.DeleteIncomingEmailAlias(t.Context(), 1))
+	_, err = repo_model.GetIncomingEmailAliasByAlias(t.Context(), "repo1")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
@@ -6,9 +6,11 @@ package repo
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
@@ -32,6 +34,12 @@ type Mirror struct {
 	LFSEndpoint string `xorm:"lfs_endpoint TEXT"`
 
 	RemoteAddress string `xorm:"VARCHAR(2048)"`
+
+	// RefFilter is a comma separated list of the branch patterns to mirror, eg: "main, release/*", empty means all branches
+	RefFilter string `xorm:"TEXT"`
+	SkipTags  bool   `xorm:"NOT NULL DEFAULT false"`
+	// MaxSize is the max size of the repository in bytes, the mirror stops syncing once it's exceeded, 0 means no limit
+	MaxSize int64 `xorm:"NOT NULL DEFAULT 0"`
 }
 
 func init() {
@@ -64,6 +72,97 @@ func (m *Mirror) GetRemoteName() string {
 	return "origin"
 }
 
+// BranchPatterns returns the patterns of the branches to mirror
+func (m *Mirror) BranchPatterns() []string {
+	return ParseMirrorRefFilter(m.RefFilter)
+}
+
+// IsRefFiltered returns true if the mirror doesn't sync all the refs of the remote
+func (m *Mirror) IsRefFiltered() bool {
+	return len(m.BranchPatterns()) > 0 || m.SkipTags
+}
+
+// FetchRefSpecs returns the refspecs used to fetch the filtered mirror
+func (m *Mirror) FetchRefSpecs() []string {
+	return MirrorFetchRefSpecs(m.RefFilter, m.SkipTags)
+}
+
+// MatchRef returns true if the ref should be kept by the filtered mirror
+func (m *Mirror) MatchRef(refName git.RefName) bool {
+	switch {
+	case refName.IsTag():
+		return !m.SkipTags
+	case refName.IsBranch():
+		patterns := m.BranchPatterns()
+		if len(patterns) == 0 {
+			return true
+		}
+		for _, pattern := range patterns {
+			if matchMirrorRefPattern(pattern, refName.BranchName()) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// IsSizeExceeded returns true if the repository is larger than the max size of the mirror
+func (m *Mirror) IsSizeExceeded(size int64) bool {
+	return m.MaxSize > 0 && size > m.MaxSize
+}
+
+// ParseMirrorRefFilter splits the ref filter of a mirror to the branch patterns
+func ParseMirrorRefFilter(refFilter string) []string {
+	var patterns []string
+	for pattern := range strings.SplitSeq(refFilter, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// ValidateMirrorRefFilter checks every branch pattern of the ref filter could be used in a git refspec
+func ValidateMirrorRefFilter(refFilter string) error {
+	for _, pattern := range ParseMirrorRefFilter(refFilter) {
+		// git refspecs only support one "*" in a pattern
+		if strings.Count(pattern, "*") > 1 || !git.IsValidRefPattern(strings.Replace(pattern, "*", "x", 1)) {
+			return util.NewInvalidArgumentErrorf("invalid mirror branch pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// MirrorFetchRefSpecs returns the refspecs to fetch the branches matching the ref filter and the tags unless skipTags is set,
+// it returns nil if all the refs should be fetched.
+func MirrorFetchRefSpecs(refFilter string, skipTags bool) []string {
+	patterns := ParseMirrorRefFilter(refFilter)
+	if len(patterns) == 0 && !skipTags {
+		return nil
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"*"}
+	}
+	refSpecs := make([]string, 0, len(patterns)+1)
+	for _, pattern := range patterns {
+		refSpecs = append(refSpecs, "+"+git.BranchPrefix+pattern+":"+git.BranchPrefix+pattern)
+	}
+	if !skipTags {
+		refSpecs = append(refSpecs, "+"+git.TagPrefix+"*:"+git.TagPrefix+"*")
+	}
+	return refSpecs
+}
+
+// matchMirrorRefPattern matches the name like git matches a refspec pattern, "*" matches any characters including "/"
+func matchMirrorRefPattern(pattern, name string) bool {
+	prefix, suffix, hasStar := strings.Cut(pattern, "*")
+	if !hasStar {
+		return pattern == name
+	}
+	return len(name) >= len(prefix)+len(suffix) && strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix)
+}
+
 // ScheduleNextUpdate calculates and sets next update time.
 func (m *Mirror) ScheduleNextUpdate() {
 	if m.Interval != 0 {
@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorRefFilter(t *testing.T) {
+	assert.NoError(t, ValidateMirrorRefFilter(""))
+	assert.NoError(t, ValidateMirrorRefFilter("main, release/*, *-stable"))
+	assert.Error(t, ValidateMirrorRefFilter("release/*/*"))
+	assert.Error(t, ValidateMirrorRefFilter("main, bad..name"))
+
+	assert.Nil(t, MirrorFetchRefSpecs(" , ", false))
+	assert.Equal(t, []string{"+refs/heads/*:refs/heads/*"}, MirrorFetchRefSpecs("", true))
+	assert.Equal(t, []string{
+		"+refs/heads/main:refs/heads/main",
+		"+refs/heads/release/*:refs/heads/release/*",
+		"+refs/tags/*:refs/tags/*",
+	}, MirrorFetchRefSpecs("main,release/*", false))
+
+	m := &Mirror{RefFilter: "main, release/*", SkipTags: true}
+	assert.True(t, m.IsRefFiltered())
+	assert.True(t, m.MatchRef(git.RefNameFromBranch("main")))
+	assert.True(t, m.MatchRef(git.RefNameFromBranch("release/1.0/hotfix")))
+	assert.False(t, m.MatchRef(git.RefNameFromBranch("feature")))
+	assert.False(t, m.MatchRef(git.RefNameFromBranch("release")))
+	assert.False(t, m.MatchRef(git.RefNameFromTag("v1.0")))
+	assert.True(t, m.MatchRef("refs/notes/commits"))
+
+	m = &Mirror{}
+	assert.False(t, m.IsRefFiltered())
+	assert.True(t, m.MatchRef(git.RefNameFromBranch("feature")))
+	assert.True(t, m.MatchRef(git.RefNameFromTag("v1.0")))
+
+	m = &Mirror{MaxSize: 100}
+	assert.False(t, m.IsSizeExceeded(100))
+	assert.True(t, m.IsSizeExceeded(101))
+	assert.False(t, (&Mirror{}).IsSizeExceeded(101))
+}
@@ -29,8 +29,21 @@ type PushMirror struct {
 	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
 	LastUpdateUnix timeutil.TimeStamp `xorm:"INDEX last_update"`
 	LastError      string             `xorm:"text"`
+	// LastSuccessUnix is the time of the last sync without error
+	LastSuccessUnix timeutil.TimeStamp `xorm:"last_success"`
+
+	// BranchFilter is a comma separated list of the branch patterns to push, eg: "main, release/*", empty means all refs
+	BranchFilter string `xorm:"TEXT"`
+	LFS          bool   `xorm:"lfs_enabled NOT NULL DEFAULT true"`
 }
 
+// the states of the push mirrors
+const (
+	PushMirrorStatusPending = "pending"
+	PushMirrorStatusSuccess = "success"
+	PushMirrorStatusFailure = "failure"
+)
+
 type PushMirrorOptions struct {
 	db.ListOptions
 	ID         int64
@@ -74,6 +87,27 @@ func (m *PushMirror) GetRemoteName() string {
 	return m.RemoteName
 }
 
+// Status returns the state of the last sync of the push mirror
+func (m *PushMirror) Status() string {
+	switch {
+	case m.LastUpdateUnix == 0:
+		return PushMirrorStatusPending
+	case m.LastError != "":
+		return PushMirrorStatusFailure
+	default:
+		return PushMirrorStatusSuccess
+	}
+}
+
+// PushRefSpecs returns the refspecs to push the branches matching the branch filter and all the tags,
+// it returns nil if the repository should be pushed with "--mirror".
+func (m *PushMirror) PushRefSpecs() []string {
+	if len(ParseMirrorRefFilter(m.BranchFilter)) == 0 {
+		return nil
+	}
+	return MirrorFetchRefSpecs(m.BranchFilter, false)
+}
+
 // UpdatePushMirror updates the push-mirror
 func UpdatePushMirror(ctx context.Context, m *PushMirror) error {
 	_, err := db.GetEngine(ctx).ID(m.ID).AllCols().Update(m)
@@ -47,3 +47,26 @@ func TestPushMirrorsIterate(t *testing.T) {
 		return nil
 	})
 }
+
+func TestPushMirrorStatus(t *testing.T) {
+	m := &repo_model.PushMirror{}
+	assert.Equal(t, repo_model.PushMirrorStatusPending, m.Status())
+
+	m.LastUpdateUnix = timeutil.TimeStampNow()
+	assert.Equal(t, repo_model.PushMirrorStatusSuccess, m.Status())
+
+	m.LastError = "push failed"
+	assert.Equal(t, repo_model.PushMirrorStatusFailure, m.Status())
+}
+
+func TestPushMirrorPushRefSpecs(t *testing.T) {
+	m := &repo_model.PushMirror{}
+	assert.Nil(t, m.PushRefSpecs())
+
+	m.BranchFilter = "main, release/*"
+	assert.Equal(t, []string{
+		"+refs/heads/main:refs/heads/main",
+		"+refs/heads/release/*:refs/heads/release/*",
+		"+refs/tags/*:refs/tags/*",
+	}, m.PushRefSpecs())
+}
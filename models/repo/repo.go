@@ -206,6 +206,9 @@ type Repository struct {
 	LFSSize                         int64              `xorm:"NOT NULL DEFAULT 0"`
 	CodeIndexerStatus               *RepoIndexerStatus `xorm:"-"`
 	StatsIndexerStatus              *RepoIndexerStatus `xorm:"-"`
+	WikiIndexerStatus               *RepoIndexerStatus `xorm:"-"`
+	DependencyIndexerStatus         *RepoIndexerStatus `xorm:"-"`
+	SymbolIndexerStatus             *RepoIndexerStatus `xorm:"-"`
 	IsFsckEnabled                   bool               `xorm:"NOT NULL DEFAULT true"`
 	CloseIssuesViaCommitInAnyBranch bool               `xorm:"NOT NULL DEFAULT false"`
 	Topics                          []string           `xorm:"TEXT JSON"`
@@ -472,6 +475,11 @@ func (repo *Repository) MustGetUnit(ctx context.Context, tp unit.Type) *RepoUnit
 			Type:   tp,
 			Config: cfg,
 		}
+	case unit.TypeReleases:
+		return &RepoUnit{
+			Type:   tp,
+			Config: new(ReleasesConfig),
+		}
 	}
 
 	return &RepoUnit{
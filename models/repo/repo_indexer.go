@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	"xorm.io/builder"
 )
@@ -20,6 +22,12 @@ const (
 	RepoIndexerTypeCode RepoIndexerType = iota // 0
 	// RepoIndexerTypeStats repository stats indexer
 	RepoIndexerTypeStats // 1
+	// RepoIndexerTypeSymbol symbol indexer
+	RepoIndexerTypeSymbol // 2
+	// RepoIndexerTypeWiki wiki indexer
+	RepoIndexerTypeWiki // 3
+	// RepoIndexerTypeDependency dependency graph indexer
+	RepoIndexerTypeDependency // 4
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
@@ -29,6 +37,10 @@ type RepoIndexerStatus struct { //revive:disable-line:exported
 	RepoID      int64           `xorm:"INDEX(s)"`
 	CommitSha   string          `xorm:"VARCHAR(64)"`
 	IndexerType RepoIndexerType `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	IndexedUnix timeutil.TimeStamp
+	// LastError is the error of the last failed indexing, it is cleared when the repository is indexed
+	LastError  string             `xorm:"TEXT"`
+	FailedUnix timeutil.TimeStamp `xorm:"INDEX"`
 }
 
 func init() {
@@ -43,7 +55,8 @@ func GetUnindexedRepos(ctx context.Context, indexerType RepoIndexerType, maxRepo
 	}).And(builder.Eq{
 		"repository.is_empty": false,
 	})
-	sess := db.GetEngine(ctx).Table("repository").Join("LEFT OUTER", "repo_indexer_status", "repository.id = repo_indexer_status.repo_id AND repo_indexer_status.indexer_type = ?", indexerType)
+	// the repositories which have only failed are not indexed either
+	sess := db.GetEngine(ctx).Table("repository").Join("LEFT OUTER", "repo_indexer_status", "repository.id = repo_indexer_status.repo_id AND repo_indexer_status.indexer_type = ? AND repo_indexer_status.commit_sha <> ''", indexerType)
 	if maxRepoID > 0 {
 		cond = builder.And(cond, builder.Lte{
 			"repository.id": maxRepoID,
@@ -73,6 +86,18 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		if repo.StatsIndexerStatus != nil {
 			return repo.StatsIndexerStatus, nil
 		}
+	case RepoIndexerTypeWiki:
+		if repo.WikiIndexerStatus != nil {
+			return repo.WikiIndexerStatus, nil
+		}
+	case RepoIndexerTypeDependency:
+		if repo.DependencyIndexerStatus != nil {
+			return repo.DependencyIndexerStatus, nil
+		}
+	case RepoIndexerTypeSymbol:
+		if repo.SymbolIndexerStatus != nil {
+			return repo.SymbolIndexerStatus, nil
+		}
 	}
 	status := &RepoIndexerStatus{RepoID: repo.ID}
 	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Get(status); err != nil {
@@ -86,6 +111,12 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		repo.CodeIndexerStatus = status
 	case RepoIndexerTypeStats:
 		repo.StatsIndexerStatus = status
+	case RepoIndexerTypeWiki:
+		repo.WikiIndexerStatus = status
+	case RepoIndexerTypeDependency:
+		repo.DependencyIndexerStatus = status
+	case RepoIndexerTypeSymbol:
+		repo.SymbolIndexerStatus = status
 	}
 	return status, nil
 }
@@ -97,18 +128,81 @@ func UpdateIndexerStatus(ctx context.Context, repo *Repository, indexerType Repo
 		return fmt.Errorf("UpdateIndexerStatus: Unable to getIndexerStatus for repo: %s Error: %w", repo.FullName(), err)
 	}
 
-	if len(status.CommitSha) == 0 {
-		status.CommitSha = sha
+	status.CommitSha = sha
+	status.IndexedUnix = timeutil.TimeStampNow()
+	status.LastError = ""
+	status.FailedUnix = 0
+	if status.ID == 0 {
 		if err := db.Insert(ctx, status); err != nil {
 			return fmt.Errorf("UpdateIndexerStatus: Unable to insert repoIndexerStatus for repo: %s Sha: %s Error: %w", repo.FullName(), sha, err)
 		}
 		return nil
 	}
-	status.CommitSha = sha
-	_, err = db.GetEngine(ctx).ID(status.ID).Cols("commit_sha").
+	_, err = db.GetEngine(ctx).ID(status.ID).Cols("commit_sha", "indexed_unix", "last_error", "failed_unix").
 		Update(status)
 	if err != nil {
 		return fmt.Errorf("UpdateIndexerStatus: Unable to update repoIndexerStatus for repo: %s Sha: %s Error: %w", repo.FullName(), sha, err)
 	}
 	return nil
 }
+
+// UpdateIndexerFailure records the error of a failed indexing of a repository, the last indexed commit is kept
+func UpdateIndexerFailure(ctx context.Context, repoID int64, indexerType RepoIndexerType, indexErr error) error {
+	// the failures of deleted repositories are not recorded
+	if exist, err := db.GetEngine(ctx).ID(repoID).Exist(new(Repository)); err != nil || !exist {
+		return err
+	}
+
+	status := &RepoIndexerStatus{RepoID: repoID, IndexerType: indexerType}
+	has, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Get(status)
+	if err != nil {
+		return err
+	}
+	status.LastError = indexErr.Error()
+	status.FailedUnix = timeutil.TimeStampNow()
+	if !has {
+		return db.Insert(ctx, status)
+	}
+	_, err = db.GetEngine(ctx).ID(status.ID).Cols("last_error", "failed_unix").Update(status)
+	return err
+}
+
+// DeleteIndexerStatus deletes the status of a repository in an indexer, so the repository is indexed from scratch next time
+func DeleteIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoIndexerType) error {
+	if _, err := db.GetEngine(ctx).Where("repo_id = ? AND indexer_type = ?", repo.ID, indexerType).Delete(new(RepoIndexerStatus)); err != nil {
+		return err
+	}
+	switch indexerType {
+	case RepoIndexerTypeCode:
+		repo.CodeIndexerStatus = nil
+	case RepoIndexerTypeStats:
+		repo.StatsIndexerStatus = nil
+	case RepoIndexerTypeWiki:
+		repo.WikiIndexerStatus = nil
+	case RepoIndexerTypeDependency:
+		repo.DependencyIndexerStatus = nil
+	case RepoIndexerTypeSymbol:
+		repo.SymbolIndexerStatus = nil
+	}
+	return nil
+}
+
+// FindIndexerFailuresOptions represents the options to find the repositories whose last indexing failed
+type FindIndexerFailuresOptions struct {
+	db.ListOptions
+	IndexerType optional.Option[RepoIndexerType]
+}
+
+// ToConds implements db.FindOptions
+func (opts FindIndexerFailuresOptions) ToConds() builder.Cond {
+	cond := builder.Cond(builder.Gt{"failed_unix": 0})
+	if opts.IndexerType.Has() {
+		cond = cond.And(builder.Eq{"indexer_type": opts.IndexerType.Value()})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindIndexerFailuresOptions) ToOrders() string {
+	return "failed_unix DESC, id DESC"
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerFailure(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	// a failure before the first indexing doesn't make the repository indexed
+	require.NoError(t, repo_model.UpdateIndexerFailure(t.Context(), repo.ID, repo_model.RepoIndexerTypeCode, errors.New("first failure")))
+	ids, err := repo_model.GetUnindexedRepos(t.Context(), repo_model.RepoIndexerTypeCode, 0, 0, 0)
+	require.NoError(t, err)
+	assert.Contains(t, ids, repo.ID)
+
+	require.NoError(t, repo_model.UpdateIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode, "65f1bf27bc3bf70f64657658635e66094edbcb4d"))
+	require.NoError(t, repo_model.UpdateIndexerFailure(t.Context(), repo.ID, repo_model.RepoIndexerTypeCode, errors.New("second failure")))
+	require.NoError(t, repo_model.UpdateIndexerFailure(t.Context(), 3, repo_model.RepoIndexerTypeStats, errors.New("stats failure")))
+	// the failures of nonexistent repositories are ignored
+	require.NoError(t, repo_model.UpdateIndexerFailure(t.Context(), unittest.NonexistentID, repo_model.RepoIndexerTypeCode, errors.New("ignored")))
+
+	status := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoIndexerStatus{RepoID: repo.ID, IndexerType: repo_model.RepoIndexerTypeCode})
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+	assert.Equal(t, "second failure", status.LastError)
+	assert.NotZero(t, status.FailedUnix)
+
+	failures, count, err := db.FindAndCount[repo_model.RepoIndexerStatus](t.Context(), repo_model.FindIndexerFailuresOptions{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	assert.Len(t, failures, 2)
+	failures, err = db.Find[repo_model.RepoIndexerStatus](t.Context(), repo_model.FindIndexerFailuresOptions{IndexerType: optional.Some(repo_model.RepoIndexerTypeCode)})
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, repo.ID, failures[0].RepoID)
+
+	// a successful indexing clears the failure
+	require.NoError(t, repo_model.UpdateIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode, "2a47ca4b614a9f5a43abbd5ad851a54a616ffee6"))
+	status = unittest.AssertExistsAndLoadBean(t, &repo_model.RepoIndexerStatus{RepoID: repo.ID, IndexerType: repo_model.RepoIndexerTypeCode})
+	assert.Empty(t, status.LastError)
+	assert.Zero(t, status.FailedUnix)
+	assert.NotZero(t, status.IndexedUnix)
+
+	require.NoError(t, repo_model.DeleteIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode))
+	unittest.AssertNotExistsBean(t, &repo_model.RepoIndexerStatus{RepoID: repo.ID, IndexerType: repo_model.RepoIndexerTypeCode})
+	status, err = repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeCode)
+	require.NoError(t, err)
+	assert.Empty(t, status.CommitSha)
+}
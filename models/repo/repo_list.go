@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
+	org_model "code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
@@ -678,10 +679,12 @@ func AccessibleRepositoryCondition(user *user_model.User, unitType unit.Type) bu
 				userOrgTeamUnitRepoCond("`repository`.id", user.ID, unitType),
 			)
 		}
-		// 4. Repositories that we directly own
+		// 4. Be able to see all repositories of organizations through custom organization roles
+		cond = cond.Or(builder.In("`repository`.owner_id", org_model.OrgRoleRepoOwnerBuilder(user.ID)))
+		// 5. Repositories that we directly own
 		cond = cond.Or(builder.Eq{"`repository`.owner_id": user.ID})
 		if !user.IsRestricted {
-			// 5. Be able to see all public repos in private organizations that we are an org_user of
+			// 6. Be able to see all public repos in private organizations that we are an org_user of
 			cond = cond.Or(userOrgPublicRepoCond(user.ID))
 		}
 	}
@@ -741,6 +744,16 @@ func FindUserCodeAccessibleOwnerRepoIDs(ctx context.Context, ownerID int64, user
 	))
 }
 
+// FindUserWikiAccessibleRepoIDs finds all at Wiki level accessible repositories' ID with an enabled wiki by the user's id,
+// the site administrators can access all the wikis.
+func FindUserWikiAccessibleRepoIDs(ctx context.Context, user *user_model.User) ([]int64, error) {
+	cond := builder.In("`repository`.id", builder.Select("repo_id").From("repo_unit").Where(builder.Eq{"type": unit.TypeWiki}))
+	if user == nil || !user.IsAdmin {
+		cond = cond.And(AccessibleRepositoryCondition(user, unit.TypeWiki))
+	}
+	return SearchRepositoryIDsByCondition(ctx, cond)
+}
+
 // GetUserRepositories returns a list of repositories of given user.
 func GetUserRepositories(ctx context.Context, opts SearchRepoOptions) (RepositoryList, int64, error) {
 	if len(opts.OrderBy) == 0 {
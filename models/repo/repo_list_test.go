@@ -10,6 +10,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/optional"
 
 	"github.com/stretchr/testify/assert"
@@ -402,3 +403,29 @@ func TestSearchRepositoryByTopicName(t *testing.T) {
 		})
 	}
 }
+
+func TestFindUserWikiAccessibleRepoIDs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the public repositories with a wiki
+	repoIDs, err := repo_model.FindUserWikiAccessibleRepoIDs(t.Context(), nil)
+	assert.NoError(t, err)
+	assert.Contains(t, repoIDs, int64(1))
+	assert.NotContains(t, repoIDs, int64(2))
+	assert.NotContains(t, repoIDs, int64(10))
+
+	// the private repositories of the owner
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repoIDs, err = repo_model.FindUserWikiAccessibleRepoIDs(t.Context(), user2)
+	assert.NoError(t, err)
+	assert.Contains(t, repoIDs, int64(1))
+	assert.Contains(t, repoIDs, int64(2))
+
+	// all the repositories with a wiki for the administrators
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	repoIDs, err = repo_model.FindUserWikiAccessibleRepoIDs(t.Context(), user1)
+	assert.NoError(t, err)
+	assert.Contains(t, repoIDs, int64(2))
+	assert.Contains(t, repoIDs, int64(5))
+	assert.NotContains(t, repoIDs, int64(10))
+}
@@ -131,6 +131,10 @@ type PullRequestsConfig struct {
 	DefaultDeleteBranchAfterMerge bool
 	DefaultMergeStyle             MergeStyle
 	DefaultAllowMaintainerEdit    bool
+	// RequireSignOff requires the commits of pull requests to be signed off by their authors (DCO)
+	RequireSignOff bool
+	// SignOffExemptUsers are the names of the users whose commits don't need to be signed off, e.g. bots
+	SignOffExemptUsers []string
 }
 
 // FromDB fills up a PullRequestsConfig from serialized format.
@@ -249,6 +253,41 @@ func (cfg *ProjectsConfig) IsProjectsAllowed(m ProjectsMode) bool {
 	return projectsMode == m || projectsMode == ProjectsModeAll
 }
 
+// ReleasesConfig describes releases config
+type ReleasesConfig struct {
+	// PublishAssetsToPackages publishes the .deb, .rpm and OCI image archive assets of the releases into the package registries of the owner
+	PublishAssetsToPackages bool
+	DebianDistribution      string
+	DebianComponent         string
+	RpmGroup                string
+}
+
+// FromDB fills up a ReleasesConfig from serialized format.
+func (cfg *ReleasesConfig) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &cfg)
+}
+
+// ToDB exports a ReleasesConfig to a serialized format.
+func (cfg *ReleasesConfig) ToDB() ([]byte, error) {
+	return json.Marshal(cfg)
+}
+
+// GetDebianDistribution returns the distribution the .deb assets are published to
+func (cfg *ReleasesConfig) GetDebianDistribution() string {
+	if cfg.DebianDistribution != "" {
+		return cfg.DebianDistribution
+	}
+	return "stable"
+}
+
+// GetDebianComponent returns the component the .deb assets are published to
+func (cfg *ReleasesConfig) GetDebianComponent() string {
+	if cfg.DebianComponent != "" {
+		return cfg.DebianComponent
+	}
+	return "main"
+}
+
 // BeforeSet is invoked from XORM before setting the value of a field of this object.
 func (r *RepoUnit) BeforeSet(colName string, val xorm.Cell) {
 	switch colName {
@@ -266,7 +305,9 @@ func (r *RepoUnit) BeforeSet(colName string, val xorm.Cell) {
 			r.Config = new(ActionsConfig)
 		case unit.TypeProjects:
 			r.Config = new(ProjectsConfig)
-		case unit.TypeCode, unit.TypeReleases, unit.TypeWiki, unit.TypePackages:
+		case unit.TypeReleases:
+			r.Config = new(ReleasesConfig)
+		case unit.TypeCode, unit.TypeWiki, unit.TypePackages:
 			fallthrough
 		default:
 			r.Config = new(UnitConfig)
@@ -290,8 +331,8 @@ func (r *RepoUnit) PullRequestsConfig() *PullRequestsConfig {
 }
 
 // ReleasesConfig returns config for unit.TypeReleases
-func (r *RepoUnit) ReleasesConfig() *UnitConfig {
-	return r.Config.(*UnitConfig)
+func (r *RepoUnit) ReleasesConfig() *ReleasesConfig {
+	return r.Config.(*ReleasesConfig)
 }
 
 // ExternalWikiConfig returns config for unit.TypeExternalWiki
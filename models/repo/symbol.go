@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+
+	"xorm.io/builder"
+)
+
+// RepoSymbol represents a symbol defined in a file of the default branch of a repository, which is found by ctags
+type RepoSymbol struct { //revive:disable-line:exported
+	ID       int64  `xorm:"pk autoincr"`
+	RepoID   int64  `xorm:"INDEX(name) INDEX(path) NOT NULL"`
+	CommitID string `xorm:"VARCHAR(64)"`
+	Name     string `xorm:"VARCHAR(255) INDEX(name) NOT NULL"`
+	// Kind is the kind of the symbol given by ctags, e.g. "function", "struct" or "method"
+	Kind     string `xorm:"VARCHAR(50)"`
+	Language string `xorm:"VARCHAR(50)"`
+	Path     string `xorm:"VARCHAR(255) INDEX(path) NOT NULL"`
+	Line     int    `xorm:"NOT NULL"`
+	// EndLine is the last line of the definition, it is 0 if ctags doesn't know it
+	EndLine int `xorm:"NOT NULL DEFAULT 0"`
+	// Scope is the name of the symbol the symbol is defined in, e.g. the type of a method
+	Scope     string `xorm:"VARCHAR(255)"`
+	ScopeKind string `xorm:"VARCHAR(50)"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoSymbol))
+}
+
+// FindRepoSymbolsOptions represents the options to find symbols
+type FindRepoSymbolsOptions struct {
+	db.ListOptions
+	RepoID int64
+	// Name finds the definitions of a symbol
+	Name string
+	// Path finds the symbols of a file
+	Path string
+	// Keyword finds the symbols whose names start with it
+	Keyword string
+	Kind    string
+}
+
+// ToConds implements db.FindOptions
+func (opts FindRepoSymbolsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"name": opts.Name})
+	}
+	if opts.Path != "" {
+		cond = cond.And(builder.Eq{"path": opts.Path})
+	}
+	if opts.Keyword != "" {
+		cond = cond.And(builder.Like{"name", opts.Keyword + "%"})
+	}
+	if opts.Kind != "" {
+		cond = cond.And(builder.Eq{"kind": opts.Kind})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindRepoSymbolsOptions) ToOrders() string {
+	return "repo_id, path, line, id"
+}
+
+// UpdateRepoSymbols replaces the symbols of the repository by the symbols of the commit
+func UpdateRepoSymbols(ctx context.Context, repo *Repository, commitID string, symbols []*RepoSymbol) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", repo.ID).Delete(new(RepoSymbol)); err != nil {
+			return err
+		}
+		for _, symbol := range symbols {
+			symbol.RepoID = repo.ID
+			symbol.CommitID = commitID
+		}
+		// insert in batches to not exceed the limit of the parameters of a statement
+		for chunk := range slices.Chunk(symbols, 100) {
+			if err := db.Insert(ctx, chunk); err != nil {
+				return err
+			}
+		}
+		return UpdateIndexerStatus(ctx, repo, RepoIndexerTypeSymbol, commitID)
+	})
+}
@@ -10,6 +10,9 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
 )
 
 // WatchMode specifies what kind of watch the user has on a repository
@@ -26,14 +29,29 @@ const (
 	WatchModeAuto // 3
 )
 
+// WatchEvent is a type of repository event the watchers are notified of, named after its column in the watch table
+type WatchEvent string
+
+const (
+	WatchEventIssues       WatchEvent = "issues"
+	WatchEventPullRequests WatchEvent = "pull_requests"
+	WatchEventReleases     WatchEvent = "releases"
+	WatchEventCIFailures   WatchEvent = "ci_failures"
+)
+
 // Watch is connection request for receiving repository notification.
 type Watch struct {
-	ID          int64              `xorm:"pk autoincr"`
-	UserID      int64              `xorm:"UNIQUE(watch)"`
-	RepoID      int64              `xorm:"UNIQUE(watch)"`
-	Mode        WatchMode          `xorm:"SMALLINT NOT NULL DEFAULT 1"`
-	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
-	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+	ID     int64     `xorm:"pk autoincr"`
+	UserID int64     `xorm:"UNIQUE(watch)"`
+	RepoID int64     `xorm:"UNIQUE(watch)"`
+	Mode   WatchMode `xorm:"SMALLINT NOT NULL DEFAULT 1"`
+	// The types of events the watcher is notified of
+	Issues       bool               `xorm:"NOT NULL DEFAULT true"`
+	PullRequests bool               `xorm:"NOT NULL DEFAULT true"`
+	Releases     bool               `xorm:"NOT NULL DEFAULT true"`
+	CIFailures   bool               `xorm:"'ci_failures' NOT NULL DEFAULT false"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"INDEX updated"`
 }
 
 func init() {
@@ -49,6 +67,9 @@ func GetWatch(ctx context.Context, userID, repoID int64) (Watch, error) {
 	}
 	if !has {
 		watch.Mode = WatchModeNone
+		watch.Issues = true
+		watch.PullRequests = true
+		watch.Releases = true
 	}
 	return watch, nil
 }
@@ -123,6 +144,66 @@ func WatchRepo(ctx context.Context, doer *user_model.User, repo *Repository, doW
 	return watchRepoMode(ctx, watch, WatchModeNormal)
 }
 
+// SetWatchEvents sets the types of events a user is notified of as a watcher of a repository, the user starts watching the repository if needed
+func SetWatchEvents(ctx context.Context, doer *user_model.User, repo *Repository, events map[WatchEvent]bool) (*Watch, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*Watch, error) {
+		watch, err := GetWatch(ctx, doer.ID, repo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !IsWatchMode(watch.Mode) {
+			if user_model.IsUserBlockedBy(ctx, doer, repo.OwnerID) {
+				return nil, user_model.ErrBlockedUser
+			}
+			if err := watchRepoMode(ctx, watch, WatchModeNormal); err != nil {
+				return nil, err
+			}
+			if watch, err = GetWatch(ctx, doer.ID, repo.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		cols := make([]string, 0, len(events))
+		for event, enabled := range events {
+			switch event {
+			case WatchEventIssues:
+				watch.Issues = enabled
+			case WatchEventPullRequests:
+				watch.PullRequests = enabled
+			case WatchEventReleases:
+				watch.Releases = enabled
+			case WatchEventCIFailures:
+				watch.CIFailures = enabled
+			default:
+				return nil, util.NewInvalidArgumentErrorf("unknown watch event %q", event)
+			}
+			cols = append(cols, string(event))
+		}
+		if len(cols) > 0 {
+			if _, err := db.GetEngine(ctx).ID(watch.ID).Cols(cols...).Update(&watch); err != nil {
+				return nil, err
+			}
+		}
+		return &watch, nil
+	})
+}
+
+// IsParticipatingOnly returns whether a user only wants the notifications of what they participate in by default,
+// such users are not made watchers of repositories automatically
+func IsParticipatingOnly(ctx context.Context, userID int64) (bool, error) {
+	value, err := user_model.GetUserSetting(ctx, userID, user_model.SettingsKeyNotificationParticipatingOnly)
+	return value == "true", err
+}
+
+// AutoWatchRepo makes a user watch a repository on their behalf, unless the user only wants to participate
+func AutoWatchRepo(ctx context.Context, user *user_model.User, repo *Repository) error {
+	participatingOnly, err := IsParticipatingOnly(ctx, user.ID)
+	if err != nil || participatingOnly {
+		return err
+	}
+	return WatchRepo(ctx, user, repo, true)
+}
+
 // GetWatchers returns all watchers of given repository.
 func GetWatchers(ctx context.Context, repoID int64) ([]*Watch, error) {
 	watches := make([]*Watch, 0, 10)
@@ -134,14 +215,15 @@ func GetWatchers(ctx context.Context, repoID int64) ([]*Watch, error) {
 		Find(&watches)
 }
 
-// GetRepoWatchersIDs returns IDs of watchers for a given repo ID
+// GetRepoWatchersIDs returns IDs of watchers notified of a type of event for a given repo ID
 // but avoids joining with `user` for performance reasons
 // User permissions must be verified elsewhere if required
-func GetRepoWatchersIDs(ctx context.Context, repoID int64) ([]int64, error) {
+func GetRepoWatchersIDs(ctx context.Context, repoID int64, event WatchEvent) ([]int64, error) {
 	ids := make([]int64, 0, 64)
 	return ids, db.GetEngine(ctx).Table("watch").
 		Where("watch.repo_id=?", repoID).
 		And("watch.mode<>?", WatchModeDont).
+		And(builder.Eq{"watch." + string(event): true}).
 		Select("user_id").
 		Find(&ids)
 }
@@ -174,5 +256,9 @@ func WatchIfAuto(ctx context.Context, userID, repoID int64, isWrite bool) error
 	if watch.Mode != WatchModeNone {
 		return nil
 	}
+	participatingOnly, err := IsParticipatingOnly(ctx, userID)
+	if err != nil || participatingOnly {
+		return err
+	}
 	return watchRepoMode(ctx, watch, WatchModeAuto)
 }
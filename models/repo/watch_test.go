@@ -11,6 +11,8 @@ import (
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -119,3 +121,64 @@ func TestWatchIfAuto(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, watchers, prevCount)
 }
+
+func TestSetWatchEvents(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user12 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 12})
+
+	// the watchers are notified of the issues, pull requests and releases by default
+	watchers, err := repo_model.GetRepoWatchersIDs(t.Context(), repo.ID, repo_model.WatchEventIssues)
+	assert.NoError(t, err)
+	assert.Contains(t, watchers, user4.ID)
+	watchers, err = repo_model.GetRepoWatchersIDs(t.Context(), repo.ID, repo_model.WatchEventCIFailures)
+	assert.NoError(t, err)
+	assert.Empty(t, watchers)
+
+	watch, err := repo_model.SetWatchEvents(t.Context(), user4, repo, map[repo_model.WatchEvent]bool{
+		repo_model.WatchEventIssues:     false,
+		repo_model.WatchEventCIFailures: true,
+	})
+	assert.NoError(t, err)
+	assert.False(t, watch.Issues)
+	assert.True(t, watch.PullRequests)
+	assert.True(t, watch.CIFailures)
+	watchers, err = repo_model.GetRepoWatchersIDs(t.Context(), repo.ID, repo_model.WatchEventIssues)
+	assert.NoError(t, err)
+	assert.NotContains(t, watchers, user4.ID)
+	watchers, err = repo_model.GetRepoWatchersIDs(t.Context(), repo.ID, repo_model.WatchEventCIFailures)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{user4.ID}, watchers)
+
+	// a user who doesn't watch the repository yet starts watching it
+	prevCount := repo.NumWatches
+	watch, err = repo_model.SetWatchEvents(t.Context(), user12, repo, map[repo_model.WatchEvent]bool{repo_model.WatchEventReleases: false})
+	assert.NoError(t, err)
+	assert.Equal(t, repo_model.WatchModeNormal, watch.Mode)
+	assert.True(t, watch.Issues)
+	assert.False(t, watch.Releases)
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.Equal(t, prevCount+1, repo.NumWatches)
+
+	_, err = repo_model.SetWatchEvents(t.Context(), user12, repo, map[repo_model.WatchEvent]bool{"discussions": true})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestAutoWatchRepoParticipatingOnly(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user12 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 12})
+	defer test.MockVariableValue(&setting.Service.AutoWatchOnChanges, true)()
+
+	assert.NoError(t, user_model.SetUserSetting(t.Context(), user12.ID, user_model.SettingsKeyNotificationParticipatingOnly, "true"))
+	assert.NoError(t, repo_model.AutoWatchRepo(t.Context(), user12, repo))
+	assert.NoError(t, repo_model.WatchIfAuto(t.Context(), user12.ID, repo.ID, true))
+	assert.False(t, repo_model.IsWatching(t.Context(), user12.ID, repo.ID))
+
+	assert.NoError(t, user_model.SetUserSetting(t.Context(), user12.ID, user_model.SettingsKeyNotificationParticipatingOnly, "false"))
+	assert.NoError(t, repo_model.AutoWatchRepo(t.Context(), user12, repo))
+	assert.True(t, repo_model.IsWatching(t.Context(), user12.ID, repo.ID))
+}
@@ -85,3 +85,22 @@ func WikiPath(userName, repoName string) string {
 func (repo *Repository) WikiPath() string {
 	return WikiPath(repo.OwnerName, repo.Name)
 }
+
+// ErrWikiPageProtected represents an error that the wiki page is protected and the user is not allowed to edit it.
+type ErrWikiPageProtected struct {
+	Title string
+}
+
+// IsErrWikiPageProtected checks if an error is an ErrWikiPageProtected.
+func IsErrWikiPageProtected(err error) bool {
+	_, ok := err.(ErrWikiPageProtected)
+	return ok
+}
+
+func (err ErrWikiPageProtected) Error() string {
+	return "wiki page is protected: " + err.Title
+}
+
+func (err ErrWikiPageProtected) Unwrap() error {
+	return util.ErrPermissionDenied
+}
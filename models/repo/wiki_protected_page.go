@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ProtectedWikiPage represents a pattern of wiki pages which can only be edited by the repository administrators.
+// The pattern is matched against the git file name of the page without the ".md" suffix, e.g. "Home" or "Release-Process".
+type ProtectedWikiPage struct {
+	ID           int64          `xorm:"pk autoincr"`
+	RepoID       int64          `xorm:"INDEX NOT NULL"`
+	NamePattern  string         `xorm:"NOT NULL"`
+	RegexPattern *regexp.Regexp `xorm:"-"`
+	GlobPattern  glob.Glob      `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ProtectedWikiPage))
+}
+
+// EnsureCompiledPattern ensures the glob or regular expression pattern is compiled
+func (p *ProtectedWikiPage) EnsureCompiledPattern() error {
+	if p.RegexPattern != nil || p.GlobPattern != nil {
+		return nil
+	}
+
+	var err error
+	if len(p.NamePattern) >= 2 && strings.HasPrefix(p.NamePattern, "/") && strings.HasSuffix(p.NamePattern, "/") {
+		p.RegexPattern, err = regexp.Compile(p.NamePattern[1 : len(p.NamePattern)-1])
+	} else {
+		p.GlobPattern, err = glob.Compile(p.NamePattern)
+	}
+	return err
+}
+
+// Match returns true if the page name matches the pattern, the pattern must have been compiled
+func (p *ProtectedWikiPage) Match(pageName string) bool {
+	if p.RegexPattern != nil {
+		return p.RegexPattern.MatchString(pageName)
+	}
+	return p.GlobPattern.Match(pageName)
+}
+
+// GetProtectedWikiPages returns all the protected wiki page patterns of the repository
+func GetProtectedWikiPages(ctx context.Context, repoID int64) ([]*ProtectedWikiPage, error) {
+	pages := make([]*ProtectedWikiPage, 0, 5)
+	return pages, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("id").Find(&pages)
+}
+
+// GetProtectedWikiPageByID returns the protected wiki page pattern of the repository with the given id
+func GetProtectedWikiPageByID(ctx context.Context, repoID, id int64) (*ProtectedWikiPage, error) {
+	p, exist, err := db.Get[ProtectedWikiPage](ctx, builder.Eq{"id": id, "repo_id": repoID})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, db.ErrNotExist{Resource: "protected_wiki_page", ID: id}
+	}
+	return p, nil
+}
+
+// InsertProtectedWikiPage inserts a protected wiki page pattern
+func InsertProtectedWikiPage(ctx context.Context, p *ProtectedWikiPage) error {
+	return db.Insert(ctx, p)
+}
+
+// DeleteProtectedWikiPage deletes a protected wiki page pattern of the repository
+func DeleteProtectedWikiPage(ctx context.Context, repoID, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(&ProtectedWikiPage{})
+	return err
+}
+
+// IsWikiPageProtected returns true if the page name matches one of the patterns
+func IsWikiPageProtected(pages []*ProtectedWikiPage, pageName string) (bool, error) {
+	for _, p := range pages {
+		if err := p.EnsureCompiledPattern(); err != nil {
+			return false, err
+		}
+		if p.Match(pageName) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
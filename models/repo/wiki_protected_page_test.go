@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWikiPageProtected(t *testing.T) {
+	pages := []*ProtectedWikiPage{
+		{NamePattern: "Home"},
+		{NamePattern: "Release-*"},
+		{NamePattern: `/^_(Sidebar|Footer)$/`},
+	}
+
+	cases := []struct {
+		pageName  string
+		protected bool
+	}{
+		{"Home", true},
+		{"Home-Page", false},
+		{"Release-Process", true},
+		{"Releases", false},
+		{"_Sidebar", true},
+		{"_Footer", true},
+		{"My_Sidebar", false},
+	}
+	for _, c := range cases {
+		protected, err := IsWikiPageProtected(pages, c.pageName)
+		assert.NoError(t, err)
+		assert.Equal(t, c.protected, protected, "page %s", c.pageName)
+	}
+
+	_, err := IsWikiPageProtected([]*ProtectedWikiPage{{NamePattern: "/[/"}}, "Home")
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ package secret
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	actions_module "code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/log"
 	secret_module "code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/secret/external"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
@@ -34,12 +36,18 @@ import (
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to zero, global secrets are not supported.
 // It's for security reasons, admin may be not aware of that the secrets could be stolen by any user when setting them as global.
+//
+// A repo level secret can belong to a deployment environment of the repository (EnvironmentID),
+// then it's only available to the jobs deploying to the environment.
 type Secret struct {
-	ID          int64
-	OwnerID     int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        string             `xorm:"LONGTEXT"` // encrypted data
+	ID            int64
+	OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+	RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          string `xorm:"LONGTEXT"` // encrypted data, or the encrypted reference of an external secret
+	// Provider is the external secret store of the referenced value, it's empty for the secrets whose value is stored by Gitea
+	Provider    string             `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
 	Description string             `xorm:"TEXT"`
 	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
 }
@@ -63,7 +71,7 @@ func (err ErrSecretNotFound) Unwrap() error {
 }
 
 // InsertEncryptedSecret Creates, encrypts, and validates a new secret with yet unencrypted data and insert into database
-func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*Secret, error) {
+func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, provider, data, description string) (*Secret, error) {
 	if ownerID != 0 && repoID != 0 {
 		// It's trying to create a secret that belongs to a repository, but OwnerID has been set accidentally.
 		// Remove OwnerID to avoid confusion; it's not worth returning an error here.
@@ -72,25 +80,31 @@ func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, dat
 	if ownerID == 0 && repoID == 0 {
 		return nil, fmt.Errorf("%w: ownerID and repoID cannot be both zero, global secrets are not supported", util.ErrInvalidArgument)
 	}
+	return insertEncryptedSecret(ctx, &Secret{OwnerID: ownerID, RepoID: repoID}, name, provider, data, description)
+}
+
+// InsertEncryptedEnvironmentSecret creates a secret of a deployment environment of the repository
+func InsertEncryptedEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, provider, data, description string) (*Secret, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, fmt.Errorf("%w: repoID and environmentID are required by environment secrets", util.ErrInvalidArgument)
+	}
+	return insertEncryptedSecret(ctx, &Secret{RepoID: repoID, EnvironmentID: environmentID}, name, provider, data, description)
+}
 
+func insertEncryptedSecret(ctx context.Context, secret *Secret, name, provider, data, description string) (*Secret, error) {
 	if len(data) > SecretDataMaxLength {
 		return nil, util.NewInvalidArgumentErrorf("data too long")
 	}
 
-	description = util.TruncateRunes(description, SecretDescriptionMaxLength)
-
 	encrypted, err := secret_module.EncryptSecret(setting.SecretKey, data)
 	if err != nil {
 		return nil, err
 	}
 
-	secret := &Secret{
-		OwnerID:     ownerID,
-		RepoID:      repoID,
-		Name:        strings.ToUpper(name),
-		Data:        encrypted,
-		Description: description,
-	}
+	secret.Name = strings.ToUpper(name)
+	secret.Data = encrypted
+	secret.Provider = provider
+	secret.Description = util.TruncateRunes(description, SecretDescriptionMaxLength)
 	return secret, db.Insert(ctx, secret)
 }
 
@@ -104,6 +118,8 @@ type FindSecretsOptions struct {
 	OwnerID  int64 // it will be ignored if RepoID is set
 	SecretID int64
 	Name     string
+	// EnvironmentID finds the secrets of a deployment environment of the repository, the other repo level secrets are found if it's 0
+	EnvironmentID int64
 }
 
 func (opts FindSecretsOptions) ToConds() builder.Cond {
@@ -116,6 +132,7 @@ func (opts FindSecretsOptions) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.SecretID != 0 {
 		cond = cond.And(builder.Eq{"id": opts.SecretID})
@@ -128,7 +145,7 @@ func (opts FindSecretsOptions) ToConds() builder.Cond {
 }
 
 // UpdateSecret changes org or user reop secret.
-func UpdateSecret(ctx context.Context, secretID int64, data, description string) error {
+func UpdateSecret(ctx context.Context, secretID int64, provider, data, description string) error {
 	if len(data) > SecretDataMaxLength {
 		return util.NewInvalidArgumentErrorf("data too long")
 	}
@@ -142,9 +159,10 @@ func UpdateSecret(ctx context.Context, secretID int64, data, description string)
 
 	s := &Secret{
 		Data:        encrypted,
+		Provider:    provider,
 		Description: description,
 	}
-	affected, err := db.GetEngine(ctx).ID(secretID).Cols("data", "description").Update(s)
+	affected, err := db.GetEngine(ctx).ID(secretID).Cols("data", "provider", "description").Update(s)
 	if affected != 1 {
 		return ErrSecretNotFound{}
 	}
@@ -175,12 +193,37 @@ func GetSecretsOfTask(ctx context.Context, task *actions_model.ActionTask) (map[
 		return nil, err
 	}
 
-	for _, secret := range append(ownerSecrets, repoSecrets...) {
+	allSecrets := append(ownerSecrets, repoSecrets...)
+	if task.Job.Environment != "" {
+		// the secrets of the deployment environment override the repo level ones
+		env, err := actions_model.GetEnvironmentByName(ctx, task.Job.RepoID, task.Job.Environment)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+		if env != nil {
+			envSecrets, err := db.Find[Secret](ctx, FindSecretsOptions{RepoID: task.Job.Run.RepoID, EnvironmentID: env.ID})
+			if err != nil {
+				log.Error("find secrets of environment %v: %v", env.ID, err)
+				return nil, err
+			}
+			allSecrets = append(allSecrets, envSecrets...)
+		}
+	}
+
+	for _, secret := range allSecrets {
 		v, err := secret_module.DecryptSecret(setting.SecretKey, secret.Data)
 		if err != nil {
 			log.Error("decrypt secret %v %q: %v", secret.ID, secret.Name, err)
 			return nil, err
 		}
+		if secret.Provider != "" {
+			// the value is fetched from the external store for each job, so it's rotated outside Gitea
+			if v, err = external.Resolve(ctx, secret.Provider, task.Job.Run.Repo.OwnerName, v); err != nil {
+				log.Warn("resolve %s secret %v %q: %v", secret.Provider, secret.ID, secret.Name, err)
+				delete(secrets, secret.Name) // don't fall back to the owner level secret with the same name
+				continue
+			}
+		}
 		secrets[secret.Name] = v
 	}
 
@@ -0,0 +1,195 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package system
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AnnouncementType is the kind of an announcement, it defines how the banner is shown
+type AnnouncementType string
+
+const (
+	AnnouncementTypeInfo        AnnouncementType = "info"
+	AnnouncementTypeWarning     AnnouncementType = "warning"
+	AnnouncementTypeMaintenance AnnouncementType = "maintenance"
+)
+
+// IsValid checks whether the type is known
+func (t AnnouncementType) IsValid() bool {
+	switch t {
+	case AnnouncementTypeInfo, AnnouncementTypeWarning, AnnouncementTypeMaintenance:
+		return true
+	}
+	return false
+}
+
+// AnnouncementAudience defines who sees an announcement
+type AnnouncementAudience string
+
+const (
+	// AnnouncementAudienceAll shows the announcement to everyone, including anonymous visitors
+	AnnouncementAudienceAll AnnouncementAudience = "all"
+	// AnnouncementAudienceOrgMembers shows the announcement to the members of the organization of the announcement
+	AnnouncementAudienceOrgMembers AnnouncementAudience = "org_members"
+	// AnnouncementAudienceAdmins shows the announcement to the instance admins
+	AnnouncementAudienceAdmins AnnouncementAudience = "admins"
+)
+
+// IsValid checks whether the audience is known
+func (a AnnouncementAudience) IsValid() bool {
+	switch a {
+	case AnnouncementAudienceAll, AnnouncementAudienceOrgMembers, AnnouncementAudienceAdmins:
+		return true
+	}
+	return false
+}
+
+// ErrAnnouncementNotExist represents a "announcement not exist" error
+var ErrAnnouncementNotExist = util.NewNotExistErrorf("announcement does not exist")
+
+// Announcement is a banner which is shown on every page to its audience while it is scheduled
+type Announcement struct {
+	ID       int64                `xorm:"pk autoincr"`
+	Type     AnnouncementType     `xorm:"VARCHAR(20) NOT NULL"`
+	Content  string               `xorm:"TEXT NOT NULL"` // markdown
+	Audience AnnouncementAudience `xorm:"VARCHAR(20) NOT NULL"`
+	OrgID    int64                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// Dismissible announcements can be hidden by the signed-in users
+	Dismissible bool `xorm:"NOT NULL DEFAULT true"`
+	// StartsUnix is the time from which the announcement is shown (0 means immediately)
+	StartsUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// EndsUnix is the time from which the announcement isn't shown anymore (0 means never)
+	EndsUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// AnnouncementDismissal records that a user has dismissed an announcement
+type AnnouncementDismissal struct {
+	ID             int64              `xorm:"pk autoincr"`
+	AnnouncementID int64              `xorm:"UNIQUE(s) NOT NULL"`
+	UserID         int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(Announcement))
+	db.RegisterModel(new(AnnouncementDismissal))
+}
+
+// IsActive checks whether the announcement is scheduled to be shown now
+func (a *Announcement) IsActive() bool {
+	now := timeutil.TimeStampNow()
+	return a.StartsUnix <= now && (a.EndsUnix == 0 || now < a.EndsUnix)
+}
+
+// CreateAnnouncement creates an announcement
+func CreateAnnouncement(ctx context.Context, a *Announcement) error {
+	return db.Insert(ctx, a)
+}
+
+// GetAnnouncementByID returns the announcement
+func GetAnnouncementByID(ctx context.Context, id int64) (*Announcement, error) {
+	a := &Announcement{}
+	has, err := db.GetEngine(ctx).ID(id).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAnnouncementNotExist
+	}
+	return a, nil
+}
+
+// UpdateAnnouncement updates all columns of the announcement
+func UpdateAnnouncement(ctx context.Context, a *Announcement) error {
+	_, err := db.GetEngine(ctx).ID(a.ID).AllCols().Update(a)
+	return err
+}
+
+// DeleteAnnouncementByID deletes the announcement and its dismissals
+func DeleteAnnouncementByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("announcement_id = ?", id).Delete(new(AnnouncementDismissal)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(new(Announcement))
+		return err
+	})
+}
+
+// FindAnnouncements returns a page of all announcements, the newest first, and their total count
+func FindAnnouncements(ctx context.Context, opts db.ListOptions) ([]*Announcement, int64, error) {
+	sess := db.GetEngine(ctx).OrderBy("id DESC")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	announcements := make([]*Announcement, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&announcements)
+	return announcements, count, err
+}
+
+// FindActiveAnnouncementsOptions defines the viewer of the announcements
+type FindActiveAnnouncementsOptions struct {
+	// UserID is the signed-in user, 0 for anonymous visitors
+	UserID  int64
+	IsAdmin bool
+}
+
+func (opts FindActiveAnnouncementsOptions) toConds() builder.Cond {
+	now := timeutil.TimeStampNow()
+	cond := builder.NewCond().
+		And(builder.Lte{"starts_unix": now}).
+		And(builder.Eq{"ends_unix": 0}.Or(builder.Gt{"ends_unix": now}))
+
+	audience := builder.NewCond().Or(builder.Eq{"audience": AnnouncementAudienceAll})
+	if opts.UserID > 0 {
+		audience = audience.Or(builder.Eq{"audience": AnnouncementAudienceOrgMembers}.
+			And(builder.In("org_id", builder.Select("org_id").From("org_user").Where(builder.Eq{"uid": opts.UserID}))))
+		if opts.IsAdmin {
+			audience = audience.Or(builder.Eq{"audience": AnnouncementAudienceAdmins})
+		}
+		cond = cond.And(builder.NotIn("id", builder.Select("announcement_id").From("announcement_dismissal").Where(builder.Eq{"user_id": opts.UserID})))
+	}
+	return cond.And(audience)
+}
+
+// FindActiveAnnouncements returns the announcements which are shown to the viewer now, the ones dismissed by the user
+// are skipped
+func FindActiveAnnouncements(ctx context.Context, opts FindActiveAnnouncementsOptions) ([]*Announcement, error) {
+	announcements := make([]*Announcement, 0, 5)
+	return announcements, db.GetEngine(ctx).Where(opts.toConds()).OrderBy("starts_unix DESC, id DESC").Find(&announcements)
+}
+
+// GetActiveAnnouncementByID returns the announcement if it is shown to the viewer now
+func GetActiveAnnouncementByID(ctx context.Context, id int64, opts FindActiveAnnouncementsOptions) (*Announcement, error) {
+	a := &Announcement{}
+	has, err := db.GetEngine(ctx).Where(opts.toConds().And(builder.Eq{"id": id})).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAnnouncementNotExist
+	}
+	return a, nil
+}
+
+// DismissAnnouncement hides the dismissible announcement for the user
+func DismissAnnouncement(ctx context.Context, a *Announcement, userID int64) error {
+	if !a.Dismissible {
+		return util.NewInvalidArgumentErrorf("announcement can't be dismissed")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Exist(&AnnouncementDismissal{AnnouncementID: a.ID, UserID: userID})
+		if err != nil || has {
+			return err
+		}
+		return db.Insert(ctx, &AnnouncementDismissal{AnnouncementID: a.ID, UserID: userID})
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package system_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindActiveAnnouncements(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	all := &system.Announcement{Type: system.AnnouncementTypeInfo, Content: "all", Audience: system.AnnouncementAudienceAll, Dismissible: true}
+	org := &system.Announcement{Type: system.AnnouncementTypeWarning, Content: "org", Audience: system.AnnouncementAudienceOrgMembers, OrgID: 3}
+	admins := &system.Announcement{Type: system.AnnouncementTypeMaintenance, Content: "admins", Audience: system.AnnouncementAudienceAdmins}
+	scheduled := &system.Announcement{Type: system.AnnouncementTypeInfo, Content: "scheduled", Audience: system.AnnouncementAudienceAll, StartsUnix: now + 3600}
+	expired := &system.Announcement{Type: system.AnnouncementTypeInfo, Content: "expired", Audience: system.AnnouncementAudienceAll, EndsUnix: now - 60}
+	for _, a := range []*system.Announcement{all, org, admins, scheduled, expired} {
+		require.NoError(t, system.CreateAnnouncement(t.Context(), a))
+	}
+
+	contents := func(opts system.FindActiveAnnouncementsOptions) []string {
+		announcements, err := system.FindActiveAnnouncements(t.Context(), opts)
+		require.NoError(t, err)
+		var result []string
+		for _, a := range announcements {
+			result = append(result, a.Content)
+		}
+		return result
+	}
+
+	assert.ElementsMatch(t, []string{"all"}, contents(system.FindActiveAnnouncementsOptions{}))
+	// user 2 is a member of org 3
+	assert.ElementsMatch(t, []string{"all", "org"}, contents(system.FindActiveAnnouncementsOptions{UserID: 2}))
+	assert.ElementsMatch(t, []string{"all"}, contents(system.FindActiveAnnouncementsOptions{UserID: 8}))
+	assert.ElementsMatch(t, []string{"all", "admins"}, contents(system.FindActiveAnnouncementsOptions{UserID: 1, IsAdmin: true}))
+
+	_, err := system.GetActiveAnnouncementByID(t.Context(), admins.ID, system.FindActiveAnnouncementsOptions{UserID: 2})
+	assert.ErrorIs(t, err, system.ErrAnnouncementNotExist)
+
+	require.NoError(t, system.DismissAnnouncement(t.Context(), all, 2))
+	require.NoError(t, system.DismissAnnouncement(t.Context(), all, 2))
+	assert.ElementsMatch(t, []string{"org"}, contents(system.FindActiveAnnouncementsOptions{UserID: 2}))
+	assert.ElementsMatch(t, []string{"all"}, contents(system.FindActiveAnnouncementsOptions{}))
+	assert.Error(t, system.DismissAnnouncement(t.Context(), org, 2))
+
+	require.NoError(t, system.DeleteAnnouncementByID(t.Context(), all.ID))
+	unittest.AssertNotExistsBean(t, &system.AnnouncementDismissal{AnnouncementID: all.ID})
+	_, err = system.GetAnnouncementByID(t.Context(), all.ID)
+	assert.ErrorIs(t, err, system.ErrAnnouncementNotExist)
+}
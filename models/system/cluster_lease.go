@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package system
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ClusterLease represents a lease held by one of the instances sharing the database,
+// eg: the leadership of the cluster or the lock of a cron task
+type ClusterLease struct {
+	ID         string             `xorm:"pk varchar(200)"`
+	Owner      string             `xorm:"VARCHAR(255) NOT NULL"`
+	LeaseUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(ClusterLease))
+}
+
+// AcquireClusterLease acquires or renews the lease of the key for the owner until the given time,
+// it returns false if the lease is held by another owner and hasn't expired
+func AcquireClusterLease(ctx context.Context, key, owner string, until timeutil.TimeStamp) (bool, error) {
+	e := db.GetEngine(ctx)
+	res, err := e.Exec("UPDATE cluster_lease SET owner = ?, lease_until = ? WHERE id = ? AND (owner = ? OR lease_until < ?)",
+		owner, until, key, owner, timeutil.TimeStampNow())
+	if err != nil {
+		return false, err
+	}
+	if rows, _ := res.RowsAffected(); rows != 0 {
+		return true, nil
+	}
+
+	// MySQL doesn't count the rows which are not changed, the lease may be renewed in the same second
+	lease := &ClusterLease{ID: key}
+	has, err := e.Get(lease)
+	if err != nil {
+		return false, err
+	} else if has {
+		return lease.Owner == owner && lease.LeaseUntil >= until, nil
+	}
+
+	if _, err = e.Insert(&ClusterLease{ID: key, Owner: owner, LeaseUntil: until}); err != nil {
+		// another owner may insert the lease at the same time
+		if has, _ := e.Exist(&ClusterLease{ID: key}); has {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ReleaseClusterLease releases the lease of the key if it's held by the owner
+func ReleaseClusterLease(ctx context.Context, key, owner string) error {
+	_, err := db.GetEngine(ctx).Where("id = ? AND owner = ?", key, owner).Delete(new(ClusterLease))
+	return err
+}
+
+// GetClusterLeaseOwner returns the owner of the lease of the key, it's empty if the lease has expired
+func GetClusterLeaseOwner(ctx context.Context, key string) (string, error) {
+	lease := &ClusterLease{ID: key}
+	has, err := db.GetEngine(ctx).Get(lease)
+	if err != nil || !has || lease.LeaseUntil < timeutil.TimeStampNow() {
+		return "", err
+	}
+	return lease.Owner, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package system_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterLease(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+	now := timeutil.TimeStampNow()
+
+	ok, err := system.AcquireClusterLease(ctx, "leader", "node1", now+30)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the lease can be renewed by its owner, even in the same second
+	ok, err = system.AcquireClusterLease(ctx, "leader", "node1", now+30)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = system.AcquireClusterLease(ctx, "leader", "node1", now+60)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = system.AcquireClusterLease(ctx, "leader", "node2", now+30)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	owner, err := system.GetClusterLeaseOwner(ctx, "leader")
+	require.NoError(t, err)
+	assert.Equal(t, "node1", owner)
+
+	// the releasing of another owner is ignored
+	require.NoError(t, system.ReleaseClusterLease(ctx, "leader", "node2"))
+	owner, err = system.GetClusterLeaseOwner(ctx, "leader")
+	require.NoError(t, err)
+	assert.Equal(t, "node1", owner)
+
+	require.NoError(t, system.ReleaseClusterLease(ctx, "leader", "node1"))
+	owner, err = system.GetClusterLeaseOwner(ctx, "leader")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+	ok, err = system.AcquireClusterLease(ctx, "leader", "node2", now+30)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// an expired lease can be taken over
+	_, err = db.GetEngine(ctx).Exec("UPDATE cluster_lease SET lease_until = ? WHERE id = ?", now-1, "leader")
+	require.NoError(t, err)
+	owner, err = system.GetClusterLeaseOwner(ctx, "leader")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+	ok, err = system.AcquireClusterLease(ctx, "leader", "node1", now+30)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
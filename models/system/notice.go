@@ -24,6 +24,12 @@ const (
 	NoticeRepository NoticeType = iota + 1
 	// NoticeTask type
 	NoticeTask
+	// NoticeImpersonation type
+	NoticeImpersonation
+	// NoticeOffboarding type
+	NoticeOffboarding
+	// NoticeApproval type
+	NoticeApproval
 )
 
 // Notice represents a system notice for admin.
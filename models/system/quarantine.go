@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package system
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// QuarantineSubject is the kind of upload in which malware has been found
+type QuarantineSubject int
+
+const (
+	// QuarantineSubjectAttachment is an issue or pull request attachment or a release asset, it is kept but only served to admins
+	QuarantineSubjectAttachment QuarantineSubject = iota + 1
+	// QuarantineSubjectUserAvatar is a rejected avatar of a user or an organization
+	QuarantineSubjectUserAvatar
+	// QuarantineSubjectRepoAvatar is a rejected avatar of a repository
+	QuarantineSubjectRepoAvatar
+)
+
+// ErrQuarantinedFileNotExist represents a "quarantined file not exist" error
+var ErrQuarantinedFileNotExist = util.NewNotExistErrorf("quarantined file does not exist")
+
+// QuarantinedFile is an upload in which malware has been found, it waits for the review of an admin
+type QuarantinedFile struct {
+	ID           int64             `xorm:"pk autoincr"`
+	Subject      QuarantineSubject `xorm:"NOT NULL"`
+	AttachmentID int64             `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID       int64             `xorm:"NOT NULL DEFAULT 0"`
+	// UserID is the user or organization of an avatar
+	UserID      int64 `xorm:"NOT NULL DEFAULT 0"`
+	UploaderID  int64 `xorm:"NOT NULL DEFAULT 0"`
+	Name        string
+	Size        int64 `xorm:"NOT NULL DEFAULT 0"`
+	Signature   string
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(QuarantinedFile))
+}
+
+// TrStr returns the translation key of the subject
+func (f *QuarantinedFile) TrStr() string {
+	switch f.Subject {
+	case QuarantineSubjectAttachment:
+		return "admin.quarantine.subject_attachment"
+	case QuarantineSubjectUserAvatar:
+		return "admin.quarantine.subject_user_avatar"
+	}
+	return "admin.quarantine.subject_repo_avatar"
+}
+
+// InsertQuarantinedFile inserts a quarantined file
+func InsertQuarantinedFile(ctx context.Context, f *QuarantinedFile) error {
+	return db.Insert(ctx, f)
+}
+
+// GetQuarantinedFileByID gets a quarantined file by its id
+func GetQuarantinedFileByID(ctx context.Context, id int64) (*QuarantinedFile, error) {
+	f := &QuarantinedFile{}
+	has, err := db.GetEngine(ctx).ID(id).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrQuarantinedFileNotExist
+	}
+	return f, nil
+}
+
+// FindQuarantinedFiles returns a page of the quarantined files, the newest first, and their total count
+func FindQuarantinedFiles(ctx context.Context, opts db.ListOptions) ([]*QuarantinedFile, int64, error) {
+	sess := db.GetEngine(ctx).OrderBy("created_unix DESC, id DESC")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	files := make([]*QuarantinedFile, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&files)
+	return files, count, err
+}
+
+// CountQuarantinedFiles counts the quarantined files
+func CountQuarantinedFiles(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(new(QuarantinedFile))
+}
+
+// DeleteQuarantinedFileByID deletes a quarantined file
+func DeleteQuarantinedFileByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(new(QuarantinedFile))
+	return err
+}
+
+// DeleteQuarantinedFilesByAttachmentID deletes the quarantined files of an attachment
+func DeleteQuarantinedFilesByAttachmentID(ctx context.Context, attachmentID int64) error {
+	_, err := db.GetEngine(ctx).Where("attachment_id = ?", attachmentID).Delete(new(QuarantinedFile))
+	return err
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// FederatedUser links a local user of the remote user type to the ActivityPub actor it stands for
+type FederatedUser struct {
+	ID          int64              `xorm:"pk autoincr"`
+	UserID      int64              `xorm:"UNIQUE NOT NULL"`
+	ActorIRI    string             `xorm:"UNIQUE VARCHAR(255) NOT NULL"`
+	Inbox       string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(FederatedUser))
+}
+
+// GetFederatedUserByActorIRI returns the local user standing for a remote actor
+func GetFederatedUserByActorIRI(ctx context.Context, actorIRI string) (*User, error) {
+	fu := new(FederatedUser)
+	has, err := db.GetEngine(ctx).Where("actor_iri = ?", actorIRI).Get(fu)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrUserNotExist{Name: actorIRI}
+	}
+	return GetUserByID(ctx, fu.UserID)
+}
+
+// GetFederatedUser returns the remote actor a user of the remote user type stands for
+func GetFederatedUser(ctx context.Context, userID int64) (*FederatedUser, error) {
+	fu := new(FederatedUser)
+	has, err := db.GetEngine(ctx).Where("user_id = ?", userID).Get(fu)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrUserNotExist{UID: userID}
+	}
+	return fu, nil
+}
+
+// CreateFederatedUser creates a user of the remote user type standing for a remote actor.
+// The user can't sign in, it is only the poster of the content the actor sends.
+func CreateFederatedUser(ctx context.Context, u *User, fu *FederatedUser) error {
+	u.Type = UserTypeRemoteUser
+	u.ProhibitLogin = true
+	maxRepoCreation := 0
+	overwrite := &CreateUserOverwriteOptions{
+		IsActive:                optional.Some(true),
+		IsRestricted:            optional.Some(false),
+		AllowCreateOrganization: optional.Some(false),
+		MaxRepoCreation:         &maxRepoCreation,
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := AdminCreateUser(ctx, u, &Meta{}, overwrite); err != nil {
+			return err
+		}
+		fu.UserID = u.ID
+		return db.Insert(ctx, fu)
+	})
+}
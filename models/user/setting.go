@@ -98,6 +98,22 @@ func GetSettings(ctx context.Context, uid int64, keys []string) (map[string]*Set
 	return settingsMap, nil
 }
 
+// GetSettingForUsers returns the values of a setting of the users having it, by user ID
+func GetSettingForUsers(ctx context.Context, key string, userIDs []int64) (map[int64]string, error) {
+	settings := make([]*Setting, 0, len(userIDs))
+	if err := db.GetEngine(ctx).
+		Where("setting_key=?", key).
+		And(builder.In("user_id", userIDs)).
+		Find(&settings); err != nil {
+		return nil, err
+	}
+	values := make(map[int64]string, len(settings))
+	for _, s := range settings {
+		values[s.UserID] = s.SettingValue
+	}
+	return values, nil
+}
+
 // GetUserAllSettings returns all settings from user
 func GetUserAllSettings(ctx context.Context, uid int64) (map[string]*Setting, error) {
 	settings := make([]*Setting, 0, 5)
@@ -26,4 +26,24 @@ const (
 	SettingEmailNotificationGiteaActionsAll         = "all"
 	SettingEmailNotificationGiteaActionsFailureOnly = "failure-only" // Default for actions email preference
 	SettingEmailNotificationGiteaActionsDisabled    = "disabled"
+
+	// SettingsKeyEmailNotificationDigest is the setting key for batching the notification emails into a digest,
+	// the emails are sent immediately without the setting
+	SettingsKeyEmailNotificationDigest   = "email_notification.digest"
+	SettingEmailNotificationDigestDaily  = "daily"
+	SettingEmailNotificationDigestWeekly = "weekly"
+
+	// SettingsKeyNotificationParticipatingOnly is the setting key whether the user is notified only of what they participate in
+	// unless they watch a repository explicitly, such users are not made watchers of repositories automatically
+	SettingsKeyNotificationParticipatingOnly = "notification.participating_only"
+
+	// SettingsKeyWebPushEvents is the setting key for the comma separated types of events to send web push notifications for
+	SettingsKeyWebPushEvents = "web_push.events"
+
+	// SettingsKeyMatrixID is the setting key for the Matrix user ID to send the Matrix notifications to
+	SettingsKeyMatrixID = "matrix.user_id"
+	// SettingsKeyMatrixRoomID is the setting key for the room of the direct messages of the Matrix notifications
+	SettingsKeyMatrixRoomID = "matrix.room_id"
+	// SettingsKeyMatrixEvents is the setting key for the comma separated types of events to send Matrix notifications for
+	SettingsKeyMatrixEvents = "matrix.events"
 )
@@ -0,0 +1,192 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// TermsKind is the kind of a policy document the users have to accept
+type TermsKind string
+
+const (
+	// TermsKindToS is the terms of service
+	TermsKindToS TermsKind = "tos"
+	// TermsKindAUP is the acceptable use policy
+	TermsKindAUP TermsKind = "aup"
+)
+
+// IsValid checks whether the kind is known
+func (k TermsKind) IsValid() bool {
+	return k == TermsKindToS || k == TermsKindAUP
+}
+
+// TermsDocument is a version of a policy document, the latest version of each kind is the current one. The documents
+// are never changed or deleted because the acceptances refer to them.
+type TermsDocument struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Kind        TermsKind          `xorm:"VARCHAR(20) UNIQUE(s) NOT NULL"`
+	Version     string             `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+	Title       string             `xorm:"NOT NULL"`
+	Content     string             `xorm:"TEXT NOT NULL"` // markdown
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// TermsAcceptance records that a user has accepted a version of a policy document
+type TermsAcceptance struct {
+	ID          int64              `xorm:"pk autoincr"`
+	DocumentID  int64              `xorm:"UNIQUE(s) NOT NULL"`
+	UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(TermsDocument))
+	db.RegisterModel(new(TermsAcceptance))
+}
+
+// ErrTermsDocumentNotExist represents a "terms document not exist" error
+var ErrTermsDocumentNotExist = util.NewNotExistErrorf("terms document does not exist")
+
+// ErrTermsDocumentAlreadyExist represents a "terms document already exist" error
+var ErrTermsDocumentAlreadyExist = util.NewAlreadyExistErrorf("terms document version already exists")
+
+// CreateTermsDocument publishes a new version of a policy document, it replaces the current version of the kind
+func CreateTermsDocument(ctx context.Context, d *TermsDocument) error {
+	d.Version = strings.TrimSpace(d.Version)
+	if !d.Kind.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid kind %q", d.Kind)
+	} else if d.Version == "" {
+		return util.NewInvalidArgumentErrorf("empty version")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Exist(&TermsDocument{Kind: d.Kind, Version: d.Version})
+		if err != nil {
+			return err
+		} else if has {
+			return ErrTermsDocumentAlreadyExist
+		}
+		return db.Insert(ctx, d)
+	})
+}
+
+// GetTermsDocumentByID returns the version of the policy document
+func GetTermsDocumentByID(ctx context.Context, id int64) (*TermsDocument, error) {
+	d := &TermsDocument{}
+	has, err := db.GetEngine(ctx).ID(id).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrTermsDocumentNotExist
+	}
+	return d, nil
+}
+
+// FindTermsDocuments returns all versions of the policy documents, the latest first
+func FindTermsDocuments(ctx context.Context, opts db.ListOptions) ([]*TermsDocument, int64, error) {
+	sess := db.GetEngine(ctx).OrderBy("id DESC")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	docs := make([]*TermsDocument, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&docs)
+	return docs, count, err
+}
+
+// currentTermsDocumentCond selects the latest version of each kind
+func currentTermsDocumentCond() builder.Cond {
+	return builder.In("id", builder.Select("MAX(id)").From("terms_document").GroupBy("kind"))
+}
+
+// GetCurrentTermsDocuments returns the current version of each policy document
+func GetCurrentTermsDocuments(ctx context.Context) ([]*TermsDocument, error) {
+	docs := make([]*TermsDocument, 0, 2)
+	return docs, db.GetEngine(ctx).Where(currentTermsDocumentCond()).OrderBy("kind").Find(&docs)
+}
+
+// GetPendingTermsDocuments returns the current versions of the policy documents the user hasn't accepted yet
+func GetPendingTermsDocuments(ctx context.Context, userID int64) ([]*TermsDocument, error) {
+	docs := make([]*TermsDocument, 0, 2)
+	return docs, db.GetEngine(ctx).
+		Where(currentTermsDocumentCond()).
+		And(builder.NotIn("id", builder.Select("document_id").From("terms_acceptance").Where(builder.Eq{"user_id": userID}))).
+		OrderBy("kind").
+		Find(&docs)
+}
+
+// TermsAcceptanceDeadline returns the time until which the pending documents have to be accepted, the grace period
+// starts when a version is published
+func TermsAcceptanceDeadline(pending []*TermsDocument, gracePeriod time.Duration) time.Time {
+	var deadline time.Time
+	for _, d := range pending {
+		if t := d.CreatedUnix.AsTime().Add(gracePeriod); deadline.IsZero() || t.Before(deadline) {
+			deadline = t
+		}
+	}
+	return deadline
+}
+
+// IsTermsAcceptanceOverdue checks whether the user has pending policy documents whose grace period has expired, such
+// users can't use the instance until they have accepted the documents. Only individual users have to accept them.
+func IsTermsAcceptanceOverdue(ctx context.Context, u *User) (bool, error) {
+	if !u.IsIndividual() {
+		return false, nil
+	}
+	pending, err := GetPendingTermsDocuments(ctx, u.ID)
+	if err != nil || len(pending) == 0 {
+		return false, err
+	}
+	return time.Now().After(TermsAcceptanceDeadline(pending, setting.Service.TermsAcceptanceGracePeriod)), nil
+}
+
+// AcceptTermsDocuments records that the user has accepted the versions of the policy documents
+func AcceptTermsDocuments(ctx context.Context, userID int64, docs []*TermsDocument) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, d := range docs {
+			has, err := db.GetEngine(ctx).Exist(&TermsAcceptance{DocumentID: d.ID, UserID: userID})
+			if err != nil {
+				return err
+			} else if has {
+				continue
+			}
+			if err := db.Insert(ctx, &TermsAcceptance{DocumentID: d.ID, UserID: userID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindTermsAcceptancesOptions are the options of finding acceptances
+type FindTermsAcceptancesOptions struct {
+	db.ListOptions
+	DocumentID int64
+	UserID     int64
+}
+
+// ToConds implements db.FindOptions
+func (opts FindTermsAcceptancesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.DocumentID > 0 {
+		cond = cond.And(builder.Eq{"document_id": opts.DocumentID})
+	}
+	if opts.UserID > 0 {
+		cond = cond.And(builder.Eq{"user_id": opts.UserID})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindTermsAcceptancesOptions) ToOrders() string {
+	return "id DESC"
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermsDocuments(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	assert.ErrorIs(t, user_model.CreateTermsDocument(ctx, &user_model.TermsDocument{Kind: "other", Version: "1"}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, user_model.CreateTermsDocument(ctx, &user_model.TermsDocument{Kind: user_model.TermsKindToS, Version: " "}), util.ErrInvalidArgument)
+
+	tos1 := &user_model.TermsDocument{Kind: user_model.TermsKindToS, Version: "1", Title: "Terms of Service", Content: "v1"}
+	require.NoError(t, user_model.CreateTermsDocument(ctx, tos1))
+	aup1 := &user_model.TermsDocument{Kind: user_model.TermsKindAUP, Version: "1", Title: "Acceptable Use Policy", Content: "v1"}
+	require.NoError(t, user_model.CreateTermsDocument(ctx, aup1))
+	assert.ErrorIs(t, user_model.CreateTermsDocument(ctx, &user_model.TermsDocument{Kind: user_model.TermsKindToS, Version: "1"}), util.ErrAlreadyExist)
+
+	pending, err := user_model.GetPendingTermsDocuments(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	require.NoError(t, user_model.AcceptTermsDocuments(ctx, 2, pending))
+	require.NoError(t, user_model.AcceptTermsDocuments(ctx, 2, pending))
+	pending, err = user_model.GetPendingTermsDocuments(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// a new version replaces the current one and has to be accepted again
+	tos2 := &user_model.TermsDocument{Kind: user_model.TermsKindToS, Version: "2", Title: "Terms of Service", Content: "v2"}
+	require.NoError(t, user_model.CreateTermsDocument(ctx, tos2))
+	current, err := user_model.GetCurrentTermsDocuments(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, current, 2) {
+		assert.Equal(t, aup1.ID, current[0].ID)
+		assert.Equal(t, tos2.ID, current[1].ID)
+	}
+	pending, err = user_model.GetPendingTermsDocuments(ctx, 2)
+	require.NoError(t, err)
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, tos2.ID, pending[0].ID)
+	}
+
+	docs, count, err := user_model.FindTermsDocuments(ctx, db.ListOptions{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	assert.Len(t, docs, 2)
+
+	acceptances, err := db.Find[user_model.TermsAcceptance](ctx, user_model.FindTermsAcceptancesOptions{DocumentID: tos1.ID})
+	require.NoError(t, err)
+	if assert.Len(t, acceptances, 1) {
+		assert.EqualValues(t, 2, acceptances[0].UserID)
+	}
+}
+
+func TestIsTermsAcceptanceOverdue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+	defer test.MockVariableValue(&setting.Service.TermsAcceptanceGracePeriod, time.Hour)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+
+	overdue, err := user_model.IsTermsAcceptanceOverdue(ctx, user2)
+	require.NoError(t, err)
+	assert.False(t, overdue)
+
+	d := &user_model.TermsDocument{Kind: user_model.TermsKindToS, Version: "1", Title: "Terms of Service", Content: "v1"}
+	require.NoError(t, user_model.CreateTermsDocument(ctx, d))
+	overdue, err = user_model.IsTermsAcceptanceOverdue(ctx, user2)
+	require.NoError(t, err)
+	assert.False(t, overdue)
+
+	// the grace period has expired
+	_, err = db.GetEngine(ctx).Table("terms_document").Where("id = ?", d.ID).
+		Update(map[string]any{"created_unix": timeutil.TimeStamp(time.Now().Add(-2 * time.Hour).Unix())})
+	require.NoError(t, err)
+	overdue, err = user_model.IsTermsAcceptanceOverdue(ctx, user2)
+	require.NoError(t, err)
+	assert.True(t, overdue)
+	overdue, err = user_model.IsTermsAcceptanceOverdue(ctx, org3)
+	require.NoError(t, err)
+	assert.False(t, overdue)
+
+	require.NoError(t, user_model.AcceptTermsDocuments(ctx, user2.ID, []*user_model.TermsDocument{d}))
+	overdue, err = user_model.IsTermsAcceptanceOverdue(ctx, user2)
+	require.NoError(t, err)
+	assert.False(t, overdue)
+}
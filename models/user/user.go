@@ -145,6 +145,8 @@ type User struct {
 	NumMembers                int
 	Visibility                structs.VisibleType `xorm:"NOT NULL DEFAULT 0"`
 	RepoAdminChangeTeamAccess bool                `xorm:"NOT NULL DEFAULT false"`
+	// DisallowImpersonation prevents instance admins from signing in as members of the organization
+	DisallowImpersonation bool `xorm:"NOT NULL DEFAULT false"`
 
 	// Preferences
 	DiffViewStyle       string `xorm:"NOT NULL DEFAULT ''"`
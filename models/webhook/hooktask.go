@@ -56,6 +56,11 @@ type HookTask struct {
 	IsDelivered bool
 	Delivered   timeutil.TimeStampNano
 
+	// Retry info.
+	Attempt      int                `xorm:"NOT NULL DEFAULT 1"`       // the attempt of the delivery, starting at 1
+	DeliverAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"` // a scheduled retry which isn't enqueued yet, it's not delivered before this time
+	IsDeadLetter bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// History info.
 	IsSucceed       bool
 	RequestContent  string        `xorm:"LONGTEXT"`
@@ -68,6 +73,80 @@ func init() {
 	db.RegisterModel(new(HookTask))
 }
 
+// HookTaskStatus represents the delivery status of a hook task
+type HookTaskStatus string
+
+const (
+	HookTaskStatusPending    HookTaskStatus = "pending"
+	HookTaskStatusSucceeded  HookTaskStatus = "succeeded"
+	HookTaskStatusFailed     HookTaskStatus = "failed"      // the delivery failed and gets retried
+	HookTaskStatusDeadLetter HookTaskStatus = "dead_letter" // the delivery failed and will not be retried
+)
+
+// IsValid checks if the status is known
+func (s HookTaskStatus) IsValid() bool {
+	switch s {
+	case HookTaskStatusPending, HookTaskStatusSucceeded, HookTaskStatusFailed, HookTaskStatusDeadLetter:
+		return true
+	}
+	return false
+}
+
+// Status returns the delivery status of the hook task
+func (t *HookTask) Status() HookTaskStatus {
+	switch {
+	case !t.IsDelivered && t.DeliverAfter == 0:
+		return HookTaskStatusPending
+	case !t.IsDelivered:
+		return HookTaskStatusFailed
+	case t.IsSucceed:
+		return HookTaskStatusSucceeded
+	case t.IsDeadLetter:
+		return HookTaskStatusDeadLetter
+	}
+	return HookTaskStatusFailed
+}
+
+// FindHookTaskOptions represents the options to find the hook tasks of a webhook
+type FindHookTaskOptions struct {
+	db.ListOptions
+	HookID int64
+	Status HookTaskStatus
+	Since  timeutil.TimeStampNano // the tasks delivered at or after this time
+	Before timeutil.TimeStampNano // the tasks delivered before this time
+}
+
+func (opts FindHookTaskOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.HookID != 0 {
+		cond = cond.And(builder.Eq{"hook_id": opts.HookID})
+	}
+	switch opts.Status {
+	case HookTaskStatusPending:
+		cond = cond.And(builder.Eq{"is_delivered": false, "deliver_after": 0})
+	case HookTaskStatusSucceeded:
+		cond = cond.And(builder.Eq{"is_delivered": true, "is_succeed": true})
+	case HookTaskStatusFailed:
+		cond = cond.And(builder.Or(
+			builder.Eq{"is_delivered": false}.And(builder.Gt{"deliver_after": 0}),
+			builder.Eq{"is_delivered": true, "is_succeed": false, "is_dead_letter": false},
+		))
+	case HookTaskStatusDeadLetter:
+		cond = cond.And(builder.Eq{"is_delivered": true, "is_succeed": false, "is_dead_letter": true})
+	}
+	if opts.Since != 0 {
+		cond = cond.And(builder.Gte{"delivered": opts.Since})
+	}
+	if opts.Before != 0 {
+		cond = cond.And(builder.Lt{"delivered": opts.Before})
+	}
+	return cond
+}
+
+func (opts FindHookTaskOptions) ToOrders() string {
+	return "id DESC"
+}
+
 // BeforeUpdate will be invoked by XORM before updating a record
 // representing this object
 func (t *HookTask) BeforeUpdate() {
@@ -126,6 +205,9 @@ func CreateHookTask(ctx context.Context, t *HookTask) (*HookTask, error) {
 	if t.PayloadVersion == 0 {
 		return nil, errors.New("missing HookTask.PayloadVersion")
 	}
+	if t.Attempt == 0 {
+		t.Attempt = 1
+	}
 	return t, db.Insert(ctx, t)
 }
 
@@ -170,8 +252,37 @@ func ReplayHookTask(ctx context.Context, hookID int64, uuid string) (*HookTask,
 	})
 }
 
+// ClearHookTaskDeadLetter removes the dead letter state of a hook task after it got redelivered
+func ClearHookTaskDeadLetter(ctx context.Context, t *HookTask) error {
+	t.IsDeadLetter = false
+	_, err := db.GetEngine(ctx).ID(t.ID).Cols("is_dead_letter").NoAutoTime().Update(t)
+	return err
+}
+
+// MarkHookTaskRetryEnqueued clears the schedule of a due retry before it gets enqueued, it returns false if the retry
+// isn't due or has been enqueued in the meantime
+func MarkHookTaskRetryEnqueued(ctx context.Context, id int64) (bool, error) {
+	count, err := db.GetEngine(ctx).ID(id).
+		Where("is_delivered = ?", false).
+		And(builder.Gt{"deliver_after": 0}.And(builder.Lte{"deliver_after": timeutil.TimeStampNow()})).
+		Cols("deliver_after").NoAutoTime().
+		Update(&HookTask{DeliverAfter: 0})
+	return count != 0, err
+}
+
 // FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID
+// which are due to be delivered
 func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, error) {
+	return findUndeliveredHookTaskIDs(ctx, lowerID, builder.Lte{"deliver_after": timeutil.TimeStampNow()})
+}
+
+// FindDueRetryHookTaskIDs will find the next 100 retries of hook tasks with ID greater than the provided lowerID
+// which are due to be delivered
+func FindDueRetryHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, error) {
+	return findUndeliveredHookTaskIDs(ctx, lowerID, builder.Gt{"deliver_after": 0}.And(builder.Lte{"deliver_after": timeutil.TimeStampNow()}))
+}
+
+func findUndeliveredHookTaskIDs(ctx context.Context, lowerID int64, cond builder.Cond) ([]int64, error) {
 	const batchSize = 100
 
 	tasks := make([]int64, 0, batchSize)
@@ -180,6 +291,7 @@ func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, er
 		Table(new(HookTask)).
 		Where("is_delivered=?", false).
 		And("id > ?", lowerID).
+		And(cond).
 		Asc("id").
 		Limit(batchSize).
 		Find(&tasks)
@@ -23,6 +23,8 @@ const (
 	GithubEventPullRequestComment       = "pull_request_comment"
 	GithubEventGollum                   = "gollum"
 	GithubEventSchedule                 = "schedule"
+	GithubEventWorkflowCall             = "workflow_call"
+	GithubEventRepositoryDispatch       = "repository_dispatch"
 )
 
 // IsDefaultBranchWorkflow returns true if the event only triggers workflows on the default branch
@@ -52,6 +54,10 @@ func IsDefaultBranchWorkflow(triggedEvent webhook_module.HookEventType) bool {
 		// GitHub "schedule" event
 		// https://docs.github.com/en/actions/using-workflows/events-that-trigger-workflows#schedule
 		return true
+	case webhook_module.HookEventRepositoryDispatch:
+		// GitHub "repository_dispatch" event
+		// https://docs.github.com/en/actions/using-workflows/events-that-trigger-workflows#repository_dispatch
+		return true
 	case webhook_module.HookEventIssues,
 		webhook_module.HookEventIssueAssign,
 		webhook_module.HookEventIssueLabel,
@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ReusableWorkflowRef is the parsed `uses` of a job calling a reusable workflow
+type ReusableWorkflowRef struct {
+	// Local is true for `./.gitea/workflows/build.yml`, the workflow is read from the caller repository at the same commit
+	Local bool
+	Owner string
+	Repo  string
+	Path  string
+	Ref   string
+}
+
+// ParseReusableWorkflowRef parses the `uses` of a job, the supported forms are
+//
+//	./.gitea/workflows/build.yml
+//	owner/repo/.gitea/workflows/build.yml@ref
+//	https://gitea.example.com/owner/repo/.gitea/workflows/build.yml@ref
+//
+// It returns nil without error for workflows hosted on another instance, those are resolved by the runner.
+func ParseReusableWorkflowRef(uses string) (*ReusableWorkflowRef, error) {
+	if path, ok := strings.CutPrefix(uses, "./"); ok {
+		if !IsWorkflow(path) {
+			return nil, util.NewInvalidArgumentErrorf("%q is not a workflow file", uses)
+		}
+		return &ReusableWorkflowRef{Local: true, Path: path}, nil
+	}
+
+	if strings.HasPrefix(uses, "https://") || strings.HasPrefix(uses, "http://") {
+		u, err := url.Parse(uses)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid reusable workflow %q", uses)
+		}
+		appURL, err := url.Parse(setting.AppURL)
+		if err != nil || !strings.EqualFold(u.Host, appURL.Host) {
+			return nil, nil
+		}
+		uses = strings.TrimPrefix(strings.TrimPrefix(u.Path, "/"), strings.TrimPrefix(appURL.Path, "/"))
+	}
+
+	name, ref, ok := strings.Cut(uses, "@")
+	if !ok || ref == "" {
+		return nil, util.NewInvalidArgumentErrorf("reusable workflow %q must be referenced with @ref", uses)
+	}
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || !IsWorkflow(parts[2]) {
+		return nil, util.NewInvalidArgumentErrorf("invalid reusable workflow %q", uses)
+	}
+	return &ReusableWorkflowRef{Owner: parts[0], Repo: parts[1], Path: parts[2], Ref: ref}, nil
+}
+
+// ReusableWorkflow is a workflow which can be called by other workflows with `on: workflow_call`
+type ReusableWorkflow struct {
+	Inputs  map[string]model.WorkflowCallInput
+	Secrets map[string]ReusableWorkflowSecret
+}
+
+// ReusableWorkflowSecret is a secret declared in `on.workflow_call.secrets`
+type ReusableWorkflowSecret struct {
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+// ReadReusableWorkflow parses the content of a called workflow, it fails if the workflow isn't triggered by `workflow_call`
+func ReadReusableWorkflow(content []byte) (*ReusableWorkflow, error) {
+	wf, err := model.ReadWorkflow(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(wf.On(), GithubEventWorkflowCall) {
+		return nil, util.NewInvalidArgumentErrorf("the workflow is not triggered by %s", GithubEventWorkflowCall)
+	}
+
+	rw := &ReusableWorkflow{Inputs: wf.WorkflowCallConfig().Inputs}
+
+	// act doesn't parse the declared secrets, they are only available if `on` is a mapping
+	var declared struct {
+		On struct {
+			WorkflowCall struct {
+				Secrets map[string]ReusableWorkflowSecret `yaml:"secrets"`
+			} `yaml:"workflow_call"`
+		} `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(content, &declared); err == nil {
+		rw.Secrets = declared.On.WorkflowCall.Secrets
+	}
+	return rw, nil
+}
+
+// CheckCall validates the `with` inputs and `secrets` passed by a caller job.
+// `secrets: inherit` must be allowed by the caller, because it exposes all the secrets of the caller repository.
+func (rw *ReusableWorkflow) CheckCall(with map[string]any, secrets *yaml.Node, allowInherit bool) error {
+	for name := range with {
+		if _, ok := rw.Inputs[name]; !ok {
+			return util.NewInvalidArgumentErrorf("input %q is not defined by the called workflow", name)
+		}
+	}
+	for name, input := range rw.Inputs {
+		if _, ok := with[name]; !ok && input.Required && input.Default == "" {
+			return util.NewInvalidArgumentErrorf("required input %q is not provided", name)
+		}
+	}
+
+	passed := map[string]string{}
+	switch {
+	case secrets == nil || secrets.IsZero():
+	case secrets.Kind == yaml.ScalarNode && secrets.Value == "inherit":
+		if !allowInherit {
+			return util.NewInvalidArgumentErrorf("secrets can only be inherited by workflows of the same owner")
+		}
+		return nil
+	case secrets.Kind == yaml.MappingNode:
+		if err := secrets.Decode(&passed); err != nil {
+			return fmt.Errorf("invalid secrets: %w", err)
+		}
+	default:
+		return util.NewInvalidArgumentErrorf("secrets must be a mapping or inherit")
+	}
+
+	for name := range passed {
+		if _, ok := rw.Secrets[name]; !ok {
+			return util.NewInvalidArgumentErrorf("secret %q is not defined by the called workflow", name)
+		}
+	}
+	for name, secret := range rw.Secrets {
+		if _, ok := passed[name]; !ok && secret.Required {
+			return util.NewInvalidArgumentErrorf("required secret %q is not provided", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseReusableWorkflowRef(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/sub/")()
+
+	cases := []struct {
+		uses     string
+		expected *ReusableWorkflowRef
+		isErr    bool
+	}{
+		{uses: "./.gitea/workflows/build.yml", expected: &ReusableWorkflowRef{Local: true, Path: ".gitea/workflows/build.yml"}},
+		{uses: "org/ci/.github/workflows/test.yaml@v1", expected: &ReusableWorkflowRef{Owner: "org", Repo: "ci", Path: ".github/workflows/test.yaml", Ref: "v1"}},
+		{uses: "https://gitea.example.com/sub/org/ci/.gitea/workflows/test.yml@main", expected: &ReusableWorkflowRef{Owner: "org", Repo: "ci", Path: ".gitea/workflows/test.yml", Ref: "main"}},
+		{uses: "https://github.com/org/ci/.github/workflows/test.yml@main"},
+		{uses: "org/ci/.gitea/workflows/test.yml", isErr: true},
+		{uses: "org/ci/build.sh@main", isErr: true},
+		{uses: "./scripts/build.yml", isErr: true},
+	}
+	for _, c := range cases {
+		ref, err := ParseReusableWorkflowRef(c.uses)
+		if c.isErr {
+			assert.Error(t, err, c.uses)
+			continue
+		}
+		assert.NoError(t, err, c.uses)
+		assert.Equal(t, c.expected, ref, c.uses)
+	}
+}
+
+func TestReusableWorkflowCheckCall(t *testing.T) {
+	_, err := ReadReusableWorkflow([]byte("on: push\njobs:\n  a:\n    runs-on: ubuntu-latest\n"))
+	assert.Error(t, err)
+
+	rw, err := ReadReusableWorkflow([]byte(`on:
+  workflow_call:
+    inputs:
+      target:
+        type: string
+        required: true
+      verbose:
+        type: boolean
+        default: "false"
+    secrets:
+      deploy_key:
+        required: true
+      optional_token:
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo deploy
+`))
+	require.NoError(t, err)
+	assert.Len(t, rw.Inputs, 2)
+	assert.Len(t, rw.Secrets, 2)
+
+	secrets := func(s string) *yaml.Node {
+		var node yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(s), &node))
+		return node.Content[0]
+	}
+
+	assert.NoError(t, rw.CheckCall(map[string]any{"target": "prod"}, secrets("deploy_key: ${{ secrets.KEY }}"), false))
+	assert.NoError(t, rw.CheckCall(map[string]any{"target": "prod", "verbose": true}, secrets("inherit"), true))
+	assert.Error(t, rw.CheckCall(map[string]any{"target": "prod"}, secrets("inherit"), false))
+	assert.Error(t, rw.CheckCall(map[string]any{}, secrets("deploy_key: x"), false))
+	assert.Error(t, rw.CheckCall(map[string]any{"target": "prod", "unknown": 1}, secrets("deploy_key: x"), false))
+	assert.Error(t, rw.CheckCall(map[string]any{"target": "prod"}, nil, false))
+	assert.Error(t, rw.CheckCall(map[string]any{"target": "prod"}, secrets("{deploy_key: x, other: y}"), false))
+}
@@ -250,6 +250,10 @@ func detectMatched(gitRepo *git.Repository, commit *git.Commit, triggedEvent web
 		webhook_module.HookEventWorkflowRun:
 		return matchWorkflowRunEvent(payload.(*api.WorkflowRunPayload), evt)
 
+	case // repository_dispatch
+		webhook_module.HookEventRepositoryDispatch:
+		return matchRepositoryDispatchEvent(payload.(*api.RepositoryDispatchPayload), evt)
+
 	default:
 		log.Warn("unsupported event %q", triggedEvent)
 		return false
@@ -753,3 +757,27 @@ func matchWorkflowRunEvent(payload *api.WorkflowRunPayload, evt *jobparser.Event
 	}
 	return matchTimes == len(evt.Acts())
 }
+
+func matchRepositoryDispatchEvent(payload *api.RepositoryDispatchPayload, evt *jobparser.Event) bool {
+	// with no special filter parameters
+	if len(evt.Acts()) == 0 {
+		return true
+	}
+
+	matchTimes := 0
+	// all acts conditions should be satisfied
+	for cond, vals := range evt.Acts() {
+		switch cond {
+		case "types":
+			for _, val := range vals {
+				if val == payload.Action {
+					matchTimes++
+					break
+				}
+			}
+		default:
+			log.Warn("repository dispatch event unsupported condition %q", cond)
+		}
+	}
+	return matchTimes == len(evt.Acts())
+}
@@ -125,6 +125,27 @@ func TestDetectMatched(t *testing.T) {
 			yamlOn:       "on: schedule",
 			expected:     true,
 		},
+		{
+			desc:         "HookEventRepositoryDispatch(repository_dispatch) matches GithubEventRepositoryDispatch(repository_dispatch)",
+			triggedEvent: webhook_module.HookEventRepositoryDispatch,
+			payload:      &api.RepositoryDispatchPayload{Action: "deploy"},
+			yamlOn:       "on: repository_dispatch",
+			expected:     true,
+		},
+		{
+			desc:         "HookEventRepositoryDispatch(repository_dispatch) `deploy` type matches GithubEventRepositoryDispatch(repository_dispatch) with `deploy` type",
+			triggedEvent: webhook_module.HookEventRepositoryDispatch,
+			payload:      &api.RepositoryDispatchPayload{Action: "deploy"},
+			yamlOn:       "on:\n  repository_dispatch:\n    types: [build, deploy]",
+			expected:     true,
+		},
+		{
+			desc:         "HookEventRepositoryDispatch(repository_dispatch) `test` type doesn't match GithubEventRepositoryDispatch(repository_dispatch) with `deploy` type",
+			triggedEvent: webhook_module.HookEventRepositoryDispatch,
+			payload:      &api.RepositoryDispatchPayload{Action: "test"},
+			yamlOn:       "on:\n  repository_dispatch:\n    types: [deploy]",
+			expected:     false,
+		},
 		{
 			desc:         "push to tag matches workflow with paths condition (should skip paths check)",
 			triggedEvent: webhook_module.HookEventPush,
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/hostmatcher"
 	"code.gitea.io/gitea/modules/proxy"
 	"code.gitea.io/gitea/modules/setting"
 
@@ -50,6 +51,19 @@ func containsRequiredHTTPHeaders(method string, headers []string) error {
 	return nil
 }
 
+// AllowedHostMatcher returns the matcher of the hosts the remote actors and their inboxes may be on
+func AllowedHostMatcher() *hostmatcher.HostMatchList {
+	return hostmatcher.ParseHostMatchList("federation.ALLOWED_HOST_LIST", setting.Federation.AllowedHostList)
+}
+
+// NewTransport returns the transport of the requests to the remote actors, it only dials the allowed hosts
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy:       proxy.Proxy(),
+		DialContext: hostmatcher.NewDialContext("activitypub", AllowedHostMatcher(), nil, nil),
+	}
+}
+
 // Client struct
 type Client struct {
 	client      *http.Client
@@ -81,9 +95,7 @@ func NewClient(ctx context.Context, user *user_model.User, pubID string) (c *Cli
 
 	c = &Client{
 		client: &http.Client{
-			Transport: &http.Transport{
-				Proxy: proxy.Proxy(),
-			},
+			Transport: NewTransport(),
 		},
 		algs:        setting.HttpsigAlgs,
 		digestAlg:   httpsig.DigestAlgorithm(setting.Federation.DigestAlgorithm),
@@ -0,0 +1,233 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/nosql"
+	"code.gitea.io/gitea/modules/util"
+
+	mc "gitea.com/go-chi/cache" //nolint:depguard // we wrap this package here
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const layeredFlushKey = "*" // the invalidation message of a flush, "*" is not used by any key
+
+// LayeredCacher represents a cache adapter keeping the hot items of a redis cache in a small in-memory LRU cache.
+// The nodes sharing the redis cache tell each other to drop the local copies of the changed items by redis pub/sub,
+// the local copies also expire after a short time in case an invalidation message is lost.
+type LayeredCacher struct {
+	remote RedisCacher
+
+	lock       sync.Mutex
+	local      *lru.Cache[string, *MemoryItem]
+	localTTL   int64
+	generation uint64 // increased by each invalidation, to not keep the items read from redis before an invalidation
+
+	nodeID  string
+	channel string
+}
+
+var _ mc.Cache = &LayeredCacher{}
+
+func (c *LayeredCacher) getLocal(key string) (any, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	item, ok := c.local.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if item.hasExpired() {
+		c.local.Remove(key)
+		return nil, false
+	}
+	return item.Val, true
+}
+
+func (c *LayeredCacher) putLocal(key string, val any, expire int64, generation uint64) {
+	timeout := c.localTTL
+	if expire > 0 && expire < timeout {
+		timeout = expire
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.local.Add(key, &MemoryItem{Val: val, Created: time.Now().Unix(), Timeout: timeout})
+}
+
+func (c *LayeredCacher) invalidateLocal(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	if key == layeredFlushKey {
+		c.local.Purge()
+	} else {
+		c.local.Remove(key)
+	}
+}
+
+// invalidate drops the local copies of an item on all nodes
+func (c *LayeredCacher) invalidate(key string) {
+	c.invalidateLocal(key)
+	if err := c.remote.c.Publish(graceful.GetManager().HammerContext(), c.channel, c.nodeID+" "+key).Err(); err != nil {
+		log.Error("Unable to publish the invalidation of cache key %q: %v", key, err)
+	}
+}
+
+func (c *LayeredCacher) currentGeneration() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.generation
+}
+
+// Put puts value into cache with key and expire time.
+func (c *LayeredCacher) Put(key string, val any, expire int64) error {
+	if err := c.remote.Put(key, val, expire); err != nil {
+		return err
+	}
+	c.invalidate(key)
+	// redis only stores strings, keep the same type in the local cache
+	c.putLocal(key, toStr(val), expire, c.currentGeneration())
+	return nil
+}
+
+// Get gets cached value by given key.
+func (c *LayeredCacher) Get(key string) any {
+	if val, ok := c.getLocal(key); ok {
+		return val
+	}
+	generation := c.currentGeneration()
+	val := c.remote.Get(key)
+	if val != nil {
+		c.putLocal(key, val, 0, generation)
+	}
+	return val
+}
+
+// Delete deletes cached value by given key.
+func (c *LayeredCacher) Delete(key string) error {
+	err := c.remote.Delete(key)
+	c.invalidate(key)
+	return err
+}
+
+// Incr increases cached int-type value by given key as a counter.
+func (c *LayeredCacher) Incr(key string) error {
+	err := c.remote.Incr(key)
+	c.invalidate(key)
+	return err
+}
+
+// Decr decreases cached int-type value by given key as a counter.
+func (c *LayeredCacher) Decr(key string) error {
+	err := c.remote.Decr(key)
+	c.invalidate(key)
+	return err
+}
+
+// IsExist returns true if cached value exists.
+func (c *LayeredCacher) IsExist(key string) bool {
+	if _, ok := c.getLocal(key); ok {
+		return true
+	}
+	return c.remote.IsExist(key)
+}
+
+// Flush deletes all cached data.
+func (c *LayeredCacher) Flush() error {
+	err := c.remote.Flush()
+	c.invalidate(layeredFlushKey)
+	return err
+}
+
+// subscribe drops the local copies of the items changed by the other nodes
+func (c *LayeredCacher) subscribe(pubsub *redis.PubSub) {
+	defer pubsub.Close()
+	ch := pubsub.ChannelWithSubscriptions()
+	for {
+		select {
+		case <-graceful.GetManager().ShutdownContext().Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			switch msg := msg.(type) {
+			case *redis.Subscription:
+				// the invalidations published while the connection was lost are missed
+				c.invalidateLocal(layeredFlushKey)
+			case *redis.Message:
+				nodeID, key, _ := strings.Cut(msg.Payload, " ")
+				if nodeID != c.nodeID {
+					c.invalidateLocal(key)
+				}
+			}
+		}
+	}
+}
+
+// StartAndGC starts GC routine based on config string settings.
+// AdapterConfig: the redis connection string (see RedisCacher) with the options of the local cache,
+// local_size=10000 is the max number of items and local_ttl=60 is the max seconds to keep an item.
+func (c *LayeredCacher) StartAndGC(opts mc.Options) error {
+	if err := c.remote.StartAndGC(opts); err != nil {
+		return err
+	}
+
+	size, localTTL := 10000, int64(60)
+	channel := "gitea:cache:invalidate"
+	for k, v := range nosql.ToRedisURI(opts.AdapterConfig).Query() {
+		var err error
+		switch k {
+		case "local_size":
+			size, err = strconv.Atoi(v[0])
+		case "local_ttl":
+			localTTL, err = strconv.ParseInt(v[0], 10, 64)
+		case "invalidation_channel":
+			channel = v[0]
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if c.local, err = lru.New[string, *MemoryItem](size); err != nil {
+		return err
+	}
+	nodeID, err := util.CryptoRandomBytes(8)
+	if err != nil {
+		return err
+	}
+	c.localTTL = localTTL
+	c.channel = c.remote.prefix + channel
+	c.nodeID = hex.EncodeToString(nodeID)
+
+	pubsub := c.remote.c.Subscribe(graceful.GetManager().ShutdownContext(), c.channel)
+	if _, err = pubsub.Receive(graceful.GetManager().HammerContext()); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+	go c.subscribe(pubsub)
+	return nil
+}
+
+// Ping tests if the cache is alive.
+func (c *LayeredCacher) Ping() error {
+	return c.remote.Ping()
+}
+
+func init() {
+	mc.Register("layered", &LayeredCacher{})
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/nosql"
+
+	mc "gitea.com/go-chi/cache" //nolint:depguard // we wrap this package here
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayeredCacherLocal(t *testing.T) {
+	local, err := lru.New[string, *MemoryItem](2)
+	require.NoError(t, err)
+	c := &LayeredCacher{local: local, localTTL: 60}
+
+	c.putLocal("a", "1", 0, c.currentGeneration())
+	val, ok := c.getLocal("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", val)
+
+	// the items read before an invalidation are not kept
+	generation := c.currentGeneration()
+	c.invalidateLocal("b")
+	c.putLocal("b", "2", 0, generation)
+	_, ok = c.getLocal("b")
+	assert.False(t, ok)
+
+	c.invalidateLocal("a")
+	_, ok = c.getLocal("a")
+	assert.False(t, ok)
+
+	// the local copies expire after localTTL
+	c.local.Add("c", &MemoryItem{Val: "3", Created: time.Now().Unix() - 60, Timeout: c.localTTL})
+	_, ok = c.getLocal("c")
+	assert.False(t, ok)
+
+	c.putLocal("a", "1", 0, c.currentGeneration())
+	c.putLocal("b", "2", 0, c.currentGeneration())
+	c.invalidateLocal(layeredFlushKey)
+	assert.Zero(t, c.local.Len())
+}
+
+func TestLayeredCacher(t *testing.T) {
+	const conn = "redis://127.0.0.1:6379/0?prefix=layered_test:&local_ttl=60"
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	if nosql.GetManager().GetRedisClient(conn).Ping(ctx).Err() != nil {
+		t.Skip("redis-server not found")
+		return
+	}
+
+	// two nodes sharing the redis cache
+	node1, node2 := &LayeredCacher{}, &LayeredCacher{}
+	require.NoError(t, node1.StartAndGC(mc.Options{AdapterConfig: conn}))
+	require.NoError(t, node2.StartAndGC(mc.Options{AdapterConfig: conn}))
+	require.NoError(t, node1.Flush())
+
+	require.NoError(t, node1.Put("key", "v1", 60))
+	assert.Equal(t, "v1", node2.Get("key"))
+	_, ok := node2.getLocal("key")
+	assert.True(t, ok)
+
+	// the local copy of the other node is dropped when the item changes
+	require.NoError(t, node1.Put("key", "v2", 60))
+	assert.Eventually(t, func() bool {
+		_, ok := node2.getLocal("key")
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "v2", node2.Get("key"))
+
+	require.NoError(t, node2.Delete("key"))
+	assert.Eventually(t, func() bool {
+		return node1.Get("key") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, node1.IsExist("key"))
+}
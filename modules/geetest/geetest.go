@@ -0,0 +1,86 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package geetest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Response is the result of a solved Geetest v4 challenge as submitted by the client
+type Response struct {
+	LotNumber     string
+	CaptchaOutput string
+	PassToken     string
+	GenTime       string
+}
+
+// validateResponse is the structure of JSON returned from the validation API
+type validateResponse struct {
+	// Result is "success" or "fail" if the request could be validated
+	Result string `json:"result"`
+	Reason string `json:"reason"`
+	// Status is "error" if the request itself is invalid, e.g. the captcha ID is unknown
+	Status string `json:"status"`
+	Code   string `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+// SignToken signs the lot number with the captcha key as required by the validation API
+func SignToken(captchaKey, lotNumber string) string {
+	mac := hmac.New(sha256.New, []byte(captchaKey))
+	_, _ = mac.Write([]byte(lotNumber))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify calls the Geetest v4 validation API to verify the response
+func Verify(ctx context.Context, response Response) (bool, error) {
+	// Geetest v4 server side validation: https://docs.geetest.com/gt4/apirefer/api/server
+	post := url.Values{
+		"lot_number":     {response.LotNumber},
+		"captcha_output": {response.CaptchaOutput},
+		"pass_token":     {response.PassToken},
+		"gen_time":       {response.GenTime},
+		"sign_token":     {SignToken(setting.Service.GeetestCaptchaKey, response.LotNumber)},
+	}
+	apiURL := util.URLJoin(setting.Service.GeetestURL, "validate") + "?captcha_id=" + url.QueryEscape(setting.Service.GeetestCaptchaID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(post.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("Failed to create CAPTCHA request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("Failed to send CAPTCHA response: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("Failed to read CAPTCHA response: %w", err)
+	}
+
+	var jsonResponse validateResponse
+	if err := json.Unmarshal(body, &jsonResponse); err != nil {
+		return false, fmt.Errorf("Failed to parse CAPTCHA response: %w", err)
+	}
+
+	if jsonResponse.Status == "error" {
+		return false, fmt.Errorf("Geetest validation error %s: %s", jsonResponse.Code, jsonResponse.Msg)
+	} else if jsonResponse.Result != "success" {
+		return false, fmt.Errorf("Geetest validation failed: %s", jsonResponse.Reason)
+	}
+	return true, nil
+}
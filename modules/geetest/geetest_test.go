@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package geetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/validate", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		switch {
+		case r.URL.Query().Get("captcha_id") != "test-id":
+			_, _ = w.Write([]byte(`{"status":"error","code":"-50005","msg":"illegal captcha_id"}`))
+		case r.PostForm.Get("sign_token") != SignToken("test-key", r.PostForm.Get("lot_number")):
+			_, _ = w.Write([]byte(`{"status":"success","result":"fail","reason":"sign_token_error"}`))
+		case r.PostForm.Get("pass_token") != "valid":
+			_, _ = w.Write([]byte(`{"status":"success","result":"fail","reason":"pass_token expire"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"success","result":"success","reason":""}`))
+		}
+	}))
+	defer srv.Close()
+
+	defer test.MockVariableValue(&setting.Service.GeetestURL, srv.URL)()
+	defer test.MockVariableValue(&setting.Service.GeetestCaptchaID, "test-id")()
+	defer test.MockVariableValue(&setting.Service.GeetestCaptchaKey, "test-key")()
+
+	valid, err := Verify(t.Context(), Response{LotNumber: "lot", CaptchaOutput: "output", PassToken: "valid", GenTime: "1"})
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = Verify(t.Context(), Response{LotNumber: "lot", CaptchaOutput: "output", PassToken: "expired", GenTime: "1"})
+	assert.ErrorContains(t, err, "pass_token expire")
+	assert.False(t, valid)
+
+	setting.Service.GeetestCaptchaKey = "wrong-key"
+	valid, err = Verify(t.Context(), Response{LotNumber: "lot", CaptchaOutput: "output", PassToken: "valid", GenTime: "1"})
+	assert.ErrorContains(t, err, "sign_token_error")
+	assert.False(t, valid)
+
+	setting.Service.GeetestCaptchaID = "unknown"
+	valid, err = Verify(t.Context(), Response{LotNumber: "lot", CaptchaOutput: "output", PassToken: "valid", GenTime: "1"})
+	assert.ErrorContains(t, err, "illegal captcha_id")
+	assert.False(t, valid)
+}
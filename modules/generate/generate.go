@@ -5,6 +5,7 @@
 package generate
 
 import (
+	"crypto/ecdh"
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
@@ -72,3 +73,13 @@ func NewSecretKey() (string, error) {
 
 	return secretKey, nil
 }
+
+// NewVAPIDKeys generates a P-256 key pair intended to be used by the VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY of web push,
+// both base64 encoded: the public key as an uncompressed point and the private key as its scalar.
+func NewVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()), base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
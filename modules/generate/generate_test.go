@@ -32,3 +32,14 @@ func TestNewJwtSecretWithBase64(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, secret, decoded)
 }
+
+func TestNewVAPIDKeys(t *testing.T) {
+	publicKey, privateKey, err := NewVAPIDKeys()
+	assert.NoError(t, err)
+	decoded, err := base64.RawURLEncoding.DecodeString(publicKey)
+	assert.NoError(t, err)
+	assert.Len(t, decoded, 65)
+	decoded, err = base64.RawURLEncoding.DecodeString(privateKey)
+	assert.NoError(t, err)
+	assert.Len(t, decoded, 32)
+}
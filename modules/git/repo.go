@@ -129,6 +129,8 @@ type CloneRepoOptions struct {
 	Depth         int
 	Filter        string
 	SkipTLSVerify bool
+	// RefSpecs are the refspecs to fetch when cloning a mirror, empty means all the refs
+	RefSpecs []string
 }
 
 // Clone clones original repository to target path.
@@ -138,6 +140,10 @@ func Clone(ctx context.Context, from, to string, opts CloneRepoOptions) error {
 		return err
 	}
 
+	if opts.Mirror && len(opts.RefSpecs) > 0 {
+		return cloneMirrorRefSpecs(ctx, from, to, opts)
+	}
+
 	cmd := gitcmd.NewCommand().AddArguments("clone")
 	if opts.SkipTLSVerify {
 		cmd.AddArguments("-c", "http.sslVerify=false")
@@ -190,6 +196,79 @@ func Clone(ctx context.Context, from, to string, opts CloneRepoOptions) error {
 	return nil
 }
 
+// cloneMirrorRefSpecs creates a mirror like "git clone --mirror" but only fetches the refs matching the refspecs
+func cloneMirrorRefSpecs(ctx context.Context, from, to string, opts CloneRepoOptions) error {
+	if opts.Timeout <= 0 {
+		opts.Timeout = -1
+	}
+	envs := os.Environ()
+	if u, err := url.Parse(from); err == nil {
+		envs = proxy.EnvWithProxy(u)
+	}
+	newCommand := func(args ...string) *gitcmd.Command {
+		cmd := gitcmd.NewCommand()
+		if opts.SkipTLSVerify {
+			cmd.AddArguments("-c", "http.sslVerify=false")
+		}
+		return cmd.AddDynamicArguments(args...)
+	}
+
+	// the object format and the HEAD of the new repository should be the same as the remote
+	stdout, stderr, err := newCommand().AddArguments("ls-remote", "--symref").AddDashesAndList(from, "HEAD").
+		RunStdString(ctx, &gitcmd.RunOpts{Timeout: opts.Timeout, Env: envs})
+	if err != nil {
+		return gitcmd.ConcatenateError(err, stderr)
+	}
+	objectFormat, headRef := Sha1ObjectFormat, ""
+	for line := range strings.SplitSeq(stdout, "\n") {
+		if symRef, ok := strings.CutPrefix(line, "ref: "); ok {
+			headRef, _, _ = strings.Cut(symRef, "\t")
+		} else if id, _, ok := strings.Cut(line, "\t"); ok && len(id) == Sha256ObjectFormat.FullLength() {
+			objectFormat = Sha256ObjectFormat
+		}
+	}
+
+	if err := InitRepository(ctx, to, true, objectFormat.Name()); err != nil {
+		return err
+	}
+	// keep the same remote config as "git clone --mirror", the refspecs are only used by this fetch
+	for _, kv := range [][2]string{
+		{"remote.origin.url", from},
+		{"remote.origin.fetch", "+refs/*:refs/*"},
+		{"remote.origin.mirror", "true"},
+	} {
+		if _, stderr, err := gitcmd.NewCommand("config").AddDynamicArguments(kv[0], kv[1]).RunStdString(ctx, &gitcmd.RunOpts{Dir: to}); err != nil {
+			return gitcmd.ConcatenateError(err, stderr)
+		}
+	}
+
+	cmd := newCommand().AddArguments("fetch", "--no-tags")
+	if opts.Quiet {
+		cmd.AddArguments("--quiet")
+	}
+	errBuf := new(bytes.Buffer)
+	if err := cmd.AddDynamicArguments("origin").AddDynamicArguments(opts.RefSpecs...).Run(ctx, &gitcmd.RunOpts{
+		Timeout: opts.Timeout,
+		Dir:     to,
+		Env:     envs,
+		Stdout:  io.Discard,
+		Stderr:  errBuf,
+	}); err != nil {
+		return gitcmd.ConcatenateError(err, errBuf.String())
+	}
+
+	// keep the default HEAD if the HEAD of the remote isn't mirrored
+	if headRef == "" {
+		return nil
+	}
+	if _, _, err := gitcmd.NewCommand("show-ref", "--verify", "--quiet").AddDynamicArguments(headRef).RunStdString(ctx, &gitcmd.RunOpts{Dir: to}); err == nil {
+		if _, stderr, err := gitcmd.NewCommand("symbolic-ref", "HEAD").AddDynamicArguments(headRef).RunStdString(ctx, &gitcmd.RunOpts{Dir: to}); err != nil {
+			return gitcmd.ConcatenateError(err, stderr)
+		}
+	}
+	return nil
+}
+
 // PushOptions options when push to remote
 type PushOptions struct {
 	Remote  string
@@ -198,6 +277,9 @@ type PushOptions struct {
 	Mirror  bool
 	Env     []string
 	Timeout time.Duration
+	// Prune deletes the remote refs matching the refspecs which don't exist locally
+	Prune    bool
+	RefSpecs []string
 }
 
 // Push pushs local commits to given remote branch.
@@ -209,10 +291,18 @@ func Push(ctx context.Context, repoPath string, opts PushOptions) error {
 	if opts.Mirror {
 		cmd.AddArguments("--mirror")
 	}
+	if opts.Prune {
+		cmd.AddArguments("--prune")
+	}
+	if !opts.Mirror && len(opts.RefSpecs) > 0 {
+		// a remote added with "--mirror=push" would otherwise ignore the refspecs
+		cmd.AddConfig("remote."+opts.Remote+".mirror", "false")
+	}
 	remoteBranchArgs := []string{opts.Remote}
 	if len(opts.Branch) > 0 {
 		remoteBranchArgs = append(remoteBranchArgs, opts.Branch)
 	}
+	remoteBranchArgs = append(remoteBranchArgs, opts.RefSpecs...)
 	cmd.AddDashesAndList(remoteBranchArgs...)
 
 	stdout, stderr, err := cmd.RunStdString(ctx, &gitcmd.RunOpts{Env: opts.Env, Timeout: opts.Timeout, Dir: repoPath})
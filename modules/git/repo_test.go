@@ -5,9 +5,13 @@ package git
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"code.gitea.io/gitea/modules/git/gitcmd"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetLatestCommitTime(t *testing.T) {
@@ -29,3 +33,28 @@ func TestRepoIsEmpty(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, isEmpty)
 }
+
+func TestCloneMirrorRefSpecs(t *testing.T) {
+	bareRepo1Path, err := filepath.Abs(filepath.Join(testReposDir, "repo1_bare"))
+	require.NoError(t, err)
+	mirrorPath := filepath.Join(t.TempDir(), "mirror.git")
+	require.NoError(t, Clone(t.Context(), bareRepo1Path, mirrorPath, CloneRepoOptions{
+		Mirror:   true,
+		RefSpecs: []string{"+refs/heads/branch*:refs/heads/branch*"},
+	}))
+
+	repo, err := OpenRepository(t.Context(), mirrorPath)
+	require.NoError(t, err)
+	defer repo.Close()
+	branches, _, err := repo.GetBranchNames(0, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"branch1", "branch2"}, branches)
+	tags, _, err := gitcmd.NewCommand("for-each-ref", "refs/tags/").RunStdString(t.Context(), &gitcmd.RunOpts{Dir: mirrorPath})
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	// the following syncs of the mirror use the refspecs of the mirror filters
+	stdout, _, err := gitcmd.NewCommand("config", "remote.origin.fetch").RunStdString(t.Context(), &gitcmd.RunOpts{Dir: mirrorPath})
+	require.NoError(t, err)
+	assert.Equal(t, "+refs/*:refs/*", strings.TrimSpace(stdout))
+}
@@ -5,6 +5,8 @@ package git
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"sort"
 
 	"code.gitea.io/gitea/modules/util"
@@ -45,8 +47,9 @@ func parsePayloadSignature(data []byte, messageStart int) (payload, msg, sign st
 	}
 
 	if signStart != -1 && signEnd != -1 {
+		// the payload is signed including the line break which ends the message
 		msgEnd := max(messageStart, signStart-1)
-		return string(data[:msgEnd]), string(data[messageStart:msgEnd]), string(data[signStart:signEnd])
+		return string(data[:signStart]), string(data[messageStart:msgEnd]), string(data[signStart:signEnd])
 	}
 	return string(data), string(data[messageStart:]), ""
 }
@@ -94,6 +97,21 @@ func parseTagData(objectFormat ObjectFormat, data []byte) (*Tag, error) {
 	return tag, nil
 }
 
+// TagFromReader will generate an annotated Tag from a provided reader
+// We need this to interpret tags from cat-file
+func TagFromReader(objectID ObjectID, reader io.Reader) (*Tag, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tag %q: %w", objectID.String(), err)
+	}
+	tag, err := parseTagData(objectID.Type(), data)
+	if err != nil {
+		return nil, err
+	}
+	tag.ID = objectID
+	return tag, nil
+}
+
 type tagSorter []*Tag
 
 func (ts tagSorter) Len() int {
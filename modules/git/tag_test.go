@@ -79,7 +79,8 @@ type commit
 tag v0
 tagger dummy user <dummy-email@example.com> 1484491741 +0100
 
-dummy message`,
+dummy message
+`,
 				},
 			},
 		},
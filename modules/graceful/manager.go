@@ -68,6 +68,13 @@ func initManager(ctx context.Context) {
 	})
 }
 
+// SetConfigReloader sets the function reloading the settings when SIGHUP is received and RELOAD_CONFIG_ON_SIGHUP is enabled
+func (g *Manager) SetConfigReloader(reloader func(ctx context.Context)) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.configReloader = reloader
+}
+
 // RunWithCancel helps to run a function with a custom context, the Cancel function will be called at shutdown
 // The Cancel function should stop the Run function in predictable time.
 func (g *Manager) RunWithCancel(rc RunCanceler) {
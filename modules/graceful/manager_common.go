@@ -51,6 +51,7 @@ type Manager struct {
 
 	toRunAtShutdown  []func()
 	toRunAtTerminate []func()
+	configReloader   func(ctx context.Context)
 }
 
 func newGracefulManager(ctx context.Context) *Manager {
@@ -126,6 +126,14 @@ func (g *Manager) handleSignals(ctx context.Context) {
 		case sig := <-signalChannel:
 			switch sig {
 			case syscall.SIGHUP:
+				g.lock.RLock()
+				reloader := g.configReloader
+				g.lock.RUnlock()
+				if setting.ReloadConfigOnSIGHUP && reloader != nil {
+					log.Info("PID: %d. Received SIGHUP. Reloading config...", pid)
+					go reloader(g.ShutdownContext())
+					break
+				}
 				log.Info("PID: %d. Received SIGHUP. Attempting GracefulRestart...", pid)
 				g.DoGracefulRestart()
 			case syscall.SIGUSR1:
@@ -4,6 +4,8 @@
 package httpcache
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -79,6 +81,7 @@ func HandleGenericETagCache(req *http.Request, w http.ResponseWriter, etag strin
 func checkIfNoneMatchIsValid(req *http.Request, etag string) bool {
 	ifNoneMatch := req.Header.Get("If-None-Match")
 	if len(ifNoneMatch) > 0 {
+		etag = strings.TrimPrefix(etag, "W/") // If-None-Match uses the weak comparison
 		for item := range strings.SplitSeq(ifNoneMatch, ",") {
 			item = strings.TrimPrefix(strings.TrimSpace(item), "W/") // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag#directives
 			if item == etag {
@@ -121,3 +124,40 @@ func HandleGenericETagTimeCache(req *http.Request, w http.ResponseWriter, etag s
 	SetCacheControlInHeader(w.Header(), CacheControlForPrivateStatic())
 	return false
 }
+
+// GenerateWeakETag returns a weak ETag of the values a response is generated from,
+// it changes if any value changes but the responses with the same ETag aren't guaranteed to be byte-for-byte identical.
+func GenerateWeakETag(values ...any) string {
+	h := sha256.New()
+	for _, v := range values {
+		_, _ = fmt.Fprintf(h, "%v\x00", v)
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// HandleConditionalRequest handles the ETag and Last-Modified validators of a dynamic resource like an API response,
+// which the clients have to revalidate on every request. The lastModified can be zero if the resource has no update time.
+// It returns true if the request was handled.
+func HandleConditionalRequest(req *http.Request, w http.ResponseWriter, etag string, lastModified time.Time) (handled bool) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	w.Header().Set("Etag", etag)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	SetCacheControlInHeader(w.Header(), &CacheControlOptions{MaxAge: 0})
+
+	// If-Modified-Since must be ignored if the request has If-None-Match, refer to RFC 9110 section 13.1.3
+	if req.Header.Get("If-None-Match") != "" {
+		handled = checkIfNoneMatchIsValid(req, etag)
+	} else if ifModifiedSince := req.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ifModifiedSince)
+		handled = err == nil && lastModified.Unix() <= t.Unix()
+	}
+	if handled {
+		w.WriteHeader(http.StatusNotModified)
+	}
+	return handled
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -95,3 +96,64 @@ func TestHandleGenericETagCache(t *testing.T) {
 		assert.Equal(t, http.StatusNotModified, w.Code)
 	})
 }
+
+func TestGenerateWeakETag(t *testing.T) {
+	etag := GenerateWeakETag("repo", 1, int64(1700000000))
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Equal(t, etag, GenerateWeakETag("repo", 1, int64(1700000000)))
+	assert.NotEqual(t, etag, GenerateWeakETag("repo", 1, int64(1700000001)))
+	// the values are separated, so they can't be shifted
+	assert.NotEqual(t, GenerateWeakETag("ab", "c"), GenerateWeakETag("a", "bc"))
+}
+
+func TestHandleConditionalRequest(t *testing.T) {
+	etag := GenerateWeakETag("test")
+	lastModified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	handle := func(method string, headers map[string]string) (*httptest.ResponseRecorder, bool) {
+		req := httptest.NewRequest(method, "/", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		return w, HandleConditionalRequest(req, w, etag, lastModified)
+	}
+
+	t.Run("Unconditional", func(t *testing.T) {
+		w, handled := handle("GET", nil)
+		assert.False(t, handled)
+		assert.Equal(t, etag, w.Header().Get("Etag"))
+		assert.Equal(t, "Fri, 02 Jan 2026 03:04:05 GMT", w.Header().Get("Last-Modified"))
+		assert.Contains(t, w.Header().Get("Cache-Control"), "max-age=0")
+	})
+	t.Run("If-None-Match", func(t *testing.T) {
+		w, handled := handle("GET", map[string]string{"If-None-Match": etag})
+		assert.True(t, handled)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		_, handled = handle("GET", map[string]string{"If-None-Match": `"other", ` + strings.TrimPrefix(etag, "W/")})
+		assert.True(t, handled)
+
+		_, handled = handle("GET", map[string]string{"If-None-Match": `"other"`})
+		assert.False(t, handled)
+
+		// If-Modified-Since is ignored if the request has If-None-Match
+		_, handled = handle("GET", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified.Format(http.TimeFormat)})
+		assert.False(t, handled)
+	})
+	t.Run("If-Modified-Since", func(t *testing.T) {
+		w, handled := handle("GET", map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)})
+		assert.True(t, handled)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		_, handled = handle("GET", map[string]string{"If-Modified-Since": lastModified.Add(-time.Second).Format(http.TimeFormat)})
+		assert.False(t, handled)
+
+		_, handled = handle("GET", map[string]string{"If-Modified-Since": "invalid"})
+		assert.False(t, handled)
+	})
+	t.Run("Unsafe method", func(t *testing.T) {
+		_, handled := handle("PATCH", map[string]string{"If-None-Match": etag})
+		assert.False(t, handled)
+	})
+}
@@ -108,7 +108,7 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 		"type":          analyzer_custom.Name,
 		"char_filters":  []string{},
 		"tokenizer":     letter.Name,
-		"token_filters": []string{unicodeNormalizeName, lowercase.Name},
+		"token_filters": append([]string{unicodeNormalizeName, lowercase.Name}, inner_bleve.CJKTokenFilters()...),
 	}); err != nil {
 		return nil, err
 	}
@@ -5,6 +5,8 @@ package code
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"runtime/pprof"
 	"slices"
@@ -18,6 +20,8 @@ import (
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
 	"code.gitea.io/gitea/modules/indexer/code/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/meilisearch"
+	"code.gitea.io/gitea/modules/indexer/code/zoekt"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
@@ -116,7 +120,7 @@ func Init() {
 
 	// Create the Queue
 	switch setting.Indexer.RepoType {
-	case "bleve", "elasticsearch":
+	case "bleve", "elasticsearch", "meilisearch", "zoekt":
 		handler := func(items ...*internal.IndexerData) (unhandled []*internal.IndexerData) {
 			indexer := *globalIndexer.Load()
 			for _, indexerData := range items {
@@ -125,6 +129,9 @@ func Init() {
 					if !setting.IsInTesting {
 						log.Error("Codes indexer handler: index error for repo %v: %v", indexerData.RepoID, err)
 					}
+					if err := repo_model.UpdateIndexerFailure(ctx, indexerData.RepoID, repo_model.RepoIndexerTypeCode, err); err != nil {
+						log.Error("Codes indexer handler: unable to record the failure of repo %v: %v", indexerData.RepoID, err)
+					}
 				}
 			}
 			return nil // do not re-queue the failed items, otherwise some broken repo will block the queue
@@ -183,6 +190,35 @@ func Init() {
 				close(waitChannel)
 				log.Fatal("PID: %d Unable to initialize the elasticsearch Repository Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.RepoConnStr, err)
 			}
+		case "meilisearch":
+			log.Info("PID: %d Initializing Repository Indexer at: %s", os.Getpid(), setting.Indexer.RepoConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing repository indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.RepoIndexerName)
+				}
+			}()
+
+			rIndexer = meilisearch.NewIndexer(setting.Indexer.RepoConnStr, setting.Indexer.RepoConnAuth, setting.Indexer.RepoIndexerName)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the meilisearch Repository Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.RepoConnStr, err)
+			}
+
+		case "zoekt":
+			log.Info("PID: %d Initializing Repository Indexer at: %s", os.Getpid(), setting.Indexer.RepoConnStr)
+			rIndexer = zoekt.NewIndexer(setting.Indexer.RepoConnStr, setting.Indexer.RepoPath, setting.Indexer.RepoZoektGitIndex)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the zoekt Repository Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.RepoConnStr, err)
+			}
 
 		default:
 			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.RepoType)
@@ -241,6 +277,23 @@ func UpdateRepoIndexer(repo *repo_model.Repository) {
 	}
 }
 
+// ReindexRepository removes the repository from the index and queues it to be indexed from scratch
+func ReindexRepository(ctx context.Context, repo *repo_model.Repository) error {
+	if indexerQueue == nil {
+		return errors.New("the repository indexer is not enabled")
+	}
+	if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		return err
+	}
+	if err := repo_model.DeleteIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode); err != nil {
+		return err
+	}
+	if err := indexerQueue.Push(&internal.IndexerData{RepoID: repo.ID}); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		return err
+	}
+	return nil
+}
+
 // IsAvailable checks if issue indexer is available
 func IsAvailable(ctx context.Context) bool {
 	return (*globalIndexer.Load()).Ping(ctx) == nil
@@ -249,25 +302,33 @@ func IsAvailable(ctx context.Context) bool {
 // populateRepoIndexer populate the repo indexer with pre-existing data. This
 // should only be run when the indexer is created for the first time.
 func populateRepoIndexer(ctx context.Context) {
+	if err := PopulateRepoIndexer(ctx); err != nil {
+		log.Error("Repository indexer population failed: %v", err)
+	}
+}
+
+// PopulateRepoIndexer queues all the repositories to be (re)indexed, e.g. after changing the indexer type
+func PopulateRepoIndexer(ctx context.Context) error {
+	if indexerQueue == nil {
+		return errors.New("the repository indexer is not enabled")
+	}
 	log.Info("Populating the repo indexer with existing repositories")
 
 	exist, err := db.IsTableNotEmpty("repository")
 	if err != nil {
-		log.Fatal("System error: %v", err)
+		return err
 	} else if !exist {
-		return
+		return nil
 	}
 
-	// if there is any existing repo indexer metadata in the DB, delete it
-	// since we are starting afresh. Also, xorm requires deletes to have a
-	// condition, and we want to delete everything, thus 1=1.
-	if err := db.DeleteAllRecords("repo_indexer_status"); err != nil {
-		log.Fatal("System error: %v", err)
+	// the statuses of the repositories are for a previous index, so they are reset
+	if _, err := db.GetEngine(ctx).Where("indexer_type = ?", repo_model.RepoIndexerTypeCode).Delete(new(repo_model.RepoIndexerStatus)); err != nil {
+		return err
 	}
 
 	var maxRepoID int64
 	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
-		log.Fatal("System error: %v", err)
+		return err
 	}
 
 	// start with the maximum existing repo ID and work backwards, so that we
@@ -276,32 +337,24 @@ func populateRepoIndexer(ctx context.Context) {
 	for maxRepoID > 0 {
 		select {
 		case <-ctx.Done():
-			log.Info("Repository Indexer population shutdown before completion")
-			return
+			return fmt.Errorf("shutdown before completion: %w", ctx.Err())
 		default:
 		}
 		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeCode, maxRepoID, 0, 50)
 		if err != nil {
-			log.Error("populateRepoIndexer: %v", err)
-			return
+			return err
 		} else if len(ids) == 0 {
 			break
 		}
 		for _, id := range ids {
-			select {
-			case <-ctx.Done():
-				log.Info("Repository Indexer population shutdown before completion")
-				return
-			default:
-			}
 			if err := indexerQueue.Push(&internal.IndexerData{RepoID: id}); err != nil {
-				log.Error("indexerQueue.Push: %v", err)
-				return
+				return err
 			}
 			maxRepoID = id - 1
 		}
 	}
 	log.Info("Done (re)populating the repo indexer with existing repositories")
+	return nil
 }
 
 func SupportedSearchModes() []indexer.SearchMode {
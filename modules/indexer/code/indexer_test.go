@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
 	"code.gitea.io/gitea/modules/indexer/code/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/meilisearch"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/modules/util"
@@ -296,6 +297,21 @@ func TestBleveIndexAndSearch(t *testing.T) {
 	testIndexer("bleve", t, idx)
 }
 
+func TestBleveCJKBigramIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	defer test.MockVariableValue(&setting.Indexer.TypeBleveMaxFuzzniess, 2)()
+	// the segmentation of CJK texts must not change the results of the other texts
+	defer test.MockVariableValue(&setting.Indexer.TypeBleveCJKAnalyzer, "bigram")()
+
+	idx := bleve.NewIndexer(t.TempDir())
+	defer idx.Close()
+
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	testIndexer("bleve_cjk_bigram", t, idx)
+}
+
 func TestESIndexAndSearch(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 
@@ -318,6 +334,28 @@ func TestESIndexAndSearch(t *testing.T) {
 	testIndexer("elastic_search", t, indexer)
 }
 
+func TestMeilisearchIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	u := os.Getenv("TEST_MEILISEARCH_URL")
+	if u == "" {
+		t.SkipNow()
+		return
+	}
+
+	indexer := meilisearch.NewIndexer(u, os.Getenv("TEST_MEILISEARCH_KEY"), "gitea_codes")
+	if _, err := indexer.Init(t.Context()); err != nil {
+		if indexer != nil {
+			indexer.Close()
+		}
+		require.NoError(t, err, "Unable to init meilisearch indexer")
+	}
+
+	defer indexer.Close()
+
+	testIndexer("meilisearch", t, indexer)
+}
+
 func setupRepositoryIndexes(ctx context.Context, indexer internal.Indexer) error {
 	for _, repoID := range repositoriesToSearch() {
 		if err := index(ctx, indexer, repoID); err != nil {
@@ -0,0 +1,350 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/analyze"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_meilisearch "code.gitea.io/gitea/modules/indexer/internal/meilisearch"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/typesniffer"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	meilisearchRepoIndexerLatestVersion = 1
+
+	// TODO: make this configurable if necessary
+	maxTotalHits = 10000
+	batchSize    = 50
+	maxLanguages = 10
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_meilisearch.Indexer
+	indexer_internal.Indexer // do not composite inner_meilisearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new meilisearch indexer
+func NewIndexer(url, apiKey, indexerName string) *Indexer {
+	settings := &meilisearch.Settings{
+		// the file names are listed first, so the matches of the file names are ranked higher
+		SearchableAttributes: []string{
+			"filename",
+			"content",
+		},
+		FilterableAttributes: []string{
+			"repo_id",
+			"language",
+		},
+		SortableAttributes: []string{
+			"updated_at",
+		},
+		Pagination: &meilisearch.Pagination{
+			MaxTotalHits: maxTotalHits,
+		},
+	}
+
+	inner := inner_meilisearch.NewIndexer(url, apiKey, indexerName, meilisearchRepoIndexerLatestVersion, settings)
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+}
+
+// document is a file stored in the index
+type document struct {
+	ID        string `json:"id"`
+	RepoID    int64  `json:"repo_id"`
+	Filename  string `json:"filename"`
+	Content   string `json:"content"`
+	CommitID  string `json:"commit_id"`
+	Language  string `json:"language"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// documentID returns the id of the document of a file, the ids of meilisearch can only contain
+// alphanumeric characters, hyphens and underscores, so the file name is hashed
+func documentID(repoID int64, filename string) string {
+	sum := sha256.Sum256([]byte(filename))
+	return indexer_internal.Base36(repoID) + "_" + hex.EncodeToString(sum[:])
+}
+
+func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserError, batchReader *bufio.Reader, sha string, update internal.FileUpdate, repo *repo_model.Repository) (*document, bool, error) {
+	// Ignore vendored files in code search
+	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
+		return nil, false, nil
+	}
+
+	size := update.Size
+	var err error
+	if !update.Sized {
+		var stdout string
+		stdout, _, err = gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha).RunStdString(ctx, &gitcmd.RunOpts{Dir: repo.RepoPath()})
+		if err != nil {
+			return nil, false, err
+		}
+		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
+			return nil, false, fmt.Errorf("misformatted git cat-file output: %w", err)
+		}
+	}
+
+	if size > setting.Indexer.MaxIndexerFileSize {
+		return nil, true, nil
+	}
+
+	if _, err := batchWriter.Write([]byte(update.BlobSha + "\n")); err != nil {
+		return nil, false, err
+	}
+
+	_, _, size, err = git.ReadBatchLine(batchReader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fileContents, err := io.ReadAll(io.LimitReader(batchReader, size))
+	if err != nil {
+		return nil, false, err
+	} else if !typesniffer.DetectContentType(fileContents).IsText() {
+		// FIXME: UTF-16 files will probably fail here
+		return nil, false, nil
+	}
+
+	if _, err = batchReader.Discard(1); err != nil {
+		return nil, false, err
+	}
+
+	return &document{
+		ID:        documentID(repo.ID, update.Filename),
+		RepoID:    repo.ID,
+		Filename:  update.Filename,
+		Content:   string(charset.ToUTF8DropErrors(fileContents, charset.ConvertOpts{})),
+		CommitID:  sha,
+		Language:  analyze.GetCodeLanguage(update.Filename, fileContents),
+		UpdatedAt: int64(timeutil.TimeStampNow()),
+	}, false, nil
+}
+
+// Index will save the index data
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+	docs := make([]*document, 0, len(changes.Updates))
+	deletes := make([]string, 0, len(changes.RemovedFilenames))
+	if len(changes.Updates) > 0 {
+		batch, err := git.NewBatch(ctx, repo.RepoPath())
+		if err != nil {
+			return err
+		}
+		defer batch.Close()
+
+		for _, update := range changes.Updates {
+			doc, oversized, err := b.addUpdate(ctx, batch.Writer, batch.Reader, sha, update, repo)
+			if err != nil {
+				return err
+			}
+			if oversized {
+				deletes = append(deletes, documentID(repo.ID, update.Filename))
+			} else if doc != nil {
+				docs = append(docs, doc)
+			}
+		}
+		batch.Close()
+	}
+
+	for _, filename := range changes.RemovedFilenames {
+		deletes = append(deletes, documentID(repo.ID, filename))
+	}
+
+	index := b.inner.Client.Index(b.inner.VersionedIndexName())
+	for i := 0; i < len(docs); i += batchSize {
+		task, err := index.AddDocumentsWithContext(ctx, docs[i:min(i+batchSize, len(docs))], nil)
+		if err != nil {
+			return err
+		}
+		if err := b.inner.WaitForTask(ctx, task); err != nil {
+			return err
+		}
+	}
+	if len(deletes) > 0 {
+		task, err := index.DeleteDocumentsWithContext(ctx, deletes)
+		if err != nil {
+			return err
+		}
+		return b.inner.WaitForTask(ctx, task)
+	}
+	return nil
+}
+
+// Delete entries by repoId
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	task, err := b.inner.Client.Index(b.inner.VersionedIndexName()).
+		DeleteDocumentsByFilterWithContext(ctx, inner_meilisearch.NewFilterEq("repo_id", repoID).Statement())
+	if err != nil {
+		return err
+	}
+	return b.inner.WaitForTask(ctx, task)
+}
+
+// matchPosition is the position of a keyword in a field, in bytes
+type matchPosition struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+type hit struct {
+	document
+	MatchesPosition map[string][]matchPosition `json:"_matchesPosition"`
+}
+
+func convertHits(searchRes *meilisearch.SearchResponse) ([]*internal.SearchResult, error) {
+	var docs []*hit
+	if err := searchRes.Hits.Decode(&docs); err != nil {
+		return nil, err
+	}
+
+	hits := make([]*internal.SearchResult, 0, len(docs))
+	for _, doc := range docs {
+		var startIndex, endIndex int
+		if pos := doc.MatchesPosition["content"]; len(pos) > 0 && pos[0].Start+pos[0].Length <= len(doc.Content) {
+			startIndex, endIndex = pos[0].Start, pos[0].Start+pos[0].Length
+		} else {
+			// only the file name matches
+			startIndex, endIndex = internal.FilenameMatchIndexPos(doc.Content)
+		}
+		hits = append(hits, &internal.SearchResult{
+			RepoID:      doc.RepoID,
+			Filename:    doc.Filename,
+			CommitID:    doc.CommitID,
+			Content:     doc.Content,
+			UpdatedUnix: timeutil.TimeStamp(doc.UpdatedAt),
+			Language:    doc.Language,
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+			Color:       enry.GetColor(doc.Language),
+		})
+	}
+	return hits, nil
+}
+
+// convertFacets returns the most used languages of the results
+func convertFacets(searchRes *meilisearch.SearchResponse) ([]*internal.SearchResultLanguages, error) {
+	if len(searchRes.FacetDistribution) == 0 {
+		return nil, nil
+	}
+	var facets map[string]map[string]int
+	if err := json.Unmarshal(searchRes.FacetDistribution, &facets); err != nil {
+		return nil, err
+	}
+
+	languages := make([]*internal.SearchResultLanguages, 0, len(facets["language"]))
+	for language, count := range facets["language"] {
+		languages = append(languages, &internal.SearchResultLanguages{
+			Language: language,
+			Color:    enry.GetColor(language),
+			Count:    count,
+		})
+	}
+	slices.SortFunc(languages, func(a, b *internal.SearchResultLanguages) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return languages[:min(len(languages), maxLanguages)], nil
+}
+
+// doubleQuoteKeyword makes meilisearch search the keyword as a phrase
+// See https://www.meilisearch.com/docs/reference/api/search#phrase-search
+func doubleQuoteKeyword(k string) string {
+	return `"` + strings.ReplaceAll(k, `"`, "") + `"`
+}
+
+// Search searches for codes and language stats by given conditions.
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+	keyword := opts.Keyword
+	if util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue) == indexer.SearchModeExact {
+		keyword = doubleQuoteKeyword(keyword)
+	}
+
+	query := inner_meilisearch.FilterAnd{}
+	if len(opts.RepoIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("repo_id", opts.RepoIDs...))
+	}
+
+	index := b.inner.Client.Index(b.inner.VersionedIndexName())
+	start, pageSize := opts.GetSkipTake()
+	req := &meilisearch.SearchRequest{
+		Filter:              query.Statement(),
+		Limit:               int64(pageSize),
+		Offset:              int64(start),
+		Facets:              []string{"language"},
+		ShowMatchesPosition: true,
+		MatchingStrategy:    "all",
+	}
+
+	if opts.Language == "" {
+		searchRes, err := index.SearchWithContext(ctx, keyword, req)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		hits, err := convertHits(searchRes)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		languages, err := convertFacets(searchRes)
+		return searchRes.EstimatedTotalHits, hits, languages, err
+	}
+
+	// the languages are counted without the language filter, so the other languages can be chosen
+	countRes, err := index.SearchWithContext(ctx, keyword, &meilisearch.SearchRequest{
+		Filter:           req.Filter,
+		Limit:            1, // 0 means the default limit, so 1 is used to make the cost as low as possible
+		Facets:           req.Facets,
+		MatchingStrategy: req.MatchingStrategy,
+	})
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	languages, err := convertFacets(countRes)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	query.And(inner_meilisearch.NewFilterEqString("language", opts.Language))
+	req.Filter = query.Statement()
+	req.Facets = nil
+	searchRes, err := index.SearchWithContext(ctx, keyword, req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	hits, err := convertHits(searchRes)
+	return searchRes.EstimatedTotalHits, hits, languages, err
+}
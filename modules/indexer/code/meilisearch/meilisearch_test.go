@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentID(t *testing.T) {
+	id := documentID(35, "docs/README.md")
+	assert.Regexp(t, `^z_[0-9a-f]{64}$`, id)
+	assert.NotEqual(t, id, documentID(35, "docs/readme.md"))
+}
+
+func TestConvertHits(t *testing.T) {
+	convert := func(d any) []byte {
+		b, _ := json.Marshal(d)
+		return b
+	}
+
+	hits, err := convertHits(&meilisearch.SearchResponse{
+		Hits: []meilisearch.Hit{
+			{
+				"repo_id":          convert(1),
+				"filename":         convert("main.go"),
+				"content":          convert("package main\n\nfunc main() {}\n"),
+				"language":         convert("Go"),
+				"_matchesPosition": convert(map[string]any{"content": []map[string]int{{"start": 19, "length": 4}}}),
+			},
+			{
+				"repo_id":          convert(1),
+				"filename":         convert("main_test.go"),
+				"content":          convert("package main\n"),
+				"_matchesPosition": convert(map[string]any{"filename": []map[string]int{{"start": 0, "length": 4}}}),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "main", hits[0].Content[hits[0].StartIndex:hits[0].EndIndex])
+	assert.Equal(t, "Go", hits[0].Language)
+	// the first lines of the file are shown if only the file name matches
+	assert.Equal(t, 0, hits[1].StartIndex)
+	assert.Equal(t, len(hits[1].Content), hits[1].EndIndex)
+}
+
+func TestConvertFacets(t *testing.T) {
+	languages, err := convertFacets(&meilisearch.SearchResponse{
+		FacetDistribution: []byte(`{"language":{"Markdown":1,"Go":3,"C++":1}}`),
+	})
+	require.NoError(t, err)
+	require.Len(t, languages, 3)
+	assert.Equal(t, "Go", languages[0].Language)
+	assert.Equal(t, 3, languages[0].Count)
+	assert.Equal(t, "C++", languages[1].Language)
+	assert.Equal(t, "Markdown", languages[2].Language)
+}
@@ -0,0 +1,302 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package zoekt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer searches the code with a zoekt-webserver, the shards it serves are written by zoekt-git-index
+// into the index directory, which must be shared with the webserver.
+// See https://github.com/sourcegraph/zoekt
+type Indexer struct {
+	url      string
+	indexDir string
+	gitIndex string
+	client   *http.Client
+}
+
+// NewIndexer creates a new zoekt indexer
+func NewIndexer(url, indexDir, gitIndex string) *Indexer {
+	return &Indexer{
+		url:      strings.TrimSuffix(url, "/"),
+		indexDir: indexDir,
+		gitIndex: gitIndex,
+	}
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	// the regexp mode accepts the whole zoekt query syntax, e.g. "case:yes file:\.go$ func.*Search"
+	return indexer.GitGrepSupportedSearchModes()
+}
+
+// Init initializes the indexer, the index exists if there are shards in the index directory
+func (b *Indexer) Init(_ context.Context) (bool, error) {
+	if b.client != nil {
+		return false, errors.New("indexer is already initialized")
+	}
+	if _, err := exec.LookPath(b.gitIndex); err != nil {
+		return false, fmt.Errorf("unable to find %s: %w", b.gitIndex, err)
+	}
+	if err := os.MkdirAll(b.indexDir, os.ModePerm); err != nil {
+		return false, err
+	}
+	b.client = &http.Client{Timeout: time.Minute}
+
+	shards, err := filepath.Glob(filepath.Join(b.indexDir, "*.zoekt"))
+	return len(shards) > 0, err
+}
+
+// Ping checks if the zoekt-webserver is available
+func (b *Indexer) Ping(ctx context.Context) error {
+	if b.client == nil {
+		return errors.New("indexer is not initialized")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url+"/healthz", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("zoekt-webserver responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Close closes the indexer
+func (b *Indexer) Close() {
+	b.client = nil
+}
+
+// shardName is the name of a repository in the shards, the repository ID is used so the shards don't
+// depend on the owner or the name of the repository, which can change
+func shardName(repoID int64) string {
+	return strconv.FormatInt(repoID, 10)
+}
+
+// Index indexes the default branch of the repository, zoekt-git-index only updates the shards of the
+// repository if the branch has changed since the last time, so the changes are not needed
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, _ *internal.RepoChanges) error {
+	// zoekt-git-index reads the name and the ID of the repository from its git config
+	for key, value := range map[string]string{
+		"zoekt.name":   shardName(repo.ID),
+		"zoekt.repoid": shardName(repo.ID),
+	} {
+		if _, _, err := gitcmd.NewCommand("config").AddDynamicArguments(key, value).RunStdString(ctx, &gitcmd.RunOpts{Dir: repo.RepoPath()}); err != nil {
+			return err
+		}
+	}
+
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("Zoekt index %s at %s", repo.FullName(), sha))
+	defer finished()
+
+	args := []string{
+		"-index", b.indexDir,
+		"-branches", repo.DefaultBranch,
+		"-file_limit", strconv.FormatInt(setting.Indexer.MaxIndexerFileSize, 10),
+		repo.RepoPath(),
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, b.gitIndex, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %s %v: %w\nStderr: %s", b.gitIndex, args, err, stderr.String())
+	}
+	return nil
+}
+
+// Delete removes the shards of the repository, the zoekt-webserver unloads them by watching the index directory
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	shards, err := filepath.Glob(filepath.Join(b.indexDir, url.QueryEscape(shardName(repoID))+"_v*.zoekt"))
+	if err != nil {
+		return err
+	}
+	for _, shard := range shards {
+		if err := util.Remove(shard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// literal returns a zoekt query atom matching the text exactly, the spaces and the quotes are escaped since they
+// separate the atoms of a query
+func literal(text string) string {
+	return strings.NewReplacer(" ", `\x20`, `"`, `\x22`).Replace(regexp.QuoteMeta(text))
+}
+
+// buildQuery converts the keyword to a zoekt query
+func buildQuery(opts *internal.SearchOptions, searchMode indexer.SearchModeType) string {
+	var q string
+	switch searchMode {
+	case indexer.SearchModeRegexp:
+		q = opts.Keyword
+	case indexer.SearchModeExact:
+		q = literal(opts.Keyword)
+	default: /* words */
+		words := strings.Fields(opts.Keyword)
+		for i := range words {
+			words[i] = literal(words[i])
+		}
+		q = strings.Join(words, " ")
+	}
+	if opts.Language != "" {
+		q = fmt.Sprintf("(%s) lang:%q", q, opts.Language)
+	}
+	return q
+}
+
+// searchRequest is the body of the search API of zoekt-webserver
+type searchRequest struct {
+	Q       string
+	RepoIDs *[]uint32 `json:",omitempty"`
+	Opts    searchOptions
+}
+
+type searchOptions struct {
+	Whole              bool
+	MaxDocDisplayCount int
+	MaxWallTime        int64 // in nanoseconds, it's a time.Duration of zoekt
+}
+
+type fileMatch struct {
+	FileName     string
+	RepositoryID uint32
+	Language     string
+	Version      string
+	Content      []byte
+	LineMatches  []*lineMatch
+}
+
+type lineMatch struct {
+	FileName      bool // the match is in the file name
+	LineFragments []*lineFragment
+}
+
+type lineFragment struct {
+	Offset      uint32 // the offset of the match in the content
+	MatchLength int
+}
+
+type searchResponse struct {
+	Result struct {
+		FileCount int
+		Files     []*fileMatch
+	}
+}
+
+func convertFileMatch(f *fileMatch) *internal.SearchResult {
+	content := string(f.Content)
+	startIndex, endIndex := internal.FilenameMatchIndexPos(content)
+	for _, lm := range f.LineMatches {
+		if lm.FileName || len(lm.LineFragments) == 0 {
+			continue
+		}
+		if start := int(lm.LineFragments[0].Offset); start+lm.LineFragments[0].MatchLength <= len(content) {
+			startIndex, endIndex = start, start+lm.LineFragments[0].MatchLength
+		}
+		break
+	}
+	return &internal.SearchResult{
+		RepoID:     int64(f.RepositoryID),
+		Filename:   f.FileName,
+		CommitID:   f.Version,
+		Content:    content,
+		Language:   f.Language,
+		StartIndex: startIndex,
+		EndIndex:   endIndex,
+		Color:      enry.GetColor(f.Language),
+	}
+}
+
+// Search searches the code with the zoekt-webserver, the results are ranked by zoekt
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+	if b.client == nil {
+		return 0, nil, nil, errors.New("indexer is not initialized")
+	}
+
+	start, pageSize := opts.GetSkipTake()
+	body := &searchRequest{
+		Q: buildQuery(opts, util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)),
+		Opts: searchOptions{
+			Whole: true, // the whole content is needed to show the lines around the matches
+			// zoekt has no offset, so the results of the previous pages are skipped
+			MaxDocDisplayCount: start + pageSize,
+			MaxWallTime:        int64(10 * time.Second),
+		},
+	}
+	if len(opts.RepoIDs) > 0 {
+		repoIDs := make([]uint32, len(opts.RepoIDs))
+		for i, id := range opts.RepoIDs {
+			repoIDs[i] = uint32(id)
+		}
+		body.RepoIDs = &repoIDs
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url+"/api/search", bytes.NewReader(data))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		log.Debug("zoekt-webserver responded %s to %q: %s", resp.Status, body.Q, msg)
+		if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity {
+			return 0, nil, nil, util.NewInvalidArgumentErrorf("invalid query: %s", strings.TrimSpace(string(msg)))
+		}
+		return 0, nil, nil, fmt.Errorf("zoekt-webserver responded %s", resp.Status)
+	}
+
+	var res searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return 0, nil, nil, err
+	}
+
+	hits := make([]*internal.SearchResult, 0, pageSize)
+	for i := start; i < len(res.Result.Files); i++ {
+		hits = append(hits, convertFileMatch(res.Result.Files[i]))
+	}
+	// zoekt doesn't count the languages of all the matches, so there is no language filter
+	return int64(res.Result.FileCount), hits, nil, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package zoekt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildQuery(t *testing.T) {
+	cases := []struct {
+		keyword  string
+		mode     indexer.SearchModeType
+		language string
+		expected string
+	}{
+		{"func main", indexer.SearchModeExact, "", `func\x20main`},
+		{`a.b "c"`, indexer.SearchModeExact, "", `a\.b\x20\x22c\x22`},
+		{"func  main", indexer.SearchModeWords, "", `func main`},
+		{"case:yes file:\\.go$ Fo+", indexer.SearchModeRegexp, "", "case:yes file:\\.go$ Fo+"},
+		{"main", indexer.SearchModeExact, "Go Module", `(main) lang:"Go Module"`},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, buildQuery(&internal.SearchOptions{Keyword: c.keyword, Language: c.language}, c.mode), c.keyword)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var received searchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/search", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.Q == "(" {
+			http.Error(w, "missing )", http.StatusBadRequest)
+			return
+		}
+
+		res := searchResponse{}
+		res.Result.FileCount = 3
+		for _, name := range []string{"a.go", "b.go", "c.go"}[:min(3, received.Opts.MaxDocDisplayCount)] {
+			f := &fileMatch{FileName: name, RepositoryID: 7, Language: "Go", Content: []byte("package main\n\nfunc main() {}\n")}
+			f.LineMatches = []*lineMatch{{LineFragments: []*lineFragment{{Offset: 19, MatchLength: 4}}}}
+			res.Result.Files = append(res.Result.Files, f)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer server.Close()
+
+	b := NewIndexer(server.URL+"/", t.TempDir(), "zoekt-git-index")
+	b.client = server.Client()
+
+	total, hits, languages, err := b.Search(t.Context(), &internal.SearchOptions{
+		RepoIDs:    []int64{7},
+		Keyword:    "main",
+		SearchMode: indexer.SearchModeExact,
+		Paginator:  &db.ListOptions{Page: 2, PageSize: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{7}, *received.RepoIDs)
+	assert.Equal(t, 4, received.Opts.MaxDocDisplayCount)
+	assert.EqualValues(t, 3, total)
+	assert.Nil(t, languages)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "c.go", hits[0].Filename)
+	assert.EqualValues(t, 7, hits[0].RepoID)
+	assert.Equal(t, "main", hits[0].Content[hits[0].StartIndex:hits[0].EndIndex])
+
+	_, _, _, err = b.Search(t.Context(), &internal.SearchOptions{
+		Keyword:    "(",
+		SearchMode: indexer.SearchModeRegexp,
+		Paginator:  &db.ListOptions{Page: 1, PageSize: 2},
+	})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"fmt"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const (
+	// maxFileSize is the size limit of manifests and lockfiles which are parsed
+	maxFileSize = 32 * 1024 * 1024
+	// maxColumnSize is the size of the columns of the names and versions of dependencies
+	maxColumnSize = 255
+)
+
+// DBIndexer implements Indexer interface to store the dependency graphs in the database
+type DBIndexer struct{}
+
+// Index updates the dependency graph of the default branch of a repository
+func (db *DBIndexer) Index(id int64) error {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().ShutdownContext(), fmt.Sprintf("Dependency.DB Index Repo[%d]", id))
+	defer finished()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeDependency)
+	if err != nil {
+		return err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		if err.Error() == "no such file or directory" {
+			return nil
+		}
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrBranchNotExist(err) || git.IsErrNotExist(err) || setting.IsInTesting {
+			log.Debug("Unable to get commit for default branch %s in %s ... skipping this repository", repo.DefaultBranch, repo.FullName())
+			return nil
+		}
+		log.Error("Unable to get commit for default branch %s in %s. Error: %v", repo.DefaultBranch, repo.FullName(), err)
+		return err
+	}
+	commitID := commit.ID.String()
+
+	// Do not rebuild the dependency graph if already built for this commit
+	if status.CommitSha == commitID {
+		return nil
+	}
+
+	components, err := ExtractDependencies(repo, commit)
+	if err != nil {
+		log.Error("Unable to extract dependencies for ID %s for default branch %s in %s. Error: %v", commitID, repo.DefaultBranch, repo.FullName(), err)
+		return err
+	}
+
+	deps := make([]*repo_model.RepoDependency, 0, len(components))
+	for _, c := range components {
+		if len(c.Name) > maxColumnSize || len(c.Version) > maxColumnSize || len(c.Requirement) > maxColumnSize || len(c.License) > maxColumnSize {
+			continue
+		}
+		deps = append(deps, &repo_model.RepoDependency{
+			Ecosystem:   string(c.Ecosystem),
+			Name:        c.Name,
+			LowerName:   strings.ToLower(sbom.NormalizeName(c.Ecosystem, c.Name)),
+			Version:     c.Version,
+			Requirement: c.Requirement,
+			Scope:       string(c.Scope),
+			IsDirect:    c.Direct,
+			License:     c.License,
+			Manifest:    c.Source,
+		})
+	}
+	if err := repo_model.UpdateRepoDependencies(ctx, repo, commitID, deps); err != nil {
+		log.Error("Unable to update dependencies for ID %s for default branch %s in %s. Error: %v", commitID, repo.DefaultBranch, repo.FullName(), err)
+		return err
+	}
+
+	log.Debug("DBIndexer completed dependencies for ID %s for default branch %s in %s. dependency count: %d", commitID, repo.DefaultBranch, repo.FullName(), len(deps))
+	return nil
+}
+
+// ExtractDependencies parses the manifests and lockfiles in the tree of the commit,
+// the files of vendored or installed dependencies are ignored
+func ExtractDependencies(repo *repo_model.Repository, commit *git.Commit) ([]*sbom.Component, error) {
+	entries, err := commit.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, err
+	}
+
+	var declared, resolved []*sbom.Component
+	for _, entry := range entries {
+		name := entry.Name()
+		isManifest, isLockfile := sbom.IsManifest(name), sbom.IsLockfile(name)
+		if !entry.IsRegular() || (!isManifest && !isLockfile) || sbom.IsVendoredPath(name) || entry.Size() > maxFileSize {
+			continue
+		}
+
+		r, err := entry.Blob().DataAsync()
+		if err != nil {
+			return nil, err
+		}
+		var components []*sbom.Component
+		if isManifest {
+			components, err = sbom.ParseManifest(name, r)
+		} else {
+			components, err = sbom.ParseLockfile(name, r)
+		}
+		r.Close()
+		if err != nil {
+			// a broken file in the tree must not prevent the dependency graph of the other files
+			log.Debug("Unable to parse %s of %s: %v", name, repo.FullName(), err)
+			continue
+		}
+		if isManifest {
+			declared = append(declared, components...)
+		} else {
+			resolved = append(resolved, components...)
+		}
+	}
+	return sbom.ResolveDependencies(declared, resolved), nil
+}
+
+// Close dummy function
+func (db *DBIndexer) Close() {
+}
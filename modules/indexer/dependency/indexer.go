@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// Indexer defines an interface to index the dependency graphs of repositories
+type Indexer interface {
+	Index(id int64) error
+	Close()
+}
+
+// indexer represents a indexer instance
+var indexer Indexer
+
+// Init initialize the dependency indexer
+func Init() error {
+	indexer = &DBIndexer{}
+
+	if err := initDependencyQueue(); err != nil {
+		return err
+	}
+
+	go populateRepoIndexer(graceful.GetManager().ShutdownContext())
+
+	return nil
+}
+
+// populateRepoIndexer populates the dependency indexer with the repositories which haven't been indexed yet
+func populateRepoIndexer(ctx context.Context) {
+	log.Info("Populating the repo dependency indexer with existing repositories")
+
+	isShutdown := graceful.GetManager().IsShutdown()
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	var maxRepoID int64
+	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, repositories created after gitea
+	// starts are added to the indexer when they are pushed to
+	for maxRepoID > 0 {
+		select {
+		case <-isShutdown:
+			log.Info("Repository Dependency Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeDependency, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateRepoIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			select {
+			case <-isShutdown:
+				log.Info("Repository Dependency Indexer population shutdown before completion")
+				return
+			default:
+			}
+			if err := dependencyQueue.Push(id); err != nil {
+				log.Error("dependencyQueue.Push: %v", err)
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done populating the repo dependency indexer with existing repositories")
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+	_ "code.gitea.io/gitea/models/activities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestRepoDependencyIndex(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	setting.CfgProvider, _ = setting.NewConfigProviderFromData("")
+
+	setting.LoadQueueSettings()
+
+	err := Init()
+	assert.NoError(t, err)
+
+	repo, err := repo_model.GetRepositoryByID(t.Context(), 1)
+	assert.NoError(t, err)
+
+	// the dependencies of an outdated commit get replaced
+	assert.NoError(t, repo_model.UpdateRepoDependencies(t.Context(), repo, "0000000000000000000000000000000000000000", []*repo_model.RepoDependency{
+		{Ecosystem: "npm", Name: "lodash", LowerName: "lodash", Version: "4.17.21", IsDirect: true, Manifest: "package.json"},
+	}))
+	repo.DependencyIndexerStatus = nil
+
+	err = UpdateRepoIndexer(repo)
+	assert.NoError(t, err)
+
+	assert.NoError(t, queue.GetManager().FlushAll(t.Context(), 5*time.Second))
+
+	status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeDependency)
+	assert.NoError(t, err)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+	deps, err := db.Find[repo_model.RepoDependency](t.Context(), repo_model.FindRepoDependenciesOptions{RepoID: repo.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, deps)
+}
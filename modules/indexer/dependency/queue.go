@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"errors"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// dependencyQueue represents a queue to handle repository dependency graph updates
+var dependencyQueue *queue.WorkerPoolQueue[int64]
+
+func handler(items ...int64) []int64 {
+	for _, id := range items {
+		if err := indexer.Index(id); err != nil {
+			if !setting.IsInTesting {
+				log.Error("dependency queue indexer.Index(%d) failed: %v", id, err)
+			}
+			if err := repo_model.UpdateIndexerFailure(graceful.GetManager().ShutdownContext(), id, repo_model.RepoIndexerTypeDependency, err); err != nil {
+				log.Error("dependency queue: unable to record the failure of repo %d: %v", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func initDependencyQueue() error {
+	dependencyQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "repo_dependency_update", handler)
+	if dependencyQueue == nil {
+		return errors.New("unable to create repo_dependency_update queue")
+	}
+	go graceful.GetManager().RunWithCancel(dependencyQueue)
+	return nil
+}
+
+// UpdateRepoIndexer queues the update of the dependency graph of a repository
+func UpdateRepoIndexer(repo *repo_model.Repository) error {
+	if err := dependencyQueue.Push(repo.ID); err != nil {
+		if err != queue.ErrAlreadyInQueue {
+			return err
+		}
+		log.Debug("Repo ID: %d already queued", repo.ID)
+	}
+	return nil
+}
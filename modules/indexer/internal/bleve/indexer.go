@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/gitea/modules/indexer/internal"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/mapping"
@@ -74,6 +75,9 @@ func (i *Indexer) Init(_ context.Context) (bool, error) {
 	}); err != nil {
 		return false, err
 	}
+	if err = indexer.SetInternal([]byte(cjkAnalyzerKey), []byte(setting.Indexer.TypeBleveCJKAnalyzer)); err != nil {
+		return false, err
+	}
 
 	i.Indexer = indexer
 
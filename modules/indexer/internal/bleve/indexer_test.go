@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerRebuildOnCJKAnalyzerChange(t *testing.T) {
+	dir := t.TempDir()
+	mappingGetter := func() (mapping.IndexMapping, error) {
+		return bleve.NewIndexMapping(), nil
+	}
+	initIndexer := func() bool {
+		indexer := NewIndexer(dir, 1, mappingGetter)
+		defer indexer.Close()
+		existed, err := indexer.Init(t.Context())
+		require.NoError(t, err)
+		return existed
+	}
+
+	assert.False(t, initIndexer())
+	assert.True(t, initIndexer())
+
+	defer test.MockVariableValue(&setting.Indexer.TypeBleveCJKAnalyzer, "bigram")()
+	assert.False(t, initIndexer(), "the index should be rebuilt with the new analyzer")
+	assert.True(t, initIndexer())
+}
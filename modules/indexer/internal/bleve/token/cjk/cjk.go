@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cjk
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+const (
+	Name = "gitea/cjk"
+)
+
+// TokenFilter splits the CJK characters from the other characters of the tokens and marks them as ideographic,
+// so they are segmented by the cjk_bigram token filter whatever tokenizer is used, e.g. the letter tokenizer
+// makes a single token of a Chinese sentence.
+type TokenFilter struct{}
+
+func NewTokenFilter() *TokenFilter {
+	return &TokenFilter{}
+}
+
+func TokenFilterConstructor(config map[string]any, cache *registry.Cache) (analysis.TokenFilter, error) {
+	return NewTokenFilter(), nil
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+func (s *TokenFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	output := make(analysis.TokenStream, 0, len(input))
+	for _, token := range input {
+		start := 0
+		for start < len(token.Term) {
+			r, _ := utf8.DecodeRune(token.Term[start:])
+			cjk := isCJK(r)
+			end := start
+			for end < len(token.Term) {
+				r, size := utf8.DecodeRune(token.Term[end:])
+				if isCJK(r) != cjk {
+					break
+				}
+				end += size
+			}
+			if start == 0 && end == len(token.Term) && !cjk {
+				output = append(output, token)
+				break
+			}
+
+			part := &analysis.Token{
+				Term:     token.Term[start:end],
+				Start:    token.Start + start,
+				End:      token.Start + end,
+				Position: token.Position,
+				Type:     token.Type,
+				KeyWord:  token.KeyWord,
+			}
+			if cjk {
+				part.Type = analysis.Ideographic
+			}
+			output = append(output, part)
+			start = end
+		}
+	}
+	return output
+}
+
+func init() {
+	// FIXME: move it to the bleve's init function, but do not call it in global init
+	err := registry.RegisterTokenFilter(Name, TokenFilterConstructor)
+	if err != nil {
+		panic(err)
+	}
+}
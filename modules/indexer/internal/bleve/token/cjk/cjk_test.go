@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cjk
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/character"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenFilter(t *testing.T) {
+	scenarios := []struct {
+		Input string
+		Terms []string
+		Types []analysis.TokenType
+	}{
+		{
+			Input: "hello world",
+			Terms: []string{"hello", "world"},
+			Types: []analysis.TokenType{analysis.AlphaNumeric, analysis.AlphaNumeric},
+		},
+		{
+			Input: "修复中文搜索",
+			Terms: []string{"修复中文搜索"},
+			Types: []analysis.TokenType{analysis.Ideographic},
+		},
+		{
+			Input: "使用bleve索引",
+			Terms: []string{"使用", "bleve", "索引"},
+			Types: []analysis.TokenType{analysis.Ideographic, analysis.AlphaNumeric, analysis.Ideographic},
+		},
+		{
+			Input: "",
+			Terms: []string{},
+			Types: []analysis.TokenType{},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Input, func(t *testing.T) {
+			tokens := NewTokenFilter().Filter(character.NewCharacterTokenizer(unicode.IsLetter).Tokenize([]byte(scenario.Input)))
+			terms := make([]string, 0, len(tokens))
+			types := make([]analysis.TokenType, 0, len(tokens))
+			for _, token := range tokens {
+				terms = append(terms, string(token.Term))
+				types = append(types, token.Type)
+				// the offsets are the ones of the term in the input
+				assert.Equal(t, string(token.Term), scenario.Input[token.Start:token.End])
+			}
+			assert.Equal(t, scenario.Terms, terms)
+			assert.Equal(t, scenario.Types, types)
+		})
+	}
+}
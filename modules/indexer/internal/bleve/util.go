@@ -8,11 +8,13 @@ import (
 	"os"
 	"unicode"
 
+	"code.gitea.io/gitea/modules/indexer/internal/bleve/token/cjk"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/blevesearch/bleve/v2"
+	bleve_cjk "github.com/blevesearch/bleve/v2/analysis/lang/cjk"
 	unicode_tokenizer "github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/index/upsidedown"
 	"github.com/ethantkoenig/rupture"
@@ -20,8 +22,20 @@ import (
 
 const (
 	maxFuzziness = 2
+
+	// cjkAnalyzerKey is the internal key of the index storing the TYPE_BLEVE_CJK_ANALYZER it was built with
+	cjkAnalyzerKey = "gitea_cjk_analyzer"
 )
 
+// CJKTokenFilters returns the token filters to append to the analyzers of the indexes,
+// so the texts in Chinese and Japanese are segmented as configured by TYPE_BLEVE_CJK_ANALYZER
+func CJKTokenFilters() []string {
+	if setting.Indexer.TypeBleveCJKAnalyzer == "bigram" {
+		return []string{bleve_cjk.WidthName, cjk.Name, bleve_cjk.BigramName}
+	}
+	return nil
+}
+
 // openIndexer open the index at the specified path, checking for metadata
 // updates and bleve version updates.  If index needs to be created (or
 // re-created), returns (nil, nil)
@@ -52,6 +66,20 @@ func openIndexer(path string, latestVersion int) (bleve.Index, int, error) {
 		return nil, 0, err
 	}
 
+	// the indexes built before the option was added have no key, they are the same as "none"
+	analyzer, err := index.GetInternal([]byte(cjkAnalyzerKey))
+	if err != nil {
+		_ = index.Close()
+		return nil, 0, err
+	}
+	if util.IfZero(string(analyzer), "none") != setting.Indexer.TypeBleveCJKAnalyzer {
+		log.Warn("Indexer was built with the CJK analyzer %q, deleting and rebuilding with %q", util.IfZero(string(analyzer), "none"), setting.Indexer.TypeBleveCJKAnalyzer)
+		if err := index.Close(); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, util.RemoveAll(path)
+	}
+
 	return index, 0, nil
 }
 
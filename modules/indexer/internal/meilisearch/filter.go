@@ -80,6 +80,12 @@ func NewFilterEq[T bool | int64](field string, value T) FilterEq {
 	return FilterEq(fmt.Sprintf("%s = %v", field, value))
 }
 
+// NewFilterEqString creates a new FilterEq of a string value, the quotes and the backslashes of the value are escaped.
+func NewFilterEqString(field, value string) FilterEq {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return FilterEq(fmt.Sprintf(`%s = "%s"`, field, value))
+}
+
 func (f FilterEq) Statement() string {
 	return string(f)
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/meilisearch/meilisearch-go"
 )
@@ -79,6 +80,18 @@ func (i *Indexer) Ping(ctx context.Context) error {
 	return nil
 }
 
+// WaitForTask waits until meilisearch has processed the asynchronous task, so the changes of the task are visible to the searches
+func (i *Indexer) WaitForTask(ctx context.Context, info *meilisearch.TaskInfo) error {
+	task, err := i.Client.WaitForTaskWithContext(ctx, info.TaskUID, 50*time.Millisecond)
+	if err != nil {
+		return err
+	}
+	if task.Status != meilisearch.TaskStatusSucceeded {
+		return fmt.Errorf("meilisearch task %d %s: %s", info.TaskUID, task.Status, task.Error.Message)
+	}
+	return nil
+}
+
 // Close closes the indexer
 func (i *Indexer) Close() {
 	if i == nil {
@@ -103,7 +103,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 		"type":          custom.Name,
 		"char_filters":  []string{},
 		"tokenizer":     unicode.Name,
-		"token_filters": []string{unicodeNormalizeName, camelcase.Name, lowercase.Name},
+		"token_filters": append([]string{unicodeNormalizeName, camelcase.Name, lowercase.Name}, inner_bleve.CJKTokenFilters()...),
 	}); err != nil {
 		return nil, err
 	}
@@ -6,7 +6,14 @@ package bleve
 import (
 	"testing"
 
+	indexer_module "code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
 	"code.gitea.io/gitea/modules/indexer/issues/internal/tests"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBleveIndexer(t *testing.T) {
@@ -16,3 +23,25 @@ func TestBleveIndexer(t *testing.T) {
 
 	tests.TestIndexer(t, indexer)
 }
+
+func TestBleveIndexerCJKBigram(t *testing.T) {
+	defer test.MockVariableValue(&setting.Indexer.TypeBleveCJKAnalyzer, "bigram")()
+
+	indexer := NewIndexer(t.TempDir())
+	defer indexer.Close()
+	_, err := indexer.Init(t.Context())
+	require.NoError(t, err)
+
+	require.NoError(t, indexer.Index(t.Context(), &internal.IndexerData{ID: 1, RepoID: 1, Title: "修复中文搜索的问题"}))
+
+	for keyword, total := range map[string]int64{
+		"中文":    1,
+		"中文搜索":  1,
+		"文中":    0, // the characters are in the title, but not next to each other
+		"修复 问题": 1,
+	} {
+		result, err := indexer.Search(t.Context(), &internal.SearchOptions{Keyword: keyword, RepoIDs: []int64{1}, SearchMode: indexer_module.SearchModeWords})
+		require.NoError(t, err)
+		assert.Equal(t, total, result.Total, keyword)
+	}
+}
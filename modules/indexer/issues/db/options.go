@@ -55,6 +55,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 	}
 
 	opts := &issue_model.IssuesOptions{
+		HideModerated:      true,
 		Paginator:          options.Paginator,
 		RepoIDs:            options.RepoIDs,
 		AllPublic:          options.AllPublic,
@@ -75,6 +76,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issue_m
 		SortType:           sortType,
 		UpdatedAfterUnix:   options.UpdatedAfterUnix.Value(),
 		UpdatedBeforeUnix:  options.UpdatedBeforeUnix.Value(),
+		FieldValues:        options.FieldValues,
 		PriorityRepoID:     0,
 		IsArchived:         options.IsArchived,
 		Owner:              nil,
@@ -24,6 +24,8 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 		IsPull:     opts.IsPull,
 		IsClosed:   opts.IsClosed,
 		IsArchived: opts.IsArchived,
+
+		FieldValues: opts.FieldValues,
 	}
 
 	if len(opts.LabelIDs) == 1 && opts.LabelIDs[0] == 0 {
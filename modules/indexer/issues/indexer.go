@@ -292,6 +292,9 @@ func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, err
 		// Even worse, the external indexer like elastic search may not be available for a while,
 		// and the user may not be able to list issues completely until it is available again.
 		ix = db.GetIndexer()
+	} else if len(opts.FieldValues) > 0 {
+		// The values of the custom fields are only stored in the database.
+		ix = db.GetIndexer()
 	}
 
 	result, err := ix.Search(ctx, opts)
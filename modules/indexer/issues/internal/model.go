@@ -110,6 +110,8 @@ type SearchOptions struct {
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
+	FieldValues map[int64]string // normalized values of custom fields by field ID, only the database indexer supports them
+
 	Paginator *db.ListOptions
 
 	SortBy SortBy // sort by field
@@ -25,6 +25,10 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		}
 		return nil, false, err
 	}
+	// a hidden issue is removed from the index until a moderator unhides it
+	if issue.IsHidden {
+		return nil, false, nil
+	}
 
 	// FIXME: what if users want to search for a review comment of a pull request?
 	//        The comment type is CommentTypeCode or CommentTypeReview.
@@ -35,7 +39,8 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 
 	comments := make([]string, 0, len(issue.Comments))
 	for _, comment := range issue.Comments {
-		if comment.Content != "" {
+		// the comments hidden by a moderator aren't searchable
+		if comment.Content != "" && !comment.IsHidden {
 			// what ever the comment type is, index the content if it is not empty.
 			comments = append(comments, comment.Content)
 		}
@@ -23,6 +23,9 @@ func handler(items ...int64) []int64 {
 			if !setting.IsInTesting {
 				log.Error("stats queue indexer.Index(%d) failed: %v", opts, err)
 			}
+			if err := repo_model.UpdateIndexerFailure(graceful.GetManager().ShutdownContext(), opts, repo_model.RepoIndexerTypeStats, err); err != nil {
+				log.Error("stats queue: unable to record the failure of repo %d: %v", opts, err)
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// maxSymbols is the limit of the symbols of a repository, the symbols of the files after it are dropped
+const maxSymbols = 500000
+
+// ctagsArgs makes universal-ctags print a JSON object for each tag to the stdout, with the line, the end line,
+// the scope, the long name of the kind and the language of the tag, the files are read from the stdin
+var ctagsArgs = []string{
+	"--output-format=json",
+	"--fields=-P+neZKl",
+	"--sort=no",
+	"--links=no",
+	"-f", "-",
+	"-L", "-",
+}
+
+// tag is a tag printed by universal-ctags with the JSON output format
+// See https://docs.ctags.io/en/latest/man/ctags-json-output.5.html
+type tag struct {
+	Type      string `json:"_type"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Language  string `json:"language"`
+	Line      int    `json:"line"`
+	End       int    `json:"end"`
+	Kind      string `json:"kind"`
+	Scope     string `json:"scope"`
+	ScopeKind string `json:"scopeKind"`
+}
+
+func runCtags(ctx context.Context, dir string, files []string) ([]*repo_model.RepoSymbol, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, setting.Indexer.SymbolIndexerCtags, ctagsArgs...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(strings.Join(files, "\n") + "\n")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	process.SetSysProcAttribute(cmd)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseTags(&stdout)
+}
+
+// parseTags converts the tags printed by universal-ctags to symbols, the pseudo tags and the tags
+// which don't fit in the columns are skipped
+func parseTags(r io.Reader) ([]*repo_model.RepoSymbol, error) {
+	var symbols []*repo_model.RepoSymbol
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() && len(symbols) < maxSymbols {
+		var t tag
+		if err := json.Unmarshal(scanner.Bytes(), &t); err != nil {
+			return nil, fmt.Errorf("unable to parse the output of ctags: %w", err)
+		}
+		if t.Type != "tag" || t.Line <= 0 || len(t.Name) > maxColumnSize || len(t.Path) > maxColumnSize {
+			continue
+		}
+		symbols = append(symbols, &repo_model.RepoSymbol{
+			Name:      t.Name,
+			Kind:      util.TruncateRunes(t.Kind, 50),
+			Language:  util.TruncateRunes(t.Language, 50),
+			Path:      t.Path,
+			Line:      t.Line,
+			EndLine:   t.End,
+			Scope:     util.TruncateRunes(t.Scope, maxColumnSize),
+			ScopeKind: util.TruncateRunes(t.ScopeKind, 50),
+		})
+	}
+	return symbols, scanner.Err()
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/analyze"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// maxColumnSize is the size of the columns of the names and the paths of symbols
+const maxColumnSize = 255
+
+// DBIndexer implements Indexer interface to store the symbols in the database
+type DBIndexer struct{}
+
+// Index updates the symbols of the default branch of a repository
+func (db *DBIndexer) Index(id int64) error {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().ShutdownContext(), fmt.Sprintf("Symbol.DB Index Repo[%d]", id))
+	defer finished()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeSymbol)
+	if err != nil {
+		return err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		if err.Error() == "no such file or directory" {
+			return nil
+		}
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrBranchNotExist(err) || git.IsErrNotExist(err) || setting.IsInTesting {
+			log.Debug("Unable to get commit for default branch %s in %s ... skipping this repository", repo.DefaultBranch, repo.FullName())
+			return nil
+		}
+		log.Error("Unable to get commit for default branch %s in %s. Error: %v", repo.DefaultBranch, repo.FullName(), err)
+		return err
+	}
+	commitID := commit.ID.String()
+
+	// Do not index the symbols again if already indexed for this commit
+	if status.CommitSha == commitID {
+		return nil
+	}
+
+	symbols, err := ExtractSymbols(ctx, commit)
+	if err != nil {
+		log.Error("Unable to extract symbols for ID %s for default branch %s in %s. Error: %v", commitID, repo.DefaultBranch, repo.FullName(), err)
+		return err
+	}
+	if err := repo_model.UpdateRepoSymbols(ctx, repo, commitID, symbols); err != nil {
+		log.Error("Unable to update symbols for ID %s for default branch %s in %s. Error: %v", commitID, repo.DefaultBranch, repo.FullName(), err)
+		return err
+	}
+
+	log.Debug("DBIndexer completed symbols for ID %s for default branch %s in %s. symbol count: %d", commitID, repo.DefaultBranch, repo.FullName(), len(symbols))
+	return nil
+}
+
+// ExtractSymbols writes the files of the tree of the commit into a temporary directory and runs ctags on them,
+// the files which are larger than MAX_FILE_SIZE and the vendored files are ignored
+func ExtractSymbols(ctx context.Context, commit *git.Commit) ([]*repo_model.RepoSymbol, error) {
+	entries, err := commit.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, cleanup, err := setting.AppDataTempDir("symbol-indexer").MkdirTempRandom("ctags")
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsRegular() || len(name) > maxColumnSize || entry.Size() > setting.Indexer.MaxIndexerFileSize ||
+			(setting.Indexer.ExcludeVendored && analyze.IsVendor(name)) {
+			continue
+		}
+		if err := writeBlob(filepath.Join(dir, filepath.FromSlash(name)), entry.Blob()); err != nil {
+			return nil, err
+		}
+		files = append(files, name)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return runCtags(ctx, dir, files)
+}
+
+func writeBlob(path string, blob *git.Blob) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	r, err := blob.DataAsync()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Close dummy function
+func (db *DBIndexer) Close() {
+}
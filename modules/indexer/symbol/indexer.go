@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbol
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Indexer defines an interface to index the symbols of repositories
+type Indexer interface {
+	Index(id int64) error
+	Close()
+}
+
+// indexer represents a indexer instance
+var indexer Indexer
+
+// Init initialize the symbol indexer
+func Init() error {
+	if !setting.Indexer.SymbolIndexerEnabled {
+		return nil
+	}
+
+	indexer = &DBIndexer{}
+
+	if err := initSymbolQueue(); err != nil {
+		return err
+	}
+
+	go populateRepoIndexer(graceful.GetManager().ShutdownContext())
+
+	return nil
+}
+
+// populateRepoIndexer populates the symbol indexer with the repositories which haven't been indexed yet
+func populateRepoIndexer(ctx context.Context) {
+	log.Info("Populating the repo symbol indexer with existing repositories")
+
+	isShutdown := graceful.GetManager().IsShutdown()
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	var maxRepoID int64
+	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, repositories created after gitea
+	// starts are added to the indexer when they are pushed to
+	for maxRepoID > 0 {
+		select {
+		case <-isShutdown:
+			log.Info("Repository Symbol Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeSymbol, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateRepoIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			select {
+			case <-isShutdown:
+				log.Info("Repository Symbol Indexer population shutdown before completion")
+				return
+			default:
+			}
+			if err := symbolQueue.Push(id); err != nil {
+				log.Error("symbolQueue.Push: %v", err)
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done populating the repo symbol indexer with existing repositories")
+}
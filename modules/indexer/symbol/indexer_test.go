@@ -0,0 +1,97 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbol
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+	_ "code.gitea.io/gitea/models/activities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestParseTags(t *testing.T) {
+	symbols, err := parseTags(strings.NewReader(`{"_type": "ptag", "name": "JSON_OUTPUT_VERSION", "path": "0.0"}
+{"_type": "tag", "name": "Search", "path": "modules/search.go", "language": "Go", "line": 12, "kind": "method", "scope": "Indexer", "scopeKind": "struct", "end": 20}
+{"_type": "tag", "name": "main", "path": "main.go", "language": "Go", "line": 5, "kind": "function"}
+`))
+	require.NoError(t, err)
+	require.Len(t, symbols, 2)
+	assert.Equal(t, &repo_model.RepoSymbol{
+		Name:      "Search",
+		Kind:      "method",
+		Language:  "Go",
+		Path:      "modules/search.go",
+		Line:      12,
+		EndLine:   20,
+		Scope:     "Indexer",
+		ScopeKind: "struct",
+	}, symbols[0])
+	assert.Equal(t, "main", symbols[1].Name)
+	assert.Zero(t, symbols[1].EndLine)
+
+	_, err = parseTags(strings.NewReader("main\tmain.go\t5\n"))
+	assert.Error(t, err)
+}
+
+func TestRepoSymbolIndex(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	setting.CfgProvider, _ = setting.NewConfigProviderFromData("")
+
+	setting.LoadQueueSettings()
+
+	// a fake ctags which prints a tag for each file it reads
+	ctags := filepath.Join(t.TempDir(), "ctags")
+	require.NoError(t, os.WriteFile(ctags, []byte(`#!/bin/sh
+while read -r f; do
+	echo "{\"_type\": \"tag\", \"name\": \"$(basename "$f")\", \"path\": \"$f\", \"line\": 1, \"kind\": \"file\"}"
+done
+`), 0o755))
+	defer test.MockVariableValue(&setting.Indexer.SymbolIndexerEnabled, true)()
+	defer test.MockVariableValue(&setting.Indexer.SymbolIndexerCtags, ctags)()
+
+	err := Init()
+	assert.NoError(t, err)
+
+	repo, err := repo_model.GetRepositoryByID(t.Context(), 1)
+	assert.NoError(t, err)
+
+	// the symbols of an outdated commit get replaced
+	assert.NoError(t, repo_model.UpdateRepoSymbols(t.Context(), repo, "0000000000000000000000000000000000000000", []*repo_model.RepoSymbol{
+		{Name: "main", Kind: "function", Language: "Go", Path: "main.go", Line: 5},
+	}))
+	repo.SymbolIndexerStatus = nil
+
+	err = UpdateRepoIndexer(repo)
+	assert.NoError(t, err)
+
+	assert.NoError(t, queue.GetManager().FlushAll(t.Context(), 5*time.Second))
+
+	status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeSymbol)
+	assert.NoError(t, err)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+	symbols, err := db.Find[repo_model.RepoSymbol](t.Context(), repo_model.FindRepoSymbolsOptions{RepoID: repo.ID})
+	assert.NoError(t, err)
+	require.Len(t, symbols, 1)
+	assert.Equal(t, "README.md", symbols[0].Path)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", symbols[0].CommitID)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package symbol
+
+import (
+	"errors"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// symbolQueue represents a queue to handle repository symbol updates
+var symbolQueue *queue.WorkerPoolQueue[int64]
+
+func handler(items ...int64) []int64 {
+	for _, id := range items {
+		if err := indexer.Index(id); err != nil {
+			if !setting.IsInTesting {
+				log.Error("symbol queue indexer.Index(%d) failed: %v", id, err)
+			}
+			if err := repo_model.UpdateIndexerFailure(graceful.GetManager().ShutdownContext(), id, repo_model.RepoIndexerTypeSymbol, err); err != nil {
+				log.Error("symbol queue: unable to record the failure of repo %d: %v", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func initSymbolQueue() error {
+	symbolQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "repo_symbol_update", handler)
+	if symbolQueue == nil {
+		return errors.New("unable to create repo_symbol_update queue")
+	}
+	go graceful.GetManager().RunWithCancel(symbolQueue)
+	return nil
+}
+
+// UpdateRepoIndexer queues the update of the symbols of a repository, it does nothing if the symbol indexer is disabled
+func UpdateRepoIndexer(repo *repo_model.Repository) error {
+	if symbolQueue == nil {
+		return nil
+	}
+	if err := symbolQueue.Push(repo.ID); err != nil {
+		if err != queue.ErrAlreadyInQueue {
+			return err
+		}
+		log.Debug("Repo ID: %d already queued", repo.ID)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"context"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/blevesearch/bleve/v2"
+	analyzer_custom "github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	analyzer_keyword "github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	unicodeNormalizeName = "unicodeNormalize"
+	maxBatchSize         = 16
+)
+
+// WikiIndexerData data stored in the wiki indexer
+type WikiIndexerData struct {
+	RepoID    int64
+	CommitID  string
+	Title     string
+	Content   string
+	UpdatedAt time.Time
+}
+
+// Type returns the document type, for bleve's mapping.Classifier interface.
+func (d *WikiIndexerData) Type() string {
+	return wikiIndexerDocType
+}
+
+const (
+	wikiIndexerAnalyzer      = "wikiIndexerAnalyzer"
+	wikiIndexerDocType       = "wikiIndexerDocType"
+	wikiIndexerLatestVersion = 1
+)
+
+// generateBleveIndexMapping generates a bleve index mapping for the wiki indexer
+func generateBleveIndexMapping() (mapping.IndexMapping, error) {
+	docMapping := bleve.NewDocumentMapping()
+	numericFieldMapping := bleve.NewNumericFieldMapping()
+	numericFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("RepoID", numericFieldMapping)
+
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("Title", textFieldMapping)
+	docMapping.AddFieldMappingsAt("Content", textFieldMapping)
+
+	termFieldMapping := bleve.NewTextFieldMapping()
+	termFieldMapping.IncludeInAll = false
+	termFieldMapping.Analyzer = analyzer_keyword.Name
+	docMapping.AddFieldMappingsAt("CommitID", termFieldMapping)
+
+	timeFieldMapping := bleve.NewDateTimeFieldMapping()
+	timeFieldMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("UpdatedAt", timeFieldMapping)
+
+	mapping := bleve.NewIndexMapping()
+
+	if err := mapping.AddCustomTokenFilter(unicodeNormalizeName, map[string]any{
+		"type": unicodenorm.Name,
+		"form": unicodenorm.NFC,
+	}); err != nil {
+		return nil, err
+	} else if err := mapping.AddCustomAnalyzer(wikiIndexerAnalyzer, map[string]any{
+		"type":          analyzer_custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     unicode.Name,
+		"token_filters": append([]string{unicodeNormalizeName, lowercase.Name}, inner_bleve.CJKTokenFilters()...),
+	}); err != nil {
+		return nil, err
+	}
+
+	mapping.DefaultAnalyzer = wikiIndexerAnalyzer
+	mapping.AddDocumentMapping(wikiIndexerDocType, docMapping)
+	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
+
+	return mapping, nil
+}
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer represents a bleve indexer implementation
+type Indexer struct {
+	inner                    *inner_bleve.Indexer
+	indexer_internal.Indexer // do not composite inner_bleve.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new bleve local indexer
+func NewIndexer(indexDir string) *Indexer {
+	inner := inner_bleve.NewIndexer(indexDir, wikiIndexerLatestVersion, generateBleveIndexMapping)
+	return &Indexer{
+		Indexer: inner,
+		inner:   inner,
+	}
+}
+
+func (b *Indexer) deleteRepo(repoID int64, batch *inner_bleve.FlushingBatch) error {
+	query := inner_bleve.NumericEqualityQuery(repoID, "RepoID")
+	searchRequest := bleve.NewSearchRequestOptions(query, 2147483647, 0, false)
+	result, err := b.inner.Indexer.Search(searchRequest)
+	if err != nil {
+		return err
+	}
+	for _, hit := range result.Hits {
+		if err = batch.Delete(hit.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Index replaces the indexed pages of the wiki
+func (b *Indexer) Index(_ context.Context, repo *repo_model.Repository, sha string, pages []*internal.Page) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	if err := b.deleteRepo(repo.ID, batch); err != nil {
+		return err
+	}
+	for _, page := range pages {
+		if err := batch.Index(internal.PageIndexerID(repo.ID, page.Filename), &WikiIndexerData{
+			RepoID:    repo.ID,
+			CommitID:  sha,
+			Title:     internal.PageTitle(page.Filename),
+			Content:   page.Content,
+			UpdatedAt: time.Now().UTC(),
+		}); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+// Delete deletes indexes by ids
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
+	if err := b.deleteRepo(repoID, batch); err != nil {
+		return err
+	}
+	return batch.Flush()
+}
+
+// Search searches for wiki pages in the specified repos.
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, error) {
+	var (
+		titleQuery   query.Query
+		contentQuery query.Query
+	)
+
+	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+	if searchMode == indexer.SearchModeExact {
+		tq := bleve.NewMatchPhraseQuery(opts.Keyword)
+		tq.FieldVal = "Title"
+		tq.Analyzer = wikiIndexerAnalyzer
+		tq.SetBoost(10)
+		titleQuery = tq
+
+		cq := bleve.NewMatchPhraseQuery(opts.Keyword)
+		cq.FieldVal = "Content"
+		cq.Analyzer = wikiIndexerAnalyzer
+		contentQuery = cq
+	} else /* words */ {
+		tq := bleve.NewMatchQuery(opts.Keyword)
+		tq.FieldVal = "Title"
+		tq.Analyzer = wikiIndexerAnalyzer
+		tq.Operator = query.MatchQueryOperatorAnd
+		tq.SetBoost(10)
+		titleQuery = tq
+
+		cq := bleve.NewMatchQuery(opts.Keyword)
+		cq.FieldVal = "Content"
+		cq.Analyzer = wikiIndexerAnalyzer
+		if searchMode == indexer.SearchModeFuzzy {
+			cq.Fuzziness = inner_bleve.GuessFuzzinessByKeyword(opts.Keyword)
+		} else {
+			cq.Operator = query.MatchQueryOperatorAnd
+		}
+		contentQuery = cq
+	}
+
+	var indexerQuery query.Query = bleve.NewDisjunctionQuery(titleQuery, contentQuery)
+	if len(opts.RepoIDs) > 0 {
+		repoQueries := make([]query.Query, 0, len(opts.RepoIDs))
+		for _, repoID := range opts.RepoIDs {
+			repoQueries = append(repoQueries, inner_bleve.NumericEqualityQuery(repoID, "RepoID"))
+		}
+		indexerQuery = bleve.NewConjunctionQuery(bleve.NewDisjunctionQuery(repoQueries...), indexerQuery)
+	}
+
+	from, pageSize := opts.GetSkipTake()
+	searchRequest := bleve.NewSearchRequestOptions(indexerQuery, pageSize, from, false)
+	searchRequest.Fields = []string{"Content", "RepoID", "CommitID", "UpdatedAt"}
+	searchRequest.IncludeLocations = true
+	searchRequest.SortBy([]string{"-_score", "UpdatedAt"})
+
+	result, err := b.inner.Indexer.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	searchResults := make([]*internal.SearchResult, len(result.Hits))
+	for i, hit := range result.Hits {
+		// the pages whose only title matched have no highlighted content
+		startIndex, endIndex := -1, -1
+		for _, locations := range hit.Locations["Content"] {
+			location := locations[0]
+			locationStart := int(location.Start)
+			locationEnd := int(location.End)
+			if startIndex < 0 || locationStart < startIndex {
+				startIndex = locationStart
+			}
+			if endIndex < 0 || locationEnd > endIndex {
+				endIndex = locationEnd
+			}
+		}
+
+		var updatedUnix timeutil.TimeStamp
+		if t, err := time.Parse(time.RFC3339, hit.Fields["UpdatedAt"].(string)); err == nil {
+			updatedUnix = timeutil.TimeStamp(t.Unix())
+		}
+		_, filename := internal.ParseIndexerID(hit.ID)
+		searchResults[i] = &internal.SearchResult{
+			RepoID:      int64(hit.Fields["RepoID"].(float64)),
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+			Filename:    filename,
+			Content:     hit.Fields["Content"].(string),
+			CommitID:    hit.Fields["CommitID"].(string),
+			UpdatedUnix: updatedUnix,
+		}
+	}
+	return int64(result.Total), searchResults, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package elasticsearch
+
+import (
+	"context"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_elasticsearch "code.gitea.io/gitea/modules/indexer/internal/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	esWikiIndexerLatestVersion = 1
+	esBatchSize                = 50
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_elasticsearch.Indexer
+	indexer_internal.Indexer // do not composite inner_elasticsearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new elasticsearch indexer
+func NewIndexer(url, indexerName string) *Indexer {
+	inner := inner_elasticsearch.NewIndexer(url, indexerName, esWikiIndexerLatestVersion, defaultMapping)
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+}
+
+const (
+	defaultMapping = `{
+		"mappings": {
+			"properties": {
+				"repo_id": {
+					"type": "long",
+					"index": true
+				},
+				"title": {
+					"type": "text",
+					"index": true
+				},
+				"content": {
+					"type": "text",
+					"term_vector": "with_positions_offsets",
+					"index": true
+				},
+				"commit_id": {
+					"type": "keyword",
+					"index": true
+				},
+				"updated_at": {
+					"type": "long",
+					"index": true
+				}
+			}
+		}
+	}`
+)
+
+// Index replaces the indexed pages of the wiki
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, pages []*internal.Page) error {
+	if err := b.Delete(ctx, repo.ID); err != nil {
+		return err
+	}
+
+	reqs := make([]elastic.BulkableRequest, 0, len(pages))
+	for _, page := range pages {
+		reqs = append(reqs, elastic.NewBulkIndexRequest().
+			Index(b.inner.VersionedIndexName()).
+			Id(internal.PageIndexerID(repo.ID, page.Filename)).
+			Doc(map[string]any{
+				"repo_id":    repo.ID,
+				"title":      internal.PageTitle(page.Filename),
+				"content":    page.Content,
+				"commit_id":  sha,
+				"updated_at": timeutil.TimeStampNow(),
+			}))
+	}
+	for i := 0; i < len(reqs); i += esBatchSize {
+		if _, err := b.inner.Client.Bulk().
+			Index(b.inner.VersionedIndexName()).
+			Add(reqs[i:min(i+esBatchSize, len(reqs))]...).
+			Do(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete entries by repoId
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	_, err := b.inner.Client.DeleteByQuery(b.inner.VersionedIndexName()).
+		Query(elastic.NewTermsQuery("repo_id", repoID)).
+		Refresh("true").
+		Do(ctx)
+	return err
+}
+
+// contentMatchIndexPos returns the position of the first highlighted keyword in the content,
+// or -1, -1 if there is none.
+func contentMatchIndexPos(highlighted string) (int, int) {
+	startIdx := strings.Index(highlighted, "<em>")
+	if startIdx < 0 {
+		return -1, -1
+	}
+	endIdx := strings.Index(highlighted[startIdx:], "</em>")
+	if endIdx < 0 {
+		return -1, -1
+	}
+	return startIdx, startIdx + endIdx - len("<em>")
+}
+
+// Search searches for wiki pages by given conditions.
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, error) {
+	var contentQuery, titleQuery elastic.Query
+	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+	if searchMode == indexer.SearchModeExact {
+		contentQuery = elastic.NewMatchPhraseQuery("content", opts.Keyword)
+		titleQuery = elastic.NewMatchPhraseQuery("title", opts.Keyword).Boost(10)
+	} else /* words */ {
+		contentQuery = elastic.NewMatchQuery("content", opts.Keyword).Operator("and")
+		titleQuery = elastic.NewMatchQuery("title", opts.Keyword).Operator("and").Boost(10)
+	}
+	query := elastic.NewBoolQuery().Must(elastic.NewBoolQuery().Should(contentQuery, titleQuery))
+	if len(opts.RepoIDs) > 0 {
+		repoIDs := make([]any, 0, len(opts.RepoIDs))
+		for _, repoID := range opts.RepoIDs {
+			repoIDs = append(repoIDs, repoID)
+		}
+		query = query.Must(elastic.NewTermsQuery("repo_id", repoIDs...))
+	}
+
+	start, pageSize := opts.GetSkipTake()
+	searchResult, err := b.inner.Client.Search().
+		Index(b.inner.VersionedIndexName()).
+		Query(query).
+		Highlight(
+			elastic.NewHighlight().
+				Field("content").
+				NumOfFragments(0). // return the whole content, so the positions of the keywords are the ones in the page
+				HighlighterType("fvh"),
+		).
+		Sort("_score", false).
+		Sort("updated_at", true).
+		From(start).Size(pageSize).
+		Do(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	hits := make([]*internal.SearchResult, 0, pageSize)
+	for _, hit := range searchResult.Hits.Hits {
+		repoID, filename := internal.ParseIndexerID(hit.Id)
+		var res struct {
+			Content   string `json:"content"`
+			CommitID  string `json:"commit_id"`
+			UpdatedAt int64  `json:"updated_at"`
+		}
+		if err := json.Unmarshal(hit.Source, &res); err != nil {
+			return 0, nil, err
+		}
+
+		startIndex, endIndex := -1, -1
+		if c, ok := hit.Highlight["content"]; ok && len(c) > 0 {
+			startIndex, endIndex = contentMatchIndexPos(c[0])
+		}
+		hits = append(hits, &internal.SearchResult{
+			RepoID:      repoID,
+			Filename:    filename,
+			Content:     res.Content,
+			CommitID:    res.CommitID,
+			UpdatedUnix: timeutil.TimeStamp(res.UpdatedAt),
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+		})
+	}
+	return searchResult.TotalHits(), hits, nil
+}
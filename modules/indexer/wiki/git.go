@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// getWikiHeadSha returns the head of the default branch of a repository's wiki, or an empty string if the wiki has no page
+func getWikiHeadSha(ctx context.Context, repo *repo_model.Repository) (string, error) {
+	exist, err := gitrepo.IsRepositoryExist(ctx, repo.WikiStorageRepo())
+	if err != nil || !exist {
+		return "", err
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo.WikiStorageRepo())
+	if err != nil {
+		return "", err
+	}
+	defer gitRepo.Close()
+
+	sha, err := gitRepo.GetBranchCommitID(repo.DefaultWikiBranch)
+	if git.IsErrNotExist(err) || git.IsErrBranchNotExist(err) {
+		return "", nil
+	}
+	return sha, err
+}
+
+// getWikiPages returns the pages of a repository's wiki at a commit, the pages larger than the maximum indexed file size are skipped
+func getWikiPages(ctx context.Context, repo *repo_model.Repository, sha string) ([]*internal.Page, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo.WikiStorageRepo())
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(sha)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := commit.Tree.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, err
+	}
+
+	pages := make([]*internal.Page, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsRegular() || !strings.HasSuffix(entry.Name(), ".md") || entry.Size() > setting.Indexer.MaxIndexerFileSize {
+			continue
+		}
+		content, err := entry.Blob().GetBlobContent(setting.Indexer.MaxIndexerFileSize)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, &internal.Page{
+			Filename: entry.Name(),
+			Content:  string(charset.ToUTF8DropErrors([]byte(content), charset.ConvertOpts{})),
+		})
+	}
+	return pages, nil
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/wiki/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/indexer/wiki/meilisearch"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[*internal.IndexerData]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if repo_model.IsErrRepoNotExist(err) {
+		return indexer.Delete(ctx, repoID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the pages of a disabled or deleted wiki are removed from the index
+	sha := ""
+	if repo.UnitEnabled(ctx, unit.TypeWiki) {
+		if sha, err = getWikiHeadSha(ctx, repo); err != nil {
+			return err
+		}
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki)
+	if err != nil {
+		return err
+	}
+	if sha == "" {
+		if status.CommitSha == "" {
+			return nil
+		}
+		if err := indexer.Delete(ctx, repoID); err != nil {
+			return err
+		}
+		return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki, sha)
+	}
+	if status.CommitSha == sha {
+		return nil
+	}
+
+	pages, err := getWikiPages(ctx, repo, sha)
+	if err != nil {
+		return err
+	}
+	if err := indexer.Index(ctx, repo, sha, pages); err != nil {
+		return err
+	}
+
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki, sha)
+}
+
+// Init initialize the wiki indexer
+func Init() {
+	if !setting.Indexer.WikiIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: WikiIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing wiki indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Wiki Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.RepoType {
+	case "bleve", "elasticsearch", "meilisearch":
+		handler := func(items ...*internal.IndexerData) (unhandled []*internal.IndexerData) {
+			indexer := *globalIndexer.Load()
+			for _, indexerData := range items {
+				log.Trace("IndexerData Process Wiki: %d", indexerData.RepoID)
+				if err := index(ctx, indexer, indexerData.RepoID); err != nil {
+					if !setting.IsInTesting {
+						log.Error("Wikis indexer handler: index error for repo %v: %v", indexerData.RepoID, err)
+					}
+					if err := repo_model.UpdateIndexerFailure(ctx, indexerData.RepoID, repo_model.RepoIndexerTypeWiki, err); err != nil {
+						log.Error("Wikis indexer handler: unable to record the failure of repo %v: %v", indexerData.RepoID, err)
+					}
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken wiki will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "wiki_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create wikis indexer queue")
+		}
+	default:
+		log.Fatal("Unknown wikis indexer type; %s", setting.Indexer.RepoType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			wIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.RepoType {
+		case "bleve":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.WikiPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.WikiPath)
+				}
+			}()
+
+			wIndexer = bleve.NewIndexer(setting.Indexer.WikiPath)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the bleve Wiki Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.WikiPath, err)
+			}
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.RepoConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.WikiIndexerName)
+				}
+			}()
+
+			wIndexer = elasticsearch.NewIndexer(setting.Indexer.RepoConnStr, setting.Indexer.WikiIndexerName)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the elasticsearch Wiki Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.RepoConnStr, err)
+			}
+		case "meilisearch":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.RepoConnStr)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" index to make Gitea recreate the indexes", setting.Indexer.WikiIndexerName)
+				}
+			}()
+
+			wIndexer = meilisearch.NewIndexer(setting.Indexer.RepoConnStr, setting.Indexer.RepoConnAuth, setting.Indexer.WikiIndexerName)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the meilisearch Wiki Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.RepoConnStr, err)
+			}
+
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.RepoType)
+		}
+
+		globalIndexer.Store(&wIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populateWikiIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Wiki Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Wiki Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Wiki Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Wiki Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+// UpdateRepoIndexer update a repository's wiki pages in the indexer
+func UpdateRepoIndexer(repo *repo_model.Repository) {
+	indexData := &internal.IndexerData{RepoID: repo.ID}
+	if err := indexerQueue.Push(indexData); err != nil {
+		log.Error("Update wiki index data %v failed: %v", indexData, err)
+	}
+}
+
+// ReindexRepository removes the wiki pages of the repository from the index and queues them to be indexed from scratch
+func ReindexRepository(ctx context.Context, repo *repo_model.Repository) error {
+	if indexerQueue == nil {
+		return errors.New("the wiki indexer is not enabled")
+	}
+	if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		return err
+	}
+	if err := repo_model.DeleteIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki); err != nil {
+		return err
+	}
+	if err := indexerQueue.Push(&internal.IndexerData{RepoID: repo.ID}); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		return err
+	}
+	return nil
+}
+
+// IsAvailable checks if wiki indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// populateWikiIndexer populate the wiki indexer with pre-existing data. This
+// should only be run when the indexer is created for the first time.
+func populateWikiIndexer(ctx context.Context) {
+	log.Info("Populating the wiki indexer with existing repositories")
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	// the statuses of the wikis are for a previous index, so they are reset
+	if _, err := db.GetEngine(ctx).Where("indexer_type = ?", repo_model.RepoIndexerTypeWiki).Delete(new(repo_model.RepoIndexerStatus)); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	var maxRepoID int64
+	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, so that we
+	// don't include repos that are created after gitea starts; such repos will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxRepoID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Wiki Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeWiki, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateWikiIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				log.Info("Wiki Indexer population shutdown before completion")
+				return
+			default:
+			}
+			if err := indexerQueue.Push(&internal.IndexerData{RepoID: id}); err != nil {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done (re)populating the wiki indexer with existing repositories")
+}
+
+func SupportedSearchModes() []indexer.SearchMode {
+	return (*globalIndexer.Load()).SupportedSearchModes()
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"html/template"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/indexer/wiki/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+	_ "code.gitea.io/gitea/models/activities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestBleveIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	idx := bleve.NewIndexer(t.TempDir())
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	var indexer internal.Indexer = idx
+	defer globalIndexer.Store(globalIndexer.Swap(&indexer))
+
+	require.NoError(t, index(t.Context(), idx, 1))
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeWiki)
+	require.NoError(t, err)
+	assert.NotEmpty(t, status.CommitSha)
+	// the repositories without wiki have no status
+	require.NoError(t, index(t.Context(), idx, 2))
+	unittest.AssertNotExistsBean(t, &repo_model.RepoIndexerStatus{RepoID: 2, IndexerType: repo_model.RepoIndexerTypeWiki})
+
+	search := func(repoIDs []int64, keyword string) []*Result {
+		total, results, err := PerformSearch(t.Context(), &SearchOptions{
+			RepoIDs:   repoIDs,
+			Keyword:   keyword,
+			Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, total)
+		return results
+	}
+
+	results := search(nil, "spaces")
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(1), results[0].RepoID)
+	assert.Equal(t, "Page-With-Spaced-Name.md", results[0].Filename)
+	assert.Equal(t, status.CommitSha, results[0].CommitID)
+	assert.Equal(t, template.HTML("# Page With Spaced Name\n\nThis is a page with a name with <mark>spaces</mark> (which will be replaced with &#39;-&#39;)\n"), results[0].Snippet)
+
+	// the titles of the pages are searched too
+	results = search([]int64{1}, "image")
+	require.Len(t, results, 1)
+	assert.Equal(t, "Page-With-Image.md", results[0].Filename)
+
+	assert.Len(t, search([]int64{1}, "home"), 2)
+	assert.Empty(t, search([]int64{2}, "home"))
+	assert.Empty(t, search(nil, "not-exist"))
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, template.HTML("a &lt;b&gt; <mark>c</mark> d"), snippet("a <b> c d", 6, 7))
+	// the content is shown from its beginning if the keyword matched the title
+	assert.Equal(t, template.HTML("a &lt;b&gt; c d"), snippet("a <b> c d", -1, -1))
+
+	long := ""
+	for range 100 {
+		long += "word "
+	}
+	s := string(snippet(long+"keyword "+long, 500, 507))
+	assert.Contains(t, s, "<mark>keyword</mark>")
+	assert.True(t, len(s) < 2*snippetContextLength+len("<mark>keyword</mark>")+2*len("…"))
+	assert.Equal(t, "…word", s[:len("…word")])
+}
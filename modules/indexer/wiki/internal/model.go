@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import "code.gitea.io/gitea/modules/timeutil"
+
+// Page is a page of a wiki to be indexed
+type Page struct {
+	Filename string
+	Content  string
+}
+
+// IndexerData represents data stored in the wiki indexer
+type IndexerData struct {
+	RepoID int64
+}
+
+// SearchResult result of performing a search in the wikis
+type SearchResult struct {
+	RepoID      int64
+	StartIndex  int
+	EndIndex    int
+	Filename    string
+	Content     string
+	CommitID    string
+	UpdatedUnix timeutil.TimeStamp
+}
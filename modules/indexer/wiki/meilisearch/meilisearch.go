@@ -0,0 +1,180 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_meilisearch "code.gitea.io/gitea/modules/indexer/internal/meilisearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	meilisearchWikiIndexerLatestVersion = 1
+
+	// TODO: make this configurable if necessary
+	maxTotalHits = 10000
+	batchSize    = 50
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_meilisearch.Indexer
+	indexer_internal.Indexer // do not composite inner_meilisearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new meilisearch indexer
+func NewIndexer(url, apiKey, indexerName string) *Indexer {
+	settings := &meilisearch.Settings{
+		// the titles are listed first, so the matches of the titles are ranked higher
+		SearchableAttributes: []string{
+			"title",
+			"content",
+		},
+		FilterableAttributes: []string{
+			"repo_id",
+		},
+		Pagination: &meilisearch.Pagination{
+			MaxTotalHits: maxTotalHits,
+		},
+	}
+
+	inner := inner_meilisearch.NewIndexer(url, apiKey, indexerName, meilisearchWikiIndexerLatestVersion, settings)
+	return &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+}
+
+// document is a wiki page stored in the index
+type document struct {
+	ID        string `json:"id"`
+	RepoID    int64  `json:"repo_id"`
+	Filename  string `json:"filename"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	CommitID  string `json:"commit_id"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// documentID returns the id of the document of a page, the ids of meilisearch can only contain
+// alphanumeric characters, hyphens and underscores, so the file name is hashed
+func documentID(repoID int64, filename string) string {
+	sum := sha256.Sum256([]byte(filename))
+	return indexer_internal.Base36(repoID) + "_" + hex.EncodeToString(sum[:])
+}
+
+// Index replaces the indexed pages of the wiki
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, pages []*internal.Page) error {
+	if err := b.Delete(ctx, repo.ID); err != nil {
+		return err
+	}
+
+	docs := make([]*document, 0, len(pages))
+	for _, page := range pages {
+		docs = append(docs, &document{
+			ID:        documentID(repo.ID, page.Filename),
+			RepoID:    repo.ID,
+			Filename:  page.Filename,
+			Title:     internal.PageTitle(page.Filename),
+			Content:   page.Content,
+			CommitID:  sha,
+			UpdatedAt: int64(timeutil.TimeStampNow()),
+		})
+	}
+
+	index := b.inner.Client.Index(b.inner.VersionedIndexName())
+	for i := 0; i < len(docs); i += batchSize {
+		task, err := index.AddDocumentsWithContext(ctx, docs[i:min(i+batchSize, len(docs))], nil)
+		if err != nil {
+			return err
+		}
+		if err := b.inner.WaitForTask(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete entries by repoId
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	task, err := b.inner.Client.Index(b.inner.VersionedIndexName()).
+		DeleteDocumentsByFilterWithContext(ctx, inner_meilisearch.NewFilterEq("repo_id", repoID).Statement())
+	if err != nil {
+		return err
+	}
+	return b.inner.WaitForTask(ctx, task)
+}
+
+type hit struct {
+	document
+	MatchesPosition map[string][]struct {
+		Start  int `json:"start"`
+		Length int `json:"length"`
+	} `json:"_matchesPosition"`
+}
+
+// Search searches for wiki pages by given conditions.
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, error) {
+	keyword := opts.Keyword
+	if util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue) == indexer.SearchModeExact {
+		// https://www.meilisearch.com/docs/reference/api/search#phrase-search
+		keyword = `"` + strings.ReplaceAll(keyword, `"`, "") + `"`
+	}
+
+	query := inner_meilisearch.FilterAnd{}
+	if len(opts.RepoIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("repo_id", opts.RepoIDs...))
+	}
+
+	start, pageSize := opts.GetSkipTake()
+	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).SearchWithContext(ctx, keyword, &meilisearch.SearchRequest{
+		Filter:              query.Statement(),
+		Limit:               int64(pageSize),
+		Offset:              int64(start),
+		ShowMatchesPosition: true,
+		MatchingStrategy:    "all",
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var docs []*hit
+	if err := searchRes.Hits.Decode(&docs); err != nil {
+		return 0, nil, err
+	}
+	hits := make([]*internal.SearchResult, 0, len(docs))
+	for _, doc := range docs {
+		startIndex, endIndex := -1, -1
+		if pos := doc.MatchesPosition["content"]; len(pos) > 0 && pos[0].Start+pos[0].Length <= len(doc.Content) {
+			startIndex, endIndex = pos[0].Start, pos[0].Start+pos[0].Length
+		}
+		hits = append(hits, &internal.SearchResult{
+			RepoID:      doc.RepoID,
+			Filename:    doc.Filename,
+			Content:     doc.Content,
+			CommitID:    doc.CommitID,
+			UpdatedUnix: timeutil.TimeStamp(doc.UpdatedAt),
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+		})
+	}
+	return searchRes.EstimatedTotalHits, hits, nil
+}
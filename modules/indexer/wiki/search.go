@@ -0,0 +1,109 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"html/template"
+	"strings"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// snippetContextLength is the number of bytes of the page shown around the matched keyword
+const snippetContextLength = 160
+
+// Result a search result to display
+type Result struct {
+	RepoID      int64
+	Filename    string
+	CommitID    string
+	UpdatedUnix timeutil.TimeStamp
+	// Snippet is an excerpt of the page with the matched keyword highlighted
+	Snippet template.HTML
+}
+
+type SearchOptions = internal.SearchOptions
+
+// alignStart and alignEnd move an index of the content to the beginning of a UTF-8 character
+func alignStart(content string, i int) int {
+	for i > 0 && i < len(content) && !utf8.RuneStart(content[i]) {
+		i--
+	}
+	return i
+}
+
+func alignEnd(content string, i int) int {
+	for i < len(content) && !utf8.RuneStart(content[i]) {
+		i++
+	}
+	return i
+}
+
+// snippet returns an excerpt of the content around the range of the matched keyword, the keyword is wrapped in a mark element.
+// The beginning of the content is returned if no keyword has been matched.
+func snippet(content string, startIndex, endIndex int) template.HTML {
+	if startIndex < 0 || endIndex > len(content) || startIndex >= endIndex {
+		end := alignEnd(content, min(len(content), 2*snippetContextLength))
+		s := template.HTMLEscapeString(content[:end])
+		if end < len(content) {
+			s += "…"
+		}
+		return template.HTML(s)
+	}
+
+	from := alignStart(content, max(0, startIndex-snippetContextLength))
+	to := alignEnd(content, min(len(content), endIndex+snippetContextLength))
+	// the excerpt starts and ends on word boundaries
+	if from > 0 {
+		if i := strings.IndexAny(content[from:startIndex], " \n"); i >= 0 {
+			from += i + 1
+		}
+	}
+	if to < len(content) {
+		if i := strings.LastIndexAny(content[endIndex:to], " \n"); i >= 0 {
+			to = endIndex + i
+		}
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+	sb.WriteString(template.HTMLEscapeString(content[from:startIndex]))
+	sb.WriteString("<mark>")
+	sb.WriteString(template.HTMLEscapeString(content[startIndex:endIndex]))
+	sb.WriteString("</mark>")
+	sb.WriteString(template.HTMLEscapeString(content[endIndex:to]))
+	if to < len(content) {
+		sb.WriteString("…")
+	}
+	return template.HTML(sb.String())
+}
+
+// PerformSearch perform a search in the wikis
+func PerformSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, error) {
+	if opts == nil || len(opts.Keyword) == 0 {
+		return 0, nil, nil
+	}
+
+	total, results, err := (*globalIndexer.Load()).Search(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	displayResults := make([]*Result, len(results))
+	for i, result := range results {
+		displayResults[i] = &Result{
+			RepoID:      result.RepoID,
+			Filename:    result.Filename,
+			CommitID:    result.CommitID,
+			UpdatedUnix: result.UpdatedUnix,
+			Snippet:     snippet(result.Content, result.StartIndex, result.EndIndex),
+		}
+	}
+	return int(total), displayResults, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ValidateValues checks the values submitted for a form template against the validations of its fields,
+// and returns the first invalid value as an invalid argument error
+func ValidateValues(template *api.IssueTemplate, values url.Values) error {
+	for _, field := range template.Fields {
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		if f.ID == "" || f.Type == api.IssueFormFieldTypeMarkdown {
+			continue
+		}
+		if err := f.validateValue(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *valuedField) name() string {
+	if label := f.Label(); label != "" {
+		return label
+	}
+	return f.ID
+}
+
+func (f *valuedField) isRequired() bool {
+	required, _ := f.Validations["required"].(bool)
+	return required && f.VisibleOnForm()
+}
+
+func (f *valuedField) validateValue() error {
+	switch f.Type {
+	case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+		value := f.Value()
+		if value == "" {
+			if f.isRequired() {
+				return util.NewInvalidArgumentErrorf("%q is required", f.name())
+			}
+			return nil
+		}
+		if f.Type == api.IssueFormFieldTypeTextarea {
+			return nil
+		}
+		if isNumber, _ := f.Validations["is_number"].(bool); isNumber {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return util.NewInvalidArgumentErrorf("%q should be a number", f.name())
+			}
+		}
+		if pattern, _ := f.Validations["regex"].(string); pattern != "" {
+			// like the pattern of the input element of the form, the regular expression must match the whole value,
+			// and a regular expression which isn't supported here is only checked by the browser
+			if re, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil && !re.MatchString(value) {
+				return util.NewInvalidArgumentErrorf("%q should match %s", f.name(), pattern)
+			}
+		}
+	case api.IssueFormFieldTypeDropdown:
+		options := f.Options()
+		var checked int
+		for _, idx := range strings.Split(f.Get("form-field-"+f.ID), ",") {
+			if idx == "" {
+				continue
+			}
+			if i, err := strconv.Atoi(idx); err != nil || i < 0 || i >= len(options) {
+				return util.NewInvalidArgumentErrorf("%q has an unknown option: %s", f.name(), idx)
+			}
+			checked++
+		}
+		if multiple, _ := f.Attributes["multiple"].(bool); !multiple && checked > 1 {
+			return util.NewInvalidArgumentErrorf("%q accepts only one option", f.name())
+		}
+		if checked == 0 && f.isRequired() {
+			return util.NewInvalidArgumentErrorf("%q is required", f.name())
+		}
+	case api.IssueFormFieldTypeCheckboxes:
+		for _, option := range f.Options() {
+			if option.isRequired() && !option.IsChecked() {
+				return util.NewInvalidArgumentErrorf("%q of %q is required", option.Label(), f.name())
+			}
+		}
+	}
+	return nil
+}
+
+func (o *valuedOption) isRequired() bool {
+	if vs, ok := o.data.(map[string]any); ok {
+		required, _ := vs["required"].(bool)
+		return required
+	}
+	return false
+}
+
+// ValuesFromAPI converts the values of the fields of a form template given by field ID in an API request
+// to the values submitted by the form: an input or a textarea has one value, the values of a dropdown or
+// checkboxes are the labels of the selected options. The omitted fields get their default values.
+func ValuesFromAPI(template *api.IssueTemplate, apiValues map[string][]string) (url.Values, error) {
+	values := url.Values{}
+	known := make(map[string]bool, len(template.Fields))
+	for _, field := range template.Fields {
+		if field.ID == "" || field.Type == api.IssueFormFieldTypeMarkdown {
+			continue
+		}
+		known[field.ID] = true
+		f := &valuedField{IssueFormField: field, Values: values}
+
+		fieldValues, ok := apiValues[field.ID]
+		if !ok {
+			switch field.Type {
+			case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+				if value, ok := field.Attributes["value"].(string); ok {
+					values.Set("form-field-"+field.ID, value)
+				}
+			case api.IssueFormFieldTypeDropdown:
+				if defaultIdx, ok := field.Attributes["default"].(int); ok {
+					values.Set("form-field-"+field.ID, strconv.Itoa(defaultIdx))
+				}
+			}
+			continue
+		}
+
+		switch field.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			if len(fieldValues) > 1 {
+				return nil, util.NewInvalidArgumentErrorf("%q accepts only one value", f.name())
+			}
+			if len(fieldValues) == 1 {
+				values.Set("form-field-"+field.ID, fieldValues[0])
+			}
+		case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+			options := f.Options()
+			indexes := make([]string, 0, len(fieldValues))
+			for _, value := range fieldValues {
+				idx := slices.IndexFunc(options, func(o *valuedOption) bool { return o.Label() == value })
+				if idx < 0 {
+					return nil, util.NewInvalidArgumentErrorf("%q has no option %q", f.name(), value)
+				}
+				if field.Type == api.IssueFormFieldTypeCheckboxes {
+					values.Set("form-field-"+field.ID+"-"+strconv.Itoa(idx), "on")
+				} else {
+					indexes = append(indexes, strconv.Itoa(idx))
+				}
+			}
+			if field.Type == api.IssueFormFieldTypeDropdown {
+				values.Set("form-field-"+field.ID, strings.Join(indexes, ","))
+			}
+		}
+	}
+
+	for id := range apiValues {
+		if !known[id] {
+			return nil, util.NewInvalidArgumentErrorf("unknown field %q", id)
+		}
+	}
+	return values, nil
+}
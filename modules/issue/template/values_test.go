@@ -0,0 +1,158 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"net/url"
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const valuesTestTemplate = `
+name: Bug report
+about: Report a bug
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for reporting
+  - type: input
+    id: version
+    attributes:
+      label: Version
+      value: "1.0"
+    validations:
+      required: true
+      regex: "[0-9]+\\.[0-9]+"
+  - type: input
+    id: count
+    attributes:
+      label: Count
+    validations:
+      is_number: true
+  - type: textarea
+    id: logs
+    attributes:
+      label: Logs
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      options: [Linux, macOS, Windows]
+    validations:
+      required: true
+  - type: dropdown
+    id: browsers
+    attributes:
+      label: Browsers
+      multiple: true
+      options: [Firefox, Chrome]
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I searched the existing issues
+          required: true
+        - label: I want to fix it
+`
+
+func TestValidateValues(t *testing.T) {
+	template, err := Unmarshal("bug.yaml", []byte(valuesTestTemplate))
+	require.NoError(t, err)
+	require.NoError(t, Validate(template))
+
+	valid := func() url.Values {
+		return url.Values{
+			"form-field-version":  {"1.22"},
+			"form-field-os":       {"0"},
+			"form-field-terms-0":  {"on"},
+			"form-field-browsers": {"0,1"},
+		}
+	}
+	require.NoError(t, ValidateValues(template, valid()))
+
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"required input", "form-field-version", " ", `"Version" is required`},
+		{"regex", "form-field-version", "1.22-rc", `"Version" should match [0-9]+\.[0-9]+`},
+		{"number", "form-field-count", "ten", `"Count" should be a number`},
+		{"required dropdown", "form-field-os", "", `"OS" is required`},
+		{"unknown option", "form-field-os", "3", `"OS" has an unknown option: 3`},
+		{"single option", "form-field-os", "0,1", `"OS" accepts only one option`},
+		{"required checkbox", "form-field-terms-0", "", `"I searched the existing issues" of "Terms" is required`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := valid()
+			values.Set(tt.key, tt.value)
+			err := ValidateValues(template, values)
+			require.ErrorIs(t, err, util.ErrInvalidArgument)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValuesFromAPI(t *testing.T) {
+	template, err := Unmarshal("bug.yaml", []byte(valuesTestTemplate))
+	require.NoError(t, err)
+
+	values, err := ValuesFromAPI(template, map[string][]string{
+		"os":       {"Windows"},
+		"browsers": {"Chrome", "Firefox"},
+		"terms":    {"I searched the existing issues"},
+		"logs":     {"panic"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"form-field-version":  {"1.0"},
+		"form-field-os":       {"2"},
+		"form-field-browsers": {"1,0"},
+		"form-field-terms-0":  {"on"},
+		"form-field-logs":     {"panic"},
+	}, values)
+	require.NoError(t, ValidateValues(template, values))
+	assert.Equal(t, `### Version
+
+1.0
+
+### Count
+
+_No response_
+
+### Logs
+
+panic
+
+### OS
+
+Windows
+
+### Browsers
+
+Firefox, Chrome
+
+### Terms
+
+- [x] I searched the existing issues
+- [ ] I want to fix it
+
+`, RenderToMarkdown(template, values))
+
+	for _, apiValues := range []map[string][]string{
+		{"unknown": {"value"}},
+		{"os": {"BSD"}},
+		{"version": {"1.0", "2.0"}},
+	} {
+		_, err := ValuesFromAPI(template, apiValues)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	}
+}
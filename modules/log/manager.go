@@ -123,6 +123,27 @@ func (m *LoggerManager) NewSharedWriter(writerName, writerType string, mode Writ
 	return writer, nil
 }
 
+// SetWriterLevels changes the levels of the writers of all loggers without recreating them,
+// the levels are keyed by the writer names and the writers which are not in the map are not changed
+func (m *LoggerManager) SetWriterLevels(levels map[string]Level) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// a shared writer is used by several loggers, so all loggers must be locked before changing the writers
+	for _, logger := range m.loggers {
+		logger.eventWriterMu.Lock()
+		defer logger.eventWriterMu.Unlock()
+	}
+	for _, logger := range m.loggers {
+		for name, w := range logger.eventWriters {
+			if level, ok := levels[name]; ok {
+				w.Base().Mode.Level = level // the level is only read by the loggers while they are locked
+			}
+		}
+		logger.syncLevelInternal()
+	}
+}
+
 func (m *LoggerManager) GetSharedWriter(writerName string) EventWriter {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -40,3 +40,25 @@ func TestSharedWorker(t *testing.T) {
 	logs := w.(*dummyWriter).FetchLogs()
 	assert.Equal(t, []string{"msg-1\n", "msg-2\n", "msg-3\n"}, logs)
 }
+
+func TestSetWriterLevels(t *testing.T) {
+	RegisterEventWriter("dummy", func(writerName string, writerMode WriterMode) EventWriter {
+		return newDummyWriter(writerName, writerMode.Level, 0)
+	})
+
+	m := NewManager()
+	defer m.Close()
+
+	shared, err := m.NewSharedWriter("shared", "dummy", WriterMode{Level: INFO, Flags: FlagsFromBits(0)})
+	assert.NoError(t, err)
+	own := newDummyWriter("own", ERROR, 0)
+	m.GetLogger("test").AddWriters(shared, own)
+	m.GetLogger("test-another").AddWriters(shared)
+	assert.Equal(t, INFO, m.GetLogger("test").GetLevel())
+
+	m.SetWriterLevels(map[string]Level{"shared": WARN, "own": DEBUG})
+	assert.Equal(t, WARN, shared.GetLevel())
+	assert.Equal(t, DEBUG, own.GetLevel())
+	assert.Equal(t, DEBUG, m.GetLogger("test").GetLevel())
+	assert.Equal(t, WARN, m.GetLogger("test-another").GetLevel())
+}
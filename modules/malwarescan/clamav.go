@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const clamAVChunkSize = 64 * 1024
+
+// ClamAV streams the files to a clamd daemon with the INSTREAM command (https://linux.die.net/man/8/clamd)
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a scanner for the clamd daemon at the "tcp://host:port" or "unix:///path/to/clamd.sock" address
+func NewClamAV(address string, timeout time.Duration) (*ClamAV, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "tcp":
+		return &ClamAV{network: "tcp", address: u.Host, timeout: timeout}, nil
+	case "unix":
+		return &ClamAV{network: "unix", address: u.Path, timeout: timeout}, nil
+	}
+	return nil, fmt.Errorf("unsupported clamd address %q", address)
+}
+
+// Scan sends the content to clamd and parses its verdict
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	// clamd stops reading and answers with an error if the stream exceeds its limits, the answer is more useful than the write error
+	if err := writeClamAVStream(conn, r); err != nil {
+		if _, respErr := readClamAVResponse(conn); respErr != nil {
+			return nil, fmt.Errorf("%w (%v)", respErr, err)
+		}
+		return nil, err
+	}
+	return readClamAVResponse(conn)
+}
+
+func writeClamAVStream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// a chunk of length zero ends the stream
+	_, err := w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// readClamAVResponse parses responses like "stream: OK", "stream: Eicar-Signature FOUND" or "... ERROR"
+func readClamAVResponse(r io.Reader) (*Result, error) {
+	line, err := bufio.NewReader(r).ReadString(0)
+	if err != nil && line == "" {
+		return nil, err
+	}
+	line = strings.TrimSpace(strings.TrimSuffix(line, "\x00"))
+	verdict := strings.TrimSpace(strings.TrimPrefix(line, "stream:"))
+
+	switch {
+	case verdict == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	}
+	return nil, fmt.Errorf("clamd: %s", line)
+}
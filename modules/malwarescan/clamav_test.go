@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeClamd accepts INSTREAM commands and reports every stream containing "EICAR" as infected
+func startFakeClamd(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+
+				br := bufio.NewReader(conn)
+				command, err := br.ReadString(0)
+				if err != nil || command != "zINSTREAM\x00" {
+					_, _ = io.WriteString(conn, "UNKNOWN COMMAND\x00")
+					return
+				}
+
+				var content bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&content, br, int64(size)); err != nil {
+						return
+					}
+				}
+
+				if strings.Contains(content.String(), "EICAR") {
+					_, _ = io.WriteString(conn, "stream: Eicar-Signature FOUND\x00")
+				} else {
+					_, _ = io.WriteString(conn, "stream: OK\x00")
+				}
+			}()
+		}
+	}()
+
+	return "tcp://" + l.Addr().String()
+}
+
+func TestClamAV(t *testing.T) {
+	c, err := NewClamAV(startFakeClamd(t), time.Minute)
+	require.NoError(t, err)
+
+	result, err := c.Scan(t.Context(), strings.NewReader("harmless"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	// the content is sent in multiple chunks
+	result, err = c.Scan(t.Context(), strings.NewReader(strings.Repeat("x", 3*clamAVChunkSize)+"EICAR"))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Eicar-Signature", result.Signature)
+
+	_, err = NewClamAV("http://localhost:3310", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestReadClamAVResponse(t *testing.T) {
+	result, err := readClamAVResponse(strings.NewReader("stream: OK\x00"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = readClamAVResponse(strings.NewReader("stream: Win.Test.EICAR_HDB-1 FOUND\x00"))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", result.Signature)
+
+	_, err = readClamAVResponse(strings.NewReader("INSTREAM size limit exceeded. ERROR\x00"))
+	assert.ErrorContains(t, err, "size limit exceeded")
+}
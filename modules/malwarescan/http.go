@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+)
+
+// HTTPScanner posts the files to a scanning service which answers with a JSON verdict
+type HTTPScanner struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+type httpScanResponse struct {
+	Infected  bool   `json:"infected"`
+	Signature string `json:"signature"`
+}
+
+// NewHTTPScanner creates a scanner for the service at the url, the token is sent as bearer token if it is set
+func NewHTTPScanner(url, token string, timeout time.Duration) *HTTPScanner {
+	return &HTTPScanner{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Scan posts the content as request body and parses the verdict of the response
+func (s *HTTPScanner) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanning service responded with status %d", resp.StatusCode)
+	}
+
+	var verdict httpScanResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&verdict); err != nil {
+		return nil, fmt.Errorf("invalid response of the scanning service: %w", err)
+	}
+	return &Result{Infected: verdict.Infected, Signature: verdict.Signature}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPScanner(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		content, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(content), "EICAR") {
+			_, _ = io.WriteString(w, `{"infected":true,"signature":"Eicar-Signature"}`)
+		} else {
+			_, _ = io.WriteString(w, `{"infected":false}`)
+		}
+	}))
+	defer srv.Close()
+
+	s := NewHTTPScanner(srv.URL, "secret", time.Minute)
+
+	result, err := s.Scan(t.Context(), strings.NewReader("harmless"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = s.Scan(t.Context(), strings.NewReader("EICAR"))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Eicar-Signature", result.Signature)
+
+	_, err = NewHTTPScanner(srv.URL, "wrong", time.Minute).Scan(t.Context(), strings.NewReader("harmless"))
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Result is the verdict of a scan
+type Result struct {
+	Infected bool
+	// Signature is the name of the malware which has been found
+	Signature string
+}
+
+// Scanner scans the content of a file for malware
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// NewScanner creates the scanner configured in the [malware_scan] section
+func NewScanner() (Scanner, error) {
+	switch setting.MalwareScan.Scanner {
+	case setting.MalwareScannerClamAV:
+		return NewClamAV(setting.MalwareScan.ClamAVAddress, setting.MalwareScan.Timeout)
+	case setting.MalwareScannerHTTP:
+		return NewHTTPScanner(setting.MalwareScan.HTTPURL, setting.MalwareScan.HTTPToken, setting.MalwareScan.Timeout), nil
+	}
+	return nil, fmt.Errorf("unknown malware scanner %q", setting.MalwareScan.Scanner)
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package diagram renders the diagram files (draw.io, Excalidraw and PlantUML) to SVG images
+package diagram
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation"
+	"code.gitea.io/gitea/modules/util"
+)
+
+func init() {
+	markup.RegisterRenderer(DrawioRenderer{})
+	markup.RegisterRenderer(ExcalidrawRenderer{})
+	markup.RegisterRenderer(PlantUMLRenderer{})
+}
+
+// svgImageSanitizerRules allows the rendered diagrams which are embedded as data URI images
+var svgImageSanitizerRules = []setting.MarkupSanitizerRule{{AllowDataURIImages: true}}
+
+// renderCached returns the SVG image of the diagram source from the cache, the image is rendered if it isn't cached yet.
+// The images are cached by the hash of their source, so the same diagram is only rendered once wherever it is shown.
+func renderCached(kind string, source []byte, render func() ([]byte, error)) ([]byte, error) {
+	hash := sha256.Sum256(source)
+	svg, err := cache.GetString("Diagram:"+kind+":"+hex.EncodeToString(hash[:]), func() (string, error) {
+		svg, err := render()
+		return string(svg), err
+	})
+	return []byte(svg), err
+}
+
+// writeSVGImage writes the SVG image of a diagram to the rendered file view
+func writeSVGImage(ctx *markup.RenderContext, output io.Writer, svg []byte, alt string) error {
+	return ctx.RenderInternal.FormatWithSafeAttrs(output, `<div class="diagram-view"><img src="%s" alt="%s"></div>`,
+		"data:image/svg+xml;base64,"+base64.StdEncoding.EncodeToString(svg), alt)
+}
+
+// writeRenderError writes a message instead of the diagram which can't be rendered
+func writeRenderError(ctx *markup.RenderContext, output io.Writer, err error) error {
+	if !errors.Is(err, util.ErrInvalidArgument) {
+		log.Error("Unable to render the diagram %q: %v", ctx.RenderOptions.RelativePath, err)
+	}
+	message, viewSource := "The diagram can't be rendered.", "View Source"
+	if locale, ok := ctx.Value(translation.ContextKey).(translation.Locale); ok {
+		message, viewSource = locale.TrString("repo.diagram_render_failed"), locale.TrString("repo.file_view_source")
+	}
+	link := ctx.RenderHelper.ResolveLink(util.PathEscapeSegments(ctx.RenderOptions.RelativePath), markup.LinkTypeDefault) + "?display=source"
+	return ctx.RenderInternal.FormatWithSafeAttrs(output, `<div class="diagram-render-error">%s <a href="%s">%s</a></div>`, message, link, viewSource)
+}
+
+const fontFamily = `system-ui, -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif`
+
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]{3,20}|rgba?\([0-9., %]+\))$`)
+
+// svgColor returns the color if it is a valid color of the diagrams, otherwise the default color
+func svgColor(color, defaultColor string) string {
+	if color == "transparent" || color == "none" {
+		return "none"
+	}
+	if colorPattern.MatchString(color) {
+		return color
+	}
+	return defaultColor
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+type point struct {
+	X, Y float64
+}
+
+type bounds struct {
+	MinX, MinY, MaxX, MaxY float64
+	empty                  bool
+}
+
+func newBounds() *bounds {
+	return &bounds{empty: true}
+}
+
+func (b *bounds) add(x, y float64) {
+	if b.empty {
+		b.MinX, b.MinY, b.MaxX, b.MaxY, b.empty = x, y, x, y, false
+		return
+	}
+	b.MinX, b.MinY = min(b.MinX, x), min(b.MinY, y)
+	b.MaxX, b.MaxY = max(b.MaxX, x), max(b.MaxY, y)
+}
+
+// svgBuilder writes the elements of a SVG image, the diagrams are drawn in their own coordinates
+// and the view box of the image is the bounds of all the drawn elements.
+type svgBuilder struct {
+	strings.Builder
+	bounds *bounds
+}
+
+func newSVGBuilder() *svgBuilder {
+	return &svgBuilder{bounds: newBounds()}
+}
+
+// element writes an element with its attributes which are given as name and value pairs
+func (b *svgBuilder) element(name string, attrs ...any) {
+	b.WriteString("<" + name)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		var value string
+		switch v := attrs[i+1].(type) {
+		case float64:
+			value = formatFloat(v)
+		default:
+			value = fmt.Sprint(v)
+		}
+		if value == "" {
+			continue
+		}
+		fmt.Fprintf(b, ` %s="%s"`, attrs[i], html.EscapeString(value))
+	}
+	b.WriteString("/>")
+}
+
+// text writes a multiline text, the lines are centered vertically around y if middle is true, otherwise the first line starts at y
+func (b *svgBuilder) text(x, y float64, content string, fontSize float64, color, anchor string, middle bool) {
+	lines := strings.Split(content, "\n")
+	lineHeight := fontSize * 1.25
+	startY := y + fontSize
+	if middle {
+		startY = y - lineHeight*float64(len(lines)-1)/2 + fontSize*0.35
+	}
+	fmt.Fprintf(b, `<text font-size="%s" fill="%s" text-anchor="%s">`, formatFloat(fontSize), html.EscapeString(color), anchor)
+	for i, line := range lines {
+		fmt.Fprintf(b, `<tspan x="%s" y="%s">%s</tspan>`, formatFloat(x), formatFloat(startY+lineHeight*float64(i)), html.EscapeString(line))
+	}
+	b.WriteString("</text>")
+}
+
+// arrowHead writes a filled arrow head at the end of the line from "from" to "to"
+func (b *svgBuilder) arrowHead(from, to point, size float64, color string) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	length := max(1e-9, math.Hypot(dx, dy))
+	ux, uy := dx/length, dy/length
+	left := point{to.X - ux*size - uy*size/2, to.Y - uy*size + ux*size/2}
+	right := point{to.X - ux*size + uy*size/2, to.Y - uy*size - ux*size/2}
+	b.element("polygon", "points", pointsAttr([]point{to, left, right}), "fill", color, "stroke", color)
+}
+
+// svg returns the complete SVG image with the drawn elements
+func (b *svgBuilder) svg(background string) []byte {
+	const padding = 10
+	bd := b.bounds
+	if bd.empty {
+		bd.add(0, 0)
+	}
+	x, y := bd.MinX-padding, bd.MinY-padding
+	width, height := bd.MaxX-bd.MinX+2*padding, bd.MaxY-bd.MinY+2*padding
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%s %s %s %s" width="%s" height="%s" font-family="%s">`,
+		formatFloat(x), formatFloat(y), formatFloat(width), formatFloat(height), formatFloat(width), formatFloat(height), html.EscapeString(fontFamily))
+	if background != "" && background != "none" {
+		fmt.Fprintf(&sb, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`, formatFloat(x), formatFloat(y), formatFloat(width), formatFloat(height), html.EscapeString(background))
+	}
+	sb.WriteString(b.String())
+	sb.WriteString("</svg>")
+	return []byte(sb.String())
+}
+
+func pointsAttr(points []point) string {
+	parts := make([]string, 0, len(points))
+	for _, p := range points {
+		parts = append(parts, formatFloat(p.X)+","+formatFloat(p.Y))
+	}
+	return strings.Join(parts, " ")
+}
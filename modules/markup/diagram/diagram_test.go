@@ -0,0 +1,168 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package diagram
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDrawioModel = `<mxGraphModel><root>
+<mxCell id="0"/>
+<mxCell id="1" parent="0"/>
+<mxCell id="a" value="Start &amp; go" style="rounded=1;fillColor=#dae8fc;" vertex="1" parent="1"><mxGeometry x="20" y="20" width="120" height="60" as="geometry"/></mxCell>
+<object id="b" label="End"><mxCell style="ellipse;whiteSpace=wrap;" vertex="1" parent="1"><mxGeometry x="220" y="20" width="80" height="60" as="geometry"/></mxCell></object>
+<mxCell id="e" style="endArrow=classic;" edge="1" parent="1" source="a" target="b"><mxGeometry relative="1" as="geometry"/></mxCell>
+</root></mxGraphModel>`
+
+func renderSVG(t *testing.T, r markup.SVGRenderer, input string) string {
+	svg, err := r.RenderSVG(t.Context(), strings.NewReader(input))
+	require.NoError(t, err)
+	return string(svg)
+}
+
+func TestRenderDrawio(t *testing.T) {
+	svg := renderSVG(t, DrawioRenderer{}, testDrawioModel)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="10 10 300 80"`))
+	assert.Contains(t, svg, `<rect x="20" y="20" width="120" height="60" rx="9" fill="#dae8fc" stroke="#000000" stroke-width="1"/>`)
+	assert.Contains(t, svg, `<ellipse cx="260" cy="50" rx="40" ry="30" fill="#ffffff"`)
+	assert.Contains(t, svg, `>Start &amp; go</tspan>`)
+	assert.Contains(t, svg, `>End</tspan>`)
+	assert.Contains(t, svg, `<polyline points="140,50 220,50"`)
+
+	t.Run("Compressed", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+		_, _ = w.Write([]byte(url.PathEscape(testDrawioModel)))
+		_ = w.Close()
+		file := `<mxfile><diagram id="d" name="Page-1">` + base64.StdEncoding.EncodeToString(buf.Bytes()) + `</diagram></mxfile>`
+		assert.Equal(t, svg, renderSVG(t, DrawioRenderer{}, file))
+	})
+
+	t.Run("Uncompressed", func(t *testing.T) {
+		file := `<mxfile><diagram id="d" name="Page-1">` + testDrawioModel + `</diagram></mxfile>`
+		assert.Equal(t, svg, renderSVG(t, DrawioRenderer{}, file))
+	})
+}
+
+func TestRenderExcalidraw(t *testing.T) {
+	svg := renderSVG(t, ExcalidrawRenderer{}, `{
+  "type": "excalidraw",
+  "elements": [
+    {"type": "rectangle", "x": 0, "y": 0, "width": 100, "height": 50, "strokeColor": "#1971c2", "backgroundColor": "transparent", "strokeWidth": 2},
+    {"type": "arrow", "x": 100, "y": 25, "width": 100, "height": 0, "points": [[0, 0], [100, 0]], "endArrowhead": "arrow"},
+    {"type": "text", "x": 10, "y": 60, "width": 80, "height": 25, "text": "<hello>", "fontSize": 20, "textAlign": "center"},
+    {"type": "ellipse", "x": 0, "y": 0, "width": 500, "height": 500, "isDeleted": true}
+  ],
+  "appState": {"viewBackgroundColor": "#ffffff"}
+}`)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-10 -10 220 105"`))
+	assert.Contains(t, svg, `<rect x="0" y="0" width="100" height="50" fill="none" stroke="#1971c2" stroke-width="2"/>`)
+	assert.Contains(t, svg, `<polyline points="100,25 200,25" fill="none"`)
+	assert.Contains(t, svg, `<tspan x="50" y="80">&lt;hello&gt;</tspan>`)
+	assert.NotContains(t, svg, "<ellipse")
+}
+
+func TestRenderInvalidDiagram(t *testing.T) {
+	for _, c := range []struct {
+		renderer markup.SVGRenderer
+		input    string
+	}{
+		{DrawioRenderer{}, "not xml"},
+		{DrawioRenderer{}, "<mxfile></mxfile>"},
+		{DrawioRenderer{}, `<mxfile><diagram>not compressed</diagram></mxfile>`},
+		{ExcalidrawRenderer{}, "not json"},
+		{ExcalidrawRenderer{}, `{"type": "other"}`},
+	} {
+		_, err := c.renderer.RenderSVG(t.Context(), strings.NewReader(c.input))
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, "input: %s", c.input)
+	}
+
+	var buf strings.Builder
+	err := markup.Render(markup.NewRenderContext(t.Context()).WithRelativePath("test.drawio"), strings.NewReader("not xml"), &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div class="diagram-render-error">The diagram can&#39;t be rendered. <a href="/test.drawio?display=source" rel="nofollow">View Source</a></div>`, buf.String())
+}
+
+func TestPlantUMLEncoding(t *testing.T) {
+	source, err := DecodePlantUML("SyfFKj2rKt3CoKnELR1Io4ZDoSa70000")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob -> Alice : hello", source)
+
+	source = "@startuml\nBob -> Alice : hello ✓\n@enduml"
+	encoded, err := EncodePlantUML(source)
+	assert.NoError(t, err)
+	decoded, err := DecodePlantUML(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, source, decoded)
+
+	_, err = DecodePlantUML("not~encoded")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	defer test.MockVariableValue(&setting.MarkupPlantUML.MaxSourceCharacters, 10)()
+	_, err = EncodePlantUML(source)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = DecodePlantUML(encoded)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestRenderPlantUML(t *testing.T) {
+	_, err := RenderPlantUML(t.Context(), "Bob -> Alice")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoded, _ := strings.CutPrefix(r.URL.Path, "/plantuml/svg/")
+		requested, _ = DecodePlantUML(encoded)
+		w.Header().Set("Content-Type", "image/svg+xml")
+		if strings.Contains(requested, "error") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = io.WriteString(w, `<svg xmlns="http://www.w3.org/2000/svg"></svg>`)
+	}))
+	defer server.Close()
+	defer test.MockVariableValue(&setting.MarkupPlantUML.ServerURL, server.URL+"/plantuml")()
+
+	svg, err := RenderPlantUML(t.Context(), "Bob -> Alice")
+	assert.NoError(t, err)
+	assert.Equal(t, `<svg xmlns="http://www.w3.org/2000/svg"></svg>`, string(svg))
+	assert.Equal(t, "@startuml\nBob -> Alice\n@enduml", requested)
+
+	// the syntax errors are rendered as images by the server
+	_, err = RenderPlantUML(t.Context(), "syntax error")
+	assert.NoError(t, err)
+	assert.Equal(t, "@startuml\nsyntax error\n@enduml", requested)
+}
+
+func TestRenderPlantUMLByCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command is a shell script")
+	}
+	script := filepath.Join(t.TempDir(), "plantuml.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"<svg>$PLANTUML_SECURITY_PROFILE</svg>\"\n"), 0o755))
+	defer test.MockVariableValue(&setting.MarkupPlantUML.RenderCommand, script)()
+	defer test.MockVariableValue(&setting.MarkupPlantUML.SecurityProfile, "ALLOWLIST")()
+
+	svg, err := renderPlantUMLByCommand(t.Context(), "@startuml\nBob -> Alice\n@enduml")
+	assert.NoError(t, err)
+	assert.Equal(t, "<svg>ALLOWLIST</svg>\n", string(svg))
+}
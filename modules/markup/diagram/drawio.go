@@ -0,0 +1,395 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package diagram
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"html"
+	"io"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// DrawioRenderer implements markup.Renderer for draw.io diagrams
+type DrawioRenderer struct{}
+
+var _ markup.SVGRenderer = (*DrawioRenderer)(nil)
+
+// Name implements markup.Renderer
+func (DrawioRenderer) Name() string {
+	return "drawio"
+}
+
+// Extensions implements markup.Renderer
+func (DrawioRenderer) Extensions() []string {
+	return []string{".drawio"}
+}
+
+// SanitizerRules implements markup.Renderer
+func (DrawioRenderer) SanitizerRules() []setting.MarkupSanitizerRule {
+	return svgImageSanitizerRules
+}
+
+// Render implements markup.Renderer
+func (r DrawioRenderer) Render(ctx *markup.RenderContext, input io.Reader, output io.Writer) error {
+	svg, err := r.RenderSVG(ctx, input)
+	if err != nil {
+		return writeRenderError(ctx, output, err)
+	}
+	return writeSVGImage(ctx, output, svg, "draw.io diagram")
+}
+
+// RenderSVG implements markup.SVGRenderer, the first page of the diagram is rendered
+func (DrawioRenderer) RenderSVG(_ context.Context, input io.Reader) ([]byte, error) {
+	source, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return renderCached("drawio", source, func() ([]byte, error) {
+		model, err := parseDrawio(source)
+		if err != nil {
+			return nil, err
+		}
+		return renderDrawioModel(model), nil
+	})
+}
+
+type drawioFile struct {
+	Diagrams []struct {
+		Model   *drawioModel `xml:"mxGraphModel"`
+		Content string       `xml:",chardata"`
+	} `xml:"diagram"`
+}
+
+type drawioModel struct {
+	Background string `xml:"background,attr"`
+	Root       struct {
+		Items []drawioItem `xml:",any"`
+	} `xml:"root"`
+}
+
+// drawioItem is a cell of the diagram, a cell with custom properties is wrapped in an "object" or "UserObject" element
+type drawioItem struct {
+	XMLName  xml.Name
+	ID       string          `xml:"id,attr"`
+	Value    string          `xml:"value,attr"`
+	Label    string          `xml:"label,attr"`
+	Style    string          `xml:"style,attr"`
+	Parent   string          `xml:"parent,attr"`
+	Source   string          `xml:"source,attr"`
+	Target   string          `xml:"target,attr"`
+	Vertex   string          `xml:"vertex,attr"`
+	Edge     string          `xml:"edge,attr"`
+	Geometry *drawioGeometry `xml:"mxGeometry"`
+	Cell     *drawioItem     `xml:"mxCell"`
+}
+
+type drawioGeometry struct {
+	X         float64       `xml:"x,attr"`
+	Y         float64       `xml:"y,attr"`
+	Width     float64       `xml:"width,attr"`
+	Height    float64       `xml:"height,attr"`
+	Relative  string        `xml:"relative,attr"`
+	Points    []drawioPoint `xml:"Array>mxPoint"`
+	EndPoints []drawioPoint `xml:"mxPoint"`
+}
+
+type drawioPoint struct {
+	X  float64 `xml:"x,attr"`
+	Y  float64 `xml:"y,attr"`
+	As string  `xml:"as,attr"`
+}
+
+// drawioCell is a cell of the diagram with its absolute geometry
+type drawioCell struct {
+	*drawioItem
+	Label  string
+	Styles map[string]string
+	X, Y   float64
+	W, H   float64
+}
+
+func (c *drawioCell) isVertex() bool { return c.Vertex == "1" }
+
+func (c *drawioCell) isEdge() bool { return c.Edge == "1" }
+
+func (c *drawioCell) center() point { return point{c.X + c.W/2, c.Y + c.H/2} }
+
+// parseDrawio parses the first page of a draw.io file, the file could also contain only the graph model
+func parseDrawio(source []byte) (*drawioModel, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(source, &root); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid draw.io file: %v", err)
+	}
+	if root.XMLName.Local == "mxGraphModel" {
+		model := &drawioModel{}
+		return model, xml.Unmarshal(source, model)
+	}
+
+	var file drawioFile
+	if err := xml.Unmarshal(source, &file); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid draw.io file: %v", err)
+	}
+	if len(file.Diagrams) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("draw.io file has no diagram")
+	}
+	diagram := file.Diagrams[0]
+	if diagram.Model != nil {
+		return diagram.Model, nil
+	}
+
+	// the diagrams are compressed by default: deflated, base64 encoded and url encoded
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(diagram.Content))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid compressed draw.io diagram: %v", err)
+	}
+	inflated, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), int64(setting.UI.MaxDisplayFileSize)))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid compressed draw.io diagram: %v", err)
+	}
+	decoded, err := url.PathUnescape(string(inflated))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid compressed draw.io diagram: %v", err)
+	}
+	model := &drawioModel{}
+	if err := xml.Unmarshal([]byte(decoded), model); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid compressed draw.io diagram: %v", err)
+	}
+	return model, nil
+}
+
+// parseDrawioStyle parses a style like "ellipse;whiteSpace=wrap;fillColor=#dae8fc;", the keys without value are the shape names
+func parseDrawioStyle(style string) map[string]string {
+	styles := map[string]string{}
+	for item := range strings.SplitSeq(style, ";") {
+		if key, value, ok := strings.Cut(item, "="); ok {
+			styles[key] = value
+		} else if item != "" {
+			styles["shape"] = util.IfZero(styles["shape"], item)
+		}
+	}
+	return styles
+}
+
+var (
+	drawioLineBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>`)
+	drawioTagRegexp       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// drawioLabel returns the text of a label which can be HTML
+func drawioLabel(label string, styles map[string]string) string {
+	if styles["html"] == "1" {
+		label = drawioLineBreakRegexp.ReplaceAllString(label, "\n")
+		label = html.UnescapeString(drawioTagRegexp.ReplaceAllString(label, ""))
+	}
+	return strings.TrimSpace(label)
+}
+
+func drawioFloat(s string, defaultValue float64) float64 {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return defaultValue
+}
+
+func drawioCells(model *drawioModel) ([]*drawioCell, map[string]*drawioCell) {
+	items := model.Root.Items
+	cells := make([]*drawioCell, 0, len(items))
+	cellMap := make(map[string]*drawioCell, len(items))
+	for i := range items {
+		item := &items[i]
+		label := item.Value
+		if item.Cell != nil {
+			// the properties of the cell are in the wrapper element
+			cell := *item.Cell
+			cell.ID, label = item.ID, item.Label
+			item = &cell
+		}
+		styles := parseDrawioStyle(item.Style)
+		c := &drawioCell{drawioItem: item, Label: drawioLabel(label, styles), Styles: styles}
+		if item.Geometry != nil {
+			c.X, c.Y, c.W, c.H = item.Geometry.X, item.Geometry.Y, item.Geometry.Width, item.Geometry.Height
+		}
+		cells = append(cells, c)
+		cellMap[c.ID] = c
+	}
+
+	// the geometry of a cell in a group or a container is relative to its parent
+	var offset func(c *drawioCell, depth int) point
+	offset = func(c *drawioCell, depth int) point {
+		parent := cellMap[c.Parent]
+		if parent == nil || !parent.isVertex() || parent.Geometry == nil || depth > 100 {
+			return point{}
+		}
+		p := offset(parent, depth+1)
+		return point{p.X + parent.Geometry.X, p.Y + parent.Geometry.Y}
+	}
+	for _, c := range cells {
+		if c.isVertex() && c.Geometry != nil {
+			o := offset(c, 0)
+			c.X, c.Y = c.X+o.X, c.Y+o.Y
+		}
+	}
+	return cells, cellMap
+}
+
+func renderDrawioModel(model *drawioModel) []byte {
+	b := newSVGBuilder()
+	cells, cellMap := drawioCells(model)
+	for _, c := range cells {
+		switch {
+		case c.isEdge():
+			renderDrawioEdge(b, c, cells, cellMap)
+		case c.isVertex() && c.Geometry != nil:
+			if parent := cellMap[c.Parent]; parent != nil && parent.isEdge() {
+				// the label of an edge is positioned along the edge, it is drawn with the edge
+				continue
+			}
+			renderDrawioVertex(b, c)
+		}
+	}
+	return b.svg(svgColor(model.Background, "none"))
+}
+
+func renderDrawioVertex(b *svgBuilder, c *drawioCell) {
+	shape := c.Styles["shape"]
+	isText := shape == "text" || shape == "edgeLabel"
+	fill := svgColor(c.Styles["fillColor"], util.Iif(isText, "none", "#ffffff"))
+	stroke := svgColor(c.Styles["strokeColor"], util.Iif(isText, "none", "#000000"))
+	strokeWidth := drawioFloat(c.Styles["strokeWidth"], 1)
+	dash := util.Iif(c.Styles["dashed"] == "1", "3 3", "")
+
+	b.bounds.add(c.X, c.Y)
+	b.bounds.add(c.X+c.W, c.Y+c.H)
+	switch shape {
+	case "ellipse", "doubleEllipse":
+		b.element("ellipse", "cx", c.X+c.W/2, "cy", c.Y+c.H/2, "rx", c.W/2, "ry", c.H/2,
+			"fill", fill, "stroke", stroke, "stroke-width", strokeWidth, "stroke-dasharray", dash)
+	case "rhombus":
+		points := []point{{c.X + c.W/2, c.Y}, {c.X + c.W, c.Y + c.H/2}, {c.X + c.W/2, c.Y + c.H}, {c.X, c.Y + c.H/2}}
+		b.element("polygon", "points", pointsAttr(points),
+			"fill", fill, "stroke", stroke, "stroke-width", strokeWidth, "stroke-dasharray", dash)
+	default:
+		radius := 0.0
+		if c.Styles["rounded"] == "1" {
+			radius = min(c.W, c.H) * 0.15
+		}
+		b.element("rect", "x", c.X, "y", c.Y, "width", c.W, "height", c.H, "rx", util.Iif(radius > 0, formatFloat(radius), ""),
+			"fill", fill, "stroke", stroke, "stroke-width", strokeWidth, "stroke-dasharray", dash)
+	}
+
+	if c.Label == "" {
+		return
+	}
+	fontSize := drawioFloat(c.Styles["fontSize"], 12)
+	fontColor := svgColor(c.Styles["fontColor"], "#000000")
+	if shape == "swimlane" {
+		// the label of a container is in its header
+		b.text(c.X+c.W/2, c.Y+drawioFloat(c.Styles["startSize"], 23)/2, c.Label, fontSize, fontColor, "middle", true)
+		return
+	}
+	b.text(c.X+c.W/2, c.Y+c.H/2, c.Label, fontSize, fontColor, "middle", true)
+}
+
+// clipToBorder returns the point where the line from the center of the cell to the point crosses the border of the cell
+func clipToBorder(c *drawioCell, to point) point {
+	center := c.center()
+	dx, dy := to.X-center.X, to.Y-center.Y
+	if (dx == 0 && dy == 0) || c.W == 0 || c.H == 0 {
+		return center
+	}
+	scale := math.Inf(1)
+	if dx != 0 {
+		scale = min(scale, c.W/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		scale = min(scale, c.H/2/math.Abs(dy))
+	}
+	if scale > 1 {
+		// the point is inside the cell
+		return center
+	}
+	return point{center.X + dx*scale, center.Y + dy*scale}
+}
+
+func renderDrawioEdge(b *svgBuilder, c *drawioCell, cells []*drawioCell, cellMap map[string]*drawioCell) {
+	if c.Geometry == nil {
+		return
+	}
+	var start, end *point
+	for _, p := range c.Geometry.EndPoints {
+		switch p.As {
+		case "sourcePoint":
+			start = &point{p.X, p.Y}
+		case "targetPoint":
+			end = &point{p.X, p.Y}
+		}
+	}
+	waypoints := make([]point, 0, len(c.Geometry.Points))
+	for _, p := range c.Geometry.Points {
+		waypoints = append(waypoints, point{p.X, p.Y})
+	}
+
+	source, target := cellMap[c.Source], cellMap[c.Target]
+	if source != nil && source.isVertex() {
+		start = util.ToPointer(source.center())
+	}
+	if target != nil && target.isVertex() {
+		end = util.ToPointer(target.center())
+	}
+	if start == nil || end == nil {
+		return
+	}
+
+	points := append(append([]point{*start}, waypoints...), *end)
+	if source != nil && source.isVertex() {
+		points[0] = clipToBorder(source, points[1])
+	}
+	if target != nil && target.isVertex() {
+		points[len(points)-1] = clipToBorder(target, points[len(points)-2])
+	}
+	for _, p := range points {
+		b.bounds.add(p.X, p.Y)
+	}
+
+	stroke := svgColor(c.Styles["strokeColor"], "#000000")
+	b.element("polyline", "points", pointsAttr(points), "fill", "none", "stroke", stroke,
+		"stroke-width", drawioFloat(c.Styles["strokeWidth"], 1), "stroke-dasharray", util.Iif(c.Styles["dashed"] == "1", "3 3", ""))
+	if arrow := c.Styles["endArrow"]; arrow != "none" {
+		b.arrowHead(points[len(points)-2], points[len(points)-1], 8, stroke)
+	}
+	if arrow := c.Styles["startArrow"]; arrow != "" && arrow != "none" {
+		b.arrowHead(points[1], points[0], 8, stroke)
+	}
+
+	// the label of the edge is either its value or the value of its child label cells
+	labels := make([]string, 0, 1)
+	if c.Label != "" {
+		labels = append(labels, c.Label)
+	}
+	for _, child := range cells {
+		if child.Parent == c.ID && child.isVertex() && child.Label != "" {
+			labels = append(labels, child.Label)
+		}
+	}
+	if len(labels) > 0 {
+		middle := len(points) / 2
+		p := point{(points[middle-1].X + points[middle].X) / 2, (points[middle-1].Y + points[middle].Y) / 2}
+		fontSize := drawioFloat(c.Styles["fontSize"], 11)
+		b.text(p.X, p.Y, strings.Join(labels, "\n"), fontSize, svgColor(c.Styles["fontColor"], "#000000"), "middle", true)
+	}
+}
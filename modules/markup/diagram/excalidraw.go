@@ -0,0 +1,219 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package diagram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ExcalidrawRenderer implements markup.Renderer for Excalidraw drawings
+type ExcalidrawRenderer struct{}
+
+var _ markup.SVGRenderer = (*ExcalidrawRenderer)(nil)
+
+// Name implements markup.Renderer
+func (ExcalidrawRenderer) Name() string {
+	return "excalidraw"
+}
+
+// Extensions implements markup.Renderer
+func (ExcalidrawRenderer) Extensions() []string {
+	return []string{".excalidraw"}
+}
+
+// SanitizerRules implements markup.Renderer
+func (ExcalidrawRenderer) SanitizerRules() []setting.MarkupSanitizerRule {
+	return svgImageSanitizerRules
+}
+
+// Render implements markup.Renderer
+func (r ExcalidrawRenderer) Render(ctx *markup.RenderContext, input io.Reader, output io.Writer) error {
+	svg, err := r.RenderSVG(ctx, input)
+	if err != nil {
+		return writeRenderError(ctx, output, err)
+	}
+	return writeSVGImage(ctx, output, svg, "Excalidraw drawing")
+}
+
+// RenderSVG implements markup.SVGRenderer
+func (ExcalidrawRenderer) RenderSVG(_ context.Context, input io.Reader) ([]byte, error) {
+	source, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return renderCached("excalidraw", source, func() ([]byte, error) {
+		var drawing excalidrawFile
+		if err := json.Unmarshal(source, &drawing); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid Excalidraw file: %v", err)
+		}
+		if drawing.Type != "excalidraw" {
+			return nil, util.NewInvalidArgumentErrorf("invalid Excalidraw file type: %q", drawing.Type)
+		}
+		return renderExcalidraw(&drawing), nil
+	})
+}
+
+type excalidrawFile struct {
+	Type     string               `json:"type"`
+	Elements []*excalidrawElement `json:"elements"`
+	AppState struct {
+		ViewBackgroundColor string `json:"viewBackgroundColor"`
+	} `json:"appState"`
+	Files map[string]struct {
+		DataURL string `json:"dataURL"`
+	} `json:"files"`
+}
+
+type excalidrawElement struct {
+	Type            string      `json:"type"`
+	X               float64     `json:"x"`
+	Y               float64     `json:"y"`
+	Width           float64     `json:"width"`
+	Height          float64     `json:"height"`
+	Angle           float64     `json:"angle"`
+	StrokeColor     string      `json:"strokeColor"`
+	BackgroundColor string      `json:"backgroundColor"`
+	StrokeWidth     float64     `json:"strokeWidth"`
+	StrokeStyle     string      `json:"strokeStyle"`
+	Opacity         *float64    `json:"opacity"`
+	Roundness       *struct{}   `json:"roundness"`
+	IsDeleted       bool        `json:"isDeleted"`
+	Text            string      `json:"text"`
+	FontSize        float64     `json:"fontSize"`
+	FontFamily      int         `json:"fontFamily"`
+	TextAlign       string      `json:"textAlign"`
+	Points          [][]float64 `json:"points"`
+	StartArrowhead  *string     `json:"startArrowhead"`
+	EndArrowhead    *string     `json:"endArrowhead"`
+	FileID          string      `json:"fileId"`
+}
+
+func (e *excalidrawElement) points() []point {
+	points := make([]point, 0, len(e.Points))
+	for _, p := range e.Points {
+		if len(p) >= 2 {
+			points = append(points, point{e.X + p[0], e.Y + p[1]})
+		}
+	}
+	return points
+}
+
+// addBounds adds the corners of the rotated box of the element to the bounds
+func (e *excalidrawElement) addBounds(b *bounds) {
+	cx, cy := e.X+e.Width/2, e.Y+e.Height/2
+	sin, cos := math.Sincos(e.Angle)
+	for _, p := range []point{{e.X, e.Y}, {e.X + e.Width, e.Y}, {e.X, e.Y + e.Height}, {e.X + e.Width, e.Y + e.Height}} {
+		dx, dy := p.X-cx, p.Y-cy
+		b.add(cx+dx*cos-dy*sin, cy+dx*sin+dy*cos)
+	}
+}
+
+func renderExcalidraw(drawing *excalidrawFile) []byte {
+	b := newSVGBuilder()
+	for _, e := range drawing.Elements {
+		if e.IsDeleted {
+			continue
+		}
+		// the points of the lines are relative to the position of the element, and the size of the element is the size of their bounds
+		if points := e.points(); len(points) > 0 {
+			for _, p := range points {
+				b.bounds.add(p.X, p.Y)
+			}
+		} else {
+			e.addBounds(b.bounds)
+		}
+
+		group := ""
+		if e.Angle != 0 {
+			group += fmt.Sprintf(` transform="rotate(%s %s %s)"`, formatFloat(e.Angle*180/math.Pi), formatFloat(e.X+e.Width/2), formatFloat(e.Y+e.Height/2))
+		}
+		if e.Opacity != nil && *e.Opacity < 100 {
+			group += fmt.Sprintf(` opacity="%s"`, formatFloat(max(0, *e.Opacity)/100))
+		}
+		if group != "" {
+			b.WriteString("<g" + group + ">")
+		}
+		renderExcalidrawElement(b, e, drawing)
+		if group != "" {
+			b.WriteString("</g>")
+		}
+	}
+	return b.svg(svgColor(drawing.AppState.ViewBackgroundColor, "#ffffff"))
+}
+
+func renderExcalidrawElement(b *svgBuilder, e *excalidrawElement, drawing *excalidrawFile) {
+	stroke := svgColor(e.StrokeColor, "#1e1e1e")
+	fill := svgColor(e.BackgroundColor, "none")
+	strokeWidth := util.IfZero(e.StrokeWidth, 1)
+	dash := ""
+	switch e.StrokeStyle {
+	case "dashed":
+		dash = "8 8"
+	case "dotted":
+		dash = "1.5 6"
+	}
+
+	switch e.Type {
+	case "rectangle":
+		radius := ""
+		if e.Roundness != nil {
+			radius = formatFloat(min(32, min(e.Width, e.Height)*0.25))
+		}
+		b.element("rect", "x", e.X, "y", e.Y, "width", e.Width, "height", e.Height, "rx", radius,
+			"fill", fill, "stroke", stroke, "stroke-width", strokeWidth, "stroke-dasharray", dash)
+	case "ellipse":
+		b.element("ellipse", "cx", e.X+e.Width/2, "cy", e.Y+e.Height/2, "rx", e.Width/2, "ry", e.Height/2,
+			"fill", fill, "stroke", stroke, "stroke-width", strokeWidth, "stroke-dasharray", dash)
+	case "diamond":
+		points := []point{{e.X + e.Width/2, e.Y}, {e.X + e.Width, e.Y + e.Height/2}, {e.X + e.Width/2, e.Y + e.Height}, {e.X, e.Y + e.Height/2}}
+		b.element("polygon", "points", pointsAttr(points),
+			"fill", fill, "stroke", stroke, "stroke-width", strokeWidth, "stroke-dasharray", dash)
+	case "line", "arrow", "freedraw":
+		points := e.points()
+		if len(points) < 2 {
+			return
+		}
+		b.element("polyline", "points", pointsAttr(points), "fill", util.Iif(e.Type == "line", fill, "none"), "stroke", stroke,
+			"stroke-width", strokeWidth, "stroke-dasharray", dash, "stroke-linecap", "round", "stroke-linejoin", "round")
+		arrowSize := 8 + strokeWidth*2
+		if e.EndArrowhead != nil {
+			b.arrowHead(points[len(points)-2], points[len(points)-1], arrowSize, stroke)
+		}
+		if e.StartArrowhead != nil {
+			b.arrowHead(points[1], points[0], arrowSize, stroke)
+		}
+	case "text":
+		fontSize := util.IfZero(e.FontSize, 20)
+		x, anchor := e.X, "start"
+		switch e.TextAlign {
+		case "center":
+			x, anchor = e.X+e.Width/2, "middle"
+		case "right":
+			x, anchor = e.X+e.Width, "end"
+		}
+		if e.FontFamily == 3 {
+			b.WriteString(`<g font-family="monospace">`)
+			defer b.WriteString("</g>")
+		}
+		b.text(x, e.Y, e.Text, fontSize, stroke, anchor, false)
+	case "image":
+		// only the images embedded in the drawing are shown
+		file, ok := drawing.Files[e.FileID]
+		if !ok || !strings.HasPrefix(file.DataURL, "data:image/") {
+			return
+		}
+		fmt.Fprintf(b, `<image href="%s" x="%s" y="%s" width="%s" height="%s" preserveAspectRatio="none"/>`,
+			html.EscapeString(file.DataURL), formatFloat(e.X), formatFloat(e.Y), formatFloat(e.Width), formatFloat(e.Height))
+	}
+}
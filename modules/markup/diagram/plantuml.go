@@ -0,0 +1,205 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package diagram
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// plantUMLEncoding is the base64 alphabet of the PlantUML text encoding
+var plantUMLEncoding = base64.NewEncoding("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_").WithPadding(base64.NoPadding)
+
+// maxSVGSize is the maximum size of a SVG image rendered by PlantUML
+const maxSVGSize = 10 * 1024 * 1024
+
+// PlantUMLRenderer implements markup.Renderer for PlantUML diagrams, it is only enabled if a PlantUML renderer is configured
+type PlantUMLRenderer struct{}
+
+var _ markup.SVGRenderer = (*PlantUMLRenderer)(nil)
+
+// Name implements markup.Renderer
+func (PlantUMLRenderer) Name() string {
+	return "plantuml"
+}
+
+// Extensions implements markup.Renderer
+func (PlantUMLRenderer) Extensions() []string {
+	if !IsPlantUMLEnabled() {
+		return nil
+	}
+	return []string{".puml", ".plantuml"}
+}
+
+// SanitizerRules implements markup.Renderer
+func (PlantUMLRenderer) SanitizerRules() []setting.MarkupSanitizerRule {
+	return svgImageSanitizerRules
+}
+
+// Render implements markup.Renderer
+func (r PlantUMLRenderer) Render(ctx *markup.RenderContext, input io.Reader, output io.Writer) error {
+	svg, err := r.RenderSVG(ctx, input)
+	if err != nil {
+		return writeRenderError(ctx, output, err)
+	}
+	return writeSVGImage(ctx, output, svg, "PlantUML diagram")
+}
+
+// RenderSVG implements markup.SVGRenderer
+func (PlantUMLRenderer) RenderSVG(ctx context.Context, input io.Reader) ([]byte, error) {
+	source, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return RenderPlantUML(ctx, string(source))
+}
+
+// IsPlantUMLEnabled returns true if a PlantUML server or command is configured to render the diagrams
+func IsPlantUMLEnabled() bool {
+	return setting.MarkupPlantUML.ServerURL != "" || setting.MarkupPlantUML.RenderCommand != ""
+}
+
+// NormalizePlantUMLSource returns the source of the diagram enclosed by "@startuml" and "@enduml" if it isn't enclosed yet,
+// so the diagrams of the markdown code blocks can omit them
+func NormalizePlantUMLSource(source string) string {
+	source = strings.TrimSpace(strings.ReplaceAll(source, "\r\n", "\n"))
+	if strings.HasPrefix(source, "@start") {
+		return source
+	}
+	return "@startuml\n" + source + "\n@enduml"
+}
+
+func checkPlantUMLSourceLength(source string) error {
+	if maxLength := setting.MarkupPlantUML.MaxSourceCharacters; maxLength >= 0 && len(source) > maxLength {
+		return util.NewInvalidArgumentErrorf("PlantUML source of %d characters exceeds the maximum allowed length of %d", len(source), maxLength)
+	}
+	return nil
+}
+
+// EncodePlantUML encodes the source of a diagram with the PlantUML text encoding used in the URLs of the PlantUML servers
+func EncodePlantUML(source string) (string, error) {
+	if err := checkPlantUMLSourceLength(source); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(source)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	// PlantUML encodes the data by groups of 3 bytes, the last group is padded with zeros
+	data := buf.Bytes()
+	if n := len(data) % 3; n != 0 {
+		data = append(data, make([]byte, 3-n)...)
+	}
+	return plantUMLEncoding.EncodeToString(data), nil
+}
+
+// DecodePlantUML decodes the source of a diagram encoded by EncodePlantUML
+func DecodePlantUML(encoded string) (string, error) {
+	data, err := plantUMLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", util.NewInvalidArgumentErrorf("invalid encoded PlantUML source: %v", err)
+	}
+	maxLength := setting.MarkupPlantUML.MaxSourceCharacters
+	r := flate.NewReader(bytes.NewReader(data))
+	if maxLength >= 0 {
+		r = io.NopCloser(io.LimitReader(r, int64(maxLength)+1))
+	}
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return "", util.NewInvalidArgumentErrorf("invalid encoded PlantUML source: %v", err)
+	}
+	if err := checkPlantUMLSourceLength(string(source)); err != nil {
+		return "", err
+	}
+	return string(source), nil
+}
+
+// RenderPlantUML renders the diagram to a SVG image with the configured PlantUML command or server
+func RenderPlantUML(ctx context.Context, source string) ([]byte, error) {
+	if !IsPlantUMLEnabled() {
+		return nil, util.NewInvalidArgumentErrorf("PlantUML rendering is not enabled")
+	}
+	source = NormalizePlantUMLSource(source)
+	if err := checkPlantUMLSourceLength(source); err != nil {
+		return nil, err
+	}
+	return renderCached("plantuml", []byte(source), func() (svg []byte, err error) {
+		if setting.MarkupPlantUML.RenderCommand != "" {
+			svg, err = renderPlantUMLByCommand(ctx, source)
+		} else {
+			svg, err = renderPlantUMLByServer(ctx, source)
+		}
+		if err == nil && !bytes.Contains(svg, []byte("<svg")) {
+			err = fmt.Errorf("PlantUML hasn't rendered a SVG image")
+		}
+		return svg, err
+	})
+}
+
+func renderPlantUMLByCommand(ctx context.Context, source string) ([]byte, error) {
+	ctx, _, finished := process.GetManager().AddContextTimeout(ctx, time.Minute, "Render PlantUML diagram")
+	defer finished()
+
+	commands := strings.Fields(setting.MarkupPlantUML.RenderCommand)
+	cmd := exec.CommandContext(ctx, commands[0], commands[1:]...)
+	// the default security profile of PlantUML lets the diagrams include the local files and any URL
+	cmd.Env = append(os.Environ(), "PLANTUML_SECURITY_PROFILE="+setting.MarkupPlantUML.SecurityProfile)
+	process.SetSysProcAttribute(cmd)
+	cmd.Stdin = strings.NewReader(source)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("PlantUML command %q failed: %w, stderr: %s", commands[0], err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+func renderPlantUMLByServer(ctx context.Context, source string) ([]byte, error) {
+	encoded, err := EncodePlantUML(source)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, setting.MarkupPlantUML.ServerURL+"/svg/"+encoded, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: &http.Transport{Proxy: proxy.Proxy()}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// the server responds a SVG image of the error with the status 400 if the diagram has a syntax error
+	isErrorImage := resp.StatusCode == http.StatusBadRequest && strings.HasPrefix(resp.Header.Get("Content-Type"), "image/svg+xml")
+	if resp.StatusCode != http.StatusOK && !isErrorImage {
+		return nil, fmt.Errorf("PlantUML server responded with status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSVGSize))
+}
@@ -5,6 +5,8 @@ package external
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -81,6 +83,10 @@ func (p *Renderer) Render(ctx *markup.RenderContext, input io.Reader, output io.
 		envMark("GITEA_PREFIX_SRC"), baseLinkSrc,
 		envMark("GITEA_PREFIX_RAW"), baseLinkRaw,
 	).Replace(p.Command)
+	if p.Sandbox == setting.RenderSandboxCommand {
+		// the sandbox command runs the command of the renderer which is given as its arguments
+		command = p.SandboxCommand + " " + command
+	}
 	commands := strings.Fields(command)
 	args := commands[1:]
 
@@ -102,15 +108,29 @@ func (p *Renderer) Render(ctx *markup.RenderContext, input io.Reader, output io.
 		if err != nil {
 			return fmt.Errorf("%s close temp file when rendering %s failed: %w", p.Name(), p.Command, err)
 		}
+		if p.RunAsUser != "" {
+			// the temp file must be readable by the user who runs the command
+			if err := os.Chmod(f.Name(), 0o644); err != nil {
+				return fmt.Errorf("%s change mode of temp file when rendering %s failed: %w", p.Name(), p.Command, err)
+			}
+		}
 		args = append(args, f.Name())
 	}
 
-	processCtx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("Render [%s] for %s", commands[0], baseLinkSrc))
+	description := fmt.Sprintf("Render [%s] for %s", commands[0], baseLinkSrc)
+	var processCtx context.Context
+	var cancel context.CancelFunc
+	var finished process.FinishedFunc
+	if p.Timeout > 0 {
+		processCtx, cancel, finished = process.GetManager().AddContextTimeout(ctx, p.Timeout, description)
+	} else {
+		processCtx, cancel, finished = process.GetManager().AddContext(ctx, description)
+	}
 	defer finished()
 
-	cmd := exec.CommandContext(processCtx, commands[0], args...)
-	cmd.Env = append(
-		os.Environ(),
+	cmdName, cmdArgs := resourceLimitedCommand(p.MarkupRenderer, commands[0], args)
+	cmd := exec.CommandContext(processCtx, cmdName, cmdArgs...)
+	cmd.Env = commandEnv(p.MarkupRenderer,
 		"GITEA_PREFIX_SRC="+baseLinkSrc,
 		"GITEA_PREFIX_RAW="+baseLinkRaw,
 	)
@@ -119,10 +139,27 @@ func (p *Renderer) Render(ctx *markup.RenderContext, input io.Reader, output io.
 	}
 	var stderr bytes.Buffer
 	cmd.Stdout = output
+	var limitedOutput *limitedWriter
+	if p.MaxOutputSize >= 0 {
+		limitedOutput = &limitedWriter{w: output, remaining: p.MaxOutputSize, cancel: cancel}
+		cmd.Stdout = limitedOutput
+	}
 	cmd.Stderr = &stderr
 	process.SetSysProcAttribute(cmd)
+	if err := setSandboxAttributes(cmd, p.MarkupRenderer); err != nil {
+		return fmt.Errorf("%s render sandbox of command %s failed: %w", p.Name(), commands[0], err)
+	}
 
-	if err := cmd.Run(); err != nil {
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s render start command %s %v failed: %w", p.Name(), commands[0], args, err)
+	}
+	if err := cmd.Wait(); err != nil {
+		switch {
+		case limitedOutput != nil && limitedOutput.exceeded:
+			return fmt.Errorf("%s render output of command %s exceeds the maximum size of %d bytes", p.Name(), commands[0], p.MaxOutputSize)
+		case errors.Is(processCtx.Err(), context.DeadlineExceeded):
+			return fmt.Errorf("%s render command %s timed out after %v", p.Name(), commands[0], p.Timeout)
+		}
 		return fmt.Errorf("%s render run command %s %v failed: %w\nStderr: %s", p.Name(), commands[0], args, err, stderr.String())
 	}
 	return nil
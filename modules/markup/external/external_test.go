@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package external
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func render(t *testing.T, renderer *setting.MarkupRenderer, input string) (string, error) {
+	var output strings.Builder
+	err := (&Renderer{renderer}).Render(markup.NewRenderContext(t.Context()), strings.NewReader(input), &output)
+	return output.String(), err
+}
+
+func TestRenderLimits(t *testing.T) {
+	output, err := render(t, &setting.MarkupRenderer{MarkupName: "cat", Command: "cat", MaxMemory: -1, MaxOutputSize: 5}, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", output)
+
+	_, err = render(t, &setting.MarkupRenderer{MarkupName: "cat", Command: "cat", MaxMemory: -1, MaxOutputSize: 4}, "hello")
+	assert.ErrorContains(t, err, "exceeds the maximum size of 4 bytes")
+
+	_, err = render(t, &setting.MarkupRenderer{MarkupName: "sleep", Command: "sleep 10", MaxMemory: -1, MaxOutputSize: -1, Timeout: 100 * time.Millisecond}, "")
+	assert.ErrorContains(t, err, "timed out after 100ms")
+}
+
+func TestRenderResourceLimits(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the resource limits are only supported on Linux")
+	}
+
+	// the limits are already effective when the renderer starts
+	// dd allocates a buffer of the block size
+	allocate := &setting.MarkupRenderer{MarkupName: "dd", Command: "dd if=/dev/zero of=/dev/null bs=256M count=1", MaxMemory: 64 << 20, MaxOutputSize: -1}
+	_, err := render(t, allocate, "")
+	assert.ErrorContains(t, err, "render run command dd")
+
+	allocate.MaxMemory = -1
+	_, err = render(t, allocate, "")
+	assert.NoError(t, err)
+
+	output, err := render(t, &setting.MarkupRenderer{MarkupName: "cat", Command: "cat", MaxMemory: 64 << 20, MaxCPUTime: time.Second, MaxOutputSize: -1}, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", output)
+}
+
+func TestRenderSandboxCommand(t *testing.T) {
+	t.Setenv("GITEA_TEST_SECRET", "secret")
+	renderer := &setting.MarkupRenderer{MarkupName: "env", Command: "sh -c env", MaxMemory: -1, MaxOutputSize: -1, Sandbox: setting.RenderSandboxNone}
+	output, err := render(t, renderer, "")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "GITEA_TEST_SECRET=secret")
+
+	// the sandbox command gets the render command as arguments, and the environment of Gitea isn't passed to the sandbox
+	renderer.Sandbox, renderer.SandboxCommand = setting.RenderSandboxCommand, "env"
+	output, err = render(t, renderer, "")
+	assert.NoError(t, err)
+	assert.NotContains(t, output, "GITEA_TEST_SECRET")
+	assert.Contains(t, output, "GITEA_PREFIX_SRC=")
+}
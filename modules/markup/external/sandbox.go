@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package external
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+var errOutputTooLarge = errors.New("output exceeds the maximum size")
+
+// limitedWriter writes the output of a renderer until its maximum size, then the command is cancelled
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+	cancel    context.CancelFunc
+	exceeded  bool
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		l.exceeded = true
+		l.cancel()
+		return 0, errOutputTooLarge
+	}
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// commandEnv returns the environment variables of the command, the sandboxed commands don't inherit the environment
+// of Gitea which can contain secrets.
+func commandEnv(renderer *setting.MarkupRenderer, extra ...string) []string {
+	if renderer.Sandbox == setting.RenderSandboxNone && renderer.RunAsUser == "" {
+		return append(os.Environ(), extra...)
+	}
+	var env []string
+	for _, name := range []string{"PATH", "LANG", "LC_ALL", "TZ"} {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return append(env, extra...)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package external
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// setSandboxAttributes sets the user and the namespaces of the command according to the settings of the renderer
+func setSandboxAttributes(cmd *exec.Cmd, renderer *setting.MarkupRenderer) error {
+	if renderer.RunAsUser != "" {
+		credential, err := lookupCredential(renderer.RunAsUser)
+		if err != nil {
+			return err
+		}
+		cmd.SysProcAttr.Credential = credential
+	}
+	if renderer.Sandbox == setting.RenderSandboxNamespace {
+		// the command can't access the network, the other processes and the IPC objects of the host
+		cmd.SysProcAttr.Cloneflags = syscall.CLONE_NEWNET | syscall.CLONE_NEWPID | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS
+		if os.Geteuid() != 0 {
+			// unprivileged processes can only create the namespaces in a new user namespace, the user is mapped to itself
+			cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER
+			cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Geteuid(), HostID: os.Geteuid(), Size: 1}}
+			cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getegid(), HostID: os.Getegid(), Size: 1}}
+		}
+	}
+	return nil
+}
+
+// lookupCredential returns the credential of a user given by name or by uid
+func lookupCredential(name string) (*syscall.Credential, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		if u, err = user.LookupId(name); err != nil {
+			return nil, fmt.Errorf("unable to find the user %q to run the renderer: %w", name, err)
+		}
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid), NoSetGroups: true}, nil
+}
+
+// resourceLimitedCommand returns the command which runs the renderer with limited memory and CPU time, the limits
+// are set by a shell before it executes the renderer, so they are already effective when the renderer starts
+func resourceLimitedCommand(renderer *setting.MarkupRenderer, name string, args []string) (string, []string) {
+	var limits []string
+	if renderer.MaxMemory >= 0 {
+		// the virtual memory is given in KiB
+		limits = append(limits, fmt.Sprintf("ulimit -v %d", (renderer.MaxMemory+1023)/1024))
+	}
+	if renderer.MaxCPUTime > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -t %d", int64(math.Ceil(renderer.MaxCPUTime.Seconds()))))
+	}
+	if len(limits) == 0 {
+		return name, args
+	}
+	// the renderer and its arguments are passed as the positional parameters, they are never interpreted by the shell
+	script := strings.Join(limits, " && ") + ` && exec "$0" "$@"`
+	return "/bin/sh", append([]string{"-c", script, name}, args...)
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+
+package external
+
+import (
+	"os/exec"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// setSandboxAttributes does nothing, the namespaces and the other users are only supported on Linux
+func setSandboxAttributes(_ *exec.Cmd, _ *setting.MarkupRenderer) error {
+	return nil
+}
+
+// resourceLimitedCommand returns the command unchanged, the resource limits are only supported on Linux
+func resourceLimitedCommand(_ *setting.MarkupRenderer, name string, args []string) (string, []string) {
+	return name, args
+}
@@ -98,6 +98,10 @@ func visitNodeImg(ctx *RenderContext, img *html.Node) (next *html.Node) {
 		}
 
 		imgAttr.Val = ctx.RenderHelper.ResolveLink(imgSrcOrigin, LinkTypeMedia)
+		// the files like diagrams are served as images rendered by their renderers
+		if !IsFullURLString(imgSrcOrigin) && !strings.Contains(imgSrcOrigin, "?") && GetSVGRendererByFileName(imgSrcOrigin) != nil {
+			imgAttr.Val += "?format=svg"
+		}
 		imgAttr.Val = camoHandleLink(imgAttr.Val)
 		img.Attr[i] = imgAttr
 	}
@@ -0,0 +1,286 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jupyter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"code.gitea.io/gitea/modules/highlight"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation"
+	"code.gitea.io/gitea/modules/util"
+
+	trend "github.com/buildkite/terminal-to-html/v3"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func init() {
+	markup.RegisterRenderer(Renderer{})
+}
+
+// Renderer implements markup.Renderer for Jupyter notebooks
+type Renderer struct{}
+
+var _ markup.PostProcessRenderer = (*Renderer)(nil)
+
+// Name implements markup.Renderer
+func (Renderer) Name() string {
+	return "jupyter"
+}
+
+// NeedPostProcess implements markup.PostProcessRenderer
+func (Renderer) NeedPostProcess() bool { return true }
+
+// Extensions implements markup.Renderer
+func (Renderer) Extensions() []string {
+	return []string{".ipynb"}
+}
+
+// SanitizerRules implements markup.Renderer
+func (Renderer) SanitizerRules() []setting.MarkupSanitizerRule {
+	return []setting.MarkupSanitizerRule{
+		// the images of the outputs are embedded in the notebook
+		{AllowDataURIImages: true},
+		{Element: "span", AllowAttr: "class", Regexp: `^term-((fg[ix]?|bg)\d+|container)$`},
+	}
+}
+
+// multilineString is a string of the notebook format which can be either a string or a list of lines
+type multilineString string
+
+func (s *multilineString) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = multilineString(str)
+		return nil
+	}
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return err
+	}
+	*s = multilineString(strings.Join(lines, ""))
+	return nil
+}
+
+type notebook struct {
+	NBFormat int `json:"nbformat"`
+	Metadata struct {
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+		KernelSpec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+	} `json:"metadata"`
+	Cells []*cell `json:"cells"`
+}
+
+type cell struct {
+	CellType       string                                `json:"cell_type"`
+	Source         multilineString                       `json:"source"`
+	ExecutionCount *int                                  `json:"execution_count"`
+	Outputs        []*output                             `json:"outputs"`
+	Attachments    map[string]map[string]multilineString `json:"attachments"`
+}
+
+type output struct {
+	OutputType     string                     `json:"output_type"`
+	ExecutionCount *int                       `json:"execution_count"`
+	Name           string                     `json:"name"`
+	Text           multilineString            `json:"text"`
+	Data           map[string]multilineString `json:"data"`
+	EName          string                     `json:"ename"`
+	EValue         string                     `json:"evalue"`
+	Traceback      []string                   `json:"traceback"`
+}
+
+// imageMimeTypes are the image types of the outputs and attachments which can be embedded as data URIs
+var imageMimeTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// displayMimeTypes are the types of the output data in the order of preference, a display output may provide several types
+var displayMimeTypes = append([]string{"text/html", "text/markdown", "text/latex"}, append(imageMimeTypes, "text/plain")...)
+
+// Render renders the notebook cells to HTML
+func (Renderer) Render(ctx *markup.RenderContext, input io.Reader, output io.Writer) error {
+	var nb notebook
+	if err := json.NewDecoder(input).Decode(&nb); err != nil || nb.NBFormat < 4 {
+		return writeInvalidNotebook(ctx, output)
+	}
+
+	language := nb.Metadata.LanguageInfo.Name
+	if language == "" {
+		language = nb.Metadata.KernelSpec.Language
+	}
+
+	w := bufio.NewWriter(output)
+	if _, err := w.WriteString(string(ctx.RenderInternal.ProtectSafeAttrs(`<div class="notebook">`))); err != nil {
+		return err
+	}
+	for _, c := range nb.Cells {
+		var err error
+		switch c.CellType {
+		case "markdown":
+			err = renderMarkdownCell(ctx, w, c)
+		case "code":
+			err = renderCodeCell(ctx, w, c, language)
+		default:
+			err = ctx.RenderInternal.FormatWithSafeAttrs(w, `<div class="notebook-cell notebook-raw-cell"><pre>%s</pre></div>`, string(c.Source))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := w.WriteString("</div>"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func writeInvalidNotebook(ctx *markup.RenderContext, output io.Writer) error {
+	message, viewSource := "The notebook can't be rendered.", "View Source"
+	if locale, ok := ctx.Value(translation.ContextKey).(translation.Locale); ok {
+		message, viewSource = locale.TrString("repo.notebook_invalid"), locale.TrString("repo.file_view_source")
+	}
+	link := ctx.RenderHelper.ResolveLink(util.PathEscapeSegments(ctx.RenderOptions.RelativePath), markup.LinkTypeDefault) + "?display=source"
+	return ctx.RenderInternal.FormatWithSafeAttrs(output, `<div class="notebook-invalid">%s <a href="%s">%s</a></div>`, message, link, viewSource)
+}
+
+func renderMarkdown(ctx *markup.RenderContext, w io.Writer, source string) error {
+	return markdown.Renderer{}.Render(ctx, strings.NewReader(source), w)
+}
+
+func renderMarkdownCell(ctx *markup.RenderContext, w io.Writer, c *cell) error {
+	source := string(c.Source)
+	// the images pasted into the markdown cells are stored as attachments of the cell
+	for name, attachment := range c.Attachments {
+		for _, mimeType := range imageMimeTypes {
+			if data, ok := attachment[mimeType]; ok {
+				source = strings.ReplaceAll(source, "attachment:"+name, dataURI(mimeType, data))
+				break
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, string(ctx.RenderInternal.ProtectSafeAttrs(`<div class="notebook-cell notebook-markdown-cell">`))); err != nil {
+		return err
+	}
+	if err := renderMarkdown(ctx, w, source); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</div>")
+	return err
+}
+
+func executionCount(count *int) string {
+	if count == nil {
+		return " "
+	}
+	return fmt.Sprint(*count)
+}
+
+func renderCodeCell(ctx *markup.RenderContext, w io.Writer, c *cell, language string) error {
+	code, _ := highlight.Code("", language, string(c.Source))
+	if err := ctx.RenderInternal.FormatWithSafeAttrs(w, `<div class="notebook-cell notebook-code-cell"><div class="notebook-input"><div class="notebook-prompt">In [%s]:</div><pre class="notebook-source"><code class="chroma language-%s">%s</code></pre></div>`,
+		executionCount(c.ExecutionCount), language, code); err != nil {
+		return err
+	}
+
+	for _, o := range c.Outputs {
+		prompt := ""
+		if o.OutputType == "execute_result" {
+			prompt = fmt.Sprintf("Out [%s]:", executionCount(o.ExecutionCount))
+		}
+		if err := ctx.RenderInternal.FormatWithSafeAttrs(w, `<div class="notebook-output"><div class="notebook-prompt">%s</div><div class="notebook-output-content">`, prompt); err != nil {
+			return err
+		}
+		if err := renderOutput(ctx, w, o); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "</div></div>"); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "</div>")
+	return err
+}
+
+// renderTerminalText renders the text of a stream or an error which may contain ANSI colors
+func renderTerminalText(ctx *markup.RenderContext, w io.Writer, class, text string) error {
+	return ctx.RenderInternal.FormatWithSafeAttrs(w, `<pre class="%s">%s</pre>`, class, template.HTML(trend.Render([]byte(text))))
+}
+
+func renderOutput(ctx *markup.RenderContext, w io.Writer, o *output) error {
+	switch o.OutputType {
+	case "stream":
+		class := "notebook-stream"
+		if o.Name == "stderr" {
+			class += " notebook-stderr"
+		}
+		return renderTerminalText(ctx, w, class, string(o.Text))
+	case "error":
+		text := strings.Join(o.Traceback, "\n")
+		if text == "" {
+			text = o.EName + ": " + o.EValue
+		}
+		return renderTerminalText(ctx, w, "notebook-error", text)
+	case "execute_result", "display_data":
+		for _, mimeType := range displayMimeTypes {
+			data, ok := o.Data[mimeType]
+			if !ok {
+				continue
+			}
+			switch mimeType {
+			case "text/html":
+				return renderOutputHTML(w, string(data))
+			case "text/markdown":
+				return renderMarkdown(ctx, w, string(data))
+			case "text/latex":
+				// latex outputs are usually math environments enclosed in "$" or "$$" which are rendered as math by markdown
+				return renderMarkdown(ctx, w, string(data))
+			case "text/plain":
+				return ctx.RenderInternal.FormatWithSafeAttrs(w, `<pre class="notebook-text">%s</pre>`, string(data))
+			default:
+				return ctx.RenderInternal.FormatWithSafeAttrs(w, `<img src="%s" alt="%s">`, dataURI(mimeType, data), mimeType)
+			}
+		}
+	}
+	return nil
+}
+
+// renderOutputHTML balances the tags of the html of an output so that it can't break out of its container,
+// the html is sanitized with the rest of the rendered notebook
+func renderOutputHTML(w io.Writer, s string) error {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := html.Render(w, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataURI returns the data URI of the base64 encoded data, the data of the notebooks may contain line breaks
+func dataURI(mimeType string, data multilineString) string {
+	var b bytes.Buffer
+	b.WriteString("data:" + mimeType + ";base64,")
+	for _, r := range []byte(data) {
+		if r != '\n' && r != '\r' {
+			b.WriteByte(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jupyter
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testNotebook = `{
+ "nbformat": 4,
+ "nbformat_minor": 5,
+ "metadata": {"language_info": {"name": "python"}},
+ "cells": [
+  {"cell_type": "markdown", "metadata": {}, "source": ["# Analysis\n", "See ![plot](attachment:plot.png) and $x^2$"],
+   "attachments": {"plot.png": {"image/png": "iVBORw0KGgo="}}},
+  {"cell_type": "code", "execution_count": 1, "metadata": {}, "source": "print('hello')\n1 + 1",
+   "outputs": [
+    {"output_type": "stream", "name": "stdout", "text": ["hello\n"]},
+    {"output_type": "execute_result", "execution_count": 1, "metadata": {}, "data": {"text/plain": ["2"]}}
+   ]},
+  {"cell_type": "code", "execution_count": 2, "metadata": {}, "source": "plot()",
+   "outputs": [
+    {"output_type": "display_data", "metadata": {}, "data": {"image/png": "iVBORw0K\nGgo=\n", "text/plain": ["<Figure>"]}},
+    {"output_type": "display_data", "metadata": {}, "data": {"text/html": ["<table><tr><td>cell</td></tr></table><script>alert(1)</script></div></div>"]}},
+    {"output_type": "error", "ename": "ValueError", "evalue": "bad", "traceback": ["\u001b[31mValueError\u001b[0m: bad"]}
+   ]},
+  {"cell_type": "raw", "metadata": {}, "source": "<b>raw</b>"}
+ ]
+}`
+
+func render(t *testing.T, input string) string {
+	var buf strings.Builder
+	err := markup.Render(markup.NewRenderContext(t.Context()).WithRelativePath("test.ipynb"), strings.NewReader(input), &buf)
+	assert.NoError(t, err)
+	return buf.String()
+}
+
+func TestRenderNotebook(t *testing.T) {
+	defer test.MockVariableValue(&setting.Markdown.MathCodeBlockOptions, setting.MarkdownMathCodeBlockOptions{ParseInlineDollar: true})()
+	html := render(t, testNotebook)
+
+	assert.Contains(t, html, `<div class="notebook-cell notebook-markdown-cell"><h1 id="user-content-analysis" dir="auto">Analysis</h1>`)
+	assert.Contains(t, html, `<img src="data:image/png;base64,iVBORw0KGgo=" alt="plot"`)
+	assert.Contains(t, html, `<code class="language-math">x^2</code>`)
+
+	assert.Contains(t, html, `<div class="notebook-prompt">In [1]:</div>`)
+	assert.Contains(t, html, `<code class="chroma language-python"><span class="nb">print</span>`)
+	assert.Contains(t, html, `<pre class="notebook-stream">hello</pre>`)
+	assert.Contains(t, html, `<div class="notebook-prompt">Out [1]:</div><div class="notebook-output-content"><pre class="notebook-text">2</pre>`)
+
+	// the image is preferred to the text of the figure
+	assert.Contains(t, html, `<img src="data:image/png;base64,iVBORw0KGgo=" alt="image/png" loading="lazy"/>`)
+	assert.NotContains(t, html, "&lt;Figure&gt;")
+
+	// the html of the outputs is sanitized and can't close the containers of the cells
+	assert.Contains(t, html, `<table><tbody><tr><td>cell</td></tr></tbody></table>&lt;script&gt;alert(1)&lt;/script&gt;</div></div>`)
+	assert.NotContains(t, html, "<script")
+
+	assert.Contains(t, html, `<pre class="notebook-error"><span class="term-fg31">ValueError</span>: bad</pre>`)
+	assert.Contains(t, html, `<div class="notebook-cell notebook-raw-cell"><pre>&lt;b&gt;raw&lt;/b&gt;</pre></div>`)
+}
+
+func TestRenderInvalidNotebook(t *testing.T) {
+	for _, input := range []string{"not json", `{"nbformat": 3, "worksheets": []}`} {
+		html := render(t, input)
+		assert.Contains(t, html, `<div class="notebook-invalid">The notebook can&#39;t be rendered. <a href="/test.ipynb?display=source"`)
+	}
+}
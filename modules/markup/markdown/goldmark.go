@@ -46,6 +46,8 @@ func (g *ASTTransformer) Transform(node *ast.Document, reader text.Reader, pc pa
 	rc := pc.Get(renderConfigKey).(*RenderConfig)
 
 	tocList := make([]Header, 0, 20)
+	// the code blocks can't be replaced while walking the tree
+	replacedCodeBlocks := map[ast.Node]ast.Node{}
 	if rc.yamlNode != nil {
 		metaNode := rc.toMetaNode(g)
 		if metaNode != nil {
@@ -75,9 +77,16 @@ func (g *ASTTransformer) Transform(node *ast.Document, reader text.Reader, pc pa
 			g.transformCodeSpan(ctx, v, reader)
 		case *ast.Blockquote:
 			return g.transformBlockquote(v, reader)
+		case *ast.FencedCodeBlock:
+			if replacement := g.transformPlantUMLCodeBlock(ctx, v, reader); replacement != nil {
+				replacedCodeBlocks[v] = replacement
+			}
 		}
 		return ast.WalkContinue, nil
 	})
+	for codeBlock, replacement := range replacedCodeBlocks {
+		codeBlock.Parent().ReplaceChild(codeBlock.Parent(), codeBlock, replacement)
+	}
 
 	showTocInMain := tocMode == "true" /* old behavior, in main view */ || tocMode == "main"
 	showTocInSidebar := !showTocInMain && tocMode != "false" // not hidden, not main, then show it in sidebar
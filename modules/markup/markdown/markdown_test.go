@@ -11,6 +11,7 @@ import (
 
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/diagram"
 	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
@@ -547,3 +548,17 @@ func TestMarkdownLink(t *testing.T) {
 <a href="#user-content-foo" rel="nofollow">link3</a></p>
 `, string(result))
 }
+
+func TestMarkdownPlantUML(t *testing.T) {
+	input := "```plantuml\nBob -> Alice : hello\n```\n"
+	result, err := markdown.RenderString(markup.NewTestRenderContext(), input)
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), `<code class="chroma language-plantuml display">`)
+
+	defer test.MockVariableValue(&setting.MarkupPlantUML.ServerURL, "https://plantuml.example.com")()
+	encoded, err := diagram.EncodePlantUML("@startuml\nBob -> Alice : hello\n@enduml")
+	assert.NoError(t, err)
+	result, err = markdown.RenderString(markup.NewTestRenderContext(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div class="plantuml-block"><a href="/-/markup/plantuml/`+encoded+`" target="_blank" rel="nofollow noopener"><img src="/-/markup/plantuml/`+encoded+`" alt="PlantUML diagram"/></a></div>`, string(result))
+}
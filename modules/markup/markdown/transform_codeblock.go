@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package markdown
+
+import (
+	"bytes"
+
+	"code.gitea.io/gitea/modules/htmlutil"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/diagram"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// transformPlantUMLCodeBlock returns the image of the diagram of a "plantuml" code block which replaces the code block,
+// the image is rendered by the server when it is requested. It returns nil if the code block isn't a PlantUML diagram.
+func (g *ASTTransformer) transformPlantUMLCodeBlock(ctx *markup.RenderContext, v *ast.FencedCodeBlock, reader text.Reader) ast.Node {
+	language := string(v.Language(reader.Source()))
+	if (language != "plantuml" && language != "puml") || !diagram.IsPlantUMLEnabled() {
+		return nil
+	}
+
+	var source bytes.Buffer
+	lines := v.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		source.Write(segment.Value(reader.Source()))
+	}
+	// the diagrams which are too large are left as code blocks
+	encoded, err := diagram.EncodePlantUML(diagram.NormalizePlantUMLSource(source.String()))
+	if err != nil {
+		return nil
+	}
+	link := ctx.ResolveLinkRoot("-/markup/plantuml/" + encoded)
+	return NewRawHTML(htmlutil.HTMLFormat(`<div class="plantuml-block"><img src="%s" alt="PlantUML diagram"></div>`, link))
+}
@@ -4,6 +4,7 @@
 package markup
 
 import (
+	"context"
 	"io"
 	"path"
 	"strings"
@@ -40,6 +41,12 @@ type RendererContentDetector interface {
 	CanRender(filename string, sniffedType typesniffer.SniffedType, prefetchBuf []byte) bool
 }
 
+// SVGRenderer defines an interface for renderers which can also render a file to a standalone SVG image,
+// so that the file can be embedded as an image in other documents
+type SVGRenderer interface {
+	RenderSVG(ctx context.Context, input io.Reader) ([]byte, error)
+}
+
 var (
 	extRenderers = make(map[string]Renderer)
 	renderers    = make(map[string]Renderer)
@@ -59,6 +66,12 @@ func GetRendererByFileName(filename string) Renderer {
 	return extRenderers[extension]
 }
 
+// GetSVGRendererByFileName returns the renderer of the file if it can render the file to a SVG image
+func GetSVGRendererByFileName(filename string) SVGRenderer {
+	renderer, _ := GetRendererByFileName(filename).(SVGRenderer)
+	return renderer
+}
+
 // DetectRendererType detects the markup type of the content
 func DetectRendererType(filename string, sniffedType typesniffer.SniffedType, prefetchBuf []byte) string {
 	for _, renderer := range renderers {
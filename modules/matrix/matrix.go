@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package matrix is a minimal client of the Matrix client-server API to send direct messages to users
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"code.gitea.io/gitea/modules/json"
+)
+
+// userIDPattern matches the Matrix user IDs like @alice:example.org
+var userIDPattern = regexp.MustCompile(`^@[a-z0-9._=/+\-]+:[A-Za-z0-9.\-]+(:[0-9]+)?$`)
+
+// IsValidUserID returns whether a string is a Matrix user ID
+func IsValidUserID(id string) bool {
+	return len(id) <= 255 && userIDPattern.MatchString(id)
+}
+
+// Error is an error returned by a homeserver
+type Error struct {
+	StatusCode int    `json:"-"`
+	ErrCode    string `json:"errcode"`
+	Message    string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("matrix homeserver responded with status %d: %s %s", e.StatusCode, e.ErrCode, e.Message)
+}
+
+// IsErrForbidden returns whether the homeserver refused a request, e.g. a message to a room the account has left
+func IsErrForbidden(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusForbidden
+}
+
+// Client sends requests to a homeserver on behalf of an account
+type Client struct {
+	HomeserverURL string
+	AccessToken   string
+	HTTPClient    *http.Client
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.HomeserverURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &Error{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(respBody, e)
+		return e
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+// CreateDirectRoom creates a private room for the direct messages to a user and invites the user to it
+func (c *Client) CreateDirectRoom(ctx context.Context, userID string) (string, error) {
+	var result struct {
+		RoomID string `json:"room_id"`
+	}
+	err := c.do(ctx, http.MethodPost, "/_matrix/client/v3/createRoom", map[string]any{
+		"is_direct": true,
+		"invite":    []string{userID},
+		"preset":    "trusted_private_chat",
+	}, &result)
+	return result.RoomID, err
+}
+
+// SendNotice sends a notice to a room, the homeserver ignores the messages sent again with the same transaction ID
+func (c *Client) SendNotice(ctx context.Context, roomID, txnID, body, formattedBody string) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/_matrix/client/v3/rooms/%s/send/m.room.message/%s", url.PathEscape(roomID), url.PathEscape(txnID)), map[string]string{
+		"msgtype":        "m.notice",
+		"body":           body,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formattedBody,
+	}, nil)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package matrix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidUserID(t *testing.T) {
+	assert.True(t, IsValidUserID("@alice:example.org"))
+	assert.True(t, IsValidUserID("@bob.smith_1:matrix.example.org:8448"))
+	assert.False(t, IsValidUserID("alice:example.org"))
+	assert.False(t, IsValidUserID("@Alice:example.org"))
+	assert.False(t, IsValidUserID("@alice"))
+	assert.False(t, IsValidUserID("@alice:"))
+}
+
+func TestClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.EscapedPath() {
+		case "/_matrix/client/v3/createRoom":
+			assert.Equal(t, true, body["is_direct"])
+			assert.Equal(t, []any{"@alice:example.org"}, body["invite"])
+			_, _ = w.Write([]byte(`{"room_id":"!room:example.org"}`))
+		case "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/txn1":
+			assert.Equal(t, "m.notice", body["msgtype"])
+			assert.Equal(t, "hello", body["body"])
+			_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errcode":"M_FORBIDDEN","error":"not in room"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{HomeserverURL: server.URL, AccessToken: "token", HTTPClient: server.Client()}
+	roomID, err := c.CreateDirectRoom(t.Context(), "@alice:example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "!room:example.org", roomID)
+
+	require.NoError(t, c.SendNotice(t.Context(), roomID, "txn1", "hello", "<b>hello</b>"))
+
+	err = c.SendNotice(t.Context(), "!left:example.org", "txn2", "hello", "hello")
+	assert.True(t, IsErrForbidden(err))
+	assert.ErrorContains(t, err, "M_FORBIDDEN")
+	assert.Len(t, requests, 3)
+}
@@ -19,6 +19,9 @@ const namespace = "gitea_"
 // exposes gitea metrics for prometheus
 type Collector struct {
 	Accesses           *prometheus.Desc
+	ActionCaches       *prometheus.Desc
+	ActionCacheSize    *prometheus.Desc
+	ActionCacheHits    *prometheus.Desc
 	Attachments        *prometheus.Desc
 	BuildInfo          *prometheus.Desc
 	Comments           *prometheus.Desc
@@ -41,6 +44,8 @@ type Collector struct {
 	Releases           *prometheus.Desc
 	Repositories       *prometheus.Desc
 	Stars              *prometheus.Desc
+	StorageTierObjects *prometheus.Desc
+	StorageTierSize    *prometheus.Desc
 	Teams              *prometheus.Desc
 	UpdateTasks        *prometheus.Desc
 	Users              *prometheus.Desc
@@ -56,6 +61,21 @@ func NewCollector() Collector {
 			"Number of Accesses",
 			nil, nil,
 		),
+		ActionCaches: prometheus.NewDesc(
+			namespace+"action_caches",
+			"Number of Actions caches",
+			nil, nil,
+		),
+		ActionCacheSize: prometheus.NewDesc(
+			namespace+"action_cache_size_bytes",
+			"Total size of Actions caches",
+			nil, nil,
+		),
+		ActionCacheHits: prometheus.NewDesc(
+			namespace+"action_cache_hits",
+			"Number of times the existing Actions caches have been restored",
+			nil, nil,
+		),
 		Attachments: prometheus.NewDesc(
 			namespace+"attachments",
 			"Number of Attachments",
@@ -171,6 +191,16 @@ func NewCollector() Collector {
 			"Number of Stars",
 			nil, nil,
 		),
+		StorageTierObjects: prometheus.NewDesc(
+			namespace+"storage_tier_objects",
+			"Number of objects in a tier of a storage",
+			[]string{"storage", "tier"}, nil,
+		),
+		StorageTierSize: prometheus.NewDesc(
+			namespace+"storage_tier_size_bytes",
+			"Total size of the objects in a tier of a storage",
+			[]string{"storage", "tier"}, nil,
+		),
 		Teams: prometheus.NewDesc(
 			namespace+"teams",
 			"Number of Teams",
@@ -202,6 +232,9 @@ func NewCollector() Collector {
 // Describe returns all possible prometheus.Desc
 func (c Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Accesses
+	ch <- c.ActionCaches
+	ch <- c.ActionCacheSize
+	ch <- c.ActionCacheHits
 	ch <- c.Attachments
 	ch <- c.BuildInfo
 	ch <- c.Comments
@@ -224,6 +257,8 @@ func (c Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Releases
 	ch <- c.Repositories
 	ch <- c.Stars
+	ch <- c.StorageTierObjects
+	ch <- c.StorageTierSize
 	ch <- c.Teams
 	ch <- c.UpdateTasks
 	ch <- c.Users
@@ -240,6 +275,21 @@ func (c Collector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		float64(stats.Counter.Access),
 	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ActionCaches,
+		prometheus.GaugeValue,
+		float64(stats.Counter.ActionCache),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ActionCacheSize,
+		prometheus.GaugeValue,
+		float64(stats.Counter.ActionCacheSize),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ActionCacheHits,
+		prometheus.GaugeValue,
+		float64(stats.Counter.ActionCacheHit),
+	)
 	ch <- prometheus.MustNewConstMetric(
 		c.Attachments,
 		prometheus.GaugeValue,
@@ -360,6 +410,20 @@ func (c Collector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		float64(stats.Counter.Star),
 	)
+	for _, st := range stats.Counter.StorageTier {
+		ch <- prometheus.MustNewConstMetric(
+			c.StorageTierObjects,
+			prometheus.GaugeValue,
+			float64(st.Count),
+			st.Storage, st.Tier,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.StorageTierSize,
+			prometheus.GaugeValue,
+			float64(st.Size),
+			st.Storage, st.Tier,
+		)
+	}
 	ch <- prometheus.MustNewConstMetric(
 		c.Teams,
 		prometheus.GaugeValue,
@@ -18,13 +18,15 @@ import (
 
 // Load project data from file, with optional validation
 func Load(filename string, data any, validation bool) error {
-	isJSON := strings.HasSuffix(filename, ".json")
-
 	bs, err := os.ReadFile(filename)
 	if err != nil {
 		return err
 	}
+	return Unmarshal(bs, data, strings.HasSuffix(filename, ".json"), validation)
+}
 
+// Unmarshal project data from JSON or YAML content, with optional validation
+func Unmarshal(bs []byte, data any, isJSON, validation bool) error {
 	if validation {
 		err := validate(bs, data, isJSON)
 		if err != nil {
@@ -66,6 +68,8 @@ func validate(bs []byte, datatype any, isJSON bool) error {
 		schemaFilename = "issue.json"
 	case *[]*Milestone:
 		schemaFilename = "milestone.json"
+	case *IssueArchive:
+		schemaFilename = "issue_archive.json"
 	default:
 		return fmt.Errorf("file_format:validate: %T has not a validation implemented", datatype)
 	}
@@ -4,11 +4,13 @@
 package migration
 
 import (
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMigrationJSON_IssueOK(t *testing.T) {
@@ -36,3 +38,20 @@ func TestMigrationJSON_MilestoneOK(t *testing.T) {
 	err := Load("file_format_testdata/milestones.json", &milestones, true)
 	assert.NoError(t, err)
 }
+
+func TestMigrationJSON_IssueArchive(t *testing.T) {
+	bs, err := os.ReadFile("file_format_testdata/issue_archive.json")
+	require.NoError(t, err)
+	var archive IssueArchive
+	require.NoError(t, Unmarshal(bs, &archive, true, true))
+	assert.Equal(t, IssueArchiveVersion, archive.Version)
+	require.Len(t, archive.Issues, 1)
+	assert.Equal(t, []string{"bug"}, archive.Issues[0].Labels)
+	require.Len(t, archive.Issues[0].Comments, 1)
+	assert.Equal(t, "other", archive.Issues[0].Comments[0].PosterName)
+
+	err = Unmarshal([]byte(`{"version": 1, "issues": [{"number": 1, "title": "title_a"}]}`), &archive, true, true)
+	var validationErr *jsonschema.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.GoString(), "poster_name")
+}
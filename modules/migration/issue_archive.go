@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migration
+
+import "time"
+
+const (
+	// IssueArchiveVersion is the version of the issue archive format written by this version of Gitea
+	IssueArchiveVersion = 1
+	// IssueArchiveFilename is the name of the JSON file describing the issues in an issue archive
+	IssueArchiveFilename = "issues.json"
+	// IssueArchiveAttachmentsDir is the directory of the files of the attachments in an issue archive
+	IssueArchiveAttachmentsDir = "attachments"
+)
+
+// IssueArchive is the content of the JSON file of an issue archive.
+// An issue archive is a zip file with this JSON file and the files of the attachments,
+// the format is described by the schema schemas/issue_archive.json.
+type IssueArchive struct {
+	Version    int              `json:"version"`
+	Repository string           `json:"repository"`
+	Created    time.Time        `json:"created"`
+	Labels     []*Label         `json:"labels"`
+	Milestones []*Milestone     `json:"milestones"`
+	Issues     []*ArchivedIssue `json:"issues"`
+}
+
+// ArchivedIssue is an issue with its comments in an issue archive
+type ArchivedIssue struct {
+	Number      int64                 `json:"number"`
+	PosterID    int64                 `json:"poster_id"`
+	PosterName  string                `json:"poster_name"`
+	Title       string                `json:"title"`
+	Content     string                `json:"content"`
+	Milestone   string                `json:"milestone,omitempty"`
+	State       string                `json:"state"` // closed, open
+	IsLocked    bool                  `json:"is_locked"`
+	Created     time.Time             `json:"created"`
+	Updated     time.Time             `json:"updated"`
+	Closed      *time.Time            `json:"closed,omitempty"`
+	Labels      []string              `json:"labels"`
+	Reactions   []*Reaction           `json:"reactions"`
+	Attachments []*ArchivedAttachment `json:"attachments"`
+	Comments    []*ArchivedComment    `json:"comments"`
+}
+
+// ArchivedComment is a comment of an issue in an issue archive
+type ArchivedComment struct {
+	PosterID    int64                 `json:"poster_id"`
+	PosterName  string                `json:"poster_name"`
+	Content     string                `json:"content"`
+	Created     time.Time             `json:"created"`
+	Updated     time.Time             `json:"updated"`
+	Reactions   []*Reaction           `json:"reactions"`
+	Attachments []*ArchivedAttachment `json:"attachments"`
+}
+
+// ArchivedAttachment is an attachment of an issue or a comment in an issue archive,
+// its file is stored as IssueArchiveAttachmentsDir/UUID in the archive.
+type ArchivedAttachment struct {
+	UUID    string    `json:"uuid"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
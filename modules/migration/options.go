@@ -38,6 +38,9 @@ type MigrateOptions struct {
 	ReleaseAssets   bool
 	MigrateToRepoID int64
 	MirrorInterval  string `json:"mirror_interval"`
+	MirrorRefFilter string `json:"mirror_ref_filter"`
+	MirrorSkipTags  bool   `json:"mirror_skip_tags"`
+	MirrorMaxSize   int64  `json:"mirror_max_size"`
 
 	AWSAccessKeyID     string
 	AWSSecretAccessKey string
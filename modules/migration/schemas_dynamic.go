@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 )
 
 func openSchema(s string) (io.ReadCloser, error) {
@@ -32,6 +33,13 @@ func openSchema(s string) (io.ReadCloser, error) {
 		//
 		if _, err := os.Stat(filename); os.IsNotExist(err) {
 			filename = filepath.Join("modules/migration/schemas", basename)
+			//
+			// The tests of the other packages run in their own directory.
+			//
+			if _, err := os.Stat(filename); os.IsNotExist(err) {
+				_, file, _, _ := runtime.Caller(0)
+				filename = filepath.Join(filepath.Dir(file), "schemas", basename)
+			}
 		}
 	}
 	return os.Open(filename)
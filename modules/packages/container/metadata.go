@@ -21,6 +21,7 @@ const (
 	PropertyMediaType         = "container.mediatype"
 	PropertyManifestTagged    = "container.manifest.tagged"
 	PropertyManifestReference = "container.manifest.reference"
+	PropertySubject           = "container.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -63,6 +64,9 @@ type Metadata struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
+	Subject          string            `json:"subject,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
 }
 
 type Manifest struct {
@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// ArtifactKind describes what an artifact attached to an image is about
+type ArtifactKind string
+
+const (
+	ArtifactKindSignature   ArtifactKind = "signature"
+	ArtifactKindAttestation ArtifactKind = "attestation"
+	ArtifactKindSBOM        ArtifactKind = "sbom"
+	ArtifactKindOther       ArtifactKind = "other"
+)
+
+// https://github.com/sigstore/cosign/blob/main/specs/SIGNATURE_SPEC.md
+// https://github.com/notaryproject/specifications/blob/main/specs/signature-specification.md
+var artifactTypeKinds = map[string]ArtifactKind{
+	"application/vnd.dev.cosign.artifact.sig.v1+json":  ArtifactKindSignature,
+	"application/vnd.dev.cosign.simplesigning.v1+json": ArtifactKindSignature,
+	"application/vnd.cncf.notary.signature":            ArtifactKindSignature,
+	"application/vnd.dsse.envelope.v1+json":            ArtifactKindAttestation,
+	"application/vnd.in-toto+json":                     ArtifactKindAttestation,
+	"application/vnd.dev.sigstore.bundle.v0.3+json":    ArtifactKindAttestation,
+	"application/vnd.dev.cosign.artifact.sbom.v1+json": ArtifactKindSBOM,
+	"application/spdx+json":                            ArtifactKindSBOM,
+	"text/spdx":                                        ArtifactKindSBOM,
+	"application/vnd.cyclonedx+json":                   ArtifactKindSBOM,
+	"application/vnd.cyclonedx+xml":                    ArtifactKindSBOM,
+	"application/vnd.syft+json":                        ArtifactKindSBOM,
+}
+
+// cosign stores the artifacts of an image without referrers support as tags named after the digest of the image
+var cosignTagSuffixes = map[string]ArtifactKind{
+	".sig":  ArtifactKindSignature,
+	".att":  ArtifactKindAttestation,
+	".sbom": ArtifactKindSBOM,
+}
+
+// GetArtifactKind returns the kind of an artifact with the artifact type
+func GetArtifactKind(artifactType string) ArtifactKind {
+	if kind, ok := artifactTypeKinds[strings.ToLower(artifactType)]; ok {
+		return kind
+	}
+	return ArtifactKindOther
+}
+
+// CosignTag returns the tag cosign uses to store the artifacts of the kind for the image digest
+func CosignTag(d digest.Digest, kind ArtifactKind) string {
+	for suffix, k := range cosignTagSuffixes {
+		if k == kind {
+			return d.Algorithm().String() + "-" + d.Encoded() + suffix
+		}
+	}
+	return ""
+}
+
+// ParseCosignTag returns the image digest and the kind of artifacts stored in the cosign tag
+func ParseCosignTag(tag string) (digest.Digest, ArtifactKind, bool) {
+	for suffix, kind := range cosignTagSuffixes {
+		name, ok := strings.CutSuffix(tag, suffix)
+		if !ok {
+			continue
+		}
+		algorithm, encoded, ok := strings.Cut(name, "-")
+		if !ok {
+			return "", "", false
+		}
+		d := digest.NewDigestFromEncoded(digest.Algorithm(algorithm), encoded)
+		if d.Validate() != nil {
+			return "", "", false
+		}
+		return d, kind, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArtifactKind(t *testing.T) {
+	assert.Equal(t, ArtifactKindSignature, GetArtifactKind("application/vnd.dev.cosign.artifact.sig.v1+json"))
+	assert.Equal(t, ArtifactKindSignature, GetArtifactKind("application/vnd.cncf.notary.signature"))
+	assert.Equal(t, ArtifactKindAttestation, GetArtifactKind("application/vnd.dsse.envelope.v1+json"))
+	assert.Equal(t, ArtifactKindSBOM, GetArtifactKind("application/SPDX+json"))
+	assert.Equal(t, ArtifactKindOther, GetArtifactKind("application/vnd.oci.image.config.v1+json"))
+	assert.Equal(t, ArtifactKindOther, GetArtifactKind(""))
+}
+
+func TestCosignTag(t *testing.T) {
+	d := digest.FromString("image")
+
+	tag := CosignTag(d, ArtifactKindSignature)
+	assert.Equal(t, "sha256-"+d.Encoded()+".sig", tag)
+	assert.Equal(t, "sha256-"+d.Encoded()+".att", CosignTag(d, ArtifactKindAttestation))
+	assert.Empty(t, CosignTag(d, ArtifactKindOther))
+
+	parsed, kind, ok := ParseCosignTag(tag)
+	assert.True(t, ok)
+	assert.Equal(t, d, parsed)
+	assert.Equal(t, ArtifactKindSignature, kind)
+
+	parsed, kind, ok = ParseCosignTag(CosignTag(d, ArtifactKindSBOM))
+	assert.True(t, ok)
+	assert.Equal(t, d, parsed)
+	assert.Equal(t, ArtifactKindSBOM, kind)
+
+	for _, tag := range []string{"latest", "v1.sig", "sha256-invalid.sig", "sha256-" + d.Encoded(), "md5-" + d.Encoded() + ".sig"} {
+		_, _, ok = ParseCosignTag(tag)
+		assert.False(t, ok, tag)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package homebrew
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+)
+
+const (
+	PropertyTag     = "homebrew.tag"
+	PropertyCellar  = "homebrew.cellar"
+	PropertyRebuild = "homebrew.rebuild"
+
+	// CellarAny is the cellar of bottles which can be poured into any prefix
+	CellarAny = ":any"
+
+	maxFormulaSize = 1 * 1024 * 1024
+)
+
+var (
+	ErrInvalidBottle       = util.NewInvalidArgumentErrorf("bottle is invalid")
+	ErrMissingReceipt      = util.NewInvalidArgumentErrorf("bottle contains no INSTALL_RECEIPT.json")
+	ErrInvalidBottleName   = util.NewInvalidArgumentErrorf("bottle file name is invalid")
+	ErrInvalidFormulaName  = util.NewInvalidArgumentErrorf("formula name is invalid")
+	ErrInvalidVersion      = util.NewInvalidArgumentErrorf("version is invalid")
+	ErrInvalidCellarOption = util.NewInvalidArgumentErrorf("cellar is invalid")
+)
+
+var (
+	// https://docs.brew.sh/Formula-Cookbook#formula-naming
+	namePattern    = regexp.MustCompile(`\A[a-z0-9][a-z0-9+._@-]*\z`)
+	versionPattern = regexp.MustCompile(`\A[0-9A-Za-z][0-9A-Za-z+._-]*\z`)
+	tagPattern     = regexp.MustCompile(`\A[a-z0-9_]+\z`)
+	cellarPattern  = regexp.MustCompile(`\A(?::any|:any_skip_relocation|/[^\s"]+)\z`)
+
+	formulaFieldPattern = regexp.MustCompile(`(?m)^\s*(desc|homepage|license)\s+"((?:[^"\\]|\\.)*)"`)
+)
+
+// Metadata represents the metadata of a Homebrew formula version
+type Metadata struct {
+	Description  string   `json:"description,omitempty"`
+	Homepage     string   `json:"homepage,omitempty"`
+	License      string   `json:"license,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// Bottle describes a bottle file of a formula version
+type Bottle struct {
+	Tag     string
+	Rebuild int
+}
+
+// IsValidName checks the name of a formula
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// IsValidVersion checks the version of a formula, including the optional revision suffix like "1.2.3_1"
+func IsValidVersion(version string) bool {
+	return versionPattern.MatchString(version)
+}
+
+// IsValidCellar checks the cellar of a bottle, either ":any", ":any_skip_relocation" or an absolute path
+func IsValidCellar(cellar string) bool {
+	return cellarPattern.MatchString(cellar)
+}
+
+// BottleFilename returns the name of a bottle file like the one created by "brew bottle"
+func BottleFilename(name, version string, bottle *Bottle) string {
+	if bottle.Rebuild > 0 {
+		return fmt.Sprintf("%s--%s.%s.bottle.%d.tar.gz", name, version, bottle.Tag, bottle.Rebuild)
+	}
+	return fmt.Sprintf("%s--%s.%s.bottle.tar.gz", name, version, bottle.Tag)
+}
+
+// ParseBottleFilename parses the tag and the rebuild number of a bottle file name like "name--1.0.0.arm64_sonoma.bottle.tar.gz"
+func ParseBottleFilename(name, version, filename string) (*Bottle, error) {
+	rest, ok := strings.CutPrefix(filename, name+"--"+version+".")
+	if !ok {
+		return nil, ErrInvalidBottleName
+	}
+	rest, ok = strings.CutSuffix(rest, ".tar.gz")
+	if !ok {
+		return nil, ErrInvalidBottleName
+	}
+	tag, rebuild, ok := strings.Cut(rest, ".bottle")
+	if !ok || !tagPattern.MatchString(tag) {
+		return nil, ErrInvalidBottleName
+	}
+
+	b := &Bottle{Tag: tag}
+	if rebuild != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(rebuild, "."))
+		if err != nil || n < 1 || !strings.HasPrefix(rebuild, ".") {
+			return nil, ErrInvalidBottleName
+		}
+		b.Rebuild = n
+	}
+	return b, nil
+}
+
+type installReceipt struct {
+	RuntimeDependencies []struct {
+		FullName string `json:"full_name"`
+	} `json:"runtime_dependencies"`
+}
+
+// ParseBottle checks the .tar.gz archive of a bottle and reads the metadata from the
+// INSTALL_RECEIPT.json and the formula file stored in the bottle
+func ParseBottle(r io.Reader, name string) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidBottle
+	}
+	defer gzr.Close()
+
+	m := &Metadata{}
+	hasReceipt := false
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidBottle
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// The files of a bottle are stored in "<name>/<version>/"
+		parts := strings.Split(strings.TrimPrefix(path.Clean(hd.Name), "./"), "/")
+		if len(parts) < 3 || parts[0] != name {
+			continue
+		}
+
+		switch {
+		case len(parts) == 3 && parts[2] == "INSTALL_RECEIPT.json":
+			var receipt installReceipt
+			if err := json.NewDecoder(tr).Decode(&receipt); err != nil {
+				return nil, ErrInvalidBottle
+			}
+			for _, dep := range receipt.RuntimeDependencies {
+				if dep.FullName != "" {
+					m.Dependencies = append(m.Dependencies, dep.FullName)
+				}
+			}
+			hasReceipt = true
+		case len(parts) == 4 && parts[2] == ".brew" && parts[3] == name+".rb":
+			content, err := io.ReadAll(io.LimitReader(tr, maxFormulaSize))
+			if err != nil {
+				return nil, err
+			}
+			parseFormula(m, string(content))
+		}
+	}
+
+	if !hasReceipt {
+		return nil, ErrMissingReceipt
+	}
+	return m, nil
+}
+
+// parseFormula reads the simple string fields of a formula file
+func parseFormula(m *Metadata, content string) {
+	for _, match := range formulaFieldPattern.FindAllStringSubmatch(content, -1) {
+		value := strings.ReplaceAll(strings.ReplaceAll(match[2], `\"`, `"`), `\\`, `\`)
+		switch match[1] {
+		case "desc":
+			m.Description = value
+		case "homepage":
+			if validation.IsValidURL(value) {
+				m.Homepage = value
+			}
+		case "license":
+			m.License = value
+		}
+	}
+}
+
+// ClassName returns the Ruby class name of a formula like Homebrew does, "foo-bar@1.2" becomes "FooBarAT12"
+func ClassName(name string) string {
+	var sb strings.Builder
+	upper := true
+	for i, r := range name {
+		switch {
+		case r == '-' || r == '_' || r == '.':
+			upper = true
+		case r == '+':
+			sb.WriteRune('x')
+		case r == '@' && i+1 < len(name) && unicode.IsDigit(rune(name[i+1])):
+			sb.WriteString("AT")
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package homebrew
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBottle(files map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gw.Close()
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParseBottle(t *testing.T) {
+	t.Run("InvalidBottle", func(t *testing.T) {
+		m, err := ParseBottle(bytes.NewReader([]byte("dummy")), "hello")
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrInvalidBottle)
+	})
+
+	t.Run("MissingReceipt", func(t *testing.T) {
+		m, err := ParseBottle(createBottle(map[string]string{
+			"hello/1.0.0/bin/hello": "binary",
+		}), "hello")
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrMissingReceipt)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		m, err := ParseBottle(createBottle(map[string]string{
+			"hello/1.0.0/bin/hello":               "binary",
+			"hello/1.0.0/INSTALL_RECEIPT.json":    `{"runtime_dependencies":[{"full_name":"openssl@3","version":"3.3.0"},{"full_name":"owner/tap/libfoo"}]}`,
+			"hello/1.0.0/.brew/hello.rb":          "class Hello < Formula\n  desc \"Say \\\"hello\\\"\"\n  homepage \"https://example.com\"\n  license \"MIT\"\nend\n",
+			"hello/1.0.0/share/doc/other/.brew/x": "",
+		}), "hello")
+		require.NoError(t, err)
+		assert.Equal(t, `Say "hello"`, m.Description)
+		assert.Equal(t, "https://example.com", m.Homepage)
+		assert.Equal(t, "MIT", m.License)
+		assert.Equal(t, []string{"openssl@3", "owner/tap/libfoo"}, m.Dependencies)
+	})
+}
+
+func TestParseBottleFilename(t *testing.T) {
+	b, err := ParseBottleFilename("hello", "1.0.0", "hello--1.0.0.arm64_sonoma.bottle.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, &Bottle{Tag: "arm64_sonoma"}, b)
+	assert.Equal(t, "hello--1.0.0.arm64_sonoma.bottle.tar.gz", BottleFilename("hello", "1.0.0", b))
+
+	b, err = ParseBottleFilename("hello", "1.0.0_1", "hello--1.0.0_1.x86_64_linux.bottle.2.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, &Bottle{Tag: "x86_64_linux", Rebuild: 2}, b)
+	assert.Equal(t, "hello--1.0.0_1.x86_64_linux.bottle.2.tar.gz", BottleFilename("hello", "1.0.0_1", b))
+
+	for _, filename := range []string{
+		"hello--2.0.0.arm64_sonoma.bottle.tar.gz",
+		"hello--1.0.0.arm64_sonoma.tar.gz",
+		"hello--1.0.0.Sonoma.bottle.tar.gz",
+		"hello--1.0.0.sonoma.bottle.0.tar.gz",
+		"hello--1.0.0.sonoma.bottlex.tar.gz",
+		"hello--1.0.0.sonoma.bottle.zip",
+	} {
+		_, err = ParseBottleFilename("hello", "1.0.0", filename)
+		assert.ErrorIs(t, err, ErrInvalidBottleName, filename)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	assert.True(t, IsValidName("hello"))
+	assert.True(t, IsValidName("python@3.12"))
+	assert.True(t, IsValidName("libc++"))
+	assert.False(t, IsValidName("Hello"))
+	assert.False(t, IsValidName("-hello"))
+	assert.False(t, IsValidName("a/b"))
+
+	assert.True(t, IsValidVersion("1.2.3_1"))
+	assert.False(t, IsValidVersion("1.0/2"))
+
+	assert.True(t, IsValidCellar(":any"))
+	assert.True(t, IsValidCellar(":any_skip_relocation"))
+	assert.True(t, IsValidCellar("/opt/homebrew/Cellar"))
+	assert.False(t, IsValidCellar(":none"))
+	assert.False(t, IsValidCellar("Cellar"))
+}
+
+func TestClassName(t *testing.T) {
+	assert.Equal(t, "Hello", ClassName("hello"))
+	assert.Equal(t, "FooBar", ClassName("foo-bar"))
+	assert.Equal(t, "PythonAT312", ClassName("python@3.12"))
+	assert.Equal(t, "Libcxx", ClassName("libc++"))
+}
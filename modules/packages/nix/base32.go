@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// base32Alphabet is the alphabet of the base32 encoding of Nix which omits e, o, u and t
+const base32Alphabet = "0123456789abcdfghijklmnpqrsvwxyz"
+
+var ErrInvalidBase32 = util.NewInvalidArgumentErrorf("invalid nix base32 string")
+
+// EncodeBase32 encodes the bytes with the base32 encoding of Nix, it starts with the last bits of the input
+func EncodeBase32(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	n := (len(b)*8-1)/5 + 1
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := n - 1; i >= 0; i-- {
+		bit := i * 5
+		j, k := bit/8, uint(bit%8)
+		c := b[j] >> k
+		if j+1 < len(b) && k > 3 {
+			c |= b[j+1] << (8 - k)
+		}
+		sb.WriteByte(base32Alphabet[c&0x1f])
+	}
+	return sb.String()
+}
+
+// DecodeBase32 decodes a string encoded with the base32 encoding of Nix
+func DecodeBase32(s string) ([]byte, error) {
+	size := len(s) * 5 / 8
+	if size == 0 || (size*8-1)/5+1 != len(s) {
+		return nil, ErrInvalidBase32
+	}
+	b := make([]byte, size)
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base32Alphabet, s[len(s)-i-1])
+		if digit < 0 {
+			return nil, ErrInvalidBase32
+		}
+		bit := i * 5
+		j, k := bit/8, uint(bit%8)
+		b[j] |= byte(digit << k)
+		carry := byte(digit >> (8 - k))
+		if j+1 < size {
+			b[j+1] |= carry
+		} else if carry != 0 {
+			return nil, ErrInvalidBase32
+		}
+	}
+	return b, nil
+}
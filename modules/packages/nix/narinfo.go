@@ -0,0 +1,236 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	// StoreDir is the only store directory the binary cache serves
+	StoreDir = "/nix/store"
+
+	// UploadVersion is the name of the internal version which holds the NARs until their narinfo is uploaded
+	UploadVersion = "_upload"
+
+	SettingKeyPrivate = "nix.key.private"
+	SettingKeyPublic  = "nix.key.public"
+
+	maxNarInfoSize = 1 * 1024 * 1024
+)
+
+var (
+	ErrInvalidNarInfo   = util.NewInvalidArgumentErrorf("narinfo is invalid")
+	ErrInvalidStorePath = util.NewInvalidArgumentErrorf("store path is invalid")
+	ErrInvalidHash      = util.NewInvalidArgumentErrorf("hash is invalid")
+	ErrInvalidNarURL    = util.NewInvalidArgumentErrorf("nar url is invalid")
+)
+
+var (
+	// https://nix.dev/manual/nix/latest/store/store-path
+	hashPattern      = regexp.MustCompile(`\A[0-9a-df-np-sv-z]{32}\z`)
+	namePattern      = regexp.MustCompile(`\A[A-Za-z0-9+\-._?=]+\z`)
+	narFilenameRegex = regexp.MustCompile(`\A[0-9a-z]+\.nar(?:\.(?:xz|bz2|zst|br|gz|lz4|lzip))?\z`)
+)
+
+// Metadata represents the narinfo of a store path, the signatures of the cache are not stored
+type Metadata struct {
+	StorePath   string   `json:"store_path"`
+	URL         string   `json:"url"`
+	Compression string   `json:"compression,omitempty"`
+	FileHash    string   `json:"file_hash,omitempty"`
+	FileSize    int64    `json:"file_size,omitempty"`
+	NarHash     string   `json:"nar_hash"`
+	NarSize     int64    `json:"nar_size"`
+	References  []string `json:"references,omitempty"`
+	Deriver     string   `json:"deriver,omitempty"`
+	System      string   `json:"system,omitempty"`
+	CA          string   `json:"ca,omitempty"`
+	Signatures  []string `json:"signatures,omitempty"`
+}
+
+// IsValidHash checks if the string is the hash part of a store path
+func IsValidHash(hash string) bool {
+	return hashPattern.MatchString(hash)
+}
+
+// ParseStorePath splits a store path like /nix/store/<hash>-<name> into the hash and the name
+func ParseStorePath(storePath string) (string, string, error) {
+	dir, base := path.Split(storePath)
+	if dir != StoreDir+"/" {
+		return "", "", ErrInvalidStorePath
+	}
+	return ParseStorePathBase(base)
+}
+
+// ParseStorePathBase splits the base name of a store path into the hash and the name
+func ParseStorePathBase(base string) (string, string, error) {
+	hash, name, ok := strings.Cut(base, "-")
+	if !ok || !IsValidHash(hash) || len(name) > 211 || !namePattern.MatchString(name) || strings.HasPrefix(name, ".") {
+		return "", "", ErrInvalidStorePath
+	}
+	return hash, name, nil
+}
+
+// IsValidNarFilename checks if the name is a valid file name of a (compressed) NAR
+func IsValidNarFilename(filename string) bool {
+	return narFilenameRegex.MatchString(filename)
+}
+
+// ParseNarInfo parses and validates a narinfo file
+// https://fzakaria.github.io/nix-http-binary-cache-api-spec/
+func ParseNarInfo(r io.Reader) (*Metadata, error) {
+	m := &Metadata{}
+
+	scanner := bufio.NewScanner(io.LimitReader(r, maxNarInfoSize))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, ErrInvalidNarInfo
+		}
+
+		var err error
+		switch key {
+		case "StorePath":
+			m.StorePath = value
+		case "URL":
+			m.URL = value
+		case "Compression":
+			m.Compression = value
+		case "FileHash":
+			m.FileHash = value
+		case "FileSize":
+			m.FileSize, err = strconv.ParseInt(value, 10, 64)
+		case "NarHash":
+			m.NarHash = value
+		case "NarSize":
+			m.NarSize, err = strconv.ParseInt(value, 10, 64)
+		case "References":
+			m.References = strings.Fields(value)
+		case "Deriver":
+			if value != "unknown-deriver" {
+				m.Deriver = value
+			}
+		case "System":
+			m.System = value
+		case "CA":
+			m.CA = value
+		case "Sig":
+			m.Signatures = append(m.Signatures, value)
+		}
+		if err != nil {
+			return nil, ErrInvalidNarInfo
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := ParseStorePath(m.StorePath); err != nil {
+		return nil, err
+	}
+	for _, ref := range m.References {
+		if _, _, err := ParseStorePathBase(ref); err != nil {
+			return nil, err
+		}
+	}
+	if m.Deriver != "" {
+		if _, _, err := ParseStorePathBase(m.Deriver); err != nil {
+			return nil, err
+		}
+	}
+	if m.NarSize <= 0 {
+		return nil, ErrInvalidNarInfo
+	}
+	if _, err := ParseSHA256(m.NarHash); err != nil {
+		return nil, err
+	}
+	if m.FileHash != "" {
+		if _, err := ParseSHA256(m.FileHash); err != nil {
+			return nil, err
+		}
+	}
+	if dir, filename := path.Split(m.URL); dir != "nar/" || !IsValidNarFilename(filename) {
+		return nil, ErrInvalidNarURL
+	}
+	if m.Compression == "" {
+		m.Compression = "bzip2"
+	}
+	return m, nil
+}
+
+// ParseSHA256 parses a hash like sha256:<nix base32> or sha256:<hex> and returns the hex encoded hash
+func ParseSHA256(s string) (string, error) {
+	algorithm, value, ok := strings.Cut(s, ":")
+	if !ok || algorithm != "sha256" {
+		return "", ErrInvalidHash
+	}
+	switch len(value) {
+	case 64:
+		if _, err := hex.DecodeString(value); err != nil {
+			return "", ErrInvalidHash
+		}
+		return strings.ToLower(value), nil
+	case 52:
+		b, err := DecodeBase32(value)
+		if err != nil {
+			return "", ErrInvalidHash
+		}
+		return hex.EncodeToString(b), nil
+	}
+	return "", ErrInvalidHash
+}
+
+// Fingerprint returns the data which is signed by the signatures of the narinfo
+func (m *Metadata) Fingerprint() string {
+	refs := make([]string, 0, len(m.References))
+	for _, ref := range m.References {
+		refs = append(refs, StoreDir+"/"+ref)
+	}
+	return fmt.Sprintf("1;%s;%s;%d;%s", m.StorePath, m.NarHash, m.NarSize, strings.Join(refs, ","))
+}
+
+// WriteNarInfo writes the narinfo with the additional signatures
+func (m *Metadata) WriteNarInfo(w io.Writer, signatures ...string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "StorePath: %s\n", m.StorePath)
+	fmt.Fprintf(&sb, "URL: %s\n", m.URL)
+	fmt.Fprintf(&sb, "Compression: %s\n", m.Compression)
+	if m.FileHash != "" {
+		fmt.Fprintf(&sb, "FileHash: %s\n", m.FileHash)
+	}
+	if m.FileSize != 0 {
+		fmt.Fprintf(&sb, "FileSize: %d\n", m.FileSize)
+	}
+	fmt.Fprintf(&sb, "NarHash: %s\n", m.NarHash)
+	fmt.Fprintf(&sb, "NarSize: %d\n", m.NarSize)
+	fmt.Fprintf(&sb, "References: %s\n", strings.Join(m.References, " "))
+	if m.Deriver != "" {
+		fmt.Fprintf(&sb, "Deriver: %s\n", m.Deriver)
+	}
+	if m.System != "" {
+		fmt.Fprintf(&sb, "System: %s\n", m.System)
+	}
+	for _, sig := range append(m.Signatures, signatures...) {
+		fmt.Fprintf(&sb, "Sig: %s\n", sig)
+	}
+	if m.CA != "" {
+		fmt.Fprintf(&sb, "CA: %s\n", m.CA)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	storeHash = "3n58xw4373jp0ljirf06d8077j15pc4j"
+	storePath = StoreDir + "/" + storeHash + "-hello-2.12.1"
+	narInfo   = `StorePath: ` + storePath + `
+URL: nar/1w1fff338fvdw53sqgamddn1b2xgds473pv6y13gizdbqjv4i5p3.nar.xz
+Compression: xz
+FileHash: sha256:1w1fff338fvdw53sqgamddn1b2xgds473pv6y13gizdbqjv4i5p3
+FileSize: 50088
+NarHash: sha256:0yzhigwjl6bws649vcs2asa4lbs8hg93hyix187gc7s7a74w5h80
+NarSize: 226488
+References: 3n58xw4373jp0ljirf06d8077j15pc4j-hello-2.12.1 qbvj84pcbbbz5ywhhxk4gg9yi6nsrlgv-glibc-2.38-44
+Deriver: 7f03iqm43s2hvnai1206i1h4ch2hwwyb-hello-2.12.1.drv
+System: x86_64-linux
+Sig: cache.nixos.org-1:dummy
+`
+)
+
+func TestBase32(t *testing.T) {
+	sum := sha256.Sum256(nil)
+	encoded := EncodeBase32(sum[:])
+	assert.Equal(t, "0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73", encoded)
+
+	decoded, err := DecodeBase32(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, sum[:], decoded)
+
+	_, err = DecodeBase32("invalid-e")
+	assert.ErrorIs(t, err, ErrInvalidBase32)
+}
+
+func TestParseStorePath(t *testing.T) {
+	hash, name, err := ParseStorePath(storePath)
+	require.NoError(t, err)
+	assert.Equal(t, storeHash, hash)
+	assert.Equal(t, "hello-2.12.1", name)
+
+	for _, invalid := range []string{
+		"/nix/store/" + storeHash,
+		"/usr/store/" + storeHash + "-hello",
+		"/nix/store/" + strings.ToUpper(storeHash) + "-hello",
+		"/nix/store/" + storeHash + "-.hidden",
+		"/nix/store/" + storeHash + "-hello/bin",
+	} {
+		_, _, err := ParseStorePath(invalid)
+		assert.ErrorIs(t, err, ErrInvalidStorePath, invalid)
+	}
+}
+
+func TestParseSHA256(t *testing.T) {
+	sum := sha256.Sum256(nil)
+	expected := hex.EncodeToString(sum[:])
+
+	h, err := ParseSHA256("sha256:" + EncodeBase32(sum[:]))
+	require.NoError(t, err)
+	assert.Equal(t, expected, h)
+
+	h, err = ParseSHA256("sha256:" + strings.ToUpper(expected))
+	require.NoError(t, err)
+	assert.Equal(t, expected, h)
+
+	for _, invalid := range []string{"", expected, "sha512:" + expected, "sha256:abc"} {
+		_, err := ParseSHA256(invalid)
+		assert.ErrorIs(t, err, ErrInvalidHash, invalid)
+	}
+}
+
+func TestParseNarInfo(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m, err := ParseNarInfo(strings.NewReader(narInfo))
+		require.NoError(t, err)
+		assert.Equal(t, storePath, m.StorePath)
+		assert.Equal(t, "nar/1w1fff338fvdw53sqgamddn1b2xgds473pv6y13gizdbqjv4i5p3.nar.xz", m.URL)
+		assert.Equal(t, "xz", m.Compression)
+		assert.EqualValues(t, 50088, m.FileSize)
+		assert.EqualValues(t, 226488, m.NarSize)
+		assert.Len(t, m.References, 2)
+		assert.Equal(t, "7f03iqm43s2hvnai1206i1h4ch2hwwyb-hello-2.12.1.drv", m.Deriver)
+		assert.Equal(t, "x86_64-linux", m.System)
+		assert.Equal(t, []string{"cache.nixos.org-1:dummy"}, m.Signatures)
+	})
+
+	t.Run("DefaultCompression", func(t *testing.T) {
+		m, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfo, "Compression: xz\n", "", 1)))
+		require.NoError(t, err)
+		assert.Equal(t, "bzip2", m.Compression)
+	})
+
+	cases := map[string]struct {
+		Old, New string
+		Err      error
+	}{
+		"InvalidLine":      {"System: x86_64-linux", "System", ErrInvalidNarInfo},
+		"InvalidNarSize":   {"NarSize: 226488", "NarSize: abc", ErrInvalidNarInfo},
+		"MissingNarSize":   {"NarSize: 226488\n", "", ErrInvalidNarInfo},
+		"InvalidStorePath": {"StorePath: " + storePath, "StorePath: /tmp/hello", ErrInvalidStorePath},
+		"InvalidReference": {"References: ", "References: ../", ErrInvalidStorePath},
+		"InvalidNarHash":   {"NarHash: sha256:", "NarHash: md5:", ErrInvalidHash},
+		"InvalidURL":       {"URL: nar/", "URL: ../", ErrInvalidNarURL},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfo, c.Old, c.New, 1)))
+			assert.Nil(t, m)
+			assert.ErrorIs(t, err, c.Err)
+		})
+	}
+}
+
+func TestWriteNarInfo(t *testing.T) {
+	m, err := ParseNarInfo(strings.NewReader(narInfo))
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, m.WriteNarInfo(&sb))
+	assert.Equal(t, narInfo, sb.String())
+
+	m2, err := ParseNarInfo(strings.NewReader(sb.String()))
+	require.NoError(t, err)
+	assert.Equal(t, m, m2)
+}
+
+func TestSigning(t *testing.T) {
+	m, err := ParseNarInfo(strings.NewReader(narInfo))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1;"+storePath+";sha256:0yzhigwjl6bws649vcs2asa4lbs8hg93hyix187gc7s7a74w5h80;226488;"+storePath+","+StoreDir+"/qbvj84pcbbbz5ywhhxk4gg9yi6nsrlgv-glibc-2.38-44", m.Fingerprint())
+
+	secretKey, publicKey, err := GenerateKeyPair("gitea-test-1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(publicKey, "gitea-test-1:"))
+
+	sig, err := Sign(secretKey, m)
+	require.NoError(t, err)
+	assert.True(t, Verify(publicKey, sig, m))
+
+	_, otherPublicKey, err := GenerateKeyPair("gitea-test-1")
+	require.NoError(t, err)
+	assert.False(t, Verify(otherPublicKey, sig, m))
+
+	m.NarSize++
+	assert.False(t, Verify(publicKey, sig, m))
+
+	_, err = Sign("invalid", m)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
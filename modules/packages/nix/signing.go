@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrInvalidKey = util.NewInvalidArgumentErrorf("signing key is invalid")
+
+// GenerateKeyPair creates a signing key pair in the format of "nix key generate-secret", like <name>:<base64 key>
+func GenerateKeyPair(name string) (string, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return name + ":" + base64.StdEncoding.EncodeToString(priv), name + ":" + base64.StdEncoding.EncodeToString(pub), nil
+}
+
+// Sign creates the signature of the narinfo with the secret key, like <name>:<base64 signature>
+func Sign(secretKey string, m *Metadata) (string, error) {
+	name, key, ok := strings.Cut(secretKey, ":")
+	if !ok {
+		return "", ErrInvalidKey
+	}
+	priv, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(priv) != ed25519.PrivateKeySize {
+		return "", ErrInvalidKey
+	}
+	return name + ":" + base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(m.Fingerprint()))), nil
+}
+
+// Verify checks if the signature of the narinfo was created by the secret key of the public key
+func Verify(publicKey, signature string, m *Metadata) bool {
+	keyName, key, ok := strings.Cut(publicKey, ":")
+	if !ok {
+		return false
+	}
+	sigName, sig, ok := strings.Cut(signature, ":")
+	if !ok || sigName != keyName {
+		return false
+	}
+	pub, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return false
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(pub, []byte(m.Fingerprint()), sigBytes)
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	PropertyOS   = "terraform.os"
+	PropertyArch = "terraform.arch"
+
+	SettingKeyPrivate = "terraform.key.private"
+	SettingKeyPublic  = "terraform.key.public"
+
+	maxReadmeSize = 1 * 1024 * 1024
+)
+
+// Kind is the kind of a Terraform package
+type Kind string
+
+const (
+	KindModule   Kind = "module"
+	KindProvider Kind = "provider"
+)
+
+var (
+	ErrInvalidArchive = util.NewInvalidArgumentErrorf("archive is invalid")
+	ErrMissingTfFiles = util.NewInvalidArgumentErrorf("module archive contains no .tf file")
+	ErrMissingBinary  = util.NewInvalidArgumentErrorf("provider archive contains no provider binary")
+)
+
+// DefaultProtocols are the plugin protocol versions of the providers which don't specify them
+var DefaultProtocols = []string{"5.0"}
+
+var (
+	// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#module-addresses
+	moduleNamePattern   = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z_-]{0,62}[0-9A-Za-z])?\z`)
+	moduleSystemPattern = regexp.MustCompile(`\A[0-9a-z]{1,64}\z`)
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#provider-addresses
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	platformPattern     = regexp.MustCompile(`\A[0-9a-z]{1,32}\z`)
+	protocolPattern     = regexp.MustCompile(`\A\d+\.\d+\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider version
+type Metadata struct {
+	Kind Kind `json:"kind"`
+	// Readme is the README.md of a module
+	Readme string `json:"readme,omitempty"`
+	// Protocols are the plugin protocol versions supported by a provider
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// IsValidModuleName checks the name and the target system of a module
+func IsValidModuleName(name, system string) bool {
+	return moduleNamePattern.MatchString(name) && moduleSystemPattern.MatchString(system)
+}
+
+// IsValidProviderType checks the type of a provider
+func IsValidProviderType(providerType string) bool {
+	return providerTypePattern.MatchString(providerType)
+}
+
+// IsValidPlatform checks the operating system and the architecture of a provider build
+func IsValidPlatform(os, arch string) bool {
+	return platformPattern.MatchString(os) && platformPattern.MatchString(arch)
+}
+
+// ParseProtocols parses a comma separated list of plugin protocol versions, like "5.0,6.0"
+func ParseProtocols(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultProtocols, nil
+	}
+	var protocols []string
+	for p := range strings.SplitSeq(s, ",") {
+		p = strings.TrimSpace(p)
+		if !protocolPattern.MatchString(p) {
+			return nil, util.NewInvalidArgumentErrorf("invalid protocol version %q", p)
+		}
+		protocols = append(protocols, p)
+	}
+	return protocols, nil
+}
+
+// ModulePackageName returns the name of the package of a module, the modules of different systems are separate packages
+func ModulePackageName(name, system string) string {
+	return name + "/" + system
+}
+
+// ModuleArchiveFilename returns the name of the archive file of a module version
+func ModuleArchiveFilename(name, system, version string) string {
+	return fmt.Sprintf("%s-%s-%s.tar.gz", name, system, version)
+}
+
+// ProviderArchiveFilename returns the name of the archive file of a provider build, like the HashiCorp release tooling
+func ProviderArchiveFilename(providerType, version, os, arch string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_%s_%s.zip", providerType, version, os, arch)
+}
+
+// ShasumsFilename returns the name of the checksums file of a provider version
+func ShasumsFilename(providerType, version string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_SHA256SUMS", providerType, version)
+}
+
+// ParseModuleArchive checks the .tar.gz archive of a module and reads its README.md
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	defer gzr.Close()
+
+	m := &Metadata{Kind: KindModule}
+	hasTfFiles := false
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(path.Clean(hd.Name), "./")
+		if strings.Contains(name, "/") {
+			continue
+		}
+		if strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
+			hasTfFiles = true
+		} else if strings.EqualFold(name, "README.md") {
+			readme, err := io.ReadAll(io.LimitReader(tr, maxReadmeSize))
+			if err != nil {
+				return nil, err
+			}
+			m.Readme = string(readme)
+		}
+	}
+
+	if !hasTfFiles {
+		return nil, ErrMissingTfFiles
+	}
+	return m, nil
+}
+
+// CheckProviderArchive checks the .zip archive of a provider build contains the provider binary
+func CheckProviderArchive(r io.ReaderAt, size int64, providerType string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return ErrInvalidArchive
+	}
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, "terraform-provider-"+providerType) {
+			return nil
+		}
+	}
+	return ErrMissingBinary
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createModuleArchive(files map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gw.Close()
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParseModuleArchive(t *testing.T) {
+	t.Run("InvalidArchive", func(t *testing.T) {
+		m, err := ParseModuleArchive(bytes.NewReader([]byte("dummy")))
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("MissingTfFiles", func(t *testing.T) {
+		m, err := ParseModuleArchive(createModuleArchive(map[string]string{
+			"README.md":           "# Module",
+			"examples/basic/x.tf": "",
+		}))
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrMissingTfFiles)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		m, err := ParseModuleArchive(createModuleArchive(map[string]string{
+			"./main.tf":           `resource "null_resource" "x" {}`,
+			"./README.md":         "# Module",
+			"modules/sub/main.tf": "",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, m.Kind)
+		assert.Equal(t, "# Module", m.Readme)
+	})
+}
+
+func TestCheckProviderArchive(t *testing.T) {
+	createArchive := func(filename string) *bytes.Reader {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create(filename)
+		w.Write([]byte("binary"))
+		zw.Close()
+		return bytes.NewReader(buf.Bytes())
+	}
+
+	r := createArchive("terraform-provider-example_v1.0.0")
+	require.NoError(t, CheckProviderArchive(r, r.Size(), "example"))
+
+	r = createArchive("README.md")
+	assert.ErrorIs(t, CheckProviderArchive(r, r.Size(), "example"), ErrMissingBinary)
+
+	r = bytes.NewReader([]byte("dummy"))
+	assert.ErrorIs(t, CheckProviderArchive(r, r.Size(), "example"), ErrInvalidArchive)
+}
+
+func TestValidation(t *testing.T) {
+	assert.True(t, IsValidModuleName("consul", "aws"))
+	assert.True(t, IsValidModuleName("vpc_Peering-2", "aws"))
+	assert.False(t, IsValidModuleName("-consul", "aws"))
+	assert.False(t, IsValidModuleName("consul", "AWS"))
+	assert.False(t, IsValidModuleName("consul", "a/b"))
+
+	assert.True(t, IsValidProviderType("random"))
+	assert.False(t, IsValidProviderType("Random"))
+	assert.False(t, IsValidProviderType("rand_om"))
+
+	assert.True(t, IsValidPlatform("linux", "amd64"))
+	assert.False(t, IsValidPlatform("linux", "amd-64"))
+
+	protocols, err := ParseProtocols("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProtocols, protocols)
+	protocols, err = ParseProtocols("5.0, 6.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+	_, err = ParseProtocols("6")
+	assert.Error(t, err)
+}
@@ -36,6 +36,13 @@ func ReloadTemplates(ctx context.Context) ResponseExtra {
 	return requestJSONClientMsg(req, "Reloaded")
 }
 
+// ReloadConfig calls the internal reload-config function
+func ReloadConfig(ctx context.Context) ResponseExtra {
+	reqURL := setting.LocalURL + "api/internal/manager/reload-config"
+	req := newInternalRequestAPI(ctx, reqURL, "POST")
+	return requestJSONClientMsg(req, "Reloaded")
+}
+
 // FlushOptions represents the options for the flush call
 type FlushOptions struct {
 	Timeout     time.Duration
@@ -19,6 +19,17 @@ type baseQueue interface {
 	RemoveAll(ctx context.Context) error
 }
 
+// baseQueueAcknowledger is implemented by the base queues which keep the popped items until they are acknowledged,
+// the items which are never acknowledged (eg: the process is killed) are delivered again
+type baseQueueAcknowledger interface {
+	AckItem(data []byte) error
+}
+
+// baseQueuePartitioned is implemented by the base queues which keep the order of the items pushed with the same partition key
+type baseQueuePartitioned interface {
+	PushItemWithKey(ctx context.Context, key string, data []byte) error
+}
+
 func popItemByChan(ctx context.Context, popItemFn func(ctx context.Context) ([]byte, error)) (chanItem chan []byte, chanErr chan error) {
 	chanItem = make(chan []byte)
 	chanErr = make(chan error)
@@ -0,0 +1,308 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package queue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var (
+	natsNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+	// natsAckWait is how long the server waits for the acknowledgement of a popped item before delivering it again,
+	// the items being handled are kept in progress so only the items of a stopped node are redelivered
+	natsAckWait = time.Minute
+)
+
+// baseNats stores the items of a queue in a JetStream stream, the stream is consumed by a durable consumer shared by all nodes.
+// A popped item is only removed from the stream when it's acknowledged after being handled, so the items of a node
+// stopped before handling them are delivered again.
+type baseNats struct {
+	cfg      *BaseConfig
+	isUnique bool
+
+	conn     *nats.Conn
+	js       jetstream.JetStream
+	stream   jetstream.Stream
+	consumer jetstream.Consumer
+	set      jetstream.KeyValue // the items in a unique queue, keyed by the hash of their data
+	subject  string
+
+	mu       sync.Mutex
+	inflight map[string][]jetstream.Msg // the popped messages waiting for acknowledgement, by their data
+	closed   chan struct{}
+}
+
+var (
+	_ baseQueue             = (*baseNats)(nil)
+	_ baseQueueAcknowledger = (*baseNats)(nil)
+)
+
+func natsName(s string) string {
+	return natsNameInvalidChars.ReplaceAllString(s, "_")
+}
+
+func newBaseNatsGeneric(cfg *BaseConfig, unique bool) (baseQueue, error) {
+	u, err := url.Parse(cfg.ConnStr)
+	if err != nil {
+		return nil, err
+	}
+	// "replicas" is the number of copies of the streams in a JetStream cluster
+	replicas := 1
+	if v := u.Query().Get("replicas"); v != "" {
+		if replicas, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+	u.RawQuery = ""
+
+	conn, err := nats.Connect(u.String(), nats.Name("gitea"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	q := &baseNats{
+		cfg:      cfg,
+		isUnique: unique,
+		conn:     conn,
+		js:       js,
+		subject:  "gitea.queue." + natsName(cfg.QueueFullName),
+		inflight: map[string][]jetstream.Msg{},
+		closed:   make(chan struct{}),
+	}
+
+	ctx := graceful.GetManager().ShutdownContext()
+	for range 10 {
+		err = q.init(ctx, replicas)
+		if err == nil {
+			break
+		}
+		log.Warn("NATS JetStream is not ready, waiting for 1 second to retry: %v", err)
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go q.keepInProgress()
+	return q, nil
+}
+
+func (q *baseNats) init(ctx context.Context, replicas int) (err error) {
+	name := natsName(q.cfg.QueueFullName)
+	q.stream, err = q.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      name,
+		Subjects:  []string{q.subject},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+		Replicas:  replicas,
+	})
+	if err != nil {
+		return err
+	}
+	q.consumer, err = q.stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:       name,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       natsAckWait,
+		MaxAckPending: -1,
+	})
+	if err != nil {
+		return err
+	}
+	if q.isUnique {
+		q.set, err = q.js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+			Bucket:   natsName(q.cfg.SetFullName),
+			Storage:  jetstream.FileStorage,
+			Replicas: replicas,
+		})
+	}
+	return err
+}
+
+func newBaseNatsSimple(cfg *BaseConfig) (baseQueue, error) {
+	return newBaseNatsGeneric(cfg, false)
+}
+
+func newBaseNatsUnique(cfg *BaseConfig) (baseQueue, error) {
+	return newBaseNatsGeneric(cfg, true)
+}
+
+func natsSetKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// keepInProgress tells the server the popped items are still being handled, until they are acknowledged
+func (q *baseNats) keepInProgress() {
+	t := time.NewTicker(natsAckWait / 3)
+	defer t.Stop()
+	for {
+		select {
+		case <-q.closed:
+			return
+		case <-t.C:
+		}
+		q.mu.Lock()
+		for _, msgs := range q.inflight {
+			for _, msg := range msgs {
+				_ = msg.InProgress()
+			}
+		}
+		q.mu.Unlock()
+	}
+}
+
+// pending returns the number of items which haven't been popped
+func (q *baseNats) pending(ctx context.Context) (int, error) {
+	info, err := q.consumer.Info(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(info.NumPending), nil
+}
+
+func (q *baseNats) PushItem(ctx context.Context, data []byte) error {
+	return backoffErr(ctx, backoffBegin, backoffUpper, time.After(pushBlockTime), func() (retry bool, err error) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		cnt, err := q.pending(ctx)
+		if err != nil {
+			return false, err
+		}
+		if cnt >= q.cfg.Length {
+			return true, nil
+		}
+
+		if q.isUnique {
+			if _, err = q.set.Create(ctx, natsSetKey(data), nil); errors.Is(err, jetstream.ErrKeyExists) {
+				return false, ErrAlreadyInQueue
+			} else if err != nil {
+				return false, err
+			}
+		}
+		_, err = q.js.Publish(ctx, q.subject, data)
+		return false, err
+	})
+}
+
+func (q *baseNats) PopItem(ctx context.Context) ([]byte, error) {
+	return backoffRetErr(ctx, backoffBegin, backoffUpper, infiniteTimerC, func() (retry bool, data []byte, err error) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		batch, err := q.consumer.FetchNoWait(1)
+		if err != nil {
+			return true, nil, nil
+		}
+		msg, ok := <-batch.Messages()
+		if !ok {
+			return true, nil, nil
+		}
+		data = msg.Data()
+		q.inflight[string(data)] = append(q.inflight[string(data)], msg)
+		if q.isUnique {
+			// the item is no longer in the queue, it could be pushed again while it's being handled
+			_ = q.set.Delete(ctx, natsSetKey(data))
+		}
+		return false, data, nil
+	})
+}
+
+// AckItem removes a popped item from the stream after it has been handled or requeued
+func (q *baseNats) AckItem(data []byte) error {
+	q.mu.Lock()
+	msgs := q.inflight[string(data)]
+	if len(msgs) == 0 {
+		q.mu.Unlock()
+		return nil
+	}
+	msg := msgs[0]
+	if len(msgs) == 1 {
+		delete(q.inflight, string(data))
+	} else {
+		q.inflight[string(data)] = msgs[1:]
+	}
+	q.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return msg.DoubleAck(ctx)
+}
+
+func (q *baseNats) HasItem(ctx context.Context, data []byte) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.isUnique {
+		return false, nil
+	}
+	_, err := q.set.Get(ctx, natsSetKey(data))
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (q *baseNats) Len(ctx context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending(ctx)
+}
+
+func (q *baseNats) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	select {
+	case <-q.closed:
+	default:
+		close(q.closed)
+		// the items which haven't been acknowledged are delivered again when their acknowledgement wait expires
+		q.conn.Close()
+	}
+	return nil
+}
+
+func (q *baseNats) RemoveAll(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.inflight = map[string][]jetstream.Msg{}
+	// like the redis queue, the "set" is cleared after the stream, it's better to have duplicate items than losing items
+	if err := q.stream.Purge(ctx); err != nil {
+		return err
+	}
+	if !q.isUnique {
+		return nil
+	}
+	keys, err := q.set.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for key := range keys.Keys() {
+		if err := q.set.Purge(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package queue
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitNatsReady(conn string, dur time.Duration) (ready bool) {
+	for t := time.Now(); ; time.Sleep(50 * time.Millisecond) {
+		nc, err := nats.Connect(conn)
+		if err == nil {
+			nc.Close()
+			return true
+		}
+		if time.Since(t) > dur {
+			return false
+		}
+	}
+}
+
+func natsServerCmd(t *testing.T) *exec.Cmd {
+	natsServerProg, err := exec.LookPath("nats-server")
+	if err != nil {
+		return nil
+	}
+	c := &exec.Cmd{
+		Path:   natsServerProg,
+		Args:   []string{natsServerProg, "--jetstream", "--store_dir", t.TempDir(), "--addr", "127.0.0.1", "--port", "4222"},
+		Dir:    t.TempDir(),
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	return c
+}
+
+func TestBaseNats(t *testing.T) {
+	var natsServer *exec.Cmd
+	defer func() {
+		if natsServer != nil {
+			_ = natsServer.Process.Signal(os.Interrupt)
+			_ = natsServer.Wait()
+		}
+	}()
+	if !waitNatsReady("nats://127.0.0.1:4222", 0) {
+		natsServer = natsServerCmd(t)
+		if natsServer == nil && os.Getenv("CI") == "" {
+			t.Skip("nats-server not found")
+			return
+		}
+		assert.NoError(t, natsServer.Start())
+		require.True(t, waitNatsReady("nats://127.0.0.1:4222", 5*time.Second), "start nats-server")
+	}
+
+	testQueueBasic(t, newBaseNatsSimple, toBaseConfig("baseNats", setting.QueueSettings{Length: 10, ConnStr: "nats://127.0.0.1:4222"}), false)
+	testQueueBasic(t, newBaseNatsUnique, toBaseConfig("baseNatsUnique", setting.QueueSettings{Length: 10, ConnStr: "nats://127.0.0.1:4222"}), true)
+
+	t.Run("Redelivery", func(t *testing.T) {
+		defer test.MockVariableValue(&natsAckWait, time.Second)()
+		cfg := toBaseConfig("baseNatsRedelivery", setting.QueueSettings{Length: 10, ConnStr: "nats://127.0.0.1:4222"})
+
+		q, err := newBaseNatsSimple(cfg)
+		require.NoError(t, err)
+		ctx := t.Context()
+		_ = q.RemoveAll(ctx)
+		assert.NoError(t, q.PushItem(ctx, []byte("foo")))
+		assert.NoError(t, q.PushItem(ctx, []byte("bar")))
+
+		// the acknowledged item is removed, the other one is delivered again after the node stops
+		it, err := q.PopItem(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "foo", string(it))
+		assert.NoError(t, q.(baseQueueAcknowledger).AckItem(it))
+		it, err = q.PopItem(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", string(it))
+		assert.NoError(t, q.Close())
+
+		q, err = newBaseNatsSimple(cfg)
+		require.NoError(t, err)
+		defer q.Close()
+		it, err = q.PopItem(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", string(it))
+		assert.NoError(t, q.(baseQueueAcknowledger).AckItem(it))
+
+		cnt, err := q.Len(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, cnt)
+	})
+}
@@ -33,6 +33,8 @@
 //     - Channel: Uses Go's native channel constructs to manage the queue, suitable for in-memory queuing.
 //     - LevelDB: Especially useful in persistent queues for single instances.
 //     - Redis: Suitable for clusters, where we may have multiple nodes.
+//     - NATS JetStream: Suitable for clusters, the items are delivered again if a node stops before handling them.
+//     - Kafka: Suitable for clusters with a high throughput, the items can be partitioned by a key (see PartitionKeyer).
 //     - Dummy: This is special, it's not a real queue, it's a immediate no-op queue, which is useful for tests.
 //     - They all have the same abstraction, the same interface, and they are tested by the same testing code.
 //
@@ -66,3 +68,9 @@ import "code.gitea.io/gitea/modules/util"
 type HandlerFuncT[T any] func(...T) (unhandled []T)
 
 var ErrAlreadyInQueue = util.NewAlreadyExistErrorf("already in queue")
+
+// PartitionKeyer can be implemented by the items to be partitioned by a key (eg: the repository or owner ID) in the base queues
+// supporting partitions (Kafka), the items of the same key are popped in the order they were pushed
+type PartitionKeyer interface {
+	QueuePartitionKey() string
+}
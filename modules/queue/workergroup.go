@@ -99,6 +99,8 @@ func (q *WorkerPoolQueue[T]) doWorkerHandle(batch []T) {
 		q.workerActiveNum--
 		q.workerNumMu.Unlock()
 	}()
+	// the items are either handled or requeued (or dropped when flushing) at the end
+	defer q.baseAckItems(batch...)
 
 	unhandled := q.safeHandler(batch...)
 	// if none of the items were handled, it should back-off for a few seconds
@@ -136,13 +138,37 @@ func (q *WorkerPoolQueue[T]) basePushForShutdown(items ...T) bool {
 	defer ctxShutdownCancel()
 	for _, item := range items {
 		// if there is still any error, the queue can do nothing instead of losing the items
-		if err := q.baseQueue.PushItem(ctxShutdown, q.marshal(item)); err != nil {
+		if err := q.basePushItem(ctxShutdown, item); err != nil {
 			log.Error("Failed to requeue item for queue %q when shutting down: %v", q.GetName(), err)
 		}
 	}
 	return true
 }
 
+// baseAckItems acknowledges the popped items to the base queue after they have been handled or requeued
+func (q *WorkerPoolQueue[T]) baseAckItems(items ...T) {
+	if _, ok := q.baseQueue.(baseQueueAcknowledger); !ok {
+		return
+	}
+	data := make([][]byte, 0, len(items))
+	for _, item := range items {
+		data = append(data, q.marshal(item))
+	}
+	q.baseAckData(data...)
+}
+
+func (q *WorkerPoolQueue[T]) baseAckData(data ...[]byte) {
+	acknowledger, ok := q.baseQueue.(baseQueueAcknowledger)
+	if !ok {
+		return
+	}
+	for _, it := range data {
+		if err := acknowledger.AckItem(it); err != nil {
+			log.Error("Failed to acknowledge item for queue %q: %v", q.GetName(), err)
+		}
+	}
+}
+
 func resetIdleTicker(t *time.Ticker, dur time.Duration) {
 	t.Reset(dur)
 	select {
@@ -214,9 +240,11 @@ func (q *WorkerPoolQueue[T]) doFlush(wg *workerGroup[T], flush flushType) {
 		wg.batchBuffer = nil
 		for {
 			select {
-			case <-wg.popItemChan:
+			case data := <-wg.popItemChan:
+				q.baseAckData(data)
 			case <-wg.popItemErr:
-			case <-q.batchChan:
+			case batch := <-q.batchChan:
+				q.baseAckItems(batch...)
 			case <-q.ctxRun.Done():
 				return
 			default:
@@ -249,6 +277,7 @@ loop:
 			}
 			emptyCounter = 0
 			if v, jsonOk := q.unmarshal(data); !jsonOk {
+				q.baseAckData(data)
 				continue
 			} else {
 				q.doWorkerHandle([]T{v})
@@ -328,6 +357,7 @@ func (q *WorkerPoolQueue[T]) doRun() {
 			// if there is no shutdown context, just call the handler to try to handle the items. if the handler fails again, the items are lost
 			q.safeHandler(unhandled...)
 		}
+		q.baseAckItems(unhandled...)
 
 		close(q.shutdownDone)
 	}()
@@ -350,6 +380,7 @@ func (q *WorkerPoolQueue[T]) doRun() {
 			}
 			if v, jsonOk := q.unmarshal(data); !jsonOk {
 				testRecorder.Record("pop:corrupted:%s", data) // in rare cases the levelqueue(leveldb) might be corrupted
+				q.baseAckData(data)
 				continue
 			} else {
 				wg.batchBuffer = append(wg.batchBuffer, v)
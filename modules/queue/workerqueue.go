@@ -172,7 +172,16 @@ func (q *WorkerPoolQueue[T]) Push(data T) error {
 			q.safeHandler(data)
 		}
 	}
-	return q.baseQueue.PushItem(q.ctxRun, q.marshal(data))
+	return q.basePushItem(q.ctxRun, data)
+}
+
+func (q *WorkerPoolQueue[T]) basePushItem(ctx context.Context, data T) error {
+	if partitioned, ok := q.baseQueue.(baseQueuePartitioned); ok {
+		if keyer, ok := any(data).(PartitionKeyer); ok {
+			return partitioned.PushItemWithKey(ctx, keyer.QueuePartitionKey(), q.marshal(data))
+		}
+	}
+	return q.baseQueue.PushItem(ctx, q.marshal(data))
 }
 
 // Has only works for unique queues. Keep in mind that this check may not be reliable (due to lacking of proper transaction support)
@@ -205,6 +214,10 @@ func getNewQueueFn(t string) (string, func(cfg *BaseConfig, unique bool) (baseQu
 		return t, newBaseChannelGeneric
 	case "redis":
 		return t, newBaseRedisGeneric
+	case "nats":
+		return t, newBaseNatsGeneric
+	case "kafka":
+		return t, newBaseKafkaGeneric
 	default: // level(leveldb,levelqueue,persistable-channel)
 		return "level", newBaseLevelQueueGeneric
 	}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryCleanupInterval is the interval to remove the buckets which are full again
+const memoryCleanupInterval = time.Minute
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	fullAt  time.Time
+}
+
+type memoryLimiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*memoryBucket
+	lastCleanup time.Time
+	now         func() time.Time // make it possible to change the time in tests
+}
+
+var _ Limiter = &memoryLimiter{}
+
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+func (l *memoryLimiter) Take(_ context.Context, key string, rate Rate) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanup(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(rate.Limit), updated: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = min(float64(rate.Limit), b.tokens+float64(elapsed)/float64(rate.interval()))
+		b.updated = now
+	}
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	res := newResult(rate, b.tokens, allowed)
+	b.fullAt = now.Add(res.ResetAfter)
+	return res, nil
+}
+
+// cleanup removes the buckets which are full again, they are the same as new buckets
+func (l *memoryLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < memoryCleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for key, b := range l.buckets {
+		if !now.Before(b.fullAt) {
+			delete(l.buckets, key)
+		}
+	}
+}
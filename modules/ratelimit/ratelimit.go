@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package ratelimit limits the rate of operations with token buckets.
+//
+// A bucket holds at most Rate.Limit tokens and is refilled continuously with Rate.Limit tokens per Rate.Period,
+// every operation takes a token. The buckets are stored in memory or in redis to share them between instances.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Rate is the size and the refill rate of a bucket
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+// interval is the time to refill one token
+func (r Rate) interval() time.Duration {
+	return r.Period / time.Duration(r.Limit)
+}
+
+// Result is the state of a bucket after trying to take a token
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is the time until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is the time until a token is available, it is zero if the operation is allowed
+	RetryAfter time.Duration
+}
+
+// Limiter stores the buckets
+type Limiter interface {
+	// Take tries to take a token from the bucket of the key, a new bucket is full
+	Take(ctx context.Context, key string, rate Rate) (Result, error)
+}
+
+// newResult returns the result for the tokens left in the bucket
+func newResult(rate Rate, tokens float64, allowed bool) Result {
+	interval := float64(rate.interval())
+	res := Result{
+		Allowed:    allowed,
+		Limit:      rate.Limit,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration(math.Ceil((float64(rate.Limit) - tokens) * interval)),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration(math.Ceil((1 - tokens) * interval))
+	}
+	return res
+}
+
+var (
+	defaultLimiter Limiter
+	initOnce       sync.Once
+	initFunc       = func() {
+		switch setting.APIRateLimit.ServiceType {
+		case "redis":
+			defaultLimiter = NewRedisLimiter(setting.APIRateLimit.ServiceConnStr)
+		case "memory":
+			fallthrough
+		default:
+			defaultLimiter = NewMemoryLimiter()
+		}
+	} // define initFunc as a variable to make it possible to change it in tests
+)
+
+// DefaultLimiter returns the default limiter.
+func DefaultLimiter() Limiter {
+	initOnce.Do(func() {
+		initFunc()
+	})
+	return defaultLimiter
+}
+
+// Take tries to take a token from the bucket of the key, it uses the default limiter.
+func Take(ctx context.Context, key string, rate Rate) (Result, error) {
+	return DefaultLimiter().Take(ctx, key, rate)
+}
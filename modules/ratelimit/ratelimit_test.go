@@ -0,0 +1,101 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ratelimit
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("redis", func(t *testing.T) {
+		url := "redis://127.0.0.1:6379/0"
+		if os.Getenv("CI") == "" {
+			// Make it possible to run tests against a local redis instance
+			url = os.Getenv("TEST_REDIS_URL")
+			if url == "" {
+				t.Skip("TEST_REDIS_URL not set and not running in CI")
+				return
+			}
+		}
+		testLimiter(t, NewRedisLimiter(url))
+	})
+	t.Run("memory", func(t *testing.T) {
+		testLimiter(t, NewMemoryLimiter())
+	})
+}
+
+func testLimiter(t *testing.T, limiter Limiter) {
+	// a unique key to not share the bucket with previous runs against the same redis
+	key := "test-" + time.Now().String()
+	rate := Rate{Limit: 3, Period: time.Hour}
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		res, err := limiter.Take(t.Context(), key, rate)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, remaining, res.Remaining)
+		assert.Zero(t, res.RetryAfter)
+	}
+
+	res, err := limiter.Take(t.Context(), key, rate)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	// a token is refilled every 20 minutes
+	assert.InDelta(t, 20*time.Minute, res.RetryAfter, float64(time.Minute))
+	assert.InDelta(t, time.Hour, res.ResetAfter, float64(time.Minute))
+
+	// the buckets of other keys are independent
+	res, err = limiter.Take(t.Context(), key+"-other", rate)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryLimiterRefill(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewMemoryLimiter().(*memoryLimiter)
+	limiter.now = func() time.Time { return now }
+	rate := Rate{Limit: 2, Period: time.Minute}
+
+	take := func() Result {
+		res, err := limiter.Take(t.Context(), "key", rate)
+		require.NoError(t, err)
+		return res
+	}
+
+	assert.True(t, take().Allowed)
+	assert.True(t, take().Allowed)
+	res := take()
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 30*time.Second, res.RetryAfter)
+	assert.Equal(t, time.Minute, res.ResetAfter)
+
+	now = now.Add(45 * time.Second)
+	res = take()
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 45*time.Second, res.ResetAfter)
+
+	// the bucket is never filled above the limit
+	now = now.Add(time.Hour)
+	res = take()
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+
+	// the full buckets are removed
+	now = now.Add(2 * memoryCleanupInterval)
+	take()
+	assert.Len(t, limiter.buckets, 1)
+	now = now.Add(2 * memoryCleanupInterval)
+	_, err := limiter.Take(t.Context(), "other", rate)
+	require.NoError(t, err)
+	assert.NotContains(t, limiter.buckets, "key")
+}
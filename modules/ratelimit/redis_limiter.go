@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/gitea/modules/nosql"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisRateLimitKeyPrefix = "gitea:ratelimit:"
+
+// redisTakeScript refills and takes a token from the bucket atomically with the clock of the redis server,
+// so the buckets are the same for all instances. The bucket expires when it is full again.
+// The tokens are returned as string because redis converts the numbers returned by scripts to integers.
+var redisTakeScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = limit
+	updated = now
+end
+if now > updated then
+	tokens = math.min(limit, tokens + (now - updated) * limit / period)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.max(1, math.ceil((limit - tokens) * period / limit)))
+return {allowed, tostring(tokens)}
+`)
+
+type redisLimiter struct {
+	client redis.UniversalClient
+}
+
+var _ Limiter = &redisLimiter{}
+
+func NewRedisLimiter(connection string) Limiter {
+	return &redisLimiter{
+		client: nosql.GetManager().GetRedisClient(connection),
+	}
+}
+
+func (l *redisLimiter) Take(ctx context.Context, key string, rate Rate) (Result, error) {
+	reply, err := redisTakeScript.Run(ctx, l.client, []string{redisRateLimitKeyPrefix + key}, rate.Limit, rate.Period.Milliseconds()).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("unexpected reply of rate limit script: %v", reply)
+	}
+	allowed, _ := reply[0].(int64)
+	tokensStr, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected tokens of rate limit script: %v", reply[1])
+	}
+	return newResult(rate, tokens, allowed == 1), nil
+}
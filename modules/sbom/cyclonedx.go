@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"io"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/google/uuid"
+)
+
+// https://cyclonedx.org/docs/1.5/json/
+type cycloneDXDocument struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDXTools     `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func writeCycloneDX(w io.Writer, d *Document) error {
+	components := d.uniqueComponents()
+
+	doc := cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: d.Created.UTC().Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []cycloneDXComponent{{Type: "application", Name: "Gitea", Version: setting.AppVer}},
+			},
+			Component: cycloneDXComponent{
+				Type:    "application",
+				BOMRef:  d.Namespace,
+				Name:    d.Name,
+				Version: d.Version,
+			},
+		},
+		Components: make([]cycloneDXComponent, 0, len(components)),
+	}
+	for _, c := range components {
+		purl := c.PackageURL()
+		doc.Components = append(doc.Components, cycloneDXComponent{
+			Type:       "library",
+			BOMRef:     purl,
+			Name:       c.Name,
+			Version:    c.Version,
+			PURL:       purl,
+			Properties: []cycloneDXProperty{{Name: "gitea:source", Value: c.Source}},
+		})
+	}
+	return json.NewEncoder(w).Encode(doc)
+}
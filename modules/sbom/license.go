@@ -0,0 +1,104 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/container"
+)
+
+// NormalizeLicenseID returns the lower case SPDX license identifier without the "+", "-only" and "-or-later" suffixes,
+// so the versions of a license are compared regardless of whether later versions are allowed
+func NormalizeLicenseID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	id = strings.TrimSuffix(id, "+")
+	id = strings.TrimSuffix(id, "-only")
+	return strings.TrimSuffix(id, "-or-later")
+}
+
+// IsLicenseDenied checks if the SPDX license expression can only be satisfied by denied licenses, the denied licenses are
+// normalized by NormalizeLicenseID. Either license of "A OR B" can be chosen, both licenses of "A AND B" apply.
+// An empty or invalid expression isn't denied because the license is unknown.
+func IsLicenseDenied(expression string, denied container.Set[string]) bool {
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))
+	if len(tokens) == 0 {
+		return false
+	}
+	p := &licenseExpressionParser{tokens: tokens, denied: denied}
+	allowed, ok := p.parseOr()
+	if !ok || p.pos != len(p.tokens) {
+		return false
+	}
+	return !allowed
+}
+
+type licenseExpressionParser struct {
+	tokens []string
+	pos    int
+	denied container.Set[string]
+}
+
+func (p *licenseExpressionParser) next(operator string) bool {
+	if p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], operator) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr returns whether the expression is allowed, false is returned as second value if it is invalid
+func (p *licenseExpressionParser) parseOr() (allowed, ok bool) {
+	if allowed, ok = p.parseAnd(); !ok {
+		return false, false
+	}
+	for p.next("OR") {
+		right, ok := p.parseAnd()
+		if !ok {
+			return false, false
+		}
+		allowed = allowed || right
+	}
+	return allowed, true
+}
+
+func (p *licenseExpressionParser) parseAnd() (allowed, ok bool) {
+	if allowed, ok = p.parseLicense(); !ok {
+		return false, false
+	}
+	for p.next("AND") {
+		right, ok := p.parseLicense()
+		if !ok {
+			return false, false
+		}
+		allowed = allowed && right
+	}
+	return allowed, true
+}
+
+func (p *licenseExpressionParser) parseLicense() (allowed, ok bool) {
+	if p.pos >= len(p.tokens) {
+		return false, false
+	}
+	if p.next("(") {
+		if allowed, ok = p.parseOr(); !ok || !p.next(")") {
+			return false, false
+		}
+		return allowed, true
+	}
+
+	id := p.tokens[p.pos]
+	if id == ")" || strings.EqualFold(id, "AND") || strings.EqualFold(id, "OR") || strings.EqualFold(id, "WITH") {
+		return false, false
+	}
+	p.pos++
+	// an exception only grants additional permissions
+	if p.next("WITH") {
+		if p.pos >= len(p.tokens) {
+			return false, false
+		}
+		p.pos++
+	}
+	return !p.denied.Contains(NormalizeLicenseID(id)), true
+}
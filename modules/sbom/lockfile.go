@@ -0,0 +1,157 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrUnsupportedLockfile = util.NewInvalidArgumentErrorf("unsupported lockfile")
+
+var lockfileParsers = map[string]func(io.Reader) ([]*Component, error){
+	"go.sum":            parseGoSum,
+	"package-lock.json": parsePackageLock,
+	"requirements.txt":  parseRequirements,
+}
+
+// parseGoSum parses a go.sum file, the modules of which only the go.mod file is listed are not part of the build
+func parseGoSum(r io.Reader) ([]*Component, error) {
+	var components []*Component
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		key := fields[0] + "@" + fields[1]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		components = append(components, &Component{
+			Ecosystem: EcosystemGo,
+			Name:      fields[0],
+			Version:   fields[1],
+		})
+	}
+	return components, scanner.Err()
+}
+
+// parsePackageLock parses a package-lock.json file of lockfile version 1, 2 or 3, the licenses are only recorded by version 2 and 3
+func parsePackageLock(r io.Reader) ([]*Component, error) {
+	type dependency struct {
+		Version string `json:"version"`
+		Link    bool   `json:"link"`
+		// License is a SPDX license expression, some old packages record an object instead
+		License      any                    `json:"license"`
+		Dependencies map[string]*dependency `json:"dependencies"`
+	}
+	var lock struct {
+		Packages     map[string]*dependency `json:"packages"`
+		Dependencies map[string]*dependency `json:"dependencies"`
+	}
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+
+	var components []*Component
+	seen := make(map[string]bool)
+	add := func(name string, d *dependency) {
+		key := name + "@" + d.Version
+		if name == "" || d.Version == "" || d.Link || seen[key] {
+			return
+		}
+		seen[key] = true
+		license, _ := d.License.(string)
+		components = append(components, &Component{
+			Ecosystem: EcosystemNpm,
+			Name:      name,
+			Version:   d.Version,
+			License:   license,
+		})
+	}
+
+	if len(lock.Packages) > 0 {
+		for key, d := range lock.Packages {
+			// the root package has an empty key, dependencies are keyed by their install location "node_modules/a/node_modules/@scope/b"
+			_, name, ok := cutLast(key, "node_modules/")
+			if ok {
+				add(name, d)
+			}
+		}
+		return components, nil
+	}
+
+	var walk func(map[string]*dependency)
+	walk = func(dependencies map[string]*dependency) {
+		for name, d := range dependencies {
+			add(name, d)
+			walk(d.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return components, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+var (
+	requirementPattern   = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*([^;#]*)`)
+	pinnedVersionPattern = regexp.MustCompile(`^===?\s*([^\s,]+)`)
+)
+
+// parseRequirements parses a pip requirements.txt file, requirements which are not pinned to a version are listed without version.
+// The requirements are the direct dependencies of the project.
+func parseRequirements(r io.Reader) ([]*Component, error) {
+	var components []*Component
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		m := requirementPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		specifier := strings.Join(strings.Fields(m[2]), "")
+		if strings.HasPrefix(specifier, "@") {
+			// a direct reference "name @ https://..." has no version
+			specifier = ""
+		}
+		var version string
+		if pinned := pinnedVersionPattern.FindStringSubmatch(specifier); pinned != nil {
+			version = pinned[1]
+		}
+		key := NormalizePyPIName(m[1]) + "@" + version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		components = append(components, &Component{
+			Ecosystem:   EcosystemPyPI,
+			Name:        m[1],
+			Version:     version,
+			Requirement: specifier,
+			Scope:       ScopeRuntime,
+			Direct:      true,
+		})
+	}
+	return components, scanner.Err()
+}
@@ -0,0 +1,457 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"golang.org/x/mod/modfile"
+)
+
+var ErrUnsupportedManifest = util.NewInvalidArgumentErrorf("unsupported manifest")
+
+var manifestParsers = map[string]func(io.Reader) ([]*Component, error){
+	"go.mod":       parseGoMod,
+	"package.json": parsePackageJSON,
+	"pom.xml":      parsePom,
+	"Cargo.toml":   parseCargoToml,
+}
+
+// exactVersionPattern matches a semantic version which isn't a range
+var exactVersionPattern = regexp.MustCompile(`^v?\d+(?:\.\d+){0,2}(?:[-+][0-9A-Za-z.+-]*)?$`)
+
+// IsManifest checks if the file is a supported manifest
+func IsManifest(filename string) bool {
+	_, ok := manifestParsers[path.Base(filename)]
+	return ok
+}
+
+// ParseManifest parses the components declared by the manifest
+func ParseManifest(filename string, r io.Reader) ([]*Component, error) {
+	parse, ok := manifestParsers[path.Base(filename)]
+	if !ok {
+		return nil, ErrUnsupportedManifest
+	}
+	components, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range components {
+		c.Source = filename
+	}
+	slices.SortFunc(components, func(a, b *Component) int {
+		return strings.Compare(a.PackageURL(), b.PackageURL())
+	})
+	return components, nil
+}
+
+// IsVendoredPath checks if the path is in a directory of vendored or installed dependencies
+func IsVendoredPath(path string) bool {
+	for segment := range strings.SplitSeq(path, "/") {
+		if segment == "node_modules" || segment == "vendor" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveDependencies merges the components declared by manifests with the components of the lockfiles in the same directory.
+// A declared component gets the version and the license resolved by the lockfile, the other components of the lockfiles are indirect dependencies.
+func ResolveDependencies(declared, resolved []*Component) []*Component {
+	type key struct {
+		dir       string
+		ecosystem Ecosystem
+		name      string
+	}
+	keyOf := func(c *Component) key {
+		return key{path.Dir(c.Source), c.Ecosystem, NormalizeName(c.Ecosystem, c.Name)}
+	}
+
+	versions := make(map[key][]*Component)
+	for _, c := range resolved {
+		versions[keyOf(c)] = append(versions[keyOf(c)], c)
+	}
+
+	res := make([]*Component, 0, len(declared)+len(resolved))
+	for _, d := range declared {
+		k := keyOf(d)
+		// several resolved versions of a declared component can't be told apart, the version stays unknown
+		if d.Version == "" && len(versions[k]) == 1 {
+			d.Version = versions[k][0].Version
+		}
+		for _, c := range versions[k] {
+			if c.Version == d.Version && d.License == "" {
+				d.License = c.License
+			}
+		}
+		versions[k] = slices.DeleteFunc(versions[k], func(c *Component) bool {
+			return c.Version == d.Version
+		})
+		res = append(res, d)
+	}
+	for _, c := range resolved {
+		if slices.Contains(versions[keyOf(c)], c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// parseGoMod parses the requirements of a go.mod file, the requirements marked as indirect aren't direct dependencies
+func parseGoMod(r io.Reader) ([]*Component, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseLax("go.mod", data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]*Component, 0, len(f.Require))
+	for _, req := range f.Require {
+		components = append(components, &Component{
+			Ecosystem:   EcosystemGo,
+			Name:        req.Mod.Path,
+			Version:     req.Mod.Version,
+			Requirement: req.Mod.Version,
+			Scope:       ScopeRuntime,
+			Direct:      !req.Indirect,
+		})
+	}
+	return components, nil
+}
+
+// parsePackageJSON parses the dependencies of a package.json file, local packages are ignored
+func parsePackageJSON(r io.Reader) ([]*Component, error) {
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+	}
+	if err := json.NewDecoder(r).Decode(&pkg); err != nil {
+		return nil, err
+	}
+
+	var components []*Component
+	seen := make(map[string]bool)
+	add := func(dependencies map[string]string, scope Scope) {
+		for name, requirement := range dependencies {
+			if seen[name] || strings.HasPrefix(requirement, "file:") || strings.HasPrefix(requirement, "link:") ||
+				strings.HasPrefix(requirement, "workspace:") || strings.HasPrefix(requirement, "portal:") {
+				continue
+			}
+			seen[name] = true
+			// an alias "npm:real-name@^1.0.0" installs another package under the name
+			if alias, ok := strings.CutPrefix(requirement, "npm:"); ok {
+				if i := strings.LastIndex(alias, "@"); i > 0 {
+					name, requirement = alias[:i], alias[i+1:]
+				} else {
+					name, requirement = alias, ""
+				}
+			}
+			components = append(components, &Component{
+				Ecosystem:   EcosystemNpm,
+				Name:        name,
+				Version:     util.Iif(exactVersionPattern.MatchString(requirement), strings.TrimPrefix(requirement, "v"), ""),
+				Requirement: requirement,
+				Scope:       scope,
+				Direct:      true,
+			})
+		}
+	}
+	// a dependency listed by several fields is installed in the scope of the first of them
+	add(pkg.Dependencies, ScopeRuntime)
+	add(pkg.OptionalDependencies, ScopeOptional)
+	add(pkg.PeerDependencies, ScopePeer)
+	add(pkg.DevDependencies, ScopeDevelopment)
+	return components, nil
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+	Optional   bool   `xml:"optional"`
+}
+
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// parsePom parses the dependencies of a Maven pom.xml file, the properties defined by the file are expanded
+func parsePom(r io.Reader) ([]*Component, error) {
+	var pom struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+		Parent  struct {
+			GroupID string `xml:"groupId"`
+			Version string `xml:"version"`
+		} `xml:"parent"`
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies         []pomDependency `xml:"dependencies>dependency"`
+		DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	if err := xml.NewDecoder(r).Decode(&pom); err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{
+		"project.groupId":        util.IfZero(pom.GroupID, pom.Parent.GroupID),
+		"project.version":        util.IfZero(pom.Version, pom.Parent.Version),
+		"project.parent.groupId": pom.Parent.GroupID,
+		"project.parent.version": pom.Parent.Version,
+	}
+	for _, p := range pom.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	expand := func(s string) string {
+		s = pomPropertyPattern.ReplaceAllStringFunc(strings.TrimSpace(s), func(m string) string {
+			if v, ok := properties[m[2:len(m)-1]]; ok {
+				return v
+			}
+			return m
+		})
+		// a value referring to properties of other files can't be resolved
+		return util.Iif(strings.Contains(s, "${"), "", s)
+	}
+
+	managedVersions := make(map[string]string, len(pom.DependencyManagement))
+	for _, d := range pom.DependencyManagement {
+		managedVersions[expand(d.GroupID)+":"+expand(d.ArtifactID)] = expand(d.Version)
+	}
+
+	components := make([]*Component, 0, len(pom.Dependencies))
+	for _, d := range pom.Dependencies {
+		name := expand(d.GroupID) + ":" + expand(d.ArtifactID)
+		if strings.HasPrefix(name, ":") || strings.HasSuffix(name, ":") {
+			continue
+		}
+		var scope Scope
+		switch strings.TrimSpace(d.Scope) {
+		case "", "compile", "runtime":
+			scope = util.Iif(d.Optional, ScopeOptional, ScopeRuntime)
+		case "test":
+			scope = ScopeTest
+		case "provided", "system":
+			scope = ScopeProvided
+		default:
+			// "import" only imports the managed dependencies of another pom
+			continue
+		}
+		requirement := util.IfZero(expand(d.Version), managedVersions[name])
+		components = append(components, &Component{
+			Ecosystem:   EcosystemMaven,
+			Name:        name,
+			Version:     util.Iif(strings.ContainsAny(requirement, "[](),"), "", requirement),
+			Requirement: requirement,
+			Scope:       scope,
+			Direct:      true,
+		})
+	}
+	return components, nil
+}
+
+// parseCargoToml parses the dependencies of a Cargo.toml file. Only the subset of TOML used by the dependency tables
+// is supported: plain versions, inline tables and "[dependencies.name]" tables, local path dependencies are ignored.
+func parseCargoToml(r io.Reader) ([]*Component, error) {
+	var components []*Component
+	var table *cargoDependency // the dependency defined by the current "[dependencies.name]" table
+	flush := func() {
+		if table != nil {
+			if c := table.component(); c != nil {
+				components = append(components, c)
+			}
+			table = nil
+		}
+	}
+
+	var scope Scope
+	var inDependencies bool
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(cutTomlComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			flush()
+			var name string
+			scope, name, inDependencies = cargoDependencySection(strings.Trim(line, "[] \t"))
+			if inDependencies && name != "" {
+				table = &cargoDependency{key: name, scope: scope}
+				inDependencies = false
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = unquoteToml(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch {
+		case table != nil:
+			table.set(key, value)
+		case inDependencies:
+			d := &cargoDependency{key: key, scope: scope}
+			if strings.HasPrefix(value, "{") {
+				for k, v := range parseTomlInlineTable(value) {
+					d.set(k, v)
+				}
+			} else {
+				d.version = unquoteToml(value)
+			}
+			if c := d.component(); c != nil {
+				components = append(components, c)
+			}
+		}
+	}
+	flush()
+	return components, scanner.Err()
+}
+
+type cargoDependency struct {
+	key      string
+	scope    Scope
+	pkg      string
+	version  string
+	path     string
+	optional bool
+}
+
+func (d *cargoDependency) set(key, value string) {
+	switch key {
+	case "version":
+		d.version = unquoteToml(value)
+	case "package":
+		d.pkg = unquoteToml(value)
+	case "path":
+		d.path = unquoteToml(value)
+	case "optional":
+		d.optional = value == "true"
+	}
+}
+
+func (d *cargoDependency) component() *Component {
+	if d.path != "" && d.version == "" {
+		return nil
+	}
+	var version string
+	if v, ok := strings.CutPrefix(d.version, "="); ok && exactVersionPattern.MatchString(strings.TrimSpace(v)) {
+		version = strings.TrimSpace(v)
+	}
+	return &Component{
+		Ecosystem:   EcosystemCargo,
+		Name:        util.IfZero(d.pkg, d.key),
+		Version:     version,
+		Requirement: d.version,
+		Scope:       util.Iif(d.optional && d.scope == ScopeRuntime, ScopeOptional, d.scope),
+		Direct:      true,
+	}
+}
+
+// cargoDependencySection returns the scope of the dependency table of the section and the name of the dependency
+// if the table defines a single one, e.g. "target.'cfg(unix)'.dev-dependencies" or "dependencies.serde"
+func cargoDependencySection(section string) (scope Scope, name string, ok bool) {
+	if rest, isTarget := strings.CutPrefix(section, "target."); isTarget {
+		// the target is a key which may be quoted and contain dots
+		if quote := rest[:min(len(rest), 1)]; quote == `"` || quote == "'" {
+			end := strings.Index(rest[1:], quote)
+			if end < 0 {
+				return "", "", false
+			}
+			rest = rest[end+2:]
+		} else if _, after, found := strings.Cut(rest, "."); found {
+			rest = after
+		}
+		section = strings.TrimPrefix(rest, ".")
+	}
+	section = strings.TrimPrefix(section, "workspace.")
+
+	table, name, _ := strings.Cut(section, ".")
+	switch table {
+	case "dependencies":
+		scope = ScopeRuntime
+	case "dev-dependencies":
+		scope = ScopeDevelopment
+	case "build-dependencies":
+		scope = ScopeBuild
+	default:
+		return "", "", false
+	}
+	return scope, unquoteToml(name), true
+}
+
+// cutTomlComment removes the comment from a line, the "#" in strings doesn't start a comment
+func cutTomlComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseTomlInlineTable parses the keys of an inline table "{ version = "1.0", features = ["a", "b"] }",
+// the values are returned as they are written
+func parseTomlInlineTable(s string) map[string]string {
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "{"), "}")
+	res := make(map[string]string)
+	var quote byte
+	var depth, start int
+	add := func(entry string) {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			res[unquoteToml(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			add(s[start:i])
+			start = i + 1
+		}
+	}
+	add(s[start:])
+	return res
+}
+
+func unquoteToml(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
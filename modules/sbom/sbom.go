@@ -0,0 +1,194 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Format is the format of a SBOM document
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// ContentType returns the media type of documents of the format
+func (f Format) ContentType() string {
+	if f == FormatSPDX {
+		return "application/spdx+json"
+	}
+	return "application/vnd.cyclonedx+json"
+}
+
+// ParseFormat parses the format name, the default is CycloneDX
+func ParseFormat(s string) (Format, bool) {
+	switch strings.ToLower(s) {
+	case "", "cyclonedx", "cyclonedx-json":
+		return FormatCycloneDX, true
+	case "spdx", "spdx-json":
+		return FormatSPDX, true
+	}
+	return "", false
+}
+
+// Ecosystem is the package ecosystem of a component, it is the type of the package URL
+type Ecosystem string
+
+const (
+	EcosystemGo    Ecosystem = "golang"
+	EcosystemNpm   Ecosystem = "npm"
+	EcosystemPyPI  Ecosystem = "pypi"
+	EcosystemCargo Ecosystem = "cargo"
+	EcosystemMaven Ecosystem = "maven"
+)
+
+// Scope is the scope in which a dependency declared by a manifest is needed
+type Scope string
+
+const (
+	ScopeRuntime     Scope = "runtime"
+	ScopeDevelopment Scope = "development"
+	ScopeTest        Scope = "test"
+	ScopeBuild       Scope = "build"
+	ScopeOptional    Scope = "optional"
+	ScopePeer        Scope = "peer"
+	ScopeProvided    Scope = "provided"
+)
+
+// Component is a dependency found in a lockfile or a manifest
+type Component struct {
+	Ecosystem Ecosystem
+	Name      string
+	// Version is the exact version of the component, it is empty if the version isn't pinned
+	Version string
+	// Source is the path of the lockfile or the manifest the component was found in
+	Source string
+	// Requirement is the version constraint of a component declared by a manifest
+	Requirement string
+	Scope       Scope
+	// Direct is true if the component is declared by a manifest of the project
+	Direct bool
+	// License is the SPDX license expression of the component if the lockfile records it
+	License string
+}
+
+// PackageURL returns the package URL (https://github.com/package-url/purl-spec) of the component
+func (c *Component) PackageURL() string {
+	name := c.Name
+	switch c.Ecosystem {
+	case EcosystemPyPI:
+		name = NormalizePyPIName(name)
+	case EcosystemMaven:
+		// the group id is the namespace of the package URL
+		name = strings.Replace(name, ":", "/", 1)
+	case EcosystemNpm:
+		name = strings.TrimPrefix(name, "@")
+		if name != c.Name {
+			name = "%40" + name
+		}
+	}
+
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "%40") {
+			segments[i] = url.PathEscape(segment)
+		}
+	}
+	purl := "pkg:" + string(c.Ecosystem) + "/" + strings.Join(segments, "/")
+	if c.Version != "" {
+		purl += "@" + url.PathEscape(c.Version)
+	}
+	return purl
+}
+
+// NormalizePyPIName normalizes a Python package name as described in PEP 503
+func NormalizePyPIName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
+
+// NormalizeName returns the name of the package by which it is looked up in the ecosystem,
+// names which are equivalent in the ecosystem have the same normalized name
+func NormalizeName(ecosystem Ecosystem, name string) string {
+	switch ecosystem {
+	case EcosystemPyPI:
+		return NormalizePyPIName(name)
+	case EcosystemCargo:
+		return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	}
+	return name
+}
+
+// Document is the subject of a SBOM and the components it depends on
+type Document struct {
+	// Name is the name of the subject, e.g. the full name of a repository
+	Name string
+	// Version is the version of the subject, e.g. a commit id
+	Version string
+	// Namespace is an URL which identifies the subject
+	Namespace  string
+	Created    time.Time
+	Components []*Component
+}
+
+// AddComponents adds the components to the document
+func (d *Document) AddComponents(components ...*Component) {
+	d.Components = append(d.Components, components...)
+}
+
+// uniqueComponents returns the components sorted by their package URL, each package URL is listed once
+func (d *Document) uniqueComponents() []*Component {
+	components := make([]*Component, 0, len(d.Components))
+	seen := make(map[string]bool, len(d.Components))
+	for _, c := range d.Components {
+		purl := c.PackageURL()
+		if !seen[purl] {
+			seen[purl] = true
+			components = append(components, c)
+		}
+	}
+	slices.SortFunc(components, func(a, b *Component) int {
+		return strings.Compare(a.PackageURL(), b.PackageURL())
+	})
+	return components
+}
+
+// Write writes the document in the format
+func (d *Document) Write(w io.Writer, format Format) error {
+	if format == FormatSPDX {
+		return writeSPDX(w, d)
+	}
+	return writeCycloneDX(w, d)
+}
+
+// IsLockfile checks if the file is a supported lockfile
+func IsLockfile(filename string) bool {
+	_, ok := lockfileParsers[path.Base(filename)]
+	return ok
+}
+
+// ParseLockfile parses the components of the lockfile
+func ParseLockfile(filename string, r io.Reader) ([]*Component, error) {
+	parse, ok := lockfileParsers[path.Base(filename)]
+	if !ok {
+		return nil, ErrUnsupportedLockfile
+	}
+	components, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range components {
+		c.Source = filename
+	}
+	slices.SortFunc(components, func(a, b *Component) int {
+		return strings.Compare(a.PackageURL(), b.PackageURL())
+	})
+	return components, nil
+}
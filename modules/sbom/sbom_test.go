@@ -0,0 +1,308 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockfile(t *testing.T) {
+	t.Run("GoSum", func(t *testing.T) {
+		components, err := ParseLockfile("sub/go.sum", strings.NewReader(`github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+golang.org/x/text v0.3.0/go.mod h1:NqM8EUOU14njkJ3fqMW+pc6Ldnwhi/IjpwHt7yyuwOQ=
+`))
+		require.NoError(t, err)
+		require.Len(t, components, 1)
+		assert.Equal(t, &Component{Ecosystem: EcosystemGo, Name: "github.com/pkg/errors", Version: "v0.9.1", Source: "sub/go.sum"}, components[0])
+		assert.Equal(t, "pkg:golang/github.com/pkg/errors@v0.9.1", components[0].PackageURL())
+	})
+
+	t.Run("PackageLock", func(t *testing.T) {
+		components, err := ParseLockfile("package-lock.json", strings.NewReader(`{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.21", "license": "MIT"},
+    "node_modules/@babel/core": {"version": "7.24.0", "license": {"type": "MIT"}},
+    "node_modules/@babel/core/node_modules/semver": {"version": "6.3.1"},
+    "node_modules/local": {"resolved": "packages/local", "link": true}
+  }
+}`))
+		require.NoError(t, err)
+		require.Len(t, components, 3)
+		assert.Equal(t, "pkg:npm/%40babel/core@7.24.0", components[0].PackageURL())
+		assert.Equal(t, "pkg:npm/lodash@4.17.21", components[1].PackageURL())
+		assert.Equal(t, "MIT", components[1].License)
+		// the licenses recorded as objects by old packages are ignored
+		assert.Empty(t, components[0].License)
+		assert.Equal(t, "pkg:npm/semver@6.3.1", components[2].PackageURL())
+
+		components, err = ParseLockfile("package-lock.json", strings.NewReader(`{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}}
+  }
+}`))
+		require.NoError(t, err)
+		require.Len(t, components, 2)
+		assert.Equal(t, "a", components[0].Name)
+		assert.Equal(t, "b", components[1].Name)
+
+		_, err = ParseLockfile("package-lock.json", strings.NewReader("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Requirements", func(t *testing.T) {
+		components, err := ParseLockfile("requirements.txt", strings.NewReader(`# comment
+-r other.txt
+--index-url https://example.com/simple
+Django==4.2.1
+requests[security] == 2.31.0 ; python_version >= "3.8"
+zope.interface>=5.0
+Django==4.2.1
+`))
+		require.NoError(t, err)
+		require.Len(t, components, 3)
+		assert.Equal(t, "pkg:pypi/django@4.2.1", components[0].PackageURL())
+		assert.Equal(t, "pkg:pypi/requests@2.31.0", components[1].PackageURL())
+		assert.Equal(t, "pkg:pypi/zope-interface", components[2].PackageURL())
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		assert.False(t, IsLockfile("src/Cargo.lock"))
+		_, err := ParseLockfile("Cargo.lock", strings.NewReader(""))
+		assert.ErrorIs(t, err, ErrUnsupportedLockfile)
+	})
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Run("GoMod", func(t *testing.T) {
+		components, err := ParseManifest("go.mod", strings.NewReader(`module example.com/app
+
+go 1.24
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/text v0.3.0 // indirect
+)
+
+replace github.com/pkg/errors => ../errors
+`))
+		require.NoError(t, err)
+		require.Len(t, components, 2)
+		assert.Equal(t, &Component{Ecosystem: EcosystemGo, Name: "github.com/pkg/errors", Version: "v0.9.1", Source: "go.mod", Requirement: "v0.9.1", Scope: ScopeRuntime, Direct: true}, components[0])
+		assert.False(t, components[1].Direct)
+	})
+
+	t.Run("PackageJSON", func(t *testing.T) {
+		components, err := ParseManifest("web/package.json", strings.NewReader(`{
+  "name": "app",
+  "dependencies": {"lodash": "^4.17.0", "left-pad": "1.3.0", "local": "file:../local", "my-react": "npm:react@^18.2.0"},
+  "devDependencies": {"@types/node": "~20.0.0", "lodash": "^4.17.0"}
+}`))
+		require.NoError(t, err)
+		require.Len(t, components, 4)
+		assert.Equal(t, "@types/node", components[0].Name)
+		assert.Equal(t, ScopeDevelopment, components[0].Scope)
+		assert.Equal(t, "left-pad", components[1].Name)
+		assert.Equal(t, "1.3.0", components[1].Version)
+		assert.Equal(t, "lodash", components[2].Name)
+		assert.Empty(t, components[2].Version)
+		assert.Equal(t, "^4.17.0", components[2].Requirement)
+		assert.Equal(t, ScopeRuntime, components[2].Scope)
+		assert.Equal(t, "react", components[3].Name)
+		assert.Equal(t, "^18.2.0", components[3].Requirement)
+		assert.Equal(t, "web/package.json", components[3].Source)
+	})
+
+	t.Run("Pom", func(t *testing.T) {
+		components, err := ParseManifest("pom.xml", strings.NewReader(`<project>
+  <groupId>com.example</groupId>
+  <artifactId>app</artifactId>
+  <version>1.0.0</version>
+  <properties><junit.version>5.10.0</junit.version></properties>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>33.0.0-jre</version></dependency>
+  </dependencies></dependencyManagement>
+  <dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId></dependency>
+    <dependency><groupId>org.junit.jupiter</groupId><artifactId>junit-jupiter</artifactId><version>${junit.version}</version><scope>test</scope></dependency>
+    <dependency><groupId>${project.groupId}</groupId><artifactId>lib</artifactId><version>[1.0,2.0)</version></dependency>
+    <dependency><groupId>org.example</groupId><artifactId>bom</artifactId><scope>import</scope></dependency>
+  </dependencies>
+</project>`))
+		require.NoError(t, err)
+		require.Len(t, components, 3)
+		assert.Equal(t, "com.example:lib", components[0].Name)
+		assert.Empty(t, components[0].Version)
+		assert.Equal(t, "[1.0,2.0)", components[0].Requirement)
+		assert.Equal(t, "pkg:maven/com.google.guava/guava@33.0.0-jre", components[1].PackageURL())
+		assert.Equal(t, ScopeRuntime, components[1].Scope)
+		assert.Equal(t, "5.10.0", components[2].Version)
+		assert.Equal(t, ScopeTest, components[2].Scope)
+
+		_, err = ParseManifest("pom.xml", strings.NewReader("<project>"))
+		assert.Error(t, err)
+	})
+
+	t.Run("CargoToml", func(t *testing.T) {
+		components, err := ParseManifest("Cargo.toml", strings.NewReader(`[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive", "rc"] } # comment
+log = "=0.4.20"
+local = { path = "../local" }
+tokio_util = { package = "tokio-util", version = "0.7", optional = true }
+
+[dependencies.regex]
+version = "1.10"
+
+[dev-dependencies]
+criterion = "0.5"
+
+[target.'cfg(unix)'.build-dependencies]
+cc = "1.0"
+`))
+		require.NoError(t, err)
+		require.Len(t, components, 6)
+		assert.Equal(t, "cc", components[0].Name)
+		assert.Equal(t, ScopeBuild, components[0].Scope)
+		assert.Equal(t, "criterion", components[1].Name)
+		assert.Equal(t, ScopeDevelopment, components[1].Scope)
+		assert.Equal(t, &Component{Ecosystem: EcosystemCargo, Name: "log", Version: "0.4.20", Source: "Cargo.toml", Requirement: "=0.4.20", Scope: ScopeRuntime, Direct: true}, components[2])
+		assert.Equal(t, "regex", components[3].Name)
+		assert.Equal(t, "1.10", components[3].Requirement)
+		assert.Equal(t, "serde", components[4].Name)
+		assert.Equal(t, "1.0", components[4].Requirement)
+		assert.Equal(t, "tokio-util", components[5].Name)
+		assert.Equal(t, ScopeOptional, components[5].Scope)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		assert.False(t, IsManifest("setup.py"))
+		_, err := ParseManifest("setup.py", strings.NewReader(""))
+		assert.ErrorIs(t, err, ErrUnsupportedManifest)
+	})
+}
+
+func TestResolveDependencies(t *testing.T) {
+	declared := []*Component{
+		{Ecosystem: EcosystemNpm, Name: "lodash", Requirement: "^4.17.0", Source: "package.json", Direct: true},
+		{Ecosystem: EcosystemNpm, Name: "semver", Requirement: "^7.0.0", Source: "package.json", Direct: true},
+		{Ecosystem: EcosystemNpm, Name: "chalk", Requirement: "^5.0.0", Source: "web/package.json", Direct: true},
+	}
+	resolved := []*Component{
+		{Ecosystem: EcosystemNpm, Name: "lodash", Version: "4.17.21", Source: "package-lock.json", License: "MIT"},
+		{Ecosystem: EcosystemNpm, Name: "semver", Version: "7.6.0", Source: "package-lock.json"},
+		{Ecosystem: EcosystemNpm, Name: "semver", Version: "6.3.1", Source: "package-lock.json"},
+		{Ecosystem: EcosystemNpm, Name: "chalk", Version: "4.1.2", Source: "package-lock.json"},
+	}
+	components := ResolveDependencies(declared, resolved)
+	require.Len(t, components, 6)
+	assert.Equal(t, "4.17.21", components[0].Version)
+	assert.Equal(t, "MIT", components[0].License)
+	assert.Empty(t, components[1].Version)
+	// the lockfile of another directory doesn't resolve the versions of a manifest
+	assert.Empty(t, components[2].Version)
+	assert.Equal(t, "7.6.0", components[3].Version)
+	assert.Equal(t, "6.3.1", components[4].Version)
+	assert.Equal(t, "chalk", components[5].Name)
+	assert.False(t, components[5].Direct)
+
+	assert.True(t, IsVendoredPath("a/node_modules/b/package.json"))
+	assert.False(t, IsVendoredPath("vendors/package.json"))
+}
+
+func TestIsLicenseDenied(t *testing.T) {
+	denied := container.SetOf(NormalizeLicenseID("GPL-3.0-only"), NormalizeLicenseID("AGPL-3.0"))
+	for expression, expected := range map[string]bool{
+		"":                               false,
+		"MIT":                            false,
+		"GPL-3.0":                        true,
+		"gpl-3.0-or-later":               true,
+		"GPL-3.0+":                       true,
+		"MIT OR GPL-3.0":                 false,
+		"MIT AND GPL-3.0":                true,
+		"(MIT OR AGPL-3.0) AND GPL-3.0":  true,
+		"(GPL-3.0 OR AGPL-3.0)":          true,
+		"GPL-3.0 WITH GCC-exception-3.1": true,
+		"GPL-2.0 WITH Classpath-exception-2.0 or Apache-2.0": false,
+		"MIT AND":  false,
+		"(GPL-3.0": false,
+	} {
+		assert.Equal(t, expected, IsLicenseDenied(expression, denied), expression)
+	}
+}
+
+func TestDocumentWrite(t *testing.T) {
+	doc := &Document{
+		Name:      "user2/repo1",
+		Version:   "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		Namespace: "https://gitea.example.com/user2/repo1/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		Created:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	doc.AddComponents(
+		&Component{Ecosystem: EcosystemNpm, Name: "lodash", Version: "4.17.21", Source: "package-lock.json"},
+		&Component{Ecosystem: EcosystemGo, Name: "github.com/pkg/errors", Version: "v0.9.1", Source: "go.sum"},
+		&Component{Ecosystem: EcosystemNpm, Name: "lodash", Version: "4.17.21", Source: "web/package-lock.json"},
+	)
+
+	t.Run("CycloneDX", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, doc.Write(&buf, FormatCycloneDX))
+
+		var result cycloneDXDocument
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &result))
+		assert.Equal(t, "CycloneDX", result.BOMFormat)
+		assert.Equal(t, "1.5", result.SpecVersion)
+		assert.True(t, strings.HasPrefix(result.SerialNumber, "urn:uuid:"))
+		assert.Equal(t, "2026-01-02T03:04:05Z", result.Metadata.Timestamp)
+		assert.Equal(t, "user2/repo1", result.Metadata.Component.Name)
+		require.Len(t, result.Components, 2)
+		assert.Equal(t, "pkg:golang/github.com/pkg/errors@v0.9.1", result.Components[0].PURL)
+		assert.Equal(t, "pkg:npm/lodash@4.17.21", result.Components[1].PURL)
+		assert.Equal(t, "library", result.Components[1].Type)
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, doc.Write(&buf, FormatSPDX))
+
+		var result spdxDocument
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &result))
+		assert.Equal(t, "SPDX-2.3", result.SPDXVersion)
+		assert.Equal(t, doc.Namespace, result.DocumentNamespace)
+		require.Len(t, result.Packages, 3)
+		assert.Equal(t, "SPDXRef-Root", result.Packages[0].SPDXID)
+		assert.Equal(t, "github.com/pkg/errors", result.Packages[1].Name)
+		assert.Equal(t, "pkg:golang/github.com/pkg/errors@v0.9.1", result.Packages[1].ExternalRefs[0].ReferenceLocator)
+		assert.Equal(t, "SPDXRef-Package-golang-github.com-pkg-errors-0", result.Packages[1].SPDXID)
+		require.Len(t, result.Relationships, 3)
+		assert.Equal(t, "DESCRIBES", result.Relationships[0].RelationshipType)
+		assert.Equal(t, "DEPENDS_ON", result.Relationships[1].RelationshipType)
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	f, ok := ParseFormat("")
+	assert.True(t, ok)
+	assert.Equal(t, FormatCycloneDX, f)
+	f, ok = ParseFormat("SPDX")
+	assert.True(t, ok)
+	assert.Equal(t, FormatSPDX, f)
+	assert.Equal(t, "application/spdx+json", f.ContentType())
+	_, ok = ParseFormat("swid")
+	assert.False(t, ok)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+type awsProvider struct{}
+
+func (p *awsProvider) Enabled() bool {
+	return setting.ActionsSecretProviders.AWS.Enabled && setting.ActionsSecretProviders.AWS.Region != ""
+}
+
+func (p *awsProvider) Validate(ownerName, ref string) error {
+	_, _, err := splitReference(setting.ActionsSecretProviders.AWS.NamePrefix, ownerName, ref)
+	return err
+}
+
+// credentials returns the configured access key, or the one of the standard AWS environment variables
+func (p *awsProvider) credentials() aws.Credentials {
+	cfg := &setting.ActionsSecretProviders.AWS
+	if cfg.AccessKeyID != "" {
+		return aws.Credentials{AccessKeyID: cfg.AccessKeyID, SecretAccessKey: cfg.SecretAccessKey}
+	}
+	return aws.Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+func (p *awsProvider) Resolve(ctx context.Context, ownerName, ref string) (string, error) {
+	cfg := &setting.ActionsSecretProviders.AWS
+	name, field, err := splitReference(cfg.NamePrefix, ownerName, ref)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": name})
+	if err != nil {
+		return "", err
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", cfg.Region)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, p.credentials(), req, hex.EncodeToString(payloadHash[:]), "secretsmanager", cfg.Region, time.Now()); err != nil {
+		return "", err
+	}
+
+	resp, err := newHTTPClient(cfg.Timeout).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		if errResp.Type == "ResourceNotFoundException" {
+			return "", util.NewNotExistErrorf("aws secret %q doesn't exist", name)
+		}
+		return "", fmt.Errorf("aws secrets manager responded %d: %s %s", resp.StatusCode, errResp.Type, errResp.Message)
+	}
+	var result struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if field == "" {
+		return result.SecretString, nil
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(result.SecretString), &data); err != nil {
+		return "", fmt.Errorf("aws secret %q isn't a JSON object: %w", name, err)
+	}
+	return fieldValue(data, field)
+}
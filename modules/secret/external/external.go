@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package external resolves the Actions secrets which reference a value in an external secret store
+package external
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	ProviderVault = "vault" // HashiCorp Vault KV version 2, the reference is `path/of/secret#field`
+	ProviderAWS   = "aws"   // AWS Secrets Manager, the reference is `secret-name` or `secret-name#json-key`
+)
+
+// Provider is an external secret store
+type Provider interface {
+	// Enabled reports whether the provider is configured
+	Enabled() bool
+	// Validate checks that the reference is valid and that the owner may use it
+	Validate(ownerName, ref string) error
+	// Resolve returns the current value of the referenced secret
+	Resolve(ctx context.Context, ownerName, ref string) (string, error)
+}
+
+var providers = map[string]Provider{
+	ProviderVault: &vaultProvider{},
+	ProviderAWS:   &awsProvider{},
+}
+
+// EnabledProviders returns the names of the configured providers
+func EnabledProviders() []string {
+	var names []string
+	for _, name := range []string{ProviderVault, ProviderAWS} {
+		if providers[name].Enabled() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func getProvider(name string) (Provider, error) {
+	p, ok := providers[name]
+	if !ok || !p.Enabled() {
+		return nil, util.NewInvalidArgumentErrorf("secret provider %q is not enabled", name)
+	}
+	return p, nil
+}
+
+// Validate checks the reference of a secret of the owner or of one of its repositories
+func Validate(provider, ownerName, ref string) error {
+	p, err := getProvider(provider)
+	if err != nil {
+		return err
+	}
+	return p.Validate(ownerName, strings.TrimSpace(ref))
+}
+
+// Resolve returns the value of the secret referenced by a secret of the owner or of one of its repositories
+func Resolve(ctx context.Context, provider, ownerName, ref string) (string, error) {
+	p, err := getProvider(provider)
+	if err != nil {
+		return "", err
+	}
+	ref = strings.TrimSpace(ref)
+	if err := p.Validate(ownerName, ref); err != nil {
+		return "", err
+	}
+	return p.Resolve(ctx, ownerName, ref)
+}
+
+// ownerPrefix renders the prefix which all the references of the owner must start with
+func ownerPrefix(prefix, ownerName string) string {
+	return strings.ReplaceAll(prefix, "{owner}", strings.ToLower(ownerName))
+}
+
+// splitReference splits `name#field` and checks the name starts with the owner's prefix and has no relative segments
+func splitReference(prefix, ownerName, ref string) (name, field string, err error) {
+	name, field, _ = strings.Cut(ref, "#")
+	if name == "" {
+		return "", "", util.NewInvalidArgumentErrorf("secret reference must not be empty")
+	}
+	for _, seg := range strings.Split(name, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", "", util.NewInvalidArgumentErrorf("invalid secret reference %q", ref)
+		}
+	}
+	if p := ownerPrefix(prefix, ownerName); !strings.HasPrefix(name, p) {
+		return "", "", util.NewInvalidArgumentErrorf("secret reference %q must start with %q", ref, p)
+	}
+	return name, field, nil
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{Proxy: proxy.Proxy()},
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package external
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitReference(t *testing.T) {
+	name, field, err := splitReference("gitea/{owner}/", "MyOrg", "gitea/myorg/deploy#token")
+	require.NoError(t, err)
+	assert.Equal(t, "gitea/myorg/deploy", name)
+	assert.Equal(t, "token", field)
+
+	for _, ref := range []string{"", "#token", "gitea/other/deploy#token", "gitea/myorg/../other/deploy#token", "gitea/myorg//deploy"} {
+		_, _, err = splitReference("gitea/{owner}/", "MyOrg", ref)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, ref)
+	}
+}
+
+func TestVaultResolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["role_id"] != "role" || req["secret_id"] != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"approle-token","lease_duration":3600}}`))
+		case "/v1/kv/data/gitea/myorg/deploy":
+			if r.Header.Get("X-Vault-Token") != "approle-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"s3cret","port":22}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.Vault.Enabled, true)()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.Vault.URL, srv.URL)()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.Vault.Mount, "kv")()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.Vault.RoleID, "role")()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.Vault.SecretID, "secret")()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.Vault.PathPrefix, "gitea/{owner}/")()
+	providers[ProviderVault] = &vaultProvider{}
+
+	assert.Equal(t, []string{ProviderVault}, EnabledProviders())
+
+	v, err := Resolve(t.Context(), ProviderVault, "myorg", "gitea/myorg/deploy#token")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", v)
+	v, err = Resolve(t.Context(), ProviderVault, "myorg", "gitea/myorg/deploy#port")
+	require.NoError(t, err)
+	assert.Equal(t, "22", v)
+
+	_, err = Resolve(t.Context(), ProviderVault, "myorg", "gitea/myorg/deploy#missing")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	_, err = Resolve(t.Context(), ProviderVault, "myorg", "gitea/myorg/other#token")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	// a field is required
+	assert.ErrorIs(t, Validate(ProviderVault, "myorg", "gitea/myorg/deploy"), util.ErrInvalidArgument)
+	// the other owners' secrets can't be referenced
+	assert.ErrorIs(t, Validate(ProviderVault, "evil", "gitea/myorg/deploy#token"), util.ErrInvalidArgument)
+	assert.ErrorIs(t, Validate(ProviderAWS, "myorg", "gitea/myorg/deploy"), util.ErrInvalidArgument)
+}
+
+func TestAWSResolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch req["SecretId"] {
+		case "gitea/myorg/plain":
+			_, _ = w.Write([]byte(`{"SecretString":"plain-value"}`))
+		case "gitea/myorg/json":
+			_, _ = w.Write([]byte(`{"SecretString":"{\"user\":\"bot\",\"password\":\"pw\"}"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.AWS.Enabled, true)()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.AWS.Region, "us-east-1")()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.AWS.Endpoint, srv.URL)()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.AWS.AccessKeyID, "AKID")()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.AWS.SecretAccessKey, "SECRET")()
+	defer test.MockVariableValue(&setting.ActionsSecretProviders.AWS.NamePrefix, "gitea/{owner}/")()
+
+	v, err := Resolve(t.Context(), ProviderAWS, "MyOrg", "gitea/myorg/plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-value", v)
+	v, err = Resolve(t.Context(), ProviderAWS, "MyOrg", "gitea/myorg/json#password")
+	require.NoError(t, err)
+	assert.Equal(t, "pw", v)
+	_, err = Resolve(t.Context(), ProviderAWS, "MyOrg", "gitea/myorg/missing")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	_, err = Resolve(t.Context(), ProviderAWS, "MyOrg", "gitea/myorg/plain#key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+type vaultProvider struct {
+	mu           sync.Mutex
+	loginToken   string
+	loginExpires time.Time
+}
+
+func (p *vaultProvider) Enabled() bool {
+	return setting.ActionsSecretProviders.Vault.Enabled && setting.ActionsSecretProviders.Vault.URL != ""
+}
+
+func (p *vaultProvider) Validate(ownerName, ref string) error {
+	_, field, err := splitReference(setting.ActionsSecretProviders.Vault.PathPrefix, ownerName, ref)
+	if err != nil {
+		return err
+	}
+	if field == "" {
+		return util.NewInvalidArgumentErrorf("vault secret reference %q must end with #field", ref)
+	}
+	return nil
+}
+
+func (p *vaultProvider) do(ctx context.Context, method, path, token string, body, result any) error {
+	cfg := &setting.ActionsSecretProviders.Vault
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, cfg.URL+"/v1/"+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", cfg.Namespace)
+	}
+	resp, err := newHTTPClient(cfg.Timeout).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		if resp.StatusCode == http.StatusNotFound {
+			return util.NewNotExistErrorf("vault secret %q doesn't exist", path)
+		}
+		return fmt.Errorf("vault responded %d: %s", resp.StatusCode, strings.Join(errResp.Errors, ", "))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// token returns the configured token, or logs in with the AppRole auth method
+func (p *vaultProvider) token(ctx context.Context) (string, error) {
+	cfg := &setting.ActionsSecretProviders.Vault
+	if cfg.Token != "" {
+		return cfg.Token, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.loginToken != "" && time.Now().Before(p.loginExpires) {
+		return p.loginToken, nil
+	}
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := p.do(ctx, http.MethodPost, "auth/approle/login", "", map[string]string{
+		"role_id":   cfg.RoleID,
+		"secret_id": cfg.SecretID,
+	}, &resp); err != nil {
+		return "", fmt.Errorf("vault approle login: %w", err)
+	}
+	p.loginToken = resp.Auth.ClientToken
+	// renew the token a bit before it expires
+	p.loginExpires = time.Now().Add(time.Duration(resp.Auth.LeaseDuration)*time.Second - 30*time.Second)
+	return p.loginToken, nil
+}
+
+func (p *vaultProvider) Resolve(ctx context.Context, ownerName, ref string) (string, error) {
+	path, field, err := splitReference(setting.ActionsSecretProviders.Vault.PathPrefix, ownerName, ref)
+	if err != nil {
+		return "", err
+	}
+	token, err := p.token(ctx)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := p.do(ctx, http.MethodGet, setting.ActionsSecretProviders.Vault.Mount+"/data/"+path, token, nil, &resp); err != nil {
+		return "", err
+	}
+	return fieldValue(resp.Data.Data, field)
+}
+
+// fieldValue returns a field of a JSON secret, values which aren't strings are returned as JSON
+func fieldValue(data map[string]any, field string) (string, error) {
+	v, ok := data[field]
+	if !ok {
+		return "", util.NewNotExistErrorf("secret field %q doesn't exist", field)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	bs, err := json.Marshal(v)
+	return string(bs), err
+}
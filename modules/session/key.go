@@ -8,4 +8,9 @@ const (
 	KeyUname = "uname"
 
 	KeyUserHasTwoFactorAuth = "userHasTwoFactorAuth"
+
+	// KeyImpersonatorUID is the uid of the admin who has signed in as the user of KeyUID
+	KeyImpersonatorUID = "impersonatorUid"
+	// KeyImpersonationDeadline is the unix time at which the admin is signed in as themselves again
+	KeyImpersonationDeadline = "impersonationDeadline"
 )
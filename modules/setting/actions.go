@@ -25,6 +25,15 @@ var (
 		EndlessTaskTimeout    time.Duration     `ini:"ENDLESS_TASK_TIMEOUT"`
 		AbandonedJobTimeout   time.Duration     `ini:"ABANDONED_JOB_TIMEOUT"`
 		SkipWorkflowStrings   []string          `ini:"SKIP_WORKFLOW_STRINGS"`
+		IDTokenEnabled        bool              `ini:"ID_TOKEN_ENABLED"`
+		IDTokenExpiry         time.Duration     `ini:"ID_TOKEN_EXPIRY"`
+		CacheEnabled          bool              `ini:"CACHE_ENABLED"`
+		CacheStorage          *Storage          // how the caches of actions/cache should be stored
+		CacheMaxSizePerRepo   int64             `ini:"-"`
+		CacheUnusedTTL        time.Duration     `ini:"CACHE_UNUSED_TTL"`
+
+		// ScheduleJitter is the longest delay of the scheduled runs, so they don't all start at the same time
+		ScheduleJitter time.Duration `ini:"SCHEDULE_JITTER"`
 	}{
 		Enabled:             true,
 		DefaultActionsURL:   defaultActionsURLGitHub,
@@ -114,6 +123,22 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 	Actions.ZombieTaskTimeout = sec.Key("ZOMBIE_TASK_TIMEOUT").MustDuration(10 * time.Minute)
 	Actions.EndlessTaskTimeout = sec.Key("ENDLESS_TASK_TIMEOUT").MustDuration(3 * time.Hour)
 	Actions.AbandonedJobTimeout = sec.Key("ABANDONED_JOB_TIMEOUT").MustDuration(24 * time.Hour)
+	Actions.IDTokenExpiry = sec.Key("ID_TOKEN_EXPIRY").MustDuration(10 * time.Minute)
+	Actions.ScheduleJitter = sec.Key("SCHEDULE_JITTER").MustDuration(0)
+	if Actions.ScheduleJitter < 0 {
+		return fmt.Errorf("invalid [actions] SCHEDULE_JITTER: %q", sec.Key("SCHEDULE_JITTER").String())
+	}
+
+	Actions.CacheStorage, err = getStorage(rootCfg, "actions_cache", "", nil)
+	if err != nil {
+		return err
+	}
+	// default to 10 GiB and 7 days like GitHub, -1 means no size limit
+	sec.Key("CACHE_MAX_SIZE_PER_REPO").MustString("10 GiB")
+	Actions.CacheMaxSizePerRepo = mustBytes(sec, "CACHE_MAX_SIZE_PER_REPO")
+	Actions.CacheUnusedTTL = sec.Key("CACHE_UNUSED_TTL").MustDuration(7 * 24 * time.Hour)
+
+	loadActionsSecretProvidersFrom(rootCfg)
 
 	if !Actions.LogCompression.IsValid() {
 		return fmt.Errorf("invalid [actions] LOG_COMPRESSION: %q", Actions.LogCompression)
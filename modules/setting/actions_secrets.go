@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"strings"
+	"time"
+)
+
+// ActionsSecretProviders are the external stores which Actions secrets can reference,
+// the references are resolved when a job is picked by a runner, so the values never reach the database.
+var ActionsSecretProviders = struct {
+	Vault struct {
+		Enabled bool
+		URL     string
+		// Namespace is the Vault Enterprise namespace
+		Namespace string
+		// Mount is the mount path of the KV version 2 secrets engine
+		Mount string
+		// Token authenticates Gitea, or RoleID and SecretID for the AppRole auth method
+		Token    string
+		RoleID   string
+		SecretID string
+		// PathPrefix is the path every reference must start with, {owner} is replaced by the user or organization name
+		PathPrefix string
+		Timeout    time.Duration
+	}
+	AWS struct {
+		Enabled         bool
+		Region          string
+		Endpoint        string
+		AccessKeyID     string
+		SecretAccessKey string
+		// NamePrefix is the prefix of every referenced secret name, {owner} is replaced by the user or organization name
+		NamePrefix string
+		Timeout    time.Duration
+	}
+}{}
+
+func loadActionsSecretProvidersFrom(rootCfg ConfigProvider) {
+	vault := &ActionsSecretProviders.Vault
+	sec := rootCfg.Section("actions.secrets.vault")
+	vault.Enabled = sec.Key("ENABLED").MustBool(false)
+	vault.URL = strings.TrimSuffix(sec.Key("URL").String(), "/")
+	vault.Namespace = sec.Key("NAMESPACE").String()
+	vault.Mount = strings.Trim(sec.Key("MOUNT").MustString("secret"), "/")
+	vault.Token = loadSecret(sec, "TOKEN_URI", "TOKEN")
+	vault.RoleID = sec.Key("ROLE_ID").String()
+	vault.SecretID = loadSecret(sec, "SECRET_ID_URI", "SECRET_ID")
+	vault.PathPrefix = sec.Key("PATH_PREFIX").MustString("gitea/{owner}/")
+	vault.Timeout = sec.Key("TIMEOUT").MustDuration(10 * time.Second)
+
+	aws := &ActionsSecretProviders.AWS
+	sec = rootCfg.Section("actions.secrets.aws")
+	aws.Enabled = sec.Key("ENABLED").MustBool(false)
+	aws.Region = sec.Key("REGION").String()
+	aws.Endpoint = strings.TrimSuffix(sec.Key("ENDPOINT").String(), "/")
+	aws.AccessKeyID = sec.Key("ACCESS_KEY_ID").String()
+	aws.SecretAccessKey = loadSecret(sec, "SECRET_ACCESS_KEY_URI", "SECRET_ACCESS_KEY")
+	aws.NamePrefix = sec.Key("NAME_PREFIX").MustString("gitea/{owner}/")
+	aws.Timeout = sec.Key("TIMEOUT").MustDuration(10 * time.Second)
+}
@@ -4,6 +4,8 @@
 package setting
 
 import (
+	"time"
+
 	"code.gitea.io/gitea/modules/container"
 )
 
@@ -13,6 +15,10 @@ var Admin struct {
 	DefaultEmailNotification    string
 	UserDisabledFeatures        container.Set[string]
 	ExternalUserDisableFeatures container.Set[string]
+	EnableImpersonation         bool
+	ImpersonationDuration       time.Duration
+	RequireSecondApproval       bool
+	ApprovalRequestExpiry       time.Duration
 }
 
 func loadAdminFrom(rootCfg ConfigProvider) {
@@ -21,6 +27,10 @@ func loadAdminFrom(rootCfg ConfigProvider) {
 	Admin.DefaultEmailNotification = sec.Key("DEFAULT_EMAIL_NOTIFICATIONS").MustString("enabled")
 	Admin.UserDisabledFeatures = container.SetOf(sec.Key("USER_DISABLED_FEATURES").Strings(",")...)
 	Admin.ExternalUserDisableFeatures = container.SetOf(sec.Key("EXTERNAL_USER_DISABLE_FEATURES").Strings(",")...).Union(Admin.UserDisabledFeatures)
+	Admin.EnableImpersonation = sec.Key("ENABLE_IMPERSONATION").MustBool(false)
+	Admin.ImpersonationDuration = sec.Key("IMPERSONATION_DURATION").MustDuration(30 * time.Minute)
+	Admin.RequireSecondApproval = sec.Key("REQUIRE_SECOND_APPROVAL").MustBool(false)
+	Admin.ApprovalRequestExpiry = sec.Key("APPROVAL_REQUEST_EXPIRY").MustDuration(72 * time.Hour)
 }
 
 const (
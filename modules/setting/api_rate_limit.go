@@ -0,0 +1,187 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/nosql"
+)
+
+// APIRateLimitRule is the budget of the API requests matching the rule, every client has a bucket per rule
+type APIRateLimitRule struct {
+	Name               string
+	Methods            container.Set[string] // empty matches all methods
+	Paths              []*GlobMatcher        // relative to /api/v1, empty matches all paths
+	Period             time.Duration
+	AnonymousLimit     int
+	AuthenticatedLimit int
+}
+
+// Match checks if the request of the method to the path relative to /api/v1 is limited by the rule
+func (r *APIRateLimitRule) Match(method, path string) bool {
+	if len(r.Methods) > 0 && !r.Methods.Contains(method) {
+		return false
+	}
+	if len(r.Paths) == 0 {
+		return true
+	}
+	for _, p := range r.Paths {
+		if p.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIRateLimit represents the configuration of the rate limit of the API
+var APIRateLimit = struct {
+	Enabled        bool
+	ServiceType    string
+	ServiceConnStr string
+}{
+	ServiceType: "memory",
+}
+
+// APIRateLimitRules are the rules of the API rate limit, they can be changed by reloading the settings
+type APIRateLimitRules struct {
+	ExemptAdmins bool
+	Default      *APIRateLimitRule
+	Rules        []*APIRateLimitRule // the first matching rule is used instead of the default one
+	GraphQL      *APIRateLimitRule   // the rule of the GraphQL API, the rules of the REST API don't apply to it
+}
+
+var apiRateLimitRules atomic.Pointer[APIRateLimitRules]
+
+// GetAPIRateLimitRules returns the current rules of the API rate limit
+func GetAPIRateLimitRules() *APIRateLimitRules {
+	if rules := apiRateLimitRules.Load(); rules != nil {
+		return rules
+	}
+	return &APIRateLimitRules{
+		Default: &APIRateLimitRule{
+			Name:               "default",
+			Period:             time.Hour,
+			AnonymousLimit:     60,
+			AuthenticatedLimit: 5000,
+		},
+		GraphQL: &APIRateLimitRule{
+			Name:               "graphql",
+			Period:             time.Hour,
+			AnonymousLimit:     60,
+			AuthenticatedLimit: 5000,
+		},
+	}
+}
+
+// SetAPIRateLimitRules replaces the rules of the API rate limit
+func SetAPIRateLimitRules(rules *APIRateLimitRules) {
+	apiRateLimitRules.Store(rules)
+}
+
+func loadAPIRateLimitFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("api.rate_limit")
+	APIRateLimit.Enabled = sec.Key("ENABLED").MustBool(false)
+	APIRateLimit.ServiceType = sec.Key("SERVICE_TYPE").MustString("memory")
+	switch APIRateLimit.ServiceType {
+	case "memory":
+	case "redis":
+		connStr := sec.Key("SERVICE_CONN_STR").String()
+		if connStr == "" {
+			log.Fatal("SERVICE_CONN_STR is empty for redis")
+		}
+		if nosql.ToRedisURI(connStr) == nil {
+			log.Fatal("SERVICE_CONN_STR %s is not a valid redis connection string", connStr)
+		}
+		APIRateLimit.ServiceConnStr = connStr
+	default:
+		log.Fatal("Unknown API rate limit service type: %s", APIRateLimit.ServiceType)
+	}
+	rules, err := parseAPIRateLimitRulesFrom(sec)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+	SetAPIRateLimitRules(rules)
+}
+
+// parseAPIRateLimitRulesFrom parses the default rule, the rule of the GraphQL API and the rules of the API rate limit
+func parseAPIRateLimitRulesFrom(sec ConfigSection) (*APIRateLimitRules, error) {
+	defaultRule := &APIRateLimitRule{
+		Name:               "default",
+		Period:             sec.Key("PERIOD").MustDuration(time.Hour),
+		AnonymousLimit:     sec.Key("ANONYMOUS_LIMIT").MustInt(60),
+		AuthenticatedLimit: sec.Key("AUTHENTICATED_LIMIT").MustInt(5000),
+	}
+	if err := checkAPIRateLimitRule(defaultRule); err != nil {
+		return nil, err
+	}
+	graphQLRule := &APIRateLimitRule{
+		Name:               "graphql",
+		Period:             sec.Key("GRAPHQL_PERIOD").MustDuration(defaultRule.Period),
+		AnonymousLimit:     sec.Key("GRAPHQL_ANONYMOUS_LIMIT").MustInt(defaultRule.AnonymousLimit),
+		AuthenticatedLimit: sec.Key("GRAPHQL_AUTHENTICATED_LIMIT").MustInt(defaultRule.AuthenticatedLimit),
+	}
+	if err := checkAPIRateLimitRule(graphQLRule); err != nil {
+		return nil, err
+	}
+
+	var rules []*APIRateLimitRule
+	for _, ruleSec := range sec.ChildSections() {
+		rule := &APIRateLimitRule{
+			Name:               strings.TrimPrefix(ruleSec.Name(), "api.rate_limit."),
+			Methods:            container.SetOf[string](),
+			Period:             ruleSec.Key("PERIOD").MustDuration(defaultRule.Period),
+			AnonymousLimit:     ruleSec.Key("ANONYMOUS_LIMIT").MustInt(defaultRule.AnonymousLimit),
+			AuthenticatedLimit: ruleSec.Key("AUTHENTICATED_LIMIT").MustInt(defaultRule.AuthenticatedLimit),
+		}
+		for _, method := range ruleSec.Key("METHODS").Strings(",") {
+			rule.Methods.Add(strings.ToUpper(method))
+		}
+		for _, path := range ruleSec.Key("PATHS").Strings(",") {
+			g, err := GlobMatcherCompile(path, '/')
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q of API rate limit rule %s: %w", path, rule.Name, err)
+			}
+			rule.Paths = append(rule.Paths, g)
+		}
+		if err := checkAPIRateLimitRule(rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return &APIRateLimitRules{
+		ExemptAdmins: sec.Key("EXEMPT_ADMINS").MustBool(false),
+		Default:      defaultRule,
+		Rules:        rules,
+		GraphQL:      graphQLRule,
+	}, nil
+}
+
+func checkAPIRateLimitRule(rule *APIRateLimitRule) error {
+	if rule.Period <= 0 || rule.AnonymousLimit <= 0 || rule.AuthenticatedLimit <= 0 {
+		return fmt.Errorf("the period and the limits of API rate limit rule %s must be positive", rule.Name)
+	}
+	return nil
+}
+
+// GetAPIRateLimitRule returns the rule limiting the request of the method to the path relative to /api/v1
+func GetAPIRateLimitRule(method, path string) *APIRateLimitRule {
+	rules := GetAPIRateLimitRules()
+	for _, rule := range rules.Rules {
+		if rule.Match(method, path) {
+			return rule
+		}
+	}
+	return rules.Default
+}
+
+// GetGraphQLRateLimitRule returns the rule limiting the requests to the GraphQL API
+func GetGraphQLRateLimitRule() *APIRateLimitRule {
+	return GetAPIRateLimitRules().GraphQL
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAPIRateLimitConfig(t *testing.T) {
+	defer test.MockVariableValue(&APIRateLimit)()
+	defer SetAPIRateLimitRules(GetAPIRateLimitRules())
+
+	t.Run("Default", func(t *testing.T) {
+		cfg, err := NewConfigProviderFromData(``)
+		require.NoError(t, err)
+
+		loadAPIRateLimitFrom(cfg)
+		assert.False(t, APIRateLimit.Enabled)
+		assert.Equal(t, "memory", APIRateLimit.ServiceType)
+		rules := GetAPIRateLimitRules()
+		assert.False(t, rules.ExemptAdmins)
+		assert.Empty(t, rules.Rules)
+		assert.Equal(t, time.Hour, rules.Default.Period)
+		assert.Equal(t, 60, rules.Default.AnonymousLimit)
+		assert.Equal(t, 5000, rules.Default.AuthenticatedLimit)
+		assert.Equal(t, rules.Default, GetAPIRateLimitRule("GET", "/repos/search"))
+		assert.Equal(t, "graphql", GetGraphQLRateLimitRule().Name)
+		assert.Equal(t, 60, GetGraphQLRateLimitRule().AnonymousLimit)
+	})
+
+	t.Run("Rules", func(t *testing.T) {
+		cfg, err := NewConfigProviderFromData(`
+[api.rate_limit]
+ENABLED = true
+SERVICE_TYPE = redis
+SERVICE_CONN_STR = redis://127.0.0.1:6379/0
+PERIOD = 10m
+ANONYMOUS_LIMIT = 10
+AUTHENTICATED_LIMIT = 100
+GRAPHQL_AUTHENTICATED_LIMIT = 50
+
+[api.rate_limit.search]
+METHODS = get
+PATHS = /repos/search, /repos/*/*/issues/search
+AUTHENTICATED_LIMIT = 20
+
+[api.rate_limit.write]
+METHODS = POST,PUT,PATCH,DELETE
+PERIOD = 1m
+`)
+		require.NoError(t, err)
+
+		loadAPIRateLimitFrom(cfg)
+		assert.True(t, APIRateLimit.Enabled)
+		assert.Equal(t, "redis", APIRateLimit.ServiceType)
+		assert.Equal(t, "redis://127.0.0.1:6379/0", APIRateLimit.ServiceConnStr)
+		rules := GetAPIRateLimitRules()
+		require.Len(t, rules.Rules, 2)
+
+		search := rules.Rules[0]
+		assert.Equal(t, "search", search.Name)
+		assert.Equal(t, 10*time.Minute, search.Period)
+		assert.Equal(t, 10, search.AnonymousLimit)
+		assert.Equal(t, 20, search.AuthenticatedLimit)
+
+		write := rules.Rules[1]
+		assert.Equal(t, "write", write.Name)
+		assert.Equal(t, time.Minute, write.Period)
+		assert.Equal(t, 100, write.AuthenticatedLimit)
+
+		assert.Equal(t, search, GetAPIRateLimitRule("GET", "/repos/search"))
+		assert.Equal(t, search, GetAPIRateLimitRule("GET", "/repos/user2/repo1/issues/search"))
+		assert.Equal(t, rules.Default, GetAPIRateLimitRule("GET", "/repos/user2/repo1/issues"))
+		assert.Equal(t, rules.Default, GetAPIRateLimitRule("GET", "/repos/user2/repo1/sub/issues/search"))
+		assert.Equal(t, write, GetAPIRateLimitRule("POST", "/repos/search"))
+		assert.Equal(t, write, GetAPIRateLimitRule("DELETE", "/repos/user2/repo1"))
+
+		// the GraphQL API isn't limited by the rules
+		graphQL := GetGraphQLRateLimitRule()
+		assert.Equal(t, 10*time.Minute, graphQL.Period)
+		assert.Equal(t, 10, graphQL.AnonymousLimit)
+		assert.Equal(t, 50, graphQL.AuthenticatedLimit)
+	})
+}
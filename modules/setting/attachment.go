@@ -3,12 +3,15 @@
 
 package setting
 
+import "time"
+
 type AttachmentSettingType struct {
-	Storage      *Storage
-	AllowedTypes string
-	MaxSize      int64
-	MaxFiles     int
-	Enabled      bool
+	Storage               *Storage
+	AllowedTypes          string
+	MaxSize               int64
+	MaxFiles              int
+	Enabled               bool
+	ResumableUploadExpiry time.Duration
 }
 
 var Attachment AttachmentSettingType
@@ -19,6 +22,8 @@ func loadAttachmentFrom(rootCfg ConfigProvider) (err error) {
 		MaxSize:      2048,
 		MaxFiles:     5,
 		Enabled:      true,
+
+		ResumableUploadExpiry: 24 * time.Hour,
 	}
 	sec, _ := rootCfg.GetSection("attachment")
 	if sec == nil {
@@ -30,6 +35,7 @@ func loadAttachmentFrom(rootCfg ConfigProvider) (err error) {
 	Attachment.MaxSize = sec.Key("MAX_SIZE").MustInt64(Attachment.MaxSize)
 	Attachment.MaxFiles = sec.Key("MAX_FILES").MustInt(Attachment.MaxFiles)
 	Attachment.Enabled = sec.Key("ENABLED").MustBool(Attachment.Enabled)
+	Attachment.ResumableUploadExpiry = sec.Key("RESUMABLE_UPLOAD_EXPIRY").MustDuration(Attachment.ResumableUploadExpiry)
 	Attachment.Storage, err = getStorage(rootCfg, "attachments", "", sec)
 	return err
 }
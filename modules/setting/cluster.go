@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"os"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/nosql"
+)
+
+// Cluster represents the configuration of the instances sharing the database and the storages
+var Cluster = struct {
+	LeaderElection string // none, db or redis
+	ConnStr        string
+	NodeName       string
+	LeaseDuration  time.Duration
+}{
+	LeaderElection: "none",
+	LeaseDuration:  30 * time.Second,
+}
+
+func loadClusterFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("cluster")
+	Cluster.LeaderElection = sec.Key("LEADER_ELECTION").MustString("none")
+	switch Cluster.LeaderElection {
+	case "none", "db":
+	case "redis":
+		Cluster.ConnStr = sec.Key("CONN_STR").MustString(GlobalLock.ServiceConnStr)
+		if Cluster.ConnStr == "" {
+			log.Fatal("CONN_STR is empty for redis leader election")
+		}
+		if nosql.ToRedisURI(Cluster.ConnStr) == nil {
+			log.Fatal("CONN_STR %s is not a valid redis connection string", Cluster.ConnStr)
+		}
+	default:
+		log.Fatal("Unknown leader election type: %s", Cluster.LeaderElection)
+	}
+
+	hostname, _ := os.Hostname()
+	Cluster.NodeName = sec.Key("NODE_NAME").MustString(hostname)
+	Cluster.LeaseDuration = sec.Key("LEASE_DURATION").MustDuration(30 * time.Second)
+	if Cluster.LeaseDuration < 3*time.Second {
+		log.Warn("[cluster] LEASE_DURATION %v is too short, use 3s instead", Cluster.LeaseDuration)
+		Cluster.LeaseDuration = 3 * time.Second
+	}
+}
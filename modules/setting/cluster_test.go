@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadClusterConfig(t *testing.T) {
+	defer test.MockVariableValue(&Cluster)()
+	defer test.MockVariableValue(&GlobalLock)()
+
+	cfg, err := NewConfigProviderFromData(``)
+	assert.NoError(t, err)
+	loadClusterFrom(cfg)
+	assert.Equal(t, "none", Cluster.LeaderElection)
+	assert.NotEmpty(t, Cluster.NodeName)
+	assert.Equal(t, 30*time.Second, Cluster.LeaseDuration)
+
+	// the redis leader election uses the connection of the global lock by default
+	cfg, err = NewConfigProviderFromData(`
+[global_lock]
+SERVICE_TYPE = redis
+SERVICE_CONN_STR = redis://127.0.0.1:6379/0
+[cluster]
+LEADER_ELECTION = redis
+NODE_NAME = node1
+LEASE_DURATION = 1m
+`)
+	assert.NoError(t, err)
+	loadGlobalLockFrom(cfg)
+	loadClusterFrom(cfg)
+	assert.Equal(t, "redis", Cluster.LeaderElection)
+	assert.Equal(t, "redis://127.0.0.1:6379/0", Cluster.ConnStr)
+	assert.Equal(t, "node1", Cluster.NodeName)
+	assert.Equal(t, time.Minute, Cluster.LeaseDuration)
+}
@@ -4,7 +4,9 @@
 package setting
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting/config"
@@ -52,9 +54,30 @@ type RepositoryStruct struct {
 	GitGuideRemoteName *config.Value[string]
 }
 
+// MaintenanceStruct is the read-only maintenance mode, which rejects the changes (pushes, issue writes, settings)
+// while the reads and the clones continue, eg: for the backups and the storage migrations
+type MaintenanceStruct struct {
+	ReadOnly *config.Value[bool]
+	Message  *config.Value[string]
+	// StartTime and EndTime (unix seconds) schedule a read-only window, the window has no end if EndTime is 0
+	StartTime *config.Value[int64]
+	EndTime   *config.Value[int64]
+}
+
+// IsReadOnly returns whether the instance is in the read-only maintenance mode now
+func (m *MaintenanceStruct) IsReadOnly(ctx context.Context) bool {
+	if m.ReadOnly.Value(ctx) {
+		return true
+	}
+	start, end := m.StartTime.Value(ctx), m.EndTime.Value(ctx)
+	now := time.Now().Unix()
+	return start > 0 && now >= start && (end <= 0 || now < end)
+}
+
 type ConfigStruct struct {
-	Picture    *PictureStruct
-	Repository *RepositoryStruct
+	Picture     *PictureStruct
+	Repository  *RepositoryStruct
+	Maintenance *MaintenanceStruct
 }
 
 var (
@@ -73,6 +96,12 @@ func initDefaultConfig() {
 			OpenWithEditorApps: config.ValueJSON[OpenWithEditorAppsType]("repository.open-with.editor-apps"),
 			GitGuideRemoteName: config.ValueJSON[string]("repository.git-guide-remote-name").WithDefault("origin"),
 		},
+		Maintenance: &MaintenanceStruct{
+			ReadOnly:  config.ValueJSON[bool]("maintenance.read_only"),
+			Message:   config.ValueJSON[string]("maintenance.message"),
+			StartTime: config.ValueJSON[int64]("maintenance.start_time"),
+			EndTime:   config.ValueJSON[int64]("maintenance.end_time"),
+		},
 	}
 }
 
@@ -13,23 +13,26 @@ import (
 )
 
 var IncomingEmail = struct {
-	Enabled              bool
-	ReplyToAddress       string
-	TokenPlaceholder     string `ini:"-"`
-	Host                 string
-	Port                 int
-	UseTLS               bool `ini:"USE_TLS"`
-	SkipTLSVerify        bool `ini:"SKIP_TLS_VERIFY"`
-	Username             string
-	Password             string
-	Mailbox              string
-	DeleteHandledMessage bool
-	MaximumMessageSize   uint32
+	Enabled                     bool
+	ReplyToAddress              string
+	TokenPlaceholder            string `ini:"-"`
+	Host                        string
+	Port                        int
+	UseTLS                      bool `ini:"USE_TLS"`
+	SkipTLSVerify               bool `ini:"SKIP_TLS_VERIFY"`
+	Username                    string
+	Password                    string
+	Mailbox                     string
+	DeleteHandledMessage        bool
+	MaximumMessageSize          uint32
+	RequireSenderAuthentication bool
+	AuthservID                  string `ini:"AUTHSERV_ID"`
 }{
-	Mailbox:              "INBOX",
-	DeleteHandledMessage: true,
-	TokenPlaceholder:     "%{token}",
-	MaximumMessageSize:   10485760,
+	Mailbox:                     "INBOX",
+	DeleteHandledMessage:        true,
+	TokenPlaceholder:            "%{token}",
+	MaximumMessageSize:          10485760,
+	RequireSenderAuthentication: true,
 }
 
 func loadIncomingEmailFrom(rootCfg ConfigProvider) {
@@ -42,6 +45,10 @@ func loadIncomingEmailFrom(rootCfg ConfigProvider) {
 	if err := checkReplyToAddress(); err != nil {
 		log.Fatal("Invalid incoming_mail.REPLY_TO_ADDRESS (%s): %v", IncomingEmail.ReplyToAddress, err)
 	}
+
+	if IncomingEmail.RequireSenderAuthentication && IncomingEmail.AuthservID == "" {
+		log.Warn("incoming_mail.AUTHSERV_ID is not set, no sender of an incoming email to a repository can be authenticated")
+	}
 }
 
 func checkReplyToAddress() error {
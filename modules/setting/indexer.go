@@ -26,13 +26,23 @@ var Indexer = struct {
 	RepoType             string
 	RepoPath             string
 	RepoConnStr          string
+	RepoConnAuth         string
 	RepoIndexerName      string
+	RepoZoektGitIndex    string
 	MaxIndexerFileSize   int64
 	IncludePatterns      []*GlobMatcher
 	ExcludePatterns      []*GlobMatcher
 	ExcludeVendored      bool
 
+	WikiIndexerEnabled bool
+	WikiPath           string
+	WikiIndexerName    string
+
+	SymbolIndexerEnabled bool
+	SymbolIndexerCtags   string
+
 	TypeBleveMaxFuzzniess int
+	TypeBleveCJKAnalyzer  string
 }{
 	IssueType:        "bleve",
 	IssuePath:        "indexers/issues.bleve",
@@ -45,9 +55,20 @@ var Indexer = struct {
 	RepoType:             "bleve",
 	RepoPath:             "indexers/repos.bleve",
 	RepoConnStr:          "",
+	RepoConnAuth:         "",
 	RepoIndexerName:      "gitea_codes",
+	RepoZoektGitIndex:    "zoekt-git-index",
 	MaxIndexerFileSize:   1024 * 1024,
 	ExcludeVendored:      true,
+
+	WikiIndexerEnabled: false,
+	WikiPath:           "indexers/wikis.bleve",
+	WikiIndexerName:    "gitea_wikis",
+
+	SymbolIndexerEnabled: false,
+	SymbolIndexerCtags:   "ctags",
+
+	TypeBleveCJKAnalyzer: "none",
 }
 
 func loadIndexerFrom(rootCfg ConfigProvider) {
@@ -62,14 +83,7 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	} else {
 		Indexer.IssueConnStr = sec.Key("ISSUE_INDEXER_CONN_STR").MustString(Indexer.IssueConnStr)
 		if Indexer.IssueType == "meilisearch" {
-			u, err := url.Parse(Indexer.IssueConnStr)
-			if err != nil {
-				log.Warn("Failed to parse ISSUE_INDEXER_CONN_STR: %v", err)
-				u = &url.URL{}
-			}
-			Indexer.IssueConnAuth, _ = u.User.Password()
-			u.User = nil
-			Indexer.IssueConnStr = u.String()
+			Indexer.IssueConnStr, Indexer.IssueConnAuth = parseMeilisearchConnStr("ISSUE_INDEXER_CONN_STR", Indexer.IssueConnStr)
 		}
 	}
 
@@ -78,19 +92,56 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.RepoIndexerEnabled = sec.Key("REPO_INDEXER_ENABLED").MustBool(false)
 	Indexer.RepoIndexerRepoTypes = strings.Split(sec.Key("REPO_INDEXER_REPO_TYPES").MustString("sources,forks,mirrors,templates"), ",")
 	Indexer.RepoType = sec.Key("REPO_INDEXER_TYPE").MustString("bleve")
-	Indexer.RepoPath = filepath.ToSlash(sec.Key("REPO_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/repos.bleve"))))
+	defaultRepoPath := "indexers/repos.bleve"
+	if Indexer.RepoType == "zoekt" {
+		defaultRepoPath = "indexers/repos.zoekt"
+	}
+	Indexer.RepoPath = filepath.ToSlash(sec.Key("REPO_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, defaultRepoPath))))
 	if !filepath.IsAbs(Indexer.RepoPath) {
 		Indexer.RepoPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.RepoPath))
 	}
 	Indexer.RepoConnStr = sec.Key("REPO_INDEXER_CONN_STR").MustString("")
+	if Indexer.RepoType == "meilisearch" {
+		Indexer.RepoConnStr, Indexer.RepoConnAuth = parseMeilisearchConnStr("REPO_INDEXER_CONN_STR", Indexer.RepoConnStr)
+	}
 	Indexer.RepoIndexerName = sec.Key("REPO_INDEXER_NAME").MustString("gitea_codes")
+	Indexer.RepoZoektGitIndex = sec.Key("REPO_INDEXER_ZOEKT_GIT_INDEX").MustString("zoekt-git-index")
 
 	Indexer.IncludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_INCLUDE").MustString(""))
 	Indexer.ExcludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_EXCLUDE").MustString(""))
 	Indexer.ExcludeVendored = sec.Key("REPO_INDEXER_EXCLUDE_VENDORED").MustBool(true)
 	Indexer.MaxIndexerFileSize = sec.Key("MAX_FILE_SIZE").MustInt64(1024 * 1024)
+
+	// the wiki indexer uses the same backend as the code indexer
+	Indexer.WikiIndexerEnabled = sec.Key("WIKI_INDEXER_ENABLED").MustBool(false)
+	if Indexer.WikiIndexerEnabled && Indexer.RepoType == "zoekt" {
+		log.Warn("The wiki indexer doesn't support the zoekt REPO_INDEXER_TYPE, it's disabled")
+		Indexer.WikiIndexerEnabled = false
+	}
+	Indexer.WikiPath = filepath.ToSlash(sec.Key("WIKI_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/wikis.bleve"))))
+	if !filepath.IsAbs(Indexer.WikiPath) {
+		Indexer.WikiPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.WikiPath))
+	}
+	Indexer.WikiIndexerName = sec.Key("WIKI_INDEXER_NAME").MustString("gitea_wikis")
+
+	Indexer.SymbolIndexerEnabled = sec.Key("SYMBOL_INDEXER_ENABLED").MustBool(false)
+	Indexer.SymbolIndexerCtags = sec.Key("SYMBOL_INDEXER_CTAGS").MustString("ctags")
+
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
 	Indexer.TypeBleveMaxFuzzniess = sec.Key("TYPE_BLEVE_MAX_FUZZINESS").MustInt(0)
+	Indexer.TypeBleveCJKAnalyzer = sec.Key("TYPE_BLEVE_CJK_ANALYZER").In("none", []string{"none", "bigram"})
+}
+
+// parseMeilisearchConnStr splits the API key, given as the password of the connection string, from the URL
+func parseMeilisearchConnStr(key, connStr string) (string, string) {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		log.Warn("Failed to parse %s: %v", key, err)
+		u = &url.URL{}
+	}
+	apiKey, _ := u.User.Password()
+	u.User = nil
+	return u.String(), apiKey
 }
 
 // IndexerGlobFromString parses a comma separated list of patterns and returns a glob.Glob slice suited for repo indexing
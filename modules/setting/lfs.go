@@ -23,7 +23,8 @@ var LFS = struct {
 	LocksPagingNum int           `ini:"LFS_LOCKS_PAGING_NUM"`
 	MaxBatchSize   int           `ini:"LFS_MAX_BATCH_SIZE"`
 
-	Storage *Storage
+	Storage  *Storage
+	ColdTier *StorageColdTier
 }{}
 
 // LFSClient represents configuration for Gitea's LFS clients, for example: mirroring upstream Git LFS
@@ -57,6 +58,9 @@ func loadLFSFrom(rootCfg ConfigProvider) error {
 	if err != nil {
 		return err
 	}
+	if LFS.ColdTier, err = getStorageColdTier(rootCfg, "lfs", lfsSec); err != nil {
+		return err
+	}
 
 	// Rest of LFS service settings
 	if LFS.LocksPagingNum == 0 {
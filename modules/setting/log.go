@@ -212,27 +212,36 @@ func initManagedLoggers(manager *log.LoggerManager, cfg ConfigProvider) {
 	initLoggerByName(manager, cfg, "xorm")
 }
 
-func initLoggerByName(manager *log.LoggerManager, rootCfg ConfigProvider, loggerName string) {
+// loggerModeNames returns the names of the modes (writers) of the logger, it returns nil if the logger is disabled
+func loggerModeNames(rootCfg ConfigProvider, loggerName string) (modeNames []string) {
 	sec := rootCfg.Section("log")
 	keyPrefix := "logger." + loggerName
 
 	disabled := sec.HasKey(keyPrefix+".MODE") && sec.Key(keyPrefix+".MODE").String() == ""
 	if disabled {
-		return
+		return nil
 	}
 
 	modeVal := sec.Key(keyPrefix + ".MODE").String()
 	if modeVal == "," {
 		modeVal = Log.Mode
 	}
+	for modeName := range strings.SplitSeq(modeVal, ",") {
+		if modeName = strings.TrimSpace(modeName); modeName != "" {
+			modeNames = append(modeNames, modeName)
+		}
+	}
+	return modeNames
+}
+
+func initLoggerByName(manager *log.LoggerManager, rootCfg ConfigProvider, loggerName string) {
+	modeNames := loggerModeNames(rootCfg, loggerName)
+	if modeNames == nil {
+		return
+	}
 
 	var eventWriters []log.EventWriter
-	modes := strings.SplitSeq(modeVal, ",")
-	for modeName := range modes {
-		modeName = strings.TrimSpace(modeName)
-		if modeName == "" {
-			continue
-		}
+	for _, modeName := range modeNames {
 		writerName, writerType, writerMode, err := loadLogModeByName(rootCfg, loggerName, modeName)
 		if err != nil {
 			log.FallbackErrorf("Failed to load writer mode %q for logger %s: %v", modeName, loggerName, err)
@@ -255,6 +264,27 @@ func initLoggerByName(manager *log.LoggerManager, rootCfg ConfigProvider, logger
 	manager.GetLogger(loggerName).ReplaceAllWriters(eventWriters...)
 }
 
+// reloadLogLevelsFrom changes the levels of the existing log writers, the other log settings are not reloaded
+func reloadLogLevelsFrom(manager *log.LoggerManager, rootCfg ConfigProvider) {
+	sec := rootCfg.Section("log")
+	Log.Level = log.LevelFromString(sec.Key("LEVEL").MustString(log.INFO.String()))
+	Log.StacktraceLogLevel = log.LevelFromString(sec.Key("STACKTRACE_LEVEL").MustString(log.NONE.String()))
+	prepareLoggerConfig(rootCfg)
+
+	levels := map[string]log.Level{}
+	for _, loggerName := range []string{log.DEFAULT, "access", "router", "xorm"} {
+		for _, modeName := range loggerModeNames(rootCfg, loggerName) {
+			writerName, _, writerMode, err := loadLogModeByName(rootCfg, loggerName, modeName)
+			if err != nil {
+				log.Error("Failed to load writer mode %q for logger %s: %v", modeName, loggerName, err)
+				continue
+			}
+			levels[writerName] = writerMode.Level
+		}
+	}
+	manager.SetWriterLevels(levels)
+}
+
 func InitSQLLoggersForCli(level log.Level) {
 	log.SetConsoleLogger("xorm", "console", level)
 }
@@ -5,9 +5,11 @@ package setting
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/mail"
 	"strings"
+	"sync/atomic"
 	"text/template"
 	"time"
 
@@ -56,8 +58,18 @@ type Mailer struct {
 	FromDisplayNameFormatTemplate *template.Template `ini:"-"`
 }
 
-// MailService the global mailer
-var MailService *Mailer
+var currentMailService atomic.Pointer[Mailer]
+
+// GetMailService returns the global mailer, it is nil if the mailer is not enabled.
+// The mailer can be replaced by reloading the settings, so the callers shouldn't keep it.
+func GetMailService() *Mailer {
+	return currentMailService.Load()
+}
+
+// SetMailService replaces the global mailer
+func SetMailService(m *Mailer) {
+	currentMailService.Store(m)
+}
 
 func loadMailsFrom(rootCfg ConfigProvider) {
 	loadMailerFrom(rootCfg)
@@ -67,10 +79,21 @@ func loadMailsFrom(rootCfg ConfigProvider) {
 }
 
 func loadMailerFrom(rootCfg ConfigProvider) {
+	mailService, err := parseMailerFrom(rootCfg)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+	if mailService != nil {
+		SetMailService(mailService)
+	}
+}
+
+// parseMailerFrom parses the [mailer] section, it returns nil if the mailer is not enabled
+func parseMailerFrom(rootCfg ConfigProvider) (*Mailer, error) {
 	sec := rootCfg.Section("mailer")
 	// Check mailer setting.
 	if !sec.Key("ENABLED").MustBool() {
-		return
+		return nil, nil
 	}
 
 	// Handle Deprecations and map on to new configuration
@@ -90,7 +113,7 @@ func loadMailerFrom(rootCfg ConfigProvider) {
 		if err != nil && strings.Contains(err.Error(), "missing port in address") {
 			addr = givenHost
 		} else if err != nil {
-			log.Fatal("Invalid mailer.HOST (%s): %v", givenHost, err)
+			return nil, fmt.Errorf("invalid mailer.HOST (%s): %w", givenHost, err)
 		}
 		if addr == "" {
 			addr = "127.0.0.1"
@@ -155,46 +178,46 @@ func loadMailerFrom(rootCfg ConfigProvider) {
 	sec.Key("FROM").MustString(sec.Key("USER").String())
 
 	// Now map the values on to the MailService
-	MailService = &Mailer{}
-	if err := sec.MapTo(MailService); err != nil {
-		log.Fatal("Unable to map [mailer] section on to MailService. Error: %v", err)
+	mailService := &Mailer{}
+	if err := sec.MapTo(mailService); err != nil {
+		return nil, fmt.Errorf("unable to map [mailer] section on to MailService: %w", err)
 	}
 
 	overrideHeader := rootCfg.Section("mailer.override_header").Keys()
-	MailService.OverrideHeader = make(map[string][]string)
+	mailService.OverrideHeader = make(map[string][]string)
 	for _, key := range overrideHeader {
-		MailService.OverrideHeader[key.Name()] = key.Strings(",")
+		mailService.OverrideHeader[key.Name()] = key.Strings(",")
 	}
 
 	// Infer SMTPPort if not set
-	if MailService.SMTPPort == "" {
-		switch MailService.Protocol {
+	if mailService.SMTPPort == "" {
+		switch mailService.Protocol {
 		case "smtp":
-			MailService.SMTPPort = "25"
+			mailService.SMTPPort = "25"
 		case "smtps":
-			MailService.SMTPPort = "465"
+			mailService.SMTPPort = "465"
 		case "smtp+starttls":
-			MailService.SMTPPort = "587"
+			mailService.SMTPPort = "587"
 		}
 	}
 
 	// Infer Protocol
-	if MailService.Protocol == "" {
-		if strings.ContainsAny(MailService.SMTPAddr, "/\\") {
-			MailService.Protocol = "smtp+unix"
+	if mailService.Protocol == "" {
+		if strings.ContainsAny(mailService.SMTPAddr, "/\\") {
+			mailService.Protocol = "smtp+unix"
 		} else {
-			switch MailService.SMTPPort {
+			switch mailService.SMTPPort {
 			case "25":
-				MailService.Protocol = "smtp"
+				mailService.Protocol = "smtp"
 			case "465":
-				MailService.Protocol = "smtps"
+				mailService.Protocol = "smtps"
 			case "587":
-				MailService.Protocol = "smtp+starttls"
+				mailService.Protocol = "smtp+starttls"
 			default:
-				log.Error("unable to infer unspecified mailer.PROTOCOL from mailer.SMTP_PORT = %q, assume using smtps", MailService.SMTPPort)
-				MailService.Protocol = "smtps"
-				if MailService.SMTPPort == "" {
-					MailService.SMTPPort = "465"
+				log.Error("unable to infer unspecified mailer.PROTOCOL from mailer.SMTP_PORT = %q, assume using smtps", mailService.SMTPPort)
+				mailService.Protocol = "smtps"
+				if mailService.SMTPPort == "" {
+					mailService.SMTPPort = "465"
 				}
 			}
 		}
@@ -203,16 +226,16 @@ func loadMailerFrom(rootCfg ConfigProvider) {
 	// we want to warn if users use SMTP on a non-local IP;
 	// we might as well take the opportunity to check that it has an IP at all
 	// This check is not needed for sendmail
-	switch MailService.Protocol {
+	switch mailService.Protocol {
 	case "sendmail":
 		var err error
-		MailService.SendmailArgs, err = shellquote.Split(sec.Key("SENDMAIL_ARGS").String())
+		mailService.SendmailArgs, err = shellquote.Split(sec.Key("SENDMAIL_ARGS").String())
 		if err != nil {
 			log.Error("Failed to parse Sendmail args: '%s' with error %v", sec.Key("SENDMAIL_ARGS").String(), err)
 		}
 	case "smtp", "smtps", "smtp+starttls", "smtp+unix":
-		ips := tryResolveAddr(MailService.SMTPAddr)
-		if MailService.Protocol == "smtp" {
+		ips := tryResolveAddr(mailService.SMTPAddr)
+		if mailService.Protocol == "smtp" {
 			for _, ip := range ips {
 				if !ip.IP.IsLoopback() {
 					log.Warn("connecting over insecure SMTP protocol to non-local address is not recommended")
@@ -223,47 +246,48 @@ func loadMailerFrom(rootCfg ConfigProvider) {
 	case "dummy": // just mention and do nothing
 	}
 
-	if MailService.From != "" {
-		parsed, err := mail.ParseAddress(MailService.From)
+	if mailService.From != "" {
+		parsed, err := mail.ParseAddress(mailService.From)
 		if err != nil {
-			log.Fatal("Invalid mailer.FROM (%s): %v", MailService.From, err)
+			return nil, fmt.Errorf("invalid mailer.FROM (%s): %w", mailService.From, err)
 		}
-		MailService.FromName = parsed.Name
-		MailService.FromEmail = parsed.Address
+		mailService.FromName = parsed.Name
+		mailService.FromEmail = parsed.Address
 	} else {
 		log.Error("no mailer.FROM provided, email system may not work.")
 	}
 
-	MailService.FromDisplayNameFormatTemplate, _ = template.New("mailFrom").Parse("{{ .DisplayName }}")
-	if MailService.FromDisplayNameFormat != "" {
-		template, err := template.New("mailFrom").Parse(MailService.FromDisplayNameFormat)
+	mailService.FromDisplayNameFormatTemplate, _ = template.New("mailFrom").Parse("{{ .DisplayName }}")
+	if mailService.FromDisplayNameFormat != "" {
+		template, err := template.New("mailFrom").Parse(mailService.FromDisplayNameFormat)
 		if err != nil {
 			log.Error("mailer.FROM_DISPLAY_NAME_FORMAT is no valid template: %v", err)
 		} else {
-			MailService.FromDisplayNameFormatTemplate = template
+			mailService.FromDisplayNameFormatTemplate = template
 		}
 	}
 
-	switch MailService.EnvelopeFrom {
+	switch mailService.EnvelopeFrom {
 	case "":
-		MailService.OverrideEnvelopeFrom = false
+		mailService.OverrideEnvelopeFrom = false
 	case "<>":
-		MailService.EnvelopeFrom = ""
-		MailService.OverrideEnvelopeFrom = true
+		mailService.EnvelopeFrom = ""
+		mailService.OverrideEnvelopeFrom = true
 	default:
-		parsed, err := mail.ParseAddress(MailService.EnvelopeFrom)
+		parsed, err := mail.ParseAddress(mailService.EnvelopeFrom)
 		if err != nil {
-			log.Fatal("Invalid mailer.ENVELOPE_FROM (%s): %v", MailService.EnvelopeFrom, err)
+			return nil, fmt.Errorf("invalid mailer.ENVELOPE_FROM (%s): %w", mailService.EnvelopeFrom, err)
 		}
-		MailService.OverrideEnvelopeFrom = true
-		MailService.EnvelopeFrom = parsed.Address
+		mailService.OverrideEnvelopeFrom = true
+		mailService.EnvelopeFrom = parsed.Address
 	}
+	return mailService, nil
 }
 
 func loadRegisterMailFrom(rootCfg ConfigProvider) {
 	if !rootCfg.Section("service").Key("REGISTER_EMAIL_CONFIRM").MustBool() {
 		return
-	} else if MailService == nil {
+	} else if GetMailService() == nil {
 		log.Warn("Register Mail Service: Mail Service is not enabled")
 		return
 	}
@@ -273,7 +297,7 @@ func loadRegisterMailFrom(rootCfg ConfigProvider) {
 func loadNotifyMailFrom(rootCfg ConfigProvider) {
 	if !rootCfg.Section("service").Key("ENABLE_NOTIFY_MAIL").MustBool() {
 		return
-	} else if MailService == nil {
+	} else if GetMailService() == nil {
 		log.Warn("Notify Mail Service: Mail Service is not enabled")
 		return
 	}
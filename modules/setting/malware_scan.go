@@ -0,0 +1,77 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Malware scanners
+const (
+	MalwareScannerClamAV = "clamav"
+	MalwareScannerHTTP   = "http"
+)
+
+// MalwareScan settings
+var MalwareScan = struct {
+	Enabled bool
+	// Scanner is "clamav" to talk to a clamd daemon or "http" to post the files to a scanning service
+	Scanner string
+	// ClamAVAddress is the address of the clamd daemon, "tcp://host:port" or "unix:///path/to/clamd.sock"
+	ClamAVAddress string
+	// HTTPURL is the endpoint of the scanning service
+	HTTPURL string
+	// HTTPToken is sent as bearer token to the scanning service
+	HTTPToken string
+	Timeout   time.Duration
+	// MaxSize is the size in bytes above which files are not scanned (0 means no limit)
+	MaxSize int64
+	// AllowOnError accepts the upload if the scanner is unavailable, otherwise the upload fails
+	AllowOnError    bool
+	ScanAttachments bool
+	ScanAvatars     bool
+}{
+	Scanner:         MalwareScannerClamAV,
+	ClamAVAddress:   "tcp://localhost:3310",
+	Timeout:         time.Minute,
+	ScanAttachments: true,
+	ScanAvatars:     true,
+}
+
+func loadMalwareScanFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("malware_scan")
+	MalwareScan.Enabled = sec.Key("ENABLED").MustBool(false)
+	MalwareScan.Scanner = sec.Key("SCANNER").In(MalwareScannerClamAV, []string{MalwareScannerClamAV, MalwareScannerHTTP})
+	MalwareScan.ClamAVAddress = sec.Key("CLAMAV_ADDRESS").MustString("tcp://localhost:3310")
+	MalwareScan.HTTPURL = sec.Key("HTTP_URL").String()
+	MalwareScan.HTTPToken = sec.Key("HTTP_TOKEN").String()
+	MalwareScan.Timeout = sec.Key("TIMEOUT").MustDuration(time.Minute)
+	MalwareScan.MaxSize = sec.Key("MAX_SIZE").MustInt64(0)
+	MalwareScan.AllowOnError = sec.Key("ALLOW_ON_ERROR").MustBool(false)
+	MalwareScan.ScanAttachments = sec.Key("SCAN_ATTACHMENTS").MustBool(true)
+	MalwareScan.ScanAvatars = sec.Key("SCAN_AVATARS").MustBool(true)
+
+	if !MalwareScan.Enabled {
+		return nil
+	}
+	if MalwareScan.Timeout <= 0 {
+		MalwareScan.Timeout = time.Minute
+	}
+
+	switch MalwareScan.Scanner {
+	case MalwareScannerClamAV:
+		u, err := url.Parse(MalwareScan.ClamAVAddress)
+		if err != nil || (u.Scheme != "tcp" && u.Scheme != "unix") {
+			return fmt.Errorf("invalid [malware_scan] CLAMAV_ADDRESS %q", MalwareScan.ClamAVAddress)
+		}
+	case MalwareScannerHTTP:
+		u, err := url.Parse(MalwareScan.HTTPURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid [malware_scan] HTTP_URL %q", MalwareScan.HTTPURL)
+		}
+	}
+	return nil
+}
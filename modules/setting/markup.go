@@ -5,7 +5,9 @@ package setting
 
 import (
 	"regexp"
+	"runtime"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
@@ -18,12 +20,29 @@ var (
 	MermaidMaxSourceCharacters int
 )
 
+// MarkupPlantUML represents the configuration of the PlantUML diagram renderer
+var MarkupPlantUML = struct {
+	ServerURL           string
+	RenderCommand       string
+	SecurityProfile     string
+	MaxSourceCharacters int
+}{
+	SecurityProfile:     "SANDBOX",
+	MaxSourceCharacters: 50000,
+}
+
 const (
 	RenderContentModeSanitized   = "sanitized"
 	RenderContentModeNoSanitizer = "no-sanitizer"
 	RenderContentModeIframe      = "iframe"
 )
 
+const (
+	RenderSandboxNone      = "none"      // run the command with the privileges of Gitea
+	RenderSandboxNamespace = "namespace" // run the command in new Linux namespaces without network access
+	RenderSandboxCommand   = "command"   // run the command by the configured sandbox command, e.g. a container runtime
+)
+
 type MarkdownRenderOptions struct {
 	NewLineHardBreak  bool
 	ShortIssuePattern bool // Actually it is a "markup" option because it is used in "post processor"
@@ -63,6 +82,14 @@ type MarkupRenderer struct {
 	NeedPostProcess      bool
 	MarkupSanitizerRules []MarkupSanitizerRule
 	RenderContentMode    string
+
+	Sandbox        string
+	SandboxCommand string
+	RunAsUser      string
+	Timeout        time.Duration
+	MaxMemory      int64 // in bytes, -1 means no limit
+	MaxCPUTime     time.Duration
+	MaxOutputSize  int64 // in bytes, -1 means no limit
 }
 
 // MarkupSanitizerRule defines the policy for whitelisting attributes on
@@ -128,6 +155,10 @@ func loadMarkupFrom(rootCfg ConfigProvider) {
 	}
 
 	MermaidMaxSourceCharacters = rootCfg.Section("markup").Key("MERMAID_MAX_SOURCE_CHARACTERS").MustInt(50000)
+	MarkupPlantUML.ServerURL = strings.TrimSuffix(rootCfg.Section("markup").Key("PLANTUML_SERVER_URL").String(), "/")
+	MarkupPlantUML.RenderCommand = rootCfg.Section("markup").Key("PLANTUML_RENDER_COMMAND").String()
+	MarkupPlantUML.SecurityProfile = rootCfg.Section("markup").Key("PLANTUML_SECURITY_PROFILE").In("SANDBOX", []string{"SANDBOX", "ALLOWLIST", "INTERNET"})
+	MarkupPlantUML.MaxSourceCharacters = rootCfg.Section("markup").Key("PLANTUML_MAX_SOURCE_CHARACTERS").MustInt(50000)
 	ExternalMarkupRenderers = make([]*MarkupRenderer, 0, 10)
 	ExternalSanitizerRules = make([]MarkupSanitizerRule, 0, 10)
 
@@ -253,6 +284,23 @@ func newMarkupRenderer(name string, sec ConfigSection) {
 		renderContentMode = RenderContentModeSanitized
 	}
 
+	sandbox := sec.Key("SANDBOX").In(RenderSandboxNone, []string{RenderSandboxNone, RenderSandboxNamespace, RenderSandboxCommand})
+	sandboxCommand := sec.Key("SANDBOX_COMMAND").String()
+	if sandbox == RenderSandboxNamespace && runtime.GOOS != "linux" {
+		log.Fatal("In %s: SANDBOX = %s is only supported on Linux", sec.Name(), RenderSandboxNamespace)
+	}
+	if sandbox == RenderSandboxCommand && sandboxCommand == "" {
+		log.Fatal("In %s: SANDBOX_COMMAND is required for SANDBOX = %s", sec.Name(), RenderSandboxCommand)
+	}
+	runAsUser := sec.Key("RUN_AS_USER").String()
+	if runAsUser != "" && runtime.GOOS != "linux" {
+		log.Fatal("In %s: RUN_AS_USER is only supported on Linux", sec.Name())
+	}
+	maxMemory, maxCPUTime := mustBytes(sec, "MAX_MEMORY"), sec.Key("MAX_CPU_TIME").MustDuration(0)
+	if (maxMemory >= 0 || maxCPUTime > 0) && runtime.GOOS != "linux" {
+		log.Warn("In %s: MAX_MEMORY and MAX_CPU_TIME are only supported on Linux and are ignored", sec.Name())
+	}
+
 	ExternalMarkupRenderers = append(ExternalMarkupRenderers, &MarkupRenderer{
 		Enabled:           sec.Key("ENABLED").MustBool(false),
 		MarkupName:        name,
@@ -261,5 +309,13 @@ func newMarkupRenderer(name string, sec ConfigSection) {
 		IsInputFile:       sec.Key("IS_INPUT_FILE").MustBool(false),
 		NeedPostProcess:   sec.Key("NEED_POSTPROCESS").MustBool(true),
 		RenderContentMode: renderContentMode,
+
+		Sandbox:        sandbox,
+		SandboxCommand: sandboxCommand,
+		RunAsUser:      runAsUser,
+		Timeout:        sec.Key("TIMEOUT").MustDuration(0),
+		MaxMemory:      maxMemory,
+		MaxCPUTime:     maxCPUTime,
+		MaxOutputSize:  mustBytes(sec, "MAX_OUTPUT_SIZE"),
 	})
 }
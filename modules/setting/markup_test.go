@@ -5,6 +5,7 @@ package setting
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -48,4 +49,35 @@ RENDER_OPTIONS_REPO_FILE = short-issue-pattern, new-line-hard-break
 		loadMarkupFrom(cfg)
 		assert.Equal(t, MarkdownRenderOptions{NewLineHardBreak: true, ShortIssuePattern: true}, Markdown.RenderOptionsRepoFile)
 	})
+
+	t.Run("ExternalRendererSandbox", func(t *testing.T) {
+		cfg, _ = NewConfigProviderFromData(`
+[markup.asciidoc]
+ENABLED = true
+FILE_EXTENSIONS = .adoc
+RENDER_COMMAND = asciidoc -
+[markup.restructuredtext]
+ENABLED = true
+FILE_EXTENSIONS = .rst
+RENDER_COMMAND = rst2html
+SANDBOX = command
+SANDBOX_COMMAND = docker run --rm -i rst
+TIMEOUT = 30s
+MAX_OUTPUT_SIZE = 1 MiB
+`)
+		loadMarkupFrom(cfg)
+		if assert.Len(t, ExternalMarkupRenderers, 2) {
+			r := ExternalMarkupRenderers[0]
+			assert.Equal(t, RenderSandboxNone, r.Sandbox)
+			assert.EqualValues(t, 0, r.Timeout)
+			assert.EqualValues(t, -1, r.MaxMemory)
+			assert.EqualValues(t, -1, r.MaxOutputSize)
+
+			r = ExternalMarkupRenderers[1]
+			assert.Equal(t, RenderSandboxCommand, r.Sandbox)
+			assert.Equal(t, "docker run --rm -i rst", r.SandboxCommand)
+			assert.Equal(t, 30*time.Second, r.Timeout)
+			assert.EqualValues(t, 1024*1024, r.MaxOutputSize)
+		}
+	})
 }
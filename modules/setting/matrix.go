@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Matrix settings of the account sending the direct messages of the Matrix notifications
+var Matrix = struct {
+	Enabled       bool
+	HomeserverURL string `ini:"HOMESERVER_URL"`
+	// AccessToken is the access token of the Matrix account of the instance
+	AccessToken    string
+	DeliverTimeout time.Duration
+}{
+	DeliverTimeout: 10 * time.Second,
+}
+
+func loadMatrixFrom(rootCfg ConfigProvider) error {
+	mustMapSetting(rootCfg, "matrix", &Matrix)
+	if !Matrix.Enabled {
+		return nil
+	}
+	Matrix.HomeserverURL = strings.TrimSuffix(Matrix.HomeserverURL, "/")
+	if Matrix.HomeserverURL == "" || Matrix.AccessToken == "" {
+		return errors.New(`matrix notifications require "HOMESERVER_URL" and "ACCESS_TOKEN"`)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import "strings"
+
+// Moderation settings
+var Moderation = struct {
+	Enabled bool
+	// Moderators are the names of the users who may handle the reports in addition to the administrators
+	Moderators []string
+}{}
+
+func loadModerationFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("moderation")
+	Moderation.Enabled = sec.Key("ENABLED").MustBool(false)
+	Moderation.Moderators = nil
+	for _, name := range sec.Key("MODERATORS").Strings(",") {
+		Moderation.Moderators = append(Moderation.Moderators, strings.ToLower(name))
+	}
+}
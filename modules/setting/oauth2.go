@@ -50,8 +50,8 @@ func (accountLinking OAuth2AccountLinkingType) isValid() bool {
 	return false
 }
 
-// OAuth2Client settings
-var OAuth2Client struct {
+// OAuth2ClientSettings are the settings of the OAuth2 client, they can be changed by reloading the settings
+type OAuth2ClientSettings struct {
 	RegisterEmailConfirm   bool
 	OpenIDConnectScopes    []string
 	EnableAutoRegistration bool
@@ -60,22 +60,39 @@ var OAuth2Client struct {
 	AccountLinking         OAuth2AccountLinkingType
 }
 
+var oauth2Client atomic.Pointer[OAuth2ClientSettings]
+
+// GetOAuth2Client returns the current settings of the OAuth2 client
+func GetOAuth2Client() *OAuth2ClientSettings {
+	if client := oauth2Client.Load(); client != nil {
+		return client
+	}
+	return &OAuth2ClientSettings{}
+}
+
+// SetOAuth2Client replaces the settings of the OAuth2 client
+func SetOAuth2Client(client *OAuth2ClientSettings) {
+	oauth2Client.Store(client)
+}
+
 func loadOAuth2ClientFrom(rootCfg ConfigProvider) {
 	sec := rootCfg.Section("oauth2_client")
-	OAuth2Client.RegisterEmailConfirm = sec.Key("REGISTER_EMAIL_CONFIRM").MustBool(Service.RegisterEmailConfirm)
-	OAuth2Client.OpenIDConnectScopes = parseScopes(sec, "OPENID_CONNECT_SCOPES")
-	OAuth2Client.EnableAutoRegistration = sec.Key("ENABLE_AUTO_REGISTRATION").MustBool()
-	OAuth2Client.Username = OAuth2UsernameType(sec.Key("USERNAME").MustString(string(OAuth2UsernameNickname)))
-	if !OAuth2Client.Username.isValid() {
-		OAuth2Client.Username = OAuth2UsernameNickname
-		log.Warn("[oauth2_client].USERNAME setting is invalid, falls back to %q", OAuth2Client.Username)
+	client := &OAuth2ClientSettings{}
+	client.RegisterEmailConfirm = sec.Key("REGISTER_EMAIL_CONFIRM").MustBool(Service.RegisterEmailConfirm)
+	client.OpenIDConnectScopes = parseScopes(sec, "OPENID_CONNECT_SCOPES")
+	client.EnableAutoRegistration = sec.Key("ENABLE_AUTO_REGISTRATION").MustBool()
+	client.Username = OAuth2UsernameType(sec.Key("USERNAME").MustString(string(OAuth2UsernameNickname)))
+	if !client.Username.isValid() {
+		client.Username = OAuth2UsernameNickname
+		log.Warn("[oauth2_client].USERNAME setting is invalid, falls back to %q", client.Username)
 	}
-	OAuth2Client.UpdateAvatar = sec.Key("UPDATE_AVATAR").MustBool()
-	OAuth2Client.AccountLinking = OAuth2AccountLinkingType(sec.Key("ACCOUNT_LINKING").MustString(string(OAuth2AccountLinkingLogin)))
-	if !OAuth2Client.AccountLinking.isValid() {
-		log.Warn("Account linking setting is not valid: '%s', will fallback to '%s'", OAuth2Client.AccountLinking, OAuth2AccountLinkingLogin)
-		OAuth2Client.AccountLinking = OAuth2AccountLinkingLogin
+	client.UpdateAvatar = sec.Key("UPDATE_AVATAR").MustBool()
+	client.AccountLinking = OAuth2AccountLinkingType(sec.Key("ACCOUNT_LINKING").MustString(string(OAuth2AccountLinkingLogin)))
+	if !client.AccountLinking.isValid() {
+		log.Warn("Account linking setting is not valid: '%s', will fallback to '%s'", client.AccountLinking, OAuth2AccountLinkingLogin)
+		client.AccountLinking = OAuth2AccountLinkingLogin
 	}
+	SetOAuth2Client(client)
 }
 
 func parseScopes(sec ConfigSection, name string) []string {
@@ -6,10 +6,51 @@ package setting
 import (
 	"fmt"
 	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/container"
 
 	"github.com/dustin/go-humanize"
 )
 
+// PackageProxyTypes are the package types which can fetch missing packages from an upstream registry
+var PackageProxyTypes = []string{"container", "npm", "pypi"}
+
+// PackageProxy describes the upstream registry of a package registry type
+type PackageProxy struct {
+	Upstream string
+	Username string
+	Password string
+	// Owners are the lower names of the owners whose registry proxies the upstream registry
+	Owners container.Set[string]
+	// CacheTTL is how long the package metadata (versions, tags) fetched from the upstream is cached, the files are stored as packages
+	CacheTTL time.Duration
+	// MaxSize is the maximum size of a file fetched from the upstream (-1 means no limits)
+	MaxSize int64
+	// AllowedLicenses are the glob patterns of the licenses a fetched package must match, no patterns allow all licenses
+	AllowedLicenses []string
+}
+
+// PackageScan describes the vulnerability scanning of package versions
+type PackageScan struct {
+	Enabled bool
+	// Command is the Trivy compatible scanner executable
+	Command string
+	// Types are the package types which are scanned, empty means all types
+	Types container.Set[string]
+	// OnUpload scans package versions when they are created, otherwise they are only scanned by the cron task
+	OnUpload bool
+	Timeout  time.Duration
+	// BlockSeverity is the lowest severity of a finding which blocks the download of the version, empty means downloads are never blocked
+	BlockSeverity string
+}
+
+// PackageScanSeverities are the severities reported by the scanner, ordered from low to high
+var PackageScanSeverities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
 // Package registry settings
 var (
 	Packages = struct {
@@ -31,7 +72,9 @@ var (
 		LimitSizeGeneric     int64
 		LimitSizeGo          int64
 		LimitSizeHelm        int64
+		LimitSizeHomebrew    int64
 		LimitSizeMaven       int64
+		LimitSizeNix         int64
 		LimitSizeNpm         int64
 		LimitSizeNuGet       int64
 		LimitSizePub         int64
@@ -39,9 +82,15 @@ var (
 		LimitSizeRpm         int64
 		LimitSizeRubyGems    int64
 		LimitSizeSwift       int64
+		LimitSizeTerraform   int64
 		LimitSizeVagrant     int64
 
 		DefaultRPMSignEnabled bool
+
+		// Proxies are the upstream registries of the registry types which fetch missing packages on demand, keyed by the package type
+		Proxies map[string]*PackageProxy `ini:"-"`
+
+		Scan PackageScan `ini:"-"`
 	}{
 		Enabled:              true,
 		LimitTotalOwnerCount: -1,
@@ -52,7 +101,13 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	sec, _ := rootCfg.GetSection("packages")
 	if sec == nil {
 		Packages.Storage, err = getStorage(rootCfg, "packages", "", nil)
-		return err
+		if err != nil {
+			return err
+		}
+		if err := loadPackageScanFrom(rootCfg); err != nil {
+			return err
+		}
+		return loadPackageProxiesFrom(rootCfg)
 	}
 
 	if err = sec.MapTo(&Packages); err != nil {
@@ -78,7 +133,9 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHomebrew = mustBytes(sec, "LIMIT_SIZE_HOMEBREW")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
+	Packages.LimitSizeNix = mustBytes(sec, "LIMIT_SIZE_NIX")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
 	Packages.LimitSizePub = mustBytes(sec, "LIMIT_SIZE_PUB")
@@ -86,8 +143,65 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeRpm = mustBytes(sec, "LIMIT_SIZE_RPM")
 	Packages.LimitSizeRubyGems = mustBytes(sec, "LIMIT_SIZE_RUBYGEMS")
 	Packages.LimitSizeSwift = mustBytes(sec, "LIMIT_SIZE_SWIFT")
+	Packages.LimitSizeTerraform = mustBytes(sec, "LIMIT_SIZE_TERRAFORM")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
+	if err := loadPackageScanFrom(rootCfg); err != nil {
+		return err
+	}
+	return loadPackageProxiesFrom(rootCfg)
+}
+
+func loadPackageScanFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("packages.scan")
+	Packages.Scan = PackageScan{
+		Enabled:       sec.Key("ENABLED").MustBool(false),
+		Command:       sec.Key("COMMAND").MustString("trivy"),
+		Types:         container.SetOf(sec.Key("TYPES").Strings(",")...),
+		OnUpload:      sec.Key("SCAN_ON_UPLOAD").MustBool(true),
+		Timeout:       sec.Key("TIMEOUT").MustDuration(10 * time.Minute),
+		BlockSeverity: strings.ToUpper(sec.Key("BLOCK_SEVERITY").String()),
+	}
+	if Packages.Scan.Timeout <= 0 {
+		Packages.Scan.Timeout = 10 * time.Minute
+	}
+	if Packages.Scan.BlockSeverity != "" && !slices.Contains(PackageScanSeverities, Packages.Scan.BlockSeverity) {
+		return fmt.Errorf("invalid [packages.scan] BLOCK_SEVERITY %q", Packages.Scan.BlockSeverity)
+	}
+	return nil
+}
+
+func loadPackageProxiesFrom(rootCfg ConfigProvider) error {
+	Packages.Proxies = make(map[string]*PackageProxy)
+	for _, packageType := range PackageProxyTypes {
+		sec, _ := rootCfg.GetSection("packages.proxy." + packageType)
+		if sec == nil {
+			continue
+		}
+		upstream := strings.TrimSuffix(sec.Key("UPSTREAM").String(), "/")
+		if upstream == "" {
+			continue
+		}
+		if u, err := url.Parse(upstream); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid [packages.proxy.%s] UPSTREAM %q", packageType, upstream)
+		}
+		owners := container.Set[string]{}
+		for _, owner := range sec.Key("OWNERS").Strings(",") {
+			owners.Add(strings.ToLower(owner))
+		}
+		if len(owners) == 0 {
+			return fmt.Errorf("[packages.proxy.%s] OWNERS must not be empty", packageType)
+		}
+		Packages.Proxies[packageType] = &PackageProxy{
+			Upstream:        upstream,
+			Username:        sec.Key("USERNAME").String(),
+			Password:        sec.Key("PASSWORD").String(),
+			Owners:          owners,
+			CacheTTL:        sec.Key("CACHE_TTL").MustDuration(30 * time.Minute),
+			MaxSize:         mustBytes(sec, "MAX_SIZE"),
+			AllowedLicenses: sec.Key("ALLOWED_LICENSES").Strings(","),
+		}
+	}
 	return nil
 }
 
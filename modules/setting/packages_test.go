@@ -5,6 +5,7 @@ package setting
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -196,3 +197,70 @@ MINIO_SECRET_ACCESS_KEY = correct_key
 	assert.Equal(t, "my_packages/", storage.MinioConfig.BasePath)
 	assert.True(t, storage.MinioConfig.ServeDirect)
 }
+
+func TestLoadPackageProxies(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[packages.proxy.npm]
+UPSTREAM = https://registry.npmjs.org/
+OWNERS = Mirror, other
+CACHE_TTL = 10m
+MAX_SIZE = 1 mib
+ALLOWED_LICENSES = MIT, Apache-*
+[packages.proxy.pypi]
+OWNERS = mirror
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadPackagesFrom(cfg))
+
+	assert.Len(t, Packages.Proxies, 1)
+	npm := Packages.Proxies["npm"]
+	assert.Equal(t, "https://registry.npmjs.org", npm.Upstream)
+	assert.True(t, npm.Owners.Contains("mirror"))
+	assert.True(t, npm.Owners.Contains("other"))
+	assert.Equal(t, 10*time.Minute, npm.CacheTTL)
+	assert.EqualValues(t, 1048576, npm.MaxSize)
+	assert.Equal(t, []string{"MIT", "Apache-*"}, npm.AllowedLicenses)
+
+	cfg, err = NewConfigProviderFromData(`
+[packages.proxy.npm]
+UPSTREAM = https://registry.npmjs.org
+`)
+	assert.NoError(t, err)
+	assert.Error(t, loadPackagesFrom(cfg))
+
+	cfg, err = NewConfigProviderFromData(`
+[packages.proxy.container]
+UPSTREAM = registry-1.docker.io
+OWNERS = mirror
+`)
+	assert.NoError(t, err)
+	assert.Error(t, loadPackagesFrom(cfg))
+}
+
+func TestLoadPackageScan(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[packages.scan]
+ENABLED = true
+COMMAND = /usr/bin/trivy
+TYPES = container, npm
+SCAN_ON_UPLOAD = false
+BLOCK_SEVERITY = high
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadPackagesFrom(cfg))
+
+	assert.True(t, Packages.Scan.Enabled)
+	assert.Equal(t, "/usr/bin/trivy", Packages.Scan.Command)
+	assert.True(t, Packages.Scan.Types.Contains("npm"))
+	assert.False(t, Packages.Scan.Types.Contains("pypi"))
+	assert.False(t, Packages.Scan.OnUpload)
+	assert.Equal(t, 10*time.Minute, Packages.Scan.Timeout)
+	assert.Equal(t, "HIGH", Packages.Scan.BlockSeverity)
+
+	cfg, err = NewConfigProviderFromData(`
+[packages.scan]
+BLOCK_SEVERITY = severe
+`)
+	assert.NoError(t, err)
+	assert.Error(t, loadPackagesFrom(cfg))
+}
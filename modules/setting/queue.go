@@ -17,7 +17,7 @@ type QueueSettings struct {
 
 	Type    string
 	Datadir string
-	ConnStr string // for leveldb or redis
+	ConnStr string // for leveldb, redis, nats or kafka
 	Length  int    // max queue length before blocking
 
 	QueueName, SetName string // the name suffix for storage (db key, redis key), "set" is for unique queue
@@ -28,7 +28,7 @@ type QueueSettings struct {
 
 func GetQueueSettings(rootCfg ConfigProvider, name string) (QueueSettings, error) {
 	queueSettingsDefault := QueueSettings{
-		Type:    "level",         // dummy, channel, level, redis
+		Type:    "level",         // dummy, channel, level, redis, nats, kafka
 		Datadir: "queues/common", // relative to AppDataPath
 		Length:  100000,          // queue length before a channel queue will block
 
@@ -80,6 +80,12 @@ func GetQueueSettings(rootCfg ConfigProvider, name string) (QueueSettings, error
 	if cfg.Type == "redis" && cfg.ConnStr == "" {
 		cfg.ConnStr = "redis://127.0.0.1:6379/0"
 	}
+	if cfg.Type == "nats" && cfg.ConnStr == "" {
+		cfg.ConnStr = "nats://127.0.0.1:4222"
+	}
+	if cfg.Type == "kafka" && cfg.ConnStr == "" {
+		cfg.ConnStr = "kafka://127.0.0.1:9092"
+	}
 
 	if cfg.Length <= 0 {
 		cfg.Length = queueSettingsDefault.Length
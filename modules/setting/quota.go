@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// Quota settings
+var Quota = struct {
+	Enabled bool
+
+	// The default limits of an owner without an override, a negative value means no limit
+	DefaultTotalSize       int64
+	DefaultGitSize         int64
+	DefaultLFSSize         int64
+	DefaultPackagesSize    int64
+	DefaultAttachmentsSize int64
+	DefaultArtifactsSize   int64
+}{
+	Enabled:                false,
+	DefaultTotalSize:       -1,
+	DefaultGitSize:         -1,
+	DefaultLFSSize:         -1,
+	DefaultPackagesSize:    -1,
+	DefaultAttachmentsSize: -1,
+	DefaultArtifactsSize:   -1,
+}
+
+func loadQuotaFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("quota")
+	Quota.Enabled = sec.Key("ENABLED").MustBool(false)
+	Quota.DefaultTotalSize = mustBytes(sec, "DEFAULT_TOTAL_SIZE")
+	Quota.DefaultGitSize = mustBytes(sec, "DEFAULT_GIT_SIZE")
+	Quota.DefaultLFSSize = mustBytes(sec, "DEFAULT_LFS_SIZE")
+	Quota.DefaultPackagesSize = mustBytes(sec, "DEFAULT_PACKAGES_SIZE")
+	Quota.DefaultAttachmentsSize = mustBytes(sec, "DEFAULT_ATTACHMENTS_SIZE")
+	Quota.DefaultArtifactsSize = mustBytes(sec, "DEFAULT_ARTIFACTS_SIZE")
+}
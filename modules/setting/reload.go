@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+// ReloadSettings reads the config file again and reloads the settings which can be changed without restarting:
+// the levels of the log writers, the mailer, the allowed hosts of the webhooks, the OAuth2 client options
+// and the limits of the API rate limit. The other settings keep their values until the next restart.
+// Nothing is changed if the config file is invalid.
+func ReloadSettings() error {
+	cfg, err := NewConfigProviderFromFile(CustomConf)
+	if err != nil {
+		return fmt.Errorf("unable to load config file %q: %w", CustomConf, err)
+	}
+	cfg.DisableSaving()
+
+	// parse the settings which could be invalid before changing anything
+	mailService, err := parseMailerFrom(cfg)
+	if err != nil {
+		return err
+	}
+	rateLimitRules, err := parseAPIRateLimitRulesFrom(cfg.Section("api.rate_limit"))
+	if err != nil {
+		return err
+	}
+
+	// the requests in progress read the settings concurrently, so the new values are published
+	// atomically and every reader sees either the old or the new settings
+	reloadLogLevelsFrom(log.GetManager(), cfg)
+	SetMailService(mailService)
+	SetWebhookAllowedHostList(cfg.Section("webhook").Key("ALLOWED_HOST_LIST").MustString(""))
+	loadOAuth2ClientFrom(cfg)
+	SetAPIRateLimitRules(rateLimitRules)
+
+	log.Info("Settings reloaded from %q", CustomConf)
+	return nil
+}
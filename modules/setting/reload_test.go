@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadSettings(t *testing.T) {
+	defer SetMailService(GetMailService())
+	defer SetWebhookAllowedHostList(GetWebhookAllowedHostList())
+	defer SetOAuth2Client(GetOAuth2Client())
+	defer SetAPIRateLimitRules(GetAPIRateLimitRules())
+	defer test.MockVariableValue(&Log)()
+
+	customConf := filepath.Join(t.TempDir(), "app.ini")
+	defer test.MockVariableValue(&CustomConf, customConf)()
+
+	require.NoError(t, os.WriteFile(customConf, []byte(`
+[mailer]
+ENABLED = true
+PROTOCOL = dummy
+FROM = gitea@example.com
+[webhook]
+ALLOWED_HOST_LIST = *.example.com
+[oauth2_client]
+ENABLE_AUTO_REGISTRATION = true
+[api.rate_limit]
+ANONYMOUS_LIMIT = 10
+[api.rate_limit.search]
+PATHS = /repos/search
+`), 0o644))
+	require.NoError(t, ReloadSettings())
+	assert.Equal(t, "dummy", GetMailService().Protocol)
+	assert.Equal(t, "gitea@example.com", GetMailService().FromEmail)
+	assert.Equal(t, "*.example.com", GetWebhookAllowedHostList())
+	assert.True(t, GetOAuth2Client().EnableAutoRegistration)
+	assert.Equal(t, 10, GetAPIRateLimitRules().Default.AnonymousLimit)
+	assert.Equal(t, "search", GetAPIRateLimitRule("GET", "/repos/search").Name)
+
+	// nothing is changed if the config file is invalid
+	require.NoError(t, os.WriteFile(customConf, []byte(`
+[mailer]
+ENABLED = true
+FROM = invalid <
+[webhook]
+ALLOWED_HOST_LIST = *
+`), 0o644))
+	assert.Error(t, ReloadSettings())
+	assert.Equal(t, "gitea@example.com", GetMailService().FromEmail)
+	assert.Equal(t, "*.example.com", GetWebhookAllowedHostList())
+
+	// the mailer is disabled if it's not enabled anymore
+	require.NoError(t, os.WriteFile(customConf, []byte(``), 0o644))
+	require.NoError(t, ReloadSettings())
+	assert.Nil(t, GetMailService())
+	assert.Empty(t, GetWebhookAllowedHostList())
+	assert.Equal(t, 60, GetAPIRateLimitRules().Default.AnonymousLimit)
+	assert.Empty(t, GetAPIRateLimitRules().Rules)
+}
@@ -6,7 +6,8 @@ package setting
 import "fmt"
 
 var RepoArchive = struct {
-	Storage *Storage
+	Storage  *Storage
+	ColdTier *StorageColdTier
 }{}
 
 func loadRepoArchiveFrom(rootCfg ConfigProvider) (err error) {
@@ -20,6 +21,9 @@ func loadRepoArchiveFrom(rootCfg ConfigProvider) (err error) {
 		return fmt.Errorf("mapto repoarchive failed: %v", err)
 	}
 
-	RepoArchive.Storage, err = getStorage(rootCfg, "repo-archive", "", sec)
+	if RepoArchive.Storage, err = getStorage(rootCfg, "repo-archive", "", sec); err != nil {
+		return err
+	}
+	RepoArchive.ColdTier, err = getStorageColdTier(rootCfg, "repo-archive", sec)
 	return err
 }
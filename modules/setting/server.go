@@ -113,6 +113,7 @@ var (
 	SSLCipherSuites            []string
 	GracefulRestartable        bool
 	GracefulHammerTime         time.Duration
+	ReloadConfigOnSIGHUP       bool
 	StartupTimeout             time.Duration
 	PerWriteTimeout            = 30 * time.Second
 	PerWritePerKbTimeout       = 10 * time.Second
@@ -283,6 +284,7 @@ func loadServerFrom(rootCfg ConfigProvider) {
 	ProxyProtocolAcceptUnknown = sec.Key("PROXY_PROTOCOL_ACCEPT_UNKNOWN").MustBool(false)
 	GracefulRestartable = sec.Key("ALLOW_GRACEFUL_RESTARTS").MustBool(true)
 	GracefulHammerTime = sec.Key("GRACEFUL_HAMMER_TIME").MustDuration(60 * time.Second)
+	ReloadConfigOnSIGHUP = sec.Key("RELOAD_CONFIG_ON_SIGHUP").MustBool(false)
 	StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(0 * time.Second)
 	PerWriteTimeout = sec.Key("PER_WRITE_TIMEOUT").MustDuration(PerWriteTimeout)
 	PerWritePerKbTimeout = sec.Key("PER_WRITE_PER_KB_TIMEOUT").MustDuration(PerWritePerKbTimeout)
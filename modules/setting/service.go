@@ -21,6 +21,7 @@ const (
 	HCaptcha     = "hcaptcha"
 	MCaptcha     = "mcaptcha"
 	CfTurnstile  = "cfturnstile"
+	Geetest      = "geetest"
 )
 
 // Service settings
@@ -58,6 +59,11 @@ var Service = struct {
 	RequireExternalRegistrationCaptcha      bool
 	RequireExternalRegistrationPassword     bool
 	CaptchaType                             string
+	RegisterCaptchaType                     string
+	LoginCaptchaType                        string
+	LoginCaptchaFailedAttempts              int
+	IssueCaptchaType                        string
+	IssueCaptchaAccountAge                  time.Duration
 	RecaptchaSecret                         string
 	RecaptchaSitekey                        string
 	RecaptchaURL                            string
@@ -68,6 +74,9 @@ var Service = struct {
 	McaptchaSecret                          string
 	McaptchaSitekey                         string
 	McaptchaURL                             string
+	GeetestCaptchaID                        string
+	GeetestCaptchaKey                       string
+	GeetestURL                              string
 	DefaultKeepEmailPrivate                 bool
 	DefaultAllowCreateOrganization          bool
 	DefaultUserIsRestricted                 bool
@@ -83,6 +92,7 @@ var Service = struct {
 	AutoWatchOnChanges                      bool
 	DefaultOrgMemberVisible                 bool
 	UserDeleteWithCommentsMaxTime           time.Duration
+	TermsAcceptanceGracePeriod              time.Duration
 	ValidSiteURLSchemes                     []string
 
 	// OpenID settings
@@ -192,6 +202,11 @@ func loadServiceFrom(rootCfg ConfigProvider) {
 	Service.RequireExternalRegistrationCaptcha = sec.Key("REQUIRE_EXTERNAL_REGISTRATION_CAPTCHA").MustBool(Service.EnableCaptcha)
 	Service.RequireExternalRegistrationPassword = sec.Key("REQUIRE_EXTERNAL_REGISTRATION_PASSWORD").MustBool()
 	Service.CaptchaType = sec.Key("CAPTCHA_TYPE").MustString(ImageCaptcha)
+	Service.RegisterCaptchaType = sec.Key("REGISTER_CAPTCHA_TYPE").MustString(Service.CaptchaType)
+	Service.LoginCaptchaType = sec.Key("LOGIN_CAPTCHA_TYPE").MustString(Service.CaptchaType)
+	Service.LoginCaptchaFailedAttempts = sec.Key("LOGIN_CAPTCHA_FAILED_ATTEMPTS").MustInt(0)
+	Service.IssueCaptchaType = sec.Key("ISSUE_CAPTCHA_TYPE").MustString(Service.CaptchaType)
+	Service.IssueCaptchaAccountAge = sec.Key("ISSUE_CAPTCHA_ACCOUNT_AGE").MustDuration(0)
 	Service.RecaptchaSecret = sec.Key("RECAPTCHA_SECRET").MustString("")
 	Service.RecaptchaSitekey = sec.Key("RECAPTCHA_SITEKEY").MustString("")
 	Service.RecaptchaURL = sec.Key("RECAPTCHA_URL").MustString("https://www.google.com/recaptcha/")
@@ -202,6 +217,9 @@ func loadServiceFrom(rootCfg ConfigProvider) {
 	Service.McaptchaURL = sec.Key("MCAPTCHA_URL").MustString("https://demo.mcaptcha.org/")
 	Service.McaptchaSecret = sec.Key("MCAPTCHA_SECRET").MustString("")
 	Service.McaptchaSitekey = sec.Key("MCAPTCHA_SITEKEY").MustString("")
+	Service.GeetestURL = sec.Key("GEETEST_URL").MustString("https://gcaptcha4.geetest.com/")
+	Service.GeetestCaptchaID = sec.Key("GEETEST_CAPTCHA_ID").MustString("")
+	Service.GeetestCaptchaKey = sec.Key("GEETEST_CAPTCHA_KEY").MustString("")
 	Service.DefaultKeepEmailPrivate = sec.Key("DEFAULT_KEEP_EMAIL_PRIVATE").MustBool()
 	Service.DefaultAllowCreateOrganization = sec.Key("DEFAULT_ALLOW_CREATE_ORGANIZATION").MustBool(true)
 	Service.DefaultUserIsRestricted = sec.Key("DEFAULT_USER_IS_RESTRICTED").MustBool(false)
@@ -248,6 +266,7 @@ func loadServiceFrom(rootCfg ConfigProvider) {
 	Service.DefaultOrgVisibilityMode = structs.VisibilityModes[Service.DefaultOrgVisibility]
 	Service.DefaultOrgMemberVisible = sec.Key("DEFAULT_ORG_MEMBER_VISIBLE").MustBool()
 	Service.UserDeleteWithCommentsMaxTime = sec.Key("USER_DELETE_WITH_COMMENTS_MAX_TIME").MustDuration(0)
+	Service.TermsAcceptanceGracePeriod = sec.Key("TERMS_ACCEPTANCE_GRACE_PERIOD").MustDuration(7 * 24 * time.Hour)
 	sec.Key("VALID_SITE_URL_SCHEMES").MustString("http,https")
 	Service.ValidSiteURLSchemes = sec.Key("VALID_SITE_URL_SCHEMES").Strings(",")
 	schemes := make([]string, 0, len(Service.ValidSiteURLSchemes))
@@ -135,19 +135,35 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	if err := loadPackagesFrom(cfg); err != nil {
 		return err
 	}
+	if err := loadMalwareScanFrom(cfg); err != nil {
+		return err
+	}
+	if err := loadSpamDetectionFrom(cfg); err != nil {
+		return err
+	}
+	loadModerationFrom(cfg)
 	if err := loadActionsFrom(cfg); err != nil {
 		return err
 	}
+	loadQuotaFrom(cfg)
 	loadUIFrom(cfg)
 	loadAdminFrom(cfg)
 	loadAPIFrom(cfg)
+	loadAPIRateLimitFrom(cfg)
 	loadMetricsFrom(cfg)
 	loadCamoFrom(cfg)
+	if err := loadWebPushFrom(cfg); err != nil {
+		return err
+	}
+	if err := loadMatrixFrom(cfg); err != nil {
+		return err
+	}
 	loadI18nFrom(cfg)
 	loadGitFrom(cfg)
 	loadMirrorFrom(cfg)
 	loadMarkupFrom(cfg)
 	loadGlobalLockFrom(cfg)
+	loadClusterFrom(cfg)
 	loadOtherFrom(cfg)
 	return nil
 }
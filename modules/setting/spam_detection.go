@@ -0,0 +1,73 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SpamDetection settings
+var SpamDetection = struct {
+	Enabled bool
+	// NewAccountAge is the age below which the issues and comments of an account are checked
+	NewAccountAge time.Duration
+	// MinAccountAge is the age below which all issues and comments of an account are held (0 disables the rule)
+	MinAccountAge time.Duration
+	// MaxLinkDensity is the percentage of the words being links above which the content is held (0 disables the rule)
+	MaxLinkDensity int
+	// MinLinks is the number of links below which the link density isn't checked
+	MinLinks int
+	// DuplicateThreshold is the number of times the same content may be posted within DuplicateWindow before it is
+	// held (0 disables the rule)
+	DuplicateThreshold int
+	DuplicateWindow    time.Duration
+	// ClassifierURL is the endpoint of an external classifier, the content is only checked by the built-in rules if
+	// it is empty
+	ClassifierURL string
+	// ClassifierToken is sent as bearer token to the classifier
+	ClassifierToken   string
+	ClassifierTimeout time.Duration
+	// AllowOnError publishes the content if the classifier fails, otherwise it is held
+	AllowOnError bool
+}{
+	NewAccountAge:      72 * time.Hour,
+	MinAccountAge:      10 * time.Minute,
+	MaxLinkDensity:     30,
+	MinLinks:           3,
+	DuplicateThreshold: 3,
+	DuplicateWindow:    24 * time.Hour,
+	ClassifierTimeout:  10 * time.Second,
+	AllowOnError:       true,
+}
+
+func loadSpamDetectionFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("spam_detection")
+	SpamDetection.Enabled = sec.Key("ENABLED").MustBool(false)
+	SpamDetection.NewAccountAge = sec.Key("NEW_ACCOUNT_AGE").MustDuration(72 * time.Hour)
+	SpamDetection.MinAccountAge = sec.Key("MIN_ACCOUNT_AGE").MustDuration(10 * time.Minute)
+	SpamDetection.MaxLinkDensity = sec.Key("MAX_LINK_DENSITY").MustInt(30)
+	SpamDetection.MinLinks = sec.Key("MIN_LINKS").MustInt(3)
+	SpamDetection.DuplicateThreshold = sec.Key("DUPLICATE_THRESHOLD").MustInt(3)
+	SpamDetection.DuplicateWindow = sec.Key("DUPLICATE_WINDOW").MustDuration(24 * time.Hour)
+	SpamDetection.ClassifierURL = sec.Key("CLASSIFIER_URL").String()
+	SpamDetection.ClassifierToken = sec.Key("CLASSIFIER_TOKEN").String()
+	SpamDetection.ClassifierTimeout = sec.Key("CLASSIFIER_TIMEOUT").MustDuration(10 * time.Second)
+	SpamDetection.AllowOnError = sec.Key("ALLOW_ON_ERROR").MustBool(true)
+
+	if !SpamDetection.Enabled {
+		return nil
+	}
+	if SpamDetection.ClassifierTimeout <= 0 {
+		SpamDetection.ClassifierTimeout = 10 * time.Second
+	}
+	if SpamDetection.ClassifierURL != "" {
+		u, err := url.Parse(SpamDetection.ClassifierURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid [spam_detection] CLASSIFIER_URL %q", SpamDetection.ClassifierURL)
+		}
+	}
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
 )
 
 // StorageType is a type of Storage
@@ -21,12 +24,15 @@ const (
 	MinioStorageType StorageType = "minio"
 	// AzureBlobStorageType is the type descriptor for azure blob storage
 	AzureBlobStorageType StorageType = "azureblob"
+	// GCSStorageType is the type descriptor for google cloud storage
+	GCSStorageType StorageType = "gcs"
 )
 
 var storageTypes = []StorageType{
 	LocalStorageType,
 	MinioStorageType,
 	AzureBlobStorageType,
+	GCSStorageType,
 }
 
 // IsValidStorageType returns true if the given storage type is valid
@@ -36,18 +42,19 @@ func IsValidStorageType(storageType StorageType) bool {
 
 // MinioStorageConfig represents the configuration for a minio storage
 type MinioStorageConfig struct {
-	Endpoint           string `ini:"MINIO_ENDPOINT" json:",omitempty"`
-	AccessKeyID        string `ini:"MINIO_ACCESS_KEY_ID" json:",omitempty"`
-	SecretAccessKey    string `ini:"MINIO_SECRET_ACCESS_KEY" json:",omitempty"`
-	IamEndpoint        string `ini:"MINIO_IAM_ENDPOINT" json:",omitempty"`
-	Bucket             string `ini:"MINIO_BUCKET" json:",omitempty"`
-	Location           string `ini:"MINIO_LOCATION" json:",omitempty"`
-	BasePath           string `ini:"MINIO_BASE_PATH" json:",omitempty"`
-	UseSSL             bool   `ini:"MINIO_USE_SSL"`
-	InsecureSkipVerify bool   `ini:"MINIO_INSECURE_SKIP_VERIFY"`
-	ChecksumAlgorithm  string `ini:"MINIO_CHECKSUM_ALGORITHM" json:",omitempty"`
-	ServeDirect        bool   `ini:"SERVE_DIRECT"`
-	BucketLookUpType   string `ini:"MINIO_BUCKET_LOOKUP_TYPE" json:",omitempty"`
+	Endpoint           string        `ini:"MINIO_ENDPOINT" json:",omitempty"`
+	AccessKeyID        string        `ini:"MINIO_ACCESS_KEY_ID" json:",omitempty"`
+	SecretAccessKey    string        `ini:"MINIO_SECRET_ACCESS_KEY" json:",omitempty"`
+	IamEndpoint        string        `ini:"MINIO_IAM_ENDPOINT" json:",omitempty"`
+	Bucket             string        `ini:"MINIO_BUCKET" json:",omitempty"`
+	Location           string        `ini:"MINIO_LOCATION" json:",omitempty"`
+	BasePath           string        `ini:"MINIO_BASE_PATH" json:",omitempty"`
+	UseSSL             bool          `ini:"MINIO_USE_SSL"`
+	InsecureSkipVerify bool          `ini:"MINIO_INSECURE_SKIP_VERIFY"`
+	ChecksumAlgorithm  string        `ini:"MINIO_CHECKSUM_ALGORITHM" json:",omitempty"`
+	ServeDirect        bool          `ini:"SERVE_DIRECT"`
+	ServeDirectExpiry  time.Duration `ini:"SERVE_DIRECT_URL_EXPIRY"`
+	BucketLookUpType   string        `ini:"MINIO_BUCKET_LOOKUP_TYPE" json:",omitempty"`
 }
 
 func (cfg *MinioStorageConfig) ToShadow() {
@@ -61,12 +68,16 @@ func (cfg *MinioStorageConfig) ToShadow() {
 
 // MinioStorageConfig represents the configuration for a minio storage
 type AzureBlobStorageConfig struct {
-	Endpoint    string `ini:"AZURE_BLOB_ENDPOINT" json:",omitempty"`
-	AccountName string `ini:"AZURE_BLOB_ACCOUNT_NAME" json:",omitempty"`
-	AccountKey  string `ini:"AZURE_BLOB_ACCOUNT_KEY" json:",omitempty"`
-	Container   string `ini:"AZURE_BLOB_CONTAINER" json:",omitempty"`
-	BasePath    string `ini:"AZURE_BLOB_BASE_PATH" json:",omitempty"`
-	ServeDirect bool   `ini:"SERVE_DIRECT"`
+	Endpoint                string        `ini:"AZURE_BLOB_ENDPOINT" json:",omitempty"`
+	AccountName             string        `ini:"AZURE_BLOB_ACCOUNT_NAME" json:",omitempty"`
+	AccountKey              string        `ini:"AZURE_BLOB_ACCOUNT_KEY" json:",omitempty"`
+	SASToken                string        `ini:"AZURE_BLOB_SAS_TOKEN" json:",omitempty"`
+	ManagedIdentityClientID string        `ini:"AZURE_BLOB_MANAGED_IDENTITY_CLIENT_ID" json:",omitempty"`
+	IdentityEndpoint        string        `ini:"AZURE_BLOB_IDENTITY_ENDPOINT" json:",omitempty"`
+	Container               string        `ini:"AZURE_BLOB_CONTAINER" json:",omitempty"`
+	BasePath                string        `ini:"AZURE_BLOB_BASE_PATH" json:",omitempty"`
+	ServeDirect             bool          `ini:"SERVE_DIRECT"`
+	ServeDirectExpiry       time.Duration `ini:"SERVE_DIRECT_URL_EXPIRY"`
 }
 
 func (cfg *AzureBlobStorageConfig) ToShadow() {
@@ -76,15 +87,31 @@ func (cfg *AzureBlobStorageConfig) ToShadow() {
 	if cfg.AccountName != "" {
 		cfg.AccountName = "******"
 	}
+	if cfg.SASToken != "" {
+		cfg.SASToken = "******"
+	}
+}
+
+// GCSStorageConfig represents the configuration for a google cloud storage
+type GCSStorageConfig struct {
+	Endpoint          string        `ini:"GCS_ENDPOINT" json:",omitempty"`
+	Bucket            string        `ini:"GCS_BUCKET" json:",omitempty"`
+	BasePath          string        `ini:"GCS_BASE_PATH" json:",omitempty"`
+	CredentialsFile   string        `ini:"GCS_CREDENTIALS_FILE" json:",omitempty"`
+	ServiceAccount    string        `ini:"GCS_SERVICE_ACCOUNT" json:",omitempty"`
+	KMSKeyName        string        `ini:"GCS_KMS_KEY_NAME" json:",omitempty"`
+	ServeDirect       bool          `ini:"SERVE_DIRECT"`
+	ServeDirectExpiry time.Duration `ini:"SERVE_DIRECT_URL_EXPIRY"`
 }
 
 // Storage represents configuration of storages
 type Storage struct {
-	Type            StorageType            // local or minio or azureblob
+	Type            StorageType            // local or minio or azureblob or gcs
 	Path            string                 `json:",omitempty"` // for local type
 	TemporaryPath   string                 `json:",omitempty"`
 	MinioConfig     MinioStorageConfig     // for minio type
 	AzureBlobConfig AzureBlobStorageConfig // for azureblob type
+	GCSConfig       GCSStorageConfig       // for gcs type
 }
 
 func (storage *Storage) ToShadowCopy() Storage {
@@ -96,7 +123,57 @@ func (storage *Storage) ToShadowCopy() Storage {
 
 func (storage *Storage) ServeDirect() bool {
 	return (storage.Type == MinioStorageType && storage.MinioConfig.ServeDirect) ||
-		(storage.Type == AzureBlobStorageType && storage.AzureBlobConfig.ServeDirect)
+		(storage.Type == AzureBlobStorageType && storage.AzureBlobConfig.ServeDirect) ||
+		(storage.Type == GCSStorageType && storage.GCSConfig.ServeDirect)
+}
+
+// StorageColdTier represents the secondary storage the objects which haven't been accessed for a while are moved to
+type StorageColdTier struct {
+	Storage   *Storage
+	AfterDays int
+}
+
+// getStorageColdTier reads the cold tier from COLD_STORAGE_TYPE of the section, it returns nil if there is none.
+// The cold storage is configured like the other storages with the name "<name>-cold".
+func getStorageColdTier(rootCfg ConfigProvider, name string, sec ConfigSection) (*StorageColdTier, error) {
+	if sec == nil {
+		return nil, nil
+	}
+	typ := sec.Key("COLD_STORAGE_TYPE").String()
+	if typ == "" {
+		return nil, nil
+	}
+
+	tier := &StorageColdTier{AfterDays: sec.Key("COLD_STORAGE_AFTER_DAYS").MustInt(30)}
+	if tier.AfterDays < 1 {
+		return nil, fmt.Errorf("[%s].COLD_STORAGE_AFTER_DAYS must be at least 1", sec.Name())
+	}
+	var err error
+	if tier.Storage, err = getStorage(rootCfg, name+"-cold", typ, nil); err != nil {
+		return nil, fmt.Errorf("cold storage of %s: %w", name, err)
+	}
+	if IsValidStorageType(StorageType(typ)) && tier.Storage.Type != StorageType(typ) {
+		// the storage falls back to the [storage] section whose type differs
+		return nil, fmt.Errorf("cold storage of %s has type %q instead of %q, configure it in [storage.%s-cold]", name, tier.Storage.Type, typ, name)
+	}
+	return tier, nil
+}
+
+// defaultServeDirectExpiry is the lifetime of the signed URLs used by SERVE_DIRECT if none is configured
+const defaultServeDirectExpiry = 5 * time.Minute
+
+// getServeDirectExpiry reads SERVE_DIRECT_URL_EXPIRY from the override section, falling back to the target section value
+func getServeDirectExpiry(overrideSec ConfigSection, current time.Duration) (time.Duration, error) {
+	if overrideSec != nil && overrideSec.HasKey("SERVE_DIRECT_URL_EXPIRY") {
+		current = overrideSec.Key("SERVE_DIRECT_URL_EXPIRY").MustDuration(current)
+	}
+	if current == 0 {
+		return defaultServeDirectExpiry, nil
+	}
+	if current < time.Second || current > 7*24*time.Hour {
+		return 0, fmt.Errorf("invalid SERVE_DIRECT_URL_EXPIRY %q, it must be between 1s and 168h", current)
+	}
+	return current, nil
 }
 
 const storageSectionName = "storage"
@@ -117,7 +194,10 @@ func getDefaultStorageSection(rootCfg ConfigProvider) ConfigSection {
 	storageSec.Key("AZURE_BLOB_ENDPOINT").MustString("")
 	storageSec.Key("AZURE_BLOB_ACCOUNT_NAME").MustString("")
 	storageSec.Key("AZURE_BLOB_ACCOUNT_KEY").MustString("")
+	storageSec.Key("AZURE_BLOB_SAS_TOKEN").MustString("")
 	storageSec.Key("AZURE_BLOB_CONTAINER").MustString("gitea")
+	storageSec.Key("GCS_ENDPOINT").MustString("https://storage.googleapis.com")
+	storageSec.Key("GCS_BUCKET").MustString("gitea")
 	return storageSec
 }
 
@@ -143,6 +223,8 @@ func getStorage(rootCfg ConfigProvider, name, typ string, sec ConfigSection) (*S
 		return getStorageForMinio(targetSec, overrideSec, tp, name)
 	case string(AzureBlobStorageType):
 		return getStorageForAzureBlob(targetSec, overrideSec, tp, name)
+	case string(GCSStorageType):
+		return getStorageForGCS(targetSec, overrideSec, tp, name)
 	default:
 		return nil, fmt.Errorf("unsupported storage type %q", targetType)
 	}
@@ -309,6 +391,11 @@ func getStorageForMinio(targetSec, overrideSec ConfigSection, tp targetSecType,
 	} else {
 		storage.MinioConfig.BasePath = defaultPath
 	}
+
+	var err error
+	if storage.MinioConfig.ServeDirectExpiry, err = getServeDirectExpiry(overrideSec, storage.MinioConfig.ServeDirectExpiry); err != nil {
+		return nil, err
+	}
 	return &storage, nil
 }
 
@@ -338,5 +425,57 @@ func getStorageForAzureBlob(targetSec, overrideSec ConfigSection, tp targetSecTy
 	} else {
 		storage.AzureBlobConfig.BasePath = defaultPath
 	}
+
+	if storage.AzureBlobConfig.Endpoint == "" && storage.AzureBlobConfig.AccountName != "" {
+		storage.AzureBlobConfig.Endpoint = "https://" + storage.AzureBlobConfig.AccountName + ".blob.core.windows.net/"
+	}
+	storage.AzureBlobConfig.SASToken = strings.TrimPrefix(storage.AzureBlobConfig.SASToken, "?")
+	if storage.AzureBlobConfig.ServeDirect && storage.AzureBlobConfig.AccountKey == "" && storage.AzureBlobConfig.SASToken != "" {
+		// the signed URLs would expose the SAS token, which may be allowed to write
+		log.Warn("SERVE_DIRECT of storage %q is disabled since Azure Blob can't sign URLs with a SAS token", name)
+		storage.AzureBlobConfig.ServeDirect = false
+	}
+
+	var err error
+	if storage.AzureBlobConfig.ServeDirectExpiry, err = getServeDirectExpiry(overrideSec, storage.AzureBlobConfig.ServeDirectExpiry); err != nil {
+		return nil, err
+	}
+	return &storage, nil
+}
+
+func getStorageForGCS(targetSec, overrideSec ConfigSection, tp targetSecType, name string) (*Storage, error) { //nolint:dupl // duplicates minio setup
+	var storage Storage
+	storage.Type = StorageType(targetSec.Key("STORAGE_TYPE").String())
+	if err := targetSec.MapTo(&storage.GCSConfig); err != nil {
+		return nil, fmt.Errorf("map gcs config failed: %v", err)
+	}
+
+	var defaultPath string
+	if storage.GCSConfig.BasePath != "" {
+		if tp == targetSecIsStorage || tp == targetSecIsDefault {
+			defaultPath = strings.TrimSuffix(storage.GCSConfig.BasePath, "/") + "/" + name + "/"
+		} else {
+			defaultPath = storage.GCSConfig.BasePath
+		}
+	}
+	if defaultPath == "" {
+		defaultPath = name + "/"
+	}
+
+	if overrideSec != nil {
+		storage.GCSConfig.ServeDirect = ConfigSectionKeyBool(overrideSec, "SERVE_DIRECT", storage.GCSConfig.ServeDirect)
+		storage.GCSConfig.BasePath = ConfigSectionKeyString(overrideSec, "GCS_BASE_PATH", defaultPath)
+		storage.GCSConfig.Bucket = ConfigSectionKeyString(overrideSec, "GCS_BUCKET", storage.GCSConfig.Bucket)
+	} else {
+		storage.GCSConfig.BasePath = defaultPath
+	}
+	if storage.GCSConfig.Endpoint == "" {
+		storage.GCSConfig.Endpoint = "https://storage.googleapis.com"
+	}
+
+	var err error
+	if storage.GCSConfig.ServeDirectExpiry, err = getServeDirectExpiry(overrideSec, storage.GCSConfig.ServeDirectExpiry); err != nil {
+		return nil, err
+	}
 	return &storage, nil
 }
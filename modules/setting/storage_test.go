@@ -6,6 +6,7 @@ package setting
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -590,3 +591,154 @@ AZURE_BLOB_BASE_PATH = /lfs
 	assert.Equal(t, "my_account_key", LFS.Storage.AzureBlobConfig.AccountKey)
 	assert.Equal(t, "/lfs", LFS.Storage.AzureBlobConfig.BasePath)
 }
+
+func Test_getStorageServeDirectExpiry(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = minio
+SERVE_DIRECT = true
+
+[lfs]
+SERVE_DIRECT_URL_EXPIRY = 1h
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadAttachmentFrom(cfg))
+	assert.True(t, Attachment.Storage.ServeDirect())
+	assert.Equal(t, 5*time.Minute, Attachment.Storage.MinioConfig.ServeDirectExpiry)
+	assert.NoError(t, loadLFSFrom(cfg))
+	assert.Equal(t, time.Hour, LFS.Storage.MinioConfig.ServeDirectExpiry)
+
+	cfg, err = NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = azureblob
+SERVE_DIRECT_URL_EXPIRY = 30m
+
+[storage.lfs]
+SERVE_DIRECT_URL_EXPIRY = 200h
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadAttachmentFrom(cfg))
+	assert.Equal(t, 30*time.Minute, Attachment.Storage.AzureBlobConfig.ServeDirectExpiry)
+	assert.Error(t, loadLFSFrom(cfg))
+
+	cfg, err = NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = minio
+SERVE_DIRECT_URL_EXPIRY = 500ms
+`)
+	assert.NoError(t, err)
+	assert.Error(t, loadAttachmentFrom(cfg))
+}
+
+func Test_getStorageAzureBlobAuth(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = azureblob
+AZURE_BLOB_ACCOUNT_NAME = gitea
+AZURE_BLOB_SAS_TOKEN = ?sv=2022-11-02&sig=secret
+SERVE_DIRECT = true
+`)
+	assert.NoError(t, err)
+
+	assert.NoError(t, loadAttachmentFrom(cfg))
+	assert.Equal(t, "https://gitea.blob.core.windows.net/", Attachment.Storage.AzureBlobConfig.Endpoint)
+	assert.Equal(t, "sv=2022-11-02&sig=secret", Attachment.Storage.AzureBlobConfig.SASToken)
+	// the signed URLs would expose the SAS token
+	assert.False(t, Attachment.Storage.ServeDirect())
+	assert.Equal(t, "******", Attachment.Storage.ToShadowCopy().AzureBlobConfig.SASToken)
+
+	cfg, err = NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = azureblob
+AZURE_BLOB_ENDPOINT = https://lfs.example.com/
+AZURE_BLOB_MANAGED_IDENTITY_CLIENT_ID = 00000000-0000-0000-0000-000000000000
+SERVE_DIRECT = true
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadLFSFrom(cfg))
+	assert.Equal(t, "https://lfs.example.com/", LFS.Storage.AzureBlobConfig.Endpoint)
+	assert.Empty(t, LFS.Storage.AzureBlobConfig.SASToken)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", LFS.Storage.AzureBlobConfig.ManagedIdentityClientID)
+	assert.True(t, LFS.Storage.ServeDirect())
+}
+
+func Test_getStorageGCS(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = gcs
+GCS_CREDENTIALS_FILE = /etc/gitea/gcs.json
+GCS_KMS_KEY_NAME = projects/p/locations/l/keyRings/r/cryptoKeys/k
+SERVE_DIRECT = true
+
+[lfs]
+GCS_BUCKET = lfs-bucket
+`)
+	assert.NoError(t, err)
+
+	assert.NoError(t, loadAttachmentFrom(cfg))
+	assert.EqualValues(t, "gcs", Attachment.Storage.Type)
+	assert.Equal(t, "https://storage.googleapis.com", Attachment.Storage.GCSConfig.Endpoint)
+	assert.Equal(t, "gitea", Attachment.Storage.GCSConfig.Bucket)
+	assert.Equal(t, "attachments/", Attachment.Storage.GCSConfig.BasePath)
+	assert.Equal(t, "/etc/gitea/gcs.json", Attachment.Storage.GCSConfig.CredentialsFile)
+	assert.Equal(t, "projects/p/locations/l/keyRings/r/cryptoKeys/k", Attachment.Storage.GCSConfig.KMSKeyName)
+	assert.True(t, Attachment.Storage.ServeDirect())
+
+	assert.NoError(t, loadLFSFrom(cfg))
+	assert.Equal(t, "lfs-bucket", LFS.Storage.GCSConfig.Bucket)
+	assert.Equal(t, "lfs/", LFS.Storage.GCSConfig.BasePath)
+}
+
+func Test_getStorageColdTier(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[storage.archive-cold]
+STORAGE_TYPE = minio
+MINIO_BUCKET = cold
+
+[lfs]
+COLD_STORAGE_TYPE = local
+COLD_STORAGE_AFTER_DAYS = 60
+
+[repo-archive]
+COLD_STORAGE_TYPE = archive-cold
+`)
+	assert.NoError(t, err)
+
+	assert.NoError(t, loadLFSFrom(cfg))
+	assert.NotNil(t, LFS.ColdTier)
+	assert.Equal(t, 60, LFS.ColdTier.AfterDays)
+	assert.EqualValues(t, "local", LFS.ColdTier.Storage.Type)
+	assert.Equal(t, filepath.Join(AppDataPath, "lfs-cold"), LFS.ColdTier.Storage.Path)
+	assert.Equal(t, filepath.Join(AppDataPath, "lfs"), LFS.Storage.Path)
+
+	assert.NoError(t, loadRepoArchiveFrom(cfg))
+	assert.NotNil(t, RepoArchive.ColdTier)
+	assert.Equal(t, 30, RepoArchive.ColdTier.AfterDays)
+	assert.EqualValues(t, "minio", RepoArchive.ColdTier.Storage.Type)
+	assert.Equal(t, "cold", RepoArchive.ColdTier.Storage.MinioConfig.Bucket)
+	assert.Equal(t, "repo-archive-cold/", RepoArchive.ColdTier.Storage.MinioConfig.BasePath)
+	assert.EqualValues(t, "local", RepoArchive.Storage.Type)
+
+	cfg, err = NewConfigProviderFromData(`
+[repo-archive]
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadRepoArchiveFrom(cfg))
+	assert.Nil(t, RepoArchive.ColdTier)
+
+	cfg, err = NewConfigProviderFromData(`
+[repo-archive]
+COLD_STORAGE_TYPE = local
+COLD_STORAGE_AFTER_DAYS = 0
+`)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, loadRepoArchiveFrom(cfg), "COLD_STORAGE_AFTER_DAYS")
+
+	// the [storage] section isn't used for another type
+	cfg, err = NewConfigProviderFromData(`
+[repo-archive]
+COLD_STORAGE_TYPE = minio
+`)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, loadRepoArchiveFrom(cfg), "[storage.repo-archive-cold]")
+}
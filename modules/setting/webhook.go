@@ -5,6 +5,9 @@ package setting
 
 import (
 	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 )
@@ -14,19 +17,45 @@ var Webhook = struct {
 	QueueLength     int
 	DeliverTimeout  int
 	SkipTLSVerify   bool
-	AllowedHostList string
 	Types           []string
 	PagingNum       int
 	ProxyURL        string
 	ProxyURLFixed   *url.URL
 	ProxyHosts      []string
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+
+	SigningEnabled           bool
+	SigningPrivateKeyFile    string
+	SigningPublishedKeyFiles []string
 }{
-	QueueLength:    1000,
-	DeliverTimeout: 5,
-	SkipTLSVerify:  false,
-	PagingNum:      10,
-	ProxyURL:       "",
-	ProxyHosts:     []string{},
+	QueueLength:     1000,
+	DeliverTimeout:  5,
+	SkipTLSVerify:   false,
+	PagingNum:       10,
+	ProxyURL:        "",
+	ProxyHosts:      []string{},
+	MaxAttempts:     5,
+	RetryBackoff:    time.Minute,
+	MaxRetryBackoff: time.Hour,
+
+	SigningPrivateKeyFile: "webhook/signing.pem",
+}
+
+var webhookAllowedHostList atomic.Pointer[string]
+
+// GetWebhookAllowedHostList returns the hosts the webhooks are allowed to call, it can be changed by reloading the settings
+func GetWebhookAllowedHostList() string {
+	if hostList := webhookAllowedHostList.Load(); hostList != nil {
+		return *hostList
+	}
+	return ""
+}
+
+// SetWebhookAllowedHostList replaces the hosts the webhooks are allowed to call
+func SetWebhookAllowedHostList(hostList string) {
+	webhookAllowedHostList.Store(&hostList)
 }
 
 func loadWebhookFrom(rootCfg ConfigProvider) {
@@ -34,8 +63,8 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 	Webhook.QueueLength = sec.Key("QUEUE_LENGTH").MustInt(1000)
 	Webhook.DeliverTimeout = sec.Key("DELIVER_TIMEOUT").MustInt(5)
 	Webhook.SkipTLSVerify = sec.Key("SKIP_TLS_VERIFY").MustBool()
-	Webhook.AllowedHostList = sec.Key("ALLOWED_HOST_LIST").MustString("")
-	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist"}
+	SetWebhookAllowedHostList(sec.Key("ALLOWED_HOST_LIST").MustString(""))
+	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist", "custom", "kafka", "nats"}
 	Webhook.PagingNum = sec.Key("PAGING_NUM").MustInt(10)
 	Webhook.ProxyURL = sec.Key("PROXY_URL").MustString("")
 	if Webhook.ProxyURL != "" {
@@ -47,4 +76,23 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 		}
 	}
 	Webhook.ProxyHosts = sec.Key("PROXY_HOSTS").Strings(",")
+	Webhook.MaxAttempts = max(sec.Key("MAX_ATTEMPTS").MustInt(5), 1)
+	Webhook.RetryBackoff = sec.Key("RETRY_BACKOFF").MustDuration(time.Minute)
+	if Webhook.RetryBackoff <= 0 {
+		log.Error("Webhook RETRY_BACKOFF must be positive")
+		Webhook.RetryBackoff = time.Minute
+	}
+	Webhook.MaxRetryBackoff = max(sec.Key("MAX_RETRY_BACKOFF").MustDuration(time.Hour), Webhook.RetryBackoff)
+
+	Webhook.SigningEnabled = sec.Key("SIGNING_ENABLED").MustBool()
+	Webhook.SigningPrivateKeyFile = sec.Key("SIGNING_PRIVATE_KEY_FILE").MustString("webhook/signing.pem")
+	if !filepath.IsAbs(Webhook.SigningPrivateKeyFile) {
+		Webhook.SigningPrivateKeyFile = filepath.Join(AppDataPath, Webhook.SigningPrivateKeyFile)
+	}
+	Webhook.SigningPublishedKeyFiles = sec.Key("SIGNING_PUBLISHED_KEY_FILES").Strings(",")
+	for i, keyFile := range Webhook.SigningPublishedKeyFiles {
+		if !filepath.IsAbs(keyFile) {
+			Webhook.SigningPublishedKeyFiles[i] = filepath.Join(AppDataPath, keyFile)
+		}
+	}
 }
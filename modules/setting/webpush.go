@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/modules/generate"
+)
+
+// WebPush settings
+var WebPush = struct {
+	Enabled bool
+	// Subject is the contact of the instance for the push services, a "mailto:" or an "https:" URL
+	Subject         string
+	VAPIDPublicKey  string `ini:"VAPID_PUBLIC_KEY"`
+	VAPIDPrivateKey string `ini:"VAPID_PRIVATE_KEY"`
+	AllowedHostList string
+	DeliverTimeout  time.Duration
+	// TTL is how long the push services keep a notification while the browser is offline
+	TTL time.Duration `ini:"TTL"`
+}{
+	DeliverTimeout: 10 * time.Second,
+	TTL:            24 * time.Hour,
+}
+
+// validVAPIDKeys checks the VAPID keys are a P-256 key pair
+func validVAPIDKeys(publicKey, privateKey string) bool {
+	publicBytes, err := base64.RawURLEncoding.DecodeString(publicKey)
+	if err != nil {
+		return false
+	}
+	privateBytes, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return false
+	}
+	key, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return false
+	}
+	return string(key.PublicKey().Bytes()) == string(publicBytes)
+}
+
+func loadWebPushFrom(rootCfg ConfigProvider) error {
+	mustMapSetting(rootCfg, "webpush", &WebPush)
+	if !WebPush.Enabled {
+		return nil
+	}
+	if WebPush.Subject == "" {
+		WebPush.Subject = AppURL
+	}
+
+	if !InstallLock || validVAPIDKeys(WebPush.VAPIDPublicKey, WebPush.VAPIDPrivateKey) {
+		return nil
+	}
+	if WebPush.VAPIDPublicKey != "" || WebPush.VAPIDPrivateKey != "" {
+		return fmt.Errorf("invalid VAPID key pair in [webpush], remove both keys to generate a new pair")
+	}
+
+	var err error
+	if WebPush.VAPIDPublicKey, WebPush.VAPIDPrivateKey, err = generate.NewVAPIDKeys(); err != nil {
+		return fmt.Errorf("error generating VAPID keys: %w", err)
+	}
+
+	// Save keys
+	saveCfg, err := rootCfg.PrepareSaving()
+	if err != nil {
+		return fmt.Errorf("error saving VAPID keys: %w", err)
+	}
+	for _, cfg := range []ConfigProvider{rootCfg, saveCfg} {
+		cfg.Section("webpush").Key("VAPID_PUBLIC_KEY").SetValue(WebPush.VAPIDPublicKey)
+		cfg.Section("webpush").Key("VAPID_PRIVATE_KEY").SetValue(WebPush.VAPIDPrivateKey)
+	}
+	if err := saveCfg.Save(); err != nil {
+		return fmt.Errorf("error saving VAPID keys: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package spamdetection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+)
+
+// Poster is the account which has posted the content
+type Poster struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	CreatedUnix int64  `json:"created"`
+}
+
+// Content is an issue or a comment to be classified
+type Content struct {
+	// Type is "issue" or "comment"
+	Type    string `json:"type"`
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content"`
+	// Repo is the full name of the repository
+	Repo   string `json:"repo"`
+	Poster Poster `json:"poster"`
+}
+
+// Verdict is the result of a classification
+type Verdict struct {
+	Spam   bool   `json:"spam"`
+	Reason string `json:"reason"`
+}
+
+// Classifier posts the content to an external classifier which answers with a JSON verdict
+type Classifier struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewClassifier creates a classifier for the service at the url, the token is sent as bearer token if it is set
+func NewClassifier(url, token string, timeout time.Duration) *Classifier {
+	return &Classifier{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Classify posts the content as JSON and parses the verdict of the response
+func (c *Classifier) Classify(ctx context.Context, content *Content) (*Verdict, error) {
+	body, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("classifier responded with status %d", resp.StatusCode)
+	}
+
+	var verdict Verdict
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&verdict); err != nil {
+		return nil, fmt.Errorf("invalid response of the classifier: %w", err)
+	}
+	return &verdict, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package spamdetection
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var content Content
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&content))
+		assert.Equal(t, "user2", content.Poster.Name)
+		if strings.Contains(content.Content, "casino") {
+			_, _ = io.WriteString(w, `{"spam":true,"reason":"gambling"}`)
+		} else {
+			_, _ = io.WriteString(w, `{"spam":false}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClassifier(srv.URL, "secret", time.Minute)
+
+	verdict, err := c.Classify(t.Context(), &Content{Type: "comment", Content: "looks good to me", Poster: Poster{Name: "user2"}})
+	require.NoError(t, err)
+	assert.False(t, verdict.Spam)
+
+	verdict, err = c.Classify(t.Context(), &Content{Type: "issue", Title: "win", Content: "best online casino", Poster: Poster{Name: "user2"}})
+	require.NoError(t, err)
+	assert.True(t, verdict.Spam)
+	assert.Equal(t, "gambling", verdict.Reason)
+
+	_, err = NewClassifier(srv.URL, "wrong", time.Minute).Classify(t.Context(), &Content{Type: "comment"})
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package spamdetection
+
+import (
+	"strings"
+
+	"mvdan.cc/xurls/v2"
+)
+
+var linkPattern = xurls.Strict()
+
+// CountLinks counts the links in the content
+func CountLinks(content string) int {
+	return len(linkPattern.FindAllStringIndex(content, -1))
+}
+
+// LinkDensity returns the percentage of the words of the content which contain links
+func LinkDensity(content string) int {
+	words := strings.Fields(content)
+	if len(words) == 0 {
+		return 0
+	}
+	links := 0
+	for _, word := range words {
+		if linkPattern.MatchString(word) {
+			links++
+		}
+	}
+	return links * 100 / len(words)
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package spamdetection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkDensity(t *testing.T) {
+	assert.Equal(t, 0, LinkDensity(""))
+	assert.Equal(t, 0, LinkDensity("no links at all"))
+	assert.Equal(t, 25, LinkDensity("see https://example.com for details"))
+	assert.Equal(t, 100, LinkDensity("https://a.example.com https://b.example.com [b](https://c.example.com)"))
+	assert.Equal(t, 3, CountLinks("https://a.example.com https://b.example.com [b](https://c.example.com)"))
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"code.gitea.io/gitea/modules/log"
@@ -19,12 +20,14 @@ import (
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
 )
 
 var _ Object = &azureBlobObject{}
@@ -98,8 +101,13 @@ var _ ObjectStorage = &AzureBlobStorage{}
 type AzureBlobStorage struct {
 	cfg        *setting.AzureBlobStorageConfig
 	ctx        context.Context
-	credential *azblob.SharedKeyCredential
+	credential *azblob.SharedKeyCredential // nil if a SAS token or the managed identity is used
 	client     *azblob.Client
+
+	// the user delegation key signs the URLs if the managed identity is used
+	delegationMu        sync.Mutex
+	delegationCred      *service.UserDelegationCredential
+	delegationExpiresAt time.Time
 }
 
 func convertAzureBlobErr(err error) error {
@@ -123,20 +131,36 @@ func NewAzureBlobStorage(ctx context.Context, cfg *setting.Storage) (ObjectStora
 
 	log.Info("Creating Azure Blob storage at %s:%s with base path %s", config.Endpoint, config.Container, config.BasePath)
 
-	cred, err := azblob.NewSharedKeyCredential(config.AccountName, config.AccountKey)
-	if err != nil {
-		return nil, convertAzureBlobErr(err)
+	var cred *azblob.SharedKeyCredential
+	var client *azblob.Client
+	var err error
+	switch {
+	case config.AccountKey != "":
+		if cred, err = azblob.NewSharedKeyCredential(config.AccountName, config.AccountKey); err != nil {
+			return nil, convertAzureBlobErr(err)
+		}
+		client, err = azblob.NewClientWithSharedKeyCredential(config.Endpoint, cred, &azblob.ClientOptions{})
+	case config.SASToken != "":
+		// the SAS token is kept in the query of the URLs of the containers and the blobs
+		client, err = azblob.NewClientWithNoCredential(strings.TrimSuffix(config.Endpoint, "?")+"?"+config.SASToken, &azblob.ClientOptions{})
+	default:
+		log.Info("Using the managed identity to access Azure Blob storage at %s", config.Endpoint)
+		client, err = azblob.NewClient(config.Endpoint, newAzureManagedIdentityCredential(config.IdentityEndpoint, config.ManagedIdentityClientID), &azblob.ClientOptions{})
 	}
-	client, err := azblob.NewClientWithSharedKeyCredential(config.Endpoint, cred, &azblob.ClientOptions{})
 	if err != nil {
 		return nil, convertAzureBlobErr(err)
 	}
 
 	_, err = client.CreateContainer(ctx, config.Container, &container.CreateOptions{})
 	if err != nil {
-		// Check to see if we already own this container (which happens if you run this twice)
-		if !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
-			return nil, convertMinioErr(err)
+		switch {
+		case bloberror.HasCode(err, bloberror.ContainerAlreadyExists):
+			// Check to see if we already own this container (which happens if you run this twice)
+		case cred == nil && bloberror.HasCode(err, bloberror.AuthorizationFailure, bloberror.AuthorizationPermissionMismatch, bloberror.AuthorizationResourceTypeMismatch):
+			// the SAS tokens and the identities are often only allowed to access the blobs of an existing container
+			log.Warn("Unable to create the Azure Blob container %s, it must exist: %v", config.Container, err)
+		default:
+			return nil, convertAzureBlobErr(err)
 		}
 	}
 
@@ -246,14 +270,25 @@ func (a *AzureBlobStorage) Delete(path string) error {
 	return convertAzureBlobErr(err)
 }
 
-// URL gets the redirect URL to a file. The presigned link is valid for 5 minutes.
+// URL gets the redirect URL to a file. The SAS link is valid for the configured SERVE_DIRECT_URL_EXPIRY.
 func (a *AzureBlobStorage) URL(path, name, _ string, reqParams url.Values) (*url.URL, error) {
 	blobClient := a.getBlobClient(path)
 
+	expires := a.cfg.ServeDirectExpiry
+	if expires <= 0 {
+		expires = 5 * time.Minute
+	}
 	startTime := time.Now()
+	if a.credential == nil {
+		if a.cfg.SASToken != "" {
+			// the configured SAS token must not be exposed
+			return nil, ErrURLNotSupported
+		}
+		return a.userDelegationURL(blobClient, path, startTime, startTime.Add(expires))
+	}
 	u, err := blobClient.GetSASURL(sas.BlobPermissions{
 		Read: true,
-	}, time.Now().Add(5*time.Minute), &blob.GetSASURLOptions{
+	}, startTime.Add(expires), &blob.GetSASURLOptions{
 		StartTime: &startTime,
 	})
 	if err != nil {
@@ -263,6 +298,40 @@ func (a *AzureBlobStorage) URL(path, name, _ string, reqParams url.Values) (*url
 	return url.Parse(u)
 }
 
+// userDelegationURL signs the URL with a user delegation key of the managed identity, the key is reused until
+// it expires before the URL
+func (a *AzureBlobStorage) userDelegationURL(blobClient *blob.Client, path string, startTime, expiryTime time.Time) (*url.URL, error) {
+	a.delegationMu.Lock()
+	if a.delegationCred == nil || a.delegationExpiresAt.Before(expiryTime) {
+		// a user delegation key is valid for 7 days at most
+		keyExpiresAt := startTime.Add(max(24*time.Hour, expiryTime.Sub(startTime)+time.Hour))
+		cred, err := a.client.ServiceClient().GetUserDelegationCredential(a.ctx, service.KeyInfo{
+			Start:  to.Ptr(startTime.UTC().Format(sas.TimeFormat)),
+			Expiry: to.Ptr(keyExpiresAt.UTC().Format(sas.TimeFormat)),
+		}, nil)
+		if err != nil {
+			a.delegationMu.Unlock()
+			return nil, convertAzureBlobErr(err)
+		}
+		a.delegationCred, a.delegationExpiresAt = cred, keyExpiresAt
+	}
+	cred := a.delegationCred
+	a.delegationMu.Unlock()
+
+	params, err := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		StartTime:     startTime.UTC(),
+		ExpiryTime:    expiryTime.UTC(),
+		Permissions:   (&sas.BlobPermissions{Read: true}).String(),
+		ContainerName: a.cfg.Container,
+		BlobName:      a.buildAzureBlobPath(path),
+	}.SignWithUserDelegation(cred)
+	if err != nil {
+		return nil, convertAzureBlobErr(err)
+	}
+	return url.Parse(blobClient.URL() + "?" + params.Encode())
+}
+
 // IterateObjects iterates across the objects in the azureblobstorage
 func (a *AzureBlobStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
 	dirName = a.buildAzureBlobPath(dirName)
@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// azureIMDSEndpoint is the token endpoint of the instance metadata service of the Azure virtual machines
+const azureIMDSEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+var _ azcore.TokenCredential = &azureManagedIdentityCredential{}
+
+// azureManagedIdentityCredential gets the tokens of the managed identity of the Azure resource Gitea runs on.
+// App Service and Container Apps provide the IDENTITY_ENDPOINT and IDENTITY_HEADER environment variables,
+// the virtual machines and AKS nodes provide the instance metadata service.
+type azureManagedIdentityCredential struct {
+	endpoint string
+	header   string // the X-IDENTITY-HEADER of App Service and Container Apps
+	clientID string // the client ID of a user-assigned identity, the system-assigned identity is used if it is empty
+	client   *http.Client
+}
+
+func newAzureManagedIdentityCredential(endpoint, clientID string) *azureManagedIdentityCredential {
+	c := &azureManagedIdentityCredential{
+		endpoint: endpoint,
+		clientID: clientID,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+	if c.endpoint == "" {
+		if c.endpoint = os.Getenv("IDENTITY_ENDPOINT"); c.endpoint != "" {
+			c.header = os.Getenv("IDENTITY_HEADER")
+		} else {
+			c.endpoint = azureIMDSEndpoint
+		}
+	}
+	return c
+}
+
+// GetToken gets a token for the scope, azblob caches the token until it's about to expire
+func (c *azureManagedIdentityCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if len(opts.Scopes) != 1 {
+		return azcore.AccessToken{}, errors.New("managed identity requires exactly one scope")
+	}
+
+	query := url.Values{}
+	query.Set("resource", strings.TrimSuffix(opts.Scopes[0], "/.default"))
+	if c.header != "" {
+		query.Set("api-version", "2019-08-01")
+	} else {
+		query.Set("api-version", "2018-02-01")
+	}
+	if c.clientID != "" {
+		query.Set("client_id", c.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+	if c.header != "" {
+		req.Header.Set("X-IDENTITY-HEADER", c.header)
+	} else {
+		req.Header.Set("Metadata", "true")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return azcore.AccessToken{}, fmt.Errorf("managed identity endpoint is unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return azcore.AccessToken{}, fmt.Errorf("managed identity endpoint responded %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"` // in seconds since the epoch
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return azcore.AccessToken{}, err
+	}
+	expiresOn, err := strconv.ParseInt(token.ExpiresOn, 10, 64)
+	if err != nil {
+		return azcore.AccessToken{}, fmt.Errorf("invalid expires_on %q of managed identity token: %w", token.ExpiresOn, err)
+	}
+	return azcore.AccessToken{Token: token.AccessToken, ExpiresOn: time.Unix(expiresOn, 0)}, nil
+}
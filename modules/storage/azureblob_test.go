@@ -5,13 +5,19 @@ package storage
 
 import (
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/modules/setting"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAzureBlobStorageIterator(t *testing.T) {
@@ -99,3 +105,56 @@ func Test_azureBlobObject(t *testing.T) {
 	assert.NoError(t, obj.Close())
 	assert.NoError(t, s.Delete("test.txt"))
 }
+
+func TestAzureManagedIdentityCredential(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		if r.URL.Query().Get("client_id") == "unknown" {
+			http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_on":"1893456000","resource":"https://storage.azure.com","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	opts := policy.TokenRequestOptions{Scopes: []string{"https://storage.azure.com/.default"}}
+
+	// the instance metadata service
+	t.Setenv("IDENTITY_ENDPOINT", "")
+	cred := newAzureManagedIdentityCredential(server.URL, "")
+	token, err := cred.GetToken(t.Context(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Token)
+	assert.Equal(t, time.Unix(1893456000, 0), token.ExpiresOn)
+	assert.Equal(t, "true", received.Header.Get("Metadata"))
+	assert.Equal(t, "https://storage.azure.com", received.URL.Query().Get("resource"))
+	assert.Equal(t, "2018-02-01", received.URL.Query().Get("api-version"))
+	assert.False(t, received.URL.Query().Has("client_id"))
+	assert.Equal(t, azureIMDSEndpoint, newAzureManagedIdentityCredential("", "").endpoint)
+
+	// the identity endpoint of App Service with a user-assigned identity
+	t.Setenv("IDENTITY_ENDPOINT", server.URL)
+	t.Setenv("IDENTITY_HEADER", "secret")
+	cred = newAzureManagedIdentityCredential("", "client")
+	_, err = cred.GetToken(t.Context(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", received.Header.Get("X-IDENTITY-HEADER"))
+	assert.Equal(t, "2019-08-01", received.URL.Query().Get("api-version"))
+	assert.Equal(t, "client", received.URL.Query().Get("client_id"))
+
+	_, err = newAzureManagedIdentityCredential("", "unknown").GetToken(t.Context(), opts)
+	assert.ErrorContains(t, err, "invalid_request")
+}
+
+func TestAzureBlobStorageSASTokenURL(t *testing.T) {
+	cfg := &setting.AzureBlobStorageConfig{SASToken: "sv=2022-11-02&sig=secret", Container: "test"}
+	client, err := azblob.NewClientWithNoCredential("https://devstoreaccount1.blob.core.windows.net/?"+cfg.SASToken, nil)
+	require.NoError(t, err)
+	s := &AzureBlobStorage{cfg: cfg, client: client}
+
+	assert.Equal(t, "https://devstoreaccount1.blob.core.windows.net/test/a%2Fb?sv=2022-11-02&sig=secret", s.getBlobClient("a/b").URL())
+	// the SAS token must not be exposed by the signed URLs
+	_, err = s.URL("a/b", "b", http.MethodGet, nil)
+	assert.ErrorIs(t, err, ErrURLNotSupported)
+}
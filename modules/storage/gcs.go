@@ -0,0 +1,346 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	gcs "cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+var _ ObjectStorage = &GCSStorage{}
+
+// gcsScopes allow to read and write the objects, and to sign the URLs with the IAM credentials API if the
+// credentials have no private key
+var gcsScopes = []string{gcs.ScopeReadWrite, "https://www.googleapis.com/auth/iam"}
+
+type gcsObjectInfo struct {
+	Key        string
+	Length     int64
+	Updated    time.Time
+	Generation int64
+}
+
+func newGCSObjectInfo(attrs *gcs.ObjectAttrs) *gcsObjectInfo {
+	return &gcsObjectInfo{
+		Key:        attrs.Name,
+		Length:     attrs.Size,
+		Updated:    attrs.Updated,
+		Generation: attrs.Generation,
+	}
+}
+
+func (o *gcsObjectInfo) Name() string {
+	return path.Base(o.Key)
+}
+
+func (o *gcsObjectInfo) Size() int64 {
+	return o.Length
+}
+
+func (o *gcsObjectInfo) ModTime() time.Time {
+	return o.Updated
+}
+
+func (o *gcsObjectInfo) IsDir() bool {
+	return strings.HasSuffix(o.Key, "/")
+}
+
+func (o *gcsObjectInfo) Mode() os.FileMode {
+	return os.ModePerm
+}
+
+func (o *gcsObjectInfo) Sys() any {
+	return nil
+}
+
+var _ Object = &gcsObject{}
+
+type gcsObject struct {
+	ctx    context.Context
+	handle *gcs.ObjectHandle // the generation makes sure the content belongs to the stated object even if it has been overwritten
+	info   *gcsObjectInfo
+	offset int64
+	reader *gcs.Reader // the content from the offset, it's requested by the first read
+}
+
+func (o *gcsObject) Read(p []byte) (int, error) {
+	if o.offset >= o.info.Length {
+		return 0, io.EOF
+	}
+	if o.reader == nil {
+		reader, err := o.handle.NewRangeReader(o.ctx, o.offset, -1)
+		if err != nil {
+			return 0, convertGCSErr(err)
+		}
+		o.reader = reader
+	}
+	n, err := o.reader.Read(p)
+	o.offset += int64(n)
+	return n, err
+}
+
+func (o *gcsObject) Close() error {
+	o.offset = 0
+	if o.reader == nil {
+		return nil
+	}
+	err := o.reader.Close()
+	o.reader = nil
+	return err
+}
+
+func (o *gcsObject) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset = o.info.Length + offset
+	default:
+		return 0, errors.New("Seek: invalid whence")
+	}
+
+	if offset > o.info.Length || offset < 0 {
+		return 0, errors.New("Seek: invalid offset")
+	}
+	if offset != o.offset && o.reader != nil {
+		_ = o.reader.Close()
+		o.reader = nil
+	}
+	o.offset = offset
+	return o.offset, nil
+}
+
+func (o *gcsObject) Stat() (os.FileInfo, error) {
+	return o.info, nil
+}
+
+// GCSStorage stores the objects in a Google Cloud Storage bucket. No ACL is set on the objects,
+// so the buckets with uniform bucket-level access are supported.
+type GCSStorage struct {
+	cfg      *setting.GCSStorageConfig
+	ctx      context.Context
+	bucket   *gcs.BucketHandle
+	insecure bool // the endpoint is http, so are the signed URLs
+}
+
+func convertGCSErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Convert two responses to standard analogues
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return os.ErrNotExist
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		return fmt.Errorf("%w: %s", os.ErrPermission, apiErr.Message)
+	}
+	return err
+}
+
+// NewGCSStorage returns a google cloud storage
+func NewGCSStorage(ctx context.Context, cfg *setting.Storage) (ObjectStorage, error) {
+	config := cfg.GCSConfig
+
+	log.Info("Creating GCS storage at %s:%s with base path %s", config.Endpoint, config.Bucket, config.BasePath)
+
+	var credsOption option.ClientOption
+	if config.CredentialsFile != "" {
+		// a service account key, or a workload identity federation configuration created by
+		// "gcloud iam workload-identity-pools create-cred-config"
+		data, err := os.ReadFile(config.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		credsOption = option.WithCredentialsJSON(data)
+	} else {
+		// the application default credentials, e.g. GOOGLE_APPLICATION_CREDENTIALS or the metadata server of GCE and GKE
+		creds, err := google.FindDefaultCredentials(ctx, gcsScopes...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the GCS credentials: %w", err)
+		}
+		credsOption = option.WithCredentials(creds)
+	}
+	return newGCSStorage(ctx, &config, credsOption, option.WithScopes(gcsScopes...))
+}
+
+func newGCSStorage(ctx context.Context, config *setting.GCSStorageConfig, opts ...option.ClientOption) (*GCSStorage, error) {
+	endpoint := strings.TrimSuffix(config.Endpoint, "/")
+	client, err := gcs.NewClient(ctx, append(opts, option.WithEndpoint(endpoint+"/storage/v1/"))...)
+	if err != nil {
+		return nil, err
+	}
+	g := &GCSStorage{
+		cfg:      config,
+		ctx:      ctx,
+		bucket:   client.Bucket(config.Bucket),
+		insecure: strings.HasPrefix(endpoint, "http://"),
+	}
+
+	// The bucket is not created since it belongs to a project, and the identities which are only allowed to access
+	// the objects can't get the bucket, so only a missing bucket is an error.
+	attrs, err := g.bucket.Attrs(ctx)
+	if errors.Is(err, gcs.ErrBucketNotExist) {
+		return nil, fmt.Errorf("GCS bucket %s doesn't exist", config.Bucket)
+	} else if err = convertGCSErr(err); err != nil && !errors.Is(err, os.ErrPermission) {
+		return nil, err
+	} else if err == nil && !attrs.UniformBucketLevelAccess.Enabled {
+		log.Warn("The GCS bucket %s doesn't have uniform bucket-level access, the new objects get its default object ACL", config.Bucket)
+	}
+	return g, nil
+}
+
+func (g *GCSStorage) buildGCSPath(p string) string {
+	p = strings.TrimPrefix(util.PathJoinRelX(g.cfg.BasePath, p), "/") // object store doesn't use slash for root path
+	if p == "." {
+		p = "" // object store doesn't use dot as relative path
+	}
+	return p
+}
+
+func (g *GCSStorage) buildGCSDirPrefix(p string) string {
+	// ending slash is required for avoiding matching like "foo/" and "foobar/" with prefix "foo"
+	p = g.buildGCSPath(p) + "/"
+	if p == "/" {
+		p = "" // object store doesn't use slash for root path
+	}
+	return p
+}
+
+func (g *GCSStorage) newObject(info *gcsObjectInfo) *gcsObject {
+	return &gcsObject{ctx: g.ctx, handle: g.bucket.Object(info.Key).Generation(info.Generation), info: info}
+}
+
+func (g *GCSStorage) stat(key string) (*gcsObjectInfo, error) {
+	attrs, err := g.bucket.Object(key).Attrs(g.ctx)
+	if err != nil {
+		return nil, convertGCSErr(err)
+	}
+	return newGCSObjectInfo(attrs), nil
+}
+
+// Open opens a file
+func (g *GCSStorage) Open(path string) (Object, error) {
+	info, err := g.stat(g.buildGCSPath(path))
+	if err != nil {
+		return nil, err
+	}
+	return g.newObject(info), nil
+}
+
+// Save saves a file to the bucket, the large ones are uploaded in chunks so the size doesn't need to be known
+func (g *GCSStorage) Save(path string, r io.Reader, size int64) (int64, error) {
+	ctx, cancel := context.WithCancel(g.ctx)
+	defer cancel() // canceling the context aborts the upload if it isn't closed
+
+	w := g.bucket.Object(g.buildGCSPath(path)).NewWriter(ctx)
+	w.ContentType = "application/octet-stream"
+	// the customer-managed encryption key, the default key of the bucket is used if it is empty
+	w.KMSKeyName = g.cfg.KMSKeyName
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return 0, convertGCSErr(err)
+	}
+	if size >= 0 && n != size {
+		return 0, fmt.Errorf("gcs upload size mismatch: %d != %d", n, size)
+	}
+	if err := w.Close(); err != nil {
+		return 0, convertGCSErr(err)
+	}
+	return n, nil
+}
+
+// Stat returns the stat information of the object
+func (g *GCSStorage) Stat(path string) (os.FileInfo, error) {
+	return g.stat(g.buildGCSPath(path))
+}
+
+// Delete delete a file
+func (g *GCSStorage) Delete(path string) error {
+	err := g.bucket.Object(g.buildGCSPath(path)).Delete(g.ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return nil // like S3, deleting a nonexistent object succeeds
+	}
+	return convertGCSErr(err)
+}
+
+// URL gets the redirect URL to a file. The V4 signed link is valid for the configured SERVE_DIRECT_URL_EXPIRY.
+// It's signed with the private key of the credentials, or with the IAM credentials API as GCS_SERVICE_ACCOUNT
+// or as the service account of the credentials.
+func (g *GCSStorage) URL(path, name, method string, serveDirectReqParams url.Values) (*url.URL, error) {
+	// copy serveDirectReqParams
+	reqParams, err := url.ParseQuery(serveDirectReqParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+	reqParams.Set("response-content-disposition", "attachment; filename=\""+quoteEscaper.Replace(name)+"\"")
+	expires := g.cfg.ServeDirectExpiry
+	if expires <= 0 {
+		expires = 5 * time.Minute
+	}
+	if method != http.MethodHead {
+		method = http.MethodGet
+	}
+	signed, err := g.bucket.SignedURL(g.buildGCSPath(path), &gcs.SignedURLOptions{
+		GoogleAccessID:  g.cfg.ServiceAccount,
+		Method:          method,
+		Expires:         time.Now().Add(expires),
+		QueryParameters: reqParams,
+		Scheme:          gcs.SigningSchemeV4,
+		Insecure:        g.insecure,
+	})
+	if err != nil {
+		return nil, convertGCSErr(err)
+	}
+	return url.Parse(signed)
+}
+
+// IterateObjects iterates across the objects in the gcs bucket
+func (g *GCSStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
+	query := &gcs.Query{Prefix: g.buildGCSDirPrefix(dirName)}
+	if err := query.SetAttrSelection([]string{"Name", "Size", "Updated", "Generation"}); err != nil {
+		return err
+	}
+	it := g.bucket.Objects(g.ctx, query)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		} else if err != nil {
+			return convertGCSErr(err)
+		}
+
+		info := newGCSObjectInfo(attrs)
+		if err := func(object *gcsObject, fn func(path string, obj Object) error) error {
+			defer object.Close()
+			return fn(strings.TrimPrefix(info.Key, g.cfg.BasePath), object)
+		}(g.newObject(info), fn); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	RegisterStorageType(setting.GCSStorageType, NewGCSStorage)
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+)
+
+// fakeGCS implements the parts of the JSON and XML APIs used by GCSStorage
+type fakeGCS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	kmsKeys []string
+}
+
+func (f *fakeGCS) objectJSON(name string) map[string]string {
+	return map[string]string{
+		"bucket":     "bucket",
+		"name":       name,
+		"size":       strconv.Itoa(len(f.objects[name])),
+		"updated":    "2026-01-02T03:04:05.000Z",
+		"generation": "1",
+	}
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	escapedPath := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodGet && escapedPath == "/storage/v1/b/bucket":
+		_, _ = w.Write([]byte(`{"name":"bucket","iamConfiguration":{"uniformBucketLevelAccess":{"enabled":true}}}`))
+	case r.Method == http.MethodGet && escapedPath == "/storage/v1/b/bucket/o":
+		var names []string
+		for name := range f.objects {
+			if strings.HasPrefix(name, r.FormValue("prefix")) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		// one object per page
+		start, _ := strconv.Atoi(r.FormValue("pageToken"))
+		if start >= len(names) {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		res := map[string]any{"items": []map[string]string{f.objectJSON(names[start])}}
+		if start+1 < len(names) {
+			res["nextPageToken"] = strconv.Itoa(start + 1)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	case strings.HasPrefix(escapedPath, "/storage/v1/b/bucket/o/"):
+		name, _ := url.PathUnescape(strings.TrimPrefix(escapedPath, "/storage/v1/b/bucket/o/"))
+		if _, ok := f.objects[name]; !ok {
+			http.Error(w, `{"error":{"code":404,"message":"No such object"}}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.objects, name)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.objectJSON(name))
+	case r.Method == http.MethodGet && strings.HasPrefix(escapedPath, "/bucket/"):
+		// the XML API serves the content
+		name, _ := url.PathUnescape(strings.TrimPrefix(escapedPath, "/bucket/"))
+		content, ok := f.objects[name]
+		if !ok || r.FormValue("generation") != "1" {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Goog-Generation", "1")
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+	case r.Method == http.MethodPost && escapedPath == "/upload/storage/v1/b/bucket/o":
+		if r.FormValue("uploadType") != "multipart" {
+			http.Error(w, "bad upload type", http.StatusBadRequest)
+			return
+		}
+		f.kmsKeys = append(f.kmsKeys, r.FormValue("kmsKeyName"))
+		_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		metaPart, err := reader.NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var meta struct {
+			Name string `json:"name"`
+		}
+		_ = json.NewDecoder(metaPart).Decode(&meta)
+		mediaPart, err := reader.NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[meta.Name], _ = io.ReadAll(mediaPart)
+		_ = json.NewEncoder(w).Encode(f.objectJSON(meta.Name))
+	case strings.HasPrefix(escapedPath, "/storage/v1/b/"):
+		http.Error(w, `{"error":{"code":404,"message":"The specified bucket does not exist."}}`, http.StatusNotFound)
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.String(), http.StatusBadRequest)
+	}
+}
+
+func TestGCSStorage(t *testing.T) {
+	fake := &fakeGCS{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	_, err := newGCSStorage(t.Context(), &setting.GCSStorageConfig{Endpoint: server.URL, Bucket: "missing"}, option.WithHTTPClient(server.Client()))
+	assert.ErrorContains(t, err, "doesn't exist")
+
+	s, err := newGCSStorage(t.Context(), &setting.GCSStorageConfig{Endpoint: server.URL + "/", Bucket: "bucket", BasePath: "base/", KMSKeyName: "key"}, option.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	for _, f := range [][]string{{"a/1.txt", "a1"}, {"ab/1.txt", "ab1"}, {"b/x 4.txt", "bx4"}, {"empty", ""}} {
+		n, err := s.Save(f[0], strings.NewReader(f[1]), int64(len(f[1])))
+		require.NoError(t, err)
+		assert.EqualValues(t, len(f[1]), n)
+	}
+	assert.Equal(t, "bx4", string(fake.objects["base/b/x 4.txt"]))
+	assert.Equal(t, []string{"key", "key", "key", "key"}, fake.kmsKeys)
+
+	// the size is unknown
+	n, err := s.Save("b/2.txt", strings.NewReader("b2"), -1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	// the upload is aborted if the size mismatches
+	_, err = s.Save("b/3.txt", strings.NewReader("b3"), 3)
+	assert.ErrorContains(t, err, "size mismatch")
+	assert.NotContains(t, fake.objects, "base/b/3.txt")
+
+	info, err := s.Stat("/a/1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "1.txt", info.Name())
+	assert.EqualValues(t, 2, info.Size())
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), info.ModTime().UTC())
+	_, err = s.Stat("nonexistent")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	obj, err := s.Open("b/x 4.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "bx4", string(content))
+	_, err = obj.Seek(1, io.SeekStart)
+	require.NoError(t, err)
+	content, err = io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "x4", string(content))
+	require.NoError(t, obj.Close())
+
+	for dir, expected := range map[string][]string{
+		"a": {"a/1.txt"},
+		"b": {"b/2.txt", "b/x 4.txt"},
+		"":  {"a/1.txt", "ab/1.txt", "b/2.txt", "b/x 4.txt", "empty"},
+	} {
+		var paths []string
+		require.NoError(t, s.IterateObjects(dir, func(path string, obj Object) error {
+			paths = append(paths, path)
+			return nil
+		}))
+		assert.Equal(t, expected, paths, dir)
+	}
+
+	require.NoError(t, s.Delete("a/1.txt"))
+	require.NoError(t, s.Delete("a/1.txt"))
+	_, err = s.Stat("a/1.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestGCSStorageSignedURL(t *testing.T) {
+	server := httptest.NewServer(&fakeGCS{objects: map[string][]byte{}})
+	defer server.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	credsJSON, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "gitea@project.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+		"token_uri":    server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	cfg := &setting.GCSStorageConfig{Endpoint: server.URL, Bucket: "bucket", BasePath: "lfs/", ServeDirectExpiry: time.Hour}
+	s, err := newGCSStorage(t.Context(), cfg, option.WithCredentialsJSON(credsJSON), option.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	u, err := s.URL("a b", "a b", http.MethodGet, nil)
+	require.NoError(t, err)
+	serverURL, _ := url.Parse(server.URL)
+	assert.Equal(t, "http", u.Scheme)
+	assert.Equal(t, serverURL.Host, u.Host)
+	assert.Equal(t, "/bucket/lfs/a%20b", u.EscapedPath())
+	query := u.Query()
+	assert.Equal(t, `attachment; filename="a b"`, query.Get("response-content-disposition"))
+	assert.True(t, strings.HasPrefix(query.Get("X-Goog-Credential"), "gitea@project.iam.gserviceaccount.com/"))
+	expires, _ := strconv.Atoi(query.Get("X-Goog-Expires"))
+	assert.InDelta(t, 3600, expires, 1)
+
+	// verify the signature like gcs does
+	signature, err := hex.DecodeString(query.Get("X-Goog-Signature"))
+	require.NoError(t, err)
+	query.Del("X-Goog-Signature")
+	datetime := query.Get("X-Goog-Date")
+	scope := strings.TrimPrefix(query.Get("X-Goog-Credential"), "gitea@project.iam.gserviceaccount.com/")
+	canonicalRequest := "GET\n/bucket/lfs/a%20b\n" + strings.ReplaceAll(query.Encode(), "+", "%20") + "\nhost:" + serverURL.Hostname() + "\n\nhost\nUNSIGNED-PAYLOAD"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "GOOG4-RSA-SHA256\n" + datetime + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+	hash := sha256.Sum256([]byte(stringToSign))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+}
@@ -278,7 +278,7 @@ func (m *MinioStorage) Delete(path string) error {
 	return convertMinioErr(err)
 }
 
-// URL gets the redirect URL to a file. The presigned link is valid for 5 minutes.
+// URL gets the redirect URL to a file. The presigned link is valid for the configured SERVE_DIRECT_URL_EXPIRY.
 func (m *MinioStorage) URL(path, name, method string, serveDirectReqParams url.Values) (*url.URL, error) {
 	// copy serveDirectReqParams
 	reqParams, err := url.ParseQuery(serveDirectReqParams.Encode())
@@ -287,7 +287,10 @@ func (m *MinioStorage) URL(path, name, method string, serveDirectReqParams url.V
 	}
 	// TODO it may be good to embed images with 'inline' like ServeData does, but we don't want to have to read the file, do we?
 	reqParams.Set("response-content-disposition", "attachment; filename=\""+quoteEscaper.Replace(name)+"\"")
-	expires := 5 * time.Minute
+	expires := m.cfg.ServeDirectExpiry
+	if expires <= 0 {
+		expires = 5 * time.Minute
+	}
 	if method == http.MethodHead {
 		u, err := m.client.PresignedHeadObject(m.ctx, m.bucket, m.buildMinioPath(path), expires, reqParams)
 		return u, convertMinioErr(err)
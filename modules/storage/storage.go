@@ -133,6 +133,8 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+	// ActionsCache represents the storage of the caches saved by actions/cache
+	ActionsCache ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -188,7 +190,7 @@ func initLFS() (err error) {
 		return nil
 	}
 	log.Info("Initialising LFS storage with type: %s", setting.LFS.Storage.Type)
-	LFS, err = NewStorage(setting.LFS.Storage.Type, setting.LFS.Storage)
+	LFS, err = newTieredStorage(setting.LFS.Storage, setting.LFS.ColdTier)
 	return err
 }
 
@@ -200,10 +202,24 @@ func initRepoAvatars() (err error) {
 
 func initRepoArchives() (err error) {
 	log.Info("Initialising Repository Archive storage with type: %s", setting.RepoArchive.Storage.Type)
-	RepoArchives, err = NewStorage(setting.RepoArchive.Storage.Type, setting.RepoArchive.Storage)
+	RepoArchives, err = newTieredStorage(setting.RepoArchive.Storage, setting.RepoArchive.ColdTier)
 	return err
 }
 
+// newTieredStorage creates the storage, which is a TieredStorage if there is a cold tier
+func newTieredStorage(cfg *setting.Storage, coldTier *setting.StorageColdTier) (ObjectStorage, error) {
+	hot, err := NewStorage(cfg.Type, cfg)
+	if err != nil || coldTier == nil {
+		return hot, err
+	}
+	log.Info("Initialising the cold tier with type: %s, objects not accessed for %d days are moved to it", coldTier.Storage.Type, coldTier.AfterDays)
+	cold, err := NewStorage(coldTier.Storage.Type, coldTier.Storage)
+	if err != nil {
+		return nil, err
+	}
+	return NewTieredStorage(hot, cold, coldTier.Storage.ServeDirect()), nil
+}
+
 func initPackages() (err error) {
 	if !setting.Packages.Enabled {
 		Packages = discardStorage("Packages isn't enabled")
@@ -218,6 +234,7 @@ func initActions() (err error) {
 	if !setting.Actions.Enabled {
 		Actions = discardStorage("Actions isn't enabled")
 		ActionsArtifacts = discardStorage("ActionsArtifacts isn't enabled")
+		ActionsCache = discardStorage("ActionsCache isn't enabled")
 		return nil
 	}
 	log.Info("Initialising Actions storage with type: %s", setting.Actions.LogStorage.Type)
@@ -225,6 +242,14 @@ func initActions() (err error) {
 		return err
 	}
 	log.Info("Initialising ActionsArtifacts storage with type: %s", setting.Actions.ArtifactStorage.Type)
-	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
+	if ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage); err != nil {
+		return err
+	}
+	if !setting.Actions.CacheEnabled {
+		ActionsCache = discardStorage("ActionsCache isn't enabled")
+		return nil
+	}
+	log.Info("Initialising ActionsCache storage with type: %s", setting.Actions.CacheStorage.Type)
+	ActionsCache, err = NewStorage(setting.Actions.CacheStorage.Type, setting.Actions.CacheStorage)
 	return err
 }
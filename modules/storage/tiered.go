@@ -0,0 +1,134 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+)
+
+var _ ObjectStorage = &TieredStorage{}
+
+// TieredStorage stores the objects in a hot storage and keeps the objects which haven't been accessed for a while
+// in a cheaper cold storage. The objects are moved between the tiers by MoveToCold and MoveToHot,
+// the objects in the cold storage are read through transparently.
+type TieredStorage struct {
+	hot             ObjectStorage
+	cold            ObjectStorage
+	coldServeDirect bool
+}
+
+// NewTieredStorage returns a tiered storage, the cold storage only redirects to its URLs if coldServeDirect is set
+func NewTieredStorage(hot, cold ObjectStorage, coldServeDirect bool) *TieredStorage {
+	return &TieredStorage{hot: hot, cold: cold, coldServeDirect: coldServeDirect}
+}
+
+// stat finds the tier the object is stored in, the hot one takes precedence while an object is being moved
+func (t *TieredStorage) stat(path string) (ObjectStorage, os.FileInfo, error) {
+	info, err := t.hot.Stat(path)
+	if err == nil {
+		return t.hot, info, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+	info, err = t.cold.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t.cold, info, nil
+}
+
+// Open opens the object from the tier it is stored in
+func (t *TieredStorage) Open(path string) (Object, error) {
+	tier, _, err := t.stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return tier.Open(path)
+}
+
+// Save saves the object to the hot tier
+func (t *TieredStorage) Save(path string, r io.Reader, size int64) (int64, error) {
+	return t.hot.Save(path, r, size)
+}
+
+// Stat returns the info of the object from the tier it is stored in
+func (t *TieredStorage) Stat(path string) (os.FileInfo, error) {
+	_, info, err := t.stat(path)
+	return info, err
+}
+
+// Delete deletes the object from both tiers
+func (t *TieredStorage) Delete(path string) error {
+	var errs []error
+	for _, tier := range []ObjectStorage{t.hot, t.cold} {
+		if err := tier.Delete(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// URL returns the URL of the tier the object is stored in
+func (t *TieredStorage) URL(path, name, method string, reqParams url.Values) (*url.URL, error) {
+	tier, _, err := t.stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if tier == t.cold && !t.coldServeDirect {
+		return nil, ErrURLNotSupported
+	}
+	return tier.URL(path, name, method, reqParams)
+}
+
+// IterateObjects iterates across the objects of both tiers, an object being moved is only visited once
+func (t *TieredStorage) IterateObjects(dirName string, iterator func(path string, obj Object) error) error {
+	if err := t.hot.IterateObjects(dirName, iterator); err != nil {
+		return err
+	}
+	return t.cold.IterateObjects(dirName, func(path string, obj Object) error {
+		if _, err := t.hot.Stat(path); err == nil {
+			return obj.Close()
+		} else if !errors.Is(err, os.ErrNotExist) {
+			_ = obj.Close()
+			return err
+		}
+		return iterator(path, obj)
+	})
+}
+
+// MoveToCold moves the object from the hot tier to the cold tier
+func (t *TieredStorage) MoveToCold(path string) (int64, error) {
+	return moveObject(t.cold, t.hot, path)
+}
+
+// MoveToHot moves the object from the cold tier back to the hot tier
+func (t *TieredStorage) MoveToHot(path string) (int64, error) {
+	return moveObject(t.hot, t.cold, path)
+}
+
+// moveObject copies the object before deleting it from the source, so it's always readable.
+// The object has been moved already if it only exists in the destination.
+func moveObject(dst, src ObjectStorage, path string) (int64, error) {
+	info, err := src.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if info, err = dst.Stat(path); err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	n, err := Copy(dst, path, src, path)
+	if err != nil {
+		return 0, err
+	} else if n != info.Size() {
+		return 0, fmt.Errorf("copied %d bytes of %s instead of %d", n, path, info.Size())
+	}
+	return n, src.Delete(path)
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTieredStorage(t *testing.T) {
+	hot, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	cold, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	s := NewTieredStorage(hot, cold, false)
+
+	for _, p := range []string{"a/1.txt", "a/2.txt", "b/3.txt"} {
+		_, err := s.Save(p, strings.NewReader(p), int64(len(p)))
+		require.NoError(t, err)
+	}
+	_, err = cold.Stat("a/1.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	n, err := s.MoveToCold("a/1.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, n)
+	_, err = hot.Stat("a/1.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	// moving again is a no-op
+	n, err = s.MoveToCold("a/1.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, n)
+	_, err = s.MoveToCold("nonexistent")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// the cold objects are read through
+	info, err := s.Stat("a/1.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, info.Size())
+	obj, err := s.Open("a/1.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "a/1.txt", string(content))
+	require.NoError(t, obj.Close())
+	_, err = s.Open("nonexistent")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = s.URL("a/1.txt", "1.txt", http.MethodGet, nil)
+	assert.ErrorIs(t, err, ErrURLNotSupported)
+
+	// an object being moved is visited once
+	_, err = Copy(cold, "a/2.txt", hot, "a/2.txt")
+	require.NoError(t, err)
+	var paths []string
+	require.NoError(t, s.IterateObjects("a", func(path string, obj Object) error {
+		paths = append(paths, path)
+		return obj.Close()
+	}))
+	assert.ElementsMatch(t, []string{"a/1.txt", "a/2.txt"}, paths)
+
+	require.NoError(t, s.Delete("a/2.txt"))
+	_, err = cold.Stat("a/2.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = s.Stat("a/2.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = s.MoveToHot("a/1.txt")
+	require.NoError(t, err)
+	_, err = hot.Stat("a/1.txt")
+	require.NoError(t, err)
+	_, err = cold.Stat("a/1.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// AdminApprovalRequest represents a destructive admin operation which has to be approved by a second administrator
+type AdminApprovalRequest struct {
+	ID int64 `json:"id"`
+	// enum: delete_user,offboard_user,anonymize_user,delete_org,delete_repo,create_auth_source,edit_auth_source,delete_auth_source
+	Action   string `json:"action"`
+	TargetID int64  `json:"target_id"`
+	// The name of the target when the operation was requested
+	TargetName string `json:"target_name"`
+	Requester  *User  `json:"requester"`
+	// The administrator who has approved or rejected the request
+	Approver *User `json:"approver,omitempty"`
+	// enum: pending,approved,rejected,expired,failed
+	Status string `json:"status"`
+	// The error of a failed operation
+	Message string `json:"message,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Expires time.Time  `json:"expires_at"`
+	Decided *time.Time `json:"decided_at,omitempty"`
+}
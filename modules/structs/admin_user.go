@@ -81,3 +81,23 @@ type EditUserOption struct {
 	// User visibility level: public, limited, or private
 	Visibility string `json:"visibility" binding:"In(,public,limited,private)"`
 }
+
+// OffboardUserOption options when offboarding a user
+type OffboardUserOption struct {
+	// The user or organization the repositories are transferred to, they are archived if it's empty or if a
+	// repository can't be transferred
+	RepoOwner string `json:"repo_owner"`
+	// The user who replaces the offboarded user as assignee of the open issues and pull requests, the offboarded
+	// user is only unassigned if it's empty
+	IssueAssignee string `json:"issue_assignee"`
+}
+
+// OffboardUserResult describes what has been done while offboarding a user
+type OffboardUserResult struct {
+	// The full names of the repositories which have been transferred
+	TransferredRepos []string `json:"transferred_repos"`
+	// The full names of the repositories which have been archived
+	ArchivedRepos []string `json:"archived_repos"`
+	// The number of issues and pull requests which have been assigned to the new assignee
+	ReassignedIssues int `json:"reassigned_issues"`
+}
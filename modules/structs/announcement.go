@@ -0,0 +1,64 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// Announcement represents an instance announcement banner
+type Announcement struct {
+	ID int64 `json:"id"`
+	// enum: info,warning,maintenance
+	Type string `json:"type"`
+	// The markdown content of the banner
+	Content string `json:"content"`
+	// enum: all,org_members,admins
+	Audience string `json:"audience"`
+	// The organization whose members see the banner if the audience is org_members
+	Org string `json:"org,omitempty"`
+	// Whether the signed-in users can hide the banner
+	Dismissible bool `json:"dismissible"`
+	// The time from which the banner is shown, it is shown immediately if it is empty
+	StartTime *time.Time `json:"start_time"`
+	// The time from which the banner isn't shown anymore, it is shown forever if it is empty
+	EndTime *time.Time `json:"end_time"`
+	// Whether the banner is shown now
+	Active bool `json:"active"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateAnnouncementOption options for creating an announcement
+type CreateAnnouncementOption struct {
+	// enum: info,warning,maintenance
+	// required: true
+	Type string `json:"type" binding:"Required"`
+	// required: true
+	Content string `json:"content" binding:"Required"`
+	// enum: all,org_members,admins
+	// required: true
+	Audience string `json:"audience" binding:"Required"`
+	// The organization whose members see the banner, required if the audience is org_members
+	Org string `json:"org"`
+	// default: true
+	Dismissible *bool      `json:"dismissible"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+}
+
+// EditAnnouncementOption options for editing an announcement
+type EditAnnouncementOption struct {
+	// enum: info,warning,maintenance
+	Type    *string `json:"type"`
+	Content *string `json:"content"`
+	// enum: all,org_members,admins
+	Audience    *string `json:"audience"`
+	Org         *string `json:"org"`
+	Dismissible *bool   `json:"dismissible"`
+	// Set a zero time to show the banner immediately
+	StartTime *time.Time `json:"start_time"`
+	// Set a zero time to show the banner forever
+	EndTime *time.Time `json:"end_time"`
+}
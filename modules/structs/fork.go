@@ -10,3 +10,13 @@ type CreateForkOption struct {
 	// name of the forked repository
 	Name *string `json:"name"`
 }
+
+// CreateFederatedForkOption options for forking a repository of another instance
+type CreateFederatedForkOption struct {
+	// URL of the home page of the repository to fork
+	OriginURL string `json:"origin_url" binding:"Required;ValidUrl"`
+	// organization name, if forking into an organization
+	Organization *string `json:"organization"`
+	// name of the forked repository
+	Name string `json:"name" binding:"Required;AlphaDashDot;MaxSize(100)"`
+}
@@ -23,6 +23,12 @@ type Hook struct {
 	Type string `json:"type"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter"`
+	// Path filter pattern, push events are only delivered if a changed file matches
+	PathFilter string `json:"path_filter"`
+	// Label filter pattern, issue and pull request events are only delivered if a label matches
+	LabelFilter string `json:"label_filter"`
+	// Actor filter pattern, events are only delivered if the login of the user who triggered them matches
+	ActorFilter string `json:"actor_filter"`
 	// The URL of the webhook endpoint (hidden in JSON)
 	URL string `json:"-"`
 	// Configuration settings for the webhook
@@ -44,6 +50,41 @@ type Hook struct {
 // HookList represents a list of API hook.
 type HookList []*Hook
 
+// HookDelivery represents a delivery of a webhook
+type HookDelivery struct {
+	// The unique identifier of the delivery
+	ID int64 `json:"id"`
+	// The UUID sent in the X-Gitea-Delivery header
+	UUID string `json:"uuid"`
+	// The event like "push"
+	Event string `json:"event"`
+	// The detailed event type like "pull_request_review_approved"
+	EventType string `json:"event_type"`
+	// The attempt of the delivery, starting at 1
+	Attempt int `json:"attempt"`
+	// The status of the delivery, failed deliveries get retried and are dead-lettered after the last attempt
+	// enum: pending,succeeded,failed,dead_letter
+	Status string `json:"status"`
+	// The HTTP status code of the response
+	StatusCode int `json:"status_code"`
+	// The date and time when the delivery was created or attempted
+	// swagger:strfmt date-time
+	Delivered time.Time `json:"delivered_at"`
+	// The date and time of the next attempt of a failed delivery
+	// swagger:strfmt date-time
+	Scheduled *time.Time `json:"scheduled_at,omitempty"`
+}
+
+// RedeliverHookDeliveriesOption options to redeliver the dead-lettered deliveries of a webhook
+type RedeliverHookDeliveriesOption struct {
+	// Only redeliver the deliveries attempted at or after this time
+	// swagger:strfmt date-time
+	Since time.Time `json:"since"`
+	// Only redeliver the deliveries attempted before this time
+	// swagger:strfmt date-time
+	Before time.Time `json:"before"`
+}
+
 // CreateHookOptionConfig has all config options in it
 // required are "content_type" and "url" Required
 type CreateHookOptionConfig map[string]string
@@ -51,7 +92,7 @@ type CreateHookOptionConfig map[string]string
 // CreateHookOption options when create a hook
 type CreateHookOption struct {
 	// required: true
-	// enum: dingtalk,discord,gitea,gogs,msteams,slack,telegram,feishu,wechatwork,packagist
+	// enum: dingtalk,discord,gitea,gogs,msteams,slack,telegram,feishu,wechatwork,packagist,custom,kafka,nats,matrix
 	// The type of the webhook to create
 	Type string `json:"type" binding:"Required"`
 	// required: true
@@ -61,6 +102,12 @@ type CreateHookOption struct {
 	Events []string `json:"events"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter" binding:"GlobPattern"`
+	// Path filter pattern, push events are only delivered if a changed file matches
+	PathFilter string `json:"path_filter" binding:"GlobPattern"`
+	// Label filter pattern, issue and pull request events are only delivered if a label matches
+	LabelFilter string `json:"label_filter" binding:"GlobPattern"`
+	// Actor filter pattern, events are only delivered if the login of the user who triggered them matches
+	ActorFilter string `json:"actor_filter" binding:"GlobPattern"`
 	// Authorization header to include in webhook requests
 	AuthorizationHeader string `json:"authorization_header"`
 	// default: false
@@ -76,6 +123,12 @@ type EditHookOption struct {
 	Events []string `json:"events"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter" binding:"GlobPattern"`
+	// Path filter pattern, push events are only delivered if a changed file matches
+	PathFilter string `json:"path_filter" binding:"GlobPattern"`
+	// Label filter pattern, issue and pull request events are only delivered if a label matches
+	LabelFilter string `json:"label_filter" binding:"GlobPattern"`
+	// Actor filter pattern, events are only delivered if the login of the user who triggered them matches
+	ActorFilter string `json:"actor_filter" binding:"GlobPattern"`
 	// Authorization header to include in webhook requests
 	AuthorizationHeader string `json:"authorization_header"`
 	// Whether the webhook is active and will be triggered
@@ -379,6 +432,8 @@ const (
 	HookIssueReviewRequested HookIssueAction = "review_requested"
 	// HookIssueReviewRequestRemoved is an issue action for removing a review request to someone on a pull request.
 	HookIssueReviewRequestRemoved HookIssueAction = "review_request_removed"
+	// HookIssueSLABreached is an issue action for when an SLA rule of an issue is breached.
+	HookIssueSLABreached HookIssueAction = "sla_breached"
 )
 
 // IssuePayload represents the payload information that is sent along with an issue event.
@@ -397,6 +452,8 @@ type IssuePayload struct {
 	Sender *User `json:"sender"`
 	// The commit ID related to the issue action
 	CommitID string `json:"commit_id"`
+	// The breached SLA target (for sla_breached actions)
+	SLA *IssueSLAStatus `json:"sla,omitempty"`
 }
 
 // JSONPayload encodes the IssuePayload to JSON, with an indentation of two spaces.
@@ -565,6 +622,25 @@ func (p *WorkflowDispatchPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
 
+// RepositoryDispatchPayload represents a repository dispatch payload
+type RepositoryDispatchPayload struct {
+	// The event type given by the external system
+	Action string `json:"action"`
+	// The branch the workflows are run on, this is always the default branch
+	Branch string `json:"branch"`
+	// The client payload given by the external system
+	ClientPayload map[string]any `json:"client_payload"`
+	// The repository containing the workflows
+	Repository *Repository `json:"repository"`
+	// The user who triggered the repository dispatch
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *RepositoryDispatchPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
 // CommitStatusPayload represents a payload information of commit status event.
 type CommitStatusPayload struct {
 	// TODO: add Branches per https://docs.github.com/en/webhooks/webhook-events-and-payloads#status
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// Indexer represents the status of an indexer
+type Indexer struct {
+	// The name of the indexer: "code", "wiki", "issues", "stats", "dependency" or "symbol"
+	Name string `json:"name"`
+	// The backend of the indexer, e.g. "bleve" or "elasticsearch"
+	Type      string `json:"type"`
+	Enabled   bool   `json:"enabled"`
+	Available bool   `json:"available"`
+	// The number of the items waiting in the queue of the indexer
+	QueueLength int `json:"queue_length"`
+	Workers     int `json:"workers"`
+	// The number of the repositories whose last indexing failed
+	FailedRepos int64 `json:"failed_repos"`
+}
+
+// RepoIndexerStatus represents the status of a repository in an indexer
+type RepoIndexerStatus struct {
+	Indexer    string      `json:"indexer"`
+	Repository *Repository `json:"repository"`
+	// The last indexed commit, it is empty if the repository hasn't been indexed
+	CommitSHA string `json:"commit_sha"`
+	// The commit of the default branch which should be indexed, it is only returned by the status of a repository
+	HeadCommitSHA string `json:"head_commit_sha,omitempty"`
+	// swagger:strfmt date-time
+	Indexed *time.Time `json:"indexed_at"`
+	// The error of the last indexing if it failed
+	Error string `json:"error"`
+	// swagger:strfmt date-time
+	Failed *time.Time `json:"failed_at"`
+}
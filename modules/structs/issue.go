@@ -100,6 +100,10 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// file name of an issue form template of the default branch, the body of the issue is rendered from the form
+	Template string `json:"template"`
+	// values of the fields of the form template by field ID, the values of a dropdown or checkboxes are the labels of the selected options
+	TemplateValues map[string][]string `json:"template_values"`
 }
 
 // EditIssueOption options for editing an issue
@@ -117,6 +121,38 @@ type EditIssueOption struct {
 	RemoveDeadline *bool      `json:"unset_due_date"`
 }
 
+// BulkEditIssuesOption options for editing several issues and pull requests at once
+type BulkEditIssuesOption struct {
+	// indexes of the issues and pull requests to edit
+	// required: true
+	Indexes []int64 `json:"indexes" binding:"Required"`
+	// label IDs or names to add
+	AddLabels []any `json:"add_labels"`
+	// label IDs or names to remove
+	RemoveLabels []any `json:"remove_labels"`
+	// usernames to add to the assignees
+	AddAssignees []string `json:"add_assignees"`
+	// usernames to remove from the assignees
+	RemoveAssignees []string `json:"remove_assignees"`
+	// milestone to move to, 0 to remove the milestone
+	Milestone *int64 `json:"milestone"`
+	// enum: open,closed
+	State *string `json:"state"`
+	// comment to post
+	Comment string `json:"comment"`
+}
+
+// BulkEditIssueResult the result of the bulk edit of an issue or pull request
+type BulkEditIssueResult struct {
+	Index int64 `json:"index"`
+	// HTTP status code of the edit of this issue
+	Status int `json:"status"`
+	// the error if the issue couldn't be edited
+	Message string `json:"message,omitempty"`
+	// the edited issue
+	Issue *Issue `json:"issue,omitempty"`
+}
+
 // EditDeadlineOption options for creating a deadline
 type EditDeadlineOption struct {
 	// required:true
@@ -274,3 +310,10 @@ type IssueMeta struct {
 type LockIssueOption struct {
 	Reason string `json:"lock_reason"`
 }
+
+// SimilarIssue represents an open issue similar to an issue being created
+type SimilarIssue struct {
+	Issue *Issue `json:"issue"`
+	// similarity between 0 and 1
+	Score float64 `json:"score"`
+}
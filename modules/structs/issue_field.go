@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// IssueField a custom field of the issues and pull requests of a repository or an organization
+// swagger:model
+type IssueField struct {
+	// ID is the unique identifier for the field
+	ID int64 `json:"id"`
+	// Name is the display name of the field
+	Name string `json:"name"`
+	// Description provides additional context about the field's purpose
+	Description string `json:"description"`
+	// Type is the type of the values of the field
+	// enum: text,number,enum,date,user
+	Type string `json:"type"`
+	// Options are the allowed values of an enum field
+	Options []string `json:"options"`
+	// IsOrgField indicates if the field belongs to the organization of the repository
+	IsOrgField bool `json:"is_org_field"`
+}
+
+// CreateIssueFieldOption options for creating a custom field
+type CreateIssueFieldOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required"`
+	// Description provides additional context about the field's purpose
+	Description string `json:"description"`
+	// required:true
+	// enum: text,number,enum,date,user
+	Type string `json:"type" binding:"Required"`
+	// Options are the allowed values of an enum field
+	Options []string `json:"options"`
+}
+
+// EditIssueFieldOption options for editing a custom field, the type of a field can't be changed
+type EditIssueFieldOption struct {
+	// Name is the new display name for the field
+	Name *string `json:"name"`
+	// Description provides additional context about the field's purpose
+	Description *string `json:"description"`
+	// Options are the allowed values of an enum field, the values which aren't options anymore are removed
+	Options []string `json:"options"`
+}
+
+// IssueFieldValue the value of a custom field of an issue or a pull request
+// swagger:model
+type IssueFieldValue struct {
+	// FieldID is the ID of the custom field
+	FieldID int64 `json:"field_id"`
+	// Name is the name of the custom field
+	Name string `json:"name"`
+	// Type is the type of the custom field
+	Type string `json:"type"`
+	// Value is the value of the field, a date is formatted as YYYY-MM-DD and a user is a username
+	Value string `json:"value"`
+}
+
+// EditIssueFieldValuesOption options for setting the values of the custom fields of an issue or a pull request
+type EditIssueFieldValuesOption struct {
+	// Fields maps the names of the custom fields to their new values, an empty value removes the value of a field
+	// required:true
+	Fields map[string]string `json:"fields" binding:"Required"`
+}
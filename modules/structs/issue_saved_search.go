@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// IssueSavedSearch a named filter of the issue list of a repository, saved by a user or shared by an organization
+// swagger:model
+type IssueSavedSearch struct {
+	// ID is the unique identifier for the search
+	ID int64 `json:"id"`
+	// Name is the display name of the search
+	Name string `json:"name"`
+	// Query is the query string of the issue list, e.g. state=open&labels=1
+	Query string `json:"query"`
+	// Type is the type of the issues of the search
+	// enum: issues,pulls
+	Type string `json:"type"`
+	// Shared indicates if the search is shared by the organization with its members
+	Shared bool `json:"shared"`
+	// HTMLURL is the URL of the issue list of the repository with the search applied
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+// CreateIssueSavedSearchOption options for saving a search of the issue list
+type CreateIssueSavedSearchOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required"`
+	// Query is the query string of the issue list, the page and the unknown parameters are dropped
+	Query string `json:"query"`
+	// Type is the type of the issues of the search, defaults to issues
+	// enum: issues,pulls
+	Type string `json:"type"`
+	// Shared saves the search for all the members of the organization of the repository, only for organization owners
+	Shared bool `json:"shared"`
+}
+
+// EditIssueSavedSearchOption options for editing a saved search
+type EditIssueSavedSearchOption struct {
+	// Name is the new display name for the search
+	Name *string `json:"name"`
+	// Query is the new query string of the issue list
+	Query *string `json:"query"`
+}
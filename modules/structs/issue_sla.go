@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// IssueSLARule a service level rule of the issues of a repository having some labels
+// swagger:model
+type IssueSLARule struct {
+	// ID is the unique identifier for the rule
+	ID int64 `json:"id"`
+	// Name is the display name of the rule
+	Name string `json:"name"`
+	// Labels are the IDs of the labels an issue must have to match the rule, none for all the issues
+	Labels []int64 `json:"labels"`
+	// ExemptLabels are the IDs of the labels of the issues excluded from the rule
+	ExemptLabels []int64 `json:"exempt_labels"`
+	// ResponseHours is the time in hours to the first comment of another user than the poster, 0 for none
+	ResponseHours int `json:"response_hours"`
+	// ResolutionHours is the time in hours to the closing of an issue, 0 for none
+	ResolutionHours int `json:"resolution_hours"`
+	// StaleDays is the number of days without activity after which an open issue is nudged, 0 for none
+	StaleDays int `json:"stale_days"`
+}
+
+// CreateIssueSLARuleOption options for creating an SLA rule
+type CreateIssueSLARuleOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required"`
+	// Labels are the IDs of the labels an issue must have to match the rule, none for all the issues
+	Labels []int64 `json:"labels"`
+	// ExemptLabels are the IDs of the labels of the issues excluded from the rule
+	ExemptLabels []int64 `json:"exempt_labels"`
+	// ResponseHours is the time in hours to the first comment of another user than the poster, 0 for none
+	ResponseHours int `json:"response_hours"`
+	// ResolutionHours is the time in hours to the closing of an issue, 0 for none
+	ResolutionHours int `json:"resolution_hours"`
+	// StaleDays is the number of days without activity after which an open issue is nudged, 0 for none
+	StaleDays int `json:"stale_days"`
+}
+
+// EditIssueSLARuleOption options for editing an SLA rule
+type EditIssueSLARuleOption struct {
+	// Name is the new display name for the rule
+	Name *string `json:"name"`
+	// Labels are the IDs of the labels an issue must have to match the rule
+	Labels []int64 `json:"labels"`
+	// ExemptLabels are the IDs of the labels of the issues excluded from the rule
+	ExemptLabels []int64 `json:"exempt_labels"`
+	// ResponseHours is the time in hours to the first comment of another user than the poster, 0 for none
+	ResponseHours *int `json:"response_hours"`
+	// ResolutionHours is the time in hours to the closing of an issue, 0 for none
+	ResolutionHours *int `json:"resolution_hours"`
+	// StaleDays is the number of days without activity after which an open issue is nudged, 0 for none
+	StaleDays *int `json:"stale_days"`
+}
+
+// IssueSLAStatus the state of a target of an SLA rule for an issue
+// swagger:model
+type IssueSLAStatus struct {
+	// RuleID is the ID of the SLA rule
+	RuleID int64 `json:"rule_id"`
+	// RuleName is the name of the SLA rule
+	RuleName string `json:"rule_name"`
+	// Kind is the target of the rule
+	// enum: response,resolution
+	Kind string `json:"kind"`
+	// State is the state of the target
+	// enum: pending,met,breached
+	State string `json:"state"`
+	// swagger:strfmt date-time
+	Due time.Time `json:"due"`
+	// swagger:strfmt date-time
+	Completed *time.Time `json:"completed,omitempty"`
+}
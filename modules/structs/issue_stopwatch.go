@@ -24,6 +24,8 @@ type StopWatch struct {
 	RepoOwnerName string `json:"repo_owner_name"`
 	// RepoName is the name of the repository
 	RepoName string `json:"repo_name"`
+	// UserName is the name of the user running the stopwatch
+	UserName string `json:"user_name"`
 }
 
 // StopWatches represent a list of stopwatches
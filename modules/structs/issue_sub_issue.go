@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// SubIssue an issue of a tree of sub-issues with its own sub-issues
+// swagger:model
+type SubIssue struct {
+	// ID is the unique identifier for the issue
+	ID int64 `json:"id"`
+	// Index is the number of the issue in its repository
+	Index int64 `json:"number"`
+	// Title is the title of the issue
+	Title string `json:"title"`
+	// State is the state of the issue
+	State StateType `json:"state"`
+	// HTMLURL is the web URL of the issue
+	HTMLURL string `json:"html_url"`
+	// Repo is the repository of the issue
+	Repo *RepositoryMeta `json:"repository"`
+	// Progress is the progress of the sub-issues of the issue at all depths
+	Progress SubIssueProgress `json:"progress"`
+	// SubIssues are the sub-issues of the issue
+	SubIssues []*SubIssue `json:"sub_issues"`
+}
+
+// SubIssueProgress the progress of a tree of sub-issues
+type SubIssueProgress struct {
+	// Total is the number of the sub-issues at all depths
+	Total int `json:"total"`
+	// Closed is the number of the closed sub-issues at all depths
+	Closed int `json:"closed"`
+	// Percent is the percentage of the closed sub-issues
+	Percent int `json:"percent"`
+}
+
+// IssueHierarchy the parent and the tree of sub-issues of an issue
+// swagger:model
+type IssueHierarchy struct {
+	// Parent is the parent issue, null if the issue has no parent
+	Parent *Issue `json:"parent"`
+	// Progress is the progress of the sub-issues at all depths
+	Progress SubIssueProgress `json:"progress"`
+	// SubIssues are the sub-issues of the issue
+	SubIssues []*SubIssue `json:"sub_issues"`
+}
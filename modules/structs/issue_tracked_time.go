@@ -38,3 +38,53 @@ type TrackedTime struct {
 
 // TrackedTimeList represents a list of tracked times
 type TrackedTimeList []*TrackedTime
+
+// TrackedTimeSum tracked time summed for a user, a repository, a milestone or an issue,
+// only the fields of the dimension of the report are set
+type TrackedTimeSum struct {
+	// UserName is the name of the user, when summed by user
+	UserName string `json:"user_name,omitempty"`
+	// Repository is the full name of the repository, when summed by repository, milestone or issue
+	Repository string `json:"repository,omitempty"`
+	// MilestoneID is the ID of the milestone, 0 for the issues without milestone, when summed by milestone
+	MilestoneID int64 `json:"milestone_id,omitempty"`
+	// Milestone is the name of the milestone, when summed by milestone
+	Milestone string `json:"milestone,omitempty"`
+	// IssueIndex is the index of the issue, when summed by issue
+	IssueIndex int64 `json:"issue_index,omitempty"`
+	// IssueTitle is the title of the issue, when summed by issue
+	IssueTitle string `json:"issue_title,omitempty"`
+	// Time is the tracked time in seconds
+	Time int64 `json:"time"`
+	// Duration is a human-readable duration string
+	Duration string `json:"duration"`
+}
+
+// TimeTrackingRule restricts who may track time on the repositories of an organization,
+// the owners of the organization always may
+type TimeTrackingRule struct {
+	// ID is the unique identifier of the rule
+	ID int64 `json:"id"`
+	// Repository is the name of the repository of the rule, empty for the rule of the repositories without their own rule
+	Repository string `json:"repository"`
+	// Teams are the IDs of the teams whose members may track time
+	Teams []int64 `json:"teams"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateTimeTrackingRuleOption options for creating a time tracking rule of an organization
+type CreateTimeTrackingRuleOption struct {
+	// Repository is the name of the repository of the rule, empty for the rule of the repositories without their own rule
+	Repository string `json:"repository"`
+	// Teams are the IDs of the teams whose members may track time, none for the owners only
+	Teams []int64 `json:"teams"`
+}
+
+// EditTimeTrackingRuleOption options for editing a time tracking rule of an organization
+type EditTimeTrackingRuleOption struct {
+	// Teams are the IDs of the teams whose members may track time, none for the owners only
+	Teams []int64 `json:"teams"`
+}
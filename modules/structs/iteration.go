@@ -0,0 +1,117 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// Iteration a date-bounded time box, a sprint, of the issues of a repository or of all the repositories of an organization
+// swagger:model
+type Iteration struct {
+	// ID is the unique identifier for the iteration
+	ID int64 `json:"id"`
+	// Name is the display name of the iteration
+	Name string `json:"name"`
+	// Description provides details about the iteration
+	Description string `json:"description"`
+	// swagger:strfmt date-time
+	Start time.Time `json:"start"`
+	// swagger:strfmt date-time
+	End time.Time `json:"end"`
+	// Capacity is the planned work of the iteration in seconds of time estimate, 0 if it isn't planned
+	Capacity int64 `json:"capacity"`
+	// State indicates if the iteration is open or closed
+	State StateType `json:"state"`
+	// IsOrgIteration indicates if the iteration belongs to the organization of the repository
+	IsOrgIteration bool `json:"is_org_iteration"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+	// swagger:strfmt date-time
+	Closed *time.Time `json:"closed_at"`
+}
+
+// CreateIterationOption options for creating an iteration
+type CreateIterationOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required"`
+	// Description provides details about the iteration
+	Description string `json:"description"`
+	// required:true
+	// swagger:strfmt date-time
+	Start time.Time `json:"start" binding:"Required"`
+	// required:true
+	// swagger:strfmt date-time
+	End time.Time `json:"end" binding:"Required"`
+	// Capacity is the planned work of the iteration in seconds of time estimate
+	Capacity int64 `json:"capacity"`
+}
+
+// EditIterationOption options for editing an iteration
+type EditIterationOption struct {
+	// Name is the new display name of the iteration
+	Name *string `json:"name"`
+	// Description provides details about the iteration
+	Description *string `json:"description"`
+	// swagger:strfmt date-time
+	Start *time.Time `json:"start"`
+	// swagger:strfmt date-time
+	End *time.Time `json:"end"`
+	// Capacity is the planned work of the iteration in seconds of time estimate
+	Capacity *int64 `json:"capacity"`
+}
+
+// CloseIterationOption options for closing an iteration
+type CloseIterationOption struct {
+	// CarryOverTo is the ID of the open iteration the open issues are carried over to, they go back to the backlog if it's 0
+	CarryOverTo int64 `json:"carry_over_to"`
+}
+
+// SetIssueIterationOption options for moving an issue to an iteration
+type SetIssueIterationOption struct {
+	// IterationID is the ID of an open iteration of the repository or of its organization
+	// required:true
+	IterationID int64 `json:"iteration_id" binding:"Required"`
+}
+
+// IterationProgress the remaining and the completed work of an iteration at a time
+type IterationProgress struct {
+	// swagger:strfmt date-time
+	Time time.Time `json:"time"`
+	// OpenIssues is the number of the open issues of the iteration
+	OpenIssues int `json:"open_issues"`
+	// ClosedIssues is the number of the closed issues of the iteration
+	ClosedIssues int `json:"closed_issues"`
+	// RemainingEstimate is the time estimate of the open issues in seconds
+	RemainingEstimate int64 `json:"remaining_estimate"`
+	// CompletedEstimate is the time estimate of the closed issues in seconds
+	CompletedEstimate int64 `json:"completed_estimate"`
+}
+
+// IterationBurndown the burndown series of an iteration
+// swagger:model
+type IterationBurndown struct {
+	// Iteration is the iteration
+	Iteration *Iteration `json:"iteration"`
+	// Series is the progress at the start of the iteration, at the same time of each following day and at its end,
+	// or until now if it isn't over
+	Series []*IterationProgress `json:"series"`
+}
+
+// IterationVelocity the committed and the completed work of an iteration
+// swagger:model
+type IterationVelocity struct {
+	// Iteration is the iteration
+	Iteration *Iteration `json:"iteration"`
+	// CommittedIssues is the number of the issues which have been part of the iteration
+	CommittedIssues int `json:"committed_issues"`
+	// CommittedEstimate is the time estimate of the committed issues in seconds
+	CommittedEstimate int64 `json:"committed_estimate"`
+	// CompletedIssues is the number of the issues closed in the iteration
+	CompletedIssues int `json:"completed_issues"`
+	// CompletedEstimate is the time estimate of the completed issues in seconds
+	CompletedEstimate int64 `json:"completed_estimate"`
+	// CarriedOverIssues is the number of the open issues carried over to another iteration when it was closed
+	CarriedOverIssues int `json:"carried_over_issues"`
+}
@@ -79,3 +79,25 @@ type LFSLockDeleteRequest struct {
 	// Whether to force delete the lock even if not owned by the requester
 	Force bool `json:"force"`
 }
+
+// RepoLFSLock represents a LFS lock for the administration of the locks
+type RepoLFSLock struct {
+	ID int64 `json:"id"`
+	// The file path that is locked
+	Path string `json:"path"`
+	// The user who holds the lock
+	Owner *User `json:"owner"`
+	// The repository of the locked file
+	Repo *RepositoryMeta `json:"repository"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// DeleteLFSLocksOption options when force-unlocking LFS locks in bulk,
+// at least one of the IDs and the owner is required and the locks matching all the given ones are unlocked
+type DeleteLFSLocksOption struct {
+	// The IDs of the locks to unlock
+	IDs []int64 `json:"ids"`
+	// The name of the user whose locks are all unlocked
+	Owner string `json:"owner"`
+}
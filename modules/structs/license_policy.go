@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// LicensePolicy denies licenses in the repositories of an organization
+type LicensePolicy struct {
+	// The denied SPDX license identifiers
+	DeniedLicenses []string `json:"denied_licenses"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// EditLicensePolicyOption options for setting the license policy of an organization
+type EditLicensePolicyOption struct {
+	// The denied SPDX license identifiers, "GPL-3.0" denies "GPL-3.0-only" and "GPL-3.0-or-later" too
+	// required: true
+	DeniedLicenses []string `json:"denied_licenses" binding:"Required"`
+}
+
+// LicenseViolation represents a license of a repository denied by the license policy of the organization
+type LicenseViolation struct {
+	// The license file of the code or the manifest of the dependency
+	Path string `json:"path"`
+	// The name of the dependency, it is empty for the license of the code
+	Package string `json:"package"`
+	Version string `json:"version"`
+	// The SPDX license expression
+	License string `json:"license"`
+}
+
+// LicenseCompliance represents the compliance of the default branch of a repository with the license policy of the organization
+type LicenseCompliance struct {
+	// Whether the organization has a license policy
+	HasPolicy bool `json:"has_policy"`
+	// The licenses detected in the license file of the repository
+	Licenses   []string            `json:"licenses"`
+	Violations []*LicenseViolation `json:"violations"`
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// MaintenanceMode represents the read-only maintenance mode of the instance
+type MaintenanceMode struct {
+	// Whether the read-only mode is enabled by the admin toggle
+	ReadOnly bool `json:"read_only"`
+	// The message shown to the users, a default message is used if it is empty
+	Message string `json:"message"`
+	// The start of the scheduled read-only window
+	StartTime *time.Time `json:"start_time"`
+	// The end of the scheduled read-only window, the window has no end if it is empty
+	EndTime *time.Time `json:"end_time"`
+	// Whether the instance is read-only now, by the toggle or the scheduled window
+	Active bool `json:"active"`
+}
+
+// EditMaintenanceModeOption options for editing the maintenance mode
+type EditMaintenanceModeOption struct {
+	ReadOnly *bool   `json:"read_only"`
+	Message  *string `json:"message"`
+	// The start of the scheduled read-only window, set a zero time to remove the window
+	StartTime *time.Time `json:"start_time"`
+	// The end of the scheduled read-only window, set a zero time to remove the end
+	EndTime *time.Time `json:"end_time"`
+}
@@ -17,6 +17,10 @@ type CreatePushMirrorOption struct {
 	Interval string `json:"interval"`
 	// Whether to sync on every commit
 	SyncOnCommit bool `json:"sync_on_commit"`
+	// Comma separated branch patterns to push, eg: `main, release/*`, empty means all refs
+	BranchFilter string `json:"branch_filter"`
+	// Whether to push the LFS objects, defaults to true
+	LFS *bool `json:"lfs"`
 }
 
 // PushMirror represents information of a push mirror
@@ -38,4 +42,24 @@ type PushMirror struct {
 	Interval string `json:"interval"`
 	// Whether to sync on every commit
 	SyncOnCommit bool `json:"sync_on_commit"`
+	// Comma separated branch patterns to push, empty means all refs
+	BranchFilter string `json:"branch_filter"`
+	// Whether to push the LFS objects
+	LFS bool `json:"lfs"`
+	// The status of the last sync
+	Status *PushMirrorStatus `json:"status"`
+}
+
+// PushMirrorStatus represents the status of the last sync of a push mirror
+// swagger:model
+type PushMirrorStatus struct {
+	// The state of the last sync
+	// enum: pending,success,failure
+	State string `json:"state"`
+	// swagger:strfmt date-time
+	LastUpdate *time.Time `json:"last_update"`
+	// swagger:strfmt date-time
+	LastSuccess *time.Time `json:"last_success"`
+	// The error message of the last sync, empty if it succeeded
+	LastError string `json:"last_error"`
 }
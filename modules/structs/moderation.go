@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// AbuseReport represents a report about an issue, a comment, a repository or a user
+type AbuseReport struct {
+	ID int64 `json:"id"`
+	// enum: open,resolved
+	Status string `json:"status"`
+	// enum: user,repo,issue,comment
+	ContentType string `json:"content_type"`
+	ContentID   int64  `json:"content_id"`
+	// The URL of the content, empty if the content doesn't exist anymore
+	ContentURL string `json:"content_url"`
+	// Whether the reported content is hidden by a moderator and can be unhidden
+	ContentHidden bool `json:"content_hidden"`
+	// A copy of the content when it was reported
+	ContentSnapshot string `json:"content_snapshot"`
+	// The reported user, the owner of the reported repository or the poster of the reported issue or comment
+	Owner    *User `json:"owner"`
+	Reporter *User `json:"reporter"`
+	// enum: spam,malware,harassment,illegal,inappropriate,other
+	Category string `json:"category"`
+	Remarks  string `json:"remarks"`
+	// The action taken by the moderator who has resolved the report
+	// enum: none,hide,warn,suspend
+	Action            string `json:"action,omitempty"`
+	ResolutionMessage string `json:"resolution_message,omitempty"`
+	Resolver          *User  `json:"resolver,omitempty"`
+	// swagger:strfmt date-time
+	Created  time.Time  `json:"created_at"`
+	Resolved *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ResolveAbuseReportOption options for resolving an abuse report
+type ResolveAbuseReportOption struct {
+	// The resolution template whose action and message are used, action and message are ignored if it is set
+	TemplateID int64 `json:"template_id"`
+	// Hide hides issues, pull requests and comments until they are unhidden, it makes repositories and user profiles private
+	// enum: none,hide,warn,suspend
+	Action string `json:"action"`
+	// The message sent to the owner of the content, it is required to warn the owner
+	Message string `json:"message"`
+}
+
+// ResolutionTemplate represents a predefined resolution of abuse reports
+type ResolutionTemplate struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// enum: none,hide,warn,suspend
+	Action  string `json:"action"`
+	Message string `json:"message"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateResolutionTemplateOption options for creating a resolution template
+type CreateResolutionTemplateOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// required: true
+	// enum: none,hide,warn,suspend
+	Action  string `json:"action" binding:"Required"`
+	Message string `json:"message"`
+}
@@ -49,6 +49,18 @@ type NotificationCount struct {
 	New int64 `json:"new"`
 }
 
+// NotificationSettings represents the notification settings of a user
+type NotificationSettings struct {
+	// Whether the user is notified only of the issues and pull requests they participate in
+	// unless they watch a repository explicitly, such users don't watch repositories automatically
+	ParticipatingOnly bool `json:"participating_only"`
+}
+
+// EditNotificationSettingsOption options when editing the notification settings of a user
+type EditNotificationSettingsOption struct {
+	ParticipatingOnly *bool `json:"participating_only"`
+}
+
 // NotifySubjectType represent type of notification subject
 type NotifySubjectType string
 
@@ -25,6 +25,8 @@ type Organization struct {
 	Visibility string `json:"visibility"`
 	// Whether repository administrators can change team access
 	RepoAdminChangeTeamAccess bool `json:"repo_admin_change_team_access"`
+	// Whether instance administrators are prevented from signing in as members of the organization
+	DisallowImpersonation bool `json:"disallow_impersonation"`
 	// username of the organization
 	// deprecated
 	UserName string `json:"username"`
@@ -85,6 +87,8 @@ type EditOrgOption struct {
 	Visibility string `json:"visibility" binding:"In(,public,limited,private)"`
 	// Whether repository administrators can change team access
 	RepoAdminChangeTeamAccess *bool `json:"repo_admin_change_team_access"`
+	// Whether instance administrators are prevented from signing in as members of the organization
+	DisallowImpersonation *bool `json:"disallow_impersonation"`
 }
 
 // RenameOrgOption options when renaming an organization
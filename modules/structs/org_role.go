@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// OrgRole a custom role of an organization composed from the permission catalog, it grants its permissions on all
+// repositories of the organization
+// swagger:model
+type OrgRole struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// example: ["code.read","releases.write","repo.create"]
+	Permissions []string `json:"permissions"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// OrgRoleAssignment an assignment of a custom role to a member or a team of an organization
+// swagger:model
+type OrgRoleAssignment struct {
+	ID     int64 `json:"id"`
+	RoleID int64 `json:"role_id"`
+	// User is set if the role is assigned to a member
+	User *User `json:"user,omitempty"`
+	// Team is set if the role is assigned to a team
+	Team *Team `json:"team,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateOrgRoleOption options for creating a custom role of an organization
+type CreateOrgRoleOption struct {
+	// required:true
+	Name        string `json:"name" binding:"Required;MaxSize(255)"`
+	Description string `json:"description"`
+	// Permissions are keys of the permission catalog
+	// example: ["code.read","releases.write","repo.create"]
+	Permissions []string `json:"permissions"`
+}
+
+// EditOrgRoleOption options for editing a custom role of an organization
+type EditOrgRoleOption struct {
+	Name        *string `json:"name" binding:"MaxSize(255)"`
+	Description *string `json:"description"`
+	// Permissions replace the permissions of the role if set
+	// example: ["code.read","releases.write","repo.create"]
+	Permissions []string `json:"permissions"`
+}
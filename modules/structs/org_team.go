@@ -25,6 +25,8 @@ type Team struct {
 	UnitsMap map[string]string `json:"units_map"`
 	// Whether the team can create repositories in the organization
 	CanCreateOrgRepo bool `json:"can_create_org_repo"`
+	// The parent team, 0 for a top level team. The team inherits the repository permissions of its ancestors.
+	ParentID int64 `json:"parent_id"`
 }
 
 // CreateTeamOption options for creating a team
@@ -44,6 +46,8 @@ type CreateTeamOption struct {
 	UnitsMap map[string]string `json:"units_map"`
 	// Whether the team can create repositories in the organization
 	CanCreateOrgRepo bool `json:"can_create_org_repo"`
+	// The parent team, the team is created at the top level if it is empty
+	ParentID int64 `json:"parent_id"`
 }
 
 // EditTeamOption options for editing a team
@@ -63,4 +67,6 @@ type EditTeamOption struct {
 	UnitsMap map[string]string `json:"units_map"`
 	// Whether the team can create repositories in the organization
 	CanCreateOrgRepo *bool `json:"can_create_org_repo"`
+	// Move the team below this team, 0 moves it to the top level
+	ParentID *int64 `json:"parent_id"`
 }
@@ -47,3 +47,119 @@ type PackageFile struct {
 	// The SHA512 hash of the package file
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageCleanupRule represents a rule which removes package versions
+type PackageCleanupRule struct {
+	// The unique identifier of the rule
+	ID int64 `json:"id"`
+	// Whether the rule is executed by the cleanup cron task
+	Enabled bool `json:"enabled"`
+	// The type of the packages the rule applies to
+	Type string `json:"type"`
+	// The repository the rule is limited to, the rule applies to all packages of the owner if empty
+	Repository *Repository `json:"repository"`
+	// How many of the most recent versions per package are kept
+	KeepCount int `json:"keep_count"`
+	// Versions matching the regular expression are kept
+	KeepPattern string `json:"keep_pattern"`
+	// Only versions older than the number of days are removed
+	RemoveDays int `json:"remove_days"`
+	// Only versions matching the regular expression are removed
+	RemovePattern string `json:"remove_pattern"`
+	// Whether the patterns match the full package name and version instead of the version only
+	MatchFullName bool `json:"match_full_name"`
+	// Pre-release versions older than the number of days are removed, 0 disables the removal
+	RemovePrereleaseDays int `json:"remove_prerelease_days"`
+	// Untagged container manifests older than the number of days are removed, 0 disables the removal
+	RemoveUntaggedDays int `json:"remove_untagged_days"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreatePackageCleanupRuleOption options for creating a package cleanup rule
+type CreatePackageCleanupRuleOption struct {
+	// required: true
+	Type string `json:"type" binding:"Required"`
+	// Name of the repository of the owner the rule is limited to
+	RepoName             string `json:"repo_name"`
+	Enabled              bool   `json:"enabled"`
+	KeepCount            int    `json:"keep_count"`
+	KeepPattern          string `json:"keep_pattern"`
+	RemoveDays           int    `json:"remove_days"`
+	RemovePattern        string `json:"remove_pattern"`
+	MatchFullName        bool   `json:"match_full_name"`
+	RemovePrereleaseDays int    `json:"remove_prerelease_days"`
+	RemoveUntaggedDays   int    `json:"remove_untagged_days"`
+}
+
+// EditPackageCleanupRuleOption options for editing a package cleanup rule
+type EditPackageCleanupRuleOption struct {
+	Enabled              *bool   `json:"enabled"`
+	KeepCount            *int    `json:"keep_count"`
+	KeepPattern          *string `json:"keep_pattern"`
+	RemoveDays           *int    `json:"remove_days"`
+	RemovePattern        *string `json:"remove_pattern"`
+	MatchFullName        *bool   `json:"match_full_name"`
+	RemovePrereleaseDays *int    `json:"remove_prerelease_days"`
+	RemoveUntaggedDays   *int    `json:"remove_untagged_days"`
+}
+
+// ContainerArtifact represents a signature, attestation or other artifact attached to a container image
+type ContainerArtifact struct {
+	// The kind of the artifact: signature, attestation, sbom or other
+	Kind string `json:"kind"`
+	// The digest of the manifest of the artifact
+	Digest       string `json:"digest"`
+	MediaType    string `json:"media_type"`
+	ArtifactType string `json:"artifact_type"`
+	Size         int64  `json:"size"`
+	// The tag the artifact is stored as by cosign, empty if the artifact is attached as referrer
+	Tag         string            `json:"tag"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// ContainerSignatureStatus represents the artifacts attached to a container image version.
+// The signatures are not verified by the registry.
+type ContainerSignatureStatus struct {
+	// The digest of the manifest of the image version
+	Digest string `json:"digest"`
+	// Whether at least one signature is attached
+	Signed bool `json:"signed"`
+	// Whether at least one attestation is attached
+	Attested bool `json:"attested"`
+	// Whether at least one SBOM is attached
+	HasSBOM   bool                 `json:"has_sbom"`
+	Artifacts []*ContainerArtifact `json:"artifacts"`
+}
+
+// PackageVulnerability represents a vulnerability found by the scan of a package version
+type PackageVulnerability struct {
+	// The identifier of the vulnerability, e.g. a CVE or GHSA id
+	ID string `json:"id"`
+	// The scanned file or image layer the vulnerability was found in
+	Target           string `json:"target"`
+	PackageName      string `json:"package_name"`
+	InstalledVersion string `json:"installed_version"`
+	FixedVersion     string `json:"fixed_version"`
+	// UNKNOWN, LOW, MEDIUM, HIGH or CRITICAL
+	Severity string `json:"severity"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+}
+
+// PackageVulnerabilityReport represents the result of the last vulnerability scan of a package version
+type PackageVulnerabilityReport struct {
+	// The state of the scan: pending, done, failed or skipped
+	Status string `json:"status"`
+	// swagger:strfmt date-time
+	ScannedAt *time.Time `json:"scanned_at,omitempty"`
+	// The error of the last scan if it failed, the vulnerabilities are the ones of the last successful scan
+	Error string `json:"error,omitempty"`
+	// The highest severity of the vulnerabilities, empty if there are none
+	MaxSeverity string `json:"max_severity"`
+	// The number of vulnerabilities per severity
+	Counts          map[string]int          `json:"counts"`
+	Vulnerabilities []*PackageVulnerability `json:"vulnerabilities"`
+}
@@ -142,6 +142,18 @@ type CreatePullRequestOption struct {
 	TeamReviewers []string `json:"team_reviewers"`
 }
 
+// CreateFederatedPullRequestOption options when offering a pull request to the origin of a repository forked from another instance
+type CreateFederatedPullRequestOption struct {
+	// The branch of the repository to merge
+	Head string `json:"head" binding:"Required"`
+	// The branch of the origin repository to merge into
+	Base string `json:"base" binding:"Required"`
+	// The title of the pull request
+	Title string `json:"title" binding:"Required"`
+	// The description body of the pull request
+	Body string `json:"body"`
+}
+
 // EditPullRequestOption options when modify pull request
 type EditPullRequestOption struct {
 	// The new title for the pull request
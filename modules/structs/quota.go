@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// QuotaSizes represents storage sizes in bytes per category
+type QuotaSizes struct {
+	// Total size, for limits it applies to the sum of all categories
+	Total int64 `json:"total"`
+	// Size of the git repositories
+	Git int64 `json:"git"`
+	// Size of the LFS objects
+	LFS int64 `json:"lfs"`
+	// Size of the packages
+	Packages int64 `json:"packages"`
+	// Size of the issue, comment and release attachments
+	Attachments int64 `json:"attachments"`
+	// Size of the Actions artifacts
+	Artifacts int64 `json:"artifacts"`
+}
+
+// QuotaInfo represents the storage quota of a user or an organization
+type QuotaInfo struct {
+	// Whether the quota is enforced on this instance
+	Enabled bool `json:"enabled"`
+	// Whether the limits are overridden by an admin instead of using the defaults
+	Overridden bool `json:"overridden"`
+	// The limits in bytes, a negative value means no limit
+	Limits QuotaSizes `json:"limits"`
+	// The used storage in bytes
+	Usage QuotaSizes `json:"usage"`
+}
+
+// SetQuotaOption options for overriding the storage quota of a user or an organization
+// Omitted limits keep their current value, a negative value means no limit.
+type SetQuotaOption struct {
+	Total       *int64 `json:"total"`
+	Git         *int64 `json:"git"`
+	LFS         *int64 `json:"lfs"`
+	Packages    *int64 `json:"packages"`
+	Attachments *int64 `json:"attachments"`
+	Artifacts   *int64 `json:"artifacts"`
+}
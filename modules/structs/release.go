@@ -59,6 +59,27 @@ type CreateReleaseOption struct {
 	IsDraft bool `json:"draft"`
 	// Whether to mark the release as a prerelease
 	IsPrerelease bool `json:"prerelease"`
+	// Whether to generate the release notes from the pull requests merged since the previous tag, the body is prepended to them
+	GenerateReleaseNotes bool `json:"generate_release_notes"`
+}
+
+// GenerateReleaseNotesOption options when generating release notes
+type GenerateReleaseNotesOption struct {
+	// The name of the tag of the release
+	// required: true
+	TagName string `json:"tag_name" binding:"Required"`
+	// The target commitish the tag is created from if it doesn't exist, the default branch is used if empty
+	Target string `json:"target_commitish"`
+	// The tag the changes are listed since, the nearest tag reachable from the release is used if empty
+	PreviousTagName string `json:"previous_tag_name"`
+}
+
+// ReleaseNotes represents release notes generated from the merged pull requests
+type ReleaseNotes struct {
+	// The display title of the release
+	Title string `json:"name"`
+	// The generated release notes
+	Note string `json:"body"`
 }
 
 // EditReleaseOption options when editing a release
@@ -94,6 +94,7 @@ type Repository struct {
 	HasProjects                   bool             `json:"has_projects"`
 	ProjectsMode                  string           `json:"projects_mode"`
 	HasReleases                   bool             `json:"has_releases"`
+	PublishReleaseAssets          bool             `json:"publish_release_assets"`
 	HasPackages                   bool             `json:"has_packages"`
 	HasActions                    bool             `json:"has_actions"`
 	IgnoreWhitespaceConflicts     bool             `json:"ignore_whitespace_conflicts"`
@@ -108,6 +109,8 @@ type Repository struct {
 	DefaultDeleteBranchAfterMerge bool             `json:"default_delete_branch_after_merge"`
 	DefaultMergeStyle             string           `json:"default_merge_style"`
 	DefaultAllowMaintainerEdit    bool             `json:"default_allow_maintainer_edit"`
+	RequireSignOff                bool             `json:"require_signoff"`
+	SignOffExemptUsers            []string         `json:"signoff_exempt_users"`
 	AvatarURL                     string           `json:"avatar_url"`
 	Internal                      bool             `json:"internal"`
 	MirrorInterval                string           `json:"mirror_interval"`
@@ -193,6 +196,8 @@ type EditRepoOption struct {
 	ProjectsMode *string `json:"projects_mode,omitempty" binding:"In(repo,owner,all)"`
 	// either `true` to enable releases unit, or `false` to disable them.
 	HasReleases *bool `json:"has_releases,omitempty"`
+	// either `true` to publish the .deb, .rpm and OCI image archive assets of releases into the package registries, or `false` to not publish them.
+	PublishReleaseAssets *bool `json:"publish_release_assets,omitempty"`
 	// either `true` to enable packages unit, or `false` to disable them.
 	HasPackages *bool `json:"has_packages,omitempty"`
 	// either `true` to enable actions unit, or `false` to disable them.
@@ -221,12 +226,22 @@ type EditRepoOption struct {
 	DefaultMergeStyle *string `json:"default_merge_style,omitempty"`
 	// set to `true` to allow edits from maintainers by default
 	DefaultAllowMaintainerEdit *bool `json:"default_allow_maintainer_edit,omitempty"`
+	// set to `true` to require the commits of pull requests to be signed off by their authors (DCO)
+	RequireSignOff *bool `json:"require_signoff,omitempty"`
+	// set the names of the users whose commits don't need to be signed off, e.g. bots
+	SignOffExemptUsers *[]string `json:"signoff_exempt_users,omitempty"`
 	// set to `true` to archive this repository.
 	Archived *bool `json:"archived,omitempty"`
 	// set to a string like `8h30m0s` to set the mirror interval time
 	MirrorInterval *string `json:"mirror_interval,omitempty"`
 	// enable prune - remove obsolete remote-tracking references when mirroring
 	EnablePrune *bool `json:"enable_prune,omitempty"`
+	// comma separated branch patterns the mirror syncs, eg: `main, release/*`, empty means all branches
+	MirrorRefFilter *string `json:"mirror_ref_filter,omitempty"`
+	// set to `true` to not sync the tags when mirroring
+	MirrorSkipTags *bool `json:"mirror_skip_tags,omitempty"`
+	// max size of the mirror in bytes, the mirror stops syncing once it's exceeded, 0 means no limit
+	MirrorMaxSize *int64 `json:"mirror_max_size,omitempty"`
 }
 
 // GenerateRepoOption options when creating a repository using a template
@@ -259,6 +274,8 @@ type GenerateRepoOption struct {
 	Labels bool `json:"labels"`
 	// include protected branches in template repo
 	ProtectedBranch bool `json:"protected_branch"`
+	// values of the custom variables declared in the template's .gitea/template.yaml
+	Variables map[string]string `json:"variables"`
 }
 
 // CreateBranchRepoOption options when creating a branch in a repository
@@ -306,22 +323,25 @@ type GitServiceType int
 
 // enumerate all GitServiceType
 const (
-	NotMigrated       GitServiceType = iota // 0 not migrated from external sites
-	PlainGitService                         // 1 plain git service
-	GithubService                           // 2 github.com
-	GiteaService                            // 3 gitea service
-	GitlabService                           // 4 gitlab service
-	GogsService                             // 5 gogs service
-	OneDevService                           // 6 onedev service
-	GitBucketService                        // 7 gitbucket service
-	CodebaseService                         // 8 codebase service
-	CodeCommitService                       // 9 codecommit service
+	NotMigrated            GitServiceType = iota // 0 not migrated from external sites
+	PlainGitService                              // 1 plain git service
+	GithubService                                // 2 github.com
+	GiteaService                                 // 3 gitea service
+	GitlabService                                // 4 gitlab service
+	GogsService                                  // 5 gogs service
+	OneDevService                                // 6 onedev service
+	GitBucketService                             // 7 gitbucket service
+	CodebaseService                              // 8 codebase service
+	CodeCommitService                            // 9 codecommit service
+	BitbucketServerService                       // 10 bitbucket server / data center service
+	AzureDevOpsService                           // 11 azure devops service
+	GerritService                                // 12 gerrit service
 )
 
 // Name represents the service type's name
 // WARNING: the name has to be equal to that on goth's library
 func (gt GitServiceType) Name() string {
-	return strings.ToLower(gt.Title())
+	return strings.ToLower(strings.ReplaceAll(gt.Title(), " ", ""))
 }
 
 // Title represents the service type's proper title
@@ -343,6 +363,12 @@ func (gt GitServiceType) Title() string {
 		return "Codebase"
 	case CodeCommitService:
 		return "CodeCommit"
+	case BitbucketServerService:
+		return "Bitbucket Server"
+	case AzureDevOpsService:
+		return "Azure DevOps"
+	case GerritService:
+		return "Gerrit"
 	case PlainGitService:
 		return "Git"
 	}
@@ -361,7 +387,7 @@ type MigrateRepoOptions struct {
 	// required: true
 	RepoName string `json:"repo_name" binding:"Required;AlphaDashDot;MaxSize(100)"`
 
-	// enum: git,github,gitea,gitlab,gogs,onedev,gitbucket,codebase,codecommit
+	// enum: git,github,gitea,gitlab,gogs,onedev,gitbucket,codebase,codecommit,bitbucketserver,azuredevops,gerrit
 	Service      string `json:"service"`
 	AuthUsername string `json:"auth_username"`
 	AuthPassword string `json:"auth_password"`
@@ -379,6 +405,12 @@ type MigrateRepoOptions struct {
 	PullRequests   bool   `json:"pull_requests"`
 	Releases       bool   `json:"releases"`
 	MirrorInterval string `json:"mirror_interval"`
+	// comma separated branch patterns the pull mirror syncs, eg: "main, release/*", empty means all branches
+	MirrorRefFilter string `json:"mirror_ref_filter"`
+	// the pull mirror doesn't sync the tags
+	MirrorSkipTags bool `json:"mirror_skip_tags"`
+	// max size of the pull mirror in bytes, 0 means no limit
+	MirrorMaxSize int64 `json:"mirror_max_size"`
 
 	AWSAccessKeyID     string `json:"aws_access_key_id"`
 	AWSSecretAccessKey string `json:"aws_secret_access_key"`
@@ -387,7 +419,7 @@ type MigrateRepoOptions struct {
 // TokenAuth represents whether a service type supports token-based auth
 func (gt GitServiceType) TokenAuth() bool {
 	switch gt {
-	case GithubService, GiteaService, GitlabService:
+	case GithubService, GiteaService, GitlabService, AzureDevOpsService:
 		return true
 	}
 	return false
@@ -404,6 +436,9 @@ var SupportedFullGitService = []GitServiceType{
 	GitBucketService,
 	CodebaseService,
 	CodeCommitService,
+	BitbucketServerService,
+	AzureDevOpsService,
+	GerritService,
 }
 
 // RepoTransfer represents a pending repo transfer
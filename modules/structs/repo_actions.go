@@ -55,6 +55,18 @@ type CreateActionWorkflowDispatch struct {
 	Inputs map[string]string `json:"inputs,omitempty"`
 }
 
+// CreateRepositoryDispatchOption represents the payload for triggering a repository dispatch event
+// swagger:model
+type CreateRepositoryDispatchOption struct {
+	// A custom event type, workflows can filter for it with "types"
+	// required: true
+	// example: deploy
+	EventType string `json:"event_type" binding:"Required;MaxSize(100)"`
+	// JSON object with extra information passed to the workflows as github.event.client_payload, at most 10 top-level properties
+	// required: false
+	ClientPayload map[string]any `json:"client_payload,omitempty"`
+}
+
 // ActionWorkflow represents a ActionWorkflow
 type ActionWorkflow struct {
 	// ID is the unique identifier for the workflow
@@ -103,6 +115,41 @@ type ActionArtifact struct {
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
+// ActionArtifactRetention represents the artifact retention policy of a repository or organization, a zero limit means no limit
+type ActionArtifactRetention struct {
+	// MaxAgeDays is the number of days artifacts are kept at most
+	MaxAgeDays int64 `json:"max_age_days"`
+	// MaxTotalSize is the maximum size in bytes of the artifacts of a repository, the oldest artifacts are deleted first
+	MaxTotalSize int64 `json:"max_total_size"`
+	// KeepLastN is the number of the latest runs of each workflow whose artifacts are kept
+	KeepLastN int64 `json:"keep_last_n"`
+}
+
+// EditActionArtifactRetentionOption options to set the artifact retention policy, a zero limit means no limit
+type EditActionArtifactRetentionOption struct {
+	// MaxAgeDays is the number of days artifacts are kept at most
+	MaxAgeDays int64 `json:"max_age_days"`
+	// MaxTotalSize is the maximum size in bytes of the artifacts of a repository, the oldest artifacts are deleted first
+	MaxTotalSize int64 `json:"max_total_size"`
+	// KeepLastN is the number of the latest runs of each workflow whose artifacts are kept
+	KeepLastN int64 `json:"keep_last_n"`
+}
+
+// ActionArtifactUsage represents the storage consumed by the artifacts of a repository or organization
+type ActionArtifactUsage struct {
+	TotalCount   int64                      `json:"total_count"`
+	TotalSize    int64                      `json:"total_size"`
+	Repositories []*ActionRepoArtifactUsage `json:"repositories"`
+}
+
+// ActionRepoArtifactUsage represents the storage consumed by the artifacts of a repository
+type ActionRepoArtifactUsage struct {
+	RepoID   int64  `json:"repository_id"`
+	FullName string `json:"full_name"`
+	Count    int64  `json:"count"`
+	Size     int64  `json:"size"`
+}
+
 // ActionWorkflowRun represents a WorkflowRun
 type ActionWorkflowRun struct {
 	ID             int64       `json:"id"`
@@ -122,6 +169,8 @@ type ActionWorkflowRun struct {
 	Repository     *Repository `json:"repository,omitempty"`
 	HeadRepository *Repository `json:"head_repository,omitempty"`
 	Conclusion     string      `json:"conclusion,omitempty"`
+	// ConcurrencyGroup is the evaluated workflow level concurrency group of the run
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
 	// swagger:strfmt date-time
 	StartedAt time.Time `json:"started_at"`
 	// swagger:strfmt date-time
@@ -183,6 +232,49 @@ type ActionWorkflowJob struct {
 	CompletedAt time.Time `json:"completed_at"`
 }
 
+// ActionWorkflowGraphJob represents a job of the graph of a workflow run
+type ActionWorkflowGraphJob struct {
+	ID int64 `json:"id"`
+	// JobID is the id of the job in the workflow file, the jobs expanded from a matrix share it
+	JobID      string   `json:"job_id"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	Conclusion string   `json:"conclusion,omitempty"`
+	Needs      []string `json:"needs"`
+	Labels     []string `json:"labels"`
+	// Matrix is the matrix combination the job runs with
+	Matrix      map[string]any `json:"matrix,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	RunAttempt  int64          `json:"run_attempt"`
+	RunnerID    int64          `json:"runner_id,omitempty"`
+	RunnerName  string         `json:"runner_name,omitempty"`
+	// Duration is the number of seconds the job has been running
+	Duration int64                 `json:"duration"`
+	Steps    []*ActionWorkflowStep `json:"steps"`
+	// swagger:strfmt date-time
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	// swagger:strfmt date-time
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ActionWorkflowGraphEdge represents a dependency between two jobs of a workflow run
+type ActionWorkflowGraphEdge struct {
+	// From is the id of the job which is needed
+	From int64 `json:"from"`
+	// To is the id of the job which needs it
+	To int64 `json:"to"`
+}
+
+// ActionWorkflowRunGraph represents the jobs of a workflow run and the dependencies between them
+type ActionWorkflowRunGraph struct {
+	RunID      int64                      `json:"run_id"`
+	WorkflowID string                     `json:"workflow_id"`
+	Status     string                     `json:"status"`
+	Conclusion string                     `json:"conclusion,omitempty"`
+	Jobs       []*ActionWorkflowGraphJob  `json:"jobs"`
+	Edges      []*ActionWorkflowGraphEdge `json:"edges"`
+}
+
 // ActionRunnerLabel represents a Runner Label
 type ActionRunnerLabel struct {
 	ID   int64  `json:"id"`
@@ -200,8 +292,144 @@ type ActionRunner struct {
 	Labels    []*ActionRunnerLabel `json:"labels"`
 }
 
+// CreateRunnerJITTokenOption options to create a just-in-time runner registration token
+type CreateRunnerJITTokenOption struct {
+	// Labels of the ephemeral runner registered with the token
+	// required: true
+	Labels []string `json:"labels" binding:"Required"`
+	// ExpiresIn is the lifetime of the token in seconds, the default is one hour and the maximum one day
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// RunnerJITToken represents a single-use runner registration token
+type RunnerJITToken struct {
+	Token  string   `json:"token"`
+	Labels []string `json:"labels"`
+	// swagger:strfmt date-time
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ActionRunnerPendingJobs represents the number of jobs with the same labels waiting for a runner
+type ActionRunnerPendingJobs struct {
+	Labels []string `json:"labels"`
+	Count  int64    `json:"count"`
+}
+
 // ActionRunnersResponse returns Runners
 type ActionRunnersResponse struct {
 	Entries    []*ActionRunner `json:"runners"`
 	TotalCount int64           `json:"total_count"`
 }
+
+// ActionConcurrencyGroup represents an active concurrency group of a repository
+type ActionConcurrencyGroup struct {
+	Name string `json:"name"`
+	// JobLevel is true for groups defined by the concurrency of a job
+	JobLevel bool `json:"job_level"`
+	// IDs of the runs holding the group
+	InProgressRunIDs []int64 `json:"in_progress_run_ids"`
+	// IDs of the runs waiting for the group
+	QueuedRunIDs []int64 `json:"queued_run_ids"`
+}
+
+// ActionCache represents a cache saved by actions/cache
+type ActionCache struct {
+	ID      int64  `json:"id"`
+	Key     string `json:"key"`
+	Version string `json:"version"`
+	// Ref is the git ref of the run which saved the cache, only runs of this ref,
+	// of pull requests based on it and, for the default branch, all runs can restore it
+	Ref         string `json:"ref"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+	// HitCount is the number of times the cache has been restored
+	HitCount int64 `json:"hit_count"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+}
+
+// ActionCacheList represents a list of caches
+type ActionCacheList struct {
+	TotalCount int64          `json:"total_count"`
+	Caches     []*ActionCache `json:"actions_caches"`
+}
+
+// ActionCacheUsage represents the storage consumed by the caches of a repository
+type ActionCacheUsage struct {
+	FullName                string `json:"full_name"`
+	ActiveCachesCount       int64  `json:"active_caches_count"`
+	ActiveCachesSizeInBytes int64  `json:"active_caches_size_in_bytes"`
+	// HitCount is the number of times the caches have been restored
+	HitCount int64 `json:"hit_count"`
+}
+
+// ActionEnvironment represents a deployment environment of a repository
+type ActionEnvironment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// WaitTimer is the number of minutes the jobs wait before they start
+	WaitTimer int64 `json:"wait_timer"`
+	// Reviewers and ReviewerTeams can approve the jobs, one approval is required if any is set
+	Reviewers         []string `json:"reviewers"`
+	ReviewerTeams     []string `json:"reviewer_teams"`
+	PreventSelfReview bool     `json:"prevent_self_review"`
+	// DeploymentBranches are the glob patterns of the branches and tags which can deploy, all of them can if it's empty
+	DeploymentBranches []string `json:"deployment_branches"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ActionEnvironmentList represents a list of environments
+type ActionEnvironmentList struct {
+	TotalCount   int64                `json:"total_count"`
+	Environments []*ActionEnvironment `json:"environments"`
+}
+
+// CreateOrUpdateEnvironmentOption options when creating or updating an environment
+type CreateOrUpdateEnvironmentOption struct {
+	// minutes the jobs wait before they start, at most 43200
+	WaitTimer int64 `json:"wait_timer"`
+	// names of the users who can approve the jobs
+	Reviewers []string `json:"reviewers"`
+	// names of the teams which can approve the jobs
+	ReviewerTeams []string `json:"reviewer_teams"`
+	// whether the user who triggered the run is kept from approving its jobs
+	PreventSelfReview bool `json:"prevent_self_review"`
+	// glob patterns of the branches and tags which can deploy to the environment
+	DeploymentBranches []string `json:"deployment_branches"`
+}
+
+// PendingDeployment represents an environment whose reviewers have to approve the jobs of a run
+type PendingDeployment struct {
+	Environment *ActionEnvironment `json:"environment"`
+	// WaitTimerUntil is the time the wait timer of the jobs elapses, it's empty if the timer hasn't started
+	// swagger:strfmt date-time
+	WaitTimerUntil *time.Time `json:"wait_timer_until"`
+	// CurrentUserCanApprove is whether the authenticated user can approve or reject the jobs
+	CurrentUserCanApprove bool `json:"current_user_can_approve"`
+}
+
+// ReviewPendingDeploymentsOption options when approving or rejecting the pending deployments of a run
+type ReviewPendingDeploymentsOption struct {
+	// ids of the environments to review
+	// required: true
+	EnvironmentIDs []int64 `json:"environment_ids" binding:"Required"`
+	// required: true
+	// enum: approved,rejected
+	State   string `json:"state" binding:"Required;In(approved,rejected)"`
+	Comment string `json:"comment"`
+}
+
+// DeploymentReview represents the approval or rejection of the jobs of a run deploying to an environment
+type DeploymentReview struct {
+	ID          int64  `json:"id"`
+	Environment string `json:"environment"`
+	State       string `json:"state"`
+	Comment     string `json:"comment"`
+	User        *User  `json:"user"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
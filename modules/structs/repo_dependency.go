@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// RepoDependency represents a dependency of the default branch of a repository
+type RepoDependency struct {
+	// The package ecosystem: "golang", "npm", "pypi", "cargo" or "maven"
+	Ecosystem string `json:"ecosystem"`
+	// The name of the package, the name of a Maven package is "groupId:artifactId"
+	Name string `json:"name"`
+	// The exact version, it is empty if the version isn't known
+	Version string `json:"version"`
+	// The version constraint declared by the manifest
+	Requirement string `json:"requirement"`
+	// The scope in which a dependency declared by a manifest is needed: "runtime", "development", "test", "build", "optional", "peer" or "provided"
+	Scope string `json:"scope"`
+	// Whether the dependency is declared by a manifest of the repository, otherwise it is a dependency of the dependencies
+	Direct bool `json:"direct"`
+	// The SPDX license expression of the dependency if the lockfile records it
+	License string `json:"license"`
+	// The path of the manifest or the lockfile the dependency was found in
+	Manifest string `json:"manifest"`
+	// The package URL of the dependency
+	PackageURL string `json:"purl"`
+}
+
+// Dependent represents a repository which depends on a package
+type Dependent struct {
+	Repository *Repository     `json:"repository"`
+	Dependency *RepoDependency `json:"dependency"`
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// IncomingEmailAlias represents the address emails are sent to for creating issues in a repository
+type IncomingEmailAlias struct {
+	// The alias of the repository in the address
+	Alias string `json:"alias"`
+	// The address emails creating issues in the repository are sent to
+	Address string `json:"address"`
+}
+
+// EditIncomingEmailAliasOption options when setting the address emails creating issues in a repository are sent to
+type EditIncomingEmailAliasOption struct {
+	// The alias of the repository in the address, lowercase letters, digits, dots, dashes and underscores,
+	// a random alias is generated if empty
+	Alias string `json:"alias" binding:"MaxSize(64)"`
+}
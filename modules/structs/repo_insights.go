@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// WeeklyCommitStats represents the commits and the changed lines of a week
+type WeeklyCommitStats struct {
+	// The first day (Sunday) of the week
+	Week time.Time `json:"week"`
+	// The number of added lines
+	Additions int `json:"additions"`
+	// The number of deleted lines
+	Deletions int `json:"deletions"`
+	// The number of commits
+	Commits int `json:"commits"`
+}
+
+// ContributorStats represents the weekly commit statistics of a contributor
+type ContributorStats struct {
+	// The display name of the contributor
+	Name string `json:"name"`
+	// The login name of the contributor if the commits are linked to a user
+	Login string `json:"login"`
+	// The avatar URL of the contributor
+	AvatarURL string `json:"avatar_url"`
+	// The total number of commits of the contributor
+	TotalCommits int64 `json:"total_commits"`
+	// The statistics of the weeks with commits, ordered by week
+	Weeks []*WeeklyCommitStats `json:"weeks"`
+}
+
+// DurationPercentiles represents the distribution of durations in seconds
+type DurationPercentiles struct {
+	// The number of measured durations
+	Count int64 `json:"count"`
+	// The median duration in seconds
+	P50 int64 `json:"p50_seconds"`
+	// The 75th percentile in seconds
+	P75 int64 `json:"p75_seconds"`
+	// The 90th percentile in seconds
+	P90 int64 `json:"p90_seconds"`
+	// The 95th percentile in seconds
+	P95 int64 `json:"p95_seconds"`
+}
+
+// PullRequestInsights represents the pull request metrics of a period
+type PullRequestInsights struct {
+	// swagger:strfmt date-time
+	Since time.Time `json:"since"`
+	// swagger:strfmt date-time
+	Before time.Time `json:"before"`
+	// The number of pull requests opened in the period
+	Opened int64 `json:"opened"`
+	// The number of pull requests merged in the period
+	Merged int64 `json:"merged"`
+	// The number of pull requests closed without merge in the period
+	ClosedWithoutMerge int64 `json:"closed_without_merge"`
+	// The time from opening to merging of the pull requests merged in the period
+	LeadTime *DurationPercentiles `json:"lead_time"`
+	// The time from opening to the first review by another user of the pull requests first reviewed in the period
+	ReviewTurnaround *DurationPercentiles `json:"review_turnaround"`
+}
+
+// IssueInsights represents the issue metrics of a period
+type IssueInsights struct {
+	// swagger:strfmt date-time
+	Since time.Time `json:"since"`
+	// swagger:strfmt date-time
+	Before time.Time `json:"before"`
+	// The number of issues opened in the period
+	Opened int64 `json:"opened"`
+	// The number of issues closed in the period
+	Closed int64 `json:"closed"`
+	// The ratio of the issues opened in the period which are closed
+	CloseRate float64 `json:"close_rate"`
+	// The time from opening to closing of the issues closed in the period
+	TimeToClose *DurationPercentiles `json:"time_to_close"`
+}
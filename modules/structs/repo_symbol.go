@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// RepoSymbol represents a symbol defined in a file of the default branch of a repository
+type RepoSymbol struct {
+	Name string `json:"name"`
+	// The kind of the symbol given by ctags, e.g. "function", "struct" or "method"
+	Kind     string `json:"kind"`
+	Language string `json:"language"`
+	// The path of the file the symbol is defined in
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	// The last line of the definition, it is 0 if it isn't known
+	EndLine int `json:"end_line"`
+	// The name of the symbol the symbol is defined in, e.g. the type of a method
+	Scope     string `json:"scope"`
+	ScopeKind string `json:"scope_kind"`
+	// The commit the symbols were indexed at
+	CommitID string `json:"commit_id"`
+	// The link to the definition in the code view
+	HTMLURL string `json:"html_url"`
+}
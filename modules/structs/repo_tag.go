@@ -70,6 +70,12 @@ type TagProtection struct {
 	WhitelistUsernames []string `json:"whitelist_usernames"`
 	// List of team names allowed to create/delete protected tags
 	WhitelistTeams []string `json:"whitelist_teams"`
+	// Whether lightweight tags are rejected
+	RequireAnnotated bool `json:"require_annotated"`
+	// Whether tags must be annotated and signed with a verified GPG or SSH key
+	RequireSigned bool `json:"require_signed"`
+	// Whether tag names must be semantic versions, released tags can't be moved or deleted
+	EnforceSemver bool `json:"enforce_semver"`
 	// swagger:strfmt date-time
 	// The date and time when the tag protection was created
 	Created time.Time `json:"created_at"`
@@ -86,6 +92,12 @@ type CreateTagProtectionOption struct {
 	WhitelistUsernames []string `json:"whitelist_usernames"`
 	// List of team names allowed to create/delete protected tags
 	WhitelistTeams []string `json:"whitelist_teams"`
+	// Whether lightweight tags are rejected
+	RequireAnnotated bool `json:"require_annotated"`
+	// Whether tags must be annotated and signed with a verified GPG or SSH key
+	RequireSigned bool `json:"require_signed"`
+	// Whether tag names must be semantic versions, released tags can't be moved or deleted
+	EnforceSemver bool `json:"enforce_semver"`
 }
 
 // EditTagProtectionOption options for editing a tag protection
@@ -96,4 +108,10 @@ type EditTagProtectionOption struct {
 	WhitelistUsernames []string `json:"whitelist_usernames"`
 	// List of team names allowed to create/delete protected tags
 	WhitelistTeams []string `json:"whitelist_teams"`
+	// Whether lightweight tags are rejected
+	RequireAnnotated *bool `json:"require_annotated"`
+	// Whether tags must be annotated and signed with a verified GPG or SSH key
+	RequireSigned *bool `json:"require_signed"`
+	// Whether tag names must be semantic versions, released tags can't be moved or deleted
+	EnforceSemver *bool `json:"enforce_semver"`
 }
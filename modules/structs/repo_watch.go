@@ -21,4 +21,24 @@ type WatchInfo struct {
 	URL string `json:"url"`
 	// The URL of the repository being watched
 	RepositoryURL string `json:"repository_url"`
+	// The types of events the watcher is notified of
+	Events WatchEvents `json:"events"`
+}
+
+// WatchEvents represents the types of events the watcher of a repository is notified of,
+// the issues and pull requests the user participates in are notified of anyway
+type WatchEvents struct {
+	Issues       bool `json:"issues"`
+	PullRequests bool `json:"pull_requests"`
+	Releases     bool `json:"releases"`
+	// Failed workflow runs
+	CIFailures bool `json:"ci_failures"`
+}
+
+// EditWatchOption options when changing the types of events the watcher of a repository is notified of
+type EditWatchOption struct {
+	Issues       *bool `json:"issues"`
+	PullRequests *bool `json:"pull_requests"`
+	Releases     *bool `json:"releases"`
+	CIFailures   *bool `json:"ci_failures"`
 }
@@ -12,6 +12,8 @@ type Secret struct {
 	Name string `json:"name"`
 	// the secret's description
 	Description string `json:"description"`
+	// the external store of the secret's value, `vault` or `aws`, it's empty for secrets stored by Gitea
+	Provider string `json:"provider"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 }
@@ -28,4 +30,11 @@ type CreateOrUpdateSecretOption struct {
 	//
 	// required: false
 	Description string `json:"description"`
+
+	// Provider is the external secret store, `vault` or `aws`, then Data is the reference of the secret in the store:
+	// `path/of/secret#field` for Vault, `secret-name` or `secret-name#json-key` for AWS Secrets Manager.
+	// The value is fetched when a job runs, so it's never stored by Gitea.
+	//
+	// required: false
+	Provider string `json:"provider" binding:"In(,vault,aws)"`
 }
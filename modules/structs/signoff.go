@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// SignOffPolicy requires the commits of the pull requests of all repositories of an organization to be signed off (DCO)
+type SignOffPolicy struct {
+	// The names of the users whose commits don't need to be signed off
+	ExemptUsers []string `json:"exempt_users"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// EditSignOffPolicyOption options for setting the sign-off policy of an organization
+type EditSignOffPolicyOption struct {
+	// The names of the users whose commits don't need to be signed off, e.g. bots
+	ExemptUsers []string `json:"exempt_users"`
+}
+
+// PullSignOffCommit represents the sign-off check of a commit of a pull request
+type PullSignOffCommit struct {
+	SHA    string      `json:"sha"`
+	Author *CommitUser `json:"author"`
+	// Whether the commit message has a "Signed-off-by" trailer matching the author
+	SignedOff bool `json:"signed_off"`
+	// Whether the author is exempt from signing off
+	Exempt bool `json:"exempt"`
+	// Whether it is a merge commit, which doesn't need to be signed off
+	Merge bool `json:"merge"`
+	// Whether the commit passes the check
+	Passed bool `json:"passed"`
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// TermsDocument represents a version of a policy document the users have to accept
+type TermsDocument struct {
+	ID int64 `json:"id"`
+	// enum: tos,aup
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+	Title   string `json:"title"`
+	// The markdown content of the document
+	Content string `json:"content"`
+	// Whether this version is the current version of its kind
+	Current bool `json:"current"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateTermsDocumentOption options for publishing a version of a policy document
+type CreateTermsDocumentOption struct {
+	// enum: tos,aup
+	// required: true
+	Kind string `json:"kind" binding:"Required;In(tos,aup)"`
+	// required: true
+	Version string `json:"version" binding:"Required;MaxSize(50)"`
+	// required: true
+	Title string `json:"title" binding:"Required;MaxSize(255)"`
+	// The markdown content of the document
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
+
+// TermsAcceptance represents the acceptance of a version of a policy document by a user
+type TermsAcceptance struct {
+	DocumentID int64 `json:"document_id"`
+	User       *User `json:"user"`
+	// swagger:strfmt date-time
+	Accepted time.Time `json:"accepted_at"`
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// MatrixSettings represents the Matrix notification settings of a user
+type MatrixSettings struct {
+	// The Matrix user ID the direct messages are sent to
+	MatrixID string `json:"matrix_id"`
+	// The types of events the user receives Matrix notifications for
+	Events []string `json:"events"`
+}
+
+// EditMatrixSettingsOption options when editing the Matrix notification settings of a user
+type EditMatrixSettingsOption struct {
+	// The Matrix user ID to send the direct messages to, like @alice:example.org. Empty to stop the notifications
+	MatrixID string `json:"matrix_id"`
+	// The types of events to receive Matrix notifications for: "mention" or "review_request"
+	Events []string `json:"events"`
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// WebPushSettings represents the web push settings of a user
+type WebPushSettings struct {
+	// The public VAPID key browsers need to subscribe to the web push notifications of the instance
+	VAPIDPublicKey string `json:"vapid_public_key"`
+	// The types of events the user receives web push notifications for
+	Events []string `json:"events"`
+}
+
+// EditWebPushSettingsOption options when editing the web push settings of a user
+type EditWebPushSettingsOption struct {
+	// The types of events to receive web push notifications for: "mention", "review_request" or "ci_failure"
+	Events []string `json:"events"`
+}
+
+// WebPushSubscriptionKeys are the keys a browser encrypts its web push notifications with
+type WebPushSubscriptionKeys struct {
+	// The base64 encoded P-256 public key of the browser
+	P256dh string `json:"p256dh" binding:"Required"`
+	// The base64 encoded authentication secret of the browser
+	Auth string `json:"auth" binding:"Required"`
+}
+
+// WebPushSubscription represents the web push subscription of a browser
+type WebPushSubscription struct {
+	ID int64 `json:"id"`
+	// The URL of the push service of the browser
+	Endpoint  string `json:"endpoint"`
+	UserAgent string `json:"user_agent"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateWebPushSubscriptionOption options when subscribing a browser to web push notifications,
+// as returned by PushSubscription.toJSON() in the browser
+type CreateWebPushSubscriptionOption struct {
+	// The URL of the push service of the browser
+	Endpoint string                   `json:"endpoint" binding:"Required"`
+	Keys     *WebPushSubscriptionKeys `json:"keys" binding:"Required"`
+}
@@ -15,6 +15,9 @@ type HookEvent struct {
 	SendEverything bool   `json:"send_everything"`
 	ChooseEvents   bool   `json:"choose_events"`
 	BranchFilter   string `json:"branch_filter"`
+	PathFilter     string `json:"path_filter"`
+	LabelFilter    string `json:"label_filter"`
+	ActorFilter    string `json:"actor_filter"`
 
 	HookEvents `json:"events"`
 }
@@ -40,6 +40,8 @@ const (
 	HookEventSchedule    HookEventType = "schedule"
 	HookEventWorkflowRun HookEventType = "workflow_run"
 	HookEventWorkflowJob HookEventType = "workflow_job"
+	// HookEventRepositoryDispatch is triggered from outside through the API
+	HookEventRepositoryDispatch HookEventType = "repository_dispatch"
 )
 
 func AllEvents() []HookEventType {
@@ -114,6 +116,9 @@ const (
 	MATRIX     HookType = "matrix"
 	WECHATWORK HookType = "wechatwork"
 	PACKAGIST  HookType = "packagist"
+	CUSTOM     HookType = "custom"
+	KAFKA      HookType = "kafka"
+	NATS       HookType = "nats"
 )
 
 // HookStatus is the status of a web hook
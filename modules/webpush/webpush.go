@@ -0,0 +1,224 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package webpush sends messages to the push services of the browsers following the Web Push protocol:
+// the payload is encrypted for the subscription (RFC 8291) and the requests are signed with VAPID (RFC 8292).
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrSubscriptionGone is returned when the push service doesn't know the subscription anymore, it should be deleted
+var ErrSubscriptionGone = errors.New("web push subscription is gone")
+
+// recordSize is the size of the single record of an encrypted message, the push services accept up to 4096 bytes
+const recordSize = 4096
+
+// MaxPayloadSize is the maximum size of the payload of a message, the push services accept bodies up to 4096 bytes
+// holding the header, the payload, its padding delimiter and the authentication tag
+const MaxPayloadSize = 4096 - 86 - 1 - 16
+
+// Subscription is the push subscription of a browser
+type Subscription struct {
+	Endpoint string
+	// P256dh is the base64 encoded public key of the browser
+	P256dh string
+	// Auth is the base64 encoded authentication secret of the browser
+	Auth string
+}
+
+// VAPID identifies the application server to the push services
+type VAPID struct {
+	// Subject is the contact of the application server, a "mailto:" or an "https:" URL
+	Subject    string
+	PublicKey  string
+	PrivateKey string
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	// the browsers use the URL-safe alphabet without padding, but be lenient
+	for _, enc := range []*base64.Encoding{base64.RawURLEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.StdEncoding} {
+		if b, err := enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, errors.New("invalid base64 value")
+}
+
+// Validate checks the keys of a subscription
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("the endpoint of a push subscription must be an HTTP URL")
+	}
+	publicKey, err := decodeBase64(s.P256dh)
+	if err == nil {
+		_, err = ecdh.P256().NewPublicKey(publicKey)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid p256dh key of push subscription: %w", err)
+	}
+	if auth, err := decodeBase64(s.Auth); err != nil || len(auth) != 16 {
+		return errors.New("invalid auth secret of push subscription")
+	}
+	return nil
+}
+
+// Encrypt encrypts a payload for a subscription with the "aes128gcm" content encoding,
+// the salt and the key of the application server are generated for each message
+func Encrypt(sub *Subscription, payload []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(sub, payload, salt, serverKey)
+}
+
+func encrypt(sub *Subscription, payload, salt []byte, serverKey *ecdh.PrivateKey) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, fmt.Errorf("web push payload too large: %d bytes", len(payload))
+	}
+	uaPublicBytes, err := decodeBase64(sub.P256dh)
+	if err != nil {
+		return nil, err
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := decodeBase64(sub.Auth)
+	if err != nil {
+		return nil, err
+	}
+	ecdhSecret, err := serverKey.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	serverPublicBytes := serverKey.PublicKey().Bytes()
+
+	// RFC 8291 section 3.4: combine the shared secret with the authentication secret
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublicBytes...), serverPublicBytes...)
+	ikm, err := hkdf.Key(sha256.New, ecdhSecret, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+	// RFC 8188 section 2.2 and 2.3: derive the content encryption key and the nonce
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// the header holds the salt, the record size and the public key of the application server,
+	// the payload is sent as a single record ended by the padding delimiter 0x02
+	header := make([]byte, 0, 16+4+1+len(serverPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(serverPublicBytes)))
+	header = append(header, serverPublicBytes...)
+	return gcm.Seal(header, nonce, slices.Concat(payload, []byte{0x02}), nil), nil
+}
+
+// authorization returns the VAPID authorization header for the origin of an endpoint
+func (v *VAPID) authorization(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	privateBytes, err := decodeBase64(v.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), privateBytes)
+	if err != nil {
+		return "", err
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(12 * time.Hour).Unix(),
+		"sub": v.Subject,
+	}).SignedString(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, v.PublicKey), nil
+}
+
+// Send encrypts a payload for a subscription and sends it to its push service,
+// the push service keeps it for ttl while the browser is offline
+func Send(ctx context.Context, client *http.Client, vapid *VAPID, sub *Subscription, payload []byte, ttl time.Duration) error {
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := vapid.authorization(sub.Endpoint, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.FormatInt(int64(ttl.Seconds()), 10))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/generate"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecode(t *testing.T, s string) []byte {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// TestEncrypt checks the example of RFC 8291 appendix A
+func TestEncrypt(t *testing.T) {
+	sub := &Subscription{
+		Endpoint: "https://push.example.net/push/JzLQ3raZJfFBR0aqvOMsLrt54w4rJUsV",
+		P256dh:   "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:     "BTBZMqHH6r4Tts7J_aSIgg",
+	}
+	require.NoError(t, sub.Validate())
+
+	serverKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	require.NoError(t, err)
+	body, err := encrypt(sub, []byte("When I grow up, I want to be a watermelon"), mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlw"), serverKey)
+	require.NoError(t, err)
+	assert.Equal(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN",
+		base64.RawURLEncoding.EncodeToString(body))
+
+	_, err = Encrypt(sub, make([]byte, MaxPayloadSize+1))
+	assert.Error(t, err)
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	assert.Error(t, (&Subscription{Endpoint: "ftp://push.example.net", P256dh: "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", Auth: "BTBZMqHH6r4Tts7J_aSIgg"}).Validate())
+	assert.Error(t, (&Subscription{Endpoint: "https://push.example.net", P256dh: "BCVx", Auth: "BTBZMqHH6r4Tts7J_aSIgg"}).Validate())
+	assert.Error(t, (&Subscription{Endpoint: "https://push.example.net", P256dh: "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", Auth: "BTBZ"}).Validate())
+}
+
+func TestSend(t *testing.T) {
+	publicKey, privateKey, err := generate.NewVAPIDKeys()
+	require.NoError(t, err)
+	vapid := &VAPID{Subject: "mailto:admin@example.com", PublicKey: publicKey, PrivateKey: privateKey}
+
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		if strings.HasSuffix(r.URL.Path, "/gone") {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	uaKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sub := &Subscription{
+		Endpoint: server.URL + "/push/abc",
+		P256dh:   base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+		Auth:     "BTBZMqHH6r4Tts7J_aSIgg",
+	}
+	require.NoError(t, Send(t.Context(), server.Client(), vapid, sub, []byte(`{"title":"hi"}`), time.Hour))
+	assert.Equal(t, "aes128gcm", received.Header.Get("Content-Encoding"))
+	assert.Equal(t, "3600", received.Header.Get("TTL"))
+	assert.Len(t, receivedBody, 86+len(`{"title":"hi"}`)+1+16)
+
+	// the token is signed by the VAPID key for the origin of the endpoint
+	tokenString, k, ok := strings.Cut(strings.TrimPrefix(received.Header.Get("Authorization"), "vapid t="), ", k=")
+	require.True(t, ok)
+	assert.Equal(t, publicKey, k)
+	vapidPublicKey, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), mustDecode(t, publicKey))
+	require.NoError(t, err)
+	token, err := jwt.Parse(tokenString, func(*jwt.Token) (any, error) { return vapidPublicKey, nil }, jwt.WithValidMethods([]string{"ES256"}))
+	require.NoError(t, err)
+	aud, err := token.Claims.GetAudience()
+	require.NoError(t, err)
+	assert.Equal(t, jwt.ClaimStrings{server.URL}, aud)
+
+	sub.Endpoint = server.URL + "/push/gone"
+	assert.ErrorIs(t, Send(t.Context(), server.Client(), vapid, sub, []byte("{}"), time.Hour), ErrSubscriptionGone)
+}
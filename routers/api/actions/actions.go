@@ -20,5 +20,8 @@ func Routes(prefix string) *web.Router {
 	path, handler = runner.NewRunnerServiceHandler()
 	m.Post(path+"*", http.StripPrefix(prefix, handler).ServeHTTP)
 
+	// OIDC ID tokens requested by the jobs with ACTIONS_ID_TOKEN_REQUEST_TOKEN
+	m.Get("/_apis/idtoken", ArtifactContexter(), issueIDToken)
+
 	return m
 }
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models/actions"
+	quota_model "code.gitea.io/gitea/models/quota"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
 )
@@ -66,6 +67,20 @@ func validateArtifactHash(ctx *ArtifactContext, artifactName string) bool {
 	return false
 }
 
+// checkArtifactQuota checks that the upload of size bytes doesn't exceed the artifacts quota of the owner of the run
+func checkArtifactQuota(ctx *ArtifactContext, task *actions.ActionTask, size int64) bool {
+	if err := quota_model.CheckExceeded(ctx, task.Job.OwnerID, quota_model.CategoryArtifacts, max(size, 0)); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			ctx.HTTPError(http.StatusRequestEntityTooLarge, err.Error())
+			return false
+		}
+		log.Error("Error checking the artifacts quota: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error checking the artifacts quota")
+		return false
+	}
+	return true
+}
+
 func parseArtifactItemPath(ctx *ArtifactContext) (string, string, bool) {
 	// itemPath is generated from upload-artifact action
 	// it's formatted as {artifact_name}/{artfict_path_in_runner}
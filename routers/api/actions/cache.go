@@ -0,0 +1,413 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+)
+
+// The actions/cache protocol, served at ROOT_URL/api/actions_cache/ which is the `cache.external_server` of the runners.
+//
+//  1. Restore a cache:
+//     GET  _apis/artifactcache/cache?keys=key,restore-key&version=v
+//     204 for a miss, or 200 with {"result":"hit","archiveLocation":"url","cacheKey":"key"}
+//  2. Save a cache:
+//     POST  _apis/artifactcache/caches {"key":"key","version":"v","cacheSize":1024} => {"cacheId":1}
+//     PATCH _apis/artifactcache/caches/1 with a `Content-Range: bytes start-end/*` header for each chunk
+//     POST  _apis/artifactcache/caches/1 {"size":1024} to commit the archive
+//  3. Download the archive from the signed archiveLocation:
+//     GET  _apis/artifactcache/artifacts/1?sig=...&expires=...
+const cacheRouteBase = "/_apis/artifactcache"
+
+type cacheRoutes struct {
+	prefix string
+	fs     storage.ObjectStorage
+}
+
+// CacheRoutes serves the actions/cache protocol
+func CacheRoutes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{
+		prefix: prefix,
+		fs:     storage.ActionsCache,
+	}
+
+	m.Group(cacheRouteBase, func() {
+		m.Get("/cache", r.find)
+		m.Post("/caches", r.reserve)
+		m.Patch("/caches/{cache_id}", r.upload)
+		m.Post("/caches/{cache_id}", r.commit)
+		m.Post("/clean", r.clean)
+	}, ArtifactContexter())
+	// actions/cache downloads the archive without the runtime token
+	m.Get(cacheRouteBase+"/artifacts/{cache_id}", ArtifactV4Contexter(), r.download)
+
+	return m
+}
+
+// scopes returns the scopes of the running task, see CacheScopes
+func (r cacheRoutes) scopes(ctx *ArtifactContext) (string, []string, bool) {
+	task := ctx.ActionTask
+	run, err := actions.GetRunByRepoAndID(ctx, task.RepoID, task.Job.RunID)
+	if err != nil {
+		log.Error("Error getting run: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting run")
+		return "", nil, false
+	}
+	saveScope, restoreScopes, err := actions_service.CacheScopes(ctx, run)
+	if err != nil {
+		log.Error("Error getting cache scopes: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache scopes")
+		return "", nil, false
+	}
+	return saveScope, restoreScopes, true
+}
+
+func (r cacheRoutes) buildSignature(expires string, cacheID int64) []byte {
+	mac := hmac.New(sha256.New, setting.GetGeneralTokenSigningSecret())
+	mac.Write([]byte("ActionsCache"))
+	mac.Write([]byte(expires))
+	fmt.Fprint(mac, cacheID)
+	return mac.Sum(nil)
+}
+
+func (r cacheRoutes) buildArchiveURL(ctx *ArtifactContext, c *actions.ActionCache) string {
+	if setting.Actions.CacheStorage.ServeDirect() {
+		u, err := r.fs.URL(c.StoragePath(), path.Base(c.StoragePath())+".tzst", http.MethodGet, nil)
+		if u != nil && err == nil {
+			return u.String()
+		}
+	}
+	expires := time.Now().Add(60 * time.Minute).Format(time.RFC3339)
+	return strings.TrimSuffix(httplib.GuessCurrentAppURL(ctx), "/") + strings.TrimSuffix(r.prefix, "/") +
+		cacheRouteBase + "/artifacts/" + strconv.FormatInt(c.ID, 10) +
+		"?sig=" + base64.URLEncoding.EncodeToString(r.buildSignature(expires, c.ID)) + "&expires=" + url.QueryEscape(expires)
+}
+
+type findCacheResponse struct {
+	Result          string `json:"result"`
+	ArchiveLocation string `json:"archiveLocation"`
+	CacheKey        string `json:"cacheKey"`
+}
+
+// find returns the cache to restore, 204 means there is no matching cache
+func (r cacheRoutes) find(ctx *ArtifactContext) {
+	_, restoreScopes, ok := r.scopes(ctx)
+	if !ok {
+		return
+	}
+	var keys []string
+	for key := range strings.SplitSeq(ctx.Req.URL.Query().Get("keys"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	version := ctx.Req.URL.Query().Get("version")
+	if len(keys) == 0 || version == "" {
+		ctx.HTTPError(http.StatusBadRequest, "Error keys and version are required")
+		return
+	}
+
+	c, err := actions.FindRestorableCache(ctx, ctx.ActionTask.RepoID, restoreScopes, keys, version)
+	if err != nil {
+		log.Error("Error finding cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error finding cache")
+		return
+	}
+	if c == nil {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	if err := actions.MarkCacheUsed(ctx, c); err != nil {
+		log.Error("Error marking cache %d used: %v", c.ID, err)
+	}
+	ctx.JSON(http.StatusOK, findCacheResponse{
+		Result:          "hit",
+		ArchiveLocation: r.buildArchiveURL(ctx, c),
+		CacheKey:        c.CacheKey,
+	})
+}
+
+type reserveCacheRequest struct {
+	Key       string `json:"key"`
+	Version   string `json:"version"`
+	CacheSize int64  `json:"cacheSize"`
+}
+
+type reserveCacheResponse struct {
+	CacheID int64 `json:"cacheId"`
+}
+
+// reserve creates a cache in the scope of the task's ref, the archive is uploaded later
+func (r cacheRoutes) reserve(ctx *ArtifactContext) {
+	saveScope, _, ok := r.scopes(ctx)
+	if !ok {
+		return
+	}
+	var req reserveCacheRequest
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+	if req.Key == "" || req.Version == "" {
+		ctx.HTTPError(http.StatusBadRequest, "Error key and version are required")
+		return
+	}
+	if setting.Actions.CacheMaxSizePerRepo >= 0 && req.CacheSize > setting.Actions.CacheMaxSizePerRepo {
+		ctx.HTTPError(http.StatusBadRequest, fmt.Sprintf("Cache size of %d bytes is over the %d bytes limit, not saving cache.", req.CacheSize, setting.Actions.CacheMaxSizePerRepo))
+		return
+	}
+
+	c := &actions.ActionCache{
+		RepoID:   ctx.ActionTask.RepoID,
+		Scope:    saveScope,
+		CacheKey: req.Key,
+		Version:  req.Version,
+	}
+	replaced, err := actions.ReserveCache(ctx, c)
+	if err != nil {
+		if errors.Is(err, util.ErrAlreadyExist) {
+			ctx.HTTPError(http.StatusConflict, "Cache already exists")
+			return
+		}
+		log.Error("Error reserving cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error reserving cache")
+		return
+	}
+	if replaced != nil {
+		if err := actions_service.DeleteCache(ctx, replaced); err != nil {
+			log.Error("Error deleting replaced cache %d: %v", replaced.ID, err)
+		}
+	}
+	ctx.JSON(http.StatusOK, reserveCacheResponse{CacheID: c.ID})
+}
+
+// uploadableCache returns the cache which the task is uploading
+func (r cacheRoutes) uploadableCache(ctx *ArtifactContext) (*actions.ActionCache, bool) {
+	saveScope, _, ok := r.scopes(ctx)
+	if !ok {
+		return nil, false
+	}
+	id := ctx.PathParamInt64("cache_id")
+	c, err := actions.GetCacheByID(ctx, ctx.ActionTask.RepoID, id)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+			return nil, false
+		}
+		log.Error("Error getting cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache")
+		return nil, false
+	}
+	// the caches of other refs can't be written
+	if c.Scope != saveScope {
+		ctx.HTTPError(http.StatusForbidden, "Error cache belongs to another ref")
+		return nil, false
+	}
+	if c.Complete {
+		ctx.HTTPError(http.StatusBadRequest, "Error cache has been committed")
+		return nil, false
+	}
+	return c, true
+}
+
+// parseContentRange parses the range of an uploaded chunk, e.g. `bytes 0-1023/*`
+func parseContentRange(s string) (start, end int64, err error) {
+	s, ok := strings.CutPrefix(s, "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid content range %q", s)
+	}
+	s, _, _ = strings.Cut(s, "/")
+	rawStart, rawEnd, _ := strings.Cut(s, "-")
+	if start, err = strconv.ParseInt(rawStart, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid content range %q", s)
+	}
+	if end, err = strconv.ParseInt(rawEnd, 10, 64); err != nil || end < start {
+		return 0, 0, fmt.Errorf("invalid content range %q", s)
+	}
+	return start, end, nil
+}
+
+// upload saves a chunk of the archive
+func (r cacheRoutes) upload(ctx *ArtifactContext) {
+	c, ok := r.uploadableCache(ctx)
+	if !ok {
+		return
+	}
+	start, end, err := parseContentRange(ctx.Req.Header.Get("Content-Range"))
+	if err != nil {
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+		return
+	}
+	if setting.Actions.CacheMaxSizePerRepo >= 0 && end >= setting.Actions.CacheMaxSizePerRepo {
+		ctx.HTTPError(http.StatusBadRequest, "Error cache size is over the limit")
+		return
+	}
+	if _, err := r.fs.Save(c.ChunkStoragePath(start), ctx.Req.Body, end-start+1); err != nil {
+		log.Error("Error saving chunk of cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error saving chunk")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+type commitCacheRequest struct {
+	Size int64 `json:"size"`
+}
+
+// commit merges the uploaded chunks into the archive, then the cache can be restored
+func (r cacheRoutes) commit(ctx *ArtifactContext) {
+	c, ok := r.uploadableCache(ctx)
+	if !ok {
+		return
+	}
+	var req commitCacheRequest
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+
+	type chunk struct {
+		path  string
+		start int64
+		size  int64
+	}
+	var chunks []chunk
+	if err := r.fs.IterateObjects(c.ChunksStorageDir(), func(p string, obj storage.Object) error {
+		defer obj.Close()
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		start, err := strconv.ParseInt(path.Base(p), 10, 64)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk{path: p, start: start, size: fi.Size()})
+		return nil
+	}); err != nil {
+		log.Error("Error listing chunks of cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error listing chunks")
+		return
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].start < chunks[j].start })
+	var size int64
+	for _, ch := range chunks {
+		if ch.start != size {
+			ctx.HTTPError(http.StatusBadRequest, fmt.Sprintf("Error chunk at offset %d is missing", size))
+			return
+		}
+		size += ch.size
+	}
+	if size != req.Size {
+		ctx.HTTPError(http.StatusBadRequest, fmt.Sprintf("Error uploaded size %d doesn't match the cache size %d", size, req.Size))
+		return
+	}
+
+	readers := make([]io.Reader, 0, len(chunks))
+	closeReaders := func() {
+		for _, rd := range readers {
+			_ = rd.(io.Closer).Close()
+		}
+		readers = nil
+	}
+	defer closeReaders()
+	for _, ch := range chunks {
+		f, err := r.fs.Open(ch.path)
+		if err != nil {
+			log.Error("Error opening chunk %s: %v", ch.path, err)
+			ctx.HTTPError(http.StatusInternalServerError, "Error opening chunk")
+			return
+		}
+		readers = append(readers, f)
+	}
+	if _, err := r.fs.Save(c.StoragePath(), io.MultiReader(readers...), size); err != nil {
+		log.Error("Error saving archive of cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error saving archive")
+		return
+	}
+	closeReaders()
+	for _, ch := range chunks {
+		if err := r.fs.Delete(ch.path); err != nil {
+			log.Warn("Error deleting chunk %s: %v", ch.path, err)
+		}
+	}
+
+	if err := actions.CommitCache(ctx, c, size); err != nil {
+		log.Error("Error committing cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error committing cache")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// clean is called by old versions of actions/cache, the caches are evicted by the cleanup cron task
+func (r cacheRoutes) clean(ctx *ArtifactContext) {
+	ctx.Status(http.StatusOK)
+}
+
+// download serves the archive for a signed archiveLocation
+func (r cacheRoutes) download(ctx *ArtifactContext) {
+	id := ctx.PathParamInt64("cache_id")
+	expires := ctx.Req.URL.Query().Get("expires")
+	sig, _ := base64.URLEncoding.DecodeString(ctx.Req.URL.Query().Get("sig"))
+	if !hmac.Equal(sig, r.buildSignature(expires, id)) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+		return
+	}
+	if t, err := time.Parse(time.RFC3339, expires); err != nil || t.Before(time.Now()) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error link expired")
+		return
+	}
+
+	c, err := actions.GetCacheByID(ctx, 0, id)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+			return
+		}
+		log.Error("Error getting cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache")
+		return
+	}
+	if !c.Complete {
+		ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+		return
+	}
+	f, err := r.fs.Open(c.StoragePath())
+	if err != nil {
+		log.Error("Error opening archive of cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error opening archive")
+		return
+	}
+	defer f.Close()
+	ctx.Resp.Header().Set("Content-Length", strconv.FormatInt(c.Size, 10))
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = io.Copy(ctx.Resp, f)
+}
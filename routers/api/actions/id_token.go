@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
+)
+
+type idTokenResponse struct {
+	Value string `json:"value"`
+}
+
+// issueIDToken issues an OIDC ID token for the running job, the response has the same form as the one of GitHub
+func issueIDToken(ctx *ArtifactContext) {
+	if ctx.ActionTask.Status != actions_model.StatusRunning {
+		ctx.HTTPError(http.StatusForbidden, "task is not running")
+		return
+	}
+
+	token, err := actions_service.CreateIDToken(ctx, ctx.ActionTask, ctx.Req.URL.Query().Get("audience"))
+	if err != nil {
+		if errors.Is(err, actions_service.ErrIDTokenUnavailable) {
+			ctx.HTTPError(http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.HTTPError(http.StatusForbidden, err.Error())
+			return
+		}
+		log.Error("Error creating ID token for task %d: %v", ctx.ActionTask.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error creating ID token")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, idTokenResponse{Value: token})
+}
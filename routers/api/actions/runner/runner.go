@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/actions"
@@ -67,7 +68,14 @@ func (s *Service) Register(
 		}
 	}
 
-	labels := req.Msg.Labels
+	labels, ephemeral := req.Msg.Labels, req.Msg.Ephemeral
+	if runnerToken.IsJIT {
+		if runnerToken.IsExpired() {
+			return nil, errors.New("runner registration token has expired")
+		}
+		// the runner is registered for the job the token has been created for
+		labels, ephemeral = runnerToken.Labels, true
+	}
 
 	// create new runner
 	name := util.EllipsisDisplayString(req.Msg.Name, 255)
@@ -78,21 +86,35 @@ func (s *Service) Register(
 		RepoID:      runnerToken.RepoID,
 		Version:     req.Msg.Version,
 		AgentLabels: labels,
-		Ephemeral:   req.Msg.Ephemeral,
+		Ephemeral:   ephemeral,
 	}
 	if err := runner.GenerateToken(); err != nil {
 		return nil, errors.New("can't generate token")
 	}
 
-	// create new runner
-	if err := actions_model.CreateRunner(ctx, runner); err != nil {
-		return nil, errors.New("can't create new runner")
-	}
+	// a just-in-time token is only consumed if the runner is created
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if runnerToken.IsJIT {
+			if err := actions_model.UseRunnerJITToken(ctx, runnerToken); err != nil {
+				return errors.New("runner registration token has been used")
+			}
+		}
+
+		// create new runner
+		if err := actions_model.CreateRunner(ctx, runner); err != nil {
+			return errors.New("can't create new runner")
+		}
 
-	// update token status
-	runnerToken.IsActive = true
-	if err := actions_model.UpdateRunnerToken(ctx, runnerToken, "is_active"); err != nil {
-		return nil, errors.New("can't update runner token status")
+		// update token status
+		if !runnerToken.IsJIT {
+			runnerToken.IsActive = true
+			if err := actions_model.UpdateRunnerToken(ctx, runnerToken, "is_active"); err != nil {
+				return errors.New("can't update runner token status")
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	res := connect.NewResponse(&runnerv1.RegisterResponse{
@@ -24,7 +24,9 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/generic"
 	"code.gitea.io/gitea/routers/api/packages/goproxy"
 	"code.gitea.io/gitea/routers/api/packages/helm"
+	"code.gitea.io/gitea/routers/api/packages/homebrew"
 	"code.gitea.io/gitea/routers/api/packages/maven"
+	"code.gitea.io/gitea/routers/api/packages/nix"
 	"code.gitea.io/gitea/routers/api/packages/npm"
 	"code.gitea.io/gitea/routers/api/packages/nuget"
 	"code.gitea.io/gitea/routers/api/packages/pub"
@@ -32,7 +34,9 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/rpm"
 	"code.gitea.io/gitea/routers/api/packages/rubygems"
 	"code.gitea.io/gitea/routers/api/packages/swift"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/api/packages/vagrant"
+	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
 )
@@ -121,6 +125,27 @@ func CommonRoutes() *web.Router {
 		&chef.Auth{},
 	})
 
+	r.Use(common.PackagesMaintenanceMode())
+
+	// "-" can't be a user name, the Terraform registry protocols need the same base url for all owners
+	r.Group("/-/terraform", func() {
+		r.Group("/modules/v1/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Group("/{version}", func() {
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadModule)
+				r.Get("/download", terraform.DownloadModule)
+				r.Get("/{filename}", terraform.DownloadModuleArchive)
+			})
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+		r.Group("/providers/v1/{username}/{type}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Group("/{version}", func() {
+				r.Get("/download/{os}/{arch}", terraform.ProviderPackageMetadata)
+				r.Get("/{filename}", terraform.DownloadProviderFile)
+				r.Put("/{filename}", reqPackageAccess(perm.AccessModeWrite), terraform.UploadProvider)
+			})
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+	})
 	r.Group("/{username}", func() {
 		r.Group("/alpine", func() {
 			r.Get("/key", alpine.GetRepositoryKey)
@@ -352,11 +377,31 @@ func CommonRoutes() *web.Router {
 			r.Get("/{filename}", helm.DownloadPackageFile)
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/homebrew", func() {
+			r.Get("/formula.json", homebrew.EnumerateFormulae)
+			r.Get("/formula/{filename}", homebrew.DownloadFormula)
+			r.Group("/bottles/{name}/{version}/{filename}", func() {
+				r.Get("", homebrew.DownloadBottle)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), homebrew.UploadBottle)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
 			r.Head("/*", maven.ProvidePackageFileHeader)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/nix", func() {
+			r.Get("/nix-cache-info", nix.GetCacheInfo)
+			r.Get("/public-key", nix.GetPublicKey)
+			r.Group("/nar/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.DownloadNar)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNar)
+			})
+			r.Group("/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.GetNarInfo)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNarInfo)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/nuget", func() {
 			r.Group("", func() { // Needs to be unauthenticated for the NuGet client.
 				r.Get("/", nuget.ServiceIndexV2)
@@ -540,6 +585,8 @@ func ContainerRoutes() *web.Router {
 		&container.Auth{},
 	})
 
+	r.Use(common.PackagesMaintenanceMode())
+
 	// TODO: Content Discovery / References (not implemented yet)
 
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
@@ -552,6 +599,7 @@ func ContainerRoutes() *web.Router {
 		r.PathGroup("/*", func(g *web.RouterPathGroup) {
 			g.MatchPath("POST", "/<image:*>/blobs/uploads", reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName, container.PostBlobsUploads)
 			g.MatchPath("GET", "/<image:*>/tags/list", container.VerifyImageName, container.GetTagsList)
+			g.MatchPath("GET", "/<image:*>/referrers/<digest>", container.VerifyImageName, container.GetReferrers)
 
 			patternBlobsUploadsUUID := g.PatternRegexp(`/<image:*>/blobs/uploads/<uuid:[-.=\w]+>`, reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName)
 			g.MatchPattern("GET", patternBlobsUploadsUUID, container.GetBlobsUpload)
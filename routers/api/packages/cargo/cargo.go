@@ -37,6 +37,7 @@ type StatusMessage struct {
 }
 
 func apiError(ctx *context.Context, status int, obj any) {
+	status = helper.ErrorStatus(status, obj)
 	message := helper.ProcessErrorForUser(ctx, status, obj)
 	ctx.JSON(status, StatusResponse{
 		OK: false,
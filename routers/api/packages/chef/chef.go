@@ -30,6 +30,7 @@ func apiError(ctx *context.Context, status int, obj any) {
 		ErrorMessages []string `json:"error_messages"`
 	}
 
+	status = helper.ErrorStatus(status, obj)
 	message := helper.ProcessErrorForUser(ctx, status, obj)
 	ctx.JSON(status, Error{
 		ErrorMessages: []string{message},
@@ -32,8 +32,11 @@ import (
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	upstream_service "code.gitea.io/gitea/services/packages/upstream"
 
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -93,6 +96,7 @@ func jsonResponse(ctx *context.Context, status int, obj any) {
 }
 
 func apiError(ctx *context.Context, status int, err error) {
+	status = helper.ErrorStatus(status, err)
 	_ = helper.ProcessErrorForUser(ctx, status, err)
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Status: status,
@@ -100,7 +104,7 @@ func apiError(ctx *context.Context, status int, err error) {
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#error-codes
-func apiErrorDefined(ctx *context.Context, err *namedError) {
+func apiErrorDefined(ctx *context.Context, err *container_service.NamedError) {
 	type ContainerError struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
@@ -124,7 +128,7 @@ func apiUnauthorizedError(ctx *context.Context) {
 	// container registry requires that the "/v2" must be in the root, so the sub-path in AppURL should be removed
 	realmURL := httplib.GuessCurrentHostURL(ctx) + "/v2/token"
 	ctx.Resp.Header().Add("WWW-Authenticate", `Bearer realm="`+realmURL+`",service="container_registry",scope="*"`)
-	apiErrorDefined(ctx, errUnauthorized)
+	apiErrorDefined(ctx, container_service.ErrUnauthorized)
 }
 
 // ReqContainerAccess is a middleware which checks the current user valid (real user or ghost if anonymous access is enabled)
@@ -137,7 +141,7 @@ func ReqContainerAccess(ctx *context.Context) {
 // VerifyImageName is a middleware which checks if the image name is allowed
 func VerifyImageName(ctx *context.Context) {
 	if !globalVars().imageNamePattern.MatchString(ctx.PathParam("image")) {
-		apiErrorDefined(ctx, errNameInvalid)
+		apiErrorDefined(ctx, container_service.ErrNameInvalid)
 	}
 }
 
@@ -251,7 +255,7 @@ func PostBlobsUploads(ctx *context.Context) {
 			}
 
 			if accessible {
-				if err := mountBlob(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: image}, blob.Blob); err != nil {
+				if err := container_service.MountBlob(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: image}, blob.Blob); err != nil {
 					apiError(ctx, http.StatusInternalServerError, err)
 					return
 				}
@@ -275,12 +279,12 @@ func PostBlobsUploads(ctx *context.Context) {
 		}
 		defer buf.Close()
 
-		if digest != digestFromHashSummer(buf) {
-			apiErrorDefined(ctx, errDigestInvalid)
+		if digest != container_service.DigestFromHashSummer(buf) {
+			apiErrorDefined(ctx, container_service.ErrDigestInvalid)
 			return
 		}
 
-		if _, err := saveAsPackageBlob(ctx,
+		if _, err := container_service.SaveAsPackageBlob(ctx,
 			buf,
 			&packages_service.PackageCreationInfo{
 				PackageInfo: packages_service.PackageInfo{
@@ -328,7 +332,7 @@ func GetBlobsUpload(ctx *context.Context) {
 	upload, err := packages_model.GetBlobUploadByID(ctx, uuid)
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageBlobUploadNotExist) {
-			apiErrorDefined(ctx, errBlobUploadUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUploadUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -355,7 +359,7 @@ func PatchBlobsUpload(ctx *context.Context) {
 	uploader, err := container_service.NewBlobUploader(ctx, ctx.PathParam("uuid"))
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageBlobUploadNotExist) {
-			apiErrorDefined(ctx, errBlobUploadUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUploadUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -367,16 +371,16 @@ func PatchBlobsUpload(ctx *context.Context) {
 	if contentRange != "" {
 		start, end := 0, 0
 		if _, err := fmt.Sscanf(contentRange, "%d-%d", &start, &end); err != nil {
-			apiErrorDefined(ctx, errBlobUploadInvalid)
+			apiErrorDefined(ctx, container_service.ErrBlobUploadInvalid)
 			return
 		}
 
 		if int64(start) != uploader.Size() {
-			apiErrorDefined(ctx, errBlobUploadInvalid.WithStatusCode(http.StatusRequestedRangeNotSatisfiable))
+			apiErrorDefined(ctx, container_service.ErrBlobUploadInvalid.WithStatusCode(http.StatusRequestedRangeNotSatisfiable))
 			return
 		}
 	} else if uploader.Size() != 0 {
-		apiErrorDefined(ctx, errBlobUploadInvalid.WithMessage("Stream uploads after first write are not allowed"))
+		apiErrorDefined(ctx, container_service.ErrBlobUploadInvalid.WithMessage("Stream uploads after first write are not allowed"))
 		return
 	}
 
@@ -402,14 +406,14 @@ func PutBlobsUpload(ctx *context.Context) {
 
 	digest := ctx.FormTrim("digest")
 	if digest == "" {
-		apiErrorDefined(ctx, errDigestInvalid)
+		apiErrorDefined(ctx, container_service.ErrDigestInvalid)
 		return
 	}
 
 	uploader, err := container_service.NewBlobUploader(ctx, ctx.PathParam("uuid"))
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageBlobUploadNotExist) {
-			apiErrorDefined(ctx, errBlobUploadUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUploadUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -424,12 +428,12 @@ func PutBlobsUpload(ctx *context.Context) {
 		}
 	}
 
-	if digest != digestFromHashSummer(uploader) {
-		apiErrorDefined(ctx, errDigestInvalid)
+	if digest != container_service.DigestFromHashSummer(uploader) {
+		apiErrorDefined(ctx, container_service.ErrDigestInvalid)
 		return
 	}
 
-	if _, err := saveAsPackageBlob(ctx,
+	if _, err := container_service.SaveAsPackageBlob(ctx,
 		uploader,
 		&packages_service.PackageCreationInfo{
 			PackageInfo: packages_service.PackageInfo{
@@ -472,7 +476,7 @@ func DeleteBlobsUpload(ctx *context.Context) {
 	_, err := packages_model.GetBlobUploadByID(ctx, uuid)
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageBlobUploadNotExist) {
-			apiErrorDefined(ctx, errBlobUploadUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUploadUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -507,7 +511,7 @@ func HeadBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errBlobUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -526,7 +530,7 @@ func GetBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errBlobUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -540,11 +544,11 @@ func GetBlob(ctx *context.Context) {
 func DeleteBlob(ctx *context.Context) {
 	d := digest.Digest(ctx.PathParam("digest"))
 	if d.Validate() != nil {
-		apiErrorDefined(ctx, errBlobUnknown)
+		apiErrorDefined(ctx, container_service.ErrBlobUnknown)
 		return
 	}
 
-	if err := deleteBlob(ctx, ctx.Package.Owner.ID, ctx.PathParam("image"), d); err != nil {
+	if err := container_service.DeleteBlob(ctx, ctx.Package.Owner.ID, ctx.PathParam("image"), d); err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -558,7 +562,7 @@ func DeleteBlob(ctx *context.Context) {
 func PutManifest(ctx *context.Context) {
 	reference := ctx.PathParam("reference")
 
-	mci := &manifestCreationInfo{
+	mci := &container_service.ManifestCreationInfo{
 		MediaType: ctx.Req.Header.Get("Content-Type"),
 		Owner:     ctx.Package.Owner,
 		Creator:   ctx.Doer,
@@ -568,7 +572,7 @@ func PutManifest(ctx *context.Context) {
 	}
 
 	if mci.IsTagged && !globalVars().referencePattern.MatchString(reference) {
-		apiErrorDefined(ctx, errManifestInvalid.WithMessage("Tag is invalid"))
+		apiErrorDefined(ctx, container_service.ErrManifestInvalid.WithMessage("Tag is invalid"))
 		return
 	}
 
@@ -581,17 +585,17 @@ func PutManifest(ctx *context.Context) {
 	defer buf.Close()
 
 	if buf.Size() > maxManifestSize {
-		apiErrorDefined(ctx, errManifestInvalid.WithMessage("Manifest exceeds maximum size").WithStatusCode(http.StatusRequestEntityTooLarge))
+		apiErrorDefined(ctx, container_service.ErrManifestInvalid.WithMessage("Manifest exceeds maximum size").WithStatusCode(http.StatusRequestEntityTooLarge))
 		return
 	}
 
-	digest, err := processManifest(ctx, mci, buf)
+	digest, err := container_service.ProcessManifest(ctx, mci, buf)
 	if err != nil {
-		var namedError *namedError
+		var namedError *container_service.NamedError
 		if errors.As(err, &namedError) {
 			apiErrorDefined(ctx, namedError)
 		} else if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errBlobUnknown)
+			apiErrorDefined(ctx, container_service.ErrBlobUnknown)
 		} else {
 			switch err {
 			case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
@@ -603,6 +607,14 @@ func PutManifest(ctx *context.Context) {
 		return
 	}
 
+	// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pushing-manifests-with-subject
+	var manifest struct {
+		Subject *oci.Descriptor `json:"subject"`
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err == nil && json.NewDecoder(buf).Decode(&manifest) == nil && manifest.Subject != nil {
+		ctx.Resp.Header().Set("OCI-Subject", string(manifest.Subject.Digest))
+	}
+
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
@@ -642,9 +654,17 @@ func getManifestFromContext(ctx *context.Context) (*packages_model.PackageFileDe
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
 func HeadManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		if registry := upstream_service.Get(ctx.Package.Owner, packages_model.TypeContainer); registry != nil {
+			if err := serveUpstreamManifest(ctx, registry); err != nil {
+				upstreamError(ctx, err)
+			}
+			return
+		}
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errManifestUnknown)
+			apiErrorDefined(ctx, container_service.ErrManifestUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -662,9 +682,17 @@ func HeadManifest(ctx *context.Context) {
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-manifests
 func GetManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		if registry := upstream_service.Get(ctx.Package.Owner, packages_model.TypeContainer); registry != nil {
+			if err := serveUpstreamManifest(ctx, registry); err != nil {
+				upstreamError(ctx, err)
+			}
+			return
+		}
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errManifestUnknown)
+			apiErrorDefined(ctx, container_service.ErrManifestUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -679,7 +707,7 @@ func GetManifest(ctx *context.Context) {
 func DeleteManifest(ctx *context.Context) {
 	opts, err := getBlobSearchOptionsFromContext(ctx)
 	if err != nil {
-		apiErrorDefined(ctx, errManifestUnknown)
+		apiErrorDefined(ctx, container_service.ErrManifestUnknown)
 		return
 	}
 
@@ -690,7 +718,7 @@ func DeleteManifest(ctx *context.Context) {
 	}
 
 	if len(pvs) == 0 {
-		apiErrorDefined(ctx, errManifestUnknown)
+		apiErrorDefined(ctx, container_service.ErrManifestUnknown)
 		return
 	}
 
@@ -742,7 +770,7 @@ func GetTagsList(ctx *context.Context) {
 
 	if _, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeContainer, image); err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiErrorDefined(ctx, errNameUnknown)
+			apiErrorDefined(ctx, container_service.ErrNameUnknown)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -785,6 +813,42 @@ func GetTagsList(ctx *context.Context) {
 // FIXME: Workaround to be removed in v1.20.
 // Update maybe we should never really remote it, as long as there is legacy data?
 // https://github.com/go-gitea/gitea/issues/19586
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	d := digest.Digest(ctx.PathParam("digest"))
+	if d.Validate() != nil {
+		apiErrorDefined(ctx, container_service.ErrDigestInvalid)
+		return
+	}
+
+	artifactType := ctx.FormTrim("artifactType")
+
+	// a missing image has no referrers, the response is an empty index
+	referrers, err := container_service.GetReferrers(ctx, ctx.Package.Owner.ID, ctx.PathParam("image"), d, artifactType)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	index := oci.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: make([]oci.Descriptor, 0, len(referrers)),
+	}
+	for _, referrer := range referrers {
+		index.Manifests = append(index.Manifests, referrer.Descriptor)
+	}
+
+	if artifactType != "" {
+		ctx.Resp.Header().Set("OCI-Filters-Applied", "artifactType")
+	}
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		Status:      http.StatusOK,
+		ContentType: oci.MediaTypeImageIndex,
+	})
+	_ = json.NewEncoder(ctx.Resp).Encode(index) // ignore network errors
+}
+
 func workaroundGetContainerBlob(ctx *context.Context, opts *container_model.BlobSearchOptions) (*packages_model.PackageFileDescriptor, error) {
 	blob, err := container_model.GetContainerBlob(ctx, opts)
 	if err != nil {
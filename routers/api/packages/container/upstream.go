@@ -0,0 +1,226 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	container_service "code.gitea.io/gitea/services/packages/container"
+	upstream_service "code.gitea.io/gitea/services/packages/upstream"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var upstreamManifestAccept = strings.Join([]string{
+	oci.MediaTypeImageManifest,
+	oci.MediaTypeImageIndex,
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}, ", ")
+
+// upstreamManifest is a manifest of the upstream registry as it is cached
+type upstreamManifest struct {
+	MediaType string `json:"media_type"`
+	Content   []byte `json:"content"`
+}
+
+func (m *upstreamManifest) digest() string {
+	return string(digest.FromBytes(m.Content))
+}
+
+// upstreamImageName returns the name of the image in the upstream registry, the official images of Docker Hub are in the "library" namespace
+func upstreamImageName(registry *upstream_service.Registry, image string) string {
+	if strings.Contains(image, "/") {
+		return image
+	}
+	if u, err := url.Parse(registry.Config.Upstream); err == nil && strings.HasSuffix(u.Hostname(), "docker.io") {
+		return "library/" + image
+	}
+	return image
+}
+
+// getUpstreamManifest returns the manifest of the reference from the upstream registry.
+// Manifests of tags are cached for the configured time, manifests of digests never change.
+func getUpstreamManifest(ctx *context.Context, registry *upstream_service.Registry, image, reference string) (*upstreamManifest, error) {
+	isDigest := digest.Digest(reference).Validate() == nil
+
+	data, err := registry.GetMetadata(ctx.Package.Owner.ID, image+":"+reference, !isDigest && upstream_service.IsRefreshRequested(ctx.Req), func() ([]byte, error) {
+		content, header, err := registry.Fetch(ctx, registry.URL("/v2/"+upstreamImageName(registry, image)+"/manifests/"+reference), http.Header{"Accept": []string{upstreamManifestAccept}})
+		if err != nil {
+			return nil, err
+		}
+		if len(content) > maxManifestSize {
+			return nil, upstream_service.ErrFileTooLarge
+		}
+		m := &upstreamManifest{
+			MediaType: header.Get("Content-Type"),
+			Content:   content,
+		}
+		if isDigest && m.digest() != reference {
+			return nil, upstream_service.ErrDigestMismatch
+		}
+		if !container_module.IsMediaTypeValid(m.MediaType) {
+			var index oci.Index
+			if err := json.Unmarshal(content, &index); err != nil {
+				return nil, err
+			}
+			m.MediaType = index.MediaType
+		}
+		return json.Marshal(m)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var m upstreamManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// fetchUpstreamBlob fetches a blob from the upstream registry and stores it in the upload version of the image
+func fetchUpstreamBlob(ctx *context.Context, registry *upstream_service.Registry, image string, d digest.Digest) error {
+	_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID: ctx.Package.Owner.ID,
+		Image:   image,
+		Digest:  string(d),
+	})
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		return err
+	}
+
+	buf, err := registry.Download(ctx, registry.URL("/v2/"+upstreamImageName(registry, image)+"/blobs/"+string(d)), nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if container_service.DigestFromHashSummer(buf) != string(d) {
+		return upstream_service.ErrDigestMismatch
+	}
+
+	_, err = container_service.SaveAsPackageBlob(ctx,
+		buf,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner: ctx.Package.Owner,
+				Name:  image,
+			},
+			Creator: upstream_service.Creator(ctx.Doer),
+		},
+	)
+	return err
+}
+
+// storeUpstreamManifest stores an image manifest of the upstream registry with its config and layers as untagged version
+func storeUpstreamManifest(ctx *context.Context, registry *upstream_service.Registry, image string, m *upstreamManifest) error {
+	var manifest oci.Manifest
+	if err := json.Unmarshal(m.Content, &manifest); err != nil {
+		return err
+	}
+	for _, descriptor := range append([]oci.Descriptor{manifest.Config}, manifest.Layers...) {
+		if err := fetchUpstreamBlob(ctx, registry, image, descriptor.Digest); err != nil {
+			return err
+		}
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(m.Content))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	mci := &container_service.ManifestCreationInfo{
+		MediaType: m.MediaType,
+		Owner:     ctx.Package.Owner,
+		Creator:   upstream_service.Creator(ctx.Doer),
+		Image:     image,
+		Reference: m.digest(),
+		IsTagged:  false,
+	}
+	if _, err := container_service.ProcessManifest(ctx, mci, buf); err != nil {
+		return err
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeContainer, image, mci.Reference)
+	if err != nil {
+		return err
+	}
+	return packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, upstream_service.PropertyUpstream, registry.Config.Upstream)
+}
+
+// serveUpstreamManifest serves a manifest of the upstream registry.
+// Image manifests are stored with their blobs so the client can pull the layers from this registry, image indexes are served as they are
+// and their manifests get stored when the client pulls them by digest. Tags are resolved by the upstream registry and are not stored.
+func serveUpstreamManifest(ctx *context.Context, registry *upstream_service.Registry) error {
+	image := ctx.PathParam("image")
+	reference := ctx.PathParam("reference")
+	if digest.Digest(reference).Validate() != nil && !globalVars().referencePattern.MatchString(reference) {
+		return upstream_service.ErrNotFound
+	}
+
+	m, err := getUpstreamManifest(ctx, registry, image, reference)
+	if err != nil {
+		return err
+	}
+
+	if container_module.IsMediaTypeImageManifest(m.MediaType) {
+		_, err := workaroundGetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    ctx.Package.Owner.ID,
+			Image:      image,
+			Digest:     m.digest(),
+			IsManifest: true,
+		})
+		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			err = storeUpstreamManifest(ctx, registry, image, m)
+		}
+		if err != nil {
+			return err
+		}
+	} else if !container_module.IsMediaTypeImageIndex(m.MediaType) {
+		return container_service.ErrManifestInvalid.WithMessage("MediaType not recognized")
+	}
+
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		ContentDigest: m.digest(),
+		ContentType:   m.MediaType,
+		ContentLength: optional.Some(int64(len(m.Content))),
+		Status:        http.StatusOK,
+	})
+	if ctx.Req.Method != http.MethodHead {
+		_, _ = ctx.Resp.Write(m.Content)
+	}
+	return nil
+}
+
+func upstreamError(ctx *context.Context, err error) {
+	var namedError *container_service.NamedError
+	switch {
+	case errors.As(err, &namedError):
+		apiErrorDefined(ctx, namedError)
+	case errors.Is(err, upstream_service.ErrNotFound), errors.Is(err, container_model.ErrContainerBlobNotExist):
+		apiErrorDefined(ctx, container_service.ErrManifestUnknown)
+	case errors.Is(err, upstream_service.ErrFileTooLarge),
+		errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+		apiError(ctx, http.StatusForbidden, err)
+	default:
+		apiError(ctx, http.StatusBadGateway, err)
+	}
+}
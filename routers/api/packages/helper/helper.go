@@ -4,6 +4,7 @@
 package helper
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
 )
 
 // ProcessErrorForUser logs the error and returns a user-error message for the end user.
@@ -37,6 +39,14 @@ func ProcessErrorForUser(ctx *context.Context, status int, errObj any) string {
 	return message
 }
 
+// ErrorStatus returns the status for errors every package registry reports the same way, otherwise the given status
+func ErrorStatus(status int, errObj any) int {
+	if err, ok := errObj.(error); ok && errors.Is(err, packages_service.ErrDownloadBlocked) {
+		return http.StatusForbidden
+	}
+	return status
+}
+
 // ServePackageFile the content of the package file
 // If the url is set it will redirect the request, otherwise the content is copied to the response.
 func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf *packages_model.PackageFile, forceOpts ...*context.ServeHeaderOptions) {
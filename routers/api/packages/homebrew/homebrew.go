@@ -0,0 +1,392 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package homebrew
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	homebrew_module "code.gitea.io/gitea/modules/packages/homebrew"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	status = helper.ErrorStatus(status, obj)
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.PlainText(status, message)
+}
+
+func baseURL(ctx *context.Context) string {
+	return fmt.Sprintf("%sapi/packages/%s/homebrew", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name))
+}
+
+func rootURL(ctx *context.Context, pd *packages_model.PackageDescriptor) string {
+	return baseURL(ctx) + "/bottles/" + url.PathEscape(pd.Package.Name) + "/" + url.PathEscape(pd.Version.Version)
+}
+
+type bottleFile struct {
+	Cellar string `json:"cellar"`
+	URL    string `json:"url"`
+	Sha256 string `json:"sha256"`
+}
+
+type bottleSpec struct {
+	Rebuild int                    `json:"rebuild"`
+	RootURL string                 `json:"root_url"`
+	Files   map[string]*bottleFile `json:"files"`
+}
+
+// formulaInfo is the subset of the formula JSON API of Homebrew which describes a bottled formula
+// https://formulae.brew.sh/docs/api/
+type formulaInfo struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Desc     string `json:"desc"`
+	License  string `json:"license,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+	Versions struct {
+		Stable string `json:"stable"`
+		Bottle bool   `json:"bottle"`
+	} `json:"versions"`
+	Bottle struct {
+		Stable *bottleSpec `json:"stable"`
+	} `json:"bottle"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// sortedBottleFiles returns the bottle files of a formula version ordered by their tag
+func sortedBottleFiles(pd *packages_model.PackageDescriptor) []*packages_model.PackageFileDescriptor {
+	files := make([]*packages_model.PackageFileDescriptor, len(pd.Files))
+	copy(files, pd.Files)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Properties.GetByName(homebrew_module.PropertyTag) < files[j].Properties.GetByName(homebrew_module.PropertyTag)
+	})
+	return files
+}
+
+func newBottleSpec(ctx *context.Context, pd *packages_model.PackageDescriptor) *bottleSpec {
+	spec := &bottleSpec{
+		RootURL: rootURL(ctx, pd),
+		Files:   make(map[string]*bottleFile, len(pd.Files)),
+	}
+	for _, f := range pd.Files {
+		// "brew" builds the url of a bottle from the root url and the rebuild number of the version, so only the bottles of the latest rebuild are listed
+		rebuild, _ := strconv.Atoi(f.Properties.GetByName(homebrew_module.PropertyRebuild))
+		if rebuild > spec.Rebuild {
+			spec.Rebuild = rebuild
+			clear(spec.Files)
+		} else if rebuild < spec.Rebuild {
+			continue
+		}
+		spec.Files[f.Properties.GetByName(homebrew_module.PropertyTag)] = &bottleFile{
+			Cellar: f.Properties.GetByName(homebrew_module.PropertyCellar),
+			URL:    spec.RootURL + "/" + url.PathEscape(f.File.Name),
+			Sha256: f.Blob.HashSHA256,
+		}
+	}
+	return spec
+}
+
+func newFormulaInfo(ctx *context.Context, pd *packages_model.PackageDescriptor) *formulaInfo {
+	metadata := pd.Metadata.(*homebrew_module.Metadata)
+
+	info := &formulaInfo{
+		Name:         pd.Package.Name,
+		FullName:     pd.Package.Name,
+		Desc:         metadata.Description,
+		License:      metadata.License,
+		Homepage:     metadata.Homepage,
+		Dependencies: metadata.Dependencies,
+	}
+	if info.Dependencies == nil {
+		info.Dependencies = []string{}
+	}
+	info.Versions.Stable = pd.Version.Version
+	info.Versions.Bottle = true
+	info.Bottle.Stable = newBottleSpec(ctx, pd)
+	return info
+}
+
+func getLatestPackageDescriptor(ctx *context.Context, name string) (*packages_model.PackageDescriptor, error) {
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ctx.Package.Owner.ID,
+		Type:    packages_model.TypeHomebrew,
+		Name: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      name,
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+	return packages_model.GetPackageDescriptor(ctx, pvs[0])
+}
+
+// EnumerateFormulae serves the JSON of the latest version of every formula
+func EnumerateFormulae(ctx *context.Context) {
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       packages_model.TypeHomebrew,
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	formulae := make([]*formulaInfo, 0, len(pds))
+	for _, pd := range pds {
+		formulae = append(formulae, newFormulaInfo(ctx, pd))
+	}
+	sort.Slice(formulae, func(i, j int) bool {
+		return formulae[i].Name < formulae[j].Name
+	})
+
+	ctx.JSON(http.StatusOK, formulae)
+}
+
+// DownloadFormula serves the latest version of a formula, either as JSON ("<name>.json")
+// or as a Ruby formula file ("<name>.rb") which can be added to a tap
+func DownloadFormula(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	name, isJSON := strings.CutSuffix(filename, ".json")
+	if !isJSON {
+		var isRuby bool
+		name, isRuby = strings.CutSuffix(filename, ".rb")
+		if !isRuby {
+			apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+			return
+		}
+	}
+
+	pd, err := getLatestPackageDescriptor(ctx, name)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if len(pd.Files) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	if isJSON {
+		ctx.JSON(http.StatusOK, newFormulaInfo(ctx, pd))
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(buildFormula(ctx, pd)), &context.ServeHeaderOptions{
+		ContentType: "text/x-ruby",
+		Filename:    filename,
+	})
+}
+
+// rubyString quotes a value as a Ruby double quoted string literal
+func rubyString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `#`, `\#`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// rubySymbolOrString returns a cellar like ":any" as symbol and a path as string
+func rubySymbolOrString(s string) string {
+	if strings.HasPrefix(s, ":") {
+		return s
+	}
+	return rubyString(s)
+}
+
+// buildFormula creates a formula file which installs the bottles of a formula version.
+// The formula can't be built from source, the url of the stable spec points to a bottle.
+func buildFormula(ctx *context.Context, pd *packages_model.PackageDescriptor) []byte {
+	metadata := pd.Metadata.(*homebrew_module.Metadata)
+	spec := newBottleSpec(ctx, pd)
+	files := sortedBottleFiles(pd)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "class %s < Formula\n", homebrew_module.ClassName(pd.Package.Name))
+	if metadata.Description != "" {
+		fmt.Fprintf(&buf, "  desc %s\n", rubyString(metadata.Description))
+	}
+	if metadata.Homepage != "" {
+		fmt.Fprintf(&buf, "  homepage %s\n", rubyString(metadata.Homepage))
+	}
+	fmt.Fprintf(&buf, "  url %s\n", rubyString(spec.RootURL+"/"+url.PathEscape(files[0].File.Name)))
+	fmt.Fprintf(&buf, "  version %s\n", rubyString(pd.Version.Version))
+	fmt.Fprintf(&buf, "  sha256 %s\n", rubyString(files[0].Blob.HashSHA256))
+	if metadata.License != "" {
+		fmt.Fprintf(&buf, "  license %s\n", rubyString(metadata.License))
+	}
+	buf.WriteString("\n  bottle do\n")
+	fmt.Fprintf(&buf, "    root_url %s\n", rubyString(spec.RootURL))
+	if spec.Rebuild > 0 {
+		fmt.Fprintf(&buf, "    rebuild %d\n", spec.Rebuild)
+	}
+	for _, f := range files {
+		tag := f.Properties.GetByName(homebrew_module.PropertyTag)
+		if bf, ok := spec.Files[tag]; ok && bf.Sha256 == f.Blob.HashSHA256 {
+			fmt.Fprintf(&buf, "    sha256 cellar: %s, %s: %s\n", rubySymbolOrString(bf.Cellar), tag, rubyString(bf.Sha256))
+		}
+	}
+	buf.WriteString("  end\n")
+	if len(metadata.Dependencies) > 0 {
+		buf.WriteString("\n")
+		for _, dep := range metadata.Dependencies {
+			fmt.Fprintf(&buf, "  depends_on %s\n", rubyString(dep))
+		}
+	}
+	buf.WriteString("\n  def install\n")
+	buf.WriteString("    odie \"#{name} is only available as bottle\"\n")
+	buf.WriteString("  end\nend\n")
+	return buf.Bytes()
+}
+
+// DownloadBottle serves a bottle file
+func DownloadBottle(ctx *context.Context) {
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHomebrew,
+			Name:        ctx.PathParam("name"),
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.PathParam("filename"),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadBottle publishes a bottle of a formula version.
+// The file name must be the name created by "brew bottle", like name--1.0.0.arm64_sonoma.bottle.tar.gz,
+// the cellar of the bottle can be set by the "cellar" parameter and defaults to ":any".
+func UploadBottle(ctx *context.Context) {
+	name, packageVersion, filename := ctx.PathParam("name"), ctx.PathParam("version"), ctx.PathParam("filename")
+	if !homebrew_module.IsValidName(name) {
+		apiError(ctx, http.StatusBadRequest, homebrew_module.ErrInvalidFormulaName)
+		return
+	}
+	if !homebrew_module.IsValidVersion(packageVersion) {
+		apiError(ctx, http.StatusBadRequest, homebrew_module.ErrInvalidVersion)
+		return
+	}
+	bottle, err := homebrew_module.ParseBottleFilename(name, packageVersion, filename)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	cellar := ctx.FormString("cellar", homebrew_module.CellarAny)
+	if !homebrew_module.IsValidCellar(cellar) {
+		apiError(ctx, http.StatusBadRequest, homebrew_module.ErrInvalidCellarOption)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := homebrew_module.ParseBottle(buf, name)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeHomebrew,
+				Name:        name,
+				Version:     packageVersion,
+			},
+			Creator:  ctx.Doer,
+			Metadata: metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: homebrew_module.BottleFilename(name, packageVersion, bottle),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+			Properties: map[string]string{
+				homebrew_module.PropertyTag:     bottle.Tag,
+				homebrew_module.PropertyCellar:  cellar,
+				homebrew_module.PropertyRebuild: strconv.Itoa(bottle.Rebuild),
+			},
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+)
+
+const narInfoSuffix = ".narinfo"
+
+func apiError(ctx *context.Context, status int, obj any) {
+	status = helper.ErrorStatus(status, obj)
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.PlainText(status, message)
+}
+
+// GetCacheInfo serves the nix-cache-info file which describes the binary cache
+func GetCacheInfo(ctx *context.Context) {
+	ctx.Resp.Header().Set("Content-Type", "text/x-nix-cache-info")
+	ctx.PlainText(http.StatusOK, fmt.Sprintf("StoreDir: %s\nWantMassQuery: 1\nPriority: 41\n", nix_module.StoreDir))
+}
+
+// GetPublicKey serves the public key which has to be added to the trusted-public-keys of Nix
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := nix_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+func getNarInfoHash(ctx *context.Context) (string, bool) {
+	hash, ok := strings.CutSuffix(ctx.PathParam("filename"), narInfoSuffix)
+	return hash, ok && nix_module.IsValidHash(hash)
+}
+
+func getVersionByHash(ctx *context.Context, hash string) (*packages_model.PackageDescriptor, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ctx.Package.Owner.ID,
+		Type:    packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      hash,
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+	return packages_model.GetPackageDescriptor(ctx, pvs[0])
+}
+
+// GetNarInfo serves the narinfo of a store path signed with the key of the owner
+func GetNarInfo(ctx *context.Context) {
+	hash, ok := getNarInfoHash(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pd, err := getVersionByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	metadata := pd.Metadata.(*nix_module.Metadata)
+
+	priv, _, err := nix_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	sig, err := nix_module.Sign(priv, metadata)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := metadata.WriteNarInfo(&buf, sig); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "text/x-nix-narinfo")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if ctx.Req.Method != http.MethodHead {
+		_, _ = buf.WriteTo(ctx.Resp)
+	}
+}
+
+// DownloadNar serves a NAR of a published store path
+func DownloadNar(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if !nix_module.IsValidNarFilename(filename) {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+		OwnerID:     ctx.Package.Owner.ID,
+		PackageType: packages_model.TypeNix,
+		Query:       filename,
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	for _, pf := range pfs {
+		if pf.LowerName != strings.ToLower(filename) {
+			continue
+		}
+
+		s, u, _, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		helper.ServePackageFile(ctx, s, u, pf)
+		return
+	}
+
+	apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+}
+
+// UploadNar stores a NAR until the narinfo of its store path gets uploaded
+func UploadNar(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if !nix_module.IsValidNarFilename(filename) {
+		apiError(ctx, http.StatusBadRequest, nix_module.ErrInvalidNarURL)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if err := packages_service.CheckSizeQuotaExceeded(ctx, ctx.Doer, ctx.Package.Owner, packages_model.TypeNix, buf.Size()); err != nil {
+		apiError(ctx, http.StatusForbidden, err)
+		return
+	}
+
+	pv, err := packages_service.GetOrCreateInternalPackageVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeNix, nix_module.UploadVersion, nix_module.UploadVersion)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = packages_service.AddFileToPackageVersionInternal(ctx, pv, &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator:           ctx.Doer,
+		Data:              buf,
+		OverwriteExisting: true,
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// UploadNarInfo publishes a store path, its NAR must have been uploaded before
+func UploadNarInfo(ctx *context.Context) {
+	hash, ok := getNarInfoHash(ctx)
+	if !ok {
+		apiError(ctx, http.StatusBadRequest, nix_module.ErrInvalidStorePath)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	metadata, err := nix_module.ParseNarInfo(upload)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	storePathHash, name, _ := nix_module.ParseStorePath(metadata.StorePath)
+	if storePathHash != hash {
+		apiError(ctx, http.StatusBadRequest, nix_module.ErrInvalidStorePath)
+		return
+	}
+
+	// the signatures of the cache are created when the narinfo is served
+	_, pub, err := nix_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	keyName, _, _ := strings.Cut(pub, ":")
+	metadata.Signatures = slices.DeleteFunc(metadata.Signatures, func(sig string) bool {
+		return strings.HasPrefix(sig, keyName+":")
+	})
+
+	uploadVersion, err := packages_service.GetOrCreateInternalPackageVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeNix, nix_module.UploadVersion, nix_module.UploadVersion)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	narFilename := path.Base(metadata.URL)
+	narFile, err := packages_model.GetFileForVersionByName(ctx, uploadVersion.ID, narFilename, packages_model.EmptyFileKey)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("the nar %s must be uploaded before the narinfo", metadata.URL))
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	pb, err := packages_model.GetBlobByID(ctx, narFile.BlobID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if metadata.FileHash != "" {
+		if fileHash, _ := nix_module.ParseSHA256(metadata.FileHash); fileHash != pb.HashSHA256 {
+			apiError(ctx, http.StatusBadRequest, fmt.Errorf("the FileHash does not match the uploaded nar %s", metadata.URL))
+			return
+		}
+	}
+	if metadata.FileSize != 0 && metadata.FileSize != pb.Size {
+		apiError(ctx, http.StatusBadRequest, fmt.Errorf("the FileSize does not match the uploaded nar %s", metadata.URL))
+		return
+	}
+
+	buf, err := openBlobAsHashedBuffer(pb)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNix,
+				Name:        name,
+				Version:     hash,
+			},
+			Creator:  ctx.Doer,
+			Metadata: metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: narFilename,
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if err := packages_service.DeletePackageFile(ctx, narFile); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func openBlobAsHashedBuffer(pb *packages_model.PackageBlob) (*packages_module.HashedBuffer, error) {
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return packages_module.CreateHashedBufferFromReader(s)
+}
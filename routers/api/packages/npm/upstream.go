@@ -0,0 +1,275 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/validation"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	upstream_service "code.gitea.io/gitea/services/packages/upstream"
+
+	"github.com/hashicorp/go-version"
+)
+
+// upstreamVersion contains the fields of a version of the upstream package which are stored in the metadata,
+// the fields which have different types in old packages are decoded later
+type upstreamVersion struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Description          string            `json:"description"`
+	Author               npm_module.User   `json:"author"`
+	Homepage             string            `json:"homepage"`
+	License              any               `json:"license"`
+	Repository           any               `json:"repository"`
+	Keywords             any               `json:"keywords"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	PeerDependenciesMeta map[string]any    `json:"peerDependenciesMeta"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	Bin                  any               `json:"bin"`
+	Dist                 struct {
+		Integrity string `json:"integrity"`
+		Shasum    string `json:"shasum"`
+		Tarball   string `json:"tarball"`
+	} `json:"dist"`
+}
+
+// license returns the license of the version, old packages use an object like {"type": "MIT"}
+func (v *upstreamVersion) license() string {
+	switch license := v.License.(type) {
+	case string:
+		return license
+	case map[string]any:
+		s, _ := license["type"].(string)
+		return s
+	}
+	return ""
+}
+
+func (v *upstreamVersion) filename() string {
+	return strings.ToLower(path.Base(v.Dist.Tarball))
+}
+
+func (v *upstreamVersion) toMetadata(readme string) *npm_module.Metadata {
+	scope, name, ok := strings.Cut(v.Name, "/")
+	if !ok {
+		scope, name = "", v.Name
+	}
+
+	m := &npm_module.Metadata{
+		Scope:                   scope,
+		Name:                    name,
+		Description:             v.Description,
+		Author:                  v.Author.Name,
+		License:                 v.license(),
+		Dependencies:            v.Dependencies,
+		DevelopmentDependencies: v.DevDependencies,
+		PeerDependencies:        v.PeerDependencies,
+		PeerDependenciesMeta:    v.PeerDependenciesMeta,
+		OptionalDependencies:    v.OptionalDependencies,
+		Readme:                  readme,
+	}
+	if validation.IsValidURL(v.Homepage) {
+		m.ProjectURL = v.Homepage
+	}
+	switch repository := v.Repository.(type) {
+	case string:
+		m.Repository.URL = repository
+	case map[string]any:
+		m.Repository.Type, _ = repository["type"].(string)
+		m.Repository.URL, _ = repository["url"].(string)
+	}
+	if keywords, ok := v.Keywords.([]any); ok {
+		for _, keyword := range keywords {
+			if s, ok := keyword.(string); ok {
+				m.Keywords = append(m.Keywords, s)
+			}
+		}
+	}
+	switch bin := v.Bin.(type) {
+	case string:
+		m.Bin = map[string]string{name: bin}
+	case map[string]any:
+		m.Bin = make(map[string]string, len(bin))
+		for k, v := range bin {
+			if s, ok := v.(string); ok {
+				m.Bin[k] = s
+			}
+		}
+	}
+	return m
+}
+
+// getUpstreamPackage returns the package document of the upstream registry, the document is cached for the configured time
+func getUpstreamPackage(ctx *context.Context, registry *upstream_service.Registry, packageName string) (map[string]any, error) {
+	data, err := registry.GetMetadata(ctx.Package.Owner.ID, packageName, upstream_service.IsRefreshRequested(ctx.Req), func() ([]byte, error) {
+		data, _, err := registry.Fetch(ctx, registry.URL("/"+url.PathEscape(packageName)), http.Header{"Accept": []string{"application/json"}})
+		return data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func decodeUpstreamVersion(raw any) (*upstreamVersion, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var v upstreamVersion
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// serveUpstreamPackageMetadata serves the package document of the upstream registry.
+// The versions with a license which isn't allowed are removed and the tarballs point to this registry.
+func serveUpstreamPackageMetadata(ctx *context.Context, registry *upstream_service.Registry, registryURL, packageName string) error {
+	doc, err := getUpstreamPackage(ctx, registry, packageName)
+	if err != nil {
+		return err
+	}
+
+	versions, _ := doc["versions"].(map[string]any)
+	for ver, raw := range versions {
+		v, err := decodeUpstreamVersion(raw)
+		if err != nil || !registry.IsLicenseAllowed(v.license()) {
+			delete(versions, ver)
+			continue
+		}
+		if dist, ok := raw.(map[string]any)["dist"].(map[string]any); ok {
+			dist["tarball"] = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(ver), url.PathEscape(v.filename()))
+		}
+	}
+	if distTags, ok := doc["dist-tags"].(map[string]any); ok {
+		for tag, ver := range distTags {
+			if s, _ := ver.(string); versions[s] == nil {
+				delete(distTags, tag)
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, doc)
+	return nil
+}
+
+// fetchUpstreamVersion fetches the tarball of a version from the upstream registry and stores it as package
+func fetchUpstreamVersion(ctx *context.Context, registry *upstream_service.Registry, packageName, packageVersion, filename string) error {
+	doc, err := getUpstreamPackage(ctx, registry, packageName)
+	if err != nil {
+		return err
+	}
+
+	versions, _ := doc["versions"].(map[string]any)
+	raw, ok := versions[packageVersion]
+	if !ok {
+		return upstream_service.ErrNotFound
+	}
+	v, err := decodeUpstreamVersion(raw)
+	if err != nil {
+		return err
+	}
+	if v.filename() != filename {
+		return upstream_service.ErrNotFound
+	}
+	if !registry.IsLicenseAllowed(v.license()) {
+		return upstream_service.ErrLicenseNotAllowed
+	}
+	semver, err := version.NewSemver(v.Version)
+	if err != nil {
+		return err
+	}
+
+	buf, err := registry.Download(ctx, v.Dist.Tarball, nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, hashSHA1, _, hashSHA512 := buf.Sums()
+	if algorithm, integrity, ok := strings.Cut(v.Dist.Integrity, "-"); ok && algorithm == "sha512" {
+		expected, err := base64.StdEncoding.DecodeString(integrity)
+		if err != nil || !bytes.Equal(expected, hashSHA512) {
+			return upstream_service.ErrDigestMismatch
+		}
+	} else if !strings.EqualFold(v.Dist.Shasum, hex.EncodeToString(hashSHA1)) {
+		return upstream_service.ErrDigestMismatch
+	}
+
+	readme, _ := doc["readme"].(string)
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        packageName,
+				Version:     semver.String(),
+			},
+			SemverCompatible: true,
+			Creator:          upstream_service.Creator(ctx.Doer),
+			Metadata:         v.toMetadata(readme),
+			VersionProperties: map[string]string{
+				upstream_service.PropertyUpstream: registry.Config.Upstream,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: upstream_service.Creator(ctx.Doer),
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+		// the file was fetched by a concurrent request
+		return nil
+	}
+	return err
+}
+
+// hasOnlyUpstreamVersions checks if all versions of a package were fetched from the upstream registry
+func hasOnlyUpstreamVersions(pds []*packages_model.PackageDescriptor) bool {
+	for _, pd := range pds {
+		if pd.VersionProperties.GetByName(upstream_service.PropertyUpstream) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func upstreamErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, upstream_service.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, upstream_service.ErrLicenseNotAllowed), errors.Is(err, upstream_service.ErrFileTooLarge),
+		errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadGateway
+	}
+}
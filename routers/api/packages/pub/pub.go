@@ -40,6 +40,7 @@ func apiError(ctx *context.Context, status int, obj any) {
 		Error Error `json:"error"`
 	}
 
+	status = helper.ErrorStatus(status, obj)
 	message := helper.ProcessErrorForUser(ctx, status, obj)
 	jsonResponse(ctx, status, ErrorWrapper{
 		Error: Error{
@@ -8,12 +8,14 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
 	"unicode"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	"code.gitea.io/gitea/modules/setting"
@@ -21,6 +23,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	upstream_service "code.gitea.io/gitea/services/packages/upstream"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -40,6 +43,7 @@ var versionMatcher = regexp.MustCompile(`\Av?` +
 	`\z`)
 
 func apiError(ctx *context.Context, status int, obj any) {
+	status = helper.ErrorStatus(status, obj)
 	message := helper.ProcessErrorForUser(ctx, status, obj)
 	ctx.PlainText(status, message)
 }
@@ -47,16 +51,13 @@ func apiError(ctx *context.Context, status int, obj any) {
 // PackageMetadata returns the metadata for a single package
 func PackageMetadata(ctx *context.Context) {
 	packageName := normalizer.Replace(ctx.PathParam("id"))
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
-	}
 
 	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
 	if err != nil {
@@ -64,14 +65,43 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 
+	// the upstream project is served as long as no version was uploaded to this registry
+	if registry := upstream_service.Get(ctx.Package.Owner, packages_model.TypePyPI); registry != nil && hasOnlyUpstreamVersions(pds) {
+		err := serveUpstreamPackageMetadata(ctx, registry, registryURL, packageName)
+		if err == nil {
+			return
+		}
+		if len(pds) == 0 {
+			apiError(ctx, upstreamErrorStatus(err), err)
+			return
+		}
+		log.Warn("Serving the local versions of %s, the upstream registry failed: %v", packageName, err)
+	}
+
+	if len(pds) == 0 {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
 	// sort package descriptors by version to mimic PyPI format
 	sort.Slice(pds, func(i, j int) bool {
 		return strings.Compare(pds[i].Version.Version, pds[j].Version.Version) < 0
 	})
 
-	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
-	ctx.Data["PackageDescriptors"] = pds
+	links := make([]*simpleLink, 0, len(pds))
+	for _, pd := range pds {
+		for _, pf := range pd.Files {
+			links = append(links, &simpleLink{
+				URL:            registryURL + "/files/" + pd.Package.LowerName + "/" + pd.Version.Version + "/" + pf.File.Name,
+				Filename:       pf.File.Name,
+				SHA256:         pf.Blob.HashSHA256,
+				RequiresPython: pd.Metadata.(*pypi_module.Metadata).RequiresPython,
+			})
+		}
+	}
+
+	ctx.Data["PackageName"] = pds[0].Package.Name
+	ctx.Data["Links"] = links
 	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
 }
 
@@ -81,19 +111,32 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypePyPI,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	openFile := func() (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+		return packages_service.OpenFileForDownloadByPackageNameAndVersion(
+			ctx,
+			&packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			&packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			ctx.Req.Method,
+		)
+	}
+
+	s, u, pf, err := openFile()
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if registry := upstream_service.Get(ctx.Package.Owner, packages_model.TypePyPI); registry != nil {
+			if err := fetchUpstreamFile(ctx, registry, packageName, packageVersion, filename); err != nil {
+				apiError(ctx, upstreamErrorStatus(err), err)
+				return
+			}
+			s, u, pf, err = openFile()
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
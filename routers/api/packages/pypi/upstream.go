@@ -0,0 +1,244 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/modules/validation"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	upstream_service "code.gitea.io/gitea/services/packages/upstream"
+)
+
+// simpleLink is a file link of the simple repository page
+type simpleLink struct {
+	URL            string
+	Filename       string
+	SHA256         string
+	RequiresPython string
+}
+
+// upstreamInfo is the project or release information of the PyPI JSON API
+// https://docs.pypi.org/api/json/
+type upstreamInfo struct {
+	Name              string            `json:"name"`
+	Author            string            `json:"author"`
+	Summary           string            `json:"summary"`
+	Description       string            `json:"description"`
+	HomePage          string            `json:"home_page"`
+	License           string            `json:"license"`
+	LicenseExpression string            `json:"license_expression"`
+	Classifiers       []string          `json:"classifiers"`
+	RequiresPython    string            `json:"requires_python"`
+	ProjectURLs       map[string]string `json:"project_urls"`
+}
+
+type upstreamFile struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Digests  struct {
+		SHA256 string `json:"sha256"`
+	} `json:"digests"`
+	RequiresPython string `json:"requires_python"`
+	Yanked         bool   `json:"yanked"`
+}
+
+type upstreamProject struct {
+	Info     upstreamInfo               `json:"info"`
+	Releases map[string][]*upstreamFile `json:"releases"`
+}
+
+// license returns the SPDX expression of the release, or the short license text, or the license of the classifiers like "MIT License"
+func (info *upstreamInfo) license() string {
+	if info.LicenseExpression != "" {
+		return info.LicenseExpression
+	}
+	if info.License != "" && len(info.License) <= 100 && !strings.Contains(info.License, "\n") {
+		return info.License
+	}
+	for _, classifier := range info.Classifiers {
+		if license, ok := strings.CutPrefix(classifier, "License :: "); ok {
+			parts := strings.Split(license, " :: ")
+			return parts[len(parts)-1]
+		}
+	}
+	return ""
+}
+
+func (info *upstreamInfo) homepage() string {
+	for label, u := range info.ProjectURLs {
+		if normalizeLabel(label) == "homepage" && validation.IsValidURL(u) {
+			return u
+		}
+	}
+	if validation.IsValidURL(info.HomePage) {
+		return info.HomePage
+	}
+	return ""
+}
+
+// getUpstreamProject returns the project of the upstream registry, the project is cached for the configured time
+func getUpstreamProject(ctx *context.Context, registry *upstream_service.Registry, packageName string) (*upstreamProject, error) {
+	data, err := registry.GetMetadata(ctx.Package.Owner.ID, packageName, upstream_service.IsRefreshRequested(ctx.Req), func() ([]byte, error) {
+		data, _, err := registry.Fetch(ctx, registry.URL("/pypi/"+url.PathEscape(packageName)+"/json"), nil)
+		return data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var project upstreamProject
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// serveUpstreamPackageMetadata serves the simple repository page of a project of the upstream registry, the links point to this registry
+func serveUpstreamPackageMetadata(ctx *context.Context, registry *upstream_service.Registry, registryURL, packageName string) error {
+	project, err := getUpstreamProject(ctx, registry, packageName)
+	if err != nil {
+		return err
+	}
+
+	name := normalizer.Replace(project.Info.Name)
+	versions := make([]string, 0, len(project.Releases))
+	for v := range project.Releases {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+
+	links := make([]*simpleLink, 0, len(versions))
+	for _, v := range versions {
+		for _, f := range project.Releases[v] {
+			if f.Yanked {
+				continue
+			}
+			links = append(links, &simpleLink{
+				URL:            fmt.Sprintf("%s/files/%s/%s/%s", registryURL, url.PathEscape(strings.ToLower(name)), url.PathEscape(v), url.PathEscape(f.Filename)),
+				Filename:       f.Filename,
+				SHA256:         f.Digests.SHA256,
+				RequiresPython: f.RequiresPython,
+			})
+		}
+	}
+
+	ctx.Data["PackageName"] = name
+	ctx.Data["Links"] = links
+	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
+	return nil
+}
+
+// fetchUpstreamFile fetches a file of a release from the upstream registry and stores it as package
+func fetchUpstreamFile(ctx *context.Context, registry *upstream_service.Registry, packageName, packageVersion, filename string) error {
+	project, err := getUpstreamProject(ctx, registry, packageName)
+	if err != nil {
+		return err
+	}
+
+	var file *upstreamFile
+	for _, f := range project.Releases[packageVersion] {
+		if f.Filename == filename {
+			file = f
+			break
+		}
+	}
+	if file == nil || !isValidNameAndVersion(packageName, packageVersion) {
+		return upstream_service.ErrNotFound
+	}
+
+	// the information of the project is the one of the latest release, so the release is fetched to check its license
+	data, _, err := registry.Fetch(ctx, registry.URL("/pypi/"+url.PathEscape(packageName)+"/"+url.PathEscape(packageVersion)+"/json"), nil)
+	if err != nil {
+		return err
+	}
+	var release upstreamProject
+	if err := json.Unmarshal(data, &release); err != nil {
+		return err
+	}
+	info := &release.Info
+	if !registry.IsLicenseAllowed(info.license()) {
+		return upstream_service.ErrLicenseNotAllowed
+	}
+
+	buf, err := registry.Download(ctx, file.URL, nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if _, _, hashSHA256, _ := buf.Sums(); !strings.EqualFold(file.Digests.SHA256, hex.EncodeToString(hashSHA256)) {
+		return upstream_service.ErrDigestMismatch
+	}
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        normalizer.Replace(info.Name),
+				Version:     packageVersion,
+			},
+			SemverCompatible: false,
+			Creator:          upstream_service.Creator(ctx.Doer),
+			Metadata: &pypi_module.Metadata{
+				Author:          info.Author,
+				LongDescription: info.Description,
+				Summary:         info.Summary,
+				ProjectURL:      info.homepage(),
+				License:         info.license(),
+				RequiresPython:  info.RequiresPython,
+			},
+			VersionProperties: map[string]string{
+				upstream_service.PropertyUpstream: registry.Config.Upstream,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: upstream_service.Creator(ctx.Doer),
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+		// the file was fetched by a concurrent request
+		return nil
+	}
+	return err
+}
+
+// hasOnlyUpstreamVersions checks if all versions of a package were fetched from the upstream registry
+func hasOnlyUpstreamVersions(pds []*packages_model.PackageDescriptor) bool {
+	for _, pd := range pds {
+		if pd.VersionProperties.GetByName(upstream_service.PropertyUpstream) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func upstreamErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, upstream_service.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, upstream_service.ErrLicenseNotAllowed), errors.Is(err, upstream_service.ErrFileTooLarge),
+		errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadGateway
+	}
+}
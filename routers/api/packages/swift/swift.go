@@ -77,6 +77,7 @@ func apiError(ctx *context.Context, status int, obj any) {
 		Detail string `json:"detail"`
 	}
 
+	status = helper.ErrorStatus(status, obj)
 	message := helper.ProcessErrorForUser(ctx, status, obj)
 	setResponseHeaders(ctx.Resp, &headers{
 		Status:      status,
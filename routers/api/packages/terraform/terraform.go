@@ -0,0 +1,523 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	terraform_service "code.gitea.io/gitea/services/packages/terraform"
+
+	"github.com/hashicorp/go-version"
+)
+
+// The registry is mounted on "/api/packages/-/terraform" because the protocols use a single base url per host,
+// the namespace of the module and provider addresses is the owner.
+const registryPath = "api/packages/-/terraform"
+
+func apiError(ctx *context.Context, status int, obj any) {
+	status = helper.ErrorStatus(status, obj)
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, struct {
+		Errors []string `json:"errors"`
+	}{
+		Errors: []string{
+			message,
+		},
+	})
+}
+
+// ServiceDiscovery returns the base urls of the module and provider registries for "/.well-known/terraform.json"
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func ServiceDiscovery(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, map[string]string{
+		"modules.v1":   setting.AppSubURL + "/" + registryPath + "/modules/v1/",
+		"providers.v1": setting.AppSubURL + "/" + registryPath + "/providers/v1/",
+	})
+}
+
+func moduleBaseURL(ctx *context.Context) string {
+	return fmt.Sprintf("%s%s/modules/v1/%s/%s/%s", setting.AppURL, registryPath, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(ctx.PathParam("name")), url.PathEscape(ctx.PathParam("system")))
+}
+
+func providerBaseURL(ctx *context.Context) string {
+	return fmt.Sprintf("%s%s/providers/v1/%s/%s", setting.AppURL, registryPath, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(ctx.PathParam("type")))
+}
+
+func getSortedPackageDescriptors(ctx *context.Context, name string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+	return pds, nil
+}
+
+func getPackageDescriptor(ctx *context.Context, name string) (*packages_model.PackageDescriptor, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, name, ctx.PathParam("version"))
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetPackageDescriptor(ctx, pv)
+}
+
+func checkUploadedVersion(ctx *context.Context) (string, bool) {
+	v, err := version.NewSemver(ctx.PathParam("version"))
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return "", false
+	}
+	return v.String(), true
+}
+
+func uploadFile(ctx *context.Context, name, packageVersion string, metadata *terraform_module.Metadata, filename string, buf *packages_module.HashedBuffer, properties map[string]string) {
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        name,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:    ctx.Doer,
+			Data:       buf,
+			IsLead:     true,
+			Properties: properties,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func serveFile(ctx *context.Context, name, filename string) {
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        name,
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+func modulePackageName(ctx *context.Context) string {
+	return terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system"))
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+// EnumerateModuleVersions lists the versions of a module
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+func EnumerateModuleVersions(ctx *context.Context) {
+	pds, err := getSortedPackageDescriptors(ctx, modulePackageName(ctx))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pds))
+	for _, pd := range pds {
+		versions = append(versions, &moduleVersion{Version: pd.Version.Version})
+	}
+
+	type module struct {
+		Versions []*moduleVersion `json:"versions"`
+	}
+	ctx.JSON(http.StatusOK, struct {
+		Modules []*module `json:"modules"`
+	}{
+		Modules: []*module{{Versions: versions}},
+	})
+}
+
+// DownloadModule returns the url of the archive of a module version in the X-Terraform-Get header
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+func DownloadModule(ctx *context.Context) {
+	pd, err := getPackageDescriptor(ctx, modulePackageName(ctx))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if len(pd.Files) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", moduleBaseURL(ctx)+"/"+url.PathEscape(pd.Version.Version)+"/"+url.PathEscape(pd.Files[0].File.Name))
+	ctx.Status(http.StatusNoContent)
+}
+
+// DownloadModuleArchive serves the archive of a module version
+func DownloadModuleArchive(ctx *context.Context) {
+	serveFile(ctx, modulePackageName(ctx), ctx.PathParam("filename"))
+}
+
+// UploadModule publishes a module version, the body is the .tar.gz archive of the module
+func UploadModule(ctx *context.Context) {
+	name, system := ctx.PathParam("name"), ctx.PathParam("system")
+	if !terraform_module.IsValidModuleName(name, system) {
+		apiError(ctx, http.StatusBadRequest, "invalid module name or system")
+		return
+	}
+	packageVersion, ok := checkUploadedVersion(ctx)
+	if !ok {
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	uploadFile(ctx, terraform_module.ModulePackageName(name, system), packageVersion, metadata, strings.ToLower(terraform_module.ModuleArchiveFilename(name, system, packageVersion)), buf, nil)
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+// EnumerateProviderVersions lists the versions of a provider with their platforms
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func EnumerateProviderVersions(ctx *context.Context) {
+	pds, err := getSortedPackageDescriptors(ctx, ctx.PathParam("type"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pf := range pd.Files {
+			platforms = append(platforms, &providerPlatform{
+				OS:   pf.Properties.GetByName(terraform_module.PropertyOS),
+				Arch: pf.Properties.GetByName(terraform_module.PropertyArch),
+			})
+		}
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: pd.Metadata.(*terraform_module.Metadata).Protocols,
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, struct {
+		Versions []*providerVersion `json:"versions"`
+	}{
+		Versions: versions,
+	})
+}
+
+type gpgPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+type providerPackage struct {
+	Protocols           []string `json:"protocols"`
+	OS                  string   `json:"os"`
+	Arch                string   `json:"arch"`
+	Filename            string   `json:"filename"`
+	DownloadURL         string   `json:"download_url"`
+	ShasumsURL          string   `json:"shasums_url"`
+	ShasumsSignatureURL string   `json:"shasums_signature_url"`
+	Shasum              string   `json:"shasum"`
+	SigningKeys         struct {
+		GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+	} `json:"signing_keys"`
+}
+
+// ProviderPackageMetadata returns the download url, the checksum and the signing key of a provider build
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func ProviderPackageMetadata(ctx *context.Context) {
+	providerType, os, arch := ctx.PathParam("type"), ctx.PathParam("os"), ctx.PathParam("arch")
+
+	pd, err := getPackageDescriptor(ctx, providerType)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	var pfd *packages_model.PackageFileDescriptor
+	for _, f := range pd.Files {
+		if f.Properties.GetByName(terraform_module.PropertyOS) == os && f.Properties.GetByName(terraform_module.PropertyArch) == arch {
+			pfd = f
+			break
+		}
+	}
+	if pfd == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	_, pub, err := terraform_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	keyID, err := terraform_service.GetKeyID(pub)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := providerBaseURL(ctx) + "/" + url.PathEscape(pd.Version.Version)
+	shasumsFilename := terraform_module.ShasumsFilename(providerType, pd.Version.Version)
+
+	p := &providerPackage{
+		Protocols:           pd.Metadata.(*terraform_module.Metadata).Protocols,
+		OS:                  os,
+		Arch:                arch,
+		Filename:            pfd.File.Name,
+		DownloadURL:         versionURL + "/" + url.PathEscape(pfd.File.Name),
+		ShasumsURL:          versionURL + "/" + url.PathEscape(shasumsFilename),
+		ShasumsSignatureURL: versionURL + "/" + url.PathEscape(shasumsFilename+".sig"),
+		Shasum:              pfd.Blob.HashSHA256,
+	}
+	p.SigningKeys.GPGPublicKeys = []*gpgPublicKey{{KeyID: keyID, ASCIIArmor: pub}}
+
+	ctx.JSON(http.StatusOK, p)
+}
+
+// buildShasums creates the checksums file of the builds of a provider version in the sha256sum format
+func buildShasums(pd *packages_model.PackageDescriptor) []byte {
+	files := make([]*packages_model.PackageFileDescriptor, len(pd.Files))
+	copy(files, pd.Files)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].File.Name < files[j].File.Name
+	})
+
+	var buf bytes.Buffer
+	for _, f := range files {
+		fmt.Fprintf(&buf, "%s  %s\n", f.Blob.HashSHA256, f.File.Name)
+	}
+	return buf.Bytes()
+}
+
+// DownloadProviderFile serves a build of a provider version, or the checksums file of the version and its signature
+func DownloadProviderFile(ctx *context.Context) {
+	providerType, filename := ctx.PathParam("type"), ctx.PathParam("filename")
+	if !strings.HasSuffix(filename, "_SHA256SUMS") && !strings.HasSuffix(filename, "_SHA256SUMS.sig") {
+		serveFile(ctx, providerType, filename)
+		return
+	}
+
+	pd, err := getPackageDescriptor(ctx, providerType)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	shasumsFilename := terraform_module.ShasumsFilename(providerType, pd.Version.Version)
+	shasums := buildShasums(pd)
+
+	switch filename {
+	case shasumsFilename:
+		ctx.ServeContent(bytes.NewReader(shasums), &context.ServeHeaderOptions{
+			ContentType: "text/plain",
+			Filename:    shasumsFilename,
+		})
+	case shasumsFilename + ".sig":
+		priv, _, err := terraform_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		signature, err := terraform_service.SignShasums(priv, shasums)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		ctx.ServeContent(bytes.NewReader(signature), &context.ServeHeaderOptions{
+			ContentType: "application/pgp-signature",
+			Filename:    shasumsFilename + ".sig",
+		})
+	default:
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+	}
+}
+
+// UploadProvider publishes a build of a provider version.
+// The file name must be the name of the release archive, like terraform-provider-example_1.0.0_linux_amd64.zip,
+// the supported plugin protocols can be set by the "protocols" parameter when the version is created.
+func UploadProvider(ctx *context.Context) {
+	providerType := ctx.PathParam("type")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusBadRequest, "invalid provider type")
+		return
+	}
+	packageVersion, ok := checkUploadedVersion(ctx)
+	if !ok {
+		return
+	}
+
+	prefix := fmt.Sprintf("terraform-provider-%s_%s_", providerType, packageVersion)
+	platform, ok := strings.CutPrefix(ctx.PathParam("filename"), prefix)
+	if ok {
+		platform, ok = strings.CutSuffix(platform, ".zip")
+	}
+	os, arch, _ := strings.Cut(platform, "_")
+	if !ok || !terraform_module.IsValidPlatform(os, arch) {
+		apiError(ctx, http.StatusBadRequest, fmt.Sprintf("file name must be %s", terraform_module.ProviderArchiveFilename(providerType, packageVersion, "<os>", "<arch>")))
+		return
+	}
+
+	protocols, err := terraform_module.ParseProtocols(ctx.FormString("protocols"))
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if err := terraform_module.CheckProviderArchive(buf, buf.Size(), providerType); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	uploadFile(
+		ctx,
+		providerType,
+		packageVersion,
+		&terraform_module.Metadata{
+			Kind:      terraform_module.KindProvider,
+			Protocols: protocols,
+		},
+		terraform_module.ProviderArchiveFilename(providerType, packageVersion, os, arch),
+		buf,
+		map[string]string{
+			terraform_module.PropertyOS:   os,
+			terraform_module.PropertyArch: arch,
+		},
+	)
+}
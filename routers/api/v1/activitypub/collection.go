@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activitypub
+
+import (
+	"fmt"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// followersCollection writes the followers collection of an actor, or the requested page of it
+func followersCollection(ctx *context.APIContext, actorIRI string, opts activities_model.FindFederatedFollowersOptions) {
+	link := actorIRI + "/followers"
+	page := ctx.FormInt("page")
+	if page > 0 {
+		opts.ListOptions = db.ListOptions{Page: page, PageSize: setting.API.MaxResponseItems}
+	} else {
+		opts.ListOptions = db.ListOptions{PageSize: 1}
+	}
+	followers, total, err := db.FindAndCount[activities_model.FederatedFollower](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	collection := ap.OrderedCollectionNew(ap.IRI(link))
+	collection.TotalItems = uint(total)
+	collection.First = ap.IRI(link + "?page=1")
+	if page <= 0 {
+		response(ctx, collection)
+		return
+	}
+
+	collectionPage := ap.OrderedCollectionPageNew(collection)
+	collectionPage.ID = ap.IRI(fmt.Sprintf("%s?page=%d", link, page))
+	collectionPage.OrderedItems = make(ap.ItemCollection, 0, len(followers))
+	for _, f := range followers {
+		collectionPage.OrderedItems = append(collectionPage.OrderedItems, ap.IRI(f.ActorIRI))
+	}
+	if int64(page*setting.API.MaxResponseItems) < total {
+		collectionPage.Next = ap.IRI(fmt.Sprintf("%s?page=%d", link, page+1))
+	}
+	response(ctx, collectionPage)
+}
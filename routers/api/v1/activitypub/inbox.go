@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activitypub
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	federation_service "code.gitea.io/gitea/services/federation"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// handleInbox handles an activity posted to the inbox of a local actor by the remote actor who signed the request
+func handleInbox(ctx *context.APIContext, target *federation_service.InboxTarget) {
+	signer, ok := ctx.Data[signerDataKey].(*ap.Person)
+	if !ok {
+		ctx.APIError(http.StatusForbidden, "request signature verification failed")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Req.Body, setting.Federation.MaxSize))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := verifyDigest(ctx.Req, body); err != nil {
+		ctx.APIError(http.StatusForbidden, err)
+		return
+	}
+	item, err := ap.UnmarshalJSON(body)
+	if err != nil {
+		ctx.APIError(http.StatusBadRequest, err)
+		return
+	}
+	activity, err := ap.ToActivity(item)
+	if err != nil {
+		ctx.APIError(http.StatusBadRequest, err)
+		return
+	}
+
+	if err := federation_service.HandleInboxActivity(ctx, signer, target, activity); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
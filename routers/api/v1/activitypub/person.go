@@ -4,17 +4,15 @@
 package activitypub
 
 import (
-	"fmt"
 	"net/http"
-	"strings"
 
+	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/modules/activitypub"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
+	federation_service "code.gitea.io/gitea/services/federation"
 
 	ap "github.com/go-ap/activitypub"
-	"github.com/go-ap/jsonld"
 )
 
 // Person function returns the Person actor for a user
@@ -34,8 +32,7 @@ func Person(ctx *context.APIContext) {
 	//   "200":
 	//     "$ref": "#/responses/ActivityPub"
 
-	// TODO: the setting.AppURL during the test doesn't follow the definition: "It always has a '/' suffix"
-	link := fmt.Sprintf("%s/api/v1/activitypub/user-id/%d", strings.TrimSuffix(setting.AppURL, "/"), ctx.ContextUser.ID)
+	link := federation_service.UserActorIRI(ctx.ContextUser)
 	person := ap.PersonNew(ap.IRI(link))
 
 	person.Name = ap.NaturalLanguageValuesNew()
@@ -62,8 +59,9 @@ func Person(ctx *context.APIContext) {
 
 	person.Inbox = ap.IRI(link + "/inbox")
 	person.Outbox = ap.IRI(link + "/outbox")
+	person.Followers = ap.IRI(link + "/followers")
 
-	person.PublicKey.ID = ap.IRI(link + "#main-key")
+	person.PublicKey.ID = ap.IRI(federation_service.KeyID(link))
 	person.PublicKey.Owner = ap.IRI(link)
 
 	publicKeyPem, err := activitypub.GetPublicKey(ctx, ctx.ContextUser)
@@ -73,7 +71,12 @@ func Person(ctx *context.APIContext) {
 	}
 	person.PublicKey.PublicKeyPem = publicKeyPem
 
-	binary, err := jsonld.WithContext(jsonld.IRI(ap.ActivityBaseURI), jsonld.IRI(ap.SecurityContextURI)).Marshal(person)
+	response(ctx, person)
+}
+
+// response writes an ActivityStreams object
+func response(ctx *context.APIContext, item any) {
+	binary, err := federation_service.Marshal(item)
 	if err != nil {
 		ctx.APIErrorInternal(err)
 		return
@@ -101,6 +104,34 @@ func PersonInbox(ctx *context.APIContext) {
 	// responses:
 	//   "204":
 	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	handleInbox(ctx, &federation_service.InboxTarget{User: ctx.ContextUser})
+}
+
+// PersonFollowers function returns the remote actors following a user
+func PersonFollowers(ctx *context.APIContext) {
+	// swagger:operation GET /activitypub/user-id/{user-id}/followers activitypub activitypubPersonFollowers
+	// ---
+	// summary: Returns the collection of the remote actors following a user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: user-id
+	//   in: path
+	//   description: user ID of the user
+	//   type: integer
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of the collection page to return, the collection is returned without items if not set
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActivityPub"
 
-	ctx.Status(http.StatusNoContent)
+	followersCollection(ctx, federation_service.UserActorIRI(ctx.ContextUser), activities_model.FindFederatedFollowersOptions{UserID: ctx.ContextUser.ID})
 }
@@ -0,0 +1,145 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package activitypub
+
+import (
+	activities_model "code.gitea.io/gitea/models/activities"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/activitypub"
+	"code.gitea.io/gitea/services/context"
+	federation_service "code.gitea.io/gitea/services/federation"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// RepositoryIDAssignment assigns the public repository of the "repository-id" path parameter to the context
+func RepositoryIDAssignment() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		repo, err := repo_model.GetRepositoryByID(ctx, ctx.PathParamInt64("repository-id"))
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.APIErrorNotFound()
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		// only the public repositories are actors
+		if !federation_service.IsRepoFederated(repo) {
+			ctx.APIErrorNotFound()
+			return
+		}
+		ctx.Repo = &context.Repository{Repository: repo, Owner: repo.Owner}
+	}
+}
+
+// Repository function returns the ForgeFed Repository actor for a repository
+func Repository(ctx *context.APIContext) {
+	// swagger:operation GET /activitypub/repository-id/{repository-id} activitypub activitypubRepository
+	// ---
+	// summary: Returns the Repository actor for a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: repository-id
+	//   in: path
+	//   description: repository ID of the repository
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActivityPub"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	repo := ctx.Repo.Repository
+	link := federation_service.RepoActorIRI(repo)
+	actor := ap.ActorNew(ap.IRI(link), ap.ActorType)
+	actor.Type = federation_service.RepositoryType
+
+	actor.Name = ap.DefaultNaturalLanguageValue(repo.Name)
+	actor.PreferredUsername = ap.DefaultNaturalLanguageValue(repo.FullName())
+	actor.Summary = ap.DefaultNaturalLanguageValue(repo.Description)
+	actor.URL = ap.IRI(repo.HTMLURL(ctx))
+	actor.AttributedTo = ap.IRI(federation_service.UserActorIRI(repo.Owner))
+	if avatar := repo.AvatarLink(ctx); avatar != "" {
+		actor.Icon = ap.Image{
+			Type:      ap.ImageType,
+			MediaType: "image/png",
+			URL:       ap.IRI(avatar),
+		}
+	}
+
+	actor.Inbox = ap.IRI(link + "/inbox")
+	actor.Followers = ap.IRI(link + "/followers")
+
+	// a repository signs its activities with the key of its owner
+	actor.PublicKey.ID = ap.IRI(federation_service.KeyID(link))
+	actor.PublicKey.Owner = ap.IRI(link)
+	publicKeyPem, err := activitypub.GetPublicKey(ctx, repo.Owner)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	actor.PublicKey.PublicKeyPem = publicKeyPem
+
+	response(ctx, actor)
+}
+
+// RepositoryInbox function handles the incoming data for a repository inbox
+func RepositoryInbox(ctx *context.APIContext) {
+	// swagger:operation POST /activitypub/repository-id/{repository-id}/inbox activitypub activitypubRepositoryInbox
+	// ---
+	// summary: Send to the inbox
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: repository-id
+	//   in: path
+	//   description: repository ID of the repository
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	handleInbox(ctx, &federation_service.InboxTarget{Repo: ctx.Repo.Repository})
+}
+
+// RepositoryFollowers function returns the remote actors following a repository
+func RepositoryFollowers(ctx *context.APIContext) {
+	// swagger:operation GET /activitypub/repository-id/{repository-id}/followers activitypub activitypubRepositoryFollowers
+	// ---
+	// summary: Returns the collection of the remote actors following a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: repository-id
+	//   in: path
+	//   description: repository ID of the repository
+	//   type: integer
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of the collection page to return, the collection is returned without items if not set
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActivityPub"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	repo := ctx.Repo.Repository
+	followersCollection(ctx, federation_service.RepoActorIRI(repo), activities_model.FindFederatedFollowersOptions{RepoID: repo.ID})
+}
@@ -5,13 +5,17 @@ package activitypub
 
 import (
 	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"code.gitea.io/gitea/modules/activitypub"
 	"code.gitea.io/gitea/modules/httplib"
@@ -22,27 +26,38 @@ import (
 	ap "github.com/go-ap/activitypub"
 )
 
-func getPublicKeyFromResponse(b []byte, keyID *url.URL) (p crypto.PublicKey, err error) {
-	person := ap.PersonNew(ap.IRI(keyID.String()))
+// signerDataKey is the key of the verified actor who signed the request in the context data
+const signerDataKey = "ActivityPubSigner"
+
+func getPublicKeyFromResponse(b []byte, keyID *url.URL) (person *ap.Person, p crypto.PublicKey, err error) {
+	person = ap.PersonNew(ap.IRI(keyID.String()))
 	err = person.UnmarshalJSON(b)
 	if err != nil {
-		return nil, fmt.Errorf("ActivityStreams type cannot be converted to one known to have publicKey property: %w", err)
+		return nil, nil, fmt.Errorf("ActivityStreams type cannot be converted to one known to have publicKey property: %w", err)
 	}
 	pubKey := person.PublicKey
 	if pubKey.ID.String() != keyID.String() {
-		return nil, fmt.Errorf("cannot find publicKey with id: %s in %s", keyID, string(b))
+		return nil, nil, fmt.Errorf("cannot find publicKey with id: %s in %s", keyID, string(b))
+	}
+	// the key document can claim any actor, it's only trusted for the actors of its own origin which own the key
+	actorID, err := url.Parse(person.ID.String())
+	if err != nil || actorID.Scheme != keyID.Scheme || actorID.Host != keyID.Host {
+		return nil, nil, fmt.Errorf("the actor %s is not on the origin of the key %s", person.ID, keyID)
+	}
+	if pubKey.Owner.String() != person.ID.String() {
+		return nil, nil, fmt.Errorf("the key %s is owned by %s instead of the actor %s", keyID, pubKey.Owner, person.ID)
 	}
 	pubKeyPem := pubKey.PublicKeyPem
 	block, _ := pem.Decode([]byte(pubKeyPem))
 	if block == nil || block.Type != "PUBLIC KEY" {
-		return nil, errors.New("could not decode publicKeyPem to PUBLIC KEY pem block type")
+		return nil, nil, errors.New("could not decode publicKeyPem to PUBLIC KEY pem block type")
 	}
 	p, err = x509.ParsePKIXPublicKey(block.Bytes)
-	return p, err
+	return person, p, err
 }
 
 func fetch(iri *url.URL) (b []byte, err error) {
-	req := httplib.NewRequest(iri.String(), http.MethodGet)
+	req := httplib.NewRequest(iri.String(), http.MethodGet).SetTransport(activitypub.NewTransport())
 	req.Header("Accept", activitypub.ActivityStreamsContentType)
 	req.Header("User-Agent", "Gitea/"+setting.AppVer)
 	resp, err := req.Response()
@@ -58,41 +73,66 @@ func fetch(iri *url.URL) (b []byte, err error) {
 	return b, err
 }
 
-func verifyHTTPSignatures(ctx *gitea_context.APIContext) (authenticated bool, err error) {
+func verifyHTTPSignatures(ctx *gitea_context.APIContext) (signer *ap.Person, authenticated bool, err error) {
 	r := ctx.Req
 
 	// 1. Figure out what key we need to verify
 	v, err := httpsig.NewVerifier(r)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 	ID := v.KeyId()
 	idIRI, err := url.Parse(ID)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 	// 2. Fetch the public key of the other actor
 	b, err := fetch(idIRI)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
-	pubKey, err := getPublicKeyFromResponse(b, idIRI)
+	signer, pubKey, err := getPublicKeyFromResponse(b, idIRI)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 	// 3. Verify the other actor's key
 	algo := httpsig.Algorithm(setting.Federation.Algorithms[0])
 	authenticated = v.Verify(pubKey, algo) == nil
-	return authenticated, err
+	return signer, authenticated, err
+}
+
+// verifyDigest checks the Digest header of a request matches its body
+func verifyDigest(r *http.Request, body []byte) error {
+	algo, digest, ok := strings.Cut(r.Header.Get("Digest"), "=")
+	if !ok {
+		return errors.New("request has no Digest header")
+	}
+	var sum []byte
+	switch strings.ToUpper(algo) {
+	case "SHA-256":
+		s := sha256.Sum256(body)
+		sum = s[:]
+	case "SHA-512":
+		s := sha512.Sum512(body)
+		sum = s[:]
+	default:
+		return fmt.Errorf("unsupported digest algorithm %s", algo)
+	}
+	if base64.StdEncoding.EncodeToString(sum) != digest {
+		return errors.New("the Digest header does not match the request body")
+	}
+	return nil
 }
 
 // ReqHTTPSignature function
 func ReqHTTPSignature() func(ctx *gitea_context.APIContext) {
 	return func(ctx *gitea_context.APIContext) {
-		if authenticated, err := verifyHTTPSignatures(ctx); err != nil {
+		if signer, authenticated, err := verifyHTTPSignatures(ctx); err != nil {
 			ctx.APIErrorInternal(err)
 		} else if !authenticated {
 			ctx.APIError(http.StatusForbidden, "request signature verification failed")
+		} else {
+			ctx.Data[signerDataKey] = signer
 		}
 	}
 }
@@ -0,0 +1,301 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/models/organization"
+	system_model "code.gitea.io/gitea/models/system"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func toAnnouncementTime(t *time.Time) timeutil.TimeStamp {
+	if t == nil || t.Unix() <= 0 {
+		return 0
+	}
+	return timeutil.TimeStamp(t.Unix())
+}
+
+// setAnnouncementOrg resolves the organization of the announcement, it responds with an error if the organization
+// doesn't exist
+func setAnnouncementOrg(ctx *context.APIContext, a *system_model.Announcement, orgName string) bool {
+	if orgName == "" {
+		a.OrgID = 0
+		return true
+	}
+	org, err := organization.GetOrgByName(ctx, orgName)
+	if err != nil {
+		if organization.IsErrOrgNotExist(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return false
+	}
+	a.OrgID = org.ID
+	return true
+}
+
+// validateAnnouncement checks the announcement, it responds with an error if it is invalid
+func validateAnnouncement(ctx *context.APIContext, a *system_model.Announcement) bool {
+	var msg string
+	switch {
+	case !a.Type.IsValid():
+		msg = "invalid type"
+	case !a.Audience.IsValid():
+		msg = "invalid audience"
+	case a.Audience == system_model.AnnouncementAudienceOrgMembers && a.OrgID == 0:
+		msg = "org is required for the org_members audience"
+	case a.StartsUnix > 0 && a.EndsUnix > 0 && a.EndsUnix <= a.StartsUnix:
+		msg = "end_time must be after start_time"
+	default:
+		return true
+	}
+	ctx.APIError(http.StatusUnprocessableEntity, msg)
+	return false
+}
+
+func respondAnnouncement(ctx *context.APIContext, status int, a *system_model.Announcement) {
+	apiAnnouncement, err := convert.ToAnnouncement(ctx, a)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, apiAnnouncement)
+}
+
+func getAnnouncement(ctx *context.APIContext) *system_model.Announcement {
+	a, err := system_model.GetAnnouncementByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return a
+}
+
+// ListAnnouncements lists all announcements
+func ListAnnouncements(ctx *context.APIContext) {
+	// swagger:operation GET /admin/announcements admin adminListAnnouncements
+	// ---
+	// summary: List the instance announcements
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AnnouncementList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	listOptions := utils.GetListOptions(ctx)
+	announcements, count, err := system_model.FindAnnouncements(ctx, listOptions)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiAnnouncements, err := convert.ToAnnouncementList(ctx, announcements)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiAnnouncements)
+}
+
+// GetAnnouncement gets an announcement
+func GetAnnouncement(ctx *context.APIContext) {
+	// swagger:operation GET /admin/announcements/{id} admin adminGetAnnouncement
+	// ---
+	// summary: Get an instance announcement
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the announcement
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Announcement"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	a := getAnnouncement(ctx)
+	if ctx.Written() {
+		return
+	}
+	respondAnnouncement(ctx, http.StatusOK, a)
+}
+
+// CreateAnnouncement creates an announcement
+func CreateAnnouncement(ctx *context.APIContext) {
+	// swagger:operation POST /admin/announcements admin adminCreateAnnouncement
+	// ---
+	// summary: Create an instance announcement
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateAnnouncementOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Announcement"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.CreateAnnouncementOption)
+
+	a := &system_model.Announcement{
+		Type:        system_model.AnnouncementType(form.Type),
+		Content:     form.Content,
+		Audience:    system_model.AnnouncementAudience(form.Audience),
+		Dismissible: form.Dismissible == nil || *form.Dismissible,
+		StartsUnix:  toAnnouncementTime(form.StartTime),
+		EndsUnix:    toAnnouncementTime(form.EndTime),
+	}
+	if a.Audience == system_model.AnnouncementAudienceOrgMembers && !setAnnouncementOrg(ctx, a, form.Org) {
+		return
+	}
+	if !validateAnnouncement(ctx, a) {
+		return
+	}
+
+	if err := system_model.CreateAnnouncement(ctx, a); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	respondAnnouncement(ctx, http.StatusCreated, a)
+}
+
+// EditAnnouncement edits an announcement
+func EditAnnouncement(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/announcements/{id} admin adminEditAnnouncement
+	// ---
+	// summary: Edit an instance announcement
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the announcement
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EditAnnouncementOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Announcement"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.EditAnnouncementOption)
+	a := getAnnouncement(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Type != nil {
+		a.Type = system_model.AnnouncementType(*form.Type)
+	}
+	if form.Content != nil {
+		a.Content = *form.Content
+	}
+	if form.Audience != nil {
+		a.Audience = system_model.AnnouncementAudience(*form.Audience)
+	}
+	if form.Org != nil && !setAnnouncementOrg(ctx, a, *form.Org) {
+		return
+	}
+	if a.Audience != system_model.AnnouncementAudienceOrgMembers {
+		a.OrgID = 0
+	}
+	if form.Dismissible != nil {
+		a.Dismissible = *form.Dismissible
+	}
+	if form.StartTime != nil {
+		a.StartsUnix = toAnnouncementTime(form.StartTime)
+	}
+	if form.EndTime != nil {
+		a.EndsUnix = toAnnouncementTime(form.EndTime)
+	}
+	if !validateAnnouncement(ctx, a) {
+		return
+	}
+
+	if err := system_model.UpdateAnnouncement(ctx, a); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	respondAnnouncement(ctx, http.StatusOK, a)
+}
+
+// DeleteAnnouncement deletes an announcement
+func DeleteAnnouncement(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/announcements/{id} admin adminDeleteAnnouncement
+	// ---
+	// summary: Delete an instance announcement
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the announcement
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	a := getAnnouncement(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := system_model.DeleteAnnouncementByID(ctx, a.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	admin_service "code.gitea.io/gitea/services/admin"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func respondApprovalRequest(ctx *context.APIContext, status int, r *admin_model.ApprovalRequest) {
+	apiRequest, err := convert.ToAdminApprovalRequest(ctx, r, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, apiRequest)
+}
+
+func getApprovalRequest(ctx *context.APIContext) *admin_model.ApprovalRequest {
+	r, err := admin_model.GetApprovalRequestByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return r
+}
+
+// ListApprovalRequests lists the approval requests of the destructive admin operations
+func ListApprovalRequests(ctx *context.APIContext) {
+	// swagger:operation GET /admin/approvals admin adminListApprovalRequests
+	// ---
+	// summary: List the approval requests of destructive admin operations
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: status
+	//   in: query
+	//   description: filter by the status of the requests
+	//   type: string
+	//   enum: [pending, approved, rejected, expired, failed]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AdminApprovalRequestList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	if err := admin_model.ExpireApprovalRequests(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	requests, count, err := db.FindAndCount[admin_model.ApprovalRequest](ctx, admin_model.FindApprovalRequestsOptions{
+		ListOptions: listOptions,
+		Status:      admin_model.ApprovalStatus(ctx.FormString("status")),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRequests := make([]*api.AdminApprovalRequest, 0, len(requests))
+	for _, r := range requests {
+		apiRequest, err := convert.ToAdminApprovalRequest(ctx, r, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiRequests = append(apiRequests, apiRequest)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiRequests)
+}
+
+// GetApprovalRequest gets an approval request
+func GetApprovalRequest(ctx *context.APIContext) {
+	// swagger:operation GET /admin/approvals/{id} admin adminGetApprovalRequest
+	// ---
+	// summary: Get an approval request of a destructive admin operation
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the approval request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AdminApprovalRequest"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	r := getApprovalRequest(ctx)
+	if ctx.Written() {
+		return
+	}
+	respondApprovalRequest(ctx, http.StatusOK, r)
+}
+
+func decideApprovalRequest(ctx *context.APIContext, decide func(*admin_model.ApprovalRequest) error) {
+	r := getApprovalRequest(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := decide(r); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	respondApprovalRequest(ctx, http.StatusOK, r)
+}
+
+// ApproveRequest approves an approval request and executes the operation
+func ApproveRequest(ctx *context.APIContext) {
+	// swagger:operation POST /admin/approvals/{id}/approve admin adminApproveRequest
+	// ---
+	// summary: Approve a destructive admin operation requested by another administrator
+	// description: The operation is executed immediately, if it fails the status of the request is failed and the error is returned as message.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the approval request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AdminApprovalRequest"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	decideApprovalRequest(ctx, func(r *admin_model.ApprovalRequest) error {
+		return admin_service.ApproveRequest(ctx, ctx.Doer, r)
+	})
+}
+
+// RejectRequest rejects an approval request
+func RejectRequest(ctx *context.APIContext) {
+	// swagger:operation POST /admin/approvals/{id}/reject admin adminRejectRequest
+	// ---
+	// summary: Reject a destructive admin operation requested by another administrator
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the approval request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AdminApprovalRequest"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	decideApprovalRequest(ctx, func(r *admin_model.ApprovalRequest) error {
+		return admin_service.RejectRequest(ctx, ctx.Doer, r)
+	})
+}
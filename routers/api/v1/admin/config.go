@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/reload"
+)
+
+// ReloadConfig reloads the settings which can be changed without restarting
+func ReloadConfig(ctx *context.APIContext) {
+	// swagger:operation POST /admin/config/reload admin adminReloadConfig
+	// ---
+	// summary: Reload the log levels, the mailer, the webhook allowed hosts, the OAuth2 clients and the API rate limits from the config file
+	// produces:
+	// - application/json
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	if err := reload.Settings(ctx); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	indexer_service "code.gitea.io/gitea/services/indexer"
+)
+
+// ListIndexers lists the statuses of the indexers
+func ListIndexers(ctx *context.APIContext) {
+	// swagger:operation GET /admin/indexers admin adminListIndexers
+	// ---
+	// summary: List the indexers with the lengths of their queues and the numbers of the repositories whose last indexing failed
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IndexerList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	statuses, err := indexer_service.GetIndexerStatuses(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	indexers := make([]*api.Indexer, 0, len(statuses))
+	for _, status := range statuses {
+		indexers = append(indexers, convert.ToIndexer(status))
+	}
+	ctx.JSON(http.StatusOK, indexers)
+}
+
+// ListIndexerFailures lists the repositories whose last indexing failed
+func ListIndexerFailures(ctx *context.APIContext) {
+	// swagger:operation GET /admin/indexers/failures admin adminListIndexerFailures
+	// ---
+	// summary: List the repositories whose last indexing failed, the most recent failures first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: indexer
+	//   in: query
+	//   description: name of the indexer, the failures of all the indexers are listed if it is empty
+	//   type: string
+	//   enum: [code, wiki, stats, dependency, symbol]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoIndexerStatusList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	listOptions := utils.GetListOptions(ctx)
+	failures, count, err := indexer_service.FindIndexerFailures(ctx, ctx.FormTrim("indexer"), listOptions)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiFailures := make([]*api.RepoIndexerStatus, 0, len(failures))
+	for _, failure := range failures {
+		apiFailures = append(apiFailures, convert.ToRepoIndexerStatus(ctx, failure))
+	}
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiFailures)
+}
+
+func getIndexedRepo(ctx *context.APIContext) *repo_model.Repository {
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ctx.PathParam("username"), ctx.PathParam("reponame"))
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return repo
+}
+
+// GetRepoIndexerStatus gets the statuses of a repository in the indexers
+func GetRepoIndexerStatus(ctx *context.APIContext) {
+	// swagger:operation GET /admin/indexers/repos/{owner}/{repo} admin adminGetRepoIndexerStatus
+	// ---
+	// summary: Get the last indexed commits of a repository and the commits which should be indexed by the enabled indexers
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoIndexerStatusList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	repo := getIndexedRepo(ctx)
+	if ctx.Written() {
+		return
+	}
+	statuses, err := indexer_service.GetRepoIndexerStatuses(ctx, repo)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiStatuses := make([]*api.RepoIndexerStatus, 0, len(statuses))
+	for _, status := range statuses {
+		apiStatuses = append(apiStatuses, convert.ToRepoIndexerStatus(ctx, status))
+	}
+	ctx.JSON(http.StatusOK, apiStatuses)
+}
+
+// ReindexRepository makes the indexers index a repository from scratch
+func ReindexRepository(ctx *context.APIContext) {
+	// swagger:operation POST /admin/indexers/repos/{owner}/{repo}/reindex admin adminReindexRepository
+	// ---
+	// summary: Remove a repository from the indexes and index it again in the background
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: indexer
+	//   in: query
+	//   description: name of the indexer, all the enabled indexers index the repository again if it is empty
+	//   type: string
+	//   enum: [code, wiki, issues, stats, dependency, symbol]
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	repo := getIndexedRepo(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := indexer_service.ReindexRepository(ctx, repo, ctx.FormTrim("indexer")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListLFSLocks lists the LFS locks of all repositories
+func ListLFSLocks(ctx *context.APIContext) {
+	// swagger:operation GET /admin/lfs/locks admin adminListLFSLocks
+	// ---
+	// summary: List the LFS locks of all repositories, the oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: user
+	//   in: query
+	//   description: only list the locks held by this user
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoLFSLockList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.ListLFSLocks(ctx, 0)
+}
+
+// DeleteLFSLocks force-unlocks LFS locks of all repositories in bulk
+func DeleteLFSLocks(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/lfs/locks admin adminDeleteLFSLocks
+	// ---
+	// summary: Force-unlock LFS locks of all repositories in bulk
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/DeleteLFSLocksOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoLFSLockList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.DeleteLFSLocks(ctx, 0)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/setting/config"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+)
+
+func toMaintenanceTime(unix int64) *time.Time {
+	if unix <= 0 {
+		return nil
+	}
+	t := time.Unix(unix, 0)
+	return &t
+}
+
+func toMaintenanceMode(ctx *context.APIContext) *api.MaintenanceMode {
+	maintenance := setting.Config().Maintenance
+	return &api.MaintenanceMode{
+		ReadOnly:  maintenance.ReadOnly.Value(ctx),
+		Message:   maintenance.Message.Value(ctx),
+		StartTime: toMaintenanceTime(maintenance.StartTime.Value(ctx)),
+		EndTime:   toMaintenanceTime(maintenance.EndTime.Value(ctx)),
+		Active:    maintenance.IsReadOnly(ctx),
+	}
+}
+
+// GetMaintenanceMode returns the read-only maintenance mode
+func GetMaintenanceMode(ctx *context.APIContext) {
+	// swagger:operation GET /admin/maintenance admin adminGetMaintenanceMode
+	// ---
+	// summary: Get the read-only maintenance mode
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MaintenanceMode"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	ctx.JSON(http.StatusOK, toMaintenanceMode(ctx))
+}
+
+// EditMaintenanceMode changes the read-only maintenance mode
+func EditMaintenanceMode(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/maintenance admin adminEditMaintenanceMode
+	// ---
+	// summary: Change the read-only maintenance mode, the changes are rejected while it is active except this one
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EditMaintenanceModeOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MaintenanceMode"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.EditMaintenanceModeOption)
+	maintenance := setting.Config().Maintenance
+
+	settings := map[string]string{}
+	if form.ReadOnly != nil {
+		settings[maintenance.ReadOnly.DynKey()] = strconv.FormatBool(*form.ReadOnly)
+	}
+	if form.Message != nil {
+		msg, _ := json.Marshal(*form.Message)
+		settings[maintenance.Message.DynKey()] = string(msg)
+	}
+	startTime, endTime := maintenance.StartTime.Value(ctx), maintenance.EndTime.Value(ctx)
+	if form.StartTime != nil {
+		startTime = max(form.StartTime.Unix(), 0)
+		settings[maintenance.StartTime.DynKey()] = strconv.FormatInt(startTime, 10)
+	}
+	if form.EndTime != nil {
+		endTime = max(form.EndTime.Unix(), 0)
+		settings[maintenance.EndTime.DynKey()] = strconv.FormatInt(endTime, 10)
+	}
+	if startTime > 0 && endTime > 0 && endTime <= startTime {
+		ctx.APIError(http.StatusUnprocessableEntity, "end_time must be after start_time")
+		return
+	}
+
+	if err := system_model.SetSettings(ctx, settings); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	config.GetDynGetter().InvalidateCache()
+	ctx.JSON(http.StatusOK, toMaintenanceMode(ctx))
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	quota_model "code.gitea.io/gitea/models/quota"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetUserQuota gets the storage quota of a user or an organization
+func GetUserQuota(ctx *context.APIContext) {
+	// swagger:operation GET /admin/users/{username}/quota admin adminGetUserQuota
+	// ---
+	// summary: Get the storage quota and usage of a user or an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaInfo"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetQuota(ctx, ctx.ContextUser.ID)
+}
+
+// SetUserQuota overrides the storage quota of a user or an organization
+func SetUserQuota(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/users/{username}/quota admin adminSetUserQuota
+	// ---
+	// summary: Override the storage quota of a user or an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetQuotaOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaInfo"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetQuotaOption)
+
+	limit, err := quota_model.GetLimit(ctx, ctx.ContextUser.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	setSize := func(size *int64, value *int64) {
+		if value != nil {
+			*size = max(*value, -1)
+		}
+	}
+	setSize(&limit.TotalSize, form.Total)
+	setSize(&limit.GitSize, form.Git)
+	setSize(&limit.LFSSize, form.LFS)
+	setSize(&limit.PackagesSize, form.Packages)
+	setSize(&limit.AttachmentsSize, form.Attachments)
+	setSize(&limit.ArtifactsSize, form.Artifacts)
+
+	if err := quota_model.SetLimit(ctx, limit); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	shared.GetQuota(ctx, ctx.ContextUser.ID)
+}
+
+// DeleteUserQuota resets the storage quota of a user or an organization to the defaults
+func DeleteUserQuota(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/users/{username}/quota admin adminDeleteUserQuota
+	// ---
+	// summary: Reset the storage quota of a user or an organization to the defaults
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := quota_model.DeleteLimit(ctx, ctx.ContextUser.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -40,6 +40,44 @@ func CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, 0, 0)
 }
 
+// CreateRunnerJITToken creates a just-in-time token to register an ephemeral global runner
+func CreateRunnerJITToken(ctx *context.APIContext) {
+	// swagger:operation POST /admin/actions/runners/jit-token admin adminCreateRunnerJITToken
+	// ---
+	// summary: Create a single-use registration token for an ephemeral global runner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRunnerJITTokenOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/RunnerJITToken"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateRunnerJITToken(ctx, 0, 0)
+}
+
+// ListRunnerPendingJobs lists the number of jobs waiting for global runners by their labels
+func ListRunnerPendingJobs(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runners/pending-jobs admin adminListRunnerPendingJobs
+	// ---
+	// summary: List the number of jobs waiting for global runners by their labels
+	// produces:
+	// - application/json
+	// parameters:
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RunnerPendingJobsList"
+
+	shared.ListRunnerPendingJobs(ctx, 0, 0)
+}
+
 // ListRunners get all runners
 func ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /admin/actions/runners admin getAdminRunners
@@ -0,0 +1,220 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// currentTermsDocumentIDs returns the ids of the current versions, it responds with an error if they can't be loaded
+func currentTermsDocumentIDs(ctx *context.APIContext) container.Set[int64] {
+	current, err := user_model.GetCurrentTermsDocuments(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	ids := make(container.Set[int64], len(current))
+	for _, d := range current {
+		ids.Add(d.ID)
+	}
+	return ids
+}
+
+func getTermsDocument(ctx *context.APIContext) *user_model.TermsDocument {
+	d, err := user_model.GetTermsDocumentByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return d
+}
+
+// ListTermsDocuments lists all versions of the policy documents
+func ListTermsDocuments(ctx *context.APIContext) {
+	// swagger:operation GET /admin/terms admin adminListTermsDocuments
+	// ---
+	// summary: List all versions of the terms and policy documents
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TermsDocumentList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	listOptions := utils.GetListOptions(ctx)
+	docs, count, err := user_model.FindTermsDocuments(ctx, listOptions)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	currentIDs := currentTermsDocumentIDs(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	apiDocs := make([]*api.TermsDocument, 0, len(docs))
+	for _, d := range docs {
+		apiDocs = append(apiDocs, convert.ToTermsDocument(d, currentIDs.Contains(d.ID)))
+	}
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDocs)
+}
+
+// GetTermsDocument gets a version of a policy document
+func GetTermsDocument(ctx *context.APIContext) {
+	// swagger:operation GET /admin/terms/{id} admin adminGetTermsDocument
+	// ---
+	// summary: Get a version of a terms or policy document
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the document version
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TermsDocument"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	d := getTermsDocument(ctx)
+	if ctx.Written() {
+		return
+	}
+	currentIDs := currentTermsDocumentIDs(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToTermsDocument(d, currentIDs.Contains(d.ID)))
+}
+
+// CreateTermsDocument publishes a new version of a policy document
+func CreateTermsDocument(ctx *context.APIContext) {
+	// swagger:operation POST /admin/terms admin adminCreateTermsDocument
+	// ---
+	// summary: Publish a new version of a terms or policy document
+	// description: The new version replaces the current version of its kind, the users have to accept it within the configured grace period.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateTermsDocumentOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/TermsDocument"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.CreateTermsDocumentOption)
+
+	d := &user_model.TermsDocument{
+		Kind:    user_model.TermsKind(form.Kind),
+		Version: form.Version,
+		Title:   form.Title,
+		Content: form.Content,
+	}
+	if err := user_model.CreateTermsDocument(ctx, d); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToTermsDocument(d, true))
+}
+
+// ListTermsAcceptances lists the users who have accepted a version of a policy document
+func ListTermsAcceptances(ctx *context.APIContext) {
+	// swagger:operation GET /admin/terms/{id}/acceptances admin adminListTermsAcceptances
+	// ---
+	// summary: List the users who have accepted a version of a terms or policy document
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the document version
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TermsAcceptanceList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	d := getTermsDocument(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	acceptances, count, err := db.FindAndCount[user_model.TermsAcceptance](ctx, user_model.FindTermsAcceptancesOptions{
+		ListOptions: listOptions,
+		DocumentID:  d.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiAcceptances, err := convert.ToTermsAcceptanceList(ctx, acceptances, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiAcceptances)
+}
@@ -22,9 +22,11 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	admin_service "code.gitea.io/gitea/services/admin"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
@@ -280,6 +282,8 @@ func DeleteUser(ctx *context.APIContext) {
 	//   description: purge the user from the system completely
 	//   type: boolean
 	// responses:
+	//   "202":
+	//     "$ref": "#/responses/AdminApprovalRequest"
 	//   "204":
 	//     "$ref": "#/responses/empty"
 	//   "403":
@@ -300,6 +304,17 @@ func DeleteUser(ctx *context.APIContext) {
 		return
 	}
 
+	// the deletion has to be approved by a second administrator, it's accepted but not executed yet
+	if admin_service.IsApprovalRequired() {
+		r, err := admin_service.RequestDeleteUser(ctx, ctx.Doer, ctx.ContextUser, ctx.FormBool("purge"))
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		respondApprovalRequest(ctx, http.StatusAccepted, r)
+		return
+	}
+
 	if err := user_service.DeleteUser(ctx, ctx.ContextUser, ctx.FormBool("purge")); err != nil {
 		if repo_model.IsErrUserOwnRepos(err) ||
 			org_model.IsErrUserHasOrgs(err) ||
@@ -490,3 +505,139 @@ func RenameUser(ctx *context.APIContext) {
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// OffboardUser deactivates a user and hands over the repositories and the issue assignments
+func OffboardUser(ctx *context.APIContext) {
+	// swagger:operation POST /admin/users/{username}/offboard admin adminOffboardUser
+	// ---
+	// summary: Offboard a user
+	// description: Deactivates the user, revokes all credentials and sessions, transfers or archives the repositories and reassigns the open issues
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user to offboard
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/OffboardUserOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OffboardUserResult"
+	//   "202":
+	//     "$ref": "#/responses/AdminApprovalRequest"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.OffboardUserOption)
+
+	var opts user_service.OffboardOptions
+	var err error
+	if form.RepoOwner != "" {
+		if opts.RepoOwner, err = user_model.GetUserByName(ctx, form.RepoOwner); err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+	if form.IssueAssignee != "" {
+		if opts.IssueAssignee, err = user_model.GetUserByName(ctx, form.IssueAssignee); err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+
+	// the offboarding has to be approved by a second administrator, it's accepted but not executed yet
+	if admin_service.IsApprovalRequired() {
+		r, err := admin_service.RequestOffboardUser(ctx, ctx.Doer, ctx.ContextUser, opts)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		respondApprovalRequest(ctx, http.StatusAccepted, r)
+		return
+	}
+
+	result, err := user_service.OffboardUser(ctx, ctx.Doer, ctx.ContextUser, opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	log.Trace("Account offboarded by admin (%s): %s", ctx.Doer.Name, ctx.ContextUser.Name)
+
+	ctx.JSON(http.StatusOK, &api.OffboardUserResult{
+		TransferredRepos: result.TransferredRepos,
+		ArchivedRepos:    result.ArchivedRepos,
+		ReassignedIssues: result.ReassignedIssues,
+	})
+}
+
+// AnonymizeUser scrubs the personal data of a user
+func AnonymizeUser(ctx *context.APIContext) {
+	// swagger:operation POST /admin/users/{username}/anonymize admin adminAnonymizeUser
+	// ---
+	// summary: Anonymize a user
+	// description: Removes the personal data of the user but keeps the account, renamed, for the issues, comments and commits of the user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user to anonymize
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/AdminApprovalRequest"
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	// the anonymization can't be undone, it has to be approved by a second administrator
+	if admin_service.IsApprovalRequired() {
+		r, err := admin_service.RequestAnonymizeUser(ctx, ctx.Doer, ctx.ContextUser)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		respondApprovalRequest(ctx, http.StatusAccepted, r)
+		return
+	}
+
+	if err := user_service.AnonymizeUser(ctx, ctx.Doer, ctx.ContextUser); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	log.Trace("Account %d anonymized by admin (%s)", ctx.ContextUser.ID, ctx.Doer.Name)
+
+	ctx.Status(http.StatusNoContent)
+}
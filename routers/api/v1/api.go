@@ -72,6 +72,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
@@ -85,7 +86,9 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/activitypub"
 	"code.gitea.io/gitea/routers/api/v1/admin"
+	"code.gitea.io/gitea/routers/api/v1/graphql"
 	"code.gitea.io/gitea/routers/api/v1/misc"
+	"code.gitea.io/gitea/routers/api/v1/moderation"
 	"code.gitea.io/gitea/routers/api/v1/notify"
 	"code.gitea.io/gitea/routers/api/v1/org"
 	"code.gitea.io/gitea/routers/api/v1/packages"
@@ -97,6 +100,7 @@ import (
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	moderation_service "code.gitea.io/gitea/services/moderation"
 
 	_ "code.gitea.io/gitea/routers/api/v1/swagger" // for swagger generation
 
@@ -415,6 +419,16 @@ func reqSiteAdmin() func(ctx *context.APIContext) {
 	}
 }
 
+// reqModerator user should be the site admin or a moderator.
+func reqModerator() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !moderation_service.IsModerator(ctx.Doer) {
+			ctx.APIError(http.StatusForbidden, "user should be the site admin or a moderator")
+			return
+		}
+	}
+}
+
 // reqOwner user should be the owner of the repo or site admin.
 func reqOwner() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -597,6 +611,56 @@ func reqWebhooksEnabled() func(ctx *context.APIContext) {
 	}
 }
 
+// reqWebPushEnabled requires web push to be enabled in the config.
+func reqWebPushEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.WebPush.Enabled {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
+// reqModerationEnabled requires content reporting to be enabled in the config.
+func reqModerationEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.Moderation.Enabled {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
+// reqLFSEnabled requires the LFS server to be enabled in the config.
+func reqLFSEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.LFS.StartServer {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
+// reqIncomingEmailEnabled requires incoming emails to be enabled in the config.
+func reqIncomingEmailEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.IncomingEmail.Enabled {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
+// reqMatrixEnabled requires Matrix notifications to be enabled in the config.
+func reqMatrixEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.Matrix.Enabled {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
 // reqStarsEnabled requires Starring to be enabled in the config.
 func reqStarsEnabled() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -728,6 +792,22 @@ func mustEnableIssuesOrPulls(ctx *context.APIContext) {
 	}
 }
 
+// mustNotBeHiddenIssue responds not found if a moderator has hidden the issue or the pull request,
+// the moderators and the administrators of the repository can still see it
+func mustNotBeHiddenIssue(ctx *context.APIContext) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		// the handlers respond not found if the issue doesn't exist
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if moderation_service.IsIssueHiddenFrom(issue, ctx.Doer, &ctx.Repo.Permission) {
+		ctx.APIErrorNotFound()
+	}
+}
+
 func mustEnableWiki(ctx *context.APIContext) {
 	if !(ctx.Repo.CanRead(unit.TypeWiki)) {
 		ctx.APIErrorNotFound()
@@ -787,11 +867,15 @@ func buildAuthGroup() *auth.Group {
 	return group
 }
 
-func apiAuth(authMethod auth.Method) func(*context.APIContext) {
+func apiAuth(authMethod auth.Method, rateLimitRule common.APIRateLimitRuleFunc) func(*context.APIContext) {
 	return func(ctx *context.APIContext) {
 		ar, err := common.AuthShared(ctx.Base, nil, authMethod)
 		if err != nil {
-			ctx.APIError(http.StatusUnauthorized, err)
+			// the request doesn't reach the rate limit, the failure is charged to the IP address instead
+			common.APIRateLimitFailedAuth(ctx, rateLimitRule)
+			if !ctx.Written() {
+				ctx.APIError(http.StatusUnauthorized, err)
+			}
 			return
 		}
 		ctx.Doer = ar.Doer
@@ -827,6 +911,16 @@ func verifyAuthWithOptions(options *common.VerifyOptions) func(ctx *context.APIC
 				})
 				return
 			}
+
+			if overdue, err := user_model.IsTermsAcceptanceOverdue(ctx, ctx.Doer); err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			} else if overdue {
+				ctx.JSON(http.StatusForbidden, map[string]string{
+					"message": "You must accept the current terms and policies. Accept them at: " + setting.AppURL + "user/terms",
+				})
+				return
+			}
 		}
 
 		// Redirect to dashboard if user tries to visit any non-login page.
@@ -887,10 +981,9 @@ func checkDeprecatedAuthMethods(ctx *context.APIContext) {
 	}
 }
 
-// Routes registers all v1 APIs routes to web application.
-func Routes() *web.Router {
-	m := web.NewRouter()
-
+// useCommonMiddlewares registers the middlewares shared by the REST and the GraphQL API, the requests are limited
+// by the rate limit rule of the API
+func useCommonMiddlewares(m *web.Router, rateLimitRule common.APIRateLimitRuleFunc) {
 	m.Use(securityHeaders())
 	if setting.CORSConfig.Enabled {
 		m.Use(cors.Handler(cors.Options{
@@ -906,11 +999,35 @@ func Routes() *web.Router {
 	m.Use(checkDeprecatedAuthMethods)
 
 	// Get user from session if logged in.
-	m.Use(apiAuth(buildAuthGroup()))
+	m.Use(apiAuth(buildAuthGroup(), rateLimitRule))
+	m.Use(common.APIRateLimit(rateLimitRule))
 
 	m.Use(verifyAuthWithOptions(&common.VerifyOptions{
 		SignInRequired: setting.Service.RequireSignInViewStrict,
 	}))
+}
+
+// GraphQLRoutes registers the GraphQL API routes to web application.
+func GraphQLRoutes() *web.Router {
+	m := web.NewRouter()
+	useCommonMiddlewares(m, common.GraphQLRateLimitRule)
+	m.Use(func(ctx *context.APIContext) {
+		// the permissions of actions tokens are bound to the repository of the task, which only the REST API can check
+		if ctx.Data["IsActionsToken"] == true {
+			ctx.APIError(http.StatusForbidden, "actions tokens can't access the GraphQL API")
+		}
+	})
+
+	m.Combo("").Get(graphql.Query).Post(graphql.Query)
+	m.Get("/schema", graphql.SchemaDefinition)
+	return m
+}
+
+// Routes registers all v1 APIs routes to web application.
+func Routes() *web.Router {
+	m := web.NewRouter()
+	useCommonMiddlewares(m, common.RESTAPIRateLimitRule)
+	m.Use(common.APIMaintenanceMode())
 
 	addActionsRoutes := func(
 		m *web.Router,
@@ -938,9 +1055,16 @@ func Routes() *web.Router {
 				m.Get("", reqToken(), reqChecker, act.ListRunners)
 				m.Get("/registration-token", reqToken(), reqChecker, act.GetRegistrationToken)
 				m.Post("/registration-token", reqToken(), reqChecker, act.CreateRegistrationToken)
+				m.Post("/jit-token", reqToken(), reqChecker, bind(api.CreateRunnerJITTokenOption{}), act.CreateRunnerJITToken)
+				m.Get("/pending-jobs", reqToken(), reqChecker, act.ListRunnerPendingJobs)
 				m.Get("/{runner_id}", reqToken(), reqChecker, act.GetRunner)
 				m.Delete("/{runner_id}", reqToken(), reqChecker, act.DeleteRunner)
 			})
+			m.Combo("/artifact-retention", reqToken(), reqChecker).
+				Get(act.GetArtifactRetention).
+				Put(bind(api.EditActionArtifactRetentionOption{}), act.UpdateArtifactRetention).
+				Delete(act.DeleteArtifactRetention)
+			m.Get("/artifact-usage", reqToken(), reqChecker, act.GetArtifactUsage)
 			m.Get("/runs", reqToken(), reqChecker, act.ListWorkflowRuns)
 			m.Get("/jobs", reqToken(), reqChecker, act.ListWorkflowJobs)
 		})
@@ -965,7 +1089,13 @@ func Routes() *web.Router {
 				m.Group("/user-id/{user-id}", func() {
 					m.Get("", activitypub.Person)
 					m.Post("/inbox", activitypub.ReqHTTPSignature(), activitypub.PersonInbox)
+					m.Get("/followers", activitypub.PersonFollowers)
 				}, context.UserIDAssignmentAPI(), checkTokenPublicOnly())
+				m.Group("/repository-id/{repository-id}", func() {
+					m.Get("", activitypub.Repository)
+					m.Post("/inbox", activitypub.ReqHTTPSignature(), activitypub.RepositoryInbox)
+					m.Get("/followers", activitypub.RepositoryFollowers)
+				}, activitypub.RepositoryIDAssignment(), checkTokenPublicOnly())
 			}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryActivityPub))
 		}
 
@@ -1050,6 +1180,7 @@ func Routes() *web.Router {
 				m.Get("", user.GetUserSettings)
 				m.Patch("", bind(api.UserSettingsOptions{}), user.UpdateUserSettings)
 			}, reqToken())
+			m.Get("/quota", reqToken(), user.GetQuota)
 			m.Combo("/emails").
 				Get(user.ListEmails).
 				Post(bind(api.CreateEmailOption{}), user.AddEmail).
@@ -1076,6 +1207,8 @@ func Routes() *web.Router {
 					m.Get("", reqToken(), user.ListRunners)
 					m.Get("/registration-token", reqToken(), user.GetRegistrationToken)
 					m.Post("/registration-token", reqToken(), user.CreateRegistrationToken)
+					m.Post("/jit-token", reqToken(), bind(api.CreateRunnerJITTokenOption{}), user.CreateRunnerJITToken)
+					m.Get("/pending-jobs", reqToken(), user.ListRunnerPendingJobs)
 					m.Get("/{runner_id}", reqToken(), user.GetRunner)
 					m.Delete("/{runner_id}", reqToken(), user.DeleteRunner)
 				})
@@ -1148,6 +1281,20 @@ func Routes() *web.Router {
 					Delete(user.DeleteHook)
 			}, reqWebhooksEnabled())
 
+			m.Group("/web_push", func() {
+				m.Combo("").Get(user.GetWebPushSettings).
+					Put(bind(api.EditWebPushSettingsOption{}), user.EditWebPushSettings)
+				m.Combo("/subscriptions").Get(user.ListWebPushSubscriptions).
+					Post(bind(api.CreateWebPushSubscriptionOption{}), user.CreateWebPushSubscription)
+				m.Delete("/subscriptions/{id}", user.DeleteWebPushSubscription)
+			}, reqWebPushEnabled())
+			m.Combo("/matrix", reqMatrixEnabled()).Get(user.GetMatrixSettings).
+				Put(bind(api.EditMatrixSettingsOption{}), user.EditMatrixSettings)
+			m.Combo("/notification_settings").Get(user.GetNotificationSettings).
+				Patch(bind(api.EditNotificationSettingsOption{}), user.EditNotificationSettings)
+			m.Get("/announcements", user.ListMyAnnouncements)
+			m.Post("/announcements/{id}/dismiss", user.DismissAnnouncement)
+
 			m.Group("/avatar", func() {
 				m.Post("", bind(api.UpdateUserAvatarOption{}), user.UpdateAvatar)
 				m.Delete("", user.DeleteAvatar)
@@ -1181,6 +1328,7 @@ func Routes() *web.Router {
 
 			// (repo scope)
 			m.Post("/migrate", reqToken(), bind(api.MigrateRepoOptions{}), repo.Migrate)
+			m.Post("/federated-forks", reqToken(), bind(api.CreateFederatedForkOption{}), repo.CreateFederatedFork)
 
 			m.Group("/{username}/{reponame}", func() {
 				m.Get("/compare/*", reqRepoReader(unit.TypeCode), repo.CompareDiff)
@@ -1205,6 +1353,8 @@ func Routes() *web.Router {
 					m.Post("/{workflow_id}/dispatches", reqRepoWriter(unit.TypeActions), bind(api.CreateActionWorkflowDispatch{}), repo.ActionsDispatchWorkflow)
 				}, context.ReferencesGitRepo(), reqToken(), reqRepoReader(unit.TypeActions))
 
+				m.Post("/dispatches", reqToken(), reqRepoWriter(unit.TypeActions), bind(api.CreateRepositoryDispatchOption{}), repo.CreateRepositoryDispatch)
+
 				m.Group("/actions/jobs", func() {
 					m.Get("/{job_id}", repo.GetWorkflowJob)
 					m.Get("/{job_id}/logs", repo.DownloadActionsRunJobLogs)
@@ -1226,6 +1376,8 @@ func Routes() *web.Router {
 							Patch(bind(api.EditHookOption{}), repo.EditHook).
 							Delete(repo.DeleteHook)
 						m.Post("/tests", context.ReferencesGitRepo(), context.RepoRefForAPI, repo.TestHook)
+						m.Get("/deliveries", repo.ListHookDeliveries)
+						m.Post("/deliveries/redeliver", bind(api.RedeliverHookDeliveriesOption{}), repo.RedeliverHookDeliveries)
 					})
 				}, reqToken(), reqAdmin(), reqWebhooksEnabled())
 				m.Group("/collaborators", func() {
@@ -1251,6 +1403,7 @@ func Routes() *web.Router {
 				m.Combo("/forks").Get(repo.ListForks).
 					Post(reqToken(), reqRepoReader(unit.TypeCode), bind(api.CreateForkOption{}), repo.CreateFork)
 				m.Post("/merge-upstream", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeCode), bind(api.MergeUpstreamRequest{}), repo.MergeUpstream)
+				m.Post("/federated-pulls", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeCode), bind(api.CreateFederatedPullRequestOption{}), repo.CreateFederatedPullRequest)
 				m.Group("/branches", func() {
 					m.Get("", repo.ListBranches)
 					m.Get("/*", repo.GetBranch)
@@ -1274,6 +1427,20 @@ func Routes() *web.Router {
 					m.Post("", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, bind(api.CreateTagOption{}), repo.CreateTag)
 					m.Delete("/*", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.DeleteTag)
 				}, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(true))
+				m.Group("/environments", func() {
+					m.Get("", repo.ListActionEnvironments)
+					m.Group("/{environment_name}", func() {
+						m.Combo("").Get(repo.GetActionEnvironment).
+							Put(reqAdmin(), bind(api.CreateOrUpdateEnvironmentOption{}), repo.CreateOrUpdateActionEnvironment).
+							Delete(reqAdmin(), repo.DeleteActionEnvironment)
+						m.Group("/secrets", func() {
+							m.Get("", repo.ListEnvironmentSecrets)
+							m.Combo("/{secretname}").
+								Put(bind(api.CreateOrUpdateSecretOption{}), repo.CreateOrUpdateEnvironmentSecret).
+								Delete(repo.DeleteEnvironmentSecret)
+						}, reqOwner())
+					})
+				}, reqToken(), reqRepoReader(unit.TypeActions))
 				m.Group("/tag_protections", func() {
 					m.Combo("").Get(repo.ListTagProtection).
 						Post(bind(api.CreateTagProtectionOption{}), mustNotBeArchived, repo.CreateTagProtection)
@@ -1285,12 +1452,17 @@ func Routes() *web.Router {
 				}, reqToken(), reqAdmin())
 				m.Group("/actions", func() {
 					m.Get("/tasks", repo.ListActionTasks)
+					m.Get("/concurrency_groups", repo.ListActionConcurrencyGroups)
 					m.Group("/runs", func() {
 						m.Group("/{run}", func() {
 							m.Get("", repo.GetWorkflowRun)
 							m.Delete("", reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionRun)
 							m.Get("/jobs", repo.ListWorkflowRunJobs)
+							m.Get("/graph", repo.GetWorkflowRunGraph)
 							m.Get("/artifacts", repo.GetArtifactsOfRun)
+							m.Combo("/pending_deployments").Get(repo.ListPendingDeployments).
+								Post(reqToken(), bind(api.ReviewPendingDeploymentsOption{}), repo.ReviewPendingDeployments)
+							m.Get("/approvals", repo.ListDeploymentReviews)
 						})
 					})
 					m.Get("/artifacts", repo.GetArtifacts)
@@ -1299,6 +1471,9 @@ func Routes() *web.Router {
 						m.Delete("", reqRepoWriter(unit.TypeActions), repo.DeleteArtifact)
 					})
 					m.Get("/artifacts/{artifact_id}/zip", repo.DownloadArtifact)
+					m.Get("/caches", repo.ListActionCaches)
+					m.Delete("/caches/{cache_id}", reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionCache)
+					m.Get("/cache/usage", repo.GetActionCacheUsage)
 				}, reqRepoReader(unit.TypeActions), context.ReferencesGitRepo(true))
 				m.Group("/keys", func() {
 					m.Combo("").Get(repo.ListDeployKeys).
@@ -1308,8 +1483,11 @@ func Routes() *web.Router {
 				}, reqToken(), reqAdmin())
 				m.Group("/times", func() {
 					m.Combo("").Get(repo.ListTrackedTimesByRepository)
+					m.Get("/report", repo.GetTrackedTimeReport)
+					m.Get("/export", repo.ExportTrackedTimes)
 					m.Combo("/{timetrackingusername}").Get(repo.ListTrackedTimesByUser)
 				}, mustEnableIssues, reqToken())
+				m.Get("/stopwatches", mustEnableIssues, reqToken(), reqRepoWriter(unit.TypeIssues), repo.ListRepoStopwatches)
 				m.Group("/wiki", func() {
 					m.Combo("/page/{pageName}").
 						Get(repo.GetWikiPage).
@@ -1327,12 +1505,21 @@ func Routes() *web.Router {
 				m.Group("/subscription", func() {
 					m.Get("", user.IsWatching)
 					m.Put("", user.Watch)
+					m.Patch("", bind(api.EditWatchOption{}), user.EditWatch)
 					m.Delete("", user.Unwatch)
 				}, reqToken())
+				m.Combo("/lfs/locks", reqToken(), reqAdmin(), reqLFSEnabled()).Get(repo.ListLFSLocks).
+					Delete(bind(api.DeleteLFSLocksOption{}), repo.DeleteLFSLocks)
+				m.Group("/incoming_email", func() {
+					m.Get("", reqRepoReader(unit.TypeIssues), repo.GetIncomingEmailAlias)
+					m.Put("", reqAdmin(), bind(api.EditIncomingEmailAliasOption{}), repo.EditIncomingEmailAlias)
+					m.Delete("", reqAdmin(), repo.DeleteIncomingEmailAlias)
+				}, reqToken(), reqIncomingEmailEnabled())
 				m.Group("/releases", func() {
 					m.Combo("").Get(repo.ListReleases).
 						Post(reqToken(), reqRepoWriter(unit.TypeReleases), context.ReferencesGitRepo(), bind(api.CreateReleaseOption{}), repo.CreateRelease)
 					m.Combo("/latest").Get(repo.GetLatestRelease)
+					m.Post("/generate-notes", reqToken(), reqRepoWriter(unit.TypeReleases), context.ReferencesGitRepo(), bind(api.GenerateReleaseNotesOption{}), repo.GenerateReleaseNotes)
 					m.Group("/{id}", func() {
 						m.Combo("").Get(repo.GetRelease).
 							Patch(reqToken(), reqRepoWriter(unit.TypeReleases), context.ReferencesGitRepo(), bind(api.EditReleaseOption{}), repo.EditRelease).
@@ -1340,6 +1527,7 @@ func Routes() *web.Router {
 						m.Group("/assets", func() {
 							m.Combo("").Get(repo.ListReleaseAttachments).
 								Post(reqToken(), reqRepoWriter(unit.TypeReleases), repo.CreateReleaseAttachment)
+							m.Post("/uploads", reqToken(), reqRepoWriter(unit.TypeReleases), repo.CreateReleaseAttachmentUpload)
 							m.Combo("/{attachment_id}").Get(repo.GetReleaseAttachment).
 								Patch(reqToken(), reqRepoWriter(unit.TypeReleases), bind(api.EditAttachmentOptions{}), repo.EditReleaseAttachment).
 								Delete(reqToken(), reqRepoWriter(unit.TypeReleases), repo.DeleteReleaseAttachment)
@@ -1351,6 +1539,11 @@ func Routes() *web.Router {
 							Delete(reqToken(), reqRepoWriter(unit.TypeReleases), repo.DeleteReleaseByTag)
 					})
 				}, reqRepoReader(unit.TypeReleases))
+				m.Group("/attachment-uploads/{upload_id}", func() {
+					m.Head("", repo.GetAttachmentUpload)
+					m.Patch("", mustNotBeArchived, repo.AppendAttachmentUpload)
+					m.Delete("", repo.DeleteAttachmentUpload)
+				}, reqToken())
 				m.Post("/mirror-sync", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.MirrorSync)
 				m.Post("/push_mirrors-sync", reqAdmin(), reqToken(), mustNotBeArchived, repo.PushMirrorSync)
 				m.Group("/push_mirrors", func() {
@@ -1359,6 +1552,8 @@ func Routes() *web.Router {
 					m.Combo("/{name}").
 						Delete(mustNotBeArchived, repo.DeletePushMirrorByRemoteName).
 						Get(repo.GetPushMirrorByName)
+					m.Get("/{name}/status", repo.GetPushMirrorStatus)
+					m.Post("/{name}/sync", mustNotBeArchived, repo.SyncPushMirrorByName)
 				}, reqAdmin(), reqToken())
 
 				m.Get("/editorconfig/{filename}", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.GetEditorconfig)
@@ -1372,6 +1567,7 @@ func Routes() *web.Router {
 						m.Get(".{diffType:diff|patch}", repo.DownloadPullDiffOrPatch)
 						m.Post("/update", reqToken(), repo.UpdatePullRequest)
 						m.Get("/commits", repo.GetPullRequestCommits)
+						m.Get("/signoff", repo.GetPullRequestSignOff)
 						m.Get("/files", repo.GetPullRequestFiles)
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), repo.MergePullRequest).
@@ -1394,7 +1590,7 @@ func Routes() *web.Router {
 						m.Combo("/requested_reviewers", reqToken()).
 							Delete(bind(api.PullReviewRequestOptions{}), repo.DeleteReviewRequests).
 							Post(bind(api.PullReviewRequestOptions{}), repo.CreateReviewRequests)
-					})
+					}, mustNotBeHiddenIssue)
 					m.Get("/{base}/*", repo.GetPullRequestByBaseHead)
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
 				m.Group("/statuses", func() {
@@ -1459,7 +1655,18 @@ func Routes() *web.Router {
 				m.Get("/issue_config/validate", context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
 				m.Get("/licenses", reqRepoReader(unit.TypeCode), repo.GetLicenses)
+				m.Get("/licenses/compliance", reqRepoReader(unit.TypeCode), repo.GetLicenseCompliance)
+				m.Get("/sbom", reqRepoReader(unit.TypeCode), repo.GetSBOM)
+				m.Get("/dependencies", reqRepoReader(unit.TypeCode), repo.ListDependencies)
+				m.Group("/insights", func() {
+					m.Get("/contributors", reqRepoReader(unit.TypeCode), repo.GetContributorInsights)
+					m.Get("/code-frequency", reqRepoReader(unit.TypeCode), repo.GetCodeFrequencyInsights)
+					m.Get("/pulls", reqRepoReader(unit.TypePullRequests), repo.GetPullRequestInsights)
+					m.Get("/issues", reqRepoReader(unit.TypeIssues), repo.GetIssueInsights)
+				})
+				m.Get("/symbols", reqRepoReader(unit.TypeCode), repo.ListSymbols)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
+				m.Get("/events/stream", repo.StreamRepoActivityFeeds)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
 				m.Group("/avatar", func() {
 					m.Post("", bind(api.UpdateRepoAvatarOption{}), repo.UpdateAvatar)
@@ -1492,6 +1699,11 @@ func Routes() *web.Router {
 					m.Combo("").Get(repo.ListIssues).
 						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), reqRepoReader(unit.TypeIssues), repo.CreateIssue)
 					m.Get("/pinned", reqRepoReader(unit.TypeIssues), repo.ListPinnedIssues)
+					m.Get("/similar", reqRepoReader(unit.TypeIssues), repo.ListSimilarIssues)
+					m.Post("/bulk", reqToken(), mustNotBeArchived, bind(api.BulkEditIssuesOption{}), repo.BulkEditIssues)
+					m.Get("/export", repo.ExportIssues)
+					m.Combo("/archive", reqToken(), reqAdmin()).Get(repo.ExportIssueArchive).
+						Post(mustNotBeArchived, repo.ImportIssueArchive)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -1540,6 +1752,16 @@ func Routes() *web.Router {
 							m.Delete("/{id}", repo.DeleteTime)
 						}, reqToken())
 						m.Combo("/deadline").Post(reqToken(), bind(api.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+						m.Combo("/fields").Get(repo.GetIssueFieldValues).
+							Patch(reqToken(), mustNotBeArchived, bind(api.EditIssueFieldValuesOption{}), repo.EditIssueFieldValues)
+						m.Get("/sla", repo.GetIssueSLAStatuses)
+						m.Get("/similar", reqRepoReader(unit.TypeIssues), repo.ListIssueSimilarIssues)
+						m.Combo("/sub_issues").Get(repo.GetIssueHierarchy).
+							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
+						m.Combo("/iteration").Get(repo.GetIssueIteration).
+							Put(reqToken(), mustNotBeArchived, bind(api.SetIssueIterationOption{}), repo.SetIssueIteration).
+							Delete(reqToken(), mustNotBeArchived, repo.RemoveIssueIteration)
 						m.Group("/stopwatch", func() {
 							m.Post("/start", repo.StartIssueStopwatch)
 							m.Post("/stop", repo.StopIssueStopwatch)
@@ -1559,6 +1781,7 @@ func Routes() *web.Router {
 							m.Combo("").
 								Get(repo.ListIssueAttachments).
 								Post(reqToken(), mustNotBeArchived, repo.CreateIssueAttachment)
+							m.Post("/uploads", reqToken(), mustNotBeArchived, repo.CreateIssueAttachmentUpload)
 							m.Combo("/{attachment_id}").
 								Get(repo.GetIssueAttachment).
 								Patch(reqToken(), mustNotBeArchived, bind(api.EditAttachmentOptions{}), repo.EditIssueAttachment).
@@ -1583,7 +1806,7 @@ func Routes() *web.Router {
 								Put(bind(api.LockIssueOption{}), repo.LockIssue).
 								Delete(repo.UnlockIssue)
 						}, reqToken(), reqAdmin())
-					})
+					}, mustNotBeHiddenIssue)
 				}, mustEnableIssuesOrPulls)
 				m.Group("/labels", func() {
 					m.Combo("").Get(repo.ListLabels).
@@ -1592,6 +1815,40 @@ func Routes() *web.Router {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditLabelOption{}), repo.EditLabel).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteLabel)
 				})
+				m.Group("/issue_fields", func() {
+					m.Combo("").Get(repo.ListIssueFields).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateIssueFieldOption{}), repo.CreateIssueField)
+					m.Combo("/{id}").Get(repo.GetIssueField).
+						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditIssueFieldOption{}), repo.EditIssueField).
+						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteIssueField)
+				})
+				m.Group("/issue_sla_rules", func() {
+					m.Combo("").Get(repo.ListIssueSLARules).
+						Post(reqToken(), reqAdmin(), bind(api.CreateIssueSLARuleOption{}), repo.CreateIssueSLARule)
+					m.Combo("/{id}").Get(repo.GetIssueSLARule).
+						Patch(reqToken(), reqAdmin(), bind(api.EditIssueSLARuleOption{}), repo.EditIssueSLARule).
+						Delete(reqToken(), reqAdmin(), repo.DeleteIssueSLARule)
+				}, reqRepoReader(unit.TypeIssues))
+				m.Group("/iterations", func() {
+					m.Combo("").Get(repo.ListIterations).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues), bind(api.CreateIterationOption{}), repo.CreateIteration)
+					m.Get("/velocity", repo.ListIterationVelocity)
+					m.Group("/{id}", func() {
+						m.Combo("").Get(repo.GetIteration).
+							Patch(reqToken(), reqRepoWriter(unit.TypeIssues), bind(api.EditIterationOption{}), repo.EditIteration).
+							Delete(reqToken(), reqRepoWriter(unit.TypeIssues), repo.DeleteIteration)
+						m.Post("/close", reqToken(), reqRepoWriter(unit.TypeIssues), bind(api.CloseIterationOption{}), repo.CloseIteration)
+						m.Get("/issues", repo.ListIterationIssues)
+						m.Get("/burndown", repo.GetIterationBurndown)
+					})
+				}, reqRepoReader(unit.TypeIssues))
+				m.Group("/issue_searches", func() {
+					m.Combo("").Get(repo.ListIssueSavedSearches).
+						Post(reqToken(), bind(api.CreateIssueSavedSearchOption{}), repo.CreateIssueSavedSearch)
+					m.Combo("/{id}").Get(repo.GetIssueSavedSearch).
+						Patch(reqToken(), bind(api.EditIssueSavedSearchOption{}), repo.EditIssueSavedSearch).
+						Delete(reqToken(), repo.DeleteIssueSavedSearch)
+				}, mustEnableIssuesOrPulls)
 				m.Group("/milestones", func() {
 					m.Combo("").Get(repo.ListMilestones).
 						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateMilestoneOption{}), repo.CreateMilestone)
@@ -1604,6 +1861,17 @@ func Routes() *web.Router {
 
 		// NOTE: these are Gitea package management API - see packages.CommonRoutes and packages.DockerContainerRoutes for endpoints that implement package manager APIs
 		m.Group("/packages/{username}", func() {
+			m.Group("/-/rules", func() {
+				m.Combo("").Get(packages.ListCleanupRules).
+					Post(bind(api.CreatePackageCleanupRuleOption{}), packages.CreateCleanupRule)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(packages.GetCleanupRule).
+						Patch(bind(api.EditPackageCleanupRuleOption{}), packages.EditCleanupRule).
+						Delete(packages.DeleteCleanupRule)
+					m.Get("/preview", packages.PreviewCleanupRule)
+				})
+			}, reqPackageAccess(perm.AccessModeAdmin))
+
 			m.Group("/{type}/{name}", func() {
 				m.Get("/", packages.ListPackageVersions)
 
@@ -1611,6 +1879,12 @@ func Routes() *web.Router {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Get("/signatures", packages.GetContainerSignatureStatus)
+					m.Get("/sbom", packages.GetContainerSBOM)
+					m.Group("/vulnerabilities", func() {
+						m.Get("", packages.GetPackageVulnerabilities)
+						m.Post("/scan", reqPackageAccess(perm.AccessModeWrite), packages.ScanPackageVersion)
+					})
 				})
 
 				m.Group("/-", func() {
@@ -1636,12 +1910,14 @@ func Routes() *web.Router {
 				Patch(reqToken(), reqOrgOwnership(), bind(api.EditOrgOption{}), org.Edit).
 				Delete(reqToken(), reqOrgOwnership(), org.Delete)
 			m.Post("/rename", reqToken(), reqOrgOwnership(), bind(api.RenameOrgOption{}), org.Rename)
+			m.Get("/quota", reqToken(), reqOrgMembership(), org.GetQuota)
 			m.Combo("/repos").Get(user.ListOrgRepos).
 				Post(reqToken(), bind(api.CreateRepoOption{}), repo.CreateOrgRepo)
 			m.Group("/members", func() {
 				m.Get("", reqToken(), org.ListMembers)
 				m.Combo("/{username}").Get(reqToken(), org.IsMember).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteMember)
+				m.Get("/{username}/roles", reqToken(), reqOrgMembership(), org.ListMemberRoles)
 			})
 			addActionsRoutes(
 				m,
@@ -1659,6 +1935,23 @@ func Routes() *web.Router {
 				m.Post("", reqOrgOwnership(), bind(api.CreateTeamOption{}), org.CreateTeam)
 				m.Get("/search", org.SearchTeam)
 			}, reqToken(), reqOrgMembership())
+			m.Group("/roles", func() {
+				m.Combo("").Get(org.ListRoles).
+					Post(reqOrgOwnership(), bind(api.CreateOrgRoleOption{}), org.CreateRole)
+				m.Get("/permissions", org.ListRolePermissions)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(org.GetRole).
+						Patch(reqOrgOwnership(), bind(api.EditOrgRoleOption{}), org.EditRole).
+						Delete(reqOrgOwnership(), org.DeleteRole)
+					m.Get("/assignments", org.ListRoleAssignments)
+					m.Combo("/users/{username}").
+						Put(reqOrgOwnership(), org.AssignRoleToUser).
+						Delete(reqOrgOwnership(), org.UnassignRoleFromUser)
+					m.Combo("/teams/{teamid}").
+						Put(reqOrgOwnership(), org.AssignRoleToTeam).
+						Delete(reqOrgOwnership(), org.UnassignRoleFromTeam)
+				})
+			}, reqToken(), reqOrgMembership())
 			m.Group("/labels", func() {
 				m.Get("", org.ListLabels)
 				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateLabelOption{}), org.CreateLabel)
@@ -1666,6 +1959,43 @@ func Routes() *web.Router {
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			})
+			m.Group("/issue_fields", func() {
+				m.Get("", org.ListIssueFields)
+				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateIssueFieldOption{}), org.CreateIssueField)
+				m.Combo("/{id}").Get(org.GetIssueField).
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditIssueFieldOption{}), org.EditIssueField).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteIssueField)
+			})
+			m.Group("/iterations", func() {
+				m.Combo("").Get(org.ListIterations).
+					Post(reqOrgOwnership(), bind(api.CreateIterationOption{}), org.CreateIteration)
+				m.Get("/velocity", org.ListIterationVelocity)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(org.GetIteration).
+						Patch(reqOrgOwnership(), bind(api.EditIterationOption{}), org.EditIteration).
+						Delete(reqOrgOwnership(), org.DeleteIteration)
+					m.Post("/close", reqOrgOwnership(), bind(api.CloseIterationOption{}), org.CloseIteration)
+					m.Get("/burndown", org.GetIterationBurndown)
+				})
+			}, reqToken(), reqOrgMembership())
+			m.Group("/times", func() {
+				m.Get("/report", org.GetTrackedTimeReport)
+				m.Get("/export", org.ExportTrackedTimes)
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/time_tracking_rules", func() {
+				m.Combo("").Get(org.ListTimeTrackingRules).
+					Post(reqOrgOwnership(), bind(api.CreateTimeTrackingRuleOption{}), org.CreateTimeTrackingRule)
+				m.Combo("/{id}").
+					Patch(reqOrgOwnership(), bind(api.EditTimeTrackingRuleOption{}), org.EditTimeTrackingRule).
+					Delete(reqOrgOwnership(), org.DeleteTimeTrackingRule)
+			}, reqToken(), reqOrgMembership())
+			m.Group("/issue_searches", func() {
+				m.Get("", reqToken(), reqOrgMembership(), org.ListIssueSavedSearches)
+				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateIssueSavedSearchOption{}), org.CreateIssueSavedSearch)
+				m.Combo("/{id}").
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditIssueSavedSearchOption{}), org.EditIssueSavedSearch).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteIssueSavedSearch)
+			})
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
@@ -1678,6 +2008,20 @@ func Routes() *web.Router {
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
+			m.Get("/events/stream", org.StreamOrgActivityFeeds)
+			m.Group("/insights", func() {
+				m.Get("/contributors", org.GetContributorInsights)
+				m.Get("/code-frequency", org.GetCodeFrequencyInsights)
+				m.Get("/pulls", org.GetPullRequestInsights)
+				m.Get("/issues", org.GetIssueInsights)
+			}, reqToken())
+			m.Get("/dependents", org.ListDependents)
+			m.Combo("/license_policy", reqToken(), reqOrgMembership()).Get(org.GetLicensePolicy).
+				Put(reqOrgOwnership(), bind(api.EditLicensePolicyOption{}), org.SetLicensePolicy).
+				Delete(reqOrgOwnership(), org.DeleteLicensePolicy)
+			m.Combo("/signoff_policy", reqToken(), reqOrgMembership()).Get(org.GetSignOffPolicy).
+				Put(reqOrgOwnership(), bind(api.EditSignOffPolicyOption{}), org.SetSignOffPolicy).
+				Delete(reqOrgOwnership(), org.DeleteSignOffPolicy)
 
 			m.Group("/blocks", func() {
 				m.Get("", org.ListBlocks)
@@ -1706,6 +2050,7 @@ func Routes() *web.Router {
 					Delete(reqToken(), org.RemoveTeamRepository).
 					Get(reqToken(), org.GetTeamRepo)
 			})
+			m.Get("/children", org.ListTeamChildren)
 			m.Get("/activities/feeds", org.ListTeamActivityFeeds)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryOrganization), orgAssignment(false, true), reqToken(), reqTeamMembership(), checkTokenPublicOnly())
 
@@ -1714,6 +2059,30 @@ func Routes() *web.Router {
 				m.Get("", admin.ListCronTasks)
 				m.Post("/{task}", admin.PostCronTask)
 			})
+			m.Post("/config/reload", admin.ReloadConfig)
+			m.Combo("/maintenance").Get(admin.GetMaintenanceMode).
+				Patch(bind(api.EditMaintenanceModeOption{}), admin.EditMaintenanceMode)
+			m.Group("/announcements", func() {
+				m.Combo("").Get(admin.ListAnnouncements).
+					Post(bind(api.CreateAnnouncementOption{}), admin.CreateAnnouncement)
+				m.Combo("/{id}").Get(admin.GetAnnouncement).
+					Patch(bind(api.EditAnnouncementOption{}), admin.EditAnnouncement).
+					Delete(admin.DeleteAnnouncement)
+			})
+			m.Group("/approvals", func() {
+				m.Get("", admin.ListApprovalRequests)
+				m.Get("/{id}", admin.GetApprovalRequest)
+				m.Post("/{id}/approve", admin.ApproveRequest)
+				m.Post("/{id}/reject", admin.RejectRequest)
+			})
+			m.Group("/terms", func() {
+				m.Combo("").Get(admin.ListTermsDocuments).
+					Post(bind(api.CreateTermsDocumentOption{}), admin.CreateTermsDocument)
+				m.Get("/{id}", admin.GetTermsDocument)
+				m.Get("/{id}/acceptances", admin.ListTermsAcceptances)
+			})
+			m.Combo("/lfs/locks", reqLFSEnabled()).Get(admin.ListLFSLocks).
+				Delete(bind(api.DeleteLFSLocksOption{}), admin.DeleteLFSLocks)
 			m.Get("/orgs", admin.GetAllOrgs)
 			m.Group("/users", func() {
 				m.Get("", admin.SearchUsers)
@@ -1729,9 +2098,14 @@ func Routes() *web.Router {
 					m.Post("/orgs", bind(api.CreateOrgOption{}), admin.CreateOrg)
 					m.Post("/repos", bind(api.CreateRepoOption{}), admin.CreateRepo)
 					m.Post("/rename", bind(api.RenameUserOption{}), admin.RenameUser)
+					m.Post("/offboard", bind(api.OffboardUserOption{}), admin.OffboardUser)
+					m.Post("/anonymize", admin.AnonymizeUser)
 					m.Get("/badges", admin.ListUserBadges)
 					m.Post("/badges", bind(api.UserBadgeOption{}), admin.AddUserBadges)
 					m.Delete("/badges", bind(api.UserBadgeOption{}), admin.DeleteUserBadges)
+					m.Combo("/quota").Get(admin.GetUserQuota).
+						Put(bind(api.SetQuotaOption{}), admin.SetUserQuota).
+						Delete(admin.DeleteUserQuota)
 				}, context.UserAssignmentAPI())
 			})
 			m.Group("/emails", func() {
@@ -1743,6 +2117,14 @@ func Routes() *web.Router {
 				m.Post("/{username}/{reponame}", admin.AdoptRepository)
 				m.Delete("/{username}/{reponame}", admin.DeleteUnadoptedRepository)
 			})
+			m.Group("/indexers", func() {
+				m.Get("", admin.ListIndexers)
+				m.Get("/failures", admin.ListIndexerFailures)
+				m.Group("/repos/{username}/{reponame}", func() {
+					m.Get("", admin.GetRepoIndexerStatus)
+					m.Post("/reindex", admin.ReindexRepository)
+				})
+			})
 			m.Group("/hooks", func() {
 				m.Combo("").Get(admin.ListHooks).
 					Post(bind(api.CreateHookOption{}), admin.CreateHook)
@@ -1754,6 +2136,8 @@ func Routes() *web.Router {
 				m.Group("/runners", func() {
 					m.Get("", admin.ListRunners)
 					m.Post("/registration-token", admin.CreateRegistrationToken)
+					m.Post("/jit-token", bind(api.CreateRunnerJITTokenOption{}), admin.CreateRunnerJITToken)
+					m.Get("/pending-jobs", admin.ListRunnerPendingJobs)
 					m.Get("/{runner_id}", admin.GetRunner)
 					m.Delete("/{runner_id}", admin.DeleteRunner)
 				})
@@ -1765,6 +2149,18 @@ func Routes() *web.Router {
 			})
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqSiteAdmin())
 
+		m.Group("/moderation", func() {
+			m.Get("/reports", moderation.ListReports)
+			m.Group("/reports/{id}", func() {
+				m.Get("", moderation.GetReport)
+				m.Post("/resolve", bind(api.ResolveAbuseReportOption{}), moderation.ResolveReport)
+				m.Post("/unhide", moderation.UnhideContent)
+			})
+			m.Combo("/templates").Get(moderation.ListTemplates).
+				Post(bind(api.CreateResolutionTemplateOption{}), moderation.CreateTemplate)
+			m.Delete("/templates/{id}", moderation.DeleteTemplate)
+		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqModerationEnabled(), reqModerator())
+
 		m.Group("/topics", func() {
 			m.Get("/search", repo.TopicSearch)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository))
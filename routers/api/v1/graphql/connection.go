@@ -0,0 +1,160 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const cursorPrefix = "cursor:"
+
+// connectionArgs are the arguments of all connection fields
+type connectionArgs struct {
+	First *int32
+	After *string
+}
+
+// encodeCursor returns the opaque cursor of the node at the offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if offset, ok := strings.CutPrefix(string(data), cursorPrefix); ok {
+			if n, err := strconv.Atoi(offset); err == nil && n >= 0 {
+				return n, nil
+			}
+		}
+	}
+	return 0, util.NewInvalidArgumentErrorf("invalid cursor %q", cursor)
+}
+
+// getOffsetLimit returns the offset of the first node after the cursor and the number of nodes to load
+func (args connectionArgs) getOffsetLimit() (offset, limit int, err error) {
+	limit = setting.API.DefaultPagingNum
+	if args.First != nil {
+		if *args.First < 0 {
+			return 0, 0, util.NewInvalidArgumentErrorf("first must not be negative")
+		}
+		limit = int(*args.First)
+	}
+	limit = min(limit, setting.API.MaxResponseItems)
+
+	if args.After != nil {
+		after, err := decodeCursor(*args.After)
+		if err != nil {
+			return 0, 0, err
+		}
+		offset = after + 1
+	}
+	return offset, limit, nil
+}
+
+type pageInfoResolver struct {
+	hasNext, hasPrevious bool
+	start, end           *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool     { return r.hasNext }
+func (r *pageInfoResolver) HasPreviousPage() bool { return r.hasPrevious }
+func (r *pageInfoResolver) StartCursor() *string  { return r.start }
+func (r *pageInfoResolver) EndCursor() *string    { return r.end }
+
+type edgeResolver[T any] struct {
+	cursor string
+	node   T
+}
+
+func (r *edgeResolver[T]) Cursor() string { return r.cursor }
+func (r *edgeResolver[T]) Node() T        { return r.node }
+
+// connectionResolver is a page of nodes of a list following the GraphQL Cursor Connections Specification
+type connectionResolver[T any] struct {
+	nodes  []T
+	offset int
+	total  int64
+}
+
+func (r *connectionResolver[T]) Edges() []*edgeResolver[T] {
+	edges := make([]*edgeResolver[T], 0, len(r.nodes))
+	for i, node := range r.nodes {
+		edges = append(edges, &edgeResolver[T]{cursor: encodeCursor(r.offset + i), node: node})
+	}
+	return edges
+}
+
+func (r *connectionResolver[T]) Nodes() []T {
+	return r.nodes
+}
+
+func (r *connectionResolver[T]) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{
+		hasNext:     int64(r.offset+len(r.nodes)) < r.total,
+		hasPrevious: r.offset > 0,
+	}
+	if len(r.nodes) > 0 {
+		start, end := encodeCursor(r.offset), encodeCursor(r.offset+len(r.nodes)-1)
+		info.start, info.end = &start, &end
+	}
+	return info
+}
+
+func (r *connectionResolver[T]) TotalCount() int32 {
+	return int32(r.total)
+}
+
+// newConnection loads the page of nodes requested by the arguments. The models can only be loaded in pages,
+// so if the offset of the cursor isn't aligned to the page size the nodes are taken from two pages.
+// The requested nodes are charged to the budget of the query before they are loaded.
+func newConnection[M, T any](ctx context.Context, args connectionArgs, load func(opts db.ListOptions) ([]M, int64, error), toNode func(M) (T, error)) (*connectionResolver[T], error) {
+	offset, limit, err := args.getOffsetLimit()
+	if err != nil {
+		return nil, err
+	}
+	// a connection loading only the total count still costs a query
+	if err := getViewer(ctx).chargeNodes(max(limit, 1)); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		// only the total count is requested
+		_, total, err := load(db.ListOptions{Page: 1, PageSize: 1})
+		if err != nil {
+			return nil, err
+		}
+		return &connectionResolver[T]{nodes: []T{}, offset: offset, total: total}, nil
+	}
+
+	page, skip := offset/limit+1, offset%limit
+	models, total, err := load(db.ListOptions{Page: page, PageSize: limit})
+	if err != nil {
+		return nil, err
+	}
+	models = models[min(skip, len(models)):]
+	if skip > 0 && len(models) < limit && int64(offset+len(models)) < total {
+		next, _, err := load(db.ListOptions{Page: page + 1, PageSize: limit})
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, next[:min(skip, len(next))]...)
+	}
+
+	nodes := make([]T, 0, len(models))
+	for _, m := range models {
+		node, err := toNode(m)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return &connectionResolver[T]{nodes: nodes, offset: offset, total: total}, nil
+}
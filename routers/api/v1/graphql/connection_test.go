@@ -0,0 +1,110 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema(t *testing.T) {
+	// the resolvers are checked against the schema when it is parsed
+	assert.NotPanics(t, func() { getSchema() })
+}
+
+func TestCursor(t *testing.T) {
+	for _, offset := range []int{0, 1, 49, 12345} {
+		decoded, err := decodeCursor(encodeCursor(offset))
+		require.NoError(t, err)
+		assert.Equal(t, offset, decoded)
+	}
+
+	for _, cursor := range []string{"", "not base64!", "MTIz", encodeCursor(1)[1:]} {
+		_, err := decodeCursor(cursor)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, cursor)
+	}
+}
+
+func TestNewConnection(t *testing.T) {
+	defer test.MockVariableValue(&setting.API.DefaultPagingNum, 3)()
+	defer test.MockVariableValue(&setting.API.MaxResponseItems, 4)()
+
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	load := func(opts db.ListOptions) ([]int, int64, error) {
+		start := min((opts.Page-1)*opts.PageSize, len(items))
+		end := min(start+opts.PageSize, len(items))
+		return items[start:end], int64(len(items)), nil
+	}
+	toNode := func(i int) (int, error) { return i, nil }
+	ptr := func(i int32) *int32 { return &i }
+	after := func(offset int) *string {
+		cursor := encodeCursor(offset)
+		return &cursor
+	}
+
+	cases := []struct {
+		args        connectionArgs
+		nodes       []int
+		hasNext     bool
+		hasPrevious bool
+	}{
+		{args: connectionArgs{}, nodes: []int{0, 1, 2}, hasNext: true},
+		{args: connectionArgs{First: ptr(100)}, nodes: []int{0, 1, 2, 3}, hasNext: true},
+		{args: connectionArgs{First: ptr(0)}, nodes: []int{}, hasNext: true},
+		{args: connectionArgs{First: ptr(3), After: after(2)}, nodes: []int{3, 4, 5}, hasNext: true, hasPrevious: true},
+		{args: connectionArgs{First: ptr(3), After: after(3)}, nodes: []int{4, 5, 6}, hasNext: true, hasPrevious: true},
+		{args: connectionArgs{First: ptr(4), After: after(6)}, nodes: []int{7, 8, 9}, hasPrevious: true},
+		{args: connectionArgs{After: after(9)}, nodes: []int{}, hasPrevious: true},
+	}
+	for _, c := range cases {
+		conn, err := newConnection(t.Context(), c.args, load, toNode)
+		require.NoError(t, err)
+		assert.Equal(t, c.nodes, conn.Nodes())
+		assert.EqualValues(t, len(items), conn.TotalCount())
+
+		info := conn.PageInfo()
+		assert.Equal(t, c.hasNext, info.HasNextPage())
+		assert.Equal(t, c.hasPrevious, info.HasPreviousPage())
+		if len(c.nodes) > 0 {
+			edges := conn.Edges()
+			assert.Equal(t, edges[0].Cursor(), *info.StartCursor())
+			assert.Equal(t, edges[len(edges)-1].Cursor(), *info.EndCursor())
+		} else {
+			assert.Nil(t, info.EndCursor())
+		}
+	}
+
+	_, err := newConnection(t.Context(), connectionArgs{First: ptr(-1)}, load, toNode)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestNewConnectionBudget(t *testing.T) {
+	defer test.MockVariableValue(&setting.API.MaxResponseItems, 50)()
+
+	load := func(opts db.ListOptions) ([]int, int64, error) { return make([]int, opts.PageSize), 1000, nil }
+	toNode := func(i int) (int, error) { return i, nil }
+	first := int32(50)
+
+	// the connections of a query share the budget
+	ctx := context.WithValue(t.Context(), viewerKey, &viewer{})
+	for range maxQueryNodes / 50 {
+		_, err := newConnection(ctx, connectionArgs{First: &first}, load, toNode)
+		require.NoError(t, err)
+	}
+	_, err := newConnection(ctx, connectionArgs{First: &first}, load, toNode)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// every query has its own budget
+	ctx = context.WithValue(t.Context(), viewerKey, &viewer{})
+	_, err = newConnection(ctx, connectionArgs{First: &first}, load, toNode)
+	assert.NoError(t, err)
+}
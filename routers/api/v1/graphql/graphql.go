@@ -0,0 +1,167 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package graphql implements the read-only GraphQL API served at /api/graphql.
+//
+// The resolvers check the permissions of every field they load like the REST API does for the
+// equivalent endpoint, so a query may return partial data with errors for the denied fields.
+package graphql
+
+import (
+	gocontext "context"
+	_ "embed"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+const (
+	maxQueryDepth  = 12
+	maxQueryLength = 64 * 1024
+	// maxQueryNodes is the number of nodes a query may request in total, the nested connections multiply the nodes
+	maxQueryNodes = 5000
+)
+
+var getSchema = sync.OnceValue(func() *graphql.Schema {
+	return graphql.MustParseSchema(schemaString, &queryResolver{},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.MaxQueryLength(maxQueryLength),
+	)
+})
+
+type viewerKeyType struct{}
+
+var viewerKey viewerKeyType
+
+// viewer is the user executing a query and the scope of the token the request was authenticated with
+type viewer struct {
+	Doer       *user_model.User
+	Scope      auth_model.AccessTokenScope
+	IsAPIToken bool
+
+	// requestedNodes counts the nodes requested by the connections of the query, the resolvers run concurrently
+	requestedNodes atomic.Int64
+}
+
+func getViewer(ctx gocontext.Context) *viewer {
+	if v, ok := ctx.Value(viewerKey).(*viewer); ok {
+		return v
+	}
+	return &viewer{}
+}
+
+// requireScope checks if the token has read access to the category. Requests not authenticated by a token can read all categories.
+func (v *viewer) requireScope(category auth_model.AccessTokenScopeCategory) error {
+	if !v.IsAPIToken {
+		return nil
+	}
+	requiredScopes := auth_model.GetRequiredScopes(auth_model.Read, category)
+	allow, err := v.Scope.HasScope(requiredScopes...)
+	if err != nil {
+		return err
+	}
+	if !allow {
+		return util.NewPermissionDeniedErrorf("token does not have at least one of required scope(s), required=%v, token scope=%v", requiredScopes, v.Scope)
+	}
+	return nil
+}
+
+// chargeNodes charges the nodes requested by a connection to the budget of the query
+func (v *viewer) chargeNodes(n int) error {
+	if v.requestedNodes.Add(int64(n)) > maxQueryNodes {
+		return util.NewInvalidArgumentErrorf("the query requests more than %d nodes, request fewer nodes per connection", maxQueryNodes)
+	}
+	return nil
+}
+
+// PublicOnly returns true if the token can only access public resources
+func (v *viewer) PublicOnly() bool {
+	if !v.IsAPIToken {
+		return false
+	}
+	publicOnly, err := v.Scope.PublicOnly()
+	return err != nil || publicOnly
+}
+
+// IsAdmin returns true if the viewer is a site administrator
+func (v *viewer) IsAdmin() bool {
+	return v.Doer != nil && v.Doer.IsAdmin
+}
+
+// needTwoFactorAuth checks if the viewer can't access repositories because two-factor authentication is enforced
+func (v *viewer) needTwoFactorAuth(ctx gocontext.Context) (bool, error) {
+	if !setting.TwoFactorAuthEnforced || v.Doer == nil {
+		return false, nil
+	}
+	has, err := auth_model.HasTwoFactorOrWebAuthn(ctx, v.Doer.ID)
+	if err != nil {
+		return false, err
+	}
+	return !has, nil
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Query executes a GraphQL query passed as JSON body or as "query", "operationName" and "variables" parameters
+func Query(ctx *context.APIContext) {
+	var req request
+	if ctx.Req.Method == http.MethodPost {
+		if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+			ctx.APIError(http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+	} else {
+		req.Query = ctx.FormString("query")
+		req.OperationName = ctx.FormString("operationName")
+		if variables := ctx.FormString("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				ctx.APIError(http.StatusBadRequest, "invalid variables: "+err.Error())
+				return
+			}
+		}
+	}
+	if req.Query == "" {
+		ctx.APIError(http.StatusBadRequest, "query is required")
+		return
+	}
+
+	v := &viewer{Doer: ctx.Doer}
+	if scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope); ok && ctx.Data["IsApiToken"] == true {
+		v.Scope, v.IsAPIToken = scope, true
+	}
+
+	resp := getSchema().Exec(gocontext.WithValue(ctx, viewerKey, v), req.Query, req.OperationName, req.Variables)
+	for _, err := range resp.Errors {
+		// only the errors caused by the query are returned, the details of internal errors are logged
+		if err.ResolverError != nil && !errors.Is(err.ResolverError, util.ErrPermissionDenied) &&
+			!errors.Is(err.ResolverError, util.ErrInvalidArgument) && !errors.Is(err.ResolverError, util.ErrNotExist) {
+			log.Error("GraphQL query %q failed: %v", req.OperationName, err.ResolverError)
+			err.Message = "internal server error"
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SchemaDefinition returns the schema definition language of the GraphQL API
+func SchemaDefinition(ctx *context.APIContext) {
+	ctx.PlainText(http.StatusOK, schemaString)
+}
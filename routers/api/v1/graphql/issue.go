@@ -0,0 +1,255 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+func toTime(t timeutil.TimeStamp) graphql.Time {
+	return graphql.Time{Time: t.AsTime()}
+}
+
+func toOptionalTime(t timeutil.TimeStamp) *graphql.Time {
+	if t == 0 {
+		return nil
+	}
+	gt := toTime(t)
+	return &gt
+}
+
+func issueState(isClosed bool) string {
+	if isClosed {
+		return "CLOSED"
+	}
+	return "OPEN"
+}
+
+// issueResolver resolves the fields issues and pull requests have in common
+type issueResolver struct {
+	issue *issues_model.Issue
+	repo  *repositoryResolver
+}
+
+func (r *issueResolver) ID() graphql.ID          { return graphql.ID(strconv.FormatInt(r.issue.ID, 10)) }
+func (r *issueResolver) DatabaseID() int32       { return int32(r.issue.ID) }
+func (r *issueResolver) Number() int32           { return int32(r.issue.Index) }
+func (r *issueResolver) Title() string           { return r.issue.Title }
+func (r *issueResolver) Body() string            { return r.issue.Content }
+func (r *issueResolver) State() string           { return issueState(r.issue.IsClosed) }
+func (r *issueResolver) IsLocked() bool          { return r.issue.IsLocked }
+func (r *issueResolver) CreatedAt() graphql.Time { return toTime(r.issue.CreatedUnix) }
+func (r *issueResolver) UpdatedAt() graphql.Time { return toTime(r.issue.UpdatedUnix) }
+
+func (r *issueResolver) ClosedAt() *graphql.Time {
+	if !r.issue.IsClosed {
+		return nil
+	}
+	return toOptionalTime(r.issue.ClosedUnix)
+}
+
+func (r *issueResolver) URL(ctx context.Context) string {
+	return r.issue.HTMLURL(ctx)
+}
+
+func (r *issueResolver) Repository() *repositoryResolver {
+	return r.repo
+}
+
+func (r *issueResolver) Author(ctx context.Context) (*userResolver, error) {
+	if err := r.issue.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+	return newUserResolver(r.issue.Poster), nil
+}
+
+func (r *issueResolver) Labels(ctx context.Context) ([]*labelResolver, error) {
+	if err := r.issue.LoadLabels(ctx); err != nil {
+		return nil, err
+	}
+	labels := make([]*labelResolver, 0, len(r.issue.Labels))
+	for _, label := range r.issue.Labels {
+		labels = append(labels, &labelResolver{label: label})
+	}
+	return labels, nil
+}
+
+func (r *issueResolver) Assignees(ctx context.Context) ([]*userResolver, error) {
+	if err := r.issue.LoadAssignees(ctx); err != nil {
+		return nil, err
+	}
+	assignees := make([]*userResolver, 0, len(r.issue.Assignees))
+	for _, assignee := range r.issue.Assignees {
+		assignees = append(assignees, newUserResolver(assignee))
+	}
+	return assignees, nil
+}
+
+func (r *issueResolver) Comments(ctx context.Context, args connectionArgs) (*connectionResolver[*commentResolver], error) {
+	return listComments(ctx, args, &issues_model.FindCommentsOptions{
+		IssueID:       r.issue.ID,
+		Type:          issues_model.CommentTypeComment,
+		HideModerated: r.repo.hideModerated(ctx),
+	})
+}
+
+func listComments(ctx context.Context, args connectionArgs, opts *issues_model.FindCommentsOptions) (*connectionResolver[*commentResolver], error) {
+	return newConnection(ctx, args, func(listOpts db.ListOptions) ([]*issues_model.Comment, int64, error) {
+		opts.ListOptions = listOpts
+		comments, err := issues_model.FindComments(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		count, err := issues_model.CountComments(ctx, opts)
+		return comments, count, err
+	}, func(comment *issues_model.Comment) (*commentResolver, error) {
+		return &commentResolver{comment: comment}, nil
+	})
+}
+
+type pullRequestResolver struct {
+	*issueResolver
+	pr *issues_model.PullRequest
+}
+
+func newPullRequestResolver(ctx context.Context, issue *issueResolver) (*pullRequestResolver, error) {
+	if err := issue.issue.LoadPullRequest(ctx); err != nil {
+		return nil, err
+	}
+	return &pullRequestResolver{issueResolver: issue, pr: issue.issue.PullRequest}, nil
+}
+
+func (r *pullRequestResolver) HeadRefName() string { return r.pr.HeadBranch }
+func (r *pullRequestResolver) BaseRefName() string { return r.pr.BaseBranch }
+func (r *pullRequestResolver) Merged() bool        { return r.pr.HasMerged }
+
+func (r *pullRequestResolver) IsDraft(ctx context.Context) bool {
+	return r.pr.IsWorkInProgress(ctx)
+}
+
+func (r *pullRequestResolver) Mergeable(ctx context.Context) bool {
+	return r.pr.Mergeable(ctx)
+}
+
+func (r *pullRequestResolver) MergedAt() *graphql.Time {
+	if !r.pr.HasMerged {
+		return nil
+	}
+	return toOptionalTime(r.pr.MergedUnix)
+}
+
+func (r *pullRequestResolver) MergedBy(ctx context.Context) (*userResolver, error) {
+	if !r.pr.HasMerged || r.pr.MergerID == 0 {
+		return nil, nil
+	}
+	merger, err := user_model.GetPossibleUserByID(ctx, r.pr.MergerID)
+	if err != nil {
+		return nil, err
+	}
+	return newUserResolver(merger), nil
+}
+
+func (r *pullRequestResolver) MergeCommitSha() *string {
+	if !r.pr.HasMerged || r.pr.MergedCommitID == "" {
+		return nil
+	}
+	return &r.pr.MergedCommitID
+}
+
+// Reviews lists the submitted reviews, the pending reviews are drafts which are only visible to their authors
+func (r *pullRequestResolver) Reviews(ctx context.Context, args connectionArgs) (*connectionResolver[*reviewResolver], error) {
+	opts := issues_model.FindReviewOptions{
+		IssueID: r.issue.ID,
+		Types:   []issues_model.ReviewType{issues_model.ReviewTypeApprove, issues_model.ReviewTypeComment, issues_model.ReviewTypeReject, issues_model.ReviewTypeRequest},
+	}
+	return newConnection(ctx, args, func(listOpts db.ListOptions) ([]*issues_model.Review, int64, error) {
+		opts.ListOptions = listOpts
+		reviews, err := issues_model.FindReviews(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		count, err := issues_model.CountReviews(ctx, opts)
+		return reviews, count, err
+	}, func(review *issues_model.Review) (*reviewResolver, error) {
+		return &reviewResolver{review: review, repo: r.repo}, nil
+	})
+}
+
+type commentResolver struct {
+	comment *issues_model.Comment
+}
+
+func (r *commentResolver) ID() graphql.ID          { return graphql.ID(strconv.FormatInt(r.comment.ID, 10)) }
+func (r *commentResolver) DatabaseID() int32       { return int32(r.comment.ID) }
+func (r *commentResolver) Body() string            { return r.comment.Content }
+func (r *commentResolver) CreatedAt() graphql.Time { return toTime(r.comment.CreatedUnix) }
+func (r *commentResolver) UpdatedAt() graphql.Time { return toTime(r.comment.UpdatedUnix) }
+
+func (r *commentResolver) Author(ctx context.Context) (*userResolver, error) {
+	if err := r.comment.LoadPoster(ctx); err != nil {
+		return nil, err
+	}
+	return newUserResolver(r.comment.Poster), nil
+}
+
+var reviewStates = map[issues_model.ReviewType]string{
+	issues_model.ReviewTypePending: "PENDING",
+	issues_model.ReviewTypeComment: "COMMENTED",
+	issues_model.ReviewTypeApprove: "APPROVED",
+	issues_model.ReviewTypeReject:  "CHANGES_REQUESTED",
+	issues_model.ReviewTypeRequest: "REVIEW_REQUESTED",
+}
+
+type reviewResolver struct {
+	review *issues_model.Review
+	repo   *repositoryResolver
+}
+
+func (r *reviewResolver) ID() graphql.ID            { return graphql.ID(strconv.FormatInt(r.review.ID, 10)) }
+func (r *reviewResolver) DatabaseID() int32         { return int32(r.review.ID) }
+func (r *reviewResolver) State() string             { return reviewStates[r.review.Type] }
+func (r *reviewResolver) Body() string              { return r.review.Content }
+func (r *reviewResolver) CommitSha() string         { return r.review.CommitID }
+func (r *reviewResolver) IsOfficial() bool          { return r.review.Official }
+func (r *reviewResolver) IsStale() bool             { return r.review.Stale }
+func (r *reviewResolver) IsDismissed() bool         { return r.review.Dismissed }
+func (r *reviewResolver) SubmittedAt() graphql.Time { return toTime(r.review.UpdatedUnix) }
+
+func (r *reviewResolver) Author(ctx context.Context) (*userResolver, error) {
+	if r.review.ReviewerID <= 0 {
+		// requests for teams and reviews migrated from other platforms have no reviewer
+		return nil, nil
+	}
+	if err := r.review.LoadReviewer(ctx); err != nil {
+		return nil, err
+	}
+	return newUserResolver(r.review.Reviewer), nil
+}
+
+func (r *reviewResolver) Comments(ctx context.Context, args connectionArgs) (*connectionResolver[*commentResolver], error) {
+	return listComments(ctx, args, &issues_model.FindCommentsOptions{
+		ReviewID:      r.review.ID,
+		Type:          issues_model.CommentTypeCode,
+		HideModerated: r.repo.hideModerated(ctx),
+	})
+}
+
+type labelResolver struct {
+	label *issues_model.Label
+}
+
+func (r *labelResolver) ID() graphql.ID      { return graphql.ID(strconv.FormatInt(r.label.ID, 10)) }
+func (r *labelResolver) DatabaseID() int32   { return int32(r.label.ID) }
+func (r *labelResolver) Name() string        { return r.label.Name }
+func (r *labelResolver) Color() string       { return r.label.Color }
+func (r *labelResolver) Description() string { return r.label.Description }
+func (r *labelResolver) IsExclusive() bool   { return r.label.Exclusive }
@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"context"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/structs"
+)
+
+// queryResolver resolves the root fields, the objects the viewer can't see are null like they don't exist
+type queryResolver struct{}
+
+func (q *queryResolver) Viewer(ctx context.Context) (*userResolver, error) {
+	v := getViewer(ctx)
+	if v.Doer == nil {
+		return nil, nil
+	}
+	if err := v.requireScope(auth_model.AccessTokenScopeCategoryUser); err != nil {
+		return nil, err
+	}
+	return newUserResolver(v.Doer), nil
+}
+
+func (q *queryResolver) User(ctx context.Context, args struct{ Login string }) (*userResolver, error) {
+	if err := getViewer(ctx).requireScope(auth_model.AccessTokenScopeCategoryUser); err != nil {
+		return nil, err
+	}
+	u, err := user_model.GetUserByName(ctx, args.Login)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !isUserVisible(ctx, u) {
+		return nil, nil
+	}
+	return newUserResolver(u), nil
+}
+
+func (q *queryResolver) Organization(ctx context.Context, args struct{ Login string }) (*organizationResolver, error) {
+	v := getViewer(ctx)
+	if err := v.requireScope(auth_model.AccessTokenScopeCategoryOrganization); err != nil {
+		return nil, err
+	}
+	org, err := organization.GetOrgByName(ctx, args.Login)
+	if err != nil {
+		if organization.IsErrOrgNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if v.PublicOnly() && org.Visibility != structs.VisibleTypePublic {
+		return nil, nil
+	}
+	if !organization.HasOrgOrUserVisible(ctx, org.AsUser(), v.Doer) {
+		return nil, nil
+	}
+	return &organizationResolver{org: org}, nil
+}
+
+func (q *queryResolver) Repository(ctx context.Context, args struct{ Owner, Name string }) (*repositoryResolver, error) {
+	if err := getViewer(ctx).requireScope(auth_model.AccessTokenScopeCategoryRepository); err != nil {
+		return nil, err
+	}
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, args.Owner, args.Name)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	r, err := newRepositoryResolver(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if !r.isVisible() {
+		return nil, nil
+	}
+	return r, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type repositoryResolver struct {
+	repo *repo_model.Repository
+	perm access_model.Permission
+}
+
+// newRepositoryResolver loads the permission of the viewer for the repository
+func newRepositoryResolver(ctx context.Context, repo *repo_model.Repository) (*repositoryResolver, error) {
+	v := getViewer(ctx)
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+
+	perm := access_model.PermissionNoAccess()
+	if !v.PublicOnly() || !repo.IsPrivate {
+		needTwoFactor, err := v.needTwoFactorAuth(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !needTwoFactor {
+			perm, err = access_model.GetUserRepoPermission(ctx, repo, v.Doer)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &repositoryResolver{repo: repo, perm: perm}, nil
+}
+
+// isVisible checks if the viewer can see the repository
+func (r *repositoryResolver) isVisible() bool {
+	return r.perm.HasAnyUnitAccessOrPublicAccess()
+}
+
+// hideModerated checks if the issues and the comments hidden by a moderator are left out for the viewer
+func (r *repositoryResolver) hideModerated(ctx context.Context) bool {
+	return !moderation_service.CanSeeHiddenContent(getViewer(ctx).Doer, &r.perm)
+}
+
+// requireUnit checks if the viewer can read the unit of the repository and the token has the scope for it
+func (r *repositoryResolver) requireUnit(ctx context.Context, unitType unit.Type, category auth_model.AccessTokenScopeCategory) error {
+	if err := getViewer(ctx).requireScope(category); err != nil {
+		return err
+	}
+	if !r.perm.CanRead(unitType) {
+		return util.NewPermissionDeniedErrorf("no permission to read the %s of %s", strings.TrimPrefix(unit.Units[unitType].URI, "/"), r.repo.FullName())
+	}
+	return nil
+}
+
+func (r *repositoryResolver) ID() graphql.ID        { return graphql.ID(strconv.FormatInt(r.repo.ID, 10)) }
+func (r *repositoryResolver) DatabaseID() int32     { return int32(r.repo.ID) }
+func (r *repositoryResolver) Name() string          { return r.repo.Name }
+func (r *repositoryResolver) FullName() string      { return r.repo.FullName() }
+func (r *repositoryResolver) Description() string   { return r.repo.Description }
+func (r *repositoryResolver) Owner() *userResolver  { return newUserResolver(r.repo.Owner) }
+func (r *repositoryResolver) IsPrivate() bool       { return r.repo.IsPrivate }
+func (r *repositoryResolver) IsFork() bool          { return r.repo.IsFork }
+func (r *repositoryResolver) IsArchived() bool      { return r.repo.IsArchived }
+func (r *repositoryResolver) IsMirror() bool        { return r.repo.IsMirror }
+func (r *repositoryResolver) DefaultBranch() string { return r.repo.DefaultBranch }
+func (r *repositoryResolver) StarCount() int32      { return int32(r.repo.NumStars) }
+func (r *repositoryResolver) ForkCount() int32      { return int32(r.repo.NumForks) }
+
+func (r *repositoryResolver) URL(ctx context.Context) string {
+	return r.repo.HTMLURL(ctx)
+}
+
+func (r *repositoryResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.repo.CreatedUnix.AsTime()}
+}
+
+func (r *repositoryResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.repo.UpdatedUnix.AsTime()}
+}
+
+// getIssueByNumber returns nil if the repository has no issue or pull request with the number
+func (r *repositoryResolver) getIssueByNumber(ctx context.Context, number int32, isPull bool) (*issues_model.Issue, error) {
+	issue, err := issues_model.GetIssueByIndex(ctx, r.repo.ID, int64(number))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if issue.IsPull != isPull || (issue.IsHidden && r.hideModerated(ctx)) {
+		return nil, nil
+	}
+	issue.Repo = r.repo
+	return issue, nil
+}
+
+// listIssues lists the issues or pull requests of the repository from the oldest to the newest
+func (r *repositoryResolver) listIssues(ctx context.Context, args issuesArgs, isPull bool) (*connectionResolver[*issueResolver], error) {
+	opts := &issues_model.IssuesOptions{
+		HideModerated: r.hideModerated(ctx),
+		RepoIDs:       []int64{r.repo.ID},
+		IsPull:        optional.Some(isPull),
+		IsClosed:      args.isClosed(),
+		SortType:      "oldest",
+	}
+	return newConnection(ctx, args.connectionArgs, func(listOpts db.ListOptions) ([]*issues_model.Issue, int64, error) {
+		opts.Paginator = &listOpts
+		issues, err := issues_model.Issues(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		count, err := issues_model.CountIssues(ctx, opts)
+		return issues, count, err
+	}, func(issue *issues_model.Issue) (*issueResolver, error) {
+		issue.Repo = r.repo
+		return &issueResolver{issue: issue, repo: r}, nil
+	})
+}
+
+type issuesArgs struct {
+	connectionArgs
+	States *[]string
+}
+
+func (args issuesArgs) isClosed() optional.Option[bool] {
+	if args.States == nil || len(*args.States) != 1 {
+		return optional.None[bool]()
+	}
+	return optional.Some((*args.States)[0] == "CLOSED")
+}
+
+func (r *repositoryResolver) Issue(ctx context.Context, args struct{ Number int32 }) (*issueResolver, error) {
+	if err := r.requireUnit(ctx, unit.TypeIssues, auth_model.AccessTokenScopeCategoryIssue); err != nil {
+		return nil, err
+	}
+	issue, err := r.getIssueByNumber(ctx, args.Number, false)
+	if issue == nil || err != nil {
+		return nil, err
+	}
+	return &issueResolver{issue: issue, repo: r}, nil
+}
+
+func (r *repositoryResolver) Issues(ctx context.Context, args issuesArgs) (*connectionResolver[*issueResolver], error) {
+	if err := r.requireUnit(ctx, unit.TypeIssues, auth_model.AccessTokenScopeCategoryIssue); err != nil {
+		return nil, err
+	}
+	return r.listIssues(ctx, args, false)
+}
+
+func (r *repositoryResolver) PullRequest(ctx context.Context, args struct{ Number int32 }) (*pullRequestResolver, error) {
+	if err := r.requireUnit(ctx, unit.TypePullRequests, auth_model.AccessTokenScopeCategoryRepository); err != nil {
+		return nil, err
+	}
+	issue, err := r.getIssueByNumber(ctx, args.Number, true)
+	if issue == nil || err != nil {
+		return nil, err
+	}
+	return newPullRequestResolver(ctx, &issueResolver{issue: issue, repo: r})
+}
+
+func (r *repositoryResolver) PullRequests(ctx context.Context, args issuesArgs) (*connectionResolver[*pullRequestResolver], error) {
+	if err := r.requireUnit(ctx, unit.TypePullRequests, auth_model.AccessTokenScopeCategoryRepository); err != nil {
+		return nil, err
+	}
+	issues, err := r.listIssues(ctx, args, true)
+	if err != nil {
+		return nil, err
+	}
+	prs := &connectionResolver[*pullRequestResolver]{nodes: make([]*pullRequestResolver, 0, len(issues.nodes)), offset: issues.offset, total: issues.total}
+	for _, issue := range issues.nodes {
+		pr, err := newPullRequestResolver(ctx, issue)
+		if err != nil {
+			return nil, err
+		}
+		prs.nodes = append(prs.nodes, pr)
+	}
+	return prs, nil
+}
+
+func (r *repositoryResolver) Labels(ctx context.Context, args connectionArgs) (*connectionResolver[*labelResolver], error) {
+	if err := r.requireUnit(ctx, unit.TypeIssues, auth_model.AccessTokenScopeCategoryIssue); err != nil {
+		if r.requireUnit(ctx, unit.TypePullRequests, auth_model.AccessTokenScopeCategoryRepository) != nil {
+			return nil, err
+		}
+	}
+	return newConnection(ctx, args, func(opts db.ListOptions) ([]*issues_model.Label, int64, error) {
+		labels, err := issues_model.GetLabelsByRepoID(ctx, r.repo.ID, "", opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		count, err := issues_model.CountLabelsByRepoID(ctx, r.repo.ID)
+		return labels, count, err
+	}, func(label *issues_model.Label) (*labelResolver, error) {
+		return &labelResolver{label: label}, nil
+	})
+}
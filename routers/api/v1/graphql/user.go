@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/structs"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// isUserVisible checks if the viewer can see the user or organization
+func isUserVisible(ctx context.Context, u *user_model.User) bool {
+	v := getViewer(ctx)
+	if v.PublicOnly() && u.Visibility != structs.VisibleTypePublic {
+		return false
+	}
+	return user_model.IsUserVisibleToViewer(ctx, u, v.Doer)
+}
+
+type userResolver struct {
+	u *user_model.User
+}
+
+func newUserResolver(u *user_model.User) *userResolver {
+	if u == nil {
+		return nil
+	}
+	return &userResolver{u: u}
+}
+
+func (r *userResolver) ID() graphql.ID       { return graphql.ID(strconv.FormatInt(r.u.ID, 10)) }
+func (r *userResolver) DatabaseID() int32    { return int32(r.u.ID) }
+func (r *userResolver) Login() string        { return r.u.Name }
+func (r *userResolver) FullName() string     { return r.u.FullName }
+func (r *userResolver) IsOrganization() bool { return r.u.IsOrganization() }
+
+func (r *userResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.u.CreatedUnix.AsTime()}
+}
+
+func (r *userResolver) AvatarURL(ctx context.Context) string {
+	return r.u.AvatarLink(ctx)
+}
+
+func (r *userResolver) URL(ctx context.Context) string {
+	return r.u.HTMLURL(ctx)
+}
+
+// Email returns the email like the REST API: only signed-in viewers can see public emails
+func (r *userResolver) Email(ctx context.Context) *string {
+	v := getViewer(ctx)
+	if v.Doer == nil || (r.u.KeepEmailPrivate && v.Doer.ID != r.u.ID && !v.Doer.IsAdmin) {
+		return nil
+	}
+	return &r.u.Email
+}
+
+func (r *userResolver) Repositories(ctx context.Context, args connectionArgs) (*connectionResolver[*repositoryResolver], error) {
+	return listOwnerRepositories(ctx, r.u, args)
+}
+
+// listOwnerRepositories lists the repositories owned by the user or organization the viewer has access to
+func listOwnerRepositories(ctx context.Context, owner *user_model.User, args connectionArgs) (*connectionResolver[*repositoryResolver], error) {
+	v := getViewer(ctx)
+	if err := v.requireScope(auth_model.AccessTokenScopeCategoryRepository); err != nil {
+		return nil, err
+	}
+	return newConnection(ctx, args, func(opts db.ListOptions) ([]*repo_model.Repository, int64, error) {
+		return repo_model.SearchRepository(ctx, repo_model.SearchRepoOptions{
+			ListOptions: opts,
+			Actor:       v.Doer,
+			OwnerID:     owner.ID,
+			Private:     v.Doer != nil && !v.PublicOnly(),
+			Collaborate: optional.Some(false),
+			OrderBy:     db.SearchOrderByID,
+		})
+	}, func(repo *repo_model.Repository) (*repositoryResolver, error) {
+		repo.Owner = owner
+		return newRepositoryResolver(ctx, repo)
+	})
+}
+
+type organizationResolver struct {
+	org *organization.Organization
+}
+
+func (r *organizationResolver) ID() graphql.ID      { return graphql.ID(strconv.FormatInt(r.org.ID, 10)) }
+func (r *organizationResolver) DatabaseID() int32   { return int32(r.org.ID) }
+func (r *organizationResolver) Login() string       { return r.org.Name }
+func (r *organizationResolver) FullName() string    { return r.org.FullName }
+func (r *organizationResolver) Description() string { return r.org.Description }
+func (r *organizationResolver) Website() string     { return r.org.Website }
+func (r *organizationResolver) Location() string    { return r.org.Location }
+
+func (r *organizationResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.org.CreatedUnix.AsTime()}
+}
+
+func (r *organizationResolver) AvatarURL(ctx context.Context) string {
+	return r.org.AsUser().AvatarLink(ctx)
+}
+
+func (r *organizationResolver) URL(ctx context.Context) string {
+	return r.org.HTMLURL(ctx)
+}
+
+func (r *organizationResolver) Repositories(ctx context.Context, args connectionArgs) (*connectionResolver[*repositoryResolver], error) {
+	return listOwnerRepositories(ctx, r.org.AsUser(), args)
+}
+
+// Members lists the members of the organization, like the REST API only the public members unless the viewer is a member
+func (r *organizationResolver) Members(ctx context.Context, args connectionArgs) (*connectionResolver[*userResolver], error) {
+	v := getViewer(ctx)
+	if err := v.requireScope(auth_model.AccessTokenScopeCategoryOrganization); err != nil {
+		return nil, err
+	}
+	opts := &organization.FindOrgMembersOpts{OrgID: r.org.ID}
+	if v.Doer != nil && !v.PublicOnly() {
+		opts.Doer = v.Doer
+		isMember, err := r.org.IsOrgMember(ctx, v.Doer.ID)
+		if err != nil {
+			return nil, err
+		}
+		opts.IsDoerMember = isMember
+	}
+	return newConnection(ctx, args, func(listOpts db.ListOptions) ([]*user_model.User, int64, error) {
+		opts.ListOptions = listOpts
+		members, _, err := organization.FindOrgMembers(ctx, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		count, err := organization.CountOrgMembers(ctx, opts)
+		return members, count, err
+	}, func(u *user_model.User) (*userResolver, error) {
+		return newUserResolver(u), nil
+	})
+}
@@ -0,0 +1,378 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+func getReport(ctx *context.APIContext) *moderation_model.AbuseReport {
+	r, err := moderation_model.GetAbuseReportByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return r
+}
+
+func respondReport(ctx *context.APIContext, r *moderation_model.AbuseReport) {
+	apiReport, err := convert.ToAbuseReport(ctx, r, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiReport)
+}
+
+// ListReports lists the abuse reports
+func ListReports(ctx *context.APIContext) {
+	// swagger:operation GET /moderation/reports moderation moderationListReports
+	// ---
+	// summary: List the abuse reports of the moderation queue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: status
+	//   in: query
+	//   description: status of the reports, all reports are returned if it is empty
+	//   type: string
+	//   enum: [open, resolved]
+	// - name: type
+	//   in: query
+	//   description: type of the reported content
+	//   type: string
+	//   enum: [user, repo, issue, comment]
+	// - name: owner
+	//   in: query
+	//   description: username of the reported user or the owner of the reported content
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	opts := moderation_model.FindAbuseReportsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		Status:      moderation_model.ReportStatus(ctx.FormString("status")),
+		ContentType: moderation_model.ReportContentType(ctx.FormString("type")),
+	}
+	if opts.Status != "" && !opts.Status.IsValid() {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid status")
+		return
+	}
+	if opts.ContentType != "" && !opts.ContentType.IsValid() {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid type")
+		return
+	}
+	if ownerName := ctx.FormString("owner"); ownerName != "" {
+		owner, err := user_model.GetUserByName(ctx, ownerName)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		opts.OwnerID = owner.ID
+	}
+
+	reports, count, err := db.FindAndCount[moderation_model.AbuseReport](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiReports := make([]*api.AbuseReport, 0, len(reports))
+	for _, r := range reports {
+		apiReport, err := convert.ToAbuseReport(ctx, r, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiReports = append(apiReports, apiReport)
+	}
+
+	ctx.SetLinkHeader(int(count), opts.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiReports)
+}
+
+// GetReport gets an abuse report
+func GetReport(ctx *context.APIContext) {
+	// swagger:operation GET /moderation/reports/{id} moderation moderationGetReport
+	// ---
+	// summary: Get an abuse report
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	r := getReport(ctx)
+	if ctx.Written() {
+		return
+	}
+	respondReport(ctx, r)
+}
+
+// ResolveReport takes an action about the reported content and resolves the open reports of the content
+func ResolveReport(ctx *context.APIContext) {
+	// swagger:operation POST /moderation/reports/{id}/resolve moderation moderationResolveReport
+	// ---
+	// summary: Take an action about the reported content and resolve all open reports of the content
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ResolveAbuseReportOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.ResolveAbuseReportOption)
+	r := getReport(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var err error
+	if form.TemplateID > 0 {
+		err = moderation_service.ResolveReportWithTemplate(ctx, ctx.Doer, r, form.TemplateID)
+	} else {
+		err = moderation_service.ResolveReport(ctx, ctx.Doer, r, moderation_model.ModerationAction(form.Action), form.Message)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument), errors.Is(err, util.ErrNotExist):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if r, err = moderation_model.GetAbuseReportByID(ctx, r.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	respondReport(ctx, r)
+}
+
+// UnhideContent unhides the content hidden by the resolution of the report
+func UnhideContent(ctx *context.APIContext) {
+	// swagger:operation POST /moderation/reports/{id}/unhide moderation moderationUnhideContent
+	// ---
+	// summary: Unhide the content hidden by the resolution of the report
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AbuseReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	r := getReport(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := moderation_service.UnhideContent(ctx, ctx.Doer, r); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	respondReport(ctx, r)
+}
+
+// ListTemplates lists the resolution templates
+func ListTemplates(ctx *context.APIContext) {
+	// swagger:operation GET /moderation/templates moderation moderationListTemplates
+	// ---
+	// summary: List the resolution templates
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ResolutionTemplateList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	listOptions := utils.GetListOptions(ctx)
+	resolutionTemplates, count, err := db.FindAndCount[moderation_model.ResolutionTemplate](ctx, moderation_model.FindResolutionTemplatesOptions{
+		ListOptions: listOptions,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiTemplates := make([]*api.ResolutionTemplate, 0, len(resolutionTemplates))
+	for _, t := range resolutionTemplates {
+		apiTemplates = append(apiTemplates, convert.ToResolutionTemplate(t))
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiTemplates)
+}
+
+// CreateTemplate adds a resolution template
+func CreateTemplate(ctx *context.APIContext) {
+	// swagger:operation POST /moderation/templates moderation moderationCreateTemplate
+	// ---
+	// summary: Add a resolution template
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateResolutionTemplateOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ResolutionTemplate"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.CreateResolutionTemplateOption)
+	t := &moderation_model.ResolutionTemplate{
+		Name:    strings.TrimSpace(form.Name),
+		Action:  moderation_model.ModerationAction(form.Action),
+		Message: strings.TrimSpace(form.Message),
+	}
+	if err := moderation_model.CreateResolutionTemplate(ctx, t); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToResolutionTemplate(t))
+}
+
+// DeleteTemplate deletes a resolution template
+func DeleteTemplate(ctx *context.APIContext) {
+	// swagger:operation DELETE /moderation/templates/{id} moderation moderationDeleteTemplate
+	// ---
+	// summary: Delete a resolution template
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the template
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	if err := moderation_model.DeleteResolutionTemplateByID(ctx, ctx.PathParamInt64("id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
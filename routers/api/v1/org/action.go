@@ -63,6 +63,7 @@ func (Action) ListActionsSecrets(ctx *context.APIContext) {
 		apiSecrets[k] = &api.Secret{
 			Name:        v.Name,
 			Description: v.Description,
+			Provider:    v.Provider,
 			Created:     v.CreatedUnix.AsTime(),
 		}
 	}
@@ -107,7 +108,7 @@ func (Action) CreateOrUpdateSecret(ctx *context.APIContext) {
 
 	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
 
-	_, created, err := secret_service.CreateOrUpdateSecret(ctx, ctx.Org.Organization.ID, 0, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	_, created, err := secret_service.CreateOrUpdateSecret(ctx, ctx.Org.Organization.ID, 0, ctx.PathParam("secretname"), opt.Provider, opt.Data, opt.Description)
 	if err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
@@ -211,6 +212,54 @@ func (Action) CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, ctx.Org.Organization.ID, 0)
 }
 
+// CreateRunnerJITToken creates a just-in-time token to register an ephemeral organization runner
+func (Action) CreateRunnerJITToken(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/actions/runners/jit-token organization orgCreateRunnerJITToken
+	// ---
+	// summary: Create a single-use registration token for an ephemeral organization runner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRunnerJITTokenOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/RunnerJITToken"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateRunnerJITToken(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// ListRunnerPendingJobs lists the number of jobs waiting for organization runners by their labels
+func (Action) ListRunnerPendingJobs(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runners/pending-jobs organization orgListRunnerPendingJobs
+	// ---
+	// summary: List the number of jobs waiting for organization runners by their labels
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RunnerPendingJobsList"
+
+	shared.ListRunnerPendingJobs(ctx, ctx.Org.Organization.ID, 0)
+}
+
 // ListVariables list org-level variables
 func (Action) ListVariables(ctx *context.APIContext) {
 	// swagger:operation GET /orgs/{org}/actions/variables organization getOrgVariablesList
@@ -570,6 +619,102 @@ func (Action) DeleteRunner(ctx *context.APIContext) {
 	shared.DeleteRunner(ctx, ctx.Org.Organization.ID, 0, ctx.PathParamInt64("runner_id"))
 }
 
+// GetArtifactRetention returns the organization's artifact retention policy
+func (Action) GetArtifactRetention(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/artifact-retention organization orgGetArtifactRetention
+	// ---
+	// summary: Get the organization's artifact retention policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionArtifactRetention"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetArtifactRetention(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// UpdateArtifactRetention sets the organization's artifact retention policy
+func (Action) UpdateArtifactRetention(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/actions/artifact-retention organization orgUpdateArtifactRetention
+	// ---
+	// summary: Set the organization's artifact retention policy
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionArtifactRetentionOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionArtifactRetention"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.UpdateArtifactRetention(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// DeleteArtifactRetention deletes the organization's artifact retention policy
+func (Action) DeleteArtifactRetention(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/artifact-retention organization orgDeleteArtifactRetention
+	// ---
+	// summary: Delete the organization's artifact retention policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: artifact retention policy deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteArtifactRetention(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// GetArtifactUsage returns the storage consumed by the organization's artifacts
+func (Action) GetArtifactUsage(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/artifact-usage organization orgGetArtifactUsage
+	// ---
+	// summary: Get the storage consumed by the organization's artifacts
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionArtifactUsage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetArtifactUsage(ctx, ctx.Org.Organization.ID, 0)
+}
+
 func (Action) ListWorkflowJobs(ctx *context.APIContext) {
 	// swagger:operation GET /orgs/{org}/actions/jobs organization getOrgWorkflowJobs
 	// ---
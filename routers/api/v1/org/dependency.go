@@ -0,0 +1,111 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/sbom"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListDependents lists the repositories of an organization which depend on a package
+func ListDependents(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/dependents organization orgListDependents
+	// ---
+	// summary: List the repositories of an organization whose default branch depends on a package
+	// description: Each dependency on the package is listed with its repository, a repository depending on several versions is listed several times.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: ecosystem
+	//   in: query
+	//   description: ecosystem of the package
+	//   type: string
+	//   enum: [golang, npm, pypi, cargo, maven]
+	//   required: true
+	// - name: name
+	//   in: query
+	//   description: name of the package, the name of a Maven package is "groupId:artifactId"
+	//   type: string
+	//   required: true
+	// - name: direct
+	//   in: query
+	//   description: only list the repositories which declare the package in a manifest
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DependentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	ecosystem, name := ctx.FormTrim("ecosystem"), ctx.FormTrim("name")
+	if ecosystem == "" || name == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, errors.New("ecosystem and name are required"))
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	deps, count, err := db.FindAndCount[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: listOptions,
+		RepoCond:    orgRepoCond(ctx, unit.TypeCode),
+		Ecosystem:   ecosystem,
+		LowerName:   strings.ToLower(sbom.NormalizeName(sbom.Ecosystem(ecosystem), name)),
+		DirectOnly:  ctx.FormBool("direct"),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := repo_model.RepoDependencyList(deps).LoadRepos(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRepos := make(map[int64]*api.Repository)
+	dependents := make([]*api.Dependent, 0, len(deps))
+	for _, dep := range deps {
+		if dep.Repo == nil {
+			continue
+		}
+		apiRepo, ok := apiRepos[dep.RepoID]
+		if !ok {
+			permission, err := access_model.GetUserRepoPermission(ctx, dep.Repo, ctx.Doer)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			apiRepo = convert.ToRepo(ctx, dep.Repo, permission)
+			apiRepos[dep.RepoID] = apiRepo
+		}
+		dependents = append(dependents, &api.Dependent{Repository: apiRepo, Dependency: convert.ToRepoDependency(dep)})
+	}
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, dependents)
+}
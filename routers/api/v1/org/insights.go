@@ -0,0 +1,210 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"xorm.io/builder"
+)
+
+// orgRepoCond returns the condition of the repositories of the organization whose unit is accessible by the doer
+func orgRepoCond(ctx *context.APIContext, unitType unit.Type) builder.Cond {
+	cond := builder.And(
+		builder.Eq{"`repository`.owner_id": ctx.Org.Organization.ID},
+		repo_model.AccessibleRepositoryCondition(ctx.Doer, unitType),
+	)
+	if ctx.PublicOnly {
+		cond = cond.And(builder.Eq{"`repository`.is_private": false})
+	}
+	return cond
+}
+
+// orgInsightsScope returns the scope of the insights of the organization, which depends on the repositories accessible by the doer
+func orgInsightsScope(ctx *context.APIContext, unitType unit.Type) repo_service.InsightsScope {
+	return repo_service.InsightsScope{
+		Key:      fmt.Sprintf("org-%d-user-%d-public-%t", ctx.Org.Organization.ID, ctx.Doer.ID, ctx.PublicOnly),
+		RepoCond: builder.In("issue.repo_id", builder.Select("id").From("repository").Where(orgRepoCond(ctx, unitType))),
+	}
+}
+
+// getContributorInsights returns the contributor statistics of the repositories of the organization, false is returned if the response is written
+func getContributorInsights(ctx *context.APIContext) ([]*api.ContributorStats, []*api.WeeklyCommitStats, bool) {
+	repos, _, err := repo_model.SearchRepositoryByCondition(ctx, repo_model.SearchRepoOptions{}, orgRepoCond(ctx, unit.TypeCode), false)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil, false
+	}
+	contributors, total, err := repo_service.GetContributorInsights(ctx, ctx.Cache, repos)
+	if errors.Is(err, repo_service.ErrAwaitGeneration) {
+		// the statistics are generated in the background, the client should retry later
+		ctx.Status(http.StatusAccepted)
+		return nil, nil, false
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil, false
+	}
+	return contributors, total, true
+}
+
+// GetContributorInsights returns the weekly commit statistics of the contributors of the repositories of an organization
+func GetContributorInsights(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights/contributors organization orgGetContributorInsights
+	// ---
+	// summary: Get the weekly commits, additions and deletions of the contributors of the default branches of the organization's repositories
+	// description: The statistics are generated in the background, the response is 202 while they are generated.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ContributorStatsList"
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	contributors, _, ok := getContributorInsights(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, contributors)
+}
+
+// GetCodeFrequencyInsights returns the weekly additions and deletions of the repositories of an organization
+func GetCodeFrequencyInsights(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights/code-frequency organization orgGetCodeFrequencyInsights
+	// ---
+	// summary: Get the weekly commits, additions and deletions of the default branches of the organization's repositories
+	// description: The statistics are generated in the background, the response is 202 while they are generated.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WeeklyCommitStatsList"
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	_, total, ok := getContributorInsights(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, total)
+}
+
+// GetPullRequestInsights returns the pull request metrics of the repositories of an organization
+func GetPullRequestInsights(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights/pulls organization orgGetPullRequestInsights
+	// ---
+	// summary: Get the pull request lead time, review turnaround and counts of the organization's repositories in a period
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: Start of the period, 90 days before the end by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: End of the period, the next full hour by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestInsights"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	since, before, ok := utils.GetInsightsPeriod(ctx)
+	if !ok {
+		return
+	}
+	insights, err := repo_service.GetPullRequestInsights(ctx, ctx.Cache, orgInsightsScope(ctx, unit.TypePullRequests), since, before)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, insights)
+}
+
+// GetIssueInsights returns the issue metrics of the repositories of an organization
+func GetIssueInsights(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights/issues organization orgGetIssueInsights
+	// ---
+	// summary: Get the issue close rate, time to close and counts of the organization's repositories in a period
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: Start of the period, 90 days before the end by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: End of the period, the next full hour by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueInsights"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	since, before, ok := utils.GetInsightsPeriod(ctx)
+	if !ok {
+		return
+	}
+	insights, err := repo_service.GetIssueInsights(ctx, ctx.Cache, orgInsightsScope(ctx, unit.TypeIssues), since, before)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, insights)
+}
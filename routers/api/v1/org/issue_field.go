@@ -0,0 +1,231 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListIssueFields list the custom fields of an organization
+func ListIssueFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_fields organization orgListIssueFields
+	// ---
+	// summary: List an organization's custom fields of issues and pull requests
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	fields, err := issues_model.GetIssueFields(ctx, 0, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueFieldList(fields))
+}
+
+// GetIssueField get a custom field of an organization
+func GetIssueField(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_fields/{id} organization orgGetIssueField
+	// ---
+	// summary: Get a custom field of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	f, err := issues_model.GetIssueFieldByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueField(f))
+}
+
+// CreateIssueField create a custom field for an organization
+func CreateIssueField(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/issue_fields organization orgCreateIssueField
+	// ---
+	// summary: Create a custom field of the issues and pull requests of all the repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueFieldOption)
+
+	f := &issues_model.IssueField{
+		OrgID:       ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		Type:        issues_model.IssueFieldType(form.Type),
+		Options:     form.Options,
+	}
+	if err := issues_model.NewIssueField(ctx, f); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIssueField(f))
+}
+
+// EditIssueField modify a custom field of an organization
+func EditIssueField(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/issue_fields/{id} organization orgEditIssueField
+	// ---
+	// summary: Update a custom field of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueFieldOption)
+	f, err := issues_model.GetIssueFieldByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if form.Name != nil {
+		f.Name = *form.Name
+	}
+	if form.Description != nil {
+		f.Description = *form.Description
+	}
+	if form.Options != nil {
+		f.Options = form.Options
+	}
+	if err := issues_model.UpdateIssueField(ctx, f); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueField(f))
+}
+
+// DeleteIssueField delete a custom field of an organization
+func DeleteIssueField(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/issue_fields/{id} organization orgDeleteIssueField
+	// ---
+	// summary: Delete a custom field of an organization and its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	f, err := issues_model.GetIssueFieldByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.DeleteIssueField(ctx, f); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func handleIssueFieldError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
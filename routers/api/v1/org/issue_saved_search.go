@@ -0,0 +1,191 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListIssueSavedSearches list the searches shared by an organization
+func ListIssueSavedSearches(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_searches organization orgListIssueSavedSearches
+	// ---
+	// summary: List the searches of issues and pull requests shared by an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSavedSearchList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	searches, err := issues_model.GetIssueSavedSearches(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	result := make([]*api.IssueSavedSearch, len(searches))
+	for i := range searches {
+		result[i] = convert.ToIssueSavedSearch(searches[i], nil, true)
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// CreateIssueSavedSearch share a search of all the repositories of an organization
+func CreateIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/issue_searches organization orgCreateIssueSavedSearch
+	// ---
+	// summary: Share a search of the issues or pull requests of all the repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueSavedSearchOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueSavedSearch"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueSavedSearchOption)
+
+	s := &issues_model.IssueSavedSearch{
+		OwnerID: ctx.Org.Organization.ID,
+		Name:    form.Name,
+		Query:   form.Query,
+	}
+	switch form.Type {
+	case "", "issues":
+	case "pulls":
+		s.IsPull = true
+	default:
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid type: "+form.Type)
+		return
+	}
+	if err := issues_model.NewIssueSavedSearch(ctx, s); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIssueSavedSearch(s, nil, true))
+}
+
+// EditIssueSavedSearch modify a search shared by an organization
+func EditIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/issue_searches/{id} organization orgEditIssueSavedSearch
+	// ---
+	// summary: Update a search shared by an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the search to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueSavedSearchOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSavedSearch"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueSavedSearchOption)
+	s, err := issues_model.GetIssueSavedSearchByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if form.Name != nil {
+		s.Name = *form.Name
+	}
+	if form.Query != nil {
+		s.Query = *form.Query
+	}
+	if err := issues_model.UpdateIssueSavedSearch(ctx, s); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSavedSearch(s, nil, true))
+}
+
+// DeleteIssueSavedSearch delete a search shared by an organization
+func DeleteIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/issue_searches/{id} organization orgDeleteIssueSavedSearch
+	// ---
+	// summary: Delete a search shared by an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the search to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s, err := issues_model.GetIssueSavedSearchByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.DeleteIssueSavedSearch(ctx, s.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,385 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/optional"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListIterations list the iterations of an organization
+func ListIterations(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/iterations organization orgListIterations
+	// ---
+	// summary: List an organization's iterations sorted by start date
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: Iteration state, Recognized values are open, closed and all. Defaults to "all"
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IterationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	var isClosed optional.Option[bool]
+	switch state := api.StateType(ctx.FormString("state")); state {
+	case api.StateClosed, api.StateOpen:
+		isClosed = optional.Some(state == api.StateClosed)
+	}
+
+	iterations, err := issues_model.GetIterations(ctx, 0, ctx.Org.Organization.ID, isClosed)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIterationList(iterations))
+}
+
+// GetIteration get an iteration of an organization
+func GetIteration(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/iterations/{id} organization orgGetIteration
+	// ---
+	// summary: Get an iteration of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// CreateIteration create an iteration of an organization
+func CreateIteration(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/iterations organization orgCreateIteration
+	// ---
+	// summary: Create an iteration of the issues of all the repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIterationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIterationOption)
+
+	it := &issues_model.Iteration{
+		OrgID:       ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		StartUnix:   timeutil.TimeStamp(form.Start.Unix()),
+		EndUnix:     timeutil.TimeStamp(form.End.Unix()),
+		Capacity:    form.Capacity,
+	}
+	if err := issues_model.NewIteration(ctx, it); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIteration(it))
+}
+
+// EditIteration modify an iteration of an organization
+func EditIteration(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/iterations/{id} organization orgEditIteration
+	// ---
+	// summary: Update an iteration of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIterationOption)
+	it, err := issues_model.GetIterationByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if form.Name != nil {
+		it.Name = *form.Name
+	}
+	if form.Description != nil {
+		it.Description = *form.Description
+	}
+	if form.Start != nil {
+		it.StartUnix = timeutil.TimeStamp(form.Start.Unix())
+	}
+	if form.End != nil {
+		it.EndUnix = timeutil.TimeStamp(form.End.Unix())
+	}
+	if form.Capacity != nil {
+		it.Capacity = *form.Capacity
+	}
+	if err := issues_model.UpdateIteration(ctx, it); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// DeleteIteration delete an iteration of an organization
+func DeleteIteration(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/iterations/{id} organization orgDeleteIteration
+	// ---
+	// summary: Delete an iteration of an organization, its issues go back to the backlog
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.DeleteIteration(ctx, it); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// CloseIteration close an iteration of an organization
+func CloseIteration(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/iterations/{id}/close organization orgCloseIteration
+	// ---
+	// summary: Close an iteration of an organization
+	// description: The open issues of the iteration are carried over to another open iteration of the organization, or go back to the backlog.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to close
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CloseIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CloseIterationOption)
+	it, err := issues_model.GetIterationByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	var carryOverTo *issues_model.Iteration
+	if form.CarryOverTo > 0 {
+		if carryOverTo, err = issues_model.GetIterationByID(ctx, 0, ctx.Org.Organization.ID, form.CarryOverTo); err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				err = util.NewInvalidArgumentErrorf("%v", err)
+			}
+			handleIssueFieldError(ctx, err)
+			return
+		}
+	}
+	if err := issues_model.CloseIteration(ctx, it, carryOverTo); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// GetIterationBurndown get the burndown series of an iteration of an organization
+func GetIterationBurndown(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/iterations/{id}/burndown organization orgGetIterationBurndown
+	// ---
+	// summary: Get the burndown series of an iteration of an organization, counting the issues of all the repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IterationBurndown"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByID(ctx, 0, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+	burndown, err := issues_model.GetIterationBurndown(ctx, it, 0, time.Now())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIterationBurndown(it, burndown))
+}
+
+// ListIterationVelocity list the velocity of the closed iterations of an organization
+func ListIterationVelocity(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/iterations/velocity organization orgListIterationVelocity
+	// ---
+	// summary: Get the velocity of the last closed iterations of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: number of the last closed iterations, defaults to 10
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IterationVelocityList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	iterations, err := issues_model.GetIterations(ctx, 0, ctx.Org.Organization.ID, optional.Some(true))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = 10
+	}
+	iterations = iterations[max(len(iterations)-limit, 0):]
+
+	velocities, err := issues_model.GetIterationsVelocity(ctx, iterations, 0, time.Now())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIterationVelocities(velocities))
+}
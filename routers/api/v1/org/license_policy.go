@@ -0,0 +1,123 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	licensecheck_service "code.gitea.io/gitea/services/licensecheck"
+)
+
+// GetLicensePolicy get the license policy of an organization
+func GetLicensePolicy(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/license_policy organization orgGetLicensePolicy
+	// ---
+	// summary: Get the licenses denied in the repositories of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LicensePolicy"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p, err := organization.GetLicensePolicy(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToLicensePolicy(p))
+}
+
+// SetLicensePolicy set the license policy of an organization
+func SetLicensePolicy(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/license_policy organization orgSetLicensePolicy
+	// ---
+	// summary: Set the licenses denied in the repositories of an organization
+	// description: The default branches of the repositories are checked again, pushes to them and pull requests get a failing "license-compliance" commit status if they introduce a denied license of the code or of a dependency.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditLicensePolicyOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LicensePolicy"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditLicensePolicyOption)
+
+	p, err := organization.SetLicensePolicy(ctx, ctx.Org.Organization.ID, form.DeniedLicenses)
+	if err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+	if err := licensecheck_service.CheckOrgRepositories(ctx, ctx.Org.Organization.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToLicensePolicy(p))
+}
+
+// DeleteLicensePolicy delete the license policy of an organization
+func DeleteLicensePolicy(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/license_policy organization orgDeleteLicensePolicy
+	// ---
+	// summary: Delete the license policy of an organization, no licenses are denied anymore
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := organization.DeleteLicensePolicy(ctx, ctx.Org.Organization.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
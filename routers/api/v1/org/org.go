@@ -15,8 +15,10 @@ import (
 	"code.gitea.io/gitea/modules/optional"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/shared"
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	admin_service "code.gitea.io/gitea/services/admin"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	feed_service "code.gitea.io/gitea/services/feed"
@@ -393,6 +395,7 @@ func Edit(ctx *context.APIContext) {
 		Location:                  optional.Some(form.Location),
 		Visibility:                optional.FromMapLookup(api.VisibilityModes, form.Visibility),
 		RepoAdminChangeTeamAccess: optional.FromPtr(form.RepoAdminChangeTeamAccess),
+		DisallowImpersonation:     optional.FromPtr(form.DisallowImpersonation),
 	}
 	if err := user_service.UpdateUser(ctx, ctx.Org.Organization.AsUser(), opts); err != nil {
 		ctx.APIErrorInternal(err)
@@ -416,11 +419,29 @@ func Delete(ctx *context.APIContext) {
 	//   type: string
 	//   required: true
 	// responses:
+	//   "202":
+	//     "$ref": "#/responses/AdminApprovalRequest"
 	//   "204":
 	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
+	// the deletion by an administrator needs the approval of a second administrator
+	if admin_service.IsOrgDeletionApprovalRequired(ctx.Doer) {
+		r, err := admin_service.RequestDeleteOrg(ctx, ctx.Doer, ctx.Org.Organization)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiRequest, err := convert.ToAdminApprovalRequest(ctx, r, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		ctx.JSON(http.StatusAccepted, apiRequest)
+		return
+	}
+
 	if err := org.DeleteOrganization(ctx, ctx.Org.Organization, false); err != nil {
 		ctx.APIErrorInternal(err)
 		return
@@ -459,19 +480,10 @@ func ListOrgActivityFeeds(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	includePrivate := false
-	if ctx.IsSigned {
-		if ctx.Doer.IsAdmin {
-			includePrivate = true
-		} else {
-			org := organization.OrgFromUser(ctx.ContextUser)
-			isMember, err := org.IsOrgMember(ctx, ctx.Doer.ID)
-			if err != nil {
-				ctx.APIErrorInternal(err)
-				return
-			}
-			includePrivate = isMember
-		}
+	includePrivate, err := includeOrgPrivateActivities(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
 	}
 
 	listOptions := utils.GetListOptions(ctx)
@@ -493,3 +505,63 @@ func ListOrgActivityFeeds(ctx *context.APIContext) {
 
 	ctx.JSON(http.StatusOK, convert.ToActivities(ctx, feeds, ctx.Doer))
 }
+
+// StreamOrgActivityFeeds streams the new activities of an organization
+func StreamOrgActivityFeeds(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/events/stream organization orgStreamActivityFeeds
+	// ---
+	// summary: Stream an organization's new activity feeds as server-sent events
+	// description: Every `activity` event has the ID of the activity as event ID. The stream is resumed after the last received activity with the `Last-Event-ID` header or the `last_event_id` query parameter, otherwise only the activities created after the stream is opened are sent.
+	// produces:
+	// - text/event-stream
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the org
+	//   type: string
+	//   required: true
+	// - name: last_event_id
+	//   in: query
+	//   description: ID of the last received activity to resume the stream after it
+	//   type: integer
+	//   format: int64
+	// responses:
+	//   "200":
+	//     description: stream of the activities as server-sent events
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	includePrivate, err := includeOrgPrivateActivities(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	shared.StreamActivityFeeds(ctx, activities_model.GetFeedsOptions{
+		RequestedUser:  ctx.ContextUser,
+		Actor:          ctx.Doer,
+		IncludePrivate: includePrivate,
+	}, func() (bool, error) {
+		if exist, err := db.ExistByID[user_model.User](ctx, ctx.ContextUser.ID); err != nil || !exist {
+			return false, err
+		}
+		if !includePrivate {
+			return true, nil
+		}
+		// the doer must still be a member to receive the private activities
+		return includeOrgPrivateActivities(ctx)
+	})
+}
+
+// includeOrgPrivateActivities returns whether the doer can see the private activities of the organization
+func includeOrgPrivateActivities(ctx *context.APIContext) (bool, error) {
+	if !ctx.IsSigned {
+		return false, nil
+	}
+	if ctx.Doer.IsAdmin {
+		return true, nil
+	}
+	return organization.OrgFromUser(ctx.ContextUser).IsOrgMember(ctx, ctx.Doer.ID)
+}
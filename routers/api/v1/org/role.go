@@ -0,0 +1,572 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/user"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListRoles list the custom roles of an organization
+func ListRoles(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/roles organization orgListRoles
+	// ---
+	// summary: List an organization's custom roles
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRoleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	roles, err := organization.FindOrgRoles(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgRoleList(roles))
+}
+
+// ListRolePermissions list the permission catalog custom roles are composed from
+func ListRolePermissions(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/roles/permissions organization orgListRolePermissions
+	// ---
+	// summary: List the permissions custom roles can be composed from
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRolePermissionCatalog"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	catalog := organization.OrgRolePermissionCatalog()
+	permissions := make([]string, len(catalog))
+	for i, p := range catalog {
+		permissions[i] = string(p)
+	}
+
+	ctx.JSON(http.StatusOK, permissions)
+}
+
+// GetRole get a custom role of an organization
+func GetRole(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/roles/{id} organization orgGetRole
+	// ---
+	// summary: Get a custom role of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRole"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgRole(r))
+}
+
+// CreateRole create a custom role of an organization
+func CreateRole(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/roles organization orgCreateRole
+	// ---
+	// summary: Create a custom role of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrgRoleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/OrgRole"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateOrgRoleOption)
+
+	r := &organization.OrgRole{
+		OrgID:       ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+	}
+	if err := r.SetPermissions(toOrgRolePermissions(form.Permissions)); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := organization.CreateOrgRole(ctx, r); err != nil {
+		handleOrgRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToOrgRole(r))
+}
+
+// EditRole modify a custom role of an organization
+func EditRole(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/roles/{id} organization orgEditRole
+	// ---
+	// summary: Edit a custom role of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditOrgRoleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRole"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditOrgRoleOption)
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Name != nil {
+		r.Name = *form.Name
+	}
+	if form.Description != nil {
+		r.Description = *form.Description
+	}
+	if form.Permissions != nil {
+		if err := r.SetPermissions(toOrgRolePermissions(form.Permissions)); err != nil {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	if err := organization.UpdateOrgRole(ctx, r); err != nil {
+		handleOrgRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgRole(r))
+}
+
+// DeleteRole delete a custom role of an organization
+func DeleteRole(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/roles/{id} organization orgDeleteRole
+	// ---
+	// summary: Delete a custom role of an organization and its assignments
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := organization.DeleteOrgRole(ctx, r); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListRoleAssignments list the members and teams a custom role is assigned to
+func ListRoleAssignments(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/roles/{id}/assignments organization orgListRoleAssignments
+	// ---
+	// summary: List the members and teams a custom role of an organization is assigned to
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRoleAssignmentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	assignments, err := organization.GetOrgRoleAssignments(ctx, r.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiAssignments, err := convert.ToOrgRoleAssignmentList(ctx, assignments, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apiAssignments)
+}
+
+// AssignRoleToUser assign a custom role to a member of an organization
+func AssignRoleToUser(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/roles/{id}/users/{username} organization orgAssignRoleToUser
+	// ---
+	// summary: Assign a custom role to a member of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the member
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	u := user.GetContextUserByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+	isMember, err := organization.IsOrganizationMember(ctx, ctx.Org.Organization.ID, u.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if !isMember {
+		ctx.APIError(http.StatusUnprocessableEntity, "the user is not a member of the organization")
+		return
+	}
+	if err := organization.AssignOrgRole(ctx, r, u.ID, 0); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnassignRoleFromUser remove a custom role from a member of an organization
+func UnassignRoleFromUser(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/roles/{id}/users/{username} organization orgUnassignRoleFromUser
+	// ---
+	// summary: Remove a custom role from a member of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the member
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	u := user.GetContextUserByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := organization.UnassignOrgRole(ctx, r, u.ID, 0); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AssignRoleToTeam assign a custom role to a team of an organization
+func AssignRoleToTeam(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/roles/{id}/teams/{teamid} organization orgAssignRoleToTeam
+	// ---
+	// summary: Assign a custom role to a team of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: teamid
+	//   in: path
+	//   description: id of the team
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	t := getOrgRoleTeam(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := organization.AssignOrgRole(ctx, r, 0, t.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnassignRoleFromTeam remove a custom role from a team of an organization
+func UnassignRoleFromTeam(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/roles/{id}/teams/{teamid} organization orgUnassignRoleFromTeam
+	// ---
+	// summary: Remove a custom role from a team of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: teamid
+	//   in: path
+	//   description: id of the team
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	t := getOrgRoleTeam(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := organization.UnassignOrgRole(ctx, r, 0, t.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListMemberRoles list the custom roles a member has directly or through the teams
+func ListMemberRoles(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/members/{username}/roles organization orgListMemberRoles
+	// ---
+	// summary: List the custom roles a member of an organization has, directly or through the teams
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the member
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgRoleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	u := user.GetContextUserByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+	roles, err := organization.GetUserOrgRoles(ctx, ctx.Org.Organization.ID, u.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgRoleList(roles))
+}
+
+func getOrgRole(ctx *context.APIContext) *organization.OrgRole {
+	r, err := organization.GetOrgRoleByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return nil
+	}
+	return r
+}
+
+func getOrgRoleTeam(ctx *context.APIContext) *organization.Team {
+	t, err := organization.GetTeamByID(ctx, ctx.PathParamInt64("teamid"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return nil
+	} else if t.OrgID != ctx.Org.Organization.ID {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	return t
+}
+
+func toOrgRolePermissions(permissions []string) []organization.OrgRolePermission {
+	result := make([]organization.OrgRolePermission, len(permissions))
+	for i, p := range permissions {
+		result[i] = organization.OrgRolePermission(p)
+	}
+	return result
+}
+
+func handleOrgRoleError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
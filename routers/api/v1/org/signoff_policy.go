@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// GetSignOffPolicy get the sign-off policy of an organization
+func GetSignOffPolicy(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/signoff_policy organization orgGetSignOffPolicy
+	// ---
+	// summary: Get the sign-off policy of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SignOffPolicy"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p, err := organization.GetSignOffPolicy(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToSignOffPolicy(p))
+}
+
+// SetSignOffPolicy set the sign-off policy of an organization
+func SetSignOffPolicy(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/signoff_policy organization orgSetSignOffPolicy
+	// ---
+	// summary: Require the commits of the pull requests of all repositories of an organization to be signed off (DCO)
+	// description: Pull requests get a "dco" commit status which fails unless every commit has a "Signed-off-by" trailer matching its author.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSignOffPolicyOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SignOffPolicy"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	form := web.GetForm(ctx).(*api.EditSignOffPolicyOption)
+
+	p, err := organization.SetSignOffPolicy(ctx, ctx.Org.Organization.ID, form.ExemptUsers)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToSignOffPolicy(p))
+}
+
+// DeleteSignOffPolicy delete the sign-off policy of an organization
+func DeleteSignOffPolicy(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/signoff_policy organization orgDeleteSignOffPolicy
+	// ---
+	// summary: Delete the sign-off policy of an organization, the repositories may still require sign-offs themselves
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := organization.DeleteSignOffPolicy(ctx, ctx.Org.Organization.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
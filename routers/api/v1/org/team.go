@@ -17,6 +17,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/api/v1/utils"
@@ -141,6 +142,39 @@ func GetTeam(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, apiTeam)
 }
 
+// ListTeamChildren api for listing the child teams of a team
+func ListTeamChildren(ctx *context.APIContext) {
+	// swagger:operation GET /teams/{id}/children organization orgListTeamChildren
+	// ---
+	// summary: List the child teams of a team
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the team
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TeamList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	children, err := organization.GetTeamChildren(ctx, ctx.Org.Team.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiTeams, err := convert.ToTeams(ctx, children, false)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiTeams)
+}
+
 func attachTeamUnits(team *organization.Team, defaultAccessMode perm.AccessMode, units []string) {
 	unitTypes, _ := unit_model.FindUnitTypes(units...)
 	team.Units = make([]*organization.TeamUnit, 0, len(units))
@@ -214,6 +248,7 @@ func CreateTeam(ctx *context.APIContext) {
 		IncludesAllRepositories: form.IncludesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
 		AccessMode:              teamPermission,
+		ParentID:                form.ParentID,
 	}
 
 	if team.AccessMode < perm.AccessModeAdmin {
@@ -231,7 +266,7 @@ func CreateTeam(ctx *context.APIContext) {
 	}
 
 	if err := org_service.NewTeam(ctx, team); err != nil {
-		if organization.IsErrTeamAlreadyExist(err) {
+		if organization.IsErrTeamAlreadyExist(err) || errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusUnprocessableEntity, err)
 		} else {
 			ctx.APIErrorInternal(err)
@@ -271,6 +306,8 @@ func EditTeam(ctx *context.APIContext) {
 	//     "$ref": "#/responses/Team"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditTeamOption)
 	team := ctx.Org.Team
@@ -279,6 +316,17 @@ func EditTeam(ctx *context.APIContext) {
 		return
 	}
 
+	if form.ParentID != nil {
+		if err := org_service.MoveTeam(ctx, team, *form.ParentID); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+
 	if form.CanCreateOrgRepo != nil {
 		team.CanCreateOrgRepo = team.IsOwnerTeam() || *form.CanCreateOrgRepo
 	}
@@ -517,6 +565,8 @@ func RemoveTeamMember(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	u := user.GetContextUserByPathParam(ctx)
 	if ctx.Written() {
@@ -524,7 +574,11 @@ func RemoveTeamMember(ctx *context.APIContext) {
 	}
 
 	if err := org_service.RemoveTeamMember(ctx, ctx.Org.Team, u); err != nil {
-		ctx.APIErrorInternal(err)
+		if errors.Is(err, organization.ErrTeamMemberInherited) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
 		return
 	}
 	ctx.Status(http.StatusNoContent)
@@ -0,0 +1,360 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// trackedTimesFilter returns the user and the date range of the tracked times to report from the query parameters
+func trackedTimesFilter(ctx *context.APIContext) (userID, createdBeforeUnix, createdAfterUnix int64, ok bool) {
+	if qUser := ctx.FormTrim("user"); qUser != "" {
+		user, err := user_model.GetUserByName(ctx, qUser)
+		if err != nil {
+			ctx.NotFoundOrServerError(err)
+			return 0, 0, 0, false
+		}
+		userID = user.ID
+	}
+
+	var err error
+	if createdBeforeUnix, createdAfterUnix, err = context.GetQueryBeforeSince(ctx.Base); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return 0, 0, 0, false
+	}
+	return userID, createdBeforeUnix, createdAfterUnix, true
+}
+
+// GetTrackedTimeReport sum the tracked times of the repositories of an organization
+func GetTrackedTimeReport(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/times/report organization orgTrackedTimeReport
+	// ---
+	// summary: Sum the tracked times of all the repositories of an organization by user, repository, milestone or issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_by
+	//   in: query
+	//   description: dimension to sum the tracked times by
+	//   type: string
+	//   enum: [user, repo, milestone, issue]
+	//   default: repo
+	// - name: user
+	//   in: query
+	//   description: optional filter by user
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: Only sum times tracked after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only sum times tracked before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TrackedTimeSumList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	userID, before, after, ok := trackedTimesFilter(ctx)
+	if !ok {
+		return
+	}
+
+	sums, err := issues_model.GetTrackedTimeReport(ctx, &issues_model.TrackedTimeReportOptions{
+		OwnerID:           ctx.Org.Organization.ID,
+		UserID:            userID,
+		GroupBy:           issues_model.TrackedTimeGroupBy(ctx.FormString("group_by", string(issues_model.TrackedTimeGroupByRepo))),
+		CreatedAfterUnix:  after,
+		CreatedBeforeUnix: before,
+	})
+	if err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToTrackedTimeSums(sums))
+}
+
+// ExportTrackedTimes export the tracked times of the repositories of an organization as CSV
+func ExportTrackedTimes(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/times/export organization orgExportTrackedTimes
+	// ---
+	// summary: Export the tracked times of all the repositories of an organization as CSV
+	// produces:
+	// - text/csv
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: user
+	//   in: query
+	//   description: optional filter by user
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: Only export times tracked after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only export times tracked before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     description: the tracked times as CSV
+	//     schema:
+	//       type: file
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	userID, before, after, ok := trackedTimesFilter(ctx)
+	if !ok {
+		return
+	}
+
+	trackedTimes, err := issues_model.GetTrackedTimes(ctx, &issues_model.FindTrackedTimesOptions{
+		OwnerID:           ctx.Org.Organization.ID,
+		UserID:            userID,
+		CreatedAfterUnix:  after,
+		CreatedBeforeUnix: before,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := issue_service.WriteTrackedTimesCSV(ctx, &buf, trackedTimes); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-times.csv"`, ctx.Org.Organization.Name))
+	ctx.Resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(buf.Bytes())
+}
+
+// ListTimeTrackingRules list the time tracking rules of an organization
+func ListTimeTrackingRules(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/time_tracking_rules organization orgListTimeTrackingRules
+	// ---
+	// summary: List the rules restricting who may track time on an organization's repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TimeTrackingRuleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rules, err := organization.GetTimeTrackingRules(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiRules, err := convert.ToTimeTrackingRules(ctx, rules)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apiRules)
+}
+
+// respondTimeTrackingRule responds a time tracking rule of the organization with the given status
+func respondTimeTrackingRule(ctx *context.APIContext, status int, rule *organization.TimeTrackingRule) {
+	apiRules, err := convert.ToTimeTrackingRules(ctx, []*organization.TimeTrackingRule{rule})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, apiRules[0])
+}
+
+// CreateTimeTrackingRule create a time tracking rule of an organization
+func CreateTimeTrackingRule(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/time_tracking_rules organization orgCreateTimeTrackingRule
+	// ---
+	// summary: Restrict who may track time on a repository of an organization, or on all its repositories without their own rule
+	// description: The owners of the organization always may track time, the members of the teams of the rule may too.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateTimeTrackingRuleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/TimeTrackingRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateTimeTrackingRuleOption)
+
+	rule := &organization.TimeTrackingRule{
+		OrgID:   ctx.Org.Organization.ID,
+		TeamIDs: form.Teams,
+	}
+	if form.Repository != "" {
+		repo, err := repo_model.GetRepositoryByName(ctx, ctx.Org.Organization.ID, form.Repository)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		rule.RepoID = repo.ID
+	}
+	if err := organization.NewTimeTrackingRule(ctx, rule); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	respondTimeTrackingRule(ctx, http.StatusCreated, rule)
+}
+
+// EditTimeTrackingRule modify a time tracking rule of an organization
+func EditTimeTrackingRule(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/time_tracking_rules/{id} organization orgEditTimeTrackingRule
+	// ---
+	// summary: Update the teams of a time tracking rule of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditTimeTrackingRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TimeTrackingRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditTimeTrackingRuleOption)
+	rule, err := organization.GetTimeTrackingRuleByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	rule.TeamIDs = form.Teams
+	if err := organization.UpdateTimeTrackingRule(ctx, rule); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	respondTimeTrackingRule(ctx, http.StatusOK, rule)
+}
+
+// DeleteTimeTrackingRule delete a time tracking rule of an organization
+func DeleteTimeTrackingRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/time_tracking_rules/{id} organization orgDeleteTimeTrackingRule
+	// ---
+	// summary: Delete a time tracking rule of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rule, err := organization.GetTimeTrackingRuleByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := organization.DeleteTimeTrackingRule(ctx, rule); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
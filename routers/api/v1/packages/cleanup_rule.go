@@ -0,0 +1,385 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+)
+
+// ListCleanupRules lists the cleanup rules of an owner
+func ListCleanupRules(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/rules package listPackageCleanupRules
+	// ---
+	// summary: List the package cleanup rules of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupRuleList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcrs, err := packages.GetCleanupRulesByOwner(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRules := make([]*api.PackageCleanupRule, 0, len(pcrs))
+	for _, pcr := range pcrs {
+		apiRule, err := convert.ToPackageCleanupRule(ctx, pcr, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiRules = append(apiRules, apiRule)
+	}
+
+	ctx.JSON(http.StatusOK, apiRules)
+}
+
+// CreateCleanupRule creates a cleanup rule for an owner
+func CreateCleanupRule(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/-/rules package createPackageCleanupRule
+	// ---
+	// summary: Create a package cleanup rule
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageCleanupRuleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageCleanupRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreatePackageCleanupRuleOption)
+
+	pcr := &packages.PackageCleanupRule{
+		OwnerID:              ctx.Package.Owner.ID,
+		Type:                 packages.Type(form.Type),
+		Enabled:              form.Enabled,
+		KeepCount:            form.KeepCount,
+		KeepPattern:          form.KeepPattern,
+		RemoveDays:           form.RemoveDays,
+		RemovePattern:        form.RemovePattern,
+		MatchFullName:        form.MatchFullName,
+		RemovePrereleaseDays: form.RemovePrereleaseDays,
+		RemoveUntaggedDays:   form.RemoveUntaggedDays,
+	}
+	if !slices.Contains(packages.TypeList, pcr.Type) {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Errorf("invalid package type %q", form.Type))
+		return
+	}
+	if form.RepoName != "" {
+		repo, err := repo_model.GetRepositoryByName(ctx, ctx.Package.Owner.ID, form.RepoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		pcr.RepoID = repo.ID
+		pcr.Repo = repo
+	}
+	if err := validateCleanupRule(pcr); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if has, err := packages.HasOwnerCleanupRuleForPackageType(ctx, pcr.OwnerID, pcr.RepoID, pcr.Type); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if has {
+		ctx.APIError(http.StatusConflict, "a cleanup rule for the package type already exists")
+		return
+	}
+
+	pcr, err := packages.InsertCleanupRule(ctx, pcr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRule, err := convert.ToPackageCleanupRule(ctx, pcr, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiRule)
+}
+
+// GetCleanupRule gets a cleanup rule of an owner
+func GetCleanupRule(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/rules/{id} package getPackageCleanupRule
+	// ---
+	// summary: Get a package cleanup rule
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcr := getCleanupRuleByContext(ctx)
+	if pcr == nil {
+		return
+	}
+
+	apiRule, err := convert.ToPackageCleanupRule(ctx, pcr, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiRule)
+}
+
+// EditCleanupRule edits a cleanup rule of an owner
+func EditCleanupRule(ctx *context.APIContext) {
+	// swagger:operation PATCH /packages/{owner}/-/rules/{id} package editPackageCleanupRule
+	// ---
+	// summary: Edit a package cleanup rule
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditPackageCleanupRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pcr := getCleanupRuleByContext(ctx)
+	if pcr == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.EditPackageCleanupRuleOption)
+	if form.Enabled != nil {
+		pcr.Enabled = *form.Enabled
+	}
+	if form.KeepCount != nil {
+		pcr.KeepCount = *form.KeepCount
+	}
+	if form.KeepPattern != nil {
+		pcr.KeepPattern = *form.KeepPattern
+	}
+	if form.RemoveDays != nil {
+		pcr.RemoveDays = *form.RemoveDays
+	}
+	if form.RemovePattern != nil {
+		pcr.RemovePattern = *form.RemovePattern
+	}
+	if form.MatchFullName != nil {
+		pcr.MatchFullName = *form.MatchFullName
+	}
+	if form.RemovePrereleaseDays != nil {
+		pcr.RemovePrereleaseDays = *form.RemovePrereleaseDays
+	}
+	if form.RemoveUntaggedDays != nil {
+		pcr.RemoveUntaggedDays = *form.RemoveUntaggedDays
+	}
+	if err := validateCleanupRule(pcr); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := packages.UpdateCleanupRule(ctx, pcr); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRule, err := convert.ToPackageCleanupRule(ctx, pcr, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiRule)
+}
+
+// DeleteCleanupRule deletes a cleanup rule of an owner
+func DeleteCleanupRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/-/rules/{id} package deletePackageCleanupRule
+	// ---
+	// summary: Delete a package cleanup rule
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcr := getCleanupRuleByContext(ctx)
+	if pcr == nil {
+		return
+	}
+
+	if err := packages.DeleteCleanupRuleByID(ctx, pcr.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// PreviewCleanupRule lists the package versions a cleanup rule would remove
+func PreviewCleanupRule(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/rules/{id}/preview package previewPackageCleanupRule
+	// ---
+	// summary: List the package versions which a cleanup rule would remove now, nothing gets removed
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcr := getCleanupRuleByContext(ctx)
+	if pcr == nil {
+		return
+	}
+
+	pds, err := cleanup_service.PreviewCleanupRule(ctx, pcr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPackages := make([]*api.Package, 0, len(pds))
+	for _, pd := range pds {
+		apiPackage, err := convert.ToPackage(ctx, pd, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiPackages = append(apiPackages, apiPackage)
+	}
+
+	ctx.SetTotalCountHeader(int64(len(apiPackages)))
+	ctx.JSON(http.StatusOK, apiPackages)
+}
+
+func getCleanupRuleByContext(ctx *context.APIContext) *packages.PackageCleanupRule {
+	pcr, err := packages.GetCleanupRuleByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, packages.ErrPackageCleanupRuleNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	if pcr.OwnerID != ctx.Package.Owner.ID {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	return pcr
+}
+
+func validateCleanupRule(pcr *packages.PackageCleanupRule) error {
+	if pcr.KeepCount < 0 || pcr.RemoveDays < 0 || pcr.RemovePrereleaseDays < 0 || pcr.RemoveUntaggedDays < 0 {
+		return errors.New("counts and days must not be negative")
+	}
+	pcr.KeepPatternMatcher, pcr.RemovePatternMatcher = nil, nil
+	return pcr.CompiledPattern()
+}
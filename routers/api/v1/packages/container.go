@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	container_service "code.gitea.io/gitea/services/packages/container"
+	scan_service "code.gitea.io/gitea/services/packages/scan"
+)
+
+// GetContainerSignatureStatus gets the signatures and attestations attached to a container image
+func GetContainerSignatureStatus(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/signatures package getContainerSignatureStatus
+	// ---
+	// summary: Gets the signatures, attestations and SBOMs attached to a container image, the signatures are not verified
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package, only container is supported
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: tag or digest of the image
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ContainerSignatureStatus"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pd := ctx.Package.Descriptor
+	if pd.Package.Type != packages.TypeContainer {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	status, err := container_service.GetSignatureStatus(ctx, pd.Owner.ID, pd.Package.LowerName, container_service.GetManifestDigest(pd))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToContainerSignatureStatus(status))
+}
+
+// GetContainerSBOM creates a SBOM of a container image with the package scanner
+func GetContainerSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/sbom package getContainerSBOM
+	// ---
+	// summary: Gets a SBOM of a container image, it is created by the package scanner
+	// produces:
+	// - application/vnd.cyclonedx+json
+	// - application/spdx+json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package, only container is supported
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: tag or digest of the image, the image of a multi-platform image must be selected by its digest
+	//   type: string
+	//   required: true
+	// - name: format
+	//   in: query
+	//   description: format of the SBOM
+	//   type: string
+	//   enum: [cyclonedx, spdx]
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "The SBOM as CycloneDX or SPDX JSON document"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pd := ctx.Package.Descriptor
+	if pd.Package.Type != packages.TypeContainer {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	format, ok := sbom.ParseFormat(ctx.FormTrim("format"))
+	if !ok {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Errorf("unsupported SBOM format %q", ctx.FormTrim("format")))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := scan_service.GenerateImageSBOM(ctx, pd, format, &buf); err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.APIErrorNotFound(err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", format.ContentType())
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(ctx.Resp)
+}
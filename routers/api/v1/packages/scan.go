@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	scan_service "code.gitea.io/gitea/services/packages/scan"
+)
+
+// GetPackageVulnerabilities gets the vulnerabilities found by the last scan of a package version
+func GetPackageVulnerabilities(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/vulnerabilities package getPackageVulnerabilities
+	// ---
+	// summary: Gets the vulnerabilities found by the last scan of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVulnerabilityReport"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pv := ctx.Package.Descriptor.Version
+
+	ps, err := packages.GetScanByVersionID(ctx, pv.ID)
+	if err != nil {
+		if errors.Is(err, packages.ErrPackageScanNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	vulns, err := packages.GetVulnerabilitiesByVersionID(ctx, pv.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageVulnerabilityReport(ps, vulns))
+}
+
+// ScanPackageVersion requests a new vulnerability scan of a package version
+func ScanPackageVersion(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/vulnerabilities/scan package scanPackageVersion
+	// ---
+	// summary: Requests a new vulnerability scan of a package version, the scan runs in the background
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pd := ctx.Package.Descriptor
+	if !scan_service.IsScanned(pd.Package.Type) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	if err := scan_service.Enqueue(ctx, pd); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
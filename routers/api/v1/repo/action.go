@@ -22,6 +22,7 @@ import (
 	secret_model "code.gitea.io/gitea/models/secret"
 	"code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
@@ -86,6 +87,7 @@ func (Action) ListActionsSecrets(ctx *context.APIContext) {
 		apiSecrets[k] = &api.Secret{
 			Name:        v.Name,
 			Description: v.Description,
+			Provider:    v.Provider,
 			Created:     v.CreatedUnix.AsTime(),
 		}
 	}
@@ -137,7 +139,7 @@ func (Action) CreateOrUpdateSecret(ctx *context.APIContext) {
 
 	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
 
-	_, created, err := secret_service.CreateOrUpdateSecret(ctx, 0, repo.ID, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	_, created, err := secret_service.CreateOrUpdateSecret(ctx, 0, repo.ID, ctx.PathParam("secretname"), opt.Provider, opt.Data, opt.Description)
 	if err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
@@ -556,6 +558,64 @@ func (Action) CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, 0, ctx.Repo.Repository.ID)
 }
 
+// CreateRunnerJITToken creates a just-in-time token to register an ephemeral repository runner
+func (Action) CreateRunnerJITToken(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runners/jit-token repository repoCreateRunnerJITToken
+	// ---
+	// summary: Create a single-use registration token for an ephemeral repository runner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRunnerJITTokenOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/RunnerJITToken"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateRunnerJITToken(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// ListRunnerPendingJobs lists the number of jobs waiting for repository runners by their labels
+func (Action) ListRunnerPendingJobs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runners/pending-jobs repository repoListRunnerPendingJobs
+	// ---
+	// summary: List the number of jobs waiting for repository runners by their labels
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RunnerPendingJobsList"
+
+	shared.ListRunnerPendingJobs(ctx, 0, ctx.Repo.Repository.ID)
+}
+
 // ListRunners get repo-level runners
 func (Action) ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/runners repository getRepoRunners
@@ -650,6 +710,122 @@ func (Action) DeleteRunner(ctx *context.APIContext) {
 	shared.DeleteRunner(ctx, 0, ctx.Repo.Repository.ID, ctx.PathParamInt64("runner_id"))
 }
 
+// GetArtifactRetention returns the repository's artifact retention policy
+func (Action) GetArtifactRetention(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/artifact-retention repository repoGetArtifactRetention
+	// ---
+	// summary: Get the repository's artifact retention policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionArtifactRetention"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetArtifactRetention(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// UpdateArtifactRetention sets the repository's artifact retention policy
+func (Action) UpdateArtifactRetention(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/artifact-retention repository repoUpdateArtifactRetention
+	// ---
+	// summary: Set the repository's artifact retention policy
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionArtifactRetentionOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionArtifactRetention"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.UpdateArtifactRetention(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// DeleteArtifactRetention deletes the repository's artifact retention policy
+func (Action) DeleteArtifactRetention(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/artifact-retention repository repoDeleteArtifactRetention
+	// ---
+	// summary: Delete the repository's artifact retention policy
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: artifact retention policy deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteArtifactRetention(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// GetArtifactUsage returns the storage consumed by the repository's artifacts
+func (Action) GetArtifactUsage(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/artifact-usage repository repoGetArtifactUsage
+	// ---
+	// summary: Get the storage consumed by the repository's artifacts
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionArtifactUsage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetArtifactUsage(ctx, 0, ctx.Repo.Repository.ID)
+}
+
 // GetWorkflowRunJobs Lists all jobs for a workflow run.
 func (Action) ListWorkflowJobs(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/jobs repository listWorkflowJobs
@@ -833,6 +1009,38 @@ func ListActionTasks(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, &res)
 }
 
+// ListActionConcurrencyGroups lists the active concurrency groups of a repository
+func ListActionConcurrencyGroups(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/concurrency_groups repository ListActionConcurrencyGroups
+	// ---
+	// summary: List a repository's active actions concurrency groups with their running and queued runs
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionConcurrencyGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	groups, err := actions_model.FindActiveConcurrencyGroups(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionConcurrencyGroups(groups))
+}
+
 func ActionsListRepositoryWorkflows(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/workflows repository ActionsListRepositoryWorkflows
 	// ---
@@ -1050,6 +1258,55 @@ func ActionsDispatchWorkflow(ctx *context.APIContext) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// CreateRepositoryDispatch triggers the repository_dispatch workflows
+func CreateRepositoryDispatch(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/dispatches repository repoCreateDispatch
+	// ---
+	// summary: Create a repository dispatch event triggering the workflows of the default branch
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRepositoryDispatchOption"
+	// responses:
+	//   "204":
+	//     description: No Content
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opt := web.GetForm(ctx).(*api.CreateRepositoryDispatchOption)
+	if err := actions_service.DispatchRepositoryEvent(ctx, ctx.Doer, ctx.Repo.Repository, opt.EventType, opt.ClientPayload); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func ActionsEnableWorkflow(ctx *context.APIContext) {
 	// swagger:operation PUT /repos/{owner}/{repo}/actions/workflows/{workflow_id}/enable repository ActionsEnableWorkflow
 	// ---
@@ -1210,6 +1467,48 @@ func ListWorkflowRunJobs(ctx *context.APIContext) {
 	shared.ListJobs(ctx, 0, repoID, runID)
 }
 
+// GetWorkflowRunGraph gets the graph of the jobs of a workflow run
+func GetWorkflowRunGraph(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/graph repository getWorkflowRunGraph
+	// ---
+	// summary: Gets the jobs of a workflow run with their needs, matrix combinations, runner labels and step timing
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WorkflowRunGraph"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getRunFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	graph, err := convert.ToActionWorkflowRunGraph(ctx, run)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, graph)
+}
+
 // GetWorkflowJob Gets a specific workflow job for a workflow run.
 func GetWorkflowJob(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id} repository getWorkflowJob
@@ -1540,6 +1839,152 @@ func DeleteArtifact(ctx *context.APIContext) {
 	ctx.APIError(http.StatusNotFound, "Artifact not found")
 }
 
+// ListActionCaches lists the caches of a repository
+func ListActionCaches(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/caches repository listActionCaches
+	// ---
+	// summary: Lists the actions caches of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: key
+	//   in: query
+	//   description: key of the caches
+	//   type: string
+	//   required: false
+	// - name: ref
+	//   in: query
+	//   description: the full git ref the caches have been saved for, e.g. refs/heads/main
+	//   type: string
+	//   required: false
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCacheList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := actions_model.FindCacheOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		CacheKey:    ctx.FormString("key"),
+		Complete:    optional.Some(true),
+	}
+	if ref := ctx.FormString("ref"); ref != "" {
+		opts.Scopes = []string{ref}
+	}
+	caches, total, err := db.FindAndCount[actions_model.ActionCache](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	res := &api.ActionCacheList{TotalCount: total, Caches: make([]*api.ActionCache, 0, len(caches))}
+	for _, c := range caches {
+		res.Caches = append(res.Caches, convert.ToActionCache(c))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteActionCache deletes a cache of a repository
+func DeleteActionCache(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/caches/{cache_id} repository deleteActionCache
+	// ---
+	// summary: Deletes an actions cache of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: cache_id
+	//   in: path
+	//   description: id of the cache
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	c, err := actions_model.GetCacheByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("cache_id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if err := actions_service.DeleteCache(ctx, c); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetActionCacheUsage gets the storage consumed by the caches of a repository
+func GetActionCacheUsage(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/cache/usage repository getActionCacheUsage
+	// ---
+	// summary: Gets the actions cache usage of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCacheUsage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	usage, err := actions_model.GetCacheUsage(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.ActionCacheUsage{
+		FullName:                ctx.Repo.Repository.FullName(),
+		ActiveCachesCount:       usage.Count,
+		ActiveCachesSizeInBytes: usage.Size,
+		HitCount:                usage.Hits,
+	})
+}
+
 func buildSignature(endp string, expires, artifactID int64) []byte {
 	mac := hmac.New(sha256.New, setting.GetGeneralTokenSigningSecret())
 	mac.Write([]byte(endp))
@@ -0,0 +1,654 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	secret_service "code.gitea.io/gitea/services/secrets"
+)
+
+// ListActionEnvironments lists the deployment environments of a repository
+func ListActionEnvironments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments repository listActionEnvironments
+	// ---
+	// summary: Lists the deployment environments of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironmentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	envs, total, err := db.FindAndCount[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	res := &api.ActionEnvironmentList{TotalCount: total, Environments: make([]*api.ActionEnvironment, 0, len(envs))}
+	for _, env := range envs {
+		res.Environments = append(res.Environments, convert.ToActionEnvironment(ctx, env))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// getEnvironmentFromPath returns the environment named by the path, nil if the response has been written
+func getEnvironmentFromPath(ctx *context.APIContext) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("environment_name"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return env
+}
+
+// GetActionEnvironment gets a deployment environment of a repository
+func GetActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments/{environment_name} repository getActionEnvironment
+	// ---
+	// summary: Gets a deployment environment of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getEnvironmentFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionEnvironment(ctx, env))
+}
+
+// CreateOrUpdateActionEnvironment creates a deployment environment or updates its protection rules
+func CreateOrUpdateActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/environments/{environment_name} repository createOrUpdateActionEnvironment
+	// ---
+	// summary: Creates a deployment environment or updates its protection rules
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateEnvironmentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateOrUpdateEnvironmentOption)
+	repo := ctx.Repo.Repository
+
+	name := ctx.PathParam("environment_name")
+	if len(name) > 255 {
+		ctx.APIError(http.StatusBadRequest, "environment name is too long")
+		return
+	}
+
+	reviewerIDs, err := user_model.GetUserIDsByNames(ctx, form.Reviewers, false)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+	var reviewerTeamIDs []int64
+	if len(form.ReviewerTeams) > 0 {
+		if err := repo.LoadOwner(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if !repo.Owner.IsOrganization() {
+			ctx.APIError(http.StatusUnprocessableEntity, "only the repositories of organizations can have reviewer teams")
+			return
+		}
+		reviewerTeamIDs, err = organization.GetTeamIDsByNames(ctx, repo.OwnerID, form.ReviewerTeams, false)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+				return
+			}
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	env := &actions_model.ActionEnvironment{
+		RepoID:             repo.ID,
+		Name:               name,
+		WaitTimer:          form.WaitTimer,
+		ReviewerIDs:        reviewerIDs,
+		ReviewerTeamIDs:    reviewerTeamIDs,
+		PreventSelfReview:  form.PreventSelfReview,
+		DeploymentBranches: form.DeploymentBranches,
+	}
+	if err := actions_model.CreateOrUpdateEnvironment(ctx, env); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionEnvironment(ctx, env))
+}
+
+// DeleteActionEnvironment deletes a deployment environment of a repository
+func DeleteActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/environments/{environment_name} repository deleteActionEnvironment
+	// ---
+	// summary: Deletes a deployment environment of a repository with its secrets
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getEnvironmentFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteEnvironment(ctx, env); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListEnvironmentSecrets lists the secrets of a deployment environment
+func ListEnvironmentSecrets(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/environments/{environment_name}/secrets repository listEnvironmentSecrets
+	// ---
+	// summary: Lists the secrets of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getEnvironmentFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	secrets, count, err := db.FindAndCount[secret_model.Secret](ctx, &secret_model.FindSecretsOptions{
+		ListOptions:   utils.GetListOptions(ctx),
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiSecrets := make([]*api.Secret, len(secrets))
+	for k, v := range secrets {
+		apiSecrets[k] = &api.Secret{
+			Name:        v.Name,
+			Description: v.Description,
+			Provider:    v.Provider,
+			Created:     v.CreatedUnix.AsTime(),
+		}
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiSecrets)
+}
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of a deployment environment
+func CreateOrUpdateEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/environments/{environment_name}/secrets/{secretname} repository updateEnvironmentSecret
+	// ---
+	// summary: Create or Update a secret value of a deployment environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateSecretOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a secret
+	//   "204":
+	//     description: response when updating a secret
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getEnvironmentFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
+
+	_, created, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, ctx.Repo.Repository.ID, env.ID, ctx.PathParam("secretname"), opt.Provider, opt.Data, opt.Description)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if created {
+		ctx.Status(http.StatusCreated)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteEnvironmentSecret deletes a secret of a deployment environment
+func DeleteEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/environments/{environment_name}/secrets/{secretname} repository deleteEnvironmentSecret
+	// ---
+	// summary: Delete a secret of a deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: delete one secret of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getEnvironmentFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := secret_service.DeleteEnvironmentSecretByName(ctx, ctx.Repo.Repository.ID, env.ID, ctx.PathParam("secretname")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getRunFromPath returns the run of the repository with the id of the path, nil if the response has been written
+func getRunFromPath(ctx *context.APIContext) *actions_model.ActionRun {
+	run, has, err := db.GetByID[actions_model.ActionRun](ctx, ctx.PathParamInt64("run"))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	if !has || run.RepoID != ctx.Repo.Repository.ID {
+		ctx.APIErrorNotFound(util.ErrNotExist)
+		return nil
+	}
+	run.Repo = ctx.Repo.Repository
+	return run
+}
+
+func toPendingDeployments(ctx *context.APIContext, pending []*actions_service.PendingDeployment) []*api.PendingDeployment {
+	res := make([]*api.PendingDeployment, 0, len(pending))
+	for _, p := range pending {
+		d := &api.PendingDeployment{
+			Environment:           convert.ToActionEnvironment(ctx, p.Environment),
+			CurrentUserCanApprove: p.CanApprove,
+		}
+		if p.WaitTimerUntil > 0 {
+			d.WaitTimerUntil = p.WaitTimerUntil.AsTimePtr()
+		}
+		res = append(res, d)
+	}
+	return res
+}
+
+// ListPendingDeployments lists the environments the jobs of a run wait to be approved for
+func ListPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository listPendingDeployments
+	// ---
+	// summary: Lists the environments the jobs of a workflow run wait to be approved for
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PendingDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getRunFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	pending, err := actions_service.GetPendingDeployments(ctx, run, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toPendingDeployments(ctx, pending))
+}
+
+// ReviewPendingDeployments approves or rejects the jobs of a run deploying to environments
+func ReviewPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository reviewPendingDeployments
+	// ---
+	// summary: Approves or rejects the jobs of a workflow run waiting for the review of environments
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReviewPendingDeploymentsOption"
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	run := getRunFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	form := web.GetForm(ctx).(*api.ReviewPendingDeploymentsOption)
+	if err := actions_service.ReviewDeployments(ctx, run, ctx.Doer, form.EnvironmentIDs, actions_model.DeploymentReviewState(form.State), form.Comment); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.APIError(http.StatusForbidden, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListDeploymentReviews lists the approvals and rejections of the jobs of a run deploying to environments
+func ListDeploymentReviews(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/approvals repository listDeploymentReviews
+	// ---
+	// summary: Lists the reviews of the deployments of a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeploymentReviewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getRunFromPath(ctx)
+	if ctx.Written() {
+		return
+	}
+	reviews, err := actions_model.FindDeploymentReviews(ctx, run.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	envNames := make(map[int64]string)
+	userIDs := make([]int64, 0, len(reviews))
+	for _, review := range reviews {
+		userIDs = append(userIDs, review.ReviewerID)
+		if _, ok := envNames[review.EnvironmentID]; ok {
+			continue
+		}
+		env, err := actions_model.GetEnvironmentByID(ctx, run.RepoID, review.EnvironmentID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if env != nil {
+			envNames[review.EnvironmentID] = env.Name
+		}
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.DeploymentReview, 0, len(reviews))
+	for _, review := range reviews {
+		reviewer, ok := users[review.ReviewerID]
+		if !ok {
+			reviewer = user_model.NewGhostUser()
+		}
+		res = append(res, &api.DeploymentReview{
+			ID:          review.ID,
+			Environment: envNames[review.EnvironmentID],
+			State:       string(review.State),
+			Comment:     review.Comment,
+			User:        convert.ToUser(ctx, reviewer, ctx.Doer),
+			CreatedAt:   review.CreatedUnix.AsLocalTime(),
+		})
+	}
+	ctx.JSON(http.StatusOK, res)
+}
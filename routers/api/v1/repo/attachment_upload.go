@@ -0,0 +1,526 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	quota_model "code.gitea.io/gitea/models/quota"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/setting"
+	attachment_service "code.gitea.io/gitea/services/attachment"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/context/upload"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+	release_service "code.gitea.io/gitea/services/release"
+)
+
+// statusChecksumMismatch is the status defined by the checksum extension of tus for a chunk not matching its checksum
+const statusChecksumMismatch = 460
+
+// checkTusResumable sets the tus headers of the response and checks the client speaks the same protocol version
+func checkTusResumable(ctx *context.APIContext) bool {
+	ctx.Resp.Header().Set("Tus-Resumable", attachment_service.TusResumable)
+	if ctx.Req.Header.Get("Tus-Resumable") != attachment_service.TusResumable {
+		ctx.Resp.Header().Set("Tus-Version", attachment_service.TusResumable)
+		ctx.APIError(http.StatusPreconditionFailed, "unsupported tus version")
+		return false
+	}
+	return true
+}
+
+func setAttachmentUploadHeaders(ctx *context.APIContext, u *repo_model.AttachmentUpload) {
+	ctx.Resp.Header().Set("Upload-Offset", strconv.FormatInt(u.BytesReceived, 10))
+	ctx.Resp.Header().Set("Upload-Length", strconv.FormatInt(u.Size, 10))
+	ctx.Resp.Header().Set("Upload-Expires", u.UpdatedUnix.AsTime().Add(setting.Attachment.ResumableUploadExpiry).UTC().Format(http.TimeFormat))
+}
+
+// parseUploadMetadata parses the comma separated key and base64 encoded value pairs of an Upload-Metadata header
+func parseUploadMetadata(header string) map[string]string {
+	metadata := make(map[string]string)
+	for pair := range strings.SplitSeq(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		metadata[key] = string(decoded)
+	}
+	return metadata
+}
+
+// createAttachmentUpload starts the resumable upload of an attachment from a tus creation request
+func createAttachmentUpload(ctx *context.APIContext, u *repo_model.AttachmentUpload) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	if ctx.Req.Header.Get("Upload-Defer-Length") != "" {
+		ctx.APIError(http.StatusBadRequest, "deferred upload length is not supported")
+		return
+	}
+	size, err := strconv.ParseInt(ctx.Req.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || size < 0 {
+		ctx.APIError(http.StatusBadRequest, "invalid Upload-Length")
+		return
+	}
+	if size > setting.Attachment.MaxSize<<20 {
+		ctx.Resp.Header().Set("Tus-Max-Size", strconv.FormatInt(setting.Attachment.MaxSize<<20, 10))
+		ctx.APIError(http.StatusRequestEntityTooLarge, "upload is larger than the maximum size of attachments")
+		return
+	}
+
+	metadata := parseUploadMetadata(ctx.Req.Header.Get("Upload-Metadata"))
+	u.Name = metadata["filename"]
+	if name := ctx.FormString("name"); name != "" {
+		u.Name = name
+	}
+	if u.Name == "" {
+		ctx.APIError(http.StatusBadRequest, "Could not determine name of attachment.")
+		return
+	}
+	u.Size = size
+	u.RepoID = ctx.Repo.Repository.ID
+	u.UploaderID = ctx.Doer.ID
+
+	if err := attachment_service.CreateResumableUpload(ctx, u); err != nil {
+		if upload.IsErrFileTypeForbidden(err) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else if quota_model.IsErrQuotaExceeded(err) {
+			ctx.APIError(http.StatusRequestEntityTooLarge, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	setAttachmentUploadHeaders(ctx, u)
+	ctx.Resp.Header().Set("Location", ctx.Repo.Repository.APIURL()+"/attachment-uploads/"+u.ID)
+	ctx.Status(http.StatusCreated)
+}
+
+// CreateReleaseAttachmentUpload starts a resumable upload of a release attachment
+func CreateReleaseAttachmentUpload(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/releases/{id}/assets/uploads repository repoCreateReleaseAttachmentUpload
+	// ---
+	// summary: Start a resumable upload of a release attachment using the tus protocol
+	// description: The upload is continued by the requests sent to the url of the `Location` header.
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the release
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: name
+	//   in: query
+	//   description: name of the attachment, defaults to the `filename` of the `Upload-Metadata` header
+	//   type: string
+	//   required: false
+	// - name: Tus-Resumable
+	//   in: header
+	//   description: version of the tus protocol, must be `1.0.0`
+	//   type: string
+	//   required: true
+	// - name: Upload-Length
+	//   in: header
+	//   description: size of the attachment in bytes
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: Upload-Metadata
+	//   in: header
+	//   description: comma separated keys and base64 encoded values, e.g. the `filename`
+	//   type: string
+	//   required: false
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "412":
+	//     "$ref": "#/responses/error"
+	//   "413":
+	//     "$ref": "#/responses/error"
+
+	if !setting.Attachment.Enabled {
+		ctx.APIErrorNotFound("Attachment is not enabled")
+		return
+	}
+
+	releaseID := ctx.PathParamInt64("id")
+	if !checkReleaseMatchRepo(ctx, releaseID) {
+		return
+	}
+
+	createAttachmentUpload(ctx, &repo_model.AttachmentUpload{ReleaseID: releaseID})
+}
+
+// CreateIssueAttachmentUpload starts a resumable upload of an issue attachment
+func CreateIssueAttachmentUpload(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/assets/uploads issue issueCreateIssueAttachmentUpload
+	// ---
+	// summary: Start a resumable upload of an issue attachment using the tus protocol
+	// description: The upload is continued by the requests sent to the url of the `Location` header.
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: name
+	//   in: query
+	//   description: name of the attachment, defaults to the `filename` of the `Upload-Metadata` header
+	//   type: string
+	//   required: false
+	// - name: Tus-Resumable
+	//   in: header
+	//   description: version of the tus protocol, must be `1.0.0`
+	//   type: string
+	//   required: true
+	// - name: Upload-Length
+	//   in: header
+	//   description: size of the attachment in bytes
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: Upload-Metadata
+	//   in: header
+	//   description: comma separated keys and base64 encoded values, e.g. the `filename`
+	//   type: string
+	//   required: false
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/error"
+	//   "412":
+	//     "$ref": "#/responses/error"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	issue := getIssueFromContext(ctx)
+	if issue == nil {
+		return
+	}
+
+	if !canUserWriteIssueAttachment(ctx, issue) {
+		return
+	}
+
+	createAttachmentUpload(ctx, &repo_model.AttachmentUpload{IssueID: issue.ID})
+}
+
+// getAttachmentUploadFromContext loads the resumable upload of the path, which is only visible to its uploader
+func getAttachmentUploadFromContext(ctx *context.APIContext) *repo_model.AttachmentUpload {
+	u, err := repo_model.GetAttachmentUploadByID(ctx, ctx.PathParam("upload_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return nil
+	}
+	if u.RepoID != ctx.Repo.Repository.ID || u.UploaderID != ctx.Doer.ID {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	return u
+}
+
+// canUserWriteAttachmentUpload checks that the uploader can still write the release or the issue of the upload, it's
+// checked for every chunk, so an uploader whose access has been revoked can't finish the upload
+func canUserWriteAttachmentUpload(ctx *context.APIContext, u *repo_model.AttachmentUpload) (*issues_model.Issue, bool) {
+	if u.ReleaseID != 0 {
+		if !ctx.Repo.CanWrite(unit.TypeReleases) {
+			ctx.APIError(http.StatusForbidden, "user should have permission to write releases")
+			return nil, false
+		}
+		return nil, checkReleaseMatchRepo(ctx, u.ReleaseID)
+	}
+
+	// the upload route has no issue index, the issue is the one the upload was created for
+	issue, err := issues_model.GetIssueByID(ctx, u.IssueID)
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return nil, false
+	}
+	if issue.RepoID != ctx.Repo.Repository.ID {
+		ctx.APIErrorNotFound()
+		return nil, false
+	}
+	issue.Repo = ctx.Repo.Repository
+	return issue, canUserWriteIssueAttachment(ctx, issue)
+}
+
+// GetAttachmentUpload returns the progress of a resumable attachment upload
+func GetAttachmentUpload(ctx *context.APIContext) {
+	// swagger:operation HEAD /repos/{owner}/{repo}/attachment-uploads/{upload_id} repository repoGetAttachmentUpload
+	// ---
+	// summary: Get the offset of a resumable attachment upload from its `Upload-Offset` header
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: upload_id
+	//   in: path
+	//   description: id of the upload
+	//   type: string
+	//   required: true
+	// - name: Tus-Resumable
+	//   in: header
+	//   description: version of the tus protocol, must be `1.0.0`
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "412":
+	//     "$ref": "#/responses/error"
+
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	u := getAttachmentUploadFromContext(ctx)
+	if u == nil {
+		return
+	}
+
+	setAttachmentUploadHeaders(ctx, u)
+	ctx.Resp.Header().Set("Cache-Control", "no-store")
+	ctx.Status(http.StatusOK)
+}
+
+// AppendAttachmentUpload appends a chunk to a resumable attachment upload
+func AppendAttachmentUpload(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/attachment-uploads/{upload_id} repository repoAppendAttachmentUpload
+	// ---
+	// summary: Append a chunk to a resumable attachment upload
+	// description: The attachment is created and returned once all its bytes have been received.
+	// consumes:
+	// - application/offset+octet-stream
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: upload_id
+	//   in: path
+	//   description: id of the upload
+	//   type: string
+	//   required: true
+	// - name: Tus-Resumable
+	//   in: header
+	//   description: version of the tus protocol, must be `1.0.0`
+	//   type: string
+	//   required: true
+	// - name: Upload-Offset
+	//   in: header
+	//   description: offset of the chunk, which must be the offset returned by the last request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: Upload-Checksum
+	//   in: header
+	//   description: algorithm (`md5`, `sha1` or `sha256`) and base64 encoded checksum of the chunk
+	//   type: string
+	//   required: false
+	// - name: body
+	//   in: body
+	//   schema:
+	//     type: string
+	//     format: binary
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Attachment"
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "412":
+	//     "$ref": "#/responses/error"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "415":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	if ctx.Req.Header.Get("Content-Type") != "application/offset+octet-stream" {
+		ctx.APIError(http.StatusUnsupportedMediaType, "chunks must be sent as application/offset+octet-stream")
+		return
+	}
+	offset, err := strconv.ParseInt(ctx.Req.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		ctx.APIError(http.StatusBadRequest, "invalid Upload-Offset")
+		return
+	}
+
+	u := getAttachmentUploadFromContext(ctx)
+	if u == nil {
+		return
+	}
+	issue, ok := canUserWriteAttachmentUpload(ctx, u)
+	if !ok {
+		return
+	}
+
+	attach, err := attachment_service.AppendResumableUpload(ctx, u, offset, ctx.Req.Body, ctx.Req.Header.Get("Upload-Checksum"))
+	setAttachmentUploadHeaders(ctx, u)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo_model.ErrAttachmentUploadNotExist):
+			// the upload has been completed or terminated by another request
+			ctx.APIErrorNotFound()
+		case errors.Is(err, attachment_service.ErrUploadOffsetMismatch):
+			ctx.APIError(http.StatusConflict, err)
+		case errors.Is(err, attachment_service.ErrUploadChecksumMismatch):
+			ctx.APIError(statusChecksumMismatch, err)
+		case errors.Is(err, attachment_service.ErrUploadLengthExceeded):
+			ctx.APIError(http.StatusRequestEntityTooLarge, err)
+		case upload.IsErrFileTypeForbidden(err), errors.Is(err, attachment_service.ErrUploadChecksumAlgorithm):
+			ctx.APIError(http.StatusBadRequest, err)
+		case quota_model.IsErrQuotaExceeded(err):
+			ctx.APIError(http.StatusRequestEntityTooLarge, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if attach == nil {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	if attach.ReleaseID != 0 {
+		release_service.EnqueueAssetPublish(ctx, ctx.Repo.Repository, attach.ReleaseID)
+	}
+
+	if issue != nil {
+		issue.Attachments = append(issue.Attachments, attach)
+
+		if err := issue_service.ChangeContent(ctx, issue, ctx.Doer, issue.Content, issue.ContentVersion); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIAttachment(ctx.Repo.Repository, attach))
+}
+
+// DeleteAttachmentUpload aborts a resumable attachment upload
+func DeleteAttachmentUpload(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/attachment-uploads/{upload_id} repository repoDeleteAttachmentUpload
+	// ---
+	// summary: Abort a resumable attachment upload
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: upload_id
+	//   in: path
+	//   description: id of the upload
+	//   type: string
+	//   required: true
+	// - name: Tus-Resumable
+	//   in: header
+	//   description: version of the tus protocol, must be `1.0.0`
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "412":
+	//     "$ref": "#/responses/error"
+
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	u := getAttachmentUploadFromContext(ctx)
+	if u == nil {
+		return
+	}
+
+	if err := attachment_service.RemoveResumableUpload(ctx, u.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -5,11 +5,13 @@ package repo
 
 import (
 	"encoding/base64"
+	"errors"
 	"net/http"
 
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
 
@@ -40,6 +42,8 @@ func UpdateAvatar(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	form := web.GetForm(ctx).(*api.UpdateRepoAvatarOption)
 
 	content, err := base64.StdEncoding.DecodeString(form.Image)
@@ -50,7 +54,12 @@ func UpdateAvatar(ctx *context.APIContext) {
 
 	err = repo_service.UploadAvatar(ctx, ctx.Repo.Repository, content)
 	if err != nil {
+		if errors.Is(err, malwarescan_service.ErrMalwareFound) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
 		ctx.APIErrorInternal(err)
+		return
 	}
 
 	ctx.Status(http.StatusNoContent)
@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListDependencies lists the dependencies of the default branch of a repository
+func ListDependencies(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/dependencies repository repoListDependencies
+	// ---
+	// summary: List the dependencies declared by the manifests (go.mod, package.json, requirements.txt, pom.xml, Cargo.toml) and found in the lockfiles of the default branch
+	// description: The dependency graph is updated in the background after a push to the default branch.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ecosystem
+	//   in: query
+	//   description: ecosystem of the dependencies
+	//   type: string
+	//   enum: [golang, npm, pypi, cargo, maven]
+	// - name: q
+	//   in: query
+	//   description: keyword of the names of the dependencies
+	//   type: string
+	// - name: direct
+	//   in: query
+	//   description: only list the dependencies declared by the manifests
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoDependencyList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	deps, count, err := db.FindAndCount[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+		Ecosystem:   ctx.FormTrim("ecosystem"),
+		Keyword:     strings.ToLower(ctx.FormTrim("q")),
+		DirectOnly:  ctx.FormBool("direct"),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeps := make([]*api.RepoDependency, 0, len(deps))
+	for _, dep := range deps {
+		apiDeps = append(apiDeps, convert.ToRepoDependency(dep))
+	}
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeps)
+}
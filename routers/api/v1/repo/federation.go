@@ -0,0 +1,159 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	federation_service "code.gitea.io/gitea/services/federation"
+)
+
+// CreateFederatedFork forks a repository of another instance
+func CreateFederatedFork(ctx *context.APIContext) {
+	// swagger:operation POST /repos/federated-forks repository createFederatedFork
+	// ---
+	// summary: Fork a repository of another instance, whose pull requests are then offered to the origin repository over ActivityPub
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateFederatedForkOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Repository"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: The repository with the same name already exists.
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Federation.Enabled {
+		ctx.APIErrorNotFound()
+		return
+	}
+	form := web.GetForm(ctx).(*api.CreateFederatedForkOption)
+
+	owner := ctx.Doer
+	if form.Organization != nil {
+		org, err := organization.GetOrgByName(ctx, *form.Organization)
+		if err != nil {
+			if organization.IsErrOrgNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		if !ctx.Doer.IsAdmin {
+			isMember, err := org.IsOrgMember(ctx, ctx.Doer.ID)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			} else if !isMember {
+				ctx.APIError(http.StatusForbidden, fmt.Sprintf("User is no Member of Organisation '%s'", org.Name))
+				return
+			}
+		}
+		owner = org.AsUser()
+	}
+
+	repo, err := federation_service.ForkRemoteRepository(ctx, ctx.Doer, owner, form.OriginURL, form.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrAlreadyExist) || repo_model.IsErrReachLimitOfRepo(err):
+			ctx.APIError(http.StatusConflict, err)
+		case errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) || git.IsErrInvalidCloneAddr(err):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		default:
+			ctx.APIErrorInternal(util.SanitizeErrorCredentialURLs(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}))
+}
+
+// CreateFederatedPullRequest offers a pull request to the origin of a repository forked from another instance
+func CreateFederatedPullRequest(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/federated-pulls repository repoCreateFederatedPullRequest
+	// ---
+	// summary: Offer a pull request to the origin of a repository forked from another instance
+	// description: The pull request is tracked by the returned issue, the comments of the pull request are posted to the issue and
+	//   the comments of the issue are posted to the pull request.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateFederatedPullRequestOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if !setting.Federation.Enabled {
+		ctx.APIErrorNotFound()
+		return
+	}
+	form := web.GetForm(ctx).(*api.CreateFederatedPullRequestOption)
+
+	issue, err := federation_service.SendPullRequest(ctx, ctx.Doer, ctx.Repo.Repository, federation_service.SendPullRequestOptions{
+		HeadBranch: form.Head,
+		BaseBranch: form.Base,
+		Title:      form.Title,
+		Content:    form.Body,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user_model.ErrBlockedUser):
+			ctx.APIError(http.StatusForbidden, err)
+		case errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) || git.IsErrBranchNotExist(err):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, issue))
+}
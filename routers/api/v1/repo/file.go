@@ -204,6 +204,9 @@ func GetRawFileOrLFS(ctx *context.APIContext) {
 	if httpcache.HandleGenericETagCache(ctx.Req, ctx.Resp, `"`+pointer.Oid+`"`) {
 		return
 	}
+	if err := git_model.UpdateLFSObjectAccessed(ctx, pointer.Oid); err != nil {
+		log.Error("UpdateLFSObjectAccessed: %v", err)
+	}
 
 	if setting.LFS.Storage.ServeDirect() {
 		// If we have a signed url (S3, object storage), redirect to this directly.
@@ -761,6 +764,8 @@ func GetContentsExt(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/ContentsExtResponse"
+	//   "304":
+	//     description: Not modified since the ETag or the time of the conditional request
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
@@ -786,7 +791,11 @@ func GetContentsExt(ctx *context.APIContext) {
 			return
 		}
 	}
-	ctx.JSON(http.StatusOK, getRepoContents(ctx, opts))
+	ret := getRepoContents(ctx, opts)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, ret)
 }
 
 func GetContents(ctx *context.APIContext) {
@@ -820,6 +829,8 @@ func GetContents(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/ContentsResponse"
+	//   "304":
+	//     description: Not modified since the ETag or the time of the conditional request
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	ret := getRepoContents(ctx, files_service.GetContentsOrListOptions{
@@ -838,6 +849,11 @@ func getRepoContents(ctx *context.APIContext, opts files_service.GetContentsOrLi
 	if ctx.Written() {
 		return nil
 	}
+	// the contents are determined by the commit, the ref is part of the ETag because the links contain it
+	etag := httpcache.GenerateWeakETag(ctx.Repo.Repository.FullName(), refCommit.InputRef, refCommit.CommitID, opts)
+	if httpcache.HandleConditionalRequest(ctx.Req, ctx.Resp, etag, time.Time{}) {
+		return nil
+	}
 	ret, err := files_service.GetContentsOrList(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, refCommit, opts)
 	if err != nil {
 		if git.IsErrNotExist(err) {
@@ -875,6 +891,8 @@ func GetContentsList(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/ContentsListResponse"
+	//   "304":
+	//     description: Not modified since the ETag or the time of the conditional request
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
@@ -6,6 +6,7 @@ package repo
 
 import (
 	"net/http"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/perm"
@@ -14,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/web"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 	"code.gitea.io/gitea/routers/api/v1/utils"
@@ -122,6 +124,162 @@ func GetHook(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, apiHook)
 }
 
+// ListHookDeliveries list the deliveries of a repo's hook
+func ListHookDeliveries(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/hooks/{id}/deliveries repository repoListHookDeliveries
+	// ---
+	// summary: List the deliveries of a hook
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: status
+	//   in: query
+	//   description: only show the deliveries with this status
+	//   type: string
+	//   enum: [pending, succeeded, failed, dead_letter]
+	// - name: since
+	//   in: query
+	//   description: only show the deliveries attempted at or after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only show the deliveries attempted before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	hook, err := utils.GetRepoHook(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		return
+	}
+
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	status := webhook.HookTaskStatus(ctx.FormString("status"))
+	if status != "" && !status.IsValid() {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid status")
+		return
+	}
+
+	tasks, count, err := db.FindAndCount[webhook.HookTask](ctx, webhook.FindHookTaskOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		HookID:      hook.ID,
+		Status:      status,
+		Since:       timeutil.TimeStampNano(since * int64(time.Second)),
+		Before:      timeutil.TimeStampNano(before * int64(time.Second)),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeliveries := make([]*api.HookDelivery, 0, len(tasks))
+	for _, t := range tasks {
+		apiDeliveries = append(apiDeliveries, webhook_service.ToHookDelivery(t))
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeliveries)
+}
+
+// RedeliverHookDeliveries redelivers the dead-lettered deliveries of a repo's hook
+func RedeliverHookDeliveries(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/hooks/{id}/deliveries/redeliver repository repoRedeliverHookDeliveries
+	// ---
+	// summary: Redeliver the dead-lettered deliveries of a hook, at most 1000 deliveries are redelivered at once
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RedeliverHookDeliveriesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := utils.GetRepoHook(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.RedeliverHookDeliveriesOption)
+
+	var since, before timeutil.TimeStampNano
+	if !form.Since.IsZero() {
+		since = timeutil.TimeStampNano(form.Since.UnixNano())
+	}
+	if !form.Before.IsZero() {
+		before = timeutil.TimeStampNano(form.Before.UnixNano())
+	}
+
+	tasks, err := webhook_service.RedeliverHookTasks(ctx, hook, since, before)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeliveries := make([]*api.HookDelivery, 0, len(tasks))
+	for _, t := range tasks {
+		apiDeliveries = append(apiDeliveries, webhook_service.ToHookDelivery(t))
+	}
+	ctx.JSON(http.StatusOK, apiDeliveries)
+}
+
 // TestHook tests a hook
 func TestHook(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/hooks/{id}/tests repository repoTestHook
@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/mailer/incoming"
+)
+
+func toIncomingEmailAlias(alias *repo_model.IncomingEmailAlias) *api.IncomingEmailAlias {
+	return &api.IncomingEmailAlias{
+		Alias:   alias.Alias,
+		Address: incoming.IssueAddress(alias.Alias),
+	}
+}
+
+// GetIncomingEmailAlias returns the address emails creating issues in a repository are sent to
+func GetIncomingEmailAlias(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/incoming_email repository repoGetIncomingEmailAlias
+	// ---
+	// summary: Get the address emails creating issues in a repository are sent to
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IncomingEmailAlias"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	alias, err := repo_model.GetIncomingEmailAlias(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toIncomingEmailAlias(alias))
+}
+
+// EditIncomingEmailAlias sets the address emails creating issues in a repository are sent to
+func EditIncomingEmailAlias(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/incoming_email repository repoEditIncomingEmailAlias
+	// ---
+	// summary: Set the address emails creating issues in a repository are sent to
+	// description: Only the users who can read the issues of the repository can create issues by email.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIncomingEmailAliasOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IncomingEmailAlias"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIncomingEmailAliasOption)
+	alias, err := repo_model.SetIncomingEmailAlias(ctx, ctx.Repo.Repository.ID, form.Alias)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, toIncomingEmailAlias(alias))
+}
+
+// DeleteIncomingEmailAlias stops the creation of issues by email in a repository
+func DeleteIncomingEmailAlias(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/incoming_email repository repoDeleteIncomingEmailAlias
+	// ---
+	// summary: Stop the creation of issues by email in a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := repo_model.DeleteIncomingEmailAlias(ctx, ctx.Repo.Repository.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"xorm.io/builder"
+)
+
+// getContributorInsights returns the contributor statistics of the repository, false is returned if the response is written
+func getContributorInsights(ctx *context.APIContext) ([]*api.ContributorStats, []*api.WeeklyCommitStats, bool) {
+	contributors, total, err := repo_service.GetContributorInsights(ctx, ctx.Cache, []*repo_model.Repository{ctx.Repo.Repository})
+	if errors.Is(err, repo_service.ErrAwaitGeneration) {
+		// the statistics are generated in the background, the client should retry later
+		ctx.Status(http.StatusAccepted)
+		return nil, nil, false
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil, false
+	}
+	return contributors, total, true
+}
+
+// GetContributorInsights returns the weekly commit statistics of the contributors of a repository
+func GetContributorInsights(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/insights/contributors repository repoGetContributorInsights
+	// ---
+	// summary: Get the weekly commits, additions and deletions of the contributors of the default branch
+	// description: The statistics are generated in the background, the response is 202 while they are generated.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ContributorStatsList"
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	contributors, _, ok := getContributorInsights(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, contributors)
+}
+
+// GetCodeFrequencyInsights returns the weekly additions and deletions of a repository
+func GetCodeFrequencyInsights(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/insights/code-frequency repository repoGetCodeFrequencyInsights
+	// ---
+	// summary: Get the weekly commits, additions and deletions of the default branch
+	// description: The statistics are generated in the background, the response is 202 while they are generated.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WeeklyCommitStatsList"
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	_, total, ok := getContributorInsights(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, total)
+}
+
+func repoInsightsScope(repo *repo_model.Repository) repo_service.InsightsScope {
+	return repo_service.InsightsScope{
+		Key:      "repo-" + strconv.FormatInt(repo.ID, 10),
+		RepoCond: builder.Eq{"issue.repo_id": repo.ID},
+	}
+}
+
+// GetPullRequestInsights returns the pull request metrics of a repository
+func GetPullRequestInsights(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/insights/pulls repository repoGetPullRequestInsights
+	// ---
+	// summary: Get the pull request lead time, review turnaround and counts of a period
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: Start of the period, 90 days before the end by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: End of the period, the next full hour by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestInsights"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	since, before, ok := utils.GetInsightsPeriod(ctx)
+	if !ok {
+		return
+	}
+	insights, err := repo_service.GetPullRequestInsights(ctx, ctx.Cache, repoInsightsScope(ctx.Repo.Repository), since, before)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, insights)
+}
+
+// GetIssueInsights returns the issue metrics of a repository
+func GetIssueInsights(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/insights/issues repository repoGetIssueInsights
+	// ---
+	// summary: Get the issue close rate, time to close and counts of a period
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: Start of the period, 90 days before the end by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: End of the period, the next full hour by default. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueInsights"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	since, before, ok := utils.GetInsightsPeriod(ctx)
+	if !ok {
+		return
+	}
+	insights, err := repo_service.GetIssueInsights(ctx, ctx.Cache, repoInsightsScope(ctx.Repo.Repository), since, before)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, insights)
+}
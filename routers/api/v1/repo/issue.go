@@ -5,9 +5,11 @@
 package repo
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,17 +21,23 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/httpcache"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	issue_template "code.gitea.io/gitea/modules/issue/template"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	issue_service "code.gitea.io/gitea/services/issue"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+	spamdetection_service "code.gitea.io/gitea/services/spamdetection"
 )
 
 // SearchIssues searches for issues across the repositories that the user has access to
@@ -390,6 +398,13 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: field
+	//   in: query
+	//   description: Only show items having the value of a custom field, formatted as name:value. A user is given by the username and a date as YYYY-MM-DD
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -403,10 +418,154 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	searchOpt := prepareListIssuesSearchOptions(ctx)
+	if ctx.Written() {
+		return
+	}
+	listOptions := utils.GetListOptions(ctx)
+	searchOpt.Paginator = &listOptions
+
+	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+}
+
+// ExportIssues export the issues of a repository as CSV
+func ExportIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/export issue issueExportIssues
+	// ---
+	// summary: Export a repository's issues as CSV, including the values of their custom fields
+	// produces:
+	// - text/csv
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: whether issue is open or closed
+	//   type: string
+	//   enum: [closed, open, all]
+	// - name: labels
+	//   in: query
+	//   description: comma separated list of label names. Fetch only issues that have any of this label names. Non existent labels are discarded.
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: search string
+	//   type: string
+	// - name: type
+	//   in: query
+	//   description: filter by type (issues / pulls) if set
+	//   type: string
+	//   enum: [issues, pulls]
+	// - name: milestones
+	//   in: query
+	//   description: comma separated list of milestone names or ids. It uses names and fall back to ids. Fetch only issues that have any of this milestones. Non existent milestones are discarded
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: Only show items updated after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	//   required: false
+	// - name: before
+	//   in: query
+	//   description: Only show items updated before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	//   required: false
+	// - name: created_by
+	//   in: query
+	//   description: Only show items which were created by the given user
+	//   type: string
+	// - name: assigned_by
+	//   in: query
+	//   description: Only show items for which the given user is assigned
+	//   type: string
+	// - name: mentioned_by
+	//   in: query
+	//   description: Only show items in which the given user was mentioned
+	//   type: string
+	// - name: field
+	//   in: query
+	//   description: Only show items having the value of a custom field, formatted as name:value. A user is given by the username and a date as YYYY-MM-DD
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// responses:
+	//   "200":
+	//     description: the issues as CSV
+	//     schema:
+	//       type: string
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	searchOpt := prepareListIssuesSearchOptions(ctx)
+	if ctx.Written() {
+		return
+	}
+	searchOpt.Paginator = &db.ListOptions{PageSize: setting.API.MaxResponseItems}
+
+	var issues issues_model.IssueList
+	for page := 1; ; page++ {
+		searchOpt.Paginator.Page = page
+		ids, _, err := issue_indexer.SearchIssues(ctx, searchOpt)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		pageIssues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		issues = append(issues, pageIssues...)
+		if len(ids) < searchOpt.Paginator.PageSize {
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := issue_service.WriteIssuesCSV(ctx, &buf, ctx.Repo.Repository, issues); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-issues.csv"`, ctx.Repo.Repository.Name))
+	ctx.Resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(buf.Bytes())
+}
+
+// prepareListIssuesSearchOptions returns the options to search the issues of the repository from the query parameters
+func prepareListIssuesSearchOptions(ctx *context.APIContext) *issue_indexer.SearchOptions {
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.APIError(http.StatusUnprocessableEntity, err)
-		return
+		return nil
 	}
 
 	var isClosed optional.Option[bool]
@@ -429,7 +588,7 @@ func ListIssues(ctx *context.APIContext) {
 		labelIDs, err = issues_model.GetLabelIDsInRepoByNames(ctx, ctx.Repo.Repository.ID, splitted)
 		if err != nil {
 			ctx.APIErrorInternal(err)
-			return
+			return nil
 		}
 	}
 
@@ -445,7 +604,7 @@ func ListIssues(ctx *context.APIContext) {
 			}
 			if !issues_model.IsErrMilestoneNotExist(err) {
 				ctx.APIErrorInternal(err)
-				return
+				return nil
 			}
 			id, err := strconv.ParseInt(part[i], 10, 64)
 			if err != nil {
@@ -463,8 +622,6 @@ func ListIssues(ctx *context.APIContext) {
 		}
 	}
 
-	listOptions := utils.GetListOptions(ctx)
-
 	isPull := optional.None[bool]()
 	switch ctx.FormString("type") {
 	case "pulls":
@@ -475,7 +632,7 @@ func ListIssues(ctx *context.APIContext) {
 
 	if isPull.Has() && !ctx.Repo.CanReadIssuesOrPulls(isPull.Value()) {
 		ctx.APIErrorNotFound()
-		return
+		return nil
 	}
 
 	if !isPull.Has() {
@@ -483,7 +640,7 @@ func ListIssues(ctx *context.APIContext) {
 		canReadPulls := ctx.Repo.CanRead(unit.TypePullRequests)
 		if !canReadIssues && !canReadPulls {
 			ctx.APIErrorNotFound()
-			return
+			return nil
 		} else if !canReadIssues {
 			isPull = optional.Some(true)
 		} else if !canReadPulls {
@@ -494,24 +651,23 @@ func ListIssues(ctx *context.APIContext) {
 	// FIXME: we should be more efficient here
 	createdByID := getUserIDForFilter(ctx, "created_by")
 	if ctx.Written() {
-		return
+		return nil
 	}
 	assignedByID := getUserIDForFilter(ctx, "assigned_by")
 	if ctx.Written() {
-		return
+		return nil
 	}
 	mentionedByID := getUserIDForFilter(ctx, "mentioned_by")
 	if ctx.Written() {
-		return
+		return nil
 	}
 
 	searchOpt := &issue_indexer.SearchOptions{
-		Paginator: &listOptions,
-		Keyword:   keyword,
-		RepoIDs:   []int64{ctx.Repo.Repository.ID},
-		IsPull:    isPull,
-		IsClosed:  isClosed,
-		SortBy:    issue_indexer.SortByCreatedDesc,
+		Keyword:  keyword,
+		RepoIDs:  []int64{ctx.Repo.Repository.ID},
+		IsPull:   isPull,
+		IsClosed: isClosed,
+		SortBy:   issue_indexer.SortByCreatedDesc,
 	}
 	if since != 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(since)
@@ -547,20 +703,22 @@ func ListIssues(ctx *context.APIContext) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
-	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
-	if err != nil {
-		ctx.APIErrorInternal(err)
-		return
-	}
-	issues, err := issues_model.GetIssuesByIDs(ctx, ids, true)
+	searchOpt.FieldValues, err = issue_service.ParseIssueFieldFilters(ctx, ctx.Repo.Repository, ctx.FormStrings("field"))
 	if err != nil {
-		ctx.APIErrorInternal(err)
-		return
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
 	}
 
-	ctx.SetLinkHeader(int(total), listOptions.PageSize)
-	ctx.SetTotalCountHeader(total)
-	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+	return searchOpt
+}
+
+// hideModeratedContent checks whether the comments hidden by a moderator are left out for the doer
+func hideModeratedContent(ctx *context.APIContext) bool {
+	return !moderation_service.CanSeeHiddenContent(ctx.Doer, &ctx.Repo.Permission)
 }
 
 func getUserIDForFilter(ctx *context.APIContext, queryName string) int64 {
@@ -610,10 +768,12 @@ func GetIssue(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Issue"
+	//   "304":
+	//     description: Not modified since the ETag or the time of the conditional request
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	issue, err := issues_model.GetIssueWithAttrsByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
 			ctx.APIErrorNotFound()
@@ -626,6 +786,22 @@ func GetIssue(ctx *context.APIContext) {
 		ctx.APIErrorNotFound()
 		return
 	}
+
+	// the changes and the new comments of the issue update its timestamp,
+	// the doer is part of the ETag because the visibility of the emails of the users depends on it
+	var doerID int64
+	if ctx.Doer != nil {
+		doerID = ctx.Doer.ID
+	}
+	etag := httpcache.GenerateWeakETag(issue.ID, ctx.Repo.Repository.FullName(), issue.UpdatedUnix, issue.NumComments, doerID)
+	if httpcache.HandleConditionalRequest(ctx.Req, ctx.Resp, etag, issue.UpdatedUnix.AsTime()) {
+		return
+	}
+
+	if err := issue.LoadAttributes(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
 	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
@@ -656,6 +832,8 @@ func CreateIssue(ctx *context.APIContext) {
 	// responses:
 	//   "201":
 	//     "$ref": "#/responses/Issue"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "403":
 	//     "$ref": "#/responses/forbidden"
 	//   "404":
@@ -668,6 +846,14 @@ func CreateIssue(ctx *context.APIContext) {
 	//     "$ref": "#/responses/repoArchivedError"
 
 	form := web.GetForm(ctx).(*api.CreateIssueOption)
+	content := form.Body
+	if form.Template != "" {
+		content = renderIssueForm(ctx, form)
+		if ctx.Written() {
+			return
+		}
+	}
+
 	var deadlineUnix timeutil.TimeStamp
 	if form.Deadline != nil && ctx.Repo.CanWrite(unit.TypeIssues) {
 		deadlineUnix = timeutil.TimeStamp(form.Deadline.Unix())
@@ -679,7 +865,7 @@ func CreateIssue(ctx *context.APIContext) {
 		Title:        form.Title,
 		PosterID:     ctx.Doer.ID,
 		Poster:       ctx.Doer,
-		Content:      form.Body,
+		Content:      content,
 		Ref:          form.Ref,
 		DeadlineUnix: deadlineUnix,
 	}
@@ -721,6 +907,15 @@ func CreateIssue(ctx *context.APIContext) {
 		form.Labels = make([]int64, 0)
 	}
 
+	if held, err := spamdetection_service.HoldIssueIfSuspicious(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs, 0); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if held {
+		// the issue is published once it has been approved by an admin
+		ctx.Status(http.StatusAccepted)
+		return
+	}
+
 	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs, 0); err != nil {
 		if repo_model.IsErrUserDoesNotHaveAccessToRepo(err) {
 			ctx.APIError(http.StatusBadRequest, err)
@@ -752,6 +947,40 @@ func CreateIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
+// renderIssueForm validates the values of the form template of the options and renders the body of the issue
+func renderIssueForm(ctx *context.APIContext, form *api.CreateIssueOption) string {
+	if form.Body != "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "the body of an issue created from a template can't be given")
+		return ""
+	}
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.APIError(http.StatusUnprocessableEntity, "the repository has no issue templates")
+		return ""
+	}
+
+	gitRepo, err := gitrepo.RepositoryFromRequestContextOrOpen(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return ""
+	}
+	templates := issue_service.ParseTemplatesFromDefaultBranch(ctx.Repo.Repository, gitRepo).IssueTemplates
+	idx := slices.IndexFunc(templates, func(t *api.IssueTemplate) bool { return t.FileName == form.Template })
+	if idx < 0 || templates[idx].Type() != api.IssueTemplateTypeYaml {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("%q isn't an issue form template of the repository", form.Template))
+		return ""
+	}
+
+	values, err := issue_template.ValuesFromAPI(templates[idx], form.TemplateValues)
+	if err == nil {
+		err = issue_template.ValidateValues(templates[idx], values)
+	}
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return ""
+	}
+	return issue_template.RenderToMarkdown(templates[idx], values)
+}
+
 // EditIssue modify an issue of a repository
 func EditIssue(ctx *context.APIContext) {
 	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index} issue issueEditIssue
@@ -785,6 +1014,8 @@ func EditIssue(ctx *context.APIContext) {
 	// responses:
 	//   "201":
 	//     "$ref": "#/responses/Issue"
+	//   "202":
+	//     "$ref": "#/responses/Issue"
 	//   "403":
 	//     "$ref": "#/responses/forbidden"
 	//   "404":
@@ -816,14 +1047,28 @@ func EditIssue(ctx *context.APIContext) {
 		return
 	}
 
+	title, content := issue.Title, issue.Content
 	if len(form.Title) > 0 {
+		title = form.Title
+	}
+	if form.Body != nil {
+		content = *form.Body
+	}
+	// the other changes are applied while the new title and content are awaiting the approval of an admin
+	held, err := spamdetection_service.HoldIssueEditIfSuspicious(ctx, ctx.Doer, issue, title, content)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if len(form.Title) > 0 && !held {
 		err = issue_service.ChangeTitle(ctx, issue, ctx.Doer, form.Title)
 		if err != nil {
 			ctx.APIErrorInternal(err)
 			return
 		}
 	}
-	if form.Body != nil {
+	if form.Body != nil && !held {
 		err = issue_service.ChangeContent(ctx, issue, ctx.Doer, *form.Body, issue.ContentVersion)
 		if err != nil {
 			if errors.Is(err, issues_model.ErrIssueAlreadyChanged) {
@@ -938,6 +1183,10 @@ func EditIssue(ctx *context.APIContext) {
 		ctx.APIErrorInternal(err)
 		return
 	}
+	if held {
+		ctx.JSON(http.StatusAccepted, convert.ToAPIIssue(ctx, ctx.Doer, issue))
+		return
+	}
 	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
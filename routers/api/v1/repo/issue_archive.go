@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ExportIssueArchive export the issues of a repository as an issue archive
+func ExportIssueArchive(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/archive issue issueExportIssueArchive
+	// ---
+	// summary: Export the issues of a repository with their comments, reactions and attachments, and the labels and milestones of the repository as a zip archive
+	// description: The archive contains the file issues.json described by the JSON schema modules/migration/schemas/issue_archive.json and the files of the attachments. Pull requests are not exported.
+	// produces:
+	// - application/zip
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the issue archive
+	//     schema:
+	//       type: file
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-issues.zip"`, ctx.Repo.Repository.Name))
+	ctx.Resp.Header().Set("Content-Type", "application/zip")
+	if err := issue_service.WriteIssueArchive(ctx, ctx.Resp, ctx.Repo.Repository); err != nil {
+		if ctx.Resp.WrittenStatus() == 0 {
+			ctx.Resp.Header().Del("Content-Disposition")
+			ctx.APIErrorInternal(err)
+			return
+		}
+		log.Error("WriteIssueArchive: %v", err)
+	}
+}
+
+// ImportIssueArchive import the issues of an issue archive in a repository
+func ImportIssueArchive(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/archive issue issueImportIssueArchive
+	// ---
+	// summary: Import the issues of an archive exported from a repository of this or another instance
+	// description: The issues keep their numbers which must not be used in the repository, the missing labels and milestones are created. The issues, comments and reactions are posted by the user on behalf of their original authors.
+	// consumes:
+	// - multipart/form-data
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: archive
+	//   in: formData
+	//   description: issue archive to import
+	//   type: file
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	file, header, err := ctx.Req.FormFile("archive")
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	defer file.Close()
+
+	if err := issue_service.ImportIssueArchive(ctx, ctx.Doer, ctx.Repo.Repository, file, header.Size); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
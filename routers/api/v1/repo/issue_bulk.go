@@ -0,0 +1,272 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+var errPullRequestMerged = errors.New("cannot change state of this pull request, it was already merged")
+
+// bulkEditIssuesChanges are the validated changes of a bulk edit
+type bulkEditIssuesChanges struct {
+	AddLabels       []*issues_model.Label
+	RemoveLabels    []*issues_model.Label
+	AddAssignees    []*user_model.User
+	RemoveAssignees []*user_model.User
+	Milestone       *issues_model.Milestone
+	State           api.StateType
+	Comment         string
+}
+
+// BulkEditIssues edits several issues and pull requests at once
+func BulkEditIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/bulk issue issueBulkEdit
+	// ---
+	// summary: Label, assign, close, reopen, move to a milestone or comment on several issues and pull requests at once
+	// description: The changes are validated before any issue is edited, then every issue is edited on its own and gets its own result. At most `MAX_RESPONSE_ITEMS` issues can be edited at once.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/BulkEditIssuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BulkEditIssueResultList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.BulkEditIssuesOption)
+	if len(form.Indexes) > setting.API.MaxResponseItems {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("at most %d issues can be edited at once", setting.API.MaxResponseItems))
+		return
+	}
+
+	changes := prepareBulkEditIssues(ctx, form)
+	if ctx.Written() {
+		return
+	}
+
+	indexes := make(container.Set[int64], len(form.Indexes))
+	results := make([]*api.BulkEditIssueResult, 0, len(form.Indexes))
+	for _, index := range form.Indexes {
+		if !indexes.Add(index) {
+			continue
+		}
+
+		result := &api.BulkEditIssueResult{Index: index, Status: http.StatusOK}
+		issue, err := bulkEditIssue(ctx, index, changes)
+		switch {
+		case err == nil:
+			result.Issue = convert.ToAPIIssue(ctx, ctx.Doer, issue)
+		case errors.Is(err, util.ErrNotExist):
+			result.Status, result.Message = http.StatusNotFound, "issue does not exist"
+		case errors.Is(err, util.ErrPermissionDenied):
+			result.Status, result.Message = http.StatusForbidden, err.Error()
+		case errors.Is(err, errPullRequestMerged):
+			result.Status, result.Message = http.StatusPreconditionFailed, err.Error()
+		case issues_model.IsErrDependenciesLeft(err):
+			result.Status, result.Message = http.StatusPreconditionFailed, "cannot close this issue or pull request because it still has open dependencies"
+		default:
+			log.Error("Unable to bulk edit issue #%d of %s: %v", index, ctx.Repo.Repository.FullName(), err)
+			result.Status, result.Message = http.StatusInternalServerError, "unable to edit the issue"
+		}
+		results = append(results, result)
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
+
+// prepareBulkEditIssues validates the changes of the form, so that an invalid bulk edit doesn't edit any issue
+func prepareBulkEditIssues(ctx *context.APIContext, form *api.BulkEditIssuesOption) *bulkEditIssuesChanges {
+	changes := &bulkEditIssuesChanges{Comment: form.Comment}
+
+	if form.State != nil {
+		changes.State = api.StateType(*form.State)
+		if changes.State != api.StateOpen && changes.State != api.StateClosed {
+			ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("unknown state: %s", changes.State))
+			return nil
+		}
+	}
+
+	var err error
+	if len(form.AddLabels) > 0 {
+		if changes.AddLabels, err = getLabelsByIDsOrNames(ctx, form.AddLabels); err != nil {
+			return nil
+		}
+	}
+	if len(form.RemoveLabels) > 0 {
+		if changes.RemoveLabels, err = getLabelsByIDsOrNames(ctx, form.RemoveLabels); err != nil {
+			return nil
+		}
+	}
+
+	getAssignees := func(names []string) []*user_model.User {
+		users := make([]*user_model.User, 0, len(names))
+		for _, name := range names {
+			user, err := user_model.GetUserByName(ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("user does not exist: %s", name))
+				} else {
+					ctx.APIErrorInternal(err)
+				}
+				return nil
+			}
+			users = append(users, user)
+		}
+		return users
+	}
+	if changes.AddAssignees = getAssignees(form.AddAssignees); ctx.Written() {
+		return nil
+	}
+	for _, assignee := range changes.AddAssignees {
+		if user_model.IsUserBlockedBy(ctx, ctx.Doer, assignee.ID) {
+			ctx.APIError(http.StatusForbidden, fmt.Sprintf("%s: %s", user_model.ErrBlockedUser, assignee.Name))
+			return nil
+		}
+	}
+	if changes.RemoveAssignees = getAssignees(form.RemoveAssignees); ctx.Written() {
+		return nil
+	}
+
+	if form.Milestone != nil {
+		// the zero milestone removes the milestone of the issues
+		changes.Milestone = &issues_model.Milestone{}
+		if *form.Milestone > 0 {
+			changes.Milestone, err = issues_model.GetMilestoneByRepoID(ctx, ctx.Repo.Repository.ID, *form.Milestone)
+			if err != nil {
+				if issues_model.IsErrMilestoneNotExist(err) {
+					ctx.APIError(http.StatusUnprocessableEntity, err)
+				} else {
+					ctx.APIErrorInternal(err)
+				}
+				return nil
+			}
+		}
+	}
+
+	return changes
+}
+
+// bulkEditIssue applies the changes to an issue, the changes which are already applied are skipped
+func bulkEditIssue(ctx *context.APIContext, index int64, changes *bulkEditIssuesChanges) (*issues_model.Issue, error) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, index)
+	if err != nil {
+		return nil, err
+	}
+	issue.Repo = ctx.Repo.Repository
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		return nil, util.NewPermissionDeniedErrorf("write permission is required")
+	}
+
+	if changes.State == api.StateClosed && !issue.IsClosed || changes.State == api.StateOpen && issue.IsClosed {
+		if issue.IsPull {
+			if err := issue.LoadPullRequest(ctx); err != nil {
+				return nil, err
+			}
+			if issue.PullRequest.HasMerged {
+				return nil, errPullRequestMerged
+			}
+		}
+	}
+
+	if len(changes.AddLabels) > 0 {
+		if err := issue_service.AddLabels(ctx, issue, ctx.Doer, changes.AddLabels); err != nil {
+			return nil, err
+		}
+	}
+	for _, label := range changes.RemoveLabels {
+		if err := issue_service.RemoveLabel(ctx, issue, ctx.Doer, label); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, assignee := range changes.AddAssignees {
+		if _, err := issue_service.AddAssigneeIfNotAssigned(ctx, issue, ctx.Doer, assignee.ID, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, assignee := range changes.RemoveAssignees {
+		isAssigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, assignee)
+		if err != nil {
+			return nil, err
+		}
+		if isAssigned {
+			if _, _, err := issue_service.ToggleAssigneeWithNotify(ctx, issue, ctx.Doer, assignee.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if changes.Milestone != nil && issue.MilestoneID != changes.Milestone.ID {
+		oldMilestoneID := issue.MilestoneID
+		issue.MilestoneID = changes.Milestone.ID
+		issue.Milestone = nil
+		if changes.Milestone.ID > 0 {
+			issue.Milestone = changes.Milestone
+		}
+		if err := issue_service.ChangeMilestoneAssign(ctx, issue, ctx.Doer, oldMilestoneID); err != nil {
+			return nil, err
+		}
+	}
+
+	if changes.Comment != "" {
+		if _, err := issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, changes.Comment, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// the comment is posted before the issue is closed, like the "Comment and close" button
+	if changes.State == api.StateClosed && !issue.IsClosed {
+		if err := issue_service.CloseIssue(ctx, issue, ctx.Doer, ""); err != nil {
+			return nil, err
+		}
+	} else if changes.State == api.StateOpen && issue.IsClosed {
+		if err := issue_service.ReopenIssue(ctx, issue, ctx.Doer, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// refetch from database to return the edited issue
+	issue, err = issues_model.GetIssueByID(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	return issue, issue.LoadMilestone(ctx)
+}
@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	issue_service "code.gitea.io/gitea/services/issue"
+	spamdetection_service "code.gitea.io/gitea/services/spamdetection"
 )
 
 // ListIssueComments list all the comments of an issue
@@ -81,10 +82,11 @@ func ListIssueComments(ctx *context.APIContext) {
 	issue.Repo = ctx.Repo.Repository
 
 	opts := &issues_model.FindCommentsOptions{
-		IssueID: issue.ID,
-		Since:   since,
-		Before:  before,
-		Type:    issues_model.CommentTypeComment,
+		IssueID:       issue.ID,
+		Since:         since,
+		Before:        before,
+		Type:          issues_model.CommentTypeComment,
+		HideModerated: hideModeratedContent(ctx),
 	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
@@ -180,11 +182,12 @@ func ListIssueCommentsAndTimeline(ctx *context.APIContext) {
 	issue.Repo = ctx.Repo.Repository
 
 	opts := &issues_model.FindCommentsOptions{
-		ListOptions: utils.GetListOptions(ctx),
-		IssueID:     issue.ID,
-		Since:       since,
-		Before:      before,
-		Type:        issues_model.CommentTypeUndefined,
+		ListOptions:   utils.GetListOptions(ctx),
+		IssueID:       issue.ID,
+		Since:         since,
+		Before:        before,
+		Type:          issues_model.CommentTypeUndefined,
+		HideModerated: hideModeratedContent(ctx),
 	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
@@ -293,12 +296,13 @@ func ListRepoIssueComments(ctx *context.APIContext) {
 	}
 
 	opts := &issues_model.FindCommentsOptions{
-		ListOptions: utils.GetListOptions(ctx),
-		RepoID:      ctx.Repo.Repository.ID,
-		Type:        issues_model.CommentTypeComment,
-		Since:       since,
-		Before:      before,
-		IsPull:      isPull,
+		ListOptions:   utils.GetListOptions(ctx),
+		RepoID:        ctx.Repo.Repository.ID,
+		Type:          issues_model.CommentTypeComment,
+		Since:         since,
+		Before:        before,
+		IsPull:        isPull,
+		HideModerated: hideModeratedContent(ctx),
 	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
@@ -372,6 +376,8 @@ func CreateIssueComment(ctx *context.APIContext) {
 	// responses:
 	//   "201":
 	//     "$ref": "#/responses/Comment"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "403":
 	//     "$ref": "#/responses/forbidden"
 	//   "404":
@@ -396,6 +402,15 @@ func CreateIssueComment(ctx *context.APIContext) {
 		return
 	}
 
+	if held, err := spamdetection_service.HoldCommentIfSuspicious(ctx, ctx.Doer, issue, form.Body, nil); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if held {
+		// the comment is published once it has been approved by an admin
+		ctx.Status(http.StatusAccepted)
+		return
+	}
+
 	comment, err := issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Body, nil)
 	if err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
@@ -459,7 +474,7 @@ func GetIssueComment(ctx *context.APIContext) {
 		ctx.APIErrorInternal(err)
 		return
 	}
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.Status(http.StatusNotFound)
 		return
 	}
@@ -515,6 +530,8 @@ func EditIssueComment(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Comment"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "204":
 	//     "$ref": "#/responses/empty"
 	//   "403":
@@ -567,6 +584,8 @@ func EditIssueCommentDeprecated(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Comment"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "204":
 	//     "$ref": "#/responses/empty"
 	//   "403":
@@ -594,7 +613,7 @@ func editIssueComment(ctx *context.APIContext, form api.EditIssueCommentOption)
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.Status(http.StatusNotFound)
 		return
 	}
@@ -609,6 +628,15 @@ func editIssueComment(ctx *context.APIContext, form api.EditIssueCommentOption)
 		return
 	}
 
+	if held, err := spamdetection_service.HoldCommentEditIfSuspicious(ctx, ctx.Doer, comment, form.Body); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if held {
+		// the new content is applied once it has been approved by an admin
+		ctx.Status(http.StatusAccepted)
+		return
+	}
+
 	if form.Body != comment.Content {
 		oldContent := comment.Content
 		comment.Content = form.Body
@@ -713,7 +741,7 @@ func deleteIssueComment(ctx *context.APIContext) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.Status(http.StatusNotFound)
 		return
 	}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	issues_model "code.gitea.io/gitea/models/issues"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
@@ -199,6 +200,8 @@ func CreateIssueCommentAttachment(ctx *context.APIContext) {
 	if err != nil {
 		if upload.IsErrFileTypeForbidden(err) {
 			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else if quota_model.IsErrQuotaExceeded(err) {
+			ctx.APIError(http.StatusRequestEntityTooLarge, err)
 		} else {
 			ctx.APIErrorInternal(err)
 		}
@@ -347,7 +350,7 @@ func getIssueCommentSafe(ctx *context.APIContext) *issues_model.Comment {
 		ctx.APIErrorInternal(err)
 		return nil
 	}
-	if comment.Issue == nil || comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue == nil || comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.APIError(http.StatusNotFound, "no matching issue comment found")
 		return nil
 	}
@@ -0,0 +1,385 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListIssueFields list the custom fields of a repository
+func ListIssueFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_fields issue issueListIssueFields
+	// ---
+	// summary: Get the custom fields of a repository's issues and pull requests, including the fields of its organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueFieldList(fields))
+}
+
+// GetIssueField get a custom field of a repository
+func GetIssueField(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_fields/{id} issue issueGetIssueField
+	// ---
+	// summary: Get a custom field of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	f, err := issues_model.GetIssueFieldByID(ctx, ctx.Repo.Repository.ID, 0, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueField(f))
+}
+
+// CreateIssueField create a custom field for a repository
+func CreateIssueField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_fields issue issueCreateIssueField
+	// ---
+	// summary: Create a custom field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueFieldOption)
+
+	f := &issues_model.IssueField{
+		RepoID:      ctx.Repo.Repository.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		Type:        issues_model.IssueFieldType(form.Type),
+		Options:     form.Options,
+	}
+	if err := issues_model.NewIssueField(ctx, f); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIssueField(f))
+}
+
+// EditIssueField modify a custom field of a repository
+func EditIssueField(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issue_fields/{id} issue issueEditIssueField
+	// ---
+	// summary: Update a custom field
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueFieldOption)
+	f, err := issues_model.GetIssueFieldByID(ctx, ctx.Repo.Repository.ID, 0, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if form.Name != nil {
+		f.Name = *form.Name
+	}
+	if form.Description != nil {
+		f.Description = *form.Description
+	}
+	if form.Options != nil {
+		f.Options = form.Options
+	}
+	if err := issues_model.UpdateIssueField(ctx, f); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueField(f))
+}
+
+// DeleteIssueField delete a custom field of a repository
+func DeleteIssueField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_fields/{id} issue issueDeleteIssueField
+	// ---
+	// summary: Delete a custom field and its values
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	f, err := issues_model.GetIssueFieldByID(ctx, ctx.Repo.Repository.ID, 0, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.DeleteIssueField(ctx, f); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetIssueFieldValues list the values of the custom fields of an issue
+func GetIssueFieldValues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/fields issue issueGetIssueFieldValues
+	// ---
+	// summary: Get the values of the custom fields of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getIssueForFieldValues(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	writeIssueFieldValues(ctx, issue)
+}
+
+// EditIssueFieldValues set the values of the custom fields of an issue
+func EditIssueFieldValues(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index}/fields issue issueEditIssueFieldValues
+	// ---
+	// summary: Set the values of the custom fields of an issue
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueFieldValuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldValueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueFieldValuesOption)
+	issue := getIssueForFieldValues(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.APIError(http.StatusForbidden, "write permission is required")
+		return
+	}
+
+	if err := issue_service.SetIssueFieldValues(ctx, issue, form.Fields); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	writeIssueFieldValues(ctx, issue)
+}
+
+func getIssueForFieldValues(ctx *context.APIContext) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return issue
+}
+
+func writeIssueFieldValues(ctx *context.APIContext, issue *issues_model.Issue) {
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	values, err := issues_model.GetIssueFieldDisplayValues(ctx, fields, issue.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueFieldValues(fields, values[issue.ID]))
+}
+
+func handleIssueFieldError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
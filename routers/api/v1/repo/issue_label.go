@@ -324,11 +324,17 @@ func prepareForReplaceOrAdd(ctx *context.APIContext, form api.IssueLabelsOption)
 		return nil, nil, errors.New("permission denied")
 	}
 
+	labels, err := getLabelsByIDsOrNames(ctx, form.Labels)
+	return issue, labels, err
+}
+
+// getLabelsByIDsOrNames returns the repository and organization labels of a list of label IDs or names
+func getLabelsByIDsOrNames(ctx *context.APIContext, labelIDsOrNames []any) ([]*issues_model.Label, error) {
 	var (
 		labelIDs   []int64
 		labelNames []string
 	)
-	for _, label := range form.Labels {
+	for _, label := range labelIDsOrNames {
 		rv := reflect.ValueOf(label)
 		switch rv.Kind() {
 		case reflect.Float64:
@@ -337,25 +343,25 @@ func prepareForReplaceOrAdd(ctx *context.APIContext, form api.IssueLabelsOption)
 			labelNames = append(labelNames, rv.String())
 		default:
 			ctx.APIError(http.StatusBadRequest, "a label must be an integer or a string")
-			return nil, nil, errors.New("invalid label")
+			return nil, errors.New("invalid label")
 		}
 	}
 	if len(labelIDs) > 0 && len(labelNames) > 0 {
 		ctx.APIError(http.StatusBadRequest, "labels should be an array of strings or integers")
-		return nil, nil, errors.New("invalid labels")
+		return nil, errors.New("invalid labels")
 	}
 	if len(labelNames) > 0 {
 		repoLabelIDs, err := issues_model.GetLabelIDsInRepoByNames(ctx, ctx.Repo.Repository.ID, labelNames)
 		if err != nil {
 			ctx.APIErrorInternal(err)
-			return nil, nil, err
+			return nil, err
 		}
 		labelIDs = append(labelIDs, repoLabelIDs...)
 		if ctx.Repo.Owner.IsOrganization() {
 			orgLabelIDs, err := issues_model.GetLabelIDsInOrgByNames(ctx, ctx.Repo.Owner.ID, labelNames)
 			if err != nil {
 				ctx.APIErrorInternal(err)
-				return nil, nil, err
+				return nil, err
 			}
 			labelIDs = append(labelIDs, orgLabelIDs...)
 		}
@@ -364,8 +370,8 @@ func prepareForReplaceOrAdd(ctx *context.APIContext, form api.IssueLabelsOption)
 	labels, err := issues_model.GetLabelsByIDs(ctx, labelIDs, "id", "repo_id", "org_id", "name", "exclusive")
 	if err != nil {
 		ctx.APIErrorInternal(err)
-		return nil, nil, err
+		return nil, err
 	}
 
-	return issue, labels, err
+	return labels, nil
 }
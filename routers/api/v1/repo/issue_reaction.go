@@ -66,7 +66,7 @@ func GetIssueCommentReactions(ctx *context.APIContext) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.APIErrorNotFound()
 		return
 	}
@@ -203,7 +203,7 @@ func changeIssueCommentReaction(ctx *context.APIContext, form api.EditReactionOp
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.APIErrorNotFound()
 		return
 	}
@@ -0,0 +1,335 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/optional"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListIssueSavedSearches list the searches of a repository saved by the user and shared by its organization
+func ListIssueSavedSearches(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_searches issue issueListIssueSavedSearches
+	// ---
+	// summary: List the saved searches of the issues and pull requests of a repository
+	// description: The searches saved by the authenticated user and the searches shared by the organization of the repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: query
+	//   description: filter by the type of the issues of the searches
+	//   type: string
+	//   enum: [issues, pulls]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSavedSearchList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	var isPull optional.Option[bool]
+	switch ctx.FormString("type") {
+	case "pulls":
+		isPull = optional.Some(true)
+	case "issues":
+		isPull = optional.Some(false)
+	}
+
+	searches, err := issues_model.GetIssueSavedSearchesOfRepo(ctx, ctx.Repo.Repository, doerID(ctx), isPull)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	visible := make([]*issues_model.IssueSavedSearch, 0, len(searches))
+	for _, s := range searches {
+		if canReadIssueSavedSearch(ctx, s) {
+			visible = append(visible, s)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSavedSearchList(visible, ctx.Repo.Repository))
+}
+
+// GetIssueSavedSearch get a saved search of a repository
+func GetIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_searches/{id} issue issueGetIssueSavedSearch
+	// ---
+	// summary: Get a saved search of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the search to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSavedSearch"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getIssueSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSavedSearch(s, ctx.Repo.Repository, s.IsShared(ctx.Repo.Repository)))
+}
+
+// CreateIssueSavedSearch save a search of a repository
+func CreateIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_searches issue issueCreateIssueSavedSearch
+	// ---
+	// summary: Save a search of the issues or pull requests of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueSavedSearchOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueSavedSearch"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueSavedSearchOption)
+
+	s := &issues_model.IssueSavedSearch{
+		OwnerID: ctx.Doer.ID,
+		RepoID:  ctx.Repo.Repository.ID,
+		Name:    form.Name,
+		Query:   form.Query,
+	}
+	switch form.Type {
+	case "", "issues":
+	case "pulls":
+		s.IsPull = true
+	default:
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid type: "+form.Type)
+		return
+	}
+	if !canReadIssueSavedSearch(ctx, s) {
+		ctx.APIErrorNotFound()
+		return
+	}
+	if form.Shared {
+		if !checkCanManageSharedIssueSavedSearches(ctx) {
+			return
+		}
+		s.OwnerID = ctx.Repo.Repository.OwnerID
+	}
+
+	if err := issues_model.NewIssueSavedSearch(ctx, s); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIssueSavedSearch(s, ctx.Repo.Repository, form.Shared))
+}
+
+// EditIssueSavedSearch modify a saved search of a repository
+func EditIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issue_searches/{id} issue issueEditIssueSavedSearch
+	// ---
+	// summary: Update a saved search of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the search to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueSavedSearchOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSavedSearch"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueSavedSearchOption)
+
+	s := getIssueSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared := s.IsShared(ctx.Repo.Repository)
+	if shared && !checkCanManageSharedIssueSavedSearches(ctx) {
+		return
+	}
+
+	if form.Name != nil {
+		s.Name = *form.Name
+	}
+	if form.Query != nil {
+		s.Query = *form.Query
+	}
+	if err := issues_model.UpdateIssueSavedSearch(ctx, s); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSavedSearch(s, ctx.Repo.Repository, shared))
+}
+
+// DeleteIssueSavedSearch delete a saved search of a repository
+func DeleteIssueSavedSearch(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_searches/{id} issue issueDeleteIssueSavedSearch
+	// ---
+	// summary: Delete a saved search of a repository
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the search to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getIssueSavedSearch(ctx)
+	if ctx.Written() {
+		return
+	}
+	if s.IsShared(ctx.Repo.Repository) && !checkCanManageSharedIssueSavedSearches(ctx) {
+		return
+	}
+
+	if err := issues_model.DeleteIssueSavedSearch(ctx, s.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// canReadIssueSavedSearch returns whether the doer can read the issues or the pull requests of the search
+func canReadIssueSavedSearch(ctx *context.APIContext, s *issues_model.IssueSavedSearch) bool {
+	if s.IsPull {
+		return ctx.Repo.CanRead(unit.TypePullRequests)
+	}
+	return ctx.Repo.CanRead(unit.TypeIssues)
+}
+
+// doerID returns the ID of the signed-in user, 0 for an anonymous user
+func doerID(ctx *context.APIContext) int64 {
+	if ctx.Doer == nil {
+		return 0
+	}
+	return ctx.Doer.ID
+}
+
+func getIssueSavedSearch(ctx *context.APIContext) *issues_model.IssueSavedSearch {
+	s, err := issues_model.GetIssueSavedSearchOfRepo(ctx, ctx.Repo.Repository, doerID(ctx), ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return nil
+	}
+	if !canReadIssueSavedSearch(ctx, s) {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	return s
+}
+
+func checkCanManageSharedIssueSavedSearches(ctx *context.APIContext) bool {
+	canManage, err := issue_service.CanManageSharedIssueSavedSearches(ctx, ctx.Doer, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	if !canManage {
+		ctx.APIError(http.StatusForbidden, "only the owners of the organization can manage its shared searches")
+		return false
+	}
+	return true
+}
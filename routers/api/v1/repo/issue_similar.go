@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListSimilarIssues list the open issues similar to a title and a body
+func ListSimilarIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/similar issue issueListSimilarIssues
+	// ---
+	// summary: List the open issues similar to a title and a body, the most similar first
+	// description: Use it to detect duplicates before creating an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: title
+	//   in: query
+	//   description: title of the issue
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: query
+	//   description: body of the issue
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: maximum number of issues to return, 5 by default
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SimilarIssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	title := strings.TrimSpace(ctx.FormString("title"))
+	if title == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "title is required")
+		return
+	}
+
+	writeSimilarIssues(ctx, title, ctx.FormString("body"), 0)
+}
+
+// ListIssueSimilarIssues list the open issues similar to an issue
+func ListIssueSimilarIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/similar issue issueListIssueSimilarIssues
+	// ---
+	// summary: List the other open issues similar to an issue, the most similar first
+	// description: Use it to triage the possible duplicates of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: maximum number of issues to return, 5 by default
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SimilarIssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	writeSimilarIssues(ctx, issue.Title, issue.Content, issue.ID)
+}
+
+func writeSimilarIssues(ctx *context.APIContext, title, body string, excludeID int64) {
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = 5
+	} else if limit > setting.API.MaxResponseItems {
+		limit = setting.API.MaxResponseItems
+	}
+
+	similar, err := issue_service.FindSimilarIssues(ctx, ctx.Repo.Repository, title, body, excludeID, limit)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	result := make([]*api.SimilarIssue, 0, len(similar))
+	for _, s := range similar {
+		result = append(result, &api.SimilarIssue{
+			Issue: convert.ToAPIIssue(ctx, ctx.Doer, s.Issue),
+			Score: s.Score,
+		})
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListIssueSLARules list the SLA rules of a repository
+func ListIssueSLARules(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_sla_rules issue issueListIssueSLARules
+	// ---
+	// summary: Get the SLA rules of a repository's issues
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSLARuleList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rules, err := issues_model.GetIssueSLARules(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSLARuleList(rules))
+}
+
+// GetIssueSLARule get an SLA rule of a repository
+func GetIssueSLARule(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_sla_rules/{id} issue issueGetIssueSLARule
+	// ---
+	// summary: Get an SLA rule of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSLARule"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rule, err := issues_model.GetIssueSLARuleByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSLARule(rule))
+}
+
+// CreateIssueSLARule create an SLA rule of a repository
+func CreateIssueSLARule(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_sla_rules issue issueCreateIssueSLARule
+	// ---
+	// summary: Create an SLA rule of a repository's issues
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueSLARuleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueSLARule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueSLARuleOption)
+
+	rule := &issues_model.IssueSLARule{
+		RepoID:          ctx.Repo.Repository.ID,
+		Name:            form.Name,
+		LabelIDs:        form.Labels,
+		ExemptLabelIDs:  form.ExemptLabels,
+		ResponseHours:   form.ResponseHours,
+		ResolutionHours: form.ResolutionHours,
+		StaleDays:       form.StaleDays,
+	}
+	if err := issue_service.ValidateIssueSLARuleLabels(ctx, ctx.Repo.Repository, rule); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+	if err := issues_model.NewIssueSLARule(ctx, rule); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIssueSLARule(rule))
+}
+
+// EditIssueSLARule modify an SLA rule of a repository
+func EditIssueSLARule(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issue_sla_rules/{id} issue issueEditIssueSLARule
+	// ---
+	// summary: Update an SLA rule of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueSLARuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSLARule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueSLARuleOption)
+
+	rule, err := issues_model.GetIssueSLARuleByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if form.Name != nil {
+		rule.Name = *form.Name
+	}
+	if form.Labels != nil {
+		rule.LabelIDs = form.Labels
+	}
+	if form.ExemptLabels != nil {
+		rule.ExemptLabelIDs = form.ExemptLabels
+	}
+	if form.ResponseHours != nil {
+		rule.ResponseHours = *form.ResponseHours
+	}
+	if form.ResolutionHours != nil {
+		rule.ResolutionHours = *form.ResolutionHours
+	}
+	if form.StaleDays != nil {
+		rule.StaleDays = *form.StaleDays
+	}
+	if err := issue_service.ValidateIssueSLARuleLabels(ctx, ctx.Repo.Repository, rule); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+	if err := issues_model.UpdateIssueSLARule(ctx, rule); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSLARule(rule))
+}
+
+// DeleteIssueSLARule delete an SLA rule of a repository
+func DeleteIssueSLARule(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_sla_rules/{id} issue issueDeleteIssueSLARule
+	// ---
+	// summary: Delete an SLA rule of a repository
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the rule to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rule, err := issues_model.GetIssueSLARuleByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.DeleteIssueSLARule(ctx, rule.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetIssueSLAStatuses get the states of the SLA rules matching an issue
+func GetIssueSLAStatuses(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sla issue issueGetIssueSLAStatuses
+	// ---
+	// summary: Get the states of the targets of the SLA rules matching an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueSLAStatusList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getIssueForFieldValues(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull || !ctx.Repo.CanReadIssuesOrPulls(false) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	statuses, err := issue_service.GetIssueSLAStatuses(ctx, issue)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueSLAStatusList(statuses))
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// GetIssueHierarchy get the parent and the tree of sub-issues of an issue
+func GetIssueHierarchy(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueGetIssueHierarchy
+	// ---
+	// summary: Get the parent and the tree of sub-issues of an issue with the progress of the sub-issues
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueHierarchy"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getSubIssueParent(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	writeIssueHierarchy(ctx, http.StatusOK, issue)
+}
+
+// AddSubIssue make an issue a sub-issue of the issue
+func AddSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueAddSubIssue
+	// ---
+	// summary: Make the issue in the form a sub-issue of the issue in the url
+	// description: The sub-issue must belong to a repository of the same owner and can't already have a parent
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the parent issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueHierarchy"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, sub := getSubIssuesToEdit(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, parent, sub); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	writeIssueHierarchy(ctx, http.StatusCreated, parent)
+}
+
+// RemoveSubIssue remove a sub-issue of the issue
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueRemoveSubIssue
+	// ---
+	// summary: Remove the issue in the form from the sub-issues of the issue in the url
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the parent issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueHierarchy"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, sub := getSubIssuesToEdit(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.RemoveSubIssue(ctx, parent, sub); err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	writeIssueHierarchy(ctx, http.StatusOK, parent)
+}
+
+// getSubIssueParent returns the issue of the url, pull requests have no sub-issues
+func getSubIssueParent(ctx *context.APIContext) *issues_model.Issue {
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	if issue.IsPull || !ctx.Repo.CanRead(unit.TypeIssues) {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	return issue
+}
+
+// getSubIssuesToEdit returns the parent issue of the url and the sub-issue of the form,
+// the doer must be able to write the issues of both
+func getSubIssuesToEdit(ctx *context.APIContext) (parent, sub *issues_model.Issue) {
+	form := web.GetForm(ctx).(*api.IssueMeta)
+
+	parent = getSubIssueParent(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.APIError(http.StatusForbidden, "no permission to edit the sub-issues of the issue")
+		return nil, nil
+	}
+
+	repo := ctx.Repo.Repository
+	if (form.Owner != "" || form.Name != "") && (form.Owner != repo.OwnerName || form.Name != repo.Name) {
+		var err error
+		if repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, form.Owner, form.Name); err != nil {
+			ctx.NotFoundOrServerError(err)
+			return nil, nil
+		}
+	}
+	sub, err := issues_model.GetIssueByIndex(ctx, repo.ID, form.Index)
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return nil, nil
+	}
+	sub.Repo = repo
+
+	perm := getPermissionForRepo(ctx, repo)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !perm.CanReadIssuesOrPulls(sub.IsPull) {
+		ctx.APIErrorNotFound()
+		return nil, nil
+	}
+	if !perm.CanWriteIssuesOrPulls(sub.IsPull) {
+		ctx.APIError(http.StatusForbidden, "no permission to edit the sub-issue")
+		return nil, nil
+	}
+	return parent, sub
+}
+
+func writeIssueHierarchy(ctx *context.APIContext, status int, issue *issues_model.Issue) {
+	parent, err := issue_service.GetParentIssue(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	nodes, err := issue_service.GetSubIssueTree(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(status, convert.ToIssueHierarchy(ctx, ctx.Doer, parent, nodes))
+}
@@ -0,0 +1,662 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/optional"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListIterations list the iterations of a repository and of its organization
+func ListIterations(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/iterations issue issueListIterations
+	// ---
+	// summary: Get the iterations of a repository and of its organization sorted by start date
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: Iteration state, Recognized values are open, closed and all. Defaults to "all"
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IterationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	var isClosed optional.Option[bool]
+	switch state := api.StateType(ctx.FormString("state")); state {
+	case api.StateClosed, api.StateOpen:
+		isClosed = optional.Some(state == api.StateClosed)
+	}
+
+	iterations, err := issues_model.GetIterationsOfRepo(ctx, ctx.Repo.Repository, isClosed)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIterationList(iterations))
+}
+
+// GetIteration get an iteration of a repository or of its organization
+func GetIteration(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/iterations/{id} issue issueGetIteration
+	// ---
+	// summary: Get an iteration of a repository or of its organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByIDForRepo(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// CreateIteration create an iteration of a repository
+func CreateIteration(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/iterations issue issueCreateIteration
+	// ---
+	// summary: Create an iteration of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIterationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIterationOption)
+
+	it := &issues_model.Iteration{
+		RepoID:      ctx.Repo.Repository.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		StartUnix:   timeutil.TimeStamp(form.Start.Unix()),
+		EndUnix:     timeutil.TimeStamp(form.End.Unix()),
+		Capacity:    form.Capacity,
+	}
+	if err := issues_model.NewIteration(ctx, it); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToIteration(it))
+}
+
+// EditIteration modify an iteration of a repository
+func EditIteration(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/iterations/{id} issue issueEditIteration
+	// ---
+	// summary: Update an iteration of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIterationOption)
+	it, err := issues_model.GetIterationByID(ctx, ctx.Repo.Repository.ID, 0, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.UpdateIteration(ctx, applyEditIterationOption(it, form)); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// DeleteIteration delete an iteration of a repository
+func DeleteIteration(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/iterations/{id} issue issueDeleteIteration
+	// ---
+	// summary: Delete an iteration of a repository, its issues go back to the backlog
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByID(ctx, ctx.Repo.Repository.ID, 0, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	if err := issues_model.DeleteIteration(ctx, it); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// CloseIteration close an iteration of a repository
+func CloseIteration(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/iterations/{id}/close issue issueCloseIteration
+	// ---
+	// summary: Close an iteration of a repository
+	// description: The open issues of the iteration are carried over to another open iteration of the repository or of its organization, or go back to the backlog.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration to close
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CloseIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CloseIterationOption)
+	it, err := issues_model.GetIterationByID(ctx, ctx.Repo.Repository.ID, 0, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+
+	var carryOverTo *issues_model.Iteration
+	if form.CarryOverTo > 0 {
+		if carryOverTo, err = issues_model.GetIterationByIDForRepo(ctx, ctx.Repo.Repository, form.CarryOverTo); err != nil {
+			handleIssueFieldError(ctx, toInvalidArgumentIfNotExist(err))
+			return
+		}
+	}
+	if err := issues_model.CloseIteration(ctx, it, carryOverTo); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// ListIterationIssues list the issues of an iteration in a repository
+func ListIterationIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/iterations/{id}/issues issue issueListIterationIssues
+	// ---
+	// summary: Get the issues and pull requests of the repository which are part of an iteration
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByIDForRepo(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+	issues, err := issues_model.GetIterationIssues(ctx, it, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	visible := make(issues_model.IssueList, 0, len(issues))
+	for _, issue := range issues {
+		if ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+			visible = append(visible, issue)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, visible))
+}
+
+// GetIterationBurndown get the burndown series of an iteration in a repository
+func GetIterationBurndown(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/iterations/{id}/burndown issue issueGetIterationBurndown
+	// ---
+	// summary: Get the burndown series of an iteration, counting the issues of the repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the iteration
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IterationBurndown"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	it, err := issues_model.GetIterationByIDForRepo(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+	burndown, err := issues_model.GetIterationBurndown(ctx, it, ctx.Repo.Repository.ID, time.Now())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIterationBurndown(it, burndown))
+}
+
+// ListIterationVelocity list the velocity of the closed iterations of a repository
+func ListIterationVelocity(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/iterations/velocity issue issueListIterationVelocity
+	// ---
+	// summary: Get the velocity of the last closed iterations of a repository and of its organization, counting the issues of the repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: number of the last closed iterations, defaults to 10
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IterationVelocityList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	iterations, err := issues_model.GetIterationsOfRepo(ctx, ctx.Repo.Repository, optional.Some(true))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	velocities, err := issues_model.GetIterationsVelocity(ctx, lastIterations(iterations, ctx.FormInt("limit")), ctx.Repo.Repository.ID, time.Now())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIterationVelocities(velocities))
+}
+
+// GetIssueIteration get the iteration of an issue
+func GetIssueIteration(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/iteration issue issueGetIssueIteration
+	// ---
+	// summary: Get the iteration an issue is part of
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	it, err := issues_model.GetIssueIteration(ctx, issue.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if it == nil {
+		ctx.APIErrorNotFound("the issue is in the backlog")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// SetIssueIteration move an issue to an iteration
+func SetIssueIteration(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issues/{index}/iteration issue issueSetIssueIteration
+	// ---
+	// summary: Move an issue to an open iteration of the repository or of its organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueIterationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Iteration"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	form := web.GetForm(ctx).(*api.SetIssueIterationOption)
+	issue := getIssueToPlan(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	it, err := issues_model.GetIterationByIDForRepo(ctx, ctx.Repo.Repository, form.IterationID)
+	if err != nil {
+		handleIssueFieldError(ctx, toInvalidArgumentIfNotExist(err))
+		return
+	}
+	if err := issues_model.SetIssueIteration(ctx, issue, it); err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIteration(it))
+}
+
+// RemoveIssueIteration move an issue back to the backlog
+func RemoveIssueIteration(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/iteration issue issueRemoveIssueIteration
+	// ---
+	// summary: Remove an issue from its iteration, it goes back to the backlog
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	issue := getIssueToPlan(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.SetIssueIteration(ctx, issue, nil); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getIssueToPlan returns the issue of the url, the doer must be able to write it
+func getIssueToPlan(ctx *context.APIContext) *issues_model.Issue {
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.APIError(http.StatusForbidden, "no permission to change the iteration of the issue")
+		return nil
+	}
+	return issue
+}
+
+// applyEditIterationOption applies the changed fields of the form to an iteration
+func applyEditIterationOption(it *issues_model.Iteration, form *api.EditIterationOption) *issues_model.Iteration {
+	if form.Name != nil {
+		it.Name = *form.Name
+	}
+	if form.Description != nil {
+		it.Description = *form.Description
+	}
+	if form.Start != nil {
+		it.StartUnix = timeutil.TimeStamp(form.Start.Unix())
+	}
+	if form.End != nil {
+		it.EndUnix = timeutil.TimeStamp(form.End.Unix())
+	}
+	if form.Capacity != nil {
+		it.Capacity = *form.Capacity
+	}
+	return it
+}
+
+// lastIterations returns the last iterations of a list sorted by start date, 10 if limit isn't positive
+func lastIterations(iterations []*issues_model.Iteration, limit int) []*issues_model.Iteration {
+	if limit <= 0 {
+		limit = 10
+	}
+	return iterations[max(len(iterations)-limit, 0):]
+}
+
+// toInvalidArgumentIfNotExist reports a missing iteration referenced by a form as an invalid argument
+func toInvalidArgumentIfNotExist(err error) error {
+	if errors.Is(err, util.ErrNotExist) {
+		return util.NewInvalidArgumentErrorf("%v", err)
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListLFSLocks lists the LFS locks of a repository
+func ListLFSLocks(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/lfs/locks repository repoListLFSLocks
+	// ---
+	// summary: List the LFS locks of a repository, the oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: user
+	//   in: query
+	//   description: only list the locks held by this user
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoLFSLockList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.ListLFSLocks(ctx, ctx.Repo.Repository.ID)
+}
+
+// DeleteLFSLocks force-unlocks LFS locks of a repository in bulk
+func DeleteLFSLocks(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/lfs/locks repository repoDeleteLFSLocks
+	// ---
+	// summary: Force-unlock LFS locks of a repository in bulk
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/DeleteLFSLocksOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoLFSLockList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.DeleteLFSLocks(ctx, ctx.Repo.Repository.ID)
+}
@@ -4,11 +4,16 @@
 package repo
 
 import (
+	"errors"
 	"net/http"
 
+	"code.gitea.io/gitea/models/organization"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
+	licensecheck_service "code.gitea.io/gitea/services/licensecheck"
 )
 
 // GetLicenses returns licenses
@@ -49,3 +54,65 @@ func GetLicenses(ctx *context.APIContext) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// GetLicenseCompliance checks the licenses of the default branch against the license policy of the organization
+func GetLicenseCompliance(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/licenses/compliance repository repoGetLicenseCompliance
+	// ---
+	// summary: Check the licenses of the code and the dependencies of the default branch against the license policy of the organization
+	// description: The licenses of the dependencies are known if the lockfiles record them.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LicenseCompliance"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	licenses, err := repo_model.GetRepoLicenses(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	compliance := &api.LicenseCompliance{
+		Licenses:   licenses.StringList(),
+		Violations: make([]*api.LicenseViolation, 0),
+	}
+
+	if ctx.Repo.Owner.IsOrganization() {
+		policy, err := organization.GetLicensePolicy(ctx, ctx.Repo.Owner.ID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if policy != nil {
+			compliance.HasPolicy = true
+			violations, err := licensecheck_service.FindRepoViolations(ctx, ctx.Repo.Repository, policy)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			for _, v := range violations {
+				compliance.Violations = append(compliance.Violations, &api.LicenseViolation{
+					Path:    v.Path,
+					Package: v.Package,
+					Version: v.Version,
+					License: v.License,
+				})
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, compliance)
+}
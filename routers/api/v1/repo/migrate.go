@@ -161,6 +161,18 @@ func Migrate(ctx *context.APIContext) {
 		MirrorInterval: form.MirrorInterval,
 	}
 	if opts.Mirror {
+		if err := repo_model.ValidateMirrorRefFilter(form.MirrorRefFilter); err != nil {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		if form.MirrorMaxSize < 0 {
+			ctx.APIError(http.StatusUnprocessableEntity, fmt.Errorf("invalid mirror max size: %d", form.MirrorMaxSize))
+			return
+		}
+		opts.MirrorRefFilter = form.MirrorRefFilter
+		opts.MirrorSkipTags = form.MirrorSkipTags
+		opts.MirrorMaxSize = form.MirrorMaxSize
+
 		opts.Issues = false
 		opts.Milestones = false
 		opts.Labels = false
@@ -6,12 +6,14 @@ package repo
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
@@ -222,17 +224,8 @@ func GetPushMirrorByName(ctx *context.APIContext) {
 		return
 	}
 
-	mirrorName := ctx.PathParam("name")
-	// Get push mirror of a specific repo by remoteName
-	pushMirror, exist, err := db.Get[repo_model.PushMirror](ctx, repo_model.PushMirrorOptions{
-		RepoID:     ctx.Repo.Repository.ID,
-		RemoteName: mirrorName,
-	}.ToConds())
-	if err != nil {
-		ctx.APIErrorInternal(err)
-		return
-	} else if !exist {
-		ctx.APIError(http.StatusNotFound, nil)
+	pushMirror := getPushMirrorByName(ctx)
+	if ctx.Written() {
 		return
 	}
 
@@ -244,6 +237,115 @@ func GetPushMirrorByName(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, m)
 }
 
+// GetPushMirrorStatus get the status of the last sync of a push mirror
+func GetPushMirrorStatus(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/push_mirrors/{name}/status repository repoGetPushMirrorStatus
+	// ---
+	// summary: Get the status of the last sync of a push mirror
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: remote name of push mirror
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushMirrorStatus"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Mirror.Enabled {
+		ctx.APIError(http.StatusBadRequest, "Mirror feature is disabled")
+		return
+	}
+
+	pushMirror := getPushMirrorByName(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToPushMirrorStatus(pushMirror))
+}
+
+// SyncPushMirrorByName adds a push mirror to the sync queue, it could be the target of a webhook
+func SyncPushMirrorByName(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/push_mirrors/{name}/sync repository repoSyncPushMirror
+	// ---
+	// summary: Trigger the sync of a push mirror
+	// description: The push mirror is synced in background, it could be used as the target of a webhook to sync
+	//   the push mirror in addition to the interval.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: remote name of push mirror
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Mirror.Enabled {
+		ctx.APIError(http.StatusBadRequest, "Mirror feature is disabled")
+		return
+	}
+
+	pushMirror := getPushMirrorByName(ctx)
+	if ctx.Written() {
+		return
+	}
+	mirror_service.AddPushMirrorToQueue(pushMirror.ID)
+	ctx.Status(http.StatusAccepted)
+}
+
+func getPushMirrorByName(ctx *context.APIContext) *repo_model.PushMirror {
+	// Get push mirror of a specific repo by remoteName
+	pushMirror, exist, err := db.Get[repo_model.PushMirror](ctx, repo_model.PushMirrorOptions{
+		RepoID:     ctx.Repo.Repository.ID,
+		RemoteName: ctx.PathParam("name"),
+	}.ToConds())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	} else if !exist {
+		ctx.APIError(http.StatusNotFound, nil)
+		return nil
+	}
+	return pushMirror
+}
+
 // AddPushMirror adds a push mirror to a repository
 func AddPushMirror(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/push_mirrors repository repoAddPushMirror
@@ -351,6 +453,11 @@ func CreatePushMirror(ctx *context.APIContext, mirrorOption *api.CreatePushMirro
 		return
 	}
 
+	if err := repo_model.ValidateMirrorRefFilter(mirrorOption.BranchFilter); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
 	remoteSuffix, err := util.CryptoRandomString(10)
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -370,6 +477,8 @@ func CreatePushMirror(ctx *context.APIContext, mirrorOption *api.CreatePushMirro
 		Interval:      interval,
 		SyncOnCommit:  mirrorOption.SyncOnCommit,
 		RemoteAddress: remoteAddress,
+		BranchFilter:  strings.Join(repo_model.ParseMirrorRefFilter(mirrorOption.BranchFilter), ", "),
+		LFS:           optional.FromPtr(mirrorOption.LFS).ValueOrDefault(true),
 	}
 
 	if err = db.Insert(ctx, pushMirror); err != nil {
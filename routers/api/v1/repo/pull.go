@@ -131,13 +131,14 @@ func ListPullRequests(ctx *context.APIContext) {
 	}
 	listOptions := utils.GetListOptions(ctx)
 	prs, maxResults, err := issues_model.PullRequests(ctx, ctx.Repo.Repository.ID, &issues_model.PullRequestsOptions{
-		ListOptions: listOptions,
-		State:       ctx.FormTrim("state"),
-		SortType:    ctx.FormTrim("sort"),
-		Labels:      labelIDs,
-		MilestoneID: ctx.FormInt64("milestone"),
-		PosterID:    posterID,
-		BaseBranch:  ctx.FormTrim("base_branch"),
+		ListOptions:   listOptions,
+		State:         ctx.FormTrim("state"),
+		SortType:      ctx.FormTrim("sort"),
+		Labels:        labelIDs,
+		MilestoneID:   ctx.FormInt64("milestone"),
+		PosterID:      posterID,
+		BaseBranch:    ctx.FormTrim("base_branch"),
+		HideModerated: hideModeratedContent(ctx),
 	})
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -199,6 +199,14 @@ func GetPullReviewComments(ctx *context.APIContext) {
 		return
 	}
 
+	if err := review.LoadCodeComments(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if hideModeratedContent(ctx) {
+		review.CodeComments.RemoveHidden()
+	}
+
 	apiComments, err := convert.ToPullReviewCommentList(ctx, review, ctx.Doer)
 	if err != nil {
 		ctx.APIErrorInternal(err)
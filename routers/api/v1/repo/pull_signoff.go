@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	dco_service "code.gitea.io/gitea/services/dco"
+)
+
+// GetPullRequestSignOff checks the sign-offs of the commits of a pull request
+func GetPullRequestSignOff(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/signoff repository repoGetPullRequestSignOff
+	// ---
+	// summary: Check whether the commits of a pull request are signed off by their authors (DCO)
+	// description: The commits are listed newest first. Merge commits and the commits of exempt users don't need to be signed off.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullSignOffCommitList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	settings, err := dco_service.GetSettings(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	results, err := dco_service.CheckPullRequest(ctx, pr, settings)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	commits := make([]*api.PullSignOffCommit, 0, len(results))
+	for _, r := range results {
+		commits = append(commits, &api.PullSignOffCommit{
+			SHA:       r.Commit.ID.String(),
+			Author:    convert.ToCommitUser(r.Commit.Author),
+			SignedOff: r.SignedOff,
+			Exempt:    r.Exempt,
+			Merge:     r.Merge,
+			Passed:    r.Passed(),
+		})
+	}
+	ctx.JSON(http.StatusOK, commits)
+}
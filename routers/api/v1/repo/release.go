@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/git"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
@@ -224,6 +225,16 @@ func CreateRelease(ctx *context.APIContext) {
 		ctx.APIError(http.StatusUnprocessableEntity, errors.New("repo is empty"))
 		return
 	}
+	if form.GenerateReleaseNotes {
+		notes, ok := generateReleaseNotes(ctx, release_service.GenerateNotesOptions{TagName: form.TagName, Target: form.Target})
+		if !ok {
+			return
+		}
+		if form.Note != "" {
+			notes = form.Note + "\n\n" + notes
+		}
+		form.Note = notes
+	}
 	rel, err := repo_model.GetRelease(ctx, ctx.Repo.Repository.ID, form.TagName)
 	if err != nil {
 		if !repo_model.IsErrReleaseNotExist(err) {
@@ -285,6 +296,73 @@ func CreateRelease(ctx *context.APIContext) {
 	ctx.JSON(http.StatusCreated, convert.ToAPIRelease(ctx, ctx.Repo.Repository, rel))
 }
 
+// GenerateReleaseNotes generates the release notes from the pull requests merged since the previous tag
+func GenerateReleaseNotes(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/releases/generate-notes repository repoGenerateReleaseNotes
+	// ---
+	// summary: Generate the release notes from the pull requests merged since the previous tag
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/GenerateReleaseNotesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseNotes"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.GenerateReleaseNotesOption)
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.APIError(http.StatusUnprocessableEntity, errors.New("repo is empty"))
+		return
+	}
+	notes, ok := generateReleaseNotes(ctx, release_service.GenerateNotesOptions{
+		TagName:         form.TagName,
+		Target:          form.Target,
+		PreviousTagName: form.PreviousTagName,
+	})
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.ReleaseNotes{
+		Title: form.TagName,
+		Note:  notes,
+	})
+}
+
+// generateReleaseNotes returns false if an error occurs, and it writes the error response
+func generateReleaseNotes(ctx *context.APIContext, opts release_service.GenerateNotesOptions) (string, bool) {
+	notes, err := release_service.GenerateNotes(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, opts)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return "", false
+	}
+	return notes, true
+}
+
 // EditRelease edit a release
 func EditRelease(ctx *context.APIContext) {
 	// swagger:operation PATCH /repos/{owner}/{repo}/releases/{id} repository repoEditRelease
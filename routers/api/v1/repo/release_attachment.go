@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -17,6 +18,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/convert"
+	release_service "code.gitea.io/gitea/services/release"
 )
 
 func checkReleaseMatchRepo(ctx *context.APIContext, releaseID int64) bool {
@@ -245,10 +247,16 @@ func CreateReleaseAttachment(ctx *context.APIContext) {
 			ctx.APIError(http.StatusBadRequest, err)
 			return
 		}
+		if quota_model.IsErrQuotaExceeded(err) {
+			ctx.APIError(http.StatusRequestEntityTooLarge, err)
+			return
+		}
 		ctx.APIErrorInternal(err)
 		return
 	}
 
+	release_service.EnqueueAssetPublish(ctx, ctx.Repo.Repository, releaseID)
+
 	ctx.JSON(http.StatusCreated, convert.ToAPIAttachment(ctx.Repo.Repository, attach))
 }
 
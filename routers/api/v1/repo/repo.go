@@ -22,6 +22,7 @@ import (
 	unit_model "code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/httpcache"
 	"code.gitea.io/gitea/modules/label"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
@@ -30,8 +31,10 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/validation"
 	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/shared"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	actions_service "code.gitea.io/gitea/services/actions"
+	admin_service "code.gitea.io/gitea/services/admin"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	feed_service "code.gitea.io/gitea/services/feed"
@@ -376,6 +379,7 @@ func Generate(ctx *context.APIContext) {
 		Avatar:          form.Avatar,
 		IssueLabels:     form.Labels,
 		ProtectedBranch: form.ProtectedBranch,
+		Variables:       form.Variables,
 	}
 
 	if !opts.IsValid() {
@@ -421,7 +425,8 @@ func Generate(ctx *context.APIContext) {
 		if repo_model.IsErrRepoAlreadyExist(err) {
 			ctx.APIError(http.StatusConflict, "The repository with the same name already exists.")
 		} else if db.IsErrNameReserved(err) ||
-			db.IsErrNamePatternNotAllowed(err) {
+			db.IsErrNamePatternNotAllowed(err) ||
+			repo_service.IsErrTemplateVariableMissing(err) {
 			ctx.APIError(http.StatusUnprocessableEntity, err)
 		} else {
 			ctx.APIErrorInternal(err)
@@ -544,9 +549,20 @@ func Get(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Repository"
+	//   "304":
+	//     description: Not modified since the ETag or the time of the conditional request
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
+	etag, err := repoETag(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if httpcache.HandleConditionalRequest(ctx.Req, ctx.Resp, etag, ctx.Repo.Repository.UpdatedUnix.AsTime()) {
+		return
+	}
+
 	if err := ctx.Repo.Repository.LoadAttributes(ctx); err != nil {
 		ctx.APIErrorInternal(err)
 		return
@@ -555,6 +571,29 @@ func Get(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, convert.ToRepo(ctx, ctx.Repo.Repository, ctx.Repo.Permission))
 }
 
+// repoETag returns the ETag of the repository response without loading its attributes. Most changes of the repository
+// update its timestamp, the counters and the unit settings which don't are added to the ETag, as is the permission of the doer.
+func repoETag(ctx *context.APIContext) (string, error) {
+	repo := ctx.Repo.Repository
+	if err := repo.LoadUnits(ctx); err != nil {
+		return "", err
+	}
+	values := []any{
+		repo.ID, repo.FullName(), repo.UpdatedUnix, ctx.Repo.Owner.UpdatedUnix,
+		repo.NumStars, repo.NumForks, repo.NumWatches, repo.NumIssues, repo.NumClosedIssues, repo.NumPulls, repo.NumClosedPulls,
+		repo.Size, repo.IsArchived, repo.IsEmpty,
+		ctx.Repo.Permission.AccessMode, ctx.Repo.Permission.UnitAccessMode(unit_model.TypeCode),
+	}
+	for _, u := range repo.Units {
+		cfg, err := u.Config.ToDB()
+		if err != nil {
+			return "", err
+		}
+		values = append(values, u.Type, string(cfg))
+	}
+	return httpcache.GenerateWeakETag(values...), nil
+}
+
 // GetByID returns a single Repository
 func GetByID(ctx *context.APIContext) {
 	// swagger:operation GET /repositories/{id} repository repoGetByID
@@ -645,7 +684,7 @@ func Edit(ctx *context.APIContext) {
 		}
 	}
 
-	if opts.MirrorInterval != nil || opts.EnablePrune != nil {
+	if opts.MirrorInterval != nil || opts.EnablePrune != nil || opts.MirrorRefFilter != nil || opts.MirrorSkipTags != nil || opts.MirrorMaxSize != nil {
 		if err := updateMirror(ctx, opts); err != nil {
 			return
 		}
@@ -944,6 +983,12 @@ func updateRepoUnits(ctx *context.APIContext, opts api.EditRepoOption) error {
 			if opts.DefaultAllowMaintainerEdit != nil {
 				config.DefaultAllowMaintainerEdit = *opts.DefaultAllowMaintainerEdit
 			}
+			if opts.RequireSignOff != nil {
+				config.RequireSignOff = *opts.RequireSignOff
+			}
+			if opts.SignOffExemptUsers != nil {
+				config.SignOffExemptUsers = *opts.SignOffExemptUsers
+			}
 
 			units = append(units, repo_model.RepoUnit{
 				RepoID: repo.ID,
@@ -983,9 +1028,15 @@ func updateRepoUnits(ctx *context.APIContext, opts api.EditRepoOption) error {
 
 	if opts.HasReleases != nil && !unit_model.TypeReleases.UnitGlobalDisabled() {
 		if *opts.HasReleases {
+			config := repo.MustGetUnit(ctx, unit_model.TypeReleases).ReleasesConfig()
+			if opts.PublishReleaseAssets != nil {
+				config.PublishAssetsToPackages = *opts.PublishReleaseAssets
+			}
+
 			units = append(units, repo_model.RepoUnit{
 				RepoID: repo.ID,
 				Type:   unit_model.TypeReleases,
+				Config: config,
 			})
 		} else {
 			deleteUnitTypes = append(deleteUnitTypes, unit_model.TypeReleases)
@@ -1062,7 +1113,7 @@ func updateRepoArchivedState(ctx *context.APIContext, opts api.EditRepoOption) e
 	return nil
 }
 
-// updateMirror updates a repo's mirror Interval and EnablePrune
+// updateMirror updates a repo's mirror Interval, EnablePrune and ref filters
 func updateMirror(ctx *context.APIContext, opts api.EditRepoOption) error {
 	repo := ctx.Repo.Repository
 
@@ -1109,6 +1160,26 @@ func updateMirror(ctx *context.APIContext, opts api.EditRepoOption) error {
 		log.Trace("Repository %s Mirror[%d] Set EnablePrune: %t", repo.FullName(), mirror.ID, mirror.EnablePrune)
 	}
 
+	// update the ref filters
+	if opts.MirrorRefFilter != nil {
+		if err := repo_model.ValidateMirrorRefFilter(*opts.MirrorRefFilter); err != nil {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return err
+		}
+		mirror.RefFilter = strings.Join(repo_model.ParseMirrorRefFilter(*opts.MirrorRefFilter), ", ")
+	}
+	if opts.MirrorSkipTags != nil {
+		mirror.SkipTags = *opts.MirrorSkipTags
+	}
+	if opts.MirrorMaxSize != nil {
+		if *opts.MirrorMaxSize < 0 {
+			err := fmt.Errorf("invalid mirror max size: %d", *opts.MirrorMaxSize)
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return err
+		}
+		mirror.MaxSize = *opts.MirrorMaxSize
+	}
+
 	// finally update the mirror in the DB
 	if err := repo_model.UpdateMirror(ctx, mirror); err != nil {
 		log.Error("Failed to Set Mirror Interval: %s", err)
@@ -1138,6 +1209,8 @@ func Delete(ctx *context.APIContext) {
 	//   type: string
 	//   required: true
 	// responses:
+	//   "202":
+	//     "$ref": "#/responses/AdminApprovalRequest"
 	//   "204":
 	//     "$ref": "#/responses/empty"
 	//   "403":
@@ -1157,6 +1230,22 @@ func Delete(ctx *context.APIContext) {
 		return
 	}
 
+	// the deletion by an administrator needs the approval of a second administrator, except of their own repositories
+	if admin_service.IsRepoDeletionApprovalRequired(ctx.Doer, repo) {
+		r, err := admin_service.RequestDeleteRepo(ctx, ctx.Doer, repo)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiRequest, err := convert.ToAdminApprovalRequest(ctx, r, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		ctx.JSON(http.StatusAccepted, apiRequest)
+		return
+	}
+
 	if ctx.Repo.GitRepo != nil {
 		ctx.Repo.GitRepo.Close()
 	}
@@ -1314,3 +1403,58 @@ func ListRepoActivityFeeds(ctx *context.APIContext) {
 
 	ctx.JSON(http.StatusOK, convert.ToActivities(ctx, feeds, ctx.Doer))
 }
+
+// StreamRepoActivityFeeds streams the new activities of a repository
+func StreamRepoActivityFeeds(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/events/stream repository repoStreamActivityFeeds
+	// ---
+	// summary: Stream a repository's new activity feeds as server-sent events
+	// description: Every `activity` event has the ID of the activity as event ID. The stream is resumed after the last received activity with the `Last-Event-ID` header or the `last_event_id` query parameter, otherwise only the activities created after the stream is opened are sent.
+	// produces:
+	// - text/event-stream
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: last_event_id
+	//   in: query
+	//   description: ID of the last received activity to resume the stream after it
+	//   type: integer
+	//   format: int64
+	// responses:
+	//   "200":
+	//     description: stream of the activities as server-sent events
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.StreamActivityFeeds(ctx, activities_model.GetFeedsOptions{
+		RequestedRepo:  ctx.Repo.Repository,
+		Actor:          ctx.Doer,
+		IncludePrivate: true,
+	}, func() (bool, error) {
+		repo, err := repo_model.GetRepositoryByID(ctx, ctx.Repo.Repository.ID)
+		if repo_model.IsErrRepoNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		// the access of an actions token is bound to its task, which is checked with the token
+		if ctx.Doer != nil && ctx.Doer.ID == user_model.ActionsUserID {
+			return true, nil
+		}
+		permission, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			return false, err
+		}
+		return permission.HasAnyUnitAccessOrPublicAccess(), nil
+	})
+}
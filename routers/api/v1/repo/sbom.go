@@ -0,0 +1,81 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// GetSBOM creates a SBOM of a repository from its lockfiles
+func GetSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/sbom repository repoGetSBOM
+	// ---
+	// summary: Get a SBOM of the dependencies listed in the lockfiles (go.sum, package-lock.json, requirements.txt) of a repository
+	// produces:
+	// - application/vnd.cyclonedx+json
+	// - application/spdx+json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default to the repository’s default branch."
+	//   type: string
+	//   required: false
+	// - name: format
+	//   in: query
+	//   description: format of the SBOM
+	//   type: string
+	//   enum: [cyclonedx, spdx]
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "The SBOM as CycloneDX 1.5 or SPDX 2.3 JSON document"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	format, ok := sbom.ParseFormat(ctx.FormTrim("format"))
+	if !ok {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Errorf("unsupported SBOM format %q", ctx.FormTrim("format")))
+		return
+	}
+
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	refCommit := resolveRefCommit(ctx, ctx.FormTrim("ref"))
+	if ctx.Written() {
+		return
+	}
+
+	doc, err := repo_service.GenerateSBOM(ctx.Repo.Repository, refCommit.Commit)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", format.ContentType())
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if err := doc.Write(ctx.Resp, format); err != nil {
+		log.Error("Writing SBOM of %s failed: %v", ctx.Repo.Repository.FullName(), err)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListSymbols lists the symbols of the default branch of a repository
+func ListSymbols(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/symbols repository repoListSymbols
+	// ---
+	// summary: List the symbols defined in the default branch, i.e. the definitions of a symbol or the outline of a file
+	// description: The symbols are found by ctags in the background after a push to the default branch, they are ordered by path and line.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: query
+	//   description: exact name of the symbols, to find the definitions of a symbol
+	//   type: string
+	// - name: path
+	//   in: query
+	//   description: path of the file the symbols are defined in, to get the outline of a file
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: prefix of the names of the symbols
+	//   type: string
+	// - name: kind
+	//   in: query
+	//   description: kind of the symbols, e.g. "function"
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoSymbolList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Indexer.SymbolIndexerEnabled {
+		ctx.APIErrorNotFound("the symbol indexer is disabled")
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	symbols, count, err := db.FindAndCount[repo_model.RepoSymbol](ctx, repo_model.FindRepoSymbolsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+		Name:        ctx.FormTrim("name"),
+		Path:        ctx.FormTrim("path"),
+		Keyword:     ctx.FormTrim("q"),
+		Kind:        ctx.FormTrim("kind"),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiSymbols := make([]*api.RepoSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		apiSymbols = append(apiSymbols, convert.ToRepoSymbol(ctx.Repo.Repository, symbol))
+	}
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiSymbols)
+}
@@ -459,6 +459,9 @@ func CreateTagProtection(ctx *context.APIContext) {
 		NamePattern:      strings.TrimSpace(namePattern),
 		AllowlistUserIDs: whitelistUsers,
 		AllowlistTeamIDs: whitelistTeams,
+		RequireAnnotated: form.RequireAnnotated,
+		RequireSigned:    form.RequireSigned,
+		EnforceSemver:    form.EnforceSemver,
 	}
 	if err := git_model.InsertProtectedTag(ctx, protectTag); err != nil {
 		ctx.APIErrorInternal(err)
@@ -566,6 +569,16 @@ func EditTagProtection(ctx *context.APIContext) {
 		pt.AllowlistUserIDs = whitelistUsers
 	}
 
+	if form.RequireAnnotated != nil {
+		pt.RequireAnnotated = *form.RequireAnnotated
+	}
+	if form.RequireSigned != nil {
+		pt.RequireSigned = *form.RequireSigned
+	}
+	if form.EnforceSemver != nil {
+		pt.EnforceSemver = *form.EnforceSemver
+	}
+
 	err = git_model.UpdateProtectedTag(ctx, pt)
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -0,0 +1,242 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// trackedTimesFilter returns the user and the date range of the tracked times to report from the query parameters,
+// the users who can't manage the issues of the repository only get their own times
+func trackedTimesFilter(ctx *context.APIContext) (userID, createdBeforeUnix, createdAfterUnix int64, ok bool) {
+	if !ctx.Repo.Repository.IsTimetrackerEnabled(ctx) {
+		ctx.APIError(http.StatusBadRequest, "time tracking disabled")
+		return 0, 0, 0, false
+	}
+
+	if qUser := ctx.FormTrim("user"); qUser != "" {
+		user, err := user_model.GetUserByName(ctx, qUser)
+		if err != nil {
+			ctx.NotFoundOrServerError(err)
+			return 0, 0, 0, false
+		}
+		userID = user.ID
+	}
+
+	var err error
+	if createdBeforeUnix, createdAfterUnix, err = context.GetQueryBeforeSince(ctx.Base); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return 0, 0, 0, false
+	}
+
+	if !ctx.Doer.IsAdmin && userID != ctx.Doer.ID && !ctx.IsUserRepoWriter([]unit.Type{unit.TypeIssues}) {
+		if userID != 0 {
+			ctx.APIError(http.StatusForbidden, errors.New("query by user not allowed; not enough rights"))
+			return 0, 0, 0, false
+		}
+		userID = ctx.Doer.ID
+	}
+	return userID, createdBeforeUnix, createdAfterUnix, true
+}
+
+// GetTrackedTimeReport sum the tracked times of a repository
+func GetTrackedTimeReport(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/times/report repository repoTrackedTimeReport
+	// ---
+	// summary: Sum a repo's tracked times by user, milestone or issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: group_by
+	//   in: query
+	//   description: dimension to sum the tracked times by
+	//   type: string
+	//   enum: [user, milestone, issue]
+	//   default: user
+	// - name: user
+	//   in: query
+	//   description: optional filter by user (available for issue managers)
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: Only sum times tracked after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only sum times tracked before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TrackedTimeSumList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	userID, before, after, ok := trackedTimesFilter(ctx)
+	if !ok {
+		return
+	}
+
+	groupBy := issues_model.TrackedTimeGroupBy(ctx.FormString("group_by", string(issues_model.TrackedTimeGroupByUser)))
+	if groupBy == issues_model.TrackedTimeGroupByRepo {
+		ctx.APIError(http.StatusUnprocessableEntity, "the tracked times of a repository can't be summed by repository")
+		return
+	}
+
+	sums, err := issues_model.GetTrackedTimeReport(ctx, &issues_model.TrackedTimeReportOptions{
+		RepoID:            ctx.Repo.Repository.ID,
+		UserID:            userID,
+		GroupBy:           groupBy,
+		CreatedAfterUnix:  after,
+		CreatedBeforeUnix: before,
+	})
+	if err != nil {
+		handleIssueFieldError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToTrackedTimeSums(sums))
+}
+
+// ExportTrackedTimes export the tracked times of a repository as CSV
+func ExportTrackedTimes(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/times/export repository repoExportTrackedTimes
+	// ---
+	// summary: Export a repo's tracked times as CSV
+	// produces:
+	// - text/csv
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: user
+	//   in: query
+	//   description: optional filter by user (available for issue managers)
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: Only export times tracked after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: Only export times tracked before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     description: the tracked times as CSV
+	//     schema:
+	//       type: file
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	userID, before, after, ok := trackedTimesFilter(ctx)
+	if !ok {
+		return
+	}
+
+	trackedTimes, err := issues_model.GetTrackedTimes(ctx, &issues_model.FindTrackedTimesOptions{
+		RepositoryID:      ctx.Repo.Repository.ID,
+		UserID:            userID,
+		CreatedAfterUnix:  after,
+		CreatedBeforeUnix: before,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := issue_service.WriteTrackedTimesCSV(ctx, &buf, trackedTimes); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-times.csv"`, ctx.Repo.Repository.Name))
+	ctx.Resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(buf.Bytes())
+}
+
+// ListRepoStopwatches list the running stopwatches on the issues of a repository
+func ListRepoStopwatches(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/stopwatches repository repoListStopwatches
+	// ---
+	// summary: List the running stopwatches of all the users on a repo's issues
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/StopWatchList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	sws, err := issues_model.GetRepoStopwatches(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiSWs, err := convert.ToStopWatches(ctx, sws)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apiSWs)
+}
@@ -81,6 +81,8 @@ func NewWikiPage(ctx *context.APIContext) {
 			ctx.APIError(http.StatusBadRequest, err)
 		} else if repo_model.IsErrWikiAlreadyExist(err) {
 			ctx.APIError(http.StatusBadRequest, err)
+		} else if repo_model.IsErrWikiPageProtected(err) {
+			ctx.APIError(http.StatusForbidden, err)
 		} else {
 			ctx.APIErrorInternal(err)
 		}
@@ -155,6 +157,10 @@ func EditWikiPage(ctx *context.APIContext) {
 	form.ContentBase64 = string(content)
 
 	if err := wiki_service.EditWikiPage(ctx, ctx.Doer, ctx.Repo.Repository, oldWikiName, newWikiName, form.ContentBase64, form.Message); err != nil {
+		if repo_model.IsErrWikiPageProtected(err) {
+			ctx.APIError(http.StatusForbidden, err)
+			return
+		}
 		ctx.APIErrorInternal(err)
 		return
 	}
@@ -249,6 +255,10 @@ func DeleteWikiPage(ctx *context.APIContext) {
 			ctx.APIErrorNotFound(err)
 			return
 		}
+		if repo_model.IsErrWikiPageProtected(err) {
+			ctx.APIError(http.StatusForbidden, err)
+			return
+		}
 		ctx.APIErrorInternal(err)
 		return
 	}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/eventsource"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	auth_service "code.gitea.io/gitea/services/auth"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	feed_service "code.gitea.io/gitea/services/feed"
+)
+
+const activityStreamPingInterval = 30 * time.Second
+
+// isStreamAllowed checks whether the doer may still receive the activities of the stream, the doer, their token or their
+// access may have been removed since the stream has been opened
+func isStreamAllowed(ctx *context.APIContext, canAccess func() (bool, error)) (bool, error) {
+	if ctx.IsSigned && ctx.Doer.ID > 0 {
+		doer, err := user_model.GetUserByID(ctx, ctx.Doer.ID)
+		if user_model.IsErrUserNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if !doer.IsActive || doer.ProhibitLogin {
+			return false, nil
+		}
+		// the permissions are checked with the current state of the doer, e.g. they may not be an admin anymore
+		ctx.Doer = doer
+	}
+	if valid, err := auth_service.IsRequestTokenValid(ctx, ctx.Req, ctx.Data); err != nil || !valid {
+		return false, err
+	}
+	return canAccess()
+}
+
+// StreamActivityFeeds streams the new activities of the options as server-sent events until the client disconnects.
+// The ID of every event is the ID of the activity, a client resumes the stream after the last received activity
+// with the Last-Event-ID header or the last_event_id query parameter. canAccess checks before every poll whether the
+// doer can still access the activities, the stream is closed as soon as they can't or their token has been deleted.
+func StreamActivityFeeds(ctx *context.APIContext, opts activities_model.GetFeedsOptions, canAccess func() (bool, error)) {
+	if setting.UI.Notification.EventSourceUpdateTime <= 0 {
+		ctx.APIErrorNotFound("event streams are disabled")
+		return
+	}
+
+	lastEventID := ctx.Req.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.FormString("last_event_id")
+	}
+
+	var cursor int64
+	var err error
+	if lastEventID != "" {
+		cursor, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || cursor < 0 {
+			ctx.APIError(http.StatusBadRequest, "invalid last event ID")
+			return
+		}
+	} else if cursor, err = activities_model.GetLatestActionID(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "text/event-stream")
+	ctx.Resp.Header().Set("Cache-Control", "no-cache")
+	ctx.Resp.Header().Set("Connection", "keep-alive")
+	ctx.Resp.Header().Set("X-Accel-Buffering", "no")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	ctx.Resp.Flush()
+
+	pollTimer := time.NewTicker(setting.UI.Notification.EventSourceUpdateTime)
+	defer pollTimer.Stop()
+	pingTimer := time.NewTicker(activityStreamPingInterval)
+	defer pingTimer.Stop()
+	shutdownCtx := graceful.GetManager().ShutdownContext()
+
+	for {
+		if allowed, err := isStreamAllowed(ctx, canAccess); err != nil {
+			log.Error("Unable to check the access to the activity stream: %v", err)
+			return
+		} else if !allowed {
+			log.Debug("Closing the activity stream of %s as the access has been revoked", ctx.Doer.GetDisplayName())
+			return
+		}
+		if opts.Actor != nil {
+			opts.Actor = ctx.Doer
+		}
+
+		// get the signal before the query, so that the actions created during the query aren't missed
+		newFeeds := feed_service.NewFeedsSignal()
+		for {
+			actions, err := feed_service.GetFeedsAfter(ctx, opts, cursor, setting.API.MaxResponseItems)
+			if err != nil {
+				log.Error("Unable to get the activities to stream: %v", err)
+				return
+			}
+			for _, action := range actions {
+				event := &eventsource.Event{
+					Name: "activity",
+					ID:   strconv.FormatInt(action.ID, 10),
+					Data: convert.ToActivity(ctx, action, ctx.Doer),
+				}
+				if _, err := event.WriteTo(ctx.Resp); err != nil {
+					log.Debug("Unable to write to the activity stream: %v", err)
+					return
+				}
+				cursor = action.ID
+			}
+			ctx.Resp.Flush()
+			if len(actions) < setting.API.MaxResponseItems {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-shutdownCtx.Done():
+			return
+		case <-pingTimer.C:
+			if _, err := (&eventsource.Event{Name: "ping"}).WriteTo(ctx.Resp); err != nil {
+				log.Debug("Unable to write to the activity stream: %v", err)
+				return
+			}
+			ctx.Resp.Flush()
+		case <-newFeeds:
+		case <-pollTimer.C:
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// GetArtifactRetention returns the artifact retention policy of the owner or the repository,
+// an empty policy is returned if none has been set
+func GetArtifactRetention(ctx *context.APIContext, ownerID, repoID int64) {
+	policy, err := actions_model.GetArtifactRetention(ctx, ownerID, repoID)
+	if errors.Is(err, util.ErrNotExist) {
+		policy = &actions_model.ActionArtifactRetention{}
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionArtifactRetention(policy))
+}
+
+// UpdateArtifactRetention sets the artifact retention policy of the owner or the repository
+func UpdateArtifactRetention(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm(ctx).(*api.EditActionArtifactRetentionOption)
+	policy := &actions_model.ActionArtifactRetention{
+		OwnerID:      ownerID,
+		RepoID:       repoID,
+		MaxAgeDays:   form.MaxAgeDays,
+		MaxTotalSize: form.MaxTotalSize,
+		KeepLastN:    form.KeepLastN,
+	}
+	if err := actions_model.SetArtifactRetention(ctx, policy); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionArtifactRetention(policy))
+}
+
+// DeleteArtifactRetention deletes the artifact retention policy of the owner or the repository
+func DeleteArtifactRetention(ctx *context.APIContext, ownerID, repoID int64) {
+	if err := actions_model.DeleteArtifactRetention(ctx, ownerID, repoID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetArtifactUsage returns the storage consumed by the artifacts of the repository or of each repository of the owner
+func GetArtifactUsage(ctx *context.APIContext, ownerID, repoID int64) {
+	usages, err := actions_model.GetArtifactUsages(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionArtifactUsage(usages))
+}
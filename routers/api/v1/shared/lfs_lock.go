@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// getLFSLockOwnerID returns the ID of the user with the given name, 0 if the name is empty
+func getLFSLockOwnerID(ctx *context.APIContext, name string) (int64, bool) {
+	if name == "" {
+		return 0, true
+	}
+	owner, err := user_model.GetUserByName(ctx, name)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return 0, false
+	}
+	return owner.ID, true
+}
+
+func toRepoLFSLocks(ctx *context.APIContext, locks git_model.LFSLockList) ([]*api.RepoLFSLock, error) {
+	if err := locks.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	if err := locks.LoadRepos(ctx); err != nil {
+		return nil, err
+	}
+	apiLocks := make([]*api.RepoLFSLock, len(locks))
+	for i, lock := range locks {
+		apiLocks[i] = convert.ToRepoLFSLock(ctx, lock, ctx.Doer)
+	}
+	return apiLocks, nil
+}
+
+// ListLFSLocks lists the LFS locks of a repository, or of all repositories if repoID is 0, the oldest first
+func ListLFSLocks(ctx *context.APIContext, repoID int64) {
+	ownerID, ok := getLFSLockOwnerID(ctx, ctx.FormString("user"))
+	if !ok {
+		return
+	}
+
+	locks, total, err := db.FindAndCount[git_model.LFSLock](ctx, &git_model.FindLFSLocksOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      repoID,
+		OwnerID:     ownerID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiLocks, err := toRepoLFSLocks(ctx, locks)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiLocks)
+}
+
+// DeleteLFSLocks force-unlocks the LFS locks of a repository, or of all repositories if repoID is 0, and returns them
+func DeleteLFSLocks(ctx *context.APIContext, repoID int64) {
+	form := web.GetForm(ctx).(*api.DeleteLFSLocksOption)
+	if len(form.IDs) == 0 && form.Owner == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "ids or owner is required")
+		return
+	}
+	ownerID, ok := getLFSLockOwnerID(ctx, form.Owner)
+	if !ok {
+		return
+	}
+
+	locks, err := db.Find[git_model.LFSLock](ctx, &git_model.FindLFSLocksOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      repoID,
+		OwnerID:     ownerID,
+		IDs:         form.IDs,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ids := make([]int64, len(locks))
+	for i, lock := range locks {
+		ids[i] = lock.ID
+	}
+	if err := git_model.DeleteLFSLocks(ctx, ids); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiLocks, err := toRepoLFSLocks(ctx, locks)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiLocks)
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	quota_model "code.gitea.io/gitea/models/quota"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// GetQuota responds with the storage quota and usage of the owner
+// Access rights are checked at the API route level
+func GetQuota(ctx *context.APIContext, ownerID int64) {
+	limit, err := quota_model.GetOverriddenLimit(ctx, ownerID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	overridden := limit != nil
+	if !overridden {
+		limit = quota_model.DefaultLimit(ownerID)
+	}
+
+	usage, err := quota_model.GetUsage(ctx, ownerID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToQuotaInfo(limit, overridden, usage))
+}
@@ -5,13 +5,19 @@ package shared
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
@@ -36,6 +42,60 @@ func GetRegistrationToken(ctx *context.APIContext, ownerID, repoID int64) {
 	ctx.JSON(http.StatusOK, RegistrationToken{Token: token.Token})
 }
 
+const (
+	defaultRunnerJITTokenExpiry = time.Hour
+	maxRunnerJITTokenExpiry     = 24 * time.Hour
+)
+
+// CreateRunnerJITToken creates a single-use token which registers an ephemeral runner with the requested labels
+func CreateRunnerJITToken(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm(ctx).(*api.CreateRunnerJITTokenOption)
+
+	expiry := defaultRunnerJITTokenExpiry
+	if form.ExpiresIn != 0 {
+		expiry = time.Duration(form.ExpiresIn) * time.Second
+	}
+	if expiry <= 0 || expiry > maxRunnerJITTokenExpiry {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("expires_in must be between 1 and %d seconds", int64(maxRunnerJITTokenExpiry.Seconds())))
+		return
+	}
+	labels := make([]string, 0, len(form.Labels))
+	for _, label := range form.Labels {
+		if label = strings.TrimSpace(label); label != "" && !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		ctx.APIError(http.StatusUnprocessableEntity, "labels must not be empty")
+		return
+	}
+
+	token, err := actions_model.NewRunnerJITToken(ctx, ownerID, repoID, labels, timeutil.TimeStamp(time.Now().Add(expiry).Unix()))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, &api.RunnerJITToken{
+		Token:     token.Token,
+		Labels:    token.Labels,
+		ExpiresAt: token.Expires.AsTime(),
+	})
+}
+
+// ListRunnerPendingJobs lists the number of jobs waiting for a runner of the owner or repository by their labels
+func ListRunnerPendingJobs(ctx *context.APIContext, ownerID, repoID int64) {
+	pending, err := actions_model.CountPendingJobs(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	res := make([]*api.ActionRunnerPendingJobs, len(pending))
+	for i, p := range pending {
+		res[i] = &api.ActionRunnerPendingJobs{Labels: p.Labels, Count: p.Count}
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
 // ListRunners lists runners for api route validated ownerID and repoID
 // ownerID == 0 and repoID == 0 means all runners including global runners, does not appear in sql where clause
 // ownerID == 0 and repoID != 0 means all runners for the given repo
@@ -46,3 +46,31 @@ type swaggerResponseActionWorkflowList struct {
 	// in:body
 	Body api.ActionWorkflowResponse `json:"body"`
 }
+
+// RunnerJITToken
+// swagger:response RunnerJITToken
+type swaggerResponseRunnerJITToken struct {
+	// in:body
+	Body api.RunnerJITToken `json:"body"`
+}
+
+// RunnerPendingJobsList
+// swagger:response RunnerPendingJobsList
+type swaggerResponseRunnerPendingJobsList struct {
+	// in:body
+	Body []api.ActionRunnerPendingJobs `json:"body"`
+}
+
+// ActionArtifactRetention
+// swagger:response ActionArtifactRetention
+type swaggerResponseActionArtifactRetention struct {
+	// in:body
+	Body api.ActionArtifactRetention `json:"body"`
+}
+
+// ActionArtifactUsage
+// swagger:response ActionArtifactUsage
+type swaggerResponseActionArtifactUsage struct {
+	// in:body
+	Body api.ActionArtifactUsage `json:"body"`
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package swagger
+
+import (
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// IndexerList
+// swagger:response IndexerList
+type swaggerResponseIndexerList struct {
+	// in:body
+	Body []api.Indexer `json:"body"`
+}
+
+// RepoIndexerStatusList
+// swagger:response RepoIndexerStatusList
+type swaggerResponseRepoIndexerStatusList struct {
+	// in:body
+	Body []api.RepoIndexerStatus `json:"body"`
+}
@@ -21,6 +21,13 @@ type swaggerResponseIssueList struct {
 	Body []api.Issue `json:"body"`
 }
 
+// BulkEditIssueResultList
+// swagger:response BulkEditIssueResultList
+type swaggerResponseBulkEditIssueResultList struct {
+	// in:body
+	Body []api.BulkEditIssueResult `json:"body"`
+}
+
 // Comment
 // swagger:response Comment
 type swaggerResponseComment struct {
@@ -42,6 +49,125 @@ type swaggerResponseTimelineList struct {
 	Body []api.TimelineComment `json:"body"`
 }
 
+// IssueField
+// swagger:response IssueField
+type swaggerResponseIssueField struct {
+	// in:body
+	Body api.IssueField `json:"body"`
+}
+
+// IssueFieldList
+// swagger:response IssueFieldList
+type swaggerResponseIssueFieldList struct {
+	// in:body
+	Body []api.IssueField `json:"body"`
+}
+
+// IssueFieldValueList
+// swagger:response IssueFieldValueList
+type swaggerResponseIssueFieldValueList struct {
+	// in:body
+	Body []api.IssueFieldValue `json:"body"`
+}
+
+// IssueSavedSearch
+// swagger:response IssueSavedSearch
+type swaggerResponseIssueSavedSearch struct {
+	// in:body
+	Body api.IssueSavedSearch `json:"body"`
+}
+
+// IssueSavedSearchList
+// swagger:response IssueSavedSearchList
+type swaggerResponseIssueSavedSearchList struct {
+	// in:body
+	Body []api.IssueSavedSearch `json:"body"`
+}
+
+// IssueSLARule
+// swagger:response IssueSLARule
+type swaggerResponseIssueSLARule struct {
+	// in:body
+	Body api.IssueSLARule `json:"body"`
+}
+
+// IssueSLARuleList
+// swagger:response IssueSLARuleList
+type swaggerResponseIssueSLARuleList struct {
+	// in:body
+	Body []api.IssueSLARule `json:"body"`
+}
+
+// IssueSLAStatusList
+// swagger:response IssueSLAStatusList
+type swaggerResponseIssueSLAStatusList struct {
+	// in:body
+	Body []api.IssueSLAStatus `json:"body"`
+}
+
+// IssueHierarchy
+// swagger:response IssueHierarchy
+type swaggerResponseIssueHierarchy struct {
+	// in:body
+	Body api.IssueHierarchy `json:"body"`
+}
+
+// SimilarIssueList
+// swagger:response SimilarIssueList
+type swaggerResponseSimilarIssueList struct {
+	// in:body
+	Body []api.SimilarIssue `json:"body"`
+}
+
+// Iteration
+// swagger:response Iteration
+type swaggerResponseIteration struct {
+	// in:body
+	Body api.Iteration `json:"body"`
+}
+
+// IterationList
+// swagger:response IterationList
+type swaggerResponseIterationList struct {
+	// in:body
+	Body []api.Iteration `json:"body"`
+}
+
+// IterationBurndown
+// swagger:response IterationBurndown
+type swaggerResponseIterationBurndown struct {
+	// in:body
+	Body api.IterationBurndown `json:"body"`
+}
+
+// IterationVelocityList
+// swagger:response IterationVelocityList
+type swaggerResponseIterationVelocityList struct {
+	// in:body
+	Body []api.IterationVelocity `json:"body"`
+}
+
+// TrackedTimeSumList
+// swagger:response TrackedTimeSumList
+type swaggerResponseTrackedTimeSumList struct {
+	// in:body
+	Body []api.TrackedTimeSum `json:"body"`
+}
+
+// TimeTrackingRule
+// swagger:response TimeTrackingRule
+type swaggerResponseTimeTrackingRule struct {
+	// in:body
+	Body api.TimeTrackingRule `json:"body"`
+}
+
+// TimeTrackingRuleList
+// swagger:response TimeTrackingRuleList
+type swaggerResponseTimeTrackingRuleList struct {
+	// in:body
+	Body []api.TimeTrackingRule `json:"body"`
+}
+
 // Label
 // swagger:response Label
 type swaggerResponseLabel struct {
@@ -27,3 +27,10 @@ type swaggerNotificationCount struct {
 	// in:body
 	Body api.NotificationCount `json:"body"`
 }
+
+// NotificationSettings
+// swagger:response NotificationSettings
+type swaggerNotificationSettings struct {
+	// in:body
+	Body api.NotificationSettings `json:"body"`
+}
@@ -26,6 +26,8 @@ type swaggerParameterBodies struct {
 	CreateHookOption api.CreateHookOption
 	// in:body
 	EditHookOption api.EditHookOption
+	// in:body
+	RedeliverHookDeliveriesOption api.RedeliverHookDeliveriesOption
 
 	// in:body
 	EditGitHookOption api.EditGitHookOption
@@ -35,6 +37,8 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditIssueOption api.EditIssueOption
 	// in:body
+	BulkEditIssuesOption api.BulkEditIssuesOption
+	// in:body
 	EditDeadlineOption api.EditDeadlineOption
 
 	// in:body
@@ -52,12 +56,73 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	RenameUserOption api.RenameUserOption
+	// in:body
+	OffboardUserOption api.OffboardUserOption
 
 	// in:body
 	CreateLabelOption api.CreateLabelOption
 	// in:body
 	EditLabelOption api.EditLabelOption
 
+	// in:body
+	CreateIssueFieldOption api.CreateIssueFieldOption
+	// in:body
+	EditIssueFieldOption api.EditIssueFieldOption
+	// in:body
+	EditIssueFieldValuesOption api.EditIssueFieldValuesOption
+
+	// in:body
+	CreateIssueSavedSearchOption api.CreateIssueSavedSearchOption
+	// in:body
+	EditIssueSavedSearchOption api.EditIssueSavedSearchOption
+
+	// in:body
+	CreateIssueSLARuleOption api.CreateIssueSLARuleOption
+	// in:body
+	EditIssueSLARuleOption api.EditIssueSLARuleOption
+
+	// in:body
+	CreateIterationOption api.CreateIterationOption
+	// in:body
+	EditIterationOption api.EditIterationOption
+	// in:body
+	CloseIterationOption api.CloseIterationOption
+	// in:body
+	SetIssueIterationOption api.SetIssueIterationOption
+
+	// in:body
+	CreateTimeTrackingRuleOption api.CreateTimeTrackingRuleOption
+
+	// in:body
+	EditTimeTrackingRuleOption api.EditTimeTrackingRuleOption
+
+	// in:body
+	EditLicensePolicyOption api.EditLicensePolicyOption
+
+	// in:body
+	EditSignOffPolicyOption api.EditSignOffPolicyOption
+
+	// in:body
+	EditWebPushSettingsOption api.EditWebPushSettingsOption
+
+	// in:body
+	CreateWebPushSubscriptionOption api.CreateWebPushSubscriptionOption
+
+	// in:body
+	EditMatrixSettingsOption api.EditMatrixSettingsOption
+
+	// in:body
+	EditWatchOption api.EditWatchOption
+
+	// in:body
+	EditNotificationSettingsOption api.EditNotificationSettingsOption
+
+	// in:body
+	EditIncomingEmailAliasOption api.EditIncomingEmailAliasOption
+
+	// in:body
+	DeleteLFSLocksOption api.DeleteLFSLocksOption
+
 	// in:body
 	MarkupOption api.MarkupOption
 	// in:body
@@ -76,6 +141,8 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreatePullRequestOption api.CreatePullRequestOption
 	// in:body
+	CreateFederatedPullRequestOption api.CreateFederatedPullRequestOption
+	// in:body
 	EditPullRequestOption api.EditPullRequestOption
 	// in:body
 	MergePullRequestOption forms.MergePullRequestForm
@@ -84,6 +151,8 @@ type swaggerParameterBodies struct {
 	CreateReleaseOption api.CreateReleaseOption
 	// in:body
 	EditReleaseOption api.EditReleaseOption
+	// in:body
+	GenerateReleaseNotesOption api.GenerateReleaseNotesOption
 
 	// in:body
 	CreateRepoOption api.CreateRepoOption
@@ -96,6 +165,8 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateForkOption api.CreateForkOption
 	// in:body
+	CreateFederatedForkOption api.CreateFederatedForkOption
+	// in:body
 	GenerateRepoOption api.GenerateRepoOption
 
 	// in:body
@@ -106,6 +177,11 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditTeamOption api.EditTeamOption
 
+	// in:body
+	CreateOrgRoleOption api.CreateOrgRoleOption
+	// in:body
+	EditOrgRoleOption api.EditOrgRoleOption
+
 	// in:body
 	AddTimeOption api.AddTimeOption
 
@@ -217,9 +293,48 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateActionWorkflowDispatch api.CreateActionWorkflowDispatch
 
+	// in:body
+	CreateRepositoryDispatchOption api.CreateRepositoryDispatchOption
+
 	// in:body
 	UpdateVariableOption api.UpdateVariableOption
 
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	SetQuotaOption api.SetQuotaOption
+
+	// in:body
+	CreateRunnerJITTokenOption api.CreateRunnerJITTokenOption
+
+	// in:body
+	EditActionArtifactRetentionOption api.EditActionArtifactRetentionOption
+
+	// in:body
+	CreateOrUpdateEnvironmentOption api.CreateOrUpdateEnvironmentOption
+
+	// in:body
+	ReviewPendingDeploymentsOption api.ReviewPendingDeploymentsOption
+
+	// in:body
+	CreatePackageCleanupRuleOption api.CreatePackageCleanupRuleOption
+	// in:body
+	EditPackageCleanupRuleOption api.EditPackageCleanupRuleOption
+
+	// in:body
+	EditMaintenanceModeOption api.EditMaintenanceModeOption
+
+	// in:body
+	CreateAnnouncementOption api.CreateAnnouncementOption
+	// in:body
+	EditAnnouncementOption api.EditAnnouncementOption
+
+	// in:body
+	CreateTermsDocumentOption api.CreateTermsDocumentOption
+
+	// in:body
+	ResolveAbuseReportOption api.ResolveAbuseReportOption
+	// in:body
+	CreateResolutionTemplateOption api.CreateResolutionTemplateOption
 }
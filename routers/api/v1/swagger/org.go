@@ -41,3 +41,45 @@ type swaggerResponseOrganizationPermissions struct {
 	// in:body
 	Body api.OrganizationPermissions `json:"body"`
 }
+
+// LicensePolicy
+// swagger:response LicensePolicy
+type swaggerResponseLicensePolicy struct {
+	// in:body
+	Body api.LicensePolicy `json:"body"`
+}
+
+// SignOffPolicy
+// swagger:response SignOffPolicy
+type swaggerResponseSignOffPolicy struct {
+	// in:body
+	Body api.SignOffPolicy `json:"body"`
+}
+
+// OrgRole
+// swagger:response OrgRole
+type swaggerResponseOrgRole struct {
+	// in:body
+	Body api.OrgRole `json:"body"`
+}
+
+// OrgRoleList
+// swagger:response OrgRoleList
+type swaggerResponseOrgRoleList struct {
+	// in:body
+	Body []api.OrgRole `json:"body"`
+}
+
+// OrgRoleAssignmentList
+// swagger:response OrgRoleAssignmentList
+type swaggerResponseOrgRoleAssignmentList struct {
+	// in:body
+	Body []api.OrgRoleAssignment `json:"body"`
+}
+
+// OrgRolePermissionCatalog
+// swagger:response OrgRolePermissionCatalog
+type swaggerResponseOrgRolePermissionCatalog struct {
+	// in:body
+	Body []string `json:"body"`
+}
@@ -27,3 +27,31 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageCleanupRule
+// swagger:response PackageCleanupRule
+type swaggerResponsePackageCleanupRule struct {
+	// in:body
+	Body api.PackageCleanupRule `json:"body"`
+}
+
+// PackageCleanupRuleList
+// swagger:response PackageCleanupRuleList
+type swaggerResponsePackageCleanupRuleList struct {
+	// in:body
+	Body []api.PackageCleanupRule `json:"body"`
+}
+
+// ContainerSignatureStatus
+// swagger:response ContainerSignatureStatus
+type swaggerResponseContainerSignatureStatus struct {
+	// in:body
+	Body api.ContainerSignatureStatus `json:"body"`
+}
+
+// PackageVulnerabilityReport
+// swagger:response PackageVulnerabilityReport
+type swaggerResponsePackageVulnerabilityReport struct {
+	// in:body
+	Body api.PackageVulnerabilityReport `json:"body"`
+}
@@ -112,6 +112,13 @@ type swaggerResponseHookList struct {
 	Body []api.Hook `json:"body"`
 }
 
+// HookDeliveryList
+// swagger:response HookDeliveryList
+type swaggerResponseHookDeliveryList struct {
+	// in:body
+	Body []api.HookDelivery `json:"body"`
+}
+
 // GitHook
 // swagger:response GitHook
 type swaggerResponseGitHook struct {
@@ -140,6 +147,13 @@ type swaggerResponseReleaseList struct {
 	Body []api.Release `json:"body"`
 }
 
+// ReleaseNotes
+// swagger:response ReleaseNotes
+type swaggerResponseReleaseNotes struct {
+	// in:body
+	Body api.ReleaseNotes `json:"body"`
+}
+
 // PullRequest
 // swagger:response PullRequest
 type swaggerResponsePullRequest struct {
@@ -365,6 +379,34 @@ type swaggerLanguageStatistics struct {
 	Body map[string]int64 `json:"body"`
 }
 
+// ContributorStatsList
+// swagger:response ContributorStatsList
+type swaggerContributorStatsList struct {
+	// in: body
+	Body []api.ContributorStats `json:"body"`
+}
+
+// WeeklyCommitStatsList
+// swagger:response WeeklyCommitStatsList
+type swaggerWeeklyCommitStatsList struct {
+	// in: body
+	Body []api.WeeklyCommitStats `json:"body"`
+}
+
+// PullRequestInsights
+// swagger:response PullRequestInsights
+type swaggerPullRequestInsights struct {
+	// in: body
+	Body api.PullRequestInsights `json:"body"`
+}
+
+// IssueInsights
+// swagger:response IssueInsights
+type swaggerIssueInsights struct {
+	// in: body
+	Body api.IssueInsights `json:"body"`
+}
+
 // LicensesList
 // swagger:response LicensesList
 type swaggerLicensesList struct {
@@ -414,6 +456,13 @@ type swaggerPushMirrorList struct {
 	Body []api.PushMirror `json:"body"`
 }
 
+// PushMirrorStatus
+// swagger:response PushMirrorStatus
+type swaggerPushMirrorStatus struct {
+	// in:body
+	Body api.PushMirrorStatus `json:"body"`
+}
+
 // RepoCollaboratorPermission
 // swagger:response RepoCollaboratorPermission
 type swaggerRepoCollaboratorPermission struct {
@@ -463,6 +512,62 @@ type swaggerWorkflowRun struct {
 	Body api.ActionWorkflowRun `json:"body"`
 }
 
+// ActionConcurrencyGroupList
+// swagger:response ActionConcurrencyGroupList
+type swaggerActionConcurrencyGroupList struct {
+	// in:body
+	Body []api.ActionConcurrencyGroup `json:"body"`
+}
+
+// ActionCacheList
+// swagger:response ActionCacheList
+type swaggerActionCacheList struct {
+	// in:body
+	Body api.ActionCacheList `json:"body"`
+}
+
+// ActionCacheUsage
+// swagger:response ActionCacheUsage
+type swaggerActionCacheUsage struct {
+	// in:body
+	Body api.ActionCacheUsage `json:"body"`
+}
+
+// ActionEnvironment
+// swagger:response ActionEnvironment
+type swaggerActionEnvironment struct {
+	// in:body
+	Body api.ActionEnvironment `json:"body"`
+}
+
+// ActionEnvironmentList
+// swagger:response ActionEnvironmentList
+type swaggerActionEnvironmentList struct {
+	// in:body
+	Body api.ActionEnvironmentList `json:"body"`
+}
+
+// PendingDeploymentList
+// swagger:response PendingDeploymentList
+type swaggerPendingDeploymentList struct {
+	// in:body
+	Body []api.PendingDeployment `json:"body"`
+}
+
+// DeploymentReviewList
+// swagger:response DeploymentReviewList
+type swaggerDeploymentReviewList struct {
+	// in:body
+	Body []api.DeploymentReview `json:"body"`
+}
+
+// WorkflowRunGraph
+// swagger:response WorkflowRunGraph
+type swaggerWorkflowRunGraph struct {
+	// in:body
+	Body api.ActionWorkflowRunGraph `json:"body"`
+}
+
 // WorkflowJobsList
 // swagger:response WorkflowJobsList
 type swaggerActionWorkflowJobsResponse struct {
@@ -522,3 +627,52 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// IncomingEmailAlias
+// swagger:response IncomingEmailAlias
+type swaggerResponseIncomingEmailAlias struct {
+	// in:body
+	Body api.IncomingEmailAlias `json:"body"`
+}
+
+// RepoLFSLockList
+// swagger:response RepoLFSLockList
+type swaggerResponseRepoLFSLockList struct {
+	// in:body
+	Body []api.RepoLFSLock `json:"body"`
+}
+
+// RepoDependencyList
+// swagger:response RepoDependencyList
+type swaggerRepoDependencyList struct {
+	// in: body
+	Body []api.RepoDependency `json:"body"`
+}
+
+// RepoSymbolList
+// swagger:response RepoSymbolList
+type swaggerRepoSymbolList struct {
+	// in: body
+	Body []api.RepoSymbol `json:"body"`
+}
+
+// DependentList
+// swagger:response DependentList
+type swaggerDependentList struct {
+	// in: body
+	Body []api.Dependent `json:"body"`
+}
+
+// LicenseCompliance
+// swagger:response LicenseCompliance
+type swaggerResponseLicenseCompliance struct {
+	// in:body
+	Body api.LicenseCompliance `json:"body"`
+}
+
+// PullSignOffCommitList
+// swagger:response PullSignOffCommitList
+type swaggerResponsePullSignOffCommitList struct {
+	// in:body
+	Body []api.PullSignOffCommit `json:"body"`
+}
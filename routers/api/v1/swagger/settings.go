@@ -32,3 +32,87 @@ type swaggerResponseGeneralAttachmentSettings struct {
 	// in:body
 	Body api.GeneralAttachmentSettings `json:"body"`
 }
+
+// MaintenanceMode
+// swagger:response MaintenanceMode
+type swaggerResponseMaintenanceMode struct {
+	// in:body
+	Body api.MaintenanceMode `json:"body"`
+}
+
+// Announcement
+// swagger:response Announcement
+type swaggerResponseAnnouncement struct {
+	// in:body
+	Body api.Announcement `json:"body"`
+}
+
+// AnnouncementList
+// swagger:response AnnouncementList
+type swaggerResponseAnnouncementList struct {
+	// in:body
+	Body []api.Announcement `json:"body"`
+}
+
+// AdminApprovalRequest
+// swagger:response AdminApprovalRequest
+type swaggerResponseAdminApprovalRequest struct {
+	// in:body
+	Body api.AdminApprovalRequest `json:"body"`
+}
+
+// AdminApprovalRequestList
+// swagger:response AdminApprovalRequestList
+type swaggerResponseAdminApprovalRequestList struct {
+	// in:body
+	Body []api.AdminApprovalRequest `json:"body"`
+}
+
+// TermsDocument
+// swagger:response TermsDocument
+type swaggerResponseTermsDocument struct {
+	// in:body
+	Body api.TermsDocument `json:"body"`
+}
+
+// TermsDocumentList
+// swagger:response TermsDocumentList
+type swaggerResponseTermsDocumentList struct {
+	// in:body
+	Body []api.TermsDocument `json:"body"`
+}
+
+// TermsAcceptanceList
+// swagger:response TermsAcceptanceList
+type swaggerResponseTermsAcceptanceList struct {
+	// in:body
+	Body []api.TermsAcceptance `json:"body"`
+}
+
+// AbuseReport
+// swagger:response AbuseReport
+type swaggerResponseAbuseReport struct {
+	// in:body
+	Body api.AbuseReport `json:"body"`
+}
+
+// AbuseReportList
+// swagger:response AbuseReportList
+type swaggerResponseAbuseReportList struct {
+	// in:body
+	Body []api.AbuseReport `json:"body"`
+}
+
+// ResolutionTemplate
+// swagger:response ResolutionTemplate
+type swaggerResponseResolutionTemplate struct {
+	// in:body
+	Body api.ResolutionTemplate `json:"body"`
+}
+
+// ResolutionTemplateList
+// swagger:response ResolutionTemplateList
+type swaggerResponseResolutionTemplateList struct {
+	// in:body
+	Body []api.ResolutionTemplate `json:"body"`
+}
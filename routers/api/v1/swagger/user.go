@@ -49,9 +49,51 @@ type swaggerResponseUserSettings struct {
 	Body []api.UserSettings `json:"body"`
 }
 
+// OffboardUserResult
+// swagger:response OffboardUserResult
+type swaggerResponseOffboardUserResult struct {
+	// in:body
+	Body api.OffboardUserResult `json:"body"`
+}
+
 // BadgeList
 // swagger:response BadgeList
 type swaggerResponseBadgeList struct {
 	// in:body
 	Body []api.Badge `json:"body"`
 }
+
+// QuotaInfo
+// swagger:response QuotaInfo
+type swaggerResponseQuotaInfo struct {
+	// in:body
+	Body api.QuotaInfo `json:"body"`
+}
+
+// WebPushSettings
+// swagger:response WebPushSettings
+type swaggerResponseWebPushSettings struct {
+	// in:body
+	Body api.WebPushSettings `json:"body"`
+}
+
+// WebPushSubscription
+// swagger:response WebPushSubscription
+type swaggerResponseWebPushSubscription struct {
+	// in:body
+	Body api.WebPushSubscription `json:"body"`
+}
+
+// WebPushSubscriptionList
+// swagger:response WebPushSubscriptionList
+type swaggerResponseWebPushSubscriptionList struct {
+	// in:body
+	Body []api.WebPushSubscription `json:"body"`
+}
+
+// MatrixSettings
+// swagger:response MatrixSettings
+type swaggerResponseMatrixSettings struct {
+	// in:body
+	Body api.MatrixSettings `json:"body"`
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func announcementViewer(ctx *context.APIContext) system_model.FindActiveAnnouncementsOptions {
+	return system_model.FindActiveAnnouncementsOptions{UserID: ctx.Doer.ID, IsAdmin: ctx.Doer.IsAdmin}
+}
+
+// ListMyAnnouncements lists the announcements shown to the authenticated user
+func ListMyAnnouncements(ctx *context.APIContext) {
+	// swagger:operation GET /user/announcements user userListAnnouncements
+	// ---
+	// summary: List the instance announcements which are shown to the authenticated user and haven't been dismissed
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AnnouncementList"
+
+	announcements, err := system_model.FindActiveAnnouncements(ctx, announcementViewer(ctx))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiAnnouncements, err := convert.ToAnnouncementList(ctx, announcements)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAnnouncements)
+}
+
+// DismissAnnouncement hides an announcement for the authenticated user
+func DismissAnnouncement(ctx *context.APIContext) {
+	// swagger:operation POST /user/announcements/{id}/dismiss user userDismissAnnouncement
+	// ---
+	// summary: Hide an instance announcement for the authenticated user
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the announcement
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	a, err := system_model.GetActiveAnnouncementByID(ctx, ctx.PathParamInt64("id"), announcementViewer(ctx))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if err := system_model.DismissAnnouncement(ctx, a, ctx.Doer.ID); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
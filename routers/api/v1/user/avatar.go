@@ -5,11 +5,13 @@ package user
 
 import (
 	"encoding/base64"
+	"errors"
 	"net/http"
 
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -28,6 +30,8 @@ func UpdateAvatar(ctx *context.APIContext) {
 	// responses:
 	//   "204":
 	//     "$ref": "#/responses/empty"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	form := web.GetForm(ctx).(*api.UpdateUserAvatarOption)
 
 	content, err := base64.StdEncoding.DecodeString(form.Image)
@@ -38,6 +42,10 @@ func UpdateAvatar(ctx *context.APIContext) {
 
 	err = user_service.UploadAvatar(ctx, ctx.Doer, content)
 	if err != nil {
+		if errors.Is(err, malwarescan_service.ErrMalwareFound) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
 		ctx.APIErrorInternal(err)
 		return
 	}
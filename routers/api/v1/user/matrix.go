@@ -0,0 +1,91 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+)
+
+func toMatrixSettings(settings *activities_model.MatrixSettings) *api.MatrixSettings {
+	apiSettings := &api.MatrixSettings{
+		MatrixID: settings.MatrixID,
+		Events:   make([]string, len(settings.Events)),
+	}
+	for i, e := range settings.Events {
+		apiSettings.Events[i] = string(e)
+	}
+	return apiSettings
+}
+
+// GetMatrixSettings returns the Matrix notification settings of the authenticated user
+func GetMatrixSettings(ctx *context.APIContext) {
+	// swagger:operation GET /user/matrix user userGetMatrixSettings
+	// ---
+	// summary: Get the Matrix notification settings of the authenticated user
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MatrixSettings"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	settings, err := activities_model.GetMatrixSettings(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toMatrixSettings(settings))
+}
+
+// EditMatrixSettings sets the Matrix user ID of the authenticated user and the types of events it receives direct messages for
+func EditMatrixSettings(ctx *context.APIContext) {
+	// swagger:operation PUT /user/matrix user userEditMatrixSettings
+	// ---
+	// summary: Set the Matrix user ID of the authenticated user and the types of events it receives direct messages for
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditMatrixSettingsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MatrixSettings"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditMatrixSettingsOption)
+	events := make([]activities_model.MatrixEvent, len(form.Events))
+	for i, e := range form.Events {
+		events[i] = activities_model.MatrixEvent(e)
+	}
+	if err := activities_model.UpdateMatrixSettings(ctx, ctx.Doer.ID, form.MatrixID, events); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	settings, err := activities_model.GetMatrixSettings(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toMatrixSettings(settings))
+}
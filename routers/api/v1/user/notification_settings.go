@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"net/http"
+	"strconv"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetNotificationSettings returns the notification settings of the authenticated user
+func GetNotificationSettings(ctx *context.APIContext) {
+	// swagger:operation GET /user/notification_settings user userGetNotificationSettings
+	// ---
+	// summary: Get the notification settings of the authenticated user
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/NotificationSettings"
+
+	participatingOnly, err := repo_model.IsParticipatingOnly(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.NotificationSettings{ParticipatingOnly: participatingOnly})
+}
+
+// EditNotificationSettings edits the notification settings of the authenticated user
+func EditNotificationSettings(ctx *context.APIContext) {
+	// swagger:operation PATCH /user/notification_settings user userEditNotificationSettings
+	// ---
+	// summary: Edit the notification settings of the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditNotificationSettingsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/NotificationSettings"
+
+	form := web.GetForm(ctx).(*api.EditNotificationSettingsOption)
+	if form.ParticipatingOnly != nil {
+		if err := user_model.SetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyNotificationParticipatingOnly, strconv.FormatBool(*form.ParticipatingOnly)); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+	GetNotificationSettings(ctx)
+}
@@ -40,6 +40,44 @@ func CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, ctx.Doer.ID, 0)
 }
 
+// CreateRunnerJITToken creates a just-in-time token to register an ephemeral user runner
+func CreateRunnerJITToken(ctx *context.APIContext) {
+	// swagger:operation POST /user/actions/runners/jit-token user userCreateRunnerJITToken
+	// ---
+	// summary: Create a single-use registration token for an ephemeral user runner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRunnerJITTokenOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/RunnerJITToken"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateRunnerJITToken(ctx, ctx.Doer.ID, 0)
+}
+
+// ListRunnerPendingJobs lists the number of jobs waiting for user runners by their labels
+func ListRunnerPendingJobs(ctx *context.APIContext) {
+	// swagger:operation GET /user/actions/runners/pending-jobs user userListRunnerPendingJobs
+	// ---
+	// summary: List the number of jobs waiting for user runners by their labels
+	// produces:
+	// - application/json
+	// parameters:
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RunnerPendingJobsList"
+
+	shared.ListRunnerPendingJobs(ctx, ctx.Doer.ID, 0)
+}
+
 // ListRunners get user-level runners
 func ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /user/actions/runners user getUserRunners
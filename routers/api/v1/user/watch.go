@@ -11,6 +11,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
@@ -127,15 +128,13 @@ func IsWatching(ctx *context.APIContext) {
 	//   "404":
 	//     description: User is not watching this repo or repo do not exist
 
-	if repo_model.IsWatching(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID) {
-		ctx.JSON(http.StatusOK, api.WatchInfo{
-			Subscribed:    true,
-			Ignored:       false,
-			Reason:        nil,
-			CreatedAt:     ctx.Repo.Repository.CreatedUnix.AsTime(),
-			URL:           subscriptionURL(ctx.Repo.Repository),
-			RepositoryURL: ctx.Repo.Repository.APIURL(),
-		})
+	watch, err := repo_model.GetWatch(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if repo_model.IsWatchMode(watch.Mode) {
+		ctx.JSON(http.StatusOK, toWatchInfo(ctx.Repo.Repository, &watch))
 	} else {
 		ctx.APIErrorNotFound()
 	}
@@ -174,14 +173,70 @@ func Watch(ctx *context.APIContext) {
 		}
 		return
 	}
-	ctx.JSON(http.StatusOK, api.WatchInfo{
-		Subscribed:    true,
-		Ignored:       false,
-		Reason:        nil,
-		CreatedAt:     ctx.Repo.Repository.CreatedUnix.AsTime(),
-		URL:           subscriptionURL(ctx.Repo.Repository),
-		RepositoryURL: ctx.Repo.Repository.APIURL(),
-	})
+	watch, err := repo_model.GetWatch(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toWatchInfo(ctx.Repo.Repository, &watch))
+}
+
+// EditWatch changes the types of events the authenticated user is notified of as a watcher of the repo specified in ctx
+func EditWatch(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/subscription repository userCurrentEditSubscription
+	// ---
+	// summary: Change the types of events the current user is notified of as a watcher of a repo
+	// description: The repo is watched if the user doesn't watch it yet.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditWatchOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WatchInfo"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	form := web.GetForm(ctx).(*api.EditWatchOption)
+	events := make(map[repo_model.WatchEvent]bool, 4)
+	for event, enabled := range map[repo_model.WatchEvent]*bool{
+		repo_model.WatchEventIssues:       form.Issues,
+		repo_model.WatchEventPullRequests: form.PullRequests,
+		repo_model.WatchEventReleases:     form.Releases,
+		repo_model.WatchEventCIFailures:   form.CIFailures,
+	} {
+		if enabled != nil {
+			events[event] = *enabled
+		}
+	}
+
+	watch, err := repo_model.SetWatchEvents(ctx, ctx.Doer, ctx.Repo.Repository, events)
+	if err != nil {
+		if errors.Is(err, user_model.ErrBlockedUser) {
+			ctx.APIError(http.StatusForbidden, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, toWatchInfo(ctx.Repo.Repository, watch))
 }
 
 // Unwatch the repo specified in ctx, as the authenticated user
@@ -214,6 +269,23 @@ func Unwatch(ctx *context.APIContext) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func toWatchInfo(repo *repo_model.Repository, watch *repo_model.Watch) *api.WatchInfo {
+	return &api.WatchInfo{
+		Subscribed:    true,
+		Ignored:       false,
+		Reason:        nil,
+		CreatedAt:     repo.CreatedUnix.AsTime(),
+		URL:           subscriptionURL(repo),
+		RepositoryURL: repo.APIURL(),
+		Events: api.WatchEvents{
+			Issues:       watch.Issues,
+			PullRequests: watch.PullRequests,
+			Releases:     watch.Releases,
+			CIFailures:   watch.CIFailures,
+		},
+	}
+}
+
 // subscriptionURL returns the URL of the subscription API endpoint of a repo
 func subscriptionURL(repo *repo_model.Repository) string {
 	return repo.APIURL() + "/subscription"
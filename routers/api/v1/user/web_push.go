@@ -0,0 +1,198 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/modules/webpush"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func toWebPushSettings(events []activities_model.WebPushEvent) *api.WebPushSettings {
+	settings := &api.WebPushSettings{
+		VAPIDPublicKey: setting.WebPush.VAPIDPublicKey,
+		Events:         make([]string, len(events)),
+	}
+	for i, e := range events {
+		settings.Events[i] = string(e)
+	}
+	return settings
+}
+
+// GetWebPushSettings returns the web push settings of the authenticated user
+func GetWebPushSettings(ctx *context.APIContext) {
+	// swagger:operation GET /user/web_push user userGetWebPushSettings
+	// ---
+	// summary: Get the web push settings of the authenticated user
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WebPushSettings"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	events, err := activities_model.GetWebPushEvents(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toWebPushSettings(events))
+}
+
+// EditWebPushSettings sets the types of events the authenticated user receives web push notifications for
+func EditWebPushSettings(ctx *context.APIContext) {
+	// swagger:operation PUT /user/web_push user userEditWebPushSettings
+	// ---
+	// summary: Set the types of events the authenticated user receives web push notifications for
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditWebPushSettingsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WebPushSettings"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditWebPushSettingsOption)
+	events := make([]activities_model.WebPushEvent, len(form.Events))
+	for i, e := range form.Events {
+		events[i] = activities_model.WebPushEvent(e)
+	}
+	if err := activities_model.SetWebPushEvents(ctx, ctx.Doer.ID, events); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	events, err := activities_model.GetWebPushEvents(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toWebPushSettings(events))
+}
+
+// ListWebPushSubscriptions lists the browsers the authenticated user subscribed to web push notifications
+func ListWebPushSubscriptions(ctx *context.APIContext) {
+	// swagger:operation GET /user/web_push/subscriptions user userListWebPushSubscriptions
+	// ---
+	// summary: List the browsers the authenticated user subscribed to web push notifications
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WebPushSubscriptionList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	subs, err := activities_model.GetWebPushSubscriptions(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiSubs := make([]*api.WebPushSubscription, len(subs))
+	for i, sub := range subs {
+		apiSubs[i] = convert.ToWebPushSubscription(sub)
+	}
+	ctx.JSON(http.StatusOK, apiSubs)
+}
+
+// CreateWebPushSubscription subscribes a browser of the authenticated user to web push notifications
+func CreateWebPushSubscription(ctx *context.APIContext) {
+	// swagger:operation POST /user/web_push/subscriptions user userCreateWebPushSubscription
+	// ---
+	// summary: Subscribe a browser of the authenticated user to web push notifications
+	// description: Subscribing the same endpoint again renews its keys.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateWebPushSubscriptionOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/WebPushSubscription"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateWebPushSubscriptionOption)
+	if err := (&webpush.Subscription{Endpoint: form.Endpoint, P256dh: form.Keys.P256dh, Auth: form.Keys.Auth}).Validate(); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	sub := &activities_model.WebPushSubscription{
+		UserID:    ctx.Doer.ID,
+		Endpoint:  form.Endpoint,
+		P256dh:    form.Keys.P256dh,
+		Auth:      form.Keys.Auth,
+		UserAgent: ctx.Req.UserAgent(),
+	}
+	if err := activities_model.SaveWebPushSubscription(ctx, sub); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToWebPushSubscription(sub))
+}
+
+// DeleteWebPushSubscription unsubscribes a browser of the authenticated user from web push notifications
+func DeleteWebPushSubscription(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/web_push/subscriptions/{id} user userDeleteWebPushSubscription
+	// ---
+	// summary: Unsubscribe a browser of the authenticated user from web push notifications
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the subscription to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	sub, err := activities_model.GetWebPushSubscriptionByID(ctx, ctx.Doer.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError(err)
+		return
+	}
+	if err := activities_model.DeleteWebPushSubscription(ctx, sub.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
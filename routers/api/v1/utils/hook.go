@@ -83,6 +83,22 @@ func checkCreateHookOption(ctx *context.APIContext, form *api.CreateHookOption)
 		ctx.APIError(http.StatusUnprocessableEntity, "Invalid hook type: "+form.Type)
 		return false
 	}
+	if webhook_service.IsBrokerHookType(form.Type) {
+		// message broker webhooks always publish the JSON payload
+		if _, ok := form.Config["url"]; !ok {
+			ctx.APIError(http.StatusUnprocessableEntity, "Missing config option: url")
+			return false
+		}
+		if !webhook_service.IsValidBrokerURL(form.Type, form.Config["url"]) {
+			ctx.APIError(http.StatusUnprocessableEntity, "Invalid url, it must be like "+form.Type+"://host:port with comma separated servers")
+			return false
+		}
+		return true
+	}
+	if form.Type == webhook_module.MATRIX {
+		// the url of a Matrix webhook is built from its homeserver and room, checked by setMatrixHookConfig
+		return true
+	}
 	for _, name := range []string{"url", "content_type"} {
 		if _, ok := form.Config[name]; !ok {
 			ctx.APIError(http.StatusUnprocessableEntity, "Missing config option: "+name)
@@ -223,6 +239,9 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 			ChooseEvents: true,
 			HookEvents:   updateHookEvents(form.Events),
 			BranchFilter: form.BranchFilter,
+			PathFilter:   form.PathFilter,
+			LabelFilter:  form.LabelFilter,
+			ActorFilter:  form.ActorFilter,
 		},
 		IsActive: form.Active,
 		Type:     form.Type,
@@ -257,6 +276,24 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		}
 		w.Meta = string(meta)
 	}
+	if w.Type == webhook_module.CUSTOM {
+		if !setCustomHookConfig(ctx, w, &webhook_service.CustomMeta{}, form.Config) {
+			return nil, false
+		}
+	}
+	if webhook_service.IsBrokerHookType(w.Type) {
+		w.ContentType = webhook.ContentTypeJSON
+		if !setBrokerHookConfig(ctx, w, form.Config) {
+			return nil, false
+		}
+	}
+	if w.Type == webhook_module.MATRIX {
+		w.ContentType = webhook.ContentTypeJSON
+		w.HTTPMethod = http.MethodPut
+		if !setMatrixHookConfig(ctx, w, form.Config) {
+			return nil, false
+		}
+	}
 
 	if err := w.UpdateEvent(); err != nil {
 		ctx.APIErrorInternal(err)
@@ -268,6 +305,127 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 	return w, true
 }
 
+// setCustomHookConfig applies the template options of the config to a custom webhook. Writes to `ctx` if the config is invalid
+func setCustomHookConfig(ctx *context.APIContext, w *webhook.Webhook, meta *webhook_service.CustomMeta, config map[string]string) bool {
+	if method, ok := config["http_method"]; ok {
+		method = strings.ToUpper(method)
+		if method != http.MethodPost && method != http.MethodPut && method != http.MethodPatch {
+			ctx.APIError(http.StatusUnprocessableEntity, "Invalid http_method, it must be POST, PUT or PATCH")
+			return false
+		}
+		w.HTTPMethod = method
+	}
+	if v, ok := config["body_content_type"]; ok {
+		meta.ContentType = strings.TrimSpace(v)
+	}
+	if v, ok := config["headers"]; ok {
+		meta.Headers = v
+	}
+	if v, ok := config["body_template"]; ok {
+		meta.BodyTemplate = v
+	}
+	if err := meta.Validate(); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, "Invalid templates: "+err.Error())
+		return false
+	}
+
+	data, err := json.Marshal(meta)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.Meta = string(data)
+	return true
+}
+
+// setBrokerHookConfig applies the destination and delivery options of the config to a kafka or nats webhook.
+// Writes to `ctx` if the config is invalid
+func setBrokerHookConfig(ctx *context.APIContext, w *webhook.Webhook, config map[string]string) bool {
+	var meta interface{ Validate() error }
+	tls, _ := strconv.ParseBool(config["tls"])
+	switch w.Type {
+	case webhook_module.KAFKA:
+		m := &webhook_service.KafkaMeta{
+			PartitionKey: webhook_service.BrokerPartitionKeyRepository,
+			RequiredAcks: webhook_service.KafkaRequiredAcksAll,
+		}
+		if w.Meta != "" {
+			m = webhook_service.GetKafkaHook(w)
+		}
+		m.Topic = util.IfZero(strings.TrimSpace(config["topic"]), m.Topic)
+		m.PartitionKey = util.IfZero(config["partition_key"], m.PartitionKey)
+		m.RequiredAcks = util.IfZero(config["required_acks"], m.RequiredAcks)
+		if _, ok := config["tls"]; ok {
+			m.TLS = tls
+		}
+		meta = m
+	case webhook_module.NATS:
+		m := &webhook_service.NatsMeta{
+			PartitionKey: webhook_service.BrokerPartitionKeyNone,
+		}
+		if w.Meta != "" {
+			m = webhook_service.GetNatsHook(w)
+		}
+		m.Subject = util.IfZero(strings.TrimSpace(config["subject"]), m.Subject)
+		m.PartitionKey = util.IfZero(config["partition_key"], m.PartitionKey)
+		if v, ok := config["jetstream"]; ok {
+			m.JetStream, _ = strconv.ParseBool(v)
+		}
+		if _, ok := config["tls"]; ok {
+			m.TLS = tls
+		}
+		meta = m
+	}
+	if err := meta.Validate(); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, "Invalid config: "+err.Error())
+		return false
+	}
+
+	data, err := json.Marshal(meta)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.Meta = string(data)
+	return true
+}
+
+// setMatrixHookConfig applies the homeserver, room, message type and access token of the config to a Matrix webhook.
+// Writes to `ctx` if the config is invalid
+func setMatrixHookConfig(ctx *context.APIContext, w *webhook.Webhook, config map[string]string) bool {
+	m := &webhook_service.MatrixMeta{MessageType: 1}
+	if w.Meta != "" {
+		m = webhook_service.GetMatrixHook(w)
+	}
+	m.HomeserverURL = util.IfZero(strings.TrimSuffix(strings.TrimSpace(config["homeserver_url"]), "/"), m.HomeserverURL)
+	m.Room = util.IfZero(strings.TrimSpace(config["room_id"]), m.Room)
+	if v, ok := config["message_type"]; ok {
+		if m.MessageType, ok = webhook_service.ParseMatrixMessageType(v); !ok {
+			ctx.APIError(http.StatusUnprocessableEntity, `Invalid message_type, it must be "m.notice" or "m.text"`)
+			return false
+		}
+	}
+	if err := m.Validate(); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, "Invalid config: "+err.Error())
+		return false
+	}
+	if token := strings.TrimSpace(config["access_token"]); token != "" {
+		if err := w.SetHeaderAuthorization("Bearer " + token); err != nil {
+			ctx.APIErrorInternal(err)
+			return false
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.URL = webhook_service.MatrixRoomMessageURL(m.HomeserverURL, m.Room)
+	w.Meta = string(data)
+	return true
+}
+
 // EditSystemHook edit system webhook `w` according to `form`. Writes to `ctx` accordingly
 func EditSystemHook(ctx *context.APIContext, form *api.EditHookOption, hookID int64) {
 	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, hookID)
@@ -338,13 +496,18 @@ func EditRepoHook(ctx *context.APIContext, form *api.EditHookOption, hookID int6
 func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webhook) bool {
 	if form.Config != nil {
 		if url, ok := form.Config["url"]; ok {
-			if !validation.IsValidURL(url) {
+			if webhook_service.IsBrokerHookType(w.Type) {
+				if !webhook_service.IsValidBrokerURL(w.Type, url) {
+					ctx.APIError(http.StatusUnprocessableEntity, "Invalid url")
+					return false
+				}
+			} else if !validation.IsValidURL(url) {
 				ctx.APIError(http.StatusUnprocessableEntity, "Invalid url")
 				return false
 			}
 			w.URL = url
 		}
-		if ct, ok := form.Config["content_type"]; ok {
+		if ct, ok := form.Config["content_type"]; ok && !webhook_service.IsBrokerHookType(w.Type) {
 			if !webhook.IsValidHookContentType(ct) {
 				ctx.APIError(http.StatusUnprocessableEntity, "Invalid content type")
 				return false
@@ -367,6 +530,18 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 				w.Meta = string(meta)
 			}
 		}
+
+		if w.Type == webhook_module.CUSTOM {
+			if !setCustomHookConfig(ctx, w, webhook_service.GetCustomHook(w), form.Config) {
+				return false
+			}
+		}
+
+		if webhook_service.IsBrokerHookType(w.Type) {
+			if !setBrokerHookConfig(ctx, w, form.Config) {
+				return false
+			}
+		}
 	}
 
 	// Update events
@@ -375,11 +550,21 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.SendEverything = false
 	w.ChooseEvents = true
 	w.BranchFilter = form.BranchFilter
+	w.PathFilter = form.PathFilter
+	w.LabelFilter = form.LabelFilter
+	w.ActorFilter = form.ActorFilter
 
-	err := w.SetHeaderAuthorization(form.AuthorizationHeader)
-	if err != nil {
-		ctx.APIErrorInternal(err)
-		return false
+	// the access token of a Matrix webhook is kept unless replaced
+	if w.Type != webhook_module.MATRIX || form.AuthorizationHeader != "" {
+		if err := w.SetHeaderAuthorization(form.AuthorizationHeader); err != nil {
+			ctx.APIErrorInternal(err)
+			return false
+		}
+	}
+	if w.Type == webhook_module.MATRIX && form.Config != nil {
+		if !setMatrixHookConfig(ctx, w, form.Config) {
+			return false
+		}
 	}
 
 	if err := w.UpdateEvent(); err != nil {
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/services/context"
+)
+
+// defaultInsightsPeriod is the period of the insights if the "since" parameter isn't given
+const defaultInsightsPeriod = 90 * 24 * time.Hour
+
+// GetInsightsPeriod returns the period of the insights from the "since" and "before" parameters.
+// By default, the period ends at the next full hour so the insights can be cached, and starts 90 days before.
+func GetInsightsPeriod(ctx *context.APIContext) (since, before time.Time, ok bool) {
+	beforeUnix, sinceUnix, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return since, before, false
+	}
+	before = time.Now().Truncate(time.Hour).Add(time.Hour)
+	if beforeUnix != 0 {
+		before = time.Unix(beforeUnix, 0)
+	}
+	since = before.Add(-defaultInsightsPeriod)
+	if sinceUnix != 0 {
+		since = time.Unix(sinceUnix, 0)
+	}
+	if !since.Before(before) {
+		ctx.APIError(http.StatusUnprocessableEntity, errors.New("'since' must be earlier than 'before'"))
+		return since, before, false
+	}
+	return since.UTC(), before.UTC(), true
+}
@@ -42,7 +42,14 @@ func InitDBEngine(ctx context.Context) (err error) {
 	return nil
 }
 
-func migrateWithSetting(ctx context.Context, x *xorm.Engine) error {
+func migrateWithSetting(ctx context.Context, x *xorm.Engine) (err error) {
+	defer func() {
+		if err == nil {
+			// the existing rows are filled in the background, the server doesn't wait for them
+			versioned_migration.StartBackfills(x)
+		}
+	}()
+
 	if setting.Database.AutoMigration {
 		return versioned_migration.Migrate(ctx, x)
 	}
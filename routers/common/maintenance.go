@@ -0,0 +1,121 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	goctx "context"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation"
+	"code.gitea.io/gitea/services/context"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MaintenanceMessage returns the message shown to the users when the instance is in the read-only maintenance mode,
+// it returns an empty string if the instance isn't in the maintenance mode
+func MaintenanceMessage(ctx goctx.Context, locale translation.Locale) string {
+	maintenance := setting.Config().Maintenance
+	if !maintenance.IsReadOnly(ctx) {
+		return ""
+	}
+	if msg := maintenance.Message.Value(ctx); msg != "" {
+		return msg
+	}
+	return locale.TrString("maintenance_read_only")
+}
+
+// isSafeMethod returns true for the requests which don't change anything
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isRoutePathAllowedInMaintenance returns true for the web routes which don't change anything but use the POST method,
+// the sign-in routes and the admin config route (to end the maintenance mode) are also allowed.
+// The pushes are rejected by the pre-receive hook, which also covers the SSH pushes.
+func isRoutePathAllowedInMaintenance(routePattern string) bool {
+	allowedPrefixes := []string{
+		"/user/login",
+		"/user/logout",
+		"/user/two_factor",
+		"/user/webauthn",
+		"/-/admin/config",
+		"/-/markup",
+	}
+	for _, prefix := range allowedPrefixes {
+		if strings.HasPrefix(routePattern, prefix) {
+			return true
+		}
+	}
+
+	allowedSuffixes := []string{
+		"/git-upload-pack",
+		"/git-receive-pack",
+		"/info/lfs/objects/batch",
+		"/info/lfs/verify",
+		"/info/lfs/locks/verify",
+	}
+	for _, suffix := range allowedSuffixes {
+		if strings.HasSuffix(routePattern, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceMode shows the maintenance banner and rejects the web requests which change anything
+// while the instance is in the read-only maintenance mode
+func MaintenanceMode() func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		msg := MaintenanceMessage(ctx, ctx.Locale)
+		if msg == "" {
+			return
+		}
+		ctx.Data["MaintenanceMessage"] = msg
+		if isSafeMethod(ctx.Req.Method) || isRoutePathAllowedInMaintenance(chi.RouteContext(ctx).RoutePattern()) {
+			return
+		}
+
+		for _, part := range ctx.Req.Header["Accept"] {
+			if strings.Contains(part, "text/html") {
+				ctx.Flash.Error(msg)
+				ctx.RedirectToCurrentSite(ctx.Req.Referer())
+				return
+			}
+		}
+		ctx.JSONError(msg)
+	}
+}
+
+// APIMaintenanceMode rejects the API requests which change anything while the instance is in the read-only maintenance mode,
+// the admin maintenance route is allowed to end the maintenance mode
+func APIMaintenanceMode() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if isSafeMethod(ctx.Req.Method) {
+			return
+		}
+		path := strings.TrimPrefix(ctx.Req.URL.Path, "/api/v1")
+		if path == "/admin/maintenance" || path == "/markdown" || path == "/markdown/raw" || path == "/markup" {
+			return
+		}
+		if msg := MaintenanceMessage(ctx, ctx.Locale); msg != "" {
+			ctx.APIError(http.StatusServiceUnavailable, msg)
+		}
+	}
+}
+
+// PackagesMaintenanceMode rejects the package registry requests which change anything, like uploads and deletions,
+// while the instance is in the read-only maintenance mode
+func PackagesMaintenanceMode() func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		if isSafeMethod(ctx.Req.Method) {
+			return
+		}
+		if msg := MaintenanceMessage(ctx, ctx.Locale); msg != "" {
+			ctx.HTTPError(http.StatusServiceUnavailable, msg)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
+	system_model "code.gitea.io/gitea/models/system"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/services/context"
 )
@@ -65,12 +66,28 @@ func notificationUnreadCount(ctx *context.Context) int64 {
 	return count
 }
 
+func activeAnnouncements(ctx *context.Context) []*system_model.Announcement {
+	opts := system_model.FindActiveAnnouncementsOptions{}
+	if ctx.Doer != nil {
+		opts.UserID, opts.IsAdmin = ctx.Doer.ID, ctx.Doer.IsAdmin
+	}
+	announcements, err := system_model.FindActiveAnnouncements(ctx, opts)
+	if err != nil {
+		if !errors.Is(err, goctx.Canceled) {
+			log.Error("Unable to find active announcements: %v", err)
+		}
+		return nil
+	}
+	return announcements
+}
+
 type pageGlobalDataType struct {
 	IsSigned    bool
 	IsSiteAdmin bool
 
 	GetNotificationUnreadCount func() int64
 	GetActiveStopwatch         func() *StopwatchTmplInfo
+	GetAnnouncements           func() []*system_model.Announcement
 }
 
 func PageGlobalData(ctx *context.Context) {
@@ -79,5 +96,6 @@ func PageGlobalData(ctx *context.Context) {
 	data.IsSiteAdmin = ctx.Doer != nil && ctx.Doer.IsAdmin
 	data.GetNotificationUnreadCount = sync.OnceValue(func() int64 { return notificationUnreadCount(ctx) })
 	data.GetActiveStopwatch = sync.OnceValue(func() *StopwatchTmplInfo { return getActiveStopwatch(ctx) })
+	data.GetAnnouncements = sync.OnceValue(func() []*system_model.Announcement { return activeAnnouncements(ctx) })
 	ctx.Data["PageGlobalData"] = data
 }
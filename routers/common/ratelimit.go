@@ -0,0 +1,106 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/ratelimit"
+	"code.gitea.io/gitea/modules/setting"
+	giteacontext "code.gitea.io/gitea/services/context"
+)
+
+// APIRateLimitRuleFunc returns the rule limiting the request
+type APIRateLimitRuleFunc func(req *http.Request) *setting.APIRateLimitRule
+
+// RESTAPIRateLimitRule returns the rule limiting the request to the REST API
+func RESTAPIRateLimitRule(req *http.Request) *setting.APIRateLimitRule {
+	return setting.GetAPIRateLimitRule(req.Method, strings.TrimPrefix(req.URL.Path, "/api/v1"))
+}
+
+// GraphQLRateLimitRule returns the rule limiting the request to the GraphQL API
+func GraphQLRateLimitRule(*http.Request) *setting.APIRateLimitRule {
+	return setting.GetGraphQLRateLimitRule()
+}
+
+// APIRateLimit limits the rate of the API requests of every token, signed-in user and anonymous IP address.
+// The state of the bucket is returned in the RateLimit-* headers of the IETF draft "RateLimit header fields for HTTP".
+func APIRateLimit(getRule APIRateLimitRuleFunc) func(ctx *giteacontext.APIContext) {
+	if !setting.APIRateLimit.Enabled {
+		return nil
+	}
+	return func(ctx *giteacontext.APIContext) {
+		if setting.GetAPIRateLimitRules().ExemptAdmins && ctx.IsSigned && ctx.Doer.IsAdmin {
+			return
+		}
+		client, signed := apiRateLimitClient(ctx)
+		takeAPIRateLimit(ctx, getRule(ctx.Req), client, signed)
+	}
+}
+
+// APIRateLimitFailedAuth charges the request whose authentication has failed to the bucket of its IP address, so the
+// credentials can't be guessed without limit. It responds with 429 if the bucket is exhausted.
+func APIRateLimitFailedAuth(ctx *giteacontext.APIContext, getRule APIRateLimitRuleFunc) {
+	if !setting.APIRateLimit.Enabled {
+		return
+	}
+	takeAPIRateLimit(ctx, getRule(ctx.Req), apiRateLimitIP(ctx), false)
+}
+
+func takeAPIRateLimit(ctx *giteacontext.APIContext, rule *setting.APIRateLimitRule, client string, signed bool) {
+	rate := ratelimit.Rate{Limit: rule.AnonymousLimit, Period: rule.Period}
+	if signed {
+		rate.Limit = rule.AuthenticatedLimit
+	}
+
+	res, err := ratelimit.Take(ctx, rule.Name+":"+client, rate)
+	if err != nil {
+		// don't block the API if the rate limit service is unavailable
+		log.Error("Unable to take from the API rate limit bucket of %s: %v", client, err)
+		return
+	}
+
+	header := ctx.Resp.Header()
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rate.Limit, int64(rate.Period.Seconds())))
+	header.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	header.Set("RateLimit-Reset", strconv.FormatInt(ceilSeconds(res.ResetAfter), 10))
+	if !res.Allowed {
+		header.Set("Retry-After", strconv.FormatInt(ceilSeconds(res.RetryAfter), 10))
+		ctx.APIError(http.StatusTooManyRequests, "API rate limit exceeded")
+	}
+}
+
+// apiRateLimitClient returns the key of the client of the request and whether it is authenticated
+func apiRateLimitClient(ctx *giteacontext.APIContext) (string, bool) {
+	if taskID, ok := ctx.Data["ActionsTaskID"].(int64); ok && ctx.Data["IsActionsToken"] == true {
+		return "task:" + strconv.FormatInt(taskID, 10), true
+	}
+	if tokenID, ok := ctx.Data["ApiTokenID"].(int64); ok {
+		return "token:" + strconv.FormatInt(tokenID, 10), true
+	}
+	if ctx.IsSigned {
+		return "user:" + strconv.FormatInt(ctx.Doer.ID, 10), true
+	}
+	return apiRateLimitIP(ctx), false
+}
+
+func apiRateLimitIP(ctx *giteacontext.APIContext) string {
+	ip, _, err := net.SplitHostPort(ctx.RemoteAddr())
+	if err != nil {
+		ip = ctx.RemoteAddr()
+	}
+	return "ip:" + ip
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
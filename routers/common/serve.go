@@ -13,8 +13,10 @@ import (
 	"code.gitea.io/gitea/modules/httpcache"
 	"code.gitea.io/gitea/modules/httplib"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
 )
 
@@ -43,6 +45,48 @@ func ServeBlob(ctx *context.Base, repo *repo_model.Repository, filePath string,
 	return nil
 }
 
+// ServeBlobAsSVG renders a git.Blob which can be embedded as an image, e.g. a diagram, and serves the SVG image
+func ServeBlobAsSVG(ctx *context.Base, repo *repo_model.Repository, filePath string, blob *git.Blob, lastModified *time.Time) error {
+	renderer := markup.GetSVGRendererByFileName(filePath)
+	if renderer == nil {
+		return util.NewInvalidArgumentErrorf("file %q can't be rendered as an image", path.Base(filePath))
+	}
+	if blob.Size() > setting.UI.MaxDisplayFileSize {
+		return util.NewInvalidArgumentErrorf("file %q is too large to be rendered", path.Base(filePath))
+	}
+	if httpcache.HandleGenericETagTimeCache(ctx.Req, ctx.Resp, `"`+blob.ID.String()+`-svg"`, lastModified) {
+		return nil
+	}
+
+	dataRc, err := blob.DataAsync()
+	if err != nil {
+		return err
+	}
+	defer dataRc.Close()
+
+	svg, err := renderer.RenderSVG(ctx, dataRc)
+	if err != nil {
+		return err
+	}
+	_ = repo.LoadOwner(ctx)
+	ServeSVGImage(ctx, svg, &httplib.ServeHeaderOptions{
+		Filename:      path.Base(filePath) + ".svg",
+		CacheIsPublic: !repo.IsPrivate && repo.Owner != nil && repo.Owner.Visibility == structs.VisibleTypePublic,
+		CacheDuration: setting.StaticCacheTime,
+	})
+	return nil
+}
+
+// ServeSVGImage serves a SVG image rendered by Gitea, the image is sandboxed like the SVG files of the repositories
+func ServeSVGImage(ctx *context.Base, svg []byte, opts *httplib.ServeHeaderOptions) {
+	opts.ContentType = "image/svg+xml"
+	opts.Disposition = "inline"
+	opts.ContentLength = util.ToPointer(int64(len(svg)))
+	ctx.Resp.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	httplib.ServeSetHeaders(ctx.Resp, opts)
+	_, _ = ctx.Resp.Write(svg)
+}
+
 func ServeContentByReader(ctx *context.Base, filePath string, size int64, reader io.Reader) {
 	httplib.ServeContentByReader(ctx.Req, ctx.Resp, size, reader, &httplib.ServeHeaderOptions{Filename: path.Base(filePath)})
 }
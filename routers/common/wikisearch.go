@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"code.gitea.io/gitea/modules/indexer"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/services/context"
+	wiki_service "code.gitea.io/gitea/services/wiki"
+)
+
+// WikiSearchResult is a wiki search result with the title and the link of its page
+type WikiSearchResult struct {
+	*wiki_indexer.Result
+	Title string
+	// SubURL is the path of the page relative to the wiki of its repository
+	SubURL string
+}
+
+func PrepareWikiSearch(ctx *context.Context) (ret struct {
+	Keyword    string
+	SearchMode indexer.SearchModeType
+},
+) {
+	ret.Keyword = ctx.FormTrim("q")
+	ret.SearchMode = indexer.SearchModeType(ctx.FormTrim("search_mode"))
+
+	ctx.Data["Keyword"] = ret.Keyword
+	ctx.Data["SelectedSearchMode"] = string(ret.SearchMode)
+	ctx.Data["SearchModes"] = wiki_indexer.SupportedSearchModes()
+	return ret
+}
+
+// ToWikiSearchResults converts the file names of the wiki pages found by the indexer to their titles and links
+func ToWikiSearchResults(results []*wiki_indexer.Result) []*WikiSearchResult {
+	ret := make([]*WikiSearchResult, 0, len(results))
+	for _, result := range results {
+		webPath, err := wiki_service.GitPathToWebPath(result.Filename)
+		if err != nil {
+			continue
+		}
+		_, title := wiki_service.WebPathToUserTitle(webPath)
+		ret = append(ret, &WikiSearchResult{
+			Result: result,
+			Title:  title,
+			SubURL: wiki_service.WebPathToURLPath(webPath),
+		})
+	}
+	return ret
+}
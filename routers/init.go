@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/eventsource"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/highlight"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup"
@@ -40,22 +41,30 @@ import (
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
 	"code.gitea.io/gitea/services/automerge"
+	"code.gitea.io/gitea/services/cluster"
 	"code.gitea.io/gitea/services/cron"
+	dco_service "code.gitea.io/gitea/services/dco"
+	federation_service "code.gitea.io/gitea/services/federation"
 	feed_service "code.gitea.io/gitea/services/feed"
 	indexer_service "code.gitea.io/gitea/services/indexer"
+	licensecheck_service "code.gitea.io/gitea/services/licensecheck"
 	"code.gitea.io/gitea/services/mailer"
 	mailer_incoming "code.gitea.io/gitea/services/mailer/incoming"
 	markup_service "code.gitea.io/gitea/services/markup"
+	matrix_service "code.gitea.io/gitea/services/matrix"
 	repo_migrations "code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	"code.gitea.io/gitea/services/oauth2_provider"
+	packages_scan_service "code.gitea.io/gitea/services/packages/scan"
 	pull_service "code.gitea.io/gitea/services/pull"
 	release_service "code.gitea.io/gitea/services/release"
+	"code.gitea.io/gitea/services/reload"
 	repo_service "code.gitea.io/gitea/services/repository"
 	"code.gitea.io/gitea/services/repository/archiver"
 	"code.gitea.io/gitea/services/task"
 	"code.gitea.io/gitea/services/uinotification"
 	"code.gitea.io/gitea/services/webhook"
+	webpush_service "code.gitea.io/gitea/services/webpush"
 )
 
 func mustInit(fn func() error) {
@@ -130,6 +139,9 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(cache.Init)
 	mustInit(feed_service.Init)
 	mustInit(uinotification.Init)
+	mustInit(webpush_service.Init)
+	mustInit(matrix_service.Init)
+	mustInit(federation_service.Init)
 	mustInitCtx(ctx, archiver.Init)
 
 	highlight.NewContext()
@@ -162,6 +174,7 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(automerge.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
+	mustInit(packages_scan_service.Init)
 	eventsource.GetManager().Init()
 	mustInitCtx(ctx, mailer_incoming.Init)
 
@@ -175,8 +188,15 @@ func InitWebInstalled(ctx context.Context) {
 	mustInitCtx(ctx, actions_service.Init)
 
 	mustInit(repo_service.InitLicenseClassifier)
+	mustInit(licensecheck_service.Init)
+	mustInit(dco_service.Init)
 
-	// Finally start up the cron
+	graceful.GetManager().SetConfigReloader(func(ctx context.Context) {
+		_ = reload.Settings(ctx) // the error has been logged
+	})
+
+	// Finally start up the cron, the scheduled tasks are only run by the leader
+	mustInitCtx(ctx, cluster.Init)
 	cron.Init(ctx)
 }
 
@@ -188,6 +208,7 @@ func NormalRoutes() *web.Router {
 
 	r.Mount("/", web_routers.Routes())
 	r.Mount("/api/v1", apiv1.Routes())
+	r.Mount("/api/graphql", apiv1.GraphQLRoutes())
 	r.Mount("/api/internal", private.Routes())
 
 	r.Post("/-/fetch-redirect", common.FetchRedirectDelegate)
@@ -211,6 +232,10 @@ func NormalRoutes() *web.Router {
 		r.Mount(prefix, actions_router.ArtifactsRoutes(prefix))
 		prefix = actions_router.ArtifactV4RouteBase
 		r.Mount(prefix, actions_router.ArtifactsV4Routes(prefix))
+		if setting.Actions.CacheEnabled {
+			prefix = "/api/actions_cache"
+			r.Mount(prefix, actions_router.CacheRoutes(prefix))
+		}
 	}
 
 	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
@@ -124,12 +124,12 @@ func Install(ctx *context.Context) {
 	form.LogRootPath = setting.Log.RootPath
 
 	// E-mail service settings
-	if setting.MailService != nil {
-		form.SMTPAddr = setting.MailService.SMTPAddr
-		form.SMTPPort = setting.MailService.SMTPPort
-		form.SMTPFrom = setting.MailService.From
-		form.SMTPUser = setting.MailService.User
-		form.SMTPPasswd = setting.MailService.Passwd
+	if setting.GetMailService() != nil {
+		form.SMTPAddr = setting.GetMailService().SMTPAddr
+		form.SMTPPort = setting.GetMailService().SMTPPort
+		form.SMTPFrom = setting.GetMailService().From
+		form.SMTPUser = setting.GetMailService().User
+		form.SMTPPasswd = setting.GetMailService().Passwd
 	}
 	form.RegisterConfirm = setting.Service.RegisterEmailConfirm
 	form.MailNotify = setting.Service.EnableNotifyMail
@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	perm_model "code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	quota_model "code.gitea.io/gitea/models/quota"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -21,7 +25,9 @@ import (
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/translation"
 	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/common"
 	gitea_context "code.gitea.io/gitea/services/context"
 	pull_service "code.gitea.io/gitea/services/pull"
 )
@@ -113,6 +119,17 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		opts:           opts,
 	}
 
+	if msg := common.MaintenanceMessage(ctx, translation.NewLocale("en-US")); msg != "" {
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: "push rejected, " + msg,
+		})
+		return
+	}
+
+	if !preReceiveQuota(ourCtx) {
+		return
+	}
+
 	// Iterate across the provided old commit IDs
 	for i := range opts.OldCommitIDs {
 		oldCommitID := opts.OldCommitIDs[i]
@@ -120,10 +137,13 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		refFullName := opts.RefFullNames[i]
 
 		switch {
+		case opts.IsWiki:
+			// the permission to write the wiki has been checked before, the rules of the code don't apply to the wiki
+			preReceiveWiki(ourCtx, oldCommitID, newCommitID, refFullName)
 		case refFullName.IsBranch():
 			preReceiveBranch(ourCtx, oldCommitID, newCommitID, refFullName)
 		case refFullName.IsTag():
-			preReceiveTag(ourCtx, refFullName)
+			preReceiveTag(ourCtx, oldCommitID, newCommitID, refFullName)
 		case git.DefaultFeatures().SupportProcReceive && refFullName.IsFor():
 			preReceiveFor(ourCtx, refFullName)
 		default:
@@ -137,6 +157,31 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 	ctx.PlainText(http.StatusOK, "ok")
 }
 
+// preReceiveQuota rejects the push if the owner is over the storage quota, pushes which only delete refs are allowed
+func preReceiveQuota(ctx *preReceiveContext) bool {
+	emptyObjectID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	if !slices.ContainsFunc(ctx.opts.NewCommitIDs, func(id string) bool { return id != emptyObjectID }) {
+		return true
+	}
+
+	repo := ctx.Repo.Repository
+	if err := quota_model.CheckExceeded(ctx, repo.OwnerID, quota_model.CategoryGit, 0); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			log.Warn("Forbidden: push to %-v rejected: %v", repo, err)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("push rejected, %v", err),
+			})
+			return false
+		}
+		log.Error("Unable to check the quota of %-v: %v", repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
 func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	branchName := refFullName.BranchName()
 	ctx.branchName = branchName
@@ -392,7 +437,94 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, r
 	}
 }
 
-func preReceiveTag(ctx *preReceiveContext, refFullName git.RefName) {
+// preReceiveWiki rejects the push to the wiki if the pusher can't write the wiki, or if it changes protected pages and the pusher
+// is not a repository administrator.
+// The code permission and the branch and tag protection don't apply, pull requests are rejected and only the pages of
+// the default wiki branch are checked.
+func preReceiveWiki(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
+	if refFullName.IsFor() {
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: "Pull requests are not supported on the wiki.",
+		})
+		return
+	}
+
+	// the write permission check is delayed to this hook when the proc-receive hook is supported
+	if !ctx.loadPusherAndPermission() {
+		return
+	}
+	if !ctx.userPerm.CanWrite(unit.TypeWiki) && ctx.deployKeyAccessMode < perm_model.AccessModeWrite {
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: "User permission denied for writing.",
+		})
+		return
+	}
+
+	repo := ctx.Repo.Repository
+	if !refFullName.IsBranch() || refFullName.BranchName() != repo.DefaultWikiBranch {
+		return
+	}
+
+	protectedPages, err := repo_model.GetProtectedWikiPages(ctx, repo.ID)
+	if err != nil {
+		log.Error("Unable to get protected wiki pages for %-v Error: %v", repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+	if len(protectedPages) == 0 {
+		return
+	}
+
+	// deploy keys are never allowed to change the protected pages
+	if ctx.opts.DeployKeyID == 0 && ctx.userPerm.IsAdmin() {
+		return
+	}
+
+	// list the files changed by the push, or all the files of the branch when it is created or deleted
+	emptyCommitID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	var cmd *gitcmd.Command
+	switch {
+	case oldCommitID == emptyCommitID:
+		cmd = gitcmd.NewCommand("ls-tree", "-r", "-z", "--name-only").AddDynamicArguments(newCommitID)
+	case newCommitID == emptyCommitID:
+		cmd = gitcmd.NewCommand("ls-tree", "-r", "-z", "--name-only").AddDynamicArguments(oldCommitID)
+	default:
+		cmd = gitcmd.NewCommand("diff-tree", "-r", "-z", "--name-only", "--no-renames").AddDynamicArguments(oldCommitID, newCommitID)
+	}
+	stdout, _, err := cmd.RunStdString(ctx, &gitcmd.RunOpts{Dir: repo.WikiPath(), Env: ctx.env})
+	if err != nil {
+		log.Error("Unable to list the changed files between %s and %s in the wiki of %-v Error: %v", oldCommitID, newCommitID, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to list the changed files between %s and %s: %v", oldCommitID, newCommitID, err),
+		})
+		return
+	}
+
+	for _, filename := range strings.Split(stdout, "\x00") {
+		pageName, ok := strings.CutSuffix(filename, ".md")
+		if !ok {
+			continue
+		}
+		protected, err := repo_model.IsWikiPageProtected(protectedPages, pageName)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return
+		}
+		if protected {
+			log.Warn("Forbidden: Wiki page %s in %-v is protected", pageName, repo)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("wiki page %s is protected", pageName),
+			})
+			return
+		}
+	}
+}
+
+func preReceiveTag(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	if !ctx.AssertCanWriteCode() {
 		return
 	}
@@ -426,6 +558,55 @@ func preReceiveTag(ctx *preReceiveContext, refFullName git.RefName) {
 		})
 		return
 	}
+
+	// the requirements of the protected tags apply to the allowed users too
+	reqs, err := git_model.GetTagRequirements(ctx.protectedTags, tagName)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+
+	emptyCommitID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	if reqs.EnforceSemver {
+		if oldCommitID != emptyCommitID {
+			log.Warn("Forbidden: Tag %s in %-v is released and can't be changed", tagName, ctx.Repo.Repository)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Tag %s is a released version and can't be moved or deleted", tagName),
+			})
+			return
+		}
+		if !git_model.IsSemverTagName(tagName) {
+			log.Warn("Forbidden: Tag %s in %-v is not a semantic version", tagName, ctx.Repo.Repository)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("Tag %s is not a semantic version", tagName),
+			})
+			return
+		}
+	}
+
+	if reqs.RequireAnnotated && newCommitID != emptyCommitID {
+		if err := verifyTag(newCommitID, ctx.Repo.GitRepo, ctx.env, reqs.RequireSigned); err != nil {
+			var unverified *errUnverifiedTag
+			if !errors.As(err, &unverified) {
+				log.Error("Unable to verify tag %s in %-v: %v", tagName, ctx.Repo.Repository, err)
+				ctx.JSON(http.StatusInternalServerError, private.Response{
+					Err: fmt.Sprintf("Unable to verify tag %s: %v", tagName, err),
+				})
+				return
+			}
+			log.Warn("Forbidden: Tag %s in %-v does not meet the tag requirements: %v", tagName, ctx.Repo.Repository, err)
+			userMsg := fmt.Sprintf("Tag %s must be an annotated tag", tagName)
+			if unverified.annotated {
+				userMsg = fmt.Sprintf("Tag %s must be signed with a verified key", tagName)
+			}
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: userMsg,
+			})
+			return
+		}
+	}
 }
 
 func preReceiveFor(ctx *preReceiveContext, refFullName git.RefName) {
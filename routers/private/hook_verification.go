@@ -5,9 +5,11 @@ package private
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"io"
 	"os"
+	"strings"
 
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
@@ -120,3 +122,44 @@ func isErrUnverifiedCommit(err error) bool {
 	_, ok := err.(*errUnverifiedCommit)
 	return ok
 }
+
+// verifyTag checks that the tag object is an annotated tag, and if requireSigned is set that its signature is verified
+func verifyTag(sha string, repo *git.Repository, env []string, requireSigned bool) error {
+	objectType, _, runErr := gitcmd.NewCommand("cat-file", "-t").AddDynamicArguments(sha).
+		RunStdString(repo.Ctx, &gitcmd.RunOpts{Env: env, Dir: repo.Path})
+	if runErr != nil {
+		return runErr
+	}
+	if git.ObjectType(strings.TrimSpace(objectType)) != git.ObjectTag {
+		return &errUnverifiedTag{sha: sha}
+	}
+	if !requireSigned {
+		return nil
+	}
+
+	data, _, runErr := gitcmd.NewCommand("cat-file", "tag").AddDynamicArguments(sha).
+		RunStdBytes(repo.Ctx, &gitcmd.RunOpts{Env: env, Dir: repo.Path})
+	if runErr != nil {
+		return runErr
+	}
+	tag, err := git.TagFromReader(git.MustIDFromString(sha), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if verification := asymkey_service.ParseTagWithSignature(repo.Ctx, tag); !verification.Verified {
+		return &errUnverifiedTag{sha: sha, annotated: true}
+	}
+	return nil
+}
+
+type errUnverifiedTag struct {
+	sha       string
+	annotated bool
+}
+
+func (e *errUnverifiedTag) Error() string {
+	if !e.annotated {
+		return "Lightweight tag: " + e.sha
+	}
+	return "Unverified tag: " + e.sha
+}
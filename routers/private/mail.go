@@ -24,7 +24,7 @@ import (
 //
 // It doesn't wait before each message will be processed
 func SendEmail(ctx *context.PrivateContext) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		ctx.JSON(http.StatusInternalServerError, private.Response{
 			Err: "Mail service is not enabled.",
 		})
@@ -17,6 +17,7 @@ import (
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/reload"
 )
 
 // ReloadTemplates reloads all the templates
@@ -31,6 +32,17 @@ func ReloadTemplates(ctx *context.PrivateContext) {
 	ctx.PlainText(http.StatusOK, "success")
 }
 
+// ReloadConfig reloads the settings which can be changed without restarting
+func ReloadConfig(ctx *context.PrivateContext) {
+	if err := reload.Settings(ctx); err != nil {
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			UserMsg: fmt.Sprintf("Config error: %v", err),
+		})
+		return
+	}
+	ctx.PlainText(http.StatusOK, "success")
+}
+
 // FlushQueues flushes all the Queues
 func FlushQueues(ctx *context.PrivateContext) {
 	opts := web.GetForm(ctx).(*private.FlushOptions)
@@ -291,6 +291,18 @@ func ServCommand(ctx *context.PrivateContext) {
 			})
 			return
 		}
+		if overdue, err := user_model.IsTermsAcceptanceOverdue(ctx, user); err != nil {
+			log.Error("Unable to check the terms acceptance of user %d: %v", user.ID, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to check the terms acceptance of user %d: %v", user.ID, err),
+			})
+			return
+		} else if overdue {
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: "You must accept the current terms and policies. Accept them at: " + setting.AppURL + "user/terms",
+			})
+			return
+		}
 
 		results.UserName = user.Name
 		if !user.KeepEmailPrivate {
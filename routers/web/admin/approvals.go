@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	admin_service "code.gitea.io/gitea/services/admin"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplApprovals templates.TplName = "admin/approvals"
+
+// Approvals shows the approval requests of the destructive admin operations
+func Approvals(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.approvals")
+	ctx.Data["PageIsAdminApprovals"] = true
+
+	if err := admin_model.ExpireApprovalRequests(ctx); err != nil {
+		ctx.ServerError("ExpireApprovalRequests", err)
+		return
+	}
+
+	page := max(ctx.FormInt("page"), 1)
+	status := admin_model.ApprovalStatus(ctx.FormString("status"))
+	requests, count, err := db.FindAndCount[admin_model.ApprovalRequest](ctx, admin_model.FindApprovalRequestsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.Admin.NoticePagingNum},
+		Status:      status,
+	})
+	if err != nil {
+		ctx.ServerError("FindApprovalRequests", err)
+		return
+	}
+	// the approvers review the changes of the authentication sources before they approve them
+	authSourceChanges := map[int64][]*admin_service.AuthSourceFieldChange{}
+	for _, r := range requests {
+		if err := r.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+		if !r.IsPending() || (r.Action != admin_model.ApprovalActionEditAuthSource && r.Action != admin_model.ApprovalActionCreateAuthSource) {
+			continue
+		}
+		changes, err := admin_service.GetAuthSourceChanges(ctx, r)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			ctx.ServerError("GetAuthSourceChanges", err)
+			return
+		}
+		authSourceChanges[r.ID] = changes
+	}
+	ctx.Data["AuthSourceChanges"] = authSourceChanges
+
+	ctx.Data["ApprovalRequests"] = requests
+	ctx.Data["Status"] = status
+	ctx.Data["Total"] = count
+	pager := context.NewPagination(int(count), setting.UI.Admin.NoticePagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tplApprovals)
+}
+
+func decideApprovalRequest(ctx *context.Context, decide func(*admin_model.ApprovalRequest) error) {
+	r, err := admin_model.GetApprovalRequestByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetApprovalRequestByID", err)
+		}
+		return
+	}
+
+	if err := decide(r); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+		} else {
+			ctx.ServerError("DecideApprovalRequest", err)
+			return
+		}
+	} else if r.Status == admin_model.ApprovalStatusFailed {
+		ctx.Flash.Error(ctx.Tr("admin.approvals.failed", r.Message))
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.approvals." + string(r.Status) + "_success"))
+	}
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/approvals")
+}
+
+// ApproveRequest approves an approval request and executes the operation
+func ApproveRequest(ctx *context.Context) {
+	decideApprovalRequest(ctx, func(r *admin_model.ApprovalRequest) error {
+		return admin_service.ApproveRequest(ctx, ctx.Doer, r)
+	})
+}
+
+// RejectRequest rejects an approval request
+func RejectRequest(ctx *context.Context) {
+	decideApprovalRequest(ctx, func(r *admin_model.ApprovalRequest) error {
+		return admin_service.RejectRequest(ctx, ctx.Doer, r)
+	})
+}
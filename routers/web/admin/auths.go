@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
+	admin_service "code.gitea.io/gitea/services/admin"
 	auth_service "code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/auth/source/ldap"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
@@ -201,12 +202,30 @@ func parseOAuth2Config(form forms.AuthenticationForm) *oauth2.Source {
 		AdminGroup:                    form.Oauth2AdminGroup,
 		GroupTeamMap:                  form.Oauth2GroupTeamMap,
 		GroupTeamMapRemoval:           form.Oauth2GroupTeamMapRemoval,
+		GroupSyncProvider:             form.Oauth2GroupSyncProvider,
+		GroupSyncURL:                  form.Oauth2GroupSyncURL,
+		GroupSyncTokenURL:             form.Oauth2GroupSyncTokenURL,
+		GroupSyncToken:                form.Oauth2GroupSyncToken,
 
 		SSHPublicKeyClaimName: form.Oauth2SSHPublicKeyClaimName,
 		FullNameClaimName:     form.Oauth2FullNameClaimName,
 	}
 }
 
+func isValidOAuth2GroupSync(cfg *oauth2.Source) bool {
+	if !oauth2.IsValidGroupSyncProvider(cfg.GroupSyncProvider) {
+		return false
+	} else if cfg.GroupSyncProvider == "" {
+		return true
+	}
+	for _, s := range []string{cfg.GroupSyncURL, cfg.GroupSyncTokenURL} {
+		if u, err := url.Parse(s); s != "" && (err != nil || (u.Scheme != "http" && u.Scheme != "https")) {
+			return false
+		}
+	}
+	return cfg.GroupSyncURL != "" && (cfg.GroupSyncTokenURL != "" || cfg.GroupSyncToken != "")
+}
+
 func parseSSPIConfig(ctx *context.Context, form forms.AuthenticationForm) (*sspi.Source, error) {
 	if util.IsEmptyString(form.SSPISeparatorReplacement) {
 		ctx.Data["Err_SSPISeparatorReplacement"] = true
@@ -276,6 +295,11 @@ func NewAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+		if !isValidOAuth2GroupSync(oauth2Config) {
+			ctx.Data["Err_GroupSync"] = true
+			ctx.RenderWithErr(ctx.Tr("admin.auths.invalid_group_sync"), tplAuthNew, form)
+			return
+		}
 	case auth.SSPI:
 		var err error
 		config, err = parseSSPIConfig(ctx, form)
@@ -300,14 +324,24 @@ func NewAuthSourcePost(ctx *context.Context) {
 		return
 	}
 
-	if err := auth.CreateSource(ctx, &auth.Source{
+	source := &auth.Source{
 		Type:            auth.Type(form.Type),
 		Name:            form.Name,
 		IsActive:        form.IsActive,
 		IsSyncEnabled:   form.IsSyncEnabled,
 		TwoFactorPolicy: form.TwoFactorPolicy,
 		Cfg:             config,
-	}); err != nil {
+	}
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestCreateAuthSource(ctx, ctx.Doer, source); err != nil {
+			ctx.ServerError("RequestCreateAuthSource", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.Redirect(setting.AppSubURL + "/-/admin/auths")
+		return
+	}
+	if err := auth.CreateSource(ctx, source); err != nil {
 		if auth.IsErrSourceAlreadyExist(err) {
 			ctx.Data["Err_Name"] = true
 			ctx.RenderWithErr(ctx.Tr("admin.auths.login_source_exist", err.(auth.ErrSourceAlreadyExist).Name), tplAuthNew, form)
@@ -406,6 +440,11 @@ func EditAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+		if !isValidOAuth2GroupSync(oauth2Config) {
+			ctx.Data["Err_GroupSync"] = true
+			ctx.RenderWithErr(ctx.Tr("admin.auths.invalid_group_sync"), tplAuthEdit, form)
+			return
+		}
 	case auth.SSPI:
 		config, err = parseSSPIConfig(ctx, form)
 		if err != nil {
@@ -422,6 +461,15 @@ func EditAuthSourcePost(ctx *context.Context) {
 	source.IsSyncEnabled = form.IsSyncEnabled
 	source.Cfg = config
 	source.TwoFactorPolicy = form.TwoFactorPolicy
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestEditAuthSource(ctx, ctx.Doer, source); err != nil {
+			ctx.ServerError("RequestEditAuthSource", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.Redirect(setting.AppSubURL + "/-/admin/auths/" + strconv.FormatInt(form.ID, 10))
+		return
+	}
 	if err := auth.UpdateSource(ctx, source); err != nil {
 		if auth.IsErrSourceAlreadyExist(err) {
 			ctx.Data["Err_Name"] = true
@@ -449,6 +497,16 @@ func DeleteAuthSource(ctx *context.Context) {
 		return
 	}
 
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestDeleteAuthSource(ctx, ctx.Doer, source); err != nil {
+			ctx.ServerError("RequestDeleteAuthSource", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.JSONRedirect(setting.AppSubURL + "/-/admin/auths/" + url.PathEscape(ctx.PathParam("authid")))
+		return
+	}
+
 	if err = auth_service.DeleteSource(ctx, source); err != nil {
 		if auth.IsErrSourceInUse(err) {
 			ctx.Flash.Error(ctx.Tr("admin.auths.still_in_used"))
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	system_model "code.gitea.io/gitea/models/system"
 	"code.gitea.io/gitea/modules/cache"
@@ -147,9 +148,9 @@ func Config(ctx *context.Context) {
 	ctx.Data["Webhook"] = setting.Webhook
 
 	ctx.Data["MailerEnabled"] = false
-	if setting.MailService != nil {
+	if setting.GetMailService() != nil {
 		ctx.Data["MailerEnabled"] = true
-		ctx.Data["Mailer"] = setting.MailService
+		ctx.Data["Mailer"] = setting.GetMailService()
 	}
 
 	ctx.Data["CacheAdapter"] = setting.CacheService.Adapter
@@ -192,9 +193,22 @@ func ConfigSettings(ctx *context.Context) {
 	ctx.Data["PageIsAdminConfig"] = true
 	ctx.Data["PageIsAdminConfigSettings"] = true
 	ctx.Data["DefaultOpenWithEditorAppsString"] = setting.DefaultOpenWithEditorApps().ToTextareaString()
+	ctx.Data["MaintenanceStartTime"] = formatMaintenanceTime(setting.Config().Maintenance.StartTime.Value(ctx))
+	ctx.Data["MaintenanceEndTime"] = formatMaintenanceTime(setting.Config().Maintenance.EndTime.Value(ctx))
+	ctx.Data["MaintenanceTimeZone"] = setting.DefaultUILocation.String()
 	ctx.HTML(http.StatusOK, tplConfigSettings)
 }
 
+// maintenanceTimeLayout is the layout of the "datetime-local" inputs of the maintenance window
+const maintenanceTimeLayout = "2006-01-02T15:04"
+
+func formatMaintenanceTime(unix int64) string {
+	if unix <= 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).In(setting.DefaultUILocation).Format(maintenanceTimeLayout)
+}
+
 func ChangeConfig(ctx *context.Context) {
 	cfg := setting.Config()
 
@@ -230,11 +244,26 @@ func ChangeConfig(ctx *context.Context) {
 		}
 		return json.Marshal(openWithEditorApps)
 	}
+
+	marshalMaintenanceTime := func(v string) ([]byte, error) {
+		if v == "" {
+			return json.Marshal(0)
+		}
+		t, err := time.ParseInLocation(maintenanceTimeLayout, v, setting.DefaultUILocation)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t.Unix())
+	}
 	marshallers := map[string]func(string) ([]byte, error){
 		cfg.Picture.DisableGravatar.DynKey():       marshalBool,
 		cfg.Picture.EnableFederatedAvatar.DynKey(): marshalBool,
 		cfg.Repository.OpenWithEditorApps.DynKey(): marshalOpenWithApps,
 		cfg.Repository.GitGuideRemoteName.DynKey(): marshalString(cfg.Repository.GitGuideRemoteName.DefaultValue()),
+		cfg.Maintenance.ReadOnly.DynKey():          marshalBool,
+		cfg.Maintenance.Message.DynKey():           marshalString(""),
+		cfg.Maintenance.StartTime.DynKey():         marshalMaintenanceTime,
+		cfg.Maintenance.EndTime.DynKey():           marshalMaintenanceTime,
 	}
 
 	_ = ctx.Req.ParseForm()
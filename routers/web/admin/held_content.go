@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	spamdetection_service "code.gitea.io/gitea/services/spamdetection"
+)
+
+const tplHeldContent templates.TplName = "admin/held_content"
+
+// HeldContent shows the issues and comments which have been held as suspected spam
+func HeldContent(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.held_content")
+	ctx.Data["PageIsAdminHeldContent"] = true
+
+	page := max(ctx.FormInt("page"), 1)
+	contents, count, err := db.FindAndCount[issues_model.HeldContent](ctx, issues_model.FindHeldContentsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.Admin.NoticePagingNum},
+	})
+	if err != nil {
+		ctx.ServerError("FindHeldContents", err)
+		return
+	}
+	for _, h := range contents {
+		if err := h.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+
+	ctx.Data["HeldContents"] = contents
+	ctx.Data["Total"] = count
+	ctx.Data["Page"] = context.NewPagination(int(count), setting.UI.Admin.NoticePagingNum, page, 5)
+	ctx.HTML(http.StatusOK, tplHeldContent)
+}
+
+func getHeldContent(ctx *context.Context) *issues_model.HeldContent {
+	h, err := issues_model.GetHeldContentByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetHeldContentByID", err)
+		}
+		return nil
+	}
+	return h
+}
+
+// ApproveHeldContent publishes the held content
+func ApproveHeldContent(ctx *context.Context) {
+	h := getHeldContent(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := spamdetection_service.ApproveHeldContent(ctx, ctx.Doer, h); err != nil {
+		if errors.Is(err, util.ErrNotExist) || errors.Is(err, user_model.ErrBlockedUser) {
+			ctx.Flash.Error(ctx.Tr("admin.held_content.approve_failed", err.Error()))
+		} else {
+			ctx.ServerError("ApproveHeldContent", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.held_content.approve_success"))
+	}
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/held-content")
+}
+
+// RejectHeldContent deletes the held content
+func RejectHeldContent(ctx *context.Context) {
+	h := getHeldContent(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := spamdetection_service.RejectHeldContent(ctx, ctx.Doer, h); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("admin.held_content.reject_failed", err.Error()))
+		} else {
+			ctx.ServerError("RejectHeldContent", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.held_content.reject_success"))
+	}
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/held-content")
+}
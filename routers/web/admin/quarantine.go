@@ -0,0 +1,83 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
+)
+
+const (
+	tplQuarantine templates.TplName = "admin/quarantine"
+)
+
+// Quarantine shows the uploads in which malware has been found for the review by admins
+func Quarantine(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.quarantine")
+	ctx.Data["PageIsAdminQuarantine"] = true
+
+	page := max(ctx.FormInt("page"), 1)
+
+	files, total, err := system_model.FindQuarantinedFiles(ctx, db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.Admin.NoticePagingNum,
+	})
+	if err != nil {
+		ctx.ServerError("FindQuarantinedFiles", err)
+		return
+	}
+
+	// the attachments may have been deleted in the meantime, those files can only be dismissed
+	attachments := make(map[int64]*repo_model.Attachment, len(files))
+	for _, f := range files {
+		if f.Subject != system_model.QuarantineSubjectAttachment {
+			continue
+		}
+		attach, err := repo_model.GetAttachmentByID(ctx, f.AttachmentID)
+		if err != nil {
+			if repo_model.IsErrAttachmentNotExist(err) {
+				continue
+			}
+			ctx.ServerError("GetAttachmentByID", err)
+			return
+		}
+		attachments[f.ID] = attach
+	}
+
+	ctx.Data["QuarantinedFiles"] = files
+	ctx.Data["Attachments"] = attachments
+	ctx.Data["Total"] = total
+	ctx.Data["Page"] = context.NewPagination(int(total), setting.UI.Admin.NoticePagingNum, page, 5)
+
+	ctx.HTML(http.StatusOK, tplQuarantine)
+}
+
+// ReleaseQuarantinedFile releases a reviewed file from the quarantine
+func ReleaseQuarantinedFile(ctx *context.Context) {
+	if err := malwarescan_service.ReleaseQuarantinedFile(ctx, ctx.Doer, ctx.PathParamInt64("id")); err != nil {
+		ctx.ServerError("ReleaseQuarantinedFile", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.quarantine.release_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/quarantine")
+}
+
+// DeleteQuarantinedFile deletes a quarantined file
+func DeleteQuarantinedFile(ctx *context.Context) {
+	if err := malwarescan_service.DeleteQuarantinedFile(ctx, ctx.Doer, ctx.PathParamInt64("id")); err != nil {
+		ctx.ServerError("DeleteQuarantinedFile", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.quarantine.delete_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/quarantine")
+}
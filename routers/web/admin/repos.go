@@ -16,6 +16,7 @@ import (
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/web/explore"
+	admin_service "code.gitea.io/gitea/services/admin"
 	"code.gitea.io/gitea/services/context"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
@@ -46,6 +47,16 @@ func DeleteRepo(ctx *context.Context) {
 		return
 	}
 
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestDeleteRepo(ctx, ctx.Doer, repo); err != nil {
+			ctx.ServerError("RequestDeleteRepo", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.JSONRedirect(setting.AppSubURL + "/-/admin/repos?page=" + url.QueryEscape(ctx.FormString("page")) + "&sort=" + url.QueryEscape(ctx.FormString("sort")))
+		return
+	}
+
 	if ctx.Repo != nil && ctx.Repo.GitRepo != nil && ctx.Repo.Repository != nil && ctx.Repo.Repository.ID == repo.ID {
 		ctx.Repo.GitRepo.Close()
 	}
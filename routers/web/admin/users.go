@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
@@ -23,9 +24,12 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
+	web_auth "code.gitea.io/gitea/routers/web/auth"
 	"code.gitea.io/gitea/routers/web/explore"
 	user_setting "code.gitea.io/gitea/routers/web/user/setting"
+	admin_service "code.gitea.io/gitea/services/admin"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/mailer"
@@ -99,7 +103,7 @@ func NewUser(ctx *context.Context) {
 	}
 	ctx.Data["Sources"] = sources
 
-	ctx.Data["CanSendEmail"] = setting.MailService != nil
+	ctx.Data["CanSendEmail"] = setting.GetMailService() != nil
 	ctx.HTML(http.StatusOK, tplUserNew)
 }
 
@@ -120,7 +124,7 @@ func NewUserPost(ctx *context.Context) {
 	}
 	ctx.Data["Sources"] = sources
 
-	ctx.Data["CanSendEmail"] = setting.MailService != nil
+	ctx.Data["CanSendEmail"] = setting.GetMailService() != nil
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplUserNew)
@@ -305,6 +309,9 @@ func ViewUser(ctx *context.Context) {
 	ctx.Data["Users"] = orgs // needed to be able to use explore/user_list template
 	ctx.Data["OrgsTotal"] = len(orgs)
 
+	ctx.Data["CanImpersonate"] = user_service.CanImpersonate(ctx, ctx.Doer, u) == nil
+	ctx.Data["ImpersonationDuration"] = setting.Admin.ImpersonationDuration.String()
+
 	ctx.HTML(http.StatusOK, tplUserView)
 }
 
@@ -496,6 +503,16 @@ func DeleteUser(ctx *context.Context) {
 		return
 	}
 
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestDeleteUser(ctx, ctx.Doer, u, ctx.FormBool("purge")); err != nil {
+			ctx.ServerError("RequestDeleteUser", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.Redirect(setting.AppSubURL + "/-/admin/users/" + url.PathEscape(ctx.PathParam("userid")))
+		return
+	}
+
 	if err = user_service.DeleteUser(ctx, u, ctx.FormBool("purge")); err != nil {
 		switch {
 		case repo_model.IsErrUserOwnRepos(err):
@@ -521,6 +538,135 @@ func DeleteUser(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + "/-/admin/users")
 }
 
+// ImpersonateUser signs the admin in as the user until the impersonation expires or is stopped
+func ImpersonateUser(ctx *context.Context) {
+	u, err := user_model.GetUserByID(ctx, ctx.PathParamInt64("userid"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetUserByID", err)
+		}
+		return
+	}
+
+	if err := user_service.StartImpersonation(ctx, ctx.Doer, u); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.Flash.Error(ctx.Tr("admin.users.impersonate_not_allowed"))
+			ctx.JSONRedirect(setting.AppSubURL + "/-/admin/users/" + strconv.FormatInt(u.ID, 10))
+			return
+		}
+		ctx.ServerError("StartImpersonation", err)
+		return
+	}
+
+	deadline := time.Now().Add(setting.Admin.ImpersonationDuration).Unix()
+	if err := web_auth.SignInImpersonatedUser(ctx, ctx.Doer, u, deadline); err != nil {
+		ctx.ServerError("SignInImpersonatedUser", err)
+		return
+	}
+	log.Trace("Admin %s started to impersonate %s", ctx.Doer.Name, u.Name)
+
+	ctx.JSONRedirect(setting.AppSubURL + "/")
+}
+
+// OffboardUser deactivates the user, revokes the access and hands over the repositories and the issue assignments
+func OffboardUser(ctx *context.Context) {
+	u, err := user_model.GetUserByID(ctx, ctx.PathParamInt64("userid"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetUserByID", err)
+		}
+		return
+	}
+	redirectTo := setting.AppSubURL + "/-/admin/users/" + strconv.FormatInt(u.ID, 10)
+
+	var opts user_service.OffboardOptions
+	for name, target := range map[string]**user_model.User{
+		"repo_owner":     &opts.RepoOwner,
+		"issue_assignee": &opts.IssueAssignee,
+	} {
+		userName := strings.TrimSpace(ctx.FormString(name))
+		if userName == "" {
+			continue
+		}
+		if *target, err = user_model.GetUserByName(ctx, userName); err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Flash.Error(ctx.Tr("form.user_not_exist"))
+				ctx.JSONRedirect(redirectTo)
+			} else {
+				ctx.ServerError("GetUserByName", err)
+			}
+			return
+		}
+	}
+
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestOffboardUser(ctx, ctx.Doer, u, opts); err != nil {
+			ctx.ServerError("RequestOffboardUser", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.JSONRedirect(redirectTo)
+		return
+	}
+
+	result, err := user_service.OffboardUser(ctx, ctx.Doer, u, opts)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("admin.users.offboard_not_allowed", err.Error()))
+			ctx.JSONRedirect(redirectTo)
+			return
+		}
+		ctx.ServerError("OffboardUser", err)
+		return
+	}
+	log.Trace("Account offboarded by admin (%s): %s", ctx.Doer.Name, u.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.users.offboard_success", len(result.TransferredRepos), len(result.ArchivedRepos), result.ReassignedIssues))
+	ctx.JSONRedirect(redirectTo)
+}
+
+// AnonymizeUser scrubs the personal data of the user but keeps the account for the issues and the comments
+func AnonymizeUser(ctx *context.Context) {
+	u, err := user_model.GetUserByID(ctx, ctx.PathParamInt64("userid"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetUserByID", err)
+		}
+		return
+	}
+	redirectTo := setting.AppSubURL + "/-/admin/users/" + strconv.FormatInt(u.ID, 10)
+
+	if admin_service.IsApprovalRequired() {
+		if _, err := admin_service.RequestAnonymizeUser(ctx, ctx.Doer, u); err != nil {
+			ctx.ServerError("RequestAnonymizeUser", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.JSONRedirect(redirectTo)
+		return
+	}
+
+	if err := user_service.AnonymizeUser(ctx, ctx.Doer, u); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("admin.users.anonymize_not_allowed", err.Error()))
+			ctx.JSONRedirect(redirectTo)
+			return
+		}
+		ctx.ServerError("AnonymizeUser", err)
+		return
+	}
+	log.Trace("Account %d anonymized by admin (%s)", u.ID, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.users.anonymize_success"))
+	ctx.JSONRedirect(redirectTo)
+}
+
 // AvatarPost response for change user's avatar request
 func AvatarPost(ctx *context.Context) {
 	u := prepareUserInfo(ctx)
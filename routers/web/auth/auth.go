@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/auth/password"
+	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/eventsource"
 	"code.gitea.io/gitea/modules/httplib"
 	"code.gitea.io/gitea/modules/log"
@@ -175,11 +177,59 @@ func prepareSignInPageData(ctx *context.Context) {
 	ctx.Data["EnablePasswordSignInForm"] = setting.Service.EnablePasswordSignInForm
 	ctx.Data["EnablePasskeyAuth"] = setting.Service.EnablePasskeyAuth
 
-	if setting.Service.EnableCaptcha && setting.Service.RequireCaptchaForLogin {
-		context.SetCaptchaData(ctx)
+	if isLoginCaptchaRequired("") {
+		context.SetCaptchaData(ctx, setting.Service.LoginCaptchaType)
 	}
 }
 
+// loginFailuresTTL is the time in seconds the failed login attempts of a username are counted
+const loginFailuresTTL = 3600
+
+func loginFailuresCacheKey(userName string) string {
+	return "login_failures:" + strings.ToLower(userName)
+}
+
+// countsLoginFailures checks whether the failed login attempts have to be counted to decide about the captcha
+func countsLoginFailures() bool {
+	return setting.Service.EnableCaptcha && setting.Service.RequireCaptchaForLogin && setting.Service.LoginCaptchaFailedAttempts > 0
+}
+
+func getLoginFailures(userName string) int {
+	v, _ := cache.GetCache().Get(loginFailuresCacheKey(userName))
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+func recordLoginFailure(userName string) {
+	if !countsLoginFailures() {
+		return
+	}
+	if err := cache.GetCache().Put(loginFailuresCacheKey(userName), strconv.Itoa(getLoginFailures(userName)+1), loginFailuresTTL); err != nil {
+		log.Error("Unable to record the failed login attempt for %s: %v", userName, err)
+	}
+}
+
+func resetLoginFailures(userName string) {
+	if !countsLoginFailures() {
+		return
+	}
+	if err := cache.GetCache().Delete(loginFailuresCacheKey(userName)); err != nil {
+		log.Error("Unable to reset the failed login attempts for %s: %v", userName, err)
+	}
+}
+
+// isLoginCaptchaRequired checks whether a captcha has to be solved to log in with the username. If the captcha is only
+// required after failed attempts, an empty username never requires it.
+func isLoginCaptchaRequired(userName string) bool {
+	if !setting.Service.EnableCaptcha || !setting.Service.RequireCaptchaForLogin {
+		return false
+	}
+	if setting.Service.LoginCaptchaFailedAttempts <= 0 {
+		return true
+	}
+	return userName != "" && getLoginFailures(userName) >= setting.Service.LoginCaptchaFailedAttempts
+}
+
 // SignIn render sign in page
 func SignIn(ctx *context.Context) {
 	if CheckAutoLogin(ctx) {
@@ -208,8 +258,9 @@ func SignInPost(ctx *context.Context) {
 
 	form := web.GetForm(ctx).(*forms.SignInForm)
 
-	if setting.Service.EnableCaptcha && setting.Service.RequireCaptchaForLogin {
-		context.VerifyCaptcha(ctx, tplSignIn, form)
+	if isLoginCaptchaRequired(form.UserName) {
+		context.SetCaptchaData(ctx, setting.Service.LoginCaptchaType)
+		context.VerifyCaptcha(ctx, setting.Service.LoginCaptchaType, tplSignIn, form)
 		if ctx.Written() {
 			return
 		}
@@ -218,6 +269,10 @@ func SignInPost(ctx *context.Context) {
 	u, source, err := auth_service.UserSignIn(ctx, form.UserName, form.Password)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrInvalidArgument) {
+			recordLoginFailure(form.UserName)
+			if isLoginCaptchaRequired(form.UserName) {
+				context.SetCaptchaData(ctx, setting.Service.LoginCaptchaType)
+			}
 			ctx.RenderWithErr(ctx.Tr("form.username_password_incorrect"), tplSignIn, &form)
 			log.Warn("Failed authentication attempt for %s from %s: %v", form.UserName, ctx.RemoteAddr(), err)
 		} else if user_model.IsErrEmailAlreadyUsed(err) {
@@ -241,6 +296,7 @@ func SignInPost(ctx *context.Context) {
 		}
 		return
 	}
+	resetLoginFailures(form.UserName)
 
 	// Now handle 2FA:
 	// First of all if the source can skip local two fa we're done
@@ -330,6 +386,8 @@ func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRe
 		"twofaRemember",
 		"linkAccount",
 		"linkAccountData",
+		session.KeyImpersonatorUID,
+		session.KeyImpersonationDeadline,
 	}, map[string]any{
 		session.KeyUID:                  u.ID,
 		session.KeyUname:                u.Name,
@@ -383,7 +441,7 @@ func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRe
 // extractUserNameFromOAuth2 tries to extract a normalized username from the given OAuth2 user.
 // It returns ("", nil) if the required field doesn't exist.
 func extractUserNameFromOAuth2(gothUser *goth.User) (string, error) {
-	switch setting.OAuth2Client.Username {
+	switch setting.GetOAuth2Client().Username {
 	case setting.OAuth2UsernameEmail:
 		return user_model.NormalizeUserName(gothUser.Email)
 	case setting.OAuth2UsernamePreferredUsername:
@@ -434,7 +492,10 @@ func SignUp(ctx *context.Context) {
 	}
 
 	ctx.Data["OAuth2Providers"] = oauth2Providers
-	context.SetCaptchaData(ctx)
+	context.SetCaptchaData(ctx, setting.Service.RegisterCaptchaType)
+	if prepareSignUpTermsDocuments(ctx); ctx.Written() {
+		return
+	}
 
 	ctx.Data["PageIsSignUp"] = true
 
@@ -463,7 +524,11 @@ func SignUpPost(ctx *context.Context) {
 	}
 
 	ctx.Data["OAuth2Providers"] = oauth2Providers
-	context.SetCaptchaData(ctx)
+	context.SetCaptchaData(ctx, setting.Service.RegisterCaptchaType)
+	termsDocuments := prepareSignUpTermsDocuments(ctx)
+	if ctx.Written() {
+		return
+	}
 
 	ctx.Data["PageIsSignUp"] = true
 
@@ -478,7 +543,7 @@ func SignUpPost(ctx *context.Context) {
 		return
 	}
 
-	context.VerifyCaptcha(ctx, tplSignUp, form)
+	context.VerifyCaptcha(ctx, setting.Service.RegisterCaptchaType, tplSignUp, form)
 	if ctx.Written() {
 		return
 	}
@@ -488,6 +553,11 @@ func SignUpPost(ctx *context.Context) {
 		return
 	}
 
+	if len(termsDocuments) > 0 && !form.AcceptTerms {
+		ctx.RenderWithErr(ctx.Tr("auth.terms_not_accepted"), tplSignUp, &form)
+		return
+	}
+
 	if form.Password != form.Retype {
 		ctx.Data["Err_Password"] = true
 		ctx.RenderWithErr(ctx.Tr("form.password_not_match"), tplSignUp, &form)
@@ -524,11 +594,26 @@ func SignUpPost(ctx *context.Context) {
 		// error already handled
 		return
 	}
+	if err := user_model.AcceptTermsDocuments(ctx, u.ID, termsDocuments); err != nil {
+		ctx.ServerError("AcceptTermsDocuments", err)
+		return
+	}
 
 	ctx.Flash.Success(ctx.Tr("auth.sign_up_successful"))
 	handleSignIn(ctx, u, false)
 }
 
+// prepareSignUpTermsDocuments shows the current policy documents on the sign-up page, they have to be accepted to register
+func prepareSignUpTermsDocuments(ctx *context.Context) []*user_model.TermsDocument {
+	docs, err := user_model.GetCurrentTermsDocuments(ctx)
+	if err != nil {
+		ctx.ServerError("GetCurrentTermsDocuments", err)
+		return nil
+	}
+	ctx.Data["TermsDocuments"] = docs
+	return docs
+}
+
 // createAndHandleCreatedUser calls createUserInContext and
 // then handleUserCreated.
 func createAndHandleCreatedUser(ctx *context.Context, tpl templates.TplName, form any, u *user_model.User, overwrites *user_model.CreateUserOverwriteOptions, possibleLinkAccountData *LinkAccountData) bool {
@@ -547,7 +632,7 @@ func createUserInContext(ctx *context.Context, tpl templates.TplName, form any,
 	}
 	if err := user_model.CreateUser(ctx, u, meta, overwrites); err != nil {
 		if possibleLinkAccountData != nil && (user_model.IsErrUserAlreadyExist(err) || user_model.IsErrEmailAlreadyUsed(err)) {
-			switch setting.OAuth2Client.AccountLinking {
+			switch setting.GetOAuth2Client().AccountLinking {
 			case setting.OAuth2AccountLinkingAuto:
 				var user *user_model.User
 				user = &user_model.User{Name: u.Name}
@@ -699,7 +784,7 @@ func Activate(ctx *context.Context) {
 			return
 		}
 
-		if setting.MailService == nil || !setting.Service.RegisterEmailConfirm {
+		if setting.GetMailService() == nil || !setting.Service.RegisterEmailConfirm {
 			renderActivationPromptMessage(ctx, ctx.Tr("auth.disable_register_mail"))
 			return
 		}
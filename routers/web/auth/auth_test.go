@@ -61,7 +61,10 @@ func TestUserLogin(t *testing.T) {
 }
 
 func TestSignUpOAuth2Login(t *testing.T) {
-	defer test.MockVariableValue(&setting.OAuth2Client.EnableAutoRegistration, true)()
+	oauth2Client := *setting.GetOAuth2Client()
+	oauth2Client.EnableAutoRegistration = true
+	defer setting.SetOAuth2Client(setting.GetOAuth2Client())
+	setting.SetOAuth2Client(&oauth2Client)
 
 	_ = oauth2.Init(t.Context())
 	addOAuth2Source(t, "dummy-auth-source", oauth2.Source{})
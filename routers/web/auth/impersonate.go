@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"fmt"
+	"strconv"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/session"
+	"code.gitea.io/gitea/modules/setting"
+	auth_service "code.gitea.io/gitea/services/auth"
+	"code.gitea.io/gitea/services/context"
+	user_service "code.gitea.io/gitea/services/user"
+)
+
+// SignInImpersonatedUser switches the session of the admin to the impersonated user in a new session
+func SignInImpersonatedUser(ctx *context.Context, impersonator, u *user_model.User, deadline int64) error {
+	if err := updateSession(ctx, auth_service.ImpersonationStaleSessionKeys, map[string]any{
+		session.KeyImpersonatorUID:       impersonator.ID,
+		session.KeyImpersonationDeadline: deadline,
+		session.KeyUID:                   u.ID,
+		session.KeyUname:                 u.Name,
+	}); err != nil {
+		return fmt.Errorf("updateSession: %w", err)
+	}
+	// force to generate a new CSRF token
+	ctx.Csrf.PrepareForSessionUser(ctx)
+	return nil
+}
+
+// StopImpersonation signs the admin who impersonates the current user in as themselves again
+func StopImpersonation(ctx *context.Context) {
+	impersonator, ok := ctx.Data["Impersonator"].(*user_model.User)
+	if !ok {
+		ctx.Redirect(setting.AppSubURL + "/")
+		return
+	}
+
+	if err := auth_service.StopImpersonationSession(ctx.Resp, ctx.Req, impersonator); err != nil {
+		ctx.ServerError("StopImpersonationSession", err)
+		return
+	}
+
+	if err := user_service.StopImpersonation(ctx, impersonator, ctx.Doer, false); err != nil {
+		ctx.ServerError("StopImpersonation", err)
+		return
+	}
+
+	ctx.Redirect(setting.AppSubURL + "/-/admin/users/" + strconv.FormatInt(ctx.Doer.ID, 10))
+}
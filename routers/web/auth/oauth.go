@@ -139,7 +139,7 @@ func SignInOAuthCallback(ctx *context.Context) {
 
 			ctx.Redirect(setting.AppSubURL + "/user/settings/security")
 			return
-		} else if !setting.Service.AllowOnlyInternalRegistration && setting.OAuth2Client.EnableAutoRegistration {
+		} else if !setting.Service.AllowOnlyInternalRegistration && setting.GetOAuth2Client().EnableAutoRegistration {
 			// create new user with details from oauth2 provider
 			var missingFields []string
 			if gothUser.UserID == "" {
@@ -154,7 +154,7 @@ func SignInOAuthCallback(ctx *context.Context) {
 				return
 			}
 			if uname == "" {
-				switch setting.OAuth2Client.Username {
+				switch setting.GetOAuth2Client().Username {
 				case setting.OAuth2UsernameNickname:
 					missingFields = append(missingFields, "nickname")
 				case setting.OAuth2UsernamePreferredUsername:
@@ -184,7 +184,7 @@ func SignInOAuthCallback(ctx *context.Context) {
 			}
 
 			overwriteDefault := &user_model.CreateUserOverwriteOptions{
-				IsActive: optional.Some(!setting.OAuth2Client.RegisterEmailConfirm && !setting.Service.RegisterManualConfirm),
+				IsActive: optional.Some(!setting.GetOAuth2Client().RegisterEmailConfirm && !setting.Service.RegisterManualConfirm),
 			}
 
 			source := authSource.Cfg.(*oauth2.Source)
@@ -194,7 +194,7 @@ func SignInOAuthCallback(ctx *context.Context) {
 			u.IsRestricted = isRestricted.ValueOrDefault(setting.Service.DefaultUserIsRestricted)
 
 			linkAccountData := &LinkAccountData{authSource.ID, gothUser}
-			if setting.OAuth2Client.AccountLinking == setting.OAuth2AccountLinkingDisabled {
+			if setting.GetOAuth2Client().AccountLinking == setting.OAuth2AccountLinkingDisabled {
 				linkAccountData = nil
 			}
 			if !createAndHandleCreatedUser(ctx, "", nil, u, overwriteDefault, linkAccountData) {
@@ -302,7 +302,7 @@ func showLinkingLogin(ctx *context.Context, authSourceID int64, gothUser goth.Us
 }
 
 func oauth2UpdateAvatarIfNeed(ctx *context.Context, url string, u *user_model.User) {
-	if setting.OAuth2Client.UpdateAvatar && len(url) > 0 {
+	if setting.GetOAuth2Client().UpdateAvatar && len(url) > 0 {
 		resp, err := http.Get(url)
 		if err == nil {
 			defer func() {
@@ -382,7 +382,7 @@ func handleOAuth2SignIn(ctx *context.Context, authSource *auth.Source, u *user_m
 			return
 		}
 
-		if err := updateSession(ctx, nil, map[string]any{
+		if err := updateSession(ctx, []string{session.KeyImpersonatorUID, session.KeyImpersonationDeadline}, map[string]any{
 			session.KeyUID:                  u.ID,
 			session.KeyUname:                u.Name,
 			session.KeyUserHasTwoFactorAuth: userHasTwoFactorAuth,
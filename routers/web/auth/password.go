@@ -34,7 +34,7 @@ var (
 func ForgotPasswd(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("auth.forgot_password_title")
 
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		log.Warn("no mail service configured")
 		ctx.Data["IsResetDisable"] = true
 		ctx.HTML(http.StatusOK, tplForgotPassword)
@@ -51,7 +51,7 @@ func ForgotPasswd(ctx *context.Context) {
 func ForgotPasswdPost(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("auth.forgot_password_title")
 
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		ctx.NotFound(nil)
 		return
 	}
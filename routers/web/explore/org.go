@@ -26,6 +26,7 @@ func Organizations(ctx *context.Context) {
 	ctx.Data["PageIsExplore"] = true
 	ctx.Data["PageIsExploreOrganizations"] = true
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
 
 	visibleTypes := []structs.VisibleType{structs.VisibleTypePublic}
 	if ctx.Doer != nil {
@@ -5,6 +5,12 @@
 package misc
 
 import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/markup/diagram"
+	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
@@ -18,3 +24,28 @@ func Markup(ctx *context.Context) {
 	mode := util.Iif(form.Wiki, "wiki", form.Mode) //nolint:staticcheck // form.Wiki is deprecated
 	common.RenderMarkup(ctx.Base, ctx.Repo, mode, form.Text, form.Context, form.FilePath)
 }
+
+// PlantUMLDiagram serves the SVG image of a PlantUML diagram of the rendered markdown, the source of the diagram is encoded in the URL
+func PlantUMLDiagram(ctx *context.Context) {
+	if !diagram.IsPlantUMLEnabled() {
+		ctx.NotFound(nil)
+		return
+	}
+	source, err := diagram.DecodePlantUML(ctx.PathParam("encoded"))
+	if err != nil {
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+		return
+	}
+	svg, err := diagram.RenderPlantUML(ctx, source)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.ServerError("RenderPlantUML", err)
+		}
+		return
+	}
+	common.ServeSVGImage(ctx.Base, svg, &httplib.ServeHeaderOptions{
+		CacheDuration: setting.StaticCacheTime,
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package misc
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+	webhook_service "code.gitea.io/gitea/services/webhook"
+)
+
+// WebhookKeys returns the JSON Web Key Set of the keys webhook payloads are signed with
+func WebhookKeys(ctx *context.Context) {
+	if !setting.Webhook.SigningEnabled {
+		ctx.NotFound(nil)
+		return
+	}
+	ctx.JSON(http.StatusOK, webhook_service.SigningKeySet())
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+const (
+	tplReports    templates.TplName = "moderation/reports"
+	tplReportView templates.TplName = "moderation/report_view"
+	tplTemplates  templates.TplName = "moderation/templates"
+)
+
+// ReqModerator requires the signed-in user to be an administrator or a moderator
+func ReqModerator(ctx *context.Context) {
+	if !moderation_service.IsModerator(ctx.Doer) {
+		ctx.HTTPError(http.StatusForbidden)
+	}
+}
+
+// Reports shows the moderation queue
+func Reports(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("moderation.reports")
+	ctx.Data["PageIsModerationReports"] = true
+
+	status := moderation_model.ReportStatus(ctx.FormString("status"))
+	if status != moderation_model.ReportStatusResolved {
+		status = moderation_model.ReportStatusOpen
+	}
+	page := max(ctx.FormInt("page"), 1)
+	reports, count, err := db.FindAndCount[moderation_model.AbuseReport](ctx, moderation_model.FindAbuseReportsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.Admin.NoticePagingNum},
+		Status:      status,
+	})
+	if err != nil {
+		ctx.ServerError("FindAbuseReports", err)
+		return
+	}
+	for _, r := range reports {
+		if err := r.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+
+	ctx.Data["Reports"] = reports
+	ctx.Data["Status"] = status
+	ctx.Data["Total"] = count
+	pager := context.NewPagination(int(count), setting.UI.Admin.NoticePagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tplReports)
+}
+
+func getReport(ctx *context.Context) *moderation_model.AbuseReport {
+	r, err := moderation_model.GetAbuseReportByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetAbuseReportByID", err)
+		}
+		return nil
+	}
+	if err := r.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return nil
+	}
+	return r
+}
+
+// ViewReport shows the report and the form to resolve it
+func ViewReport(ctx *context.Context) {
+	r := getReport(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("moderation.reports")
+	ctx.Data["PageIsModerationReports"] = true
+	ctx.Data["Report"] = r
+
+	if r.IsOpen() {
+		openReports, err := db.Count[moderation_model.AbuseReport](ctx, moderation_model.FindAbuseReportsOptions{
+			Status:      moderation_model.ReportStatusOpen,
+			ContentType: r.ContentType,
+			ContentID:   r.ContentID,
+		})
+		if err != nil {
+			ctx.ServerError("CountAbuseReports", err)
+			return
+		}
+		resolutionTemplates, err := db.Find[moderation_model.ResolutionTemplate](ctx, moderation_model.FindResolutionTemplatesOptions{})
+		if err != nil {
+			ctx.ServerError("FindResolutionTemplates", err)
+			return
+		}
+		ctx.Data["OpenReports"] = openReports
+		ctx.Data["ResolutionTemplates"] = resolutionTemplates
+		ctx.Data["Actions"] = moderation_model.ModerationActions
+	}
+	ctx.HTML(http.StatusOK, tplReportView)
+}
+
+// ResolveReportPost takes the action about the reported content and resolves the open reports of the content
+func ResolveReportPost(ctx *context.Context) {
+	r := getReport(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var err error
+	if templateID := ctx.FormInt64("template_id"); templateID > 0 {
+		err = moderation_service.ResolveReportWithTemplate(ctx, ctx.Doer, r, templateID)
+	} else {
+		err = moderation_service.ResolveReport(ctx, ctx.Doer, r, moderation_model.ModerationAction(ctx.FormString("action")), ctx.FormString("message"))
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(fmt.Sprintf("%s/-/moderation/reports/%d", setting.AppSubURL, r.ID))
+		} else {
+			ctx.ServerError("ResolveReport", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("moderation.reports.resolve_success"))
+	ctx.Redirect(setting.AppSubURL + "/-/moderation/reports")
+}
+
+// UnhideContentPost unhides the content hidden by the resolution of the report
+func UnhideContentPost(ctx *context.Context) {
+	r := getReport(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := moderation_service.UnhideContent(ctx, ctx.Doer, r); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+		} else {
+			ctx.ServerError("UnhideContent", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("moderation.reports.unhide_success"))
+	}
+	ctx.Redirect(fmt.Sprintf("%s/-/moderation/reports/%d", setting.AppSubURL, r.ID))
+}
+
+// Templates shows the resolution templates
+func Templates(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("moderation.templates")
+	ctx.Data["PageIsModerationTemplates"] = true
+
+	resolutionTemplates, err := db.Find[moderation_model.ResolutionTemplate](ctx, moderation_model.FindResolutionTemplatesOptions{})
+	if err != nil {
+		ctx.ServerError("FindResolutionTemplates", err)
+		return
+	}
+
+	ctx.Data["ResolutionTemplates"] = resolutionTemplates
+	ctx.Data["Actions"] = moderation_model.ModerationActions
+	ctx.HTML(http.StatusOK, tplTemplates)
+}
+
+// NewTemplatePost adds a resolution template
+func NewTemplatePost(ctx *context.Context) {
+	t := &moderation_model.ResolutionTemplate{
+		Name:    strings.TrimSpace(ctx.FormString("name")),
+		Action:  moderation_model.ModerationAction(ctx.FormString("action")),
+		Message: strings.TrimSpace(ctx.FormString("message")),
+	}
+	if t.Name == "" {
+		ctx.Flash.Error(ctx.Tr("moderation.templates.name_required"))
+	} else if err := moderation_model.CreateResolutionTemplate(ctx, t); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrAlreadyExist) {
+			ctx.Flash.Error(err.Error())
+		} else {
+			ctx.ServerError("CreateResolutionTemplate", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("moderation.templates.add_success"))
+	}
+	ctx.Redirect(setting.AppSubURL + "/-/moderation/templates")
+}
+
+// DeleteTemplate deletes a resolution template
+func DeleteTemplate(ctx *context.Context) {
+	if err := moderation_model.DeleteResolutionTemplateByID(ctx, ctx.PathParamInt64("id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("DeleteResolutionTemplateByID", err)
+		}
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("moderation.templates.delete_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/moderation/templates")
+}
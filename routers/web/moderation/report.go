@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+const tplReport templates.TplName = "moderation/report"
+
+// NewReport shows the form to report an issue, a comment, a repository or a user
+func NewReport(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("moderation.report.title")
+
+	r, err := moderation_service.PrepareReport(ctx, ctx.Doer, moderation_model.ReportContentType(ctx.FormString("type")), ctx.FormInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrAlreadyExist) {
+			ctx.Data["AlreadyReported"] = true
+			ctx.HTML(http.StatusOK, tplReport)
+		} else if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrInvalidArgument) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("PrepareReport", err)
+		}
+		return
+	}
+	if err := r.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	ctx.Data["Report"] = r
+	ctx.Data["Categories"] = moderation_model.ReportCategories
+	ctx.HTML(http.StatusOK, tplReport)
+}
+
+// NewReportPost files the report into the moderation queue
+func NewReportPost(ctx *context.Context) {
+	contentType := moderation_model.ReportContentType(ctx.FormString("type"))
+	contentID := ctx.FormInt64("id")
+	formLink := fmt.Sprintf("%s/-/moderation/report?type=%s&id=%d", setting.AppSubURL, url.QueryEscape(string(contentType)), contentID)
+
+	r, err := moderation_service.ReportContent(ctx, ctx.Doer, contentType, contentID, moderation_model.ReportCategory(ctx.FormString("category")), ctx.FormString("remarks"))
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.NotFound(err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.Redirect(formLink)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(formLink)
+		default:
+			ctx.ServerError("ReportContent", err)
+		}
+		return
+	}
+	if err := r.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("moderation.report.success"))
+	if r.ContentLink != "" {
+		ctx.Redirect(r.ContentLink)
+		return
+	}
+	ctx.Redirect(setting.AppSubURL + "/")
+}
@@ -343,10 +343,11 @@ func ViewProject(ctx *context.Context) {
 	assigneeID := ctx.FormString("assignee")
 
 	opts := issues_model.IssuesOptions{
-		LabelIDs:   preparedLabelFilter.SelectedLabelIDs,
-		AssigneeID: assigneeID,
-		Owner:      project.Owner,
-		Doer:       ctx.Doer,
+		HideModerated: true,
+		LabelIDs:      preparedLabelFilter.SelectedLabelIDs,
+		AssigneeID:    assigneeID,
+		Owner:         project.Owner,
+		Doer:          ctx.Doer,
 	}
 
 	issuesMap, err := project_service.LoadIssuesFromProject(ctx, project, &opts)
@@ -382,8 +383,9 @@ func ViewProject(ctx *context.Context) {
 
 			if len(referencedIDs) > 0 {
 				if linkedPrs, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
-					IssueIDs: referencedIDs,
-					IsPull:   optional.Some(true),
+					HideModerated: true,
+					IssueIDs:      referencedIDs,
+					IsPull:        optional.Some(true),
 				}); err == nil {
 					linkedPrsMap[issue.ID] = linkedPrs
 				}
@@ -23,6 +23,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	user_setting "code.gitea.io/gitea/routers/web/user/setting"
+	admin_service "code.gitea.io/gitea/services/admin"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	org_service "code.gitea.io/gitea/services/org"
@@ -45,6 +46,8 @@ func Settings(ctx *context.Context) {
 	ctx.Data["PageIsSettingsOptions"] = true
 	ctx.Data["CurrentVisibility"] = ctx.Org.Organization.Visibility
 	ctx.Data["RepoAdminChangeTeamAccess"] = ctx.Org.Organization.RepoAdminChangeTeamAccess
+	ctx.Data["DisallowImpersonation"] = ctx.Org.Organization.DisallowImpersonation
+	ctx.Data["EnableImpersonation"] = setting.Admin.EnableImpersonation
 	ctx.Data["ContextUser"] = ctx.ContextUser
 
 	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
@@ -85,6 +88,9 @@ func SettingsPost(ctx *context.Context) {
 		Location:                  optional.Some(form.Location),
 		RepoAdminChangeTeamAccess: optional.Some(form.RepoAdminChangeTeamAccess),
 	}
+	if setting.Admin.EnableImpersonation {
+		opts.DisallowImpersonation = optional.Some(form.DisallowImpersonation)
+	}
 	if ctx.Doer.IsAdmin {
 		opts.MaxRepoCreation = optional.Some(form.MaxRepoCreation)
 	}
@@ -128,6 +134,16 @@ func SettingsDeleteOrgPost(ctx *context.Context) {
 		return
 	}
 
+	if admin_service.IsOrgDeletionApprovalRequired(ctx.Doer) {
+		if _, err := admin_service.RequestDeleteOrg(ctx, ctx.Doer, ctx.Org.Organization); err != nil {
+			ctx.ServerError("RequestDeleteOrg", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.JSONRedirect(ctx.Org.OrgLink + "/settings")
+		return
+	}
+
 	if err := org_service.DeleteOrganization(ctx, ctx.Org.Organization, false /* no purge */); err != nil {
 		if repo_model.IsErrUserOwnRepos(err) {
 			ctx.JSONError(ctx.Tr("form.org_still_own_repo"))
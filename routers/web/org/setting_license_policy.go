@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+	licensecheck_service "code.gitea.io/gitea/services/licensecheck"
+)
+
+const tplSettingsLicensePolicy templates.TplName = "org/settings/license_policy"
+
+// LicensePolicy render the license policy settings page of an organization
+func LicensePolicy(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.license_policy")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsLicensePolicy"] = true
+
+	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+		ctx.ServerError("RenderUserOrgHeader", err)
+		return
+	}
+
+	p, err := organization.GetLicensePolicy(ctx, ctx.Org.Organization.ID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetLicensePolicy", err)
+		return
+	}
+	if p != nil {
+		ctx.Data["DeniedLicenses"] = strings.Join(p.DeniedLicenses, "\n")
+	}
+
+	ctx.HTML(http.StatusOK, tplSettingsLicensePolicy)
+}
+
+// LicensePolicyPost response for updating the license policy of an organization
+func LicensePolicyPost(ctx *context.Context) {
+	orgID := ctx.Org.Organization.ID
+	denied := strings.Fields(ctx.FormString("denied_licenses"))
+	if len(denied) == 0 {
+		if err := organization.DeleteLicensePolicy(ctx, orgID); err != nil {
+			ctx.ServerError("DeleteLicensePolicy", err)
+			return
+		}
+	} else {
+		if _, err := organization.SetLicensePolicy(ctx, orgID, denied); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(err.Error())
+				ctx.Redirect(ctx.Org.OrgLink + "/settings/license_policy")
+			} else {
+				ctx.ServerError("SetLicensePolicy", err)
+			}
+			return
+		}
+		if err := licensecheck_service.CheckOrgRepositories(ctx, orgID); err != nil {
+			ctx.ServerError("CheckOrgRepositories", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.license_policy.update_success"))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/license_policy")
+}
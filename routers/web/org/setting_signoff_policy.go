@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplSettingsSignOffPolicy templates.TplName = "org/settings/signoff_policy"
+
+// SignOffPolicy render the sign-off policy settings page of an organization
+func SignOffPolicy(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.signoff_policy")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsSignOffPolicy"] = true
+
+	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+		ctx.ServerError("RenderUserOrgHeader", err)
+		return
+	}
+
+	p, err := organization.GetSignOffPolicy(ctx, ctx.Org.Organization.ID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetSignOffPolicy", err)
+		return
+	}
+	ctx.Data["RequireSignOff"] = p != nil
+	if p != nil {
+		ctx.Data["ExemptUsers"] = strings.Join(p.ExemptUsers, ",")
+	}
+
+	ctx.HTML(http.StatusOK, tplSettingsSignOffPolicy)
+}
+
+// SignOffPolicyPost response for updating the sign-off policy of an organization
+func SignOffPolicyPost(ctx *context.Context) {
+	orgID := ctx.Org.Organization.ID
+	if ctx.FormBool("require_signoff") {
+		if _, err := organization.SetSignOffPolicy(ctx, orgID, util.SplitTrimSpace(ctx.FormString("exempt_users"), ",")); err != nil {
+			ctx.ServerError("SetSignOffPolicy", err)
+			return
+		}
+	} else if err := organization.DeleteSignOffPolicy(ctx, orgID); err != nil {
+		ctx.ServerError("DeleteSignOffPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.signoff_policy.update_success"))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/signoff_policy")
+}
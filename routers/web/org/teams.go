@@ -22,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
@@ -81,6 +82,8 @@ func TeamsAction(ctx *context.Context) {
 		if err != nil {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
+			} else if errors.Is(err, org_model.ErrTeamMemberInherited) {
+				ctx.Flash.Error(ctx.Tr("org.teams.inherited_member_cannot_be_removed"))
 			} else {
 				log.Error("Action(%s): %v", ctx.PathParam("action"), err)
 				ctx.JSON(http.StatusOK, map[string]any{
@@ -108,6 +111,8 @@ func TeamsAction(ctx *context.Context) {
 		if err != nil {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
+			} else if errors.Is(err, org_model.ErrTeamMemberInherited) {
+				ctx.Flash.Error(ctx.Tr("org.teams.inherited_member_cannot_be_removed"))
 			} else {
 				log.Error("Action(%s): %v", ctx.PathParam("action"), err)
 				ctx.JSON(http.StatusOK, map[string]any{
@@ -129,7 +134,7 @@ func TeamsAction(ctx *context.Context) {
 		u, err = user_model.GetUserByName(ctx, uname)
 		if err != nil {
 			if user_model.IsErrUserNotExist(err) {
-				if setting.MailService != nil && user_model.ValidateEmail(uname) == nil {
+				if setting.GetMailService() != nil && user_model.ValidateEmail(uname) == nil {
 					if err := org_service.CreateTeamInvite(ctx, ctx.Doer, ctx.Org.Team, uname); err != nil {
 						if org_model.IsErrTeamInviteAlreadyExist(err) {
 							ctx.Flash.Error(ctx.Tr("form.duplicate_invite_to_team"))
@@ -279,9 +284,39 @@ func NewTeam(ctx *context.Context) {
 	ctx.Data["PageIsOrgTeamsNew"] = true
 	ctx.Data["Team"] = &org_model.Team{}
 	ctx.Data["Units"] = unit_model.Units
+	if !prepareParentTeamCandidates(ctx, &org_model.Team{OrgID: ctx.Org.Organization.ID}) {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplTeamNew)
 }
 
+// prepareTeamTree loads the parent and the child teams of the team for the sidebar
+func prepareTeamTree(ctx *context.Context) bool {
+	parent, err := ctx.Org.Team.LoadParent(ctx)
+	if err != nil {
+		ctx.ServerError("LoadParent", err)
+		return false
+	}
+	children, err := org_model.GetTeamChildren(ctx, ctx.Org.Team.ID)
+	if err != nil {
+		ctx.ServerError("GetTeamChildren", err)
+		return false
+	}
+	ctx.Data["ParentTeam"] = parent
+	ctx.Data["ChildTeams"] = children
+	return true
+}
+
+func prepareParentTeamCandidates(ctx *context.Context, t *org_model.Team) bool {
+	candidates, err := org_model.FindTeamParentCandidates(ctx, t)
+	if err != nil {
+		ctx.ServerError("FindTeamParentCandidates", err)
+		return false
+	}
+	ctx.Data["ParentTeamCandidates"] = candidates
+	return true
+}
+
 // FIXME: TEAM-UNIT-PERMISSION: this design is not right, when a new unit is added in the future,
 // The existing teams won't inherit the correct admin permission for the new unit.
 // The full history is like this:
@@ -335,6 +370,7 @@ func NewTeamPost(ctx *context.Context) {
 		AccessMode:              teamPermission,
 		IncludesAllRepositories: includesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
+		ParentID:                form.ParentTeam,
 	}
 
 	units := make([]*org_model.TeamUnit, 0, len(unitPerms))
@@ -352,6 +388,9 @@ func NewTeamPost(ctx *context.Context) {
 	ctx.Data["PageIsOrgTeamsNew"] = true
 	ctx.Data["Units"] = unit_model.Units
 	ctx.Data["Team"] = t
+	if !prepareParentTeamCandidates(ctx, &org_model.Team{OrgID: t.OrgID}) {
+		return
+	}
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplTeamNew)
@@ -364,10 +403,12 @@ func NewTeamPost(ctx *context.Context) {
 	}
 
 	if err := org_service.NewTeam(ctx, t); err != nil {
-		ctx.Data["Err_TeamName"] = true
 		switch {
 		case org_model.IsErrTeamAlreadyExist(err):
+			ctx.Data["Err_TeamName"] = true
 			ctx.RenderWithErr(ctx.Tr("form.team_name_been_taken"), tplTeamNew, &form)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.RenderWithErr(ctx.Tr("org.teams.invalid_parent_team"), tplTeamNew, &form)
 		default:
 			ctx.ServerError("NewTeam", err)
 		}
@@ -387,6 +428,9 @@ func TeamMembers(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Org.Team.Name
 	ctx.Data["PageIsOrgTeams"] = true
 	ctx.Data["PageIsOrgTeamMembers"] = true
+	if !prepareTeamTree(ctx) {
+		return
+	}
 
 	if err := ctx.Org.Team.LoadMembers(ctx); err != nil {
 		ctx.ServerError("GetMembers", err)
@@ -400,7 +444,7 @@ func TeamMembers(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Invites"] = invites
-	ctx.Data["IsEmailInviteEnabled"] = setting.MailService != nil
+	ctx.Data["IsEmailInviteEnabled"] = setting.GetMailService() != nil
 
 	ctx.HTML(http.StatusOK, tplTeamMembers)
 }
@@ -415,6 +459,9 @@ func TeamRepositories(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Org.Team.Name
 	ctx.Data["PageIsOrgTeams"] = true
 	ctx.Data["PageIsOrgTeamRepos"] = true
+	if !prepareTeamTree(ctx) {
+		return
+	}
 
 	repos, err := repo_model.GetTeamRepositories(ctx, &repo_model.SearchTeamRepoOptions{
 		TeamID: ctx.Org.Team.ID,
@@ -484,6 +531,9 @@ func EditTeam(ctx *context.Context) {
 	}
 	ctx.Data["Team"] = ctx.Org.Team
 	ctx.Data["Units"] = unit_model.Units
+	if !prepareParentTeamCandidates(ctx, ctx.Org.Team) {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplTeamNew)
 }
 
@@ -501,6 +551,9 @@ func EditTeamPost(ctx *context.Context) {
 	ctx.Data["PageIsOrgTeams"] = true
 	ctx.Data["Team"] = t
 	ctx.Data["Units"] = unit_model.Units
+	if !prepareParentTeamCandidates(ctx, t) {
+		return
+	}
 
 	if !t.IsOwnerTeam() {
 		t.Name = form.TeamName
@@ -540,6 +593,17 @@ func EditTeamPost(ctx *context.Context) {
 		return
 	}
 
+	if !t.IsOwnerTeam() {
+		if err := org_service.MoveTeam(ctx, t, form.ParentTeam); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.RenderWithErr(ctx.Tr("org.teams.invalid_parent_team"), tplTeamNew, &form)
+			} else {
+				ctx.ServerError("MoveTeam", err)
+			}
+			return
+		}
+	}
+
 	if err := org_service.UpdateTeam(ctx, t, isAuthChanged, isIncludeAllChanged); err != nil {
 		ctx.Data["Err_TeamName"] = true
 		switch {
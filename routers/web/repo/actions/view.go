@@ -119,6 +119,7 @@ type ViewResponse struct {
 			Title             string        `json:"title"`
 			TitleHTML         template.HTML `json:"titleHTML"`
 			Status            string        `json:"status"`
+			ErrorMessage      string        `json:"errorMessage"`
 			CanCancel         bool          `json:"canCancel"`
 			CanApprove        bool          `json:"canApprove"` // the run needs an approval and the doer has permission to approve
 			CanRerun          bool          `json:"canRerun"`
@@ -236,7 +237,8 @@ func ViewPost(ctx *context_module.Context) {
 	resp.State.Run.Link = run.Link()
 	resp.State.Run.CanCancel = !run.Status.IsDone() && ctx.Repo.CanWrite(unit.TypeActions)
 	resp.State.Run.CanApprove = run.NeedApproval && ctx.Repo.CanWrite(unit.TypeActions)
-	resp.State.Run.CanRerun = run.Status.IsDone() && ctx.Repo.CanWrite(unit.TypeActions)
+	// a run which failed before it started can't be rerun, its jobs would skip the failed checks
+	resp.State.Run.CanRerun = run.Status.IsDone() && run.ErrorMessage == "" && ctx.Repo.CanWrite(unit.TypeActions)
 	resp.State.Run.CanDeleteArtifact = run.Status.IsDone() && ctx.Repo.CanWrite(unit.TypeActions)
 	resp.State.Run.Done = run.Status.IsDone()
 	resp.State.Run.WorkflowID = run.WorkflowID
@@ -244,6 +246,7 @@ func ViewPost(ctx *context_module.Context) {
 	resp.State.Run.IsSchedule = run.IsSchedule()
 	resp.State.Run.Jobs = make([]*ViewJob, 0, len(jobs)) // marshal to '[]' instead fo 'null' in json
 	resp.State.Run.Status = run.Status.String()
+	resp.State.Run.ErrorMessage = run.ErrorMessage
 	for _, v := range jobs {
 		resp.State.Run.Jobs = append(resp.State.Run.Jobs, &ViewJob{
 			ID:       v.ID,
@@ -412,6 +415,11 @@ func Rerun(ctx *context_module.Context) {
 		return
 	}
 
+	if run.ErrorMessage != "" {
+		ctx.JSONError(ctx.Locale.Tr("actions.runs.rerun_failed_to_start"))
+		return
+	}
+
 	// can not rerun job when workflow is disabled
 	cfgUnit := ctx.Repo.Repository.MustGetUnit(ctx, unit.TypeActions)
 	cfg := cfgUnit.ActionsConfig()
@@ -442,6 +450,12 @@ func Rerun(ctx *context_module.Context) {
 		return
 	}
 
+	// the deployments of the rerun jobs have to be reviewed again
+	if err := actions_model.DeleteDeploymentReviewsOfRun(ctx, run.ID); err != nil {
+		ctx.ServerError("DeleteDeploymentReviewsOfRun", err)
+		return
+	}
+
 	if jobIndexStr == "" { // rerun all jobs
 		for _, j := range jobs {
 			// if the job has needs, it should be set to "blocked" status to wait for other jobs
@@ -451,6 +465,7 @@ func Rerun(ctx *context_module.Context) {
 				return
 			}
 		}
+		emitRerunJobs(run.ID)
 		ctx.JSONOK()
 		return
 	}
@@ -465,10 +480,18 @@ func Rerun(ctx *context_module.Context) {
 			return
 		}
 	}
+	emitRerunJobs(run.ID)
 
 	ctx.JSONOK()
 }
 
+// emitRerunJobs lets the job emitter start the rerun jobs deploying to environments, they are blocked until they pass the protection rules
+func emitRerunJobs(runID int64) {
+	if err := actions_service.EmitJobsIfReady(runID); err != nil {
+		log.Error("Emit ready jobs of run %d: %v", runID, err)
+	}
+}
+
 func rerunJob(ctx *context_module.Context, job *actions_model.ActionRunJob, shouldBlock bool) error {
 	status := job.Status
 	if !status.IsDone() || !job.Run.Status.IsDone() {
@@ -477,14 +500,15 @@ func rerunJob(ctx *context_module.Context, job *actions_model.ActionRunJob, shou
 
 	job.TaskID = 0
 	job.Status = actions_model.StatusWaiting
-	if shouldBlock {
+	if shouldBlock || job.Environment != "" {
 		job.Status = actions_model.StatusBlocked
 	}
 	job.Started = 0
 	job.Stopped = 0
+	job.WaitTimerUntil = 0
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, "task_id", "status", "started", "stopped")
+		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, "task_id", "status", "started", "stopped", "wait_timer_until")
 		return err
 	}); err != nil {
 		return err
@@ -566,6 +590,7 @@ func Cancel(ctx *context_module.Context) {
 		job := updatedjobs[0]
 		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
 	}
+	actions_service.ReleaseConcurrencyGroups(ctx, updatedjobs)
 	ctx.JSONOK()
 }
 
@@ -588,7 +613,9 @@ func Approve(ctx *context_module.Context) {
 			return err
 		}
 		for _, job := range jobs {
-			if len(job.Needs) == 0 && job.Status.IsBlocked() {
+			// the jobs of concurrency groups are started by the job emitter once their groups are free
+			// so are the jobs deploying to environments, which may be protected
+			if len(job.Needs) == 0 && job.Status.IsBlocked() && run.ConcurrencyGroup == "" && job.ConcurrencyGroup == "" && job.Environment == "" {
 				job.Status = actions_model.StatusWaiting
 				n, err := actions_model.UpdateRunJob(ctx, job, nil, "status")
 				if err != nil {
@@ -611,6 +638,9 @@ func Approve(ctx *context_module.Context) {
 		job := updatedjobs[0]
 		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
 	}
+	if err := actions_service.EmitJobsIfReady(run.ID); err != nil {
+		log.Error("Emit ready jobs of run %d: %v", run.ID, err)
+	}
 
 	for _, job := range updatedjobs {
 		_ = job.LoadAttributes(ctx)
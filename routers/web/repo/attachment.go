@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	access_model "code.gitea.io/gitea/models/perm/access"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/httpcache"
 	"code.gitea.io/gitea/modules/log"
@@ -55,6 +56,10 @@ func uploadAttachment(ctx *context.Context, repoID int64, allowedTypes string) {
 			ctx.HTTPError(http.StatusBadRequest, err.Error())
 			return
 		}
+		if quota_model.IsErrQuotaExceeded(err) {
+			ctx.HTTPError(http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
 		ctx.HTTPError(http.StatusInternalServerError, fmt.Sprintf("NewAttachment: %v", err))
 		return
 	}
@@ -122,6 +127,12 @@ func ServeAttachment(ctx *context.Context, uuid string) {
 		}
 	}
 
+	// malware has been found in quarantined attachments, only admins may download them for the review
+	if attach.IsQuarantined && !(ctx.IsSigned && ctx.Doer.IsAdmin) {
+		ctx.HTTPError(http.StatusForbidden, "attachment has been quarantined")
+		return
+	}
+
 	if err := attach.IncreaseDownloadCount(ctx); err != nil {
 		ctx.ServerError("IncreaseDownloadCount", err)
 		return
@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+
+	"xorm.io/builder"
+)
+
+const (
+	tplDependencies templates.TplName = "repo/dependencies"
+	tplDependents   templates.TplName = "repo/dependents"
+)
+
+// Dependencies render the page to show the dependency graph of the default branch
+func Dependencies(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.dependencies")
+	ctx.Data["PageIsDependencies"] = true
+
+	status, err := repo_model.GetIndexerStatus(ctx, ctx.Repo.Repository, repo_model.RepoIndexerTypeDependency)
+	if err != nil {
+		ctx.ServerError("GetIndexerStatus", err)
+		return
+	}
+	ctx.Data["IsGenerating"] = status.CommitSha == ""
+
+	ecosystems, err := repo_model.GetRepoDependencyEcosystems(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetRepoDependencyEcosystems", err)
+		return
+	}
+
+	page := max(ctx.FormInt("page"), 1)
+	ecosystem, keyword, directOnly := ctx.FormTrim("ecosystem"), ctx.FormTrim("q"), ctx.FormBool("direct")
+	deps, total, err := db.FindAndCount[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.RepoSearchPagingNum},
+		RepoID:      ctx.Repo.Repository.ID,
+		Ecosystem:   ecosystem,
+		Keyword:     strings.ToLower(keyword),
+		DirectOnly:  directOnly,
+	})
+	if err != nil {
+		ctx.ServerError("FindRepoDependencies", err)
+		return
+	}
+
+	// mark the dependencies whose licenses are denied by the license policy of the organization
+	deniedDeps := make(container.Set[int64])
+	if ctx.Repo.Owner.IsOrganization() {
+		policy, err := organization.GetLicensePolicy(ctx, ctx.Repo.Owner.ID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			ctx.ServerError("GetLicensePolicy", err)
+			return
+		}
+		if policy != nil {
+			denied := policy.DeniedLicenseSet()
+			for _, dep := range deps {
+				if sbom.IsLicenseDenied(dep.License, denied) {
+					deniedDeps.Add(dep.ID)
+				}
+			}
+		}
+	}
+
+	ctx.Data["Ecosystems"] = ecosystems
+	ctx.Data["Ecosystem"] = ecosystem
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["DirectOnly"] = directOnly
+	ctx.Data["Dependencies"] = deps
+	ctx.Data["DeniedDependencies"] = deniedDeps
+	// the dependents can be looked up among the repositories of an organization
+	ctx.Data["CanListDependents"] = ctx.Repo.Owner.IsOrganization()
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDependencies)
+}
+
+// Dependents render the page to show the repositories of the organization which depend on a package
+func Dependents(ctx *context.Context) {
+	ecosystem, name := ctx.FormTrim("ecosystem"), ctx.FormTrim("name")
+	if !ctx.Repo.Owner.IsOrganization() || ecosystem == "" || name == "" {
+		ctx.NotFound(nil)
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.dependencies.dependents")
+	ctx.Data["PageIsDependencies"] = true
+
+	page := max(ctx.FormInt("page"), 1)
+	deps, total, err := db.FindAndCount[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.RepoSearchPagingNum},
+		RepoCond: builder.And(
+			builder.Eq{"`repository`.owner_id": ctx.Repo.Owner.ID},
+			repo_model.AccessibleRepositoryCondition(ctx.Doer, unit.TypeCode),
+		),
+		Ecosystem: ecosystem,
+		LowerName: strings.ToLower(sbom.NormalizeName(sbom.Ecosystem(ecosystem), name)),
+	})
+	if err != nil {
+		ctx.ServerError("FindRepoDependencies", err)
+		return
+	}
+	if err := repo_model.RepoDependencyList(deps).LoadRepos(ctx); err != nil {
+		ctx.ServerError("LoadRepos", err)
+		return
+	}
+
+	ctx.Data["Ecosystem"] = ecosystem
+	ctx.Data["PackageName"] = name
+	ctx.Data["Dependencies"] = deps
+
+	pager := context.NewPagination(int(total), setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDependents)
+}
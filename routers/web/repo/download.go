@@ -5,6 +5,8 @@
 package repo
 
 import (
+	"errors"
+	"net/http"
 	"time"
 
 	git_model "code.gitea.io/gitea/models/git"
@@ -14,6 +16,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
@@ -51,6 +54,9 @@ func ServeBlobOrLFS(ctx *context.Context, blob *git.Blob, lastModified *time.Tim
 		if httpcache.HandleGenericETagCache(ctx.Req, ctx.Resp, `"`+pointer.Oid+`"`) {
 			return nil
 		}
+		if err := git_model.UpdateLFSObjectAccessed(ctx, pointer.Oid); err != nil {
+			log.Error("ServeBlobOrLFS: UpdateLFSObjectAccessed: %v", err)
+		}
 
 		if setting.LFS.Storage.ServeDirect() {
 			// If we have a signed url (S3, object storage, blob storage), redirect to this directly.
@@ -126,6 +132,18 @@ func SingleDownloadOrLFS(ctx *context.Context) {
 		return
 	}
 
+	// the files like diagrams are embedded as images in the rendered documents
+	if ctx.FormString("format") == "svg" {
+		if err := common.ServeBlobAsSVG(ctx.Base, ctx.Repo.Repository, ctx.Repo.TreePath, blob, lastModified); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.HTTPError(http.StatusBadRequest, err.Error())
+			} else {
+				ctx.ServerError("ServeBlobAsSVG", err)
+			}
+		}
+		return
+	}
+
 	if err := ServeBlobOrLFS(ctx, blob, lastModified); err != nil {
 		ctx.ServerError("ServeBlobOrLFS", err)
 	}
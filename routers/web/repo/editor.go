@@ -25,6 +25,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/context/upload"
+	dco_service "code.gitea.io/gitea/services/dco"
 	"code.gitea.io/gitea/services/forms"
 	files_service "code.gitea.io/gitea/services/repository/files"
 )
@@ -72,6 +73,14 @@ func prepareEditorCommitFormOptions(ctx *context.Context, editorAction string) *
 	ctx.Data["TreePath"] = ctx.Repo.TreePath
 	ctx.Data["CommitFormOptions"] = commitFormOptions
 
+	// the pull requests of the repository require the commits to be signed off
+	signOffSettings, err := dco_service.GetSettings(ctx, commitFormOptions.TargetRepo)
+	if err != nil {
+		ctx.ServerError("GetSettings", err)
+		return nil
+	}
+	ctx.Data["SignOffRequired"] = signOffSettings.Required
+
 	// for online editor
 	ctx.Data["PreviewableExtensions"] = strings.Join(markup.PreviewableExtensions(), ",")
 	ctx.Data["LineWrapExtensions"] = strings.Join(setting.Repository.Editor.LineWrapExtensions, ",")
@@ -25,12 +25,14 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 	repo_service "code.gitea.io/gitea/services/repository"
 
@@ -173,6 +175,13 @@ func httpBase(ctx *context.Context) *serviceHandler {
 			ctx.PlainText(http.StatusForbidden, "Your account is disabled.")
 			return nil
 		}
+		if overdue, err := user_model.IsTermsAcceptanceOverdue(ctx, ctx.Doer); err != nil {
+			ctx.ServerError("IsTermsAcceptanceOverdue", err)
+			return nil
+		} else if overdue {
+			ctx.PlainText(http.StatusForbidden, "You must accept the current terms and policies. Accept them at: "+setting.AppURL+"user/terms")
+			return nil
+		}
 
 		environ = []string{
 			repo_module.EnvRepoUsername + "=" + username,
@@ -196,11 +205,18 @@ func httpBase(ctx *context.Context) *serviceHandler {
 					return nil
 				}
 				if task.RepoID != repo.ID {
-					ctx.PlainText(http.StatusForbidden, "User permission denied")
-					return nil
-				}
-
-				if task.IsForkPullRequest {
+					// a job calling a reusable workflow of another repository may only read that repository
+					canRead, err := actions_service.CanTaskReadRepository(ctx, task, repo)
+					if err != nil {
+						ctx.ServerError("CanTaskReadRepository", err)
+						return nil
+					}
+					if !canRead || accessMode > perm.AccessModeRead {
+						ctx.PlainText(http.StatusForbidden, "User permission denied")
+						return nil
+					}
+					environ = append(environ, fmt.Sprintf("%s=%d", repo_module.EnvActionPerm, perm.AccessModeRead))
+				} else if task.IsForkPullRequest {
 					if accessMode > perm.AccessModeRead {
 						ctx.PlainText(http.StatusForbidden, "User permission denied")
 						return nil
@@ -33,6 +33,8 @@ import (
 	"code.gitea.io/gitea/services/convert"
 	"code.gitea.io/gitea/services/forms"
 	issue_service "code.gitea.io/gitea/services/issue"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+	spamdetection_service "code.gitea.io/gitea/services/spamdetection"
 )
 
 const (
@@ -199,6 +201,27 @@ func GetActionIssue(ctx *context.Context) *issues_model.Issue {
 	return issue
 }
 
+// MustNotBeHiddenIssue responds not found for the issue or the pull request hidden by a moderator,
+// the moderators and the administrators of the repository can still see it
+func MustNotBeHiddenIssue(ctx *context.Context) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		// the handlers respond not found if the issue doesn't exist
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return
+	}
+	if moderation_service.IsIssueHiddenFrom(issue, ctx.Doer, &ctx.Repo.Permission) {
+		ctx.NotFound(nil)
+	}
+}
+
+// hideModeratedContent checks whether the comments hidden by a moderator are left out for the doer
+func hideModeratedContent(ctx *context.Context) bool {
+	return !moderation_service.CanSeeHiddenContent(ctx.Doer, &ctx.Repo.Permission)
+}
+
 func checkIssueRights(ctx *context.Context, issue *issues_model.Issue) {
 	if issue.IsPull && !ctx.Repo.CanRead(unit.TypePullRequests) ||
 		!issue.IsPull && !ctx.Repo.CanRead(unit.TypeIssues) {
@@ -295,6 +318,18 @@ func UpdateIssueTitle(ctx *context.Context) {
 		return
 	}
 
+	if held, err := spamdetection_service.HoldIssueEditIfSuspicious(ctx, ctx.Doer, issue, title, issue.Content); err != nil {
+		ctx.ServerError("HoldIssueEditIfSuspicious", err)
+		return
+	} else if held {
+		// the page is reloaded after the title has been saved
+		ctx.Flash.Info(ctx.Tr("repo.issues.edit.held"))
+		ctx.JSON(http.StatusOK, map[string]any{
+			"title": issue.Title,
+		})
+		return
+	}
+
 	if err := issue_service.ChangeTitle(ctx, issue, ctx.Doer, title); err != nil {
 		ctx.ServerError("ChangeTitle", err)
 		return
@@ -341,6 +376,14 @@ func UpdateIssueContent(ctx *context.Context) {
 		return
 	}
 
+	if held, err := spamdetection_service.HoldIssueEditIfSuspicious(ctx, ctx.Doer, issue, issue.Title, ctx.Req.FormValue("content")); err != nil {
+		ctx.ServerError("HoldIssueEditIfSuspicious", err)
+		return
+	} else if held {
+		ctx.JSONError(ctx.Tr("repo.issues.edit.held"))
+		return
+	}
+
 	if err := issue_service.ChangeContent(ctx, issue, ctx.Doer, ctx.Req.FormValue("content"), ctx.FormInt("content_version")); err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
 			ctx.JSONError(ctx.Tr("repo.issues.edit.blocked_user"))
@@ -406,6 +449,46 @@ func UpdateIssueDeadline(ctx *context.Context) {
 	ctx.JSONRedirect("")
 }
 
+// UpdateIssueFieldValues change the values of the custom fields of an issue
+func UpdateIssueFieldValues(ctx *context.Context) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.HTTPError(http.StatusInternalServerError, "GetIssueByIndex", err.Error())
+		}
+		return
+	}
+
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.HTTPError(http.StatusForbidden, "", "Not repo writer")
+		return
+	}
+
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetIssueFieldsOfRepo", err)
+		return
+	}
+	// the form contains all the fields, an empty value removes the value of a field
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.Name] = ctx.FormString(fmt.Sprintf("field-%d", f.ID))
+	}
+
+	if err := issue_service.SetIssueFieldValues(ctx, issue, values); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("SetIssueFieldValues", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect("")
+}
+
 // UpdateIssueMilestone change issue's milestone
 func UpdateIssueMilestone(ctx *context.Context) {
 	issues := getActionIssues(ctx)
@@ -28,6 +28,7 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	issue_service "code.gitea.io/gitea/services/issue"
 	pull_service "code.gitea.io/gitea/services/pull"
+	spamdetection_service "code.gitea.io/gitea/services/spamdetection"
 )
 
 // NewComment create a comment for issue
@@ -201,6 +202,14 @@ func NewComment(ctx *context.Context) {
 		return
 	}
 
+	if held, err := spamdetection_service.HoldCommentIfSuspicious(ctx, ctx.Doer, issue, form.Content, attachments); err != nil {
+		ctx.ServerError("HoldCommentIfSuspicious", err)
+		return
+	} else if held {
+		ctx.Flash.Info(ctx.Tr("repo.issues.comment.held"))
+		return
+	}
+
 	comment, err := issue_service.CreateIssueComment(ctx, ctx.Doer, ctx.Repo.Repository, issue, form.Content, attachments)
 	if err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
@@ -227,7 +236,7 @@ func UpdateCommentContent(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.NotFound(issues_model.ErrCommentNotExist{})
 		return
 	}
@@ -249,6 +258,14 @@ func UpdateCommentContent(ctx *context.Context) {
 		return
 	}
 
+	if held, err := spamdetection_service.HoldCommentEditIfSuspicious(ctx, ctx.Doer, comment, newContent); err != nil {
+		ctx.ServerError("HoldCommentEditIfSuspicious", err)
+		return
+	} else if held {
+		ctx.JSONError(ctx.Tr("repo.issues.edit.held"))
+		return
+	}
+
 	if newContent != comment.Content {
 		// allow to save empty content
 		oldContent := comment.Content
@@ -315,7 +332,7 @@ func DeleteComment(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.NotFound(issues_model.ErrCommentNotExist{})
 		return
 	}
@@ -350,7 +367,7 @@ func ChangeCommentReaction(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.NotFound(issues_model.ErrCommentNotExist{})
 		return
 	}
@@ -455,7 +472,7 @@ func GetCommentAttachments(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.NotFound(issues_model.ErrCommentNotExist{})
 		return
 	}
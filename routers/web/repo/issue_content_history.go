@@ -137,6 +137,10 @@ func GetContentHistoryDetail(ctx *context.Context) {
 			log.Error("can not get comment for issue content history %v. err=%v", historyID, err)
 			return
 		}
+		if comment.IsHidden && hideModeratedContent(ctx) {
+			ctx.NotFound(issues_model.ErrCommentNotExist{})
+			return
+		}
 	}
 
 	// get the previous history revision (if exists)
@@ -216,7 +220,7 @@ func SoftDeleteContentHistory(ctx *context.Context) {
 			log.Error("can not get comment for issue content history %v. err=%v", historyID, err)
 			return
 		}
-		if comment.IssueID != issue.ID {
+		if comment.IssueID != issue.ID || (comment.IsHidden && hideModeratedContent(ctx)) {
 			ctx.NotFound(issues_model.ErrCommentNotExist{})
 			return
 		}
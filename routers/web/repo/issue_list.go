@@ -5,6 +5,7 @@ package repo
 
 import (
 	"bytes"
+	"errors"
 	"maps"
 	"net/http"
 	"slices"
@@ -536,9 +537,19 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 
 	prepareIssueFilterExclusiveOrderScopes(ctx, preparedLabelFilter.AllLabels)
 
+	fieldValues, err := issue_service.ParseIssueFieldFilters(ctx, repo, ctx.FormStrings("field"))
+	if err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("ParseIssueFieldFilters", err)
+			return
+		}
+		ctx.Flash.Error(err.Error(), true)
+	}
+
 	var keywordMatchedIssueIDs []int64
 	var issueStats *issues_model.IssueStats
 	statsOpts := &issues_model.IssuesOptions{
+		HideModerated:     true,
 		RepoIDs:           []int64{repo.ID},
 		LabelIDs:          preparedLabelFilter.SelectedLabelIDs,
 		MilestoneIDs:      mileIDs,
@@ -550,6 +561,7 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 		ReviewedID:        reviewedID,
 		IsPull:            isPullOption,
 		IssueIDs:          nil,
+		FieldValues:       fieldValues,
 	}
 	if keyword != "" {
 		keywordMatchedIssueIDs, _, err = issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, statsOpts))
@@ -618,6 +630,7 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 		// Or the keyword is empty, it also needs to usd db indexer.
 		// In either case, no need to use keyword anymore
 		searchResult, err := db_indexer.GetIndexer().FindWithIssueOptions(ctx, &issues_model.IssuesOptions{
+			HideModerated: true,
 			Paginator: &db.ListOptions{
 				Page:     pager.Paginater.Current(),
 				PageSize: setting.UI.IssuePagingNum,
@@ -635,6 +648,7 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 			LabelIDs:          preparedLabelFilter.SelectedLabelIDs,
 			SortType:          sortType,
 			IssueIDs:          keywordMatchedIssueIDs,
+			FieldValues:       fieldValues,
 		})
 		if err != nil {
 			ctx.ServerError("DBIndexer.Search", err)
@@ -791,6 +805,11 @@ func Issues(ctx *context.Context) {
 		return
 	}
 
+	prepareIssueSavedSearches(ctx, isPullList)
+	if ctx.Written() {
+		return
+	}
+
 	ctx.Data["CanWriteIssuesOrPulls"] = ctx.Repo.CanWriteIssuesOrPulls(isPullList)
 
 	ctx.HTML(http.StatusOK, tplIssues)
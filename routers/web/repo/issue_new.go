@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
@@ -34,6 +35,7 @@ import (
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
 	issue_service "code.gitea.io/gitea/services/issue"
+	spamdetection_service "code.gitea.io/gitea/services/spamdetection"
 )
 
 // Tries to load and set an issue template. The first return value indicates if a template was loaded.
@@ -148,6 +150,10 @@ func NewIssue(ctx *context.Context) {
 
 	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWrite(unit.TypeIssues)
 
+	if isIssueCaptchaRequired(ctx) {
+		context.SetCaptchaData(ctx, setting.Service.IssueCaptchaType)
+	}
+
 	if !issueConfig.BlankIssuesEnabled && hasTemplates && !templateLoaded {
 		// The "issues/new" and "issues/new/choose" share the same query parameters "project" and "milestone", if blank issues are disabled, just redirect to the "issues/choose" page with these parameters.
 		ctx.Redirect(fmt.Sprintf("%s/issues/new/choose?%s", ctx.Repo.Repository.Link(), ctx.Req.URL.RawQuery), http.StatusSeeOther)
@@ -157,6 +163,14 @@ func NewIssue(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplIssueNew)
 }
 
+// isIssueCaptchaRequired checks whether the doer has to solve a captcha to create an issue because their account is new
+func isIssueCaptchaRequired(ctx *context.Context) bool {
+	if !setting.Service.EnableCaptcha || setting.Service.IssueCaptchaAccountAge <= 0 || ctx.Doer.IsAdmin {
+		return false
+	}
+	return time.Since(ctx.Doer.CreatedUnix.AsTime()) < setting.Service.IssueCaptchaAccountAge
+}
+
 func renderErrorOfTemplates(ctx *context.Context, errs map[string]error) template.HTML {
 	var files []string
 	for k := range errs {
@@ -367,9 +381,24 @@ func NewIssuePost(ctx *context.Context) {
 		return
 	}
 
+	if isIssueCaptchaRequired(ctx) {
+		valid, err := context.CheckCaptcha(ctx, setting.Service.IssueCaptchaType)
+		if err != nil {
+			ctx.ServerError("CheckCaptcha", err)
+			return
+		} else if !valid {
+			ctx.JSONError(ctx.Tr("form.captcha_incorrect"))
+			return
+		}
+	}
+
 	content := form.Content
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
 		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
+			if err := issue_template.ValidateValues(template, ctx.Req.Form); err != nil {
+				ctx.JSONError(err.Error())
+				return
+			}
 			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
 		}
 	}
@@ -385,6 +414,15 @@ func NewIssuePost(ctx *context.Context) {
 		Ref:         form.Ref,
 	}
 
+	if held, err := spamdetection_service.HoldIssueIfSuspicious(ctx, ctx.Doer, repo, issue, labelIDs, attachments, assigneeIDs, projectID); err != nil {
+		ctx.ServerError("HoldIssueIfSuspicious", err)
+		return
+	} else if held {
+		ctx.Flash.Info(ctx.Tr("repo.issues.new.held"))
+		ctx.JSONRedirect(repo.Link() + "/issues")
+		return
+	}
+
 	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs, projectID); err != nil {
 		if repo_model.IsErrUserDoesNotHaveAccessToRepo(err) {
 			ctx.HTTPError(http.StatusBadRequest, "UserDoesNotHaveAccessToRepo", err.Error())
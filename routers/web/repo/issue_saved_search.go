@@ -0,0 +1,114 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// prepareIssueSavedSearches prepares the saved searches shown as tabs of the issue list
+func prepareIssueSavedSearches(ctx *context.Context, isPull bool) {
+	var doerID int64
+	if ctx.IsSigned {
+		doerID = ctx.Doer.ID
+	}
+	searches, err := issues_model.GetIssueSavedSearchesOfRepo(ctx, ctx.Repo.Repository, doerID, optional.Some(isPull))
+	if err != nil {
+		ctx.ServerError("GetIssueSavedSearchesOfRepo", err)
+		return
+	}
+	canShare, err := issue_service.CanManageSharedIssueSavedSearches(ctx, ctx.Doer, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("CanManageSharedIssueSavedSearches", err)
+		return
+	}
+	// the query of the current page is normalized in the same way to find the active search
+	currentQuery, _ := issues_model.NormalizeIssueSavedSearchQuery(ctx.Req.URL.RawQuery)
+
+	ctx.Data["IssueSavedSearches"] = searches
+	ctx.Data["IssueSavedSearchQuery"] = currentQuery
+	ctx.Data["CanShareIssueSavedSearches"] = canShare
+}
+
+// NewIssueSavedSearch saves the current search of the issue list
+func NewIssueSavedSearch(ctx *context.Context) {
+	isPull := ctx.PathParam("type") == "pulls"
+	if !isPull && !ctx.Repo.CanRead(unit.TypeIssues) {
+		ctx.NotFound(nil)
+		return
+	}
+
+	s := &issues_model.IssueSavedSearch{
+		OwnerID: ctx.Doer.ID,
+		RepoID:  ctx.Repo.Repository.ID,
+		IsPull:  isPull,
+		Name:    ctx.FormString("name"),
+		Query:   ctx.FormString("query"),
+	}
+	if ctx.FormBool("shared") {
+		canShare, err := issue_service.CanManageSharedIssueSavedSearches(ctx, ctx.Doer, ctx.Repo.Repository)
+		if err != nil {
+			ctx.ServerError("CanManageSharedIssueSavedSearches", err)
+			return
+		}
+		if !canShare {
+			ctx.JSONError(ctx.Tr("repo.issues.saved_searches_share_not_allowed"))
+			return
+		}
+		s.OwnerID = ctx.Repo.Repository.OwnerID
+	}
+
+	if err := issues_model.NewIssueSavedSearch(ctx, s); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrAlreadyExist) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("NewIssueSavedSearch", err)
+		}
+		return
+	}
+
+	link := ctx.Repo.RepoLink + "/" + ctx.PathParam("type")
+	if s.Query != "" {
+		link += "?" + s.Query
+	}
+	ctx.JSONRedirect(link)
+}
+
+// DeleteIssueSavedSearch deletes a saved search of the issue list
+func DeleteIssueSavedSearch(ctx *context.Context) {
+	s, err := issues_model.GetIssueSavedSearchOfRepo(ctx, ctx.Repo.Repository, ctx.Doer.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetIssueSavedSearchOfRepo", err)
+		}
+		return
+	}
+	if s.IsShared(ctx.Repo.Repository) {
+		canShare, err := issue_service.CanManageSharedIssueSavedSearches(ctx, ctx.Doer, ctx.Repo.Repository)
+		if err != nil {
+			ctx.ServerError("CanManageSharedIssueSavedSearches", err)
+			return
+		}
+		if !canShare {
+			ctx.JSONError(ctx.Tr("repo.issues.saved_searches_share_not_allowed"))
+			return
+		}
+	}
+
+	if err := issues_model.DeleteIssueSavedSearch(ctx, s.ID); err != nil {
+		ctx.ServerError("DeleteIssueSavedSearch", err)
+		return
+	}
+
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/" + ctx.PathParam("type"))
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+const tplSimilarIssues templates.TplName = "repo/issue/similar_issues"
+
+// SimilarIssues renders the open issues similar to the title and the content of a new issue
+func SimilarIssues(ctx *context.Context) {
+	similar, err := issue_service.FindSimilarIssues(ctx, ctx.Repo.Repository, ctx.FormTrim("title"), ctx.FormString("content"), 0, 5)
+	if err != nil {
+		ctx.ServerError("FindSimilarIssues", err)
+		return
+	}
+
+	ctx.Data["SimilarIssues"] = similar
+	ctx.HTML(http.StatusOK, tplSimilarIssues)
+}
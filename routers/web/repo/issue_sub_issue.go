@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// getIssueForSubIssues returns the issue of the url if the doer can edit its sub-issues
+func getIssueForSubIssues(ctx *context.Context) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return nil
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.NotFound(nil)
+		return nil
+	}
+	issue.Repo = ctx.Repo.Repository
+	return issue
+}
+
+// getIssueByRef returns the issue referenced as "#index" or "owner/repo#index", nil if it doesn't exist
+func getIssueByRef(ctx *context.Context, ref string) (*issues_model.Issue, error) {
+	repo := ctx.Repo.Repository
+	if repoName, indexStr, ok := strings.Cut(ref, "#"); ok && repoName != "" {
+		ownerName, name, _ := strings.Cut(repoName, "/")
+		var err error
+		if repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, name); err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		ref = indexStr
+	}
+	index, err := strconv.ParseInt(strings.TrimPrefix(ref, "#"), 10, 64)
+	if err != nil {
+		return nil, nil //nolint:nilerr // an invalid reference references no issue
+	}
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	issue.Repo = repo
+	return issue, nil
+}
+
+// AddSubIssue makes the issue referenced as "#index" or "owner/repo#index" a sub-issue of the issue
+func AddSubIssue(ctx *context.Context) {
+	parent := getIssueForSubIssues(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	sub, err := getIssueByRef(ctx, strings.TrimSpace(ctx.FormString("issue")))
+	if err != nil {
+		ctx.ServerError("getIssueByRef", err)
+		return
+	}
+	if sub == nil {
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issues.add_error_not_exist"))
+		return
+	}
+	repo := sub.Repo
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	if !perm.CanReadIssuesOrPulls(sub.IsPull) {
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issues.add_error_not_exist"))
+		return
+	}
+	if !perm.CanWriteIssuesOrPulls(sub.IsPull) {
+		ctx.JSONError(ctx.Tr("repo.issues.sub_issues.add_error_no_permission"))
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, parent, sub); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issues.add_error_has_parent"))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issues.add_error_circular"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.JSONError(ctx.Tr("repo.issues.sub_issues.add_error_invalid"))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect(parent.Link())
+}
+
+// RemoveSubIssue removes a sub-issue of the issue
+func RemoveSubIssue(ctx *context.Context) {
+	parent := getIssueForSubIssues(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	sub, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("id"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetIssueByID", err)
+		}
+		return
+	}
+
+	if err := issues_model.RemoveSubIssue(ctx, parent, sub); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("RemoveSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect(parent.Link())
+}
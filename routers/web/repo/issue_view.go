@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -364,6 +365,9 @@ func ViewIssue(ctx *context.Context) {
 		ctx.ServerError("filterXRefComments", err)
 		return
 	}
+	if hideModeratedContent(ctx) {
+		issue.Comments = slices.DeleteFunc(issue.Comments, func(c *issues_model.Comment) bool { return c.IsHidden })
+	}
 
 	ctx.Data["Title"] = fmt.Sprintf("#%d - %s", issue.Index, emoji.ReplaceAliases(issue.Title))
 
@@ -388,6 +392,9 @@ func ViewIssue(ctx *context.Context) {
 		prepareIssueViewSidebarTimeTracker,
 		prepareIssueViewSidebarDependency,
 		prepareIssueViewSidebarPin,
+		prepareIssueViewSidebarFields,
+		prepareIssueViewSidebarSLA,
+		prepareIssueViewSidebarSubIssues,
 		func(ctx *context.Context, issue *issues_model.Issue) { preparePullViewPullInfo(ctx, issue) },
 		preparePullViewReviewAndMerge,
 	}
@@ -599,6 +606,53 @@ func prepareIssueViewSidebarPin(ctx *context.Context, issue *issues_model.Issue)
 	ctx.Data["PinEnabled"] = setting.Repository.Issue.MaxPinned != 0
 }
 
+func prepareIssueViewSidebarFields(ctx *context.Context, issue *issues_model.Issue) {
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetIssueFieldsOfRepo", err)
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+	values, err := issues_model.GetIssueFieldDisplayValues(ctx, fields, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueFieldDisplayValues", err)
+		return
+	}
+	ctx.Data["IssueFields"] = fields
+	ctx.Data["IssueFieldValues"] = values[issue.ID]
+}
+
+func prepareIssueViewSidebarSLA(ctx *context.Context, issue *issues_model.Issue) {
+	statuses, err := issue_service.GetIssueSLAStatuses(ctx, issue)
+	if err != nil {
+		ctx.ServerError("GetIssueSLAStatuses", err)
+		return
+	}
+	ctx.Data["IssueSLAStatuses"] = statuses
+}
+
+func prepareIssueViewSidebarSubIssues(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+	parent, err := issue_service.GetParentIssue(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.ServerError("GetParentIssue", err)
+		return
+	}
+	subIssues, err := issue_service.GetSubIssueTree(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.ServerError("GetSubIssueTree", err)
+		return
+	}
+	ctx.Data["ParentIssue"] = parent
+	ctx.Data["SubIssues"] = subIssues
+	ctx.Data["SubIssueProgress"] = subIssues.Progress()
+	ctx.Data["CanEditSubIssues"] = ctx.Repo.CanWriteIssuesOrPulls(false) && !ctx.Repo.Repository.IsArchived
+}
+
 func prepareIssueViewCommentsAndSidebarParticipants(ctx *context.Context, issue *issues_model.Issue) {
 	var (
 		role                 issues_model.RoleDescriptor
@@ -735,6 +789,9 @@ func prepareIssueViewCommentsAndSidebarParticipants(ctx *context.Context, issue
 				ctx.ServerError("Review.LoadCodeComments", err)
 				return
 			}
+			if hideModeratedContent(ctx) {
+				comment.Review.CodeComments.RemoveHidden()
+			}
 			for _, codeComments := range comment.Review.CodeComments {
 				for _, lineComments := range codeComments {
 					for _, c := range lineComments {
@@ -315,9 +315,10 @@ func ViewProject(ctx *context.Context) {
 	assigneeID := ctx.FormString("assignee")
 
 	issuesMap, err := project_service.LoadIssuesFromProject(ctx, project, &issues_model.IssuesOptions{
-		RepoIDs:    []int64{ctx.Repo.Repository.ID},
-		LabelIDs:   preparedLabelFilter.SelectedLabelIDs,
-		AssigneeID: assigneeID,
+		HideModerated: true,
+		RepoIDs:       []int64{ctx.Repo.Repository.ID},
+		LabelIDs:      preparedLabelFilter.SelectedLabelIDs,
+		AssigneeID:    assigneeID,
 	})
 	if err != nil {
 		ctx.ServerError("LoadIssuesOfColumns", err)
@@ -351,8 +352,9 @@ func ViewProject(ctx *context.Context) {
 
 			if len(referencedIDs) > 0 {
 				if linkedPrs, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
-					IssueIDs: referencedIDs,
-					IsPull:   optional.Some(true),
+					HideModerated: true,
+					IssueIDs:      referencedIDs,
+					IsPull:        optional.Some(true),
 				}); err == nil {
 					linkedPrsMap[issue.ID] = linkedPrs
 				}
@@ -773,7 +773,7 @@ func viewPullFiles(ctx *context.Context, beforeCommitID, afterCommitID string) {
 		"numberOfViewedFiles": diff.NumViewedFiles,
 	}
 
-	if err = diff.LoadComments(ctx, issue, ctx.Doer, ctx.Data["ShowOutdatedComments"].(bool)); err != nil {
+	if err = diff.LoadComments(ctx, issue, ctx.Doer, ctx.Data["ShowOutdatedComments"].(bool), hideModeratedContent(ctx)); err != nil {
 		ctx.ServerError("LoadComments", err)
 		return
 	}
@@ -1301,6 +1301,10 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	content := form.Content
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
 		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
+			if err := issue_template.ValidateValues(template, ctx.Req.Form); err != nil {
+				ctx.JSONError(err.Error())
+				return
+			}
 			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
 		}
 	}
@@ -132,7 +132,7 @@ func UpdateResolveConversation(ctx *context.Context) {
 		return
 	}
 
-	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || (comment.IsHiddenByModerator() && hideModeratedContent(ctx)) {
 		ctx.NotFound(errors.New("comment's repoID is incorrect"))
 		return
 	}
@@ -170,7 +170,7 @@ func renderConversation(ctx *context.Context, comment *issues_model.Comment, ori
 	ctx.Data["PageIsPullFiles"] = origin == "diff"
 
 	showOutdatedComments := origin == "timeline" || ctx.Data["ShowOutdatedComments"].(bool)
-	comments, err := issues_model.FetchCodeCommentsByLine(ctx, comment.Issue, ctx.Doer, comment.TreePath, comment.Line, showOutdatedComments)
+	comments, err := issues_model.FetchCodeCommentsByLine(ctx, comment.Issue, ctx.Doer, comment.TreePath, comment.Line, showOutdatedComments, hideModeratedContent(ctx))
 	if err != nil {
 		ctx.ServerError("FetchCodeCommentsByLine", err)
 		return
@@ -517,6 +517,32 @@ func NewReleasePost(ctx *context.Context) {
 	ctx.Redirect(ctx.Repo.RepoLink + "/releases")
 }
 
+// GenerateReleaseNotes generates the release notes from the pull requests merged since the previous tag to pre-fill the release
+func GenerateReleaseNotes(ctx *context.Context) {
+	tagName := ctx.FormTrim("tag_name")
+	if tagName == "" {
+		ctx.JSONError(ctx.Tr("repo.release.generate_notes.tag_name_required"))
+		return
+	}
+
+	notes, err := release_service.GenerateNotes(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, release_service.GenerateNotesOptions{
+		TagName:         tagName,
+		Target:          ctx.FormTrim("tag_target"),
+		PreviousTagName: ctx.FormTrim("previous_tag"),
+	})
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.release.generate_notes.target_not_exist"))
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("GenerateNotes", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, map[string]any{"content": notes})
+}
+
 // EditRelease render release edit page
 func EditRelease(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("repo.release.edit_release")
@@ -155,6 +155,34 @@ func createCommon(ctx *context.Context) {
 	ctx.Data["DefaultObjectFormat"] = git.Sha1ObjectFormat
 }
 
+const templateVariableFormPrefix = "template_variable_"
+
+// prepareTemplateVariables renders the custom variables declared in the .gitea/template.yaml of the template repository
+func prepareTemplateVariables(ctx *context.Context, templateRepo *repo_model.Repository) {
+	if templateRepo.IsEmpty {
+		return
+	}
+	cfg, err := repo_service.ReadGiteaTemplateConfig(ctx, templateRepo)
+	if err != nil {
+		log.Warn("ReadGiteaTemplateConfig(%s): %v", templateRepo.FullName(), err)
+		return
+	}
+	if cfg != nil {
+		ctx.Data["TemplateVariables"] = cfg.Variables
+	}
+}
+
+// templateVariablesFromForm returns the values of the template variables submitted with the form
+func templateVariablesFromForm(ctx *context.Context) map[string]string {
+	variables := make(map[string]string)
+	for key, values := range ctx.Req.PostForm {
+		if name, ok := strings.CutPrefix(key, templateVariableFormPrefix); ok && len(values) > 0 {
+			variables[name] = values[0]
+		}
+	}
+	return variables
+}
+
 // Create render creating repository page
 func Create(ctx *context.Context) {
 	createCommon(ctx)
@@ -175,6 +203,7 @@ func Create(ctx *context.Context) {
 		if err == nil && access_model.CheckRepoUnitUser(ctx, templateRepo, ctxUser, unit.TypeCode) {
 			ctx.Data["repo_template"] = templateID
 			ctx.Data["repo_template_name"] = templateRepo.Name
+			prepareTemplateVariables(ctx, templateRepo)
 		}
 	}
 
@@ -208,6 +237,8 @@ func handleCreateError(ctx *context.Context, owner *user_model.User, err error,
 	case db.IsErrNamePatternNotAllowed(err):
 		ctx.Data["Err_RepoName"] = true
 		ctx.RenderWithErr(ctx.Tr("repo.form.name_pattern_not_allowed", err.(db.ErrNamePatternNotAllowed).Pattern), tpl, form)
+	case repo_service.IsErrTemplateVariableMissing(err):
+		ctx.RenderWithErr(ctx.Tr("repo.template.variable_missing", err.(repo_service.ErrTemplateVariableMissing).Name), tpl, form)
 	default:
 		ctx.ServerError(name, err)
 	}
@@ -229,8 +260,11 @@ func CreatePost(ctx *context.Context) {
 		if err == nil && access_model.CheckRepoUnitUser(ctx, templateRepo, ctxUser, unit.TypeCode) {
 			ctx.Data["repo_template"] = form.RepoTemplate
 			ctx.Data["repo_template_name"] = templateRepo.Name
+			prepareTemplateVariables(ctx, templateRepo)
 		}
 	}
+	templateVariables := templateVariablesFromForm(ctx)
+	ctx.Data["TemplateVariableValues"] = templateVariables
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplCreate)
@@ -251,6 +285,7 @@ func CreatePost(ctx *context.Context) {
 			Avatar:          form.Avatar,
 			IssueLabels:     form.Labels,
 			ProtectedBranch: form.ProtectedBranch,
+			Variables:       templateVariables,
 		}
 
 		if !opts.IsValid() {
@@ -7,20 +7,32 @@ import (
 	"net/http"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/git"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/indexer/code/gitgrep"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
 
-const tplSearch templates.TplName = "repo/search"
+const (
+	tplSearch     templates.TplName = "repo/search"
+	tplSearchWiki templates.TplName = "repo/search_wiki"
+)
+
+// prepareSearchTabs shows the tabs of the code and the wiki searches if the wiki can be searched too
+func prepareSearchTabs(ctx *context.Context) {
+	ctx.Data["CanSearchCode"] = ctx.Repo.CanRead(unit.TypeCode)
+	ctx.Data["CanSearchWiki"] = setting.Indexer.WikiIndexerEnabled && ctx.Repo.CanRead(unit.TypeWiki)
+}
 
 // Search render repository search page
 func Search(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
+	prepareSearchTabs(ctx)
 	prepareSearch := common.PrepareCodeSearch(ctx)
 	if prepareSearch.Keyword == "" {
 		ctx.HTML(http.StatusOK, tplSearch)
@@ -77,3 +89,53 @@ func Search(ctx *context.Context) {
 
 	ctx.HTML(http.StatusOK, tplSearch)
 }
+
+// SearchWiki render repository wiki search page
+func SearchWiki(ctx *context.Context) {
+	if !setting.Indexer.WikiIndexerEnabled {
+		ctx.NotFound(nil)
+		return
+	}
+
+	ctx.Data["PageIsWiki"] = true
+	ctx.Data["PageIsWikiSearch"] = true
+	prepareSearchTabs(ctx)
+	prepareSearch := common.PrepareWikiSearch(ctx)
+	if prepareSearch.Keyword == "" {
+		ctx.HTML(http.StatusOK, tplSearchWiki)
+		return
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	total, searchResults, err := wiki_indexer.PerformSearch(ctx, &wiki_indexer.SearchOptions{
+		RepoIDs:    []int64{ctx.Repo.Repository.ID},
+		Keyword:    prepareSearch.Keyword,
+		SearchMode: prepareSearch.SearchMode,
+		Paginator: &db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.RepoSearchPagingNum,
+		},
+	})
+	if err != nil {
+		if wiki_indexer.IsAvailable(ctx) {
+			ctx.ServerError("SearchResults", err)
+			return
+		}
+		ctx.Data["WikiIndexerUnavailable"] = true
+	} else {
+		ctx.Data["WikiIndexerUnavailable"] = !wiki_indexer.IsAvailable(ctx)
+	}
+
+	ctx.Data["Repo"] = ctx.Repo.Repository
+	ctx.Data["SearchResults"] = common.ToWikiSearchResults(searchResults)
+
+	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplSearchWiki)
+}
@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
 
@@ -50,6 +51,9 @@ func UpdateAvatarSetting(ctx *context.Context, form forms.AvatarForm) error {
 		return errors.New(ctx.Locale.TrString("settings.uploaded_avatar_not_a_image"))
 	}
 	if err = repo_service.UploadAvatar(ctx, ctxRepo, data); err != nil {
+		if errors.Is(err, malwarescan_service.ErrMalwareFound) {
+			return errors.New(ctx.Locale.TrString("settings.uploaded_avatar_malware_found"))
+		}
 		return fmt.Errorf("UploadAvatar: %w", err)
 	}
 	return nil
@@ -49,8 +49,11 @@ func NewProtectedTagPost(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.ProtectTagForm)
 
 	pt := &git_model.ProtectedTag{
-		RepoID:      repo.ID,
-		NamePattern: strings.TrimSpace(form.NamePattern),
+		RepoID:           repo.ID,
+		NamePattern:      strings.TrimSpace(form.NamePattern),
+		RequireAnnotated: form.RequireAnnotated,
+		RequireSigned:    form.RequireSigned,
+		EnforceSemver:    form.EnforceSemver,
 	}
 
 	if strings.TrimSpace(form.AllowlistUsers) != "" {
@@ -85,6 +88,9 @@ func EditProtectedTag(ctx *context.Context) {
 	ctx.Data["name_pattern"] = pt.NamePattern
 	ctx.Data["allowlist_users"] = strings.Join(base.Int64sToStrings(pt.AllowlistUserIDs), ",")
 	ctx.Data["allowlist_teams"] = strings.Join(base.Int64sToStrings(pt.AllowlistTeamIDs), ",")
+	ctx.Data["require_annotated"] = pt.RequireAnnotated
+	ctx.Data["require_signed"] = pt.RequireSigned
+	ctx.Data["enforce_semver"] = pt.EnforceSemver
 
 	ctx.HTML(http.StatusOK, tplTags)
 }
@@ -112,6 +118,9 @@ func EditProtectedTagPost(ctx *context.Context) {
 	pt.NamePattern = strings.TrimSpace(form.NamePattern)
 	pt.AllowlistUserIDs, _ = base.StringsToInt64s(strings.Split(form.AllowlistUsers, ","))
 	pt.AllowlistTeamIDs, _ = base.StringsToInt64s(strings.Split(form.AllowlistTeams, ","))
+	pt.RequireAnnotated = form.RequireAnnotated
+	pt.RequireSigned = form.RequireSigned
+	pt.EnforceSemver = form.EnforceSemver
 
 	if err := git_model.UpdateProtectedTag(ctx, pt); err != nil {
 		ctx.ServerError("UpdateProtectedTag", err)
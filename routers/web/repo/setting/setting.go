@@ -17,9 +17,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
-	"code.gitea.io/gitea/modules/indexer/code"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
-	"code.gitea.io/gitea/modules/indexer/stats"
 	"code.gitea.io/gitea/modules/lfs"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -29,14 +27,17 @@ import (
 	"code.gitea.io/gitea/modules/validation"
 	"code.gitea.io/gitea/modules/web"
 	actions_service "code.gitea.io/gitea/services/actions"
+	admin_service "code.gitea.io/gitea/services/admin"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	indexer_service "code.gitea.io/gitea/services/indexer"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	repo_service "code.gitea.io/gitea/services/repository"
 	wiki_service "code.gitea.io/gitea/services/wiki"
 
+	"github.com/dustin/go-humanize"
 	"xorm.io/xorm/convert"
 )
 
@@ -60,6 +61,7 @@ func SettingsCtxData(ctx *context.Context) {
 	ctx.Data["DisableNewPushMirrors"] = setting.Mirror.DisableNewPush
 	ctx.Data["DefaultMirrorInterval"] = setting.Mirror.DefaultInterval
 	ctx.Data["MinimumMirrorInterval"] = setting.Mirror.MinInterval
+	ctx.Data["PackagesEnabled"] = setting.Packages.Enabled
 	ctx.Data["CanConvertFork"] = ctx.Repo.Repository.IsFork && ctx.Doer.CanCreateRepoIn(ctx.Repo.Repository.Owner)
 
 	signing, _ := asymkey_service.SigningKey(ctx, ctx.Repo.Repository.RepoPath())
@@ -104,6 +106,7 @@ func SettingsPost(ctx *context.Context) {
 	ctx.Data["DisableNewPushMirrors"] = setting.Mirror.DisableNewPush
 	ctx.Data["DefaultMirrorInterval"] = setting.Mirror.DefaultInterval
 	ctx.Data["MinimumMirrorInterval"] = setting.Mirror.MinInterval
+	ctx.Data["PackagesEnabled"] = setting.Packages.Enabled
 
 	signing, _ := asymkey_service.SigningKey(ctx, ctx.Repo.Repository.RepoPath())
 	ctx.Data["SigningKeyAvailable"] = signing != nil
@@ -251,8 +254,26 @@ func handleSettingsPostMirror(ctx *context.Context) {
 		return
 	}
 
+	if err := repo_model.ValidateMirrorRefFilter(form.MirrorRefFilter); err != nil {
+		ctx.Data["Err_MirrorRefFilter"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.mirror_ref_filter_invalid"), tplSettingsOptions, &form)
+		return
+	}
+
+	var maxSize uint64
+	if s := strings.TrimSpace(form.MirrorMaxSize); s != "" {
+		if maxSize, err = humanize.ParseBytes(s); err != nil {
+			ctx.Data["Err_MirrorMaxSize"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.mirror_max_size_invalid"), tplSettingsOptions, &form)
+			return
+		}
+	}
+
 	pullMirror.EnablePrune = form.EnablePrune
 	pullMirror.Interval = interval
+	pullMirror.RefFilter = strings.Join(repo_model.ParseMirrorRefFilter(form.MirrorRefFilter), ", ")
+	pullMirror.SkipTags = form.MirrorSkipTags
+	pullMirror.MaxSize = int64(maxSize)
 	pullMirror.ScheduleNextUpdate()
 	if err := repo_model.UpdateMirror(ctx, pullMirror); err != nil {
 		ctx.ServerError("UpdateMirror", err)
@@ -449,6 +470,12 @@ func handleSettingsPostPushMirrorAdd(ctx *context.Context) {
 		return
 	}
 
+	if err := repo_model.ValidateMirrorRefFilter(form.PushMirrorBranchFilter); err != nil {
+		ctx.Data["Err_PushMirrorBranchFilter"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.mirror_ref_filter_invalid"), tplSettingsOptions, &form)
+		return
+	}
+
 	address, err := git.ParseRemoteAddr(form.PushMirrorAddress, form.PushMirrorUsername, form.PushMirrorPassword)
 	if err == nil {
 		err = migrations.IsMigrateURLAllowed(address, ctx.Doer)
@@ -479,6 +506,8 @@ func handleSettingsPostPushMirrorAdd(ctx *context.Context) {
 		SyncOnCommit:  form.PushMirrorSyncOnCommit,
 		Interval:      interval,
 		RemoteAddress: remoteAddress,
+		BranchFilter:  strings.Join(repo_model.ParseMirrorRefFilter(form.PushMirrorBranchFilter), ", "),
+		LFS:           form.PushMirrorLFS,
 	}
 	if err := db.Insert(ctx, m); err != nil {
 		ctx.ServerError("InsertPushMirror", err)
@@ -603,7 +632,17 @@ func handleSettingsPostAdvanced(ctx *context.Context) {
 	}
 
 	if form.EnableReleases && !unit_model.TypeReleases.UnitGlobalDisabled() {
-		units = append(units, newRepoUnit(repo, unit_model.TypeReleases, nil))
+		config := repo.MustGetUnit(ctx, unit_model.TypeReleases).ReleasesConfig()
+		// the publishing options are only shown if the package registries are enabled
+		if setting.Packages.Enabled {
+			config = &repo_model.ReleasesConfig{
+				PublishAssetsToPackages: form.ReleasesPublishAssets,
+				DebianDistribution:      strings.TrimSpace(form.ReleasesDebianDistribution),
+				DebianComponent:         strings.TrimSpace(form.ReleasesDebianComponent),
+				RpmGroup:                strings.TrimSpace(form.ReleasesRpmGroup),
+			}
+		}
+		units = append(units, newRepoUnit(repo, unit_model.TypeReleases, config))
 	} else if !unit_model.TypeReleases.UnitGlobalDisabled() {
 		deleteUnitTypes = append(deleteUnitTypes, unit_model.TypeReleases)
 	}
@@ -634,6 +673,8 @@ func handleSettingsPostAdvanced(ctx *context.Context) {
 			DefaultDeleteBranchAfterMerge: form.DefaultDeleteBranchAfterMerge,
 			DefaultMergeStyle:             repo_model.MergeStyle(form.PullsDefaultMergeStyle),
 			DefaultAllowMaintainerEdit:    form.DefaultAllowMaintainerEdit,
+			RequireSignOff:                form.PullsRequireSignOff,
+			SignOffExemptUsers:            util.SplitTrimSpace(form.PullsSignOffExemptUsers, ","),
 		}))
 	} else if !unit_model.TypePullRequests.UnitGlobalDisabled() {
 		deleteUnitTypes = append(deleteUnitTypes, unit_model.TypePullRequests)
@@ -708,17 +749,15 @@ func handleSettingsPostAdminIndex(ctx *context.Context) {
 	}
 
 	switch form.RequestReindexType {
-	case "stats":
-		if err := stats.UpdateRepoIndexer(ctx.Repo.Repository); err != nil {
-			ctx.ServerError("UpdateStatsRepondexer", err)
-			return
-		}
-	case "code":
-		if !setting.Indexer.RepoIndexerEnabled {
-			ctx.HTTPError(http.StatusForbidden)
+	case indexer_service.IndexerStats, indexer_service.IndexerCode:
+		if err := indexer_service.ReindexRepository(ctx, repo, form.RequestReindexType); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.HTTPError(http.StatusForbidden)
+			} else {
+				ctx.ServerError("ReindexRepository", err)
+			}
 			return
 		}
-		code.UpdateRepoIndexer(ctx.Repo.Repository)
 	default:
 		ctx.NotFound(nil)
 		return
@@ -904,6 +943,16 @@ func handleSettingsPostDelete(ctx *context.Context) {
 		return
 	}
 
+	if admin_service.IsRepoDeletionApprovalRequired(ctx.Doer, repo) {
+		if _, err := admin_service.RequestDeleteRepo(ctx, ctx.Doer, repo); err != nil {
+			ctx.ServerError("RequestDeleteRepo", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("admin.approvals.requested"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+		return
+	}
+
 	// Close the gitrepository before doing this.
 	if ctx.Repo.GitRepo != nil {
 		ctx.Repo.GitRepo.Close()
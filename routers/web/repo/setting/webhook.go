@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/perm"
@@ -22,6 +23,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
@@ -149,6 +151,10 @@ func WebhooksNew(ctx *context.Context) {
 		ctx.Data["DiscordHook"] = map[string]any{
 			"Username": "Gitea",
 		}
+	} else if hookType == webhook_module.CUSTOM {
+		ctx.Data["CustomHook"] = &webhook_service.CustomMeta{
+			BodyTemplate: "{{json .Payload}}",
+		}
 	}
 	ctx.Data["BaseLink"] = orCtx.LinkNew
 	ctx.Data["BaseLinkNew"] = orCtx.LinkNew
@@ -189,6 +195,9 @@ func ParseHookEvent(form forms.WebhookForm) *webhook_module.HookEvent {
 			webhook_module.HookEventWorkflowJob:              form.WorkflowJob,
 		},
 		BranchFilter: form.BranchFilter,
+		PathFilter:   form.PathFilter,
+		LabelFilter:  form.LabelFilter,
+		ActorFilter:  form.ActorFilter,
 	}
 }
 
@@ -453,7 +462,7 @@ func matrixHookParams(ctx *context.Context) webhookParams {
 
 	return webhookParams{
 		Type:        webhook_module.MATRIX,
-		URL:         fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message", form.HomeserverURL, url.PathEscape(form.RoomID)),
+		URL:         webhook_service.MatrixRoomMessageURL(form.HomeserverURL, form.RoomID),
 		ContentType: webhook.ContentTypeJSON,
 		HTTPMethod:  http.MethodPut,
 		WebhookForm: form.WebhookForm,
@@ -581,6 +590,87 @@ func packagistHookParams(ctx *context.Context) webhookParams {
 	}
 }
 
+// CustomHooksNewPost response for creating a custom webhook
+func CustomHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, customHookParams(ctx))
+}
+
+// CustomHooksEditPost response for editing a custom webhook
+func CustomHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, customHookParams(ctx))
+}
+
+func customHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewCustomHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.CUSTOM,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		HTTPMethod:  form.HTTPMethod,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.CustomMeta{
+			ContentType:  strings.TrimSpace(form.ContentType),
+			Headers:      form.Headers,
+			BodyTemplate: form.BodyTemplate,
+		},
+	}
+}
+
+// KafkaHooksNewPost response for creating a kafka webhook
+func KafkaHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, kafkaHookParams(ctx))
+}
+
+// KafkaHooksEditPost response for editing a kafka webhook
+func KafkaHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, kafkaHookParams(ctx))
+}
+
+func kafkaHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewKafkaHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.KAFKA,
+		URL:         strings.TrimSpace(form.PayloadURL),
+		ContentType: webhook.ContentTypeJSON,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.KafkaMeta{
+			Topic:        form.Topic,
+			PartitionKey: form.PartitionKey,
+			RequiredAcks: form.RequiredAcks,
+			TLS:          form.TLS,
+		},
+	}
+}
+
+// NatsHooksNewPost response for creating a nats webhook
+func NatsHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, natsHookParams(ctx))
+}
+
+// NatsHooksEditPost response for editing a nats webhook
+func NatsHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, natsHookParams(ctx))
+}
+
+func natsHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewNatsHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.NATS,
+		URL:         strings.TrimSpace(form.PayloadURL),
+		ContentType: webhook.ContentTypeJSON,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.NatsMeta{
+			Subject:      form.Subject,
+			PartitionKey: form.PartitionKey,
+			JetStream:    form.JetStream,
+			TLS:          form.TLS,
+		},
+	}
+}
+
 func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 	orCtx, err := getOwnerRepoCtx(ctx)
 	if err != nil {
@@ -619,6 +709,12 @@ func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 		ctx.Data["MatrixHook"] = webhook_service.GetMatrixHook(w)
 	case webhook_module.PACKAGIST:
 		ctx.Data["PackagistHook"] = webhook_service.GetPackagistHook(w)
+	case webhook_module.CUSTOM:
+		ctx.Data["CustomHook"] = webhook_service.GetCustomHook(w)
+	case webhook_module.KAFKA:
+		ctx.Data["KafkaHook"] = webhook_service.GetKafkaHook(w)
+	case webhook_module.NATS:
+		ctx.Data["NatsHook"] = webhook_service.GetNatsHook(w)
 	}
 
 	ctx.Data["History"], err = w.History(ctx, 1)
@@ -728,6 +824,44 @@ func ReplayWebhook(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/%d", orCtx.Link, w.ID))
 }
 
+// RedeliverWebhooks redelivers the dead-lettered deliveries of a webhook
+func RedeliverWebhooks(ctx *context.Context) {
+	orCtx, w := checkWebhook(ctx)
+	if ctx.Written() {
+		return
+	}
+	redirectLink := fmt.Sprintf("%s/%d", orCtx.Link, w.ID)
+
+	// the range is given in days, the end day is included
+	since, err1 := parseRedeliveryDay(ctx.FormString("since"), 0)
+	before, err2 := parseRedeliveryDay(ctx.FormString("before"), 1)
+	if err1 != nil || err2 != nil {
+		ctx.Flash.Error(ctx.Tr("repo.settings.webhook.redeliver.invalid_range"))
+		ctx.Redirect(redirectLink)
+		return
+	}
+
+	tasks, err := webhook_service.RedeliverHookTasks(ctx, w, since, before)
+	if err != nil {
+		ctx.ServerError("RedeliverHookTasks", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.TrN(len(tasks), "repo.settings.webhook.redeliver.success_1", "repo.settings.webhook.redeliver.success_n", len(tasks)))
+	ctx.Redirect(redirectLink)
+}
+
+func parseRedeliveryDay(v string, addDays int) (timeutil.TimeStampNano, error) {
+	if v == "" {
+		return 0, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", v, setting.DefaultUILocation)
+	if err != nil {
+		return 0, err
+	}
+	return timeutil.TimeStampNano(t.AddDate(0, 0, addDays).UnixNano()), nil
+}
+
 // DeleteWebhook delete a webhook
 func DeleteWebhook(ctx *context.Context) {
 	if err := webhook.DeleteWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
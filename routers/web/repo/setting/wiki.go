@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const tplWiki templates.TplName = "repo/settings/wiki"
+
+// ProtectedWikiPages render the page to protect wiki pages
+func ProtectedWikiPages(ctx *context.Context) {
+	if setWikiContext(ctx) != nil {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplWiki)
+}
+
+// NewProtectedWikiPagePost handles creation of a protected wiki page pattern
+func NewProtectedWikiPagePost(ctx *context.Context) {
+	if setWikiContext(ctx) != nil {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplWiki)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ProtectWikiPageForm)
+	if err := repo_model.InsertProtectedWikiPage(ctx, &repo_model.ProtectedWikiPage{
+		RepoID:      ctx.Repo.Repository.ID,
+		NamePattern: strings.TrimSpace(form.NamePattern),
+	}); err != nil {
+		ctx.ServerError("InsertProtectedWikiPage", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/wiki")
+}
+
+// DeleteProtectedWikiPagePost handles deletion of a protected wiki page pattern
+func DeleteProtectedWikiPagePost(ctx *context.Context) {
+	id := ctx.FormInt64("id")
+	if _, err := repo_model.GetProtectedWikiPageByID(ctx, ctx.Repo.Repository.ID, id); err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetProtectedWikiPageByID", err)
+		}
+		return
+	}
+
+	if err := repo_model.DeleteProtectedWikiPage(ctx, ctx.Repo.Repository.ID, id); err != nil {
+		ctx.ServerError("DeleteProtectedWikiPage", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/wiki")
+}
+
+func setWikiContext(ctx *context.Context) error {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.wiki")
+	ctx.Data["PageIsSettingsWiki"] = true
+
+	pages, err := repo_model.GetProtectedWikiPages(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetProtectedWikiPages", err)
+		return err
+	}
+	ctx.Data["ProtectedWikiPages"] = pages
+	return nil
+}
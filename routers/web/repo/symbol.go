@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// maxDefinitions is the number of the definitions of a symbol shown by the jump to definition
+const maxDefinitions = 20
+
+// Symbols responds the definitions of a symbol of the default branch for the jump to definition of the code view
+func Symbols(ctx *context.Context) {
+	name := ctx.FormTrim("name")
+	if !setting.Indexer.SymbolIndexerEnabled || name == "" {
+		ctx.NotFound(nil)
+		return
+	}
+
+	symbols, err := db.Find[repo_model.RepoSymbol](ctx, repo_model.FindRepoSymbolsOptions{
+		ListOptions: db.ListOptions{PageSize: maxDefinitions},
+		RepoID:      ctx.Repo.Repository.ID,
+		Name:        name,
+	})
+	if err != nil {
+		ctx.ServerError("FindRepoSymbols", err)
+		return
+	}
+
+	apiSymbols := make([]*api.RepoSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		apiSymbols = append(apiSymbols, convert.ToRepoSymbol(ctx.Repo.Repository, symbol))
+	}
+	ctx.JSON(http.StatusOK, apiSymbols)
+}
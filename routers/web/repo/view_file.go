@@ -251,6 +251,10 @@ func prepareFileView(ctx *context.Context, entry *git.TreeEntry) {
 	case handleFileViewRenderSource(ctx, entry.Name(), attrs, fInfo, utf8Reader):
 		// it also sets ctx.Data["FileContent"] and more
 		ctx.Data["IsDisplayingSource"] = true
+		if setting.Indexer.SymbolIndexerEnabled {
+			// the definitions of the symbols of the default branch are looked up for the jump to definition
+			ctx.Data["SymbolsLink"] = ctx.Repo.RepoLink + "/symbols"
+		}
 	case handleFileViewRenderImage(ctx, fInfo, buf):
 		ctx.Data["IsImageFile"] = true
 	case fInfo.st.IsVideo():
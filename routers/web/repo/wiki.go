@@ -229,6 +229,14 @@ func renderViewPage(ctx *context.Context) (*git.Repository, *git.TreeEntry) {
 	ctx.Data["Title"] = displayName
 	ctx.Data["title"] = displayName
 
+	isProtected, err := wiki_service.IsPageProtected(ctx, ctx.Repo.Repository, pageName)
+	if err != nil {
+		ctx.ServerError("IsPageProtected", err)
+		return nil, nil
+	}
+	ctx.Data["IsWikiPageProtected"] = isProtected
+	ctx.Data["CanEditWikiPage"] = !isProtected || ctx.Repo.IsAdmin()
+
 	isSideBar := pageName == "_Sidebar"
 	isFooter := pageName == "_Footer"
 
@@ -401,6 +409,16 @@ func renderEditPage(ctx *context.Context) {
 	ctx.Data["Title"] = displayName
 	ctx.Data["title"] = displayName
 
+	canEditPage, err := wiki_service.CanEditPage(ctx, ctx.Repo.Repository, ctx.Repo.Permission, pageName)
+	if err != nil {
+		ctx.ServerError("CanEditPage", err)
+		return
+	}
+	if !canEditPage {
+		ctx.HTTPError(http.StatusForbidden, "Editing of protected wiki pages is only allowed for the repository administrators")
+		return
+	}
+
 	// lookup filename in wiki -  gitTree entry , real filename
 	entry, _, noEntry, isRaw := wikiEntryByName(ctx, commit, pageName)
 	if noEntry {
@@ -687,6 +705,9 @@ func NewWikiPost(ctx *context.Context) {
 		} else if repo_model.IsErrWikiAlreadyExist(err) {
 			ctx.Data["Err_Title"] = true
 			ctx.RenderWithErr(ctx.Tr("repo.wiki.page_already_exists"), tplWikiNew, &form)
+		} else if repo_model.IsErrWikiPageProtected(err) {
+			ctx.Data["Err_Title"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.wiki.protected_page", form.Title), tplWikiNew, &form)
 		} else {
 			ctx.ServerError("AddWikiPage", err)
 		}
@@ -733,6 +754,11 @@ func EditWikiPost(ctx *context.Context) {
 	}
 
 	if err := wiki_service.EditWikiPage(ctx, ctx.Doer, ctx.Repo.Repository, oldWikiName, newWikiName, form.Content, form.Message); err != nil {
+		if repo_model.IsErrWikiPageProtected(err) {
+			ctx.Data["Err_Title"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.wiki.protected_page", err.(repo_model.ErrWikiPageProtected).Title), tplWikiNew, &form)
+			return
+		}
 		ctx.ServerError("EditWikiPage", err)
 		return
 	}
@@ -750,6 +776,10 @@ func DeleteWikiPagePost(ctx *context.Context) {
 	}
 
 	if err := wiki_service.DeleteWikiPage(ctx, ctx.Doer, ctx.Repo.Repository, wikiName); err != nil {
+		if repo_model.IsErrWikiPageProtected(err) {
+			ctx.JSONError(ctx.Tr("repo.wiki.protected_page", err.(repo_model.ErrWikiPageProtected).Title))
+			return
+		}
 		ctx.ServerError("DeleteWikiPage", err)
 		return
 	}
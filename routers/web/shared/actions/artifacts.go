@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	tplRepoArtifacts templates.TplName = "repo/settings/actions"
+	tplOrgArtifacts  templates.TplName = "org/settings/actions"
+)
+
+type artifactsCtx struct {
+	OwnerID           int64
+	RepoID            int64
+	IsRepo            bool
+	IsOrg             bool
+	ArtifactsTemplate templates.TplName
+	RedirectLink      string
+}
+
+func getArtifactsCtx(ctx *context.Context) (*artifactsCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &artifactsCtx{
+			RepoID:            ctx.Repo.Repository.ID,
+			IsRepo:            true,
+			ArtifactsTemplate: tplRepoArtifacts,
+			RedirectLink:      ctx.Repo.RepoLink + "/settings/actions/artifacts",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+			ctx.ServerError("RenderUserOrgHeader", err)
+			return nil, nil
+		}
+		return &artifactsCtx{
+			OwnerID:           ctx.ContextUser.ID,
+			IsOrg:             true,
+			ArtifactsTemplate: tplOrgArtifacts,
+			RedirectLink:      ctx.Org.OrgLink + "/settings/actions/artifacts",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set Artifacts context")
+}
+
+// Artifacts shows the artifact retention policy and the storage consumed by artifacts
+func Artifacts(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.artifacts")
+	ctx.Data["PageType"] = "artifacts"
+	ctx.Data["PageIsSharedSettingsArtifacts"] = true
+
+	aCtx, err := getArtifactsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getArtifactsCtx", err)
+		return
+	} else if aCtx == nil {
+		return
+	}
+
+	policy, err := actions_model.GetArtifactRetention(ctx, aCtx.OwnerID, aCtx.RepoID)
+	if errors.Is(err, util.ErrNotExist) {
+		policy = &actions_model.ActionArtifactRetention{}
+	} else if err != nil {
+		ctx.ServerError("GetArtifactRetention", err)
+		return
+	}
+	usages, err := actions_model.GetArtifactUsages(ctx, aCtx.OwnerID, aCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetArtifactUsages", err)
+		return
+	}
+	var totalSize int64
+	for _, u := range usages {
+		totalSize += u.Size
+	}
+
+	ctx.Data["ArtifactRetention"] = policy
+	ctx.Data["ArtifactUsages"] = usages
+	ctx.Data["ArtifactTotalSize"] = totalSize
+	ctx.Data["IsOrgArtifacts"] = aCtx.IsOrg
+	ctx.HTML(http.StatusOK, aCtx.ArtifactsTemplate)
+}
+
+// ArtifactsRetentionPost updates the artifact retention policy
+func ArtifactsRetentionPost(ctx *context.Context) {
+	aCtx, err := getArtifactsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getArtifactsCtx", err)
+		return
+	} else if aCtx == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.EditArtifactRetentionForm)
+	var maxTotalSize uint64
+	if s := strings.TrimSpace(form.MaxTotalSize); s != "" {
+		if maxTotalSize, err = humanize.ParseBytes(s); err != nil {
+			ctx.Flash.Error(ctx.Tr("actions.artifacts.retention.invalid_size", s))
+			ctx.Redirect(aCtx.RedirectLink)
+			return
+		}
+	}
+
+	policy := &actions_model.ActionArtifactRetention{
+		OwnerID:      aCtx.OwnerID,
+		RepoID:       aCtx.RepoID,
+		MaxAgeDays:   form.MaxAgeDays,
+		MaxTotalSize: int64(maxTotalSize),
+		KeepLastN:    form.KeepLastN,
+	}
+	if policy.IsEmpty() {
+		err = actions_model.DeleteArtifactRetention(ctx, aCtx.OwnerID, aCtx.RepoID)
+	} else {
+		err = actions_model.SetArtifactRetention(ctx, policy)
+	}
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.Flash.Error(ctx.Tr("actions.artifacts.retention.update.failed"))
+		ctx.Redirect(aCtx.RedirectLink)
+		return
+	} else if err != nil {
+		ctx.ServerError("SetArtifactRetention", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.artifacts.retention.update.success"))
+	ctx.Redirect(aCtx.RedirectLink)
+}
@@ -6,18 +6,17 @@ package packages
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
-	container_service "code.gitea.io/gitea/services/packages/container"
+	cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
 )
 
 func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
@@ -27,6 +26,13 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 		return
 	}
 
+	for _, pcr := range pcrs {
+		if err := pcr.LoadRepo(ctx); err != nil {
+			ctx.ServerError("LoadRepo", err)
+			return
+		}
+	}
+
 	ctx.Data["CleanupRules"] = pcrs
 }
 
@@ -39,6 +45,10 @@ func SetRuleEditContext(ctx *context.Context, owner *user_model.User) {
 	if pcr == nil {
 		return
 	}
+	if err := pcr.LoadRepo(ctx); err != nil {
+		ctx.ServerError("LoadRepo", err)
+		return
+	}
 
 	setRuleEditContext(ctx, pcr)
 }
@@ -94,6 +104,8 @@ func performRuleEditPost(ctx *context.Context, owner *user_model.User, pcr *pack
 	pcr.RemoveDays = form.RemoveDays
 	pcr.RemovePattern = form.RemovePattern
 	pcr.MatchFullName = form.MatchFullName
+	pcr.RemovePrereleaseDays = form.RemovePrereleaseDays
+	pcr.RemoveUntaggedDays = form.RemoveUntaggedDays
 
 	ctx.Data["IsEditRule"] = isEditRule
 	ctx.Data["CleanupRule"] = pcr
@@ -112,7 +124,22 @@ func performRuleEditPost(ctx *context.Context, owner *user_model.User, pcr *pack
 	} else {
 		pcr.Type = packages_model.Type(form.Type)
 
-		if has, err := packages_model.HasOwnerCleanupRuleForPackageType(ctx, owner.ID, pcr.Type); err != nil {
+		if form.RepoName != "" {
+			repo, err := repo_model.GetRepositoryByName(ctx, owner.ID, form.RepoName)
+			if err != nil {
+				if !repo_model.IsErrRepoNotExist(err) {
+					ctx.ServerError("GetRepositoryByName", err)
+					return
+				}
+				ctx.Data["Err_RepoName"] = true
+				ctx.RenderWithErr(ctx.Tr("packages.owner.settings.cleanuprules.repository.not_exist"), template, form)
+				return
+			}
+			pcr.RepoID = repo.ID
+			pcr.Repo = repo
+		}
+
+		if has, err := packages_model.HasOwnerCleanupRuleForPackageType(ctx, owner.ID, pcr.RepoID, pcr.Type); err != nil {
 			ctx.ServerError("HasOwnerCleanupRuleForPackageType", err)
 			return
 		} else if has {
@@ -138,69 +165,12 @@ func SetRulePreviewContext(ctx *context.Context, owner *user_model.User) {
 		return
 	}
 
-	if err := pcr.CompiledPattern(); err != nil {
-		ctx.ServerError("CompiledPattern", err)
-		return
-	}
-
-	olderThan := time.Now().AddDate(0, 0, -pcr.RemoveDays)
-
-	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
+	versionsToRemove, err := cleanup_service.PreviewCleanupRule(ctx, pcr)
 	if err != nil {
-		ctx.ServerError("GetPackagesByType", err)
+		ctx.ServerError("PreviewCleanupRule", err)
 		return
 	}
 
-	versionsToRemove := make([]*packages_model.PackageDescriptor, 0, 10)
-
-	for _, p := range packages {
-		pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
-			PackageID:  p.ID,
-			IsInternal: optional.Some(false),
-			Sort:       packages_model.SortCreatedDesc,
-		})
-		if err != nil {
-			ctx.ServerError("SearchVersions", err)
-			return
-		}
-		if pcr.KeepCount > 0 {
-			if pcr.KeepCount < len(pvs) {
-				pvs = pvs[pcr.KeepCount:]
-			} else {
-				pvs = nil
-			}
-		}
-		for _, pv := range pvs {
-			if skip, err := container_service.ShouldBeSkipped(ctx, pcr, p, pv); err != nil {
-				ctx.ServerError("ShouldBeSkipped", err)
-				return
-			} else if skip {
-				continue
-			}
-
-			toMatch := pv.LowerVersion
-			if pcr.MatchFullName {
-				toMatch = p.LowerName + "/" + pv.LowerVersion
-			}
-			if pcr.KeepPatternMatcher != nil && pcr.KeepPatternMatcher.MatchString(toMatch) {
-				continue
-			}
-			if pv.CreatedUnix.AsLocalTime().After(olderThan) {
-				continue
-			}
-			if pcr.RemovePatternMatcher != nil && !pcr.RemovePatternMatcher.MatchString(toMatch) {
-				continue
-			}
-
-			pd, err := packages_model.GetPackageDescriptor(ctx, pv)
-			if err != nil {
-				ctx.ServerError("GetPackageDescriptor", err)
-				return
-			}
-			versionsToRemove = append(versionsToRemove, pd)
-		}
-	}
-
 	ctx.Data["CleanupRule"] = pcr
 	ctx.Data["VersionsToRemove"] = versionsToRemove
 }
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+)
+
+// DismissAnnouncement hides the announcement banner for the signed-in user
+func DismissAnnouncement(ctx *context.Context) {
+	a, err := system_model.GetActiveAnnouncementByID(ctx, ctx.PathParamInt64("id"), system_model.FindActiveAnnouncementsOptions{
+		UserID:  ctx.Doer.ID,
+		IsAdmin: ctx.Doer.IsAdmin,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetActiveAnnouncementByID", err)
+		}
+		return
+	}
+	if err := system_model.DismissAnnouncement(ctx, a, ctx.Doer.ID); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.ServerError("DismissAnnouncement", err)
+		}
+		return
+	}
+	ctx.JSONRedirect("")
+}
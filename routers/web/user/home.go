@@ -414,10 +414,11 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 
 	isPullList := unitType == unit.TypePullRequests
 	opts := &issues_model.IssuesOptions{
-		IsPull:     optional.Some(isPullList),
-		SortType:   sortType,
-		IsArchived: optional.Some(false),
-		Doer:       ctx.Doer,
+		HideModerated: true,
+		IsPull:        optional.Some(isPullList),
+		SortType:      sortType,
+		IsArchived:    optional.Some(false),
+		Doer:          ctx.Doer,
 	}
 	// --------------------------------------------------------------------------
 	// Build opts (IssuesOptions), which contains filter information.
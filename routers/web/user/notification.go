@@ -214,16 +214,18 @@ func NotificationSubscriptions(ctx *context.Context) {
 	}
 
 	count, err := issues_model.CountIssues(ctx, &issues_model.IssuesOptions{
-		SubscriberID: ctx.Doer.ID,
-		IsClosed:     showClosed,
-		IsPull:       issueTypeBool,
-		LabelIDs:     labelIDs,
+		HideModerated: true,
+		SubscriberID:  ctx.Doer.ID,
+		IsClosed:      showClosed,
+		IsPull:        issueTypeBool,
+		LabelIDs:      labelIDs,
 	})
 	if err != nil {
 		ctx.ServerError("CountIssues", err)
 		return
 	}
 	issues, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
+		HideModerated: true,
 		Paginator: &db.ListOptions{
 			PageSize: setting.UI.IssuePagingNum,
 			Page:     page,
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"code.gitea.io/gitea/models/db"
 	org_model "code.gitea.io/gitea/models/organization"
@@ -16,6 +17,7 @@ import (
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/badge"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/httplib"
 	"code.gitea.io/gitea/modules/log"
@@ -32,9 +34,12 @@ import (
 	packages_helper "code.gitea.io/gitea/routers/api/packages/helper"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+	scan_service "code.gitea.io/gitea/services/packages/scan"
 )
 
 const (
@@ -280,6 +285,13 @@ func ViewPackageVersion(ctx *context.Context) {
 
 		ctx.Data["Groups"] = util.Sorted(groups.Values())
 		ctx.Data["Architectures"] = util.Sorted(architectures.Values())
+	case packages_model.TypeNix:
+		_, pub, err := nix_service.GetOrCreateKeyPair(ctx, pd.Owner)
+		if err != nil {
+			ctx.ServerError("GetOrCreateKeyPair", err)
+			return
+		}
+		ctx.Data["NixPublicKey"] = pub
 	case packages_model.TypeContainer:
 		imageMetadata := pd.Metadata
 		if versionSub != "" {
@@ -316,6 +328,26 @@ func ViewPackageVersion(ctx *context.Context) {
 	ctx.Data["LatestVersions"] = pvs
 	ctx.Data["TotalVersionCount"] = pvsTotal
 
+	if scan_service.IsScanned(pd.Package.Type) {
+		ps, err := packages_model.GetScanByVersionID(ctx, pd.Version.ID)
+		if err == nil {
+			vulns, err := packages_model.GetVulnerabilitiesByVersionID(ctx, pd.Version.ID)
+			if err != nil {
+				ctx.ServerError("GetVulnerabilitiesByVersionID", err)
+				return
+			}
+			report := convert.ToPackageVulnerabilityReport(ps, vulns)
+			ctx.Data["VulnerabilityReport"] = report
+			severities := slices.Clone(setting.PackageScanSeverities)
+			slices.Reverse(severities)
+			ctx.Data["VulnerabilitySeverities"] = severities
+			ctx.Data["TopVulnerabilities"] = report.Vulnerabilities[:min(len(report.Vulnerabilities), 10)]
+		} else if !errors.Is(err, packages_model.ErrPackageScanNotExist) {
+			ctx.ServerError("GetScanByVersionID", err)
+			return
+		}
+	}
+
 	ctx.Data["CanWritePackages"] = ctx.Package.AccessMode >= perm.AccessModeWrite || ctx.IsUserSiteAdmin()
 
 	hasRepositoryAccess := false
@@ -522,9 +554,46 @@ func DownloadPackageFile(ctx *context.Context) {
 
 	s, u, _, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
 	if err != nil {
-		ctx.ServerError("OpenFileForDownload", err)
+		if errors.Is(err, packages_service.ErrDownloadBlocked) {
+			ctx.HTTPError(http.StatusForbidden, err.Error())
+		} else {
+			ctx.ServerError("OpenFileForDownload", err)
+		}
 		return
 	}
 
 	packages_helper.ServePackageFile(ctx, s, u, pf)
 }
+
+// PackageSignatureBadge renders a badge showing if signatures are attached to a container image
+func PackageSignatureBadge(ctx *context.Context) {
+	pd := ctx.Package.Descriptor
+	if pd.Package.Type != packages_model.TypeContainer {
+		ctx.NotFound(nil)
+		return
+	}
+
+	status, err := container_service.GetSignatureStatus(ctx, pd.Owner.ID, pd.Package.LowerName, container_service.GetManifestDigest(pd))
+	if err != nil {
+		ctx.ServerError("GetSignatureStatus", err)
+		return
+	}
+
+	b := badge.GenerateBadge("signature", "unsigned", "#e05d44") // Red
+	if status.Has(container_module.ArtifactKindSignature) {
+		message := "signed"
+		if status.Has(container_module.ArtifactKindAttestation) {
+			message = "signed, attested"
+		}
+		b = badge.GenerateBadge("signature", message, "#4c1") // Green
+	}
+
+	ctx.Data["Badge"] = b
+	ctx.RespHeader().Set("Content-Type", "image/svg+xml")
+	switch ctx.FormString("style") {
+	case badge.StyleFlatSquare:
+		ctx.HTML(http.StatusOK, "shared/actions/runner_badge_flat-square")
+	default: // defaults to badge.StyleFlat
+		ctx.HTML(http.StatusOK, "shared/actions/runner_badge_flat")
+	}
+}
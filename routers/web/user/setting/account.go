@@ -230,6 +230,21 @@ func DeleteEmail(ctx *context.Context) {
 	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/account")
 }
 
+// ExportAccountData downloads the data of the signed in user as zip archive
+func ExportAccountData(ctx *context.Context) {
+	ctx.SetServeHeaders(&context.ServeHeaderOptions{
+		ContentType: "application/zip",
+		Filename:    ctx.Doer.Name + "-data.zip",
+	})
+	if err := user.ExportUserData(ctx, ctx.Doer, ctx.Resp); err != nil {
+		if !ctx.Written() {
+			ctx.ServerError("ExportUserData", err)
+			return
+		}
+		log.Error("Unable to export the data of %s: %v", ctx.Doer.Name, err)
+	}
+}
+
 // DeleteAccount render user suicide page and response for delete user himself
 func DeleteAccount(ctx *context.Context) {
 	if user_model.IsFeatureDisabledWithLoginType(ctx.Doer, setting.UserFeatureDeletion) {
@@ -28,6 +28,13 @@ func Notifications(ctx *context.Context) {
 	ctx.Data["PageIsSettingsNotifications"] = true
 	ctx.Data["EmailNotificationsPreference"] = ctx.Doer.EmailNotificationsPreference
 
+	emailDigest, err := user_model.GetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyEmailNotificationDigest)
+	if err != nil {
+		ctx.ServerError("GetUserSetting", err)
+		return
+	}
+	ctx.Data["EmailNotificationsDigest"] = emailDigest
+
 	actionsEmailPref, err := user_model.GetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyEmailNotificationGiteaActions, user_model.SettingEmailNotificationGiteaActionsFailureOnly)
 	if err != nil {
 		ctx.ServerError("GetUserSetting", err)
@@ -54,13 +61,31 @@ func NotificationsEmailPost(ctx *context.Context) {
 		ctx.Redirect(setting.AppSubURL + "/user/settings/notifications")
 		return
 	}
+	digest := ctx.FormString("digest")
+	if !(digest == "" ||
+		digest == user_model.SettingEmailNotificationDigestDaily ||
+		digest == user_model.SettingEmailNotificationDigestWeekly) {
+		ctx.Flash.Error(ctx.Tr("invalid_data", digest))
+		ctx.Redirect(setting.AppSubURL + "/user/settings/notifications")
+		return
+	}
 	opts := &user.UpdateOptions{
 		EmailNotificationsPreference: optional.Some(preference),
 	}
-	if err := user.UpdateUser(ctx, ctx.Doer, opts); err != nil {
+	err := user.UpdateUser(ctx, ctx.Doer, opts)
+	if err != nil {
 		ctx.ServerError("UpdateUser", err)
 		return
 	}
+	if digest == "" {
+		err = user_model.DeleteUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyEmailNotificationDigest)
+	} else {
+		err = user_model.SetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyEmailNotificationDigest, digest)
+	}
+	if err != nil {
+		ctx.ServerError("SetUserSetting", err)
+		return
+	}
 	ctx.Flash.Success(ctx.Tr("settings.email_preference_set_success"))
 	ctx.Redirect(setting.AppSubURL + "/user/settings/notifications")
 }
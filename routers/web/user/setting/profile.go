@@ -31,6 +31,7 @@ import (
 	"code.gitea.io/gitea/modules/web/middleware"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 	user_service "code.gitea.io/gitea/services/user"
 	"code.gitea.io/gitea/services/webtheme"
 )
@@ -158,6 +159,9 @@ func UpdateAvatarSetting(ctx *context.Context, form *forms.AvatarForm, ctxUser *
 			return errors.New(ctx.Locale.TrString("settings.uploaded_avatar_not_a_image"))
 		}
 		if err = user_service.UploadAvatar(ctx, ctxUser, data); err != nil {
+			if errors.Is(err, malwarescan_service.ErrMalwareFound) {
+				return errors.New(ctx.Locale.TrString("settings.uploaded_avatar_malware_found"))
+			}
 			return fmt.Errorf("UploadAvatar: %w", err)
 		}
 	} else if ctxUser.UseCustomAvatar && ctxUser.Avatar == "" {
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web/middleware"
+	"code.gitea.io/gitea/services/context"
+)
+
+const (
+	tplTerms         templates.TplName = "user/auth/terms"
+	tplTermsDocument templates.TplName = "user/auth/terms_document"
+)
+
+// Terms shows the current policy documents, the pending ones have to be accepted by the signed-in user
+func Terms(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("auth.terms")
+
+	current, err := user_model.GetCurrentTermsDocuments(ctx)
+	if err != nil {
+		ctx.ServerError("GetCurrentTermsDocuments", err)
+		return
+	}
+	pending, err := user_model.GetPendingTermsDocuments(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetPendingTermsDocuments", err)
+		return
+	}
+	pendingIDs := make(container.Set[int64], len(pending))
+	for _, d := range pending {
+		pendingIDs.Add(d.ID)
+	}
+
+	ctx.Data["TermsDocuments"] = current
+	ctx.Data["PendingTermsIDs"] = pendingIDs
+	if len(pending) > 0 {
+		deadline := user_model.TermsAcceptanceDeadline(pending, setting.Service.TermsAcceptanceGracePeriod)
+		ctx.Data["TermsAcceptanceOverdue"] = time.Now().After(deadline)
+	}
+	ctx.HTML(http.StatusOK, tplTerms)
+}
+
+// TermsPost records that the signed-in user has accepted the pending policy documents
+func TermsPost(ctx *context.Context) {
+	pending, err := user_model.GetPendingTermsDocuments(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetPendingTermsDocuments", err)
+		return
+	}
+
+	// every pending version must have been accepted, a new version might have been published after the page was shown
+	accepted := container.SetOf(ctx.FormStrings("document_id")...)
+	for _, d := range pending {
+		if !accepted.Contains(strconv.FormatInt(d.ID, 10)) {
+			ctx.Flash.Error(ctx.Tr("auth.terms_not_accepted"))
+			ctx.Redirect(setting.AppSubURL + "/user/terms")
+			return
+		}
+	}
+	if err := user_model.AcceptTermsDocuments(ctx, ctx.Doer.ID, pending); err != nil {
+		ctx.ServerError("AcceptTermsDocuments", err)
+		return
+	}
+
+	if redirectTo := ctx.GetSiteCookie("redirect_to"); redirectTo != "" {
+		middleware.DeleteRedirectToCookie(ctx.Resp)
+		ctx.RedirectToCurrentSite(redirectTo)
+		return
+	}
+	ctx.Redirect(setting.AppSubURL + "/")
+}
+
+// TermsDocument shows a version of a policy document, it's public because it has to be read before signing up
+func TermsDocument(ctx *context.Context) {
+	d, err := user_model.GetTermsDocumentByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetTermsDocumentByID", err)
+		}
+		return
+	}
+	ctx.Data["Title"] = d.Title
+	ctx.Data["TermsDocument"] = d
+	ctx.HTML(http.StatusOK, tplTermsDocument)
+}
@@ -6,10 +6,12 @@ package web
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/log"
@@ -23,6 +25,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/modules/web/middleware"
 	"code.gitea.io/gitea/modules/web/routing"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/routers/web/admin"
 	"code.gitea.io/gitea/routers/web/auth"
@@ -32,6 +35,7 @@ import (
 	"code.gitea.io/gitea/routers/web/feed"
 	"code.gitea.io/gitea/routers/web/healthcheck"
 	"code.gitea.io/gitea/routers/web/misc"
+	"code.gitea.io/gitea/routers/web/moderation"
 	"code.gitea.io/gitea/routers/web/org"
 	org_setting "code.gitea.io/gitea/routers/web/org/setting"
 	"code.gitea.io/gitea/routers/web/repo"
@@ -134,6 +138,38 @@ func webAuth(authMethod auth_service.Method) func(*context.Context) {
 	}
 }
 
+// checkTermsAcceptance reminds the signed-in user of the pending policy documents during the grace period and
+// redirects to the acceptance page once the grace period has expired
+func checkTermsAcceptance(ctx *context.Context) {
+	switch ctx.Req.URL.Path {
+	case "/user/terms", "/user/logout", "/user/events":
+		return
+	}
+	if !ctx.Doer.IsIndividual() || strings.HasPrefix(ctx.Req.URL.Path, "/-/terms/") {
+		return
+	}
+
+	pending, err := user_model.GetPendingTermsDocuments(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetPendingTermsDocuments", err)
+		return
+	} else if len(pending) == 0 {
+		return
+	}
+	deadline := user_model.TermsAcceptanceDeadline(pending, setting.Service.TermsAcceptanceGracePeriod)
+	if time.Now().Before(deadline) {
+		ctx.Data["TermsAcceptanceDeadline"] = deadline
+		return
+	}
+
+	if strings.HasPrefix(ctx.Req.UserAgent(), "git") {
+		ctx.HTTPError(http.StatusForbidden, ctx.Locale.TrString("auth.terms_acceptance_required"))
+		return
+	}
+	middleware.SetRedirectToCookie(ctx.Resp, setting.AppSubURL+ctx.Req.URL.RequestURI())
+	ctx.Redirect(setting.AppSubURL + "/user/terms")
+}
+
 // verifyAuthWithOptions checks authentication according to options
 func verifyAuthWithOptions(options *common.VerifyOptions) func(ctx *context.Context) {
 	return func(ctx *context.Context) {
@@ -151,7 +187,8 @@ func verifyAuthWithOptions(options *common.VerifyOptions) func(ctx *context.Cont
 				return
 			}
 
-			if ctx.Doer.MustChangePassword {
+			// an admin who impersonates the user can't change the password, so they aren't redirected to the form
+			if ctx.Doer.MustChangePassword && ctx.Data["Impersonator"] == nil {
 				if ctx.Req.URL.Path != "/user/settings/change_password" {
 					if strings.HasPrefix(ctx.Req.UserAgent(), "git") {
 						ctx.HTTPError(http.StatusUnauthorized, ctx.Locale.TrString("auth.must_change_password"))
@@ -170,6 +207,13 @@ func verifyAuthWithOptions(options *common.VerifyOptions) func(ctx *context.Cont
 				ctx.Redirect(setting.AppSubURL + "/")
 				return
 			}
+
+			// the consent has to be given by the user themselves, so an impersonating admin isn't redirected to the form
+			if ctx.Data["Impersonator"] == nil {
+				if checkTermsAcceptance(ctx); ctx.Written() {
+					return
+				}
+			}
 		}
 
 		// Redirect to dashboard (or alternate location) if user tries to visit any non-login page.
@@ -289,7 +333,7 @@ func Routes() *web.Router {
 
 	webRoutes := web.NewRouter()
 	webRoutes.Use(mid...)
-	webRoutes.Group("", func() { registerWebRoutes(webRoutes) }, common.BlockExpensive(), common.QoS())
+	webRoutes.Group("", func() { registerWebRoutes(webRoutes) }, common.BlockExpensive(), common.QoS(), common.MaintenanceMode())
 	routes.Mount("", webRoutes)
 	return routes
 }
@@ -399,6 +443,22 @@ func registerWebRoutes(m *web.Router) {
 		}
 	}
 
+	moderationEnabled := func(ctx *context.Context) {
+		if !setting.Moderation.Enabled {
+			ctx.HTTPError(http.StatusNotFound)
+			return
+		}
+	}
+
+	// an admin who impersonates the user mustn't add credentials or change the security settings, they would outlive
+	// the impersonation
+	notImpersonating := func(ctx *context.Context) {
+		if ctx.Data["Impersonator"] != nil {
+			ctx.HTTPError(http.StatusForbidden, ctx.Locale.TrString("impersonation_settings_forbidden"))
+			return
+		}
+	}
+
 	reqUnitAccess := func(unitType unit.Type, accessMode perm.AccessMode, ignoreGlobal bool) func(ctx *context.Context) {
 		return func(ctx *context.Context) {
 			// only check global disabled units when ignoreGlobal is false
@@ -434,6 +494,9 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/feishu/new", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksNewPost)
 		m.Post("/wechatwork/new", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksNewPost)
 		m.Post("/packagist/new", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksNewPost)
+		m.Post("/custom/new", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksNewPost)
+		m.Post("/kafka/new", web.Bind(forms.NewKafkaHookForm{}), repo_setting.KafkaHooksNewPost)
+		m.Post("/nats/new", web.Bind(forms.NewNatsHookForm{}), repo_setting.NatsHooksNewPost)
 	}
 
 	addWebhookEditRoutes := func() {
@@ -448,6 +511,9 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/feishu/{id}", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksEditPost)
 		m.Post("/wechatwork/{id}", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksEditPost)
 		m.Post("/packagist/{id}", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksEditPost)
+		m.Post("/custom/{id}", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksEditPost)
+		m.Post("/kafka/{id}", web.Bind(forms.NewKafkaHookForm{}), repo_setting.KafkaHooksEditPost)
+		m.Post("/nats/{id}", web.Bind(forms.NewNatsHookForm{}), repo_setting.NatsHooksEditPost)
 	}
 
 	addSettingsVariablesRoutes := func() {
@@ -477,6 +543,13 @@ func registerWebRoutes(m *web.Router) {
 		})
 	}
 
+	addSettingsArtifactsRoutes := func() {
+		m.Group("/artifacts", func() {
+			m.Get("", shared_actions.Artifacts)
+			m.Post("", web.Bind(forms.EditArtifactRetentionForm{}), shared_actions.ArtifactsRetentionPost)
+		})
+	}
+
 	// FIXME: not all routes need go through same middleware.
 	// Especially some AJAX requests, we can reduce middleware number to improve performance.
 
@@ -492,10 +565,14 @@ func registerWebRoutes(m *web.Router) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
 		m.Get("/passkey-endpoints", passkeyEndpoints)
+		m.Get("/terraform.json", packagesEnabled, terraform.ServiceDiscovery)
+		m.Get("/webhook-keys", misc.WebhookKeys)
 		m.Methods("GET, HEAD", "/*", public.FileHandlerFunc())
 	}, optionsCorsHandler())
 
 	m.Post("/-/markup", reqSignIn, web.Bind(structs.MarkupOption{}), misc.Markup)
+	m.Get("/-/markup/plantuml/{encoded}", reqSignIn, misc.PlantUMLDiagram)
+	m.Get("/-/terms/{id}", optSignIn, user.TermsDocument)
 
 	m.Group("/explore", func() {
 		m.Get("", func(ctx *context.Context) {
@@ -512,6 +589,12 @@ func registerWebRoutes(m *web.Router) {
 				return
 			}
 		}, explore.Code)
+		m.Get("/wiki", func(ctx *context.Context) {
+			if unit.TypeWiki.UnitGlobalDisabled() {
+				ctx.NotFound(nil)
+				return
+			}
+		}, explore.Wiki)
 		m.Get("/topics/search", explore.TopicSearch)
 	}, optExploreSignIn)
 
@@ -571,7 +654,7 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/grant", web.Bind(forms.GrantApplicationForm{}), auth.GrantApplicationOAuth)
 			// TODO manage redirection
 			m.Post("/authorize", web.Bind(forms.AuthorizationForm{}), auth.AuthorizeOAuth)
-		}, optSignInIgnoreCsrf, reqSignIn)
+		}, optSignInIgnoreCsrf, reqSignIn, notImpersonating)
 
 		m.Methods("GET, POST, OPTIONS", "/userinfo", optionsCorsHandler(), optSignInIgnoreCsrf, auth.InfoOAuth)
 		m.Methods("POST, OPTIONS", "/access_token", optionsCorsHandler(), web.Bind(forms.AccessTokenForm{}), optSignInIgnoreCsrf, auth.AccessTokenOAuth)
@@ -583,8 +666,8 @@ func registerWebRoutes(m *web.Router) {
 		m.Get("", user_setting.Profile)
 		m.Post("", web.Bind(forms.UpdateProfileForm{}), user_setting.ProfilePost)
 		m.Post("/update_preferences", user_setting.UpdatePreferences)
-		m.Get("/change_password", auth.MustChangePassword)
-		m.Post("/change_password", web.Bind(forms.MustChangePasswordForm{}), auth.MustChangePasswordPost)
+		m.Get("/change_password", notImpersonating, auth.MustChangePassword)
+		m.Post("/change_password", notImpersonating, web.Bind(forms.MustChangePasswordForm{}), auth.MustChangePasswordPost)
 		m.Post("/avatar", web.Bind(forms.AvatarForm{}), user_setting.AvatarPost)
 		m.Post("/avatar/delete", user_setting.DeleteAvatar)
 		m.Group("/account", func() {
@@ -592,7 +675,8 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/email", web.Bind(forms.AddEmailForm{}), user_setting.EmailPost)
 			m.Post("/email/delete", user_setting.DeleteEmail)
 			m.Post("/delete", user_setting.DeleteAccount)
-		})
+			m.Get("/export", user_setting.ExportAccountData)
+		}, notImpersonating)
 		m.Group("/appearance", func() {
 			m.Get("", user_setting.Appearance)
 			m.Post("/language", web.Bind(forms.UpdateLanguageForm{}), user_setting.UpdateUserLang)
@@ -623,7 +707,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Post("/toggle_visibility", security.ToggleOpenIDVisibility)
 			}, openIDSignInEnabled)
 			m.Post("/account_link", linkAccountEnabled, security.DeleteAccountLink)
-		})
+		}, notImpersonating)
 
 		m.Group("/applications", func() {
 			// oauth2 applications
@@ -640,11 +724,13 @@ func registerWebRoutes(m *web.Router) {
 			m.Combo("").Get(user_setting.Applications).
 				Post(web.Bind(forms.NewAccessTokenForm{}), user_setting.ApplicationsPost)
 			m.Post("/delete", user_setting.DeleteApplication)
-		})
+		}, notImpersonating)
 
-		m.Combo("/keys").Get(user_setting.Keys).
-			Post(web.Bind(forms.AddKeyForm{}), user_setting.KeysPost)
-		m.Post("/keys/delete", user_setting.DeleteKey)
+		m.Group("/keys", func() {
+			m.Combo("").Get(user_setting.Keys).
+				Post(web.Bind(forms.AddKeyForm{}), user_setting.KeysPost)
+			m.Post("/delete", user_setting.DeleteKey)
+		}, notImpersonating)
 		m.Group("/packages", func() {
 			m.Get("", user_setting.Packages)
 			m.Group("/rules", func() {
@@ -683,6 +769,7 @@ func registerWebRoutes(m *web.Router) {
 			m.Group("/{id}", func() {
 				m.Get("", repo_setting.WebHooksEdit)
 				m.Post("/replay/{uuid}", repo_setting.ReplayWebhook)
+				m.Post("/redeliver", repo_setting.RedeliverWebhooks)
 			})
 			addWebhookEditRoutes()
 		}, webhooksEnabled)
@@ -703,6 +790,9 @@ func registerWebRoutes(m *web.Router) {
 		m.Get("/forgot_password", auth.ForgotPasswd)
 		m.Post("/forgot_password", auth.ForgotPasswdPost)
 		m.Post("/logout", auth.SignOut)
+		m.Post("/impersonation/stop", reqSignIn, auth.StopImpersonation)
+		m.Post("/announcements/{id}/dismiss", reqSignIn, user.DismissAnnouncement)
+		m.Combo("/terms", reqSignIn).Get(user.Terms).Post(notImpersonating, user.TermsPost)
 		m.Get("/stopwatches", reqSignIn, user.GetStopwatches)
 		m.Get("/search_candidates", optExploreSignIn, user.SearchCandidates)
 		m.Group("/oauth2", func() {
@@ -756,6 +846,9 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/{userid}/delete", admin.DeleteUser)
 			m.Post("/{userid}/avatar", web.Bind(forms.AvatarForm{}), admin.AvatarPost)
 			m.Post("/{userid}/avatar/delete", admin.DeleteAvatar)
+			m.Post("/{userid}/impersonate", admin.ImpersonateUser)
+			m.Post("/{userid}/offboard", admin.OffboardUser)
+			m.Post("/{userid}/anonymize", admin.AnonymizeUser)
 		})
 
 		m.Group("/emails", func() {
@@ -786,6 +879,7 @@ func registerWebRoutes(m *web.Router) {
 			m.Group("/{id}", func() {
 				m.Get("", repo_setting.WebHooksEdit)
 				m.Post("/replay/{uuid}", repo_setting.ReplayWebhook)
+				m.Post("/redeliver", repo_setting.RedeliverWebhooks)
 			})
 			addWebhookEditRoutes()
 		}, webhooksEnabled)
@@ -808,6 +902,24 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/empty", admin.EmptyNotices)
 		})
 
+		m.Group("/approvals", func() {
+			m.Get("", admin.Approvals)
+			m.Post("/{id}/approve", admin.ApproveRequest)
+			m.Post("/{id}/reject", admin.RejectRequest)
+		})
+
+		m.Group("/quarantine", func() {
+			m.Get("", admin.Quarantine)
+			m.Post("/{id}/release", admin.ReleaseQuarantinedFile)
+			m.Post("/{id}/delete", admin.DeleteQuarantinedFile)
+		})
+
+		m.Group("/held-content", func() {
+			m.Get("", admin.HeldContent)
+			m.Post("/{id}/approve", admin.ApproveHeldContent)
+			m.Post("/{id}/reject", admin.RejectHeldContent)
+		})
+
 		m.Group("/applications", func() {
 			m.Get("", admin.Applications)
 			m.Post("/oauth2", web.Bind(forms.EditOAuth2ApplicationForm{}), admin.ApplicationsPost)
@@ -823,9 +935,22 @@ func registerWebRoutes(m *web.Router) {
 			addSettingsRunnersRoutes()
 			addSettingsVariablesRoutes()
 		})
-	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled))
+	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableMalwareScan", setting.MalwareScan.Enabled, "RequireSecondApproval", setting.Admin.RequireSecondApproval, "EnableSpamDetection", setting.SpamDetection.Enabled))
 	// ***** END: Admin *****
 
+	// ***** START: Moderation *****
+	m.Group("/-/moderation", func() {
+		m.Combo("/report").Get(moderation.NewReport).Post(moderation.NewReportPost)
+		m.Group("", func() {
+			m.Get("/reports", moderation.Reports)
+			m.Combo("/reports/{id}").Get(moderation.ViewReport).Post(moderation.ResolveReportPost)
+			m.Post("/reports/{id}/unhide", moderation.UnhideContentPost)
+			m.Combo("/templates").Get(moderation.Templates).Post(moderation.NewTemplatePost)
+			m.Post("/templates/{id}/delete", moderation.DeleteTemplate)
+		}, moderation.ReqModerator)
+	}, reqSignIn, moderationEnabled)
+	// ***** END: Moderation *****
+
 	m.Group("", func() {
 		m.Get("/{username}", user.UsernameSubRoute)
 		m.Methods("GET, OPTIONS", "/attachments/{uuid}", optionsCorsHandler(), repo.GetAttachment)
@@ -954,6 +1079,7 @@ func registerWebRoutes(m *web.Router) {
 					m.Group("/{id}", func() {
 						m.Get("", repo_setting.WebHooksEdit)
 						m.Post("/replay/{uuid}", repo_setting.ReplayWebhook)
+						m.Post("/redeliver", repo_setting.RedeliverWebhooks)
 					})
 					addWebhookEditRoutes()
 				}, webhooksEnabled)
@@ -971,6 +1097,7 @@ func registerWebRoutes(m *web.Router) {
 					addSettingsRunnersRoutes()
 					addSettingsSecretsRoutes()
 					addSettingsVariablesRoutes()
+					addSettingsArtifactsRoutes()
 				}, actions.MustEnableActions)
 
 				m.Post("/rename", web.Bind(forms.RenameOrgForm{}), org.SettingsRenamePost)
@@ -996,6 +1123,9 @@ func registerWebRoutes(m *web.Router) {
 					})
 				}, packagesEnabled)
 
+				m.Combo("/license_policy").Get(org.LicensePolicy).Post(org.LicensePolicyPost)
+				m.Combo("/signoff_policy").Get(org.SignOffPolicy).Post(org.SignOffPolicyPost)
+
 				m.Group("/blocked_users", func() {
 					m.Get("", org.BlockedUsers)
 					m.Post("", web.Bind(forms.BlockUserForm{}), org.BlockedUsersPost)
@@ -1023,6 +1153,7 @@ func registerWebRoutes(m *web.Router) {
 					m.Get("/versions", user.ListPackageVersions)
 					m.Group("/{version}", func() {
 						m.Get("", user.ViewPackageVersion)
+						m.Get("/badge.svg", user.PackageSignatureBadge)
 						m.Get("/{version_sub}", user.ViewPackageVersion)
 						m.Get("/files/{fileid}", user.DownloadPackageFile)
 						m.Group("/settings", func() {
@@ -1122,6 +1253,12 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/{id}", web.Bind(forms.ProtectTagForm{}), context.RepoMustNotBeArchived(), repo_setting.EditProtectedTagPost)
 		})
 
+		m.Group("/wiki", func() {
+			m.Get("", repo_setting.ProtectedWikiPages)
+			m.Post("", web.Bind(forms.ProtectWikiPageForm{}), context.RepoMustNotBeArchived(), repo_setting.NewProtectedWikiPagePost)
+			m.Post("/delete", context.RepoMustNotBeArchived(), repo_setting.DeleteProtectedWikiPagePost)
+		})
+
 		m.Group("/hooks/git", func() {
 			m.Get("", repo_setting.GitHooks)
 			m.Combo("/{name}").Get(repo_setting.GitHooksEdit).
@@ -1136,6 +1273,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("", repo_setting.WebHooksEdit)
 				m.Post("/test", repo_setting.TestWebhook)
 				m.Post("/replay/{uuid}", repo_setting.ReplayWebhook)
+				m.Post("/redeliver", repo_setting.RedeliverWebhooks)
 			})
 			addWebhookEditRoutes()
 		}, webhooksEnabled)
@@ -1164,6 +1302,7 @@ func registerWebRoutes(m *web.Router) {
 			addSettingsRunnersRoutes()
 			addSettingsSecretsRoutes()
 			addSettingsVariablesRoutes()
+			addSettingsArtifactsRoutes()
 		}, actions.MustEnableActions)
 		// the follow handler must be under "settings", otherwise this incomplete repo can't be accessed
 		m.Group("/migrate", func() {
@@ -1213,7 +1352,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("/list", repo.GetContentHistoryList)
 				m.Get("/detail", repo.GetContentHistoryDetail)
 			})
-		})
+		}, repo.MustNotBeHiddenIssue)
 	}
 	// FIXME: many "pulls" requests are sent to "issues" endpoints correctly, so the issue endpoints have to tolerate pull request permissions at the moment
 	m.Group("/{username}/{reponame}/{type:issues}", addIssuesPullsViewRoutes, optSignIn, context.RepoAssignment, context.RequireUnitReader(unit.TypeIssues, unit.TypePullRequests))
@@ -1231,7 +1370,7 @@ func registerWebRoutes(m *web.Router) {
 	m.Group("/{username}/{reponame}/{type:issues}", func() {
 		// these handlers also check unit permissions internally
 		m.Get("", repo.Issues)
-		m.Get("/{index}", repo.ViewIssue) // also do pull-request redirection (".../issues/{PR-number}" -> ".../pulls/{PR-number}")
+		m.Get("/{index}", repo.MustNotBeHiddenIssue, repo.ViewIssue) // also do pull-request redirection (".../issues/{PR-number}" -> ".../pulls/{PR-number}")
 	}, optSignIn, context.RepoAssignment, context.RequireUnitReader(unit.TypeIssues, unit.TypePullRequests, unit.TypeExternalTracker))
 	// end "/{username}/{reponame}": issue list, issue view (pull-request redirection), external tracker
 
@@ -1243,6 +1382,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("/choose", repo.NewIssueChooseTemplate)
 			})
 			m.Get("/search", repo.SearchRepoIssuesJSON)
+			m.Get("/similar", repo.SimilarIssues)
 		}, reqUnitIssuesReader)
 
 		addIssuesPullsUpdateRoutes := func() {
@@ -1251,6 +1391,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Post("/title", repo.UpdateIssueTitle)
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", repo.UpdateIssueDeadline)
+				m.Post("/fields", repo.UpdateIssueFieldValues)
 				m.Post("/watch", repo.IssueWatch)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/pin", reqRepoAdmin, repo.IssuePinOrUnpin)
@@ -1259,6 +1400,10 @@ func registerWebRoutes(m *web.Router) {
 					m.Post("/add", repo.AddDependency)
 					m.Post("/delete", repo.RemoveDependency)
 				})
+				m.Group("/sub_issues", func() {
+					m.Post("/add", repo.AddSubIssue)
+					m.Post("/remove", repo.RemoveSubIssue)
+				})
 				m.Combo("/comments").Post(repo.MustAllowUserComment, web.Bind(forms.CreateCommentForm{}), repo.NewComment)
 				m.Group("/times", func() {
 					m.Post("/add", web.Bind(forms.AddTimeManuallyForm{}), repo.AddTimeManually)
@@ -1275,7 +1420,7 @@ func registerWebRoutes(m *web.Router) {
 				m.Post("/unlock", reqRepoIssuesOrPullsWriter, repo.UnlockIssue)
 				m.Post("/delete", reqRepoAdmin, repo.DeleteIssue)
 				m.Post("/content-history/soft-delete", repo.SoftDeleteContentHistory)
-			})
+			}, repo.MustNotBeHiddenIssue)
 
 			m.Post("/attachments", repo.UploadIssueAttachment)
 			m.Post("/attachments/remove", repo.DeleteAttachment)
@@ -1288,6 +1433,8 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/delete", reqRepoAdmin, repo.BatchDeleteIssues)
 			m.Delete("/unpin/{index}", reqRepoAdmin, repo.IssueUnpin)
 			m.Post("/move_pin", reqRepoAdmin, repo.IssuePinMove)
+			m.Post("/saved_searches", repo.NewIssueSavedSearch)
+			m.Post("/saved_searches/{id}/delete", repo.DeleteIssueSavedSearch)
 		}
 		// FIXME: many "pulls" requests are sent to "issues" endpoints incorrectly, so the issue endpoints have to tolerate pull request permissions at the moment
 		m.Group("/{type:issues}", addIssuesPullsUpdateRoutes, context.RequireUnitReader(unit.TypeIssues, unit.TypePullRequests), context.RepoMustNotBeArchived())
@@ -1321,7 +1468,7 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/dismiss_review", reqRepoAdmin, web.Bind(forms.DismissReviewForm{}), repo.DismissReview)
 			m.Post("/resolve_conversation", repo.SetShowOutdatedComments, repo.UpdateResolveConversation)
 		}, reqUnitPullsReader)
-		m.Post("/pull/{index}/target_branch", reqUnitPullsReader, repo.UpdatePullRequestTarget)
+		m.Post("/pull/{index}/target_branch", reqUnitPullsReader, repo.MustNotBeHiddenIssue, repo.UpdatePullRequestTarget)
 	}, reqSignIn, context.RepoAssignment, context.RepoMustNotBeArchived())
 	// end "/{username}/{reponame}": create or edit issues, pulls, labels, milestones
 
@@ -1400,6 +1547,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Group("/releases", func() {
 			m.Get("/new", repo.NewRelease)
 			m.Post("/new", web.Bind(forms.NewReleaseForm{}), repo.NewReleasePost)
+			m.Post("/generate-notes", repo.GenerateReleaseNotes)
 			m.Post("/delete", repo.DeleteRelease)
 			m.Post("/attachments", repo.UploadReleaseAttachment)
 			m.Post("/attachments/remove", repo.DeleteAttachment)
@@ -1553,7 +1701,7 @@ func registerWebRoutes(m *web.Router) {
 					m.Post("/submit", web.Bind(forms.SubmitReviewForm{}), repo.SubmitReview)
 				}, context.RepoMustNotBeArchived())
 			})
-		})
+		}, repo.MustNotBeHiddenIssue)
 	}, optSignIn, context.RepoAssignment, repo.MustAllowPulls, reqUnitPullsReader)
 	// end "/{username}/{reponame}/pulls/{index}": repo pull request
 
@@ -1634,6 +1782,11 @@ func registerWebRoutes(m *web.Router) {
 		m.Get("/blob/*", repo.RedirectRepoBlobToCommit) // redirect "/owner/repo/blob/*" requests to "/owner/repo/src/commit/*"
 
 		m.Get("/forks", repo.Forks)
+		m.Group("/dependencies", func() {
+			m.Get("", repo.Dependencies)
+			m.Get("/dependents", repo.Dependents)
+		}, repo.MustBeNotEmpty)
+		m.Get("/symbols", repo.MustBeNotEmpty, repo.Symbols)
 		m.Get("/commit/{sha:([a-f0-9]{7,64})}.{ext:patch|diff}", repo.MustBeNotEmpty, repo.RawDiff)
 		m.Post("/lastcommit/*", context.RepoRefByType(git.RefTypeCommit), repo.LastCommit)
 	}, optSignIn, context.RepoAssignment, reqUnitCodeReader)
@@ -1643,6 +1796,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Get("/stars", starsEnabled, repo.Stars)
 		m.Get("/watchers", repo.Watchers)
 		m.Get("/search", reqUnitCodeReader, repo.Search)
+		m.Get("/search/wiki", reqUnitWikiReader, repo.SearchWiki)
 		m.Post("/action/{action:star|unstar}", reqSignIn, starsEnabled, repo.ActionStar)
 		m.Post("/action/{action:watch|unwatch}", reqSignIn, repo.ActionWatch)
 		m.Post("/action/{action:accept_transfer|reject_transfer}", reqSignIn, repo.ActionTransfer)
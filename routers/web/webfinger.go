@@ -10,10 +10,12 @@ import (
 	"strconv"
 	"strings"
 
+	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
+	federation_service "code.gitea.io/gitea/services/federation"
 )
 
 // https://datatracker.ietf.org/doc/html/draft-ietf-appsawg-webfinger-14#section-4.4
@@ -65,12 +67,33 @@ func WebfingerQuery(ctx *context.Context) {
 		if u != nil && u.KeepEmailPrivate {
 			err = user_model.ErrUserNotExist{}
 		}
+	case "http", "https":
+		// the profile page of a user or the home page of a repository
+		if resource.Host != appURL.Host || !strings.HasPrefix(resource.Path, appURL.Path) {
+			ctx.HTTPError(http.StatusBadRequest)
+			return
+		}
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(resource.Path, appURL.Path), "/"), "/")
+		switch len(parts) {
+		case 1:
+			u, err = user_model.GetUserByName(ctx, parts[0])
+		case 2:
+			var repo *repo_model.Repository
+			repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, parts[0], parts[1])
+			if err == nil {
+				webfingerRepository(ctx, repo)
+				return
+			}
+		default:
+			ctx.HTTPError(http.StatusBadRequest)
+			return
+		}
 	default:
 		ctx.HTTPError(http.StatusBadRequest)
 		return
 	}
 	if err != nil {
-		if user_model.IsErrUserNotExist(err) {
+		if user_model.IsErrUserNotExist(err) || repo_model.IsErrRepoNotExist(err) {
 			ctx.HTTPError(http.StatusNotFound)
 		} else {
 			log.Error("Error getting user: %s Error: %v", resource.Opaque, err)
@@ -120,3 +143,35 @@ func WebfingerQuery(ctx *context.Context) {
 		Links:   links,
 	})
 }
+
+// webfingerRepository returns the information about a public repository, which is a ForgeFed Repository actor
+func webfingerRepository(ctx *context.Context, repo *repo_model.Repository) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		log.Error("Error loading owner of repository %d: %v", repo.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError)
+		return
+	}
+	if !federation_service.IsRepoFederated(repo) {
+		ctx.HTTPError(http.StatusNotFound)
+		return
+	}
+
+	actorIRI := federation_service.RepoActorIRI(repo)
+	ctx.Resp.Header().Add("Access-Control-Allow-Origin", "*")
+	ctx.JSON(http.StatusOK, &webfingerJRD{
+		Subject: repo.HTMLURL(ctx),
+		Aliases: []string{actorIRI},
+		Links: []*webfingerLink{
+			{
+				Rel:  "http://webfinger.net/rel/profile-page",
+				Type: "text/html",
+				Href: repo.HTMLURL(ctx),
+			},
+			{
+				Rel:  "self",
+				Type: "application/activity+json",
+				Href: actorIRI,
+			},
+		},
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// EnforceArtifactRetention makes the artifacts which violate the retention policy of their repository expire,
+// their files are deleted by the artifacts cleanup.
+func EnforceArtifactRetention(ctx context.Context) error {
+	repos, err := actions_model.FindReposWithArtifacts(ctx)
+	if err != nil {
+		return err
+	}
+	for repoID, ownerID := range repos {
+		policy, err := actions_model.GetEffectiveArtifactRetention(ctx, ownerID, repoID)
+		if err != nil {
+			return err
+		} else if policy == nil {
+			continue
+		}
+		if err := enforceRepoArtifactRetention(ctx, repoID, policy); err != nil {
+			log.Error("Enforce artifact retention of repository %d: %v", repoID, err)
+		}
+	}
+	return nil
+}
+
+func enforceRepoArtifactRetention(ctx context.Context, repoID int64, policy *actions_model.ActionArtifactRetention) error {
+	arts, err := actions_model.ListConfirmedArtifactsOfRepo(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	expired := artifactsViolatingRetention(arts, policy)
+	if policy.KeepLastN > 0 {
+		runIDs := make(container.Set[int64])
+		for _, art := range arts {
+			runIDs.Add(art.RunID)
+		}
+		var runs []*actions_model.ActionRun
+		if err := db.GetEngine(ctx).Cols("id", "workflow_id").In("id", runIDs.Values()).Find(&runs); err != nil {
+			return fmt.Errorf("find runs: %w", err)
+		}
+		workflows := make(map[int64]string, len(runs))
+		for _, run := range runs {
+			workflows[run.ID] = run.WorkflowID
+		}
+		expireOldRunsArtifacts(arts, workflows, policy.KeepLastN, expired)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	log.Info("Artifact retention policy of repository %d expires %d artifacts", repoID, len(expired))
+	return actions_model.ExpireArtifactsByID(ctx, expired.Values())
+}
+
+// artifactsViolatingRetention returns the artifacts which are too old or exceed the total size, arts are sorted from the newest
+func artifactsViolatingRetention(arts []*actions_model.ActionArtifact, policy *actions_model.ActionArtifactRetention) container.Set[int64] {
+	expired := make(container.Set[int64])
+	oldest := timeutil.TimeStampNow() - timeutil.TimeStamp(policy.MaxAgeDays*timeutil.Day)
+	var totalSize int64
+	for _, art := range arts {
+		if policy.MaxAgeDays > 0 && art.CreatedUnix < oldest {
+			expired.Add(art.ID)
+			continue
+		}
+		totalSize += art.FileCompressedSize
+		if policy.MaxTotalSize > 0 && totalSize > policy.MaxTotalSize {
+			expired.Add(art.ID)
+		}
+	}
+	return expired
+}
+
+// expireOldRunsArtifacts adds the artifacts of the runs which are not among the latest keepLastN runs of their workflow
+func expireOldRunsArtifacts(arts []*actions_model.ActionArtifact, workflows map[int64]string, keepLastN int64, expired container.Set[int64]) {
+	keptRuns := make(map[string]container.Set[int64])
+	for _, art := range arts {
+		workflowID := workflows[art.RunID]
+		if keptRuns[workflowID] == nil {
+			keptRuns[workflowID] = make(container.Set[int64])
+		}
+		kept := keptRuns[workflowID]
+		if !kept.Contains(art.RunID) && int64(len(kept)) >= keepLastN {
+			expired.Add(art.ID)
+			continue
+		}
+		kept.Add(art.RunID)
+	}
+}
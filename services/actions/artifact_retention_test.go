@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactsViolatingRetention(t *testing.T) {
+	now := timeutil.TimeStampNow()
+	// sorted from the newest like ListConfirmedArtifactsOfRepo
+	arts := []*actions_model.ActionArtifact{
+		{ID: 5, RunID: 30, FileCompressedSize: 100, CreatedUnix: now},
+		{ID: 4, RunID: 30, FileCompressedSize: 100, CreatedUnix: now},
+		{ID: 3, RunID: 20, FileCompressedSize: 100, CreatedUnix: now - 2*timeutil.Day},
+		{ID: 2, RunID: 11, FileCompressedSize: 100, CreatedUnix: now - 5*timeutil.Day},
+		{ID: 1, RunID: 10, FileCompressedSize: 100, CreatedUnix: now - 10*timeutil.Day},
+	}
+
+	expired := artifactsViolatingRetention(arts, &actions_model.ActionArtifactRetention{MaxAgeDays: 3})
+	assert.ElementsMatch(t, []int64{1, 2}, expired.Values())
+
+	expired = artifactsViolatingRetention(arts, &actions_model.ActionArtifactRetention{MaxTotalSize: 250})
+	assert.ElementsMatch(t, []int64{1, 2, 3}, expired.Values())
+
+	expired = make(container.Set[int64])
+	workflows := map[int64]string{30: "build.yml", 20: "test.yml", 11: "build.yml", 10: "test.yml"}
+	expireOldRunsArtifacts(arts, workflows, 1, expired)
+	assert.ElementsMatch(t, []int64{1, 2}, expired.Values())
+}
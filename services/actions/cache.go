@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// abandonedCacheTimeout is the time after which a cache whose upload hasn't been committed is evicted
+const abandonedCacheTimeout = 24 * time.Hour
+
+// CacheScopes returns the scope the run saves its caches to and the scopes it can restore caches from, in order of precedence.
+// Like GitHub, a run restores the caches of its own ref, then of the base branch of its pull request, then of the default branch.
+func CacheScopes(ctx context.Context, run *actions_model.ActionRun) (saveScope string, restoreScopes []string, err error) {
+	if err := run.LoadRepo(ctx); err != nil {
+		return "", nil, err
+	}
+
+	saveScope = run.Ref
+	var baseRef string
+	if payload, err := run.GetPullRequestEventPayload(); err == nil && payload.PullRequest != nil && payload.PullRequest.Base != nil {
+		baseRef = git.BranchPrefix + payload.PullRequest.Base.Ref
+		// pull_request_target runs in the context of the base branch
+		if run.TriggerEvent == actions_module.GithubEventPullRequestTarget {
+			saveScope = baseRef
+		}
+	}
+
+	for _, scope := range []string{saveScope, baseRef, git.BranchPrefix + run.Repo.DefaultBranch} {
+		if scope != "" && scope != git.BranchPrefix && !slices.Contains(restoreScopes, scope) {
+			restoreScopes = append(restoreScopes, scope)
+		}
+	}
+	return saveScope, restoreScopes, nil
+}
+
+// DeleteCache deletes the cache and its archive
+func DeleteCache(ctx context.Context, c *actions_model.ActionCache) error {
+	if err := actions_model.DeleteCaches(ctx, []int64{c.ID}); err != nil {
+		return err
+	}
+	deleteCacheFiles(c)
+	return nil
+}
+
+func deleteCacheFiles(c *actions_model.ActionCache) {
+	if c.Complete {
+		if err := storage.ActionsCache.Delete(c.StoragePath()); err != nil {
+			log.Error("Delete archive of cache %d: %v", c.ID, err)
+		}
+		return
+	}
+	if err := storage.ActionsCache.IterateObjects(c.ChunksStorageDir(), func(path string, obj storage.Object) error {
+		_ = obj.Close()
+		return storage.ActionsCache.Delete(path)
+	}); err != nil {
+		log.Error("Delete chunks of cache %d: %v", c.ID, err)
+	}
+}
+
+// EvictCaches deletes the abandoned uploads, the caches unused for longer than CACHE_UNUSED_TTL,
+// and the least recently used caches of the repositories exceeding CACHE_MAX_SIZE_PER_REPO
+func EvictCaches(ctx context.Context) error {
+	if !setting.Actions.CacheEnabled {
+		return nil
+	}
+	now := time.Now()
+	caches, err := actions_model.FindCachesToEvict(ctx,
+		timeutil.TimeStamp(now.Add(-abandonedCacheTimeout).Unix()),
+		timeutil.TimeStamp(now.Add(-setting.Actions.CacheUnusedTTL).Unix()),
+		setting.Actions.CacheMaxSizePerRepo,
+	)
+	if err != nil {
+		return err
+	}
+	if len(caches) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(caches))
+	for _, c := range caches {
+		ids = append(ids, c.ID)
+	}
+	if err := actions_model.DeleteCaches(ctx, ids); err != nil {
+		return err
+	}
+	for _, c := range caches {
+		deleteCacheFiles(c)
+	}
+	log.Info("Evicted %d actions caches", len(caches))
+	return nil
+}
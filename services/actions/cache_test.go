@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheScopes(t *testing.T) {
+	repo := &repo_model.Repository{ID: 1, DefaultBranch: "main"}
+	prPayload := `{"pull_request":{"base":{"ref":"release"},"head":{"ref":"feature"}}}`
+
+	t.Run("Push", func(t *testing.T) {
+		run := &actions_model.ActionRun{Repo: repo, Ref: "refs/heads/feature", Event: webhook_module.HookEventPush, TriggerEvent: "push"}
+		save, restore, err := CacheScopes(t.Context(), run)
+		require.NoError(t, err)
+		assert.Equal(t, "refs/heads/feature", save)
+		assert.Equal(t, []string{"refs/heads/feature", "refs/heads/main"}, restore)
+	})
+
+	t.Run("DefaultBranch", func(t *testing.T) {
+		run := &actions_model.ActionRun{Repo: repo, Ref: "refs/heads/main", Event: webhook_module.HookEventPush, TriggerEvent: "push"}
+		save, restore, err := CacheScopes(t.Context(), run)
+		require.NoError(t, err)
+		assert.Equal(t, "refs/heads/main", save)
+		assert.Equal(t, []string{"refs/heads/main"}, restore)
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		run := &actions_model.ActionRun{
+			Repo: repo, Ref: "refs/pull/3/head", Event: webhook_module.HookEventPullRequest,
+			TriggerEvent: actions_module.GithubEventPullRequest, EventPayload: prPayload,
+		}
+		save, restore, err := CacheScopes(t.Context(), run)
+		require.NoError(t, err)
+		assert.Equal(t, "refs/pull/3/head", save)
+		assert.Equal(t, []string{"refs/pull/3/head", "refs/heads/release", "refs/heads/main"}, restore)
+	})
+
+	t.Run("PullRequestTarget", func(t *testing.T) {
+		run := &actions_model.ActionRun{
+			Repo: repo, Ref: "refs/heads/release", Event: webhook_module.HookEventPullRequest,
+			TriggerEvent: actions_module.GithubEventPullRequestTarget, EventPayload: prPayload,
+		}
+		save, restore, err := CacheScopes(t.Context(), run)
+		require.NoError(t, err)
+		assert.Equal(t, "refs/heads/release", save)
+		assert.Equal(t, []string{"refs/heads/release", "refs/heads/main"}, restore)
+	})
+}
@@ -23,11 +23,21 @@ import (
 
 // Cleanup removes expired actions logs, data, artifacts and used ephemeral runners
 func Cleanup(ctx context.Context) error {
+	// expire the artifacts violating the retention policies, they are deleted with the other expired artifacts
+	if err := EnforceArtifactRetention(ctx); err != nil {
+		return fmt.Errorf("enforce artifact retention: %w", err)
+	}
+
 	// clean up expired artifacts
 	if err := CleanupArtifacts(ctx); err != nil {
 		return fmt.Errorf("cleanup artifacts: %w", err)
 	}
 
+	// evict the unused caches and the caches exceeding the size limit
+	if err := EvictCaches(ctx); err != nil {
+		return fmt.Errorf("evict caches: %w", err)
+	}
+
 	// clean up old logs
 	if err := CleanupExpiredLogs(ctx); err != nil {
 		return fmt.Errorf("cleanup logs: %w", err)
@@ -38,6 +48,11 @@ func Cleanup(ctx context.Context) error {
 		return fmt.Errorf("cleanup old ephemeral runners: %w", err)
 	}
 
+	// clean up used and expired just-in-time runner tokens
+	if _, err := actions_model.DeleteExpiredRunnerJITTokens(ctx); err != nil {
+		return fmt.Errorf("cleanup runner tokens: %w", err)
+	}
+
 	return nil
 }
 
@@ -44,6 +44,7 @@ func notifyWorkflowJobStatusUpdate(ctx context.Context, jobs []*actions_model.Ac
 		}
 		job := jobs[0]
 		notify_service.WorkflowRunStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job.Run)
+		ReleaseConcurrencyGroups(ctx, jobs)
 	}
 }
 
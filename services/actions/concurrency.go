@@ -0,0 +1,167 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+
+	"github.com/nektos/act/pkg/jobparser"
+	"github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// readWorkflowRawConcurrency reads the workflow level `concurrency`, which can be a group name or a mapping
+func readWorkflowRawConcurrency(content []byte) (*model.RawConcurrency, error) {
+	var wf struct {
+		Concurrency yaml.Node `yaml:"concurrency"`
+	}
+	if err := yaml.Unmarshal(content, &wf); err != nil {
+		return nil, err
+	}
+	switch wf.Concurrency.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		return &model.RawConcurrency{Group: wf.Concurrency.Value}, nil
+	default:
+		rc := &model.RawConcurrency{}
+		if err := wf.Concurrency.Decode(rc); err != nil {
+			return nil, err
+		}
+		return rc, nil
+	}
+}
+
+// PrepareRunConcurrency evaluates the workflow and job level `concurrency` of a new run before it's inserted.
+// The superseded runs and jobs of the same groups are cancelled: all of them with `cancel-in-progress`, otherwise
+// only the pending ones, so there is at most one run waiting for each group.
+func PrepareRunConcurrency(ctx context.Context, run *actions_model.ActionRun, content []byte, workflows []*jobparser.SingleWorkflow, vars map[string]string, inputs map[string]any) error {
+	gitCtx := GenerateGiteaContext(run, nil)
+
+	rc, err := readWorkflowRawConcurrency(content)
+	if err != nil {
+		return fmt.Errorf("read concurrency: %w", err)
+	}
+	if rc != nil && rc.Group != "" {
+		group, cancelInProgress, err := jobparser.EvaluateConcurrency(rc, "", nil, gitCtx, nil, vars, inputs)
+		if err != nil {
+			return fmt.Errorf("evaluate concurrency: %w", err)
+		}
+		run.ConcurrencyGroup, run.ConcurrencyCancel = group, cancelInProgress
+	}
+
+	var cancelledJobs []*actions_model.ActionRunJob
+	if run.ConcurrencyGroup != "" {
+		var jobs []*actions_model.ActionRunJob
+		if run.ConcurrencyCancel {
+			jobs, err = actions_model.CancelConcurrentRuns(ctx, run.RepoID, run.ConcurrencyGroup)
+		} else {
+			jobs, err = actions_model.CancelPendingConcurrentRuns(ctx, run.RepoID, run.ConcurrencyGroup)
+		}
+		if err != nil {
+			return fmt.Errorf("cancel runs of concurrency group %q: %w", run.ConcurrencyGroup, err)
+		}
+		cancelledJobs = append(cancelledJobs, jobs...)
+	}
+
+	for _, swf := range workflows {
+		id, job := swf.Job()
+		if job == nil || job.RawConcurrency == nil || job.RawConcurrency.Group == "" {
+			continue
+		}
+		jobCtx := GiteaContext{}
+		for k, v := range gitCtx {
+			jobCtx[k] = v
+		}
+		jobCtx["job"] = id
+		group, cancelInProgress, err := jobparser.EvaluateConcurrency(job.RawConcurrency, id, job, jobCtx, nil, vars, inputs)
+		if err != nil {
+			return fmt.Errorf("evaluate concurrency of job %q: %w", id, err)
+		}
+		// InsertRun takes the evaluated values from the job
+		job.RawConcurrency = &model.RawConcurrency{Group: group, CancelInProgress: strconv.FormatBool(cancelInProgress)}
+		if err := swf.SetJob(id, job); err != nil {
+			return err
+		}
+		if cancelInProgress && group != "" {
+			jobs, err := actions_model.CancelConcurrentJobs(ctx, run.RepoID, group)
+			if err != nil {
+				return fmt.Errorf("cancel jobs of concurrency group %q: %w", group, err)
+			}
+			cancelledJobs = append(cancelledJobs, jobs...)
+		}
+	}
+
+	notifyWorkflowJobStatusUpdate(ctx, cancelledJobs)
+	return nil
+}
+
+// ReleaseConcurrencyGroups lets the runs waiting for the concurrency groups of the done jobs continue
+func ReleaseConcurrencyGroups(ctx context.Context, jobs []*actions_model.ActionRunJob) {
+	runIDs := make(container.Set[int64])
+	doneRuns := make(map[int64]int64) // run id => repo id
+	for _, job := range jobs {
+		if !job.Status.IsDone() {
+			continue
+		}
+		doneRuns[job.RunID] = job.RepoID
+		if job.ConcurrencyGroup == "" {
+			continue
+		}
+		if busy, err := actions_model.IsJobConcurrencyGroupBusy(ctx, job.RepoID, job.ConcurrencyGroup, 0); err != nil {
+			log.Error("IsJobConcurrencyGroupBusy: %v", err)
+			continue
+		} else if busy {
+			continue
+		}
+		blocked, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{
+			RepoID:           job.RepoID,
+			ConcurrencyGroup: job.ConcurrencyGroup,
+			Statuses:         []actions_model.Status{actions_model.StatusBlocked},
+		})
+		if err != nil {
+			log.Error("Find blocked jobs of concurrency group %q: %v", job.ConcurrencyGroup, err)
+			continue
+		}
+		for _, b := range blocked {
+			runIDs.Add(b.RunID)
+		}
+	}
+
+	for runID, repoID := range doneRuns {
+		run, err := actions_model.GetRunByRepoAndID(ctx, repoID, runID)
+		if err != nil {
+			log.Error("GetRunByRepoAndID: %v", err)
+			continue
+		}
+		if run.ConcurrencyGroup == "" || !run.Status.IsDone() {
+			continue
+		}
+		next, err := db.Find[actions_model.ActionRun](ctx, actions_model.FindRunOptions{
+			RepoID:           run.RepoID,
+			ConcurrencyGroup: run.ConcurrencyGroup,
+			Status:           []actions_model.Status{actions_model.StatusBlocked},
+		})
+		if err != nil {
+			log.Error("Find blocked runs of concurrency group %q: %v", run.ConcurrencyGroup, err)
+			continue
+		}
+		for _, r := range next {
+			runIDs.Add(r.ID)
+		}
+	}
+
+	for runID := range runIDs {
+		if err := EmitJobsIfReady(runID); err != nil {
+			log.Error("Emit ready jobs of run %d: %v", runID, err)
+		}
+	}
+}
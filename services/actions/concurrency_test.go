@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWorkflowRawConcurrency(t *testing.T) {
+	rc, err := readWorkflowRawConcurrency([]byte("on: push\njobs: {}\n"))
+	require.NoError(t, err)
+	assert.Nil(t, rc)
+
+	rc, err = readWorkflowRawConcurrency([]byte("on: push\nconcurrency: ci-${{ gitea.ref }}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &model.RawConcurrency{Group: "ci-${{ gitea.ref }}"}, rc)
+
+	rc, err = readWorkflowRawConcurrency([]byte("on: push\nconcurrency:\n  group: deploy\n  cancel-in-progress: true\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &model.RawConcurrency{Group: "deploy", CancelInProgress: "true"}, rc)
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readJobEnvironments reads the `environment` of the jobs, which can be a name or a mapping with the name and the url
+func readJobEnvironments(content []byte) (map[string]string, error) {
+	var wf struct {
+		Jobs map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &wf); err != nil {
+		return nil, err
+	}
+	envs := make(map[string]string)
+	for id, job := range wf.Jobs {
+		var name string
+		switch job.Environment.Kind {
+		case 0:
+			continue
+		case yaml.ScalarNode:
+			name = job.Environment.Value
+		default:
+			var env struct {
+				Name string `yaml:"name"`
+			}
+			if err := job.Environment.Decode(&env); err != nil {
+				return nil, fmt.Errorf("job %q: %w", id, err)
+			}
+			name = env.Name
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			envs[id] = name
+		}
+	}
+	return envs, nil
+}
+
+// PrepareRunEnvironments reads the deployment environments of the jobs of a new run before it's inserted
+func PrepareRunEnvironments(run *actions_model.ActionRun, content []byte) error {
+	envs, err := readJobEnvironments(content)
+	if err != nil {
+		return fmt.Errorf("read environments: %w", err)
+	}
+	run.JobEnvironments = envs
+	return nil
+}
+
+// CanReviewDeployment returns whether the user is a required reviewer of the environment who can approve the jobs of the run
+func CanReviewDeployment(ctx context.Context, env *actions_model.ActionEnvironment, run *actions_model.ActionRun, doer *user_model.User) (bool, error) {
+	if doer == nil || !env.NeedReview() {
+		return false, nil
+	}
+	if env.PreventSelfReview && run.TriggerUserID == doer.ID {
+		return false, nil
+	}
+	if slices.Contains(env.ReviewerIDs, doer.ID) {
+		return true, nil
+	}
+	if len(env.ReviewerTeamIDs) == 0 {
+		return false, nil
+	}
+	if err := run.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	for _, teamID := range env.ReviewerTeamIDs {
+		if isMember, err := organization.IsTeamMember(ctx, run.Repo.OwnerID, teamID, doer.ID); err != nil {
+			return false, err
+		} else if isMember {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PendingDeployment is an environment whose required reviewers haven't reviewed the jobs of a run deploying to it yet
+type PendingDeployment struct {
+	Environment *actions_model.ActionEnvironment
+	// WaitTimerUntil is the time the wait timer of the jobs elapses, it's 0 if the timer hasn't started
+	WaitTimerUntil timeutil.TimeStamp
+	CanApprove     bool
+}
+
+// GetPendingDeployments returns the environments the blocked jobs of the run wait to be approved for
+func GetPendingDeployments(ctx context.Context, run *actions_model.ActionRun, doer *user_model.User) ([]*PendingDeployment, error) {
+	jobs, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{
+		RunID:    run.ID,
+		Statuses: []actions_model.Status{actions_model.StatusBlocked},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pending []*PendingDeployment
+	byName := make(map[string]*PendingDeployment)
+	for _, job := range jobs {
+		if job.Environment == "" {
+			continue
+		}
+		name := strings.ToLower(job.Environment)
+		if p, ok := byName[name]; ok {
+			if p != nil && job.WaitTimerUntil > p.WaitTimerUntil {
+				p.WaitTimerUntil = job.WaitTimerUntil
+			}
+			continue
+		}
+		byName[name] = nil
+		env, err := actions_model.GetEnvironmentByName(ctx, run.RepoID, job.Environment)
+		if errors.Is(err, util.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if !env.NeedReview() {
+			continue
+		}
+		if state, err := actions_model.GetDeploymentReviewState(ctx, run.ID, env.ID); err != nil {
+			return nil, err
+		} else if state != "" {
+			continue
+		}
+		canApprove, err := CanReviewDeployment(ctx, env, run, doer)
+		if err != nil {
+			return nil, err
+		}
+		p := &PendingDeployment{Environment: env, WaitTimerUntil: job.WaitTimerUntil, CanApprove: canApprove}
+		byName[name] = p
+		pending = append(pending, p)
+	}
+	return pending, nil
+}
+
+// ReviewDeployments approves or rejects the jobs of the run deploying to the environments.
+// The rejected jobs fail, the approved ones start when their wait timer has elapsed.
+func ReviewDeployments(ctx context.Context, run *actions_model.ActionRun, doer *user_model.User, environmentIDs []int64, state actions_model.DeploymentReviewState, comment string) error {
+	if len(environmentIDs) == 0 {
+		return util.NewInvalidArgumentErrorf("no environment to review")
+	}
+	pending, err := GetPendingDeployments(ctx, run, doer)
+	if err != nil {
+		return err
+	}
+	reviews := make([]*actions_model.ActionDeploymentReview, 0, len(environmentIDs))
+	for _, id := range environmentIDs {
+		idx := slices.IndexFunc(pending, func(p *PendingDeployment) bool { return p.Environment.ID == id })
+		if idx < 0 {
+			return util.NewInvalidArgumentErrorf("environment %d isn't waiting for a review", id)
+		}
+		if !pending[idx].CanApprove {
+			return util.NewPermissionDeniedErrorf("you aren't a reviewer of environment %q", pending[idx].Environment.Name)
+		}
+		reviews = append(reviews, &actions_model.ActionDeploymentReview{
+			RepoID:        run.RepoID,
+			RunID:         run.ID,
+			EnvironmentID: id,
+			ReviewerID:    doer.ID,
+			State:         state,
+			Comment:       comment,
+		})
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, review := range reviews {
+			if err := actions_model.InsertDeploymentReview(ctx, review); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return EmitJobsIfReady(run.ID)
+}
+
+// DeleteEnvironment deletes the environment with its secrets and reviews
+func DeleteEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ? AND environment_id = ?", env.RepoID, env.ID).Delete(new(secret_model.Secret)); err != nil {
+			return err
+		}
+		return actions_model.DeleteEnvironment(ctx, env)
+	})
+}
+
+// ReleaseElapsedWaitTimers lets the jobs whose environment wait timer has elapsed continue
+func ReleaseElapsedWaitTimers(ctx context.Context) error {
+	runIDs, err := actions_model.FindRunsWithElapsedWaitTimers(ctx)
+	if err != nil {
+		return err
+	}
+	for runID := range runIDs {
+		if err := EmitJobsIfReady(runID); err != nil {
+			log.Error("Emit ready jobs of run %d: %v", runID, err)
+		}
+	}
+	return nil
+}
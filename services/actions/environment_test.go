@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJobEnvironments(t *testing.T) {
+	envs, err := readJobEnvironments([]byte(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+  staging:
+    runs-on: ubuntu-latest
+    environment: staging
+    steps:
+      - run: ./deploy.sh
+  production:
+    runs-on: ubuntu-latest
+    environment:
+      name: production
+      url: https://example.com
+    steps:
+      - run: ./deploy.sh
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"staging": "staging", "production": "production"}, envs)
+
+	_, err = readJobEnvironments([]byte("jobs:\n  deploy:\n    environment: [a, b]\n"))
+	assert.Error(t, err)
+}
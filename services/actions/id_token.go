@@ -0,0 +1,132 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IDTokenClaims are the claims of the OIDC ID token issued to a running job.
+// The claim names follow GitHub Actions, so cloud providers can reuse their existing trust policies.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Ref               string `json:"ref"`
+	RefType           string `json:"ref_type"`
+	Sha               string `json:"sha"`
+	Repository        string `json:"repository"`
+	RepositoryID      string `json:"repository_id"`
+	RepositoryOwner   string `json:"repository_owner"`
+	RepositoryOwnerID string `json:"repository_owner_id"`
+	Actor             string `json:"actor"`
+	ActorID           string `json:"actor_id"`
+	Workflow          string `json:"workflow"`
+	WorkflowRef       string `json:"workflow_ref"`
+	EventName         string `json:"event_name"`
+	RunID             string `json:"run_id"`
+	RunNumber         string `json:"run_number"`
+	RunAttempt        string `json:"run_attempt"`
+	Job               string `json:"job"`
+}
+
+// ErrIDTokenUnavailable is returned when ID tokens can't be issued with the current configuration
+var ErrIDTokenUnavailable = errors.New("actions ID tokens are not available")
+
+// ErrIDTokenNotPermitted is returned when the job isn't allowed to request an ID token
+var ErrIDTokenNotPermitted = util.NewPermissionDeniedErrorf(`the job needs the "id-token: write" permission and mustn't be triggered by a pull request from a fork`)
+
+// IDTokenRequestURL returns the URL jobs use to request an ID token, it is passed as ACTIONS_ID_TOKEN_REQUEST_URL.
+// Like GitHub it already contains a query string, because clients append "&audience=..." to it.
+func IDTokenRequestURL() string {
+	return setting.AppURL + "api/actions/_apis/idtoken?api-version=2.0"
+}
+
+// CreateIDToken creates an OIDC ID token describing the job of the task for the given audience.
+// If the audience is empty, the URL of the repository owner is used like GitHub does.
+func CreateIDToken(ctx context.Context, task *actions_model.ActionTask, audience string) (string, error) {
+	if !setting.Actions.IDTokenEnabled || !setting.OAuth2.Enabled {
+		return "", ErrIDTokenUnavailable
+	}
+	signingKey := oauth2_provider.DefaultSigningKey
+	if signingKey == nil || signingKey.IsSymmetric() {
+		// tokens signed with a shared secret can't be verified by third parties
+		return "", ErrIDTokenUnavailable
+	}
+
+	if err := task.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+	job := task.Job
+	run := job.Run
+	if err := run.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+
+	// the code of a pull request from a fork can't be trusted with the identity of the repository
+	if run.IsForkPullRequest || job.IsForkPullRequest {
+		return "", ErrIDTokenNotPermitted
+	}
+	if permitted, err := job.CanRequestIDToken(); err != nil {
+		return "", err
+	} else if !permitted {
+		return "", ErrIDTokenNotPermitted
+	}
+
+	repoFullName := run.Repo.OwnerName + "/" + run.Repo.Name
+	if audience == "" {
+		audience = setting.AppURL + run.Repo.OwnerName
+	}
+
+	refName := git.RefName(run.Ref)
+	subject := "repo:" + repoFullName + ":ref:" + run.Ref
+	if run.TriggerEvent == actions_module.GithubEventPullRequest || run.TriggerEvent == actions_module.GithubEventPullRequestTarget {
+		subject = "repo:" + repoFullName + ":pull_request"
+	}
+
+	now := time.Now()
+	claims := IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    strings.TrimSuffix(setting.AppURL, "/"),
+			Subject:   subject,
+			Audience:  []string{audience},
+			ID:        fmt.Sprintf("%d-%d", task.ID, now.UnixNano()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(setting.Actions.IDTokenExpiry)),
+		},
+		Ref:               run.Ref,
+		RefType:           string(refName.RefType()),
+		Sha:               run.CommitSHA,
+		Repository:        repoFullName,
+		RepositoryID:      strconv.FormatInt(run.RepoID, 10),
+		RepositoryOwner:   run.Repo.OwnerName,
+		RepositoryOwnerID: strconv.FormatInt(run.OwnerID, 10),
+		Actor:             run.TriggerUser.Name,
+		ActorID:           strconv.FormatInt(run.TriggerUserID, 10),
+		Workflow:          run.WorkflowID,
+		WorkflowRef:       repoFullName + "/" + run.WorkflowID + "@" + run.Ref,
+		EventName:         run.TriggerEvent,
+		RunID:             strconv.FormatInt(run.ID, 10),
+		RunNumber:         strconv.FormatInt(run.Index, 10),
+		RunAttempt:        strconv.FormatInt(job.Attempt, 10),
+		Job:               job.JobID,
+	}
+
+	token := jwt.NewWithClaims(signingKey.SigningMethod(), claims)
+	signingKey.PreProcessToken(token)
+	return token.SignedString(signingKey.SignKey())
+}
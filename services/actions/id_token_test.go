@@ -0,0 +1,110 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateIDToken(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+
+	_, err := CreateIDToken(t.Context(), task, "")
+	assert.ErrorIs(t, err, ErrIDTokenUnavailable)
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signingKey, err := oauth2_provider.CreateJWTSigningKey("ES256", privateKey)
+	require.NoError(t, err)
+
+	defer test.MockVariableValue(&setting.Actions.IDTokenEnabled, true)()
+	defer test.MockVariableValue(&setting.OAuth2.Enabled, true)()
+	defer test.MockVariableValue(&oauth2_provider.DefaultSigningKey, signingKey)()
+
+	// the job doesn't have the "id-token: write" permission
+	_, err = CreateIDToken(t.Context(), task, "sts.amazonaws.com")
+	assert.ErrorIs(t, err, ErrIDTokenNotPermitted)
+
+	_, err = db.GetEngine(t.Context()).ID(192).Cols("workflow_payload").Update(&actions_model.ActionRunJob{
+		WorkflowPayload: []byte("name: test\non: push\npermissions:\n  id-token: write\njobs:\n  job_2:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"),
+	})
+	require.NoError(t, err)
+
+	task = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	token, err := CreateIDToken(t.Context(), task, "sts.amazonaws.com")
+	require.NoError(t, err)
+
+	claims := &IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return signingKey.VerifyKey(), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "repo:user5/repo4:ref:refs/heads/master", claims.Subject)
+	assert.Equal(t, jwt.ClaimStrings{"sts.amazonaws.com"}, claims.Audience)
+	assert.Equal(t, "user5/repo4", claims.Repository)
+	assert.Equal(t, "artifact.yaml", claims.Workflow)
+	assert.Equal(t, "791", claims.RunID)
+	assert.Equal(t, "job_2", claims.Job)
+	assert.Equal(t, "branch", claims.RefType)
+}
+
+func TestCreateIDTokenForkPullRequest(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signingKey, err := oauth2_provider.CreateJWTSigningKey("ES256", privateKey)
+	require.NoError(t, err)
+
+	defer test.MockVariableValue(&oauth2_provider.DefaultSigningKey, signingKey)()
+	defer test.MockVariableValue(&setting.Actions.IDTokenEnabled, true)()
+	defer test.MockVariableValue(&setting.OAuth2.Enabled, true)()
+
+	_, err = db.GetEngine(t.Context()).ID(192).Cols("workflow_payload", "is_fork_pull_request").Update(&actions_model.ActionRunJob{
+		WorkflowPayload:   []byte("name: test\non: pull_request\npermissions: write-all\njobs:\n  job_2:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"),
+		IsForkPullRequest: true,
+	})
+	require.NoError(t, err)
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	_, err = CreateIDToken(t.Context(), task, "")
+	assert.ErrorIs(t, err, ErrIDTokenNotPermitted)
+}
+
+func TestActionRunJobCanRequestIDToken(t *testing.T) {
+	cases := []struct {
+		payload  string
+		expected bool
+	}{
+		{"jobs:\n  a:\n    runs-on: x\n", false},
+		{"permissions: write-all\njobs:\n  a:\n    runs-on: x\n", true},
+		{"permissions: read-all\njobs:\n  a:\n    runs-on: x\n", false},
+		{"permissions:\n  id-token: write\njobs:\n  a:\n    runs-on: x\n", true},
+		{"permissions:\n  id-token: read\njobs:\n  a:\n    runs-on: x\n", false},
+		// the job permissions replace the workflow permissions
+		{"permissions:\n  id-token: write\njobs:\n  a:\n    runs-on: x\n    permissions:\n      contents: read\n", false},
+		{"jobs:\n  a:\n    runs-on: x\n    permissions:\n      id-token: write\n", true},
+	}
+	for _, c := range cases {
+		permitted, err := (&actions_model.ActionRunJob{WorkflowPayload: []byte(c.payload)}).CanRequestIDToken()
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, permitted, c.payload)
+	}
+}
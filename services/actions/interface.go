@@ -27,12 +27,24 @@ type API interface {
 	GetRegistrationToken(*context.APIContext)
 	// CreateRegistrationToken get registration token
 	CreateRegistrationToken(*context.APIContext)
+	// CreateRunnerJITToken create a just-in-time runner registration token
+	CreateRunnerJITToken(*context.APIContext)
+	// ListRunnerPendingJobs list the number of jobs waiting for runners
+	ListRunnerPendingJobs(*context.APIContext)
 	// ListRunners list runners
 	ListRunners(*context.APIContext)
 	// GetRunner get a runner
 	GetRunner(*context.APIContext)
 	// DeleteRunner delete runner
 	DeleteRunner(*context.APIContext)
+	// GetArtifactRetention get the artifact retention policy
+	GetArtifactRetention(*context.APIContext)
+	// UpdateArtifactRetention set the artifact retention policy
+	UpdateArtifactRetention(*context.APIContext)
+	// DeleteArtifactRetention delete the artifact retention policy
+	DeleteArtifactRetention(*context.APIContext)
+	// GetArtifactUsage get the storage consumed by artifacts
+	GetArtifactUsage(*context.APIContext)
 	// ListWorkflowJobs list jobs
 	ListWorkflowJobs(*context.APIContext)
 	// ListWorkflowRuns list runs
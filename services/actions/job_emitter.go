@@ -51,7 +51,24 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 	if err != nil {
 		return err
 	}
+	var run *actions_model.ActionRun
+	if len(jobs) > 0 {
+		run, err = actions_model.GetRunByRepoAndID(ctx, jobs[0].RepoID, runID)
+		if err != nil {
+			return err
+		}
+		if run.NeedApproval {
+			return nil
+		}
+		// the run waits until the earlier runs of its concurrency group are done
+		if blocked, err := actions_model.IsRunConcurrencyBlocked(ctx, run); err != nil {
+			return err
+		} else if blocked {
+			return nil
+		}
+	}
 	var updatedjobs []*actions_model.ActionRunJob
+	var deploymentFailed bool
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		idToJobs := make(map[string][]*actions_model.ActionRunJob, len(jobs))
 		for _, job := range jobs {
@@ -61,6 +78,29 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 		updates := newJobStatusResolver(jobs).Resolve()
 		for _, job := range jobs {
 			if status, ok := updates[job.ID]; ok {
+				if status == actions_model.StatusWaiting {
+					// the job waits until the other job of its concurrency group is done
+					if busy, err := actions_model.IsJobConcurrencyGroupBusy(ctx, job.RepoID, job.ConcurrencyGroup, job.ID); err != nil {
+						return err
+					} else if busy {
+						continue
+					}
+					// the job deploying to a protected environment waits for the approval and the wait timer
+					waitTimerUntil := job.WaitTimerUntil
+					var err error
+					if status, err = actions_model.CheckEnvironmentProtection(ctx, run, job); err != nil {
+						return err
+					}
+					if status == actions_model.StatusBlocked {
+						if job.WaitTimerUntil != waitTimerUntil {
+							if _, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "wait_timer_until"); err != nil {
+								return err
+							}
+						}
+						continue
+					}
+					deploymentFailed = deploymentFailed || status == actions_model.StatusFailure
+				}
 				job.Status = status
 				if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status"); err != nil {
 					return err
@@ -75,6 +115,13 @@ func checkJobsOfRun(ctx context.Context, runID int64) error {
 		return err
 	}
 	CreateCommitStatus(ctx, jobs...)
+	ReleaseConcurrencyGroups(ctx, jobs)
+	if deploymentFailed {
+		// the jobs needing the failed deployment are resolved again
+		if err := EmitJobsIfReady(runID); err != nil {
+			log.Error("Emit ready jobs of run %d: %v", runID, err)
+		}
+	}
 	for _, job := range updatedjobs {
 		_ = job.LoadAttributes(ctx)
 		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
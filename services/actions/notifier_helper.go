@@ -25,6 +25,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 	"code.gitea.io/gitea/services/convert"
 	notify_service "code.gitea.io/gitea/services/notify"
@@ -365,6 +366,26 @@ func handleWorkflows(
 			continue
 		}
 
+		if err := CheckReusableWorkflows(ctx, run, jobs); err != nil {
+			// like an invalid workflow, the run is created in the failed state to show the users why it didn't run
+			log.Debug("CheckReusableWorkflows of %s in repo %d: %v", dwf.EntryName, input.Repo.ID, err)
+			run.Status = actions_model.StatusFailure
+			run.ErrorMessage = err.Error()
+			run.NeedApproval = false
+			run.Started = timeutil.TimeStampNow()
+			run.Stopped = run.Started
+		} else {
+			if err := PrepareRunConcurrency(ctx, run, dwf.Content, jobs, vars, nil); err != nil {
+				log.Error("PrepareRunConcurrency of %s in repo %d: %v", dwf.EntryName, input.Repo.ID, err)
+				continue
+			}
+
+			if err := PrepareRunEnvironments(run, dwf.Content); err != nil {
+				log.Error("PrepareRunEnvironments of %s in repo %d: %v", dwf.EntryName, input.Repo.ID, err)
+				continue
+			}
+		}
+
 		if len(jobs) > 0 && jobs[0].RunName != "" {
 			run.Title = jobs[0].RunName
 		}
@@ -538,7 +559,11 @@ func handleSchedules(
 			log.Error("ReadWorkflow: %v", err)
 			continue
 		}
-		schedules := workflow.OnSchedule()
+		schedules, err := readScheduleSpecs(workflow)
+		if err != nil {
+			log.Error("readScheduleSpecs: %v", err)
+			continue
+		}
 		if len(schedules) == 0 {
 			log.Warn("no schedule event")
 			continue
@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+)
+
+// maxReusableWorkflowSize is the maximum size of a called workflow file which is read to validate a call
+const maxReusableWorkflowSize = 1024 * 1024
+
+// canCallReusableWorkflow reports whether workflows of the caller repository may use the workflows of the callee repository.
+// Workflows can be shared by public repositories and by repositories of the same owner.
+func canCallReusableWorkflow(ctx context.Context, caller, callee *repo_model.Repository) bool {
+	if caller.ID == callee.ID {
+		return true
+	}
+	if !callee.UnitEnabled(ctx, unit.TypeCode) {
+		return false
+	}
+	return !callee.IsPrivate || caller.OwnerID == callee.OwnerID
+}
+
+// CheckReusableWorkflows validates the jobs of a run which call reusable workflows of this instance,
+// the called workflow must exist at the requested ref, be callable by the run's repository and accept the inputs and secrets.
+func CheckReusableWorkflows(ctx context.Context, run *actions_model.ActionRun, workflows []*jobparser.SingleWorkflow) error {
+	if err := run.LoadRepo(ctx); err != nil {
+		return err
+	}
+	for _, swf := range workflows {
+		jobID, job := swf.Job()
+		if job == nil || job.Uses == "" {
+			continue
+		}
+		ref, err := actions_module.ParseReusableWorkflowRef(job.Uses)
+		if err != nil {
+			return fmt.Errorf("job %q: %w", jobID, err)
+		} else if ref == nil {
+			continue
+		}
+
+		callee, content, err := readReusableWorkflow(ctx, run, ref)
+		if err != nil {
+			return fmt.Errorf("job %q: %w", jobID, err)
+		}
+		rw, err := actions_module.ReadReusableWorkflow(content)
+		if err != nil {
+			return fmt.Errorf("job %q: %s: %w", jobID, job.Uses, err)
+		}
+		if err := rw.CheckCall(job.With, &job.RawSecrets, callee.OwnerID == run.Repo.OwnerID); err != nil {
+			return fmt.Errorf("job %q: %s: %w", jobID, job.Uses, err)
+		}
+	}
+	return nil
+}
+
+func readReusableWorkflow(ctx context.Context, run *actions_model.ActionRun, ref *actions_module.ReusableWorkflowRef) (*repo_model.Repository, []byte, error) {
+	callee := run.Repo
+	commitID := run.CommitSHA
+	if !ref.Local {
+		var err error
+		callee, err = repo_model.GetRepositoryByOwnerAndName(ctx, ref.Owner, ref.Repo)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, nil, util.NewNotExistErrorf("repository %s/%s of the reusable workflow doesn't exist", ref.Owner, ref.Repo)
+			}
+			return nil, nil, err
+		}
+		if !canCallReusableWorkflow(ctx, run.Repo, callee) {
+			// don't reveal the existence of private repositories
+			return nil, nil, util.NewNotExistErrorf("repository %s/%s of the reusable workflow doesn't exist", ref.Owner, ref.Repo)
+		}
+		commitID = ref.Ref
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, callee)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		return nil, nil, util.NewNotExistErrorf("ref %q of %s doesn't exist", commitID, callee.FullName())
+	}
+	content, err := commit.GetFileContent(ref.Path, maxReusableWorkflowSize)
+	if err != nil {
+		return nil, nil, util.NewNotExistErrorf("workflow %q doesn't exist in %s at %s", ref.Path, callee.FullName(), commitID)
+	}
+	return callee, []byte(content), nil
+}
+
+// CanTaskReadRepository reports whether the token of the task may read the repository, which is the case for the repository
+// of the task itself and for the repository of a reusable workflow called by the task's job.
+func CanTaskReadRepository(ctx context.Context, task *actions_model.ActionTask, repo *repo_model.Repository) (bool, error) {
+	if task.RepoID == repo.ID {
+		return true, nil
+	}
+	if err := task.LoadJob(ctx); err != nil {
+		return false, err
+	}
+	workflows, err := jobparser.Parse(task.Job.WorkflowPayload)
+	if err != nil || len(workflows) != 1 {
+		return false, nil //nolint:nilerr // a job which can't be parsed doesn't call any workflow
+	}
+	_, job := workflows[0].Job()
+	if job == nil || job.Uses == "" {
+		return false, nil
+	}
+	ref, err := actions_module.ParseReusableWorkflowRef(job.Uses)
+	if err != nil || ref == nil || ref.Local {
+		return false, nil //nolint:nilerr // invalid references don't grant access
+	}
+	if !strings.EqualFold(ref.Owner, repo.OwnerName) || !strings.EqualFold(ref.Repo, repo.Name) {
+		return false, nil
+	}
+
+	caller, err := repo_model.GetRepositoryByID(ctx, task.RepoID)
+	if err != nil {
+		return false, err
+	}
+	return canCallReusableWorkflow(ctx, caller, repo), nil
+}
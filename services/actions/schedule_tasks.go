@@ -6,6 +6,7 @@ package actions
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
@@ -13,13 +14,52 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/timeutil"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 	notify_service "code.gitea.io/gitea/services/notify"
 
 	"github.com/nektos/act/pkg/jobparser"
+	"github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
 )
 
+// readScheduleSpecs returns the cron specs of the `schedule` event of the workflow.
+// The `timezone` of a schedule is added to its spec as CRON_TZ, the specs without it use UTC.
+func readScheduleSpecs(workflow *model.Workflow) ([]string, error) {
+	if workflow.RawOn.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var on map[string]yaml.Node
+	if err := workflow.RawOn.Decode(&on); err != nil {
+		return nil, err
+	}
+	node, ok := on["schedule"]
+	if !ok {
+		return nil, nil
+	}
+	var schedules []struct {
+		Cron     string `yaml:"cron"`
+		Timezone string `yaml:"timezone"`
+	}
+	if err := node.Decode(&schedules); err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	specs := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		spec := strings.TrimSpace(schedule.Cron)
+		if spec == "" {
+			continue
+		}
+		if tz := strings.TrimSpace(schedule.Timezone); tz != "" && !strings.HasPrefix(spec, "TZ=") && !strings.HasPrefix(spec, "CRON_TZ=") {
+			if _, err := time.LoadLocation(tz); err != nil {
+				return nil, fmt.Errorf("invalid timezone %q of schedule %q: %w", tz, spec, err)
+			}
+			spec = "CRON_TZ=" + tz + " " + spec
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
 // StartScheduleTasks start the task
 func StartScheduleTasks(ctx context.Context) error {
 	return startTasks(ctx)
@@ -98,7 +138,7 @@ func startTasks(ctx context.Context) error {
 
 			// Update the spec's next run time and previous run time
 			row.Prev = row.Next
-			row.Next = timeutil.TimeStamp(schedule.Next(now.Add(1 * time.Minute)).Unix())
+			row.Next = row.NextTime(schedule, now.Add(1*time.Minute))
 			if err := actions_model.UpdateScheduleSpec(ctx, row, "prev", "next"); err != nil {
 				log.Error("UpdateScheduleSpec: %v", err)
 				return err
@@ -144,6 +184,15 @@ func CreateScheduleTask(ctx context.Context, cron *actions_model.ActionSchedule)
 	if err != nil {
 		return err
 	}
+	if err := CheckReusableWorkflows(ctx, run, workflows); err != nil {
+		return err
+	}
+	if err := PrepareRunConcurrency(ctx, run, cron.Content, workflows, vars, nil); err != nil {
+		return err
+	}
+	if err := PrepareRunEnvironments(run, cron.Content); err != nil {
+		return err
+	}
 
 	// Insert the action run and its associated jobs into the database
 	if err := actions_model.InsertRun(ctx, run, workflows); err != nil {
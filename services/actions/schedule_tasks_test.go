@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadScheduleSpecs(t *testing.T) {
+	read := func(content string) ([]string, error) {
+		workflow, err := model.ReadWorkflow(strings.NewReader(content))
+		require.NoError(t, err)
+		return readScheduleSpecs(workflow)
+	}
+
+	specs, err := read(`
+on:
+  schedule:
+    - cron: "0 0 * * *"
+    - cron: "30 9 * * 1-5"
+      timezone: Europe/Berlin
+    - cron: "TZ=Asia/Tokyo 0 12 * * *"
+      timezone: Europe/Berlin
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0 0 * * *", "CRON_TZ=Europe/Berlin 30 9 * * 1-5", "TZ=Asia/Tokyo 0 12 * * *"}, specs)
+
+	specs, err = read(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`)
+	require.NoError(t, err)
+	assert.Empty(t, specs)
+
+	_, err = read(`
+on:
+  schedule:
+    - cron: "0 0 * * *"
+      timezone: Mars/Olympus_Mons
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`)
+	assert.ErrorContains(t, err, "invalid timezone")
+}
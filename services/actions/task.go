@@ -11,6 +11,7 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/modules/setting"
 	notify_service "code.gitea.io/gitea/services/notify"
 
 	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
@@ -112,6 +113,11 @@ func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error)
 	gitCtx := GenerateGiteaContext(t.Job.Run, t.Job)
 	gitCtx["token"] = t.Token
 	gitCtx["gitea_runtime_token"] = giteaRuntimeToken
+	if setting.Actions.IDTokenEnabled {
+		// the runner exposes them as ACTIONS_ID_TOKEN_REQUEST_URL and ACTIONS_ID_TOKEN_REQUEST_TOKEN
+		gitCtx["actions_id_token_request_url"] = IDTokenRequestURL()
+		gitCtx["actions_id_token_request_token"] = giteaRuntimeToken
+	}
 
 	return structpb.NewStruct(gitCtx)
 }
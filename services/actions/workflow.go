@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/reqctx"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	notify_service "code.gitea.io/gitea/services/notify"
@@ -142,6 +143,9 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 	if err != nil {
 		return err
 	}
+	if err := CheckReusableWorkflows(ctx, run, workflows); err != nil {
+		return err
+	}
 
 	if len(workflows) > 0 && workflows[0].RunName != "" {
 		run.Title = workflows[0].RunName
@@ -193,6 +197,13 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 		log.Error("CancelRunningJobs: %v", err)
 	}
 
+	if err := PrepareRunConcurrency(ctx, run, content, workflows, nil, inputsWithDefaults); err != nil {
+		return err
+	}
+	if err := PrepareRunEnvironments(run, content); err != nil {
+		return err
+	}
+
 	// Insert the action run and its associated jobs into the database
 	if err := actions_model.InsertRun(ctx, run, workflows); err != nil {
 		return fmt.Errorf("InsertRun: %w", err)
@@ -217,3 +228,32 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 	}
 	return nil
 }
+
+// maxRepositoryDispatchClientPayloadProperties is the maximum of top-level properties of a client payload, the same as GitHub
+const maxRepositoryDispatchClientPayloadProperties = 10
+
+// DispatchRepositoryEvent triggers the workflows of the default branch listening to the repository_dispatch event with the given type.
+// https://docs.github.com/en/rest/repos/repos#create-a-repository-dispatch-event
+func DispatchRepositoryEvent(ctx reqctx.RequestContext, doer *user_model.User, repo *repo_model.Repository, eventType string, clientPayload map[string]any) error {
+	if eventType == "" {
+		return util.NewInvalidArgumentErrorf("event_type is empty")
+	}
+	if len(clientPayload) > maxRepositoryDispatchClientPayloadProperties {
+		return util.NewInvalidArgumentErrorf("client_payload can't have more than %d top-level properties", maxRepositoryDispatchClientPayloadProperties)
+	}
+	if repo.IsEmpty {
+		return util.NewNotExistErrorf("repository is empty")
+	}
+
+	// https://docs.github.com/en/webhooks/webhook-events-and-payloads#repository_dispatch
+	payload := &api.RepositoryDispatchPayload{
+		Action:        eventType,
+		Branch:        repo.DefaultBranch,
+		ClientPayload: clientPayload,
+		Repository:    convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeNone}),
+		Sender:        convert.ToUserWithAccessMode(ctx, doer, perm.AccessModeNone),
+	}
+	return notify(ctx, newNotifyInput(repo, doer, webhook_module.HookEventRepositoryDispatch).
+		WithRef(git.RefNameFromBranch(repo.DefaultBranch).String()).
+		WithPayload(payload))
+}
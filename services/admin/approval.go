@@ -0,0 +1,480 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	auth_model "code.gitea.io/gitea/models/auth"
+	org_model "code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	auth_service "code.gitea.io/gitea/services/auth"
+	org_service "code.gitea.io/gitea/services/org"
+	repo_service "code.gitea.io/gitea/services/repository"
+	user_service "code.gitea.io/gitea/services/user"
+)
+
+// IsApprovalRequired checks whether the destructive admin operations have to be approved by a second administrator
+func IsApprovalRequired() bool {
+	return setting.Admin.RequireSecondApproval
+}
+
+type deleteUserPayload struct {
+	Purge bool `json:"purge"`
+}
+
+type offboardUserPayload struct {
+	RepoOwnerID     int64 `json:"repo_owner_id,omitempty"`
+	IssueAssigneeID int64 `json:"issue_assignee_id,omitempty"`
+}
+
+// authSourceChange keeps the new settings of an authentication source until the change is approved
+type authSourceChange struct {
+	// Type is the type of a new authentication source
+	Type            auth_model.Type `json:"type,omitempty"`
+	Name            string          `json:"name"`
+	IsActive        bool            `json:"is_active"`
+	IsSyncEnabled   bool            `json:"is_sync_enabled"`
+	TwoFactorPolicy string          `json:"two_factor_policy"`
+	Cfg             string          `json:"cfg"`
+	// OriginalHash identifies the settings the change has been requested for, the change isn't applied to other ones
+	OriginalHash string `json:"original_hash"`
+}
+
+func (change *authSourceChange) apply(source *auth_model.Source) error {
+	if err := source.Cfg.FromDB([]byte(change.Cfg)); err != nil {
+		return err
+	}
+	source.Name = change.Name
+	source.IsActive = change.IsActive
+	source.IsSyncEnabled = change.IsSyncEnabled
+	source.TwoFactorPolicy = change.TwoFactorPolicy
+	return nil
+}
+
+// newSource returns the new authentication source, it isn't saved
+func (change *authSourceChange) newSource() (*auth_model.Source, error) {
+	source := &auth_model.Source{Type: change.Type, Cfg: auth_model.NewSourceConfig(change.Type)}
+	if source.Cfg == nil {
+		return nil, util.NewInvalidArgumentErrorf("unknown authentication source type %d", change.Type)
+	}
+	source.Cfg.SetAuthSource(source)
+	if err := change.apply(source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// authSourceFields returns the settings of the authentication source by their names, the settings of its configuration
+// are prefixed with "Cfg."
+func authSourceFields(source *auth_model.Source) (map[string]string, error) {
+	fields := map[string]string{
+		"Name":            source.Name,
+		"IsActive":        strconv.FormatBool(source.IsActive),
+		"IsSyncEnabled":   strconv.FormatBool(source.IsSyncEnabled),
+		"TwoFactorPolicy": source.TwoFactorPolicy,
+	}
+	// the decoded configuration is compared, the encrypted secrets differ each time they are encoded
+	data, err := json.Marshal(source.Cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg := map[string]any{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	for name, value := range cfg {
+		if str, ok := value.(string); ok {
+			fields["Cfg."+name] = str
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields["Cfg."+name] = string(data)
+	}
+	return fields, nil
+}
+
+// hashAuthSource returns a hash of the settings of the authentication source, it's keyed by the secret key as the settings
+// include secrets
+func hashAuthSource(source *auth_model.Source) (string, error) {
+	fields, err := authSourceFields(source)
+	if err != nil {
+		return "", err
+	}
+	h := hmac.New(sha256.New, []byte(setting.SecretKey))
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		_, _ = fmt.Fprintf(h, "%q=%q\n", name, fields[name])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isSecretAuthSourceField(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "password") || strings.Contains(name, "secret")
+}
+
+// AuthSourceFieldChange is a setting of an authentication source changed by an approval request
+type AuthSourceFieldChange struct {
+	Name     string
+	OldValue string
+	NewValue string
+	// IsSecret is set if the values are masked
+	IsSecret bool
+}
+
+// GetAuthSourceChanges returns the settings of the authentication source changed or set by the approval request, the
+// values of the secrets are masked
+func GetAuthSourceChanges(ctx context.Context, r *admin_model.ApprovalRequest) ([]*AuthSourceFieldChange, error) {
+	if r.Action != admin_model.ApprovalActionEditAuthSource && r.Action != admin_model.ApprovalActionCreateAuthSource {
+		return nil, nil
+	}
+	var change authSourceChange
+	if err := json.Unmarshal([]byte(r.Payload), &change); err != nil {
+		return nil, err
+	}
+
+	var source *auth_model.Source
+	var err error
+	oldFields := map[string]string{}
+	if r.Action == admin_model.ApprovalActionCreateAuthSource {
+		if source, err = change.newSource(); err != nil {
+			return nil, err
+		}
+	} else {
+		if source, err = auth_model.GetSourceByID(ctx, r.TargetID); err != nil {
+			return nil, err
+		}
+		if oldFields, err = authSourceFields(source); err != nil {
+			return nil, err
+		}
+		if err := change.apply(source); err != nil {
+			return nil, err
+		}
+	}
+	newFields, err := authSourceFields(source)
+	if err != nil {
+		return nil, err
+	}
+
+	names := slices.Collect(maps.Keys(oldFields))
+	for name := range newFields {
+		if _, ok := oldFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	changes := make([]*AuthSourceFieldChange, 0, len(names))
+	for _, name := range names {
+		c := &AuthSourceFieldChange{Name: name, OldValue: oldFields[name], NewValue: newFields[name]}
+		if c.OldValue == c.NewValue {
+			continue
+		}
+		if isSecretAuthSourceField(name) {
+			c.IsSecret = true
+			c.OldValue, c.NewValue = maskSecret(c.OldValue), maskSecret(c.NewValue)
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func maskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "********"
+}
+
+func requestApproval(ctx context.Context, doer *user_model.User, action admin_model.ApprovalAction, targetID int64, targetName string, payload any) (*admin_model.ApprovalRequest, error) {
+	r := &admin_model.ApprovalRequest{
+		Action:      action,
+		TargetID:    targetID,
+		TargetName:  targetName,
+		RequesterID: doer.ID,
+		Requester:   doer,
+		ExpiresUnix: timeutil.TimeStamp(time.Now().Add(setting.Admin.ApprovalRequestExpiry).Unix()),
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		r.Payload = string(data)
+	}
+	if err := admin_model.CreateApprovalRequest(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := system_model.CreateNotice(ctx, system_model.NoticeApproval, "%s requested %s of %s (approval request %d)", doer.Name, r.Action, r.TargetName, r.ID); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RequestDeleteUser queues the deletion of the user until a second administrator approves it
+func RequestDeleteUser(ctx context.Context, doer, u *user_model.User, purge bool) (*admin_model.ApprovalRequest, error) {
+	return requestApproval(ctx, doer, admin_model.ApprovalActionDeleteUser, u.ID, u.Name, &deleteUserPayload{Purge: purge})
+}
+
+// RequestOffboardUser queues the offboarding of the user until a second administrator approves it
+func RequestOffboardUser(ctx context.Context, doer, u *user_model.User, opts user_service.OffboardOptions) (*admin_model.ApprovalRequest, error) {
+	payload := &offboardUserPayload{}
+	if opts.RepoOwner != nil {
+		payload.RepoOwnerID = opts.RepoOwner.ID
+	}
+	if opts.IssueAssignee != nil {
+		payload.IssueAssigneeID = opts.IssueAssignee.ID
+	}
+	return requestApproval(ctx, doer, admin_model.ApprovalActionOffboardUser, u.ID, u.Name, payload)
+}
+
+// RequestAnonymizeUser queues the anonymization of the user until a second administrator approves it
+func RequestAnonymizeUser(ctx context.Context, doer, u *user_model.User) (*admin_model.ApprovalRequest, error) {
+	return requestApproval(ctx, doer, admin_model.ApprovalActionAnonymizeUser, u.ID, u.Name, nil)
+}
+
+// IsOrgDeletionApprovalRequired checks whether the deletion of the organization has to be approved, it's the case for
+// every organization an administrator deletes: an administrator can make themselves an owner of any organization
+func IsOrgDeletionApprovalRequired(doer *user_model.User) bool {
+	return IsApprovalRequired() && doer.IsAdmin
+}
+
+// RequestDeleteOrg queues the deletion of the organization until a second administrator approves it
+func RequestDeleteOrg(ctx context.Context, doer *user_model.User, org *org_model.Organization) (*admin_model.ApprovalRequest, error) {
+	return requestApproval(ctx, doer, admin_model.ApprovalActionDeleteOrg, org.ID, org.Name, nil)
+}
+
+// IsRepoDeletionApprovalRequired checks whether the deletion of the repository has to be approved, it's the case for
+// every repository an administrator deletes except their own personal ones
+func IsRepoDeletionApprovalRequired(doer *user_model.User, repo *repo_model.Repository) bool {
+	return IsApprovalRequired() && doer.IsAdmin && repo.OwnerID != doer.ID
+}
+
+// RequestDeleteRepo queues the deletion of the repository until a second administrator approves it
+func RequestDeleteRepo(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) (*admin_model.ApprovalRequest, error) {
+	return requestApproval(ctx, doer, admin_model.ApprovalActionDeleteRepo, repo.ID, repo.FullName(), nil)
+}
+
+// RequestCreateAuthSource queues the creation of the authentication source until a second administrator approves it, the
+// source isn't saved
+func RequestCreateAuthSource(ctx context.Context, doer *user_model.User, source *auth_model.Source) (*admin_model.ApprovalRequest, error) {
+	cfg, err := source.Cfg.ToDB()
+	if err != nil {
+		return nil, err
+	}
+	return requestApproval(ctx, doer, admin_model.ApprovalActionCreateAuthSource, 0, source.Name, &authSourceChange{
+		Type:            source.Type,
+		Name:            source.Name,
+		IsActive:        source.IsActive,
+		IsSyncEnabled:   source.IsSyncEnabled,
+		TwoFactorPolicy: source.TwoFactorPolicy,
+		Cfg:             string(cfg),
+	})
+}
+
+// RequestEditAuthSource queues the change of the authentication source until a second administrator approves it, the
+// source has to carry the new settings and isn't saved
+func RequestEditAuthSource(ctx context.Context, doer *user_model.User, source *auth_model.Source) (*admin_model.ApprovalRequest, error) {
+	original, err := auth_model.GetSourceByID(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	originalHash, err := hashAuthSource(original)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := source.Cfg.ToDB()
+	if err != nil {
+		return nil, err
+	}
+	return requestApproval(ctx, doer, admin_model.ApprovalActionEditAuthSource, source.ID, source.Name, &authSourceChange{
+		Name:            source.Name,
+		IsActive:        source.IsActive,
+		IsSyncEnabled:   source.IsSyncEnabled,
+		TwoFactorPolicy: source.TwoFactorPolicy,
+		Cfg:             string(cfg),
+		OriginalHash:    originalHash,
+	})
+}
+
+// RequestDeleteAuthSource queues the deletion of the authentication source until a second administrator approves it
+func RequestDeleteAuthSource(ctx context.Context, doer *user_model.User, source *auth_model.Source) (*admin_model.ApprovalRequest, error) {
+	return requestApproval(ctx, doer, admin_model.ApprovalActionDeleteAuthSource, source.ID, source.Name, nil)
+}
+
+// decide checks whether the administrator may decide about the request and stores the decision
+func decide(ctx context.Context, approver *user_model.User, r *admin_model.ApprovalRequest, status admin_model.ApprovalStatus) error {
+	if !approver.IsAdmin {
+		return util.NewPermissionDeniedErrorf("only administrators can decide about approval requests")
+	} else if r.RequesterID == approver.ID {
+		return util.NewInvalidArgumentErrorf("the requester can't decide about their own approval request")
+	} else if !r.IsPending() {
+		return util.NewInvalidArgumentErrorf("the approval request is %s", r.Status)
+	}
+	if r.ExpiresUnix <= timeutil.TimeStampNow() {
+		if err := admin_model.ExpireApprovalRequests(ctx); err != nil {
+			return err
+		}
+		r.Status = admin_model.ApprovalStatusExpired
+		return util.NewInvalidArgumentErrorf("the approval request is %s", r.Status)
+	}
+
+	r.ApproverID = approver.ID
+	r.Approver = approver
+	r.Status = status
+	r.DecidedUnix = timeutil.TimeStampNow()
+	decided, err := admin_model.UpdateApprovalRequestDecision(ctx, r)
+	if err != nil {
+		return err
+	} else if !decided {
+		return util.NewInvalidArgumentErrorf("the approval request has been decided meanwhile")
+	}
+	return nil
+}
+
+// ApproveRequest approves the request and executes the operation. If the operation fails, the request is marked as
+// failed and the error is kept as message of the request.
+func ApproveRequest(ctx context.Context, approver *user_model.User, r *admin_model.ApprovalRequest) error {
+	if err := decide(ctx, approver, r, admin_model.ApprovalStatusApproved); err != nil {
+		return err
+	}
+
+	if err := executeRequest(ctx, r); err != nil {
+		r.Status = admin_model.ApprovalStatusFailed
+		r.Message = err.Error()
+		if err := admin_model.UpdateApprovalRequestResult(ctx, r); err != nil {
+			return err
+		}
+	}
+	return system_model.CreateNotice(ctx, system_model.NoticeApproval, "%s approved %s of %s requested by user %d (approval request %d): %s",
+		approver.Name, r.Action, r.TargetName, r.RequesterID, r.ID, r.Status)
+}
+
+// RejectRequest rejects the request, the operation isn't executed
+func RejectRequest(ctx context.Context, approver *user_model.User, r *admin_model.ApprovalRequest) error {
+	if err := decide(ctx, approver, r, admin_model.ApprovalStatusRejected); err != nil {
+		return err
+	}
+	return system_model.CreateNotice(ctx, system_model.NoticeApproval, "%s rejected %s of %s requested by user %d (approval request %d)",
+		approver.Name, r.Action, r.TargetName, r.RequesterID, r.ID)
+}
+
+func executeRequest(ctx context.Context, r *admin_model.ApprovalRequest) error {
+	switch r.Action {
+	case admin_model.ApprovalActionDeleteUser:
+		var payload deleteUserPayload
+		if err := json.Unmarshal([]byte(r.Payload), &payload); err != nil {
+			return err
+		}
+		u, err := user_model.GetUserByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		return user_service.DeleteUser(ctx, u, payload.Purge)
+	case admin_model.ApprovalActionOffboardUser:
+		var payload offboardUserPayload
+		if err := json.Unmarshal([]byte(r.Payload), &payload); err != nil {
+			return err
+		}
+		u, err := user_model.GetUserByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		var opts user_service.OffboardOptions
+		if payload.RepoOwnerID > 0 {
+			if opts.RepoOwner, err = user_model.GetUserByID(ctx, payload.RepoOwnerID); err != nil {
+				return err
+			}
+		}
+		if payload.IssueAssigneeID > 0 {
+			if opts.IssueAssignee, err = user_model.GetUserByID(ctx, payload.IssueAssigneeID); err != nil {
+				return err
+			}
+		}
+		if err := r.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		_, err = user_service.OffboardUser(ctx, r.Requester, u, opts)
+		return err
+	case admin_model.ApprovalActionAnonymizeUser:
+		u, err := user_model.GetUserByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		if err := r.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		return user_service.AnonymizeUser(ctx, r.Requester, u)
+	case admin_model.ApprovalActionDeleteOrg:
+		org, err := org_model.GetOrgByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		return org_service.DeleteOrganization(ctx, org, false)
+	case admin_model.ApprovalActionDeleteRepo:
+		repo, err := repo_model.GetRepositoryByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		if err := r.LoadAttributes(ctx); err != nil {
+			return err
+		}
+		return repo_service.DeleteRepository(ctx, r.Requester, repo, true)
+	case admin_model.ApprovalActionCreateAuthSource:
+		var change authSourceChange
+		if err := json.Unmarshal([]byte(r.Payload), &change); err != nil {
+			return err
+		}
+		source, err := change.newSource()
+		if err != nil {
+			return err
+		}
+		return auth_model.CreateSource(ctx, source)
+	case admin_model.ApprovalActionEditAuthSource:
+		var change authSourceChange
+		if err := json.Unmarshal([]byte(r.Payload), &change); err != nil {
+			return err
+		}
+		source, err := auth_model.GetSourceByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		// the approver has reviewed the change of the settings the change has been requested for
+		if hash, err := hashAuthSource(source); err != nil {
+			return err
+		} else if hash != change.OriginalHash {
+			return util.NewInvalidArgumentErrorf("the authentication source has been changed after the change was requested")
+		}
+		if err := change.apply(source); err != nil {
+			return err
+		}
+		return auth_model.UpdateSource(ctx, source)
+	case admin_model.ApprovalActionDeleteAuthSource:
+		source, err := auth_model.GetSourceByID(ctx, r.TargetID)
+		if err != nil {
+			return err
+		}
+		return auth_service.DeleteSource(ctx, source)
+	}
+	return fmt.Errorf("unknown approval action %q", r.Action)
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"testing"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	org_model "code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/auth/source/ldap"
+	"code.gitea.io/gitea/services/auth/source/pam"
+	user_service "code.gitea.io/gitea/services/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproveRequest(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user9 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 9})
+
+	r, err := RequestDeleteUser(ctx, admin, user9, false)
+	require.NoError(t, err)
+	assert.Equal(t, admin_model.ApprovalStatusPending, r.Status)
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeApproval})
+
+	// the requester can't approve their own request, and a non-admin can't decide at all
+	assert.ErrorIs(t, ApproveRequest(ctx, admin, r), util.ErrInvalidArgument)
+	assert.ErrorIs(t, ApproveRequest(ctx, user4, r), util.ErrPermissionDenied)
+
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	r = unittest.AssertExistsAndLoadBean(t, &admin_model.ApprovalRequest{ID: r.ID})
+	assert.Equal(t, admin_model.ApprovalStatusApproved, r.Status)
+	assert.Equal(t, approver.ID, r.ApproverID)
+	unittest.AssertNotExistsBean(t, &user_model.User{ID: user9.ID})
+
+	// a decided request can't be decided again
+	assert.ErrorIs(t, RejectRequest(ctx, approver, r), util.ErrInvalidArgument)
+
+	// the failure of the operation is recorded
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	r, err = RequestDeleteUser(ctx, admin, user2, false)
+	require.NoError(t, err)
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusFailed, r.Status)
+	assert.NotEmpty(t, r.Message)
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: user2.ID})
+}
+
+func TestRejectRequest(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+	user9 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 9})
+
+	r, err := RequestDeleteUser(ctx, admin, user9, false)
+	require.NoError(t, err)
+	require.NoError(t, RejectRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusRejected, r.Status)
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: user9.ID})
+
+	// an expired request can't be approved anymore
+	r, err = RequestDeleteUser(ctx, admin, user9, false)
+	require.NoError(t, err)
+	r.ExpiresUnix = timeutil.TimeStampNow() - 1
+	_, err = unittest.GetXORMEngine().ID(r.ID).Cols("expires_unix").Update(r)
+	require.NoError(t, err)
+	assert.ErrorIs(t, ApproveRequest(ctx, approver, r), util.ErrInvalidArgument)
+	unittest.AssertExistsAndLoadBean(t, &admin_model.ApprovalRequest{ID: r.ID, Status: admin_model.ApprovalStatusExpired})
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: user9.ID})
+}
+
+func TestApproveOffboardUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+	user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+
+	r, err := RequestOffboardUser(ctx, admin, user10, user_service.OffboardOptions{IssueAssignee: approver})
+	require.NoError(t, err)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).IsActive)
+
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusApproved, r.Status)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).IsActive)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: 6, AssigneeID: approver.ID})
+}
+
+func TestApproveAnonymizeUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+	user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+
+	r, err := RequestAnonymizeUser(ctx, admin, user10)
+	require.NoError(t, err)
+	assert.Equal(t, "user10", unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).Name)
+
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusApproved, r.Status)
+	assert.Equal(t, "deleted-user-10", unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10}).Name)
+}
+
+func TestDeletionApprovalRequired(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Admin.RequireSecondApproval, true)()
+
+	// user2 owns org3 and the repositories of both
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	admin.IsAdmin = true
+	org3 := unittest.AssertExistsAndLoadBean(t, &org_model.Organization{ID: 3})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1, OwnerID: 2})
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3, OwnerID: 3})
+	isOwner, err := org3.IsOwnedBy(t.Context(), admin.ID)
+	require.NoError(t, err)
+	require.True(t, isOwner)
+
+	// an administrator can make themselves an owner of any organization, only their own repositories are exempted
+	assert.True(t, IsOrgDeletionApprovalRequired(admin))
+	assert.True(t, IsRepoDeletionApprovalRequired(admin, repo3))
+	assert.False(t, IsRepoDeletionApprovalRequired(admin, repo1))
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	assert.False(t, IsOrgDeletionApprovalRequired(user2))
+	assert.False(t, IsRepoDeletionApprovalRequired(user2, repo3))
+
+	setting.Admin.RequireSecondApproval = false
+	assert.False(t, IsOrgDeletionApprovalRequired(admin))
+	assert.False(t, IsRepoDeletionApprovalRequired(admin, repo3))
+}
+
+func TestApproveCreateAuthSource(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+
+	source := &auth_model.Source{Type: auth_model.PAM, Name: "pam-new", IsActive: true, Cfg: &pam.Source{ServiceName: "gitea"}}
+	r, err := RequestCreateAuthSource(ctx, admin, source)
+	require.NoError(t, err)
+
+	// the source isn't created before it's approved
+	unittest.AssertNotExistsBean(t, &auth_model.Source{Name: "pam-new"})
+
+	// the approver reviews the settings
+	changes, err := GetAuthSourceChanges(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, []*AuthSourceFieldChange{
+		{Name: "Cfg.ServiceName", NewValue: "gitea"},
+		{Name: "IsActive", NewValue: "true"},
+		{Name: "IsSyncEnabled", NewValue: "false"},
+		{Name: "Name", NewValue: "pam-new"},
+	}, changes)
+
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusApproved, r.Status)
+	source = unittest.AssertExistsAndLoadBean(t, &auth_model.Source{Name: "pam-new"})
+	assert.Equal(t, auth_model.PAM, source.Type)
+	if assert.IsType(t, &pam.Source{}, source.Cfg) {
+		assert.Equal(t, "gitea", source.Cfg.(*pam.Source).ServiceName)
+	}
+}
+
+func TestApproveEditAuthSource(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+
+	source := &auth_model.Source{Type: auth_model.PAM, Name: "pam", IsActive: true, Cfg: &pam.Source{ServiceName: "gitea"}}
+	require.NoError(t, auth_model.CreateSource(ctx, source))
+
+	source.Name = "pam-changed"
+	source.Cfg = &pam.Source{ServiceName: "changed", EmailDomain: "example.com"}
+	r, err := RequestEditAuthSource(ctx, admin, source)
+	require.NoError(t, err)
+
+	// the change isn't applied before it's approved
+	source, err = auth_model.GetSourceByID(ctx, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "pam", source.Name)
+
+	// the approver reviews the changed settings
+	changes, err := GetAuthSourceChanges(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, []*AuthSourceFieldChange{
+		{Name: "Cfg.EmailDomain", NewValue: "example.com"},
+		{Name: "Cfg.ServiceName", OldValue: "gitea", NewValue: "changed"},
+		{Name: "Name", OldValue: "pam", NewValue: "pam-changed"},
+	}, changes)
+
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusApproved, r.Status)
+	source, err = auth_model.GetSourceByID(ctx, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "pam-changed", source.Name)
+	if assert.IsType(t, &pam.Source{}, source.Cfg) {
+		assert.Equal(t, "changed", source.Cfg.(*pam.Source).ServiceName)
+		assert.Equal(t, "example.com", source.Cfg.(*pam.Source).EmailDomain)
+	}
+
+	r, err = RequestDeleteAuthSource(ctx, admin, source)
+	require.NoError(t, err)
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusApproved, r.Status)
+	unittest.AssertNotExistsBean(t, &auth_model.Source{ID: source.ID})
+}
+
+func TestApproveEditAuthSourceChangedMeanwhile(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	approver := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	approver.IsAdmin = true
+
+	source := &auth_model.Source{Type: auth_model.LDAP, Name: "ldap", IsActive: true, Cfg: &ldap.Source{Host: "ldap.example.com", BindPassword: "old-secret"}}
+	require.NoError(t, auth_model.CreateSource(ctx, source))
+
+	source, err := auth_model.GetSourceByID(ctx, source.ID)
+	require.NoError(t, err)
+	source.Cfg.(*ldap.Source).BindPassword = "new-secret"
+	r, err := RequestEditAuthSource(ctx, admin, source)
+	require.NoError(t, err)
+
+	// the secrets are masked
+	changes, err := GetAuthSourceChanges(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, []*AuthSourceFieldChange{
+		{Name: "Cfg.BindPassword", OldValue: "********", NewValue: "********", IsSecret: true},
+	}, changes)
+
+	// the change isn't applied to the settings changed after it has been requested
+	source, err = auth_model.GetSourceByID(ctx, source.ID)
+	require.NoError(t, err)
+	source.Cfg.(*ldap.Source).Host = "other.example.com"
+	require.NoError(t, auth_model.UpdateSource(ctx, source))
+	require.NoError(t, ApproveRequest(ctx, approver, r))
+	assert.Equal(t, admin_model.ApprovalStatusFailed, r.Status)
+	source, err = auth_model.GetSourceByID(ctx, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "old-secret", source.Cfg.(*ldap.Source).BindPassword)
+}
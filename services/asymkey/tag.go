@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package asymkey
+
+import (
+	"context"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/modules/git"
+)
+
+// ParseTagWithSignature checks if the signature of an annotated tag is good against keystore.
+// The tagger takes the place of the committer, so the signature must belong to the account of the tagger.
+func ParseTagWithSignature(ctx context.Context, t *git.Tag) *asymkey_model.CommitVerification {
+	return ParseCommitWithSignature(ctx, &git.Commit{
+		ID:            t.ID,
+		Author:        t.Tagger,
+		Committer:     t.Tagger,
+		CommitMessage: t.Message,
+		Signature:     t.Signature,
+	})
+}
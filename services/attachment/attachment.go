@@ -10,10 +10,13 @@ import (
 	"io"
 
 	"code.gitea.io/gitea/models/db"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context/upload"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 
 	"github.com/google/uuid"
 )
@@ -32,7 +35,10 @@ func NewAttachment(ctx context.Context, attach *repo_model.Attachment, file io.R
 		}
 		attach.Size = size
 
-		return db.Insert(ctx, attach)
+		if err := db.Insert(ctx, attach); err != nil {
+			return err
+		}
+		return malwarescan_service.ScanAttachment(ctx, attach)
 	})
 
 	return attach, err
@@ -48,9 +54,25 @@ func UploadAttachment(ctx context.Context, file io.Reader, allowedTypes string,
 		return nil, err
 	}
 
+	if err := checkAttachmentQuota(ctx, attach.RepoID, fileSize); err != nil {
+		return nil, err
+	}
+
 	return NewAttachment(ctx, attach, io.MultiReader(bytes.NewReader(buf), file), fileSize)
 }
 
+// checkAttachmentQuota checks that the upload fits into the storage quota of the repository owner
+func checkAttachmentQuota(ctx context.Context, repoID, fileSize int64) error {
+	if !setting.Quota.Enabled {
+		return nil
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	return quota_model.CheckExceeded(ctx, repo.OwnerID, quota_model.CategoryAttachments, max(fileSize, 0))
+}
+
 // UpdateAttachment updates an attachment, verifying that its name is among the allowed types.
 func UpdateAttachment(ctx context.Context, allowedTypes string, attach *repo_model.Attachment) error {
 	if err := upload.Verify(nil, attach.Name, allowedTypes); err != nil {
@@ -0,0 +1,336 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context/upload"
+)
+
+// Resumable uploads implement the core protocol of tus (https://tus.io/protocols/resumable-upload)
+// and its creation, termination, checksum and expiration extensions.
+const (
+	TusResumable          = "1.0.0"
+	TusExtensions         = "creation,termination,checksum,expiration"
+	TusChecksumAlgorithms = "md5,sha1,sha256"
+)
+
+var (
+	// ErrUploadOffsetMismatch occurs if a chunk doesn't start where the previous one ended
+	ErrUploadOffsetMismatch = util.NewInvalidArgumentErrorf("upload offset mismatch")
+	// ErrUploadChecksumMismatch occurs if a chunk doesn't match the checksum sent with it
+	ErrUploadChecksumMismatch = util.NewInvalidArgumentErrorf("upload checksum mismatch")
+	// ErrUploadChecksumAlgorithm occurs if the checksum of a chunk uses an unsupported algorithm
+	ErrUploadChecksumAlgorithm = util.NewInvalidArgumentErrorf("unsupported upload checksum algorithm")
+	// ErrUploadLengthExceeded occurs if more bytes are sent than the length declared for the upload
+	ErrUploadLengthExceeded = util.NewInvalidArgumentErrorf("upload exceeds its declared length")
+)
+
+func resumableUploadLockKey(id string) string {
+	return "attachment_upload_" + id
+}
+
+// resumableUploadChunkPath returns the path of the chunk starting at offset in the attachment storage.
+// The chunks are staged there, so the upload can be resumed on any instance and object storages don't need an append.
+func resumableUploadChunkPath(id string, offset int64) string {
+	return fmt.Sprintf("resumable-upload/%s-%d", id, offset)
+}
+
+// resumableUploadChunks returns the paths of the chunks which make up the first end bytes of the upload
+func resumableUploadChunks(id string, end int64) ([]string, error) {
+	var paths []string
+	for offset := int64(0); offset < end; {
+		p := resumableUploadChunkPath(id, offset)
+		fi, err := storage.Attachments.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if fi.Size() <= 0 {
+			return nil, fmt.Errorf("empty chunk %s", p)
+		}
+		paths = append(paths, p)
+		offset += fi.Size()
+	}
+	return paths, nil
+}
+
+// chunkReader hides the read error of a chunk from the storage, so the bytes read before the error are still saved
+type chunkReader struct {
+	r   io.Reader
+	err error
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if err != nil && err != io.EOF {
+		c.err = err
+		return n, io.EOF
+	}
+	return n, err
+}
+
+// chunksReader reads the chunks one after another
+type chunksReader struct {
+	paths []string
+	cur   storage.Object
+}
+
+func (c *chunksReader) Read(p []byte) (int, error) {
+	for {
+		if c.cur == nil {
+			if len(c.paths) == 0 {
+				return 0, io.EOF
+			}
+			obj, err := storage.Attachments.Open(c.paths[0])
+			if err != nil {
+				return 0, err
+			}
+			c.cur, c.paths = obj, c.paths[1:]
+		}
+		n, err := c.cur.Read(p)
+		if err == io.EOF {
+			_ = c.cur.Close()
+			c.cur = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *chunksReader) Close() error {
+	if c.cur == nil {
+		return nil
+	}
+	return c.cur.Close()
+}
+
+func allowedTypesOfUpload(u *repo_model.AttachmentUpload) string {
+	if u.ReleaseID != 0 {
+		return setting.Repository.Release.AllowedTypes
+	}
+	return setting.Attachment.AllowedTypes
+}
+
+// parseUploadChecksum parses an Upload-Checksum header made of the algorithm and the base64 encoded checksum
+func parseUploadChecksum(checksum string) (hash.Hash, []byte, error) {
+	algorithm, encoded, ok := strings.Cut(checksum, " ")
+	if !ok {
+		return nil, nil, util.NewInvalidArgumentErrorf("invalid upload checksum %q", checksum)
+	}
+	expected, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("invalid upload checksum %q", checksum)
+	}
+
+	switch strings.ToLower(algorithm) {
+	case "md5":
+		return md5.New(), expected, nil
+	case "sha1":
+		return sha1.New(), expected, nil
+	case "sha256":
+		return sha256.New(), expected, nil
+	}
+	return nil, nil, ErrUploadChecksumAlgorithm
+}
+
+// CreateResumableUpload starts a resumable upload of an attachment whose name and size are already known
+func CreateResumableUpload(ctx context.Context, u *repo_model.AttachmentUpload) error {
+	if err := upload.Verify(nil, u.Name, allowedTypesOfUpload(u)); err != nil {
+		return err
+	}
+
+	if err := checkAttachmentQuota(ctx, u.RepoID, u.Size); err != nil {
+		return err
+	}
+
+	return repo_model.CreateAttachmentUpload(ctx, u)
+}
+
+// AppendResumableUpload appends a chunk starting at offset to the upload. If a checksum is given, the chunk
+// is only kept if it matches. Once all the bytes have been received, the attachment is created and returned.
+func AppendResumableUpload(ctx context.Context, u *repo_model.AttachmentUpload, offset int64, r io.Reader, checksum string) (*repo_model.Attachment, error) {
+	releaser, err := globallock.Lock(ctx, resumableUploadLockKey(u.ID))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	// another request may have appended a chunk since the upload was loaded
+	current, err := repo_model.GetAttachmentUploadByID(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	*u = *current
+
+	if offset != u.BytesReceived {
+		return nil, ErrUploadOffsetMismatch
+	}
+
+	var hasher hash.Hash
+	var expected []byte
+	if checksum != "" {
+		if hasher, expected, err = parseUploadChecksum(checksum); err != nil {
+			return nil, err
+		}
+	}
+
+	remaining := u.Size - u.BytesReceived
+	cr := &chunkReader{r: io.LimitReader(r, remaining+1)}
+	var src io.Reader = cr
+	if hasher != nil {
+		src = io.TeeReader(cr, hasher)
+	}
+
+	// a chunk left behind by an interrupted request at the same offset is overwritten
+	chunkPath := resumableUploadChunkPath(u.ID, u.BytesReceived)
+	n, err := storage.Attachments.Save(chunkPath, src, -1)
+	if err != nil {
+		return nil, err
+	}
+	err = cr.err
+	if err == nil && n > remaining {
+		err = ErrUploadLengthExceeded
+	} else if err == nil && hasher != nil && !bytes.Equal(hasher.Sum(nil), expected) {
+		err = ErrUploadChecksumMismatch
+	}
+	if err != nil {
+		// without a checksum the bytes received before the connection broke are kept, so the client can resume from there
+		if hasher != nil || n > remaining || n == 0 {
+			if deleteErr := storage.Attachments.Delete(chunkPath); deleteErr != nil {
+				log.Error("Delete(%s): %v", chunkPath, deleteErr)
+			}
+			return nil, err
+		}
+		u.BytesReceived += n
+		if updateErr := repo_model.UpdateAttachmentUploadBytesReceived(ctx, u); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	if n > 0 {
+		u.BytesReceived += n
+		if err := repo_model.UpdateAttachmentUploadBytesReceived(ctx, u); err != nil {
+			return nil, err
+		}
+	} else if err := storage.Attachments.Delete(chunkPath); err != nil {
+		log.Error("Delete(%s): %v", chunkPath, err)
+	}
+
+	if !u.IsComplete() {
+		return nil, nil
+	}
+	return completeResumableUpload(ctx, u)
+}
+
+// completeResumableUpload streams the received chunks into the attachment storage and removes the upload
+func completeResumableUpload(ctx context.Context, u *repo_model.AttachmentUpload) (*repo_model.Attachment, error) {
+	paths, err := resumableUploadChunks(u.ID, u.Size)
+	if err != nil {
+		return nil, err
+	}
+	r := &chunksReader{paths: paths}
+	defer r.Close()
+
+	attach, err := UploadAttachment(ctx, r, allowedTypesOfUpload(u), u.Size, &repo_model.Attachment{
+		Name:       u.Name,
+		UploaderID: u.UploaderID,
+		RepoID:     u.RepoID,
+		IssueID:    u.IssueID,
+		ReleaseID:  u.ReleaseID,
+	})
+	if err != nil {
+		// the content will never be accepted, so there is no point in keeping it
+		if upload.IsErrFileTypeForbidden(err) {
+			if removeErr := removeResumableUpload(ctx, u); removeErr != nil {
+				log.Error("removeResumableUpload(%s): %v", u.ID, removeErr)
+			}
+		}
+		return nil, err
+	}
+
+	if err := removeResumableUpload(ctx, u); err != nil {
+		log.Error("removeResumableUpload(%s): %v", u.ID, err)
+	}
+	return attach, nil
+}
+
+// RemoveResumableUpload deletes the received data and the model of a resumable upload
+func RemoveResumableUpload(ctx context.Context, id string) error {
+	releaser, err := globallock.Lock(ctx, resumableUploadLockKey(id))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	u, err := repo_model.GetAttachmentUploadByID(ctx, id)
+	if errors.Is(err, util.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return removeResumableUpload(ctx, u)
+}
+
+// removeResumableUpload deletes the upload, the caller must hold its lock
+func removeResumableUpload(ctx context.Context, u *repo_model.AttachmentUpload) error {
+	if err := repo_model.DeleteAttachmentUploadByID(ctx, u.ID); err != nil {
+		return err
+	}
+
+	// the chunks follow each other, the last one may be left behind by an interrupted request
+	for offset := int64(0); ; {
+		p := resumableUploadChunkPath(u.ID, offset)
+		fi, err := storage.Attachments.Stat(p)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := storage.Attachments.Delete(p); err != nil {
+			return err
+		}
+		if fi.Size() <= 0 {
+			return nil
+		}
+		offset += fi.Size()
+	}
+}
+
+// DeleteExpiredResumableUploads removes the resumable uploads which haven't received data for the given duration
+func DeleteExpiredResumableUploads(ctx context.Context, olderThan time.Duration) error {
+	uploads, err := repo_model.FindExpiredAttachmentUploads(ctx, olderThan)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range uploads {
+		if err := RemoveResumableUpload(ctx, u.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
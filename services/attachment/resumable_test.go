@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attachment
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Checksum(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return "sha1 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestResumableUpload(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	u := &repo_model.AttachmentUpload{
+		RepoID:     1,
+		ReleaseID:  1,
+		UploaderID: 2,
+		Name:       "notes.txt",
+		Size:       int64(len("hello world")),
+	}
+	require.NoError(t, CreateResumableUpload(t.Context(), u))
+
+	attach, err := AppendResumableUpload(t.Context(), u, 0, strings.NewReader("hello "), sha1Checksum("hallo "))
+	assert.ErrorIs(t, err, ErrUploadChecksumMismatch)
+	assert.Nil(t, attach)
+	assert.EqualValues(t, 0, u.BytesReceived)
+
+	stale := *u
+	attach, err = AppendResumableUpload(t.Context(), u, 0, strings.NewReader("hello "), sha1Checksum("hello "))
+	assert.NoError(t, err)
+	assert.Nil(t, attach)
+	assert.EqualValues(t, 6, u.BytesReceived)
+
+	// the chunks are staged in the attachment storage
+	_, err = storage.Attachments.Stat(resumableUploadChunkPath(u.ID, 0))
+	assert.NoError(t, err)
+
+	// the upload loaded by a concurrent request is refreshed
+	_, err = AppendResumableUpload(t.Context(), &stale, 0, strings.NewReader("hello "), "")
+	assert.ErrorIs(t, err, ErrUploadOffsetMismatch)
+	assert.EqualValues(t, 6, stale.BytesReceived)
+
+	_, err = AppendResumableUpload(t.Context(), u, 6, strings.NewReader("world"), "crc32 AAAAAA==")
+	assert.ErrorIs(t, err, ErrUploadChecksumAlgorithm)
+
+	_, err = AppendResumableUpload(t.Context(), u, 6, strings.NewReader("world!"), "")
+	assert.ErrorIs(t, err, ErrUploadLengthExceeded)
+
+	// the upload is resumed from the state stored in the database
+	u, err = repo_model.GetAttachmentUploadByID(t.Context(), u.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, u.BytesReceived)
+
+	attach, err = AppendResumableUpload(t.Context(), u, 6, strings.NewReader("world"), sha1Checksum("world"))
+	require.NoError(t, err)
+	require.NotNil(t, attach)
+	assert.Equal(t, "notes.txt", attach.Name)
+	assert.EqualValues(t, 1, attach.ReleaseID)
+	assert.EqualValues(t, 11, attach.Size)
+
+	f, err := storage.Attachments.Open(attach.RelativePath())
+	require.NoError(t, err)
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(content))
+
+	_, err = repo_model.GetAttachmentUploadByID(t.Context(), u.ID)
+	assert.ErrorIs(t, err, repo_model.ErrAttachmentUploadNotExist)
+	for _, offset := range []int64{0, 6} {
+		_, err = storage.Attachments.Stat(resumableUploadChunkPath(u.ID, offset))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	}
+}
+
+func TestDeleteExpiredResumableUploads(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	u := &repo_model.AttachmentUpload{RepoID: 1, UploaderID: 2, Name: "notes.txt", Size: 10}
+	require.NoError(t, CreateResumableUpload(t.Context(), u))
+	_, err := AppendResumableUpload(t.Context(), u, 0, strings.NewReader("hello"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, DeleteExpiredResumableUploads(t.Context(), time.Hour))
+	unittest.AssertExistsAndLoadBean(t, &repo_model.AttachmentUpload{ID: u.ID})
+
+	require.NoError(t, DeleteExpiredResumableUploads(t.Context(), -time.Hour))
+	unittest.AssertNotExistsBean(t, &repo_model.AttachmentUpload{ID: u.ID})
+	_, err = storage.Attachments.Stat(resumableUploadChunkPath(u.ID, 0))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
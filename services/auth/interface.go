@@ -39,3 +39,9 @@ type PasswordAuthenticator interface {
 type SynchronizableSource interface {
 	Sync(ctx context.Context, updateExisting bool) error
 }
+
+// GroupSynchronizableSource represents a source that can synchronize the team memberships of its users with the groups
+// of the identity provider
+type GroupSynchronizableSource interface {
+	SyncGroups(ctx context.Context) error
+}
@@ -12,9 +12,11 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/auth/httpauth"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/reqctx"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/services/actions"
@@ -69,6 +71,35 @@ func CheckTaskIsRunning(ctx context.Context, taskID int64) bool {
 	return task.Status == actions_model.StatusRunning
 }
 
+// IsRequestTokenValid checks whether the token which has authenticated the request is still valid. Long-lived requests
+// like event streams check it regularly, the token may have been deleted, revoked or have expired meanwhile.
+func IsRequestTokenValid(ctx context.Context, req *http.Request, data reqctx.ContextData) (bool, error) {
+	if taskID, ok := data["ActionsTaskID"].(int64); ok {
+		return CheckTaskIsRunning(ctx, taskID), nil
+	}
+	if tokenID, ok := data["ApiTokenID"].(int64); ok {
+		return db.ExistByID[auth_model.AccessToken](ctx, tokenID)
+	}
+	if isAPIToken, _ := data["IsApiToken"].(bool); !isAPIToken {
+		return true, nil
+	}
+
+	// the OAuth2 access token is sent as bearer token or in the basic authorization
+	var tokens []string
+	if token, ok := parseToken(req); ok {
+		tokens = append(tokens, token)
+	}
+	if parsed, ok := httpauth.ParseAuthorizationHeader(req.Header.Get("Authorization")); ok && parsed.BasicAuth != nil {
+		tokens = append(tokens, parsed.BasicAuth.Username, parsed.BasicAuth.Password)
+	}
+	for _, token := range tokens {
+		if _, uid := GetOAuthAccessTokenScopeAndUserID(ctx, token); uid != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // OAuth2 implements the Auth interface and authenticates requests
 // (API requests only) by looking for an OAuth token in query parameters or the
 // "Authorization" header.
@@ -153,6 +184,7 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 	}
 	store.GetData()["IsApiToken"] = true
 	store.GetData()["ApiTokenScope"] = t.Scope
+	store.GetData()["ApiTokenID"] = t.ID
 	return t.UID
 }
 
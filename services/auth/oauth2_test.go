@@ -4,6 +4,8 @@
 package auth
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"code.gitea.io/gitea/models/unittest"
@@ -52,3 +54,28 @@ func TestCheckTaskIsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRequestTokenValid(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/repos/user2/repo1/events/stream", nil)
+	cases := map[string]struct {
+		Data     reqctx.ContextData
+		Expected bool
+	}{
+		"Session":       {Data: reqctx.ContextData{}, Expected: true},
+		"AccessToken":   {Data: reqctx.ContextData{"IsApiToken": true, "ApiTokenID": int64(1)}, Expected: true},
+		"DeletedToken":  {Data: reqctx.ContextData{"IsApiToken": true, "ApiTokenID": int64(1000)}, Expected: false},
+		"RunningTask":   {Data: reqctx.ContextData{"IsActionsToken": true, "ActionsTaskID": int64(47)}, Expected: true},
+		"CancelledTask": {Data: reqctx.ContextData{"IsActionsToken": true, "ActionsTaskID": int64(46)}, Expected: false},
+		"MissingOAuth2": {Data: reqctx.ContextData{"IsApiToken": true}, Expected: false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			valid, err := IsRequestTokenValid(t.Context(), req, c.Data)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Expected, valid)
+		})
+	}
+}
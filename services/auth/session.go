@@ -4,10 +4,17 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/session"
+	"code.gitea.io/gitea/modules/timeutil"
+	gitea_context "code.gitea.io/gitea/services/context"
+	user_service "code.gitea.io/gitea/services/user"
 )
 
 // Ensure the struct implements the interface.
@@ -46,15 +53,126 @@ func (s *Session) Verify(req *http.Request, w http.ResponseWriter, store DataSto
 
 	// Get user object
 	user, err := user_model.GetUserByID(req.Context(), id)
+	if err != nil && !user_model.IsErrUserNotExist(err) {
+		log.Error("GetUserByID: %v", err)
+		// Return the err as-is to keep current signed-in session, in case the err is something like context.Canceled. Otherwise non-existing user (nil, nil) will make the caller clear the signed-in session.
+		return nil, err
+	}
+
+	if impersonatorID, ok := sess.Get(session.KeyImpersonatorUID).(int64); ok {
+		return verifyImpersonation(req, w, store, sess, impersonatorID, user)
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	log.Trace("Session Authorization: Logged in user %-v", user)
+	return user, nil
+}
+
+// verifyImpersonation returns the user who is impersonated by an admin while the impersonation lasts, every request
+// which may change something is recorded. Afterwards the admin is signed in as themselves again.
+func verifyImpersonation(req *http.Request, w http.ResponseWriter, store DataStore, sess SessionStore, impersonatorID int64, user *user_model.User) (*user_model.User, error) {
+	impersonator, err := user_model.GetUserByID(req.Context(), impersonatorID)
 	if err != nil {
 		if !user_model.IsErrUserNotExist(err) {
 			log.Error("GetUserByID: %v", err)
-			// Return the err as-is to keep current signed-in session, in case the err is something like context.Canceled. Otherwise non-existing user (nil, nil) will make the caller clear the signed-in session.
 			return nil, err
 		}
+		_ = sess.Delete(session.KeyImpersonatorUID)
+		_ = sess.Delete(session.KeyImpersonationDeadline)
 		return nil, nil
 	}
 
-	log.Trace("Session Authorization: Logged in user %-v", user)
+	// the admin may have lost the permission since the impersonation has started
+	expired := user == nil
+	if !expired {
+		if err := user_service.CanImpersonate(req.Context(), impersonator, user); err != nil {
+			if !errors.Is(err, user_service.ErrImpersonationNotAllowed) {
+				log.Error("CanImpersonate: %v", err)
+				return nil, err
+			}
+			expired = true
+		}
+	}
+	deadline, _ := sess.Get(session.KeyImpersonationDeadline).(int64)
+	if expired || timeutil.TimeStampNow() >= timeutil.TimeStamp(deadline) {
+		if err := StopImpersonationSession(w, req, impersonator); err != nil {
+			log.Error("StopImpersonationSession: %v", err)
+			return nil, err
+		}
+		if user != nil {
+			if err := user_service.StopImpersonation(req.Context(), impersonator, user, true); err != nil {
+				log.Error("StopImpersonation: %v", err)
+			}
+		}
+		return impersonator, nil
+	}
+
+	store.GetData()["Impersonator"] = impersonator
+	store.GetData()["ImpersonationDeadline"] = timeutil.TimeStamp(deadline)
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if err := user_service.RecordImpersonatedRequest(req.Context(), impersonator, user, req.Method, req.URL.RequestURI()); err != nil {
+			log.Error("RecordImpersonatedRequest: %v", err)
+		}
+	}
+
+	log.Trace("Session Authorization: Logged in user %-v impersonated by %-v", user, impersonator)
 	return user, nil
 }
+
+// ImpersonationStaleSessionKeys are the keys of the signed-in user which mustn't be carried over when the admin
+// switches between their own identity and the impersonated one
+var ImpersonationStaleSessionKeys = []string{
+	"openid_verified_uri",
+	"openid_signin_remember",
+	"openid_determined_email",
+	"openid_determined_username",
+	"twofaUid",
+	"twofaRemember",
+	"linkAccount",
+	"linkAccountData",
+	session.KeyUserHasTwoFactorAuth,
+	session.KeyImpersonatorUID,
+	session.KeyImpersonationDeadline,
+}
+
+// StopImpersonationSession signs the admin who impersonates the current user in as themselves again in a new session,
+// it's used both when the admin stops the impersonation and when the impersonation expires
+func StopImpersonationSession(resp http.ResponseWriter, req *http.Request, impersonator *user_model.User) error {
+	hasTwoFactorAuth, err := auth_model.HasTwoFactorOrWebAuthn(req.Context(), impersonator.ID)
+	if err != nil {
+		return fmt.Errorf("HasTwoFactorOrWebAuthn: %w", err)
+	}
+
+	sess, err := session.RegenerateSession(resp, req)
+	if err != nil {
+		return fmt.Errorf("regenerate session: %w", err)
+	}
+	for _, k := range ImpersonationStaleSessionKeys {
+		if err := sess.Delete(k); err != nil {
+			return fmt.Errorf("delete %v in session[%s]: %w", k, sess.ID(), err)
+		}
+	}
+	for k, v := range map[string]any{
+		session.KeyUID:                  impersonator.ID,
+		session.KeyUname:                impersonator.Name,
+		session.KeyUserHasTwoFactorAuth: hasTwoFactorAuth,
+	} {
+		if err := sess.Set(k, v); err != nil {
+			return fmt.Errorf("set %v in session[%s]: %w", k, sess.ID(), err)
+		}
+	}
+	if err := sess.Release(); err != nil {
+		return fmt.Errorf("store session[%s]: %w", sess.ID(), err)
+	}
+
+	// force to generate a new CSRF token
+	if ctx := gitea_context.GetWebContext(req.Context()); ctx != nil {
+		ctx.Csrf.PrepareForSessionUser(ctx)
+	}
+	return nil
+}
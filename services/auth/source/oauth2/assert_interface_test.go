@@ -16,6 +16,8 @@ type sourceInterface interface {
 	auth_model.Config
 	auth_model.RegisterableSource
 	auth.PasswordAuthenticator
+	auth.SynchronizableSource
+	auth.GroupSynchronizableSource
 }
 
 var _ (sourceInterface) = &oauth2.Source{}
@@ -60,7 +60,7 @@ func init() {
 			EmailURL:   availableAttribute(github.EmailURL),
 		},
 		func(clientID, secret, callbackURL string, custom *CustomURLMapping, scopes []string) (goth.Provider, error) {
-			if setting.OAuth2Client.EnableAutoRegistration {
+			if setting.GetOAuth2Client().EnableAutoRegistration {
 				scopes = append(scopes, "user:email")
 			}
 			return github.NewCustomisedURL(clientID, secret, callbackURL, custom.AuthURL, custom.TokenURL, custom.ProfileURL, custom.EmailURL, scopes...), nil
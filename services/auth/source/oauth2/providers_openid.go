@@ -38,7 +38,7 @@ func (o *OpenIDProvider) IconHTML(size int) template.HTML {
 
 // CreateGothProvider creates a GothProvider from this Provider
 func (o *OpenIDProvider) CreateGothProvider(providerName, callbackURL string, source *Source) (goth.Provider, error) {
-	scopes := setting.OAuth2Client.OpenIDConnectScopes
+	scopes := setting.GetOAuth2Client().OpenIDConnectScopes
 	if len(scopes) == 0 {
 		scopes = append(scopes, source.Scopes...)
 	}
@@ -71,7 +71,7 @@ func init() {
 	// named gplus due to legacy gplus -> google migration (Google killed Google+). This ensures old connections still work
 	RegisterGothProvider(NewSimpleProvider("gplus", "Google", []string{"email"},
 		func(clientKey, secret, callbackURL string, scopes ...string) goth.Provider {
-			if setting.OAuth2Client.UpdateAvatar || setting.OAuth2Client.EnableAutoRegistration {
+			if setting.GetOAuth2Client().UpdateAvatar || setting.GetOAuth2Client().EnableAutoRegistration {
 				scopes = append(scopes, "profile")
 			}
 			return google.New(clientKey, secret, callbackURL, scopes...)
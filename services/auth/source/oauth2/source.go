@@ -28,6 +28,14 @@ type Source struct {
 	GroupTeamMapRemoval bool
 	RestrictedGroup     string
 
+	// GroupSyncProvider is the group API the team memberships are synchronized with periodically, empty disables it
+	GroupSyncProvider string
+	// GroupSyncURL is the base URL of the group API
+	GroupSyncURL string
+	// GroupSyncTokenURL is the token endpoint of the client credentials grant, GroupSyncToken is used if it is empty
+	GroupSyncTokenURL string
+	GroupSyncToken    string
+
 	SSHPublicKeyClaimName string
 	FullNameClaimName     string
 }
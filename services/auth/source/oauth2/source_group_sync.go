@@ -0,0 +1,188 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	auth_module "code.gitea.io/gitea/modules/auth"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/proxy"
+	source_service "code.gitea.io/gitea/services/auth/source"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// The group APIs of the identity providers the memberships can be synchronized with
+const (
+	GroupSyncProviderAzureAD  = "azuread"
+	GroupSyncProviderKeycloak = "keycloak"
+	GroupSyncProviderSCIM     = "scim"
+)
+
+// IsValidGroupSyncProvider checks whether the group API is supported, an empty provider disables the synchronization
+func IsValidGroupSyncProvider(provider string) bool {
+	switch provider {
+	case "", GroupSyncProviderAzureAD, GroupSyncProviderKeycloak, GroupSyncProviderSCIM:
+		return true
+	}
+	return false
+}
+
+var errGroupSyncUserNotExist = errors.New("the user does not exist at the identity provider")
+
+// SyncGroups synchronizes the team memberships of the users of this source with the groups they are members of at the
+// identity provider. Unlike the synchronization at login, the groups are pulled from the group API of the provider, so
+// the memberships of users who don't log in anymore are removed as well.
+func (source *Source) SyncGroups(ctx context.Context) error {
+	if source.GroupSyncProvider == "" || (source.GroupTeamMap == "" && !source.GroupTeamMapRemoval) {
+		return nil
+	}
+	log.Trace("Doing: SyncExternalGroups[%s]", source.AuthSource.Name)
+
+	groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(source.GroupTeamMap)
+	if err != nil {
+		return err
+	}
+	client, err := source.groupSyncClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	orgCache := make(map[string]*organization.Organization)
+	teamCache := make(map[string]*organization.Team)
+	opts := user_model.FindExternalUserOptions{LoginSourceID: source.AuthSource.ID}
+	return user_model.IterateExternalLogin(ctx, opts, func(ctx context.Context, e *user_model.ExternalLoginUser) error {
+		groups, err := source.fetchUserGroups(ctx, client, e.ExternalID)
+		if errors.Is(err, errGroupSyncUserNotExist) {
+			// the user has been deleted at the identity provider, so all the mapped memberships are stale
+			groups = make(container.Set[string])
+		} else if err != nil {
+			return fmt.Errorf("fetch groups of %q: %w", e.ExternalID, err)
+		}
+
+		u, err := user_model.GetUserByID(ctx, e.UserID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		return source_service.SyncGroupsToTeamsCached(ctx, u, groups, groupTeamMapping, source.GroupTeamMapRemoval, orgCache, teamCache)
+	})
+}
+
+// groupSyncClient returns the client authenticated for the group API, with a static token or with a token of the
+// client credentials grant of the OAuth2 application of this source
+func (source *Source) groupSyncClient(ctx context.Context) (*http.Client, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: &http.Transport{Proxy: proxy.Proxy()}})
+	if source.GroupSyncTokenURL == "" {
+		if source.GroupSyncToken == "" {
+			return nil, fmt.Errorf("group sync of source %q requires a token or a token URL", source.AuthSource.Name)
+		}
+		return oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: source.GroupSyncToken})), nil
+	}
+	cfg := &clientcredentials.Config{
+		ClientID:     source.ClientID,
+		ClientSecret: source.ClientSecret,
+		TokenURL:     source.GroupSyncTokenURL,
+	}
+	if source.GroupSyncProvider == GroupSyncProviderAzureAD {
+		cfg.Scopes = []string{"https://graph.microsoft.com/.default"}
+	}
+	return cfg.Client(ctx), nil
+}
+
+// fetchUserGroups returns the names and the ids of the groups the user is a member of, both can be used as keys of
+// the group team map because the providers put either of them into the group claim depending on their configuration
+func (source *Source) fetchUserGroups(ctx context.Context, client *http.Client, externalID string) (container.Set[string], error) {
+	baseURL := strings.TrimSuffix(source.GroupSyncURL, "/")
+	userPath := url.PathEscape(externalID)
+	groups := make(container.Set[string])
+
+	switch source.GroupSyncProvider {
+	case GroupSyncProviderAzureAD:
+		// https://learn.microsoft.com/en-us/graph/api/user-list-memberof
+		next := baseURL + "/users/" + userPath + "/memberOf?$select=id,displayName"
+		for next != "" {
+			var page struct {
+				Value []struct {
+					ID          string `json:"id"`
+					DisplayName string `json:"displayName"`
+				} `json:"value"`
+				NextLink string `json:"@odata.nextLink"`
+			}
+			if err := getGroupSyncJSON(ctx, client, next, &page); err != nil {
+				return nil, err
+			}
+			for _, g := range page.Value {
+				groups.AddMultiple(g.ID, g.DisplayName)
+			}
+			next = page.NextLink
+		}
+	case GroupSyncProviderKeycloak:
+		// https://www.keycloak.org/docs-api/latest/rest-api/index.html#_users, the URL is the realm of the admin API
+		var keycloakGroups []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Path string `json:"path"`
+		}
+		if err := getGroupSyncJSON(ctx, client, baseURL+"/users/"+userPath+"/groups?briefRepresentation=true&max=-1", &keycloakGroups); err != nil {
+			return nil, err
+		}
+		for _, g := range keycloakGroups {
+			groups.AddMultiple(g.ID, g.Name, g.Path)
+		}
+	case GroupSyncProviderSCIM:
+		// https://datatracker.ietf.org/doc/html/rfc7643#section-4.1.2
+		var scimUser struct {
+			Groups []struct {
+				Value   string `json:"value"`
+				Display string `json:"display"`
+			} `json:"groups"`
+		}
+		if err := getGroupSyncJSON(ctx, client, baseURL+"/Users/"+userPath+"?attributes=groups", &scimUser); err != nil {
+			return nil, err
+		}
+		for _, g := range scimUser.Groups {
+			groups.AddMultiple(g.Value, g.Display)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported group sync provider %q", source.GroupSyncProvider)
+	}
+
+	groups.Remove("")
+	return groups, nil
+}
+
+func getGroupSyncJSON(ctx context.Context, client *http.Client, reqURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errGroupSyncUserNotExist
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %d of %s", resp.StatusCode, req.URL.Path)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
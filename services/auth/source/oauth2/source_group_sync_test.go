@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package oauth2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchUserGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/users/u1/memberOf?$select=id,displayName":
+			_, _ = w.Write([]byte(`{"value":[{"id":"g1","displayName":"devs"}],"@odata.nextLink":"http://` + r.Host + `/users/u1/memberOf?page=2"}`))
+		case "/users/u1/memberOf?page=2":
+			_, _ = w.Write([]byte(`{"value":[{"id":"g2","displayName":"ops"}]}`))
+		case "/users/u1/groups?briefRepresentation=true&max=-1":
+			_, _ = w.Write([]byte(`[{"id":"g1","name":"devs","path":"/eng/devs"}]`))
+		case "/Users/u1?attributes=groups":
+			_, _ = w.Write([]byte(`{"id":"u1","groups":[{"value":"g1","display":"devs"},{"value":"g2"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetch := func(provider, externalID string) (container.Set[string], error) {
+		source := &Source{GroupSyncProvider: provider, GroupSyncURL: server.URL + "/", GroupSyncToken: "secret", ConfigBase: auth.ConfigBase{AuthSource: &auth.Source{Name: "idp"}}}
+		client, err := source.groupSyncClient(t.Context())
+		require.NoError(t, err)
+		return source.fetchUserGroups(t.Context(), client, externalID)
+	}
+
+	groups, err := fetch(GroupSyncProviderAzureAD, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, container.SetOf("g1", "devs", "g2", "ops"), groups)
+
+	groups, err = fetch(GroupSyncProviderKeycloak, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, container.SetOf("g1", "devs", "/eng/devs"), groups)
+
+	groups, err = fetch(GroupSyncProviderSCIM, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, container.SetOf("g1", "devs", "g2"), groups)
+
+	_, err = fetch(GroupSyncProviderKeycloak, "deleted")
+	assert.ErrorIs(t, err, errGroupSyncUserNotExist)
+}
+
+func TestSyncGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/kc-5/groups":
+			_, _ = w.Write([]byte(`[{"id":"g1","name":"devs","path":"/devs"}]`))
+		default:
+			// kc-4 has been deleted at the identity provider
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	source := &Source{
+		GroupSyncProvider:   GroupSyncProviderKeycloak,
+		GroupSyncURL:        server.URL,
+		GroupSyncToken:      "secret",
+		GroupTeamMap:        `{"devs": {"org3": ["team1"]}}`,
+		GroupTeamMapRemoval: true,
+		ConfigBase:          auth.ConfigBase{AuthSource: &auth.Source{ID: 13, Type: auth.OAuth2, Name: "keycloak", IsActive: true}},
+	}
+	for uid, externalID := range map[int64]string{4: "kc-4", 5: "kc-5"} {
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: uid})
+		require.NoError(t, user_model.LinkExternalToUser(t.Context(), u, &user_model.ExternalLoginUser{ExternalID: externalID, UserID: uid, LoginSourceID: 13}))
+	}
+
+	// user4 is a member of team1 of org3, user5 isn't
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: 2, UID: 4})
+	unittest.AssertNotExistsBean(t, &organization.TeamUser{TeamID: 2, UID: 5})
+
+	require.NoError(t, source.SyncGroups(t.Context()))
+	unittest.AssertNotExistsBean(t, &organization.TeamUser{TeamID: 2, UID: 4})
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: 2, UID: 5})
+}
@@ -94,10 +94,12 @@ func syncGroupsToTeamsCached(ctx context.Context, user *user_model.User, orgTeam
 				teamCache[orgName+teamName] = team
 			}
 
-			isMember, err := organization.IsTeamMember(ctx, org.ID, team.ID, user.ID)
+			// only the members of the team itself are synchronized, not the ones inherited from the child teams
+			teamUser, err := organization.GetTeamUser(ctx, team.ID, user.ID)
 			if err != nil {
 				return err
 			}
+			isMember := teamUser != nil && !teamUser.IsInherited
 
 			if action == syncAdd && !isMember {
 				if err := org_service.AddTeamMember(ctx, team, user); err != nil {
@@ -41,3 +41,34 @@ func SyncExternalUsers(ctx context.Context, updateExisting bool) error {
 	}
 	return nil
 }
+
+// SyncExternalGroups is used to synchronize the team memberships of the users of external authorization sources with
+// the groups of the identity providers
+func SyncExternalGroups(ctx context.Context) error {
+	log.Trace("Doing: SyncExternalGroups")
+
+	ls, err := db.Find[auth.Source](ctx, auth.FindSourcesOptions{})
+	if err != nil {
+		log.Error("SyncExternalGroups: %v", err)
+		return err
+	}
+
+	for _, s := range ls {
+		if !s.IsActive {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			log.Warn("SyncExternalGroups: Cancelled before update of %s", s.Name)
+			return db.ErrCancelledf("Before update of %s", s.Name)
+		default:
+		}
+
+		if syncable, ok := s.Cfg.(GroupSynchronizableSource); ok {
+			if err := syncable.SyncGroups(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
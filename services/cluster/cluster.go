@@ -0,0 +1,154 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package cluster coordinates the instances sharing the database and the storages:
+// one of them is elected as the leader to run the scheduled tasks, and the tasks are locked by leases
+// so they are never run by several instances at the same time.
+package cluster
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const leaderKey = "leader"
+
+var (
+	store leaseStore // nil if the leader election is disabled
+
+	// leaderUntil is the unix time in nanoseconds until which this instance is the leader
+	leaderUntil atomic.Int64
+)
+
+// Init starts the leader election if it's enabled
+func Init(ctx context.Context) error {
+	switch setting.Cluster.LeaderElection {
+	case "db":
+		store = dbLeaseStore{}
+	case "redis":
+		store = newRedisLeaseStore(setting.Cluster.ConnStr)
+	default:
+		return nil
+	}
+
+	// campaign before returning, so the tasks run at start know whether this instance is the leader
+	campaign(ctx)
+	go graceful.GetManager().RunWithShutdownContext(runElection)
+	return nil
+}
+
+// IsLeader returns whether this instance is the leader, it's always true if the leader election is disabled
+func IsLeader() bool {
+	return store == nil || time.Now().UnixNano() < leaderUntil.Load()
+}
+
+// Leader returns the node name of the leader, it's empty if there is no leader at the moment
+func Leader(ctx context.Context) (string, error) {
+	if store == nil {
+		return setting.Cluster.NodeName, nil
+	}
+	return store.owner(ctx, leaderKey)
+}
+
+func runElection(ctx context.Context) {
+	ticker := time.NewTicker(setting.Cluster.LeaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if IsLeader() {
+				// let another instance take over without waiting for the lease to expire
+				leaderUntil.Store(0)
+				if err := store.release(graceful.GetManager().HammerContext(), leaderKey, setting.Cluster.NodeName); err != nil {
+					log.Error("Unable to release the leadership of the cluster: %v", err)
+				}
+			}
+			return
+		case <-ticker.C:
+			campaign(ctx)
+		}
+	}
+}
+
+// campaign acquires or renews the leadership
+func campaign(ctx context.Context) {
+	ttl := setting.Cluster.LeaseDuration
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, ttl/3)
+	defer cancel()
+
+	wasLeader := IsLeader()
+	acquired, err := store.acquire(ctx, leaderKey, setting.Cluster.NodeName, ttl)
+	if err != nil {
+		log.Error("Unable to acquire the leadership of the cluster: %v", err)
+	}
+	if acquired && err == nil {
+		// keep a margin as the database only stores the expiry in seconds
+		leaderUntil.Store(start.Add(ttl - time.Second).UnixNano())
+		if !wasLeader {
+			log.Info("This instance %q is the leader of the cluster now", setting.Cluster.NodeName)
+		}
+		return
+	}
+	leaderUntil.Store(0)
+	if wasLeader {
+		log.Warn("This instance %q is no longer the leader of the cluster", setting.Cluster.NodeName)
+	}
+}
+
+// TryLock tries to acquire the lock of the key shared by the instances, it returns immediately.
+// The lock is a lease renewed until it's released, so it's released by itself if the instance stops.
+// It uses the global lock if the leader election is disabled.
+func TryLock(ctx context.Context, key string) (bool, globallock.ReleaseFunc, error) {
+	if store == nil {
+		return globallock.TryLock(ctx, key)
+	}
+
+	// the owner is unique for each lock, so the lock can't be acquired twice by the same instance
+	random, err := util.CryptoRandomString(8)
+	if err != nil {
+		return false, func() {}, err
+	}
+	owner := setting.Cluster.NodeName + ":" + random
+	ttl := setting.Cluster.LeaseDuration
+	if acquired, err := store.acquire(ctx, key, owner, ttl); err != nil || !acquired {
+		return false, func() {}, err
+	}
+
+	renewCtx, cancel := context.WithCancel(graceful.GetManager().ShutdownContext())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				if acquired, err := store.acquire(renewCtx, key, owner, ttl); err != nil || !acquired {
+					log.Error("Unable to renew the lock %q: acquired=%v, err=%v", key, acquired, err)
+				}
+			}
+		}
+	}()
+
+	var releaseOnce sync.Once
+	return true, func() {
+		releaseOnce.Do(func() {
+			cancel()
+			<-done
+			if err := store.release(graceful.GetManager().HammerContext(), key, owner); err != nil {
+				log.Error("Unable to release the lock %q: %v", key, err)
+			}
+		})
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderElection(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&store, leaseStore(dbLeaseStore{}))()
+	defer test.MockVariableValue(&setting.Cluster.NodeName, "node1")()
+	defer test.MockVariableValue(&setting.Cluster.LeaseDuration, 30*time.Second)()
+	defer leaderUntil.Store(0)
+
+	campaign(t.Context())
+	assert.True(t, IsLeader())
+	leader, err := Leader(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "node1", leader)
+
+	// another instance takes over the expired lease
+	_, err = db.GetEngine(t.Context()).Exec("UPDATE cluster_lease SET lease_until = 1 WHERE id = ?", leaderKey)
+	require.NoError(t, err)
+	ok, err := system_model.AcquireClusterLease(t.Context(), leaderKey, "node2", timeutil.TimeStampNow().Add(30))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	campaign(t.Context())
+	assert.False(t, IsLeader())
+	leader, err = Leader(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "node2", leader)
+}
+
+func TestTryLock(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&store, leaseStore(dbLeaseStore{}))()
+	defer test.MockVariableValue(&setting.Cluster.NodeName, "node1")()
+	defer test.MockVariableValue(&setting.Cluster.LeaseDuration, 3*time.Second)()
+
+	locked, release, err := TryLock(t.Context(), "cron_task:test")
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// the lock can't be acquired again, even by the same instance
+	locked2, release2, err := TryLock(t.Context(), "cron_task:test")
+	require.NoError(t, err)
+	assert.False(t, locked2)
+	release2()
+
+	// the lock is renewed while it's held
+	time.Sleep(4 * time.Second)
+	locked2, _, err = TryLock(t.Context(), "cron_task:test")
+	require.NoError(t, err)
+	assert.False(t, locked2)
+
+	release()
+	release() // releasing twice is safe
+	locked2, release2, err = TryLock(t.Context(), "cron_task:test")
+	require.NoError(t, err)
+	assert.True(t, locked2)
+	release2()
+}
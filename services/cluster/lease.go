@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cluster
+
+import (
+	"context"
+	"time"
+
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/nosql"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// leaseStore keeps the leases shared by the instances, a lease is held by one owner until it expires
+type leaseStore interface {
+	// acquire acquires or renews the lease of the key, it returns false if the lease is held by another owner
+	acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	release(ctx context.Context, key, owner string) error
+	owner(ctx context.Context, key string) (string, error)
+}
+
+type dbLeaseStore struct{}
+
+func (dbLeaseStore) acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	return system_model.AcquireClusterLease(ctx, key, owner, timeutil.TimeStampNow().AddDuration(ttl))
+}
+
+func (dbLeaseStore) release(ctx context.Context, key, owner string) error {
+	return system_model.ReleaseClusterLease(ctx, key, owner)
+}
+
+func (dbLeaseStore) owner(ctx context.Context, key string) (string, error) {
+	return system_model.GetClusterLeaseOwner(ctx, key)
+}
+
+const redisLeaseKeyPrefix = "gitea:cluster:"
+
+var (
+	redisAcquireScript = redis.NewScript(`
+local owner = redis.call("GET", KEYS[1])
+if owner == false or owner == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return 1
+end
+return 0`)
+	redisReleaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+type redisLeaseStore struct {
+	client redis.UniversalClient
+}
+
+func newRedisLeaseStore(connStr string) *redisLeaseStore {
+	return &redisLeaseStore{client: nosql.GetManager().GetRedisClient(connStr)}
+}
+
+func (s *redisLeaseStore) acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	acquired, err := redisAcquireScript.Run(ctx, s.client, []string{redisLeaseKeyPrefix + key}, owner, ttl.Milliseconds()).Int()
+	return acquired == 1, err
+}
+
+func (s *redisLeaseStore) release(ctx context.Context, key, owner string) error {
+	return redisReleaseScript.Run(ctx, s.client, []string{redisLeaseKeyPrefix + key}, owner).Err()
+}
+
+func (s *redisLeaseStore) owner(ctx context.Context, key string) (string, error) {
+	owner, err := s.client.Get(ctx, redisLeaseKeyPrefix+key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return owner, err
+}
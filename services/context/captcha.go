@@ -4,10 +4,12 @@
 package context
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
 	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/geetest"
 	"code.gitea.io/gitea/modules/hcaptcha"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/mcaptcha"
@@ -35,55 +37,99 @@ func GetImageCaptcha() *captcha.Captcha {
 	return cpt
 }
 
-// SetCaptchaData sets common captcha data
-func SetCaptchaData(ctx *Context) {
+// CaptchaProvider verifies the captcha response submitted with a form
+type CaptchaProvider interface {
+	// Verify checks whether the captcha response of the request is valid, the error explains why it isn't
+	Verify(ctx *Context) (bool, error)
+}
+
+// CaptchaProviderFunc is a CaptchaProvider implemented by a function
+type CaptchaProviderFunc func(ctx *Context) (bool, error)
+
+// Verify implements CaptchaProvider
+func (f CaptchaProviderFunc) Verify(ctx *Context) (bool, error) {
+	return f(ctx)
+}
+
+const (
+	gRecaptchaResponseField  = "g-recaptcha-response"
+	hCaptchaResponseField    = "h-captcha-response"
+	mCaptchaResponseField    = "m-captcha-response"
+	cfTurnstileResponseField = "cf-turnstile-response"
+)
+
+// responseFieldProvider verifies the token the captcha widget has put into a form field
+func responseFieldProvider(field string, verify func(ctx context.Context, response string) (bool, error)) CaptchaProvider {
+	return CaptchaProviderFunc(func(ctx *Context) (bool, error) {
+		return verify(ctx, ctx.Req.Form.Get(field))
+	})
+}
+
+var captchaProviders = map[string]CaptchaProvider{
+	setting.ImageCaptcha: CaptchaProviderFunc(func(ctx *Context) (bool, error) {
+		return GetImageCaptcha().VerifyReq(ctx.Req), nil
+	}),
+	setting.ReCaptcha:   responseFieldProvider(gRecaptchaResponseField, recaptcha.Verify),
+	setting.HCaptcha:    responseFieldProvider(hCaptchaResponseField, hcaptcha.Verify),
+	setting.MCaptcha:    responseFieldProvider(mCaptchaResponseField, mcaptcha.Verify),
+	setting.CfTurnstile: responseFieldProvider(cfTurnstileResponseField, turnstile.Verify),
+	setting.Geetest: CaptchaProviderFunc(func(ctx *Context) (bool, error) {
+		return geetest.Verify(ctx, geetest.Response{
+			LotNumber:     ctx.Req.Form.Get("lot_number"),
+			CaptchaOutput: ctx.Req.Form.Get("captcha_output"),
+			PassToken:     ctx.Req.Form.Get("pass_token"),
+			GenTime:       ctx.Req.Form.Get("gen_time"),
+		})
+	}),
+}
+
+// RegisterCaptchaProvider registers the provider of a captcha type, it replaces the provider already registered for it
+func RegisterCaptchaProvider(captchaType string, provider CaptchaProvider) {
+	captchaProviders[captchaType] = provider
+}
+
+// SetCaptchaData sets common captcha data for a form protected by the captcha type
+func SetCaptchaData(ctx *Context, captchaType string) {
 	if !setting.Service.EnableCaptcha {
 		return
 	}
 	ctx.Data["EnableCaptcha"] = setting.Service.EnableCaptcha
 	ctx.Data["RecaptchaURL"] = setting.Service.RecaptchaURL
 	ctx.Data["Captcha"] = GetImageCaptcha()
-	ctx.Data["CaptchaType"] = setting.Service.CaptchaType
+	ctx.Data["CaptchaType"] = captchaType
 	ctx.Data["RecaptchaSitekey"] = setting.Service.RecaptchaSitekey
 	ctx.Data["HcaptchaSitekey"] = setting.Service.HcaptchaSitekey
 	ctx.Data["McaptchaSitekey"] = setting.Service.McaptchaSitekey
 	ctx.Data["McaptchaURL"] = setting.Service.McaptchaURL
 	ctx.Data["CfTurnstileSitekey"] = setting.Service.CfTurnstileSitekey
+	ctx.Data["GeetestCaptchaID"] = setting.Service.GeetestCaptchaID
 }
 
-const (
-	gRecaptchaResponseField  = "g-recaptcha-response"
-	hCaptchaResponseField    = "h-captcha-response"
-	mCaptchaResponseField    = "m-captcha-response"
-	cfTurnstileResponseField = "cf-turnstile-response"
-)
+// CheckCaptcha checks the captcha response of the request with the provider of the captcha type,
+// an error is only returned if there is no such provider
+func CheckCaptcha(ctx *Context, captchaType string) (bool, error) {
+	provider, ok := captchaProviders[captchaType]
+	if !ok {
+		return false, fmt.Errorf("unknown Captcha Type: %s", captchaType)
+	}
+	valid, err := provider.Verify(ctx)
+	if err != nil {
+		log.Debug("Captcha Verify failed: %v", err)
+	}
+	return valid, nil
+}
 
 // VerifyCaptcha verifies Captcha data
 // No-op if captchas are not enabled
-func VerifyCaptcha(ctx *Context, tpl templates.TplName, form any) {
+func VerifyCaptcha(ctx *Context, captchaType string, tpl templates.TplName, form any) {
 	if !setting.Service.EnableCaptcha {
 		return
 	}
 
-	var valid bool
-	var err error
-	switch setting.Service.CaptchaType {
-	case setting.ImageCaptcha:
-		valid = GetImageCaptcha().VerifyReq(ctx.Req)
-	case setting.ReCaptcha:
-		valid, err = recaptcha.Verify(ctx, ctx.Req.Form.Get(gRecaptchaResponseField))
-	case setting.HCaptcha:
-		valid, err = hcaptcha.Verify(ctx, ctx.Req.Form.Get(hCaptchaResponseField))
-	case setting.MCaptcha:
-		valid, err = mcaptcha.Verify(ctx, ctx.Req.Form.Get(mCaptchaResponseField))
-	case setting.CfTurnstile:
-		valid, err = turnstile.Verify(ctx, ctx.Req.Form.Get(cfTurnstileResponseField))
-	default:
-		ctx.ServerError("Unknown Captcha Type", fmt.Errorf("unknown Captcha Type: %s", setting.Service.CaptchaType))
-		return
-	}
+	valid, err := CheckCaptcha(ctx, captchaType)
 	if err != nil {
-		log.Debug("Captcha Verify failed: %v", err)
+		ctx.ServerError("Unknown Captcha Type", err)
+		return
 	}
 
 	if !valid {
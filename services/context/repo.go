@@ -18,6 +18,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	unit_model "code.gitea.io/gitea/models/unit"
@@ -191,9 +192,21 @@ func (r *Repository) CanUseTimetracker(ctx context.Context, issue *issues_model.
 	// Checking for following:
 	// 1. Is timetracker enabled
 	// 2. Is the user a contributor, admin, poster or assignee and do the repository policies require this?
+	// 3. Do the time tracking rules of the organization owning the repository allow the user?
 	isAssigned, _ := issues_model.IsUserAssignedToIssue(ctx, issue, user)
-	return r.Repository.IsTimetrackerEnabled(ctx) && (!r.Repository.AllowOnlyContributorsToTrackTime(ctx) ||
-		r.Permission.CanWriteIssuesOrPulls(issue.IsPull) || issue.IsPoster(user.ID) || isAssigned)
+	if !r.Repository.IsTimetrackerEnabled(ctx) || (r.Repository.AllowOnlyContributorsToTrackTime(ctx) &&
+		!r.Permission.CanWriteIssuesOrPulls(issue.IsPull) && !issue.IsPoster(user.ID) && !isAssigned) {
+		return false
+	}
+	if user.IsAdmin {
+		return true
+	}
+	canTrack, err := organization.CanTrackTime(ctx, r.Repository.OwnerID, r.Repository.ID, user.ID)
+	if err != nil {
+		log.Error("CanTrackTime: %v", err)
+		return false
+	}
+	return canTrack
 }
 
 // CanCreateIssueDependencies returns whether or not a user can create dependencies.
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToAdminApprovalRequest converts an approval request to API format
+func ToAdminApprovalRequest(ctx context.Context, r *admin_model.ApprovalRequest, doer *user_model.User) (*api.AdminApprovalRequest, error) {
+	if err := r.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	apiRequest := &api.AdminApprovalRequest{
+		ID:         r.ID,
+		Action:     string(r.Action),
+		TargetID:   r.TargetID,
+		TargetName: r.TargetName,
+		Requester:  ToUser(ctx, r.Requester, doer),
+		Status:     string(r.Status),
+		Message:    r.Message,
+		Created:    r.CreatedUnix.AsTime(),
+		Expires:    r.ExpiresUnix.AsTime(),
+	}
+	if r.Approver != nil {
+		apiRequest.Approver = ToUser(ctx, r.Approver, doer)
+	}
+	if !r.DecidedUnix.IsZero() {
+		apiRequest.Decided = r.DecidedUnix.AsTimePtr()
+	}
+	return apiRequest, nil
+}
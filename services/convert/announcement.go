@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToAnnouncement converts an announcement to API format
+func ToAnnouncement(ctx context.Context, a *system_model.Announcement) (*api.Announcement, error) {
+	apiAnnouncement := &api.Announcement{
+		ID:          a.ID,
+		Type:        string(a.Type),
+		Content:     a.Content,
+		Audience:    string(a.Audience),
+		Dismissible: a.Dismissible,
+		Active:      a.IsActive(),
+		Created:     a.CreatedUnix.AsTime(),
+		Updated:     a.UpdatedUnix.AsTime(),
+	}
+	if !a.StartsUnix.IsZero() {
+		apiAnnouncement.StartTime = a.StartsUnix.AsTimePtr()
+	}
+	if !a.EndsUnix.IsZero() {
+		apiAnnouncement.EndTime = a.EndsUnix.AsTimePtr()
+	}
+	if a.OrgID > 0 {
+		org, err := user_model.GetUserByID(ctx, a.OrgID)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		} else if org != nil {
+			apiAnnouncement.Org = org.Name
+		}
+	}
+	return apiAnnouncement, nil
+}
+
+// ToAnnouncementList converts a list of announcements to API format
+func ToAnnouncementList(ctx context.Context, announcements []*system_model.Announcement) ([]*api.Announcement, error) {
+	result := make([]*api.Announcement, len(announcements))
+	for i, a := range announcements {
+		var err error
+		if result[i], err = ToAnnouncement(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
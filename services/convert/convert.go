@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -254,26 +255,41 @@ func ToActionWorkflowRun(ctx context.Context, repo *repo_model.Repository, run *
 	}
 	status, conclusion := ToActionsStatus(run.Status)
 	return &api.ActionWorkflowRun{
-		ID:           run.ID,
-		URL:          fmt.Sprintf("%s/actions/runs/%d", repo.APIURL(), run.ID),
-		HTMLURL:      run.HTMLURL(),
-		RunNumber:    run.Index,
-		StartedAt:    run.Started.AsLocalTime(),
-		CompletedAt:  run.Stopped.AsLocalTime(),
-		Event:        string(run.Event),
-		DisplayTitle: run.Title,
-		HeadBranch:   git.RefName(run.Ref).BranchName(),
-		HeadSha:      run.CommitSHA,
-		Status:       status,
-		Conclusion:   conclusion,
-		Path:         fmt.Sprintf("%s@%s", run.WorkflowID, run.Ref),
-		Repository:   ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeNone}),
-		TriggerActor: ToUser(ctx, run.TriggerUser, nil),
+		ID:               run.ID,
+		URL:              fmt.Sprintf("%s/actions/runs/%d", repo.APIURL(), run.ID),
+		HTMLURL:          run.HTMLURL(),
+		RunNumber:        run.Index,
+		StartedAt:        run.Started.AsLocalTime(),
+		CompletedAt:      run.Stopped.AsLocalTime(),
+		Event:            string(run.Event),
+		DisplayTitle:     run.Title,
+		HeadBranch:       git.RefName(run.Ref).BranchName(),
+		HeadSha:          run.CommitSHA,
+		Status:           status,
+		Conclusion:       conclusion,
+		Path:             fmt.Sprintf("%s@%s", run.WorkflowID, run.Ref),
+		ConcurrencyGroup: run.ConcurrencyGroup,
+		Repository:       ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeNone}),
+		TriggerActor:     ToUser(ctx, run.TriggerUser, nil),
 		// We do not have a way to get a different User for the actor than the trigger user
 		Actor: ToUser(ctx, run.TriggerUser, nil),
 	}, nil
 }
 
+// ToActionConcurrencyGroups converts the active concurrency groups of a repository to API format
+func ToActionConcurrencyGroups(groups []*actions_model.ConcurrencyGroup) []*api.ActionConcurrencyGroup {
+	ret := make([]*api.ActionConcurrencyGroup, 0, len(groups))
+	for _, group := range groups {
+		ret = append(ret, &api.ActionConcurrencyGroup{
+			Name:             group.Name,
+			JobLevel:         group.IsJobLevel,
+			InProgressRunIDs: util.Iif(group.InProgress == nil, []int64{}, group.InProgress),
+			QueuedRunIDs:     util.Iif(group.Queued == nil, []int64{}, group.Queued),
+		})
+	}
+	return ret
+}
+
 func ToWorkflowRunAction(status actions_model.Status) string {
 	var action string
 	switch status {
@@ -353,16 +369,8 @@ func ToActionWorkflowJob(ctx context.Context, repo *repo_model.Repository, task
 		if runner, ok, _ := db.GetByID[actions_model.ActionRunner](ctx, runnerID); ok {
 			runnerName = runner.Name
 		}
-		for i, step := range task.Steps {
-			stepStatus, stepConclusion := ToActionsStatus(job.Status)
-			steps = append(steps, &api.ActionWorkflowStep{
-				Name:        step.Name,
-				Number:      int64(i),
-				Status:      stepStatus,
-				Conclusion:  stepConclusion,
-				StartedAt:   step.Started.AsTime().UTC(),
-				CompletedAt: step.Stopped.AsTime().UTC(),
-			})
+		if steps, err = toActionWorkflowSteps(ctx, task); err != nil {
+			return nil, err
 		}
 	}
 
@@ -390,6 +398,114 @@ func ToActionWorkflowJob(ctx context.Context, repo *repo_model.Repository, task
 	}, nil
 }
 
+// toActionWorkflowSteps converts the steps of the task, they are loaded if they haven't been
+func toActionWorkflowSteps(ctx context.Context, task *actions_model.ActionTask) ([]*api.ActionWorkflowStep, error) {
+	if task.Steps == nil {
+		var err error
+		if task.Steps, err = actions_model.GetTaskStepsByTaskID(ctx, task.ID); err != nil {
+			return nil, err
+		}
+	}
+	steps := make([]*api.ActionWorkflowStep, 0, len(task.Steps))
+	for i, step := range task.Steps {
+		stepStatus, stepConclusion := ToActionsStatus(step.Status)
+		steps = append(steps, &api.ActionWorkflowStep{
+			Name:        step.Name,
+			Number:      int64(i),
+			Status:      stepStatus,
+			Conclusion:  stepConclusion,
+			StartedAt:   step.Started.AsTime().UTC(),
+			CompletedAt: step.Stopped.AsTime().UTC(),
+		})
+	}
+	return steps, nil
+}
+
+// ToActionWorkflowRunGraph converts the jobs of the run to the graph of the workflow,
+// with an edge from every job to the jobs needing it, the jobs expanded from a matrix are separate nodes
+func ToActionWorkflowRunGraph(ctx context.Context, run *actions_model.ActionRun) (*api.ActionWorkflowRunGraph, error) {
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		return nil, err
+	}
+	taskIDs := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		if job.TaskID > 0 {
+			taskIDs = append(taskIDs, job.TaskID)
+		}
+	}
+	tasks := make(map[int64]*actions_model.ActionTask, len(taskIDs))
+	if len(taskIDs) > 0 {
+		if err := db.GetEngine(ctx).In("id", taskIDs).Find(&tasks); err != nil {
+			return nil, err
+		}
+	}
+	runnerNames := make(map[int64]string)
+
+	status, conclusion := ToActionsStatus(run.Status)
+	graph := &api.ActionWorkflowRunGraph{
+		RunID:      run.ID,
+		WorkflowID: run.WorkflowID,
+		Status:     status,
+		Conclusion: conclusion,
+		Jobs:       make([]*api.ActionWorkflowGraphJob, 0, len(jobs)),
+		Edges:      make([]*api.ActionWorkflowGraphEdge, 0),
+	}
+	byJobID := make(map[string][]int64, len(jobs))
+	for _, job := range jobs {
+		byJobID[job.JobID] = append(byJobID[job.JobID], job.ID)
+	}
+	for _, job := range jobs {
+		matrix, err := job.Matrix()
+		if err != nil {
+			return nil, fmt.Errorf("matrix of job %d: %w", job.ID, err)
+		}
+		status, conclusion := ToActionsStatus(job.Status)
+		node := &api.ActionWorkflowGraphJob{
+			ID:          job.ID,
+			JobID:       job.JobID,
+			Name:        job.Name,
+			Status:      status,
+			Conclusion:  conclusion,
+			Needs:       job.Needs,
+			Labels:      job.RunsOn,
+			Matrix:      matrix,
+			Environment: job.Environment,
+			RunAttempt:  job.Attempt,
+			Duration:    int64(job.Duration().Seconds()),
+			Steps:       []*api.ActionWorkflowStep{},
+		}
+		if job.Started > 0 {
+			node.StartedAt = job.Started.AsTimePtr()
+		}
+		if job.Stopped > 0 {
+			node.CompletedAt = job.Stopped.AsTimePtr()
+		}
+		if task, ok := tasks[job.TaskID]; ok {
+			node.RunnerID = task.RunnerID
+			name, ok := runnerNames[task.RunnerID]
+			if !ok {
+				if runner, has, _ := db.GetByID[actions_model.ActionRunner](ctx, task.RunnerID); has {
+					name = runner.Name
+				}
+				runnerNames[task.RunnerID] = name
+			}
+			node.RunnerName = name
+			if node.Steps, err = toActionWorkflowSteps(ctx, task); err != nil {
+				return nil, err
+			}
+		}
+		graph.Jobs = append(graph.Jobs, node)
+
+		for _, need := range job.Needs {
+			for _, from := range byJobID[need] {
+				graph.Edges = append(graph.Edges, &api.ActionWorkflowGraphEdge{From: from, To: job.ID})
+			}
+		}
+	}
+	return graph, nil
+}
+
 func getActionWorkflowEntry(ctx context.Context, repo *repo_model.Repository, commit *git.Commit, folder string, entry *git.TreeEntry) *api.ActionWorkflow {
 	cfgUnit := repo.MustGetUnit(ctx, unit.TypeActions)
 	cfg := cfgUnit.ActionsConfig()
@@ -505,6 +621,77 @@ func ToActionArtifact(repo *repo_model.Repository, art *actions_model.ActionArti
 	}, nil
 }
 
+// ToActionArtifactRetention convert an artifact retention policy to api.ActionArtifactRetention
+func ToActionArtifactRetention(policy *actions_model.ActionArtifactRetention) *api.ActionArtifactRetention {
+	return &api.ActionArtifactRetention{
+		MaxAgeDays:   policy.MaxAgeDays,
+		MaxTotalSize: policy.MaxTotalSize,
+		KeepLastN:    policy.KeepLastN,
+	}
+}
+
+// ToActionArtifactUsage convert the artifact storage usages of repositories to api.ActionArtifactUsage
+func ToActionArtifactUsage(usages []*actions_model.ArtifactUsage) *api.ActionArtifactUsage {
+	ret := &api.ActionArtifactUsage{Repositories: make([]*api.ActionRepoArtifactUsage, 0, len(usages))}
+	for _, u := range usages {
+		ret.TotalCount += u.Count
+		ret.TotalSize += u.Size
+		ret.Repositories = append(ret.Repositories, &api.ActionRepoArtifactUsage{
+			RepoID:   u.Repo.ID,
+			FullName: u.Repo.FullName(),
+			Count:    u.Count,
+			Size:     u.Size,
+		})
+	}
+	return ret
+}
+
+func ToActionCache(c *actions_model.ActionCache) *api.ActionCache {
+	return &api.ActionCache{
+		ID:             c.ID,
+		Key:            c.CacheKey,
+		Version:        c.Version,
+		Ref:            c.Scope,
+		SizeInBytes:    c.Size,
+		HitCount:       c.HitCount,
+		CreatedAt:      c.CreatedUnix.AsLocalTime(),
+		LastAccessedAt: c.UsedUnix.AsLocalTime(),
+	}
+}
+
+// ToActionEnvironment convert an ActionEnvironment to api.ActionEnvironment
+func ToActionEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) *api.ActionEnvironment {
+	reviewers, err := user_model.GetUsersByIDs(ctx, env.ReviewerIDs)
+	if err != nil {
+		log.Error("GetUsersByIDs: %v", err)
+	}
+	teams, err := organization.GetTeamsByIDs(ctx, env.ReviewerTeamIDs)
+	if err != nil {
+		log.Error("GetTeamsByIDs: %v", err)
+	}
+	reviewerTeams := make([]*organization.Team, 0, len(teams))
+	for _, team := range teams {
+		reviewerTeams = append(reviewerTeams, team)
+	}
+	slices.SortFunc(reviewerTeams, func(a, b *organization.Team) int { return strings.Compare(a.LowerName, b.LowerName) })
+
+	deploymentBranches := env.DeploymentBranches
+	if deploymentBranches == nil {
+		deploymentBranches = []string{}
+	}
+	return &api.ActionEnvironment{
+		ID:                 env.ID,
+		Name:               env.Name,
+		WaitTimer:          env.WaitTimer,
+		Reviewers:          getWhitelistEntities(reviewers, env.ReviewerIDs),
+		ReviewerTeams:      getWhitelistEntities(reviewerTeams, env.ReviewerTeamIDs),
+		PreventSelfReview:  env.PreventSelfReview,
+		DeploymentBranches: deploymentBranches,
+		CreatedAt:          env.CreatedUnix.AsLocalTime(),
+		UpdatedAt:          env.UpdatedUnix.AsLocalTime(),
+	}
+}
+
 func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *api.ActionRunner {
 	status := runner.Status()
 	apiStatus := "offline"
@@ -646,6 +833,7 @@ func ToOrganization(ctx context.Context, org *organization.Organization) *api.Or
 		Location:                  org.Location,
 		Visibility:                org.Visibility.String(),
 		RepoAdminChangeTeamAccess: org.RepoAdminChangeTeamAccess,
+		DisallowImpersonation:     org.DisallowImpersonation,
 	}
 }
 
@@ -676,6 +864,7 @@ func ToTeams(ctx context.Context, teams []*organization.Team, loadOrgs bool) ([]
 			Permission:              t.AccessMode.ToString(),
 			Units:                   t.GetUnitNames(),
 			UnitsMap:                t.GetUnitsMap(),
+			ParentID:                t.ParentID,
 		}
 
 		if loadOrgs {
@@ -739,6 +928,9 @@ func ToTagProtection(ctx context.Context, pt *git_model.ProtectedTag, repo *repo
 		NamePattern:        pt.NamePattern,
 		WhitelistUsernames: whitelistUsernames,
 		WhitelistTeams:     whitelistTeams,
+		RequireAnnotated:   pt.RequireAnnotated,
+		RequireSigned:      pt.RequireSigned,
+		EnforceSemver:      pt.EnforceSemver,
 		Created:            pt.CreatedUnix.AsTime(),
 		Updated:            pt.UpdatedUnix.AsTime(),
 	}
@@ -785,6 +977,25 @@ func ToLFSLock(ctx context.Context, l *git_model.LFSLock) *api.LFSLock {
 	}
 }
 
+// ToRepoLFSLock converts a LFSLock to api.RepoLFSLock, its owner and repository must be loaded
+func ToRepoLFSLock(ctx context.Context, l *git_model.LFSLock, doer *user_model.User) *api.RepoLFSLock {
+	lock := &api.RepoLFSLock{
+		ID:      l.ID,
+		Path:    l.Path,
+		Owner:   ToUser(ctx, l.Owner, doer),
+		Created: l.Created.Round(time.Second),
+	}
+	if l.Repo != nil {
+		lock.Repo = &api.RepositoryMeta{
+			ID:       l.Repo.ID,
+			Name:     l.Repo.Name,
+			Owner:    l.Repo.OwnerName,
+			FullName: l.Repo.FullName(),
+		}
+	}
+	return lock
+}
+
 // ToChangedFile convert a gitdiff.DiffFile to api.ChangedFile
 func ToChangedFile(f *gitdiff.DiffFile, repo *repo_model.Repository, commit string) *api.ChangedFile {
 	status := "changed"
@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	api "code.gitea.io/gitea/modules/structs"
+	indexer_service "code.gitea.io/gitea/services/indexer"
+)
+
+// ToIndexer converts an indexer_service.IndexerStatus to an api.Indexer
+func ToIndexer(status *indexer_service.IndexerStatus) *api.Indexer {
+	return &api.Indexer{
+		Name:        status.Name,
+		Type:        status.Backend,
+		Enabled:     status.Enabled,
+		Available:   status.Available,
+		QueueLength: status.QueueLength,
+		Workers:     status.WorkerNumber,
+		FailedRepos: status.FailedRepoNum,
+	}
+}
+
+// ToRepoIndexerStatus converts an indexer_service.RepoIndexerStatus to an api.RepoIndexerStatus, it is only used by site admins
+func ToRepoIndexerStatus(ctx context.Context, status *indexer_service.RepoIndexerStatus) *api.RepoIndexerStatus {
+	s := &api.RepoIndexerStatus{
+		Indexer:       status.Indexer,
+		Repository:    ToRepo(ctx, status.Repo, access_model.Permission{AccessMode: perm.AccessModeAdmin}),
+		CommitSHA:     status.CommitID,
+		HeadCommitSHA: status.HeadCommitID,
+		Error:         status.LastError,
+	}
+	if status.IndexedUnix != 0 {
+		indexed := status.IndexedUnix.AsTime()
+		s.Indexed = &indexed
+	}
+	if status.FailedUnix != 0 {
+		failed := status.FailedUnix.AsTime()
+		s.Failed = &failed
+	}
+	return s
+}
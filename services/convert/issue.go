@@ -168,9 +168,11 @@ func ToStopWatches(ctx context.Context, sws []*issues_model.Stopwatch) (api.Stop
 
 	issueCache := make(map[int64]*issues_model.Issue)
 	repoCache := make(map[int64]*repo_model.Repository)
+	userCache := make(map[int64]*user_model.User)
 	var (
 		issue *issues_model.Issue
 		repo  *repo_model.Repository
+		user  *user_model.User
 		ok    bool
 		err   error
 	)
@@ -190,6 +192,17 @@ func ToStopWatches(ctx context.Context, sws []*issues_model.Stopwatch) (api.Stop
 				return nil, err
 			}
 		}
+		user, ok = userCache[sw.UserID]
+		if !ok {
+			user, err = user_model.GetPossibleUserByID(ctx, sw.UserID)
+			if err != nil {
+				if !user_model.IsErrUserNotExist(err) {
+					return nil, err
+				}
+				user = user_model.NewGhostUser()
+			}
+			userCache[sw.UserID] = user
+		}
 
 		result = append(result, api.StopWatch{
 			Created:       sw.CreatedUnix.AsTime(),
@@ -199,6 +212,7 @@ func ToStopWatches(ctx context.Context, sws []*issues_model.Stopwatch) (api.Stop
 			IssueTitle:    issue.Title,
 			RepoOwnerName: repo.OwnerName,
 			RepoName:      repo.Name,
+			UserName:      user.Name,
 		})
 	}
 	return result, nil
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToIssueField converts IssueField to API format
+func ToIssueField(f *issues_model.IssueField) *api.IssueField {
+	options := f.Options
+	if options == nil {
+		options = []string{}
+	}
+	return &api.IssueField{
+		ID:          f.ID,
+		Name:        f.Name,
+		Description: f.Description,
+		Type:        string(f.Type),
+		Options:     options,
+		IsOrgField:  f.BelongsToOrg(),
+	}
+}
+
+// ToIssueFieldList converts list of IssueField to API format
+func ToIssueFieldList(fields []*issues_model.IssueField) []*api.IssueField {
+	result := make([]*api.IssueField, len(fields))
+	for i := range fields {
+		result[i] = ToIssueField(fields[i])
+	}
+	return result
+}
+
+// ToIssueFieldValues converts the display values of the custom fields of an issue to API format
+func ToIssueFieldValues(fields []*issues_model.IssueField, values map[int64]string) []*api.IssueFieldValue {
+	result := make([]*api.IssueFieldValue, 0, len(values))
+	for _, f := range fields {
+		if value, ok := values[f.ID]; ok {
+			result = append(result, &api.IssueFieldValue{
+				FieldID: f.ID,
+				Name:    f.Name,
+				Type:    string(f.Type),
+				Value:   value,
+			})
+		}
+	}
+	return result
+}
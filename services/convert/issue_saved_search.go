@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToIssueSavedSearch converts IssueSavedSearch to API format, the URL is only known for a search of a repository
+func ToIssueSavedSearch(s *issues_model.IssueSavedSearch, repo *repo_model.Repository, shared bool) *api.IssueSavedSearch {
+	result := &api.IssueSavedSearch{
+		ID:     s.ID,
+		Name:   s.Name,
+		Query:  s.Query,
+		Type:   "issues",
+		Shared: shared,
+	}
+	if s.IsPull {
+		result.Type = "pulls"
+	}
+	if repo != nil {
+		result.HTMLURL = repo.HTMLURL() + "/" + result.Type
+		if s.Query != "" {
+			result.HTMLURL += "?" + s.Query
+		}
+	}
+	return result
+}
+
+// ToIssueSavedSearchList converts list of IssueSavedSearch of a repository to API format
+func ToIssueSavedSearchList(searches []*issues_model.IssueSavedSearch, repo *repo_model.Repository) []*api.IssueSavedSearch {
+	result := make([]*api.IssueSavedSearch, len(searches))
+	for i := range searches {
+		result[i] = ToIssueSavedSearch(searches[i], repo, searches[i].IsShared(repo))
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToIssueSLARule converts IssueSLARule to API format
+func ToIssueSLARule(r *issues_model.IssueSLARule) *api.IssueSLARule {
+	labels, exemptLabels := r.LabelIDs, r.ExemptLabelIDs
+	if labels == nil {
+		labels = []int64{}
+	}
+	if exemptLabels == nil {
+		exemptLabels = []int64{}
+	}
+	return &api.IssueSLARule{
+		ID:              r.ID,
+		Name:            r.Name,
+		Labels:          labels,
+		ExemptLabels:    exemptLabels,
+		ResponseHours:   r.ResponseHours,
+		ResolutionHours: r.ResolutionHours,
+		StaleDays:       r.StaleDays,
+	}
+}
+
+// ToIssueSLARuleList converts list of IssueSLARule to API format
+func ToIssueSLARuleList(rules []*issues_model.IssueSLARule) []*api.IssueSLARule {
+	result := make([]*api.IssueSLARule, len(rules))
+	for i := range rules {
+		result[i] = ToIssueSLARule(rules[i])
+	}
+	return result
+}
+
+// ToIssueSLAStatus converts IssueSLAStatus to API format
+func ToIssueSLAStatus(status *issues_model.IssueSLAStatus) *api.IssueSLAStatus {
+	result := &api.IssueSLAStatus{
+		RuleID:   status.Rule.ID,
+		RuleName: status.Rule.Name,
+		Kind:     string(status.Kind),
+		State:    string(status.State),
+		Due:      status.Due.AsTime(),
+	}
+	if status.Completed > 0 {
+		completed := status.Completed.AsTime()
+		result.Completed = &completed
+	}
+	return result
+}
+
+// ToIssueSLAStatusList converts list of IssueSLAStatus to API format
+func ToIssueSLAStatusList(statuses []*issues_model.IssueSLAStatus) []*api.IssueSLAStatus {
+	result := make([]*api.IssueSLAStatus, len(statuses))
+	for i := range statuses {
+		result[i] = ToIssueSLAStatus(statuses[i])
+	}
+	return result
+}
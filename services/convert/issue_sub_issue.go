@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToSubIssueProgress converts SubIssueProgress to API format
+func ToSubIssueProgress(progress issues_model.SubIssueProgress) api.SubIssueProgress {
+	return api.SubIssueProgress{
+		Total:   progress.Total,
+		Closed:  progress.Closed,
+		Percent: progress.Percent(),
+	}
+}
+
+// ToSubIssues converts a tree of sub-issues to API format, the repositories of the issues must be loaded
+func ToSubIssues(ctx context.Context, nodes issues_model.SubIssueNodes) []*api.SubIssue {
+	result := make([]*api.SubIssue, 0, len(nodes))
+	for _, node := range nodes {
+		issue := node.Issue
+		result = append(result, &api.SubIssue{
+			ID:      issue.ID,
+			Index:   issue.Index,
+			Title:   issue.Title,
+			State:   issue.State(),
+			HTMLURL: issue.HTMLURL(ctx),
+			Repo: &api.RepositoryMeta{
+				ID:       issue.Repo.ID,
+				Name:     issue.Repo.Name,
+				Owner:    issue.Repo.OwnerName,
+				FullName: issue.Repo.FullName(),
+			},
+			Progress:  ToSubIssueProgress(node.SubIssues.Progress()),
+			SubIssues: ToSubIssues(ctx, node.SubIssues),
+		})
+	}
+	return result
+}
+
+// ToIssueHierarchy converts the parent and the tree of sub-issues of an issue to API format
+func ToIssueHierarchy(ctx context.Context, doer *user_model.User, parent *issues_model.Issue, nodes issues_model.SubIssueNodes) *api.IssueHierarchy {
+	hierarchy := &api.IssueHierarchy{
+		Progress:  ToSubIssueProgress(nodes.Progress()),
+		SubIssues: ToSubIssues(ctx, nodes),
+	}
+	if parent != nil {
+		hierarchy.Parent = ToAPIIssue(ctx, doer, parent)
+	}
+	return hierarchy
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToIteration converts Iteration to API format
+func ToIteration(it *issues_model.Iteration) *api.Iteration {
+	apiIteration := &api.Iteration{
+		ID:             it.ID,
+		Name:           it.Name,
+		Description:    it.Description,
+		Start:          it.StartUnix.AsTime(),
+		End:            it.EndUnix.AsTime(),
+		Capacity:       it.Capacity,
+		State:          api.StateOpen,
+		IsOrgIteration: it.BelongsToOrg(),
+		Created:        it.CreatedUnix.AsTime(),
+		Updated:        it.UpdatedUnix.AsTime(),
+	}
+	if it.IsClosed {
+		apiIteration.State = api.StateClosed
+		apiIteration.Closed = it.ClosedUnix.AsTimePtr()
+	}
+	return apiIteration
+}
+
+// ToIterationList converts list of Iteration to API format
+func ToIterationList(iterations []*issues_model.Iteration) []*api.Iteration {
+	result := make([]*api.Iteration, len(iterations))
+	for i := range iterations {
+		result[i] = ToIteration(iterations[i])
+	}
+	return result
+}
+
+// ToIterationBurndown converts the burndown series of an iteration to API format
+func ToIterationBurndown(it *issues_model.Iteration, burndown []*issues_model.IterationProgress) *api.IterationBurndown {
+	series := make([]*api.IterationProgress, len(burndown))
+	for i, p := range burndown {
+		series[i] = &api.IterationProgress{
+			Time:              p.Time.AsTime(),
+			OpenIssues:        p.OpenIssues,
+			ClosedIssues:      p.ClosedIssues,
+			RemainingEstimate: p.RemainingEstimate,
+			CompletedEstimate: p.CompletedEstimate,
+		}
+	}
+	return &api.IterationBurndown{
+		Iteration: ToIteration(it),
+		Series:    series,
+	}
+}
+
+// ToIterationVelocities converts the velocities of iterations to API format
+func ToIterationVelocities(velocities []*issues_model.IterationVelocity) []*api.IterationVelocity {
+	result := make([]*api.IterationVelocity, len(velocities))
+	for i, v := range velocities {
+		result[i] = &api.IterationVelocity{
+			Iteration:         ToIteration(v.Iteration),
+			CommittedIssues:   v.CommittedIssues,
+			CommittedEstimate: v.CommittedEstimate,
+			CompletedIssues:   v.CompletedIssues,
+			CompletedEstimate: v.CompletedEstimate,
+			CarriedOverIssues: v.CarriedOverIssues,
+		}
+	}
+	return result
+}
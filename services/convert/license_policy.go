@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"code.gitea.io/gitea/models/organization"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToLicensePolicy converts an organization.LicensePolicy to an api.LicensePolicy
+func ToLicensePolicy(p *organization.LicensePolicy) *api.LicensePolicy {
+	return &api.LicensePolicy{
+		DeniedLicenses: p.DeniedLicenses,
+		Created:        p.CreatedUnix.AsTime(),
+		Updated:        p.UpdatedUnix.AsTime(),
+	}
+}
@@ -22,5 +22,23 @@ func ToPushMirror(ctx context.Context, pm *repo_model.PushMirror) (*api.PushMirr
 		LastError:      pm.LastError,
 		Interval:       pm.Interval.String(),
 		SyncOnCommit:   pm.SyncOnCommit,
+		BranchFilter:   pm.BranchFilter,
+		LFS:            pm.LFS,
+		Status:         ToPushMirrorStatus(pm),
 	}, nil
 }
+
+// ToPushMirrorStatus converts the status of the last sync of a push mirror to api.PushMirrorStatus
+func ToPushMirrorStatus(pm *repo_model.PushMirror) *api.PushMirrorStatus {
+	status := &api.PushMirrorStatus{
+		State:     pm.Status(),
+		LastError: pm.LastError,
+	}
+	if pm.LastUpdateUnix != 0 {
+		status.LastUpdate = pm.LastUpdateUnix.AsTimePtr()
+	}
+	if pm.LastSuccessUnix != 0 {
+		status.LastSuccess = pm.LastSuccessUnix.AsTimePtr()
+	}
+	return status
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/httplib"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToAbuseReport converts an abuse report to API format
+func ToAbuseReport(ctx context.Context, r *moderation_model.AbuseReport, doer *user_model.User) (*api.AbuseReport, error) {
+	if err := r.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	apiReport := &api.AbuseReport{
+		ID:                r.ID,
+		Status:            string(r.Status),
+		ContentType:       string(r.ContentType),
+		ContentID:         r.ContentID,
+		ContentSnapshot:   r.ContentSnapshot,
+		ContentHidden:     r.IsContentHidden,
+		Owner:             ToUser(ctx, r.Owner, doer),
+		Reporter:          ToUser(ctx, r.Reporter, doer),
+		Category:          string(r.Category),
+		Remarks:           r.Remarks,
+		Action:            string(r.Action),
+		ResolutionMessage: r.ResolutionMessage,
+		Created:           r.CreatedUnix.AsTime(),
+	}
+	if r.ContentLink != "" {
+		apiReport.ContentURL = httplib.MakeAbsoluteURL(ctx, r.ContentLink)
+	}
+	if r.Resolver != nil {
+		apiReport.Resolver = ToUser(ctx, r.Resolver, doer)
+	}
+	if !r.ResolvedUnix.IsZero() {
+		apiReport.Resolved = r.ResolvedUnix.AsTimePtr()
+	}
+	return apiReport, nil
+}
+
+// ToResolutionTemplate converts a resolution template to API format
+func ToResolutionTemplate(t *moderation_model.ResolutionTemplate) *api.ResolutionTemplate {
+	return &api.ResolutionTemplate{
+		ID:      t.ID,
+		Name:    t.Name,
+		Action:  string(t.Action),
+		Message: t.Message,
+		Created: t.CreatedUnix.AsTime(),
+		Updated: t.UpdatedUnix.AsTime(),
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToOrgRole converts OrgRole to API format
+func ToOrgRole(r *organization.OrgRole) *api.OrgRole {
+	permissions := make([]string, len(r.Permissions))
+	for i, p := range r.Permissions {
+		permissions[i] = string(p)
+	}
+	return &api.OrgRole{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+		Created:     r.CreatedUnix.AsTime(),
+		Updated:     r.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToOrgRoleList converts list of OrgRole to API format
+func ToOrgRoleList(roles []*organization.OrgRole) []*api.OrgRole {
+	result := make([]*api.OrgRole, len(roles))
+	for i := range roles {
+		result[i] = ToOrgRole(roles[i])
+	}
+	return result
+}
+
+// ToOrgRoleAssignmentList converts list of OrgRoleAssignment to API format
+func ToOrgRoleAssignmentList(ctx context.Context, assignments []*organization.OrgRoleAssignment, doer *user_model.User) ([]*api.OrgRoleAssignment, error) {
+	result := make([]*api.OrgRoleAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		apiAssignment := &api.OrgRoleAssignment{
+			ID:      a.ID,
+			RoleID:  a.RoleID,
+			Created: a.CreatedUnix.AsTime(),
+		}
+		if a.UserID > 0 {
+			u, err := user_model.GetPossibleUserByID(ctx, a.UserID)
+			if err != nil {
+				return nil, err
+			}
+			apiAssignment.User = ToUser(ctx, u, doer)
+		} else {
+			t, err := organization.GetTeamByID(ctx, a.TeamID)
+			if err != nil {
+				return nil, err
+			}
+			if apiAssignment.Team, err = ToTeam(ctx, t); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, apiAssignment)
+	}
+	return result, nil
+}
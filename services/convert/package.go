@@ -9,7 +9,9 @@ import (
 	"code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	user_model "code.gitea.io/gitea/models/user"
+	container_module "code.gitea.io/gitea/modules/packages/container"
 	api "code.gitea.io/gitea/modules/structs"
+	container_service "code.gitea.io/gitea/services/packages/container"
 )
 
 // ToPackage convert a packages.PackageDescriptor to api.Package
@@ -51,3 +53,88 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageCleanupRule converts packages.PackageCleanupRule to api.PackageCleanupRule
+func ToPackageCleanupRule(ctx context.Context, pcr *packages.PackageCleanupRule, doer *user_model.User) (*api.PackageCleanupRule, error) {
+	if err := pcr.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	var repo *api.Repository
+	if pcr.Repo != nil {
+		permission, err := access_model.GetUserRepoPermission(ctx, pcr.Repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		repo = ToRepo(ctx, pcr.Repo, permission)
+	}
+
+	return &api.PackageCleanupRule{
+		ID:                   pcr.ID,
+		Enabled:              pcr.Enabled,
+		Type:                 string(pcr.Type),
+		Repository:           repo,
+		KeepCount:            pcr.KeepCount,
+		KeepPattern:          pcr.KeepPattern,
+		RemoveDays:           pcr.RemoveDays,
+		RemovePattern:        pcr.RemovePattern,
+		MatchFullName:        pcr.MatchFullName,
+		RemovePrereleaseDays: pcr.RemovePrereleaseDays,
+		RemoveUntaggedDays:   pcr.RemoveUntaggedDays,
+		Created:              pcr.CreatedUnix.AsTime(),
+		Updated:              pcr.UpdatedUnix.AsTime(),
+	}, nil
+}
+
+// ToContainerSignatureStatus converts the signature status of a container image to api.ContainerSignatureStatus
+func ToContainerSignatureStatus(status *container_service.SignatureStatus) *api.ContainerSignatureStatus {
+	artifacts := make([]*api.ContainerArtifact, 0, len(status.Artifacts))
+	for _, artifact := range status.Artifacts {
+		artifacts = append(artifacts, &api.ContainerArtifact{
+			Kind:         string(artifact.Kind),
+			Digest:       string(artifact.Descriptor.Digest),
+			MediaType:    artifact.Descriptor.MediaType,
+			ArtifactType: artifact.Descriptor.ArtifactType,
+			Size:         artifact.Descriptor.Size,
+			Tag:          artifact.Tag,
+			Annotations:  artifact.Descriptor.Annotations,
+		})
+	}
+
+	return &api.ContainerSignatureStatus{
+		Digest:    string(status.Digest),
+		Signed:    status.Has(container_module.ArtifactKindSignature),
+		Attested:  status.Has(container_module.ArtifactKindAttestation),
+		HasSBOM:   status.Has(container_module.ArtifactKindSBOM),
+		Artifacts: artifacts,
+	}
+}
+
+// ToPackageVulnerabilityReport converts the scan of a package version and its findings to api.PackageVulnerabilityReport
+func ToPackageVulnerabilityReport(ps *packages.PackageScan, vulns []*packages.PackageVulnerability) *api.PackageVulnerabilityReport {
+	report := &api.PackageVulnerabilityReport{
+		Status:          ps.Status.String(),
+		Error:           ps.Error,
+		MaxSeverity:     ps.MaxSeverity.String(),
+		Counts:          make(map[string]int),
+		Vulnerabilities: make([]*api.PackageVulnerability, 0, len(vulns)),
+	}
+	if ps.ScannedUnix > 0 {
+		scannedAt := ps.ScannedUnix.AsTime()
+		report.ScannedAt = &scannedAt
+	}
+	for _, v := range vulns {
+		report.Counts[v.Severity.String()]++
+		report.Vulnerabilities = append(report.Vulnerabilities, &api.PackageVulnerability{
+			ID:               v.VulnerabilityID,
+			Target:           v.Target,
+			PackageName:      v.PackageName,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			Severity:         v.Severity.String(),
+			Title:            v.Title,
+			URL:              v.URL,
+		})
+	}
+	return report
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	quota_model "code.gitea.io/gitea/models/quota"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToQuotaInfo converts the quota limit and usage of an owner to API format
+func ToQuotaInfo(limit *quota_model.Limit, overridden bool, usage *quota_model.Usage) *api.QuotaInfo {
+	return &api.QuotaInfo{
+		Enabled:    setting.Quota.Enabled,
+		Overridden: overridden,
+		Limits: api.QuotaSizes{
+			Total:       limit.TotalSize,
+			Git:         limit.GitSize,
+			LFS:         limit.LFSSize,
+			Packages:    limit.PackagesSize,
+			Attachments: limit.AttachmentsSize,
+			Artifacts:   limit.ArtifactsSize,
+		},
+		Usage: api.QuotaSizes{
+			Total:       usage.Total(),
+			Git:         usage.Git,
+			LFS:         usage.LFS,
+			Packages:    usage.Packages,
+			Attachments: usage.Attachments,
+			Artifacts:   usage.Artifacts,
+		},
+	}
+}
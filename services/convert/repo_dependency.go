@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/sbom"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToRepoDependency converts a repo_model.RepoDependency to an api.RepoDependency
+func ToRepoDependency(dep *repo_model.RepoDependency) *api.RepoDependency {
+	component := &sbom.Component{Ecosystem: sbom.Ecosystem(dep.Ecosystem), Name: dep.Name, Version: dep.Version}
+	return &api.RepoDependency{
+		Ecosystem:   dep.Ecosystem,
+		Name:        dep.Name,
+		Version:     dep.Version,
+		Requirement: dep.Requirement,
+		Scope:       dep.Scope,
+		Direct:      dep.IsDirect,
+		License:     dep.License,
+		Manifest:    dep.Manifest,
+		PackageURL:  component.PackageURL(),
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"fmt"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToRepoSymbol converts a repo_model.RepoSymbol to an api.RepoSymbol
+func ToRepoSymbol(repo *repo_model.Repository, symbol *repo_model.RepoSymbol) *api.RepoSymbol {
+	return &api.RepoSymbol{
+		Name:      symbol.Name,
+		Kind:      symbol.Kind,
+		Language:  symbol.Language,
+		Path:      symbol.Path,
+		Line:      symbol.Line,
+		EndLine:   symbol.EndLine,
+		Scope:     symbol.Scope,
+		ScopeKind: symbol.ScopeKind,
+		CommitID:  symbol.CommitID,
+		HTMLURL:   fmt.Sprintf("%s/src/commit/%s/%s#L%d", repo.HTMLURL(), symbol.CommitID, util.PathEscapeSegments(symbol.Path), symbol.Line),
+	}
+}
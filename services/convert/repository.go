@@ -103,6 +103,8 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 	defaultDeleteBranchAfterMerge := false
 	defaultMergeStyle := repo_model.MergeStyleMerge
 	defaultAllowMaintainerEdit := false
+	requireSignOff := false
+	signOffExemptUsers := make([]string, 0)
 	if unit, err := repo.GetUnit(ctx, unit_model.TypePullRequests); err == nil {
 		config := unit.PullRequestsConfig()
 		hasPullRequests = true
@@ -118,6 +120,10 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 		defaultDeleteBranchAfterMerge = config.DefaultDeleteBranchAfterMerge
 		defaultMergeStyle = config.GetDefaultMergeStyle()
 		defaultAllowMaintainerEdit = config.DefaultAllowMaintainerEdit
+		requireSignOff = config.RequireSignOff
+		if config.SignOffExemptUsers != nil {
+			signOffExemptUsers = config.SignOffExemptUsers
+		}
 	}
 	hasProjects := false
 	projectsMode := repo_model.ProjectsModeAll
@@ -128,8 +134,10 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 	}
 
 	hasReleases := false
-	if _, err := repo.GetUnit(ctx, unit_model.TypeReleases); err == nil {
+	publishReleaseAssets := false
+	if unit, err := repo.GetUnit(ctx, unit_model.TypeReleases); err == nil {
 		hasReleases = true
+		publishReleaseAssets = unit.ReleasesConfig().PublishAssetsToPackages
 	}
 
 	hasPackages := false
@@ -228,6 +236,7 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 		HasProjects:                   hasProjects,
 		ProjectsMode:                  string(projectsMode),
 		HasReleases:                   hasReleases,
+		PublishReleaseAssets:          publishReleaseAssets,
 		HasPackages:                   hasPackages,
 		HasActions:                    hasActions,
 		ExternalWiki:                  externalWiki,
@@ -244,6 +253,8 @@ func innerToRepo(ctx context.Context, repo *repo_model.Repository, permissionInR
 		DefaultDeleteBranchAfterMerge: defaultDeleteBranchAfterMerge,
 		DefaultMergeStyle:             string(defaultMergeStyle),
 		DefaultAllowMaintainerEdit:    defaultAllowMaintainerEdit,
+		RequireSignOff:                requireSignOff,
+		SignOffExemptUsers:            signOffExemptUsers,
 		AvatarURL:                     repo.AvatarLink(ctx),
 		Internal:                      !repo.IsPrivate && repo.Owner.Visibility == api.VisibleTypePrivate,
 		MirrorInterval:                mirrorInterval,
@@ -11,7 +11,8 @@ import (
 // ToSecret converts Secret to API format
 func ToSecret(secret *secret_model.Secret) *api.Secret {
 	result := &api.Secret{
-		Name: secret.Name,
+		Name:     secret.Name,
+		Provider: secret.Provider,
 	}
 
 	return result
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"code.gitea.io/gitea/models/organization"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToSignOffPolicy converts an organization.SignOffPolicy to an api.SignOffPolicy
+func ToSignOffPolicy(p *organization.SignOffPolicy) *api.SignOffPolicy {
+	exemptUsers := p.ExemptUsers
+	if exemptUsers == nil {
+		exemptUsers = make([]string, 0)
+	}
+	return &api.SignOffPolicy{
+		ExemptUsers: exemptUsers,
+		Created:     p.CreatedUnix.AsTime(),
+		Updated:     p.UpdatedUnix.AsTime(),
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToTermsDocument converts a version of a policy document to API format
+func ToTermsDocument(d *user_model.TermsDocument, current bool) *api.TermsDocument {
+	return &api.TermsDocument{
+		ID:      d.ID,
+		Kind:    string(d.Kind),
+		Version: d.Version,
+		Title:   d.Title,
+		Content: d.Content,
+		Current: current,
+		Created: d.CreatedUnix.AsTime(),
+	}
+}
+
+// ToTermsAcceptanceList converts a list of acceptances to API format, the acceptances of deleted users are skipped
+func ToTermsAcceptanceList(ctx context.Context, acceptances []*user_model.TermsAcceptance, doer *user_model.User) ([]*api.TermsAcceptance, error) {
+	userIDs := make([]int64, 0, len(acceptances))
+	for _, a := range acceptances {
+		userIDs = append(userIDs, a.UserID)
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.TermsAcceptance, 0, len(acceptances))
+	for _, a := range acceptances {
+		u, ok := users[a.UserID]
+		if !ok {
+			continue
+		}
+		result = append(result, &api.TermsAcceptance{
+			DocumentID: a.DocumentID,
+			User:       ToUser(ctx, u, doer),
+			Accepted:   a.CreatedUnix.AsTime(),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToTrackedTimeSums converts the sums of a tracked time report to API format
+func ToTrackedTimeSums(sums []*issues_model.TrackedTimeSum) []*api.TrackedTimeSum {
+	result := make([]*api.TrackedTimeSum, len(sums))
+	for i, sum := range sums {
+		result[i] = &api.TrackedTimeSum{
+			UserName:    sum.UserName,
+			MilestoneID: sum.MilestoneID,
+			Milestone:   sum.MilestoneName,
+			IssueIndex:  sum.IssueIndex,
+			IssueTitle:  sum.IssueTitle,
+			Time:        sum.SumTime,
+			Duration:    util.SecToHours(sum.SumTime),
+		}
+		if sum.RepoID != 0 {
+			result[i].Repository = sum.RepoOwnerName + "/" + sum.RepoName
+		}
+	}
+	return result
+}
+
+// ToTimeTrackingRules converts the time tracking rules of an organization to API format
+func ToTimeTrackingRules(ctx context.Context, rules []*organization.TimeTrackingRule) ([]*api.TimeTrackingRule, error) {
+	repoIDs := make([]int64, 0, len(rules))
+	for _, r := range rules {
+		if r.RepoID != 0 {
+			repoIDs = append(repoIDs, r.RepoID)
+		}
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.TimeTrackingRule, len(rules))
+	for i, r := range rules {
+		result[i] = &api.TimeTrackingRule{
+			ID:      r.ID,
+			Teams:   r.TeamIDs,
+			Created: r.CreatedUnix.AsTime(),
+			Updated: r.UpdatedUnix.AsTime(),
+		}
+		if result[i].Teams == nil {
+			result[i].Teams = []int64{}
+		}
+		if repo := repos[r.RepoID]; repo != nil {
+			result[i].Repository = repo.Name
+		}
+	}
+	return result, nil
+}
@@ -40,6 +40,12 @@ func ToGitServiceType(value string) structs.GitServiceType {
 		return structs.CodebaseService
 	case "codecommit":
 		return structs.CodeCommitService
+	case "bitbucketserver":
+		return structs.BitbucketServerService
+	case "azuredevops":
+		return structs.AzureDevOpsService
+	case "gerrit":
+		return structs.GerritService
 	default:
 		return structs.PlainGitService
 	}
@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	activities_model "code.gitea.io/gitea/models/activities"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToWebPushSubscription converts a web push subscription to API format
+func ToWebPushSubscription(sub *activities_model.WebPushSubscription) *api.WebPushSubscription {
+	return &api.WebPushSubscription{
+		ID:        sub.ID,
+		Endpoint:  sub.Endpoint,
+		UserAgent: sub.UserAgent,
+		Created:   sub.CreatedUnix.AsTime(),
+		Updated:   sub.UpdatedUnix.AsTime(),
+	}
+}
@@ -14,12 +14,12 @@ import (
 	"code.gitea.io/gitea/models/db"
 	system_model "code.gitea.io/gitea/models/system"
 	user_model "code.gitea.io/gitea/models/user"
-	"code.gitea.io/gitea/modules/globallock"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/translation"
+	"code.gitea.io/gitea/services/cluster"
 )
 
 var (
@@ -63,8 +63,13 @@ func (t *Task) GetConfig() Config {
 	return reflect.New(reflect.TypeOf(t.config)).Elem().Interface().(Config)
 }
 
-// Run will run the task incrementing the cron counter with no user defined
+// Run will run the task incrementing the cron counter with no user defined,
+// the scheduled tasks are only run by the leader if there are several instances
 func (t *Task) Run() {
+	if !cluster.IsLeader() {
+		log.Trace("skip cron task %q as this instance is not the leader", t.Name)
+		return
+	}
 	t.RunWithUser(&user_model.User{
 		ID:        -1,
 		Name:      "(Cron)",
@@ -78,7 +83,7 @@ func getCronTaskLockKey(name string) string {
 
 // RunWithUser will run the task incrementing the cron counter at the time with User
 func (t *Task) RunWithUser(doer *user_model.User, config Config) {
-	locked, releaser, err := globallock.TryLock(graceful.GetManager().ShutdownContext(), getCronTaskLockKey(t.Name))
+	locked, releaser, err := cluster.TryLock(graceful.GetManager().ShutdownContext(), getCronTaskLockKey(t.Name))
 	if err != nil {
 		log.Error("Failed to acquire lock for cron task %q: %v", t.Name, err)
 		return
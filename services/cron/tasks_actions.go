@@ -20,6 +20,7 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerReleaseWaitTimers()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +75,13 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerReleaseWaitTimers() {
+	RegisterTaskFatal("release_environment_wait_timers", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.ReleaseElapsedWaitTimers(ctx)
+	})
+}
@@ -13,10 +13,14 @@ import (
 	"code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/setting"
+	attachment_service "code.gitea.io/gitea/services/attachment"
 	"code.gitea.io/gitea/services/auth"
+	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/mailer"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	packages_scan_service "code.gitea.io/gitea/services/packages/scan"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 )
@@ -101,6 +105,16 @@ func registerSyncExternalUsers() {
 	})
 }
 
+func registerSyncExternalGroups() {
+	RegisterTaskFatal("sync_external_groups", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return auth.SyncExternalGroups(ctx)
+	})
+}
+
 func registerDeletedBranchesCleanup() {
 	RegisterTaskFatal("deleted_branches_cleanup", &OlderThanConfig{
 		BaseConfig: BaseConfig{
@@ -156,6 +170,20 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerScanPackages() {
+	RegisterTaskFatal("scan_packages", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		OlderThan: 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*OlderThanConfig)
+		return packages_scan_service.ScanOutdated(ctx, realConfig.OlderThan)
+	})
+}
+
 func registerSyncRepoLicenses() {
 	RegisterTaskFatal("sync_repo_licenses", &BaseConfig{
 		Enabled:    false,
@@ -166,6 +194,44 @@ func registerSyncRepoLicenses() {
 	})
 }
 
+func registerCheckIssueSLAs() {
+	type IssueSLAConfig struct {
+		BaseConfig
+		RemindBefore time.Duration
+	}
+	RegisterTaskFatal("check_issue_sla", &IssueSLAConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@every 10m",
+		},
+		RemindBefore: time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*IssueSLAConfig)
+		return issue_service.CheckIssueSLAs(ctx, realConfig.RemindBefore)
+	})
+}
+
+func registerSendEmailDigests() {
+	RegisterTaskFatal("send_email_digests", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return mailer.SendEmailDigests(ctx)
+	})
+}
+
+func registerCleanupAttachmentUploads() {
+	RegisterTaskFatal("cleanup_attachment_uploads", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return attachment_service.DeleteExpiredResumableUploads(ctx, setting.Attachment.ResumableUploadExpiry)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -174,6 +240,7 @@ func initBasicTasks() {
 	registerCheckRepoStats()
 	registerArchiveCleanup()
 	registerSyncExternalUsers()
+	registerSyncExternalGroups()
 	registerDeletedBranchesCleanup()
 	if !setting.Repository.DisableMigrations {
 		registerUpdateMigrationPosterID()
@@ -181,6 +248,14 @@ func initBasicTasks() {
 	registerCleanupHookTaskTable()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
+		if setting.Packages.Scan.Enabled {
+			registerScanPackages()
+		}
 	}
 	registerSyncRepoLicenses()
+	registerCheckIssueSLAs()
+	registerSendEmailDigests()
+	if setting.Attachment.Enabled {
+		registerCleanupAttachmentUploads()
+	}
 }
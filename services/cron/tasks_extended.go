@@ -11,10 +11,12 @@ import (
 	"code.gitea.io/gitea/models/system"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git/gitcmd"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/updatechecker"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	"code.gitea.io/gitea/services/mailer"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 	user_service "code.gitea.io/gitea/services/user"
@@ -214,6 +216,43 @@ func registerGCLFS() {
 	})
 }
 
+func registerExpireLFSLocks() {
+	if !setting.LFS.StartServer {
+		return
+	}
+
+	RegisterTaskFatal("expire_lfs_locks", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		OlderThan: 30 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*OlderThanConfig)
+		locks, err := repo_service.ExpireLFSLocks(ctx, realConfig.OlderThan)
+		if err != nil {
+			return err
+		}
+		return mailer.SendLFSLocksExpiredMail(ctx, locks)
+	})
+}
+
+func registerTransitionStorageTiers() {
+	if setting.LFS.ColdTier == nil && setting.RepoArchive.ColdTier == nil {
+		return
+	}
+
+	// the cold tiers are opted in by configuring them, so the transition is enabled by default
+	RegisterTaskFatal("transition_storage_tiers", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return repo_service.TransitionStorageTiers(ctx)
+	})
+}
+
 func registerRebuildIssueIndexer() {
 	RegisterTaskFatal("rebuild_issue_indexer", &BaseConfig{
 		Enabled:    false,
@@ -224,6 +263,16 @@ func registerRebuildIssueIndexer() {
 	})
 }
 
+func registerRebuildCodeIndexer() {
+	RegisterTaskFatal("rebuild_code_indexer", &BaseConfig{
+		Enabled:    false,
+		RunAtStart: false,
+		Schedule:   "@annually",
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		return code_indexer.PopulateRepoIndexer(ctx)
+	})
+}
+
 func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
@@ -238,5 +287,8 @@ func initExtendedTasks() {
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
 	registerGCLFS()
+	registerExpireLFSLocks()
+	registerTransitionStorageTiers()
 	registerRebuildIssueIndexer()
+	registerRebuildCodeIndexer()
 }
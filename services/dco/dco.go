@@ -0,0 +1,238 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package dco enforces the Developer Certificate of Origin: the commits of pull requests
+// must carry a "Signed-off-by" trailer of their authors.
+package dco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	org_model "code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	commitstatus_service "code.gitea.io/gitea/services/repository/commitstatus"
+)
+
+// StatusContext is the context of the commit statuses created by the sign-off checks
+const StatusContext = "dco"
+
+const signOffTrailer = "signed-off-by:"
+
+// HasSignOff checks if the commit message has a "Signed-off-by" trailer whose name and email match the author
+func HasSignOff(message string, author *git.Signature) bool {
+	for line := range strings.SplitSeq(message, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < len(signOffTrailer) || !strings.EqualFold(line[:len(signOffTrailer)], signOffTrailer) {
+			continue
+		}
+		value := strings.TrimSpace(line[len(signOffTrailer):])
+		name, email, ok := strings.Cut(value, "<")
+		if !ok || !strings.HasSuffix(email, ">") {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(author.Name)) &&
+			strings.EqualFold(strings.TrimSuffix(email, ">"), author.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// Settings are the sign-off requirements of a repository
+type Settings struct {
+	Required bool
+	// ExemptUsers are the lower case names of the users whose commits don't need to be signed off
+	ExemptUsers container.Set[string]
+}
+
+// GetSettings returns the sign-off requirements of a repository, which are required if the repository
+// or the sign-off policy of its organization require them. The exempt users of both apply.
+func GetSettings(ctx context.Context, repo *repo_model.Repository) (*Settings, error) {
+	settings := &Settings{ExemptUsers: make(container.Set[string])}
+	if u, err := repo.GetUnit(ctx, unit.TypePullRequests); err == nil {
+		config := u.PullRequestsConfig()
+		if config.RequireSignOff {
+			settings.Required = true
+			for _, name := range config.SignOffExemptUsers {
+				settings.ExemptUsers.Add(strings.ToLower(name))
+			}
+		}
+	} else if !repo_model.IsErrUnitTypeNotExist(err) {
+		return nil, err
+	}
+
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if repo.Owner.IsOrganization() {
+		policy, err := org_model.GetSignOffPolicy(ctx, repo.OwnerID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+		if policy != nil {
+			settings.Required = true
+			for _, name := range policy.ExemptUsers {
+				settings.ExemptUsers.Add(strings.ToLower(name))
+			}
+		}
+	}
+	return settings, nil
+}
+
+// CommitResult is the result of the sign-off check of a commit
+type CommitResult struct {
+	Commit    *git.Commit
+	SignedOff bool
+	// Exempt is set if the author is an exempt user
+	Exempt bool
+	// Merge commits don't need to be signed off
+	Merge bool
+}
+
+// Passed returns whether the commit doesn't need to be signed off or is signed off
+func (r *CommitResult) Passed() bool {
+	return r.SignedOff || r.Exempt || r.Merge
+}
+
+func checkCommit(ctx context.Context, settings *Settings, commit *git.Commit) (*CommitResult, error) {
+	result := &CommitResult{
+		Commit:    commit,
+		SignedOff: HasSignOff(commit.CommitMessage, commit.Author),
+		Merge:     commit.ParentCount() > 1,
+	}
+	if !result.SignedOff && len(settings.ExemptUsers) > 0 {
+		u, err := user_model.GetUserByEmail(ctx, commit.Author.Email)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		}
+		result.Exempt = u != nil && settings.ExemptUsers.Contains(u.LowerName)
+	}
+	return result, nil
+}
+
+// CheckPullRequest checks the sign-offs of the commits of a pull request, the newest commit first
+func CheckPullRequest(ctx context.Context, pr *issues_model.PullRequest, settings *Settings) ([]*CommitResult, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+	if err != nil {
+		return nil, err
+	}
+	commits, err := gitRepo.CommitsBetweenIDs(headCommitID, pr.MergeBase)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*CommitResult, 0, len(commits))
+	for _, commit := range commits {
+		result, err := checkCommit(ctx, settings, commit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+var checkQueue *queue.WorkerPoolQueue[int64]
+
+// Init starts the queue which checks the sign-offs of pull requests
+func Init() error {
+	checkQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "dco_check", handler)
+	if checkQueue == nil {
+		return errors.New("unable to create dco_check queue")
+	}
+	go graceful.GetManager().RunWithCancel(checkQueue)
+
+	notify_service.RegisterNotifier(&dcoNotifier{})
+	return nil
+}
+
+func handler(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, pullID := range items {
+		if err := checkPullRequest(ctx, pullID); err != nil {
+			log.Error("Sign-off check of pull request %d failed: %v", pullID, err)
+		}
+	}
+	return nil
+}
+
+// AddToQueue adds the pull request to the queue of sign-off checks
+func AddToQueue(pr *issues_model.PullRequest) {
+	if err := checkQueue.Push(pr.ID); err != nil {
+		log.Error("Unable to push pull request %d to the dco check queue: %v", pr.ID, err)
+	}
+}
+
+func checkPullRequest(ctx context.Context, pullID int64) error {
+	pr, err := issues_model.GetPullRequestByID(ctx, pullID)
+	if err != nil {
+		return err
+	}
+	if pr.HasMerged {
+		return nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	settings, err := GetSettings(ctx, pr.BaseRepo)
+	if err != nil || !settings.Required {
+		return err
+	}
+
+	results, err := CheckPullRequest(ctx, pr, settings)
+	if err != nil || len(results) == 0 {
+		return err
+	}
+
+	failed := make([]string, 0, len(results))
+	for _, r := range results {
+		if !r.Passed() {
+			failed = append(failed, base.ShortSha(r.Commit.ID.String()))
+		}
+	}
+	state, description := commitstatus.CommitStatusSuccess, "All commits are signed off"
+	if len(failed) > 0 {
+		state = commitstatus.CommitStatusFailure
+		description = fmt.Sprintf("%d of %d commits are not signed off: %s", len(failed), len(results), strings.Join(failed, ", "))
+		if len(description) > 255 {
+			description = description[:252] + "..."
+		}
+	}
+
+	sha := results[0].Commit.ID.String()
+	creator := user_model.NewActionsUser()
+	return commitstatus_service.CreateCommitStatus(ctx, pr.BaseRepo, creator, sha, &git_model.CommitStatus{
+		SHA:         sha,
+		TargetURL:   fmt.Sprintf("%s/pulls/%d/commits", pr.BaseRepo.Link(), pr.Index),
+		Description: description,
+		Context:     StatusContext,
+		CreatorID:   creator.ID,
+		State:       state,
+	})
+}
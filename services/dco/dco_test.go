@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dco
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasSignOff(t *testing.T) {
+	author := &git.Signature{Name: "Jane Doe", Email: "jane@example.com"}
+
+	cases := []struct {
+		message  string
+		expected bool
+	}{
+		{"Fix bug\n\nSigned-off-by: Jane Doe <jane@example.com>\n", true},
+		{"Fix bug\n\nsigned-off-by:  jane doe <JANE@example.com>", true},
+		{"Fix bug\n\nCo-authored-by: John <john@example.com>\nSigned-off-by: Jane Doe <jane@example.com>", true},
+		{"Fix bug", false},
+		{"Fix bug\n\nSigned-off-by: John Doe <john@example.com>", false},
+		{"Fix bug\n\nSigned-off-by: Jane Doe <jane@example.org>", false},
+		{"Fix bug\n\nSigned-off-by: Jane Doe", false},
+		{"Fix bug\n\nSigned-off-by:", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, HasSignOff(c.message, author), c.message)
+	}
+}
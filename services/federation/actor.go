@@ -0,0 +1,170 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/activitypub"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+func fetch(ctx context.Context, link, accept string) ([]byte, error) {
+	// the remote documents are only fetched from the hosts allowed by the federation settings
+	req := httplib.NewRequest(link, http.MethodGet).SetContext(ctx).SetTransport(activitypub.NewTransport())
+	req.Header("Accept", accept)
+	req.Header("User-Agent", "Gitea/"+setting.AppVer)
+	resp, err := req.Response()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s failed with status %s", link, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, setting.Federation.MaxSize))
+}
+
+// naturalValue returns the first value of a natural language property, its String method brackets the other ones
+func naturalValue(v ap.NaturalLanguageValues) string {
+	return v.First().Value.String()
+}
+
+// FetchActor fetches a remote actor, which must be on the host of its IRI like its inbox
+func FetchActor(ctx context.Context, actorIRI string) (*ap.Actor, error) {
+	b, err := fetch(ctx, actorIRI, activitypub.ActivityStreamsContentType)
+	if err != nil {
+		return nil, err
+	}
+	item, err := ap.UnmarshalJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := ap.ToActor(item)
+	if err != nil {
+		return nil, err
+	}
+	if !sameHost(actorIRI, actor.GetLink().String()) {
+		return nil, util.NewInvalidArgumentErrorf("the actor %s is not on the host of %s", actor.GetLink(), actorIRI)
+	}
+	if _, err := actorInbox(actor); err != nil {
+		return nil, err
+	}
+	return actor, nil
+}
+
+// ResolveRepositoryActor fetches the Repository actor of the home page of a repository with WebFinger
+func ResolveRepositoryActor(ctx context.Context, repoURL string) (*ap.Actor, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, util.NewInvalidArgumentErrorf("invalid repository URL %q", repoURL)
+	}
+	finger := fmt.Sprintf("%s://%s/.well-known/webfinger?resource=%s", u.Scheme, u.Host, url.QueryEscape(repoURL))
+	b, err := fetch(ctx, finger, "application/jrd+json")
+	if err != nil {
+		return nil, err
+	}
+
+	var jrd struct {
+		Links []struct {
+			Rel  string `json:"rel"`
+			Type string `json:"type"`
+			Href string `json:"href"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal(b, &jrd); err != nil {
+		return nil, err
+	}
+	for _, link := range jrd.Links {
+		if link.Rel == "self" && link.Type == "application/activity+json" {
+			if !sameHost(repoURL, link.Href) {
+				return nil, util.NewInvalidArgumentErrorf("the actor %s is not on the host of %s", link.Href, repoURL)
+			}
+			actor, err := FetchActor(ctx, link.Href)
+			if err != nil {
+				return nil, err
+			}
+			if actor.Type != RepositoryType {
+				return nil, util.NewInvalidArgumentErrorf("%s is not a repository", repoURL)
+			}
+			return actor, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("%s has no ActivityPub actor", repoURL)
+}
+
+// actorCloneURL returns the URL to clone the Git repository of a remote Repository actor, which is a Gitea one
+func actorCloneURL(actor *ap.Actor) (string, error) {
+	if actor.URL == nil {
+		return "", util.NewInvalidArgumentErrorf("the repository %s has no URL", actor.GetLink())
+	}
+	return strings.TrimSuffix(actor.URL.GetLink().String(), "/") + ".git", nil
+}
+
+var (
+	invalidUsernameCharsPattern   = regexp.MustCompile(`[^\w.-]+`)
+	consecutiveUsernameSepPattern = regexp.MustCompile(`[-._]{2,}`)
+)
+
+// getOrCreateFederatedUser returns the local user of the remote user type standing for a remote actor
+func getOrCreateFederatedUser(ctx context.Context, actor *ap.Actor) (*user_model.User, error) {
+	actorIRI := actor.GetLink().String()
+	u, err := user_model.GetFederatedUserByActorIRI(ctx, actorIRI)
+	if err == nil || !user_model.IsErrUserNotExist(err) {
+		return u, err
+	}
+
+	link, err := url.Parse(actorIRI)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid actor IRI %q", actorIRI)
+	}
+	preferredName := naturalValue(actor.PreferredUsername)
+	if preferredName == "" {
+		preferredName = "user"
+	}
+	// the name of the user is the name of the actor suffixed with its host, like "alice-example.com"
+	baseName := invalidUsernameCharsPattern.ReplaceAllString(preferredName+"-"+link.Host, "-")
+	baseName = strings.Trim(consecutiveUsernameSepPattern.ReplaceAllString(baseName, "-"), "-._")
+	name := baseName
+	for i := 1; ; i++ {
+		exist, err := user_model.IsUserExist(ctx, 0, name)
+		if err != nil {
+			return nil, err
+		} else if !exist {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", baseName, i)
+	}
+
+	u = &user_model.User{
+		Name:     name,
+		FullName: naturalValue(actor.Name),
+		Email:    strings.ToLower(name) + "@" + setting.Service.NoReplyAddress,
+	}
+	if actor.URL != nil {
+		u.Website = actor.URL.GetLink().String()
+	}
+	inbox, err := actorInbox(actor)
+	if err != nil {
+		return nil, err
+	}
+	fu := &user_model.FederatedUser{ActorIRI: actorIRI, Inbox: inbox}
+	if err := user_model.CreateFederatedUser(ctx, u, fu); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
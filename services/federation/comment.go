@@ -0,0 +1,121 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	issue_service "code.gitea.io/gitea/services/issue"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+func sameHost(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	return errA == nil && errB == nil && ua.Host == ub.Host
+}
+
+// handleCreate posts a comment sent by a remote actor to the federated issue it is attached to
+func handleCreate(ctx context.Context, sender *ap.Actor, target *InboxTarget, create *ap.Activity) error {
+	note, _ := ap.ToObject(create.Object)
+	if note == nil || note.Type != ap.NoteType || note.Context == nil {
+		log.Trace("Ignoring Create activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+		return nil
+	}
+	fis, err := issues_model.GetFederatedIssuesByTicketIRI(ctx, note.Context.GetLink().String())
+	if err != nil {
+		return err
+	}
+	// the comment is sent to the repository of the pull request on the origin, and to the author of the tracking issue on the fork
+	var fi *issues_model.FederatedIssue
+	var issue *issues_model.Issue
+	for _, f := range fis {
+		i, err := issues_model.GetIssueByID(ctx, f.IssueID)
+		if err != nil {
+			return err
+		}
+		if (target.Repo != nil && target.Repo.ID == i.RepoID) || (target.User != nil && target.User.ID == i.PosterID) {
+			fi, issue = f, i
+			break
+		}
+	}
+	if fi == nil {
+		log.Trace("Ignoring Create activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+		return nil
+	}
+	// only the actors of the instance of the other side can comment
+	if !sameHost(fi.RemoteActorIRI, sender.GetLink().String()) {
+		return util.NewPermissionDeniedErrorf("%s can't comment on %s", sender.GetLink(), fi.TicketIRI)
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if sender.Inbox == nil {
+		return util.NewInvalidArgumentErrorf("the actor %s has no inbox", sender.GetLink())
+	}
+	poster, err := getOrCreateFederatedUser(ctx, sender)
+	if err != nil {
+		return err
+	}
+
+	content := naturalValue(note.Content)
+	if summary := naturalValue(note.Summary); summary != "" {
+		content = "**" + summary + "**\n\n" + content
+	}
+	_, err = issue_service.CreateIssueComment(ctx, poster, issue.Repo, issue, content, nil)
+	return err
+}
+
+// sendComment sends a comment of a local user on a federated issue to the other side
+func sendComment(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, build func(actorIRI, ticketIRI, remoteActorIRI string) ap.Item) {
+	// the comments posted for remote actors aren't sent back
+	if !setting.Federation.Enabled || doer.Type == user_model.UserTypeRemoteUser || !doer.Visibility.IsPublic() {
+		return
+	}
+	fi, err := issues_model.GetFederatedIssueByIssueID(ctx, issue.ID)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Error("GetFederatedIssueByIssueID: %v", err)
+		}
+		return
+	}
+	// the pull request hasn't been opened yet by the origin
+	if fi.TicketIRI == "" {
+		return
+	}
+	actorIRI := UserActorIRI(doer)
+	if err := enqueue(doer, actorIRI, build(actorIRI, fi.TicketIRI, fi.RemoteActorIRI), fi.RemoteInbox); err != nil {
+		log.Error("Unable to queue activity of %s: %v", actorIRI, err)
+	}
+}
+
+// NewCommentActivity returns the Create activity of a comment on a federated issue, the comment is a Note attached to the pull request on the origin
+func NewCommentActivity(ctx context.Context, actorIRI, ticketIRI, remoteActorIRI string, comment *issues_model.Comment, summary string) *ap.Activity {
+	note := ap.ObjectNew(ap.NoteType)
+	note.ID = ap.IRI(comment.HTMLURL(ctx))
+	note.URL = note.ID
+	note.Content = ap.DefaultNaturalLanguageValue(comment.Content)
+	if summary != "" {
+		note.Summary = ap.DefaultNaturalLanguageValue(summary)
+	}
+	note.MediaType = "text/markdown"
+	note.AttributedTo = ap.IRI(actorIRI)
+	note.Context = ap.IRI(ticketIRI)
+	note.InReplyTo = ap.IRI(ticketIRI)
+	note.Published = comment.CreatedUnix.AsTime()
+
+	create := ap.CreateNew(ap.IRI(comment.HTMLURL(ctx)+"#create"), note)
+	create.Actor = ap.IRI(actorIRI)
+	create.To = ap.ItemCollection{ap.IRI(remoteActorIRI)}
+	create.Published = note.Published
+	return create
+}
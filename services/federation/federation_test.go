@@ -0,0 +1,268 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+const (
+	testRemoteActor = "https://remote.example.com/users/alice"
+	testRemoteInbox = "https://remote.example.com/users/alice/inbox"
+)
+
+func testRemoteSender() *ap.Actor {
+	sender := ap.PersonNew(testRemoteActor)
+	sender.Inbox = ap.IRI(testRemoteInbox)
+	sender.Endpoints = &ap.Endpoints{SharedInbox: ap.IRI("https://remote.example.com/inbox")}
+	return sender
+}
+
+func TestHandleInboxActivity(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	deliveries := make(chan *delivery, 10)
+	cfg, err := setting.GetQueueSettings(setting.CfgProvider, "activitypub_delivery")
+	require.NoError(t, err)
+	deliveryQueue, err = queue.NewWorkerPoolQueueWithContext(t.Context(), "activitypub_delivery", cfg, func(items ...*delivery) []*delivery {
+		for _, d := range items {
+			deliveries <- d
+		}
+		return nil
+	}, false)
+	require.NoError(t, err)
+	go deliveryQueue.Run()
+	defer func() {
+		deliveryQueue.ShutdownWait(time.Second)
+		deliveryQueue = nil
+	}()
+
+	ctx := t.Context()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	target := &InboxTarget{User: user}
+	sender := testRemoteSender()
+
+	follow := ap.FollowNew(testRemoteActor+"#follow", ap.IRI(UserActorIRI(user)))
+	follow.Actor = ap.IRI(testRemoteActor)
+	require.NoError(t, HandleInboxActivity(ctx, sender, target, follow))
+	follower := unittest.AssertExistsAndLoadBean(t, &activities_model.FederatedFollower{UserID: user.ID, ActorIRI: testRemoteActor})
+	assert.Equal(t, testRemoteInbox, follower.Inbox)
+	assert.Equal(t, "https://remote.example.com/inbox", follower.DeliveryInbox())
+
+	select {
+	case d := <-deliveries:
+		assert.Equal(t, user.ID, d.SignerID)
+		assert.Equal(t, UserActorIRI(user), d.ActorIRI)
+		assert.Equal(t, testRemoteInbox, d.Inbox)
+		item, err := ap.UnmarshalJSON(d.Payload)
+		require.NoError(t, err)
+		accept, err := ap.ToActivity(item)
+		require.NoError(t, err)
+		assert.Equal(t, ap.AcceptType, accept.Type)
+		assert.Equal(t, ap.IRI(UserActorIRI(user)), accept.Actor.GetLink())
+		assert.Equal(t, ap.IRI(testRemoteActor+"#follow"), accept.Object.GetLink())
+	case <-time.After(time.Second):
+		assert.FailNow(t, "Timeout: the follow was not accepted")
+	}
+
+	// following again updates the inboxes of the follower
+	sender.Endpoints = nil
+	require.NoError(t, HandleInboxActivity(ctx, sender, target, follow))
+	<-deliveries
+	follower = unittest.AssertExistsAndLoadBean(t, &activities_model.FederatedFollower{ID: follower.ID})
+	assert.Equal(t, testRemoteInbox, follower.DeliveryInbox())
+
+	// the actor of an activity must be the signer of the request
+	forged := ap.FollowNew("https://other.example.com/users/bob#follow", ap.IRI(UserActorIRI(user)))
+	forged.Actor = ap.IRI("https://other.example.com/users/bob")
+	assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, forged), util.ErrPermissionDenied)
+
+	// the activities are only delivered to the inboxes on the host of the actor
+	sender.Inbox = ap.IRI("http://127.0.0.1:3000/inbox")
+	assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, follow), util.ErrInvalidArgument)
+	sender.Inbox = ap.IRI(testRemoteInbox)
+	sender.Endpoints = &ap.Endpoints{SharedInbox: ap.IRI("http://127.0.0.1:3000/inbox")}
+	assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, follow), util.ErrInvalidArgument)
+	sender.Endpoints = nil
+	follower = unittest.AssertExistsAndLoadBean(t, &activities_model.FederatedFollower{ID: follower.ID})
+	assert.Equal(t, testRemoteInbox, follower.DeliveryInbox())
+
+	// a follow sent to the inbox of another actor
+	other := ap.FollowNew(testRemoteActor+"#follow-other", ap.IRI(UserActorIRI(&user_model.User{ID: 4})))
+	other.Actor = ap.IRI(testRemoteActor)
+	assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, other), util.ErrInvalidArgument)
+
+	// other activities are ignored
+	like := ap.LikeNew(testRemoteActor+"#like", ap.IRI(UserActorIRI(user)))
+	like.Actor = ap.IRI(testRemoteActor)
+	require.NoError(t, HandleInboxActivity(ctx, sender, target, like))
+
+	undo := ap.UndoNew(testRemoteActor+"#undo", follow)
+	undo.Actor = ap.IRI(testRemoteActor)
+	require.NoError(t, HandleInboxActivity(ctx, sender, target, undo))
+	unittest.AssertNotExistsBean(t, &activities_model.FederatedFollower{ID: follower.ID})
+}
+
+func TestDeliver(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var received []byte
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Signature"))
+		assert.NotEmpty(t, r.Header.Get("Digest"))
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	d := &delivery{SignerID: user.ID, ActorIRI: UserActorIRI(user), Inbox: server.URL + "/inbox", Payload: []byte(`{"type":"Accept"}`)}
+	// the inboxes are only on the allowed hosts, which are the external ones by default
+	assert.Error(t, deliver(t.Context(), d))
+	assert.Nil(t, received)
+
+	defer test.MockVariableValue(&setting.Federation.AllowedHostList, "loopback")()
+	require.NoError(t, deliver(t.Context(), d))
+	assert.JSONEq(t, `{"type":"Accept"}`, string(received))
+
+	status = http.StatusInternalServerError
+	assert.Error(t, deliver(t.Context(), d))
+}
+
+func TestFetchActor(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/webfinger":
+			// the test repositories name the href of their actor in their URL
+			resource, _ := url.Parse(r.URL.Query().Get("resource"))
+			_, _ = fmt.Fprintf(w, `{"links":[{"rel":"self","type":"application/activity+json","href":%q}]}`, resource.Query().Get("href"))
+		case "/repos/origin":
+			_, _ = fmt.Fprintf(w, `{"id":"%[1]s/repos/origin","type":"Repository","inbox":"%[1]s/repos/origin/inbox"}`, server.URL)
+		case "/users/alice":
+			_, _ = fmt.Fprintf(w, `{"id":"%[1]s/users/alice","type":"Person","inbox":"%[1]s/users/alice/inbox"}`, server.URL)
+		case "/users/bob":
+			_, _ = fmt.Fprintf(w, `{"id":"%s/users/bob","type":"Person","inbox":"https://other.example.com/inbox"}`, server.URL)
+		case "/users/carol":
+			_, _ = io.WriteString(w, `{"id":"https://other.example.com/users/carol","type":"Person","inbox":"https://other.example.com/users/carol/inbox"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer test.MockVariableValue(&setting.Federation.MaxSize, 1<<20)()
+
+	// the actors are only fetched from the allowed hosts, which are the external ones by default
+	_, err := FetchActor(t.Context(), server.URL+"/users/alice")
+	assert.Error(t, err)
+
+	defer test.MockVariableValue(&setting.Federation.AllowedHostList, "loopback")()
+	actor, err := FetchActor(t.Context(), server.URL+"/users/alice")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/users/alice/inbox", actor.Inbox.GetLink().String())
+
+	// the inbox and the actor must be on the host of the fetched IRI
+	_, err = FetchActor(t.Context(), server.URL+"/users/bob")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = FetchActor(t.Context(), server.URL+"/users/carol")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// the actor found by WebFinger must be on the host of the repository
+	actor, err = ResolveRepositoryActor(t.Context(), server.URL+"/origin?href="+url.QueryEscape(server.URL+"/repos/origin"))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/repos/origin", actor.GetLink().String())
+	_, err = ResolveRepositoryActor(t.Context(), server.URL+"/origin?href="+url.QueryEscape("https://other.example.com/repos/origin"))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestNewPushActivity(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ctx := t.Context()
+	pusher := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	opts := &repository.PushUpdateOptions{
+		RefFullName: git.RefNameFromBranch("main"),
+		OldCommitID: "1111111111111111111111111111111111111111",
+		NewCommitID: "2222222222222222222222222222222222222222",
+	}
+	committed := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	head := &repository.PushCommit{Sha1: "2222222222222222222222222222222222222222", Message: "second", Timestamp: committed}
+	commits := &repository.PushCommits{
+		Commits:    []*repository.PushCommit{head, {Sha1: "3333333333333333333333333333333333333333", Message: "first"}},
+		HeadCommit: head,
+		Len:        2,
+	}
+
+	push := NewPushActivity(ctx, RepoActorIRI(repo), pusher, repo, opts, commits)
+	assert.Equal(t, PushType, push.Type)
+	assert.Equal(t, ap.IRI(RepoActorIRI(repo)), push.Actor.GetLink())
+	assert.Equal(t, ap.IRI(UserActorIRI(pusher)), push.AttributedTo.GetLink())
+	assert.Equal(t, ap.ItemCollection{ap.PublicNS}, push.To)
+	assert.Equal(t, ap.ItemCollection{ap.IRI(RepoActorIRI(repo) + "/followers")}, push.CC)
+	assert.Equal(t, "user2 pushed 2 commits to user2/repo1:main", push.Summary.String())
+	assert.Equal(t, committed, push.Published)
+
+	collection, err := ap.ToOrderedCollection(push.Object)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, collection.TotalItems)
+	require.Len(t, collection.OrderedItems, 2)
+	commit, err := ap.ToObject(collection.OrderedItems[0])
+	require.NoError(t, err)
+	assert.Equal(t, CommitType, commit.Type)
+	assert.Equal(t, ap.IRI(repo.CommitLink(head.Sha1)), commit.ID)
+
+	payload, err := Marshal(push)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), ForgeFedContext)
+}
+
+func TestNewIssueActivity(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ctx := t.Context()
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issue.LoadRepo(ctx))
+	require.NoError(t, issue.LoadPoster(ctx))
+
+	create := NewIssueActivity(ctx, UserActorIRI(issue.Poster), issue)
+	assert.Equal(t, ap.CreateType, create.Type)
+	assert.Equal(t, ap.IRI(UserActorIRI(issue.Poster)), create.Actor.GetLink())
+	ticket, err := ap.ToObject(create.Object)
+	require.NoError(t, err)
+	assert.Equal(t, TicketType, ticket.Type)
+	assert.Equal(t, ap.IRI(issue.HTMLURL(ctx)), ticket.ID)
+	assert.Equal(t, issue.Title, ticket.Name.String())
+	assert.Equal(t, ap.IRI(RepoActorIRI(issue.Repo)), ticket.Context.GetLink())
+}
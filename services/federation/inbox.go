@@ -0,0 +1,123 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"fmt"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// InboxTarget is the local actor an activity is sent to, either a user or a repository
+type InboxTarget struct {
+	User *user_model.User
+	Repo *repo_model.Repository
+}
+
+// ActorIRI returns the IRI of the actor of the target
+func (t *InboxTarget) ActorIRI() string {
+	if t.Repo != nil {
+		return RepoActorIRI(t.Repo)
+	}
+	return UserActorIRI(t.User)
+}
+
+// signer returns the user whose key signs the activities of the target
+func (t *InboxTarget) signer() *user_model.User {
+	if t.Repo != nil {
+		return t.Repo.Owner
+	}
+	return t.User
+}
+
+func (t *InboxTarget) follower(actorIRI string) *activities_model.FederatedFollower {
+	f := &activities_model.FederatedFollower{ActorIRI: actorIRI}
+	if t.Repo != nil {
+		f.RepoID = t.Repo.ID
+	} else {
+		f.UserID = t.User.ID
+	}
+	return f
+}
+
+// HandleInboxActivity handles an activity sent by a remote actor, whose HTTP signature has been verified, to the inbox of a local actor.
+// Follow requests are accepted and Undo of them removes the follower. The pull requests offered by remote forks are opened, and
+// the comments on them and the pushes to their head branch are applied. Other activities are ignored.
+func HandleInboxActivity(ctx context.Context, sender *ap.Actor, target *InboxTarget, activity *ap.Activity) error {
+	if activity.Actor == nil || activity.Actor.GetLink() != sender.GetLink() {
+		return util.NewPermissionDeniedErrorf("the activity is not sent by its actor")
+	}
+
+	switch activity.Type {
+	case ap.FollowType:
+		return handleFollow(ctx, sender, target, activity)
+	case ap.UndoType:
+		return handleUndo(ctx, sender, target, activity)
+	case ap.OfferType:
+		return handleOffer(ctx, sender, target, activity)
+	case ap.AcceptType:
+		return handleAccept(ctx, sender, target, activity)
+	case ap.CreateType:
+		return handleCreate(ctx, sender, target, activity)
+	case PushType:
+		return handlePush(ctx, sender, target, activity)
+	}
+	log.Trace("Ignoring %s activity of %s sent to %s", activity.Type, sender.GetLink(), target.ActorIRI())
+	return nil
+}
+
+func handleFollow(ctx context.Context, sender *ap.Actor, target *InboxTarget, follow *ap.Activity) error {
+	targetIRI := target.ActorIRI()
+	if follow.Object == nil || follow.Object.GetLink().String() != targetIRI {
+		return util.NewInvalidArgumentErrorf("the Follow activity is not sent to the followed actor")
+	}
+	inbox, err := actorInbox(sender)
+	if err != nil {
+		return err
+	}
+
+	actorIRI := sender.GetLink().String()
+	f := target.follower(actorIRI)
+	f.Inbox = inbox
+	if sender.Endpoints != nil && sender.Endpoints.SharedInbox != nil {
+		f.SharedInbox = sender.Endpoints.SharedInbox.GetLink().String()
+		if !isActorInbox(actorIRI, f.SharedInbox) {
+			return util.NewInvalidArgumentErrorf("the shared inbox %s is not on the host of the actor %s", f.SharedInbox, actorIRI)
+		}
+	}
+	if err := activities_model.AddFederatedFollower(ctx, f); err != nil {
+		return err
+	}
+
+	accept := ap.AcceptNew(ap.IRI(fmt.Sprintf("%s/followers#%d", targetIRI, f.ID)), follow)
+	accept.Actor = ap.IRI(targetIRI)
+	accept.To = ap.ItemCollection{sender.GetLink()}
+	return enqueue(target.signer(), targetIRI, accept, f.Inbox)
+}
+
+func handleUndo(ctx context.Context, sender *ap.Actor, target *InboxTarget, undo *ap.Activity) error {
+	if undo.Object == nil {
+		return util.NewInvalidArgumentErrorf("the Undo activity has no object")
+	}
+	// the followed actor doesn't keep the Follow activities, a follow undone by reference is assumed to be the one of the sender
+	if !undo.Object.IsLink() {
+		follow, _ := ap.ToActivity(undo.Object)
+		if follow == nil || follow.Type != ap.FollowType {
+			log.Trace("Ignoring Undo activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+			return nil
+		}
+		if follow.Object == nil || follow.Object.GetLink().String() != target.ActorIRI() {
+			return util.NewInvalidArgumentErrorf("the undone Follow activity is not sent to the followed actor")
+		}
+	}
+	f := target.follower(sender.GetLink().String())
+	return activities_model.RemoveFederatedFollower(ctx, f.UserID, f.RepoID, f.ActorIRI)
+}
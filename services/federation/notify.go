@@ -0,0 +1,278 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// ForgeFed types of the objects and activities sent to the followers
+const (
+	PushType   ap.ActivityVocabularyType = "Push"
+	CommitType ap.ActivityVocabularyType = "Commit"
+	BranchType ap.ActivityVocabularyType = "Branch"
+	TicketType ap.ActivityVocabularyType = "Ticket"
+)
+
+type federationNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &federationNotifier{}
+
+// NewNotifier create a new federationNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &federationNotifier{}
+}
+
+// activityBuilder builds the activity sent by an actor to its followers
+type activityBuilder func(actorIRI string) ap.Item
+
+// sendToFollowers delivers an activity of a public repository to the followers of the repository, as sent by the repository,
+// and to the followers of the user who did it, as sent by the user. The activity is delivered once to an inbox.
+func sendToFollowers(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, build activityBuilder) {
+	if !setting.Federation.Enabled {
+		return
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		log.Error("LoadOwner: %v", err)
+		return
+	}
+	if !IsRepoFederated(repo) {
+		return
+	}
+
+	sent := make(container.Set[string])
+	send := func(signer *user_model.User, actorIRI string, userID, repoID int64) {
+		inboxes, err := activities_model.GetFederatedFollowerInboxes(ctx, userID, repoID)
+		if err != nil {
+			log.Error("GetFederatedFollowerInboxes: %v", err)
+			return
+		}
+		inboxes = container.FilterSlice(inboxes, func(inbox string) (string, bool) {
+			return inbox, sent.Add(inbox)
+		})
+		if len(inboxes) == 0 {
+			return
+		}
+		if err := enqueue(signer, actorIRI, build(actorIRI), inboxes...); err != nil {
+			log.Error("Unable to queue activity of %s: %v", actorIRI, err)
+		}
+	}
+
+	send(repo.Owner, RepoActorIRI(repo), 0, repo.ID)
+	if doer != nil && !doer.IsGhost() && doer.Visibility.IsPublic() {
+		send(doer, UserActorIRI(doer), doer.ID, 0)
+	}
+}
+
+func addressToFollowers(activity *ap.Activity, actorIRI string) {
+	activity.Actor = ap.IRI(actorIRI)
+	activity.To = ap.ItemCollection{ap.PublicNS}
+	activity.CC = ap.ItemCollection{ap.IRI(actorIRI + "/followers")}
+}
+
+func (n *federationNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.RefFullName.IsBranch() || opts.IsDelRef() || len(commits.Commits) == 0 {
+		return
+	}
+	sendToFollowers(ctx, pusher, repo, func(actorIRI string) ap.Item {
+		return NewPushActivity(ctx, actorIRI, pusher, repo, opts, commits)
+	})
+	sendToOrigin(ctx, pusher, repo, opts, commits)
+}
+
+// sendToOrigin delivers the commits pushed to the head branch of pull requests offered to the origin of a fork
+func sendToOrigin(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !setting.Federation.Enabled || !IsRepoFederated(repo) {
+		return
+	}
+	fork, err := repo_model.GetFederatedFork(ctx, repo.ID)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Error("GetFederatedFork: %v", err)
+		}
+		return
+	}
+	fis, err := issues_model.GetOpenFederatedIssuesByHead(ctx, repo.ID, "", opts.RefName())
+	if err != nil {
+		log.Error("GetOpenFederatedIssuesByHead: %v", err)
+		return
+	} else if len(fis) == 0 {
+		return
+	}
+	actorIRI := RepoActorIRI(repo)
+	push := NewPushActivity(ctx, actorIRI, pusher, repo, opts, commits)
+	push.To = append(push.To, ap.IRI(fork.OriginIRI))
+	if err := enqueue(repo.Owner, actorIRI, push, fork.OriginInbox); err != nil {
+		log.Error("Unable to queue activity of %s: %v", actorIRI, err)
+	}
+}
+
+func (n *federationNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
+	if rel.IsTag {
+		return
+	}
+	if err := rel.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes: %v", err)
+		return
+	}
+	sendToFollowers(ctx, rel.Publisher, rel.Repo, func(actorIRI string) ap.Item {
+		return NewReleaseActivity(actorIRI, rel)
+	})
+}
+
+func (n *federationNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	sendToFollowers(ctx, issue.Poster, issue.Repo, func(actorIRI string) ap.Item {
+		return NewIssueActivity(ctx, actorIRI, issue)
+	})
+}
+
+func (n *federationNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
+	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
+) {
+	sendComment(ctx, doer, issue, func(actorIRI, ticketIRI, remoteActorIRI string) ap.Item {
+		return NewCommentActivity(ctx, actorIRI, ticketIRI, remoteActorIRI, comment, "")
+	})
+}
+
+func (n *federationNotifier) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, review *issues_model.Review, comment *issues_model.Comment, mentions []*user_model.User) {
+	var summary string
+	switch review.Type {
+	case issues_model.ReviewTypeApprove:
+		summary = "approved these changes"
+	case issues_model.ReviewTypeReject:
+		summary = "requested changes"
+	case issues_model.ReviewTypeComment:
+		summary = "reviewed these changes"
+	default:
+		return
+	}
+	if comment == nil {
+		return
+	}
+	if err := review.LoadReviewer(ctx); err != nil {
+		log.Error("LoadReviewer: %v", err)
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	sendComment(ctx, review.Reviewer, pr.Issue, func(actorIRI, ticketIRI, remoteActorIRI string) ap.Item {
+		return NewCommentActivity(ctx, actorIRI, ticketIRI, remoteActorIRI, comment, summary)
+	})
+}
+
+func (n *federationNotifier) PullRequestCodeComment(ctx context.Context, pr *issues_model.PullRequest, comment *issues_model.Comment, mentions []*user_model.User) {
+	if err := comment.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	summary := fmt.Sprintf("commented on line %d of %s", comment.UnsignedLine(), comment.TreePath)
+	sendComment(ctx, comment.Poster, pr.Issue, func(actorIRI, ticketIRI, remoteActorIRI string) ap.Item {
+		return NewCommentActivity(ctx, actorIRI, ticketIRI, remoteActorIRI, comment, summary)
+	})
+}
+
+// NewPushActivity returns the ForgeFed Push activity of commits pushed to a branch of a repository
+func NewPushActivity(ctx context.Context, actorIRI string, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) *ap.Activity {
+	items := make(ap.ItemCollection, 0, len(commits.Commits))
+	for _, c := range commits.Commits {
+		commit := ap.ObjectNew(ap.ObjectType)
+		commit.Type = CommitType
+		commit.ID = ap.IRI(repo.CommitLink(c.Sha1))
+		commit.URL = commit.ID
+		commit.Summary = ap.DefaultNaturalLanguageValue(c.Message)
+		commit.Published = c.Timestamp
+		items = append(items, commit)
+	}
+	collection := ap.OrderedCollectionNew("")
+	collection.OrderedItems = items
+	collection.TotalItems = uint(commits.Len)
+
+	branch := ap.ObjectNew(ap.ObjectType)
+	branch.Type = BranchType
+	branch.Name = ap.DefaultNaturalLanguageValue(opts.RefName())
+	branch.URL = ap.IRI(repo.HTMLURL(ctx) + "/src/branch/" + util.PathEscapeSegments(opts.RefName()))
+	branch.Context = ap.IRI(RepoActorIRI(repo))
+
+	push := ap.ActivityNew(ap.IRI(repo.CommitLink(opts.NewCommitID)+"#push"), ap.ActivityType, collection)
+	push.Type = PushType
+	addressToFollowers(push, actorIRI)
+	push.AttributedTo = ap.IRI(UserActorIRI(pusher))
+	push.Context = ap.IRI(RepoActorIRI(repo))
+	push.Target = branch
+	push.Summary = ap.DefaultNaturalLanguageValue(fmt.Sprintf("%s pushed %d commits to %s:%s", pusher.Name, commits.Len, repo.FullName(), opts.RefName()))
+	if commits.HeadCommit != nil {
+		push.Published = commits.HeadCommit.Timestamp
+	}
+	return push
+}
+
+// NewReleaseActivity returns the Create activity of a published release, the release is a Note whose content is its Markdown notes
+func NewReleaseActivity(actorIRI string, rel *repo_model.Release) *ap.Activity {
+	note := ap.ObjectNew(ap.NoteType)
+	note.ID = ap.IRI(rel.HTMLURL())
+	note.URL = note.ID
+	note.Name = ap.DefaultNaturalLanguageValue(rel.Title)
+	note.Summary = ap.DefaultNaturalLanguageValue(fmt.Sprintf("%s released %s of %s", rel.Publisher.Name, rel.TagName, rel.Repo.FullName()))
+	note.Content = ap.DefaultNaturalLanguageValue(rel.Note)
+	note.MediaType = "text/markdown"
+	note.AttributedTo = ap.IRI(UserActorIRI(rel.Publisher))
+	note.Context = ap.IRI(RepoActorIRI(rel.Repo))
+	note.Published = rel.CreatedUnix.AsTime()
+
+	create := ap.CreateNew(ap.IRI(rel.HTMLURL()+"#create"), note)
+	addressToFollowers(create, actorIRI)
+	create.Published = note.Published
+	return create
+}
+
+// NewIssueActivity returns the Create activity of a new issue, the issue is a ForgeFed Ticket whose content is its Markdown description
+func NewIssueActivity(ctx context.Context, actorIRI string, issue *issues_model.Issue) *ap.Activity {
+	ticket := ap.ObjectNew(ap.ObjectType)
+	ticket.Type = TicketType
+	ticket.ID = ap.IRI(issue.HTMLURL(ctx))
+	ticket.URL = ticket.ID
+	ticket.Name = ap.DefaultNaturalLanguageValue(issue.Title)
+	ticket.Summary = ap.DefaultNaturalLanguageValue(fmt.Sprintf("%s opened %s#%d", issue.Poster.Name, issue.Repo.FullName(), issue.Index))
+	ticket.Content = ap.DefaultNaturalLanguageValue(issue.Content)
+	ticket.MediaType = "text/markdown"
+	ticket.AttributedTo = ap.IRI(UserActorIRI(issue.Poster))
+	ticket.Context = ap.IRI(RepoActorIRI(issue.Repo))
+	ticket.Published = issue.CreatedUnix.AsTime()
+
+	create := ap.CreateNew(ap.IRI(issue.HTMLURL(ctx)+"#create"), ticket)
+	addressToFollowers(create, actorIRI)
+	create.Published = ticket.Published
+	return create
+}
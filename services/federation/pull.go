@@ -0,0 +1,432 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/migrations"
+	notify_service "code.gitea.io/gitea/services/notify"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	ap "github.com/go-ap/activitypub"
+)
+
+// ForkRemoteRepository forks a public repository of another instance: its Git data is cloned into a new repository of owner,
+// whose pull requests can then be offered to the origin repository with SendPullRequest
+func ForkRemoteRepository(ctx context.Context, doer, owner *user_model.User, originURL, name string) (*repo_model.Repository, error) {
+	if err := migrations.IsMigrateURLAllowed(originURL, doer); err != nil {
+		return nil, err
+	}
+	origin, err := ResolveRepositoryActor(ctx, originURL)
+	if err != nil {
+		return nil, err
+	}
+	cloneURL, err := actorCloneURL(origin)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrations.IsMigrateURLAllowed(cloneURL, doer); err != nil {
+		return nil, err
+	}
+
+	repo, err := repo_service.CreateRepositoryDirectly(ctx, doer, owner, repo_service.CreateRepoOptions{
+		Name:           name,
+		Description:    naturalValue(origin.Summary),
+		OriginalURL:    origin.URL.GetLink().String(),
+		GitServiceType: api.PlainGitService,
+		Status:         repo_model.RepositoryBeingMigrated,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository created above is deleted if the clone fails, MigrateRepository returns no repository in that case
+	migrated, err := migrations.MigrateRepository(ctx, doer, owner.Name, migrations.MigrateOptions{
+		CloneAddr:       cloneURL,
+		RepoName:        name,
+		Description:     repo.Description,
+		GitServiceType:  api.PlainGitService,
+		MigrateToRepoID: repo.ID,
+	}, nil)
+	if err == nil {
+		err = repo_model.AddFederatedFork(ctx, &repo_model.FederatedFork{
+			RepoID:      migrated.ID,
+			OriginIRI:   origin.GetLink().String(),
+			OriginInbox: origin.Inbox.GetLink().String(),
+		})
+	}
+	if err != nil {
+		if errDelete := repo_service.DeleteRepositoryDirectly(ctx, repo.ID); errDelete != nil {
+			log.Error("DeleteRepositoryDirectly: %v", errDelete)
+		}
+		return nil, err
+	}
+	repo = migrated
+
+	notify_service.MigrateRepository(ctx, doer, owner, repo)
+	return repo, nil
+}
+
+// SendPullRequestOptions are the options to offer a pull request to the origin of a repository forked from another instance
+type SendPullRequestOptions struct {
+	HeadBranch string
+	BaseBranch string
+	Title      string
+	Content    string
+}
+
+// SendPullRequest offers a pull request of a branch of a repository forked from another instance to its origin repository.
+// The pull request is tracked by a new issue of the fork, the comments of the pull request are posted to it and the comments
+// of the issue are posted to the pull request.
+func SendPullRequest(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, opts SendPullRequestOptions) (*issues_model.Issue, error) {
+	fork, err := repo_model.GetFederatedFork(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	// the origin fetches the commits of the fork and the actor of the author
+	if !IsRepoFederated(repo) {
+		return nil, util.NewInvalidArgumentErrorf("the repository must be public to send a pull request to another instance")
+	}
+	if !doer.Visibility.IsPublic() {
+		return nil, util.NewInvalidArgumentErrorf("the user must be public to send a pull request to another instance")
+	}
+	if !gitrepo.IsBranchExist(ctx, repo, opts.HeadBranch) {
+		return nil, git.ErrBranchNotExist{Name: opts.HeadBranch}
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    opts.Title,
+		PosterID: doer.ID,
+		Poster:   doer,
+		Content:  opts.Content,
+	}
+	if err := issue_service.NewIssue(ctx, repo, issue, nil, nil, nil, 0); err != nil {
+		return nil, err
+	}
+
+	fi := &issues_model.FederatedIssue{
+		IssueID:        issue.ID,
+		OfferIRI:       issue.HTMLURL(ctx) + "#offer",
+		RemoteActorIRI: fork.OriginIRI,
+		RemoteInbox:    fork.OriginInbox,
+		HeadRepoIRI:    RepoActorIRI(repo),
+		HeadBranch:     opts.HeadBranch,
+	}
+	if err := issues_model.NewFederatedIssue(ctx, fi); err != nil {
+		return nil, err
+	}
+
+	actorIRI := UserActorIRI(doer)
+	return issue, enqueue(doer, actorIRI, NewPullRequestOffer(ctx, actorIRI, fork.OriginIRI, issue, fi, opts.BaseBranch), fork.OriginInbox)
+}
+
+func newBranch(repoIRI, name string) *ap.Object {
+	branch := ap.ObjectNew(ap.ObjectType)
+	branch.Type = BranchType
+	branch.Name = ap.DefaultNaturalLanguageValue(name)
+	branch.Context = ap.IRI(repoIRI)
+	return branch
+}
+
+// NewPullRequestOffer returns the Offer activity of a pull request to the origin repository.
+// The pull request is a ForgeFed Ticket whose attachment is the offer to merge the head branch of the fork into the base branch of the origin.
+func NewPullRequestOffer(ctx context.Context, actorIRI, originIRI string, issue *issues_model.Issue, fi *issues_model.FederatedIssue, baseBranch string) *ap.Activity {
+	merge := ap.ActivityNew("", ap.OfferType, nil)
+	merge.Origin = newBranch(fi.HeadRepoIRI, fi.HeadBranch)
+	merge.Target = newBranch(originIRI, baseBranch)
+
+	ticket := ap.ObjectNew(ap.ObjectType)
+	ticket.Type = TicketType
+	ticket.ID = ap.IRI(issue.HTMLURL(ctx))
+	ticket.URL = ticket.ID
+	ticket.Name = ap.DefaultNaturalLanguageValue(issue.Title)
+	ticket.Content = ap.DefaultNaturalLanguageValue(issue.Content)
+	ticket.MediaType = "text/markdown"
+	ticket.AttributedTo = ap.IRI(actorIRI)
+	ticket.Context = ap.IRI(originIRI)
+	ticket.Attachment = merge
+
+	offer := ap.OfferNew(ap.IRI(fi.OfferIRI), ticket)
+	offer.Actor = ap.IRI(actorIRI)
+	offer.Target = ap.IRI(originIRI)
+	offer.To = ap.ItemCollection{ap.IRI(originIRI)}
+	return offer
+}
+
+// fetchRemoteBranch fetches a branch of a remote Git repository into a reference of a repository and returns the fetched commit
+func fetchRemoteBranch(ctx context.Context, repo *repo_model.Repository, cloneURL, branch, refName string) (string, error) {
+	if !isValidRemoteBranch(branch) {
+		return "", util.NewInvalidArgumentErrorf("the branch name %q is invalid", branch)
+	}
+	opts := &gitcmd.RunOpts{Dir: repo.RepoPath(), Timeout: time.Duration(setting.Git.Timeout.Migrate) * time.Second}
+	if err := gitcmd.NewCommand("fetch", "--no-tags").AddDynamicArguments(cloneURL, "+"+git.BranchPrefix+branch+":"+refName).Run(ctx, opts); err != nil {
+		return "", fmt.Errorf("unable to fetch %s of %s: %w", branch, util.SanitizeCredentialURLs(cloneURL), err)
+	}
+	return refCommitID(ctx, repo, refName)
+}
+
+func refCommitID(ctx context.Context, repo *repo_model.Repository, refName string) (string, error) {
+	commitID, _, err := gitcmd.NewCommand("rev-parse").AddDynamicArguments(refName).RunStdString(ctx, &gitcmd.RunOpts{Dir: repo.RepoPath()})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commitID), nil
+}
+
+// remoteForkCloneURL returns the URL to fetch the commits of a remote fork, which must be allowed for the poster
+func remoteForkCloneURL(ctx context.Context, headRepoIRI string, poster *user_model.User) (string, error) {
+	if err := migrations.IsMigrateURLAllowed(headRepoIRI, poster); err != nil {
+		return "", util.NewPermissionDeniedErrorf("the fork %s is not allowed", headRepoIRI)
+	}
+	fork, err := FetchActor(ctx, headRepoIRI)
+	if err != nil {
+		return "", err
+	}
+	if fork.Type != RepositoryType {
+		return "", util.NewInvalidArgumentErrorf("%s is not a repository", headRepoIRI)
+	}
+	cloneURL, err := actorCloneURL(fork)
+	if err != nil {
+		return "", err
+	}
+	if err := migrations.IsMigrateURLAllowed(cloneURL, poster); err != nil {
+		return "", util.NewPermissionDeniedErrorf("the fork %s is not allowed", headRepoIRI)
+	}
+	return cloneURL, nil
+}
+
+func branchName(item ap.Item) string {
+	branch, _ := ap.ToObject(item)
+	if branch == nil || branch.Type != BranchType {
+		return ""
+	}
+	return naturalValue(branch.Name)
+}
+
+// isValidRemoteBranch reports whether a branch name sent by a remote instance can be pasted into a reference and a refspec,
+// a name like "*" would fetch all the branches of the remote repository
+func isValidRemoteBranch(name string) bool {
+	return git.IsValidRefPattern(name) && !strings.ContainsAny(name, ":*")
+}
+
+// handleOffer opens the pull request offered by a remote fork to a local repository and accepts the offer
+func handleOffer(ctx context.Context, sender *ap.Actor, target *InboxTarget, offer *ap.Activity) error {
+	repo := target.Repo
+	ticket, _ := ap.ToObject(offer.Object)
+	if repo == nil || ticket == nil || ticket.Type != TicketType {
+		log.Trace("Ignoring Offer activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+		return nil
+	}
+	repoIRI := RepoActorIRI(repo)
+	if ticket.Context == nil || ticket.Context.GetLink().String() != repoIRI {
+		return util.NewInvalidArgumentErrorf("the offered ticket is not one of the repository")
+	}
+	merge, _ := ap.ToActivity(ticket.Attachment)
+	if merge == nil || merge.Origin == nil || merge.Target == nil {
+		return util.NewInvalidArgumentErrorf("the offered ticket is not a pull request")
+	}
+	headBranch, baseBranch := branchName(merge.Origin), branchName(merge.Target)
+	headRepo, _ := ap.ToObject(merge.Origin)
+	if headBranch == "" || baseBranch == "" || headRepo.Context == nil {
+		return util.NewInvalidArgumentErrorf("the offered pull request has no valid branches")
+	}
+	if !isValidRemoteBranch(headBranch) || !isValidRemoteBranch(baseBranch) {
+		return util.NewInvalidArgumentErrorf("the offered pull request has invalid branch names")
+	}
+	headRepoIRI := headRepo.Context.GetLink().String()
+	// the sender offers the pull requests of its own forks
+	if !sameHost(sender.GetLink().String(), headRepoIRI) {
+		return util.NewInvalidArgumentErrorf("the fork %s is not on the host of the actor %s", headRepoIRI, sender.GetLink())
+	}
+	inbox, err := actorInbox(sender)
+	if err != nil {
+		return err
+	}
+
+	if _, err := issues_model.GetFederatedIssueByOfferIRI(ctx, offer.GetLink().String(), sender.GetLink().String()); err == nil {
+		// the offer has already been accepted
+		return nil
+	}
+	if !repo.AllowsPulls(ctx) {
+		return util.NewPermissionDeniedErrorf("the repository doesn't accept pull requests")
+	}
+	if !gitrepo.IsBranchExist(ctx, repo, baseBranch) {
+		return util.NewInvalidArgumentErrorf("the base branch %s doesn't exist", baseBranch)
+	}
+
+	poster, err := getOrCreateFederatedUser(ctx, sender)
+	if err != nil {
+		return err
+	}
+	cloneURL, err := remoteForkCloneURL(ctx, headRepoIRI, poster)
+	if err != nil {
+		return err
+	}
+	// the commits are kept by a temporary reference until the reference of the pull request is created
+	tmpRef := fmt.Sprintf("refs/federation/%d/%s", poster.ID, headBranch)
+	headCommitID, err := fetchRemoteBranch(ctx, repo, cloneURL, headBranch, tmpRef)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := gitrepo.RemoveRef(ctx, repo, tmpRef); err != nil {
+			log.Error("RemoveRef: %v", err)
+		}
+	}()
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    naturalValue(ticket.Name),
+		PosterID: poster.ID,
+		Poster:   poster,
+		IsPull:   true,
+		Content:  naturalValue(ticket.Content),
+	}
+	pr := &issues_model.PullRequest{
+		HeadRepoID:   repo.ID,
+		BaseRepoID:   repo.ID,
+		HeadBranch:   poster.Name + "/" + headBranch,
+		HeadCommitID: headCommitID,
+		BaseBranch:   baseBranch,
+		HeadRepo:     repo,
+		BaseRepo:     repo,
+		Type:         issues_model.PullRequestGitea,
+		// like AGit, the commits of the pull request are only referenced by the pull request in the base repository
+		Flow: issues_model.PullRequestFlowAGit,
+	}
+	if err := pull_service.NewPullRequest(ctx, &pull_service.NewPullRequestOptions{Repo: repo, Issue: issue, PullRequest: pr}); err != nil {
+		return err
+	}
+
+	fi := &issues_model.FederatedIssue{
+		IssueID:        issue.ID,
+		TicketIRI:      issue.HTMLURL(ctx),
+		OfferIRI:       offer.GetLink().String(),
+		RemoteActorIRI: sender.GetLink().String(),
+		RemoteInbox:    inbox,
+		HeadRepoIRI:    headRepoIRI,
+		HeadBranch:     headBranch,
+	}
+	if err := issues_model.NewFederatedIssue(ctx, fi); err != nil {
+		return err
+	}
+
+	accept := ap.AcceptNew(ap.IRI(fi.TicketIRI+"#accept"), offer.GetLink())
+	accept.Actor = ap.IRI(repoIRI)
+	accept.To = ap.ItemCollection{sender.GetLink()}
+	accept.Result = ap.IRI(fi.TicketIRI)
+	return enqueue(repo.Owner, repoIRI, accept, fi.RemoteInbox)
+}
+
+// handleAccept records the pull request opened by the origin repository for an offer of a local fork
+func handleAccept(ctx context.Context, sender *ap.Actor, target *InboxTarget, accept *ap.Activity) error {
+	if accept.Object == nil || accept.Result == nil {
+		log.Trace("Ignoring Accept activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+		return nil
+	}
+	// the offer must have been sent to the sender of the Accept
+	fi, err := issues_model.GetFederatedIssueByOfferIRI(ctx, accept.Object.GetLink().String(), sender.GetLink().String())
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			log.Trace("Ignoring Accept activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+			return nil
+		}
+		return err
+	}
+	fi.TicketIRI = accept.Result.GetLink().String()
+	return issues_model.UpdateFederatedIssueTicket(ctx, fi)
+}
+
+// handlePush updates the pull requests offered by a remote fork when commits are pushed to their head branch
+func handlePush(ctx context.Context, sender *ap.Actor, target *InboxTarget, push *ap.Activity) error {
+	headBranch := branchName(push.Target)
+	if target.Repo == nil || headBranch == "" {
+		log.Trace("Ignoring Push activity of %s sent to %s", sender.GetLink(), target.ActorIRI())
+		return nil
+	}
+	if !isValidRemoteBranch(headBranch) {
+		return util.NewInvalidArgumentErrorf("the pushed branch name %q is invalid", headBranch)
+	}
+	headRepoIRI := sender.GetLink().String()
+	fis, err := issues_model.GetOpenFederatedIssuesByHead(ctx, target.Repo.ID, headRepoIRI, headBranch)
+	if err != nil || len(fis) == 0 {
+		return err
+	}
+
+	for _, fi := range fis {
+		pr, err := issues_model.GetPullRequestByIssueID(ctx, fi.IssueID)
+		if err != nil {
+			return err
+		}
+		if err := pr.LoadBaseRepo(ctx); err != nil {
+			return err
+		}
+		if err := pr.LoadIssue(ctx); err != nil {
+			return err
+		}
+		if err := pr.Issue.LoadPoster(ctx); err != nil {
+			return err
+		}
+		cloneURL, err := remoteForkCloneURL(ctx, headRepoIRI, pr.Issue.Poster)
+		if err != nil {
+			return err
+		}
+		if err := updatePullRequestHead(ctx, target.Repo, pr, cloneURL, headBranch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updatePullRequestHead fetches the head branch of a pull request offered by a remote fork, like an AGit pull request is updated by a push
+func updatePullRequestHead(ctx context.Context, repo *repo_model.Repository, pr *issues_model.PullRequest, cloneURL, headBranch string) error {
+	oldCommitID, err := refCommitID(ctx, repo, pr.GetGitHeadRefName())
+	if err != nil {
+		return err
+	}
+	newCommitID, err := fetchRemoteBranch(ctx, repo, cloneURL, headBranch, pr.GetGitHeadRefName())
+	if err != nil || newCommitID == oldCommitID {
+		return err
+	}
+
+	pr.HeadCommitID = newCommitID
+	if err := issues_model.MarkReviewsAsStale(ctx, pr.IssueID); err != nil {
+		log.Error("MarkReviewsAsStale: %v", err)
+	}
+	if err := issues_model.MarkReviewsAsNotStale(ctx, pr.IssueID, newCommitID); err != nil {
+		log.Error("MarkReviewsAsNotStale: %v", err)
+	}
+	pull_service.StartPullRequestCheckImmediately(ctx, pr)
+
+	pusher := pr.Issue.Poster
+	comment, err := pull_service.CreatePushPullComment(ctx, pusher, pr, oldCommitID, newCommitID, false)
+	if err == nil && comment != nil {
+		notify_service.PullRequestPushCommits(ctx, pusher, pr, comment)
+	}
+	notify_service.PullRequestSynchronized(ctx, pusher, pr)
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package federation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/migrations"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestOffer(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issue.LoadRepo(t.Context()))
+	fi := &issues_model.FederatedIssue{
+		IssueID:     issue.ID,
+		OfferIRI:    issue.HTMLURL(t.Context()) + "#offer",
+		HeadRepoIRI: RepoActorIRI(issue.Repo),
+		HeadBranch:  "feature",
+	}
+	offer := NewPullRequestOffer(t.Context(), UserActorIRI(&user_model.User{ID: 1}), "https://remote.example.com/repos/1", issue, fi, "main")
+
+	// the ForgeFed types are decoded by the ActivityStreams decoder
+	b, err := offer.MarshalJSON()
+	require.NoError(t, err)
+	item, err := ap.UnmarshalJSON(b)
+	require.NoError(t, err)
+	decoded, err := ap.ToActivity(item)
+	require.NoError(t, err)
+	assert.Equal(t, ap.OfferType, decoded.Type)
+	assert.Equal(t, ap.IRI(fi.OfferIRI), decoded.ID)
+
+	ticket, err := ap.ToObject(decoded.Object)
+	require.NoError(t, err)
+	assert.Equal(t, TicketType, ticket.Type)
+	assert.Equal(t, issue.Title, ticket.Name.String())
+	assert.Equal(t, ap.IRI("https://remote.example.com/repos/1"), ticket.Context.GetLink())
+	merge, err := ap.ToActivity(ticket.Attachment)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", branchName(merge.Origin))
+	assert.Equal(t, "main", branchName(merge.Target))
+}
+
+func TestHandleCreate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Service.NoReplyAddress, "noreply.example.org")()
+
+	ctx := t.Context()
+	// the issue tracks a pull request offered to a remote origin
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issue.LoadRepo(ctx))
+	poster := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: issue.PosterID})
+	ticketIRI := "https://remote.example.com/alice/repo/pulls/3"
+	require.NoError(t, issues_model.NewFederatedIssue(ctx, &issues_model.FederatedIssue{
+		IssueID:        issue.ID,
+		TicketIRI:      ticketIRI,
+		OfferIRI:       issue.HTMLURL(ctx) + "#offer",
+		RemoteActorIRI: "https://remote.example.com/repos/3",
+		RemoteInbox:    "https://remote.example.com/repos/3/inbox",
+		HeadRepoIRI:    "https://gitea.example.com/repos/1",
+		HeadBranch:     "feature",
+	}))
+	target := &InboxTarget{User: poster}
+	sender := testRemoteSender()
+	sender.PreferredUsername = ap.DefaultNaturalLanguageValue("alice")
+
+	newCreate := func(sender *ap.Actor, context string) *ap.Activity {
+		note := ap.ObjectNew(ap.NoteType)
+		note.ID = ap.IRI(context + "#issuecomment-1")
+		note.Content = ap.DefaultNaturalLanguageValue("LGTM")
+		note.Summary = ap.DefaultNaturalLanguageValue("approved these changes")
+		note.Context = ap.IRI(context)
+		create := ap.CreateNew(ap.IRI(context+"#issuecomment-1-create"), note)
+		create.Actor = sender.GetLink()
+		return create
+	}
+
+	require.NoError(t, HandleInboxActivity(ctx, sender, target, newCreate(sender, ticketIRI)))
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: issue.ID, Content: "**approved these changes**\n\nLGTM"})
+	remote := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: comment.PosterID})
+	assert.Equal(t, "alice-remote.example.com", remote.Name)
+	assert.Equal(t, user_model.UserTypeRemoteUser, remote.Type)
+
+	// the comments of unknown pull requests are ignored
+	require.NoError(t, HandleInboxActivity(ctx, sender, target, newCreate(sender, "https://remote.example.com/alice/repo/pulls/4")))
+	// the comments sent to another actor are ignored
+	require.NoError(t, HandleInboxActivity(ctx, sender, &InboxTarget{User: &user_model.User{ID: poster.ID + 1}}, newCreate(sender, ticketIRI)))
+	unittest.AssertCount(t, &issues_model.Comment{PosterID: remote.ID}, 1)
+
+	// only the actors of the instance of the pull request can comment
+	other := ap.PersonNew("https://other.example.com/users/bob")
+	other.Inbox = ap.IRI("https://other.example.com/users/bob/inbox")
+	assert.ErrorIs(t, HandleInboxActivity(ctx, other, target, newCreate(other, ticketIRI)), util.ErrPermissionDenied)
+}
+
+func TestHandleOfferAndPushWithInvalidBranches(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ctx := t.Context()
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo.LoadOwner(ctx))
+	target := &InboxTarget{Repo: repo}
+	sender := testRemoteSender()
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issue.LoadRepo(ctx))
+
+	// the branch names are pasted into the refspec of the fetch, "*" would fetch all the branches of the fork
+	for _, branch := range []string{"*", "main:refs/heads/main", "a..b", "feature/*"} {
+		fi := &issues_model.FederatedIssue{
+			OfferIRI:    "https://remote.example.com/alice/fork/pulls/1#offer",
+			HeadRepoIRI: "https://remote.example.com/repos/2",
+			HeadBranch:  branch,
+		}
+		offer := NewPullRequestOffer(ctx, testRemoteActor, RepoActorIRI(repo), issue, fi, "master")
+		assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, offer), util.ErrInvalidArgument, branch)
+
+		fi.HeadBranch = "feature"
+		offer = NewPullRequestOffer(ctx, testRemoteActor, RepoActorIRI(repo), issue, fi, branch)
+		assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, offer), util.ErrInvalidArgument, branch)
+
+		push := ap.ActivityNew("https://remote.example.com/alice/fork/commit/1#push", ap.ActivityType, nil)
+		push.Type = PushType
+		push.Actor = sender.GetLink()
+		push.Target = newBranch("https://remote.example.com/repos/2", branch)
+		assert.ErrorIs(t, HandleInboxActivity(ctx, sender, target, push), util.ErrInvalidArgument, branch)
+	}
+}
+
+func TestForkRemoteRepositoryCloneFailure(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/webfinger":
+			_, _ = fmt.Fprintf(w, `{"links":[{"rel":"self","type":"application/activity+json","href":"%s/repos/origin"}]}`, server.URL)
+		case "/repos/origin":
+			// the Git data of the repository can't be cloned
+			_, _ = fmt.Fprintf(w, `{"id":"%[1]s/repos/origin","type":"Repository","inbox":"%[1]s/repos/origin/inbox","url":"%[1]s/alice/origin"}`, server.URL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer test.MockVariableValue(&setting.Federation.MaxSize, 1<<20)()
+	defer test.MockVariableValue(&setting.Federation.AllowedHostList, "loopback")()
+	defer test.MockVariableValue(&setting.Migrations.AllowLocalNetworks, true)()
+	require.NoError(t, migrations.Init())
+	defer func() { _ = migrations.Init() }()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	_, err := ForkRemoteRepository(t.Context(), user2, user2, server.URL+"/alice/origin", "failed-federated-fork")
+	assert.Error(t, err)
+	// the repository created for the clone is deleted
+	unittest.AssertNotExistsBean(t, &repo_model.Repository{OwnerID: user2.ID, LowerName: "failed-federated-fork"})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
@@ -29,6 +30,11 @@ func GetFeeds(ctx context.Context, opts activities_model.GetFeedsOptions) (activ
 	return activities_model.GetFeeds(ctx, opts)
 }
 
+// GetFeedsAfter returns at most limit actions created after the action afterID according to the provided options, the oldest first
+func GetFeedsAfter(ctx context.Context, opts activities_model.GetFeedsOptions, afterID int64, limit int) (activities_model.ActionList, error) {
+	return activities_model.GetFeedsAfter(ctx, opts, afterID, limit)
+}
+
 // notifyWatchers creates batch of actions for every watcher.
 // It could insert duplicate actions for a repository action, like this:
 // * Original action: UserID=1 (the real actor), ActUserID=1
@@ -95,7 +101,7 @@ func notifyWatchers(ctx context.Context, act *activities_model.Action, watchers
 
 // NotifyWatchers creates batch of actions for every watcher.
 func NotifyWatchers(ctx context.Context, acts ...*activities_model.Action) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if len(acts) == 0 {
 			return nil
 		}
@@ -158,5 +164,30 @@ func NotifyWatchers(ctx context.Context, acts ...*activities_model.Action) error
 			}
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	signalNewFeeds()
+	return nil
+}
+
+var (
+	newFeedsMu     sync.Mutex
+	newFeedsSignal = make(chan struct{})
+)
+
+// NewFeedsSignal returns a channel which is closed when this instance creates new actions,
+// the actions created by the other instances of a cluster have to be polled
+func NewFeedsSignal() <-chan struct{} {
+	newFeedsMu.Lock()
+	defer newFeedsMu.Unlock()
+	return newFeedsSignal
+}
+
+func signalNewFeeds() {
+	newFeedsMu.Lock()
+	defer newFeedsMu.Unlock()
+	close(newFeedsSignal)
+	newFeedsSignal = make(chan struct{})
 }
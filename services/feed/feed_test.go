@@ -200,3 +200,42 @@ func TestNotifyWatchers(t *testing.T) {
 		OpType:    action.OpType,
 	})
 }
+
+func TestGetFeedsAfter(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	opts := activities_model.GetFeedsOptions{
+		RequestedRepo:  unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}),
+		Actor:          user,
+		IncludePrivate: true,
+	}
+
+	latestID, err := activities_model.GetLatestActionID(t.Context())
+	assert.NoError(t, err)
+	actions, err := GetFeedsAfter(t.Context(), opts, latestID, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+
+	signal := NewFeedsSignal()
+	for _, opType := range []activities_model.ActionType{activities_model.ActionCreateIssue, activities_model.ActionCommentIssue} {
+		assert.NoError(t, NotifyWatchers(t.Context(), &activities_model.Action{ActUserID: user.ID, RepoID: 1, OpType: opType}))
+	}
+	select {
+	case <-signal:
+	default:
+		assert.Fail(t, "the new actions aren't signaled")
+	}
+
+	actions, err = GetFeedsAfter(t.Context(), opts, latestID, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 2) {
+		assert.Equal(t, activities_model.ActionCreateIssue, actions[0].OpType)
+		assert.Equal(t, activities_model.ActionCommentIssue, actions[1].OpType)
+	}
+
+	actions, err = GetFeedsAfter(t.Context(), opts, actions[0].ID, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 1) {
+		assert.Equal(t, activities_model.ActionCommentIssue, actions[0].OpType)
+	}
+}
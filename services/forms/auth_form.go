@@ -85,6 +85,10 @@ type AuthenticationForm struct {
 	Oauth2RestrictedGroup         string
 	Oauth2GroupTeamMap            string `binding:"ValidGroupTeamMap"`
 	Oauth2GroupTeamMapRemoval     bool
+	Oauth2GroupSyncProvider       string
+	Oauth2GroupSyncURL            string
+	Oauth2GroupSyncTokenURL       string
+	Oauth2GroupSyncToken          string
 	Oauth2SSHPublicKeyClaimName   string
 	Oauth2FullNameClaimName       string
 
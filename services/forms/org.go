@@ -43,6 +43,7 @@ type UpdateOrgSettingForm struct {
 	Location                  string `binding:"MaxSize(50)"`
 	MaxRepoCreation           int
 	RepoAdminChangeTeamAccess bool
+	DisallowImpersonation     bool
 }
 
 // Validate validates the fields
@@ -70,6 +71,7 @@ type CreateTeamForm struct {
 	Permission       string
 	RepoAccess       string
 	CanCreateOrgRepo bool
+	ParentTeam       int64
 }
 
 // Validate validates the fields
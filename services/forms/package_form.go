@@ -15,13 +15,17 @@ import (
 type PackageCleanupRuleForm struct {
 	ID            int64
 	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,vagrant)"`
+	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,homebrew,maven,nix,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
 	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
 	KeepPattern   string `binding:"RegexPattern"`
 	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
 	RemovePattern string `binding:"RegexPattern"`
 	MatchFullName bool
-	Action        string `binding:"Required;In(save,remove)"`
+	// RepoName limits a new rule to the packages linked to the repository
+	RepoName             string
+	RemovePrereleaseDays int    `binding:"In(0,1,7,14,30,60,90,180)"`
+	RemoveUntaggedDays   int    `binding:"In(0,1,7,14,30,60,90,180)"`
+	Action               string `binding:"Required;In(save,remove)"`
 }
 
 func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
@@ -12,6 +12,7 @@ import (
 	project_model "code.gitea.io/gitea/models/project"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web/middleware"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/webhook"
 
@@ -77,6 +78,10 @@ type MigrateRepoForm struct {
 	Releases       bool   `json:"releases"`
 	MirrorInterval string `json:"mirror_interval"`
 
+	MirrorRefFilter string `json:"mirror_ref_filter"`
+	MirrorSkipTags  bool   `json:"mirror_skip_tags"`
+	MirrorMaxSize   int64  `json:"mirror_max_size"`
+
 	AWSAccessKeyID     string `json:"aws_access_key_id"`
 	AWSSecretAccessKey string `json:"aws_secret_access_key"`
 }
@@ -98,12 +103,17 @@ type RepoSettingForm struct {
 	MirrorPassword         string
 	LFS                    bool   `form:"mirror_lfs"`
 	LFSEndpoint            string `form:"mirror_lfs_endpoint"`
+	MirrorRefFilter        string
+	MirrorSkipTags         bool
+	MirrorMaxSize          string
 	PushMirrorID           int64
 	PushMirrorAddress      string
 	PushMirrorUsername     string
 	PushMirrorPassword     string
 	PushMirrorSyncOnCommit bool
 	PushMirrorInterval     string
+	PushMirrorBranchFilter string
+	PushMirrorLFS          bool
 	Private                bool
 	Template               bool
 	EnablePrune            bool
@@ -127,7 +137,11 @@ type RepoSettingForm struct {
 	EnableProjects bool
 	ProjectsMode   string
 
-	EnableReleases bool
+	EnableReleases             bool
+	ReleasesPublishAssets      bool
+	ReleasesDebianDistribution string `binding:"MaxSize(255)"`
+	ReleasesDebianComponent    string `binding:"MaxSize(255)"`
+	ReleasesRpmGroup           string `binding:"MaxSize(255)"`
 
 	EnablePackages bool
 
@@ -144,6 +158,8 @@ type RepoSettingForm struct {
 	PullsAllowRebaseUpdate           bool
 	DefaultDeleteBranchAfterMerge    bool
 	DefaultAllowMaintainerEdit       bool
+	PullsRequireSignOff              bool
+	PullsSignOffExemptUsers          string
 	EnableTimetracker                bool
 	AllowOnlyContributorsToTrackTime bool
 	EnableIssueDependencies          bool
@@ -237,6 +253,9 @@ type WebhookForm struct {
 	WorkflowJob              bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
+	PathFilter               string `binding:"GlobPattern"`
+	LabelFilter              string `binding:"GlobPattern"`
+	ActorFilter              string `binding:"GlobPattern"`
 	AuthorizationHeader      string
 	Secret                   string
 }
@@ -410,6 +429,92 @@ func (f *NewPackagistHookForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// NewCustomHookForm form for creating a hook with a templated payload
+type NewCustomHookForm struct {
+	PayloadURL   string `binding:"Required;ValidUrl"`
+	HTTPMethod   string `binding:"Required;In(POST,PUT,PATCH)"`
+	ContentType  string `binding:"MaxSize(255)"`
+	Headers      string
+	BodyTemplate string
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewCustomHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	meta := &webhook.CustomMeta{
+		ContentType:  f.ContentType,
+		Headers:      f.Headers,
+		BodyTemplate: f.BodyTemplate,
+	}
+	if err := meta.Validate(); err != nil {
+		errs = append(errs, binding.Error{
+			FieldNames:     []string{"BodyTemplate"},
+			Classification: "",
+			Message:        ctx.Locale.TrString("repo.settings.add_webhook.invalid_custom_template", err.Error()),
+		})
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewKafkaHookForm form for creating kafka hook
+type NewKafkaHookForm struct {
+	PayloadURL   string `binding:"Required"`
+	Topic        string `binding:"Required;MaxSize(249)"`
+	PartitionKey string `binding:"Required;In(repository,owner,none)"`
+	RequiredAcks string `binding:"Required;In(all,leader,none)"`
+	TLS          bool
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewKafkaHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	errs = validateBrokerURL(ctx, webhook_module.KAFKA, f.PayloadURL, errs)
+	meta := &webhook.KafkaMeta{Topic: f.Topic, PartitionKey: f.PartitionKey, RequiredAcks: f.RequiredAcks}
+	if err := meta.Validate(); err != nil && len(errs) == 0 {
+		errs = append(errs, binding.Error{
+			FieldNames: []string{"Topic"},
+			Message:    ctx.Locale.TrString("repo.settings.add_webhook.invalid_broker_options", err.Error()),
+		})
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewNatsHookForm form for creating nats hook
+type NewNatsHookForm struct {
+	PayloadURL   string `binding:"Required"`
+	Subject      string `binding:"Required;MaxSize(255)"`
+	PartitionKey string `binding:"Required;In(repository,owner,none)"`
+	JetStream    bool
+	TLS          bool
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewNatsHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	errs = validateBrokerURL(ctx, webhook_module.NATS, f.PayloadURL, errs)
+	meta := &webhook.NatsMeta{Subject: f.Subject, PartitionKey: f.PartitionKey}
+	if err := meta.Validate(); err != nil && len(errs) == 0 {
+		errs = append(errs, binding.Error{
+			FieldNames: []string{"Subject"},
+			Message:    ctx.Locale.TrString("repo.settings.add_webhook.invalid_broker_options", err.Error()),
+		})
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+func validateBrokerURL(ctx *context.ValidateContext, hookType webhook_module.HookType, brokerURL string, errs binding.Errors) binding.Errors {
+	if brokerURL != "" && !webhook.IsValidBrokerURL(hookType, brokerURL) {
+		errs = append(errs, binding.Error{
+			FieldNames: []string{"PayloadURL"},
+			Message:    ctx.Locale.TrString("repo.settings.add_webhook.invalid_broker_url", hookType),
+		})
+	}
+	return errs
+}
+
 // .___
 // |   | ______ ________ __   ____
 // |   |/  ___//  ___/  |  \_/ __ \
@@ -679,6 +784,17 @@ func (f *NewWikiForm) Validate(req *http.Request, errs binding.Errors) binding.E
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// ProtectWikiPageForm form for protecting wiki pages
+type ProtectWikiPageForm struct {
+	NamePattern string `binding:"Required;GlobOrRegexPattern"`
+}
+
+// Validate validates the fields
+func (f *ProtectWikiPageForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // ___________.__                 ___________                     __
 // \__    ___/|__| _____   ____   \__    ___/___________    ____ |  | __ ___________
 // |    |   |  |/     \_/ __ \    |    |  \_  __ \__  \ _/ ___\|  |/ // __ \_  __ \
@@ -14,9 +14,12 @@ import (
 
 // ProtectTagForm form for changing protected tag settings
 type ProtectTagForm struct {
-	NamePattern    string `binding:"Required;GlobOrRegexPattern"`
-	AllowlistUsers string
-	AllowlistTeams string
+	NamePattern      string `binding:"Required;GlobOrRegexPattern"`
+	AllowlistUsers   string
+	AllowlistTeams   string
+	RequireAnnotated bool
+	RequireSigned    bool
+	EnforceSemver    bool
 }
 
 // Validate validates the fields
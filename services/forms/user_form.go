@@ -88,10 +88,11 @@ func (f *InstallForm) Validate(req *http.Request, errs binding.Errors) binding.E
 
 // RegisterForm form for registering
 type RegisterForm struct {
-	UserName string `binding:"Required;Username;MaxSize(40)"`
-	Email    string `binding:"Required;MaxSize(254)"`
-	Password string `binding:"MaxSize(255)"`
-	Retype   string
+	UserName    string `binding:"Required;Username;MaxSize(40)"`
+	Email       string `binding:"Required;MaxSize(254)"`
+	Password    string `binding:"MaxSize(255)"`
+	Retype      string
+	AcceptTerms bool
 }
 
 // Validate validates the fields
@@ -324,6 +325,7 @@ func (f *AddKeyForm) Validate(req *http.Request, errs binding.Errors) binding.Er
 // AddSecretForm for adding secrets
 type AddSecretForm struct {
 	Name        string `binding:"Required;MaxSize(255)"`
+	Provider    string `binding:"In(,vault,aws)"`
 	Data        string `binding:"Required;MaxSize(65535)"`
 	Description string `binding:"MaxSize(65535)"`
 }
@@ -345,6 +347,18 @@ func (f *EditVariableForm) Validate(req *http.Request, errs binding.Errors) bind
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// EditArtifactRetentionForm form for editing the artifact retention policy of a repository or organization
+type EditArtifactRetentionForm struct {
+	MaxAgeDays   int64  `binding:"Range(0,36500)"`
+	MaxTotalSize string `binding:"MaxSize(64)"`
+	KeepLastN    int64  `binding:"Range(0,100000)"`
+}
+
+func (f *EditArtifactRetentionForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // NewAccessTokenForm form for creating access token
 type NewAccessTokenForm struct {
 	Name string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
@@ -466,9 +466,9 @@ type Diff struct {
 	NumViewedFiles int // user-specific
 }
 
-// LoadComments loads comments into each line
-func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, currentUser *user_model.User, showOutdatedComments bool) error {
-	allComments, err := issues_model.FetchCodeComments(ctx, issue, currentUser, showOutdatedComments)
+// LoadComments loads comments into each line, the comments hidden by a moderator are left out if hideModerated is set
+func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, currentUser *user_model.User, showOutdatedComments, hideModerated bool) error {
+	allComments, err := issues_model.FetchCodeComments(ctx, issue, currentUser, showOutdatedComments, hideModerated)
 	if err != nil {
 		return err
 	}
@@ -571,7 +571,7 @@ func TestDiff_LoadCommentsNoOutdated(t *testing.T) {
 	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
 	diff := setupDefaultDiff()
-	assert.NoError(t, diff.LoadComments(t.Context(), issue, user, false))
+	assert.NoError(t, diff.LoadComments(t.Context(), issue, user, false, false))
 	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Comments, 2)
 }
 
@@ -581,7 +581,7 @@ func TestDiff_LoadCommentsWithOutdated(t *testing.T) {
 	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
 	diff := setupDefaultDiff()
-	assert.NoError(t, diff.LoadComments(t.Context(), issue, user, true))
+	assert.NoError(t, diff.LoadComments(t.Context(), issue, user, true, false))
 	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Comments, 3)
 }
 
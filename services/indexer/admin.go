@@ -0,0 +1,300 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package indexer
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	dependency_indexer "code.gitea.io/gitea/modules/indexer/dependency"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	symbol_indexer "code.gitea.io/gitea/modules/indexer/symbol"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// The names of the indexers
+const (
+	IndexerCode       = "code"
+	IndexerWiki       = "wiki"
+	IndexerIssues     = "issues"
+	IndexerStats      = "stats"
+	IndexerDependency = "dependency"
+	IndexerSymbol     = "symbol"
+)
+
+// indexerInfo describes an indexer, the indexers without a RepoIndexerType don't keep the status of the repositories
+type indexerInfo struct {
+	name            string
+	queueName       string
+	repoIndexerType optional.Option[repo_model.RepoIndexerType]
+	enabled         func() bool
+	backend         func() string
+	available       func(ctx context.Context) bool
+	reindex         func(ctx context.Context, repo *repo_model.Repository) error
+}
+
+func alwaysAvailable(context.Context) bool { return true }
+
+// reindexByStatus makes the indexers which compare the last indexed commit with the default branch index the repository again
+func reindexByStatus(indexerType repo_model.RepoIndexerType, update func(*repo_model.Repository) error) func(ctx context.Context, repo *repo_model.Repository) error {
+	return func(ctx context.Context, repo *repo_model.Repository) error {
+		if err := repo_model.DeleteIndexerStatus(ctx, repo, indexerType); err != nil {
+			return err
+		}
+		return update(repo)
+	}
+}
+
+var indexers = []*indexerInfo{
+	{
+		name:            IndexerCode,
+		queueName:       "code_indexer",
+		repoIndexerType: optional.Some(repo_model.RepoIndexerTypeCode),
+		enabled:         func() bool { return setting.Indexer.RepoIndexerEnabled },
+		backend:         func() string { return setting.Indexer.RepoType },
+		available:       code_indexer.IsAvailable,
+		reindex:         code_indexer.ReindexRepository,
+	},
+	{
+		name:            IndexerWiki,
+		queueName:       "wiki_indexer",
+		repoIndexerType: optional.Some(repo_model.RepoIndexerTypeWiki),
+		enabled:         func() bool { return setting.Indexer.WikiIndexerEnabled },
+		backend:         func() string { return setting.Indexer.RepoType },
+		available:       wiki_indexer.IsAvailable,
+		reindex:         wiki_indexer.ReindexRepository,
+	},
+	{
+		name:      IndexerIssues,
+		queueName: "issue_indexer",
+		enabled:   func() bool { return true },
+		backend:   func() string { return setting.Indexer.IssueType },
+		available: issue_indexer.IsAvailable,
+		reindex: func(ctx context.Context, repo *repo_model.Repository) error {
+			issue_indexer.UpdateRepoIndexer(ctx, repo.ID)
+			return nil
+		},
+	},
+	{
+		name:            IndexerStats,
+		queueName:       "repo_stats_update",
+		repoIndexerType: optional.Some(repo_model.RepoIndexerTypeStats),
+		enabled:         func() bool { return true },
+		backend:         func() string { return "db" },
+		available:       alwaysAvailable,
+		reindex:         reindexByStatus(repo_model.RepoIndexerTypeStats, stats_indexer.UpdateRepoIndexer),
+	},
+	{
+		name:            IndexerDependency,
+		queueName:       "repo_dependency_update",
+		repoIndexerType: optional.Some(repo_model.RepoIndexerTypeDependency),
+		enabled:         func() bool { return true },
+		backend:         func() string { return "db" },
+		available:       alwaysAvailable,
+		reindex:         reindexByStatus(repo_model.RepoIndexerTypeDependency, dependency_indexer.UpdateRepoIndexer),
+	},
+	{
+		name:            IndexerSymbol,
+		queueName:       "repo_symbol_update",
+		repoIndexerType: optional.Some(repo_model.RepoIndexerTypeSymbol),
+		enabled:         func() bool { return setting.Indexer.SymbolIndexerEnabled },
+		backend:         func() string { return "ctags" },
+		available:       alwaysAvailable,
+		reindex:         reindexByStatus(repo_model.RepoIndexerTypeSymbol, symbol_indexer.UpdateRepoIndexer),
+	},
+}
+
+func getIndexer(name string) (*indexerInfo, error) {
+	for _, info := range indexers {
+		if info.name == name {
+			return info, nil
+		}
+	}
+	return nil, util.NewInvalidArgumentErrorf("unknown indexer %q", name)
+}
+
+// IndexerStatus is the status of an indexer
+type IndexerStatus struct {
+	Name      string
+	Backend   string
+	Enabled   bool
+	Available bool
+	// QueueLength is the number of the items waiting in the queue of the indexer
+	QueueLength   int
+	WorkerNumber  int
+	FailedRepoNum int64
+}
+
+// GetIndexerStatuses returns the statuses of all the indexers
+func GetIndexerStatuses(ctx context.Context) ([]*IndexerStatus, error) {
+	queues := make(map[string]queue.ManagedWorkerPoolQueue)
+	for _, mq := range queue.GetManager().ManagedQueues() {
+		queues[mq.GetName()] = mq
+	}
+
+	statuses := make([]*IndexerStatus, 0, len(indexers))
+	for _, info := range indexers {
+		status := &IndexerStatus{
+			Name:    info.name,
+			Backend: info.backend(),
+			Enabled: info.enabled(),
+		}
+		if status.Enabled {
+			status.Available = info.available(ctx)
+		}
+		if mq, ok := queues[info.queueName]; ok {
+			status.QueueLength = mq.GetQueueItemNumber()
+			status.WorkerNumber = mq.GetWorkerNumber()
+		}
+		if info.repoIndexerType.Has() {
+			count, err := db.Count[repo_model.RepoIndexerStatus](ctx, repo_model.FindIndexerFailuresOptions{IndexerType: info.repoIndexerType})
+			if err != nil {
+				return nil, err
+			}
+			status.FailedRepoNum = count
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// RepoIndexerStatus is the status of a repository in an indexer
+type RepoIndexerStatus struct {
+	Indexer string
+	Repo    *repo_model.Repository
+	// CommitID is the last indexed commit, HeadCommitID is the commit which should be indexed
+	CommitID     string
+	HeadCommitID string
+	IndexedUnix  timeutil.TimeStamp
+	LastError    string
+	FailedUnix   timeutil.TimeStamp
+}
+
+func toRepoIndexerStatus(name string, repo *repo_model.Repository, status *repo_model.RepoIndexerStatus) *RepoIndexerStatus {
+	return &RepoIndexerStatus{
+		Indexer:     name,
+		Repo:        repo,
+		CommitID:    status.CommitSha,
+		IndexedUnix: status.IndexedUnix,
+		LastError:   status.LastError,
+		FailedUnix:  status.FailedUnix,
+	}
+}
+
+func getHeadCommitID(ctx context.Context, repo *repo_model.Repository, indexerType repo_model.RepoIndexerType) (string, error) {
+	var sha string
+	var err error
+	if indexerType == repo_model.RepoIndexerTypeWiki {
+		if exist, err := gitrepo.IsRepositoryExist(ctx, repo.WikiStorageRepo()); err != nil || !exist {
+			return "", err
+		}
+		sha, err = gitrepo.GetBranchCommitID(ctx, repo.WikiStorageRepo(), repo.DefaultWikiBranch)
+	} else {
+		if repo.IsEmpty {
+			return "", nil
+		}
+		sha, err = gitrepo.GetBranchCommitID(ctx, repo, repo.DefaultBranch)
+	}
+	if git.IsErrNotExist(err) || git.IsErrBranchNotExist(err) {
+		return "", nil
+	}
+	return sha, err
+}
+
+// GetRepoIndexerStatuses returns the statuses of a repository in the enabled indexers which keep them
+func GetRepoIndexerStatuses(ctx context.Context, repo *repo_model.Repository) ([]*RepoIndexerStatus, error) {
+	statuses := make([]*RepoIndexerStatus, 0, len(indexers))
+	for _, info := range indexers {
+		if !info.enabled() || !info.repoIndexerType.Has() {
+			continue
+		}
+		status, err := repo_model.GetIndexerStatus(ctx, repo, info.repoIndexerType.Value())
+		if err != nil {
+			return nil, err
+		}
+		s := toRepoIndexerStatus(info.name, repo, status)
+		if s.HeadCommitID, err = getHeadCommitID(ctx, repo, info.repoIndexerType.Value()); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// FindIndexerFailures returns the repositories whose last indexing failed, the failures of all the indexers are returned if the name is empty
+func FindIndexerFailures(ctx context.Context, name string, listOptions db.ListOptions) ([]*RepoIndexerStatus, int64, error) {
+	opts := repo_model.FindIndexerFailuresOptions{ListOptions: listOptions}
+	if name != "" {
+		info, err := getIndexer(name)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !info.repoIndexerType.Has() {
+			return nil, 0, util.NewInvalidArgumentErrorf("the %s indexer doesn't record failures", name)
+		}
+		opts.IndexerType = info.repoIndexerType
+	}
+
+	failures, count, err := db.FindAndCount[repo_model.RepoIndexerStatus](ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	repoIDs := make([]int64, 0, len(failures))
+	for _, failure := range failures {
+		repoIDs = append(repoIDs, failure.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	statuses := make([]*RepoIndexerStatus, 0, len(failures))
+	for _, failure := range failures {
+		repo, ok := repos[failure.RepoID]
+		if !ok {
+			continue
+		}
+		for _, info := range indexers {
+			if info.repoIndexerType.Has() && info.repoIndexerType.Value() == failure.IndexerType {
+				statuses = append(statuses, toRepoIndexerStatus(info.name, repo, failure))
+				break
+			}
+		}
+	}
+	return statuses, count, nil
+}
+
+// ReindexRepository makes an indexer index a repository from scratch, all the enabled indexers do if the name is empty
+func ReindexRepository(ctx context.Context, repo *repo_model.Repository, name string) error {
+	if name != "" {
+		info, err := getIndexer(name)
+		if err != nil {
+			return err
+		}
+		if !info.enabled() {
+			return util.NewInvalidArgumentErrorf("the %s indexer is disabled", name)
+		}
+		return info.reindex(ctx, repo)
+	}
+
+	for _, info := range indexers {
+		if !info.enabled() {
+			continue
+		}
+		if err := info.reindex(ctx, repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
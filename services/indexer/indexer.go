@@ -5,8 +5,11 @@ package indexer
 
 import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	dependency_indexer "code.gitea.io/gitea/modules/indexer/dependency"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	symbol_indexer "code.gitea.io/gitea/modules/indexer/symbol"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -16,5 +19,12 @@ func Init() error {
 
 	issue_indexer.InitIssueIndexer(false)
 	code_indexer.Init()
+	wiki_indexer.Init()
+	if err := dependency_indexer.Init(); err != nil {
+		return err
+	}
+	if err := symbol_indexer.Init(); err != nil {
+		return err
+	}
 	return stats_indexer.Init()
 }
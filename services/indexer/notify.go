@@ -10,8 +10,11 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	dependency_indexer "code.gitea.io/gitea/modules/indexer/dependency"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	symbol_indexer "code.gitea.io/gitea/modules/indexer/symbol"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
@@ -72,6 +75,9 @@ func (r *indexerNotifier) DeleteRepository(ctx context.Context, doer *user_model
 	if setting.Indexer.RepoIndexerEnabled {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
 }
 
 func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
@@ -79,9 +85,18 @@ func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_m
 	if setting.Indexer.RepoIndexerEnabled && !repo.IsEmpty {
 		code_indexer.UpdateRepoIndexer(repo)
 	}
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if err := dependency_indexer.UpdateRepoIndexer(repo); err != nil {
+		log.Error("dependency_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+	}
+	if err := symbol_indexer.UpdateRepoIndexer(repo); err != nil {
+		log.Error("symbol_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+	}
 }
 
 func (r *indexerNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -95,6 +110,14 @@ func (r *indexerNotifier) PushCommits(ctx context.Context, pusher *user_model.Us
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if opts.RefFullName.BranchName() == repo.DefaultBranch {
+		if err := dependency_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("dependency_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+		if err := symbol_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("symbol_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -108,6 +131,14 @@ func (r *indexerNotifier) SyncPushCommits(ctx context.Context, pusher *user_mode
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if opts.RefFullName.BranchName() == repo.DefaultBranch {
+		if err := dependency_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("dependency_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+		if err := symbol_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("symbol_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
@@ -117,6 +148,12 @@ func (r *indexerNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_mo
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if err := dependency_indexer.UpdateRepoIndexer(repo); err != nil {
+		log.Error("dependency_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+	}
+	if err := symbol_indexer.UpdateRepoIndexer(repo); err != nil {
+		log.Error("symbol_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+	}
 }
 
 func (r *indexerNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
@@ -168,3 +205,21 @@ func (r *indexerNotifier) AutoMergePullRequest(ctx context.Context, doer *user_m
 	}
 	issue_indexer.UpdateIssueIndexer(ctx, pr.Issue.ID)
 }
+
+func (r *indexerNotifier) NewWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
+}
+
+func (r *indexerNotifier) EditWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page, comment string) {
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
+}
+
+func (r *indexerNotifier) DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, page string) {
+	if setting.Indexer.WikiIndexerEnabled {
+		wiki_indexer.UpdateRepoIndexer(repo)
+	}
+}
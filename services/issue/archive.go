@@ -0,0 +1,608 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/label"
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/google/uuid"
+)
+
+// issueArchivePoster returns the id and the name of the author of an issue or a comment,
+// the original author of the migrated ones
+func issueArchivePoster(poster *user_model.User, originalAuthorID int64, originalAuthor string) (int64, string) {
+	if originalAuthor != "" {
+		return originalAuthorID, originalAuthor
+	}
+	return poster.ID, poster.Name
+}
+
+func toArchivedReactions(reactions issues_model.ReactionList) []*base.Reaction {
+	archived := make([]*base.Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		userID, userName := issueArchivePoster(reaction.User, reaction.OriginalAuthorID, reaction.OriginalAuthor)
+		archived = append(archived, &base.Reaction{UserID: userID, UserName: userName, Content: reaction.Type})
+	}
+	return archived
+}
+
+// archivableAttachments returns the attachments whose files exist in the storage and which are not quarantined
+func archivableAttachments(attachments []*repo_model.Attachment) []*repo_model.Attachment {
+	archivable := make([]*repo_model.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.IsQuarantined {
+			continue
+		}
+		if _, err := storage.Attachments.Stat(attachment.RelativePath()); err != nil {
+			log.Warn("Unable to archive the attachment %s: %v", attachment.UUID, err)
+			continue
+		}
+		archivable = append(archivable, attachment)
+	}
+	return archivable
+}
+
+func toArchivedAttachments(attachments []*repo_model.Attachment) []*base.ArchivedAttachment {
+	archived := make([]*base.ArchivedAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		archived = append(archived, &base.ArchivedAttachment{
+			UUID:    attachment.UUID,
+			Name:    attachment.Name,
+			Created: attachment.CreatedUnix.AsTime(),
+		})
+	}
+	return archived
+}
+
+// WriteIssueArchive writes the issues of a repository with their comments, reactions and attachments,
+// and the labels and the milestones of the repository as an issue archive, see base.IssueArchive
+func WriteIssueArchive(ctx context.Context, w io.Writer, repo *repo_model.Repository) error {
+	issues, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
+		RepoIDs:  []int64{repo.ID},
+		IsPull:   optional.Some(false),
+		SortType: "oldest",
+	})
+	if err != nil {
+		return err
+	}
+	if err := issues.LoadAttachments(ctx); err != nil {
+		return err
+	}
+	comments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{
+		RepoID: repo.ID,
+		IsPull: optional.Some(false),
+		Type:   issues_model.CommentTypeComment,
+	})
+	if err != nil {
+		return err
+	}
+	if err := comments.LoadPosters(ctx); err != nil {
+		return err
+	}
+	if err := comments.LoadAttachments(ctx); err != nil {
+		return err
+	}
+	issueComments := make(map[int64]issues_model.CommentList, len(issues))
+	for _, comment := range comments {
+		issueComments[comment.IssueID] = append(issueComments[comment.IssueID], comment)
+	}
+
+	archive := &base.IssueArchive{
+		Version:    base.IssueArchiveVersion,
+		Repository: repo.FullName(),
+		Created:    time.Now(),
+	}
+
+	repoLabels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return err
+	}
+	labelNames := make(container.Set[string])
+	addLabels := func(labels []*issues_model.Label) {
+		for _, l := range labels {
+			if labelNames.Add(l.Name) {
+				archive.Labels = append(archive.Labels, &base.Label{
+					Name:        l.Name,
+					Color:       l.Color,
+					Description: l.Description,
+					Exclusive:   l.Exclusive,
+				})
+			}
+		}
+	}
+	addLabels(repoLabels)
+
+	milestones, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{RepoID: repo.ID})
+	if err != nil {
+		return err
+	}
+	for _, m := range milestones {
+		milestone := &base.Milestone{
+			Title:       m.Name,
+			Description: m.Content,
+			Created:     m.CreatedUnix.AsTime(),
+			State:       util.Iif(m.IsClosed, "closed", "open"),
+		}
+		// milestones without deadline have a deadline in the year 9999
+		if deadline := m.DeadlineUnix.AsTime(); deadline.Year() < 9999 {
+			milestone.Deadline = &deadline
+		}
+		updated := m.UpdatedUnix.AsTime()
+		milestone.Updated = &updated
+		if m.IsClosed && m.ClosedDateUnix > 0 {
+			closed := m.ClosedDateUnix.AsTime()
+			milestone.Closed = &closed
+		}
+		archive.Milestones = append(archive.Milestones, milestone)
+	}
+
+	attachments := make([]*repo_model.Attachment, 0, 10)
+	for _, issue := range issues {
+		addLabels(issue.Labels)
+
+		reactions, _, err := issues_model.FindReactions(ctx, issues_model.FindReactionsOptions{IssueID: issue.ID})
+		if err != nil {
+			return err
+		}
+		if _, err := reactions.LoadUsers(ctx, repo); err != nil {
+			return err
+		}
+		commentReactions := make(map[int64]issues_model.ReactionList)
+		for _, reaction := range reactions {
+			commentReactions[reaction.CommentID] = append(commentReactions[reaction.CommentID], reaction)
+		}
+
+		posterID, posterName := issueArchivePoster(issue.Poster, issue.OriginalAuthorID, issue.OriginalAuthor)
+		archived := &base.ArchivedIssue{
+			Number:     issue.Index,
+			PosterID:   posterID,
+			PosterName: posterName,
+			Title:      issue.Title,
+			Content:    issue.Content,
+			State:      string(issue.State()),
+			IsLocked:   issue.IsLocked,
+			Created:    issue.CreatedUnix.AsTime(),
+			Updated:    issue.UpdatedUnix.AsTime(),
+			Labels:     make([]string, 0, len(issue.Labels)),
+			Reactions:  toArchivedReactions(commentReactions[0]),
+			Comments:   make([]*base.ArchivedComment, 0, len(issueComments[issue.ID])),
+		}
+		if issue.Milestone != nil {
+			archived.Milestone = issue.Milestone.Name
+		}
+		if issue.IsClosed && issue.ClosedUnix > 0 {
+			closed := issue.ClosedUnix.AsTime()
+			archived.Closed = &closed
+		}
+		for _, l := range issue.Labels {
+			archived.Labels = append(archived.Labels, l.Name)
+		}
+		// the attachments of the issue include the ones of its comments
+		issueAttachments := make([]*repo_model.Attachment, 0, len(issue.Attachments))
+		for _, attachment := range issue.Attachments {
+			if attachment.CommentID == 0 {
+				issueAttachments = append(issueAttachments, attachment)
+			}
+		}
+		issueAttachments = archivableAttachments(issueAttachments)
+		archived.Attachments = toArchivedAttachments(issueAttachments)
+		attachments = append(attachments, issueAttachments...)
+
+		for _, comment := range issueComments[issue.ID] {
+			commentAttachments := archivableAttachments(comment.Attachments)
+			posterID, posterName := issueArchivePoster(comment.Poster, comment.OriginalAuthorID, comment.OriginalAuthor)
+			archived.Comments = append(archived.Comments, &base.ArchivedComment{
+				PosterID:    posterID,
+				PosterName:  posterName,
+				Content:     comment.Content,
+				Created:     comment.CreatedUnix.AsTime(),
+				Updated:     comment.UpdatedUnix.AsTime(),
+				Reactions:   toArchivedReactions(commentReactions[comment.ID]),
+				Attachments: toArchivedAttachments(commentAttachments),
+			})
+			attachments = append(attachments, commentAttachments...)
+		}
+		archive.Issues = append(archive.Issues, archived)
+	}
+
+	zw := zip.NewWriter(w)
+	bs, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := zw.Create(base.IssueArchiveFilename)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bs); err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := writeIssueArchiveAttachment(zw, attachment); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeIssueArchiveAttachment(zw *zip.Writer, attachment *repo_model.Attachment) error {
+	r, err := storage.Attachments.Open(attachment.RelativePath())
+	if err != nil {
+		return fmt.Errorf("open attachment %s: %w", attachment.UUID, err)
+	}
+	defer r.Close()
+
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     path.Join(base.IssueArchiveAttachmentsDir, attachment.UUID),
+		Method:   zip.Deflate,
+		Modified: attachment.CreatedUnix.AsTime(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// readIssueArchive reads and validates the JSON file of an issue archive
+func readIssueArchive(zr *zip.Reader) (*base.IssueArchive, error) {
+	f, err := zr.Open(base.IssueArchiveFilename)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("the archive has no file %s", base.IssueArchiveFilename)
+	}
+	defer f.Close()
+
+	bs, err := io.ReadAll(f)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("unable to read %s: %v", base.IssueArchiveFilename, err)
+	}
+	var archive base.IssueArchive
+	if err := base.Unmarshal(bs, &archive, true, true); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid %s: %v", base.IssueArchiveFilename, err)
+	}
+	if archive.Version > base.IssueArchiveVersion {
+		return nil, util.NewInvalidArgumentErrorf("unsupported issue archive version %d", archive.Version)
+	}
+	return &archive, nil
+}
+
+// issueArchiveImporter imports the content of an issue archive in a repository
+type issueArchiveImporter struct {
+	doer *user_model.User
+	repo *repo_model.Repository
+	// files are the files of the attachments of the archive by uuid
+	files map[string]*zip.File
+	// uuids are the uuids of the imported attachments by uuid of the archive
+	uuids map[string]string
+	// sources are the files of the imported attachments by uuid
+	sources  map[string]*zip.File
+	replacer *strings.Replacer
+}
+
+// prepareAttachments returns the attachments to import with new uuids, each must have a file in the archive
+func (im *issueArchiveImporter) prepareAttachments(archived []*base.ArchivedAttachment) ([]*repo_model.Attachment, error) {
+	attachments := make([]*repo_model.Attachment, 0, len(archived))
+	for _, a := range archived {
+		if _, ok := im.files[a.UUID]; !ok {
+			return nil, util.NewInvalidArgumentErrorf("the archive has no file for the attachment %s", a.UUID)
+		}
+		if _, ok := im.uuids[a.UUID]; ok {
+			return nil, util.NewInvalidArgumentErrorf("the attachment %s is duplicated", a.UUID)
+		}
+		im.uuids[a.UUID] = uuid.New().String()
+		im.sources[im.uuids[a.UUID]] = im.files[a.UUID]
+		attachments = append(attachments, &repo_model.Attachment{
+			UUID:        im.uuids[a.UUID],
+			RepoID:      im.repo.ID,
+			UploaderID:  im.doer.ID,
+			Name:        a.Name,
+			CreatedUnix: timeutil.TimeStamp(util.IfZero(a.Created, time.Now()).Unix()),
+		})
+	}
+	return attachments, nil
+}
+
+// saveAttachments stores the files of the attachments of an issue or a comment
+func (im *issueArchiveImporter) saveAttachments(ctx context.Context, attachments []*repo_model.Attachment, issueID, commentID int64) error {
+	for _, attachment := range attachments {
+		attachment.IssueID = issueID
+		attachment.CommentID = commentID
+		if err := im.saveAttachment(ctx, attachment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (im *issueArchiveImporter) saveAttachment(ctx context.Context, attachment *repo_model.Attachment) error {
+	f := im.sources[attachment.UUID]
+	r, err := f.Open()
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("unable to read the file of the attachment %s: %v", attachment.Name, err)
+	}
+	defer r.Close()
+
+	if attachment.Size, err = storage.Attachments.Save(attachment.RelativePath(), r, int64(f.UncompressedSize64)); err != nil {
+		return err
+	}
+	_, err = db.GetEngine(ctx).NoAutoTime().Insert(attachment)
+	return err
+}
+
+// content returns a content of the archive referencing the imported attachments
+func (im *issueArchiveImporter) content(content string) string {
+	if im.replacer == nil {
+		oldnew := make([]string, 0, len(im.uuids)*2)
+		for archivedUUID, newUUID := range im.uuids {
+			oldnew = append(oldnew, archivedUUID, newUUID)
+		}
+		im.replacer = strings.NewReplacer(oldnew...)
+	}
+	return im.replacer.Replace(content)
+}
+
+// remap makes the doer the poster of an issue, a comment or a reaction of an original author of the archive
+func (im *issueArchiveImporter) remap(target user_model.ExternalUserRemappable, posterID int64, posterName string) {
+	// the original author is only shown when it has an id
+	if posterID == 0 {
+		posterID = user_model.GhostUserID
+	}
+	_ = target.RemapExternalUser(posterName, posterID, im.doer.ID)
+}
+
+func (im *issueArchiveImporter) reactions(archived []*base.Reaction) []*issues_model.Reaction {
+	reactions := make([]*issues_model.Reaction, 0, len(archived))
+	for _, r := range archived {
+		reaction := &issues_model.Reaction{Type: r.Content, CreatedUnix: timeutil.TimeStampNow()}
+		im.remap(reaction, r.UserID, r.UserName)
+		reactions = append(reactions, reaction)
+	}
+	return reactions
+}
+
+// importLabels returns the labels of the repository and of its owner by name, the missing labels of the archive are created
+func (im *issueArchiveImporter) importLabels(ctx context.Context, archived []*base.Label) (map[string]*issues_model.Label, error) {
+	existing, err := issues_model.GetLabelsByRepoID(ctx, im.repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if im.repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, im.repo.OwnerID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, orgLabels...)
+	}
+	labels := make(map[string]*issues_model.Label, len(existing)+len(archived))
+	for _, l := range existing {
+		if _, ok := labels[l.Name]; !ok {
+			labels[l.Name] = l
+		}
+	}
+
+	created := make([]*issues_model.Label, 0, len(archived))
+	for _, l := range archived {
+		if _, ok := labels[l.Name]; ok {
+			continue
+		}
+		color, err := label.NormalizeColor(l.Color)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid color of the label %q: %v", l.Name, err)
+		}
+		labels[l.Name] = &issues_model.Label{
+			RepoID:      im.repo.ID,
+			Name:        l.Name,
+			Color:       color,
+			Description: l.Description,
+			Exclusive:   l.Exclusive,
+		}
+		created = append(created, labels[l.Name])
+	}
+	return labels, issues_model.NewLabels(ctx, created...)
+}
+
+// importMilestones returns the ids of the milestones of the repository by name, the missing milestones of the archive are created
+func (im *issueArchiveImporter) importMilestones(ctx context.Context, archived []*base.Milestone) (map[string]int64, error) {
+	existing, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{RepoID: im.repo.ID})
+	if err != nil {
+		return nil, err
+	}
+	milestones := make(map[string]int64, len(existing)+len(archived))
+	for _, m := range existing {
+		milestones[m.Name] = m.ID
+	}
+
+	created := make([]*issues_model.Milestone, 0, len(archived))
+	for _, m := range archived {
+		if _, ok := milestones[m.Title]; ok {
+			continue
+		}
+		milestone := &issues_model.Milestone{
+			RepoID:      im.repo.ID,
+			Name:        m.Title,
+			Content:     m.Description,
+			IsClosed:    m.State == "closed",
+			CreatedUnix: timeutil.TimeStamp(util.IfZero(m.Created, time.Now()).Unix()),
+			// milestones without deadline have a deadline in the year 9999
+			DeadlineUnix: timeutil.TimeStamp(time.Date(9999, 1, 1, 0, 0, 0, 0, setting.DefaultUILocation).Unix()),
+		}
+		milestone.UpdatedUnix = milestone.CreatedUnix
+		if m.Updated != nil {
+			milestone.UpdatedUnix = timeutil.TimeStamp(m.Updated.Unix())
+		}
+		if m.Deadline != nil {
+			milestone.DeadlineUnix = timeutil.TimeStamp(m.Deadline.Unix())
+		}
+		if milestone.IsClosed && m.Closed != nil {
+			milestone.ClosedDateUnix = timeutil.TimeStamp(m.Closed.Unix())
+		}
+		milestones[m.Title] = 0
+		created = append(created, milestone)
+	}
+	if err := issues_model.InsertMilestones(ctx, created...); err != nil {
+		return nil, err
+	}
+	for _, m := range created {
+		milestones[m.Name] = m.ID
+	}
+	return milestones, nil
+}
+
+// ImportIssueArchive imports the issues of an issue archive written by WriteIssueArchive in a repository.
+// The issues keep their numbers which must not be used in the repository, the missing labels and milestones
+// are created. The issues, comments and reactions are posted by the doer on behalf of their original authors.
+func ImportIssueArchive(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid issue archive: %v", err)
+	}
+	archive, err := readIssueArchive(zr)
+	if err != nil {
+		return err
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+
+	im := &issueArchiveImporter{
+		doer:    doer,
+		repo:    repo,
+		files:   make(map[string]*zip.File),
+		uuids:   make(map[string]string),
+		sources: make(map[string]*zip.File),
+	}
+	for _, f := range zr.File {
+		if dir, name := path.Split(f.Name); dir == base.IssueArchiveAttachmentsDir+"/" && name != "" {
+			im.files[name] = f
+		}
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		indexes, err := issues_model.GetIssueIndexesOfRepo(ctx, repo.ID)
+		if err != nil {
+			return err
+		}
+		usedIndexes := container.SetOf(indexes...)
+		for _, issue := range archive.Issues {
+			if !usedIndexes.Add(issue.Number) {
+				return util.NewInvalidArgumentErrorf("the issue number %d is already used", issue.Number)
+			}
+		}
+
+		labels, err := im.importLabels(ctx, archive.Labels)
+		if err != nil {
+			return err
+		}
+		milestones, err := im.importMilestones(ctx, archive.Milestones)
+		if err != nil {
+			return err
+		}
+
+		// the attachments get their new uuids before the contents referencing them are imported
+		issueAttachments := make([][]*repo_model.Attachment, len(archive.Issues))
+		commentAttachments := make([][][]*repo_model.Attachment, len(archive.Issues))
+		for i, archived := range archive.Issues {
+			if issueAttachments[i], err = im.prepareAttachments(archived.Attachments); err != nil {
+				return err
+			}
+			commentAttachments[i] = make([][]*repo_model.Attachment, len(archived.Comments))
+			for j, comment := range archived.Comments {
+				if commentAttachments[i][j], err = im.prepareAttachments(comment.Attachments); err != nil {
+					return err
+				}
+			}
+		}
+
+		issues := make([]*issues_model.Issue, 0, len(archive.Issues))
+		for _, archived := range archive.Issues {
+			issue := &issues_model.Issue{
+				RepoID:      repo.ID,
+				Repo:        repo,
+				Index:       archived.Number,
+				Title:       util.TruncateRunes(archived.Title, 255),
+				Content:     im.content(archived.Content),
+				IsClosed:    archived.State == "closed",
+				IsLocked:    archived.IsLocked,
+				MilestoneID: milestones[archived.Milestone],
+				Reactions:   im.reactions(archived.Reactions),
+				CreatedUnix: timeutil.TimeStamp(archived.Created.Unix()),
+				UpdatedUnix: timeutil.TimeStamp(util.IfZero(archived.Updated, archived.Created).Unix()),
+			}
+			if archived.Closed != nil {
+				issue.ClosedUnix = timeutil.TimeStamp(archived.Closed.Unix())
+			}
+			for _, name := range archived.Labels {
+				if l, ok := labels[name]; ok {
+					issue.Labels = append(issue.Labels, l)
+				}
+			}
+			im.remap(issue, archived.PosterID, archived.PosterName)
+			issues = append(issues, issue)
+		}
+		if err := issues_model.InsertIssues(ctx, issues...); err != nil {
+			return err
+		}
+
+		for i, archived := range archive.Issues {
+			if err := im.saveAttachments(ctx, issueAttachments[i], issues[i].ID, 0); err != nil {
+				return err
+			}
+			comments := make([]*issues_model.Comment, 0, len(archived.Comments))
+			for _, c := range archived.Comments {
+				comment := &issues_model.Comment{
+					IssueID:     issues[i].ID,
+					Type:        issues_model.CommentTypeComment,
+					Content:     im.content(c.Content),
+					Reactions:   im.reactions(c.Reactions),
+					CreatedUnix: timeutil.TimeStamp(c.Created.Unix()),
+					UpdatedUnix: timeutil.TimeStamp(util.IfZero(c.Updated, c.Created).Unix()),
+				}
+				im.remap(comment, c.PosterID, c.PosterName)
+				comments = append(comments, comment)
+			}
+			if err := issues_model.InsertIssueComments(ctx, comments); err != nil {
+				return err
+			}
+			for j, comment := range comments {
+				if err := im.saveAttachments(ctx, commentAttachments[i][j], issues[i].ID, comment.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := issues_model.RecalculateIssueIndexForRepo(ctx, repo.ID); err != nil {
+			return err
+		}
+		return models.UpdateRepoStats(ctx, repo.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	issue_indexer.UpdateRepoIndexer(ctx, repo.ID)
+	return nil
+}
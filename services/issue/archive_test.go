@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+	attachment_service "code.gitea.io/gitea/services/attachment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueArchive(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo4 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	attachment, err := attachment_service.NewAttachment(t.Context(), &repo_model.Attachment{
+		RepoID:  repo1.ID,
+		IssueID: 1,
+		Name:    "log.txt",
+	}, strings.NewReader("some logs"), 9)
+	require.NoError(t, err)
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue1.Content = "see [log.txt](/attachments/" + attachment.UUID + ")"
+	require.NoError(t, issues_model.UpdateIssueCols(t.Context(), issue1, "content"))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteIssueArchive(t.Context(), &buf, repo1))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	f, err := zr.Open(base.IssueArchiveFilename)
+	require.NoError(t, err)
+	bs, err := io.ReadAll(f)
+	require.NoError(t, err)
+	var archive base.IssueArchive
+	require.NoError(t, json.Unmarshal(bs, &archive))
+	assert.Equal(t, "user2/repo1", archive.Repository)
+	require.Len(t, archive.Milestones, 3)
+	assert.Equal(t, "milestone1", archive.Milestones[0].Title)
+	// the pull requests are not archived
+	require.Len(t, archive.Issues, 2)
+	assert.EqualValues(t, 1, archive.Issues[0].Number)
+	assert.Equal(t, "user1", archive.Issues[0].PosterName)
+	assert.Equal(t, []string{"label1"}, archive.Issues[0].Labels)
+	// the attachments of the fixtures have no file
+	require.Len(t, archive.Issues[0].Attachments, 1)
+	assert.Equal(t, attachment.UUID, archive.Issues[0].Attachments[0].UUID)
+	require.Len(t, archive.Issues[0].Comments, 2)
+	assert.Equal(t, "good work!", archive.Issues[0].Comments[0].Content)
+	assert.Equal(t, "org3", archive.Issues[0].Comments[0].PosterName)
+	assert.EqualValues(t, 4, archive.Issues[1].Number)
+	assert.Equal(t, "closed", archive.Issues[1].State)
+
+	require.NoError(t, ImportIssueArchive(t.Context(), doer, repo4, bytes.NewReader(buf.Bytes()), int64(buf.Len())))
+
+	imported := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo4.ID, Index: 1})
+	assert.Equal(t, doer.ID, imported.PosterID)
+	assert.Equal(t, "user1", imported.OriginalAuthor)
+	assert.EqualValues(t, 1, imported.OriginalAuthorID)
+	assert.Equal(t, 2, imported.NumComments)
+	require.NoError(t, imported.LoadAttributes(t.Context()))
+	require.Len(t, imported.Labels, 1)
+	assert.Equal(t, "label1", imported.Labels[0].Name)
+	assert.Equal(t, repo4.ID, imported.Labels[0].RepoID)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Milestone{RepoID: repo4.ID, Name: "milestone1"})
+	require.Len(t, imported.Attachments, 1)
+	assert.NotEqual(t, attachment.UUID, imported.Attachments[0].UUID)
+	assert.Equal(t, "see [log.txt](/attachments/"+imported.Attachments[0].UUID+")", imported.Content)
+	r, err := storage.Attachments.Open(imported.Attachments[0].RelativePath())
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "some logs", string(content))
+
+	closed := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo4.ID, Index: 4})
+	assert.True(t, closed.IsClosed)
+	repo4 = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	assert.Equal(t, 2, repo4.NumIssues)
+	assert.Equal(t, 1, repo4.NumClosedIssues)
+
+	// the numbers of the issues are already used
+	err = ImportIssueArchive(t.Context(), doer, repo4, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = ImportIssueArchive(t.Context(), doer, repo4, strings.NewReader("not a zip"), 9)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
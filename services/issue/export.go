@@ -0,0 +1,142 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+func formatCSVTime(ts timeutil.TimeStamp) string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// WriteIssuesCSV writes the issues of a repository as CSV with a column for each custom field of the repository
+func WriteIssuesCSV(ctx context.Context, w io.Writer, repo *repo_model.Repository, issues issues_model.IssueList) error {
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if err := issues.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	issueIDs := make([]int64, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	values, err := issues_model.GetIssueFieldDisplayValues(ctx, fields, issueIDs...)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	header := []string{"index", "title", "state", "type", "author", "assignees", "labels", "milestone", "created", "updated", "closed"}
+	for _, f := range fields {
+		header = append(header, f.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		state, typ := "open", "issue"
+		if issue.IsClosed {
+			state = "closed"
+		}
+		if issue.IsPull {
+			typ = "pull"
+		}
+		assignees := make([]string, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			assignees = append(assignees, assignee.Name)
+		}
+		labels := make([]string, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			labels = append(labels, label.Name)
+		}
+		var closedUnix timeutil.TimeStamp
+		if issue.IsClosed {
+			closedUnix = issue.ClosedUnix
+		}
+		var milestone string
+		if issue.Milestone != nil {
+			milestone = issue.Milestone.Name
+		}
+
+		record := []string{
+			strconv.FormatInt(issue.Index, 10),
+			issue.Title,
+			state,
+			typ,
+			issue.Poster.Name,
+			strings.Join(assignees, ","),
+			strings.Join(labels, ","),
+			milestone,
+			formatCSVTime(issue.CreatedUnix),
+			formatCSVTime(issue.UpdatedUnix),
+			formatCSVTime(closedUnix),
+		}
+		for _, f := range fields {
+			record = append(record, values[issue.ID][f.ID])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTrackedTimesCSV writes tracked times as CSV, one record per tracked time
+func WriteTrackedTimesCSV(ctx context.Context, w io.Writer, times issues_model.TrackedTimeList) error {
+	if err := times.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"created", "user", "repository", "index", "title", "milestone", "seconds", "duration"}); err != nil {
+		return err
+	}
+	for _, t := range times {
+		var repo, index, title, milestone string
+		if t.Issue != nil {
+			if err := t.Issue.LoadMilestone(ctx); err != nil {
+				return err
+			}
+			if t.Issue.Repo != nil {
+				repo = t.Issue.Repo.FullName()
+			}
+			index, title = strconv.FormatInt(t.Issue.Index, 10), t.Issue.Title
+			if t.Issue.Milestone != nil {
+				milestone = t.Issue.Milestone.Name
+			}
+		}
+		if err := cw.Write([]string{
+			formatCSVTime(timeutil.TimeStamp(t.CreatedUnix)),
+			t.User.Name,
+			repo,
+			index,
+			title,
+			milestone,
+			strconv.FormatInt(t.Time, 10),
+			util.SecToHours(t.Time),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -316,10 +316,17 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) ([]string, erro
 			&project_model.ProjectIssue{IssueID: issue.ID},
 			&repo_model.Attachment{IssueID: issue.ID},
 			&issues_model.PullRequest{IssueID: issue.ID},
+			&issues_model.FederatedIssue{IssueID: issue.ID},
 			&issues_model.Comment{RefIssueID: issue.ID},
 			&issues_model.IssueDependency{DependencyID: issue.ID},
 			&issues_model.Comment{DependentIssueID: issue.ID},
 			&issues_model.IssuePin{IssueID: issue.ID},
+			&issues_model.IssueFieldValue{IssueID: issue.ID},
+			&issues_model.IssueSLANotice{IssueID: issue.ID},
+			&issues_model.SubIssue{IssueID: issue.ID},
+			&issues_model.SubIssue{ParentID: issue.ID},
+			&issues_model.IterationIssue{IssueID: issue.ID},
+			&issues_model.IssueEmailMessage{IssueID: issue.ID},
 		); err != nil {
 			return nil, err
 		}
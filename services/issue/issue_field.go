@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// findIssueFieldByName returns the field with the name case-insensitively, a field of the repository
+// takes precedence over a field of its organization with the same name
+func findIssueFieldByName(fields []*issues_model.IssueField, name string) *issues_model.IssueField {
+	var found *issues_model.IssueField
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, strings.TrimSpace(name)) && (found == nil || found.BelongsToOrg()) {
+			found = f
+		}
+	}
+	return found
+}
+
+// ParseIssueFieldFilters parses the filters of the form "name:value" into the normalized values by field ID
+func ParseIssueFieldFilters(ctx context.Context, repo *repo_model.Repository, filters []string) (map[int64]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[int64]string, len(filters))
+	for _, filter := range filters {
+		name, value, ok := strings.Cut(filter, ":")
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("invalid field filter %q, it must be name:value", filter)
+		}
+		f := findIssueFieldByName(fields, name)
+		if f == nil {
+			return nil, util.NewInvalidArgumentErrorf("unknown field %s", name)
+		}
+		if values[f.ID], err = f.NormalizeValue(ctx, value); err != nil {
+			return nil, err
+		}
+		if values[f.ID] == "" {
+			return nil, util.NewInvalidArgumentErrorf("the value of the field filter %s is empty", name)
+		}
+	}
+	return values, nil
+}
+
+// SetIssueFieldValues sets the values of the custom fields of an issue by field name, an empty value removes the value of a field
+func SetIssueFieldValues(ctx context.Context, issue *issues_model.Issue, values map[string]string) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	fields, err := issues_model.GetIssueFieldsOfRepo(ctx, issue.Repo)
+	if err != nil {
+		return err
+	}
+
+	normalized := make(map[int64]string, len(values))
+	for name, value := range values {
+		f := findIssueFieldByName(fields, name)
+		if f == nil {
+			return util.NewInvalidArgumentErrorf("unknown field %s", name)
+		}
+		if normalized[f.ID], err = f.NormalizeValue(ctx, value); err != nil {
+			return err
+		}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for fieldID, value := range normalized {
+			if err := issues_model.SetIssueFieldValue(ctx, issue.ID, fieldID, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// CanManageSharedIssueSavedSearches returns whether the user can save the searches shared by the organization owning the repository
+func CanManageSharedIssueSavedSearches(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return false, err
+	}
+	if !repo.Owner.IsOrganization() {
+		return false, nil
+	}
+	if doer.IsAdmin {
+		return true, nil
+	}
+	return organization.IsOrganizationOwner(ctx, repo.OwnerID, doer.ID)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/optional"
+)
+
+const (
+	// similarIssueKeywords is the number of keywords of the title searched in the issue indexer
+	similarIssueKeywords = 5
+	// similarIssueCandidates is the number of issues searched for each keyword
+	similarIssueCandidates = 20
+	// similarIssueMinScore is the score below which an issue isn't considered similar
+	similarIssueMinScore = 0.25
+)
+
+// similarIssueStopWords are the common words ignored when comparing issues
+var similarIssueStopWords = container.SetOf(
+	"about", "after", "all", "also", "and", "any", "are", "but", "can", "cannot", "could", "does", "doesn", "don", "for",
+	"from", "had", "has", "have", "how", "into", "its", "not", "now", "off", "only", "our", "out", "should", "some",
+	"than", "that", "the", "their", "then", "there", "these", "this", "too", "use", "using", "was", "were", "what",
+	"when", "where", "which", "while", "why", "will", "with", "won", "would", "you", "your",
+)
+
+// SimilarIssue is an issue similar to an issue being created with its similarity score between 0 and 1
+type SimilarIssue struct {
+	Issue *issues_model.Issue
+	Score float64
+}
+
+// similarityWords returns the distinct lowercase words of a text, without stop words and words shorter than 3 characters
+func similarityWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(container.Set[string], len(fields))
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if utf8.RuneCountInString(field) < 3 || similarIssueStopWords.Contains(field) || !seen.Add(field) {
+			continue
+		}
+		words = append(words, field)
+	}
+	return words
+}
+
+// jaccardSimilarity returns the size of the intersection of two sets of words divided by the size of their union
+func jaccardSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := container.SetOf(a...)
+	common := 0
+	for _, word := range b {
+		if set.Contains(word) {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// issueSimilarityScore returns how similar an issue is to a title and a content,
+// the title weights more than the content which is only compared when given.
+func issueSimilarityScore(titleWords, contentWords []string, issue *issues_model.Issue) float64 {
+	score := jaccardSimilarity(titleWords, similarityWords(issue.Title))
+	if len(contentWords) == 0 {
+		return score
+	}
+	return 0.7*score + 0.3*jaccardSimilarity(contentWords, similarityWords(issue.Content))
+}
+
+// FindSimilarIssues returns the open issues of a repository similar to a title and a content, the most similar first.
+// The candidates are the issues the issue indexer finds for the most significant words of the title,
+// the issue excludeID is never returned so an existing issue can be compared to the others.
+func FindSimilarIssues(ctx context.Context, repo *repo_model.Repository, title, content string, excludeID int64, limit int) ([]*SimilarIssue, error) {
+	titleWords := similarityWords(title)
+	if len(titleWords) == 0 {
+		return nil, nil
+	}
+
+	// longer words are usually more specific, search for them first
+	keywords := slices.Clone(titleWords)
+	slices.SortStableFunc(keywords, func(a, b string) int {
+		return cmp.Compare(utf8.RuneCountInString(b), utf8.RuneCountInString(a))
+	})
+	if len(keywords) > similarIssueKeywords {
+		keywords = keywords[:similarIssueKeywords]
+	}
+
+	candidateIDs := make(container.Set[int64])
+	for _, keyword := range keywords {
+		ids, _, err := issue_indexer.SearchIssues(ctx, &issue_indexer.SearchOptions{
+			Keyword:   keyword,
+			RepoIDs:   []int64{repo.ID},
+			IsPull:    optional.Some(false),
+			IsClosed:  optional.Some(false),
+			Paginator: &db.ListOptions{PageSize: similarIssueCandidates},
+			SortBy:    issue_indexer.SortByUpdatedDesc,
+		})
+		if err != nil {
+			return nil, err
+		}
+		candidateIDs.AddMultiple(ids...)
+	}
+	candidateIDs.Remove(excludeID)
+
+	issues, err := issues_model.GetIssuesByIDs(ctx, candidateIDs.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	contentWords := similarityWords(content)
+	similar := make([]*SimilarIssue, 0, len(issues))
+	for _, issue := range issues {
+		// the indexer may be out of date
+		if issue.IsClosed || issue.IsPull || issue.RepoID != repo.ID {
+			continue
+		}
+		if score := issueSimilarityScore(titleWords, contentWords, issue); score >= similarIssueMinScore {
+			issue.Repo = repo
+			similar = append(similar, &SimilarIssue{Issue: issue, Score: score})
+		}
+	}
+	slices.SortFunc(similar, func(a, b *SimilarIssue) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.Issue.UpdatedUnix, a.Issue.UpdatedUnix))
+	})
+	if limit > 0 && len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
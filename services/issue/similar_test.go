@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarityWords(t *testing.T) {
+	assert.Equal(t, []string{"login", "page", "crashes", "firefox"}, similarityWords("The login page crashes with Firefox, the LOGIN page!"))
+	assert.Equal(t, []string{"404", "émoji"}, similarityWords("a 404 on Émoji"))
+	assert.Empty(t, similarityWords("it is on"))
+}
+
+func TestIssueSimilarityScore(t *testing.T) {
+	issue := &issues_model.Issue{Title: "Login page crashes", Content: "The login page crashes when the password is empty"}
+
+	assert.InDelta(t, 1, issueSimilarityScore(similarityWords("login page crashes"), nil, issue), 0.001)
+	assert.InDelta(t, 0.5, issueSimilarityScore(similarityWords("Crash on the login page"), nil, issue), 0.001)
+	assert.Zero(t, issueSimilarityScore(similarityWords("Add dark theme"), nil, issue))
+	// 0.7 * 1 + 0.3 * 2/6
+	assert.InDelta(t, 0.8, issueSimilarityScore(similarityWords("Login page crashes"), similarityWords("page crashes on Safari"), issue), 0.001)
+}
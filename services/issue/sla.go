@@ -0,0 +1,199 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// ValidateIssueSLARuleLabels checks that the labels of an SLA rule are labels of the repository or of its organization
+func ValidateIssueSLARuleLabels(ctx context.Context, repo *repo_model.Repository, rule *issues_model.IssueSLARule) error {
+	labelIDs := append(slices.Clone(rule.LabelIDs), rule.ExemptLabelIDs...)
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	labels, err := issues_model.GetLabelsByIDs(ctx, labelIDs, "id", "repo_id", "org_id")
+	if err != nil {
+		return err
+	}
+	found := make(container.Set[int64], len(labels))
+	for _, label := range labels {
+		if (label.BelongsToRepo() && label.RepoID == repo.ID) || (label.BelongsToOrg() && label.OrgID == repo.OwnerID) {
+			found.Add(label.ID)
+		}
+	}
+	for _, id := range labelIDs {
+		if !found.Contains(id) {
+			return util.NewInvalidArgumentErrorf("label %d is not a label of the repository", id)
+		}
+	}
+	return nil
+}
+
+// GetIssueSLAStatuses returns the states of the targets of the SLA rules matching an issue
+func GetIssueSLAStatuses(ctx context.Context, issue *issues_model.Issue) ([]*issues_model.IssueSLAStatus, error) {
+	if issue.IsPull {
+		return nil, nil
+	}
+	rules, err := issues_model.GetIssueSLARules(ctx, issue.RepoID)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	if err := issue.LoadLabels(ctx); err != nil {
+		return nil, err
+	}
+	labelIDs := make([]int64, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	var responded, resolved timeutil.TimeStamp
+	responded, err = issues_model.GetIssueFirstResponseTime(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+	if issue.IsClosed {
+		resolved = issue.ClosedUnix
+	}
+
+	now := time.Now()
+	statuses := make([]*issues_model.IssueSLAStatus, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Matches(labelIDs) {
+			continue
+		}
+		if status := rule.Status(issues_model.IssueSLAKindResponse, issue.CreatedUnix, responded, now); status != nil {
+			statuses = append(statuses, status)
+		}
+		if status := rule.Status(issues_model.IssueSLAKindResolution, issue.CreatedUnix, resolved, now); status != nil {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// CheckIssueSLAs sends the reminders and the breaches of the SLA rules of the open issues,
+// and nudges the issues which have had no activity for the stale days of their rules.
+func CheckIssueSLAs(ctx context.Context, remindBefore time.Duration) error {
+	repoIDs, err := issues_model.GetRepoIDsWithIssueSLARules(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, repoID := range repoIDs {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("before checking the SLA rules of repository %d", repoID)
+		default:
+		}
+
+		repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+		if err != nil {
+			return err
+		}
+		if repo.IsArchived || !repo.UnitEnabled(ctx, unit.TypeIssues) {
+			continue
+		}
+		rules, err := issues_model.GetIssueSLARules(ctx, repoID)
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			if err := checkIssueSLARule(ctx, repo, rule, remindBefore); err != nil {
+				log.Error("Unable to check the SLA rule %d of repository %d: %v", rule.ID, repoID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkIssueSLARule(ctx context.Context, repo *repo_model.Repository, rule *issues_model.IssueSLARule, remindBefore time.Duration) error {
+	const pageSize = 50
+	for page := 1; ; page++ {
+		issues, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
+			Paginator: &db.ListOptions{Page: page, PageSize: pageSize},
+			RepoIDs:   []int64{repo.ID},
+			LabelIDs:  rule.SearchLabelIDs(),
+			IsClosed:  optional.Some(false),
+			IsPull:    optional.Some(false),
+			SortType:  "oldest",
+		})
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			issue.Repo = repo
+			if err := checkIssueSLA(ctx, issue, rule, remindBefore); err != nil {
+				return err
+			}
+		}
+		if len(issues) < pageSize {
+			return nil
+		}
+	}
+}
+
+func checkIssueSLA(ctx context.Context, issue *issues_model.Issue, rule *issues_model.IssueSLARule, remindBefore time.Duration) error {
+	now := time.Now()
+
+	if rule.ResponseHours > 0 {
+		responded, err := issues_model.GetIssueFirstResponseTime(ctx, issue)
+		if err != nil {
+			return err
+		}
+		if err := notifyIssueSLAStatus(ctx, issue, rule.Status(issues_model.IssueSLAKindResponse, issue.CreatedUnix, responded, now), remindBefore, now); err != nil {
+			return err
+		}
+	}
+	if rule.ResolutionHours > 0 {
+		if err := notifyIssueSLAStatus(ctx, issue, rule.Status(issues_model.IssueSLAKindResolution, issue.CreatedUnix, 0, now), remindBefore, now); err != nil {
+			return err
+		}
+	}
+
+	if rule.StaleDays > 0 && issue.UpdatedUnix.AsTime().Before(now.AddDate(0, 0, -rule.StaleDays)) {
+		content := fmt.Sprintf("This issue has had no activity for %d days. Please add a comment if it is still relevant.", rule.StaleDays)
+		// the comment updates the issue, so it's nudged again after the stale days without activity
+		if _, err := CreateIssueComment(ctx, user_model.NewActionsUser(), issue.Repo, issue, content, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyIssueSLAStatus sends the reminder of a target of an SLA rule near its deadline or its breach, once
+func notifyIssueSLAStatus(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus, remindBefore time.Duration, now time.Time) error {
+	switch {
+	case status.Completed > 0:
+		return nil
+	case status.State == issues_model.IssueSLAStateBreached:
+		added, err := issues_model.AddIssueSLANotice(ctx, issue.ID, status.Rule.ID, status.Kind, true)
+		if err == nil && added {
+			notify_service.IssueSLABreached(ctx, issue, status)
+		}
+		return err
+	case remindBefore > 0 && status.Due.AsTime().Sub(now) <= remindBefore:
+		added, err := issues_model.AddIssueSLANotice(ctx, issue.ID, status.Rule.ID, status.Kind, false)
+		if err == nil && added {
+			notify_service.IssueSLAReminder(ctx, issue, status)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// AddSubIssue makes an issue a sub-issue of a parent issue of a repository of the same owner
+func AddSubIssue(ctx context.Context, parent, issue *issues_model.Issue) error {
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if parent.Repo.OwnerID != issue.Repo.OwnerID {
+		return util.NewInvalidArgumentErrorf("sub-issues must belong to the repositories of the owner of the parent issue")
+	}
+	return issues_model.AddSubIssue(ctx, parent, issue)
+}
+
+// GetParentIssue returns the parent of an issue, nil if it has none or the doer can't read it
+func GetParentIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (*issues_model.Issue, error) {
+	parent, err := issues_model.GetParentIssue(ctx, issue)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, parent.Repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanReadIssuesOrPulls(false) {
+		return nil, nil
+	}
+	return parent, nil
+}
+
+// GetSubIssueTree returns the sub-issues of an issue at all depths,
+// without the sub-issues the doer can't read and their own sub-issues.
+func GetSubIssueTree(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (issues_model.SubIssueNodes, error) {
+	nodes, err := issues_model.GetSubIssueTree(ctx, issue)
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+
+	canRead := make(map[int64]bool)
+	var filter func(nodes issues_model.SubIssueNodes) (issues_model.SubIssueNodes, error)
+	filter = func(nodes issues_model.SubIssueNodes) (issues_model.SubIssueNodes, error) {
+		readable := make(issues_model.SubIssueNodes, 0, len(nodes))
+		for _, node := range nodes {
+			if err := node.Issue.LoadRepo(ctx); err != nil {
+				return nil, err
+			}
+			can, ok := canRead[node.Issue.RepoID]
+			if !ok {
+				perm, err := access_model.GetUserRepoPermission(ctx, node.Issue.Repo, doer)
+				if err != nil {
+					return nil, err
+				}
+				can = perm.CanReadIssuesOrPulls(false)
+				canRead[node.Issue.RepoID] = can
+			}
+			if !can {
+				continue
+			}
+			if node.SubIssues, err = filter(node.SubIssues); err != nil {
+				return nil, err
+			}
+			readable = append(readable, node)
+		}
+		return readable, nil
+	}
+	return filter(nodes)
+}
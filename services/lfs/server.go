@@ -24,6 +24,7 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	perm_model "code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
@@ -194,6 +195,18 @@ func BatchHandler(ctx *context.Context) {
 
 	var responseObjects []*lfs_module.ObjectResponse
 
+	// the size of the new objects in this batch must fit into the remaining storage quota of the owner
+	var uploadSize int64
+	quotaRemaining := int64(-1)
+	if isUpload {
+		var err error
+		if quotaRemaining, err = quota_model.GetRemainingSize(ctx, repository.OwnerID, quota_model.CategoryLFS); err != nil {
+			log.Error("Unable to get the remaining quota of %s/%s. Error: %v", rc.User, rc.Repo, err)
+			writeStatus(ctx, http.StatusInternalServerError)
+			return
+		}
+	}
+
 	for _, p := range br.Objects {
 		if !p.IsValid() {
 			responseObjects = append(responseObjects, buildObjectResponse(rc, p, false, false, &lfs_module.ObjectError{
@@ -235,6 +248,15 @@ func BatchHandler(ctx *context.Context) {
 				}
 			}
 
+			if !exists && err == nil && quotaRemaining >= 0 {
+				if uploadSize += p.Size; uploadSize > quotaRemaining {
+					err = &lfs_module.ObjectError{
+						Code:    http.StatusRequestEntityTooLarge,
+						Message: "Storage quota exceeded",
+					}
+				}
+			}
+
 			if exists && meta == nil {
 				accessible, err := git_model.LFSObjectAccessible(ctx, ctx.Doer, p.Oid)
 				if err != nil {
@@ -262,6 +284,8 @@ func BatchHandler(ctx *context.Context) {
 					Code:    http.StatusNotFound,
 					Message: http.StatusText(http.StatusNotFound),
 				}
+			} else if accessErr := git_model.UpdateLFSObjectAccessed(ctx, p.Oid); accessErr != nil {
+				log.Error("Unable to record the access to LFS object [%s]. Error: %v", p.Oid, accessErr)
 			}
 
 			responseObject = buildObjectResponse(rc, p, true, false, err)
@@ -308,6 +332,18 @@ func UploadHandler(ctx *context.Context) {
 		return
 	}
 
+	if !exists {
+		if err := quota_model.CheckExceeded(ctx, repository.OwnerID, quota_model.CategoryLFS, p.Size); err != nil {
+			if quota_model.IsErrQuotaExceeded(err) {
+				writeStatusMessage(ctx, http.StatusRequestEntityTooLarge, err.Error())
+			} else {
+				log.Error("Unable to check the quota of %s/%s. Error: %v", rc.User, rc.Repo, err)
+				writeStatus(ctx, http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
 	uploadOrVerify := func() error {
 		if exists {
 			accessible, err := git_model.LFSObjectAccessible(ctx, ctx.Doer, p.Oid)
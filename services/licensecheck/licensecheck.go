@@ -0,0 +1,286 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package licensecheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	org_model "code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	dependency_indexer "code.gitea.io/gitea/modules/indexer/dependency"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	repo_service "code.gitea.io/gitea/services/repository"
+	commitstatus_service "code.gitea.io/gitea/services/repository/commitstatus"
+)
+
+// StatusContext is the context of the commit statuses created by the license checks
+const StatusContext = "license-compliance"
+
+// Violation is a license denied by the license policy of the organization
+type Violation struct {
+	// Path is the license file of the code or the manifest of the dependency
+	Path string
+	// Package is the name of the dependency, it is empty for the license of the code
+	Package string
+	Version string
+	License string
+}
+
+func (v *Violation) key() string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s", v.Path, v.Package, v.Version, v.License)
+}
+
+// FindViolations returns the licenses of the code and the dependencies which are denied
+func FindViolations(denied container.Set[string], codeLicenses []string, deps []*sbom.Component) []*Violation {
+	var violations []*Violation
+	for _, license := range codeLicenses {
+		if sbom.IsLicenseDenied(license, denied) {
+			violations = append(violations, &Violation{Path: repo_service.LicenseFileName, License: license})
+		}
+	}
+	for _, dep := range deps {
+		if sbom.IsLicenseDenied(dep.License, denied) {
+			violations = append(violations, &Violation{Path: dep.Source, Package: dep.Name, Version: dep.Version, License: dep.License})
+		}
+	}
+	return violations
+}
+
+// FindRepoViolations returns the denied licenses of the default branch of the repository,
+// the licenses known from the last license detection and dependency graph of the repository are checked
+func FindRepoViolations(ctx context.Context, repo *repo_model.Repository, policy *org_model.LicensePolicy) ([]*Violation, error) {
+	licenses, err := repo_model.GetRepoLicenses(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := db.Find[repo_model.RepoDependency](ctx, repo_model.FindRepoDependenciesOptions{
+		ListOptions: db.ListOptionsAll,
+		RepoID:      repo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	components := make([]*sbom.Component, 0, len(deps))
+	for _, dep := range deps {
+		components = append(components, &sbom.Component{
+			Ecosystem: sbom.Ecosystem(dep.Ecosystem),
+			Name:      dep.Name,
+			Version:   dep.Version,
+			License:   dep.License,
+			Source:    dep.Manifest,
+		})
+	}
+	return FindViolations(policy.DeniedLicenseSet(), licenses.StringList(), components), nil
+}
+
+func findCommitViolations(repo *repo_model.Repository, commit *git.Commit, denied container.Set[string]) ([]*Violation, error) {
+	codeLicenses, err := repo_service.DetectLicenses(commit)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := dependency_indexer.ExtractDependencies(repo, commit)
+	if err != nil {
+		return nil, err
+	}
+	return FindViolations(denied, codeLicenses, deps), nil
+}
+
+// checkRequest is an item of the queue, the pull request is checked if PullID is set, otherwise the default branch of the repository
+type checkRequest struct {
+	RepoID int64
+	PullID int64
+}
+
+var checkQueue *queue.WorkerPoolQueue[checkRequest]
+
+// Init starts the queue which checks the licenses of repositories and pull requests
+func Init() error {
+	checkQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "license_check", handler)
+	if checkQueue == nil {
+		return errors.New("unable to create license_check queue")
+	}
+	go graceful.GetManager().RunWithCancel(checkQueue)
+
+	notify_service.RegisterNotifier(&licenseCheckNotifier{})
+	return nil
+}
+
+func handler(items ...checkRequest) []checkRequest {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, item := range items {
+		var err error
+		if item.PullID > 0 {
+			err = checkPullRequest(ctx, item.PullID)
+		} else {
+			err = checkRepository(ctx, item.RepoID)
+		}
+		if err != nil {
+			log.Error("License check of repository %d (pull request %d) failed: %v", item.RepoID, item.PullID, err)
+		}
+	}
+	return nil
+}
+
+// CheckRepository adds the default branch of the repository to the queue of license checks
+func CheckRepository(repo *repo_model.Repository) {
+	if err := checkQueue.Push(checkRequest{RepoID: repo.ID}); err != nil {
+		log.Error("Unable to push repository %d to the license check queue: %v", repo.ID, err)
+	}
+}
+
+// CheckPullRequest adds the pull request to the queue of license checks
+func CheckPullRequest(pr *issues_model.PullRequest) {
+	if err := checkQueue.Push(checkRequest{RepoID: pr.BaseRepoID, PullID: pr.ID}); err != nil {
+		log.Error("Unable to push pull request %d to the license check queue: %v", pr.ID, err)
+	}
+}
+
+// CheckOrgRepositories checks the default branches of all repositories of the organization, it is called when the license policy changes
+func CheckOrgRepositories(ctx context.Context, orgID int64) error {
+	repos, err := repo_model.GetOrgRepositories(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		if !repo.IsEmpty && !repo.IsArchived {
+			CheckRepository(repo)
+		}
+	}
+	return nil
+}
+
+// getPolicy returns the license policy of the owner of the repository, nil is returned if there is none
+func getPolicy(ctx context.Context, repo *repo_model.Repository) (*org_model.LicensePolicy, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if !repo.Owner.IsOrganization() {
+		return nil, nil
+	}
+	policy, err := org_model.GetLicensePolicy(ctx, repo.OwnerID)
+	if errors.Is(err, util.ErrNotExist) {
+		return nil, nil
+	}
+	return policy, err
+}
+
+func checkRepository(ctx context.Context, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+	policy, err := getPolicy(ctx, repo)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	violations, err := findCommitViolations(repo, commit, policy.DeniedLicenseSet())
+	if err != nil {
+		return err
+	}
+	return createCommitStatus(ctx, repo, commit.ID.String(), violations)
+}
+
+func checkPullRequest(ctx context.Context, pullID int64) error {
+	pr, err := issues_model.GetPullRequestByID(ctx, pullID)
+	if err != nil {
+		return err
+	}
+	if pr.HasMerged {
+		return nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	policy, err := getPolicy(ctx, pr.BaseRepo)
+	if err != nil || policy == nil {
+		return err
+	}
+	denied := policy.DeniedLicenseSet()
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+	if err != nil {
+		return err
+	}
+	headCommit, err := gitRepo.GetCommit(headCommitID)
+	if err != nil {
+		return err
+	}
+	violations, err := findCommitViolations(pr.BaseRepo, headCommit, denied)
+	if err != nil {
+		return err
+	}
+
+	// only the violations introduced by the pull request fail its check
+	baseCommit, err := gitRepo.GetBranchCommit(pr.BaseBranch)
+	if err != nil {
+		return err
+	}
+	baseViolations, err := findCommitViolations(pr.BaseRepo, baseCommit, denied)
+	if err != nil {
+		return err
+	}
+	existing := make(container.Set[string], len(baseViolations))
+	for _, v := range baseViolations {
+		existing.Add(v.key())
+	}
+	introduced := make([]*Violation, 0, len(violations))
+	for _, v := range violations {
+		if !existing.Contains(v.key()) {
+			introduced = append(introduced, v)
+		}
+	}
+	return createCommitStatus(ctx, pr.BaseRepo, headCommitID, introduced)
+}
+
+func createCommitStatus(ctx context.Context, repo *repo_model.Repository, sha string, violations []*Violation) error {
+	state, description := commitstatus.CommitStatusSuccess, "No denied licenses found"
+	if len(violations) > 0 {
+		state, description = commitstatus.CommitStatusFailure, fmt.Sprintf("%d denied licenses found", len(violations))
+	}
+
+	creator := user_model.NewActionsUser()
+	return commitstatus_service.CreateCommitStatus(ctx, repo, creator, sha, &git_model.CommitStatus{
+		SHA:         sha,
+		TargetURL:   repo.Link() + "/dependencies",
+		Description: description,
+		Context:     StatusContext,
+		CreatorID:   creator.ID,
+		State:       state,
+	})
+}
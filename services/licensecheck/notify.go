@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package licensecheck
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/repository"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type licenseCheckNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &licenseCheckNotifier{}
+
+func (n *licenseCheckNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if opts.RefFullName.IsBranch() && opts.RefFullName.BranchName() == repo.DefaultBranch && !opts.IsDelRef() {
+		CheckRepository(repo)
+	}
+}
+
+func (n *licenseCheckNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	CheckPullRequest(pr)
+}
+
+func (n *licenseCheckNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	CheckPullRequest(pr)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package incoming
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	htmlSpaceRegex     = regexp.MustCompile(`\s+`)
+	blankLinesRegex    = regexp.MustCompile(`\n{3,}`)
+	htmlTextReplacer   = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+	linkURLReplacer    = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+	allowedLinkSchemes = []string{"http://", "https://", "mailto:"}
+)
+
+// htmlToMarkdown converts the HTML body of an email to markdown. Only the text and its basic formatting are kept,
+// everything else like scripts, styles, images and the quoted messages of replies is dropped.
+func htmlToMarkdown(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return ""
+	}
+	c := &htmlConverter{}
+	md := c.children(doc)
+
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+type htmlConverter struct {
+	listDepth int
+	// stopped is set at the start of the quoted message in an Outlook reply, which has no container element
+	stopped bool
+}
+
+func (c *htmlConverter) children(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil && !c.stopped; child = child.NextSibling {
+		sb.WriteString(c.node(child))
+	}
+	return sb.String()
+}
+
+func (c *htmlConverter) node(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return htmlTextReplacer.Replace(htmlSpaceRegex.ReplaceAllString(n.Data, " "))
+	case html.DocumentNode:
+		return c.children(n)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	id, class := getAttr(n, "id"), getAttr(n, "class")
+	if id == "divRplyFwdMsg" || id == "appendonsend" {
+		c.stopped = true
+		return ""
+	}
+	if (n.DataAtom == atom.Blockquote && getAttr(n, "type") == "cite") ||
+		strings.Contains(class, "gmail_quote") || strings.Contains(class, "moz-cite-prefix") || strings.Contains(class, "yahoo_quoted") {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head, atom.Title, atom.Template, atom.Noscript, atom.Iframe, atom.Object, atom.Img:
+		return ""
+	case atom.Br:
+		return "\n"
+	case atom.Hr:
+		return "\n\n---\n\n"
+	case atom.P, atom.Table:
+		return "\n\n" + strings.TrimSpace(c.children(n)) + "\n\n"
+	case atom.Div, atom.Tr:
+		return "\n" + strings.TrimSpace(c.children(n)) + "\n"
+	case atom.Td, atom.Th:
+		return strings.TrimSpace(c.children(n)) + " "
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return "\n\n" + strings.Repeat("#", level) + " " + strings.TrimSpace(c.children(n)) + "\n\n"
+	case atom.B, atom.Strong:
+		return wrapInline(c.children(n), "**")
+	case atom.I, atom.Em:
+		return wrapInline(c.children(n), "_")
+	case atom.S, atom.Strike, atom.Del:
+		return wrapInline(c.children(n), "~~")
+	case atom.Code:
+		return wrapInline(textContent(n), "`")
+	case atom.Pre:
+		return "\n\n```\n" + strings.Trim(textContent(n), "\n") + "\n```\n\n"
+	case atom.A:
+		text := strings.TrimSpace(c.children(n))
+		href := getAttr(n, "href")
+		if text == "" || text == href || !hasAllowedLinkScheme(href) {
+			return text
+		}
+		return fmt.Sprintf("[%s](%s)", text, linkURLReplacer.Replace(href))
+	case atom.Blockquote:
+		lines := strings.Split(strings.TrimSpace(c.children(n)), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return "\n\n" + strings.Join(lines, "\n") + "\n\n"
+	case atom.Ul, atom.Ol:
+		return c.list(n)
+	}
+	return c.children(n)
+}
+
+func (c *htmlConverter) list(n *html.Node) string {
+	c.listDepth++
+	defer func() { c.listDepth-- }()
+
+	// the items of a nested list are indented by the item containing it
+	var sb strings.Builder
+	index := 0
+	for child := n.FirstChild; child != nil && !c.stopped; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		index++
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", index)
+		}
+		item := strings.TrimSpace(blankLinesRegex.ReplaceAllString(c.children(child), "\n"))
+		sb.WriteString("\n" + marker + strings.ReplaceAll(item, "\n", "\n"+strings.Repeat(" ", len(marker))))
+	}
+	if c.listDepth > 1 {
+		return sb.String()
+	}
+	return "\n" + sb.String() + "\n\n"
+}
+
+func wrapInline(s, marker string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	// keep the surrounding spaces outside the markers, markdown doesn't allow them inside
+	start := strings.Index(s, trimmed)
+	return s[:start] + marker + trimmed + marker + s[start+len(trimmed):]
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom == atom.Br {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasAllowedLinkScheme(href string) bool {
+	lower := strings.ToLower(href)
+	for _, scheme := range allowedLinkSchemes {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package incoming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	cases := []struct {
+		HTML     string
+		Expected string
+	}{
+		{
+			HTML:     "<p>first</p><p>second<br>line</p>",
+			Expected: "first\n\nsecond\nline",
+		},
+		{
+			HTML:     "<div><b>bold</b> <i>italic</i> <s>strike</s> <code>a &lt; b</code></div>",
+			Expected: "**bold** _italic_ ~~strike~~ `a < b`",
+		},
+		{
+			HTML:     "<h2>Title</h2><pre>line 1\nline 2</pre>",
+			Expected: "## Title\n\n```\nline 1\nline 2\n```",
+		},
+		{
+			HTML:     `<a href="https://example.com/a b">link</a> <a href="javascript:alert(1)">bad</a> <a href="https://example.com">https://example.com</a>`,
+			Expected: "[link](https://example.com/a%20b) bad https://example.com",
+		},
+		{
+			HTML:     "<ul><li>one</li><li>two<ol><li>nested</li></ol></li></ul>",
+			Expected: "- one\n- two\n  1. nested",
+		},
+		{
+			HTML:     "<blockquote>quoted<br>text</blockquote>",
+			Expected: "> quoted\n> text",
+		},
+		{
+			HTML:     "<head><style>p {}</style><title>t</title></head><script>alert(1)</script><img src=x><p>&lt;script&gt; text</p>",
+			Expected: "&lt;script&gt; text",
+		},
+		{
+			HTML:     `<div>reply</div><div class="gmail_quote">On Monday someone wrote:<blockquote>old</blockquote></div>`,
+			Expected: "reply",
+		},
+		{
+			HTML:     `<p>reply</p><blockquote type="cite">old</blockquote>`,
+			Expected: "reply",
+		},
+		{
+			HTML:     `<div>reply</div><hr><div id="divRplyFwdMsg">From: someone</div><div>old</div>`,
+			Expected: "reply\n\n---",
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, htmlToMarkdown(c.HTML), c.HTML)
+	}
+}
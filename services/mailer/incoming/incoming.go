@@ -26,6 +26,7 @@ import (
 var (
 	addressTokenRegex   *regexp.Regexp
 	referenceTokenRegex *regexp.Regexp
+	issueMessageIDRegex *regexp.Regexp
 )
 
 func Init(ctx context.Context) error {
@@ -47,6 +48,10 @@ func Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	issueMessageIDRegex, err = regexp.Compile(fmt.Sprintf(`\A(.+)/(?:issues|pulls)/(\d+)(?:/.*)?@%s\z`, regexp.QuoteMeta(setting.Domain)))
+	if err != nil {
+		return err
+	}
 
 	go func() {
 		ctx, _, finished := process.GetManager().AddTypedContext(ctx, "Incoming Email", process.SystemProcessType, true)
@@ -236,7 +241,15 @@ loop:
 
 				t := searchTokenInHeaders(env)
 				if t == "" {
-					log.Debug("Incoming email token not found in headers")
+					alias := searchIssueAliasInHeaders(env)
+					if alias == "" {
+						log.Debug("Incoming email token not found in headers")
+						return nil
+					}
+					if err := handleIssueEmail(ctx, env, alias); err != nil {
+						return fmt.Errorf("could not handle issue email: %w", err)
+					}
+					handledSet.AddNum(msg.SeqNum)
 					return nil
 				}
 
@@ -291,7 +304,7 @@ func isAutomaticReply(env *enmime.Envelope) bool {
 	return autoRespond != ""
 }
 
-// searchTokenInHeaders looks for the token in To, Delivered-To and References
+// searchTokenInHeaders looks for the token in To, Delivered-To, In-Reply-To and References
 func searchTokenInHeaders(env *enmime.Envelope) string {
 	if addressTokenRegex != nil {
 		to, _ := env.AddressList("To")
@@ -309,25 +322,12 @@ func searchTokenInHeaders(env *enmime.Envelope) string {
 		}
 	}
 
-	references := env.GetHeader("References")
-	for {
-		begin := strings.IndexByte(references, '<')
-		if begin == -1 {
-			break
-		}
-		begin++
-
-		end := strings.IndexByte(references, '>')
-		if end == -1 || begin > end {
-			break
-		}
-
-		match := referenceTokenRegex.FindStringSubmatch(references[begin:end])
+	// some mail clients only keep the message replied to and drop the References
+	for _, id := range getReferencedMessageIDs(env) {
+		match := referenceTokenRegex.FindStringSubmatch(id)
 		if len(match) == 2 {
 			return match[1]
 		}
-
-		references = references[end+1:]
 	}
 
 	return ""
@@ -337,7 +337,7 @@ func searchTokenInHeaders(env *enmime.Envelope) string {
 func searchTokenInAddresses(addresses []*net_mail.Address) string {
 	for _, address := range addresses {
 		match := addressTokenRegex.FindStringSubmatch(address.Address)
-		if len(match) != 2 {
+		if len(match) != 2 || strings.HasPrefix(match[1], issueAliasPrefix) {
 			continue
 		}
 
@@ -347,6 +347,55 @@ func searchTokenInAddresses(addresses []*net_mail.Address) string {
 	return ""
 }
 
+// searchIssueAliasInHeaders looks for the alias of a repository in To, Cc and Delivered-To
+func searchIssueAliasInHeaders(env *enmime.Envelope) string {
+	if addressTokenRegex == nil {
+		return ""
+	}
+	for _, header := range []string{"To", "Cc", "Delivered-To"} {
+		addresses, _ := env.AddressList(header)
+		for _, address := range addresses {
+			match := addressTokenRegex.FindStringSubmatch(address.Address)
+			if len(match) == 2 && strings.HasPrefix(match[1], issueAliasPrefix) {
+				return strings.ToLower(strings.TrimPrefix(match[1], issueAliasPrefix))
+			}
+		}
+	}
+	return ""
+}
+
+// getReferencedMessageIDs returns the Message-IDs in In-Reply-To and References, the message replied to first
+func getReferencedMessageIDs(env *enmime.Envelope) []string {
+	ids := parseMessageIDs(env.GetHeader("In-Reply-To"))
+	references := parseMessageIDs(env.GetHeader("References"))
+	// References lists the oldest message first
+	for i := len(references) - 1; i >= 0; i-- {
+		ids = append(ids, references[i])
+	}
+	return ids
+}
+
+// parseMessageIDs returns the Message-IDs in a header without their angle brackets
+func parseMessageIDs(header string) []string {
+	var ids []string
+	for {
+		begin := strings.IndexByte(header, '<')
+		if begin == -1 {
+			break
+		}
+		begin++
+
+		end := strings.IndexByte(header, '>')
+		if end == -1 || begin > end {
+			break
+		}
+
+		ids = append(ids, header[begin:end])
+		header = header[end+1:]
+	}
+	return ids
+}
+
 type MailContent struct {
 	Content     string
 	Attachments []*Attachment
@@ -357,19 +406,33 @@ type Attachment struct {
 	Content []byte
 }
 
-// getContentFromMailReader grabs the plain content and the attachments from the mail.
+// getContentFromMailReader grabs the content and the attachments from the mail, the HTML content is converted to markdown.
 // A potential reply/signature gets stripped from the content.
 func getContentFromMailReader(env *enmime.Envelope) *MailContent {
-	attachments := make([]*Attachment, 0, len(env.Attachments))
+	attachments := make([]*Attachment, 0, len(env.Attachments)+len(env.Inlines))
 	for _, attachment := range env.Attachments {
 		attachments = append(attachments, &Attachment{
 			Name:    attachment.FileName,
 			Content: attachment.Content,
 		})
 	}
+	// the images embedded in HTML mails
+	for _, inline := range env.Inlines {
+		if inline.FileName != "" {
+			attachments = append(attachments, &Attachment{
+				Name:    inline.FileName,
+				Content: inline.Content,
+			})
+		}
+	}
+
+	text := env.Text
+	if env.HTML != "" {
+		text = htmlToMarkdown(env.HTML)
+	}
 
 	return &MailContent{
-		Content:     reply.FromText(env.Text),
+		Content:     reply.FromText(text),
 		Attachments: attachments,
 	}
 }
@@ -45,6 +45,11 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 		return err
 	}
 
+	return handleReply(ctx, content, doer, ref)
+}
+
+// handleReply creates a comment from an email replying to an issue or a comment
+func handleReply(ctx context.Context, content *MailContent, doer *user_model.User, ref any) error {
 	var issue *issues_model.Issue
 
 	switch r := ref.(type) {
@@ -82,23 +87,9 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 		return nil
 	}
 
-	attachmentIDs := make([]string, 0, len(content.Attachments))
-	if setting.Attachment.Enabled {
-		for _, attachment := range content.Attachments {
-			a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
-				Name:       attachment.Name,
-				UploaderID: doer.ID,
-				RepoID:     issue.Repo.ID,
-			})
-			if err != nil {
-				if upload.IsErrFileTypeForbidden(err) {
-					log.Info("Skipping disallowed attachment type: %s", attachment.Name)
-					continue
-				}
-				return err
-			}
-			attachmentIDs = append(attachmentIDs, a.UUID)
-		}
+	attachmentIDs, err := uploadAttachments(ctx, doer, issue.Repo, content.Attachments)
+	if err != nil {
+		return err
 	}
 
 	if content.Content == "" && len(attachmentIDs) == 0 {
@@ -142,6 +133,31 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 	return nil
 }
 
+// uploadAttachments stores the attachments of an email in a repository and returns their UUIDs,
+// the attachments of disallowed types are skipped
+func uploadAttachments(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, attachments []*Attachment) ([]string, error) {
+	if !setting.Attachment.Enabled {
+		return nil, nil
+	}
+	attachmentIDs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
+			Name:       attachment.Name,
+			UploaderID: doer.ID,
+			RepoID:     repo.ID,
+		})
+		if err != nil {
+			if upload.IsErrFileTypeForbidden(err) {
+				log.Info("Skipping disallowed attachment type: %s", attachment.Name)
+				continue
+			}
+			return nil, err
+		}
+		attachmentIDs = append(attachmentIDs, a.UUID)
+	}
+	return attachmentIDs, nil
+}
+
 // UnsubscribeHandler handles unwatching issues/pulls
 type UnsubscribeHandler struct{}
 
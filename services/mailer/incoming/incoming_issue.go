@@ -0,0 +1,236 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package incoming
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	issue_service "code.gitea.io/gitea/services/issue"
+
+	"github.com/jhillyerd/enmime"
+)
+
+// issueAliasPrefix replaces the token placeholder together with the alias of a repository in the address
+// new issues are created at, the dash can't appear in a token
+const issueAliasPrefix = "issue-"
+
+// subjectIssueRegex matches the subject of the emails sent for an issue, like "Re: [owner/repo] Title (#1)"
+var subjectIssueRegex = regexp.MustCompile(`\[([^\[\]\s]+/[^\[\]\s]+)\].*\(#(\d+)\)\s*\z`)
+
+// IssueAddress returns the address the emails creating issues in a repository are sent to
+func IssueAddress(alias string) string {
+	return strings.Replace(setting.IncomingEmail.ReplyToAddress, setting.IncomingEmail.TokenPlaceholder, issueAliasPrefix+alias, 1)
+}
+
+// handleIssueEmail creates an issue from an email sent to the address of a repository,
+// or a comment if the email replies to an issue of the repository
+func handleIssueEmail(ctx context.Context, env *enmime.Envelope, aliasName string) error {
+	alias, err := repo_model.GetIncomingEmailAliasByAlias(ctx, aliasName)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			log.Debug("Incoming email alias %q not found", aliasName)
+			return nil
+		}
+		return err
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, alias.RepoID)
+	if err != nil {
+		return err
+	}
+	if repo.IsArchived {
+		log.Debug("Repository %s is archived", repo.FullName())
+		return nil
+	}
+
+	doer, err := getIssueEmailSender(ctx, env)
+	if err != nil || doer == nil {
+		return err
+	}
+
+	// mail servers may deliver the same email again
+	messageIDs := parseMessageIDs(env.GetHeader("Message-ID"))
+	if len(messageIDs) > 0 {
+		issueID, err := issues_model.GetIssueIDByEmailMessageIDs(ctx, messageIDs[:1])
+		if err != nil {
+			return err
+		} else if issueID != 0 {
+			log.Debug("Incoming email %s was already handled", messageIDs[0])
+			return nil
+		}
+	}
+
+	content := getContentFromMailReader(env)
+	issue, err := findIssueOfEmail(ctx, repo, env)
+	if err != nil {
+		return err
+	}
+	if issue != nil {
+		if err := handleReply(ctx, content, doer, issue); err != nil {
+			return err
+		}
+	} else {
+		issue, err = createIssueFromEmail(ctx, repo, doer, env.GetHeader("Subject"), content)
+		if err != nil || issue == nil {
+			return err
+		}
+	}
+
+	if len(messageIDs) > 0 {
+		return issues_model.AddIssueEmailMessage(ctx, issue.ID, messageIDs[0])
+	}
+	return nil
+}
+
+// getIssueEmailSender returns the user who sent an email, nil if the sender is unknown or not trusted
+func getIssueEmailSender(ctx context.Context, env *enmime.Envelope) (*user_model.User, error) {
+	if setting.IncomingEmail.RequireSenderAuthentication && !isSenderAuthenticated(env) {
+		log.Info("Ignoring incoming email whose sender did not pass the DMARC check")
+		return nil, nil
+	}
+
+	from, err := env.AddressList("From")
+	if err != nil || len(from) == 0 {
+		log.Debug("Incoming email has no sender")
+		return nil, nil //nolint:nilerr // the email can't be handled
+	}
+	address := strings.ToLower(from[0].Address)
+	if setting.Service.NoReplyAddress != "" && strings.HasSuffix(address, "@"+strings.ToLower(setting.Service.NoReplyAddress)) {
+		return nil, nil
+	}
+
+	doer, err := user_model.GetUserByEmail(ctx, address)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			log.Info("Ignoring incoming email from unknown address %s", address)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !doer.IsActive || doer.ProhibitLogin || !doer.IsIndividual() {
+		return nil, nil
+	}
+	return doer, nil
+}
+
+// isSenderAuthenticated checks whether the mail server reported in Authentication-Results that the sender passed DMARC.
+// The sender can add the header to their own email, so only the topmost header carrying the trusted authserv-id of the
+// receiving mail server is evaluated.
+func isSenderAuthenticated(env *enmime.Envelope) bool {
+	if setting.IncomingEmail.AuthservID == "" {
+		return false
+	}
+	for _, result := range env.GetHeaderValues("Authentication-Results") {
+		fields := strings.Split(strings.ToLower(result), ";")
+		// the authserv-id may be followed by a version
+		authservID := strings.Fields(fields[0])
+		if len(authservID) == 0 || authservID[0] != strings.ToLower(setting.IncomingEmail.AuthservID) {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if method := strings.Fields(field); len(method) > 0 && method[0] == "dmarc=pass" {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// findIssueOfEmail returns the issue of a repository an email replies to, nil if it doesn't reply to any.
+// The issue is found by the emails it was created or commented by, the emails sent for it,
+// and at last by its subject as some mail clients don't keep the references of the message replied to.
+func findIssueOfEmail(ctx context.Context, repo *repo_model.Repository, env *enmime.Envelope) (*issues_model.Issue, error) {
+	references := getReferencedMessageIDs(env)
+	issueID, err := issues_model.GetIssueIDByEmailMessageIDs(ctx, references)
+	if err != nil {
+		return nil, err
+	}
+	if issueID != 0 {
+		issue, err := issues_model.GetIssueByID(ctx, issueID)
+		if err == nil && issue.RepoID == repo.ID {
+			return issue, nil
+		} else if err != nil && !issues_model.IsErrIssueNotExist(err) {
+			return nil, err
+		}
+	}
+
+	for _, id := range references {
+		if match := issueMessageIDRegex.FindStringSubmatch(id); match != nil && strings.EqualFold(match[1], repo.FullName()) {
+			return getIssueByIndex(ctx, repo, match[2])
+		}
+	}
+
+	if match := subjectIssueRegex.FindStringSubmatch(env.GetHeader("Subject")); match != nil && strings.EqualFold(match[1], repo.FullName()) {
+		return getIssueByIndex(ctx, repo, match[2])
+	}
+	return nil, nil
+}
+
+func getIssueByIndex(ctx context.Context, repo *repo_model.Repository, indexStr string) (*issues_model.Issue, error) {
+	index, err := strconv.ParseInt(indexStr, 10, 64)
+	if err != nil {
+		return nil, nil //nolint:nilerr // not an issue index
+	}
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if issues_model.IsErrIssueNotExist(err) {
+		return nil, nil
+	}
+	return issue, err
+}
+
+// createIssueFromEmail creates an issue with the subject and the content of an email, nil if the sender can't create issues
+func createIssueFromEmail(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, subject string, content *MailContent) (*issues_model.Issue, error) {
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return nil, err
+	}
+	if !perm.CanRead(unit.TypeIssues) {
+		log.Debug("%s can't create issues in %s", doer.Name, repo.FullName())
+		return nil, nil
+	}
+
+	title := strings.TrimSpace(subject)
+	if title == "" {
+		title, _, _ = strings.Cut(content.Content, "\n")
+		title = strings.TrimSpace(title)
+	}
+	if title == "" {
+		log.Debug("Ignoring incoming email without subject nor content")
+		return nil, nil
+	}
+
+	attachmentIDs, err := uploadAttachments(ctx, doer, repo, content.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    util.EllipsisDisplayString(title, 255),
+		PosterID: doer.ID,
+		Poster:   doer,
+		Content:  content.Content,
+	}
+	if err := issue_service.NewIssue(ctx, repo, issue, nil, attachmentIDs, nil, 0); err != nil {
+		if errors.Is(err, user_model.ErrBlockedUser) {
+			log.Debug("%s is blocked from creating issues in %s", doer.Name, repo.FullName())
+			return nil, nil
+		}
+		return nil, err
+	}
+	return issue, nil
+}
@@ -4,9 +4,13 @@
 package incoming
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
 	"github.com/jhillyerd/enmime"
 	"github.com/stretchr/testify/assert"
 )
@@ -136,3 +140,116 @@ func TestGetContentFromMailReader(t *testing.T) {
 	assert.Equal(t, "mail content without signature", content.Content)
 	assert.Empty(t, content.Attachments)
 }
+
+func TestSearchIssueAliasInHeaders(t *testing.T) {
+	defer func(r *regexp.Regexp) { addressTokenRegex = r }(addressTokenRegex)
+	addressTokenRegex = regexp.MustCompile(`\Aincoming\+(.+)@example\.com\z`)
+
+	cases := []struct {
+		Headers  string
+		Expected string
+	}{
+		{
+			Headers:  "To: incoming+token@example.com\r\n",
+			Expected: "",
+		},
+		{
+			Headers:  "To: Gitea <incoming+issue-My.Repo@example.com>\r\n",
+			Expected: "my.repo",
+		},
+		{
+			Headers:  "To: someone@example.com\r\nCc: incoming+issue-repo@example.com\r\n",
+			Expected: "repo",
+		},
+		{
+			Headers:  "To: list@example.com\r\nDelivered-To: incoming+issue-repo@example.com\r\n",
+			Expected: "repo",
+		},
+	}
+
+	for _, c := range cases {
+		env, err := enmime.ReadEnvelope(strings.NewReader("From: dummy@gitea.io\r\n" + c.Headers + "\r\ncontent\r\n"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, c.Expected, searchIssueAliasInHeaders(env), c.Headers)
+	}
+}
+
+func TestGetReferencedMessageIDs(t *testing.T) {
+	root, err := enmime.Builder().
+		From("Dummy", "dummy@gitea.io").
+		To("Dummy", "dummy@gitea.io").
+		Header("In-Reply-To", "<c@example.com>").
+		Header("References", "<a@example.com> <b@example.com>\r\n <c@example.com>").
+		Build()
+	assert.NoError(t, err)
+	env, err := enmime.EnvelopeFromPart(root)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"c@example.com", "c@example.com", "b@example.com", "a@example.com"}, getReferencedMessageIDs(env))
+	assert.Empty(t, parseMessageIDs("no message id"))
+}
+
+func TestIsSenderAuthenticated(t *testing.T) {
+	cases := []struct {
+		Results  []string
+		Expected bool
+	}{
+		{
+			Results:  nil,
+			Expected: false,
+		},
+		{
+			Results:  []string{"mx.example.com; spf=pass smtp.mailfrom=example.com; dmarc=fail header.from=example.com"},
+			Expected: false,
+		},
+		{
+			Results:  []string{"mx.example.com; spf=pass smtp.mailfrom=example.com; DMARC=pass header.from=example.com"},
+			Expected: true,
+		},
+		{
+			Results:  []string{"MX.example.com 1; dkim=fail; dmarc=pass (p=reject) header.from=example.com"},
+			Expected: true,
+		},
+		{
+			// the results of other mail servers are ignored
+			Results:  []string{"relay.example.com; dkim=fail", "mx.example.com; dmarc=pass (p=reject) header.from=example.com"},
+			Expected: true,
+		},
+		{
+			Results:  []string{"other.example.com; dmarc=pass header.from=example.com"},
+			Expected: false,
+		},
+		{
+			// the header added by the sender below the header of the receiving mail server is ignored
+			Results:  []string{"mx.example.com; dmarc=fail header.from=example.com", "mx.example.com; dmarc=pass header.from=example.com"},
+			Expected: false,
+		},
+		{
+			Results:  []string{"mx.example.com; dmarc=passed header.from=example.com"},
+			Expected: false,
+		},
+	}
+
+	newEnvelope := func(results []string) *enmime.Envelope {
+		b := enmime.Builder().
+			From("Dummy", "dummy@gitea.io").
+			To("Dummy", "dummy@gitea.io")
+		for _, v := range results {
+			b = b.Header("Authentication-Results", v)
+		}
+		root, err := b.Build()
+		assert.NoError(t, err)
+		env, err := enmime.EnvelopeFromPart(root)
+		assert.NoError(t, err)
+		return env
+	}
+
+	// no result is trusted without the authserv-id of the receiving mail server
+	assert.False(t, isSenderAuthenticated(newEnvelope([]string{"mx.example.com; dmarc=pass header.from=example.com"})))
+
+	defer test.MockVariableValue(&setting.IncomingEmail.AuthservID, "mx.example.com")()
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, isSenderAuthenticated(newEnvelope(c.Results)), c.Results)
+	}
+}
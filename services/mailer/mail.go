@@ -42,11 +42,11 @@ func LoadedTemplates() *templates.MailTemplates {
 
 // SendTestMail sends a test mail
 func SendTestMail(email string) error {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return nil
 	}
-	return sender_service.Send(sender, sender_service.NewMessage(email, "Gitea Test Email!", "Gitea Test Email!"))
+	return sender_service.Send(getSender(), sender_service.NewMessage(email, "Gitea Test Email!", "Gitea Test Email!"))
 }
 
 func sanitizeSubject(subject string) string {
@@ -132,6 +132,9 @@ func (b64embedder *mailAttachmentBase64Embedder) AttachmentSrcToBase64DataURI(ct
 	if attachment.RepoID != b64embedder.repo.ID {
 		return "", errors.New("attachment does not belong to the repository")
 	}
+	if attachment.IsQuarantined {
+		return "", errors.New("attachment has been quarantined")
+	}
 	if attachment.Size+b64embedder.estimateSize > b64embedder.maxSize {
 		return "", errors.New("total embedded images exceed max limit")
 	}
@@ -160,9 +163,9 @@ func (b64embedder *mailAttachmentBase64Embedder) AttachmentSrcToBase64DataURI(ct
 }
 
 func fromDisplayName(u *user_model.User) string {
-	if setting.MailService.FromDisplayNameFormatTemplate != nil {
+	if setting.GetMailService().FromDisplayNameFormatTemplate != nil {
 		var ctx bytes.Buffer
-		err := setting.MailService.FromDisplayNameFormatTemplate.Execute(&ctx, map[string]any{
+		err := setting.GetMailService().FromDisplayNameFormatTemplate.Execute(&ctx, map[string]any{
 			"DisplayName": u.DisplayName(),
 			"AppName":     setting.AppName,
 			"Domain":      setting.Domain,
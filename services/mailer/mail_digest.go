@@ -0,0 +1,237 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
+	sender_service "code.gitea.io/gitea/services/mailer/sender"
+)
+
+const mailNotificationDigest templates.TplName = "user/notification_digest"
+
+// emailDigestPeriod returns how long the notification emails are batched for a digest setting, 0 to send them immediately
+func emailDigestPeriod(digest string) time.Duration {
+	switch digest {
+	case user_model.SettingEmailNotificationDigestDaily:
+		return 24 * time.Hour
+	case user_model.SettingEmailNotificationDigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// addToEmailDigests adds an issue or comment notification to the digests of users instead of mailing it
+func addToEmailDigests(ctx context.Context, comment *mailComment, users []*user_model.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	commentType := issues_model.CommentTypeComment
+	reviewType := issues_model.ReviewTypeComment
+	link := comment.Issue.HTMLURL(ctx)
+	if comment.Comment != nil {
+		commentType = comment.Comment.Type
+		link += "#" + comment.Comment.HashTag()
+		if comment.Comment.Review != nil {
+			reviewType = comment.Comment.Review.Type
+		}
+	}
+	_, action, _ := actionToTemplate(comment.Issue, comment.ActionType, commentType, reviewType)
+
+	items := make([]*activities_model.EmailDigestItem, len(users))
+	for i, user := range users {
+		items[i] = &activities_model.EmailDigestItem{
+			UserID:  user.ID,
+			RepoID:  comment.Issue.RepoID,
+			IssueID: comment.Issue.ID,
+			DoerID:  comment.Doer.ID,
+			Action:  action,
+			Link:    link,
+		}
+	}
+	return activities_model.AddEmailDigestItems(ctx, items)
+}
+
+// SendEmailDigests sends the digests of the notification emails whose oldest item has waited for the period of the user,
+// the pending items of the users who stopped batching their emails are sent at once
+func SendEmailDigests(ctx context.Context) error {
+	if setting.GetMailService() == nil {
+		return nil
+	}
+
+	oldest, err := activities_model.GetEmailDigestUsers(ctx)
+	if err != nil {
+		return err
+	}
+	userIDs := make([]int64, 0, len(oldest))
+	for userID := range oldest {
+		userIDs = append(userIDs, userID)
+	}
+	digests, err := user_model.GetSettingForUsers(ctx, user_model.SettingsKeyEmailNotificationDigest, userIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if period := emailDigestPeriod(digests[userID]); time.Since(oldest[userID].AsTime()) < period {
+			continue
+		}
+		if err := sendEmailDigest(ctx, userID, digests[userID]); err != nil {
+			log.Error("Unable to send the notification digest of user %d: %v", userID, err)
+		}
+	}
+	return nil
+}
+
+type emailDigestEntry struct {
+	Issue  *issues_model.Issue
+	Action template.HTML
+	Link   string
+}
+
+type emailDigestRepo struct {
+	Repo    *repo_model.Repository
+	Entries []*emailDigestEntry
+}
+
+func sendEmailDigest(ctx context.Context, userID int64, digest string) error {
+	items, err := activities_model.GetEmailDigestItems(ctx, userID)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	maxID := items[len(items)-1].ID
+
+	user, err := user_model.GetUserByID(ctx, userID)
+	if err != nil && !user_model.IsErrUserNotExist(err) {
+		return err
+	}
+	if user == nil || !user.IsMailable() || user.EmailNotificationsPreference == user_model.EmailNotificationsDisabled {
+		return activities_model.DeleteEmailDigestItems(ctx, userID, maxID)
+	}
+
+	issueIDs := make(container.Set[int64], len(items))
+	repoIDs := make(container.Set[int64], 2)
+	doerIDs := make(container.Set[int64], len(items))
+	for _, item := range items {
+		issueIDs.Add(item.IssueID)
+		repoIDs.Add(item.RepoID)
+		doerIDs.Add(item.DoerID)
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs.Values())
+	if err != nil {
+		return err
+	}
+	issueMap := make(map[int64]*issues_model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs.Values())
+	if err != nil {
+		return err
+	}
+	doers, err := user_model.GetUsersMapByIDs(ctx, doerIDs.Values())
+	if err != nil {
+		return err
+	}
+
+	locale := translation.NewLocale(user.Language)
+	groups := make(map[int64]*emailDigestRepo, len(repos))
+	canRead := make(map[unit.Type]map[int64]bool, 2)
+	for _, item := range items {
+		issue, repo := issueMap[item.IssueID], repos[item.RepoID]
+		if issue == nil || repo == nil {
+			continue
+		}
+		// the user may have lost the access to the repository since the change
+		checkUnit := unit.TypeIssues
+		if issue.IsPull {
+			checkUnit = unit.TypePullRequests
+		}
+		if canRead[checkUnit] == nil {
+			canRead[checkUnit] = make(map[int64]bool, len(repos))
+		}
+		allowed, ok := canRead[checkUnit][repo.ID]
+		if !ok {
+			allowed = access_model.CheckRepoUnitUser(ctx, repo, user, checkUnit)
+			canRead[checkUnit][repo.ID] = allowed
+		}
+		if !allowed {
+			continue
+		}
+		group, ok := groups[repo.ID]
+		if !ok {
+			group = &emailDigestRepo{Repo: repo}
+			groups[repo.ID] = group
+		}
+		doerName := user_model.NewGhostUser().Name
+		if doer := doers[item.DoerID]; doer != nil {
+			doerName = doer.Name
+		}
+		action := "mail.digest.action." + item.Action
+		if locale.TrString(action) == action {
+			action = "mail.digest.action.default"
+		}
+		group.Entries = append(group.Entries, &emailDigestEntry{
+			Issue:  issue,
+			Action: locale.Tr(action, doerName),
+			Link:   item.Link,
+		})
+	}
+
+	if len(groups) > 0 {
+		repoGroups := make([]*emailDigestRepo, 0, len(groups))
+		for _, group := range groups {
+			repoGroups = append(repoGroups, group)
+		}
+		slices.SortFunc(repoGroups, func(a, b *emailDigestRepo) int {
+			return strings.Compare(a.Repo.FullName(), b.Repo.FullName())
+		})
+
+		subject := locale.TrString("mail.digest.subject_daily", setting.AppName)
+		if digest == user_model.SettingEmailNotificationDigestWeekly {
+			subject = locale.TrString("mail.digest.subject_weekly", setting.AppName)
+		}
+		data := map[string]any{
+			"locale":      locale,
+			"Subject":     subject,
+			"DisplayName": user.DisplayName(),
+			"Repos":       repoGroups,
+			"Link":        setting.AppURL + "notifications",
+			"SettingLink": setting.AppURL + "user/settings/notifications",
+			"Language":    locale.Language(),
+		}
+		var content bytes.Buffer
+		if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&content, string(mailNotificationDigest), data); err != nil {
+			return err
+		}
+		msg := sender_service.NewMessage(user.EmailTo(), subject, content.String())
+		msg.Info = fmt.Sprintf("UID: %d, notification digest", user.ID)
+		SendAsync(msg)
+	}
+
+	return activities_model.DeleteEmailDigestItems(ctx, userID, maxID)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"testing"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/test"
+	sender_service "code.gitea.io/gitea/services/mailer/sender"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const digestBodyTpl = `{{range .Repos}}{{.Repo.FullName}}:{{range .Entries}} #{{.Issue.Index}} {{.Link}}{{end}}{{end}}`
+
+func TestMailParticipantsCommentDigest(t *testing.T) {
+	doer, _, issue, comment := prepareMailerTest(t)
+	comment.Poster = doer
+	prepareMailTemplates("repo/issue/comment", subjectTpl, bodyTpl)
+
+	var sent []*sender_service.Message
+	defer test.MockVariableValue(&SendAsync, func(msgs ...*sender_service.Message) {
+		sent = append(sent, msgs...)
+	})()
+
+	require.NoError(t, user_model.SetUserSetting(t.Context(), 5, user_model.SettingsKeyEmailNotificationDigest, user_model.SettingEmailNotificationDigestDaily))
+
+	// the comment is batched for user5 and mailed immediately to the others
+	require.NoError(t, MailParticipantsComment(t.Context(), comment, activities_model.ActionCommentIssue, issue, nil))
+	require.Len(t, sent, 2)
+	for _, msg := range sent {
+		assert.NotEqual(t, "User Five <user5@example.com>", msg.To)
+	}
+	item := unittest.AssertExistsAndLoadBean(t, &activities_model.EmailDigestItem{UserID: 5})
+	assert.EqualValues(t, issue.ID, item.IssueID)
+	assert.Equal(t, "comment", item.Action)
+	assert.Equal(t, comment.HTMLURL(t.Context()), item.Link)
+
+	// the digest waits for a day
+	sent = nil
+	prepareMailTemplates(string(mailNotificationDigest), "", digestBodyTpl)
+	require.NoError(t, SendEmailDigests(t.Context()))
+	assert.Empty(t, sent)
+
+	_, err := db.GetEngine(t.Context()).Exec("UPDATE email_digest_item SET created_unix = ? WHERE id = ?", item.CreatedUnix.AddDuration(-25*time.Hour), item.ID)
+	require.NoError(t, err)
+	require.NoError(t, SendEmailDigests(t.Context()))
+	require.Len(t, sent, 1)
+	assert.Equal(t, "User Five <user5@example.com>", sent[0].To)
+	assert.Equal(t, "user2/repo1: #1 "+item.Link, sent[0].Body)
+	unittest.AssertNotExistsBean(t, &activities_model.EmailDigestItem{UserID: 5})
+}
+
+func TestSendEmailDigestsImmediately(t *testing.T) {
+	doer, _, issue, comment := prepareMailerTest(t)
+	prepareMailTemplates(string(mailNotificationDigest), "", digestBodyTpl)
+
+	var sent []*sender_service.Message
+	defer test.MockVariableValue(&SendAsync, func(msgs ...*sender_service.Message) {
+		sent = append(sent, msgs...)
+	})()
+
+	// the pending items of a user who stopped batching the emails are sent at once
+	require.NoError(t, addToEmailDigests(t.Context(), &mailComment{Issue: issue, Doer: doer, Comment: comment, ActionType: activities_model.ActionCommentIssue},
+		[]*user_model.User{unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})}))
+	require.NoError(t, SendEmailDigests(t.Context()))
+	require.Len(t, sent, 1)
+	assert.Equal(t, "User Five <user5@example.com>", sent[0].To)
+	unittest.AssertNotExistsBean(t, &activities_model.EmailDigestItem{UserID: 5})
+}
@@ -66,7 +66,11 @@ func mailIssueCommentToParticipants(ctx context.Context, comment *mailComment, m
 	// =========== Repo watchers ===========
 	// Make repo watchers last, since it's likely the list with the most users
 	if !(comment.Issue.IsPull && comment.Issue.PullRequest.IsWorkInProgress(ctx) && comment.ActionType != activities_model.ActionCreatePullRequest) {
-		ids, err = repo_model.GetRepoWatchersIDs(ctx, comment.Issue.RepoID)
+		event := repo_model.WatchEventIssues
+		if comment.Issue.IsPull {
+			event = repo_model.WatchEventPullRequests
+		}
+		ids, err = repo_model.GetRepoWatchersIDs(ctx, comment.Issue.RepoID, event)
 		if err != nil {
 			return fmt.Errorf("GetRepoWatchersIDs(%d): %w", comment.Issue.RepoID, err)
 		}
@@ -109,7 +113,21 @@ func mailIssueCommentBatch(ctx context.Context, comment *mailComment, users []*u
 		checkUnit = unit.TypePullRequests
 	}
 
+	// the users batching their notification emails into a digest still get the mentions immediately
+	var digestPeriods map[int64]string
+	if !fromMention {
+		ids := make([]int64, len(users))
+		for i, user := range users {
+			ids[i] = user.ID
+		}
+		var err error
+		if digestPeriods, err = user_model.GetSettingForUsers(ctx, user_model.SettingsKeyEmailNotificationDigest, ids); err != nil {
+			return err
+		}
+	}
+
 	langMap := make(map[string][]*user_model.User)
+	digestUsers := make([]*user_model.User, 0, len(digestPeriods))
 	for _, user := range users {
 		if !user.IsActive {
 			// Exclude deactivated users
@@ -133,9 +151,17 @@ func mailIssueCommentBatch(ctx context.Context, comment *mailComment, users []*u
 			continue
 		}
 
+		if digestPeriods[user.ID] != "" {
+			digestUsers = append(digestUsers, user)
+			continue
+		}
 		langMap[user.Language] = append(langMap[user.Language], user)
 	}
 
+	if err := addToEmailDigests(ctx, comment, digestUsers); err != nil {
+		return err
+	}
+
 	for lang, receivers := range langMap {
 		// because we know that the len(receivers) > 0 and we don't care about the order particularly
 		// working backwards from the last (possibly) incomplete batch. If len(receivers) can be 0 this
@@ -156,7 +182,7 @@ func mailIssueCommentBatch(ctx context.Context, comment *mailComment, users []*u
 // MailParticipants sends new issue thread created emails to repository watchers
 // and mentioned people.
 func MailParticipants(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, opType activities_model.ActionType, mentions []*user_model.User) error {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return nil
 	}
@@ -184,7 +210,7 @@ func MailParticipants(ctx context.Context, issue *issues_model.Issue, doer *user
 
 // SendIssueAssignedMail composes and sends issue assigned email
 func SendIssueAssignedMail(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, content string, comment *issues_model.Comment, recipients []*user_model.User) error {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return nil
 	}
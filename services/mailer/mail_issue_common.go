@@ -73,7 +73,7 @@ func composeIssueCommentMessages(ctx context.Context, comment *mailComment, lang
 		return nil, err
 	}
 
-	if setting.MailService.EmbedAttachmentImages {
+	if setting.GetMailService().EmbedAttachmentImages {
 		attEmbedder := newMailAttachmentBase64Embedder(comment.Doer, comment.Issue.Repo, maxEmailBodySize)
 		bodyAfterEmbedding, err := attEmbedder.Base64InlineImages(ctx, body)
 		if err != nil {
@@ -165,7 +165,7 @@ func composeIssueCommentMessages(ctx context.Context, comment *mailComment, lang
 		msg := sender_service.NewMessageFrom(
 			recipient.Email,
 			fromDisplayName(comment.Doer),
-			setting.MailService.FromEmail,
+			setting.GetMailService().FromEmail,
 			subject,
 			mailBody.String(),
 		)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 
+	git_model "code.gitea.io/gitea/models/git"
 	"code.gitea.io/gitea/models/organization"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -21,11 +22,12 @@ import (
 const (
 	mailNotifyCollaborator templates.TplName = "repo/collaborator"
 	mailRepoTransferNotify templates.TplName = "repo/transfer"
+	mailLFSLocksExpired    templates.TplName = "repo/lfs_locks_expired"
 )
 
 // SendRepoTransferNotifyMail triggers a notification e-mail when a pending repository transfer was created
 func SendRepoTransferNotifyMail(ctx context.Context, doer, newOwner *user_model.User, repo *repo_model.Repository) error {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return nil
 	}
@@ -87,7 +89,7 @@ func sendRepoTransferNotifyMailPerLang(lang string, newOwner, doer *user_model.U
 	}
 
 	for _, to := range emailTos {
-		msg := sender_service.NewMessageFrom(to.EmailTo(), fromDisplayName(doer), setting.MailService.FromEmail, subject, content.String())
+		msg := sender_service.NewMessageFrom(to.EmailTo(), fromDisplayName(doer), setting.GetMailService().FromEmail, subject, content.String())
 		msg.Info = fmt.Sprintf("UID: %d, repository pending transfer notification", newOwner.ID)
 
 		SendAsync(msg)
@@ -98,7 +100,7 @@ func sendRepoTransferNotifyMailPerLang(lang string, newOwner, doer *user_model.U
 
 // SendCollaboratorMail sends mail notification to new collaborator.
 func SendCollaboratorMail(u, doer *user_model.User, repo *repo_model.Repository) {
-	if setting.MailService == nil || !u.IsActive {
+	if setting.GetMailService() == nil || !u.IsActive {
 		return
 	}
 	locale := translation.NewLocale(u.Language)
@@ -125,3 +127,65 @@ func SendCollaboratorMail(u, doer *user_model.User, repo *repo_model.Repository)
 
 	SendAsync(msg)
 }
+
+type lfsLockExpiredEntry struct {
+	RepoName    string
+	RepoLink    string
+	Path        string
+	LockedSince string
+}
+
+// SendLFSLocksExpiredMail notifies the owners of expired LFS locks that their locks have been released
+func SendLFSLocksExpiredMail(ctx context.Context, locks git_model.LFSLockList) error {
+	if setting.GetMailService() == nil || len(locks) == 0 {
+		return nil
+	}
+	if err := locks.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	if err := locks.LoadRepos(ctx); err != nil {
+		return err
+	}
+
+	byOwner := make(map[int64]git_model.LFSLockList)
+	for _, lock := range locks {
+		if lock.Repo == nil || !lock.Owner.IsMailable() {
+			continue
+		}
+		byOwner[lock.OwnerID] = append(byOwner[lock.OwnerID], lock)
+	}
+
+	for _, ownerLocks := range byOwner {
+		owner := ownerLocks[0].Owner
+		locale := translation.NewLocale(owner.Language)
+		subject := locale.TrString("mail.repo.lfs_locks_expired.subject")
+
+		entries := make([]*lfsLockExpiredEntry, len(ownerLocks))
+		for i, lock := range ownerLocks {
+			entries[i] = &lfsLockExpiredEntry{
+				RepoName:    lock.Repo.FullName(),
+				RepoLink:    lock.Repo.HTMLURL(),
+				Path:        lock.Path,
+				LockedSince: lock.Created.Format("2006-01-02"),
+			}
+		}
+		data := map[string]any{
+			"locale":      locale,
+			"Subject":     subject,
+			"DisplayName": owner.DisplayName(),
+			"Locks":       entries,
+			"Link":        setting.AppURL,
+			"Language":    locale.Language(),
+		}
+
+		var content bytes.Buffer
+		if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&content, string(mailLFSLocksExpired), data); err != nil {
+			return err
+		}
+
+		msg := sender_service.NewMessage(owner.EmailTo(), subject, content.String())
+		msg.Info = fmt.Sprintf("UID: %d, expired LFS locks", owner.ID)
+		SendAsync(msg)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	activities_model "code.gitea.io/gitea/models/activities"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
@@ -59,7 +60,7 @@ const bodyTpl = `
 
 func prepareMailerTest(t *testing.T) (doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
-	setting.MailService = &setting.Mailer{From: "test@gitea.com"}
+	setting.SetMailService(&setting.Mailer{From: "test@gitea.com"})
 	setting.Domain = "localhost"
 	setting.AppURL = "https://try.gitea.io/"
 
@@ -73,7 +74,7 @@ func prepareMailerTest(t *testing.T) (doer *user_model.User, repo *repo_model.Re
 
 func prepareMailerBase64Test(t *testing.T) (doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, att1, att2 *repo_model.Attachment) {
 	user, repo, issue, comment := prepareMailerTest(t)
-	setting.MailService.EmbedAttachmentImages = true
+	setting.GetMailService().EmbedAttachmentImages = true
 
 	att1, err := attachment.NewAttachment(t.Context(), &repo_model.Attachment{
 		RepoID:     repo.ID,
@@ -454,8 +455,8 @@ func TestGenerateMessageIDForActionsWorkflowRunStatusEmail(t *testing.T) {
 func TestFromDisplayName(t *testing.T) {
 	tmpl, err := texttmpl.New("mailFrom").Parse("{{ .DisplayName }}")
 	assert.NoError(t, err)
-	setting.MailService = &setting.Mailer{FromDisplayNameFormatTemplate: tmpl}
-	defer func() { setting.MailService = nil }()
+	setting.SetMailService(&setting.Mailer{FromDisplayNameFormatTemplate: tmpl})
+	defer setting.SetMailService(nil)
 
 	tests := []struct {
 		userDisplayName string
@@ -485,7 +486,7 @@ func TestFromDisplayName(t *testing.T) {
 	t.Run("template with all available vars", func(t *testing.T) {
 		tmpl, err = texttmpl.New("mailFrom").Parse("{{ .DisplayName }} (by {{ .AppName }} on [{{ .Domain }}])")
 		assert.NoError(t, err)
-		setting.MailService = &setting.Mailer{FromDisplayNameFormatTemplate: tmpl}
+		setting.SetMailService(&setting.Mailer{FromDisplayNameFormatTemplate: tmpl})
 		oldAppName := setting.AppName
 		setting.AppName = "Code IT"
 		oldDomain := setting.Domain
@@ -565,3 +566,26 @@ func TestEmbedBase64Images(t *testing.T) {
 		assert.Equal(t, expected, string(resultMailBody))
 	})
 }
+
+func TestSendLFSLocksExpiredMail(t *testing.T) {
+	_, repo, _, _ := prepareMailerTest(t)
+	prepareMailTemplates(string(mailLFSLocksExpired), "", `{{range .Locks}}{{.RepoName}}:{{.Path}} {{end}}`)
+
+	var sent []*sender_service.Message
+	defer test.MockVariableValue(&SendAsync, func(msgs ...*sender_service.Message) {
+		sent = append(sent, msgs...)
+	})()
+
+	locks := git_model.LFSLockList{
+		{RepoID: repo.ID, OwnerID: 2, Path: "a.psd"},
+		{RepoID: repo.ID, OwnerID: 5, Path: "b.psd"},
+		{RepoID: repo.ID, OwnerID: 2, Path: "c.psd"},
+		// the locks of deleted users are skipped
+		{RepoID: repo.ID, OwnerID: unittest.NonexistentID, Path: "d.psd"},
+	}
+	require.NoError(t, SendLFSLocksExpiredMail(t.Context(), locks))
+	require.Len(t, sent, 2)
+	bodies := map[string]string{sent[0].To: sent[0].Body, sent[1].To: sent[1].Body}
+	assert.Equal(t, "user2/repo1:a.psd user2/repo1:c.psd ", bodies["User Two <user2@example.com>"])
+	assert.Equal(t, "user2/repo1:b.psd ", bodies["User Five <user5@example.com>"])
+}
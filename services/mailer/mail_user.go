@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 
+	moderation_model "code.gitea.io/gitea/models/moderation"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -21,6 +22,7 @@ const (
 	mailAuthActivateEmail  templates.TplName = "user/auth/activate_email"
 	mailAuthResetPassword  templates.TplName = "user/auth/reset_passwd"
 	mailAuthRegisterNotify templates.TplName = "user/auth/register_notify"
+	mailModeration         templates.TplName = "user/moderation"
 )
 
 // sendUserMail sends a mail to the user
@@ -50,7 +52,7 @@ func sendUserMail(language string, u *user_model.User, tpl templates.TplName, co
 
 // SendActivateAccountMail sends an activation mail to the user (new user registration)
 func SendActivateAccountMail(locale translation.Locale, u *user_model.User) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return
 	}
@@ -60,7 +62,7 @@ func SendActivateAccountMail(locale translation.Locale, u *user_model.User) {
 
 // SendResetPasswordMail sends a password reset mail to the user
 func SendResetPasswordMail(u *user_model.User) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return
 	}
@@ -71,7 +73,7 @@ func SendResetPasswordMail(u *user_model.User) {
 
 // SendActivateEmailMail sends confirmation email to confirm new email address
 func SendActivateEmailMail(u *user_model.User, email string) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		// No mail service configured
 		return
 	}
@@ -101,7 +103,7 @@ func SendActivateEmailMail(u *user_model.User, email string) {
 
 // SendRegisterNotifyMail triggers a notify e-mail by admin created a account.
 func SendRegisterNotifyMail(u *user_model.User) {
-	if setting.MailService == nil || !u.IsActive {
+	if setting.GetMailService() == nil || !u.IsActive {
 		// No mail service configured OR user is inactive
 		return
 	}
@@ -126,3 +128,33 @@ func SendRegisterNotifyMail(u *user_model.User) {
 
 	SendAsync(msg)
 }
+
+// SendModerationMail sends the warning or the notice of the suspension with the message of the moderators to the user
+func SendModerationMail(u *user_model.User, action moderation_model.ModerationAction, message string) {
+	if setting.GetMailService() == nil || !u.IsMailable() {
+		return
+	}
+	locale := translation.NewLocale(u.Language)
+	subject := locale.TrString("mail.moderation."+string(action)+".subject", setting.AppName)
+
+	data := map[string]any{
+		"locale":      locale,
+		"Subject":     subject,
+		"DisplayName": u.DisplayName(),
+		"Action":      action,
+		"Message":     message,
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+
+	if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&content, string(mailModeration), data); err != nil {
+		log.Error("Template: %v", err)
+		return
+	}
+
+	msg := sender_service.NewMessage(u.EmailTo(), subject, content.String())
+	msg.Info = fmt.Sprintf("UID: %d, moderation %s", u.ID, action)
+
+	SendAsync(msg)
+}
@@ -11,7 +11,9 @@ import (
 	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/log"
@@ -129,7 +131,7 @@ func composeAndSendActionsWorkflowRunStatusEmail(ctx context.Context, repo *repo
 			msg := sender_service.NewMessageFrom(
 				rec.Email,
 				displayName,
-				setting.MailService.FromEmail,
+				setting.GetMailService().FromEmail,
 				subject,
 				mailBody.String(),
 			)
@@ -150,7 +152,7 @@ func composeAndSendActionsWorkflowRunStatusEmail(ctx context.Context, repo *repo
 }
 
 func MailActionsTrigger(ctx context.Context, sender *user_model.User, repo *repo_model.Repository, run *actions_model.ActionRun) error {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		return nil
 	}
 	if !run.Status.IsDone() || run.Status.IsSkipped() {
@@ -170,6 +172,24 @@ func MailActionsTrigger(ctx context.Context, sender *user_model.User, repo *repo
 		}
 	}
 
+	if run.Status.IsFailure() {
+		watcherIDs, err := repo_model.GetRepoWatchersIDs(ctx, repo.ID, repo_model.WatchEventCIFailures)
+		if err != nil {
+			return err
+		}
+		watchers, err := user_model.GetMailableUsersByIDs(ctx, watcherIDs, false)
+		if err != nil {
+			return err
+		}
+		for _, watcher := range watchers {
+			// the sender is notified according to the preference above
+			if watcher.ID == sender.ID || !access_model.CheckRepoUnitUser(ctx, repo, watcher, unit.TypeActions) {
+				continue
+			}
+			recipients = append(recipients, watcher)
+		}
+	}
+
 	if len(recipients) > 0 {
 		log.Debug("MailActionsTrigger: Initiate email composition")
 		return composeAndSendActionsWorkflowRunStatusEmail(ctx, repo, run, sender, recipients)
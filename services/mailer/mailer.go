@@ -18,15 +18,24 @@ import (
 
 var mailQueue *queue.WorkerPoolQueue[*sender_service.Message]
 
-// sender sender for sending mail synchronously
-var sender sender_service.Sender
+// getSender returns the sender of the configured protocol, the protocol can be changed by reloading the settings
+func getSender() sender_service.Sender {
+	switch setting.GetMailService().Protocol {
+	case "sendmail":
+		return &sender_service.SendmailSender{}
+	case "dummy":
+		return &sender_service.DummySender{}
+	default:
+		return &sender_service.SMTPSender{}
+	}
+}
 
 // NewContext start mail queue service
 func NewContext(ctx context.Context) {
 	// Need to check if mailQueue is nil because in during reinstall (user had installed
 	// before but switched install lock off), this function will be called again
 	// while mail queue is already processing tasks, and produces a race condition.
-	if setting.MailService == nil || mailQueue != nil {
+	if setting.GetMailService() == nil || mailQueue != nil {
 		return
 	}
 
@@ -34,22 +43,17 @@ func NewContext(ctx context.Context) {
 		notify_service.RegisterNotifier(NewNotifier())
 	}
 
-	switch setting.MailService.Protocol {
-	case "sendmail":
-		sender = &sender_service.SendmailSender{}
-	case "dummy":
-		sender = &sender_service.DummySender{}
-	default:
-		sender = &sender_service.SMTPSender{}
-	}
-
 	templates.LoadMailTemplates(ctx, &loadedTemplates)
 
 	mailQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "mail", func(items ...*sender_service.Message) []*sender_service.Message {
+		if setting.GetMailService() == nil {
+			log.Warn("Mailer has been disabled, %d e-mails are dropped", len(items))
+			return nil
+		}
 		for _, msg := range items {
 			gomailMsg := msg.ToMessage()
 			log.Trace("New e-mail sending request %s: %s", gomailMsg.GetGenHeader("To"), msg.Info)
-			if err := sender_service.Send(sender, msg); err != nil {
+			if err := sender_service.Send(getSender(), msg); err != nil {
 				log.Error("Failed to send emails %s: %s - %v", gomailMsg.GetGenHeader("To"), msg.Info, err)
 			} else {
 				log.Trace("E-mails sent %s: %s", gomailMsg.GetGenHeader("To"), msg.Info)
@@ -67,7 +71,7 @@ func NewContext(ctx context.Context) {
 var SendAsync = sendAsync
 
 func sendAsync(msgs ...*sender_service.Message) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		log.Error("Mailer: SendAsync is being invoked but mail service hasn't been initialized")
 		return
 	}
@@ -78,3 +82,10 @@ func sendAsync(msgs ...*sender_service.Message) {
 		}
 	}()
 }
+
+// Reload applies the reloaded mailer settings, the mail queue is started if the mailer was disabled before
+func Reload(ctx context.Context) {
+	if setting.GetMailService() != nil && mailQueue == nil {
+		NewContext(ctx)
+	}
+}
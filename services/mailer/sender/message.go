@@ -44,8 +44,8 @@ func (m *Message) ToMessage() *gomail.Msg {
 		msg.SetGenHeader(gomail.Header(header), m.Headers[header]...)
 	}
 
-	if setting.MailService.SubjectPrefix != "" {
-		msg.SetGenHeader("Subject", setting.MailService.SubjectPrefix+" "+m.Subject)
+	if setting.GetMailService().SubjectPrefix != "" {
+		msg.SetGenHeader("Subject", setting.GetMailService().SubjectPrefix+" "+m.Subject)
 	} else {
 		msg.SetGenHeader("Subject", m.Subject)
 	}
@@ -53,7 +53,7 @@ func (m *Message) ToMessage() *gomail.Msg {
 	msg.SetGenHeader("X-Auto-Response-Suppress", "All")
 
 	plainBody, err := html2text.FromString(m.Body)
-	if err != nil || setting.MailService.SendAsPlainText {
+	if err != nil || setting.GetMailService().SendAsPlainText {
 		if strings.Contains(util.TruncateRunes(m.Body, 100), "<html>") {
 			log.Warn("Mail contains HTML but configured to send as plain text.")
 		}
@@ -67,7 +67,7 @@ func (m *Message) ToMessage() *gomail.Msg {
 		msg.SetGenHeader("Message-ID", m.generateAutoMessageID())
 	}
 
-	for k, v := range setting.MailService.OverrideHeader {
+	for k, v := range setting.GetMailService().OverrideHeader {
 		if len(msg.GetGenHeader(gomail.Header(k))) != 0 {
 			log.Debug("Mailer override header '%s' as per config", k)
 		}
@@ -110,5 +110,5 @@ func NewMessageFrom(to, fromDisplayName, fromAddress, subject, body string) *Mes
 
 // NewMessage creates new mail message object with default From header.
 func NewMessage(to, subject, body string) *Message {
-	return NewMessageFrom(to, setting.MailService.FromName, setting.MailService.FromEmail, subject, body)
+	return NewMessageFrom(to, setting.GetMailService().FromName, setting.GetMailService().FromEmail, subject, body)
 }
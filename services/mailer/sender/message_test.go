@@ -18,7 +18,7 @@ func TestGenerateMessageID(t *testing.T) {
 		From: "test@gitea.com",
 	}
 
-	setting.MailService = &mailService
+	setting.SetMailService(&mailService)
 	setting.Domain = "localhost"
 
 	date := time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC)
@@ -39,13 +39,13 @@ func TestGenerateMessageID(t *testing.T) {
 }
 
 func TestToMessage(t *testing.T) {
-	oldConf := setting.MailService
+	oldConf := setting.GetMailService()
 	defer func() {
-		setting.MailService = oldConf
+		setting.SetMailService(oldConf)
 	}()
-	setting.MailService = &setting.Mailer{
+	setting.SetMailService(&setting.Mailer{
 		From: "test@gitea.com",
-	}
+	})
 
 	m1 := Message{
 		Info:            "info",
@@ -77,7 +77,7 @@ func TestToMessage(t *testing.T) {
 		"X-Auto-Response-Suppress": "All",
 	}, header)
 
-	setting.MailService.OverrideHeader = map[string][]string{
+	setting.GetMailService().OverrideHeader = map[string][]string{
 		"Message-ID":     {""},               // delete message id
 		"Auto-Submitted": {"auto-generated"}, // suppress auto replay
 	}
@@ -17,7 +17,7 @@ type Sender interface {
 var Send = send
 
 func send(sender Sender, msgs ...*Message) error {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		log.Error("Mailer: Send is being invoked but mail service hasn't been initialized")
 		return nil
 	}
@@ -27,21 +27,21 @@ func (s *SendmailSender) Send(from string, to []string, msg io.WriterTo) error {
 	var waitError error
 
 	envelopeFrom := from
-	if setting.MailService.OverrideEnvelopeFrom {
-		envelopeFrom = setting.MailService.EnvelopeFrom
+	if setting.GetMailService().OverrideEnvelopeFrom {
+		envelopeFrom = setting.GetMailService().EnvelopeFrom
 	}
 
 	args := []string{"-f", envelopeFrom, "-i"}
-	args = append(args, setting.MailService.SendmailArgs...)
+	args = append(args, setting.GetMailService().SendmailArgs...)
 	args = append(args, to...)
-	log.Trace("Sending with: %s %v", setting.MailService.SendmailPath, args)
+	log.Trace("Sending with: %s %v", setting.GetMailService().SendmailPath, args)
 
-	desc := fmt.Sprintf("SendMail: %s %v", setting.MailService.SendmailPath, args)
+	desc := fmt.Sprintf("SendMail: %s %v", setting.GetMailService().SendmailPath, args)
 
-	ctx, _, finished := process.GetManager().AddContextTimeout(graceful.GetManager().HammerContext(), setting.MailService.SendmailTimeout, desc)
+	ctx, _, finished := process.GetManager().AddContextTimeout(graceful.GetManager().HammerContext(), setting.GetMailService().SendmailTimeout, desc)
 	defer finished()
 
-	cmd := exec.CommandContext(ctx, setting.MailService.SendmailPath, args...)
+	cmd := exec.CommandContext(ctx, setting.GetMailService().SendmailPath, args...)
 	pipe, err := cmd.StdinPipe()
 	if err != nil {
 		return err
@@ -53,7 +53,7 @@ func (s *SendmailSender) Send(from string, to []string, msg io.WriterTo) error {
 		return err
 	}
 
-	if setting.MailService.SendmailConvertCRLF {
+	if setting.GetMailService().SendmailConvertCRLF {
 		buf := &strings.Builder{}
 		_, err = msg.WriteTo(buf)
 		if err == nil {
@@ -25,7 +25,7 @@ var _ Sender = &SMTPSender{}
 
 // Send send email
 func (s *SMTPSender) Send(from string, to []string, msg io.WriterTo) error {
-	opts := setting.MailService
+	opts := setting.GetMailService()
 
 	var network string
 	var address string
@@ -0,0 +1,180 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	malwarescan_module "code.gitea.io/gitea/modules/malwarescan"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrMalwareFound is returned for rejected uploads in which malware has been found
+var ErrMalwareFound = util.NewInvalidArgumentErrorf("malware has been found in the file")
+
+func scan(ctx context.Context, r io.Reader, size int64) (*malwarescan_module.Result, error) {
+	if setting.MalwareScan.MaxSize > 0 && size > setting.MalwareScan.MaxSize {
+		log.Debug("File of %d bytes is too large to be scanned for malware", size)
+		return &malwarescan_module.Result{}, nil
+	}
+
+	scanner, err := malwarescan_module.NewScanner()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, setting.MalwareScan.Timeout)
+		defer cancel()
+
+		var result *malwarescan_module.Result
+		if result, err = scanner.Scan(ctx, r); err == nil {
+			return result, nil
+		}
+	}
+
+	if setting.MalwareScan.AllowOnError {
+		log.Warn("Malware scan failed, the file is accepted unscanned: %v", err)
+		return &malwarescan_module.Result{}, nil
+	}
+	return nil, fmt.Errorf("malware scan failed: %w", err)
+}
+
+// ScanAttachment scans the stored content of the attachment. If malware is found, the attachment is quarantined
+// and a system notice is created. It has to be called in the transaction inserting the attachment, so it never becomes
+// visible unscanned.
+func ScanAttachment(ctx context.Context, attach *repo_model.Attachment) error {
+	if !setting.MalwareScan.Enabled || !setting.MalwareScan.ScanAttachments {
+		return nil
+	}
+
+	f, err := storage.Attachments.Open(attach.RelativePath())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := scan(ctx, f, attach.Size)
+	if err != nil || !result.Infected {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		attach.IsQuarantined = true
+		if err := repo_model.SetAttachmentQuarantined(ctx, attach.ID, true); err != nil {
+			return err
+		}
+		if err := system_model.InsertQuarantinedFile(ctx, &system_model.QuarantinedFile{
+			Subject:      system_model.QuarantineSubjectAttachment,
+			AttachmentID: attach.ID,
+			RepoID:       attach.RepoID,
+			UploaderID:   attach.UploaderID,
+			Name:         attach.Name,
+			Size:         attach.Size,
+			Signature:    result.Signature,
+		}); err != nil {
+			return err
+		}
+		return system_model.CreateNotice(ctx, system_model.NoticeRepository,
+			"Malware %q has been found in the attachment %q (%s) of repository #%d uploaded by user #%d, it has been quarantined",
+			result.Signature, attach.Name, attach.UUID, attach.RepoID, attach.UploaderID)
+	})
+}
+
+// ScanUserAvatar scans the avatar before it is set for the user or organization, ErrMalwareFound is returned if it is infected
+func ScanUserAvatar(ctx context.Context, u *user_model.User, data []byte) error {
+	return scanAvatar(ctx, &system_model.QuarantinedFile{
+		Subject:    system_model.QuarantineSubjectUserAvatar,
+		UserID:     u.ID,
+		UploaderID: u.ID,
+		Name:       u.Name,
+		Size:       int64(len(data)),
+	}, data)
+}
+
+// ScanRepoAvatar scans the avatar before it is set for the repository, ErrMalwareFound is returned if it is infected
+func ScanRepoAvatar(ctx context.Context, repo *repo_model.Repository, data []byte) error {
+	return scanAvatar(ctx, &system_model.QuarantinedFile{
+		Subject: system_model.QuarantineSubjectRepoAvatar,
+		RepoID:  repo.ID,
+		Name:    repo.FullName(),
+		Size:    int64(len(data)),
+	}, data)
+}
+
+func scanAvatar(ctx context.Context, f *system_model.QuarantinedFile, data []byte) error {
+	if !setting.MalwareScan.Enabled || !setting.MalwareScan.ScanAvatars {
+		return nil
+	}
+
+	result, err := scan(ctx, bytes.NewReader(data), int64(len(data)))
+	if err != nil || !result.Infected {
+		return err
+	}
+
+	f.Signature = result.Signature
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := system_model.InsertQuarantinedFile(ctx, f); err != nil {
+			return err
+		}
+		return system_model.CreateNotice(ctx, system_model.NoticeRepository,
+			"Malware %q has been found in the avatar uploaded for %s, it has been rejected", result.Signature, f.Name)
+	}); err != nil {
+		return err
+	}
+	return ErrMalwareFound
+}
+
+// ReleaseQuarantinedFile removes the file from the quarantine after an admin has reviewed it as harmless.
+// Released attachments are served again, rejected avatars are only dismissed as they have not been kept.
+func ReleaseQuarantinedFile(ctx context.Context, doer *user_model.User, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		f, err := system_model.GetQuarantinedFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if f.Subject == system_model.QuarantineSubjectAttachment {
+			if err := repo_model.SetAttachmentQuarantined(ctx, f.AttachmentID, false); err != nil {
+				return err
+			}
+		}
+		if err := system_model.DeleteQuarantinedFileByID(ctx, f.ID); err != nil {
+			return err
+		}
+		return system_model.CreateNotice(ctx, system_model.NoticeRepository,
+			"Quarantined file %q (%s) has been released by %s", f.Name, f.Signature, doer.Name)
+	})
+}
+
+// DeleteQuarantinedFile removes the file from the quarantine and deletes the quarantined attachment
+func DeleteQuarantinedFile(ctx context.Context, doer *user_model.User, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		f, err := system_model.GetQuarantinedFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if f.Subject == system_model.QuarantineSubjectAttachment {
+			attach, err := repo_model.GetAttachmentByID(ctx, f.AttachmentID)
+			if err != nil && !repo_model.IsErrAttachmentNotExist(err) {
+				return err
+			}
+			if attach != nil {
+				if err := repo_model.DeleteAttachment(ctx, attach, true); err != nil {
+					return err
+				}
+			}
+		}
+		if err := system_model.DeleteQuarantinedFileByID(ctx, f.ID); err != nil {
+			return err
+		}
+		return system_model.CreateNotice(ctx, system_model.NoticeRepository,
+			"Quarantined file %q (%s) has been deleted by %s", f.Name, f.Signature, doer.Name)
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package malwarescan
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func mockScanner(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(content), "EICAR") {
+			_, _ = io.WriteString(w, `{"infected":true,"signature":"Eicar-Signature"}`)
+		} else {
+			_, _ = io.WriteString(w, `{"infected":false}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Cleanup(test.MockVariableValue(&setting.MalwareScan.Enabled, true))
+	t.Cleanup(test.MockVariableValue(&setting.MalwareScan.Scanner, setting.MalwareScannerHTTP))
+	t.Cleanup(test.MockVariableValue(&setting.MalwareScan.HTTPURL, srv.URL))
+}
+
+func createAttachment(t *testing.T, content string) *repo_model.Attachment {
+	attach := &repo_model.Attachment{
+		UUID:       uuid.New().String(),
+		RepoID:     1,
+		UploaderID: 2,
+		Name:       "file.txt",
+		Size:       int64(len(content)),
+	}
+	_, err := storage.Attachments.Save(attach.RelativePath(), strings.NewReader(content), attach.Size)
+	require.NoError(t, err)
+	require.NoError(t, db.Insert(t.Context(), attach))
+	return attach
+}
+
+func TestScanAttachment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	mockScanner(t)
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	t.Run("Clean", func(t *testing.T) {
+		attach := createAttachment(t, "harmless")
+		require.NoError(t, ScanAttachment(t.Context(), attach))
+
+		attach = unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attach.ID})
+		assert.False(t, attach.IsQuarantined)
+		unittest.AssertNotExistsBean(t, &system_model.QuarantinedFile{AttachmentID: attach.ID})
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		attach := createAttachment(t, "EICAR")
+		require.NoError(t, ScanAttachment(t.Context(), attach))
+
+		attach = unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attach.ID})
+		assert.True(t, attach.IsQuarantined)
+		f := unittest.AssertExistsAndLoadBean(t, &system_model.QuarantinedFile{AttachmentID: attach.ID})
+		assert.Equal(t, system_model.QuarantineSubjectAttachment, f.Subject)
+		assert.Equal(t, "Eicar-Signature", f.Signature)
+
+		require.NoError(t, ReleaseQuarantinedFile(t.Context(), doer, f.ID))
+
+		attach = unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attach.ID})
+		assert.False(t, attach.IsQuarantined)
+		unittest.AssertNotExistsBean(t, &system_model.QuarantinedFile{ID: f.ID})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		attach := createAttachment(t, "EICAR")
+		require.NoError(t, ScanAttachment(t.Context(), attach))
+
+		f := unittest.AssertExistsAndLoadBean(t, &system_model.QuarantinedFile{AttachmentID: attach.ID})
+		require.NoError(t, DeleteQuarantinedFile(t.Context(), doer, f.ID))
+
+		unittest.AssertNotExistsBean(t, &repo_model.Attachment{ID: attach.ID})
+		unittest.AssertNotExistsBean(t, &system_model.QuarantinedFile{ID: f.ID})
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.MalwareScan.MaxSize, 1)()
+
+		attach := createAttachment(t, "EICAR")
+		require.NoError(t, ScanAttachment(t.Context(), attach))
+		unittest.AssertNotExistsBean(t, &system_model.QuarantinedFile{AttachmentID: attach.ID})
+	})
+
+	t.Run("ScannerError", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.MalwareScan.HTTPURL, "http://127.0.0.1:1")()
+
+		attach := createAttachment(t, "EICAR")
+		assert.Error(t, ScanAttachment(t.Context(), attach))
+
+		defer test.MockVariableValue(&setting.MalwareScan.AllowOnError, true)()
+		assert.NoError(t, ScanAttachment(t.Context(), attach))
+	})
+}
+
+func TestScanAvatar(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	mockScanner(t)
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	assert.NoError(t, ScanUserAvatar(t.Context(), user, []byte("harmless")))
+	assert.ErrorIs(t, ScanUserAvatar(t.Context(), user, []byte("EICAR")), ErrMalwareFound)
+	unittest.AssertExistsAndLoadBean(t, &system_model.QuarantinedFile{Subject: system_model.QuarantineSubjectUserAvatar, UserID: user.ID})
+
+	assert.ErrorIs(t, ScanRepoAvatar(t.Context(), repo, []byte("EICAR")), ErrMalwareFound)
+	unittest.AssertExistsAndLoadBean(t, &system_model.QuarantinedFile{Subject: system_model.QuarantineSubjectRepoAvatar, RepoID: repo.ID})
+
+	defer test.MockVariableValue(&setting.MalwareScan.ScanAvatars, false)()
+	assert.NoError(t, ScanUserAvatar(t.Context(), user, []byte("EICAR")))
+}
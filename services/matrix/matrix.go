@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package matrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"slices"
+	"strconv"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/matrix"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// Message is a direct message of a Matrix notification
+type Message struct {
+	UserID int64
+	Event  activities_model.MatrixEvent
+	Title  string
+	Body   string
+	URL    string
+	// TxnID makes the homeserver ignore the message if it is sent again
+	TxnID string
+}
+
+// QueuePartitionKey keeps the messages sent to a user in order in the partitioned queues
+func (m *Message) QueuePartitionKey() string {
+	return strconv.FormatInt(m.UserID, 10)
+}
+
+var (
+	messageQueue *queue.WorkerPoolQueue[*Message]
+	client       *matrix.Client
+)
+
+// Init starts the Matrix notifier when Matrix notifications are enabled
+func Init() error {
+	if !setting.Matrix.Enabled {
+		return nil
+	}
+
+	client = &matrix.Client{
+		HomeserverURL: setting.Matrix.HomeserverURL,
+		AccessToken:   setting.Matrix.AccessToken,
+		HTTPClient: &http.Client{
+			Timeout:   setting.Matrix.DeliverTimeout,
+			Transport: &http.Transport{Proxy: proxy.Proxy()},
+		},
+	}
+
+	messageQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "matrix", handler)
+	if messageQueue == nil {
+		return errors.New("unable to create matrix queue")
+	}
+	go graceful.GetManager().RunWithCancel(messageQueue)
+
+	notify_service.RegisterNotifier(NewNotifier())
+	return nil
+}
+
+func handler(items ...*Message) []*Message {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, msg := range items {
+		if err := sendToUser(ctx, msg); err != nil {
+			log.Error("Unable to send Matrix notification to user %d: %v", msg.UserID, err)
+		}
+	}
+	return nil
+}
+
+// enqueue queues a message for a user unless the user triggered the event
+func enqueue(doer *user_model.User, receiverID int64, msg Message) {
+	if doer != nil && doer.ID == receiverID {
+		return
+	}
+	txnID, err := util.CryptoRandomString(32)
+	if err != nil {
+		log.Error("Unable to generate Matrix transaction ID: %v", err)
+		return
+	}
+	msg.UserID = receiverID
+	msg.Body = util.EllipsisDisplayString(msg.Body, 500)
+	msg.TxnID = txnID
+	if err := messageQueue.Push(&msg); err != nil {
+		log.Error("Unable to queue Matrix notification: %v", err)
+	}
+}
+
+// sendToUser sends a message to the Matrix user of a user who opted in to its type of event.
+// The room of the direct messages is created with the first message, and again if the account of the instance can't send to it anymore.
+func sendToUser(ctx context.Context, msg *Message) error {
+	user, err := user_model.GetUserByID(ctx, msg.UserID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive || user.ProhibitLogin {
+		return nil
+	}
+
+	settings, err := activities_model.GetMatrixSettings(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if settings.MatrixID == "" || !slices.Contains(settings.Events, msg.Event) {
+		return nil
+	}
+
+	body := fmt.Sprintf("%s: %s\n%s", msg.Title, msg.Body, msg.URL)
+	formattedBody := fmt.Sprintf(`%s: <a href="%s">%s</a>`, html.EscapeString(msg.Title), html.EscapeString(msg.URL), html.EscapeString(msg.Body))
+	if settings.RoomID != "" {
+		err = client.SendNotice(ctx, settings.RoomID, msg.TxnID, body, formattedBody)
+		if !matrix.IsErrForbidden(err) {
+			return err
+		}
+		log.Debug("Unable to send to Matrix room %s of user %d anymore, creating a new room: %v", settings.RoomID, user.ID, err)
+	}
+
+	roomID, err := client.CreateDirectRoom(ctx, settings.MatrixID)
+	if err != nil {
+		return err
+	}
+	if err := activities_model.SetMatrixRoomID(ctx, user.ID, roomID); err != nil {
+		return err
+	}
+	return client.SendNotice(ctx, roomID, msg.TxnID, body, formattedBody)
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package matrix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/matrix"
+	"code.gitea.io/gitea/modules/test"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestSendToUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.URL.Path == "/_matrix/client/v3/createRoom":
+			_, _ = w.Write([]byte(`{"room_id":"!new:example.org"}`))
+		case strings.Contains(r.URL.Path, "!left:example.org"):
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errcode":"M_FORBIDDEN"}`))
+		default:
+			_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+		}
+	}))
+	defer server.Close()
+	defer test.MockVariableValue(&client, &matrix.Client{HomeserverURL: server.URL, HTTPClient: server.Client()})()
+
+	msg := &Message{UserID: 2, Event: activities_model.MatrixEventMention, Title: "user1 mentioned you in user2/repo1#1", TxnID: "txn"}
+
+	// nothing is sent until the user sets a Matrix user ID and opts in to the event
+	require.NoError(t, sendToUser(t.Context(), msg))
+	require.NoError(t, activities_model.UpdateMatrixSettings(t.Context(), 2, "@user2:example.org", nil))
+	require.NoError(t, sendToUser(t.Context(), msg))
+	assert.Empty(t, requests)
+
+	// the room is created with the first message, and again when the account can't send to it anymore
+	require.NoError(t, activities_model.UpdateMatrixSettings(t.Context(), 2, "@user2:example.org", []activities_model.MatrixEvent{activities_model.MatrixEventMention}))
+	require.NoError(t, activities_model.SetMatrixRoomID(t.Context(), 2, "!left:example.org"))
+	require.NoError(t, sendToUser(t.Context(), msg))
+	assert.Equal(t, []string{
+		"PUT /_matrix/client/v3/rooms/%21left:example.org/send/m.room.message/txn",
+		"POST /_matrix/client/v3/createRoom",
+		"PUT /_matrix/client/v3/rooms/%21new:example.org/send/m.room.message/txn",
+	}, requests)
+	settings, err := activities_model.GetMatrixSettings(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "!new:example.org", settings.RoomID)
+
+	requests = nil
+	require.NoError(t, sendToUser(t.Context(), msg))
+	assert.Equal(t, []string{"PUT /_matrix/client/v3/rooms/%21new:example.org/send/m.room.message/txn"}, requests)
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package matrix
+
+import (
+	"context"
+	"fmt"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type matrixNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &matrixNotifier{}
+
+// NewNotifier create a new matrixNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &matrixNotifier{}
+}
+
+func notifyMentions(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, link string, mentions []*user_model.User) {
+	if len(mentions) == 0 {
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	msg := Message{
+		Event: activities_model.MatrixEventMention,
+		Title: fmt.Sprintf("%s mentioned you in %s#%d", doer.Name, issue.Repo.FullName(), issue.Index),
+		Body:  issue.Title,
+		URL:   link,
+	}
+	for _, user := range mentions {
+		enqueue(doer, user.ID, msg)
+	}
+}
+
+func (n *matrixNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	notifyMentions(ctx, issue.Poster, issue, issue.HTMLURL(ctx), mentions)
+}
+
+func (n *matrixNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
+	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
+) {
+	notifyMentions(ctx, doer, issue, comment.HTMLURL(ctx), mentions)
+}
+
+func (n *matrixNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	notifyMentions(ctx, pr.Issue.Poster, pr.Issue, pr.Issue.HTMLURL(ctx), mentions)
+}
+
+func (n *matrixNotifier) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, r *issues_model.Review, comment *issues_model.Comment, mentions []*user_model.User) {
+	n.pullRequestComment(ctx, pr, comment, mentions)
+}
+
+func (n *matrixNotifier) PullRequestCodeComment(ctx context.Context, pr *issues_model.PullRequest, comment *issues_model.Comment, mentions []*user_model.User) {
+	n.pullRequestComment(ctx, pr, comment, mentions)
+}
+
+func (n *matrixNotifier) pullRequestComment(ctx context.Context, pr *issues_model.PullRequest, comment *issues_model.Comment, mentions []*user_model.User) {
+	if len(mentions) == 0 {
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := comment.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	notifyMentions(ctx, comment.Poster, pr.Issue, comment.HTMLURL(ctx), mentions)
+}
+
+func (n *matrixNotifier) PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
+	if !isRequest || reviewer.IsOrganization() {
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	enqueue(doer, reviewer.ID, Message{
+		Event: activities_model.MatrixEventReviewRequest,
+		Title: fmt.Sprintf("%s requested your review on %s#%d", doer.Name, issue.Repo.FullName(), issue.Index),
+		Body:  issue.Title,
+		URL:   issue.HTMLURL(ctx),
+	})
+}
@@ -0,0 +1,780 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	_ base.Downloader        = &AzureDevOpsDownloader{}
+	_ base.DownloaderFactory = &AzureDevOpsDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&AzureDevOpsDownloaderFactory{})
+}
+
+// AzureDevOpsDownloaderFactory defines an azure devops downloader factory
+type AzureDevOpsDownloaderFactory struct{}
+
+// New returns a Downloader related to this factory according MigrateOptions
+func (f *AzureDevOpsDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionURL, project, repoName, err := parseAzureDevOpsURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Trace("Create Azure DevOps downloader. CollectionURL: %s Project: %s RepoName: %s", collectionURL, project, repoName)
+
+	return NewAzureDevOpsDownloader(ctx, collectionURL, project, repoName, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *AzureDevOpsDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.AzureDevOpsService
+}
+
+// parseAzureDevOpsURL returns the collection URL (the organization URL of Azure DevOps Services), the project and the repository name
+// of an Azure DevOps repository URL: https://dev.azure.com/org/project/_git/repo, https://org.visualstudio.com/project/_git/repo
+// or https://server/tfs/collection/project/_git/repo. The project may be omitted when the repository has the name of the project.
+func parseAzureDevOpsURL(u *url.URL) (collectionURL *url.URL, project, repoName string, err error) {
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	idx := slices.Index(fields, "_git")
+	if idx < 0 || idx+1 >= len(fields) {
+		return nil, "", "", fmt.Errorf("invalid Azure DevOps repository path: %s", u.Path)
+	}
+	repoName = strings.TrimSuffix(fields[idx+1], ".git")
+
+	collectionEnd := idx - 1
+	if idx == 0 || (strings.EqualFold(u.Hostname(), "dev.azure.com") && idx == 1) {
+		collectionEnd, project = idx, repoName
+	} else {
+		project = fields[idx-1]
+	}
+	collectionURL = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: strings.TrimSuffix("/"+strings.Join(fields[:collectionEnd], "/"), "/")}
+	return collectionURL, project, repoName, nil
+}
+
+type azureDevOpsIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"` // the email of the user, or DOMAIN\user on Azure DevOps Server
+	IsContainer bool   `json:"isContainer"`
+}
+
+// externalID returns a number made of the identity id (a GUID), it is the same in all the migrations
+func (i *azureDevOpsIdentity) externalID() int64 {
+	hex := strings.ReplaceAll(i.ID, "-", "")
+	if len(hex) < 16 {
+		return 0
+	}
+	v, err := strconv.ParseUint(hex[:16], 16, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(v >> 1)
+}
+
+// externalName returns the unique name of the identity, which is used to map it to a local user by email
+func (i *azureDevOpsIdentity) externalName() string {
+	return util.IfZero(i.UniqueName, i.DisplayName)
+}
+
+func (i *azureDevOpsIdentity) email() string {
+	if strings.Contains(i.UniqueName, "@") {
+		return i.UniqueName
+	}
+	return ""
+}
+
+type azureDevOpsRepository struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DefaultBranch string `json:"defaultBranch"`
+	RemoteURL     string `json:"remoteUrl"`
+	WebURL        string `json:"webUrl"`
+	Project       struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"project"`
+}
+
+type azureDevOpsCommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+type azureDevOpsPullRequest struct {
+	PullRequestID         int64                 `json:"pullRequestId"`
+	Status                string                `json:"status"` // active, abandoned or completed
+	CreatedBy             azureDevOpsIdentity   `json:"createdBy"`
+	CreationDate          time.Time             `json:"creationDate"`
+	ClosedDate            *time.Time            `json:"closedDate"`
+	Title                 string                `json:"title"`
+	Description           string                `json:"description"`
+	SourceRefName         string                `json:"sourceRefName"`
+	TargetRefName         string                `json:"targetRefName"`
+	IsDraft               bool                  `json:"isDraft"`
+	LastMergeSourceCommit *azureDevOpsCommitRef `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit *azureDevOpsCommitRef `json:"lastMergeTargetCommit"`
+	LastMergeCommit       *azureDevOpsCommitRef `json:"lastMergeCommit"`
+	Repository            azureDevOpsRepository `json:"repository"`
+	ForkSource            *struct {
+		Repository azureDevOpsRepository `json:"repository"`
+	} `json:"forkSource"`
+	Reviewers []*struct {
+		azureDevOpsIdentity
+		Vote int `json:"vote"` // 10 approved, 5 approved with suggestions, -5 waiting for author, -10 rejected
+	} `json:"reviewers"`
+}
+
+func (c *azureDevOpsCommitRef) sha() string {
+	if c == nil {
+		return ""
+	}
+	return c.CommitID
+}
+
+type azureDevOpsComment struct {
+	ID              int64               `json:"id"`
+	ParentCommentID int64               `json:"parentCommentId"`
+	Author          azureDevOpsIdentity `json:"author"`
+	Content         string              `json:"content"`
+	PublishedDate   time.Time           `json:"publishedDate"`
+	LastUpdatedDate time.Time           `json:"lastUpdatedDate"`
+	CommentType     string              `json:"commentType"` // text, system or codeChange
+	IsDeleted       bool                `json:"isDeleted"`
+}
+
+type azureDevOpsThread struct {
+	ID              int64                 `json:"id"`
+	PublishedDate   time.Time             `json:"publishedDate"`
+	LastUpdatedDate time.Time             `json:"lastUpdatedDate"`
+	IsDeleted       bool                  `json:"isDeleted"`
+	Comments        []*azureDevOpsComment `json:"comments"`
+	ThreadContext   *struct {
+		FilePath       string `json:"filePath"`
+		RightFileStart *struct {
+			Line int `json:"line"`
+		} `json:"rightFileStart"`
+		LeftFileStart *struct {
+			Line int `json:"line"`
+		} `json:"leftFileStart"`
+	} `json:"threadContext"`
+	Properties map[string]struct {
+		Value any `json:"$value"`
+	} `json:"properties"`
+}
+
+func (t *azureDevOpsThread) isVoteUpdate() bool {
+	threadType, ok := t.Properties["CodeReviewThreadType"]
+	return ok && fmt.Sprint(threadType.Value) == "VoteUpdate"
+}
+
+type azureDevOpsWorkItem struct {
+	ID     int64 `json:"id"`
+	Fields struct {
+		Title         string              `json:"System.Title"`
+		Description   string              `json:"System.Description"`
+		ReproSteps    string              `json:"Microsoft.VSTS.TCM.ReproSteps"`
+		State         string              `json:"System.State"`
+		WorkItemType  string              `json:"System.WorkItemType"`
+		AreaPath      string              `json:"System.AreaPath"`
+		IterationPath string              `json:"System.IterationPath"`
+		CreatedBy     azureDevOpsIdentity `json:"System.CreatedBy"`
+		CreatedDate   time.Time           `json:"System.CreatedDate"`
+		ChangedDate   time.Time           `json:"System.ChangedDate"`
+		ClosedDate    *time.Time          `json:"Microsoft.VSTS.Common.ClosedDate"`
+	} `json:"fields"`
+}
+
+type azureDevOpsWorkItemComment struct {
+	ID           int64               `json:"id"`
+	Text         string              `json:"text"`
+	CreatedBy    azureDevOpsIdentity `json:"createdBy"`
+	CreatedDate  time.Time           `json:"createdDate"`
+	ModifiedDate time.Time           `json:"modifiedDate"`
+	IsDeleted    bool                `json:"isDeleted"`
+}
+
+type azureDevOpsClassificationNode struct {
+	Name       string `json:"name"`
+	Attributes struct {
+		StartDate  *time.Time `json:"startDate"`
+		FinishDate *time.Time `json:"finishDate"`
+	} `json:"attributes"`
+	Children []*azureDevOpsClassificationNode `json:"children"`
+}
+
+type azureDevOpsList[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+// azureDevOpsPullRequestContext keeps the threads of a pull request, they contain the comments, the review comments and the votes
+type azureDevOpsPullRequestContext struct {
+	PullRequest *azureDevOpsPullRequest
+	Threads     []*azureDevOpsThread
+}
+
+// AzureDevOpsDownloader implements a Downloader interface to get repository information
+// from Azure DevOps Services or Azure DevOps Server by its REST API.
+// The work items are migrated as issues, their area paths as labels and their iteration paths as milestones.
+// - maxIssueIndex is the max work item id, the pull request numbers are added to it because
+// the work items and the pull requests have individual numbers.
+type AzureDevOpsDownloader struct {
+	base.NullDownloader
+	client        *http.Client
+	collectionURL *url.URL
+	project       string
+	repoName      string
+	username      string
+	password      string
+	token         string
+	workItemIDs   []int64
+	maxIssueIndex int64
+}
+
+// NewAzureDevOpsDownloader creates an Azure DevOps downloader
+func NewAzureDevOpsDownloader(_ context.Context, collectionURL *url.URL, project, repoName, username, password, token string) *AzureDevOpsDownloader {
+	return &AzureDevOpsDownloader{
+		client:        NewMigrationHTTPClient(),
+		collectionURL: collectionURL,
+		project:       project,
+		repoName:      repoName,
+		username:      username,
+		password:      password,
+		token:         token,
+	}
+}
+
+// String implements Stringer
+func (d *AzureDevOpsDownloader) String() string {
+	return fmt.Sprintf("migration from azure devops %s %s/%s", d.collectionURL, d.project, d.repoName)
+}
+
+func (d *AzureDevOpsDownloader) LogString() string {
+	if d == nil {
+		return "<AzureDevOpsDownloader nil>"
+	}
+	return fmt.Sprintf("<AzureDevOpsDownloader %s %s/%s>", d.collectionURL, d.project, d.repoName)
+}
+
+func (d *AzureDevOpsDownloader) repoEndpoint(elems ...string) string {
+	return "/git/repositories/" + url.PathEscape(d.repoName) + strings.Join(elems, "")
+}
+
+// callAPI calls an API of the project, the body is sent as JSON if it isn't nil
+func (d *AzureDevOpsDownloader) callAPI(ctx context.Context, method, endpoint string, query url.Values, body, result any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	if !query.Has("api-version") {
+		query.Set("api-version", "7.0")
+	}
+	apiURL := d.collectionURL.String() + "/" + url.PathEscape(d.project) + "/_apis" + endpoint + "?" + query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if d.token != "" {
+		// the personal access tokens are sent as the password of any user name
+		req.SetBasicAuth("", d.token)
+	} else if d.username != "" {
+		req.SetBasicAuth(d.username, d.password)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Azure DevOps responds 203 with a sign-in page when the credentials are invalid
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("azure devops API %s responded %s: %s", endpoint, resp.Status, respBody)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// GetRepoInfo returns repository information
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/repositories/get-repository
+func (d *AzureDevOpsDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo azureDevOpsRepository
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint(), nil, nil, &repo); err != nil {
+		return nil, err
+	}
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         repo.Project.Name,
+		Description:   repo.Project.Description,
+		CloneURL:      repo.RemoteURL,
+		OriginalURL:   repo.WebURL,
+		DefaultBranch: strings.TrimPrefix(repo.DefaultBranch, "refs/heads/"),
+	}, nil
+}
+
+// getClassificationNodes returns the relative paths of the areas or the iterations of the project,
+// the path of the root node (the project) is empty
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/wit/classification-nodes/get
+func (d *AzureDevOpsDownloader) getClassificationNodes(ctx context.Context, structureGroup string) (map[string]*azureDevOpsClassificationNode, error) {
+	var root azureDevOpsClassificationNode
+	if err := d.callAPI(ctx, http.MethodGet, "/wit/classificationnodes/"+structureGroup, url.Values{"$depth": {"100"}}, nil, &root); err != nil {
+		return nil, err
+	}
+
+	nodes := map[string]*azureDevOpsClassificationNode{}
+	var walk func(node *azureDevOpsClassificationNode, prefix string)
+	walk = func(node *azureDevOpsClassificationNode, prefix string) {
+		for _, child := range node.Children {
+			childPath := prefix + child.Name
+			nodes[childPath] = child
+			walk(child, childPath+"/")
+		}
+	}
+	walk(&root, "")
+	return nodes, nil
+}
+
+// relativeClassificationPath converts an area path or an iteration path of a work item (Project\Area\SubArea)
+// to the path relative to the project (Area/SubArea)
+func relativeClassificationPath(p string) string {
+	_, rel, _ := strings.Cut(p, `\`)
+	return strings.ReplaceAll(rel, `\`, "/")
+}
+
+func azureDevOpsAreaLabelName(areaPath string) string {
+	return "area/" + areaPath
+}
+
+// GetMilestones returns the iterations of the project as milestones
+func (d *AzureDevOpsDownloader) GetMilestones(ctx context.Context) ([]*base.Milestone, error) {
+	iterations, err := d.getClassificationNodes(ctx, "iterations")
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := make([]*base.Milestone, 0, len(iterations))
+	for iterationPath, iteration := range iterations {
+		milestone := &base.Milestone{
+			Title:    iterationPath,
+			Deadline: iteration.Attributes.FinishDate,
+			State:    "open",
+		}
+		if iteration.Attributes.StartDate != nil {
+			milestone.Created = *iteration.Attributes.StartDate
+		}
+		if finish := iteration.Attributes.FinishDate; finish != nil && finish.Before(time.Now()) {
+			milestone.State = "closed"
+			milestone.Closed = finish
+		}
+		milestones = append(milestones, milestone)
+	}
+	slices.SortFunc(milestones, func(a, b *base.Milestone) int {
+		return strings.Compare(a.Title, b.Title)
+	})
+	return milestones, nil
+}
+
+// GetLabels returns the areas of the project as labels
+func (d *AzureDevOpsDownloader) GetLabels(ctx context.Context) ([]*base.Label, error) {
+	areas, err := d.getClassificationNodes(ctx, "areas")
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]*base.Label, 0, len(areas))
+	for areaPath := range areas {
+		labels = append(labels, &base.Label{
+			Name:        azureDevOpsAreaLabelName(areaPath),
+			Color:       "0078d4",
+			Description: fmt.Sprintf("Area %s", areaPath),
+		})
+	}
+	slices.SortFunc(labels, func(a, b *base.Label) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return labels, nil
+}
+
+// loadWorkItemIDs queries the ids of all the work items of the project
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/wit/wiql/query-by-wiql
+func (d *AzureDevOpsDownloader) loadWorkItemIDs(ctx context.Context) error {
+	if d.workItemIDs != nil {
+		return nil
+	}
+	var result struct {
+		WorkItems []struct {
+			ID int64 `json:"id"`
+		} `json:"workItems"`
+	}
+	query := map[string]string{"query": "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project ORDER BY [System.Id]"}
+	if err := d.callAPI(ctx, http.MethodPost, "/wit/wiql", nil, query, &result); err != nil {
+		return err
+	}
+	d.workItemIDs = make([]int64, 0, len(result.WorkItems))
+	for _, workItem := range result.WorkItems {
+		d.workItemIDs = append(d.workItemIDs, workItem.ID)
+		d.maxIssueIndex = max(d.maxIssueIndex, workItem.ID)
+	}
+	return nil
+}
+
+// GetIssues returns the work items as issues according start and limit
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/wit/work-items/list
+func (d *AzureDevOpsDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*base.Issue, bool, error) {
+	if err := d.loadWorkItemIDs(ctx); err != nil {
+		return nil, false, err
+	}
+	start := min((page-1)*perPage, len(d.workItemIDs))
+	end := min(start+perPage, len(d.workItemIDs))
+
+	issues := make([]*base.Issue, 0, end-start)
+	// at most 200 work items can be read at once
+	for batch := range slices.Chunk(d.workItemIDs[start:end], 200) {
+		ids := make([]string, 0, len(batch))
+		for _, id := range batch {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		var workItems azureDevOpsList[*azureDevOpsWorkItem]
+		if err := d.callAPI(ctx, http.MethodGet, "/wit/workitems", url.Values{"ids": {strings.Join(ids, ",")}, "errorPolicy": {"omit"}}, nil, &workItems); err != nil {
+			return nil, false, err
+		}
+		for _, workItem := range workItems.Value {
+			// the work items which can't be read are null when errorPolicy is omit
+			if workItem != nil {
+				issues = append(issues, d.convertWorkItem(workItem))
+			}
+		}
+	}
+
+	return issues, end >= len(d.workItemIDs), nil
+}
+
+func (d *AzureDevOpsDownloader) convertWorkItem(workItem *azureDevOpsWorkItem) *base.Issue {
+	fields := &workItem.Fields
+	issue := &base.Issue{
+		Number:       workItem.ID,
+		PosterID:     fields.CreatedBy.externalID(),
+		PosterName:   fields.CreatedBy.externalName(),
+		PosterEmail:  fields.CreatedBy.email(),
+		Title:        fields.Title,
+		Content:      util.IfZero(fields.Description, fields.ReproSteps),
+		Milestone:    relativeClassificationPath(fields.IterationPath),
+		State:        "open",
+		Created:      fields.CreatedDate,
+		Updated:      fields.ChangedDate,
+		ForeignIndex: workItem.ID,
+	}
+	switch fields.State {
+	case "Closed", "Done", "Removed":
+		issue.State = "closed"
+		issue.Closed = util.IfZero(fields.ClosedDate, &fields.ChangedDate)
+	}
+	if areaPath := relativeClassificationPath(fields.AreaPath); areaPath != "" {
+		issue.Labels = []*base.Label{{Name: azureDevOpsAreaLabelName(areaPath)}}
+	}
+	return issue
+}
+
+// getWorkItemComments returns the comments of a work item
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/wit/comments/get-comments
+func (d *AzureDevOpsDownloader) getWorkItemComments(ctx context.Context, workItemID int64) ([]*base.Comment, error) {
+	var comments []*base.Comment
+	query := url.Values{"$top": {"200"}, "api-version": {"7.0-preview.3"}}
+	for {
+		var result struct {
+			Comments          []*azureDevOpsWorkItemComment `json:"comments"`
+			ContinuationToken string                        `json:"continuationToken"`
+		}
+		if err := d.callAPI(ctx, http.MethodGet, "/wit/workItems/"+strconv.FormatInt(workItemID, 10)+"/comments", query, nil, &result); err != nil {
+			return nil, err
+		}
+		for _, comment := range result.Comments {
+			if comment.IsDeleted {
+				continue
+			}
+			comments = append(comments, &base.Comment{
+				IssueIndex:  workItemID,
+				Index:       comment.ID,
+				PosterID:    comment.CreatedBy.externalID(),
+				PosterName:  comment.CreatedBy.externalName(),
+				PosterEmail: comment.CreatedBy.email(),
+				Content:     comment.Text,
+				Created:     comment.CreatedDate,
+				Updated:     comment.ModifiedDate,
+			})
+		}
+		if result.ContinuationToken == "" {
+			break
+		}
+		query.Set("continuationToken", result.ContinuationToken)
+	}
+	slices.SortStableFunc(comments, func(a, b *base.Comment) int {
+		return a.Created.Compare(b.Created)
+	})
+	return comments, nil
+}
+
+// GetComments returns the comments of a work item or a pull request
+func (d *AzureDevOpsDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	prContext, ok := commentable.GetContext().(azureDevOpsPullRequestContext)
+	if !ok {
+		comments, err := d.getWorkItemComments(ctx, commentable.GetForeignIndex())
+		return comments, true, err
+	}
+
+	var comments []*base.Comment
+	for _, thread := range prContext.Threads {
+		if thread.IsDeleted || thread.isVoteUpdate() {
+			continue
+		}
+		prefix := ""
+		if threadContext := thread.ThreadContext; threadContext != nil {
+			if threadContext.RightFileStart != nil || threadContext.LeftFileStart != nil {
+				// the comments on the lines are review comments
+				continue
+			}
+			prefix = fmt.Sprintf("`%s`: ", strings.TrimPrefix(threadContext.FilePath, "/"))
+		}
+		for _, comment := range thread.Comments {
+			if comment.IsDeleted || comment.CommentType != "text" {
+				continue
+			}
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				PosterID:    comment.Author.externalID(),
+				PosterName:  comment.Author.externalName(),
+				PosterEmail: comment.Author.email(),
+				Content:     prefix + comment.Content,
+				Created:     comment.PublishedDate,
+				Updated:     comment.LastUpdatedDate,
+			})
+		}
+	}
+	slices.SortStableFunc(comments, func(a, b *base.Comment) int {
+		return a.Created.Compare(b.Created)
+	})
+	return comments, true, nil
+}
+
+// GetPullRequests returns pull requests according page and perPage
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/get-pull-requests
+func (d *AzureDevOpsDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	var rawPullRequests azureDevOpsList[*azureDevOpsPullRequest]
+	err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullrequests"), url.Values{
+		"searchCriteria.status": {"all"},
+		"$top":                  {strconv.Itoa(perPage)},
+		"$skip":                 {strconv.Itoa((page - 1) * perPage)},
+	}, nil, &rawPullRequests)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(rawPullRequests.Value))
+	for _, pr := range rawPullRequests.Value {
+		// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-threads/list
+		var threads azureDevOpsList[*azureDevOpsThread]
+		if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullRequests/", strconv.FormatInt(pr.PullRequestID, 10), "/threads"), nil, nil, &threads); err != nil {
+			return nil, false, err
+		}
+
+		updated := pr.CreationDate
+		for _, thread := range threads.Value {
+			if thread.LastUpdatedDate.After(updated) {
+				updated = thread.LastUpdatedDate
+			}
+		}
+
+		state := "open"
+		var closed, mergedTime *time.Time
+		if pr.Status != "active" {
+			state = "closed"
+			closed = util.IfZero(pr.ClosedDate, &updated)
+		}
+		if pr.Status == "completed" {
+			mergedTime = closed
+		}
+
+		pullRequest := &base.PullRequest{
+			Number:      d.maxIssueIndex + pr.PullRequestID,
+			Title:       pr.Title,
+			PosterID:    pr.CreatedBy.externalID(),
+			PosterName:  pr.CreatedBy.externalName(),
+			PosterEmail: pr.CreatedBy.email(),
+			Content:     pr.Description,
+			State:       state,
+			Created:     pr.CreationDate,
+			Updated:     updated,
+			Closed:      closed,
+			Merged:      pr.Status == "completed",
+			MergedTime:  mergedTime,
+			Head: base.PullRequestBranch{
+				Ref:       strings.TrimPrefix(pr.SourceRefName, "refs/heads/"),
+				SHA:       pr.LastMergeSourceCommit.sha(),
+				RepoName:  pr.Repository.Name,
+				OwnerName: pr.Repository.Project.Name,
+			},
+			Base: base.PullRequestBranch{
+				Ref:       strings.TrimPrefix(pr.TargetRefName, "refs/heads/"),
+				SHA:       pr.LastMergeTargetCommit.sha(),
+				RepoName:  pr.Repository.Name,
+				OwnerName: pr.Repository.Project.Name,
+			},
+			IsDraft:      pr.IsDraft,
+			ForeignIndex: pr.PullRequestID,
+			Context:      azureDevOpsPullRequestContext{PullRequest: pr, Threads: threads.Value},
+		}
+		if pullRequest.Merged {
+			pullRequest.MergeCommitSHA = pr.LastMergeCommit.sha()
+		}
+		if pr.ForkSource != nil {
+			pullRequest.Head.RepoName = pr.ForkSource.Repository.Name
+			pullRequest.Head.OwnerName = pr.ForkSource.Repository.Project.Name
+			pullRequest.Head.CloneURL = pr.ForkSource.Repository.RemoteURL
+		}
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequest, d.collectionURL.String(), d)
+		pullRequests = append(pullRequests, pullRequest)
+	}
+
+	return pullRequests, len(rawPullRequests.Value) < perPage, nil
+}
+
+// GetReviews returns the votes and the comments on the lines of a pull request,
+// every comment on a line is a review of its author because the review comments are posted by the reviewer
+func (d *AzureDevOpsDownloader) GetReviews(_ context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	pr, ok := reviewable.(*base.PullRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reviewable: %+v", reviewable)
+	}
+	prContext, ok := pr.Context.(azureDevOpsPullRequestContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected context: %+v", pr.Context)
+	}
+	headSHA := prContext.PullRequest.LastMergeSourceCommit.sha()
+
+	var reviews []*base.Review
+	for _, reviewer := range prContext.PullRequest.Reviewers {
+		var state string
+		switch {
+		case reviewer.IsContainer:
+			continue
+		case reviewer.Vote > 0:
+			state = base.ReviewStateApproved
+		case reviewer.Vote < 0:
+			state = base.ReviewStateChangesRequested
+		default:
+			continue
+		}
+		createdAt := pr.Updated
+		for _, thread := range prContext.Threads {
+			if thread.isVoteUpdate() && len(thread.Comments) > 0 && thread.Comments[0].Author.ID == reviewer.ID {
+				createdAt = thread.PublishedDate
+			}
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   pr.Number,
+			ReviewerID:   reviewer.externalID(),
+			ReviewerName: reviewer.externalName(),
+			CommitID:     headSHA,
+			CreatedAt:    createdAt,
+			State:        state,
+		})
+	}
+
+	for _, thread := range prContext.Threads {
+		threadContext := thread.ThreadContext
+		if thread.IsDeleted || threadContext == nil {
+			continue
+		}
+		var line int
+		if threadContext.RightFileStart != nil {
+			line = threadContext.RightFileStart.Line
+		} else if threadContext.LeftFileStart != nil {
+			line = -threadContext.LeftFileStart.Line
+		} else {
+			continue
+		}
+		for _, comment := range thread.Comments {
+			if comment.IsDeleted || comment.CommentType != "text" {
+				continue
+			}
+			reviews = append(reviews, &base.Review{
+				IssueIndex:   pr.Number,
+				ReviewerID:   comment.Author.externalID(),
+				ReviewerName: comment.Author.externalName(),
+				CommitID:     headSHA,
+				CreatedAt:    comment.PublishedDate,
+				State:        base.ReviewStateCommented,
+				Comments: []*base.ReviewComment{{
+					ID:        comment.ID,
+					InReplyTo: comment.ParentCommentID,
+					Content:   comment.Content,
+					TreePath:  strings.TrimPrefix(threadContext.FilePath, "/"),
+					Line:      line,
+					CommitID:  headSHA,
+					PosterID:  comment.Author.externalID(),
+					CreatedAt: comment.PublishedDate,
+					UpdatedAt: comment.LastUpdatedDate,
+				}},
+			})
+		}
+	}
+	slices.SortStableFunc(reviews, func(a, b *base.Review) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return reviews, nil
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *AzureDevOpsDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if opts.AuthToken != "" {
+		// the personal access tokens can be used as the password of any user name
+		u.User = url.UserPassword(util.IfZero(opts.AuthUsername, "pat"), opts.AuthToken)
+	} else if opts.AuthUsername != "" {
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthPassword)
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	base "code.gitea.io/gitea/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAzureDevOpsURL(t *testing.T) {
+	cases := []struct {
+		url, collectionURL, project, repoName string
+	}{
+		{"https://dev.azure.com/org/proj/_git/repo", "https://dev.azure.com/org", "proj", "repo"},
+		{"https://org@dev.azure.com/org/My%20Project/_git/repo.git", "https://dev.azure.com/org", "My Project", "repo"},
+		{"https://dev.azure.com/org/_git/repo", "https://dev.azure.com/org", "repo", "repo"},
+		{"https://org.visualstudio.com/proj/_git/repo", "https://org.visualstudio.com", "proj", "repo"},
+		{"https://org.visualstudio.com/_git/repo", "https://org.visualstudio.com", "repo", "repo"},
+		{"https://server/tfs/DefaultCollection/proj/_git/repo/pullrequests", "https://server/tfs/DefaultCollection", "proj", "repo"},
+	}
+	for _, c := range cases {
+		u, err := url.Parse(c.url)
+		require.NoError(t, err)
+		collectionURL, project, repoName, err := parseAzureDevOpsURL(u)
+		require.NoError(t, err, c.url)
+		assert.Equal(t, c.collectionURL, collectionURL.String(), c.url)
+		assert.Equal(t, c.project, project, c.url)
+		assert.Equal(t, c.repoName, repoName, c.url)
+	}
+
+	_, _, _, err := parseAzureDevOpsURL(&url.URL{Scheme: "https", Host: "dev.azure.com", Path: "/org/proj/repo"})
+	assert.Error(t, err)
+}
+
+func TestAzureDevOpsIdentity(t *testing.T) {
+	identity := azureDevOpsIdentity{ID: "6f2a5c8e-1b2c-4d3e-8f90-123456789abc", DisplayName: "Alice", UniqueName: "alice@example.com"}
+	assert.Equal(t, int64(0x6f2a5c8e1b2c4d3e>>1), identity.externalID())
+	assert.Equal(t, "alice@example.com", identity.externalName())
+	assert.Equal(t, "alice@example.com", identity.email())
+
+	identity = azureDevOpsIdentity{ID: "invalid", DisplayName: "Bob", UniqueName: `DOMAIN\bob`}
+	assert.Zero(t, identity.externalID())
+	assert.Equal(t, `DOMAIN\bob`, identity.externalName())
+	assert.Empty(t, identity.email())
+}
+
+const (
+	azureDevOpsTestAlice = `{"id": "00000000-0001-0000-0000-000000000000", "displayName": "Alice", "uniqueName": "alice@example.com"}`
+	azureDevOpsTestBob   = `{"id": "00000000-0002-0000-0000-000000000000", "displayName": "Bob", "uniqueName": "bob@example.com"}`
+)
+
+var azureDevOpsTestResponses = map[string]string{
+	"/org/proj/_apis/git/repositories/repo": `{
+		"name": "repo", "defaultBranch": "refs/heads/main", "project": {"name": "proj", "description": "test project"},
+		"remoteUrl": "https://org@dev.azure.com/org/proj/_git/repo", "webUrl": "https://dev.azure.com/org/proj/_git/repo"
+	}`,
+	"/org/proj/_apis/wit/classificationnodes/areas": `{"name": "proj", "children": [{"name": "Frontend", "children": [{"name": "Web"}]}]}`,
+	"/org/proj/_apis/wit/classificationnodes/iterations": `{"name": "proj", "children": [
+		{"name": "Sprint 1", "attributes": {"startDate": "2024-01-01T00:00:00Z", "finishDate": "2024-01-14T00:00:00Z"}},
+		{"name": "Sprint 2"}
+	]}`,
+	"/org/proj/_apis/wit/wiql": `{"workItems": [{"id": 3}, {"id": 7}]}`,
+	"/org/proj/_apis/wit/workitems": `{"count": 2, "value": [
+		{"id": 3, "fields": {
+			"System.Title": "Bug", "Microsoft.VSTS.TCM.ReproSteps": "<p>steps</p>", "System.State": "Closed",
+			"System.AreaPath": "proj\\Frontend\\Web", "System.IterationPath": "proj\\Sprint 1", "System.CreatedBy": ` + azureDevOpsTestAlice + `,
+			"System.CreatedDate": "2024-01-02T00:00:00Z", "System.ChangedDate": "2024-01-05T00:00:00Z", "Microsoft.VSTS.Common.ClosedDate": "2024-01-04T00:00:00Z"
+		}},
+		{"id": 7, "fields": {
+			"System.Title": "Task", "System.Description": "<p>task</p>", "System.State": "Active",
+			"System.AreaPath": "proj", "System.IterationPath": "proj", "System.CreatedBy": ` + azureDevOpsTestBob + `,
+			"System.CreatedDate": "2024-01-03T00:00:00Z", "System.ChangedDate": "2024-01-03T00:00:00Z"
+		}}
+	]}`,
+	"/org/proj/_apis/wit/workItems/3/comments": `{"comments": [
+		{"id": 2, "text": "fixed", "createdBy": ` + azureDevOpsTestAlice + `, "createdDate": "2024-01-04T00:00:00Z", "modifiedDate": "2024-01-04T00:00:00Z"},
+		{"id": 1, "text": "deleted", "isDeleted": true, "createdBy": ` + azureDevOpsTestBob + `, "createdDate": "2024-01-03T00:00:00Z", "modifiedDate": "2024-01-03T00:00:00Z"}
+	]}`,
+	"/org/proj/_apis/git/repositories/repo/pullrequests": `{"count": 1, "value": [{
+		"pullRequestId": 2, "status": "completed", "title": "Fix bug", "description": "description",
+		"createdBy": ` + azureDevOpsTestAlice + `, "creationDate": "2024-01-02T00:00:00Z", "closedDate": "2024-01-04T00:00:00Z",
+		"sourceRefName": "refs/heads/fix", "targetRefName": "refs/heads/main",
+		"lastMergeSourceCommit": {"commitId": "1111111111111111111111111111111111111111"},
+		"lastMergeTargetCommit": {"commitId": "2222222222222222222222222222222222222222"},
+		"lastMergeCommit": {"commitId": "3333333333333333333333333333333333333333"},
+		"repository": {"name": "repo", "project": {"name": "proj"}},
+		"reviewers": [
+			{"id": "00000000-0002-0000-0000-000000000000", "displayName": "Bob", "uniqueName": "bob@example.com", "vote": 10},
+			{"id": "00000000-0000-0009-0000-000000000000", "displayName": "Team", "isContainer": true, "vote": -10}
+		]
+	}]}`,
+	"/org/proj/_apis/git/repositories/repo/pullRequests/2/threads": `{"count": 3, "value": [
+		{"id": 1, "publishedDate": "2024-01-02T01:00:00Z", "lastUpdatedDate": "2024-01-02T01:00:00Z", "comments": [
+			{"id": 1, "author": ` + azureDevOpsTestBob + `, "content": "looks good", "commentType": "text", "publishedDate": "2024-01-02T01:00:00Z", "lastUpdatedDate": "2024-01-02T01:00:00Z"}
+		]},
+		{"id": 2, "publishedDate": "2024-01-02T02:00:00Z", "lastUpdatedDate": "2024-01-02T03:00:00Z",
+			"threadContext": {"filePath": "/main.go", "rightFileStart": {"line": 4, "offset": 1}}, "comments": [
+			{"id": 1, "author": ` + azureDevOpsTestBob + `, "content": "rename it", "commentType": "text", "publishedDate": "2024-01-02T02:00:00Z", "lastUpdatedDate": "2024-01-02T02:00:00Z"},
+			{"id": 2, "parentCommentId": 1, "author": ` + azureDevOpsTestAlice + `, "content": "done", "commentType": "text", "publishedDate": "2024-01-02T03:00:00Z", "lastUpdatedDate": "2024-01-02T03:00:00Z"}
+		]},
+		{"id": 3, "publishedDate": "2024-01-03T00:00:00Z", "lastUpdatedDate": "2024-01-03T00:00:00Z",
+			"properties": {"CodeReviewThreadType": {"$type": "System.String", "$value": "VoteUpdate"}}, "comments": [
+			{"id": 1, "author": ` + azureDevOpsTestBob + `, "content": "Bob voted 10", "commentType": "system", "publishedDate": "2024-01-03T00:00:00Z", "lastUpdatedDate": "2024-01-03T00:00:00Z"}
+		]}
+	]}`,
+}
+
+func TestAzureDevOpsDownloadRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, token, _ := r.BasicAuth()
+		assert.Equal(t, "token", token)
+		if r.URL.Path == "/org/proj/_apis/wit/wiql" {
+			assert.Equal(t, http.MethodPost, r.Method)
+		}
+		resp, ok := azureDevOpsTestResponses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer server.Close()
+
+	ctx := t.Context()
+	u, _ := url.Parse(server.URL + "/org/proj/_git/repo")
+	collectionURL, project, repoName, err := parseAzureDevOpsURL(u)
+	require.NoError(t, err)
+	downloader := NewAzureDevOpsDownloader(ctx, collectionURL, project, repoName, "", "", "token")
+	downloader.client = server.Client()
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "repo",
+		Owner:         "proj",
+		Description:   "test project",
+		CloneURL:      "https://org@dev.azure.com/org/proj/_git/repo",
+		OriginalURL:   "https://dev.azure.com/org/proj/_git/repo",
+		DefaultBranch: "main",
+	}, repo)
+
+	labels, err := downloader.GetLabels(ctx)
+	require.NoError(t, err)
+	assertLabelsEqual(t, []*base.Label{
+		{Name: "area/Frontend", Color: "0078d4", Description: "Area Frontend"},
+		{Name: "area/Frontend/Web", Color: "0078d4", Description: "Area Frontend/Web"},
+	}, labels)
+
+	milestones, err := downloader.GetMilestones(ctx)
+	require.NoError(t, err)
+	finish := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	assertMilestonesEqual(t, []*base.Milestone{
+		{Title: "Sprint 1", Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Deadline: &finish, Closed: &finish, State: "closed"},
+		{Title: "Sprint 2", State: "open"},
+	}, milestones)
+
+	issues, isEnd, err := downloader.GetIssues(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	closed := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:      3,
+			PosterID:    1 << 15,
+			PosterName:  "alice@example.com",
+			PosterEmail: "alice@example.com",
+			Title:       "Bug",
+			Content:     "<p>steps</p>",
+			Milestone:   "Sprint 1",
+			State:       "closed",
+			Created:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			Closed:      &closed,
+			Labels:      []*base.Label{{Name: "area/Frontend/Web"}},
+		},
+		{
+			Number:      7,
+			PosterID:    2 << 15,
+			PosterName:  "bob@example.com",
+			PosterEmail: "bob@example.com",
+			Title:       "Task",
+			Content:     "<p>task</p>",
+			State:       "open",
+			Created:     time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+	}, issues)
+
+	comments, _, err := downloader.GetComments(ctx, issues[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  3,
+			PosterID:    1 << 15,
+			PosterName:  "alice@example.com",
+			PosterEmail: "alice@example.com",
+			Content:     "fixed",
+			Created:     closed,
+			Updated:     closed,
+		},
+	}, comments)
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			// the pull request numbers follow the work item numbers
+			Number:         9,
+			Title:          "Fix bug",
+			PosterID:       1 << 15,
+			PosterName:     "alice@example.com",
+			PosterEmail:    "alice@example.com",
+			Content:        "description",
+			State:          "closed",
+			Created:        time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Updated:        time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			Closed:         &closed,
+			Merged:         true,
+			MergedTime:     &closed,
+			MergeCommitSHA: "3333333333333333333333333333333333333333",
+			Head: base.PullRequestBranch{
+				Ref:       "fix",
+				SHA:       "1111111111111111111111111111111111111111",
+				RepoName:  "repo",
+				OwnerName: "proj",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "2222222222222222222222222222222222222222",
+				RepoName:  "repo",
+				OwnerName: "proj",
+			},
+			ForeignIndex: 2,
+		},
+	}, prs)
+
+	comments, _, err = downloader.GetComments(ctx, prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  9,
+			PosterID:    2 << 15,
+			PosterName:  "bob@example.com",
+			PosterEmail: "bob@example.com",
+			Content:     "looks good",
+			Created:     time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   9,
+			ReviewerID:   2 << 15,
+			ReviewerName: "bob@example.com",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        1,
+				Content:   "rename it",
+				TreePath:  "main.go",
+				Line:      4,
+				CommitID:  "1111111111111111111111111111111111111111",
+				PosterID:  2 << 15,
+				CreatedAt: time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   9,
+			ReviewerID:   1 << 15,
+			ReviewerName: "alice@example.com",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        2,
+				InReplyTo: 1,
+				Content:   "done",
+				TreePath:  "main.go",
+				Line:      4,
+				CommitID:  "1111111111111111111111111111111111111111",
+				PosterID:  1 << 15,
+				CreatedAt: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   9,
+			ReviewerID:   2 << 15,
+			ReviewerName: "bob@example.com",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateApproved,
+		},
+	}, reviews)
+}
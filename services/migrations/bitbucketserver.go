@@ -0,0 +1,553 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	_ base.Downloader        = &BitbucketServerDownloader{}
+	_ base.DownloaderFactory = &BitbucketServerDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&BitbucketServerDownloaderFactory{})
+}
+
+// BitbucketServerDownloaderFactory defines a bitbucket server downloader factory
+type BitbucketServerDownloaderFactory struct{}
+
+// New returns a Downloader related to this factory according MigrateOptions
+func (f *BitbucketServerDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, project, repoSlug, err := parseBitbucketServerURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Trace("Create Bitbucket Server downloader. BaseURL: %s Project: %s RepoSlug: %s", baseURL, project, repoSlug)
+
+	return NewBitbucketServerDownloader(ctx, baseURL, project, repoSlug, opts.AuthUsername, opts.AuthPassword, opts.AuthToken), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *BitbucketServerDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.BitbucketServerService
+}
+
+// parseBitbucketServerURL returns the base URL, the project key and the repository slug of a Bitbucket Server repository URL.
+// The clone URLs (/scm/PROJECT/repo.git) and the browse URLs (/projects/PROJECT/repos/repo, /users/user/repos/repo) are supported,
+// the instance may be served under a context path.
+func parseBitbucketServerURL(u *url.URL) (baseURL *url.URL, project, repoSlug string, err error) {
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := range fields {
+		switch {
+		case fields[i] == "scm" && len(fields) == i+3:
+			project, repoSlug = fields[i+1], strings.TrimSuffix(fields[i+2], ".git")
+		case fields[i] == "projects" && len(fields) >= i+4 && fields[i+2] == "repos":
+			project, repoSlug = fields[i+1], fields[i+3]
+		case fields[i] == "users" && len(fields) >= i+4 && fields[i+2] == "repos":
+			project, repoSlug = "~"+fields[i+1], fields[i+3]
+		default:
+			continue
+		}
+		baseURL = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join("/", strings.Join(fields[:i], "/"))}
+		baseURL.Path = strings.TrimSuffix(baseURL.Path, "/")
+		return baseURL, project, repoSlug, nil
+	}
+	return nil, "", "", fmt.Errorf("invalid Bitbucket Server repository path: %s", u.Path)
+}
+
+type bitbucketServerUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+}
+
+type bitbucketServerLinks struct {
+	Clone []struct {
+		Href string `json:"href"`
+		Name string `json:"name"`
+	} `json:"clone"`
+	Self []struct {
+		Href string `json:"href"`
+	} `json:"self"`
+}
+
+func (l *bitbucketServerLinks) cloneURL() string {
+	for _, link := range l.Clone {
+		if link.Name == "http" || link.Name == "https" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+func (l *bitbucketServerLinks) selfURL() string {
+	if len(l.Self) == 0 {
+		return ""
+	}
+	return l.Self[0].Href
+}
+
+type bitbucketServerRepository struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Project     struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links bitbucketServerLinks `json:"links"`
+}
+
+type bitbucketServerRef struct {
+	ID           string                    `json:"id"`
+	DisplayID    string                    `json:"displayId"`
+	LatestCommit string                    `json:"latestCommit"`
+	Repository   bitbucketServerRepository `json:"repository"`
+}
+
+type bitbucketServerParticipant struct {
+	User     bitbucketServerUser `json:"user"`
+	Approved bool                `json:"approved"`
+	Status   string              `json:"status"` // APPROVED, NEEDS_WORK or UNAPPROVED
+	// LastReviewedCommit is the head commit of the pull request when the participant reviewed it
+	LastReviewedCommit string `json:"lastReviewedCommit"`
+}
+
+type bitbucketServerPullRequest struct {
+	ID          int64                        `json:"id"`
+	Title       string                       `json:"title"`
+	Description string                       `json:"description"`
+	State       string                       `json:"state"` // OPEN, DECLINED or MERGED
+	Draft       bool                         `json:"draft"`
+	Locked      bool                         `json:"locked"`
+	CreatedDate int64                        `json:"createdDate"`
+	UpdatedDate int64                        `json:"updatedDate"`
+	ClosedDate  int64                        `json:"closedDate"`
+	FromRef     bitbucketServerRef           `json:"fromRef"`
+	ToRef       bitbucketServerRef           `json:"toRef"`
+	Author      bitbucketServerParticipant   `json:"author"`
+	Reviewers   []bitbucketServerParticipant `json:"reviewers"`
+	Properties  struct {
+		MergeCommit struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+	Links bitbucketServerLinks `json:"links"`
+}
+
+type bitbucketServerComment struct {
+	ID          int64                     `json:"id"`
+	Text        string                    `json:"text"`
+	Author      bitbucketServerUser       `json:"author"`
+	CreatedDate int64                     `json:"createdDate"`
+	UpdatedDate int64                     `json:"updatedDate"`
+	Comments    []*bitbucketServerComment `json:"comments"` // the replies
+}
+
+type bitbucketServerActivity struct {
+	ID            int64                   `json:"id"`
+	CreatedDate   int64                   `json:"createdDate"`
+	User          bitbucketServerUser     `json:"user"`
+	Action        string                  `json:"action"`        // COMMENTED, APPROVED, REVIEWED, MERGED, DECLINED ...
+	CommentAction string                  `json:"commentAction"` // ADDED, EDITED, DELETED or REPLIED
+	Comment       *bitbucketServerComment `json:"comment"`
+	CommentAnchor *struct {
+		Path     string `json:"path"`
+		Line     int    `json:"line"`
+		FileType string `json:"fileType"` // FROM (the old file) or TO (the new file)
+		ToHash   string `json:"toHash"`
+	} `json:"commentAnchor"`
+	Commit *struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+type bitbucketServerPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// bitbucketServerPullRequestContext keeps the comments and the reviews read from the activities of a pull request
+type bitbucketServerPullRequestContext struct {
+	Comments []*base.Comment
+	Reviews  []*base.Review
+}
+
+// BitbucketServerDownloader implements a Downloader interface to get repository information
+// from Bitbucket Server (Bitbucket Data Center) by its REST API
+type BitbucketServerDownloader struct {
+	base.NullDownloader
+	client   *http.Client
+	baseURL  *url.URL
+	project  string
+	repoSlug string
+	username string
+	password string
+	token    string
+}
+
+// NewBitbucketServerDownloader creates a Bitbucket Server downloader
+func NewBitbucketServerDownloader(_ context.Context, baseURL *url.URL, project, repoSlug, username, password, token string) *BitbucketServerDownloader {
+	return &BitbucketServerDownloader{
+		client:   NewMigrationHTTPClient(),
+		baseURL:  baseURL,
+		project:  project,
+		repoSlug: repoSlug,
+		username: username,
+		password: password,
+		token:    token,
+	}
+}
+
+// String implements Stringer
+func (d *BitbucketServerDownloader) String() string {
+	return fmt.Sprintf("migration from bitbucket server %s %s/%s", d.baseURL, d.project, d.repoSlug)
+}
+
+func (d *BitbucketServerDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketServerDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketServerDownloader %s %s/%s>", d.baseURL, d.project, d.repoSlug)
+}
+
+func (d *BitbucketServerDownloader) repoEndpoint(elems ...string) string {
+	return "/projects/" + url.PathEscape(d.project) + "/repos/" + url.PathEscape(d.repoSlug) + strings.Join(elems, "")
+}
+
+func (d *BitbucketServerDownloader) callAPI(ctx context.Context, endpoint string, query url.Values, result any) error {
+	u := *d.baseURL
+	u.Path += "/rest/api/1.0" + endpoint
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if d.token != "" {
+		req.Header.Set("Authorization", "Bearer "+d.token)
+	} else if d.username != "" {
+		req.SetBasicAuth(d.username, d.password)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("bitbucket server API %s responded %s: %s", u.Path, resp.Status, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// callBitbucketServerAPIPaged reads all the pages of a paged API
+func callBitbucketServerAPIPaged[T any](ctx context.Context, d *BitbucketServerDownloader, endpoint string) ([]T, error) {
+	var all []T
+	for start := 0; ; {
+		var page bitbucketServerPage[T]
+		if err := d.callAPI(ctx, endpoint, url.Values{"start": {strconv.Itoa(start)}, "limit": {"100"}}, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Values...)
+		if page.IsLastPage || page.NextPageStart <= start {
+			return all, nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+func bitbucketServerTime(millis int64) time.Time {
+	return time.UnixMilli(millis)
+}
+
+// GetRepoInfo returns repository information
+// https://developer.atlassian.com/server/bitbucket/rest/v900/api-group-repository/#api-api-latest-projects-projectkey-repos-repositoryslug-get
+func (d *BitbucketServerDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo bitbucketServerRepository
+	if err := d.callAPI(ctx, d.repoEndpoint(), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	var defaultBranch struct {
+		DisplayID string `json:"displayId"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint("/default-branch"), nil, &defaultBranch); err != nil {
+		// an empty repository has no default branch
+		log.Debug("Unable to get the default branch of %s: %v", d, err)
+	}
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         repo.Project.Key,
+		Description:   repo.Description,
+		CloneURL:      repo.Links.cloneURL(),
+		OriginalURL:   repo.Links.selfURL(),
+		DefaultBranch: defaultBranch.DisplayID,
+	}, nil
+}
+
+// GetPullRequests returns pull requests according page and perPage
+// https://developer.atlassian.com/server/bitbucket/rest/v900/api-group-pull-requests/#api-api-latest-projects-projectkey-repos-repositoryslug-pull-requests-get
+func (d *BitbucketServerDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	var rawPullRequests bitbucketServerPage[*bitbucketServerPullRequest]
+	err := d.callAPI(ctx, d.repoEndpoint("/pull-requests"), url.Values{
+		"state": {"ALL"},
+		"order": {"OLDEST"},
+		"start": {strconv.Itoa((page - 1) * perPage)},
+		"limit": {strconv.Itoa(perPage)},
+	}, &rawPullRequests)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(rawPullRequests.Values))
+	for _, pr := range rawPullRequests.Values {
+		activities, err := callBitbucketServerAPIPaged[*bitbucketServerActivity](ctx, d, d.repoEndpoint("/pull-requests/", strconv.FormatInt(pr.ID, 10), "/activities"))
+		if err != nil {
+			return nil, false, err
+		}
+		// the activities are returned from the newest to the oldest
+		slices.Reverse(activities)
+
+		state := "open"
+		var closed, mergedTime *time.Time
+		mergeCommitSHA := pr.Properties.MergeCommit.ID
+		if pr.State != "OPEN" {
+			state = "closed"
+			closedTime := bitbucketServerTime(util.IfZero(pr.ClosedDate, pr.UpdatedDate))
+			closed = &closedTime
+		}
+		if pr.State == "MERGED" {
+			mergedTime = closed
+			for _, activity := range activities {
+				if activity.Action == "MERGED" && activity.Commit != nil && mergeCommitSHA == "" {
+					mergeCommitSHA = activity.Commit.ID
+				}
+			}
+		}
+
+		pullRequest := &base.PullRequest{
+			Number:         pr.ID,
+			Title:          pr.Title,
+			PosterID:       pr.Author.User.ID,
+			PosterName:     pr.Author.User.Name,
+			PosterEmail:    pr.Author.User.EmailAddress,
+			Content:        pr.Description,
+			State:          state,
+			Created:        bitbucketServerTime(pr.CreatedDate),
+			Updated:        bitbucketServerTime(pr.UpdatedDate),
+			Closed:         closed,
+			Merged:         pr.State == "MERGED",
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			Head: base.PullRequestBranch{
+				Ref:       pr.FromRef.DisplayID,
+				SHA:       pr.FromRef.LatestCommit,
+				RepoName:  pr.FromRef.Repository.Slug,
+				OwnerName: pr.FromRef.Repository.Project.Key,
+			},
+			Base: base.PullRequestBranch{
+				Ref:       pr.ToRef.DisplayID,
+				SHA:       pr.ToRef.LatestCommit,
+				RepoName:  pr.ToRef.Repository.Slug,
+				OwnerName: pr.ToRef.Repository.Project.Key,
+			},
+			IsLocked:     pr.Locked,
+			IsDraft:      pr.Draft,
+			ForeignIndex: pr.ID,
+		}
+		if pullRequest.IsForkPullRequest() {
+			pullRequest.Head.CloneURL = pr.FromRef.Repository.Links.cloneURL()
+		}
+		pullRequest.Context = bitbucketServerPullRequestContext{
+			Comments: d.convertComments(pr, activities),
+			Reviews:  d.convertReviews(pr, activities),
+		}
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequest, d.baseURL.String(), d)
+		pullRequests = append(pullRequests, pullRequest)
+	}
+
+	return pullRequests, rawPullRequests.IsLastPage, nil
+}
+
+// convertComments returns the general comments of a pull request and their replies
+func (d *BitbucketServerDownloader) convertComments(pr *bitbucketServerPullRequest, activities []*bitbucketServerActivity) []*base.Comment {
+	var comments []*base.Comment
+	var walk func(comment *bitbucketServerComment, prefix string)
+	walk = func(comment *bitbucketServerComment, prefix string) {
+		if comment.Text != "" {
+			comments = append(comments, &base.Comment{
+				IssueIndex:  pr.ID,
+				Index:       comment.ID,
+				PosterID:    comment.Author.ID,
+				PosterName:  comment.Author.Name,
+				PosterEmail: comment.Author.EmailAddress,
+				Content:     prefix + comment.Text,
+				Created:     bitbucketServerTime(comment.CreatedDate),
+				Updated:     bitbucketServerTime(comment.UpdatedDate),
+			})
+		}
+		for _, reply := range comment.Comments {
+			walk(reply, prefix)
+		}
+	}
+
+	for _, activity := range activities {
+		if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil {
+			continue
+		}
+		if anchor := activity.CommentAnchor; anchor == nil {
+			walk(activity.Comment, "")
+		} else if anchor.Line == 0 {
+			// the comments on a whole file can't be review comments
+			walk(activity.Comment, fmt.Sprintf("`%s`: ", anchor.Path))
+		}
+	}
+	slices.SortStableFunc(comments, func(a, b *base.Comment) int {
+		return a.Created.Compare(b.Created)
+	})
+	return comments
+}
+
+// convertReviews returns the approvals, the "needs work" reviews and the inline comments of a pull request,
+// every inline comment is a review of its author because the review comments are posted by the reviewer
+func (d *BitbucketServerDownloader) convertReviews(pr *bitbucketServerPullRequest, activities []*bitbucketServerActivity) []*base.Review {
+	var reviews []*base.Review
+	for _, reviewer := range pr.Reviewers {
+		var state, action string
+		switch reviewer.Status {
+		case "APPROVED":
+			state, action = base.ReviewStateApproved, "APPROVED"
+		case "NEEDS_WORK":
+			state, action = base.ReviewStateChangesRequested, "REVIEWED"
+		default:
+			continue
+		}
+		createdAt := bitbucketServerTime(pr.UpdatedDate)
+		for _, activity := range activities {
+			if activity.Action == action && activity.User.ID == reviewer.User.ID {
+				createdAt = bitbucketServerTime(activity.CreatedDate)
+			}
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   pr.ID,
+			ReviewerID:   reviewer.User.ID,
+			ReviewerName: reviewer.User.Name,
+			CommitID:     reviewer.LastReviewedCommit,
+			CreatedAt:    createdAt,
+			State:        state,
+		})
+	}
+
+	for _, activity := range activities {
+		anchor := activity.CommentAnchor
+		if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil || anchor == nil || anchor.Line == 0 {
+			continue
+		}
+		line := anchor.Line
+		if anchor.FileType == "FROM" {
+			line = -line
+		}
+		var walk func(comment *bitbucketServerComment, inReplyTo int64)
+		walk = func(comment *bitbucketServerComment, inReplyTo int64) {
+			if comment.Text != "" {
+				reviews = append(reviews, &base.Review{
+					IssueIndex:   pr.ID,
+					ReviewerID:   comment.Author.ID,
+					ReviewerName: comment.Author.Name,
+					CommitID:     anchor.ToHash,
+					CreatedAt:    bitbucketServerTime(comment.CreatedDate),
+					State:        base.ReviewStateCommented,
+					Comments: []*base.ReviewComment{{
+						ID:        comment.ID,
+						InReplyTo: inReplyTo,
+						Content:   comment.Text,
+						TreePath:  anchor.Path,
+						Line:      line,
+						CommitID:  anchor.ToHash,
+						PosterID:  comment.Author.ID,
+						CreatedAt: bitbucketServerTime(comment.CreatedDate),
+						UpdatedAt: bitbucketServerTime(comment.UpdatedDate),
+					}},
+				})
+			}
+			for _, reply := range comment.Comments {
+				walk(reply, comment.ID)
+			}
+		}
+		walk(activity.Comment, 0)
+	}
+	slices.SortStableFunc(reviews, func(a, b *base.Review) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return reviews
+}
+
+// GetComments returns the comments of a pull request
+func (d *BitbucketServerDownloader) GetComments(_ context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	prContext, ok := commentable.GetContext().(bitbucketServerPullRequestContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+	return prContext.Comments, true, nil
+}
+
+// GetReviews returns the reviews of a pull request
+func (d *BitbucketServerDownloader) GetReviews(_ context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	pr, ok := reviewable.(*base.PullRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reviewable: %+v", reviewable)
+	}
+	prContext, ok := pr.Context.(bitbucketServerPullRequestContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected context: %+v", pr.Context)
+	}
+	return prContext.Reviews, nil
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketServerDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if opts.AuthToken != "" {
+		// the HTTP access tokens can be used as the password of any user name
+		u.User = url.UserPassword(util.IfZero(opts.AuthUsername, "x-token-auth"), opts.AuthToken)
+	} else if opts.AuthUsername != "" {
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthPassword)
+	}
+	return u.String(), nil
+}
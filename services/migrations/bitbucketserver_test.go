@@ -0,0 +1,211 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	base "code.gitea.io/gitea/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBitbucketServerURL(t *testing.T) {
+	cases := []struct {
+		url, baseURL, project, repoSlug string
+	}{
+		{"https://bitbucket.example.com/scm/PROJ/repo.git", "https://bitbucket.example.com", "PROJ", "repo"},
+		{"https://user@bitbucket.example.com/bitbucket/scm/PROJ/repo.git", "https://bitbucket.example.com/bitbucket", "PROJ", "repo"},
+		{"https://bitbucket.example.com/scm/~user/repo.git", "https://bitbucket.example.com", "~user", "repo"},
+		{"https://bitbucket.example.com/projects/PROJ/repos/repo/browse", "https://bitbucket.example.com", "PROJ", "repo"},
+		{"https://bitbucket.example.com/users/user/repos/repo", "https://bitbucket.example.com", "~user", "repo"},
+	}
+	for _, c := range cases {
+		u, err := url.Parse(c.url)
+		require.NoError(t, err)
+		baseURL, project, repoSlug, err := parseBitbucketServerURL(u)
+		require.NoError(t, err, c.url)
+		assert.Equal(t, c.baseURL, baseURL.String(), c.url)
+		assert.Equal(t, c.project, project, c.url)
+		assert.Equal(t, c.repoSlug, repoSlug, c.url)
+	}
+
+	_, _, _, err := parseBitbucketServerURL(&url.URL{Scheme: "https", Host: "bitbucket.example.com", Path: "/PROJ/repo"})
+	assert.Error(t, err)
+}
+
+const bitbucketServerTestRepo = `{
+	"slug": "repo", "name": "Repo", "description": "test repository", "project": {"key": "PROJ"},
+	"links": {
+		"clone": [{"href": "ssh://git@HOST/proj/repo.git", "name": "ssh"}, {"href": "http://HOST/scm/proj/repo.git", "name": "http"}],
+		"self": [{"href": "http://HOST/projects/PROJ/repos/repo/browse"}]
+	}
+}`
+
+const bitbucketServerTestPullRequests = `{"isLastPage": true, "values": [{
+	"id": 1, "title": "Add feature", "description": "feature description", "state": "MERGED",
+	"createdDate": 1700000000000, "updatedDate": 1700000500000, "closedDate": 1700000400000,
+	"fromRef": {"displayId": "feature", "latestCommit": "1111111111111111111111111111111111111111", "repository": {"slug": "repo", "project": {"key": "PROJ"}}},
+	"toRef": {"displayId": "main", "latestCommit": "2222222222222222222222222222222222222222", "repository": {"slug": "repo", "project": {"key": "PROJ"}}},
+	"author": {"user": {"id": 1, "name": "alice", "emailAddress": "alice@example.com"}},
+	"reviewers": [
+		{"user": {"id": 2, "name": "bob"}, "status": "APPROVED", "lastReviewedCommit": "1111111111111111111111111111111111111111"},
+		{"user": {"id": 3, "name": "carol"}, "status": "UNAPPROVED"}
+	],
+	"properties": {"mergeCommit": {"id": "3333333333333333333333333333333333333333"}}
+}]}`
+
+const bitbucketServerTestActivities = `{"isLastPage": true, "values": [
+	{"id": 14, "createdDate": 1700000400000, "user": {"id": 1, "name": "alice"}, "action": "MERGED"},
+	{"id": 13, "createdDate": 1700000300000, "user": {"id": 2, "name": "bob"}, "action": "APPROVED"},
+	{"id": 12, "createdDate": 1700000200000, "user": {"id": 2, "name": "bob"}, "action": "COMMENTED", "commentAction": "ADDED",
+		"comment": {"id": 102, "text": "Rename it", "author": {"id": 2, "name": "bob"}, "createdDate": 1700000200000, "updatedDate": 1700000200000,
+			"comments": [{"id": 103, "text": "Done", "author": {"id": 1, "name": "alice"}, "createdDate": 1700000250000, "updatedDate": 1700000250000}]},
+		"commentAnchor": {"path": "main.go", "line": 5, "fileType": "TO", "toHash": "1111111111111111111111111111111111111111"}},
+	{"id": 11, "createdDate": 1700000100000, "user": {"id": 3, "name": "carol"}, "action": "COMMENTED", "commentAction": "ADDED",
+		"comment": {"id": 101, "text": "Looks good", "author": {"id": 3, "name": "carol"}, "createdDate": 1700000100000, "updatedDate": 1700000100000}}
+]}`
+
+func TestBitbucketServerDownloadRepo(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/bitbucket/rest/api/1.0/projects/PROJ/repos/repo":
+			_, _ = w.Write([]byte(strings.ReplaceAll(bitbucketServerTestRepo, "HOST", server.Listener.Addr().String())))
+		case "/bitbucket/rest/api/1.0/projects/PROJ/repos/repo/default-branch":
+			_, _ = w.Write([]byte(`{"displayId": "main"}`))
+		case "/bitbucket/rest/api/1.0/projects/PROJ/repos/repo/pull-requests":
+			assert.Equal(t, "ALL", r.URL.Query().Get("state"))
+			_, _ = w.Write([]byte(bitbucketServerTestPullRequests))
+		case "/bitbucket/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/1/activities":
+			_, _ = w.Write([]byte(bitbucketServerTestActivities))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := t.Context()
+	u, _ := url.Parse(server.URL + "/bitbucket/scm/PROJ/repo.git")
+	baseURL, project, repoSlug, err := parseBitbucketServerURL(u)
+	require.NoError(t, err)
+	downloader := NewBitbucketServerDownloader(ctx, baseURL, project, repoSlug, "", "", "token")
+	downloader.client = server.Client()
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "Repo",
+		Owner:         "PROJ",
+		Description:   "test repository",
+		CloneURL:      server.URL + "/scm/proj/repo.git",
+		OriginalURL:   server.URL + "/projects/PROJ/repos/repo/browse",
+		DefaultBranch: "main",
+	}, repo)
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	closed := time.UnixMilli(1700000400000)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:         1,
+			Title:          "Add feature",
+			PosterID:       1,
+			PosterName:     "alice",
+			PosterEmail:    "alice@example.com",
+			Content:        "feature description",
+			State:          "closed",
+			Created:        time.UnixMilli(1700000000000),
+			Updated:        time.UnixMilli(1700000500000),
+			Closed:         &closed,
+			Merged:         true,
+			MergedTime:     &closed,
+			MergeCommitSHA: "3333333333333333333333333333333333333333",
+			Head: base.PullRequestBranch{
+				Ref:       "feature",
+				SHA:       "1111111111111111111111111111111111111111",
+				RepoName:  "repo",
+				OwnerName: "PROJ",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "2222222222222222222222222222222222222222",
+				RepoName:  "repo",
+				OwnerName: "PROJ",
+			},
+			ForeignIndex: 1,
+		},
+	}, prs)
+
+	comments, _, err := downloader.GetComments(ctx, prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex: 1,
+			PosterID:   3,
+			PosterName: "carol",
+			Content:    "Looks good",
+			Created:    time.UnixMilli(1700000100000),
+			Updated:    time.UnixMilli(1700000100000),
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   1,
+			ReviewerID:   2,
+			ReviewerName: "bob",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.UnixMilli(1700000200000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        102,
+				Content:   "Rename it",
+				TreePath:  "main.go",
+				Line:      5,
+				CommitID:  "1111111111111111111111111111111111111111",
+				PosterID:  2,
+				CreatedAt: time.UnixMilli(1700000200000),
+				UpdatedAt: time.UnixMilli(1700000200000),
+			}},
+		},
+		{
+			IssueIndex:   1,
+			ReviewerID:   1,
+			ReviewerName: "alice",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.UnixMilli(1700000250000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        103,
+				InReplyTo: 102,
+				Content:   "Done",
+				TreePath:  "main.go",
+				Line:      5,
+				CommitID:  "1111111111111111111111111111111111111111",
+				PosterID:  1,
+				CreatedAt: time.UnixMilli(1700000250000),
+				UpdatedAt: time.UnixMilli(1700000250000),
+			}},
+		},
+		{
+			IssueIndex:   1,
+			ReviewerID:   2,
+			ReviewerName: "bob",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.UnixMilli(1700000300000),
+			State:        base.ReviewStateApproved,
+		},
+	}, reviews)
+}
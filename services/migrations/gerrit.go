@@ -0,0 +1,555 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	_ base.Downloader        = &GerritDownloader{}
+	_ base.DownloaderFactory = &GerritDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&GerritDownloaderFactory{})
+}
+
+// GerritDownloaderFactory defines a gerrit downloader factory
+type GerritDownloaderFactory struct{}
+
+// New returns a Downloader related to this factory according MigrateOptions
+func (f *GerritDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, project, err := parseGerritURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Trace("Create Gerrit downloader. BaseURL: %s Project: %s", baseURL, project)
+
+	return NewGerritDownloader(ctx, baseURL, project, opts.AuthUsername, util.IfZero(opts.AuthPassword, opts.AuthToken)), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *GerritDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.GerritService
+}
+
+// parseGerritURL returns the base URL and the project name of a Gerrit project URL.
+// The change URLs (/c/project/+/123), the repository URLs (/admin/repos/project) and the authenticated clone URLs (/a/project)
+// may be served under a context path, the other URLs are considered as the clone URLs of an instance served at the root.
+func parseGerritURL(u *url.URL) (baseURL *url.URL, project string, err error) {
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	contextPathEnd, projectFields := 0, fields
+	for i := range fields {
+		switch {
+		case fields[i] == "c" && slices.Contains(fields[i+1:], "+"):
+			contextPathEnd, projectFields = i, fields[i+1:i+1+slices.Index(fields[i+1:], "+")]
+		case fields[i] == "admin" && len(fields) > i+2 && fields[i+1] == "repos":
+			contextPathEnd, projectFields = i, fields[i+2:]
+		case fields[i] == "a" && len(fields) > i+1:
+			contextPathEnd, projectFields = i, fields[i+1:]
+		default:
+			continue
+		}
+		break
+	}
+
+	project = strings.TrimSuffix(strings.Join(projectFields, "/"), ".git")
+	project, _, _ = strings.Cut(project, ",") // eg: /admin/repos/project,general
+	if project == "" {
+		return nil, "", fmt.Errorf("invalid Gerrit project path: %s", u.Path)
+	}
+	baseURL = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: strings.TrimSuffix("/"+strings.Join(fields[:contextPathEnd], "/"), "/")}
+	return baseURL, project, nil
+}
+
+// gerritTime is the time format of the Gerrit REST API, the times are in UTC
+type gerritTime struct {
+	time.Time
+}
+
+func (t *gerritTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseInLocation("2006-01-02 15:04:05.999999999", s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+type gerritAccount struct {
+	AccountID int64  `json:"_account_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+}
+
+// externalName returns the user name of the account, which is used to map it to a local user
+func (a *gerritAccount) externalName() string {
+	if a == nil {
+		return "Gerrit Code Review"
+	}
+	return util.IfZero(a.Username, a.Name)
+}
+
+func (a *gerritAccount) externalID() int64 {
+	if a == nil {
+		return 0
+	}
+	return a.AccountID
+}
+
+func (a *gerritAccount) email() string {
+	if a == nil {
+		return ""
+	}
+	return a.Email
+}
+
+type gerritRevision struct {
+	Number  int        `json:"_number"`
+	Ref     string     `json:"ref"`
+	Created gerritTime `json:"created"`
+	Commit  struct {
+		Parents []struct {
+			Commit string `json:"commit"`
+		} `json:"parents"`
+		Subject string `json:"subject"`
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+type gerritApproval struct {
+	gerritAccount
+	Value int        `json:"value"`
+	Date  gerritTime `json:"date"`
+}
+
+type gerritChange struct {
+	ID              string                     `json:"id"`
+	Project         string                     `json:"project"`
+	Branch          string                     `json:"branch"`
+	ChangeID        string                     `json:"change_id"`
+	Subject         string                     `json:"subject"`
+	Status          string                     `json:"status"` // NEW, MERGED or ABANDONED
+	Created         gerritTime                 `json:"created"`
+	Updated         gerritTime                 `json:"updated"`
+	Number          int64                      `json:"_number"`
+	Owner           gerritAccount              `json:"owner"`
+	WorkInProgress  bool                       `json:"work_in_progress"`
+	CurrentRevision string                     `json:"current_revision"`
+	Revisions       map[string]*gerritRevision `json:"revisions"`
+	Labels          map[string]struct {
+		All []*gerritApproval `json:"all"`
+	} `json:"labels"`
+	Messages []*struct {
+		ID             string         `json:"id"`
+		Author         *gerritAccount `json:"author"`
+		Date           gerritTime     `json:"date"`
+		Message        string         `json:"message"`
+		RevisionNumber int            `json:"_revision_number"`
+	} `json:"messages"`
+	Reviewers   map[string][]*gerritAccount `json:"reviewers"` // REVIEWER, CC
+	MoreChanges bool                        `json:"_more_changes"`
+}
+
+// revisionSHA returns the commit of a patch set
+func (c *gerritChange) revisionSHA(patchSet int) string {
+	for sha, revision := range c.Revisions {
+		if revision.Number == patchSet {
+			return sha
+		}
+	}
+	return ""
+}
+
+type gerritComment struct {
+	ID        string         `json:"id"`
+	PatchSet  int            `json:"patch_set"`
+	CommitID  string         `json:"commit_id"`
+	Path      string         `json:"path"`
+	Side      string         `json:"side"` // PARENT for the comments on the old file
+	Line      int            `json:"line"`
+	InReplyTo string         `json:"in_reply_to"`
+	Message   string         `json:"message"`
+	Updated   gerritTime     `json:"updated"`
+	Author    *gerritAccount `json:"author"`
+}
+
+// gerritChangeContext keeps the change and its inline comments
+type gerritChangeContext struct {
+	Change   *gerritChange
+	Comments []*gerritComment
+}
+
+// GerritDownloader implements a Downloader interface to get the project and its open changes from Gerrit by its REST API,
+// the changes are migrated as pull requests whose head is the current patch set, the votes are migrated as reviews.
+type GerritDownloader struct {
+	base.NullDownloader
+	client   *http.Client
+	baseURL  *url.URL
+	project  string
+	username string
+	password string
+}
+
+// NewGerritDownloader creates a Gerrit downloader, the password is the HTTP password of the user
+func NewGerritDownloader(_ context.Context, baseURL *url.URL, project, username, password string) *GerritDownloader {
+	return &GerritDownloader{
+		client:   NewMigrationHTTPClient(),
+		baseURL:  baseURL,
+		project:  project,
+		username: username,
+		password: password,
+	}
+}
+
+// String implements Stringer
+func (d *GerritDownloader) String() string {
+	return fmt.Sprintf("migration from gerrit %s %s", d.baseURL, d.project)
+}
+
+func (d *GerritDownloader) LogString() string {
+	if d == nil {
+		return "<GerritDownloader nil>"
+	}
+	return fmt.Sprintf("<GerritDownloader %s %s>", d.baseURL, d.project)
+}
+
+// authPrefix returns the prefix of the paths which require the authentication
+func (d *GerritDownloader) authPrefix() string {
+	if d.username != "" {
+		return "/a"
+	}
+	return ""
+}
+
+// gerritMagicPrefix prevents the JSON responses from being used by the cross-site scripting attacks
+var gerritMagicPrefix = []byte(")]}'")
+
+func (d *GerritDownloader) callAPI(ctx context.Context, endpoint string, query url.Values, result any) error {
+	apiURL := d.baseURL.String() + d.authPrefix() + endpoint
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if d.username != "" {
+		req.SetBasicAuth(d.username, d.password)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("gerrit API %s responded %s: %s", endpoint, resp.Status, body)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes.TrimPrefix(body, gerritMagicPrefix), result)
+}
+
+// GetRepoInfo returns repository information
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-project
+func (d *GerritDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var project struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if err := d.callAPI(ctx, "/projects/"+url.PathEscape(d.project), nil, &project); err != nil {
+		return nil, err
+	}
+
+	var head string
+	if err := d.callAPI(ctx, "/projects/"+url.PathEscape(d.project)+"/HEAD", nil, &head); err != nil {
+		log.Debug("Unable to get the HEAD of %s: %v", d, err)
+	}
+
+	owner, name := path.Split(project.Name)
+	return &base.Repository{
+		Name:          name,
+		Owner:         strings.TrimSuffix(owner, "/"),
+		Description:   project.Description,
+		CloneURL:      d.baseURL.String() + d.authPrefix() + "/" + project.Name,
+		OriginalURL:   d.baseURL.String() + "/admin/repos/" + url.PathEscape(project.Name),
+		DefaultBranch: strings.TrimPrefix(head, "refs/heads/"),
+	}, nil
+}
+
+// GetPullRequests returns the open changes as pull requests according page and perPage
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#list-changes
+func (d *GerritDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	var changes []*gerritChange
+	err := d.callAPI(ctx, "/changes/", url.Values{
+		"q": {fmt.Sprintf("project:{%s} status:open", d.project)},
+		"o": {"ALL_REVISIONS", "ALL_COMMITS", "DETAILED_ACCOUNTS", "DETAILED_LABELS", "MESSAGES"},
+		"n": {strconv.Itoa(perPage)},
+		"S": {strconv.Itoa((page - 1) * perPage)},
+	}, &changes)
+	if err != nil {
+		return nil, false, err
+	}
+
+	owner, name := path.Split(d.project)
+	owner = strings.TrimSuffix(owner, "/")
+	pullRequests := make([]*base.PullRequest, 0, len(changes))
+	for _, change := range changes {
+		// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#list-change-comments
+		var commentsByPath map[string][]*gerritComment
+		if err := d.callAPI(ctx, "/changes/"+strconv.FormatInt(change.Number, 10)+"/comments", nil, &commentsByPath); err != nil {
+			return nil, false, err
+		}
+		var comments []*gerritComment
+		for filePath, fileComments := range commentsByPath {
+			for _, comment := range fileComments {
+				comment.Path = filePath
+				comments = append(comments, comment)
+			}
+		}
+
+		revision := change.Revisions[change.CurrentRevision]
+		if revision == nil {
+			log.Warn("Change %d of %s has no current revision", change.Number, d)
+			continue
+		}
+		var baseSHA string
+		if len(revision.Commit.Parents) > 0 {
+			baseSHA = revision.Commit.Parents[0].Commit
+		}
+		_, body, _ := strings.Cut(revision.Commit.Message, "\n")
+
+		pullRequest := &base.PullRequest{
+			Number:      change.Number,
+			Title:       change.Subject,
+			PosterID:    change.Owner.externalID(),
+			PosterName:  change.Owner.externalName(),
+			PosterEmail: change.Owner.email(),
+			Content:     strings.TrimSpace(body),
+			State:       "open",
+			Created:     change.Created.Time,
+			Updated:     change.Updated.Time,
+			// the patch sets are fetched with the repository (refs/changes/*), the current patch set is the head
+			Head: base.PullRequestBranch{
+				Ref:       strings.TrimPrefix(revision.Ref, "refs/"),
+				SHA:       change.CurrentRevision,
+				RepoName:  name,
+				OwnerName: owner,
+			},
+			Base: base.PullRequestBranch{
+				Ref:       change.Branch,
+				SHA:       baseSHA,
+				RepoName:  name,
+				OwnerName: owner,
+			},
+			IsDraft:      change.WorkInProgress,
+			ForeignIndex: change.Number,
+			Context:      gerritChangeContext{Change: change, Comments: comments},
+		}
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequest, d.baseURL.String(), d)
+		pullRequests = append(pullRequests, pullRequest)
+	}
+
+	isEnd := len(changes) == 0 || !changes[len(changes)-1].MoreChanges
+	return pullRequests, isEnd, nil
+}
+
+// GetComments returns the change messages and the comments which aren't on the lines of the files
+func (d *GerritDownloader) GetComments(_ context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	changeContext, ok := commentable.GetContext().(gerritChangeContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+
+	var comments []*base.Comment
+	for _, message := range changeContext.Change.Messages {
+		comments = append(comments, &base.Comment{
+			IssueIndex:  commentable.GetLocalIndex(),
+			PosterID:    message.Author.externalID(),
+			PosterName:  message.Author.externalName(),
+			PosterEmail: message.Author.email(),
+			Content:     message.Message,
+			Created:     message.Date.Time,
+			Updated:     message.Date.Time,
+		})
+	}
+	for _, comment := range changeContext.Comments {
+		if comment.Line > 0 && !strings.HasPrefix(comment.Path, "/") {
+			continue
+		}
+		content := comment.Message
+		// the patch set level comments are general comments, the others are on a file or the commit message
+		if comment.Path != "/PATCHSET_LEVEL" {
+			content = fmt.Sprintf("`%s`: %s", comment.Path, comment.Message)
+		}
+		comments = append(comments, &base.Comment{
+			IssueIndex:  commentable.GetLocalIndex(),
+			PosterID:    comment.Author.externalID(),
+			PosterName:  comment.Author.externalName(),
+			PosterEmail: comment.Author.email(),
+			Content:     content,
+			Created:     comment.Updated.Time,
+			Updated:     comment.Updated.Time,
+		})
+	}
+	slices.SortStableFunc(comments, func(a, b *base.Comment) int {
+		return a.Created.Compare(b.Created)
+	})
+	return comments, true, nil
+}
+
+// GetReviews returns the votes, the review requests of the reviewers who haven't voted and the comments on the lines,
+// every comment on a line is a review of its author because the review comments are posted by the reviewer
+func (d *GerritDownloader) GetReviews(_ context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	pr, ok := reviewable.(*base.PullRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reviewable: %+v", reviewable)
+	}
+	changeContext, ok := pr.Context.(gerritChangeContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected context: %+v", pr.Context)
+	}
+	change := changeContext.Change
+
+	// the votes of an account on all the labels are a review, its state is decided by the Code-Review label
+	type vote struct {
+		account *gerritAccount
+		labels  []string
+		state   string
+		date    time.Time
+	}
+	votes := map[int64]*vote{}
+	var voters []int64
+	labelNames := make([]string, 0, len(change.Labels))
+	for labelName := range change.Labels {
+		labelNames = append(labelNames, labelName)
+	}
+	slices.Sort(labelNames)
+	for _, labelName := range labelNames {
+		for _, approval := range change.Labels[labelName].All {
+			if approval.Value == 0 {
+				continue
+			}
+			v, ok := votes[approval.AccountID]
+			if !ok {
+				v = &vote{account: &approval.gerritAccount, state: base.ReviewStateCommented}
+				votes[approval.AccountID] = v
+				voters = append(voters, approval.AccountID)
+			}
+			v.labels = append(v.labels, fmt.Sprintf("%s%+d", labelName, approval.Value))
+			if approval.Date.After(v.date) {
+				v.date = approval.Date.Time
+			}
+			if labelName == "Code-Review" {
+				v.state = util.Iif(approval.Value > 0, base.ReviewStateApproved, base.ReviewStateChangesRequested)
+			}
+		}
+	}
+
+	var reviews []*base.Review
+	for _, voter := range voters {
+		v := votes[voter]
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   pr.Number,
+			ReviewerID:   v.account.externalID(),
+			ReviewerName: v.account.externalName(),
+			CommitID:     change.CurrentRevision,
+			Content:      strings.Join(v.labels, " "),
+			CreatedAt:    v.date,
+			State:        v.state,
+		})
+	}
+	for _, reviewer := range change.Reviewers["REVIEWER"] {
+		if _, voted := votes[reviewer.AccountID]; voted || reviewer.AccountID == change.Owner.AccountID {
+			continue
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   pr.Number,
+			ReviewerID:   reviewer.externalID(),
+			ReviewerName: reviewer.externalName(),
+			CreatedAt:    pr.Created,
+			State:        base.ReviewStateRequestReview,
+		})
+	}
+
+	for _, comment := range changeContext.Comments {
+		if comment.Line <= 0 || strings.HasPrefix(comment.Path, "/") {
+			continue
+		}
+		line := comment.Line
+		if comment.Side == "PARENT" {
+			line = -line
+		}
+		commitID := util.IfZero(comment.CommitID, change.revisionSHA(comment.PatchSet))
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   pr.Number,
+			ReviewerID:   comment.Author.externalID(),
+			ReviewerName: comment.Author.externalName(),
+			CommitID:     commitID,
+			CreatedAt:    comment.Updated.Time,
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				Content:   comment.Message,
+				TreePath:  comment.Path,
+				Line:      line,
+				CommitID:  commitID,
+				PosterID:  comment.Author.externalID(),
+				CreatedAt: comment.Updated.Time,
+				UpdatedAt: comment.Updated.Time,
+			}},
+		})
+	}
+	slices.SortStableFunc(reviews, func(a, b *base.Review) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return reviews, nil
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *GerritDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if opts.AuthUsername != "" {
+		u.User = url.UserPassword(opts.AuthUsername, util.IfZero(opts.AuthPassword, opts.AuthToken))
+	}
+	return u.String(), nil
+}
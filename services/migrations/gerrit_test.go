@@ -0,0 +1,237 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	base "code.gitea.io/gitea/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGerritURL(t *testing.T) {
+	cases := []struct {
+		url, baseURL, project string
+	}{
+		{"https://gerrit.example.com/platform/build", "https://gerrit.example.com", "platform/build"},
+		{"https://gerrit.example.com/platform/build.git", "https://gerrit.example.com", "platform/build"},
+		{"https://user@gerrit.example.com/r/a/platform/build", "https://gerrit.example.com/r", "platform/build"},
+		{"https://gerrit.example.com/r/c/platform/build/+/123/2", "https://gerrit.example.com/r", "platform/build"},
+		{"https://gerrit.example.com/admin/repos/platform%2Fbuild,general", "https://gerrit.example.com", "platform/build"},
+	}
+	for _, c := range cases {
+		u, err := url.Parse(c.url)
+		require.NoError(t, err)
+		baseURL, project, err := parseGerritURL(u)
+		require.NoError(t, err, c.url)
+		assert.Equal(t, c.baseURL, baseURL.String(), c.url)
+		assert.Equal(t, c.project, project, c.url)
+	}
+
+	_, _, err := parseGerritURL(&url.URL{Scheme: "https", Host: "gerrit.example.com", Path: "/"})
+	assert.Error(t, err)
+}
+
+const (
+	gerritTestAlice = `{"_account_id": 1000001, "name": "Alice", "email": "alice@example.com", "username": "alice"}`
+	gerritTestBob   = `{"_account_id": 1000002, "name": "Bob", "email": "bob@example.com", "username": "bob"}`
+	gerritTestCarol = `{"_account_id": 1000003, "name": "Carol", "email": "carol@example.com", "username": "carol"}`
+)
+
+var gerritTestResponses = map[string]string{
+	"/r/a/projects/platform%2Fbuild":      `{"id": "platform%2Fbuild", "name": "platform/build", "description": "build tools"}`,
+	"/r/a/projects/platform%2Fbuild/HEAD": `"refs/heads/main"`,
+	"/r/a/changes/": `[{
+		"id": "platform%2Fbuild~main~I0123", "project": "platform/build", "branch": "main", "change_id": "I0123",
+		"subject": "Add feature", "status": "NEW", "_number": 42, "work_in_progress": true,
+		"created": "2024-01-02 00:00:00.000000000", "updated": "2024-01-03 00:00:00.000000000",
+		"owner": ` + gerritTestAlice + `,
+		"current_revision": "2222222222222222222222222222222222222222",
+		"revisions": {
+			"1111111111111111111111111111111111111111": {"_number": 1, "ref": "refs/changes/42/42/1", "created": "2024-01-02 00:00:00.000000000",
+				"commit": {"parents": [{"commit": "0000000000000000000000000000000000000001"}], "subject": "Add feature", "message": "Add feature\n\nChange-Id: I0123\n"}},
+			"2222222222222222222222222222222222222222": {"_number": 2, "ref": "refs/changes/42/42/2", "created": "2024-01-02 12:00:00.000000000",
+				"commit": {"parents": [{"commit": "0000000000000000000000000000000000000002"}], "subject": "Add feature", "message": "Add feature\n\nWith details.\n\nChange-Id: I0123\n"}}
+		},
+		"labels": {
+			"Code-Review": {"all": [
+				{"_account_id": 1000002, "name": "Bob", "username": "bob", "value": 2, "date": "2024-01-02 14:00:00.000000000"},
+				{"_account_id": 1000003, "name": "Carol", "username": "carol", "value": 0}
+			]},
+			"Verified": {"all": [{"_account_id": 1000002, "name": "Bob", "username": "bob", "value": 1, "date": "2024-01-02 13:00:00.000000000"}]}
+		},
+		"messages": [
+			{"id": "m1", "author": ` + gerritTestAlice + `, "date": "2024-01-02 00:00:00.000000000", "message": "Uploaded patch set 1.", "_revision_number": 1},
+			{"id": "m2", "date": "2024-01-02 00:01:00.000000000", "message": "Build started", "_revision_number": 1}
+		],
+		"reviewers": {"REVIEWER": [` + gerritTestAlice + `, ` + gerritTestBob + `, ` + gerritTestCarol + `]}
+	}]`,
+	"/r/a/changes/42/comments": `{
+		"main.go": [
+			{"id": "c1", "patch_set": 1, "line": 10, "message": "typo", "updated": "2024-01-02 10:00:00.000000000", "author": ` + gerritTestBob + `},
+			{"id": "c2", "patch_set": 1, "side": "PARENT", "line": 3, "in_reply_to": "c1", "message": "old line", "updated": "2024-01-02 11:00:00.000000000", "author": ` + gerritTestAlice + `}
+		],
+		"/PATCHSET_LEVEL": [
+			{"id": "c3", "patch_set": 2, "message": "LGTM", "updated": "2024-01-02 14:00:00.000000000", "author": ` + gerritTestBob + `}
+		]
+	}`,
+}
+
+func TestGerritDownloadRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "user", username)
+		assert.Equal(t, "http-password", password)
+		if r.URL.Path == "/r/a/changes/" {
+			assert.Equal(t, "project:{platform/build} status:open", r.URL.Query().Get("q"))
+		}
+		resp, ok := gerritTestResponses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(")]}'\n" + resp))
+	}))
+	defer server.Close()
+
+	ctx := t.Context()
+	u, _ := url.Parse(server.URL + "/r/c/platform/build/+/42")
+	baseURL, project, err := parseGerritURL(u)
+	require.NoError(t, err)
+	downloader := NewGerritDownloader(ctx, baseURL, project, "user", "http-password")
+	downloader.client = server.Client()
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "build",
+		Owner:         "platform",
+		Description:   "build tools",
+		CloneURL:      server.URL + "/r/a/platform/build",
+		OriginalURL:   server.URL + "/r/admin/repos/platform%2Fbuild",
+		DefaultBranch: "main",
+	}, repo)
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:      42,
+			Title:       "Add feature",
+			PosterID:    1000001,
+			PosterName:  "alice",
+			PosterEmail: "alice@example.com",
+			Content:     "With details.\n\nChange-Id: I0123",
+			State:       "open",
+			Created:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			Head: base.PullRequestBranch{
+				Ref:       "changes/42/42/2",
+				SHA:       "2222222222222222222222222222222222222222",
+				RepoName:  "build",
+				OwnerName: "platform",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "0000000000000000000000000000000000000002",
+				RepoName:  "build",
+				OwnerName: "platform",
+			},
+			IsDraft:      true,
+			ForeignIndex: 42,
+		},
+	}, prs)
+
+	comments, _, err := downloader.GetComments(ctx, prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  42,
+			PosterID:    1000001,
+			PosterName:  "alice",
+			PosterEmail: "alice@example.com",
+			Content:     "Uploaded patch set 1.",
+			Created:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			IssueIndex: 42,
+			PosterName: "Gerrit Code Review",
+			Content:    "Build started",
+			Created:    time.Date(2024, 1, 2, 0, 1, 0, 0, time.UTC),
+			Updated:    time.Date(2024, 1, 2, 0, 1, 0, 0, time.UTC),
+		},
+		{
+			IssueIndex:  42,
+			PosterID:    1000002,
+			PosterName:  "bob",
+			PosterEmail: "bob@example.com",
+			Content:     "LGTM",
+			Created:     time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   42,
+			ReviewerID:   1000003,
+			ReviewerName: "carol",
+			CreatedAt:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateRequestReview,
+		},
+		{
+			IssueIndex:   42,
+			ReviewerID:   1000002,
+			ReviewerName: "bob",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				Content:   "typo",
+				TreePath:  "main.go",
+				Line:      10,
+				CommitID:  "1111111111111111111111111111111111111111",
+				PosterID:  1000002,
+				CreatedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   42,
+			ReviewerID:   1000001,
+			ReviewerName: "alice",
+			CommitID:     "1111111111111111111111111111111111111111",
+			CreatedAt:    time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				Content:   "old line",
+				TreePath:  "main.go",
+				Line:      -3,
+				CommitID:  "1111111111111111111111111111111111111111",
+				PosterID:  1000001,
+				CreatedAt: time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   42,
+			ReviewerID:   1000002,
+			ReviewerName: "bob",
+			CommitID:     "2222222222222222222222222222222222222222",
+			Content:      "Code-Review+2 Verified+1",
+			CreatedAt:    time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateApproved,
+		},
+	}, reviews)
+}
@@ -119,18 +119,21 @@ func (g *GiteaLocalUploader) CreateRepo(ctx context.Context, repo *base.Reposito
 	r.Description = repo.Description
 
 	r, err = repo_service.MigrateRepositoryGitData(ctx, owner, r, base.MigrateOptions{
-		RepoName:       g.repoName,
-		Description:    repo.Description,
-		OriginalURL:    repo.OriginalURL,
-		GitServiceType: opts.GitServiceType,
-		Mirror:         repo.IsMirror,
-		LFS:            opts.LFS,
-		LFSEndpoint:    opts.LFSEndpoint,
-		CloneAddr:      repo.CloneURL, // SECURITY: we will assume that this has already been checked
-		Private:        repo.IsPrivate,
-		Wiki:           opts.Wiki,
-		Releases:       opts.Releases, // if didn't get releases, then sync them from tags
-		MirrorInterval: opts.MirrorInterval,
+		RepoName:        g.repoName,
+		Description:     repo.Description,
+		OriginalURL:     repo.OriginalURL,
+		GitServiceType:  opts.GitServiceType,
+		Mirror:          repo.IsMirror,
+		LFS:             opts.LFS,
+		LFSEndpoint:     opts.LFSEndpoint,
+		CloneAddr:       repo.CloneURL, // SECURITY: we will assume that this has already been checked
+		Private:         repo.IsPrivate,
+		Wiki:            opts.Wiki,
+		Releases:        opts.Releases, // if didn't get releases, then sync them from tags
+		MirrorInterval:  opts.MirrorInterval,
+		MirrorRefFilter: opts.MirrorRefFilter,
+		MirrorSkipTags:  opts.MirrorSkipTags,
+		MirrorMaxSize:   opts.MirrorMaxSize,
 	}, NewMigrationHTTPTransport())
 
 	g.sameApp = strings.HasPrefix(repo.OriginalURL, setting.AppURL)
@@ -1021,7 +1024,38 @@ func (g *GiteaLocalUploader) remapExternalUser(ctx context.Context, source user_
 			log.Error("GetUserIDByExternalUserID: %v", err)
 			return 0, err
 		}
+		if userid == 0 && g.doer.IsAdmin {
+			if userid, err = g.remapUserByIdentity(ctx, source); err != nil {
+				return 0, err
+			}
+		}
 		g.userMap[source.GetExternalID()] = userid
 	}
 	return userid, nil
 }
+
+// remapUserByIdentity maps the users of the services sharing the identities with the local instance,
+// it is only used when the migration is made by an admin because the identities come from the migrated service
+func (g *GiteaLocalUploader) remapUserByIdentity(ctx context.Context, source user_model.ExternalUserMigrated) (int64, error) {
+	var u *user_model.User
+	var err error
+	switch g.gitServiceType {
+	case structs.BitbucketServerService, structs.GerritService:
+		// Bitbucket Server and Gerrit instances usually share the user directory (eg: LDAP) with the local instance
+		u, err = user_model.GetUserByName(ctx, source.GetExternalName())
+	case structs.AzureDevOpsService:
+		// the external names of the Azure DevOps identities are their emails
+		if !strings.Contains(source.GetExternalName(), "@") {
+			return 0, nil
+		}
+		u, err = user_model.GetUserByEmail(ctx, source.GetExternalName())
+	default:
+		return 0, nil
+	}
+	if user_model.IsErrUserNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return u.ID, nil
+}
@@ -0,0 +1,839 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var _ base.Uploader = &GitHubArchiveWriter{}
+
+// githubArchiveSchemaVersion is the version of the GitHub migration archive format written by GitHubArchiveWriter
+const githubArchiveSchemaVersion = "1.2.0"
+
+// githubArchiveURLTemplates are the URL templates of the records, the records reference each other by these URLs
+var githubArchiveURLTemplates = map[string]any{
+	"user":                        "{scheme}://{host}/{user}",
+	"organization":                "{scheme}://{host}/{organization}",
+	"repository":                  "{scheme}://{host}/{owner}/{repository}",
+	"milestone":                   "{scheme}://{host}/{owner}/{repository}/milestones/{milestone}",
+	"label":                       "{scheme}://{host}/{owner}/{repository}/labels/{label}",
+	"release":                     "{scheme}://{host}/{owner}/{repository}/releases/tag/{release}",
+	"issue":                       "{scheme}://{host}/{owner}/{repository}/issues/{number}",
+	"pull_request":                "{scheme}://{host}/{owner}/{repository}/pull/{number}",
+	"pull_request_review":         "{scheme}://{host}/{owner}/{repository}/pull/{number}/files#pullrequestreview-{pull_request_review}",
+	"pull_request_review_comment": "{scheme}://{host}/{owner}/{repository}/pull/{number}/files#r{pull_request_review_comment}",
+	"issue_comment": map[string]string{
+		"issue":        "{scheme}://{host}/{owner}/{repository}/issues/{number}#issuecomment-{issue_comment}",
+		"pull_request": "{scheme}://{host}/{owner}/{repository}/pull/{number}#issuecomment-{issue_comment}",
+	},
+}
+
+// the states of the pull request reviews in the archive
+const (
+	githubArchiveReviewStatePending          = 0
+	githubArchiveReviewStateCommented        = 1
+	githubArchiveReviewStateChangesRequested = 30
+	githubArchiveReviewStateApproved         = 40
+)
+
+type githubArchiveEmail struct {
+	Address  string `json:"address"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+type githubArchiveUser struct {
+	Type      string               `json:"type"`
+	URL       string               `json:"url"`
+	Login     string               `json:"login"`
+	Name      string               `json:"name"`
+	Emails    []githubArchiveEmail `json:"emails"`
+	CreatedAt *time.Time           `json:"created_at"`
+}
+
+type githubArchiveOrganization struct {
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	Login       string     `json:"login"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Members     []any      `json:"members"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+type githubArchiveLabel struct {
+	URL       string     `json:"url"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type githubArchiveRepository struct {
+	Type          string                `json:"type"`
+	URL           string                `json:"url"`
+	Owner         string                `json:"owner"`
+	Name          string                `json:"name"`
+	Description   string                `json:"description"`
+	Private       bool                  `json:"private"`
+	HasIssues     bool                  `json:"has_issues"`
+	HasWiki       bool                  `json:"has_wiki"`
+	HasDownloads  bool                  `json:"has_downloads"`
+	Labels        []*githubArchiveLabel `json:"labels"`
+	Collaborators []any                 `json:"collaborators"`
+	CreatedAt     *time.Time            `json:"created_at"`
+	GitURL        string                `json:"git_url"`
+	WikiURL       *string               `json:"wiki_url"`
+	DefaultBranch string                `json:"default_branch"`
+	PublicKeys    []any                 `json:"public_keys"`
+	Topics        []string              `json:"topics"`
+}
+
+type githubArchiveMilestone struct {
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	Repository  string     `json:"repository"`
+	User        string     `json:"user"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	DueOn       *time.Time `json:"due_on"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+type githubArchiveReaction struct {
+	User        string     `json:"user"`
+	Content     string     `json:"content"`
+	SubjectType string     `json:"subject_type"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+type githubArchiveIssue struct {
+	Type       string                   `json:"type"`
+	URL        string                   `json:"url"`
+	Repository string                   `json:"repository"`
+	User       string                   `json:"user"`
+	Title      string                   `json:"title"`
+	Body       string                   `json:"body"`
+	Milestone  *string                  `json:"milestone"`
+	Labels     []string                 `json:"labels"`
+	Assignee   *string                  `json:"assignee"`
+	Assignees  []string                 `json:"assignees"`
+	Reactions  []*githubArchiveReaction `json:"reactions"`
+	CreatedAt  *time.Time               `json:"created_at"`
+	UpdatedAt  *time.Time               `json:"updated_at"`
+	ClosedAt   *time.Time               `json:"closed_at"`
+}
+
+type githubArchivePullRequestBranch struct {
+	Ref  string `json:"ref"`
+	SHA  string `json:"sha"`
+	User string `json:"user"`
+	Repo string `json:"repo"`
+}
+
+type githubArchivePullRequest struct {
+	githubArchiveIssue
+	Base           githubArchivePullRequestBranch `json:"base"`
+	Head           githubArchivePullRequestBranch `json:"head"`
+	MergedAt       *time.Time                     `json:"merged_at"`
+	MergeCommitSHA *string                        `json:"merge_commit_sha"`
+	WorkInProgress bool                           `json:"work_in_progress"`
+	ReviewRequests []any                          `json:"review_requests"`
+}
+
+type githubArchiveIssueComment struct {
+	Type        string                   `json:"type"`
+	URL         string                   `json:"url"`
+	Issue       string                   `json:"issue,omitempty"`
+	PullRequest string                   `json:"pull_request,omitempty"`
+	User        string                   `json:"user"`
+	Body        string                   `json:"body"`
+	Formatter   string                   `json:"formatter"`
+	Reactions   []*githubArchiveReaction `json:"reactions"`
+	CreatedAt   *time.Time               `json:"created_at"`
+	UpdatedAt   *time.Time               `json:"updated_at"`
+}
+
+type githubArchiveReview struct {
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	PullRequest string     `json:"pull_request"`
+	User        string     `json:"user"`
+	Body        string     `json:"body"`
+	HeadSHA     string     `json:"head_sha"`
+	Formatter   string     `json:"formatter"`
+	State       int        `json:"state"`
+	CreatedAt   *time.Time `json:"created_at"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+}
+
+type githubArchiveReviewComment struct {
+	Type              string                   `json:"type"`
+	URL               string                   `json:"url"`
+	PullRequest       string                   `json:"pull_request"`
+	PullRequestReview string                   `json:"pull_request_review"`
+	InReplyTo         *string                  `json:"in_reply_to"`
+	User              string                   `json:"user"`
+	Body              string                   `json:"body"`
+	Formatter         string                   `json:"formatter"`
+	DiffHunk          string                   `json:"diff_hunk"`
+	Path              string                   `json:"path"`
+	Position          int                      `json:"position"`
+	OriginalPosition  int                      `json:"original_position"`
+	CommitID          string                   `json:"commit_id"`
+	OriginalCommitID  string                   `json:"original_commit_id"`
+	Reactions         []*githubArchiveReaction `json:"reactions"`
+	CreatedAt         *time.Time               `json:"created_at"`
+	UpdatedAt         *time.Time               `json:"updated_at"`
+}
+
+type githubArchiveReleaseAsset struct {
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	Release     string     `json:"release"`
+	User        string     `json:"user"`
+	Name        string     `json:"name"`
+	ContentType string     `json:"content_type"`
+	Size        int        `json:"size"`
+	State       string     `json:"state"`
+	AssetURL    string     `json:"asset_url"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+type githubArchiveRelease struct {
+	Type            string                       `json:"type"`
+	URL             string                       `json:"url"`
+	Repository      string                       `json:"repository"`
+	User            string                       `json:"user"`
+	Name            string                       `json:"name"`
+	TagName         string                       `json:"tag_name"`
+	Body            string                       `json:"body"`
+	State           string                       `json:"state"`
+	PendingTag      string                       `json:"pending_tag"`
+	Prerelease      bool                         `json:"prerelease"`
+	TargetCommitish string                       `json:"target_commitish"`
+	ReleaseAssets   []*githubArchiveReleaseAsset `json:"release_assets"`
+	PublishedAt     *time.Time                   `json:"published_at"`
+	CreatedAt       *time.Time                   `json:"created_at"`
+}
+
+func githubArchiveTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// GitHubArchiveWriter implements an Uploader writing a GitHub migration archive (a tar.gz file),
+// the archive can be imported by the tools consuming this format, eg: ghe-migrator of GitHub Enterprise Server.
+// The repository is identified by the host of its original URL in the archive.
+type GitHubArchiveWriter struct {
+	archivePath string
+	stagingDir  string
+	cleanup     func()
+	opts        base.MigrateOptions
+
+	hostURL    string
+	ownerName  string
+	repoName   string
+	repoRecord *githubArchiveRepository
+
+	users            map[string]*githubArchiveUser
+	milestoneNumbers map[string]int64
+	pullNumbers      container.Set[int64]
+	lastID           int64
+
+	milestones     []*githubArchiveMilestone
+	releases       []*githubArchiveRelease
+	issues         []*githubArchiveIssue
+	pullRequests   []*githubArchivePullRequest
+	issueComments  []*githubArchiveIssueComment
+	reviews        []*githubArchiveReview
+	reviewComments []*githubArchiveReviewComment
+}
+
+// NewGitHubArchiveWriter creates a GitHub migration archive writer, the archive is written to archivePath when the migration finishes
+func NewGitHubArchiveWriter(_ context.Context, archivePath, ownerName, repoName string, opts base.MigrateOptions) (*GitHubArchiveWriter, error) {
+	stagingDir, cleanup, err := setting.AppDataTempDir("github-archive").MkdirTempRandom("archive-*")
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubArchiveWriter{
+		archivePath:      archivePath,
+		stagingDir:       stagingDir,
+		cleanup:          cleanup,
+		opts:             opts,
+		ownerName:        ownerName,
+		repoName:         repoName,
+		users:            make(map[string]*githubArchiveUser),
+		milestoneNumbers: make(map[string]int64),
+		pullNumbers:      make(container.Set[int64]),
+	}, nil
+}
+
+// MaxBatchInsertSize returns the table's max batch insert size
+func (g *GitHubArchiveWriter) MaxBatchInsertSize(tp string) int {
+	return 1000
+}
+
+func (g *GitHubArchiveWriter) nextID() int64 {
+	g.lastID++
+	return g.lastID
+}
+
+func (g *GitHubArchiveWriter) repoURL() string {
+	return g.hostURL + "/" + url.PathEscape(g.ownerName) + "/" + url.PathEscape(g.repoName)
+}
+
+func (g *GitHubArchiveWriter) issueURL(number int64) string {
+	if g.pullNumbers.Contains(number) {
+		return g.repoURL() + "/pull/" + strconv.FormatInt(number, 10)
+	}
+	return g.repoURL() + "/issues/" + strconv.FormatInt(number, 10)
+}
+
+func (g *GitHubArchiveWriter) labelURL(name string) string {
+	return g.repoURL() + "/labels/" + url.PathEscape(name)
+}
+
+func (g *GitHubArchiveWriter) milestoneURL(title string) *string {
+	number, ok := g.milestoneNumbers[title]
+	if !ok {
+		return nil
+	}
+	u := g.repoURL() + "/milestones/" + strconv.FormatInt(number, 10)
+	return &u
+}
+
+// userURL returns the URL of a user and adds the user to the archive, the users without a name are the ghost user
+func (g *GitHubArchiveWriter) userURL(name, email string) string {
+	login := util.IfZero(name, user_model.GhostUserName)
+	user, ok := g.users[login]
+	if !ok {
+		user = &githubArchiveUser{
+			Type:   "user",
+			URL:    g.hostURL + "/" + url.PathEscape(login),
+			Login:  login,
+			Name:   login,
+			Emails: []githubArchiveEmail{},
+		}
+		g.users[login] = user
+	}
+	if email != "" && len(user.Emails) == 0 {
+		user.Emails = append(user.Emails, githubArchiveEmail{Address: email, Primary: true, Verified: true})
+	}
+	return user.URL
+}
+
+func (g *GitHubArchiveWriter) convertReactions(reactions []*base.Reaction, subjectType string) []*githubArchiveReaction {
+	ret := make([]*githubArchiveReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		ret = append(ret, &githubArchiveReaction{
+			User:        g.userURL(reaction.UserName, ""),
+			Content:     reaction.Content,
+			SubjectType: subjectType,
+		})
+	}
+	return ret
+}
+
+func (g *GitHubArchiveWriter) gitPath() string {
+	return filepath.Join(g.stagingDir, "repositories", g.ownerName, g.repoName+".git")
+}
+
+func (g *GitHubArchiveWriter) wikiPath() string {
+	return filepath.Join(g.stagingDir, "repositories", g.ownerName, g.repoName+".wiki.git")
+}
+
+// CreateRepo clones the repository and its wiki to the archive
+func (g *GitHubArchiveWriter) CreateRepo(ctx context.Context, repo *base.Repository, opts base.MigrateOptions) error {
+	g.hostURL = "https://github.com"
+	if u, err := url.Parse(util.IfZero(repo.OriginalURL, opts.CloneAddr)); err == nil && u.Host != "" {
+		g.hostURL = u.Scheme + "://" + u.Host
+	}
+	g.ownerName = util.IfZero(g.ownerName, repo.Owner)
+	g.repoName = util.IfZero(g.repoName, repo.Name)
+
+	migrateTimeout := 2 * time.Hour
+	if err := git.Clone(ctx, repo.CloneURL, g.gitPath(), git.CloneRepoOptions{
+		Mirror:        true,
+		Quiet:         true,
+		Timeout:       migrateTimeout,
+		SkipTLSVerify: setting.Migrations.SkipTLSVerify,
+	}); err != nil {
+		return fmt.Errorf("Clone: %w", err)
+	}
+
+	g.repoRecord = &githubArchiveRepository{
+		Type:          "repository",
+		URL:           g.repoURL(),
+		Owner:         g.hostURL + "/" + url.PathEscape(g.ownerName),
+		Name:          g.repoName,
+		Description:   repo.Description,
+		Private:       opts.Private,
+		HasIssues:     opts.Issues,
+		HasWiki:       opts.Wiki,
+		HasDownloads:  true,
+		Labels:        []*githubArchiveLabel{},
+		Collaborators: []any{},
+		GitURL:        "tarball://root/repositories/" + g.ownerName + "/" + g.repoName + ".git",
+		DefaultBranch: repo.DefaultBranch,
+		PublicKeys:    []any{},
+		Topics:        []string{},
+	}
+
+	if opts.Wiki {
+		if wikiRemotePath := repository.WikiRemoteURL(ctx, repo.CloneURL); wikiRemotePath != "" {
+			if err := git.Clone(ctx, wikiRemotePath, g.wikiPath(), git.CloneRepoOptions{
+				Mirror:        true,
+				Quiet:         true,
+				Timeout:       migrateTimeout,
+				SkipTLSVerify: setting.Migrations.SkipTLSVerify,
+			}); err != nil {
+				log.Warn("Clone wiki: %v", err)
+				if err := util.RemoveAll(g.wikiPath()); err != nil {
+					return err
+				}
+			} else {
+				wikiURL := "tarball://root/repositories/" + g.ownerName + "/" + g.repoName + ".wiki.git"
+				g.repoRecord.WikiURL = &wikiURL
+			}
+		}
+	}
+	return nil
+}
+
+// Close removes the staging directory of the archive
+func (g *GitHubArchiveWriter) Close() {
+	g.cleanup()
+}
+
+// CreateTopics adds the topics to the repository
+func (g *GitHubArchiveWriter) CreateTopics(_ context.Context, topics ...string) error {
+	g.repoRecord.Topics = append(g.repoRecord.Topics, topics...)
+	return nil
+}
+
+// CreateMilestones adds the milestones to the archive
+func (g *GitHubArchiveWriter) CreateMilestones(_ context.Context, milestones ...*base.Milestone) error {
+	for _, milestone := range milestones {
+		number := int64(len(g.milestones) + 1)
+		g.milestoneNumbers[milestone.Title] = number
+		record := &githubArchiveMilestone{
+			Type:        "milestone",
+			URL:         *g.milestoneURL(milestone.Title),
+			Repository:  g.repoURL(),
+			User:        g.userURL("", ""),
+			Title:       milestone.Title,
+			Description: milestone.Description,
+			State:       util.IfZero(milestone.State, "open"),
+			CreatedAt:   githubArchiveTime(milestone.Created),
+		}
+		if milestone.Deadline != nil {
+			record.DueOn = githubArchiveTime(*milestone.Deadline)
+		}
+		if milestone.Updated != nil {
+			record.UpdatedAt = githubArchiveTime(*milestone.Updated)
+		}
+		if milestone.Closed != nil {
+			record.ClosedAt = githubArchiveTime(*milestone.Closed)
+		}
+		g.milestones = append(g.milestones, record)
+	}
+	return nil
+}
+
+// CreateLabels adds the labels to the repository
+func (g *GitHubArchiveWriter) CreateLabels(_ context.Context, labels ...*base.Label) error {
+	for _, label := range labels {
+		g.repoRecord.Labels = append(g.repoRecord.Labels, &githubArchiveLabel{
+			URL:   g.labelURL(label.Name),
+			Name:  label.Name,
+			Color: label.Color,
+		})
+	}
+	return nil
+}
+
+// CreateReleases adds the releases and their assets to the archive
+func (g *GitHubArchiveWriter) CreateReleases(_ context.Context, releases ...*base.Release) error {
+	for _, release := range releases {
+		releaseURL := g.repoURL() + "/releases/tag/" + url.PathEscape(release.TagName)
+		record := &githubArchiveRelease{
+			Type:            "release",
+			URL:             releaseURL,
+			Repository:      g.repoURL(),
+			User:            g.userURL(release.PublisherName, release.PublisherEmail),
+			Name:            release.Name,
+			TagName:         release.TagName,
+			Body:            release.Body,
+			State:           util.Iif(release.Draft, "draft", "published"),
+			PendingTag:      release.TagName,
+			Prerelease:      release.Prerelease,
+			TargetCommitish: release.TargetCommitish,
+			ReleaseAssets:   []*githubArchiveReleaseAsset{},
+			PublishedAt:     githubArchiveTime(release.Published),
+			CreatedAt:       githubArchiveTime(release.Created),
+		}
+		if g.opts.ReleaseAssets {
+			for _, asset := range release.Assets {
+				assetRecord, err := g.writeReleaseAsset(releaseURL, record.User, asset)
+				if err != nil {
+					return err
+				}
+				record.ReleaseAssets = append(record.ReleaseAssets, assetRecord)
+			}
+		}
+		g.releases = append(g.releases, record)
+	}
+	return nil
+}
+
+func (g *GitHubArchiveWriter) writeReleaseAsset(releaseURL, userURL string, asset *base.ReleaseAsset) (*githubArchiveReleaseAsset, error) {
+	// SECURITY: We cannot check the DownloadURL and DownloadFunc are safe here
+	// ... we must assume that they are safe and simply download the attachment
+	var rc io.ReadCloser
+	var err error
+	if asset.DownloadURL == nil {
+		rc, err = asset.DownloadFunc()
+	} else {
+		var resp *http.Response
+		resp, err = http.Get(*asset.DownloadURL)
+		if err == nil {
+			rc = resp.Body
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	id := g.nextID()
+	assetPath := path.Join("release_assets", strconv.FormatInt(id, 10), path.Base("/"+asset.Name))
+	if err := os.MkdirAll(filepath.Join(g.stagingDir, filepath.Dir(filepath.FromSlash(assetPath))), os.ModePerm); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(filepath.Join(g.stagingDir, filepath.FromSlash(assetPath)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	size, err := io.Copy(f, rc)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := "application/octet-stream"
+	if asset.ContentType != nil {
+		contentType = *asset.ContentType
+	}
+	return &githubArchiveReleaseAsset{
+		Type:        "release_asset",
+		URL:         releaseURL + "/" + url.PathEscape(asset.Name),
+		Release:     releaseURL,
+		User:        userURL,
+		Name:        asset.Name,
+		ContentType: contentType,
+		Size:        int(size),
+		State:       "uploaded",
+		AssetURL:    "tarball://root/" + assetPath,
+		CreatedAt:   githubArchiveTime(asset.Created),
+	}, nil
+}
+
+// SyncTags does nothing because the tags are in the git repository
+func (g *GitHubArchiveWriter) SyncTags(_ context.Context) error {
+	return nil
+}
+
+func (g *GitHubArchiveWriter) convertIssue(issue *base.Issue, issueURL, subjectType string) githubArchiveIssue {
+	record := githubArchiveIssue{
+		Type:       "issue",
+		URL:        issueURL,
+		Repository: g.repoURL(),
+		User:       g.userURL(issue.PosterName, issue.PosterEmail),
+		Title:      issue.Title,
+		Body:       issue.Content,
+		Milestone:  g.milestoneURL(issue.Milestone),
+		Labels:     make([]string, 0, len(issue.Labels)),
+		Assignees:  make([]string, 0, len(issue.Assignees)),
+		Reactions:  g.convertReactions(issue.Reactions, subjectType),
+		CreatedAt:  githubArchiveTime(issue.Created),
+		UpdatedAt:  githubArchiveTime(issue.Updated),
+	}
+	for _, label := range issue.Labels {
+		record.Labels = append(record.Labels, g.labelURL(label.Name))
+	}
+	for _, assignee := range issue.Assignees {
+		record.Assignees = append(record.Assignees, g.userURL(assignee, ""))
+	}
+	if len(record.Assignees) > 0 {
+		record.Assignee = &record.Assignees[0]
+	}
+	if issue.Closed != nil {
+		record.ClosedAt = githubArchiveTime(*issue.Closed)
+	} else if issue.State == "closed" {
+		record.ClosedAt = record.UpdatedAt
+	}
+	return record
+}
+
+// CreateIssues adds the issues to the archive
+func (g *GitHubArchiveWriter) CreateIssues(_ context.Context, issues ...*base.Issue) error {
+	for _, issue := range issues {
+		record := g.convertIssue(issue, g.repoURL()+"/issues/"+strconv.FormatInt(issue.Number, 10), "Issue")
+		g.issues = append(g.issues, &record)
+	}
+	return nil
+}
+
+// CreateComments adds the comments of the issues and the pull requests to the archive
+func (g *GitHubArchiveWriter) CreateComments(_ context.Context, comments ...*base.Comment) error {
+	for _, comment := range comments {
+		issueURL := g.issueURL(comment.IssueIndex)
+		record := &githubArchiveIssueComment{
+			Type:      "issue_comment",
+			URL:       issueURL + "#issuecomment-" + strconv.FormatInt(g.nextID(), 10),
+			User:      g.userURL(comment.PosterName, comment.PosterEmail),
+			Body:      comment.Content,
+			Formatter: "markdown",
+			Reactions: g.convertReactions(comment.Reactions, "IssueComment"),
+			CreatedAt: githubArchiveTime(comment.Created),
+			UpdatedAt: githubArchiveTime(comment.Updated),
+		}
+		if g.pullNumbers.Contains(comment.IssueIndex) {
+			record.PullRequest = issueURL
+		} else {
+			record.Issue = issueURL
+		}
+		g.issueComments = append(g.issueComments, record)
+	}
+	return nil
+}
+
+// CreatePullRequests adds the pull requests to the archive
+func (g *GitHubArchiveWriter) CreatePullRequests(_ context.Context, prs ...*base.PullRequest) error {
+	for _, pr := range prs {
+		g.pullNumbers.Add(pr.Number)
+		issue := base.Issue{
+			PosterName:  pr.PosterName,
+			PosterEmail: pr.PosterEmail,
+			Title:       pr.Title,
+			Content:     pr.Content,
+			Milestone:   pr.Milestone,
+			State:       pr.State,
+			Created:     pr.Created,
+			Updated:     pr.Updated,
+			Closed:      pr.Closed,
+			Labels:      pr.Labels,
+			Reactions:   pr.Reactions,
+			Assignees:   pr.Assignees,
+		}
+		record := &githubArchivePullRequest{
+			githubArchiveIssue: g.convertIssue(&issue, g.issueURL(pr.Number), "PullRequest"),
+			Base: githubArchivePullRequestBranch{
+				Ref:  pr.Base.Ref,
+				SHA:  pr.Base.SHA,
+				User: g.hostURL + "/" + url.PathEscape(g.ownerName),
+				Repo: g.repoURL(),
+			},
+			// the heads of the pull requests from the forks are fetched to the repository
+			Head: githubArchivePullRequestBranch{
+				Ref:  pr.Head.Ref,
+				SHA:  pr.Head.SHA,
+				User: g.hostURL + "/" + url.PathEscape(g.ownerName),
+				Repo: g.repoURL(),
+			},
+			WorkInProgress: pr.IsDraft,
+			ReviewRequests: []any{},
+		}
+		record.Type = "pull_request"
+		if pr.Merged {
+			if mergedTime := util.IfZero(pr.MergedTime, pr.Closed); mergedTime != nil {
+				record.MergedAt = githubArchiveTime(*mergedTime)
+			}
+			if pr.MergeCommitSHA != "" {
+				record.MergeCommitSHA = &pr.MergeCommitSHA
+			}
+		}
+		g.pullRequests = append(g.pullRequests, record)
+	}
+	return nil
+}
+
+// CreateReviews adds the reviews and the review comments to the archive
+func (g *GitHubArchiveWriter) CreateReviews(_ context.Context, reviews ...*base.Review) error {
+	for _, review := range reviews {
+		pullURL := g.issueURL(review.IssueIndex)
+		state := githubArchiveReviewStateCommented
+		switch review.State {
+		case base.ReviewStateApproved:
+			state = githubArchiveReviewStateApproved
+		case base.ReviewStateChangesRequested:
+			state = githubArchiveReviewStateChangesRequested
+		case base.ReviewStatePending:
+			state = githubArchiveReviewStatePending
+		case base.ReviewStateRequestReview:
+			// the review requests of the pull requests have been sent, they aren't reviews
+			continue
+		}
+		reviewer := g.userURL(review.ReviewerName, "")
+		reviewRecord := &githubArchiveReview{
+			Type:        "pull_request_review",
+			URL:         pullURL + "/files#pullrequestreview-" + strconv.FormatInt(g.nextID(), 10),
+			PullRequest: pullURL,
+			User:        reviewer,
+			Body:        review.Content,
+			HeadSHA:     review.CommitID,
+			Formatter:   "markdown",
+			State:       state,
+			CreatedAt:   githubArchiveTime(review.CreatedAt),
+			SubmittedAt: githubArchiveTime(review.CreatedAt),
+		}
+		g.reviews = append(g.reviews, reviewRecord)
+
+		for _, comment := range review.Comments {
+			position := util.IfZero(comment.Position, comment.Line)
+			g.reviewComments = append(g.reviewComments, &githubArchiveReviewComment{
+				Type:              "pull_request_review_comment",
+				URL:               pullURL + "/files#r" + strconv.FormatInt(g.nextID(), 10),
+				PullRequest:       pullURL,
+				PullRequestReview: reviewRecord.URL,
+				User:              reviewer,
+				Body:              comment.Content,
+				Formatter:         "markdown",
+				DiffHunk:          comment.DiffHunk,
+				Path:              comment.TreePath,
+				Position:          position,
+				OriginalPosition:  position,
+				CommitID:          comment.CommitID,
+				OriginalCommitID:  comment.CommitID,
+				Reactions:         g.convertReactions(comment.Reactions, "PullRequestReviewComment"),
+				CreatedAt:         githubArchiveTime(comment.CreatedAt),
+				UpdatedAt:         githubArchiveTime(comment.UpdatedAt),
+			})
+		}
+	}
+	return nil
+}
+
+// Rollback does nothing, the staging directory is removed by Close
+func (g *GitHubArchiveWriter) Rollback() error {
+	return nil
+}
+
+func writeGitHubArchiveJSON(dir, name string, v any) error {
+	bs, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), bs, 0o644)
+}
+
+// Finish writes the records to the staging directory and packs it to the archive
+func (g *GitHubArchiveWriter) Finish(_ context.Context) error {
+	owner := &githubArchiveOrganization{
+		Type:    "organization",
+		URL:     g.hostURL + "/" + url.PathEscape(g.ownerName),
+		Login:   g.ownerName,
+		Name:    g.ownerName,
+		Members: []any{},
+	}
+	users := make([]*githubArchiveUser, 0, len(g.users))
+	for _, login := range slices.Sorted(maps.Keys(g.users)) {
+		users = append(users, g.users[login])
+	}
+
+	files := []struct {
+		name    string
+		records any
+	}{
+		{"schema.json", map[string]string{"version": githubArchiveSchemaVersion}},
+		{"urls.json", githubArchiveURLTemplates},
+		{"users_000001.json", users},
+		{"organizations_000001.json", []any{owner}},
+		{"repositories_000001.json", []any{g.repoRecord}},
+		{"milestones_000001.json", g.milestones},
+		{"releases_000001.json", g.releases},
+		{"issues_000001.json", g.issues},
+		{"pull_requests_000001.json", g.pullRequests},
+		{"issue_comments_000001.json", g.issueComments},
+		{"pull_request_reviews_000001.json", g.reviews},
+		{"pull_request_review_comments_000001.json", g.reviewComments},
+	}
+	for _, file := range files {
+		if err := writeGitHubArchiveJSON(g.stagingDir, file.name, file.records); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(g.archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	if err := tw.AddFS(os.DirFS(g.stagingDir)); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// ExportGitHubArchive exports a repository according MigrateOptions to a GitHub migration archive
+func ExportGitHubArchive(ctx context.Context, archivePath, ownerName string, opts base.MigrateOptions) error {
+	doer, err := user_model.GetAdminUser(ctx)
+	if err != nil {
+		return err
+	}
+	downloader, err := newDownloader(ctx, ownerName, opts)
+	if err != nil {
+		return err
+	}
+	uploader, err := NewGitHubArchiveWriter(ctx, archivePath, ownerName, opts.RepoName, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := migrateRepository(ctx, doer, downloader, uploader, opts, nil); err != nil {
+		if err1 := util.Remove(archivePath); err1 != nil && !os.IsNotExist(err1) {
+			log.Error("remove the archive failed: %v", err1)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+	base "code.gitea.io/gitea/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readGitHubArchive(t *testing.T, archivePath string) map[string][]byte {
+	f, err := os.Open(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		bs, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = bs
+	}
+	return files
+}
+
+func TestGitHubArchiveWriter(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	ctx := t.Context()
+	archivePath := filepath.Join(t.TempDir(), "migration_archive.tar.gz")
+	opts := base.MigrateOptions{Issues: true, PullRequests: true, Comments: true, Milestones: true, Labels: true}
+	writer, err := NewGitHubArchiveWriter(ctx, archivePath, "org", "", opts)
+	require.NoError(t, err)
+	defer writer.Close()
+
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, writer.CreateRepo(ctx, &base.Repository{
+		Name:          "project",
+		Description:   "description",
+		OriginalURL:   "https://gitea.example.com/org/project",
+		CloneURL:      repo.RepoPath(),
+		DefaultBranch: "master",
+	}, opts))
+	require.NoError(t, writer.CreateTopics(ctx, "go"))
+	require.NoError(t, writer.CreateMilestones(ctx, &base.Milestone{Title: "v1.0", State: "open", Created: created}))
+	require.NoError(t, writer.CreateLabels(ctx, &base.Label{Name: "bug", Color: "ee0701"}))
+	require.NoError(t, writer.CreateIssues(ctx, &base.Issue{
+		Number:     1,
+		PosterName: "alice",
+		Title:      "An issue",
+		Content:    "issue body",
+		Milestone:  "v1.0",
+		State:      "open",
+		Created:    created,
+		Updated:    created,
+		Labels:     []*base.Label{{Name: "bug"}},
+		Reactions:  []*base.Reaction{{UserName: "bob", Content: "+1"}},
+		Assignees:  []string{"bob"},
+	}))
+	require.NoError(t, writer.CreatePullRequests(ctx, &base.PullRequest{
+		Number:     2,
+		PosterName: "bob",
+		Title:      "A pull request",
+		State:      "closed",
+		Created:    created,
+		Updated:    created,
+		Closed:     &created,
+		Merged:     true,
+		Base:       base.PullRequestBranch{Ref: "master", SHA: "1111111111111111111111111111111111111111"},
+		Head:       base.PullRequestBranch{Ref: "feature", SHA: "2222222222222222222222222222222222222222"},
+	}))
+	require.NoError(t, writer.CreateComments(ctx,
+		&base.Comment{IssueIndex: 1, PosterName: "bob", Content: "issue comment", Created: created},
+		&base.Comment{IssueIndex: 2, PosterName: "alice", Content: "pull comment", Created: created},
+	))
+	require.NoError(t, writer.CreateReviews(ctx, &base.Review{
+		IssueIndex:   2,
+		ReviewerName: "alice",
+		CommitID:     "2222222222222222222222222222222222222222",
+		State:        base.ReviewStateApproved,
+		CreatedAt:    created,
+		Comments:     []*base.ReviewComment{{Content: "nit", TreePath: "README.md", Line: 1, CreatedAt: created}},
+	}, &base.Review{IssueIndex: 2, ReviewerName: "carol", State: base.ReviewStateRequestReview}))
+	require.NoError(t, writer.Finish(ctx))
+
+	files := readGitHubArchive(t, archivePath)
+	assert.Contains(t, files, "repositories/org/project.git/HEAD")
+	assert.JSONEq(t, `{"version": "1.2.0"}`, string(files["schema.json"]))
+
+	var repos []*githubArchiveRepository
+	require.NoError(t, json.Unmarshal(files["repositories_000001.json"], &repos))
+	require.Len(t, repos, 1)
+	assert.Equal(t, "https://gitea.example.com/org/project", repos[0].URL)
+	assert.Equal(t, "tarball://root/repositories/org/project.git", repos[0].GitURL)
+	assert.Equal(t, []string{"go"}, repos[0].Topics)
+	require.Len(t, repos[0].Labels, 1)
+	assert.Equal(t, "https://gitea.example.com/org/project/labels/bug", repos[0].Labels[0].URL)
+
+	var issues []*githubArchiveIssue
+	require.NoError(t, json.Unmarshal(files["issues_000001.json"], &issues))
+	require.Len(t, issues, 1)
+	assert.Equal(t, "https://gitea.example.com/org/project/issues/1", issues[0].URL)
+	assert.Equal(t, "https://gitea.example.com/alice", issues[0].User)
+	assert.Equal(t, "https://gitea.example.com/org/project/milestones/1", *issues[0].Milestone)
+	assert.Equal(t, []string{"https://gitea.example.com/org/project/labels/bug"}, issues[0].Labels)
+	assert.Equal(t, "https://gitea.example.com/bob", *issues[0].Assignee)
+	require.Len(t, issues[0].Reactions, 1)
+	assert.Equal(t, "+1", issues[0].Reactions[0].Content)
+
+	var pulls []*githubArchivePullRequest
+	require.NoError(t, json.Unmarshal(files["pull_requests_000001.json"], &pulls))
+	require.Len(t, pulls, 1)
+	assert.Equal(t, "pull_request", pulls[0].Type)
+	assert.Equal(t, "https://gitea.example.com/org/project/pull/2", pulls[0].URL)
+	assert.Equal(t, "feature", pulls[0].Head.Ref)
+	assert.Equal(t, created, *pulls[0].MergedAt)
+
+	var comments []*githubArchiveIssueComment
+	require.NoError(t, json.Unmarshal(files["issue_comments_000001.json"], &comments))
+	require.Len(t, comments, 2)
+	assert.Equal(t, "https://gitea.example.com/org/project/issues/1", comments[0].Issue)
+	assert.Equal(t, "https://gitea.example.com/org/project/pull/2", comments[1].PullRequest)
+	assert.True(t, strings.HasPrefix(comments[1].URL, "https://gitea.example.com/org/project/pull/2#issuecomment-"))
+
+	var reviews []*githubArchiveReview
+	require.NoError(t, json.Unmarshal(files["pull_request_reviews_000001.json"], &reviews))
+	require.Len(t, reviews, 1)
+	assert.Equal(t, githubArchiveReviewStateApproved, reviews[0].State)
+
+	var reviewComments []*githubArchiveReviewComment
+	require.NoError(t, json.Unmarshal(files["pull_request_review_comments_000001.json"], &reviewComments))
+	require.Len(t, reviewComments, 1)
+	assert.Equal(t, reviews[0].URL, reviewComments[0].PullRequestReview)
+	assert.Equal(t, "README.md", reviewComments[0].Path)
+
+	var users []*githubArchiveUser
+	require.NoError(t, json.Unmarshal(files["users_000001.json"], &users))
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, user.Login)
+	}
+	assert.ElementsMatch(t, []string{"alice", "bob", "Ghost"}, logins)
+}
@@ -11,6 +11,7 @@ import (
 
 	repo_model "code.gitea.io/gitea/models/repo"
 	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
@@ -253,6 +254,11 @@ func runSync(ctx context.Context, m *repo_model.Mirror) ([]*mirrorSyncResult, bo
 	wikiPath := m.Repo.WikiPath()
 	timeout := time.Duration(setting.Git.Timeout.Mirror) * time.Second
 
+	if m.IsSizeExceeded(m.Repo.Size) {
+		log.Warn("SyncMirrors [repo: %-v]: repository size %d is larger than the max size %d of the mirror, skipping sync", m.Repo, m.Repo.Size, m.MaxSize)
+		return nil, false
+	}
+
 	log.Trace("SyncMirrors [repo: %-v]: running git remote update...", m.Repo)
 
 	// use fetch but not remote update because git fetch support --tags but remote update doesn't
@@ -260,7 +266,12 @@ func runSync(ctx context.Context, m *repo_model.Mirror) ([]*mirrorSyncResult, bo
 	if m.EnablePrune {
 		cmd.AddArguments("--prune")
 	}
-	cmd.AddArguments("--tags").AddDynamicArguments(m.GetRemoteName())
+	if m.IsRefFiltered() {
+		// the tags are fetched by the refspecs unless they are skipped
+		cmd.AddArguments("--no-tags").AddDynamicArguments(m.GetRemoteName()).AddDynamicArguments(m.FetchRefSpecs()...)
+	} else {
+		cmd.AddArguments("--tags").AddDynamicArguments(m.GetRemoteName())
+	}
 
 	remoteURL, remoteErr := gitrepo.GitRemoteGetURL(ctx, m.Repo, m.GetRemoteName())
 	if remoteErr != nil {
@@ -326,6 +337,14 @@ func runSync(ctx context.Context, m *repo_model.Mirror) ([]*mirrorSyncResult, bo
 	}
 	output := stderrBuilder.String()
 
+	var prunedResults []*mirrorSyncResult
+	if m.IsRefFiltered() {
+		var err error
+		if prunedResults, err = pruneFilteredRefs(ctx, m); err != nil {
+			log.Error("SyncMirrors [repo: %-v]: failed to prune the refs not matching the filter: %v", m.Repo, err)
+		}
+	}
+
 	if err := git.WriteCommitGraph(ctx, repoPath); err != nil {
 		log.Error("SyncMirrors [repo: %-v]: %v", m.Repo, err)
 	}
@@ -359,6 +378,15 @@ func runSync(ctx context.Context, m *repo_model.Mirror) ([]*mirrorSyncResult, bo
 	log.Trace("SyncMirrors [repo: %-v]: updating size of repository", m.Repo)
 	if err := repo_module.UpdateRepoSize(ctx, m.Repo); err != nil {
 		log.Error("SyncMirrors [repo: %-v]: failed to update size for mirror repository: %v", m.Repo.FullName(), err)
+	} else if m.MaxSize > 0 {
+		if repo, err := repo_model.GetRepositoryByID(ctx, m.RepoID); err != nil {
+			log.Error("SyncMirrors [repo: %-v]: failed to get repository size: %v", m.Repo, err)
+		} else if m.IsSizeExceeded(repo.Size) {
+			desc := fmt.Sprintf("Mirror repository '%s' has exceeded its max size %s, it won't be synced until the max size is raised", repoPath, base.FileSize(m.MaxSize))
+			if err = system_model.CreateRepositoryNotice(desc); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+		}
 	}
 
 	if repo_service.HasWiki(ctx, m.Repo) {
@@ -426,7 +454,43 @@ func runSync(ctx context.Context, m *repo_model.Mirror) ([]*mirrorSyncResult, bo
 	}
 
 	m.UpdatedUnix = timeutil.TimeStampNow()
-	return parseRemoteUpdateOutput(output, m.GetRemoteName()), true
+	return append(parseRemoteUpdateOutput(output, m.GetRemoteName()), prunedResults...), true
+}
+
+// pruneFilteredRefs deletes the branches and the tags not matching the ref filter of the mirror,
+// they were fetched before the filter was set.
+func pruneFilteredRefs(ctx context.Context, m *repo_model.Mirror) ([]*mirrorSyncResult, error) {
+	repoPath := m.Repo.RepoPath()
+	stdout, _, err := gitcmd.NewCommand("for-each-ref", "--format=%(refname)").AddDynamicArguments(git.BranchPrefix, git.TagPrefix).
+		RunStdString(ctx, &gitcmd.RunOpts{Dir: repoPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*mirrorSyncResult
+	var stdin strings.Builder
+	for refName := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		if refName == "" || m.MatchRef(git.RefName(refName)) {
+			continue
+		}
+		stdin.WriteString("delete " + refName + "\n")
+		results = append(results, &mirrorSyncResult{
+			refName:     git.RefName(refName),
+			newCommitID: gitShortEmptySha,
+		})
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	if _, stderr, err := gitcmd.NewCommand("update-ref", "--stdin").RunStdString(ctx, &gitcmd.RunOpts{
+		Dir:   repoPath,
+		Stdin: strings.NewReader(stdin.String()),
+	}); err != nil {
+		return nil, gitcmd.ConcatenateError(err, stderr)
+	}
+	log.Trace("SyncMirrors [repo: %-v]: pruned %d refs not matching the filter", m.Repo, len(results))
+	return results, nil
 }
 
 func getRepoPullMirrorLockKey(repoID int64) string {
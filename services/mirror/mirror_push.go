@@ -107,6 +107,9 @@ func SyncPushMirror(ctx context.Context, mirrorID int64) bool {
 	}
 
 	m.LastUpdateUnix = timeutil.TimeStampNow()
+	if err == nil {
+		m.LastSuccessUnix = m.LastUpdateUnix
+	}
 
 	if err := repo_model.UpdatePushMirror(ctx, m); err != nil {
 		log.Error("UpdatePushMirror [%d]: %v", m.ID, err)
@@ -135,7 +138,7 @@ func runPushSync(ctx context.Context, m *repo_model.PushMirror) error {
 			return errors.New("Unexpected error")
 		}
 
-		if setting.LFS.StartServer {
+		if m.LFS && setting.LFS.StartServer {
 			log.Trace("SyncMirrors [repo: %-v]: syncing LFS objects...", m.Repo)
 
 			gitRepo, err := gitrepo.OpenRepository(ctx, storageRepo)
@@ -155,13 +158,20 @@ func runPushSync(ctx context.Context, m *repo_model.PushMirror) error {
 		log.Trace("Pushing %s mirror[%d] remote %s", path, m.ID, m.RemoteName)
 
 		envs := proxy.EnvWithProxy(remoteURL.URL)
-		if err := git.Push(ctx, path, git.PushOptions{
+		pushOpts := git.PushOptions{
 			Remote:  m.RemoteName,
 			Force:   true,
 			Mirror:  true,
 			Timeout: timeout,
 			Env:     envs,
-		}); err != nil {
+		}
+		// the wiki is always mirrored as a whole
+		if refSpecs := m.PushRefSpecs(); !isWiki && len(refSpecs) > 0 {
+			pushOpts.Mirror = false
+			pushOpts.Prune = true
+			pushOpts.RefSpecs = refSpecs
+		}
+		if err := git.Push(ctx, path, pushOpts); err != nil {
 			log.Error("Error pushing %s mirror[%d] remote %s: %v", path, m.ID, m.RemoteName, err)
 
 			return util.SanitizeErrorCredentialURLs(err)
@@ -0,0 +1,450 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/mailer"
+	repo_service "code.gitea.io/gitea/services/repository"
+	user_service "code.gitea.io/gitea/services/user"
+)
+
+// IsModerator checks whether the user may handle the reports, the administrators are always moderators
+func IsModerator(u *user_model.User) bool {
+	if u == nil {
+		return false
+	}
+	return u.IsAdmin || slices.Contains(setting.Moderation.Moderators, u.LowerName)
+}
+
+// CanSeeHiddenContent checks whether the user may see the issues, the pull requests and the comments of the repository
+// hidden by a moderator, the moderators and the administrators of the repository can while the others get not found
+func CanSeeHiddenContent(u *user_model.User, perm *access_model.Permission) bool {
+	return IsModerator(u) || perm.IsAdmin()
+}
+
+// IsIssueHiddenFrom checks whether the issue or the pull request is hidden by a moderator from the user
+func IsIssueHiddenFrom(issue *issues_model.Issue, u *user_model.User, perm *access_model.Permission) bool {
+	return issue.IsHidden && !CanSeeHiddenContent(u, perm)
+}
+
+// IsCommentHiddenFrom checks whether the comment or its issue is hidden by a moderator from the user,
+// the issue of the comment must be loaded
+func IsCommentHiddenFrom(comment *issues_model.Comment, u *user_model.User, perm *access_model.Permission) bool {
+	return comment.IsHiddenByModerator() && !CanSeeHiddenContent(u, perm)
+}
+
+const maxRemarksLength = 2000
+
+// errContentNotExist is returned if the reported content doesn't exist or isn't visible to the reporter
+var errContentNotExist = util.NewNotExistErrorf("the reported content does not exist")
+
+func joinNonEmpty(values ...string) string {
+	return strings.Join(slices.DeleteFunc(values, func(s string) bool { return s == "" }), "\n\n")
+}
+
+// loadReportedContent checks that the reporter can see the content and fills the owner, the repository and the
+// snapshot of the report
+func loadReportedContent(ctx context.Context, reporter *user_model.User, r *moderation_model.AbuseReport) error {
+	canReadRepo := func(repo *repo_model.Repository, check func(access_model.Permission) bool) (bool, error) {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, reporter)
+		if err != nil {
+			return false, err
+		}
+		return check(perm), nil
+	}
+
+	switch r.ContentType {
+	case moderation_model.ReportContentUser:
+		u, err := user_model.GetUserByID(ctx, r.ContentID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return errContentNotExist
+			}
+			return err
+		}
+		if !user_model.IsUserVisibleToViewer(ctx, u, reporter) {
+			return errContentNotExist
+		}
+		r.OwnerID = u.ID
+		r.ContentSnapshot = joinNonEmpty(u.Name, u.FullName, u.Description, u.Website, u.Location)
+	case moderation_model.ReportContentRepo:
+		repo, err := repo_model.GetRepositoryByID(ctx, r.ContentID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return errContentNotExist
+			}
+			return err
+		}
+		if canRead, err := canReadRepo(repo, func(perm access_model.Permission) bool { return perm.HasAnyUnitAccessOrPublicAccess() }); err != nil {
+			return err
+		} else if !canRead {
+			return errContentNotExist
+		}
+		r.OwnerID = repo.OwnerID
+		r.RepoID = repo.ID
+		r.ContentSnapshot = joinNonEmpty(repo.FullName(), repo.Description, repo.Website)
+	case moderation_model.ReportContentIssue:
+		issue, err := issues_model.GetIssueByID(ctx, r.ContentID)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				return errContentNotExist
+			}
+			return err
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if canRead, err := canReadRepo(issue.Repo, func(perm access_model.Permission) bool { return perm.CanReadIssuesOrPulls(issue.IsPull) }); err != nil {
+			return err
+		} else if !canRead {
+			return errContentNotExist
+		}
+		r.OwnerID = issue.PosterID
+		r.RepoID = issue.RepoID
+		r.ContentSnapshot = joinNonEmpty(issue.Title, issue.Content)
+	case moderation_model.ReportContentComment:
+		comment, err := issues_model.GetCommentByID(ctx, r.ContentID)
+		if err != nil {
+			if issues_model.IsErrCommentNotExist(err) {
+				return errContentNotExist
+			}
+			return err
+		}
+		if err := comment.LoadIssue(ctx); err != nil {
+			return err
+		}
+		if err := comment.Issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if canRead, err := canReadRepo(comment.Issue.Repo, func(perm access_model.Permission) bool { return perm.CanReadIssuesOrPulls(comment.Issue.IsPull) }); err != nil {
+			return err
+		} else if !canRead {
+			return errContentNotExist
+		}
+		r.OwnerID = comment.PosterID
+		r.RepoID = comment.Issue.RepoID
+		r.ContentSnapshot = comment.Content
+	default:
+		return util.NewInvalidArgumentErrorf("invalid content type %q", r.ContentType)
+	}
+	return nil
+}
+
+// PrepareReport checks whether the user can report the content and returns the unsaved report about it. A user can't
+// report their own content and can't report the same content again until the previous report has been resolved.
+func PrepareReport(ctx context.Context, reporter *user_model.User, contentType moderation_model.ReportContentType, contentID int64) (*moderation_model.AbuseReport, error) {
+	r := &moderation_model.AbuseReport{
+		ReporterID:  reporter.ID,
+		Reporter:    reporter,
+		ContentType: contentType,
+		ContentID:   contentID,
+	}
+	if err := loadReportedContent(ctx, reporter, r); err != nil {
+		return nil, err
+	}
+	if r.OwnerID == reporter.ID {
+		return nil, util.NewInvalidArgumentErrorf("you can't report your own content")
+	}
+
+	has, err := moderation_model.HasOpenAbuseReport(ctx, reporter.ID, contentType, contentID)
+	if err != nil {
+		return nil, err
+	} else if has {
+		return nil, util.NewAlreadyExistErrorf("you have already reported this content")
+	}
+	return r, nil
+}
+
+// ReportContent files a report about the content into the moderation queue
+func ReportContent(ctx context.Context, reporter *user_model.User, contentType moderation_model.ReportContentType, contentID int64, category moderation_model.ReportCategory, remarks string) (*moderation_model.AbuseReport, error) {
+	if !category.IsValid() {
+		return nil, util.NewInvalidArgumentErrorf("invalid report category %q", category)
+	}
+	remarks = strings.TrimSpace(remarks)
+	if len(remarks) > maxRemarksLength {
+		return nil, util.NewInvalidArgumentErrorf("the remarks must not be longer than %d characters", maxRemarksLength)
+	}
+
+	r, err := PrepareReport(ctx, reporter, contentType, contentID)
+	if err != nil {
+		return nil, err
+	}
+	r.Category = category
+	r.Remarks = remarks
+	if err := moderation_model.CreateAbuseReport(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ResolveReport takes the action about the reported content and resolves all open reports of the content with it. The
+// message is sent to the owner of the content if the action is warn or suspend.
+func ResolveReport(ctx context.Context, doer *user_model.User, r *moderation_model.AbuseReport, action moderation_model.ModerationAction, message string) error {
+	message = strings.TrimSpace(message)
+	if !IsModerator(doer) {
+		return util.NewPermissionDeniedErrorf("only moderators can resolve reports")
+	} else if !r.IsOpen() {
+		return util.NewInvalidArgumentErrorf("the report has already been resolved")
+	} else if !action.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid moderation action %q", action)
+	} else if action == moderation_model.ModerationActionWarn && message == "" {
+		return util.NewInvalidArgumentErrorf("a message is required to warn the user")
+	}
+
+	resolution := *r
+	resolution.Status = moderation_model.ReportStatusResolved
+	resolution.Action = action
+	resolution.ResolutionMessage = message
+	resolution.ResolverID = doer.ID
+	resolution.Resolver = doer
+	resolution.ResolvedUnix = timeutil.TimeStampNow()
+
+	// the reports are resolved before the action is taken, so the action isn't taken twice if another moderator
+	// resolves the reports meanwhile, and the reports stay open if the action fails
+	var notified *user_model.User
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		if action == moderation_model.ModerationActionHide {
+			visibility, err := contentVisibility(ctx, r)
+			if err != nil {
+				return err
+			}
+			resolution.PreviousVisibility = visibility
+		}
+		resolved, err := moderation_model.ResolveOpenAbuseReports(ctx, &resolution)
+		if err != nil {
+			return err
+		} else if !resolved {
+			return util.NewInvalidArgumentErrorf("the report has been resolved meanwhile")
+		}
+		notified, err = takeAction(ctx, r, action)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return util.NewInvalidArgumentErrorf("the reported content does not exist anymore")
+		}
+		return err
+	}
+	*r = resolution
+
+	if action == moderation_model.ModerationActionHide {
+		updateIssueIndexer(ctx, r)
+	}
+	if notified != nil {
+		mailer.SendModerationMail(notified, action, message)
+	}
+	return nil
+}
+
+// ResolveReportWithTemplate resolves the report with the action and the message of the template
+func ResolveReportWithTemplate(ctx context.Context, doer *user_model.User, r *moderation_model.AbuseReport, templateID int64) error {
+	t, err := moderation_model.GetResolutionTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return util.NewInvalidArgumentErrorf("resolution template %d does not exist", templateID)
+		}
+		return err
+	}
+	return ResolveReport(ctx, doer, r, t.Action, t.Message)
+}
+
+// takeAction takes the action about the reported content and returns the owner who should receive the message
+func takeAction(ctx context.Context, r *moderation_model.AbuseReport, action moderation_model.ModerationAction) (*user_model.User, error) {
+	switch action {
+	case moderation_model.ModerationActionHide:
+		return nil, hideContent(ctx, r)
+	case moderation_model.ModerationActionWarn, moderation_model.ModerationActionSuspend:
+		owner, err := user_model.GetUserByID(ctx, r.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		if !owner.IsIndividual() {
+			return nil, util.NewInvalidArgumentErrorf("organizations can't be warned or suspended")
+		}
+		if action == moderation_model.ModerationActionSuspend {
+			if owner.IsAdmin {
+				return nil, util.NewInvalidArgumentErrorf("administrators can't be suspended")
+			}
+			if err := suspendUser(ctx, owner); err != nil {
+				return nil, err
+			}
+		}
+		return owner, nil
+	}
+	return nil, nil
+}
+
+// hideContent hides the reported issue, pull request or comment, makes the reported repository private or makes the
+// profile of the reported user private. The profiles and the repositories of administrators can't be hidden, like
+// administrators can't be suspended. The previous visibility is kept on the report, so it can be restored.
+func hideContent(ctx context.Context, r *moderation_model.AbuseReport) error {
+	switch r.ContentType {
+	case moderation_model.ReportContentUser:
+		u, err := user_model.GetUserByID(ctx, r.ContentID)
+		if err != nil {
+			return err
+		}
+		if u.IsAdmin {
+			return util.NewInvalidArgumentErrorf("the profiles of administrators can't be hidden")
+		}
+		return user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{Visibility: optional.Some(structs.VisibleTypePrivate)})
+	case moderation_model.ReportContentRepo:
+		repo, err := repo_model.GetRepositoryByID(ctx, r.ContentID)
+		if err != nil {
+			return err
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return err
+		}
+		if repo.Owner.IsAdmin {
+			return util.NewInvalidArgumentErrorf("the repositories of administrators can't be hidden")
+		}
+		if repo.IsPrivate {
+			return nil
+		}
+		return repo_service.MakeRepoPrivate(ctx, repo)
+	case moderation_model.ReportContentIssue, moderation_model.ReportContentComment:
+		return setContentHidden(ctx, r, true)
+	}
+	return util.NewInvalidArgumentErrorf("invalid content type %q", r.ContentType)
+}
+
+// contentVisibility returns the visibility of the reported user or repository, which is restored if a moderator
+// unhides the content, the visibility of issues and comments isn't changed by hiding them
+func contentVisibility(ctx context.Context, r *moderation_model.AbuseReport) (string, error) {
+	switch r.ContentType {
+	case moderation_model.ReportContentUser:
+		u, err := user_model.GetUserByID(ctx, r.ContentID)
+		if err != nil {
+			return "", err
+		}
+		return u.Visibility.String(), nil
+	case moderation_model.ReportContentRepo:
+		repo, err := repo_model.GetRepositoryByID(ctx, r.ContentID)
+		if err != nil {
+			return "", err
+		}
+		if repo.IsPrivate {
+			return structs.VisibleTypePrivate.String(), nil
+		}
+		return structs.VisibleTypePublic.String(), nil
+	}
+	return "", nil
+}
+
+// restoreVisibility restores the visibility the reported user or repository had before it was made private
+func restoreVisibility(ctx context.Context, r *moderation_model.AbuseReport) error {
+	if !r.CanRestoreVisibility() {
+		return util.NewInvalidArgumentErrorf("the content hasn't been made private by the report")
+	}
+	switch r.ContentType {
+	case moderation_model.ReportContentUser:
+		u, err := user_model.GetUserByID(ctx, r.ContentID)
+		if err != nil {
+			return err
+		}
+		return user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{Visibility: optional.Some(structs.VisibilityModes[r.PreviousVisibility])})
+	case moderation_model.ReportContentRepo:
+		repo, err := repo_model.GetRepositoryByID(ctx, r.ContentID)
+		if err != nil {
+			return err
+		}
+		if !repo.IsPrivate {
+			return nil
+		}
+		return repo_service.MakeRepoPublic(ctx, repo)
+	}
+	return util.NewInvalidArgumentErrorf("invalid content type %q", r.ContentType)
+}
+
+// setContentHidden hides or unhides the reported issue, pull request or comment, nothing is deleted, so a wrong
+// decision can be undone by unhiding the content
+func setContentHidden(ctx context.Context, r *moderation_model.AbuseReport, hidden bool) error {
+	switch r.ContentType {
+	case moderation_model.ReportContentIssue:
+		issue, err := issues_model.GetIssueByID(ctx, r.ContentID)
+		if err != nil {
+			return err
+		}
+		issue.IsHidden = hidden
+		return issues_model.UpdateIssueCols(ctx, issue, "is_hidden")
+	case moderation_model.ReportContentComment:
+		comment, err := issues_model.GetCommentByID(ctx, r.ContentID)
+		if err != nil {
+			return err
+		}
+		comment.IsHidden = hidden
+		return issues_model.UpdateCommentHidden(ctx, comment)
+	}
+	return util.NewInvalidArgumentErrorf("only issues and comments can be unhidden")
+}
+
+// updateIssueIndexer reindexes the issue of the reported issue or comment after it has been hidden or unhidden, the
+// indexer leaves out the hidden issues and comments
+func updateIssueIndexer(ctx context.Context, r *moderation_model.AbuseReport) {
+	switch r.ContentType {
+	case moderation_model.ReportContentIssue:
+		issue_indexer.UpdateIssueIndexer(ctx, r.ContentID)
+	case moderation_model.ReportContentComment:
+		comment, err := issues_model.GetCommentByID(ctx, r.ContentID)
+		if err != nil {
+			log.Error("GetCommentByID(%d): %v", r.ContentID, err)
+			return
+		}
+		issue_indexer.UpdateIssueIndexer(ctx, comment.IssueID)
+	}
+}
+
+// UnhideContent undoes the hide action of the resolved report, it unhides the reported issue, pull request or comment
+// and restores the visibility the reported user or repository had before
+func UnhideContent(ctx context.Context, doer *user_model.User, r *moderation_model.AbuseReport) error {
+	if !IsModerator(doer) {
+		return util.NewPermissionDeniedErrorf("only moderators can unhide content")
+	} else if r.IsOpen() || r.Action != moderation_model.ModerationActionHide {
+		return util.NewInvalidArgumentErrorf("the content hasn't been hidden by the report")
+	}
+	var err error
+	switch r.ContentType {
+	case moderation_model.ReportContentUser, moderation_model.ReportContentRepo:
+		err = restoreVisibility(ctx, r)
+	default:
+		err = setContentHidden(ctx, r, false)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return util.NewInvalidArgumentErrorf("the reported content does not exist anymore")
+		}
+		return err
+	}
+	updateIssueIndexer(ctx, r)
+	return nil
+}
+
+// suspendUser prohibits the user from signing in and signs out the user everywhere
+func suspendUser(ctx context.Context, u *user_model.User) error {
+	u.ProhibitLogin = true
+	if err := user_model.UpdateUserCols(ctx, u, "prohibit_login"); err != nil {
+		return err
+	}
+	return auth_model.DeleteAuthTokensByUserID(ctx, u.ID)
+}
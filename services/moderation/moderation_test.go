@@ -0,0 +1,204 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package moderation
+
+import (
+	"slices"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+	user_service "code.gitea.io/gitea/services/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportContent(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	r, err := ReportContent(t.Context(), user2, moderation_model.ReportContentComment, 2, moderation_model.ReportCategorySpam, " buy now ")
+	require.NoError(t, err)
+	r = unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: r.ID})
+	assert.Equal(t, moderation_model.ReportStatusOpen, r.Status)
+	assert.EqualValues(t, 3, r.OwnerID)
+	assert.EqualValues(t, 1, r.RepoID)
+	assert.Equal(t, "good work!", r.ContentSnapshot)
+	assert.Equal(t, "buy now", r.Remarks)
+
+	_, err = ReportContent(t.Context(), user2, moderation_model.ReportContentComment, 2, moderation_model.ReportCategorySpam, "")
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	_, err = ReportContent(t.Context(), user2, moderation_model.ReportContentUser, 2, moderation_model.ReportCategorySpam, "")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	_, err = ReportContent(t.Context(), user2, moderation_model.ReportContentIssue, 1, "unknown", "")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// the private repository isn't visible to user4
+	_, err = ReportContent(t.Context(), user4, moderation_model.ReportContentRepo, 2, moderation_model.ReportCategoryMalware, "")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	_, err = ReportContent(t.Context(), user4, moderation_model.ReportContentIssue, unittest.NonexistentID, moderation_model.ReportCategorySpam, "")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
+
+func TestResolveReport(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	r1, err := ReportContent(t.Context(), user2, moderation_model.ReportContentComment, 2, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	r2, err := ReportContent(t.Context(), user5, moderation_model.ReportContentComment, 2, moderation_model.ReportCategoryHarassment, "")
+	require.NoError(t, err)
+
+	err = ResolveReport(t.Context(), user4, r1, moderation_model.ModerationActionHide, "")
+	assert.ErrorIs(t, err, util.ErrPermissionDenied)
+
+	defer test.MockVariableValue(&setting.Moderation.Moderators, []string{"user4"})()
+	err = ResolveReport(t.Context(), user4, r1, moderation_model.ModerationActionWarn, "")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// all reports of the content are resolved at once
+	require.NoError(t, ResolveReport(t.Context(), user4, r1, moderation_model.ModerationActionHide, "spam"))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2}).IsHidden)
+	for _, id := range []int64{r1.ID, r2.ID} {
+		r := unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: id})
+		assert.Equal(t, moderation_model.ReportStatusResolved, r.Status)
+		assert.Equal(t, moderation_model.ModerationActionHide, r.Action)
+		assert.EqualValues(t, 4, r.ResolverID)
+		assert.NotZero(t, r.ResolvedUnix)
+	}
+	assert.ErrorIs(t, ResolveReport(t.Context(), user4, r1, moderation_model.ModerationActionNone, ""), util.ErrInvalidArgument)
+
+	// administrators can't be suspended, the report stays open
+	r3, err := ReportContent(t.Context(), user2, moderation_model.ReportContentIssue, 1, moderation_model.ReportCategoryOther, "")
+	require.NoError(t, err)
+	assert.ErrorIs(t, ResolveReport(t.Context(), user4, r3, moderation_model.ModerationActionSuspend, ""), util.ErrInvalidArgument)
+	assert.Equal(t, moderation_model.ReportStatusOpen, unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: r3.ID}).Status)
+
+	// the profiles of administrators can't be hidden either
+	r5, err := ReportContent(t.Context(), user2, moderation_model.ReportContentUser, 1, moderation_model.ReportCategoryOther, "")
+	require.NoError(t, err)
+	assert.ErrorIs(t, ResolveReport(t.Context(), user4, r5, moderation_model.ModerationActionHide, ""), util.ErrInvalidArgument)
+	assert.Equal(t, moderation_model.ReportStatusOpen, unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: r5.ID}).Status)
+	assert.Equal(t, structs.VisibleTypePublic, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1}).Visibility)
+
+	tpl := &moderation_model.ResolutionTemplate{Name: "Spammer", Action: moderation_model.ModerationActionSuspend, Message: "Spam is not tolerated."}
+	require.NoError(t, moderation_model.CreateResolutionTemplate(t.Context(), tpl))
+	assert.ErrorIs(t, moderation_model.CreateResolutionTemplate(t.Context(), &moderation_model.ResolutionTemplate{Name: "Spammer", Action: moderation_model.ModerationActionNone}), util.ErrAlreadyExist)
+
+	r4, err := ReportContent(t.Context(), user2, moderation_model.ReportContentUser, 5, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	require.NoError(t, ResolveReportWithTemplate(t.Context(), user4, r4, tpl.ID))
+	r4 = unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: r4.ID})
+	assert.Equal(t, moderation_model.ModerationActionSuspend, r4.Action)
+	assert.Equal(t, "Spam is not tolerated.", r4.ResolutionMessage)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5}).ProhibitLogin)
+}
+
+func TestHideAndUnhideContent(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Moderation.Moderators, []string{"user4"})()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	r, err := ReportContent(t.Context(), user2, moderation_model.ReportContentComment, 2, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	assert.ErrorIs(t, UnhideContent(t.Context(), user4, r), util.ErrInvalidArgument)
+	require.NoError(t, ResolveReport(t.Context(), user4, r, moderation_model.ModerationActionHide, ""))
+
+	// the hidden comment is kept but left out of the views and of the number of comments
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	assert.True(t, comment.IsHidden)
+	comments, err := issues_model.FindComments(t.Context(), &issues_model.FindCommentsOptions{IssueID: comment.IssueID, HideModerated: true})
+	require.NoError(t, err)
+	for _, c := range comments {
+		assert.NotEqual(t, comment.ID, c.ID)
+	}
+	comments, err = issues_model.FindComments(t.Context(), &issues_model.FindCommentsOptions{IssueID: comment.IssueID})
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(comments, func(c *issues_model.Comment) bool { return c.ID == comment.ID }))
+	numComments := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: comment.IssueID}).NumComments
+
+	assert.ErrorIs(t, UnhideContent(t.Context(), user2, r), util.ErrPermissionDenied)
+	require.NoError(t, UnhideContent(t.Context(), user4, r))
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2}).IsHidden)
+	assert.Equal(t, numComments+1, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: comment.IssueID}).NumComments)
+
+	// a hidden issue is still loaded, the moderators and the administrators of the repository can see it
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	r, err = ReportContent(t.Context(), user4, moderation_model.ReportContentIssue, issue.ID, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	require.NoError(t, ResolveReport(t.Context(), user4, r, moderation_model.ModerationActionHide, ""))
+	issue, err = issues_model.GetIssueByIndex(t.Context(), issue.RepoID, issue.Index)
+	require.NoError(t, err)
+	require.NoError(t, issue.LoadRepo(t.Context()))
+	for _, u := range []*user_model.User{user2, user4, user5} {
+		perm, err := access_model.GetUserRepoPermission(t.Context(), issue.Repo, u)
+		require.NoError(t, err)
+		assert.Equal(t, u == user5, IsIssueHiddenFrom(issue, u, &perm), "user %s", u.Name)
+	}
+
+	require.NoError(t, UnhideContent(t.Context(), user4, r))
+	issue, err = issues_model.GetIssueByIndex(t.Context(), issue.RepoID, issue.Index)
+	require.NoError(t, err)
+	assert.False(t, issue.IsHidden)
+}
+
+func TestHideAndUnhideRepoAndUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Moderation.Moderators, []string{"user4"})()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	// the hidden repository is made private and made public again when it's unhidden
+	r, err := ReportContent(t.Context(), user4, moderation_model.ReportContentRepo, 1, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	require.NoError(t, ResolveReport(t.Context(), user4, r, moderation_model.ModerationActionHide, ""))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}).IsPrivate)
+	r = unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: r.ID})
+	assert.Equal(t, "public", r.PreviousVisibility)
+	require.NoError(t, r.LoadAttributes(t.Context()))
+	assert.True(t, r.IsContentHidden)
+
+	require.NoError(t, UnhideContent(t.Context(), user4, r))
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}).IsPrivate)
+
+	// the profile gets its previous visibility back
+	require.NoError(t, user_service.UpdateUser(t.Context(), unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5}),
+		&user_service.UpdateOptions{Visibility: optional.Some(structs.VisibleTypeLimited)}))
+	r, err = ReportContent(t.Context(), user2, moderation_model.ReportContentUser, 5, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	require.NoError(t, ResolveReport(t.Context(), user4, r, moderation_model.ModerationActionHide, ""))
+	assert.Equal(t, structs.VisibleTypePrivate, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5}).Visibility)
+
+	require.NoError(t, UnhideContent(t.Context(), user4, r))
+	assert.Equal(t, structs.VisibleTypeLimited, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5}).Visibility)
+
+	// a profile which was already private has nothing to restore
+	r, err = ReportContent(t.Context(), user2, moderation_model.ReportContentUser, 5, moderation_model.ReportCategorySpam, "")
+	require.NoError(t, err)
+	require.NoError(t, user_service.UpdateUser(t.Context(), unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5}),
+		&user_service.UpdateOptions{Visibility: optional.Some(structs.VisibleTypePrivate)}))
+	require.NoError(t, ResolveReport(t.Context(), user4, r, moderation_model.ModerationActionHide, ""))
+	assert.ErrorIs(t, UnhideContent(t.Context(), user4, r), util.ErrInvalidArgument)
+}
@@ -41,6 +41,8 @@ type Notifier interface {
 	IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string)
 	IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 		addedLabels, removedLabels []*issues_model.Label)
+	IssueSLAReminder(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus)
+	IssueSLABreached(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus)
 
 	NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User)
 	MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest)
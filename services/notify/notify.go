@@ -274,6 +274,20 @@ func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues
 	}
 }
 
+// IssueSLAReminder notifies that the deadline of an SLA rule of an issue is near to notifiers
+func IssueSLAReminder(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+	for _, notifier := range notifiers {
+		notifier.IssueSLAReminder(ctx, issue, status)
+	}
+}
+
+// IssueSLABreached notifies that an SLA rule of an issue is breached to notifiers
+func IssueSLABreached(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+	for _, notifier := range notifiers {
+		notifier.IssueSLABreached(ctx, issue, status)
+	}
+}
+
 // CreateRepository notifies create repository to notifiers
 func CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
@@ -143,6 +143,14 @@ func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.Use
 	addedLabels, removedLabels []*issues_model.Label) {
 }
 
+// IssueSLAReminder places a place holder function
+func (*NullNotifier) IssueSLAReminder(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+}
+
+// IssueSLABreached places a place holder function
+func (*NullNotifier) IssueSLABreached(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+}
+
 // CreateRepository places a place holder function
 func (*NullNotifier) CreateRepository(ctx context.Context, doer, u *user_model.User, repo *repo_model.Repository) {
 }
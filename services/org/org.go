@@ -10,9 +10,11 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
 	org_model "code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
 	user_model "code.gitea.io/gitea/models/user"
@@ -35,14 +37,29 @@ func deleteOrganization(ctx context.Context, org *org_model.Organization) error
 		&org_model.TeamUser{OrgID: org.ID},
 		&org_model.TeamUnit{OrgID: org.ID},
 		&org_model.TeamInvite{OrgID: org.ID},
+		&org_model.TimeTrackingRule{OrgID: org.ID},
+		&org_model.LicensePolicy{OrgID: org.ID},
+		&org_model.SignOffPolicy{OrgID: org.ID},
+		&org_model.OrgRole{OrgID: org.ID},
+		&org_model.OrgRoleAssignment{OrgID: org.ID},
 		&secret_model.Secret{OwnerID: org.ID},
 		&user_model.Blocking{BlockerID: org.ID},
 		&actions_model.ActionRunner{OwnerID: org.ID},
 		&actions_model.ActionRunnerToken{OwnerID: org.ID},
+		&issues_model.IssueSavedSearch{OwnerID: org.ID},
+		&quota_model.Limit{OwnerID: org.ID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
 
+	if err := issues_model.DeleteIssueFields(ctx, 0, org.ID); err != nil {
+		return fmt.Errorf("DeleteIssueFields: %w", err)
+	}
+
+	if err := issues_model.DeleteIterations(ctx, 0, org.ID); err != nil {
+		return fmt.Errorf("DeleteIterations: %w", err)
+	}
+
 	if _, err := db.GetEngine(ctx).ID(org.ID).Delete(new(user_model.User)); err != nil {
 		return fmt.Errorf("Delete: %w", err)
 	}
@@ -55,6 +55,10 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		return organization.ErrTeamAlreadyExist{OrgID: t.OrgID, Name: t.LowerName}
 	}
 
+	if err = organization.ValidateTeamParent(ctx, t, t.ParentID); err != nil {
+		return err
+	}
+
 	return db.WithTx(ctx, func(ctx context.Context) error {
 		if err = db.Insert(ctx, t); err != nil {
 			return err
@@ -185,15 +189,25 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 			return err
 		}
 
+		// the child teams are moved to the parent of the team
+		if _, err := db.GetEngine(ctx).Where("parent_id = ?", t.ID).Cols("parent_id").
+			Update(&organization.Team{ParentID: t.ParentID}); err != nil {
+			return err
+		}
+
 		if err := db.DeleteBeans(ctx,
 			&organization.Team{ID: t.ID},
 			&organization.TeamUser{OrgID: t.OrgID, TeamID: t.ID},
 			&organization.TeamUnit{TeamID: t.ID},
 			&organization.TeamInvite{TeamID: t.ID},
+			&organization.OrgRoleAssignment{TeamID: t.ID},
 			&issues_model.Review{Type: issues_model.ReviewTypeRequest, ReviewerTeamID: t.ID}, // batch delete the binding relationship between team and PR (request review from team)
 		); err != nil {
 			return err
 		}
+		if err := syncInheritedTeamMembers(ctx, t.OrgID); err != nil {
+			return err
+		}
 
 		for _, tm := range t.Members {
 			if err := removeInvalidOrgUser(ctx, t.OrgID, tm); err != nil {
@@ -214,8 +228,8 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		return user_model.ErrBlockedUser
 	}
 
-	isAlreadyMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
-	if err != nil || isAlreadyMember {
+	teamUser, err := organization.GetTeamUser(ctx, team.ID, user.ID)
+	if err != nil || (teamUser != nil && !teamUser.IsInherited) {
 		return err
 	}
 
@@ -225,8 +239,16 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 
 	err = db.WithTx(ctx, func(ctx context.Context) error {
 		// check in transaction
-		isAlreadyMember, err = organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
-		if err != nil || isAlreadyMember {
+		teamUser, err = organization.GetTeamUser(ctx, team.ID, user.ID)
+		if err != nil {
+			return err
+		} else if teamUser != nil {
+			// an inherited member becomes a member of the team itself
+			if !teamUser.IsInherited {
+				return nil
+			}
+			teamUser.IsInherited = false
+			_, err = db.GetEngine(ctx).ID(teamUser.ID).Cols("is_inherited").Update(teamUser)
 			return err
 		}
 
@@ -243,6 +265,9 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		}
 
 		team.NumMembers++
+		if team.ParentID > 0 {
+			return syncInheritedTeamMembers(ctx, team.OrgID)
+		}
 		return nil
 	})
 	if err != nil {
@@ -262,7 +287,7 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 
 		go func(repos []*repo_model.Repository) {
 			for _, repo := range repos {
-				if err = repo_model.WatchRepo(graceful.GetManager().ShutdownContext(), user, repo, true); err != nil {
+				if err = repo_model.AutoWatchRepo(graceful.GetManager().ShutdownContext(), user, repo); err != nil {
 					log.Error("watch repo failed: %v", err)
 				}
 			}
@@ -274,9 +299,11 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 
 func removeTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
 	e := db.GetEngine(ctx)
-	isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
-	if err != nil || !isMember {
+	teamUser, err := organization.GetTeamUser(ctx, team.ID, user.ID)
+	if err != nil || teamUser == nil {
 		return err
+	} else if teamUser.IsInherited {
+		return organization.ErrTeamMemberInherited
 	}
 
 	// Check if the user to delete is the last member in owner team.
@@ -306,6 +333,11 @@ func removeTeamMember(ctx context.Context, team *organization.Team, user *user_m
 		return err
 	}
 
+	// the user may still be an inherited member of the team and its ancestors
+	if err := syncInheritedTeamMembers(ctx, team.OrgID); err != nil {
+		return err
+	}
+
 	// Delete access to team repositories.
 	for _, repo := range repos {
 		if err := access_model.RecalculateUserAccess(ctx, repo, user.ID); err != nil {
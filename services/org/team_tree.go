@@ -0,0 +1,146 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// MoveTeam moves the team below the parent team, parentID 0 moves it to the top level.
+// The members of the team and its descendants are inherited by the new ancestors and aren't by the old ones anymore.
+func MoveTeam(ctx context.Context, t *organization.Team, parentID int64) error {
+	if t.ParentID == parentID {
+		return nil
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := organization.ValidateTeamParent(ctx, t, parentID); err != nil {
+			return err
+		}
+		t.ParentID = parentID
+		if _, err := db.GetEngine(ctx).ID(t.ID).Cols("parent_id").Update(t); err != nil {
+			return err
+		}
+		return syncInheritedTeamMembers(ctx, t.OrgID)
+	})
+}
+
+// syncInheritedTeamMembers makes the members of every team of the organization the inherited members of all its
+// ancestors, so that child teams get the repository permissions of their ancestors. The accesses of the repositories
+// of the changed teams are recalculated.
+func syncInheritedTeamMembers(ctx context.Context, orgID int64) error {
+	teams, err := organization.FindOrgTeams(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	var teamUsers []*organization.TeamUser
+	if err := db.GetEngine(ctx).Where("org_id = ?", orgID).Find(&teamUsers); err != nil {
+		return err
+	}
+
+	children := make(map[int64][]int64, len(teams))
+	for _, t := range teams {
+		if t.ParentID > 0 {
+			children[t.ParentID] = append(children[t.ParentID], t.ID)
+		}
+	}
+	existing := make(map[int64]map[int64]*organization.TeamUser, len(teams))
+	direct := make(map[int64]container.Set[int64], len(teams))
+	for _, tu := range teamUsers {
+		if existing[tu.TeamID] == nil {
+			existing[tu.TeamID] = make(map[int64]*organization.TeamUser)
+			direct[tu.TeamID] = make(container.Set[int64])
+		}
+		existing[tu.TeamID][tu.UID] = tu
+		if !tu.IsInherited {
+			direct[tu.TeamID].Add(tu.UID)
+		}
+	}
+
+	members := make(map[int64]container.Set[int64], len(teams))
+	var collect func(teamID int64, depth int) container.Set[int64]
+	collect = func(teamID int64, depth int) container.Set[int64] {
+		if set, ok := members[teamID]; ok {
+			return set
+		}
+		set := make(container.Set[int64]).Union(direct[teamID])
+		if depth <= len(teams) { // guard against a broken tree
+			for _, childID := range children[teamID] {
+				set = set.Union(collect(childID, depth+1))
+			}
+		}
+		members[teamID] = set
+		return set
+	}
+
+	removed := make(map[int64][]int64) // team id => user ids
+	var changedTeams []*organization.Team
+	for _, t := range teams {
+		want := collect(t.ID, 0)
+		changed := false
+		for uid := range want {
+			if _, ok := existing[t.ID][uid]; ok {
+				continue
+			}
+			if err := db.Insert(ctx, &organization.TeamUser{OrgID: orgID, TeamID: t.ID, UID: uid, IsInherited: true}); err != nil {
+				return err
+			}
+			changed = true
+		}
+		for uid, tu := range existing[t.ID] {
+			if !tu.IsInherited || want.Contains(uid) {
+				continue
+			}
+			if _, err := db.GetEngine(ctx).ID(tu.ID).Delete(new(organization.TeamUser)); err != nil {
+				return err
+			}
+			removed[t.ID] = append(removed[t.ID], uid)
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		t.NumMembers = len(want)
+		if _, err := db.GetEngine(ctx).ID(t.ID).Cols("num_members").Update(t); err != nil {
+			return err
+		}
+		changedTeams = append(changedTeams, t)
+	}
+
+	recalculated := make(container.Set[int64])
+	for _, t := range changedTeams {
+		repos, err := repo_model.GetTeamRepositories(ctx, &repo_model.SearchTeamRepoOptions{TeamID: t.ID})
+		if err != nil {
+			return fmt.Errorf("GetTeamRepositories: %w", err)
+		}
+		for _, repo := range repos {
+			if recalculated.Add(repo.ID) {
+				if err := access_model.RecalculateTeamAccesses(ctx, repo, 0); err != nil {
+					return fmt.Errorf("RecalculateTeamAccesses: %w", err)
+				}
+			}
+			for _, uid := range removed[t.ID] {
+				u, err := user_model.GetUserByID(ctx, uid)
+				if err != nil {
+					return err
+				}
+				if err := repo_service.ReconsiderWatches(ctx, repo, u); err != nil {
+					return err
+				}
+				if err := repo_service.ReconsiderRepoIssuesAssignee(ctx, repo, u); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
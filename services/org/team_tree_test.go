@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNestedTeams(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ownerTeam := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 1})
+	parent := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 2}) // has write access to repo 3
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	child := &organization.Team{
+		OrgID:      parent.OrgID,
+		Name:       "child",
+		AccessMode: perm.AccessModeRead,
+		ParentID:   parent.ID,
+		Units:      []*organization.TeamUnit{{OrgID: parent.OrgID, Type: unit.TypeCode, AccessMode: perm.AccessModeRead}},
+	}
+	require.NoError(t, NewTeam(t.Context(), child))
+
+	assertCanWriteRepo3 := func(t *testing.T, expected bool) {
+		permission, err := access_model.GetUserRepoPermission(t.Context(), repo3, user5)
+		require.NoError(t, err)
+		assert.Equal(t, expected, permission.CanWrite(unit.TypeCode))
+	}
+	assertCanWriteRepo3(t, false)
+
+	// the member of the child team is an inherited member of the parent team
+	require.NoError(t, AddTeamMember(t.Context(), child, user5))
+	tu := unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: parent.ID, UID: user5.ID})
+	assert.True(t, tu.IsInherited)
+	unittest.CheckConsistencyFor(t, &organization.Team{ID: parent.ID}, &organization.Team{ID: child.ID})
+	assertCanWriteRepo3(t, true)
+
+	parent = unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: parent.ID})
+	assert.ErrorIs(t, RemoveTeamMember(t.Context(), parent, user5), organization.ErrTeamMemberInherited)
+
+	// the owners team can't be part of the tree and there must be no cycles
+	assert.ErrorIs(t, MoveTeam(t.Context(), child, ownerTeam.ID), util.ErrInvalidArgument)
+	assert.ErrorIs(t, MoveTeam(t.Context(), ownerTeam, parent.ID), util.ErrInvalidArgument)
+	assert.ErrorIs(t, MoveTeam(t.Context(), parent, child.ID), util.ErrInvalidArgument)
+	assert.ErrorIs(t, MoveTeam(t.Context(), parent, parent.ID), util.ErrInvalidArgument)
+
+	// moving the child team to the top level removes the inherited membership
+	require.NoError(t, MoveTeam(t.Context(), child, 0))
+	unittest.AssertNotExistsBean(t, &organization.TeamUser{TeamID: parent.ID, UID: user5.ID})
+	unittest.CheckConsistencyFor(t, &organization.Team{ID: parent.ID})
+	assertCanWriteRepo3(t, false)
+
+	require.NoError(t, MoveTeam(t.Context(), child, parent.ID))
+	assertCanWriteRepo3(t, true)
+
+	// an inherited member becomes a direct member and stays one when the child membership ends
+	require.NoError(t, AddTeamMember(t.Context(), parent, user5))
+	tu = unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: parent.ID, UID: user5.ID})
+	assert.False(t, tu.IsInherited)
+	require.NoError(t, RemoveTeamMember(t.Context(), child, user5))
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: parent.ID, UID: user5.ID, IsInherited: false})
+	require.NoError(t, RemoveTeamMember(t.Context(), parent, user5))
+	assertCanWriteRepo3(t, false)
+
+	// deleting a team removes the inherited memberships and moves its children up
+	grandchild := &organization.Team{OrgID: parent.OrgID, Name: "grandchild", AccessMode: perm.AccessModeRead, ParentID: child.ID}
+	require.NoError(t, NewTeam(t.Context(), grandchild))
+	require.NoError(t, AddTeamMember(t.Context(), child, user5))
+	require.NoError(t, DeleteTeam(t.Context(), child))
+	grandchild = unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: grandchild.ID})
+	assert.Equal(t, parent.ID, grandchild.ParentID)
+	unittest.AssertNotExistsBean(t, &organization.TeamUser{TeamID: parent.ID, UID: user5.ID})
+	unittest.CheckConsistencyFor(t, &organization.Team{ID: parent.ID})
+	assertCanWriteRepo3(t, false)
+}
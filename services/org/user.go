@@ -6,6 +6,7 @@ package org
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"code.gitea.io/gitea/models/db"
@@ -89,10 +90,14 @@ func RemoveOrgUser(ctx context.Context, org *organization.Organization, user *us
 			return err
 		}
 		for _, t := range teams {
-			if err = removeTeamMember(ctx, t, user); err != nil {
+			// the inherited memberships are removed together with the memberships of the child teams
+			if err = removeTeamMember(ctx, t, user); err != nil && !errors.Is(err, organization.ErrTeamMemberInherited) {
 				return err
 			}
 		}
-		return nil
+
+		// Delete the custom roles assigned to the user.
+		_, err = db.GetEngine(ctx).Where("org_id = ? AND user_id = ?", org.ID, user.ID).Delete(new(organization.OrgRoleAssignment))
+		return err
 	})
 }
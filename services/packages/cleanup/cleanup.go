@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	packages_module "code.gitea.io/gitea/modules/packages"
@@ -20,7 +21,10 @@ import (
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
 	debian_service "code.gitea.io/gitea/services/packages/debian"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
 	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+
+	"github.com/hashicorp/go-version"
 )
 
 // CleanupTask executes cleanup rules and cleanup expired package data
@@ -32,27 +36,30 @@ func CleanupTask(ctx context.Context, olderThan time.Duration) error {
 	return CleanupExpiredData(ctx, olderThan)
 }
 
-func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) (versionDeleted bool, err error) {
-	olderThan := time.Now().AddDate(0, 0, -pcr.RemoveDays)
+// isPrerelease checks if the version is a pre-release like "1.0.0-rc.1" or "1.0rc1"
+func isPrerelease(pv *packages_model.PackageVersion) bool {
+	v, err := version.NewVersion(pv.Version)
+	return err == nil && v.Prerelease() != ""
+}
+
+// selectVersionsToRemove returns the versions of the package which get removed by the rule
+func selectVersionsToRemove(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) ([]*packages_model.PackageVersion, error) {
+	now := time.Now()
+	olderThan := now.AddDate(0, 0, -pcr.RemoveDays)
 	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
 		PackageID:  p.ID,
 		IsInternal: optional.Some(false),
 		Sort:       packages_model.SortCreatedDesc,
 	})
 	if err != nil {
-		return false, fmt.Errorf("CleanupRule [%d]: SearchVersions failed: %w", pcr.ID, err)
+		return nil, fmt.Errorf("CleanupRule [%d]: SearchVersions failed: %w", pcr.ID, err)
 	}
-	if pcr.KeepCount > 0 {
-		if pcr.KeepCount < len(pvs) {
-			pvs = pvs[pcr.KeepCount:]
-		} else {
-			pvs = nil
-		}
-	}
-	for _, pv := range pvs {
+
+	versionsToRemove := make([]*packages_model.PackageVersion, 0, len(pvs))
+	for i, pv := range pvs {
 		if pcr.Type == packages_model.TypeContainer {
 			if skip, err := container_service.ShouldBeSkipped(ctx, pcr, p, pv); err != nil {
-				return false, fmt.Errorf("CleanupRule [%d]: container.ShouldBeSkipped failed: %w", pcr.ID, err)
+				return nil, fmt.Errorf("CleanupRule [%d]: container.ShouldBeSkipped failed: %w", pcr.ID, err)
 			} else if skip {
 				log.Debug("Rule[%d]: keep '%s/%s' (container)", pcr.ID, p.Name, pv.Version)
 				continue
@@ -66,6 +73,23 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 			log.Debug("Rule[%d]: keep '%s/%s' (keep pattern)", pcr.ID, p.Name, pv.Version)
 			continue
 		}
+
+		// pre-releases and untagged manifests are removed even if they are one of the versions to keep
+		if pcr.RemovePrereleaseDays > 0 && isPrerelease(pv) && pv.CreatedUnix.AsLocalTime().Before(now.AddDate(0, 0, -pcr.RemovePrereleaseDays)) {
+			log.Debug("Rule[%d]: remove '%s/%s' (pre-release)", pcr.ID, p.Name, pv.Version)
+			versionsToRemove = append(versionsToRemove, pv)
+			continue
+		}
+		if pcr.Type == packages_model.TypeContainer && pcr.RemoveUntaggedDays > 0 && container_service.IsUntagged(pv) && pv.CreatedUnix.AsLocalTime().Before(now.AddDate(0, 0, -pcr.RemoveUntaggedDays)) {
+			log.Debug("Rule[%d]: remove '%s/%s' (untagged)", pcr.ID, p.Name, pv.Version)
+			versionsToRemove = append(versionsToRemove, pv)
+			continue
+		}
+
+		if pcr.KeepCount > 0 && i < pcr.KeepCount {
+			log.Debug("Rule[%d]: keep '%s/%s' (keep count)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
 		if pv.CreatedUnix.AsLocalTime().After(olderThan) {
 			log.Debug("Rule[%d]: keep '%s/%s' (remove days) %v", pcr.ID, p.Name, pv.Version, pv.CreatedUnix.FormatDate())
 			continue
@@ -75,6 +99,76 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 			continue
 		}
 		log.Debug("Rule[%d]: remove '%s/%s'", pcr.ID, p.Name, pv.Version)
+		versionsToRemove = append(versionsToRemove, pv)
+	}
+	return versionsToRemove, nil
+}
+
+// getRulePackages returns the packages the rule applies to.
+// A rule limited to a repository applies to the packages linked to the repository,
+// a rule of the owner applies to all other packages of the type.
+func getRulePackages(ctx context.Context, pcr *packages_model.PackageCleanupRule) ([]*packages_model.Package, error) {
+	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
+	if err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: GetPackagesByType failed: %w", pcr.ID, err)
+	}
+
+	var repoIDs container.Set[int64]
+	if pcr.RepoID == 0 {
+		ids, err := packages_model.GetRepoIDsWithCleanupRule(ctx, pcr.OwnerID, pcr.Type)
+		if err != nil {
+			return nil, fmt.Errorf("CleanupRule [%d]: GetRepoIDsWithCleanupRule failed: %w", pcr.ID, err)
+		}
+		repoIDs = container.SetOf(ids...)
+	}
+
+	filtered := make([]*packages_model.Package, 0, len(packages))
+	for _, p := range packages {
+		if pcr.RepoID != 0 && p.RepoID != pcr.RepoID {
+			continue
+		}
+		if pcr.RepoID == 0 && p.RepoID != 0 && repoIDs.Contains(p.RepoID) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered, nil
+}
+
+// PreviewCleanupRule returns the versions which would be removed if the rule gets executed now
+func PreviewCleanupRule(ctx context.Context, pcr *packages_model.PackageCleanupRule) ([]*packages_model.PackageDescriptor, error) {
+	if err := pcr.CompiledPattern(); err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: CompilePattern failed: %w", pcr.ID, err)
+	}
+
+	packages, err := getRulePackages(ctx, pcr)
+	if err != nil {
+		return nil, err
+	}
+
+	pds := make([]*packages_model.PackageDescriptor, 0, 10)
+	for _, p := range packages {
+		pvs, err := selectVersionsToRemove(ctx, pcr, p)
+		if err != nil {
+			return nil, err
+		}
+		for _, pv := range pvs {
+			pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+			if err != nil {
+				return nil, err
+			}
+			pds = append(pds, pd)
+		}
+	}
+	return pds, nil
+}
+
+func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) (versionDeleted bool, err error) {
+	pvs, err := selectVersionsToRemove(ctx, pcr, p)
+	if err != nil {
+		return false, err
+	}
+	for _, pv := range pvs {
 		if err := packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
 			log.Error("CleanupRule [%d]: DeletePackageVersionAndReferences failed: %v", pcr.ID, err)
 			continue
@@ -89,9 +183,9 @@ func executeCleanupOneRule(ctx context.Context, pcr *packages_model.PackageClean
 		return fmt.Errorf("CleanupRule [%d]: CompilePattern failed: %w", pcr.ID, err)
 	}
 
-	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
+	packages, err := getRulePackages(ctx, pcr)
 	if err != nil {
-		return fmt.Errorf("CleanupRule [%d]: GetPackagesByType failed: %w", pcr.ID, err)
+		return err
 	}
 
 	anyVersionDeleted := false
@@ -171,6 +265,10 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 			return err
 		}
 
+		if err := nix_service.Cleanup(ctx, olderThan); err != nil {
+			return err
+		}
+
 		ps, err := packages_model.FindUnreferencedPackages(ctx)
 		if err != nil {
 			return err
@@ -24,9 +24,9 @@ import (
 	"github.com/opencontainers/go-digest"
 )
 
-// saveAsPackageBlob creates a package blob from an upload
+// SaveAsPackageBlob creates a package blob from an upload
 // The uploaded blob gets stored in a special upload version to link them to the package/image
-func saveAsPackageBlob(ctx context.Context, hsr packages_module.HashedSizeReader, pci *packages_service.PackageCreationInfo) (*packages_model.PackageBlob, error) { //nolint:unparam // PackageBlob is never used
+func SaveAsPackageBlob(ctx context.Context, hsr packages_module.HashedSizeReader, pci *packages_service.PackageCreationInfo) (*packages_model.PackageBlob, error) { //nolint:unparam // PackageBlob is never used
 	pb := packages_service.NewPackageBlob(hsr)
 
 	exists := false
@@ -78,8 +78,8 @@ func saveAsPackageBlob(ctx context.Context, hsr packages_module.HashedSizeReader
 	return pb, nil
 }
 
-// mountBlob mounts the specific blob to a different package
-func mountBlob(ctx context.Context, pi *packages_service.PackageInfo, pb *packages_model.PackageBlob) error {
+// MountBlob mounts the specific blob to a different package
+func MountBlob(ctx context.Context, pi *packages_service.PackageInfo, pb *packages_model.PackageBlob) error {
 	uploadVersion, err := getOrCreateUploadVersion(ctx, pi)
 	if err != nil {
 		return err
@@ -170,7 +170,8 @@ func createFileForBlob(ctx context.Context, pv *packages_model.PackageVersion, p
 	return nil
 }
 
-func deleteBlob(ctx context.Context, ownerID int64, image string, digest digest.Digest) error {
+// DeleteBlob deletes the blob with the digest from all the versions of the image
+func DeleteBlob(ctx context.Context, ownerID int64, image string, digest digest.Digest) error {
 	releaser, err := globallock.Lock(ctx, containerGlobalLockKey(ownerID, image, "blob"))
 	if err != nil {
 		return err
@@ -196,7 +197,8 @@ func deleteBlob(ctx context.Context, ownerID int64, image string, digest digest.
 	})
 }
 
-func digestFromHashSummer(h packages_module.HashSummer) string {
+// DigestFromHashSummer returns the sha256 digest of the hashed content
+func DigestFromHashSummer(h packages_module.HashSummer) string {
 	_, _, hashSHA256, _ := h.Sums()
 	return "sha256:" + hex.EncodeToString(hashSHA256)
 }
@@ -79,6 +79,11 @@ func cleanupExpiredUploadedBlobs(ctx context.Context, olderThan time.Duration) e
 	return nil
 }
 
+// IsUntagged checks if the version is a manifest which was pushed by digest instead of a tag
+func IsUntagged(pv *packages_model.PackageVersion) bool {
+	return digest.Digest(pv.LowerVersion).Validate() == nil
+}
+
 func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
 	// Always skip the "latest" tag
 	if pv.LowerVersion == "latest" {
@@ -86,7 +91,7 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 	}
 
 	// Check if the version is a digest (or untagged)
-	if digest.Digest(pv.LowerVersion).Validate() == nil {
+	if IsUntagged(pv) {
 		// Check if there is another manifest referencing this version
 		has, err := packages_model.ExistVersion(ctx, &packages_model.PackageSearchOptions{
 			PackageID: p.ID,
@@ -104,5 +109,6 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 		}
 	}
 
-	return false, nil
+	// Signatures and other artifacts are kept as long as the manifest they are attached to exists
+	return isAttachedToExistingManifest(ctx, p, pv)
 }
@@ -0,0 +1,257 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const maxImportedManifestSize = 10 * 1024 * 1024
+
+var (
+	importImageNamePattern = regexp.MustCompile(`\A[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*\z`)
+	importTagPattern       = regexp.MustCompile(`\A[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}\z`)
+)
+
+// imageLayout is an extracted OCI image layout, only its blobs are kept
+type imageLayout struct {
+	dir   string
+	index []byte
+}
+
+func (l *imageLayout) blobPath(d digest.Digest) string {
+	return filepath.Join(l.dir, d.Encoded())
+}
+
+func (l *imageLayout) readManifest(d digest.Digest) ([]byte, error) {
+	f, err := os.Open(l.blobPath(d))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, util.NewInvalidArgumentErrorf("manifest %s is missing in the image layout", d)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxImportedManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxImportedManifestSize {
+		return nil, util.NewInvalidArgumentErrorf("manifest %s is too large", d)
+	}
+	if digest.FromBytes(content) != d {
+		return nil, util.NewInvalidArgumentErrorf("manifest %s doesn't match its digest", d)
+	}
+	return content, nil
+}
+
+// extractImageLayout extracts the index and the sha256 blobs of the image layout archive into dir
+func extractImageLayout(r io.Reader, dir string) (*imageLayout, error) {
+	l := &imageLayout{dir: dir}
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid image layout archive: %v", err)
+		}
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(hd.Name, "./"))
+		if name == "index.json" {
+			if l.index, err = io.ReadAll(io.LimitReader(tr, maxImportedManifestSize+1)); err != nil {
+				return nil, err
+			}
+			if len(l.index) > maxImportedManifestSize {
+				return nil, util.NewInvalidArgumentErrorf("index.json is too large")
+			}
+			continue
+		}
+
+		d := digest.NewDigestFromEncoded(digest.SHA256, path.Base(name))
+		if path.Dir(name) != "blobs/sha256" || d.Validate() != nil {
+			continue
+		}
+		if err := writeImageLayoutBlob(l.blobPath(d), tr); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.index == nil {
+		return nil, util.NewInvalidArgumentErrorf("index.json is missing in the image layout")
+	}
+	return l, nil
+}
+
+func writeImageLayoutBlob(p string, r io.Reader) error {
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// ImportImageLayout pushes the image of an OCI image layout archive (https://github.com/opencontainers/image-spec/blob/main/image-layout.md)
+// into the container registry of the owner and tags it. The layout must reference a single image manifest or image index.
+func ImportImageLayout(ctx context.Context, owner, creator *user_model.User, image, tag string, r io.Reader) error {
+	if !importImageNamePattern.MatchString(image) {
+		return util.NewInvalidArgumentErrorf("invalid image name %q", image)
+	}
+	if !importTagPattern.MatchString(tag) {
+		return util.NewInvalidArgumentErrorf("invalid image tag %q", tag)
+	}
+
+	dir, cleanup, err := setting.AppDataTempDir("package-upload").MkdirTempRandom("oci-layout")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	l, err := extractImageLayout(r, dir)
+	if err != nil {
+		return err
+	}
+
+	var index oci.Index
+	if err := json.Unmarshal(l.index, &index); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid index.json: %v", err)
+	}
+	if len(index.Manifests) != 1 {
+		return util.NewInvalidArgumentErrorf("the image layout must contain exactly one image, found %d", len(index.Manifests))
+	}
+
+	mci := &ManifestCreationInfo{
+		Owner:     owner,
+		Creator:   creator,
+		Image:     image,
+		Reference: tag,
+		IsTagged:  true,
+	}
+	return importImageLayoutManifest(ctx, l, mci, index.Manifests[0])
+}
+
+// importImageLayoutManifest pushes the blobs or the child manifests of the manifest and then the manifest itself
+func importImageLayoutManifest(ctx context.Context, l *imageLayout, mci *ManifestCreationInfo, descriptor oci.Descriptor) error {
+	content, err := l.readManifest(descriptor.Digest)
+	if err != nil {
+		return err
+	}
+
+	mci.MediaType = descriptor.MediaType
+	if !container_module.IsMediaTypeValid(mci.MediaType) {
+		var index oci.Index
+		if err := json.Unmarshal(content, &index); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid manifest %s: %v", descriptor.Digest, err)
+		}
+		mci.MediaType = index.MediaType
+	}
+
+	switch {
+	case container_module.IsMediaTypeImageManifest(mci.MediaType):
+		var manifest oci.Manifest
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid manifest %s: %v", descriptor.Digest, err)
+		}
+		for _, blob := range append([]oci.Descriptor{manifest.Config}, manifest.Layers...) {
+			if err := importImageLayoutBlob(ctx, l, mci, blob.Digest); err != nil {
+				return err
+			}
+		}
+	case container_module.IsMediaTypeImageIndex(mci.MediaType):
+		var index oci.Index
+		if err := json.Unmarshal(content, &index); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid image index %s: %v", descriptor.Digest, err)
+		}
+		for _, manifest := range index.Manifests {
+			if err := importImageLayoutManifest(ctx, l, &ManifestCreationInfo{
+				Owner:     mci.Owner,
+				Creator:   mci.Creator,
+				Image:     mci.Image,
+				Reference: string(manifest.Digest),
+			}, manifest); err != nil {
+				return err
+			}
+		}
+	default:
+		return util.NewInvalidArgumentErrorf("unsupported media type %q of manifest %s", mci.MediaType, descriptor.Digest)
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if _, err := ProcessManifest(ctx, mci, buf); err != nil {
+		var namedError *NamedError
+		if errors.As(err, &namedError) {
+			return util.NewInvalidArgumentErrorf("invalid manifest %s: %s %s", descriptor.Digest, namedError.Code, namedError.Message)
+		}
+		return err
+	}
+	return nil
+}
+
+func importImageLayoutBlob(ctx context.Context, l *imageLayout, mci *ManifestCreationInfo, d digest.Digest) error {
+	if d.Algorithm() != digest.SHA256 || d.Validate() != nil {
+		return util.NewInvalidArgumentErrorf("unsupported blob digest %s", d)
+	}
+
+	f, err := os.Open(l.blobPath(d))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return util.NewInvalidArgumentErrorf("blob %s is missing in the image layout", d)
+		}
+		return err
+	}
+	defer f.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(f)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if DigestFromHashSummer(buf) != string(d) {
+		return util.NewInvalidArgumentErrorf("blob %s doesn't match its digest", d)
+	}
+
+	_, err = SaveAsPackageBlob(ctx, buf, &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner: mci.Owner,
+			Name:  mci.Image,
+		},
+		Creator: mci.Creator,
+	})
+	return err
+}
@@ -24,14 +24,13 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 	packages_service "code.gitea.io/gitea/services/packages"
-	container_service "code.gitea.io/gitea/services/packages/container"
 
 	"github.com/opencontainers/go-digest"
 	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
-// manifestCreationInfo describes a manifest to create
-type manifestCreationInfo struct {
+// ManifestCreationInfo describes a manifest to create
+type ManifestCreationInfo struct {
 	MediaType  string
 	Owner      *user_model.User
 	Creator    *user_model.User
@@ -41,13 +40,14 @@ type manifestCreationInfo struct {
 	Properties map[string]string
 }
 
-func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
+// ProcessManifest creates or updates the image version of the manifest or image index and returns its digest
+func ProcessManifest(ctx context.Context, mci *ManifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
 	var index oci.Index
 	if err := json.NewDecoder(buf).Decode(&index); err != nil {
 		return "", err
 	}
 	if index.SchemaVersion != 2 {
-		return "", errUnsupported.WithMessage("Schema version is not supported")
+		return "", ErrUnsupported.WithMessage("Schema version is not supported")
 	}
 	if _, err := buf.Seek(0, io.SeekStart); err != nil {
 		return "", err
@@ -56,7 +56,7 @@ func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packag
 	if !container_module.IsMediaTypeValid(mci.MediaType) {
 		mci.MediaType = index.MediaType
 		if !container_module.IsMediaTypeValid(mci.MediaType) {
-			return "", errManifestInvalid.WithMessage("MediaType not recognized")
+			return "", ErrManifestInvalid.WithMessage("MediaType not recognized")
 		}
 	}
 
@@ -72,7 +72,7 @@ func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packag
 	} else if container_module.IsMediaTypeImageIndex(mci.MediaType) {
 		return processOciImageIndex(ctx, mci, buf)
 	}
-	return "", errManifestInvalid
+	return "", ErrManifestInvalid
 }
 
 type processManifestTxRet struct {
@@ -82,7 +82,7 @@ type processManifestTxRet struct {
 	digest  string
 }
 
-func handleCreateManifestResult(ctx context.Context, err error, mci *manifestCreationInfo, contentStore *packages_module.ContentStore, txRet *processManifestTxRet) (string, error) {
+func handleCreateManifestResult(ctx context.Context, err error, mci *ManifestCreationInfo, contentStore *packages_module.ContentStore, txRet *processManifestTxRet) (string, error) {
 	if err != nil && txRet.created && txRet.pb != nil {
 		if err := contentStore.Delete(packages_module.BlobHash256Key(txRet.pb.HashSHA256)); err != nil {
 			log.Error("Error deleting package blob from content store: %v", err)
@@ -98,8 +98,8 @@ func handleCreateManifestResult(ctx context.Context, err error, mci *manifestCre
 	return txRet.digest, nil
 }
 
-func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (manifestDigest string, errRet error) {
-	manifest, configDescriptor, metadata, err := container_service.ParseManifestMetadata(ctx, buf, mci.Owner.ID, mci.Image)
+func processOciImageManifest(ctx context.Context, mci *ManifestCreationInfo, buf *packages_module.HashedBuffer) (manifestDigest string, errRet error) {
+	manifest, configDescriptor, metadata, err := ParseManifestMetadata(ctx, buf, mci.Owner.ID, mci.Image)
 	if err != nil {
 		return "", err
 	}
@@ -107,6 +107,16 @@ func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf
 		return "", err
 	}
 
+	if manifest.Subject != nil {
+		metadata.Subject = string(manifest.Subject.Digest)
+		// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+		metadata.ArtifactType = manifest.ArtifactType
+		if metadata.ArtifactType == "" {
+			metadata.ArtifactType = manifest.Config.MediaType
+		}
+	}
+	metadata.Annotations = manifest.Annotations
+
 	contentStore := packages_module.NewContentStore()
 	var txRet processManifestTxRet
 	err = db.WithTx(ctx, func(ctx context.Context) (err error) {
@@ -159,7 +169,7 @@ func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf
 	return handleCreateManifestResult(ctx, err, mci, contentStore, &txRet)
 }
 
-func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (manifestDigest string, errRet error) {
+func processOciImageIndex(ctx context.Context, mci *ManifestCreationInfo, buf *packages_module.HashedBuffer) (manifestDigest string, errRet error) {
 	var index oci.Index
 	if err := json.NewDecoder(buf).Decode(&index); err != nil {
 		return "", err
@@ -172,13 +182,18 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	var txRet processManifestTxRet
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		metadata := &container_module.Metadata{
-			Type:      container_module.TypeOCI,
-			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Type:         container_module.TypeOCI,
+			Manifests:    make([]*container_module.Manifest, 0, len(index.Manifests)),
+			ArtifactType: index.ArtifactType,
+			Annotations:  index.Annotations,
+		}
+		if index.Subject != nil {
+			metadata.Subject = string(index.Subject.Digest)
 		}
 
 		for _, manifest := range index.Manifests {
 			if !container_module.IsMediaTypeImageManifest(manifest.MediaType) {
-				return errManifestInvalid
+				return ErrManifestInvalid
 			}
 
 			platform := container_module.DefaultPlatform
@@ -197,7 +212,7 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 			})
 			if err != nil {
 				if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-					return errManifestBlobUnknown
+					return ErrManifestBlobUnknown
 				}
 				return err
 			}
@@ -229,7 +244,7 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	return handleCreateManifestResult(ctx, err, mci, contentStore, &txRet)
 }
 
-func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, metadata *container_module.Metadata) (*packages_model.PackageVersion, error) {
+func createPackageAndVersion(ctx context.Context, mci *ManifestCreationInfo, metadata *container_module.Metadata) (*packages_model.PackageVersion, error) {
 	created := true
 	p := &packages_model.Package{
 		OwnerID:   mci.Owner.ID,
@@ -319,6 +334,16 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	if metadata.Subject != "" {
+		if err = packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertySubject, metadata.Subject); err != nil {
+			return nil, err
+		}
+	} else {
+		if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertySubject); err != nil {
+			return nil, err
+		}
+	}
+
 	return pv, nil
 }
 
@@ -333,7 +358,7 @@ type blobReference struct {
 
 func createFileFromBlobReference(ctx context.Context, pv, uploadVersion *packages_model.PackageVersion, ref *blobReference) (*packages_model.PackageFile, error) {
 	if ref.File.Blob.Size != ref.ExpectedSize {
-		return nil, errSizeInvalid
+		return nil, ErrSizeInvalid
 	}
 
 	if ref.Name == "" {
@@ -379,7 +404,7 @@ func createFileFromBlobReference(ctx context.Context, pv, uploadVersion *package
 	return pf, nil
 }
 
-func createManifestBlob(ctx context.Context, contentStore *packages_module.ContentStore, mci *manifestCreationInfo, pv *packages_model.PackageVersion, buf *packages_module.HashedBuffer) (_ *packages_model.PackageBlob, created bool, manifestDigest string, _ error) {
+func createManifestBlob(ctx context.Context, contentStore *packages_module.ContentStore, mci *ManifestCreationInfo, pv *packages_model.PackageVersion, buf *packages_module.HashedBuffer) (_ *packages_model.PackageBlob, created bool, manifestDigest string, _ error) {
 	pb, exists, err := packages_model.GetOrInsertBlob(ctx, packages_service.NewPackageBlob(buf))
 	if err != nil {
 		log.Error("Error inserting package blob: %v", err)
@@ -401,7 +426,7 @@ func createManifestBlob(ctx context.Context, contentStore *packages_module.Conte
 		}
 	}
 
-	manifestDigest = digestFromHashSummer(buf)
+	manifestDigest = DigestFromHashSummer(buf)
 	pf, err := createFileFromBlobReference(ctx, pv, nil, &blobReference{
 		Digest:       digest.Digest(manifestDigest),
 		MediaType:    mci.MediaType,
@@ -0,0 +1,177 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+	"errors"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/optional"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Referrer is a manifest which is attached to another manifest of the image
+type Referrer struct {
+	Descriptor oci.Descriptor
+	Kind       container_module.ArtifactKind
+	// Tag is the cosign tag the manifest is stored as, empty for manifests attached with a subject
+	Tag string
+}
+
+// GetReferrers returns the manifests which have the digest as subject, optionally filtered by the artifact type
+func GetReferrers(ctx context.Context, ownerID int64, image string, subject digest.Digest, artifactType string) ([]*Referrer, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ownerID,
+		Type:    packages_model.TypeContainer,
+		Name: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      image,
+		},
+		Properties: map[string]string{
+			container_module.PropertySubject: string(subject),
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	referrers := make([]*Referrer, 0, len(pvs))
+	seen := make(map[digest.Digest]bool, len(pvs))
+	for _, pv := range pvs {
+		descriptor, err := getManifestDescriptor(ctx, ownerID, image, pv)
+		if err != nil {
+			return nil, err
+		}
+		if seen[descriptor.Digest] || (artifactType != "" && descriptor.ArtifactType != artifactType) {
+			continue
+		}
+		seen[descriptor.Digest] = true
+
+		referrers = append(referrers, &Referrer{
+			Descriptor: *descriptor,
+			Kind:       container_module.GetArtifactKind(descriptor.ArtifactType),
+		})
+	}
+	return referrers, nil
+}
+
+// SignatureStatus describes the signatures, attestations and SBOMs attached to an image manifest
+type SignatureStatus struct {
+	Digest    digest.Digest
+	Artifacts []*Referrer
+}
+
+// Has checks if an artifact of the kind is attached to the manifest
+func (s *SignatureStatus) Has(kind container_module.ArtifactKind) bool {
+	for _, artifact := range s.Artifacts {
+		if artifact.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSignatureStatus returns the artifacts attached to the image manifest,
+// either as referrers with a subject or with the tag scheme cosign uses for registries without referrers support.
+// The signatures are not verified, that needs the keys or identities the deployment trusts.
+func GetSignatureStatus(ctx context.Context, ownerID int64, image string, manifestDigest digest.Digest) (*SignatureStatus, error) {
+	referrers, err := GetReferrers(ctx, ownerID, image, manifestDigest, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kind := range []container_module.ArtifactKind{container_module.ArtifactKindSignature, container_module.ArtifactKindAttestation, container_module.ArtifactKindSBOM} {
+		tag := container_module.CosignTag(manifestDigest, kind)
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ownerID, packages_model.TypeContainer, image, tag)
+		if err != nil {
+			if errors.Is(err, packages_model.ErrPackageNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		descriptor, err := getManifestDescriptor(ctx, ownerID, image, pv)
+		if err != nil {
+			return nil, err
+		}
+		referrers = append(referrers, &Referrer{
+			Descriptor: *descriptor,
+			Kind:       kind,
+			Tag:        tag,
+		})
+	}
+
+	return &SignatureStatus{
+		Digest:    manifestDigest,
+		Artifacts: referrers,
+	}, nil
+}
+
+func getManifestDescriptor(ctx context.Context, ownerID int64, image string, pv *packages_model.PackageVersion) (*oci.Descriptor, error) {
+	pfd, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    ownerID,
+		Image:      image,
+		Tag:        pv.LowerVersion,
+		IsManifest: true,
+		OnlyLead:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &container_module.Metadata{}
+	if err := json.Unmarshal([]byte(pv.MetadataJSON), metadata); err != nil {
+		return nil, err
+	}
+
+	return &oci.Descriptor{
+		MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+		Digest:       digest.Digest(pfd.Properties.GetByName(container_module.PropertyDigest)),
+		Size:         pfd.Blob.Size,
+		ArtifactType: metadata.ArtifactType,
+		Annotations:  metadata.Annotations,
+	}, nil
+}
+
+// isAttachedToExistingManifest checks if the version is a referrer or cosign tag of a manifest which still exists
+func isAttachedToExistingManifest(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
+	subject, _, ok := container_module.ParseCosignTag(pv.LowerVersion)
+	if !ok {
+		pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertySubject)
+		if err != nil {
+			return false, err
+		}
+		if len(pps) == 0 {
+			return false, nil
+		}
+		subject = digest.Digest(pps[0].Value)
+	}
+
+	_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    p.OwnerID,
+		Image:      p.LowerName,
+		Digest:     string(subject),
+		IsManifest: true,
+	})
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetManifestDigest returns the digest of the manifest of the image version
+func GetManifestDigest(pd *packages_model.PackageDescriptor) digest.Digest {
+	for _, pfd := range pd.Files {
+		if pfd.File.IsLead {
+			return digest.Digest(pfd.Properties.GetByName(container_module.PropertyDigest))
+		}
+	}
+	return ""
+}
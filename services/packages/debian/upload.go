@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package debian
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	debian_module "code.gitea.io/gitea/modules/packages/debian"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// UploadPackage adds the .deb package to the distribution and component of the owner's repository and rebuilds the affected indices
+func UploadPackage(ctx context.Context, owner, creator *user_model.User, distribution, component string, buf *packages_module.HashedBuffer) (*packages_model.PackageVersion, error) {
+	pck, err := debian_module.ParsePackage(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypeDebian,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			Creator:  creator,
+			Metadata: pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename:     fmt.Sprintf("%s_%s_%s.deb", pck.Name, pck.Version, pck.Architecture),
+				CompositeKey: fmt.Sprintf("%s|%s", distribution, component),
+			},
+			Creator: creator,
+			Data:    buf,
+			IsLead:  true,
+			Properties: map[string]string{
+				debian_module.PropertyDistribution: distribution,
+				debian_module.PropertyComponent:    component,
+				debian_module.PropertyArchitecture: pck.Architecture,
+				debian_module.PropertyControl:      pck.Control,
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := BuildSpecificRepositoryFiles(ctx, owner.ID, distribution, component, pck.Architecture); err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
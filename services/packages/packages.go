@@ -15,6 +15,7 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
@@ -30,6 +31,7 @@ var (
 	ErrQuotaTypeSize   = errors.New("maximum allowed package type size exceeded")
 	ErrQuotaTotalSize  = errors.New("maximum allowed package storage quota exceeded")
 	ErrQuotaTotalCount = errors.New("maximum allowed package count exceeded")
+	ErrDownloadBlocked = errors.New("package version has vulnerabilities exceeding the allowed severity")
 )
 
 // PackageInfo describes a package
@@ -378,8 +380,12 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHomebrew:
+		typeSpecificSize = setting.Packages.LimitSizeHomebrew
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
+	case packages_model.TypeNix:
+		typeSpecificSize = setting.Packages.LimitSizeNix
 	case packages_model.TypeNpm:
 		typeSpecificSize = setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
@@ -394,6 +400,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
 		typeSpecificSize = setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		typeSpecificSize = setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
 		typeSpecificSize = setting.Packages.LimitSizeVagrant
 	}
@@ -414,6 +422,14 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		}
 	}
 
+	if err := quota_model.CheckExceeded(ctx, owner.ID, quota_model.CategoryPackages, uploadSize); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			log.Debug("Package upload of %s rejected: %v", owner.Name, err)
+			return ErrQuotaTotalSize
+		}
+		return err
+	}
+
 	return nil
 }
 
@@ -544,6 +560,10 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 		}
 	}
 
+	if err := packages_model.DeleteScanByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	return packages_model.DeleteVersionByID(ctx, pv.ID)
 }
 
@@ -600,6 +620,10 @@ func OpenBlobStream(pb *packages_model.PackageBlob) (io.ReadSeekCloser, error) {
 // OpenBlobForDownload returns the content of the specific package blob and increases the download counter.
 // If the storage supports direct serving and it's enabled, only the direct serving url is returned.
 func OpenBlobForDownload(ctx context.Context, pf *packages_model.PackageFile, pb *packages_model.PackageBlob, method string, serveDirectReqParams url.Values) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+	if err := checkDownloadAllowed(ctx, pf); err != nil {
+		return nil, nil, nil, err
+	}
+
 	key := packages_module.BlobHash256Key(pb.HashSHA256)
 
 	cs := packages_module.NewContentStore()
@@ -629,6 +653,25 @@ func OpenBlobForDownload(ctx context.Context, pf *packages_model.PackageFile, pb
 	return s, u, pf, nil
 }
 
+// checkDownloadAllowed rejects the download of the lead file of a version whose scan found a vulnerability with the blocking severity
+func checkDownloadAllowed(ctx context.Context, pf *packages_model.PackageFile) error {
+	if !pf.IsLead || !setting.Packages.Scan.Enabled || setting.Packages.Scan.BlockSeverity == "" {
+		return nil
+	}
+
+	ps, err := packages_model.GetScanByVersionID(ctx, pf.VersionID)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageScanNotExist) {
+			return nil
+		}
+		return err
+	}
+	if ps.MaxSeverity >= packages_model.ParseSeverity(setting.Packages.Scan.BlockSeverity) {
+		return ErrDownloadBlocked
+	}
+	return nil
+}
+
 // RemoveAllPackages for User
 func RemoveAllPackages(ctx context.Context, userID int64) (int, error) {
 	count := 0
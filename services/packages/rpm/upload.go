@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package rpm
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// UploadPackage adds the .rpm package to the group of the owner's repository and rebuilds the repository metadata.
+// If sign is set, the package gets signed with the key of the owner before it is stored.
+func UploadPackage(ctx context.Context, owner, creator *user_model.User, group string, sign bool, buf *packages_module.HashedBuffer) (*packages_model.PackageVersion, error) {
+	if sign {
+		priv, _, err := GetOrCreateKeyPair(ctx, owner.ID)
+		if err != nil {
+			return nil, err
+		}
+		signedBuf, err := SignPackage(buf, priv)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("unable to sign package: %v", err)
+		}
+		defer signedBuf.Close()
+
+		buf = signedBuf
+	}
+
+	pck, err := rpm_module.ParsePackage(buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	fileMetadataRaw, err := json.Marshal(pck.FileMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypeRpm,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			Creator:  creator,
+			Metadata: pck.VersionMetadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename:     fmt.Sprintf("%s-%s.%s.rpm", pck.Name, pck.Version, pck.FileMetadata.Architecture),
+				CompositeKey: group,
+			},
+			Creator: creator,
+			Data:    buf,
+			IsLead:  true,
+			Properties: map[string]string{
+				rpm_module.PropertyGroup:        group,
+				rpm_module.PropertyArchitecture: pck.FileMetadata.Architecture,
+				rpm_module.PropertyMetadata:     string(fileMetadataRaw),
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := BuildSpecificRepositoryFiles(ctx, owner.ID, group); err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
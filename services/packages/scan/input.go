@@ -0,0 +1,263 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// maxExtractedSize limits the size of the files extracted from the archives of a package
+const maxExtractedSize = 1 << 30
+
+var errSkipped = errors.New("package version can not be scanned")
+
+// getImageDescriptors returns the image manifests to scan for a container version, the manifests of an index are scanned one by one
+func getImageDescriptors(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*packages_model.PackageDescriptor, error) {
+	metadata := pd.Metadata.(*container_module.Metadata)
+	if metadata.Type != container_module.TypeOCI || metadata.Subject != "" {
+		return nil, errSkipped
+	}
+	if _, _, ok := container_module.ParseCosignTag(pd.Version.LowerVersion); ok {
+		return nil, errSkipped
+	}
+	if len(metadata.Manifests) == 0 {
+		return []*packages_model.PackageDescriptor{pd}, nil
+	}
+
+	pds := make([]*packages_model.PackageDescriptor, 0, len(metadata.Manifests))
+	for _, manifest := range metadata.Manifests {
+		pfd, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    pd.Owner.ID,
+			Image:      pd.Package.LowerName,
+			Digest:     manifest.Digest,
+			IsManifest: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		imagePd, err := packages_model.GetPackageDescriptor(ctx, pv)
+		if err != nil {
+			return nil, err
+		}
+		pds = append(pds, imagePd)
+	}
+	return pds, nil
+}
+
+// writeImageLayout writes the manifest, config and layers of the image as OCI image layout archive
+// https://github.com/opencontainers/image-spec/blob/main/image-layout.md
+func writeImageLayout(w io.Writer, pd *packages_model.PackageDescriptor) error {
+	tw := tar.NewWriter(w)
+
+	writeFile := func(name string, size int64, r io.Reader) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: size, Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		_, err := io.Copy(tw, r)
+		return err
+	}
+	writeJSON := func(name string, v any) error {
+		content, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return writeFile(name, int64(len(content)), bytes.NewReader(content))
+	}
+
+	if err := writeJSON(oci.ImageLayoutFile, oci.ImageLayout{Version: oci.ImageLayoutVersion}); err != nil {
+		return err
+	}
+
+	index := oci.Index{MediaType: oci.MediaTypeImageIndex}
+	index.SchemaVersion = 2
+	written := make(map[digest.Digest]bool)
+	for _, pfd := range pd.Files {
+		d := digest.Digest(pfd.Properties.GetByName(container_module.PropertyDigest))
+		if d.Validate() != nil {
+			return fmt.Errorf("invalid digest of file %d", pfd.File.ID)
+		}
+		if pfd.File.IsLead {
+			index.Manifests = append(index.Manifests, oci.Descriptor{
+				MediaType: pfd.Properties.GetByName(container_module.PropertyMediaType),
+				Digest:    d,
+				Size:      pfd.Blob.Size,
+			})
+		}
+		if written[d] {
+			continue
+		}
+		written[d] = true
+
+		if err := copyBlob(pfd.Blob, func(r io.Reader) error {
+			return writeFile("blobs/"+d.Algorithm().String()+"/"+d.Encoded(), pfd.Blob.Size, r)
+		}); err != nil {
+			return err
+		}
+	}
+	if err := writeJSON("index.json", index); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// writePackageFiles writes the files of the version into the directory, archives are extracted so the scanner can find the package metadata
+func writePackageFiles(dir string, pd *packages_model.PackageDescriptor) error {
+	extracted := int64(0)
+	for i, pfd := range pd.Files {
+		name := filepath.Base(filepath.Clean("/" + pfd.File.Name))
+		fileDir := filepath.Join(dir, strconv.Itoa(i))
+		if err := os.MkdirAll(fileDir, 0o755); err != nil {
+			return err
+		}
+		path := filepath.Join(fileDir, name)
+
+		err := copyBlob(pfd.Blob, func(r io.Reader) error {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(f, r)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		lowerName := strings.ToLower(name)
+		switch {
+		case strings.HasSuffix(lowerName, ".tgz"), strings.HasSuffix(lowerName, ".tar.gz"), strings.HasSuffix(lowerName, ".crate"):
+			err = extractTarGz(path, path+".d", &extracted)
+		case strings.HasSuffix(lowerName, ".zip"), strings.HasSuffix(lowerName, ".whl"), strings.HasSuffix(lowerName, ".nupkg"):
+			err = extractZip(path, path+".d", &extracted)
+		}
+		if err != nil {
+			return fmt.Errorf("extracting %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func copyBlob(pb *packages_model.PackageBlob, fn func(io.Reader) error) error {
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return fn(s)
+}
+
+// extractPath returns the path of an archive entry inside the directory, entries which would escape the directory are skipped
+func extractPath(dir, name string) (string, bool) {
+	path := filepath.Join(dir, filepath.Clean("/"+name))
+	return path, strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+func extractFile(path string, r io.Reader, extracted *int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(r, maxExtractedSize-*extracted+1))
+	*extracted += n
+	if err != nil {
+		return err
+	}
+	if *extracted > maxExtractedSize {
+		return errors.New("extracted files are too large")
+	}
+	return nil
+}
+
+func extractTarGz(archive, dir string, extracted *int64) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		path, ok := extractPath(dir, hdr.Name)
+		if !ok {
+			continue
+		}
+		if err := extractFile(path, tr, extracted); err != nil {
+			return err
+		}
+	}
+}
+
+func extractZip(archive, dir string, extracted *int64) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		path, ok := extractPath(dir, file.Name)
+		if !ok {
+			continue
+		}
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = extractFile(path, r, extracted)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
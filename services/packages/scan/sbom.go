@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scan
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrSBOMNotSupported = util.NewInvalidArgumentErrorf("a SBOM can only be generated for an image manifest, select the image of a platform by its digest")
+	ErrScannerDisabled  = util.NewNotExistErrorf("package scanning is disabled")
+)
+
+// GenerateImageSBOM creates a SBOM of a container image with the scanner and writes it in the format
+func GenerateImageSBOM(ctx context.Context, pd *packages_model.PackageDescriptor, format sbom.Format, w io.Writer) error {
+	if !setting.Packages.Scan.Enabled {
+		return ErrScannerDisabled
+	}
+
+	pds, err := getImageDescriptors(ctx, pd)
+	if err != nil {
+		if errors.Is(err, errSkipped) {
+			return ErrSBOMNotSupported
+		}
+		return err
+	}
+	if len(pds) != 1 {
+		return ErrSBOMNotSupported
+	}
+
+	dir, cleanup, err := setting.AppDataTempDir("package-scan").MkdirTempRandom("sbom")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	path := filepath.Join(dir, "image.tar")
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = writeImageLayout(f, pds[0])
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	trivyFormat := "cyclonedx"
+	if format == sbom.FormatSPDX {
+		trivyFormat = "spdx-json"
+	}
+	stdout, err := runTrivy(ctx, "image", path, "--format", trivyFormat)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, stdout)
+	return err
+}
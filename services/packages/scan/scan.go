@@ -0,0 +1,192 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+var scanQueue *queue.WorkerPoolQueue[int64]
+
+// Init starts the queue which scans package versions
+func Init() error {
+	if !setting.Packages.Enabled || !setting.Packages.Scan.Enabled {
+		return nil
+	}
+
+	scanQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "package_scan", handler)
+	if scanQueue == nil {
+		return errors.New("unable to create package_scan queue")
+	}
+	go graceful.GetManager().RunWithCancel(scanQueue)
+
+	notify_service.RegisterNotifier(&scanNotifier{})
+	return nil
+}
+
+func handler(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, versionID := range items {
+		if err := ScanVersionByID(ctx, versionID); err != nil {
+			log.Error("Scan package version %d failed: %v", versionID, err)
+		}
+	}
+	return nil
+}
+
+// IsScanned checks if package versions of the type are scanned
+func IsScanned(packageType packages_model.Type) bool {
+	return setting.Packages.Scan.Enabled && (len(setting.Packages.Scan.Types) == 0 || setting.Packages.Scan.Types.Contains(string(packageType)))
+}
+
+// Enqueue marks the scan of the version pending and adds it to the queue
+func Enqueue(ctx context.Context, pd *packages_model.PackageDescriptor) error {
+	if scanQueue == nil || !IsScanned(pd.Package.Type) {
+		return nil
+	}
+	if err := packages_model.SetScanStatus(ctx, pd.Version.ID, packages_model.ScanStatusPending, ""); err != nil {
+		return err
+	}
+	return scanQueue.Push(pd.Version.ID)
+}
+
+type scanNotifier struct {
+	notify_service.NullNotifier
+}
+
+func (n *scanNotifier) PackageCreate(ctx context.Context, _ *user_model.User, pd *packages_model.PackageDescriptor) {
+	if !setting.Packages.Scan.OnUpload {
+		return
+	}
+	if err := Enqueue(ctx, pd); err != nil {
+		log.Error("Enqueue package version %d for scanning failed: %v", pd.Version.ID, err)
+	}
+}
+
+// ScanVersionByID scans the package version and stores the findings
+func ScanVersionByID(ctx context.Context, versionID int64) error {
+	pv, err := packages_model.GetVersionByID(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil
+		}
+		return err
+	}
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return err
+	}
+	return ScanVersion(ctx, pd)
+}
+
+// ScanVersion scans the package version and stores the findings, a failed scan keeps the findings of the previous scan
+func ScanVersion(ctx context.Context, pd *packages_model.PackageDescriptor) error {
+	if pd.Version.IsInternal || !IsScanned(pd.Package.Type) {
+		return nil
+	}
+
+	vulns, err := scan(ctx, pd)
+	if err != nil {
+		if errors.Is(err, errSkipped) {
+			return packages_model.SetScanStatus(ctx, pd.Version.ID, packages_model.ScanStatusSkipped, "")
+		}
+		if err := packages_model.SetScanStatus(ctx, pd.Version.ID, packages_model.ScanStatusFailed, err.Error()); err != nil {
+			return err
+		}
+		return err
+	}
+	return packages_model.SetScanResult(ctx, pd.Version.ID, vulns)
+}
+
+func scan(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*packages_model.PackageVulnerability, error) {
+	dir, cleanup, err := setting.AppDataTempDir("package-scan").MkdirTempRandom("scan")
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if pd.Package.Type != packages_model.TypeContainer {
+		if err := writePackageFiles(dir, pd); err != nil {
+			return nil, err
+		}
+		return runScanner(ctx, "rootfs", dir)
+	}
+
+	pds, err := getImageDescriptors(ctx, pd)
+	if err != nil {
+		return nil, err
+	}
+
+	var vulns []*packages_model.PackageVulnerability
+	for i, imagePd := range pds {
+		path := filepath.Join(dir, fmt.Sprintf("image-%d.tar", i))
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		err = writeImageLayout(f, imagePd)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		imageVulns, err := runScanner(ctx, "image", path)
+		if err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, imageVulns...)
+	}
+	return vulns, nil
+}
+
+// ScanOutdated enqueues the package versions which were never scanned or whose last scan is older than the duration
+func ScanOutdated(ctx context.Context, olderThan time.Duration) error {
+	if scanQueue == nil {
+		return nil
+	}
+
+	var packageTypes []packages_model.Type
+	for packageType := range setting.Packages.Scan.Types {
+		packageTypes = append(packageTypes, packages_model.Type(packageType))
+	}
+
+	threshold := timeutil.TimeStamp(time.Now().Add(-olderThan).Unix())
+	lastID := int64(0)
+	for {
+		ids, err := packages_model.FindVersionIDsToScan(ctx, packageTypes, threshold, lastID, 100)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err := scanQueue.Push(id); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+				return err
+			}
+		}
+		lastID = ids[len(ids)-1]
+	}
+}
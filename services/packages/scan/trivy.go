@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// https://trivy.dev/latest/docs/configuration/reporting/#json
+type trivyReport struct {
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+			PrimaryURL       string `json:"PrimaryURL"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// parseReport parses the JSON report of the scanner, the same vulnerability of a package is reported once
+func parseReport(r io.Reader) ([]*packages_model.PackageVulnerability, error) {
+	var report trivyReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, err
+	}
+
+	vulns := make([]*packages_model.PackageVulnerability, 0, 10)
+	seen := make(map[string]bool)
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			key := v.VulnerabilityID + "\x00" + v.PkgName + "\x00" + v.InstalledVersion
+			if v.VulnerabilityID == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			vulns = append(vulns, &packages_model.PackageVulnerability{
+				VulnerabilityID:  v.VulnerabilityID,
+				Target:           result.Target,
+				PackageName:      v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         max(packages_model.ParseSeverity(v.Severity), packages_model.SeverityUnknown),
+				Title:            v.Title,
+				URL:              v.PrimaryURL,
+			})
+		}
+	}
+	return vulns, nil
+}
+
+// runScanner runs the scanner with the command ("image" or "rootfs") and the input path
+func runScanner(ctx context.Context, command, input string) ([]*packages_model.PackageVulnerability, error) {
+	stdout, err := runTrivy(ctx, command, input, "--format", "json", "--scanners", "vuln")
+	if err != nil {
+		return nil, err
+	}
+	return parseReport(stdout)
+}
+
+func runTrivy(ctx context.Context, command, input string, extraArgs ...string) (*bytes.Buffer, error) {
+	args := append([]string{command, "--quiet"}, extraArgs...)
+	if command == "image" {
+		args = append(args, "--input")
+	}
+	args = append(args, input)
+
+	ctx, _, finished := process.GetManager().AddContextTimeout(ctx, setting.Packages.Scan.Timeout, fmt.Sprintf("Scan package [%s %s]", setting.Packages.Scan.Command, command))
+	defer finished()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, setting.Packages.Scan.Command, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	process.SetSysProcAttribute(cmd)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return &stdout, nil
+}
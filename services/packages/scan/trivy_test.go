@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scan
+
+import (
+	"strings"
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReport(t *testing.T) {
+	vulns, err := parseReport(strings.NewReader(`{
+  "Results": [
+    {
+      "Target": "app/package-lock.json",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-0001", "PkgName": "lodash", "InstalledVersion": "4.17.20", "FixedVersion": "4.17.21", "Severity": "HIGH", "Title": "Prototype pollution", "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2024-0001"},
+        {"VulnerabilityID": "CVE-2024-0002", "PkgName": "minimist", "InstalledVersion": "1.2.0", "Severity": "negligible"},
+        {"VulnerabilityID": "", "PkgName": "broken"}
+      ]
+    },
+    {
+      "Target": "app/node_modules/lodash/package.json",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-0001", "PkgName": "lodash", "InstalledVersion": "4.17.20", "Severity": "HIGH"}
+      ]
+    },
+    {
+      "Target": "debian 12"
+    }
+  ]
+}`))
+	require.NoError(t, err)
+	require.Len(t, vulns, 2)
+
+	assert.Equal(t, "CVE-2024-0001", vulns[0].VulnerabilityID)
+	assert.Equal(t, "app/package-lock.json", vulns[0].Target)
+	assert.Equal(t, "lodash", vulns[0].PackageName)
+	assert.Equal(t, "4.17.20", vulns[0].InstalledVersion)
+	assert.Equal(t, "4.17.21", vulns[0].FixedVersion)
+	assert.Equal(t, packages_model.SeverityHigh, vulns[0].Severity)
+	assert.Equal(t, "Prototype pollution", vulns[0].Title)
+	assert.Equal(t, "https://avd.aquasec.com/nvd/cve-2024-0001", vulns[0].URL)
+
+	assert.Equal(t, "CVE-2024-0002", vulns[1].VulnerabilityID)
+	assert.Equal(t, packages_model.SeverityUnknown, vulns[1].Severity)
+
+	_, err = parseReport(strings.NewReader("no json"))
+	assert.Error(t, err)
+}
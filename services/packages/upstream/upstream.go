@@ -0,0 +1,308 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// PropertyUpstream is the version property which marks the versions fetched from an upstream registry, the value is the upstream url
+const PropertyUpstream = "upstream.url"
+
+var (
+	ErrNotFound           = util.NewNotExistErrorf("package does not exist in the upstream registry")
+	ErrLicenseNotAllowed  = util.NewPermissionDeniedErrorf("the license of the upstream package is not allowed")
+	ErrFileTooLarge       = util.NewPermissionDeniedErrorf("the upstream file exceeds the maximum size")
+	ErrDigestMismatch     = util.NewInvalidArgumentErrorf("the upstream file does not match its digest")
+	errUnexpectedResponse = "unexpected response from the upstream registry %s: %s"
+)
+
+var httpClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: proxy.Proxy(),
+	},
+	Timeout: 10 * time.Minute,
+}
+
+// Registry is the upstream registry of the package registry of an owner
+type Registry struct {
+	Type   packages_model.Type
+	Config *setting.PackageProxy
+}
+
+// Get returns the upstream registry of the registry of the owner, nil if the registry doesn't proxy an upstream
+func Get(owner *user_model.User, packageType packages_model.Type) *Registry {
+	cfg, ok := setting.Packages.Proxies[string(packageType)]
+	if !ok || !cfg.Owners.Contains(owner.LowerName) {
+		return nil
+	}
+	return &Registry{Type: packageType, Config: cfg}
+}
+
+// IsRefreshRequested checks if the client asked to skip the cached metadata
+func IsRefreshRequested(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Cache-Control"), "no-cache") || req.Header.Get("Pragma") == "no-cache"
+}
+
+// Creator returns the user shown as creator of the fetched packages
+func Creator(doer *user_model.User) *user_model.User {
+	if doer == nil {
+		return user_model.NewGhostUser()
+	}
+	return doer
+}
+
+// URL returns the url of a path of the upstream registry
+func (r *Registry) URL(path string) string {
+	return r.Config.Upstream + path
+}
+
+// IsLicenseAllowed checks if a package with the license may be fetched, a license expression like "MIT OR Apache-2.0" is allowed if one of the licenses is allowed
+func (r *Registry) IsLicenseAllowed(license string) bool {
+	if len(r.Config.AllowedLicenses) == 0 {
+		return true
+	}
+	license = strings.NewReplacer("(", " ", ")", " ").Replace(license)
+	for part := range strings.FieldsSeq(license) {
+		if part == "OR" || part == "AND" || part == "WITH" {
+			continue
+		}
+		for _, pattern := range r.Config.AllowedLicenses {
+			if g, err := glob.Compile(pattern); err == nil && g.Match(part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Registry) cacheKey(ownerID int64, key string) string {
+	return fmt.Sprintf("packages_upstream:%s:%d:%s", r.Type, ownerID, key)
+}
+
+// GetMetadata returns the cached metadata, the metadata is fetched if it isn't cached for the owner or if refresh is set
+func (r *Registry) GetMetadata(ownerID int64, key string, refresh bool, fetch func() ([]byte, error)) ([]byte, error) {
+	c := cache.GetCache()
+	cacheKey := r.cacheKey(ownerID, key)
+	if c != nil && !refresh {
+		if data, ok := c.Get(cacheKey); ok {
+			return []byte(data), nil
+		}
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if c != nil && r.Config.CacheTTL > 0 {
+		if err := c.Put(cacheKey, string(data), int64(r.Config.CacheTTL.Seconds())); err != nil {
+			log.Error("Error caching the upstream metadata %s: %v", key, err)
+		}
+	}
+	return data, nil
+}
+
+// InvalidateMetadata removes the cached metadata
+func (r *Registry) InvalidateMetadata(ownerID int64, key string) {
+	cache.Remove(r.cacheKey(ownerID, key))
+}
+
+// isUpstreamURL checks if the url points to the configured upstream registry. The metadata of the upstream may reference
+// files on other hosts, like the tarball urls of npm, the credentials mustn't be sent to them.
+func (r *Registry) isUpstreamURL(u *url.URL) bool {
+	upstream, err := url.Parse(r.Config.Upstream)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, upstream.Scheme) && strings.EqualFold(u.Host, upstream.Host)
+}
+
+// Do sends a request to the upstream registry.
+// The configured credentials are sent as basic auth, a bearer token challenge like the one of container registries is answered with a token from the realm.
+// The credentials are only used for the urls of the configured upstream, the other urls are requested anonymously.
+func (r *Registry) Do(ctx context.Context, method, rawURL string, header http.Header) (*http.Response, error) {
+	req, err := r.newRequest(ctx, method, rawURL, header)
+	if err != nil {
+		return nil, err
+	}
+	withCredentials := r.isUpstreamURL(req.URL) && (r.Config.Username != "" || r.Config.Password != "")
+	if withCredentials {
+		req.SetBasicAuth(r.Config.Username, r.Config.Password)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return nil, fmt.Errorf(errUnexpectedResponse, rawURL, resp.Status)
+	}
+	token, err := r.fetchBearerToken(ctx, parseChallengeParams(params), withCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err = r.newRequest(ctx, method, rawURL, header)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return httpClient.Do(req)
+}
+
+func (r *Registry) newRequest(ctx context.Context, method, rawURL string, header http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	return req, nil
+}
+
+// parseChallengeParams parses the parameters of a challenge like `realm="https://auth.docker.io/token",service="registry.docker.io"`
+func parseChallengeParams(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		key, rest, ok := strings.Cut(strings.TrimLeft(s, " ,"), "=")
+		if !ok {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+		s = rest
+	}
+	return params
+}
+
+func (r *Registry) fetchBearerToken(ctx context.Context, params map[string]string, withCredentials bool) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || (realm.Scheme != "http" && realm.Scheme != "https") {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if params[k] != "" {
+			q.Set(k, params[k])
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := r.newRequest(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if withCredentials {
+		req.SetBasicAuth(r.Config.Username, r.Config.Password)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf(errUnexpectedResponse, realm.String(), resp.Status)
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return util.IfZero(result.Token, result.AccessToken), nil
+}
+
+// Fetch sends a GET request to the upstream registry and returns the body, ErrNotFound is returned if the upstream doesn't know the url
+func (r *Registry) Fetch(ctx context.Context, rawURL string, header http.Header) ([]byte, http.Header, error) {
+	resp, err := r.Do(ctx, http.MethodGet, rawURL, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(rawURL, resp); err != nil {
+		return nil, nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	return data, resp.Header, err
+}
+
+// Download fetches a file from the upstream registry into a buffer, the size of the file is checked against the configured maximum size
+func (r *Registry) Download(ctx context.Context, rawURL string, header http.Header) (*packages_module.HashedBuffer, error) {
+	resp, err := r.Do(ctx, http.MethodGet, rawURL, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(rawURL, resp); err != nil {
+		return nil, err
+	}
+
+	maxSize := r.Config.MaxSize
+	if maxSize >= 0 && resp.ContentLength > maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	var body io.Reader = resp.Body
+	if maxSize >= 0 {
+		body = io.LimitReader(resp.Body, maxSize+1)
+	}
+	buf, err := packages_module.CreateHashedBufferFromReader(body)
+	if err != nil {
+		return nil, err
+	}
+	if maxSize >= 0 && buf.Size() > maxSize {
+		buf.Close()
+		return nil, ErrFileTooLarge
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		buf.Close()
+		return nil, err
+	}
+	return buf, nil
+}
+
+func checkResponse(rawURL string, resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf(errUnexpectedResponse, rawURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLicenseAllowed(t *testing.T) {
+	r := &Registry{Config: &setting.PackageProxy{}}
+	assert.True(t, r.IsLicenseAllowed(""))
+	assert.True(t, r.IsLicenseAllowed("GPL-3.0"))
+
+	r.Config.AllowedLicenses = []string{"MIT", "Apache-*", "BSD-?-Clause"}
+	assert.True(t, r.IsLicenseAllowed("MIT"))
+	assert.True(t, r.IsLicenseAllowed("Apache-2.0"))
+	assert.True(t, r.IsLicenseAllowed("BSD-3-Clause"))
+	assert.True(t, r.IsLicenseAllowed("(GPL-3.0 OR MIT)"))
+	assert.False(t, r.IsLicenseAllowed(""))
+	assert.False(t, r.IsLicenseAllowed("GPL-3.0"))
+	assert.False(t, r.IsLicenseAllowed("GPL-3.0-only WITH Classpath-exception-2.0"))
+}
+
+func TestParseChallengeParams(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/alpine:pull",
+	}, parseChallengeParams(`realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"`))
+
+	assert.Equal(t, map[string]string{
+		"realm": "https://ghcr.io/token",
+		"error": "invalid_token",
+	}, parseChallengeParams(`realm="https://ghcr.io/token", error=invalid_token`))
+}
+
+func TestDoCredentials(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	r := &Registry{Config: &setting.PackageProxy{Upstream: srv.URL + "/registry", Username: "user", Password: "secret"}}
+
+	resp, err := r.Do(t.Context(), http.MethodGet, srv.URL+"/registry/package", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Basic dXNlcjpzZWNyZXQ=", authorization)
+
+	// the url of another host, e.g. a tarball url of the upstream metadata, is requested without the credentials
+	r.Config.Upstream = "http://registry.example.com"
+	resp, err = r.Do(t.Context(), http.MethodGet, srv.URL+"/registry/package.tgz", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, authorization)
+}
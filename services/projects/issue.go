@@ -180,10 +180,11 @@ func LoadIssueNumbersForProject(ctx context.Context, project *project_model.Proj
 
 	// for user or org projects, we need to check access permissions
 	opts := issues_model.IssuesOptions{
-		ProjectID: project.ID,
-		Doer:      doer,
-		AllPublic: doer == nil,
-		Owner:     project.Owner,
+		HideModerated: true,
+		ProjectID:     project.ID,
+		Doer:          doer,
+		AllPublic:     doer == nil,
+		Owner:         project.Owner,
 	}
 
 	var err error
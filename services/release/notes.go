@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package release
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NotesConfigFiles are the files in the target commit which configure the generated release notes
+var NotesConfigFiles = []string{
+	".gitea/release.yml",
+	".gitea/release.yaml",
+	".github/release.yml",
+	".github/release.yaml",
+}
+
+// maxNotesConfigSize is the size limit of the config of the release notes
+const maxNotesConfigSize = 64 * 1024
+
+// NotesMatcher matches the pull requests by their labels and authors, "*" matches any label
+type NotesMatcher struct {
+	Labels  []string `yaml:"labels"`
+	Authors []string `yaml:"authors"`
+}
+
+func (m *NotesMatcher) matchLabels(pr *issues_model.PullRequest) bool {
+	if slices.Contains(m.Labels, "*") {
+		return true
+	}
+	for _, label := range pr.Issue.Labels {
+		if slices.ContainsFunc(m.Labels, func(name string) bool { return strings.EqualFold(name, label.Name) }) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *NotesMatcher) match(pr *issues_model.PullRequest) bool {
+	if pr.Issue.Poster != nil && slices.ContainsFunc(m.Authors, func(name string) bool { return strings.EqualFold(name, pr.Issue.Poster.Name) }) {
+		return true
+	}
+	return len(m.Labels) > 0 && m.matchLabels(pr)
+}
+
+// NotesCategory is a section of the release notes which lists the pull requests with one of its labels
+type NotesCategory struct {
+	Title   string       `yaml:"title"`
+	Labels  []string     `yaml:"labels"`
+	Exclude NotesMatcher `yaml:"exclude"`
+}
+
+// NotesConfig represents the config of the generated release notes, it's compatible with the config of GitHub
+type NotesConfig struct {
+	Changelog struct {
+		Exclude    NotesMatcher     `yaml:"exclude"`
+		Categories []*NotesCategory `yaml:"categories"`
+	} `yaml:"changelog"`
+}
+
+// DefaultNotesConfig returns the config which is used if the repository doesn't have one
+func DefaultNotesConfig() *NotesConfig {
+	cfg := &NotesConfig{}
+	cfg.Changelog.Categories = []*NotesCategory{
+		{Title: "Breaking Changes", Labels: []string{"breaking", "breaking-change", "kind/breaking"}},
+		{Title: "Features", Labels: []string{"feature", "enhancement", "kind/feature", "kind/enhancement"}},
+		{Title: "Bug Fixes", Labels: []string{"bug", "fix", "kind/bug"}},
+		{Title: "Other Changes", Labels: []string{"*"}},
+	}
+	return cfg
+}
+
+// GetNotesConfig reads the config of the release notes from the commit, the default config is returned if there is none
+func GetNotesConfig(commit *git.Commit) (*NotesConfig, error) {
+	for _, filename := range NotesConfigFiles {
+		content, err := commit.GetFileContent(filename, maxNotesConfigSize)
+		if git.IsErrNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		cfg := &NotesConfig{}
+		if err := yaml.Unmarshal([]byte(content), cfg); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid %s: %v", filename, err)
+		}
+		if len(cfg.Changelog.Categories) == 0 {
+			cfg.Changelog.Categories = DefaultNotesConfig().Changelog.Categories
+		}
+		return cfg, nil
+	}
+	return DefaultNotesConfig(), nil
+}
+
+// GenerateNotesOptions are the options to generate release notes
+type GenerateNotesOptions struct {
+	TagName string
+	// Target is the branch or commit the tag will be created from if it doesn't exist
+	Target string
+	// PreviousTagName is the tag the changes are listed since, the nearest tag reachable from the release is used if empty
+	PreviousTagName string
+}
+
+// GenerateNotes generates the release notes which list the pull requests merged since the previous tag grouped by
+// the categories of the config of the repository
+func GenerateNotes(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, opts GenerateNotesOptions) (string, error) {
+	commit, err := gitRepo.GetTagCommit(opts.TagName)
+	if git.IsErrNotExist(err) {
+		target := opts.Target
+		if target == "" {
+			target = repo.DefaultBranch
+		}
+		commit, err = gitRepo.GetCommit(target)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	previousTagName := opts.PreviousTagName
+	if previousTagName == "" {
+		if previousTagName, err = findPreviousTag(gitRepo, commit, opts.TagName); err != nil {
+			return "", err
+		}
+	}
+	var previousCommitID string
+	if previousTagName != "" {
+		if previousCommitID, err = gitRepo.GetTagCommitID(previousTagName); err != nil {
+			return "", err
+		}
+	}
+
+	cfg, err := GetNotesConfig(commit)
+	if err != nil {
+		return "", err
+	}
+
+	prs, err := getMergedPullRequestsBetween(ctx, repo, gitRepo, commit.ID.String(), previousCommitID)
+	if err != nil {
+		return "", err
+	}
+
+	sections := make([][]*issues_model.PullRequest, len(cfg.Changelog.Categories))
+	var others []*issues_model.PullRequest
+	for _, pr := range prs {
+		if cfg.Changelog.Exclude.match(pr) {
+			continue
+		}
+		idx := slices.IndexFunc(cfg.Changelog.Categories, func(c *NotesCategory) bool {
+			return (&NotesMatcher{Labels: c.Labels}).matchLabels(pr) && !c.Exclude.match(pr)
+		})
+		if idx == -1 {
+			others = append(others, pr)
+		} else {
+			sections[idx] = append(sections[idx], pr)
+		}
+	}
+
+	var sb strings.Builder
+	if len(prs) > 0 {
+		sb.WriteString("## What's Changed\n")
+	}
+	writeSection := func(title string, prs []*issues_model.PullRequest) {
+		if len(prs) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", title)
+		for _, pr := range prs {
+			fmt.Fprintf(&sb, "* %s by @%s in #%d\n", strings.TrimSpace(pr.Issue.Title), pr.Issue.Poster.Name, pr.Index)
+		}
+	}
+	for i, category := range cfg.Changelog.Categories {
+		writeSection(category.Title, sections[i])
+	}
+	writeSection("Other Changes", others)
+
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	if previousTagName != "" {
+		fmt.Fprintf(&sb, "**Full Changelog**: %s/compare/%s...%s\n", repo.HTMLURL(ctx), util.PathEscapeSegments(previousTagName), util.PathEscapeSegments(opts.TagName))
+	} else {
+		fmt.Fprintf(&sb, "**Full Changelog**: %s/commits/tag/%s\n", repo.HTMLURL(ctx), util.PathEscapeSegments(opts.TagName))
+	}
+	return sb.String(), nil
+}
+
+// findPreviousTag returns the nearest tag reachable from the commit other than the tag of the release
+func findPreviousTag(gitRepo *git.Repository, commit *git.Commit, tagName string) (string, error) {
+	stdout, _, err := gitcmd.NewCommand("describe", "--tags", "--abbrev=0").
+		AddOptionValues("--exclude", tagName).
+		AddDynamicArguments(commit.ID.String()).
+		RunStdString(gitRepo.Ctx, &gitcmd.RunOpts{Dir: gitRepo.Path})
+	if err != nil {
+		// there is no tag before the release
+		if strings.Contains(err.Stderr(), "cannot describe anything") || strings.Contains(err.Stderr(), "No tags can describe") {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// getMergedPullRequestsBetween returns the pull requests whose merged commits are reachable from the commit but not
+// from the previous commit, in the order they were merged
+func getMergedPullRequestsBetween(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, commitID, previousCommitID string) (issues_model.PullRequestList, error) {
+	cmd := gitcmd.NewCommand("rev-list").AddDynamicArguments(commitID)
+	if previousCommitID != "" {
+		cmd.AddDynamicArguments("^" + previousCommitID)
+	}
+	stdout, _, runErr := cmd.RunStdString(ctx, &gitcmd.RunOpts{Dir: gitRepo.Path})
+	if runErr != nil {
+		return nil, runErr
+	}
+	commitIDs := strings.Fields(stdout)
+
+	prs := make(issues_model.PullRequestList, 0, 10)
+	// query in batches to not exceed the limit of the parameters of a statement
+	for chunk := range slices.Chunk(commitIDs, 100) {
+		found, err := issues_model.GetMergedPullRequestsByMergedCommits(ctx, repo.ID, chunk)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, found...)
+	}
+	if len(prs) == 0 {
+		return prs, nil
+	}
+
+	issues, err := prs.LoadIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := issues.LoadPosters(ctx); err != nil {
+		return nil, err
+	}
+	if err := issues.LoadLabels(ctx); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(prs, func(a, b *issues_model.PullRequest) int {
+		return cmp.Or(cmp.Compare(a.MergedUnix, b.MergedUnix), cmp.Compare(a.Index, b.Index))
+	})
+	log.Trace("Found %d merged pull requests of %d commits in %-v", len(prs), len(commitIDs), repo)
+	return prs, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package release
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+	gitea_context "code.gitea.io/gitea/services/context"
+	notify_service "code.gitea.io/gitea/services/notify"
+	container_service "code.gitea.io/gitea/services/packages/container"
+	debian_service "code.gitea.io/gitea/services/packages/debian"
+	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+)
+
+// assetPublishQueue publishes the assets of releases into the package registries
+var assetPublishQueue *queue.WorkerPoolQueue[int64]
+
+func handlerAssetPublish(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, releaseID := range items {
+		if err := PublishAssets(ctx, releaseID); err != nil {
+			log.Error("Publish assets of release %d failed: %v", releaseID, err)
+		}
+	}
+	return nil
+}
+
+func initAssetPublishQueue(ctx context.Context) error {
+	if !setting.Packages.Enabled {
+		return nil
+	}
+
+	assetPublishQueue = queue.CreateUniqueQueue(ctx, "release_asset_publish", handlerAssetPublish)
+	if assetPublishQueue == nil {
+		return errors.New("unable to create release_asset_publish queue")
+	}
+	go graceful.GetManager().RunWithCancel(assetPublishQueue)
+
+	notify_service.RegisterNotifier(&assetPublishNotifier{})
+	return nil
+}
+
+// EnqueueAssetPublish adds the release to the queue publishing its assets if the repository has enabled it
+func EnqueueAssetPublish(ctx context.Context, repo *repo_model.Repository, releaseID int64) {
+	if assetPublishQueue == nil || !isAssetPublishEnabled(ctx, repo) {
+		return
+	}
+
+	if err := assetPublishQueue.Push(releaseID); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Enqueue assets of release %d for publishing failed: %v", releaseID, err)
+	}
+}
+
+func isAssetPublishEnabled(ctx context.Context, repo *repo_model.Repository) bool {
+	releasesUnit, err := repo.GetUnit(ctx, unit.TypeReleases)
+	if err != nil {
+		return false
+	}
+	return releasesUnit.ReleasesConfig().PublishAssetsToPackages
+}
+
+type assetPublishNotifier struct {
+	notify_service.NullNotifier
+}
+
+func (n *assetPublishNotifier) NewRelease(ctx context.Context, rel *repo_model.Release) {
+	n.enqueue(ctx, rel)
+}
+
+func (n *assetPublishNotifier) UpdateRelease(ctx context.Context, _ *user_model.User, rel *repo_model.Release) {
+	n.enqueue(ctx, rel)
+}
+
+func (n *assetPublishNotifier) enqueue(ctx context.Context, rel *repo_model.Release) {
+	if rel.IsDraft || rel.IsTag {
+		return
+	}
+	if rel.Repo == nil {
+		repo, err := repo_model.GetRepositoryByID(ctx, rel.RepoID)
+		if err != nil {
+			log.Error("GetRepositoryByID[%d]: %v", rel.RepoID, err)
+			return
+		}
+		rel.Repo = repo
+	}
+	EnqueueAssetPublish(ctx, rel.Repo, rel.ID)
+}
+
+// PublishAssets publishes the .deb, .rpm and OCI image archive (.oci.tar) assets of the release into the package
+// registries of the repository owner and links the packages to the repository. The images are named after the
+// repository and tagged with the tag of the release. Assets which have already been published are skipped.
+func PublishAssets(ctx context.Context, releaseID int64) error {
+	rel, err := repo_model.GetReleaseByID(ctx, releaseID)
+	if err != nil {
+		if repo_model.IsErrReleaseNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if rel.IsDraft || rel.IsTag {
+		return nil
+	}
+	if err := rel.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	releasesUnit, err := rel.Repo.GetUnit(ctx, unit.TypeReleases)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	cfg := releasesUnit.ReleasesConfig()
+	if !cfg.PublishAssetsToPackages {
+		return nil
+	}
+	if err := rel.Repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+
+	// the assets are published by the publisher of the release, who must be allowed to write the packages of the owner
+	accessMode, err := gitea_context.DeterminePackageAccessMode(ctx, rel.Repo.Owner, rel.Publisher)
+	if err != nil {
+		return err
+	}
+	if accessMode < perm.AccessModeWrite && !rel.Publisher.IsAdmin {
+		log.Warn("Assets of release %d aren't published: %s can't write the packages of %s", rel.ID, rel.Publisher.Name, rel.Repo.Owner.Name)
+		return nil
+	}
+
+	for _, attach := range rel.Attachments {
+		if err := publishAsset(ctx, rel, cfg, attach); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				log.Warn("Asset %s of release %d can't be published: %v", attach.Name, rel.ID, err)
+			} else {
+				log.Error("Publish asset %s of release %d failed: %v", attach.Name, rel.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func publishAsset(ctx context.Context, rel *repo_model.Release, cfg *repo_model.ReleasesConfig, attach *repo_model.Attachment) error {
+	if attach.IsQuarantined {
+		return nil
+	}
+
+	name := strings.ToLower(attach.Name)
+	if !strings.HasSuffix(name, ".deb") && !strings.HasSuffix(name, ".rpm") && !strings.HasSuffix(name, ".oci.tar") {
+		return nil
+	}
+
+	f, err := storage.Attachments.Open(attach.RelativePath())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	owner := rel.Repo.Owner
+
+	var packageID int64
+	if strings.HasSuffix(name, ".oci.tar") {
+		image := strings.ToLower(rel.Repo.Name)
+		if err := container_service.ImportImageLayout(ctx, owner, rel.Publisher, image, rel.TagName, f); err != nil {
+			return err
+		}
+		p, err := packages_model.GetPackageByName(ctx, owner.ID, packages_model.TypeContainer, image)
+		if err != nil {
+			return err
+		}
+		packageID = p.ID
+	} else {
+		buf, err := packages_module.CreateHashedBufferFromReader(f)
+		if err != nil {
+			return err
+		}
+		defer buf.Close()
+
+		var pv *packages_model.PackageVersion
+		if strings.HasSuffix(name, ".deb") {
+			pv, err = debian_service.UploadPackage(ctx, owner, rel.Publisher, cfg.GetDebianDistribution(), cfg.GetDebianComponent(), buf)
+		} else {
+			pv, err = rpm_service.UploadPackage(ctx, owner, rel.Publisher, cfg.RpmGroup, setting.Packages.DefaultRPMSignEnabled, buf)
+		}
+		if errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		packageID = pv.PackageID
+	}
+
+	p, err := packages_model.GetPackageByID(ctx, packageID)
+	if err != nil {
+		return err
+	}
+	// packages which have already been linked to another repository keep their link
+	if p.RepoID != 0 {
+		return nil
+	}
+	return packages_model.SetRepositoryLink(ctx, p.ID, rel.RepoID)
+}
@@ -100,6 +100,16 @@ func createTag(ctx context.Context, gitRepo *git.Repository, rel *repo_model.Rel
 					TagName: rel.TagName,
 				}
 			}
+			// the tags created here are never signed, they can only be pushed if a signature is required
+			reqs, err := git_model.GetTagRequirements(protectedTags, rel.TagName)
+			if err != nil {
+				return false, err
+			}
+			if reqs.RequireSigned || (reqs.RequireAnnotated && len(msg) == 0) || (reqs.EnforceSemver && !git_model.IsSemverTagName(rel.TagName)) {
+				return false, ErrProtectedTagName{
+					TagName: rel.TagName,
+				}
+			}
 
 			commit, err := gitRepo.GetCommit(rel.Target)
 			if err != nil {
@@ -370,6 +380,16 @@ func DeleteReleaseByID(ctx context.Context, repo *repo_model.Repository, rel *re
 				TagName: rel.TagName,
 			}
 		}
+		// released versions are never deleted, so they can't be re-created with other contents
+		reqs, err := git_model.GetTagRequirements(protectedTags, rel.TagName)
+		if err != nil {
+			return err
+		}
+		if reqs.EnforceSemver {
+			return ErrProtectedTagName{
+				TagName: rel.TagName,
+			}
+		}
 
 		if stdout, _, err := gitcmd.NewCommand("tag", "-d").AddDashesAndList(rel.TagName).
 			RunStdString(ctx, &gitcmd.RunOpts{Dir: repo.RepoPath()}); err != nil && !strings.Contains(err.Error(), "not found") {
@@ -423,5 +443,8 @@ func DeleteReleaseByID(ctx context.Context, repo *repo_model.Repository, rel *re
 
 // Init start release service
 func Init() error {
-	return initTagSyncQueue(graceful.GetManager().ShutdownContext())
+	if err := initTagSyncQueue(graceful.GetManager().ShutdownContext()); err != nil {
+		return err
+	}
+	return initAssetPublishQueue(graceful.GetManager().ShutdownContext())
 }
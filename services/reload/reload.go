@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package reload applies the settings reloaded from the config file to the running services
+package reload
+
+import (
+	"context"
+	"sync"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/services/mailer"
+	"code.gitea.io/gitea/services/webhook"
+)
+
+var reloadMu sync.Mutex
+
+// Settings reloads the settings which can be changed without restarting (see setting.ReloadSettings)
+// and applies them to the services, the requests and the git operations in progress are not interrupted.
+func Settings(ctx context.Context) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if err := setting.ReloadSettings(); err != nil {
+		log.Error("Unable to reload the settings: %v", err)
+		return err
+	}
+
+	mailer.Reload(ctx)
+	webhook.ReloadHTTPClient()
+	// the OAuth2 providers are created with the settings of the OAuth2 client
+	if err := oauth2.ResetOAuth2(ctx); err != nil {
+		log.Error("Unable to reload the OAuth2 sources: %v", err)
+		return err
+	}
+	return nil
+}
@@ -194,10 +194,11 @@ func doArchive(ctx context.Context, r *ArchiveRequest) (*repo_model.RepoArchiver
 	}
 
 	rPath := archiver.RelativePath()
-	_, err = storage.RepoArchives.Stat(rPath)
+	fi, err := storage.RepoArchives.Stat(rPath)
 	if err == nil {
 		if archiver.Status == repo_model.ArchiverGenerating {
 			archiver.Status = repo_model.ArchiverReady
+			archiver.Size = fi.Size()
 			if err = repo_model.UpdateRepoArchiverStatus(ctx, archiver); err != nil {
 				return nil, err
 			}
@@ -241,7 +242,8 @@ func doArchive(ctx context.Context, r *ArchiveRequest) (*repo_model.RepoArchiver
 	// TODO: add lfs data to zip
 	// TODO: add submodule data to zip
 
-	if _, err := storage.RepoArchives.Save(rPath, rd, -1); err != nil {
+	size, err := storage.RepoArchives.Save(rPath, rd, -1)
+	if err != nil {
 		return nil, fmt.Errorf("unable to write archive: %w", err)
 	}
 
@@ -252,6 +254,7 @@ func doArchive(ctx context.Context, r *ArchiveRequest) (*repo_model.RepoArchiver
 
 	if archiver.Status == repo_model.ArchiverGenerating {
 		archiver.Status = repo_model.ArchiverReady
+		archiver.Size = size
 		if err = repo_model.UpdateRepoArchiverStatus(ctx, archiver); err != nil {
 			return nil, err
 		}
@@ -374,6 +377,10 @@ func ServeRepoArchive(ctx *gitea_context.Base, repo *repo_model.Repository, gitR
 		return
 	}
 
+	if err := repo_model.UpdateRepoArchiverAccessed(ctx, archiver); err != nil {
+		log.Error("Unable to record the access to archive %v: %v", archiveReq, err)
+	}
+
 	rPath := archiver.RelativePath()
 	if setting.RepoArchive.Storage.ServeDirect() {
 		// If we have a signed url (S3, object storage), redirect to this directly.
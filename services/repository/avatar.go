@@ -14,11 +14,16 @@ import (
 	"code.gitea.io/gitea/modules/avatar"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/storage"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 )
 
 // UploadAvatar saves custom avatar for repository.
 // FIXME: split uploads to different subdirs in case we have massive number of repos.
 func UploadAvatar(ctx context.Context, repo *repo_model.Repository, data []byte) error {
+	if err := malwarescan_service.ScanRepoAvatar(ctx, repo, data); err != nil {
+		return err
+	}
+
 	avatarData, err := avatar.ProcessAvatarImage(data)
 	if err != nil {
 		return err
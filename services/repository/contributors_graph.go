@@ -80,10 +80,16 @@ func findLastSundayBeforeDate(dateStr string) (string, error) {
 
 // GetContributorStats returns contributors stats for git commits for given revision or default branch
 func GetContributorStats(ctx context.Context, cache cache.StringCache, repo *repo_model.Repository, revision string) (map[string]*ContributorData, error) {
+	return getContributorStats(ctx, cache, repo, revision, awaitGenerationTime)
+}
+
+// getContributorStats returns the cached contributors stats, ErrAwaitGeneration is returned if they aren't generated in the await time
+func getContributorStats(ctx context.Context, cache cache.StringCache, repo *repo_model.Repository, revision string, awaitTime time.Duration) (map[string]*ContributorData, error) {
 	// as GetContributorStats is resource intensive we cache the result
 	cacheKey := fmt.Sprintf(contributorStatsCacheKey, repo.FullName(), revision)
 	if !cache.IsExist(cacheKey) {
-		genReady := make(chan struct{})
+		// the channel is buffered so the generation doesn't block if it isn't awaited anymore
+		genReady := make(chan struct{}, 1)
 
 		// dont start multiple async generations
 		_, run := generateLock.Load(cacheKey)
@@ -96,7 +102,7 @@ func GetContributorStats(ctx context.Context, cache cache.StringCache, repo *rep
 		go generateContributorStats(genReady, cache, cacheKey, repo, revision)
 
 		select {
-		case <-time.After(awaitGenerationTime):
+		case <-time.After(awaitTime):
 			return nil, ErrAwaitGeneration
 		case <-genReady:
 			// we got generation ready before timeout
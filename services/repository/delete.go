@@ -153,12 +153,18 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&git_model.Branch{RepoID: repoID},
 		&git_model.LFSLock{RepoID: repoID},
 		&repo_model.LanguageStat{RepoID: repoID},
+		&repo_model.RepoDependency{RepoID: repoID},
 		&repo_model.RepoLicense{RepoID: repoID},
+		&repo_model.RepoSymbol{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
 		&repo_model.Mirror{RepoID: repoID},
 		&activities_model.Notification{RepoID: repoID},
+		&activities_model.EmailDigestItem{RepoID: repoID},
+		&activities_model.FederatedFollower{RepoID: repoID},
+		&repo_model.FederatedFork{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
 		&git_model.ProtectedTag{RepoID: repoID},
+		&repo_model.ProtectedWikiPage{RepoID: repoID},
 		&repo_model.PushMirror{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
@@ -167,6 +173,7 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&repo_model.Star{RepoID: repoID},
 		&admin_model.Task{RepoID: repoID},
 		&repo_model.Watch{RepoID: repoID},
+		&repo_model.IncomingEmailAlias{RepoID: repoID},
 		&webhook.Webhook{RepoID: repoID},
 		&secret_model.Secret{RepoID: repoID},
 		&actions_model.ActionTaskStep{RepoID: repoID},
@@ -178,7 +185,12 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&actions_model.ActionSchedule{RepoID: repoID},
 		&actions_model.ActionArtifact{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
+		&actions_model.ActionEnvironment{RepoID: repoID},
+		&actions_model.ActionDeploymentReview{RepoID: repoID},
 		&issues_model.IssuePin{RepoID: repoID},
+		&issues_model.IssueSavedSearch{RepoID: repoID},
+		&issues_model.HeldContent{RepoID: repoID},
+		&organization.TimeTrackingRule{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
@@ -188,6 +200,21 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return err
 	}
 
+	// Delete custom fields and their values
+	if err := issues_model.DeleteIssueFields(ctx, repoID, 0); err != nil {
+		return err
+	}
+
+	// Delete SLA rules and their notices
+	if err := issues_model.DeleteIssueSLARules(ctx, repoID); err != nil {
+		return err
+	}
+
+	// Delete iterations and the records of their issues
+	if err := issues_model.DeleteIterations(ctx, repoID, 0); err != nil {
+		return err
+	}
+
 	// Delete Pulls and related objects
 	if err := issues_model.DeletePullsByBaseRepoID(ctx, repoID); err != nil {
 		return err
@@ -291,6 +318,9 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 	if err = packages_model.UnlinkRepositoryFromAllPackages(ctx, repoID); err != nil {
 		return err
 	}
+	if err = packages_model.DeleteCleanupRulesByRepoID(ctx, repoID); err != nil {
+		return err
+	}
 
 	if err = committer.Commit(); err != nil {
 		return err
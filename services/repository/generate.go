@@ -11,12 +11,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
@@ -27,6 +29,7 @@ import (
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/huandu/xstrings"
+	"gopkg.in/yaml.v3"
 )
 
 type transformer struct {
@@ -50,7 +53,7 @@ var defaultTransformers = []transformer{
 	{Name: "TITLE", Transform: util.ToTitleCase},
 }
 
-func generateExpansion(ctx context.Context, src string, templateRepo, generateRepo *repo_model.Repository, sanitizeFileName bool) string {
+func generateExpansion(ctx context.Context, src string, templateRepo, generateRepo *repo_model.Repository, variables map[string]string, sanitizeFileName bool) string {
 	year, month, day := time.Now().Date()
 	expansions := []expansion{
 		{Name: "YEAR", Value: strconv.Itoa(year), Transformers: nil},
@@ -71,8 +74,21 @@ func generateExpansion(ctx context.Context, src string, templateRepo, generateRe
 		{Name: "TEMPLATE_SSH_URL", Value: templateRepo.CloneLinkGeneral(ctx).SSH, Transformers: nil},
 	}
 
+	// custom variables declared in .gitea/template.yaml, builtin expansions take precedence
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		expansions = append(expansions, expansion{Name: name, Value: variables[name], Transformers: defaultTransformers})
+	}
+
 	expansionMap := make(map[string]string)
 	for _, e := range expansions {
+		if _, ok := expansionMap[e.Name]; ok {
+			continue
+		}
 		expansionMap[e.Name] = e.Value
 		for _, tr := range e.Transformers {
 			expansionMap[fmt.Sprintf("%s_%s", e.Name, tr.Name)] = tr.Transform(e.Value)
@@ -90,11 +106,120 @@ func generateExpansion(ctx context.Context, src string, templateRepo, generateRe
 	})
 }
 
+// GiteaTemplateVariable is a custom variable declared in .gitea/template.yaml
+type GiteaTemplateVariable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Default     string `yaml:"default"`
+	Required    bool   `yaml:"required"`
+}
+
+// GiteaTemplateConfig holds the content of a .gitea/template.yaml file
+type GiteaTemplateConfig struct {
+	// Files are the globs of the files to expand, in addition to the ones listed in .gitea/template
+	Files []string `yaml:"files"`
+	// Variables are the custom variables which can be provided when generating a repository
+	Variables []*GiteaTemplateVariable `yaml:"variables"`
+	// SetupWorkflow is the file name of a workflow in .gitea/workflows which is dispatched after generation
+	SetupWorkflow string `yaml:"setup_workflow"`
+}
+
+var templateVariableNameRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// ErrTemplateVariableMissing represents a "TemplateVariableMissing" kind of error.
+type ErrTemplateVariableMissing struct {
+	Name string
+}
+
+// IsErrTemplateVariableMissing checks if an error is a ErrTemplateVariableMissing.
+func IsErrTemplateVariableMissing(err error) bool {
+	_, ok := err.(ErrTemplateVariableMissing)
+	return ok
+}
+
+func (err ErrTemplateVariableMissing) Error() string {
+	return fmt.Sprintf("template variable is required [name: %s]", err.Name)
+}
+
+func (err ErrTemplateVariableMissing) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ParseGiteaTemplateConfig parses and validates the content of a .gitea/template.yaml file
+func ParseGiteaTemplateConfig(content []byte) (*GiteaTemplateConfig, error) {
+	cfg := &GiteaTemplateConfig{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse template config: %w", err)
+	}
+	seen := make(container.Set[string], len(cfg.Variables))
+	for _, v := range cfg.Variables {
+		if v == nil || !templateVariableNameRegexp.MatchString(v.Name) {
+			return nil, util.NewInvalidArgumentErrorf("invalid template variable name")
+		}
+		if !seen.Add(v.Name) {
+			return nil, util.NewInvalidArgumentErrorf("duplicate template variable %q", v.Name)
+		}
+	}
+	cfg.SetupWorkflow = strings.TrimSpace(cfg.SetupWorkflow)
+	if strings.ContainsAny(cfg.SetupWorkflow, "/\\") {
+		return nil, util.NewInvalidArgumentErrorf("setup workflow must be a file name in the workflows directory")
+	}
+	return cfg, nil
+}
+
+// ResolveVariables returns the values of the declared variables, falling back to their defaults.
+// Values for undeclared variables are ignored.
+func (cfg *GiteaTemplateConfig) ResolveVariables(values map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(cfg.Variables))
+	for _, v := range cfg.Variables {
+		value, ok := values[v.Name]
+		if !ok || value == "" {
+			value = v.Default
+		}
+		if value == "" && v.Required {
+			return nil, ErrTemplateVariableMissing{Name: v.Name}
+		}
+		resolved[v.Name] = value
+	}
+	return resolved, nil
+}
+
+// ReadGiteaTemplateConfig reads the .gitea/template.yaml file from the default branch of the template repository.
+// It returns nil if the template repository doesn't have one.
+func ReadGiteaTemplateConfig(ctx context.Context, templateRepo *repo_model.Repository) (*GiteaTemplateConfig, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, templateRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(templateRepo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range giteaTemplateConfigNames {
+		content, err := commit.GetFileContent(".gitea/"+name, 1024*1024)
+		if git.IsErrNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return ParseGiteaTemplateConfig([]byte(content))
+	}
+	return nil, nil
+}
+
+var giteaTemplateConfigNames = []string{"template.yaml", "template.yml"}
+
 // GiteaTemplate holds information about a .gitea/template file
 type GiteaTemplate struct {
 	Path    string
 	Content []byte
 
+	// ConfigPath and Config are set if the template has a .gitea/template.yaml file
+	ConfigPath string
+	Config     *GiteaTemplateConfig
+
 	globs []glob.Glob
 }
 
@@ -104,13 +229,21 @@ func (gt *GiteaTemplate) Globs() []glob.Glob {
 		return gt.globs
 	}
 
-	gt.globs = make([]glob.Glob, 0)
+	lines := make([]string, 0)
 	scanner := bufio.NewScanner(bytes.NewReader(gt.Content))
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
+		lines = append(lines, line)
+	}
+	if gt.Config != nil {
+		lines = append(lines, gt.Config.Files...)
+	}
+
+	gt.globs = make([]glob.Glob, 0, len(lines))
+	for _, line := range lines {
 		g, err := glob.Compile(line, '/')
 		if err != nil {
 			log.Info("Invalid glob expression '%s' (skipped): %v", line, err)
@@ -122,24 +255,45 @@ func (gt *GiteaTemplate) Globs() []glob.Glob {
 }
 
 func readGiteaTemplateFile(tmpDir string) (*GiteaTemplate, error) {
+	gt := &GiteaTemplate{}
 	gtPath := filepath.Join(tmpDir, ".gitea", "template")
-	if _, err := os.Stat(gtPath); os.IsNotExist(err) {
-		return nil, nil
-	} else if err != nil {
+	if content, err := os.ReadFile(gtPath); err == nil {
+		gt.Path, gt.Content = gtPath, content
+	} else if !os.IsNotExist(err) {
 		return nil, err
 	}
 
-	content, err := os.ReadFile(gtPath)
-	if err != nil {
-		return nil, err
+	for _, name := range giteaTemplateConfigNames {
+		configPath := filepath.Join(tmpDir, ".gitea", name)
+		content, err := os.ReadFile(configPath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if gt.Config, err = ParseGiteaTemplateConfig(content); err != nil {
+			return nil, err
+		}
+		gt.ConfigPath = configPath
+		break
 	}
 
-	return &GiteaTemplate{Path: gtPath, Content: content}, nil
+	if gt.Path == "" && gt.ConfigPath == "" {
+		return nil, nil
+	}
+	return gt, nil
 }
 
-func processGiteaTemplateFile(ctx context.Context, tmpDir string, templateRepo, generateRepo *repo_model.Repository, giteaTemplateFile *GiteaTemplate) error {
-	if err := util.Remove(giteaTemplateFile.Path); err != nil {
-		return fmt.Errorf("remove .giteatemplate: %w", err)
+func processGiteaTemplateFile(ctx context.Context, tmpDir string, templateRepo, generateRepo *repo_model.Repository, giteaTemplateFile *GiteaTemplate, variables map[string]string) error {
+	if giteaTemplateFile.Path != "" {
+		if err := util.Remove(giteaTemplateFile.Path); err != nil {
+			return fmt.Errorf("remove .giteatemplate: %w", err)
+		}
+	}
+	if giteaTemplateFile.ConfigPath != "" {
+		if err := util.Remove(giteaTemplateFile.ConfigPath); err != nil {
+			return fmt.Errorf("remove template config: %w", err)
+		}
 	}
 	if len(giteaTemplateFile.Globs()) == 0 {
 		return nil // Avoid walking tree if there are no globs
@@ -162,12 +316,12 @@ func processGiteaTemplateFile(ctx context.Context, tmpDir string, templateRepo,
 					return err
 				}
 
-				generatedContent := []byte(generateExpansion(ctx, string(content), templateRepo, generateRepo, false))
+				generatedContent := []byte(generateExpansion(ctx, string(content), templateRepo, generateRepo, variables, false))
 				if err := os.WriteFile(path, generatedContent, 0o644); err != nil {
 					return err
 				}
 
-				substPath := filepath.FromSlash(filepath.Join(tmpDirSlash, generateExpansion(ctx, base, templateRepo, generateRepo, true)))
+				substPath := filepath.FromSlash(filepath.Join(tmpDirSlash, generateExpansion(ctx, base, templateRepo, generateRepo, variables, true)))
 
 				// Create parent subdirectories if needed or continue silently if it exists
 				if err = os.MkdirAll(filepath.Dir(substPath), 0o755); err != nil {
@@ -185,7 +339,7 @@ func processGiteaTemplateFile(ctx context.Context, tmpDir string, templateRepo,
 	}) // end: WalkDir
 }
 
-func generateRepoCommit(ctx context.Context, repo, templateRepo, generateRepo *repo_model.Repository, tmpDir string) error {
+func generateRepoCommit(ctx context.Context, repo, templateRepo, generateRepo *repo_model.Repository, tmpDir string, variables map[string]string) error {
 	commitTimeStr := time.Now().Format(time.RFC3339)
 	authorSig := repo.Owner.NewGitSig()
 
@@ -225,7 +379,7 @@ func generateRepoCommit(ctx context.Context, repo, templateRepo, generateRepo *r
 	}
 
 	if giteaTemplateFile != nil {
-		err = processGiteaTemplateFile(ctx, tmpDir, templateRepo, generateRepo, giteaTemplateFile)
+		err = processGiteaTemplateFile(ctx, tmpDir, templateRepo, generateRepo, giteaTemplateFile, variables)
 		if err != nil {
 			return err
 		}
@@ -255,14 +409,14 @@ func generateRepoCommit(ctx context.Context, repo, templateRepo, generateRepo *r
 }
 
 // GenerateGitContent generates git content from a template repository
-func GenerateGitContent(ctx context.Context, templateRepo, generateRepo *repo_model.Repository) (err error) {
+func GenerateGitContent(ctx context.Context, templateRepo, generateRepo *repo_model.Repository, variables map[string]string) (err error) {
 	tmpDir, cleanup, err := setting.AppDataTempDir("git-repo-content").MkdirTempRandom("gitea-" + generateRepo.Name)
 	if err != nil {
 		return fmt.Errorf("failed to create temp dir for repository %s: %w", generateRepo.FullName(), err)
 	}
 	defer cleanup()
 
-	if err = generateRepoCommit(ctx, generateRepo, templateRepo, generateRepo, tmpDir); err != nil {
+	if err = generateRepoCommit(ctx, generateRepo, templateRepo, generateRepo, tmpDir, variables); err != nil {
 		return fmt.Errorf("generateRepoCommit: %w", err)
 	}
 
@@ -306,6 +460,8 @@ type GenerateRepoOptions struct {
 	Avatar          bool
 	IssueLabels     bool
 	ProtectedBranch bool
+	// Variables are the values of the custom variables declared in .gitea/template.yaml
+	Variables map[string]string
 }
 
 // IsValid checks whether at least one option is chosen for generation
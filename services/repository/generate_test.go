@@ -89,3 +89,38 @@ func TestTransformers(t *testing.T) {
 		assert.Equal(t, c.expected, tf.Transform(input), "case %s", c.name)
 	}
 }
+
+func TestParseGiteaTemplateConfig(t *testing.T) {
+	cfg, err := ParseGiteaTemplateConfig([]byte(`
+files:
+  - "**.md"
+variables:
+  - name: SERVICE_NAME
+    description: Name of the service
+    required: true
+  - name: PORT
+    default: "8080"
+setup_workflow: setup.yaml
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"**.md"}, cfg.Files)
+	assert.Len(t, cfg.Variables, 2)
+	assert.Equal(t, "setup.yaml", cfg.SetupWorkflow)
+
+	_, err = cfg.ResolveVariables(nil)
+	assert.True(t, IsErrTemplateVariableMissing(err))
+
+	vars, err := cfg.ResolveVariables(map[string]string{"SERVICE_NAME": "billing", "UNKNOWN": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"SERVICE_NAME": "billing", "PORT": "8080"}, vars)
+
+	_, err = ParseGiteaTemplateConfig([]byte("variables:\n  - name: lower\n"))
+	assert.Error(t, err)
+	_, err = ParseGiteaTemplateConfig([]byte("variables:\n  - name: A\n  - name: A\n"))
+	assert.Error(t, err)
+	_, err = ParseGiteaTemplateConfig([]byte("setup_workflow: ../setup.yaml\n"))
+	assert.Error(t, err)
+
+	gt := GiteaTemplate{Content: []byte("**.go\n"), Config: cfg}
+	assert.Len(t, gt.Globs(), 2)
+}
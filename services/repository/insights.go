@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/httplib"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+const (
+	insightsCacheKey           = "RepoInsights/%s/%s/%d/%d"
+	insightsCacheTimeout int64 = 60 * 10
+)
+
+// InsightsScope represents the repositories of the insights, Key identifies them in the cache
+type InsightsScope struct {
+	Key      string
+	RepoCond builder.Cond // the condition on "issue.repo_id"
+}
+
+func getCachedInsights[T any](c cache.StringCache, kind string, scope InsightsScope, since, before time.Time, get func() (*T, error)) (*T, error) {
+	cacheKey := fmt.Sprintf(insightsCacheKey, kind, scope.Key, since.Unix(), before.Unix())
+	res := new(T)
+	if exist, err := c.GetJSON(cacheKey, res); exist && err == nil {
+		return res, nil
+	}
+	res, err := get()
+	if err != nil {
+		return nil, err
+	}
+	_ = c.PutJSON(cacheKey, res, insightsCacheTimeout)
+	return res, nil
+}
+
+func toAPIDurationPercentiles(p activities_model.DurationPercentiles) *api.DurationPercentiles {
+	return &api.DurationPercentiles{Count: p.Count, P50: p.P50, P75: p.P75, P90: p.P90, P95: p.P95}
+}
+
+// GetPullRequestInsights returns the cached pull request metrics of the repositories in the period
+func GetPullRequestInsights(ctx context.Context, c cache.StringCache, scope InsightsScope, since, before time.Time) (*api.PullRequestInsights, error) {
+	return getCachedInsights(c, "pulls", scope, since, before, func() (*api.PullRequestInsights, error) {
+		insights, err := activities_model.GetPullRequestInsights(ctx, scope.RepoCond, timeutil.TimeStamp(since.Unix()), timeutil.TimeStamp(before.Unix()))
+		if err != nil {
+			return nil, err
+		}
+		return &api.PullRequestInsights{
+			Since:              since,
+			Before:             before,
+			Opened:             insights.Opened,
+			Merged:             insights.Merged,
+			ClosedWithoutMerge: insights.ClosedWithoutMerge,
+			LeadTime:           toAPIDurationPercentiles(insights.LeadTime),
+			ReviewTurnaround:   toAPIDurationPercentiles(insights.ReviewTurnaround),
+		}, nil
+	})
+}
+
+// GetIssueInsights returns the cached issue metrics of the repositories in the period
+func GetIssueInsights(ctx context.Context, c cache.StringCache, scope InsightsScope, since, before time.Time) (*api.IssueInsights, error) {
+	return getCachedInsights(c, "issues", scope, since, before, func() (*api.IssueInsights, error) {
+		insights, err := activities_model.GetIssueInsights(ctx, scope.RepoCond, timeutil.TimeStamp(since.Unix()), timeutil.TimeStamp(before.Unix()))
+		if err != nil {
+			return nil, err
+		}
+		closeRate := 0.0
+		if insights.Opened > 0 {
+			closeRate = float64(insights.OpenedAndClosed) / float64(insights.Opened)
+		}
+		return &api.IssueInsights{
+			Since:       since,
+			Before:      before,
+			Opened:      insights.Opened,
+			Closed:      insights.Closed,
+			CloseRate:   closeRate,
+			TimeToClose: toAPIDurationPercentiles(insights.TimeToClose),
+		}, nil
+	})
+}
+
+// GetContributorInsights returns the weekly commit statistics of the contributors of the repositories, the contributors
+// of several repositories are merged by their login or their name. The total statistics of all the contributors are returned too.
+// ErrAwaitGeneration is returned if the statistics of a repository are still being generated.
+func GetContributorInsights(ctx context.Context, c cache.StringCache, repos []*repo_model.Repository) ([]*api.ContributorStats, []*api.WeeklyCommitStats, error) {
+	contributors := make(map[string]*api.ContributorStats)
+	totalWeeks := make(map[int64]*api.WeeklyCommitStats)
+	var generating bool
+	for _, repo := range repos {
+		if repo.IsEmpty {
+			continue
+		}
+		// the statistics of an organization can be generated for many repositories, they are generated in the background without waiting
+		stats, err := getContributorStats(ctx, c, repo, repo.DefaultBranch, util.Iif(len(repos) > 1, 0, awaitGenerationTime))
+		if errors.Is(err, ErrAwaitGeneration) {
+			generating = true
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		for key, data := range stats {
+			if key == "total" {
+				addWeeklyCommitStats(totalWeeks, data.Weeks)
+				continue
+			}
+			contributorKey := util.IfZero(data.Login, "name:"+data.Name)
+			contributor, ok := contributors[contributorKey]
+			if !ok {
+				avatarURL := data.AvatarLink
+				if strings.HasPrefix(avatarURL, "/") {
+					avatarURL = httplib.MakeAbsoluteURL(ctx, avatarURL)
+				}
+				contributor = &api.ContributorStats{Name: data.Name, Login: data.Login, AvatarURL: avatarURL}
+				contributors[contributorKey] = contributor
+			}
+			contributor.TotalCommits += data.TotalCommits
+			weeks := make(map[int64]*api.WeeklyCommitStats, len(contributor.Weeks))
+			for _, week := range contributor.Weeks {
+				weeks[week.Week.UnixMilli()] = week
+			}
+			addWeeklyCommitStats(weeks, data.Weeks)
+			contributor.Weeks = sortedWeeks(weeks)
+		}
+	}
+	if generating {
+		return nil, nil, ErrAwaitGeneration
+	}
+
+	res := make([]*api.ContributorStats, 0, len(contributors))
+	for _, contributor := range contributors {
+		res = append(res, contributor)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].TotalCommits != res[j].TotalCommits {
+			return res[i].TotalCommits > res[j].TotalCommits
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, sortedWeeks(totalWeeks), nil
+}
+
+func addWeeklyCommitStats(weeks map[int64]*api.WeeklyCommitStats, data map[int64]*WeekData) {
+	for week, d := range data {
+		w, ok := weeks[week]
+		if !ok {
+			w = &api.WeeklyCommitStats{Week: time.UnixMilli(week).UTC()}
+			weeks[week] = w
+		}
+		w.Additions += d.Additions
+		w.Deletions += d.Deletions
+		w.Commits += d.Commits
+	}
+}
+
+func sortedWeeks(weeks map[int64]*api.WeeklyCommitStats) []*api.WeeklyCommitStats {
+	res := make([]*api.WeeklyCommitStats, 0, len(weeks))
+	for _, week := range weeks {
+		res = append(res, week)
+	}
+	slices.SortFunc(res, func(a, b *api.WeeklyCommitStats) int {
+		return a.Week.Compare(b.Week)
+	})
+	return res
+}
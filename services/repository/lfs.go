@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/git"
@@ -137,3 +139,36 @@ func GarbageCollectLFSMetaObjectsForRepo(ctx context.Context, repo *repo_model.R
 	}
 	return nil
 }
+
+// ExpireLFSLocks removes the LFS locks created more than olderThan ago and returns them
+func ExpireLFSLocks(ctx context.Context, olderThan time.Duration) (git_model.LFSLockList, error) {
+	if !setting.LFS.StartServer {
+		return nil, nil
+	}
+
+	// the ages are compared here as the creation times are stored in the time zone of the database
+	deadline := time.Now().Add(-olderThan)
+	var expired git_model.LFSLockList
+	if err := db.Iterate(ctx, nil, func(ctx context.Context, lock *git_model.LFSLock) error {
+		if lock.Created.Before(deadline) {
+			expired = append(expired, lock)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(expired))
+	for i, lock := range expired {
+		ids[i] = lock.ID
+	}
+	for chunk := range slices.Chunk(ids, 500) {
+		if err := git_model.DeleteLFSLocks(ctx, chunk); err != nil {
+			return nil, err
+		}
+	}
+	if len(expired) > 0 {
+		log.Info("Removed %d LFS locks older than %s", len(expired), olderThan)
+	}
+	return expired, nil
+}
@@ -8,12 +8,14 @@ import (
 	"testing"
 	"time"
 
+	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	"code.gitea.io/gitea/modules/lfs"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
 	repo_service "code.gitea.io/gitea/services/repository"
 
 	"github.com/stretchr/testify/assert"
@@ -61,3 +63,24 @@ func storeObjectInRepo(t *testing.T, repositoryID int64, content *[]byte) string
 	}
 	return pointer.Oid
 }
+
+func TestExpireLFSLocks(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	defer test.MockVariableValue(&setting.LFS.StartServer, true)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	stale, err := git_model.CreateLFSLock(t.Context(), repo, &git_model.LFSLock{OwnerID: 2, Path: "stale.psd"})
+	assert.NoError(t, err)
+	fresh, err := git_model.CreateLFSLock(t.Context(), repo, &git_model.LFSLock{OwnerID: 2, Path: "fresh.psd"})
+	assert.NoError(t, err)
+	_, err = db.GetEngine(t.Context()).Exec("UPDATE lfs_lock SET created = ? WHERE id = ?", time.Now().Add(-31*24*time.Hour), stale.ID)
+	assert.NoError(t, err)
+
+	expired, err := repo_service.ExpireLFSLocks(t.Context(), 30*24*time.Hour)
+	assert.NoError(t, err)
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, stale.ID, expired[0].ID)
+	}
+	unittest.AssertNotExistsBean(t, &git_model.LFSLock{ID: stale.ID})
+	unittest.AssertExistsAndLoadBean(t, &git_model.LFSLock{ID: fresh.ID})
+}
@@ -120,29 +120,40 @@ func UpdateRepoLicenses(ctx context.Context, repo *repo_model.Repository, commit
 		return nil
 	}
 
-	b, err := commit.GetBlobByPath(LicenseFileName)
-	if err != nil && !git.IsErrNotExist(err) {
-		return fmt.Errorf("GetBlobByPath: %w", err)
+	licenses, err := DetectLicenses(commit)
+	if err != nil {
+		return err
 	}
-
-	if git.IsErrNotExist(err) {
+	if licenses == nil {
 		return repo_model.CleanRepoLicenses(ctx, repo)
 	}
+	return repo_model.UpdateRepoLicenses(ctx, repo, commit.ID.String(), licenses)
+}
 
-	licenses := make([]string, 0)
-	if b != nil {
-		r, err := b.DataAsync()
-		if err != nil {
-			return err
+// DetectLicenses returns the licenses detected in the license file of the commit, nil is returned if there is no license file
+func DetectLicenses(commit *git.Commit) ([]string, error) {
+	b, err := commit.GetBlobByPath(LicenseFileName)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, nil
 		}
-		defer r.Close()
+		return nil, fmt.Errorf("GetBlobByPath: %w", err)
+	}
 
-		licenses, err = detectLicense(r)
-		if err != nil {
-			return fmt.Errorf("detectLicense: %w", err)
-		}
+	r, err := b.DataAsync()
+	if err != nil {
+		return nil, err
 	}
-	return repo_model.UpdateRepoLicenses(ctx, repo, commit.ID.String(), licenses)
+	defer r.Close()
+
+	licenses, err := detectLicense(r)
+	if err != nil {
+		return nil, fmt.Errorf("detectLicense: %w", err)
+	}
+	if licenses == nil {
+		licenses = make([]string, 0)
+	}
+	return licenses, nil
 }
 
 // detectLicense returns the licenses detected by the given content buff
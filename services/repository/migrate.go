@@ -91,12 +91,16 @@ func MigrateRepositoryGitData(ctx context.Context, u *user_model.User,
 		return repo, fmt.Errorf("failed to remove existing repo dir %q, err: %w", repoPath, err)
 	}
 
-	if err := git.Clone(ctx, opts.CloneAddr, repoPath, git.CloneRepoOptions{
+	cloneOpts := git.CloneRepoOptions{
 		Mirror:        true,
 		Quiet:         true,
 		Timeout:       migrateTimeout,
 		SkipTLSVerify: setting.Migrations.SkipTLSVerify,
-	}); err != nil {
+	}
+	if opts.Mirror {
+		cloneOpts.RefSpecs = repo_model.MirrorFetchRefSpecs(opts.MirrorRefFilter, opts.MirrorSkipTags)
+	}
+	if err := git.Clone(ctx, opts.CloneAddr, repoPath, cloneOpts); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			return repo, fmt.Errorf("clone timed out, consider increasing [git.timeout] MIGRATE in app.ini, underlying err: %w", err)
 		}
@@ -187,6 +191,9 @@ func MigrateRepositoryGitData(ctx context.Context, u *user_model.User,
 				NextUpdateUnix: timeutil.TimeStampNow().AddDuration(setting.Mirror.DefaultInterval),
 				LFS:            opts.LFS,
 				RemoteAddress:  remoteAddress,
+				RefFilter:      opts.MirrorRefFilter,
+				SkipTags:       opts.MirrorSkipTags,
+				MaxSize:        opts.MirrorMaxSize,
 			}
 			if opts.LFS {
 				mirrorModel.LFSEndpoint = opts.LFSEndpoint
@@ -50,7 +50,7 @@ func addRepositoryToTeam(ctx context.Context, t *organization.Team, repo *repo_m
 			return fmt.Errorf("getMembers: %w", err)
 		}
 		for _, u := range t.Members {
-			if err = repo_model.WatchRepo(ctx, u, repo, true); err != nil {
+			if err = repo_model.AutoWatchRepo(ctx, u, repo); err != nil {
 				return fmt.Errorf("watchRepo: %w", err)
 			}
 		}
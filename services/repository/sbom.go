@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/sbom"
+)
+
+// maxLockfileSize is the size limit of lockfiles which are parsed for a SBOM
+const maxLockfileSize = 32 * 1024 * 1024
+
+// GenerateSBOM creates a SBOM of the commit from the lockfiles in the tree.
+// Lockfiles of vendored or installed dependencies get ignored because the dependencies are listed by the lockfiles of the project.
+func GenerateSBOM(repo *repo_model.Repository, commit *git.Commit) (*sbom.Document, error) {
+	entries, err := commit.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &sbom.Document{
+		Name:      repo.FullName(),
+		Version:   commit.ID.String(),
+		Namespace: fmt.Sprintf("%s/commit/%s", repo.HTMLURL(), commit.ID.String()),
+		Created:   time.Now(),
+	}
+	for _, entry := range entries {
+		if !entry.IsRegular() || !sbom.IsLockfile(entry.Name()) || sbom.IsVendoredPath(entry.Name()) || entry.Size() > maxLockfileSize {
+			continue
+		}
+
+		r, err := entry.Blob().DataAsync()
+		if err != nil {
+			return nil, err
+		}
+		components, err := sbom.ParseLockfile(entry.Name(), r)
+		r.Close()
+		if err != nil {
+			// a broken lockfile in the tree must not prevent the SBOM of the other lockfiles
+			log.Debug("Unable to parse lockfile %s of %s: %v", entry.Name(), repo.FullName(), err)
+			continue
+		}
+		doc.AddComponents(components...)
+	}
+	return doc, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+const storageTierBatchSize = 100
+
+// storageTierCutoff returns the time since which an object must have been accessed to stay in the hot tier
+func storageTierCutoff(coldTier *setting.StorageColdTier) timeutil.TimeStamp {
+	return timeutil.TimeStamp(time.Now().AddDate(0, 0, -coldTier.AfterDays).Unix())
+}
+
+// TransitionStorageTiers moves the LFS objects and the repository archives which haven't been accessed
+// for the configured days to the cold tier of their storage, and the ones accessed again back to the hot tier.
+func TransitionStorageTiers(ctx context.Context) error {
+	log.Trace("Doing: TransitionStorageTiers")
+	defer log.Trace("Finished: TransitionStorageTiers")
+
+	if tiered, ok := storage.LFS.(*storage.TieredStorage); ok && setting.LFS.ColdTier != nil {
+		for _, isCold := range []bool{false, true} {
+			if err := transitionLFSObjects(ctx, tiered, isCold, storageTierCutoff(setting.LFS.ColdTier)); err != nil {
+				return err
+			}
+		}
+	}
+	if tiered, ok := storage.RepoArchives.(*storage.TieredStorage); ok && setting.RepoArchive.ColdTier != nil {
+		for _, isCold := range []bool{false, true} {
+			if err := transitionRepoArchives(ctx, tiered, isCold, storageTierCutoff(setting.RepoArchive.ColdTier)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// transitionLFSObjects moves the LFS objects out of the given tier, an object which fails to move is retried in the next run
+func transitionLFSObjects(ctx context.Context, tiered *storage.TieredStorage, isCold bool, cutoff timeutil.TimeStamp) error {
+	var afterOid string
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		pointers, err := git_model.FindLFSObjectsToTransition(ctx, isCold, cutoff, afterOid, storageTierBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, p := range pointers {
+			move := tiered.MoveToCold
+			if isCold {
+				move = tiered.MoveToHot
+			}
+			if _, err := move(p.RelativePath()); err != nil {
+				log.Error("Unable to move LFS object %s out of the %s tier: %v", p.Oid, tierName(isCold), err)
+				continue
+			}
+			if err := git_model.SetLFSObjectCold(ctx, p.Oid, !isCold); err != nil {
+				return err
+			}
+		}
+		if len(pointers) < storageTierBatchSize {
+			return nil
+		}
+		afterOid = pointers[len(pointers)-1].Oid
+	}
+}
+
+// transitionRepoArchives moves the repository archives out of the given tier, an archive which fails to move is retried in the next run
+func transitionRepoArchives(ctx context.Context, tiered *storage.TieredStorage, isCold bool, cutoff timeutil.TimeStamp) error {
+	var afterID int64
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		archivers, err := repo_model.FindRepoArchiversToTransition(ctx, isCold, cutoff, afterID, storageTierBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, archiver := range archivers {
+			move := tiered.MoveToCold
+			if isCold {
+				move = tiered.MoveToHot
+			}
+			size, err := move(archiver.RelativePath())
+			if err != nil {
+				log.Error("Unable to move repository archive %s out of the %s tier: %v", archiver.RelativePath(), tierName(isCold), err)
+				continue
+			}
+			// the archives created before the size was recorded
+			archiver.Size = size
+			if err := repo_model.SetRepoArchiverCold(ctx, archiver, !isCold); err != nil {
+				return err
+			}
+		}
+		if len(archivers) < storageTierBatchSize {
+			return nil
+		}
+		afterID = archivers[len(archivers)-1].ID
+	}
+}
+
+func tierName(isCold bool) string {
+	if isCold {
+		return "cold"
+	}
+	return "hot"
+}
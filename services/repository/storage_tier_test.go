@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionStorageTiers(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	defer test.MockVariableValue(&setting.LFS.StartServer, true)()
+	defer test.MockVariableValue(&setting.LFS.ColdTier, &setting.StorageColdTier{
+		Storage:   &setting.Storage{Type: setting.LocalStorageType, Path: t.TempDir()},
+		AfterDays: 30,
+	})()
+	defer test.MockVariableValue(&setting.RepoArchive.ColdTier, &setting.StorageColdTier{
+		Storage:   &setting.Storage{Type: setting.LocalStorageType, Path: t.TempDir()},
+		AfterDays: 30,
+	})()
+	require.NoError(t, storage.Init())
+	defer func() {
+		// the storages without cold tiers for the other tests
+		setting.LFS.ColdTier, setting.RepoArchive.ColdTier = nil, nil
+		assert.NoError(t, storage.Init())
+	}()
+	// the LFS objects of the fixtures have no content
+	_, err := db.GetEngine(t.Context()).Exec("DELETE FROM lfs_meta_object")
+	require.NoError(t, err)
+
+	lfsContent := []byte("cold lfs object")
+	oid := storeObjectInRepo(t, 1, &lfsContent)
+	pointer := lfs.Pointer{Oid: oid, Size: int64(len(lfsContent))}
+	archiver := &repo_model.RepoArchiver{RepoID: 1, Type: git.ArchiveZip, Status: repo_model.ArchiverReady, CommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d"}
+	require.NoError(t, db.Insert(t.Context(), archiver))
+	_, err = storage.RepoArchives.Save(archiver.RelativePath(), strings.NewReader("archive"), 7)
+	require.NoError(t, err)
+
+	// the objects created recently stay in the hot tier
+	require.NoError(t, repo_service.TransitionStorageTiers(t.Context()))
+	meta := unittest.AssertExistsAndLoadBean(t, &git_model.LFSMetaObject{Pointer: lfs.Pointer{Oid: oid}})
+	assert.False(t, meta.IsCold)
+
+	old := time.Now().AddDate(0, 0, -31).Unix()
+	_, err = db.GetEngine(t.Context()).Exec("UPDATE lfs_meta_object SET created_unix = ?", old)
+	require.NoError(t, err)
+	_, err = db.GetEngine(t.Context()).Exec("UPDATE repo_archiver SET created_unix = ?", old)
+	require.NoError(t, err)
+	require.NoError(t, repo_service.TransitionStorageTiers(t.Context()))
+
+	meta = unittest.AssertExistsAndLoadBean(t, &git_model.LFSMetaObject{Pointer: lfs.Pointer{Oid: oid}})
+	assert.True(t, meta.IsCold)
+	archiver = unittest.AssertExistsAndLoadBean(t, &repo_model.RepoArchiver{ID: archiver.ID})
+	assert.True(t, archiver.IsCold)
+	assert.EqualValues(t, 7, archiver.Size)
+
+	// the cold objects are read through
+	exists, err := lfs.NewContentStore().Exists(pointer)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	cold, err := storage.NewLocalStorage(t.Context(), setting.LFS.ColdTier.Storage)
+	require.NoError(t, err)
+	_, err = cold.Stat(pointer.RelativePath())
+	require.NoError(t, err)
+
+	usages, err := git_model.GetLFSStorageTierUsage(t.Context())
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, repo_model.StorageTierUsage{IsCold: true, Count: 1, Size: pointer.Size}, *usages[0])
+	usages, err = repo_model.GetRepoArchiveStorageTierUsage(t.Context())
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, repo_model.StorageTierUsage{IsCold: true, Count: 1, Size: 7}, *usages[0])
+
+	// the objects accessed again are moved back to the hot tier
+	require.NoError(t, git_model.UpdateLFSObjectAccessed(t.Context(), oid))
+	require.NoError(t, repo_model.UpdateRepoArchiverAccessed(t.Context(), archiver))
+	require.NoError(t, repo_service.TransitionStorageTiers(t.Context()))
+
+	meta = unittest.AssertExistsAndLoadBean(t, &git_model.LFSMetaObject{Pointer: lfs.Pointer{Oid: oid}})
+	assert.False(t, meta.IsCold)
+	archiver = unittest.AssertExistsAndLoadBean(t, &repo_model.RepoArchiver{ID: archiver.ID})
+	assert.False(t, archiver.IsCold)
+	_, err = cold.Stat(pointer.RelativePath())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -12,10 +12,17 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/reqctx"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
 	notify_service "code.gitea.io/gitea/services/notify"
+
+	"github.com/nektos/act/pkg/model"
 )
 
 // GenerateIssueLabels generates issue labels from a template repository
@@ -73,6 +80,20 @@ func GenerateRepository(ctx context.Context, doer, owner *user_model.User, templ
 		}
 	}
 
+	// resolve the custom template variables before creating anything, so missing values are reported early
+	var templateConfig *GiteaTemplateConfig
+	var variables map[string]string
+	if opts.GitContent && !templateRepo.IsEmpty {
+		if templateConfig, err = ReadGiteaTemplateConfig(ctx, templateRepo); err != nil {
+			return nil, fmt.Errorf("ReadGiteaTemplateConfig: %w", err)
+		}
+		if templateConfig != nil {
+			if variables, err = templateConfig.ResolveVariables(opts.Variables); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	generateRepo := &repo_model.Repository{
 		OwnerID:          owner.ID,
 		Owner:            owner,
@@ -135,7 +156,7 @@ func GenerateRepository(ctx context.Context, doer, owner *user_model.User, templ
 	// 5 - generate the repository contents according to the template
 	// Git Content
 	if opts.GitContent && !templateRepo.IsEmpty {
-		if err = GenerateGitContent(ctx, templateRepo, generateRepo); err != nil {
+		if err = GenerateGitContent(ctx, templateRepo, generateRepo, variables); err != nil {
 			return nil, err
 		}
 	}
@@ -189,5 +210,45 @@ func GenerateRepository(ctx context.Context, doer, owner *user_model.User, templ
 
 	notify_service.CreateRepository(ctx, doer, owner, generateRepo)
 
+	// 7 - dispatch the setup workflow, a failure here doesn't affect the generated repository
+	if templateConfig != nil && templateConfig.SetupWorkflow != "" {
+		ref := util.IfZero(generateRepo.DefaultBranch, templateRepo.DefaultBranch)
+		if err := dispatchTemplateSetupWorkflow(ctx, doer, generateRepo, templateConfig.SetupWorkflow, ref, variables); err != nil {
+			log.Error("Unable to dispatch setup workflow %q for %s: %v", templateConfig.SetupWorkflow, generateRepo.FullName(), err)
+		}
+	}
+
 	return generateRepo, nil
 }
+
+// dispatchTemplateSetupWorkflow dispatches the setup workflow declared in .gitea/template.yaml on the given ref
+// of the generated repository, the template variables are passed as the workflow inputs with the same names.
+func dispatchTemplateSetupWorkflow(ctx context.Context, doer *user_model.User, generateRepo *repo_model.Repository, workflowID, ref string, variables map[string]string) error {
+	if !setting.Actions.Enabled || generateRepo.IsEmpty || !generateRepo.UnitEnabled(ctx, unit.TypeActions) {
+		return nil
+	}
+
+	reqCtx := reqctx.FromContext(ctx)
+	if reqCtx == nil {
+		newCtx, finished := reqctx.NewRequestContext(ctx, "DispatchTemplateSetupWorkflow")
+		defer finished()
+		reqCtx = reqctx.FromContext(newCtx)
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, generateRepo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	return actions_service.DispatchActionWorkflow(reqCtx, doer, generateRepo, gitRepo, workflowID, ref, func(workflowDispatch *model.WorkflowDispatch, inputs map[string]any) error {
+		for name, config := range workflowDispatch.Inputs {
+			if value, ok := variables[name]; ok {
+				inputs[name] = value
+			} else {
+				inputs[name] = config.Default
+			}
+		}
+		return nil
+	})
+}
@@ -241,7 +241,7 @@ func transferOwnership(ctx context.Context, doer *user_model.User, newOwnerName
 		return fmt.Errorf("decrease old owner repository count: %w", err)
 	}
 
-	if err := repo_model.WatchRepo(ctx, doer, repo, true); err != nil {
+	if err := repo_model.AutoWatchRepo(ctx, doer, repo); err != nil {
 		return fmt.Errorf("watchRepo: %w", err)
 	}
 
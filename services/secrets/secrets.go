@@ -5,15 +5,30 @@ package secrets
 
 import (
 	"context"
+	"strings"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/secret/external"
 )
 
-func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
+// CreateOrUpdateSecret creates or updates a secret, if provider is set the data is the reference of a secret in the external store
+func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, provider, data, description string) (*secret_model.Secret, bool, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, false, err
 	}
+	if provider != "" {
+		ownerName, err := secretOwnerName(ctx, ownerID, repoID)
+		if err != nil {
+			return nil, false, err
+		}
+		data = strings.TrimSpace(data)
+		if err := external.Validate(provider, ownerName, data); err != nil {
+			return nil, false, err
+		}
+	}
 
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID: ownerID,
@@ -25,20 +40,76 @@ func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data
 	}
 
 	if len(s) == 0 {
-		s, err := secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, data, description)
+		s, err := secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, provider, data, description)
+		if err != nil {
+			return nil, false, err
+		}
+		return s, true, nil
+	}
+
+	if err := secret_model.UpdateSecret(ctx, s[0].ID, provider, data, description); err != nil {
+		return nil, false, err
+	}
+
+	return s[0], false, nil
+}
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of a deployment environment of the repository
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, provider, data, description string) (*secret_model.Secret, bool, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, false, err
+	}
+	if provider != "" {
+		ownerName, err := secretOwnerName(ctx, 0, repoID)
+		if err != nil {
+			return nil, false, err
+		}
+		data = strings.TrimSpace(data)
+		if err := external.Validate(provider, ownerName, data); err != nil {
+			return nil, false, err
+		}
+	}
+
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(s) == 0 {
+		s, err := secret_model.InsertEncryptedEnvironmentSecret(ctx, repoID, environmentID, name, provider, data, description)
 		if err != nil {
 			return nil, false, err
 		}
 		return s, true, nil
 	}
 
-	if err := secret_model.UpdateSecret(ctx, s[0].ID, data, description); err != nil {
+	if err := secret_model.UpdateSecret(ctx, s[0].ID, provider, data, description); err != nil {
 		return nil, false, err
 	}
 
 	return s[0], false, nil
 }
 
+// secretOwnerName returns the name of the user or organization owning the secret's scope
+func secretOwnerName(ctx context.Context, ownerID, repoID int64) (string, error) {
+	if repoID != 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+		if err != nil {
+			return "", err
+		}
+		return repo.OwnerName, nil
+	}
+	owner, err := user_model.GetUserByID(ctx, ownerID)
+	if err != nil {
+		return "", err
+	}
+	return owner.Name, nil
+}
+
 func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID:  ownerID,
@@ -75,6 +146,26 @@ func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string)
 	return deleteSecret(ctx, s[0])
 }
 
+func DeleteEnvironmentSecretByName(ctx context.Context, repoID, environmentID int64, name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+	})
+	if err != nil {
+		return err
+	}
+	if len(s) != 1 {
+		return secret_model.ErrSecretNotFound{}
+	}
+
+	return deleteSecret(ctx, s[0])
+}
+
 func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
 	if _, err := db.DeleteByID[secret_model.Secret](ctx, s.ID); err != nil {
 		return err
@@ -0,0 +1,320 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package spamdetection
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	project_model "code.gitea.io/gitea/models/project"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	spamdetection_module "code.gitea.io/gitea/modules/spamdetection"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// isCheckRequired checks whether the content of the user has to be checked, only new individual accounts are checked
+func isCheckRequired(u *user_model.User) bool {
+	return setting.SpamDetection.Enabled && u.IsIndividual() && !u.IsAdmin &&
+		time.Since(u.CreatedUnix.AsTime()) < setting.SpamDetection.NewAccountAge
+}
+
+// check applies the built-in rules and the classifier to the content and collects the reasons why it is suspicious.
+// An edit which keeps the content is not checked for duplicates because the content has already been posted.
+func check(ctx context.Context, poster *user_model.User, repo *repo_model.Repository, h *issues_model.HeldContent, newContent bool) error {
+	if setting.SpamDetection.MinAccountAge > 0 && time.Since(poster.CreatedUnix.AsTime()) < setting.SpamDetection.MinAccountAge {
+		h.Reasons = append(h.Reasons, issues_model.HeldReasonAccountAge)
+	}
+
+	text := h.Title + "\n" + h.Content
+	if setting.SpamDetection.MaxLinkDensity > 0 && spamdetection_module.CountLinks(text) >= setting.SpamDetection.MinLinks &&
+		spamdetection_module.LinkDensity(text) > setting.SpamDetection.MaxLinkDensity {
+		h.Reasons = append(h.Reasons, issues_model.HeldReasonLinkDensity)
+	}
+
+	if setting.SpamDetection.DuplicateThreshold > 0 && newContent && strings.TrimSpace(h.Content) != "" {
+		since := timeutil.TimeStamp(time.Now().Add(-setting.SpamDetection.DuplicateWindow).Unix())
+		count, err := issues_model.CountPostedContents(ctx, poster.ID, h.Content, since)
+		if err != nil {
+			return err
+		}
+		if count+1 >= int64(setting.SpamDetection.DuplicateThreshold) {
+			h.Reasons = append(h.Reasons, issues_model.HeldReasonDuplicateContent)
+		}
+	}
+
+	if setting.SpamDetection.ClassifierURL != "" {
+		content := &spamdetection_module.Content{
+			Title:   h.Title,
+			Content: h.Content,
+			Repo:    repo.FullName(),
+			Poster: spamdetection_module.Poster{
+				ID:          poster.ID,
+				Name:        poster.Name,
+				Email:       poster.Email,
+				CreatedUnix: int64(poster.CreatedUnix),
+			},
+		}
+		if h.Type == issues_model.HeldContentIssue || h.Type == issues_model.HeldContentIssueEdit {
+			content.Type = "issue"
+		} else {
+			content.Type = "comment"
+		}
+
+		classifier := spamdetection_module.NewClassifier(setting.SpamDetection.ClassifierURL, setting.SpamDetection.ClassifierToken, setting.SpamDetection.ClassifierTimeout)
+		verdict, err := classifier.Classify(ctx, content)
+		if err != nil {
+			if setting.SpamDetection.AllowOnError {
+				log.Warn("Spam classification failed, the content is checked by the built-in rules only: %v", err)
+			} else {
+				h.Reasons = append(h.Reasons, issues_model.HeldReasonClassifier)
+				h.ClassifierReason = "classification failed: " + err.Error()
+			}
+		} else if verdict.Spam {
+			h.Reasons = append(h.Reasons, issues_model.HeldReasonClassifier)
+			h.ClassifierReason = verdict.Reason
+		}
+	}
+	return nil
+}
+
+func holdIfSuspicious(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, h *issues_model.HeldContent, newContent bool) (bool, error) {
+	if !isCheckRequired(doer) {
+		return false, nil
+	}
+	if err := check(ctx, doer, repo, h, newContent); err != nil || len(h.Reasons) == 0 {
+		return false, err
+	}
+
+	if err := issues_model.InsertHeldContent(ctx, h); err != nil {
+		return false, err
+	}
+	log.Info("Content of %s in %s has been held as suspected spam (held content %d): %s", doer.Name, repo.FullName(), h.ID, strings.Join(h.Reasons, ", "))
+	return true, nil
+}
+
+// HoldIssueIfSuspicious checks the new issue of the doer and holds it with its labels, milestone, assignees and
+// project instead of creating it if it is suspicious
+func HoldIssueIfSuspicious(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, issue *issues_model.Issue, labelIDs []int64, attachments []string, assigneeIDs []int64, projectID int64) (bool, error) {
+	return holdIfSuspicious(ctx, doer, repo, &issues_model.HeldContent{
+		Type:        issues_model.HeldContentIssue,
+		RepoID:      repo.ID,
+		PosterID:    doer.ID,
+		Title:       issue.Title,
+		Content:     issue.Content,
+		Attachments: attachments,
+		LabelIDs:    labelIDs,
+		MilestoneID: issue.MilestoneID,
+		AssigneeIDs: assigneeIDs,
+		ProjectID:   projectID,
+	}, true)
+}
+
+// HoldCommentIfSuspicious checks the new comment of the doer and holds it instead of creating it if it is suspicious
+func HoldCommentIfSuspicious(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, content string, attachments []string) (bool, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	return holdIfSuspicious(ctx, doer, issue.Repo, &issues_model.HeldContent{
+		Type:        issues_model.HeldContentComment,
+		RepoID:      issue.RepoID,
+		IssueID:     issue.ID,
+		PosterID:    doer.ID,
+		Content:     content,
+		Attachments: attachments,
+	}, true)
+}
+
+// HoldIssueEditIfSuspicious checks the new title and content of an issue edited by the doer and holds them instead of
+// changing the issue if they are suspicious. Only the changed fields are held, so the other one can still be changed
+// while the edit is awaiting approval.
+func HoldIssueEditIfSuspicious(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, title, content string) (bool, error) {
+	titleEdited, contentEdited := title != issue.Title, content != issue.Content
+	if !titleEdited && !contentEdited {
+		return false, nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	h := &issues_model.HeldContent{
+		Type:          issues_model.HeldContentIssueEdit,
+		RepoID:        issue.RepoID,
+		IssueID:       issue.ID,
+		PosterID:      doer.ID,
+		TitleEdited:   titleEdited,
+		ContentEdited: contentEdited,
+	}
+	if titleEdited {
+		h.Title = title
+	}
+	if contentEdited {
+		h.Content = content
+	}
+	return holdIfSuspicious(ctx, doer, issue.Repo, h, contentEdited)
+}
+
+// HoldCommentEditIfSuspicious checks the new content of a comment edited by the doer and holds it instead of changing
+// the comment if it is suspicious
+func HoldCommentEditIfSuspicious(ctx context.Context, doer *user_model.User, comment *issues_model.Comment, content string) (bool, error) {
+	if content == comment.Content {
+		return false, nil
+	}
+	if err := comment.LoadIssue(ctx); err != nil {
+		return false, err
+	}
+	if err := comment.Issue.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	return holdIfSuspicious(ctx, doer, comment.Issue.Repo, &issues_model.HeldContent{
+		Type:      issues_model.HeldContentCommentEdit,
+		RepoID:    comment.Issue.RepoID,
+		IssueID:   comment.IssueID,
+		CommentID: comment.ID,
+		PosterID:  doer.ID,
+		Content:   content,
+	}, true)
+}
+
+// ApproveHeldContent publishes the held content as if the poster had just posted it. The held content is deleted in
+// the same transaction, so it is only published once.
+func ApproveHeldContent(ctx context.Context, doer *user_model.User, h *issues_model.HeldContent) error {
+	if err := h.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	if h.Repo == nil {
+		return util.NewNotExistErrorf("the repository has been deleted")
+	} else if h.Poster.IsGhost() {
+		return util.NewNotExistErrorf("the poster has been deleted")
+	} else if h.Type != issues_model.HeldContentIssue && h.Issue == nil {
+		return util.NewNotExistErrorf("the issue has been deleted")
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if deleted, err := issues_model.DeleteHeldContentByID(ctx, h.ID); err != nil {
+			return err
+		} else if !deleted {
+			return util.NewNotExistErrorf("the content has already been approved or rejected")
+		}
+		return publishHeldContent(ctx, h)
+	}); err != nil {
+		return err
+	}
+
+	log.Info("Held content %d of %s has been approved by %s", h.ID, h.Poster.Name, doer.Name)
+	return nil
+}
+
+func publishHeldContent(ctx context.Context, h *issues_model.HeldContent) error {
+	switch h.Type {
+	case issues_model.HeldContentIssue:
+		issue := &issues_model.Issue{
+			RepoID:      h.RepoID,
+			Repo:        h.Repo,
+			Title:       h.Title,
+			PosterID:    h.PosterID,
+			Poster:      h.Poster,
+			Content:     h.Content,
+			MilestoneID: h.MilestoneID,
+		}
+		assigneeIDs, err := assignableUserIDs(ctx, h.Repo, h.AssigneeIDs)
+		if err != nil {
+			return err
+		}
+		projectID := h.ProjectID
+		if projectID > 0 {
+			// the project may have been deleted while the issue has been held, like the milestone and the labels,
+			// which are dropped by NewIssue
+			if _, err := project_model.GetProjectByID(ctx, projectID); project_model.IsErrProjectNotExist(err) {
+				projectID = 0
+			} else if err != nil {
+				return err
+			}
+		}
+		return issue_service.NewIssue(ctx, h.Repo, issue, h.LabelIDs, h.Attachments, assigneeIDs, projectID)
+	case issues_model.HeldContentComment:
+		_, err := issue_service.CreateIssueComment(ctx, h.Poster, h.Repo, h.Issue, h.Content, h.Attachments)
+		return err
+	case issues_model.HeldContentIssueEdit:
+		// the field which hasn't been edited may have been changed while the edit has been held
+		if h.TitleEdited {
+			if err := issue_service.ChangeTitle(ctx, h.Issue, h.Poster, h.Title); err != nil {
+				return err
+			}
+		}
+		if !h.ContentEdited || h.Content == h.Issue.Content {
+			return nil
+		}
+		return issue_service.ChangeContent(ctx, h.Issue, h.Poster, h.Content, h.Issue.ContentVersion)
+	case issues_model.HeldContentCommentEdit:
+		comment, err := issues_model.GetCommentByID(ctx, h.CommentID)
+		if issues_model.IsErrCommentNotExist(err) {
+			return util.NewNotExistErrorf("the comment has been deleted")
+		} else if err != nil {
+			return err
+		}
+		oldContent := comment.Content
+		comment.Content = h.Content
+		return issue_service.UpdateComment(ctx, comment, comment.ContentVersion, h.Poster, oldContent)
+	}
+	return util.NewInvalidArgumentErrorf("invalid held content type %d", h.Type)
+}
+
+// assignableUserIDs returns the users who still exist and can still be assigned to the issues of the repository
+func assignableUserIDs(ctx context.Context, repo *repo_model.Repository, userIDs []int64) ([]int64, error) {
+	assignable := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		u, err := user_model.GetUserByID(ctx, userID)
+		if user_model.IsErrUserNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if canBeAssigned, err := access_model.CanBeAssigned(ctx, u, repo, false); err != nil {
+			return nil, err
+		} else if canBeAssigned {
+			assignable = append(assignable, userID)
+		}
+	}
+	return assignable, nil
+}
+
+// RejectHeldContent deletes the held content and its attachments
+func RejectHeldContent(ctx context.Context, doer *user_model.User, h *issues_model.HeldContent) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if deleted, err := issues_model.DeleteHeldContentByID(ctx, h.ID); err != nil {
+			return err
+		} else if !deleted {
+			return util.NewNotExistErrorf("the content has already been approved or rejected")
+		}
+		if len(h.Attachments) == 0 {
+			return nil
+		}
+		attachments, err := repo_model.GetAttachmentsByUUIDs(ctx, h.Attachments)
+		if err != nil {
+			return err
+		}
+		// only the attachments which have never been linked belong to the held content
+		unlinked := make([]*repo_model.Attachment, 0, len(attachments))
+		for _, attach := range attachments {
+			if attach.IssueID == 0 && attach.ReleaseID == 0 {
+				unlinked = append(unlinked, attach)
+			}
+		}
+		_, err = repo_model.DeleteAttachments(ctx, unlinked, true)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	log.Info("Held content %d of user %d has been rejected by %s", h.ID, h.PosterID, doer.Name)
+	return nil
+}
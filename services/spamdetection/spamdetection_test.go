@@ -0,0 +1,218 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package spamdetection
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+	issue_service "code.gitea.io/gitea/services/issue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const linkSpam = "cheap https://a.example.com https://b.example.com https://c.example.com"
+
+// mockSpamDetection checks the content of all non-admin fixture users, they are older than the default account age
+func mockSpamDetection(t *testing.T) {
+	t.Cleanup(test.MockVariableValue(&setting.SpamDetection.Enabled, true))
+	t.Cleanup(test.MockVariableValue(&setting.SpamDetection.NewAccountAge, 100*365*24*time.Hour))
+	t.Cleanup(test.MockVariableValue(&setting.SpamDetection.MinAccountAge, 0))
+}
+
+func holdTestIssue(t *testing.T, doer *user_model.User, repo *repo_model.Repository, title, content string) (bool, error) {
+	return HoldIssueIfSuspicious(t.Context(), doer, repo, &issues_model.Issue{Title: title, Content: content}, nil, nil, nil, 0)
+}
+
+func TestHoldIfSuspicious(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	mockSpamDetection(t)
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+
+	held, err := holdTestIssue(t, user2, repo1, "harmless", "see https://example.com for details")
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	held, err = holdTestIssue(t, user2, repo1, "offer", linkSpam)
+	require.NoError(t, err)
+	assert.True(t, held)
+	h := unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentIssue, PosterID: 2, Title: "offer"})
+	assert.Equal(t, []string{issues_model.HeldReasonLinkDensity}, h.Reasons)
+
+	// the held issue counts as a duplicate of the comment
+	defer test.MockVariableValue(&setting.SpamDetection.DuplicateThreshold, 2)()
+	held, err = HoldCommentIfSuspicious(t.Context(), user2, issue1, linkSpam, nil)
+	require.NoError(t, err)
+	assert.True(t, held)
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentComment, PosterID: 2, IssueID: 1})
+	assert.Equal(t, []string{issues_model.HeldReasonLinkDensity, issues_model.HeldReasonDuplicateContent}, h.Reasons)
+
+	// admins are never checked
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	held, err = holdTestIssue(t, user1, repo1, "offer", linkSpam)
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	// the accounts are too old to be checked
+	setting.SpamDetection.NewAccountAge = time.Hour
+	held, err = holdTestIssue(t, user2, repo1, "offer", linkSpam)
+	require.NoError(t, err)
+	assert.False(t, held)
+}
+
+func TestHoldIfClassifiedAsSpam(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	mockSpamDetection(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(content), "casino"):
+			_, _ = io.WriteString(w, `{"spam":true,"reason":"gambling"}`)
+		case strings.Contains(string(content), "unavailable"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = io.WriteString(w, `{"spam":false}`)
+		}
+	}))
+	defer srv.Close()
+	defer test.MockVariableValue(&setting.SpamDetection.ClassifierURL, srv.URL)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	held, err := holdTestIssue(t, user2, repo1, "question", "how do I build it?")
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	held, err = holdTestIssue(t, user2, repo1, "win", "the best online casino")
+	require.NoError(t, err)
+	assert.True(t, held)
+	h := unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{PosterID: 2, Title: "win"})
+	assert.Equal(t, []string{issues_model.HeldReasonClassifier}, h.Reasons)
+	assert.Equal(t, "gambling", h.ClassifierReason)
+
+	held, err = holdTestIssue(t, user2, repo1, "question", "unavailable")
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	defer test.MockVariableValue(&setting.SpamDetection.AllowOnError, false)()
+	held, err = holdTestIssue(t, user2, repo1, "question", "unavailable")
+	require.NoError(t, err)
+	assert.True(t, held)
+}
+
+func TestApproveAndRejectHeldContent(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	mockSpamDetection(t)
+
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+
+	held, err := holdTestIssue(t, user2, repo1, "held issue", linkSpam)
+	require.NoError(t, err)
+	require.True(t, held)
+	h := unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Title: "held issue"})
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, PosterID: 2, Title: "held issue", Content: linkSpam})
+	unittest.AssertNotExistsBean(t, &issues_model.HeldContent{ID: h.ID})
+	// the content is only published once
+	assert.ErrorIs(t, ApproveHeldContent(t.Context(), user1, h), util.ErrNotExist)
+	assert.Equal(t, 1, unittest.GetCount(t, &issues_model.Issue{RepoID: 1, Title: "held issue"}))
+
+	held, err = HoldCommentIfSuspicious(t.Context(), user2, issue1, linkSpam+" again", nil)
+	require.NoError(t, err)
+	require.True(t, held)
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentComment})
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, PosterID: 2, Content: linkSpam + " again"})
+
+	// the labels, the milestone and the assignees of the issue are kept
+	held, err = HoldIssueIfSuspicious(t.Context(), user2, repo1, &issues_model.Issue{Title: "held issue with metas", Content: linkSpam, MilestoneID: 1}, []int64{1}, nil, []int64{2}, 0)
+	require.NoError(t, err)
+	require.True(t, held)
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Title: "held issue with metas"})
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "held issue with metas"})
+	assert.EqualValues(t, 1, issue.MilestoneID)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+
+	held, err = holdTestIssue(t, user2, repo1, "rejected issue", linkSpam)
+	require.NoError(t, err)
+	require.True(t, held)
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Title: "rejected issue"})
+	require.NoError(t, RejectHeldContent(t.Context(), user1, h))
+	unittest.AssertNotExistsBean(t, &issues_model.HeldContent{ID: h.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.Issue{Title: "rejected issue"})
+	assert.ErrorIs(t, RejectHeldContent(t.Context(), user1, h), util.ErrNotExist)
+}
+
+func TestHoldEditIfSuspicious(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	mockSpamDetection(t)
+
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	comment2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+
+	held, err := HoldIssueEditIfSuspicious(t.Context(), user2, issue1, "new title", issue1.Content)
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	// the issue keeps its title and content until the edit is approved
+	held, err = HoldIssueEditIfSuspicious(t.Context(), user2, issue1, "offer", linkSpam)
+	require.NoError(t, err)
+	require.True(t, held)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: issue1.Title, Content: issue1.Content})
+	h := unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentIssueEdit, IssueID: 1})
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: "offer", Content: linkSpam})
+
+	// only the edited field is applied, the other one may have been changed while the edit has been held
+	issue1 = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	held, err = HoldIssueEditIfSuspicious(t.Context(), user2, issue1, linkSpam, issue1.Content)
+	require.NoError(t, err)
+	require.True(t, held)
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentIssueEdit, IssueID: 1, TitleEdited: true})
+	assert.False(t, h.ContentEdited)
+	require.NoError(t, issue_service.ChangeContent(t.Context(), issue1, user2, "changed while held", issue1.ContentVersion))
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	issue1 = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: linkSpam, Content: "changed while held"})
+
+	held, err = HoldIssueEditIfSuspicious(t.Context(), user2, issue1, issue1.Title, linkSpam+" edited")
+	require.NoError(t, err)
+	require.True(t, held)
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentIssueEdit, IssueID: 1, ContentEdited: true})
+	assert.False(t, h.TitleEdited)
+	require.NoError(t, issue_service.ChangeTitle(t.Context(), issue1, user2, "renamed while held"))
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: "renamed while held", Content: linkSpam + " edited"})
+
+	held, err = HoldCommentEditIfSuspicious(t.Context(), user2, comment2, linkSpam+" again")
+	require.NoError(t, err)
+	require.True(t, held)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2, Content: comment2.Content})
+	h = unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentCommentEdit, CommentID: 2})
+	require.NoError(t, ApproveHeldContent(t.Context(), user1, h))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2, Content: linkSpam + " again"})
+}
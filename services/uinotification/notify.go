@@ -5,6 +5,7 @@ package uinotification
 
 import (
 	"context"
+	"strconv"
 
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
@@ -26,12 +27,21 @@ type (
 
 	issueNotificationOpts struct {
 		IssueID              int64
+		RepoID               int64
 		CommentID            int64
 		NotificationAuthorID int64
 		ReceiverID           int64 // 0 -- ALL Watcher
 	}
 )
 
+// QueuePartitionKey keeps the notifications of a repository in order in the partitioned queues
+func (opts issueNotificationOpts) QueuePartitionKey() string {
+	if opts.RepoID == 0 {
+		return ""
+	}
+	return strconv.FormatInt(opts.RepoID, 10)
+}
+
 func Init() error {
 	notify_service.RegisterNotifier(NewNotifier())
 
@@ -68,6 +78,7 @@ func (ns *notificationService) CreateIssueComment(ctx context.Context, doer *use
 ) {
 	opts := issueNotificationOpts{
 		IssueID:              issue.ID,
+		RepoID:               issue.RepoID,
 		NotificationAuthorID: doer.ID,
 	}
 	if comment != nil {
@@ -77,6 +88,7 @@ func (ns *notificationService) CreateIssueComment(ctx context.Context, doer *use
 	for _, mention := range mentions {
 		opts := issueNotificationOpts{
 			IssueID:              issue.ID,
+			RepoID:               issue.RepoID,
 			NotificationAuthorID: doer.ID,
 			ReceiverID:           mention.ID,
 		}
@@ -90,11 +102,13 @@ func (ns *notificationService) CreateIssueComment(ctx context.Context, doer *use
 func (ns *notificationService) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
 	_ = ns.issueQueue.Push(issueNotificationOpts{
 		IssueID:              issue.ID,
+		RepoID:               issue.RepoID,
 		NotificationAuthorID: issue.Poster.ID,
 	})
 	for _, mention := range mentions {
 		_ = ns.issueQueue.Push(issueNotificationOpts{
 			IssueID:              issue.ID,
+			RepoID:               issue.RepoID,
 			NotificationAuthorID: issue.Poster.ID,
 			ReceiverID:           mention.ID,
 		})
@@ -104,6 +118,7 @@ func (ns *notificationService) NewIssue(ctx context.Context, issue *issues_model
 func (ns *notificationService) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
 	_ = ns.issueQueue.Push(issueNotificationOpts{
 		IssueID:              issue.ID,
+		RepoID:               issue.RepoID,
 		NotificationAuthorID: doer.ID,
 		CommentID:            actionComment.ID,
 	})
@@ -117,6 +132,7 @@ func (ns *notificationService) IssueChangeTitle(ctx context.Context, doer *user_
 	if issue.IsPull && issues_model.HasWorkInProgressPrefix(oldTitle) && !issue.PullRequest.IsWorkInProgress(ctx) {
 		_ = ns.issueQueue.Push(issueNotificationOpts{
 			IssueID:              issue.ID,
+			RepoID:               issue.RepoID,
 			NotificationAuthorID: doer.ID,
 		})
 	}
@@ -125,6 +141,7 @@ func (ns *notificationService) IssueChangeTitle(ctx context.Context, doer *user_
 func (ns *notificationService) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 	_ = ns.issueQueue.Push(issueNotificationOpts{
 		IssueID:              pr.Issue.ID,
+		RepoID:               pr.Issue.RepoID,
 		NotificationAuthorID: doer.ID,
 	})
 }
@@ -139,7 +156,7 @@ func (ns *notificationService) NewPullRequest(ctx context.Context, pr *issues_mo
 		return
 	}
 	toNotify := make(container.Set[int64], 32)
-	repoWatchers, err := repo_model.GetRepoWatchersIDs(ctx, pr.Issue.RepoID)
+	repoWatchers, err := repo_model.GetRepoWatchersIDs(ctx, pr.Issue.RepoID, repo_model.WatchEventPullRequests)
 	if err != nil {
 		log.Error("GetRepoWatchersIDs: %v", err)
 		return
@@ -162,6 +179,7 @@ func (ns *notificationService) NewPullRequest(ctx context.Context, pr *issues_mo
 	for receiverID := range toNotify {
 		_ = ns.issueQueue.Push(issueNotificationOpts{
 			IssueID:              pr.Issue.ID,
+			RepoID:               pr.Issue.RepoID,
 			NotificationAuthorID: pr.Issue.PosterID,
 			ReceiverID:           receiverID,
 		})
@@ -171,6 +189,7 @@ func (ns *notificationService) NewPullRequest(ctx context.Context, pr *issues_mo
 func (ns *notificationService) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, r *issues_model.Review, c *issues_model.Comment, mentions []*user_model.User) {
 	opts := issueNotificationOpts{
 		IssueID:              pr.Issue.ID,
+		RepoID:               pr.Issue.RepoID,
 		NotificationAuthorID: r.Reviewer.ID,
 	}
 	if c != nil {
@@ -180,6 +199,7 @@ func (ns *notificationService) PullRequestReview(ctx context.Context, pr *issues
 	for _, mention := range mentions {
 		opts := issueNotificationOpts{
 			IssueID:              pr.Issue.ID,
+			RepoID:               pr.Issue.RepoID,
 			NotificationAuthorID: r.Reviewer.ID,
 			ReceiverID:           mention.ID,
 		}
@@ -194,6 +214,7 @@ func (ns *notificationService) PullRequestCodeComment(ctx context.Context, pr *i
 	for _, mention := range mentions {
 		_ = ns.issueQueue.Push(issueNotificationOpts{
 			IssueID:              pr.Issue.ID,
+			RepoID:               pr.Issue.RepoID,
 			NotificationAuthorID: c.Poster.ID,
 			CommentID:            c.ID,
 			ReceiverID:           mention.ID,
@@ -223,6 +244,7 @@ func (ns *notificationService) IssueChangeAssignee(ctx context.Context, doer *us
 	if !removed && doer.ID != assignee.ID {
 		opts := issueNotificationOpts{
 			IssueID:              issue.ID,
+			RepoID:               issue.RepoID,
 			NotificationAuthorID: doer.ID,
 			ReceiverID:           assignee.ID,
 		}
@@ -235,10 +257,38 @@ func (ns *notificationService) IssueChangeAssignee(ctx context.Context, doer *us
 	}
 }
 
+func (ns *notificationService) IssueSLAReminder(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+	ns.notifyIssueSLA(ctx, issue)
+}
+
+func (ns *notificationService) IssueSLABreached(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+	ns.notifyIssueSLA(ctx, issue)
+}
+
+// notifyIssueSLA notifies the assignees of an issue, or all its watchers if it has no assignee
+func (ns *notificationService) notifyIssueSLA(ctx context.Context, issue *issues_model.Issue) {
+	if err := issue.LoadAssignees(ctx); err != nil {
+		log.Error("issue.LoadAssignees: %v", err)
+		return
+	}
+	if len(issue.Assignees) == 0 {
+		_ = ns.issueQueue.Push(issueNotificationOpts{IssueID: issue.ID})
+		return
+	}
+	for _, assignee := range issue.Assignees {
+		_ = ns.issueQueue.Push(issueNotificationOpts{
+			IssueID:    issue.ID,
+			RepoID:     issue.RepoID,
+			ReceiverID: assignee.ID,
+		})
+	}
+}
+
 func (ns *notificationService) PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
 	if isRequest {
 		opts := issueNotificationOpts{
 			IssueID:              issue.ID,
+			RepoID:               issue.RepoID,
 			NotificationAuthorID: doer.ID,
 			ReceiverID:           reviewer.ID,
 		}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// AnonymizedUserName returns the name an anonymized user is renamed to
+func AnonymizedUserName(u *user_model.User) string {
+	return fmt.Sprintf("deleted-user-%d", u.ID)
+}
+
+// AnonymizeUser scrubs the personal data of the user. Unlike deleting the user, the account is kept with an anonymous
+// name, so the issues, the comments, the reviews and the commits of the user stay linked to it. The git history is not
+// rewritten because this would change the commit ids, but the email addresses of the user are removed, so the commits
+// aren't associated with the personal data anymore.
+func AnonymizeUser(ctx context.Context, doer, u *user_model.User) error {
+	if !u.IsIndividual() {
+		return util.NewInvalidArgumentErrorf("%s is not an individual user", u.Name)
+	} else if u.ID == doer.ID {
+		return util.NewInvalidArgumentErrorf("the doer can't anonymize themself")
+	}
+
+	if err := revokeUserAccess(ctx, u); err != nil {
+		return err
+	}
+	if err := DeleteAvatar(ctx, u); err != nil {
+		return err
+	}
+
+	// only local users can be renamed, the link to the external identity is personal data as well
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := user_model.RemoveAllAccountLinks(ctx, u); err != nil {
+			return err
+		}
+		u.LoginType = auth_model.Plain
+		u.LoginSource = 0
+		u.LoginName = ""
+		return user_model.UpdateUserCols(ctx, u, "login_type", "login_source", "login_name")
+	}); err != nil {
+		return err
+	}
+	if err := RenameUser(ctx, u, AnonymizedUserName(u)); err != nil {
+		return err
+	}
+
+	email := u.Name + "@" + setting.Service.NoReplyAddress
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		u.FullName = ""
+		u.Email = email
+		u.KeepEmailPrivate = true
+		u.AvatarEmail = ""
+		u.Website = ""
+		u.Location = ""
+		u.Description = ""
+		u.Language = ""
+		u.Passwd = ""
+		u.Salt = ""
+		u.PasswdHashAlgo = ""
+		if err := user_model.UpdateUserCols(ctx, u, "full_name", "email", "keep_email_private", "avatar_email", "website",
+			"location", "description", "language", "passwd", "salt", "passwd_hash_algo"); err != nil {
+			return err
+		}
+
+		if err := db.DeleteBeans(ctx,
+			&user_model.EmailAddress{UID: u.ID},
+			// the redirects keep the former names of the user
+			&user_model.Redirect{RedirectUserID: u.ID},
+			&user_model.Setting{UserID: u.ID},
+			&user_model.UserOpenID{UID: u.ID},
+			&auth_model.TwoFactor{UID: u.ID},
+			&auth_model.WebAuthnCredential{UserID: u.ID},
+		); err != nil {
+			return err
+		}
+
+		// the GPG keys contain the names and the email addresses of the user
+		keys, err := db.Find[asymkey_model.GPGKey](ctx, asymkey_model.FindGPGKeyOptions{OwnerID: u.ID})
+		if err != nil {
+			return fmt.Errorf("ListGPGKeys: %w", err)
+		}
+		for _, key := range keys {
+			if _, err := db.DeleteByBean(ctx, &asymkey_model.GPGKeyImport{KeyID: key.KeyID}); err != nil {
+				return fmt.Errorf("deleteGPGKeyImports: %w", err)
+			}
+		}
+		if _, err := db.DeleteByBean(ctx, &asymkey_model.GPGKey{OwnerID: u.ID}); err != nil {
+			return fmt.Errorf("deleteGPGKeys: %w", err)
+		}
+
+		_, err = user_model.InsertEmailAddress(ctx, &user_model.EmailAddress{
+			UID:         u.ID,
+			Email:       email,
+			IsActivated: true,
+			IsPrimary:   true,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return system_model.CreateNotice(ctx, system_model.NoticeOffboarding, "%s anonymized the user %d", doer.Name, u.ID)
+}
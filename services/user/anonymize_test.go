@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"testing"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymizeUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Service.NoReplyAddress, "noreply.localhost")()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+	comments := unittest.GetCount(t, &issues_model.Comment{PosterID: user10.ID})
+
+	assert.ErrorIs(t, AnonymizeUser(t.Context(), admin, admin), util.ErrInvalidArgument)
+	assert.ErrorIs(t, AnonymizeUser(t.Context(), admin, org3), util.ErrInvalidArgument)
+
+	require.NoError(t, db.Insert(t.Context(), &asymkey_model.GPGKey{OwnerID: user10.ID, KeyID: "0123456789ABCDEF"}))
+	require.NoError(t, db.Insert(t.Context(), &asymkey_model.GPGKeyImport{KeyID: "0123456789ABCDEF", Content: "armored key of user10"}))
+
+	require.NoError(t, AnonymizeUser(t.Context(), admin, user10))
+
+	user10 = unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+	assert.Equal(t, "deleted-user-10", user10.Name)
+	assert.Equal(t, "deleted-user-10@noreply.localhost", user10.Email)
+	assert.Empty(t, user10.FullName)
+	assert.Empty(t, user10.Passwd)
+	assert.False(t, user10.IsActive)
+
+	emails, err := user_model.GetEmailAddresses(t.Context(), user10.ID)
+	require.NoError(t, err)
+	if assert.Len(t, emails, 1) {
+		assert.Equal(t, user10.Email, emails[0].Email)
+	}
+	unittest.AssertNotExistsBean(t, &user_model.Redirect{RedirectUserID: user10.ID})
+	// the GPG keys contain the names and the email addresses of the user
+	unittest.AssertNotExistsBean(t, &asymkey_model.GPGKey{OwnerID: user10.ID})
+	_, err = asymkey_model.GetGPGImportByKeyID(t.Context(), "0123456789ABCDEF")
+	assert.True(t, asymkey_model.IsErrGPGKeyImportNotExist(err))
+	_, err = user_model.GetUserByName(t.Context(), "user10")
+	assert.True(t, user_model.IsErrUserNotExist(err))
+
+	// the comments of the user are kept
+	assert.Equal(t, comments, unittest.GetCount(t, &issues_model.Comment{PosterID: user10.ID}))
+}
@@ -15,10 +15,15 @@ import (
 	"code.gitea.io/gitea/modules/avatar"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/storage"
+	malwarescan_service "code.gitea.io/gitea/services/malwarescan"
 )
 
 // UploadAvatar saves custom avatar for user.
 func UploadAvatar(ctx context.Context, u *user_model.User, data []byte) error {
+	if err := malwarescan_service.ScanUserAvatar(ctx, u, data); err != nil {
+		return err
+	}
+
 	avatarData, err := avatar.ProcessAvatarImage(data)
 	if err != nil {
 		return err
@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	pull_model "code.gitea.io/gitea/models/pull"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
@@ -95,6 +96,14 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&user_model.Blocking{BlockerID: u.ID},
 		&user_model.Blocking{BlockeeID: u.ID},
 		&actions_model.ActionRunnerToken{OwnerID: u.ID},
+		&issues_model.IssueSavedSearch{OwnerID: u.ID},
+		&activities_model.WebPushSubscription{UserID: u.ID},
+		&activities_model.EmailDigestItem{UserID: u.ID},
+		&activities_model.FederatedFollower{UserID: u.ID},
+		&user_model.FederatedUser{UserID: u.ID},
+		&user_model.TermsAcceptance{UserID: u.ID},
+		&issues_model.HeldContent{PosterID: u.ID},
+		&quota_model.Limit{OwnerID: u.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
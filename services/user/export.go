@@ -0,0 +1,306 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/storage"
+
+	"xorm.io/builder"
+)
+
+type exportedProfile struct {
+	ID               int64     `json:"id"`
+	Username         string    `json:"username"`
+	FullName         string    `json:"full_name"`
+	Email            string    `json:"email"`
+	KeepEmailPrivate bool      `json:"keep_email_private"`
+	Website          string    `json:"website"`
+	Location         string    `json:"location"`
+	Description      string    `json:"description"`
+	Language         string    `json:"language"`
+	Visibility       string    `json:"visibility"`
+	Created          time.Time `json:"created"`
+	LastLogin        time.Time `json:"last_login"`
+}
+
+type exportedEmail struct {
+	Email       string `json:"email"`
+	IsActivated bool   `json:"is_activated"`
+	IsPrimary   bool   `json:"is_primary"`
+}
+
+type exportedIssue struct {
+	Repository string    `json:"repository"`
+	Index      int64     `json:"index"`
+	IsPull     bool      `json:"is_pull"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	IsClosed   bool      `json:"is_closed"`
+	Created    time.Time `json:"created"`
+	Updated    time.Time `json:"updated"`
+}
+
+type exportedComment struct {
+	Repository string    `json:"repository"`
+	IssueIndex int64     `json:"issue_index"`
+	Type       string    `json:"type"`
+	Body       string    `json:"body"`
+	Created    time.Time `json:"created"`
+	Updated    time.Time `json:"updated"`
+}
+
+type exportedActivity struct {
+	Type       string    `json:"type"`
+	Repository string    `json:"repository"`
+	RefName    string    `json:"ref_name,omitempty"`
+	Content    string    `json:"content,omitempty"`
+	Created    time.Time `json:"created"`
+}
+
+type exportedAttachment struct {
+	UUID       string    `json:"uuid"`
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	Repository string    `json:"repository"`
+	Path       string    `json:"path,omitempty"`
+	Created    time.Time `json:"created"`
+}
+
+// userDataExporter writes the documents of the export and remembers the repositories and the issues they refer to
+type userDataExporter struct {
+	u      *user_model.User
+	zw     *zip.Writer
+	repos  map[int64]string
+	issues map[int64]*issues_model.Issue
+}
+
+// ExportUserData writes the data of the user as zip archive. The profile, the email addresses, the issues and pull
+// requests, the comments and the activity are exported as JSON documents, the uploaded attachments are included as
+// they are.
+func ExportUserData(ctx context.Context, u *user_model.User, w io.Writer) error {
+	e := &userDataExporter{
+		u:      u,
+		zw:     zip.NewWriter(w),
+		repos:  make(map[int64]string),
+		issues: make(map[int64]*issues_model.Issue),
+	}
+	for _, export := range []func(context.Context) error{
+		e.exportProfile,
+		e.exportEmails,
+		e.exportIssues,
+		e.exportComments,
+		e.exportActivity,
+		e.exportAttachments,
+	} {
+		if err := export(ctx); err != nil {
+			return err
+		}
+	}
+	return e.zw.Close()
+}
+
+func (e *userDataExporter) writeJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := e.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// repoName returns the full name of the repository, it's empty if the repository has been deleted
+func (e *userDataExporter) repoName(ctx context.Context, repoID int64) (string, error) {
+	if name, ok := e.repos[repoID]; ok {
+		return name, nil
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil && !repo_model.IsErrRepoNotExist(err) {
+		return "", err
+	}
+	if repo != nil {
+		e.repos[repoID] = repo.FullName()
+	}
+	return e.repos[repoID], nil
+}
+
+func (e *userDataExporter) issue(ctx context.Context, issueID int64) (*issues_model.Issue, error) {
+	if issue, ok := e.issues[issueID]; ok {
+		return issue, nil
+	}
+	issue, err := issues_model.GetIssueByID(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	e.issues[issueID] = issue
+	return issue, nil
+}
+
+func (e *userDataExporter) exportProfile(_ context.Context) error {
+	return e.writeJSON("profile.json", &exportedProfile{
+		ID:               e.u.ID,
+		Username:         e.u.Name,
+		FullName:         e.u.FullName,
+		Email:            e.u.Email,
+		KeepEmailPrivate: e.u.KeepEmailPrivate,
+		Website:          e.u.Website,
+		Location:         e.u.Location,
+		Description:      e.u.Description,
+		Language:         e.u.Language,
+		Visibility:       e.u.Visibility.String(),
+		Created:          e.u.CreatedUnix.AsTime(),
+		LastLogin:        e.u.LastLoginUnix.AsTime(),
+	})
+}
+
+func (e *userDataExporter) exportEmails(ctx context.Context) error {
+	emails, err := user_model.GetEmailAddresses(ctx, e.u.ID)
+	if err != nil {
+		return err
+	}
+	exported := make([]*exportedEmail, 0, len(emails))
+	for _, email := range emails {
+		exported = append(exported, &exportedEmail{Email: email.Email, IsActivated: email.IsActivated, IsPrimary: email.IsPrimary})
+	}
+	return e.writeJSON("emails.json", exported)
+}
+
+func (e *userDataExporter) exportIssues(ctx context.Context) error {
+	exported := make([]*exportedIssue, 0, 10)
+	if err := db.Iterate(ctx, builder.Eq{"poster_id": e.u.ID}, func(ctx context.Context, issue *issues_model.Issue) error {
+		repoName, err := e.repoName(ctx, issue.RepoID)
+		if err != nil {
+			return err
+		}
+		exported = append(exported, &exportedIssue{
+			Repository: repoName,
+			Index:      issue.Index,
+			IsPull:     issue.IsPull,
+			Title:      issue.Title,
+			Body:       issue.Content,
+			IsClosed:   issue.IsClosed,
+			Created:    issue.CreatedUnix.AsTime(),
+			Updated:    issue.UpdatedUnix.AsTime(),
+		})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("export issues: %w", err)
+	}
+	return e.writeJSON("issues.json", exported)
+}
+
+func (e *userDataExporter) exportComments(ctx context.Context) error {
+	exported := make([]*exportedComment, 0, 10)
+	if err := db.Iterate(ctx, builder.Eq{"poster_id": e.u.ID}, func(ctx context.Context, comment *issues_model.Comment) error {
+		issue, err := e.issue(ctx, comment.IssueID)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		repoName, err := e.repoName(ctx, issue.RepoID)
+		if err != nil {
+			return err
+		}
+		exported = append(exported, &exportedComment{
+			Repository: repoName,
+			IssueIndex: issue.Index,
+			Type:       comment.Type.String(),
+			Body:       comment.Content,
+			Created:    comment.CreatedUnix.AsTime(),
+			Updated:    comment.UpdatedUnix.AsTime(),
+		})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("export comments: %w", err)
+	}
+	return e.writeJSON("comments.json", exported)
+}
+
+func (e *userDataExporter) exportActivity(ctx context.Context) error {
+	exported := make([]*exportedActivity, 0, 10)
+	// every action is copied to the feeds of the watchers, the copy in the feed of the user is the original one
+	cond := builder.Eq{"act_user_id": e.u.ID, "user_id": e.u.ID, "is_deleted": false}
+	if err := db.Iterate(ctx, cond, func(ctx context.Context, action *activities_model.Action) error {
+		repoName, err := e.repoName(ctx, action.RepoID)
+		if err != nil {
+			return err
+		}
+		exported = append(exported, &exportedActivity{
+			Type:       action.OpType.String(),
+			Repository: repoName,
+			RefName:    action.RefName,
+			Content:    action.Content,
+			Created:    action.CreatedUnix.AsTime(),
+		})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("export activity: %w", err)
+	}
+	return e.writeJSON("activity.json", exported)
+}
+
+func (e *userDataExporter) exportAttachments(ctx context.Context) error {
+	exported := make([]*exportedAttachment, 0, 10)
+	if err := db.Iterate(ctx, builder.Eq{"uploader_id": e.u.ID}, func(ctx context.Context, attach *repo_model.Attachment) error {
+		repoName, err := e.repoName(ctx, attach.RepoID)
+		if err != nil {
+			return err
+		}
+		filePath := path.Join("attachments", attach.UUID, path.Base(attach.Name))
+		if err := e.copyAttachment(attach, filePath); errors.Is(err, os.ErrNotExist) {
+			log.Warn("Attachment %s of user %d is missing in the storage, it's not exported", attach.UUID, e.u.ID)
+			filePath = ""
+		} else if err != nil {
+			return err
+		}
+		exported = append(exported, &exportedAttachment{
+			UUID:       attach.UUID,
+			Name:       attach.Name,
+			Size:       attach.Size,
+			Repository: repoName,
+			Path:       filePath,
+			Created:    attach.CreatedUnix.AsTime(),
+		})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("export attachments: %w", err)
+	}
+	return e.writeJSON("attachments.json", exported)
+}
+
+func (e *userDataExporter) copyAttachment(attach *repo_model.Attachment, filePath string) error {
+	fr, err := storage.Attachments.Open(attach.RelativePath())
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+
+	fw, err := e.zw.CreateHeader(&zip.FileHeader{Name: filePath, Method: zip.Deflate, Modified: attach.CreatedUnix.AsTime()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, fr)
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUserData(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportUserData(t.Context(), user2, &buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+	}
+	for _, name := range []string{"profile.json", "emails.json", "issues.json", "comments.json", "activity.json", "attachments.json"} {
+		assert.Contains(t, files, name)
+	}
+
+	var profile exportedProfile
+	require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+	assert.Equal(t, "user2", profile.Username)
+	assert.Equal(t, user2.Email, profile.Email)
+
+	var issues []*exportedIssue
+	require.NoError(t, json.Unmarshal(files["issues.json"], &issues))
+	assert.Equal(t, unittest.GetCount(t, &issues_model.Issue{PosterID: user2.ID}), len(issues))
+	for _, issue := range issues {
+		if issue.Repository == "user2/repo2" && issue.Index == 1 {
+			assert.Equal(t, "issue4", issue.Title)
+			assert.Equal(t, "content for the fourth issue", issue.Body)
+			assert.True(t, issue.IsClosed)
+			assert.Equal(t, int64(946684830), issue.Created.Unix())
+		}
+	}
+}
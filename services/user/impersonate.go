@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"context"
+
+	org_model "code.gitea.io/gitea/models/organization"
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrImpersonationNotAllowed is returned if the admin may not sign in as the user
+var ErrImpersonationNotAllowed = util.NewPermissionDeniedErrorf("the user can't be impersonated")
+
+// CanImpersonate checks whether the admin may sign in as the user. Admins, organizations, users who can't sign in
+// and members of organizations which have opted out can't be impersonated.
+func CanImpersonate(ctx context.Context, doer, u *user_model.User) error {
+	if !setting.Admin.EnableImpersonation || doer == nil || !doer.IsAdmin {
+		return ErrImpersonationNotAllowed
+	}
+	if u.ID == doer.ID || u.IsAdmin || !u.IsIndividual() || !u.IsActive || u.ProhibitLogin {
+		return ErrImpersonationNotAllowed
+	}
+
+	optedOut, err := org_model.IsMemberOfOrgDisallowingImpersonation(ctx, u.ID)
+	if err != nil {
+		return err
+	} else if optedOut {
+		return ErrImpersonationNotAllowed
+	}
+	return nil
+}
+
+// StartImpersonation checks whether the admin may sign in as the user and records the start of the impersonation
+func StartImpersonation(ctx context.Context, doer, u *user_model.User) error {
+	if err := CanImpersonate(ctx, doer, u); err != nil {
+		return err
+	}
+	return system_model.CreateNotice(ctx, system_model.NoticeImpersonation,
+		"%s started to impersonate %s for %s", doer.Name, u.Name, setting.Admin.ImpersonationDuration)
+}
+
+// StopImpersonation records the end of the impersonation
+func StopImpersonation(ctx context.Context, impersonator, u *user_model.User, expired bool) error {
+	if expired {
+		return system_model.CreateNotice(ctx, system_model.NoticeImpersonation,
+			"The impersonation of %s by %s has expired", u.Name, impersonator.Name)
+	}
+	return system_model.CreateNotice(ctx, system_model.NoticeImpersonation,
+		"%s stopped to impersonate %s", impersonator.Name, u.Name)
+}
+
+// RecordImpersonatedRequest records a request which has been made by the admin while impersonating the user
+func RecordImpersonatedRequest(ctx context.Context, impersonator, u *user_model.User, method, uri string) error {
+	return system_model.CreateNotice(ctx, system_model.NoticeImpersonation,
+		"[impersonated] %s as %s: %s %s", impersonator.Name, u.Name, method, uri)
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanImpersonate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+
+	assert.ErrorIs(t, CanImpersonate(t.Context(), admin, user2), ErrImpersonationNotAllowed)
+
+	defer test.MockVariableValue(&setting.Admin.EnableImpersonation, true)()
+
+	assert.NoError(t, CanImpersonate(t.Context(), admin, user2))
+	assert.ErrorIs(t, CanImpersonate(t.Context(), user2, admin), ErrImpersonationNotAllowed)
+	assert.ErrorIs(t, CanImpersonate(t.Context(), admin, admin), ErrImpersonationNotAllowed)
+	assert.ErrorIs(t, CanImpersonate(t.Context(), admin, org3), ErrImpersonationNotAllowed)
+
+	require.NoError(t, UpdateUser(t.Context(), org3, &UpdateOptions{DisallowImpersonation: optional.Some(true)}))
+	assert.ErrorIs(t, CanImpersonate(t.Context(), admin, user2), ErrImpersonationNotAllowed)
+
+	require.NoError(t, StartImpersonation(t.Context(), admin, unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})))
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/eventsource"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
+	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	issue_service "code.gitea.io/gitea/services/issue"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// OffboardOptions are the options of offboarding a user
+type OffboardOptions struct {
+	// RepoOwner receives the repositories of the user, they are archived if it's nil or if the transfer fails
+	RepoOwner *user_model.User
+	// IssueAssignee replaces the user as assignee of the open issues and pull requests, the user is only
+	// unassigned if it's nil or if it can't be assigned in the repository
+	IssueAssignee *user_model.User
+}
+
+// OffboardResult describes what has been done while offboarding a user
+type OffboardResult struct {
+	TransferredRepos []string
+	ArchivedRepos    []string
+	ReassignedIssues int
+}
+
+// OffboardUser deactivates the user, revokes all the credentials and the sessions, hands over the repositories and the
+// issue assignments and records the offboarding as system notice
+func OffboardUser(ctx context.Context, doer, u *user_model.User, opts OffboardOptions) (*OffboardResult, error) {
+	if !u.IsIndividual() {
+		return nil, util.NewInvalidArgumentErrorf("%s is not an individual user", u.Name)
+	} else if u.ID == doer.ID {
+		return nil, util.NewInvalidArgumentErrorf("the doer can't offboard themself")
+	}
+	if opts.RepoOwner != nil && (opts.RepoOwner.ID == u.ID || !(opts.RepoOwner.IsIndividual() || opts.RepoOwner.IsOrganization())) {
+		return nil, util.NewInvalidArgumentErrorf("%s can't receive the repositories", opts.RepoOwner.Name)
+	}
+	if opts.IssueAssignee != nil && (opts.IssueAssignee.ID == u.ID || !opts.IssueAssignee.IsIndividual()) {
+		return nil, util.NewInvalidArgumentErrorf("%s can't receive the issue assignments", opts.IssueAssignee.Name)
+	}
+
+	if err := revokeUserAccess(ctx, u); err != nil {
+		return nil, err
+	}
+
+	result := &OffboardResult{}
+	if err := handOverRepositories(ctx, doer, u, opts.RepoOwner, result); err != nil {
+		return nil, err
+	}
+	if err := reassignIssues(ctx, doer, u, opts.IssueAssignee, result); err != nil {
+		return nil, err
+	}
+
+	desc := fmt.Sprintf("%s offboarded %s: %d repositories transferred", doer.Name, u.Name, len(result.TransferredRepos))
+	if opts.RepoOwner != nil {
+		desc += " to " + opts.RepoOwner.Name
+	}
+	desc += fmt.Sprintf(", %d archived", len(result.ArchivedRepos))
+	if len(result.ArchivedRepos) > 0 {
+		desc += " (" + strings.Join(result.ArchivedRepos, ", ") + ")"
+	}
+	desc += fmt.Sprintf(", %d issue assignments reassigned", result.ReassignedIssues)
+	if opts.IssueAssignee != nil {
+		desc += " to " + opts.IssueAssignee.Name
+	}
+	return result, system_model.CreateNotice(ctx, system_model.NoticeOffboarding, desc)
+}
+
+// revokeUserAccess deactivates the user and deletes everything the user could sign in or authenticate requests with
+func revokeUserAccess(ctx context.Context, u *user_model.User) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		u.IsActive = false
+		u.IsAdmin = false
+		u.ProhibitLogin = true
+		if err := user_model.UpdateUserCols(ctx, u, "is_active", "is_admin", "prohibit_login"); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByBean(ctx, &auth_model.AccessToken{UID: u.ID}); err != nil {
+			return fmt.Errorf("deleteAccessTokens: %w", err)
+		}
+		if err := auth_model.DeleteOAuth2RelictsByUserID(ctx, u.ID); err != nil {
+			return err
+		}
+		if err := auth_model.DeleteAuthTokensByUserID(ctx, u.ID); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByBean(ctx, &asymkey_model.PublicKey{OwnerID: u.ID}); err != nil {
+			return fmt.Errorf("deletePublicKeys: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// the sessions of inactive users are rejected, this makes the open pages notice it immediately
+	eventsource.GetManager().SendMessage(u.ID, &eventsource.Event{
+		Name: "logout",
+	})
+
+	if err := asymkey_service.RewriteAllPublicKeys(ctx); err != nil {
+		return err
+	}
+	return asymkey_service.RewriteAllPrincipalKeys(ctx)
+}
+
+// handOverRepositories transfers the repositories of the user to the new owner, a repository which can't be
+// transferred is archived instead
+func handOverRepositories(ctx context.Context, doer, u, newOwner *user_model.User, result *OffboardResult) error {
+	var repos repo_model.RepositoryList
+	for page := 1; ; page++ {
+		pageRepos, _, err := repo_model.GetUserRepositories(ctx, repo_model.SearchRepoOptions{
+			ListOptions: db.ListOptions{PageSize: repo_model.RepositoryListDefaultPageSize, Page: page},
+			Private:     true,
+			Actor:       u,
+			OrderBy:     db.SearchOrderByAlphabetically,
+		})
+		if err != nil {
+			return fmt.Errorf("GetUserRepositories: %w", err)
+		}
+		repos = append(repos, pageRepos...)
+		if len(pageRepos) < repo_model.RepositoryListDefaultPageSize {
+			break
+		}
+	}
+
+	for _, repo := range repos {
+		repo.Owner = u
+		fullName := repo.FullName()
+		if newOwner != nil {
+			err := repo_service.StartRepositoryTransfer(ctx, doer, newOwner, repo, nil)
+			if err == nil {
+				result.TransferredRepos = append(result.TransferredRepos, fullName)
+				continue
+			}
+			log.Warn("Unable to transfer %s to %s while offboarding, archiving it instead: %v", fullName, newOwner.Name, err)
+		}
+
+		if !repo.IsArchived {
+			if err := repo_model.SetArchiveRepoState(ctx, repo, true); err != nil {
+				return fmt.Errorf("archive %s: %w", fullName, err)
+			}
+			if err := actions_service.CleanRepoScheduleTasks(ctx, repo); err != nil {
+				log.Error("CleanRepoScheduleTasks for archived repo %s: %v", fullName, err)
+			}
+		}
+		result.ArchivedRepos = append(result.ArchivedRepos, fullName)
+	}
+	return nil
+}
+
+// reassignIssues replaces the user as assignee of the open issues and pull requests
+func reassignIssues(ctx context.Context, doer, u, assignee *user_model.User, result *OffboardResult) error {
+	issues, _, err := issues_model.GetAssignedIssues(ctx, &issues_model.AssignedIssuesOptions{AssigneeID: u.ID})
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		if issue.IsClosed {
+			continue
+		}
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		// the assignees are toggled against the loaded ones
+		if err := issue.LoadAssignees(ctx); err != nil {
+			return err
+		}
+
+		if assignee != nil {
+			canBeAssigned, err := access_model.CanBeAssigned(ctx, assignee, issue.Repo, issue.IsPull)
+			if err != nil {
+				return err
+			}
+			isAssigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, assignee)
+			if err != nil {
+				return err
+			}
+			if canBeAssigned && !isAssigned {
+				if _, _, err := issue_service.ToggleAssigneeWithNotify(ctx, issue, doer, assignee.ID); err != nil {
+					return err
+				}
+				result.ReassignedIssues++
+			}
+		}
+
+		if _, _, err := issue_service.ToggleAssigneeWithNotify(ctx, issue, doer, u.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"testing"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffboardUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+
+	_, err := OffboardUser(t.Context(), admin, admin, OffboardOptions{})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = OffboardUser(t.Context(), admin, org3, OffboardOptions{})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = OffboardUser(t.Context(), admin, user10, OffboardOptions{RepoOwner: user10})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = OffboardUser(t.Context(), admin, user10, OffboardOptions{IssueAssignee: org3})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, auth_model.NewAccessToken(t.Context(), &auth_model.AccessToken{UID: user10.ID, Name: "offboard"}))
+
+	result, err := OffboardUser(t.Context(), admin, user10, OffboardOptions{IssueAssignee: user2})
+	require.NoError(t, err)
+	assert.Empty(t, result.TransferredRepos)
+	assert.Equal(t, []string{"user10/repo6", "user10/repo7", "user10/repo8"}, result.ArchivedRepos)
+	// user2 has already been assigned to the only open issue of user10
+	assert.Zero(t, result.ReassignedIssues)
+
+	user10 = unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+	assert.False(t, user10.IsActive)
+	assert.True(t, user10.ProhibitLogin)
+	unittest.AssertNotExistsBean(t, &auth_model.AccessToken{UID: user10.ID})
+	unittest.AssertNotExistsBean(t, &asymkey_model.PublicKey{OwnerID: user10.ID})
+	for _, id := range []int64{6, 7, 8} {
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: id}).IsArchived)
+	}
+	unittest.AssertNotExistsBean(t, &issues_model.IssueAssignees{IssueID: 6, AssigneeID: user10.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: 6, AssigneeID: user2.ID})
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeOffboarding})
+}
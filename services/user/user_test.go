@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
@@ -49,8 +50,10 @@ func TestDeleteUser(t *testing.T) {
 				return
 			}
 		}
+		assert.NoError(t, quota_model.SetLimit(t.Context(), &quota_model.Limit{OwnerID: userID, TotalSize: 1024}))
 		assert.NoError(t, DeleteUser(t.Context(), user, false))
 		unittest.AssertNotExistsBean(t, &user_model.User{ID: userID})
+		unittest.AssertNotExistsBean(t, &quota_model.Limit{OwnerID: userID})
 		unittest.CheckConsistencyFor(t, &user_model.User{}, &repo_model.Repository{})
 	}
 	test(2)
@@ -5,9 +5,12 @@ package versioned_migration
 
 import (
 	"context"
+	"sync"
 
 	"code.gitea.io/gitea/models/migrations"
 	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
 
 	"xorm.io/xorm"
 )
@@ -22,3 +25,28 @@ func Migrate(ctx context.Context, x *xorm.Engine) error {
 
 	return migrations.Migrate(ctx, x)
 }
+
+var startBackfillsOnce sync.Once
+
+// StartBackfills runs the backfills of the migrations in the background until they are done or the server shuts down,
+// the instances sharing the database run different backfills at the same time
+func StartBackfills(x *xorm.Engine) {
+	startBackfillsOnce.Do(func() {
+		go graceful.GetManager().RunWithShutdownContext(func(ctx context.Context) {
+			if err := migrations.RunBackfills(ctx, x); err != nil {
+				log.Error("Failed to run the backfills of the migrations: %v", err)
+			}
+		})
+	})
+}
+
+// Contract runs the contract steps of the migrations, all the instances must run the current version
+func Contract(ctx context.Context, x *xorm.Engine) error {
+	release, err := globallock.Lock(ctx, "gitea_versioned_migration")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return migrations.RunContracts(ctx, x)
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// Message broker webhooks (Kafka and NATS) don't send HTTP requests. Their requesters create a request
+// to a "kafka://" or "nats://" URL which is handled by a round tripper registered for the scheme in the
+// webhook HTTP client, so they share the delivery history, retries and signatures of the other webhooks.
+// The body is published as message value and the headers (except Authorization) as message headers.
+
+const (
+	// brokerPartitionKeyHeader is the header containing the message key used for partitioning
+	brokerPartitionKeyHeader = "X-Gitea-Partition-Key"
+
+	BrokerPartitionKeyRepository = "repository"
+	BrokerPartitionKeyOwner      = "owner"
+	BrokerPartitionKeyNone       = "none"
+)
+
+// IsBrokerHookType returns true if the webhook type publishes to a message broker instead of sending HTTP requests
+func IsBrokerHookType(hookType webhook_module.HookType) bool {
+	return hookType == webhook_module.KAFKA || hookType == webhook_module.NATS
+}
+
+// IsValidBrokerURL checks if the URL is a valid broker URL for the webhook type like "kafka://kafka-1:9092,kafka-2:9092"
+func IsValidBrokerURL(hookType webhook_module.HookType, brokerURL string) bool {
+	u, err := url.Parse(brokerURL)
+	if err != nil || u.Scheme != hookType || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		return false
+	}
+	return len(brokerAddrs(u)) > 0
+}
+
+// brokerAddrs returns the comma separated "host:port" addresses of a broker URL
+func brokerAddrs(u *url.URL) []string {
+	var addrs []string
+	for addr := range strings.SplitSeq(u.Host, ",") {
+		addr = strings.TrimSpace(addr)
+		if _, port, err := net.SplitHostPort(addr); err != nil || port == "" {
+			return nil
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// newBrokerRequest creates the request publishing the payload of the hook task to the topic or subject of the broker URL
+func newBrokerRequest(w *webhook_model.Webhook, t *webhook_model.HookTask, destination, partitionKey string, query url.Values) (*http.Request, []byte, error) {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid broker URL: %w", err)
+	}
+	u.Path = "/" + destination
+	u.RawQuery = query.Encode()
+
+	body := []byte(t.PayloadContent)
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(t.PayloadContent))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	key, err := getBrokerPartitionKey(partitionKey, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if key != "" {
+		req.Header.Set(brokerPartitionKeyHeader, key)
+	}
+	return req, body, addDefaultHeaders(req, []byte(w.Secret), w, t, body)
+}
+
+// getBrokerPartitionKey returns the ID of the repository or the owner the payload belongs to
+func getBrokerPartitionKey(partitionKey string, payload []byte) (string, error) {
+	if partitionKey == "" || partitionKey == BrokerPartitionKeyNone {
+		return "", nil
+	}
+
+	var p struct {
+		Repository *struct {
+			ID    int64 `json:"id"`
+			Owner *struct {
+				ID int64 `json:"id"`
+			} `json:"owner"`
+		} `json:"repository"`
+		Organization *struct {
+			ID int64 `json:"id"`
+		} `json:"organization"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", fmt.Errorf("getBrokerPartitionKey: %w", err)
+	}
+
+	var id int64
+	switch partitionKey {
+	case BrokerPartitionKeyRepository:
+		if p.Repository != nil {
+			id = p.Repository.ID
+		}
+	case BrokerPartitionKeyOwner:
+		if p.Repository != nil && p.Repository.Owner != nil {
+			id = p.Repository.Owner.ID
+		} else if p.Organization != nil {
+			id = p.Organization.ID
+		}
+	default:
+		return "", fmt.Errorf("unknown partition key %q", partitionKey)
+	}
+	if id == 0 {
+		// payloads without a repository (e.g. packages of users) are distributed over all partitions
+		return "", nil
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// brokerMessageHeaders returns the headers of the request which are published with the message
+func brokerMessageHeaders(req *http.Request) http.Header {
+	headers := req.Header.Clone()
+	headers.Del("Authorization")
+	return headers
+}
+
+// brokerCredentials returns the credentials of the Authorization header of the webhook,
+// "Basic" is used as username and password, "Bearer" as token
+func brokerCredentials(req *http.Request) (username, password, token string) {
+	authorization := req.Header.Get("Authorization")
+	if username, password, ok := req.BasicAuth(); ok {
+		return username, password, ""
+	}
+	if scheme, value, ok := strings.Cut(authorization, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return "", "", strings.TrimSpace(value)
+	}
+	return "", "", ""
+}
+
+// newBrokerResponse creates the response recorded in the delivery history after the message has been published
+func newBrokerResponse(req *http.Request, result any) (*http.Response, error) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(string(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// registerBrokerProtocols registers the round trippers publishing to the message brokers in the transport of the webhook HTTP client
+func registerBrokerProtocols(transport *http.Transport, dialContext func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	transport.RegisterProtocol(webhook_module.KAFKA, &kafkaRoundTripper{dialContext: dialContext})
+	transport.RegisterProtocol(webhook_module.NATS, &natsRoundTripper{dialContext: dialContext})
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidBrokerURL(t *testing.T) {
+	cases := []struct {
+		HookType webhook_module.HookType
+		URL      string
+		Valid    bool
+	}{
+		{webhook_module.KAFKA, "kafka://kafka-1:9092", true},
+		{webhook_module.KAFKA, "kafka://kafka-1:9092,kafka-2:9092/", true},
+		{webhook_module.NATS, "nats://127.0.0.1:4222,nats-2:4222", true},
+		{webhook_module.KAFKA, "nats://kafka-1:9092", false},
+		{webhook_module.KAFKA, "kafka://kafka-1", false},
+		{webhook_module.KAFKA, "kafka://kafka-1:9092,", false},
+		{webhook_module.KAFKA, "kafka://kafka-1:9092/topic", false},
+		{webhook_module.NATS, "nats://nats:4222?jetstream=true", false},
+		{webhook_module.NATS, "http://nats:4222", false},
+		{webhook_module.NATS, "", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Valid, IsValidBrokerURL(c.HookType, c.URL), "%s %s", c.HookType, c.URL)
+	}
+}
+
+func TestBrokerMetaValidate(t *testing.T) {
+	assert.NoError(t, (&KafkaMeta{Topic: "gitea.events_1-2", PartitionKey: "repository", RequiredAcks: "all"}).Validate())
+	assert.Error(t, (&KafkaMeta{Topic: "gitea events", PartitionKey: "repository", RequiredAcks: "all"}).Validate())
+	assert.Error(t, (&KafkaMeta{Topic: "..", PartitionKey: "repository", RequiredAcks: "all"}).Validate())
+	assert.Error(t, (&KafkaMeta{Topic: "gitea", PartitionKey: "issue", RequiredAcks: "all"}).Validate())
+	assert.Error(t, (&KafkaMeta{Topic: "gitea", PartitionKey: "none", RequiredAcks: "some"}).Validate())
+
+	assert.NoError(t, (&NatsMeta{Subject: "gitea.events", PartitionKey: "owner"}).Validate())
+	assert.Error(t, (&NatsMeta{Subject: "gitea.>", PartitionKey: "none"}).Validate())
+	assert.Error(t, (&NatsMeta{Subject: "gitea..events", PartitionKey: "none"}).Validate())
+	assert.Error(t, (&NatsMeta{Subject: "gitea.*.events", PartitionKey: "none"}).Validate())
+	assert.Error(t, (&NatsMeta{Subject: "gitea", PartitionKey: ""}).Validate())
+}
+
+func TestGetBrokerPartitionKey(t *testing.T) {
+	p := pushTestPayload()
+	p.Repo.ID = 1234567890
+	p.Repo.Owner = &api.User{ID: 42}
+	payload, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	key, err := getBrokerPartitionKey(BrokerPartitionKeyRepository, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", key)
+
+	key, err = getBrokerPartitionKey(BrokerPartitionKeyOwner, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "42", key)
+
+	key, err = getBrokerPartitionKey(BrokerPartitionKeyNone, payload)
+	require.NoError(t, err)
+	assert.Empty(t, key)
+
+	// payloads without a repository are not assigned to a partition
+	key, err = getBrokerPartitionKey(BrokerPartitionKeyRepository, []byte(`{"action":"created"}`))
+	require.NoError(t, err)
+	assert.Empty(t, key)
+
+	_, err = getBrokerPartitionKey("issue", payload)
+	assert.Error(t, err)
+}
+
+func TestBrokerRequests(t *testing.T) {
+	p := pushTestPayload()
+	p.Repo.ID = 7
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+	task := &webhook_model.HookTask{
+		UUID:           "delivery-uuid",
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	newHook := func(hookType webhook_module.HookType, url string, meta any) *webhook_model.Webhook {
+		m, err := json.Marshal(meta)
+		require.NoError(t, err)
+		return &webhook_model.Webhook{
+			RepoID:   3,
+			IsActive: true,
+			Type:     hookType,
+			URL:      url,
+			Meta:     string(m),
+			Secret:   "secret",
+		}
+	}
+
+	t.Run("Kafka", func(t *testing.T) {
+		hook := newHook(webhook_module.KAFKA, "kafka://kafka-1:9092,kafka-2:9092", &KafkaMeta{Topic: "gitea.events", PartitionKey: "repository", RequiredAcks: "leader", TLS: true})
+		req, body, err := newKafkaRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+
+		assert.Equal(t, "kafka://kafka-1:9092,kafka-2:9092/gitea.events?acks=leader&tls=true", req.URL.String())
+		assert.Equal(t, data, body)
+		assert.Equal(t, "7", req.Header.Get("X-Gitea-Partition-Key"))
+		assert.Equal(t, "delivery-uuid", req.Header.Get("X-Gitea-Delivery"))
+		assert.Equal(t, "push", req.Header.Get("X-Gitea-Event"))
+		assert.NotEmpty(t, req.Header.Get("X-Gitea-Signature"))
+		reqBody, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, data, reqBody)
+
+		hook = newHook(webhook_module.KAFKA, "kafka://kafka-1:9092", &KafkaMeta{Topic: "gitea events", PartitionKey: "repository", RequiredAcks: "all"})
+		_, _, err = newKafkaRequest(t.Context(), hook, task)
+		assert.Error(t, err)
+	})
+
+	t.Run("Nats", func(t *testing.T) {
+		hook := newHook(webhook_module.NATS, "nats://nats:4222", &NatsMeta{Subject: "gitea.events", PartitionKey: "repository", JetStream: true})
+		req, body, err := newNatsRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+
+		assert.Equal(t, "nats://nats:4222/gitea.events.7?jetstream=true", req.URL.String())
+		assert.Equal(t, data, body)
+		assert.Equal(t, "delivery-uuid", req.Header.Get("X-Gitea-Delivery"))
+
+		hook = newHook(webhook_module.NATS, "nats://nats:4222", &NatsMeta{Subject: "gitea.events", PartitionKey: "none"})
+		req, _, err = newNatsRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+		assert.Equal(t, "nats://nats:4222/gitea.events", req.URL.String())
+		assert.Empty(t, req.Header.Get("X-Gitea-Partition-Key"))
+	})
+}
+
+func TestBrokerCredentials(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "nats://nats:4222/gitea", nil)
+	require.NoError(t, err)
+
+	username, password, token := brokerCredentials(req)
+	assert.Empty(t, username+password+token)
+
+	req.SetBasicAuth("gitea", "secret")
+	username, password, token = brokerCredentials(req)
+	assert.Equal(t, "gitea", username)
+	assert.Equal(t, "secret", password)
+	assert.Empty(t, token)
+
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	username, password, token = brokerCredentials(req)
+	assert.Empty(t, username+password)
+	assert.Equal(t, "s3cr3t", token)
+	assert.Empty(t, brokerMessageHeaders(req).Get("Authorization"))
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"text/template"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	customDefaultContentType = "application/json"
+	customMaxBodySize        = 1024 * 1024
+)
+
+// CustomMeta contains the templates of a custom webhook which are rendered for every delivery
+type CustomMeta struct {
+	ContentType  string `json:"content_type"`
+	Headers      string `json:"headers"` // one "Name: value template" per line
+	BodyTemplate string `json:"body_template"`
+}
+
+// CustomTemplateContext is the data the templates of a custom webhook are rendered with
+type CustomTemplateContext struct {
+	Event     string         // the event like "pull_request"
+	EventType string         // the detailed event type like "pull_request_review_approved"
+	Delivery  string         // the UUID of the delivery
+	Payload   map[string]any // the payload the Gitea webhook type would send
+}
+
+type customHeader struct {
+	Name  string
+	Value *template.Template
+}
+
+type customTemplates struct {
+	ContentType string
+	Headers     []customHeader
+	Body        *template.Template
+}
+
+var customTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"join": func(sep string, values []any) string {
+		s := make([]string, 0, len(values))
+		for _, v := range values {
+			s = append(s, fmt.Sprint(v))
+		}
+		return strings.Join(s, sep)
+	},
+	"truncate": func(length int, s string) string {
+		if r := []rune(s); len(r) > length {
+			return string(r[:length])
+		}
+		return s
+	},
+	"default": func(def, v any) any {
+		if v == nil || v == "" {
+			return def
+		}
+		return v
+	},
+}
+
+// GetCustomHook returns the custom webhook metadata
+func GetCustomHook(w *webhook_model.Webhook) *CustomMeta {
+	s := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetCustomHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// Validate checks if the templates of the custom webhook can be parsed
+func (m *CustomMeta) Validate() error {
+	_, err := m.parse()
+	return err
+}
+
+func (m *CustomMeta) parse() (*customTemplates, error) {
+	t := &customTemplates{
+		ContentType: strings.TrimSpace(m.ContentType),
+	}
+	if t.ContentType == "" {
+		t.ContentType = customDefaultContentType
+	}
+	if strings.ContainsAny(t.ContentType, "\r\n") {
+		return nil, errors.New("the content type must be a single line")
+	}
+
+	for line := range strings.SplitSeq(m.Headers, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !ok || !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid header %q, headers must be like \"Name: value\"", line)
+		}
+		tmpl, err := template.New(name).Funcs(customTemplateFuncs).Parse(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		t.Headers = append(t.Headers, customHeader{Name: name, Value: tmpl})
+	}
+
+	var err error
+	t.Body, err = template.New("body").Funcs(customTemplateFuncs).Parse(m.BodyTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// normalizeJSONNumbers converts the whole numbers of the decoded payload to integers,
+// otherwise ids would be rendered in the exponent format of float64
+func normalizeJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeJSONNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeJSONNumbers(e)
+		}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	}
+	return v
+}
+
+func newCustomTemplateContext(t *webhook_model.HookTask) (*CustomTemplateContext, error) {
+	payload := map[string]any{}
+	if err := json.Unmarshal([]byte(t.PayloadContent), &payload); err != nil {
+		return nil, fmt.Errorf("unable to decode the payload: %w", err)
+	}
+	normalizeJSONNumbers(payload)
+
+	return &CustomTemplateContext{
+		Event:     t.EventType.Event(),
+		EventType: string(t.EventType),
+		Delivery:  t.UUID,
+		Payload:   payload,
+	}, nil
+}
+
+func renderCustomTemplate(tmpl *template.Template, data *CustomTemplateContext) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	if buf.Len() > customMaxBodySize {
+		return "", fmt.Errorf("the rendered %s is larger than %d bytes", tmpl.Name(), customMaxBodySize)
+	}
+	return buf.String(), nil
+}
+
+func newCustomRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest meta json: %w", err)
+	}
+	templates, err := meta.parse()
+	if err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest templates: %w", err)
+	}
+
+	data, err := newCustomTemplateContext(t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := renderCustomTemplate(templates.Body, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest render body: %w", err)
+	}
+
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, w.URL, strings.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", templates.ContentType)
+
+	if err := addDefaultHeaders(req, []byte(w.Secret), w, t, []byte(body)); err != nil {
+		return nil, nil, err
+	}
+
+	// the headers of the templates override the default headers
+	for _, header := range templates.Headers {
+		value, err := renderCustomTemplate(header.Value, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newCustomRequest render header %s: %w", header.Name, err)
+		}
+		value = strings.TrimSpace(value)
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, nil, fmt.Errorf("newCustomRequest rendered header %s has an invalid value", header.Name)
+		}
+		req.Header.Set(header.Name, value)
+	}
+
+	return req, []byte(body), nil
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.CUSTOM, newCustomRequest)
+}
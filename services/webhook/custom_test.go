@@ -0,0 +1,120 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"io"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomMetaValidate(t *testing.T) {
+	cases := []struct {
+		Meta  CustomMeta
+		Valid bool
+	}{
+		{CustomMeta{BodyTemplate: `{{json .Payload}}`}, true},
+		{CustomMeta{ContentType: "text/plain", Headers: "X-Event: {{.Event}}\n\nX-Static: value", BodyTemplate: `{{.Payload.ref}}`}, true},
+		{CustomMeta{BodyTemplate: `{{.Payload`}, false},
+		{CustomMeta{Headers: "X-Event {{.Event}}"}, false},
+		{CustomMeta{Headers: "Invalid Name: value"}, false},
+		{CustomMeta{Headers: "X-Event: {{.Event"}, false},
+		{CustomMeta{ContentType: "text/plain\nX-Injected: 1"}, false},
+	}
+
+	for _, c := range cases {
+		err := c.Meta.Validate()
+		if c.Valid {
+			assert.NoError(t, err, "%+v", c.Meta)
+		} else {
+			assert.Error(t, err, "%+v", c.Meta)
+		}
+	}
+}
+
+func TestCustomJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	p.Repo.ID = 1234567890 // large ids must not be rendered in the exponent format
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	newHook := func(meta CustomMeta) *webhook_model.Webhook {
+		m, err := json.Marshal(meta)
+		require.NoError(t, err)
+		return &webhook_model.Webhook{
+			RepoID:     3,
+			IsActive:   true,
+			Type:       webhook_module.CUSTOM,
+			URL:        "https://custom.example.com/",
+			Meta:       string(m),
+			HTTPMethod: "PUT",
+			Secret:     "secret",
+		}
+	}
+	task := &webhook_model.HookTask{
+		HookID:         3,
+		UUID:           "d2bd6e1c-8a9b-4b5c-9f1e-0a1b2c3d4e5f",
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	t.Run("Templates", func(t *testing.T) {
+		hook := newHook(CustomMeta{
+			ContentType:  "text/plain",
+			Headers:      "X-Event: {{upper .Event}}\nX-Repo-ID: {{.Payload.repository.id}}\nX-Gitea-Event: overridden",
+			BodyTemplate: `{{.Payload.repository.full_name}} {{.Payload.ref}} {{len .Payload.commits}} {{.Delivery}}`,
+		})
+
+		req, reqBody, err := newCustomRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+		require.NotNil(t, req)
+
+		assert.Equal(t, "PUT", req.Method)
+		assert.Equal(t, "https://custom.example.com/", req.URL.String())
+		assert.Equal(t, "text/plain", req.Header.Get("Content-Type"))
+		assert.Equal(t, "PUSH", req.Header.Get("X-Event"))
+		assert.Equal(t, "1234567890", req.Header.Get("X-Repo-ID"))
+		assert.Equal(t, "overridden", req.Header.Get("X-Gitea-Event"))
+		assert.NotEmpty(t, req.Header.Get("X-Gitea-Signature"))
+
+		expected := "test/repo refs/heads/test 2 d2bd6e1c-8a9b-4b5c-9f1e-0a1b2c3d4e5f"
+		assert.Equal(t, expected, string(reqBody))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(body))
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		hook := newHook(CustomMeta{BodyTemplate: `{"text":{{json (printf "%s pushed" .Payload.pusher.login)}},"id":{{json .Payload.repository.id}}}`})
+		hook.HTTPMethod = ""
+
+		req, reqBody, err := newCustomRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"text":"user1 pushed","id":1234567890}`, string(reqBody))
+	})
+
+	t.Run("InvalidHeaderValue", func(t *testing.T) {
+		hook := newHook(CustomMeta{Headers: `X-Injected: {{printf "a\nb"}}`})
+
+		_, _, err := newCustomRequest(t.Context(), hook, task)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		hook := newHook(CustomMeta{BodyTemplate: `{{.Payload`})
+
+		_, _, err := newCustomRequest(t.Context(), hook, task)
+		assert.Error(t, err)
+	})
+}
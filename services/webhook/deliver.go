@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	user_model "code.gitea.io/gitea/models/user"
@@ -144,6 +145,10 @@ func addDefaultHeaders(req *http.Request, secret []byte, w *webhook_model.Webhoo
 	req.Header["X-GitHub-Event"] = []string{event}
 	req.Header["X-GitHub-Event-Type"] = []string{eventType}
 	req.Header["X-GitHub-Hook-Installation-Target-Type"] = []string{targetType}
+	if keyID, signature := signPayload(payloadContent); signature != "" {
+		req.Header.Add("X-Gitea-Signature-Ed25519", signature)
+		req.Header.Add("X-Gitea-Signature-Key-Id", keyID)
+	}
 	return nil
 }
 
@@ -225,6 +230,16 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 			log.Trace("Hook delivery failed: %s", t.UUID)
 		}
 
+		retry := !t.IsSucceed && w.IsActive && !setting.DisableWebhooks
+		if retry && t.Attempt >= setting.Webhook.MaxAttempts {
+			retry = false
+			t.IsDeadLetter = true
+		}
+
+		if retry {
+			scheduleHookTaskRetry(t)
+		}
+
 		if err := webhook_model.UpdateHookTask(ctx, t); err != nil {
 			log.Error("UpdateHookTask [%d]: %v", t.ID, err)
 		}
@@ -250,7 +265,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
-	resp, err := webhookHTTPClient.Do(req.WithContext(ctx))
+	resp, err := webhookHTTPClient.Load().Do(req.WithContext(ctx))
 	if err != nil {
 		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
 		return fmt.Errorf("unable to deliver webhook task[%d] in %s due to error in http client: %w", t.ID, w.URL, err)
@@ -274,7 +289,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 }
 
 var (
-	webhookHTTPClient *http.Client
+	webhookHTTPClient atomic.Pointer[http.Client]
 	once              sync.Once
 	hostMatchers      []glob.Glob
 )
@@ -307,25 +322,46 @@ func webhookProxy(allowList *hostmatcher.HostMatchList) func(req *http.Request)
 	}
 }
 
-// Init starts the hooks delivery thread
-func Init() error {
+// initHTTPClient creates the HTTP client delivering the webhooks with the current settings
+func initHTTPClient() {
 	timeout := time.Duration(setting.Webhook.DeliverTimeout) * time.Second
 
-	allowedHostListValue := setting.Webhook.AllowedHostList
+	allowedHostListValue := setting.GetWebhookAllowedHostList()
 	if allowedHostListValue == "" {
 		allowedHostListValue = hostmatcher.MatchBuiltinExternal
 	}
 	allowedHostMatcher := hostmatcher.ParseHostMatchList("webhook.ALLOWED_HOST_LIST", allowedHostListValue)
 
-	webhookHTTPClient = &http.Client{
-		Timeout: timeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: setting.Webhook.SkipTLSVerify},
-			Proxy:           webhookProxy(allowedHostMatcher),
-			DialContext:     hostmatcher.NewDialContext("webhook", allowedHostMatcher, nil, setting.Webhook.ProxyURLFixed),
-		},
+	dialContext := hostmatcher.NewDialContext("webhook", allowedHostMatcher, nil, setting.Webhook.ProxyURLFixed)
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: setting.Webhook.SkipTLSVerify},
+		Proxy:           webhookProxy(allowedHostMatcher),
+		DialContext:     dialContext,
+	}
+	registerBrokerProtocols(transport, dialContext)
+	old := webhookHTTPClient.Swap(&http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	})
+	if old != nil {
+		old.CloseIdleConnections()
+	}
+}
+
+// ReloadHTTPClient creates the HTTP client again after the allowed hosts have been reloaded,
+// the deliveries in progress keep using the previous client
+func ReloadHTTPClient() {
+	initHTTPClient()
+}
+
+// Init starts the hooks delivery thread
+func Init() error {
+	if err := initSigningKeys(); err != nil {
+		return err
 	}
 
+	initHTTPClient()
+
 	hookQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "webhook_sender", handler)
 	if hookQueue == nil {
 		return errors.New("unable to create webhook_sender queue")
@@ -333,6 +369,7 @@ func Init() error {
 	go graceful.GetManager().RunWithCancel(hookQueue)
 
 	go graceful.GetManager().RunWithShutdownContext(populateWebhookSendingQueue)
+	go graceful.GetManager().RunWithShutdownContext(retryWebhookDeliveries)
 
 	return nil
 }
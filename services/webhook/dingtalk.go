@@ -5,10 +5,15 @@ package webhook
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	webhook_model "code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/git"
@@ -204,9 +209,31 @@ func createDingtalkPayload(title, text, singleTitle, singleURL string) DingtalkP
 	}
 }
 
+// dingtalkGenSign generates the signature of a robot with enabled signing
+// https://open.dingtalk.com/document/orgapp/customize-robot-security-settings
+func dingtalkGenSign(secret string, timestamp int64) string {
+	// "{timestamp}\n{secret}" signed by hmac-sha256 with the secret as key, then base64 encoded
+	h := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(h, "%d\n%s", timestamp, secret)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
 func newDingtalkRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
 	var pc payloadConvertor[DingtalkPayload] = dingtalkConvertor{}
-	return newJSONRequest(pc, w, t, true)
+	req, body, err := newJSONRequest(pc, w, t, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// DingTalk expects the timestamp in milliseconds and the signature as query parameters
+	if w.Secret != "" {
+		timestamp := time.Now().UnixMilli()
+		query := req.URL.Query()
+		query.Set("timestamp", strconv.FormatInt(timestamp, 10))
+		query.Set("sign", dingtalkGenSign(w.Secret, timestamp))
+		req.URL.RawQuery = query.Encode()
+	}
+	return req, body, nil
 }
 
 func init() {
@@ -5,6 +5,7 @@ package webhook
 
 import (
 	"net/url"
+	"strconv"
 	"testing"
 
 	webhook_model "code.gitea.io/gitea/models/webhook"
@@ -248,4 +249,21 @@ func TestDingTalkJSONPayload(t *testing.T) {
 	err = json.NewDecoder(req.Body).Decode(&body)
 	assert.NoError(t, err)
 	assert.Equal(t, "[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1\r\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1", body.ActionCard.Text)
+
+	t.Run("Signed", func(t *testing.T) {
+		hook.URL = "https://dingtalk.example.com/robot/send?access_token=token"
+		hook.Secret = "secret"
+
+		req, _, err := newDingtalkRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+
+		query := req.URL.Query()
+		assert.Equal(t, "token", query.Get("access_token"))
+		timestamp, err := strconv.ParseInt(query.Get("timestamp"), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, dingtalkGenSign(hook.Secret, timestamp), query.Get("sign"))
+	})
+
+	// a separate sign test, the result is generated by the python sample of the documentation
+	assert.Equal(t, "ujTYSVdXqFI993bVwzXyk6uGaF9SIJEU5YA7o+q/wYQ=", dingtalkGenSign("a", 1))
 }
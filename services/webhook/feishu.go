@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,26 +23,130 @@ import (
 type (
 	// FeishuPayload represents the payload for Feishu webhook
 	FeishuPayload struct {
-		Timestamp int64  `json:"timestamp,omitempty"` // Unix timestamp for signature verification
-		Sign      string `json:"sign,omitempty"`      // Signature for verification
-		MsgType   string `json:"msg_type"`            // text / post / image / share_chat / interactive / file /audio / media
-		Content   struct {
-			Text string `json:"text"`
-		} `json:"content"`
+		Timestamp int64          `json:"timestamp,omitempty"` // Unix timestamp for signature verification
+		Sign      string         `json:"sign,omitempty"`      // Signature for verification
+		MsgType   string         `json:"msg_type"`            // text / post / image / share_chat / interactive / file /audio / media
+		Content   *FeishuContent `json:"content,omitempty"`
+		Card      *FeishuCard    `json:"card,omitempty"`
+	}
+
+	// FeishuContent represents the content of a text message
+	FeishuContent struct {
+		Text string `json:"text"`
+	}
+
+	// FeishuCard represents an interactive message card
+	// https://open.feishu.cn/document/common-capabilities/message-card/message-cards-content/card-structure/card-content
+	FeishuCard struct {
+		Config   FeishuCardConfig    `json:"config"`
+		Header   FeishuCardHeader    `json:"header"`
+		Elements []FeishuCardElement `json:"elements"`
+	}
+
+	// FeishuCardConfig represents the config of a card
+	FeishuCardConfig struct {
+		WideScreenMode bool `json:"wide_screen_mode"`
+	}
+
+	// FeishuCardHeader represents the header of a card, the template is the color of the header
+	FeishuCardHeader struct {
+		Title    FeishuCardText `json:"title"`
+		Template string         `json:"template,omitempty"`
+	}
+
+	// FeishuCardText represents a text of a card
+	FeishuCardText struct {
+		Tag     string `json:"tag"`
+		Content string `json:"content"`
+	}
+
+	// FeishuCardElement represents a markdown or action element of a card
+	FeishuCardElement struct {
+		Tag     string             `json:"tag"`
+		Content string             `json:"content,omitempty"`
+		Actions []FeishuCardButton `json:"actions,omitempty"`
+	}
+
+	// FeishuCardButton represents a button linking to a url
+	FeishuCardButton struct {
+		Tag  string         `json:"tag"`
+		Text FeishuCardText `json:"text"`
+		URL  string         `json:"url"`
+		Type string         `json:"type"`
 	}
 )
 
 func newFeishuTextPayload(text string) FeishuPayload {
 	return FeishuPayload{
 		MsgType: "text",
-		Content: struct {
-			Text string `json:"text"`
-		}{
+		Content: &FeishuContent{
 			Text: strings.TrimSpace(text),
 		},
 	}
 }
 
+// newFeishuCardPayload creates an interactive card with the markdown text and a button linking to the url
+func newFeishuCardPayload(title, text string, color int, buttonText, url string) FeishuPayload {
+	elements := make([]FeishuCardElement, 0, 2)
+	if text = strings.TrimSpace(text); text != "" {
+		elements = append(elements, FeishuCardElement{
+			Tag:     "markdown",
+			Content: text,
+		})
+	}
+	elements = append(elements, FeishuCardElement{
+		Tag: "action",
+		Actions: []FeishuCardButton{
+			{
+				Tag:  "button",
+				Text: FeishuCardText{Tag: "plain_text", Content: buttonText},
+				URL:  url,
+				Type: "primary",
+			},
+		},
+	})
+
+	return FeishuPayload{
+		MsgType: "interactive",
+		Card: &FeishuCard{
+			Config: FeishuCardConfig{WideScreenMode: true},
+			Header: FeishuCardHeader{
+				Title:    FeishuCardText{Tag: "plain_text", Content: title},
+				Template: feishuCardTemplate(color),
+			},
+			Elements: elements,
+		},
+	}
+}
+
+// feishuCardTemplate maps the color of an event to one of the header colors of Feishu cards
+func feishuCardTemplate(color int) string {
+	switch color {
+	case greenColor, greenColorLight:
+		return "green"
+	case redColor:
+		return "red"
+	case orangeColor, orangeColorLight:
+		return "orange"
+	case yellowColor:
+		return "yellow"
+	case purpleColor:
+		return "purple"
+	case greyColor:
+		return "grey"
+	}
+	return "blue"
+}
+
+// feishuCardText joins the non-empty info lines and appends the body as separate paragraph
+func feishuCardText(body string, lines ...string) string {
+	text := strings.Join(slices.DeleteFunc(lines, func(line string) bool { return line == "" }), "\n")
+	if body = strings.TrimSpace(body); body != "" {
+		text += "\n\n" + body
+	}
+	return text
+}
+
 type feishuConvertor struct{}
 
 // Create implements PayloadConvertor Create method
@@ -71,57 +176,75 @@ func (fc feishuConvertor) Fork(p *api.ForkPayload) (FeishuPayload, error) {
 
 // Push implements PayloadConvertor Push method
 func (fc feishuConvertor) Push(p *api.PushPayload) (FeishuPayload, error) {
-	var (
-		branchName = git.RefName(p.Ref).ShortName()
-		commitDesc string
-	)
+	branchName := git.RefName(p.Ref).ShortName()
 
-	text := fmt.Sprintf("[%s:%s] %s\r\n", p.Repo.FullName, branchName, commitDesc)
-	// for each commit, generate attachment text
+	count := p.TotalCommits
+	if count == 0 {
+		count = len(p.Commits)
+	}
+	title := fmt.Sprintf("[%s:%s] %d new commit(s)", p.Repo.FullName, branchName, count)
+
+	var text strings.Builder
+	// for each commit, generate a line with the linked short id
 	for i, commit := range p.Commits {
 		var authorName string
 		if commit.Author != nil {
 			authorName = " - " + commit.Author.Name
 		}
-		text += fmt.Sprintf("[%s](%s) %s", commit.ID[:7], commit.URL,
-			strings.TrimRight(commit.Message, "\r\n")) + authorName
+		text.WriteString(fmt.Sprintf("[%s](%s) %s", commit.ID[:7], commit.URL, strings.TrimRight(commit.Message, "\r\n")) + authorName)
 		// add linebreak to each commit but the last
 		if i < len(p.Commits)-1 {
-			text += "\r\n"
+			text.WriteString("\n")
 		}
 	}
 
-	return newFeishuTextPayload(text), nil
+	link := p.CompareURL
+	if link == "" {
+		link = p.Repo.HTMLURL
+	}
+	return newFeishuCardPayload(title, text.String(), greenColor, "View changes", link), nil
 }
 
 // Issue implements PayloadConvertor Issue method
 func (fc feishuConvertor) Issue(p *api.IssuePayload) (FeishuPayload, error) {
-	title, link, by, operator, result, assignees := getIssuesInfo(p)
-	if assignees != "" {
-		if p.Action == api.HookIssueAssigned || p.Action == api.HookIssueUnassigned || p.Action == api.HookIssueMilestoned {
-			return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, result, assignees, p.Issue.Body)), nil
-		}
-		return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, assignees, p.Issue.Body)), nil
+	title, _, _, color := getIssuesPayloadInfo(p, noneLinkFormatter, true)
+	_, _, by, operator, result, assignees := getIssuesInfo(p)
+
+	var body string
+	if p.Action == api.HookIssueOpened || p.Action == api.HookIssueEdited {
+		body = p.Issue.Body
 	}
-	return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, p.Issue.Body)), nil
+	text := feishuCardText(body, by, operator, result, assignees)
+	return newFeishuCardPayload(title, text, color, "View issue", p.Issue.HTMLURL), nil
 }
 
 // IssueComment implements PayloadConvertor IssueComment method
 func (fc feishuConvertor) IssueComment(p *api.IssueCommentPayload) (FeishuPayload, error) {
-	title, link, by, operator := getIssuesCommentInfo(p)
-	return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, p.Comment.Body)), nil
+	title, _, color := getIssueCommentPayloadInfo(p, noneLinkFormatter, true)
+	_, _, by, operator := getIssuesCommentInfo(p)
+
+	buttonText := "View issue"
+	if p.IsPull {
+		buttonText = "View pull request"
+	}
+	return newFeishuCardPayload(title, feishuCardText(p.Comment.Body, by, operator), color, buttonText, p.Comment.HTMLURL), nil
 }
 
 // PullRequest implements PayloadConvertor PullRequest method
 func (fc feishuConvertor) PullRequest(p *api.PullRequestPayload) (FeishuPayload, error) {
-	title, link, by, operator, result, assignees := getPullRequestInfo(p)
-	if assignees != "" {
-		if p.Action == api.HookIssueAssigned || p.Action == api.HookIssueUnassigned || p.Action == api.HookIssueMilestoned {
-			return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, result, assignees, p.PullRequest.Body)), nil
-		}
-		return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, assignees, p.PullRequest.Body)), nil
+	title, _, _, color := getPullRequestPayloadInfo(p, noneLinkFormatter, true)
+	_, _, by, operator, result, assignees := getPullRequestInfo(p)
+
+	var branches string
+	if p.PullRequest.Head != nil && p.PullRequest.Base != nil {
+		branches = fmt.Sprintf("%s → %s", p.PullRequest.Head.Ref, p.PullRequest.Base.Ref)
+	}
+	var body string
+	if p.Action == api.HookIssueOpened || p.Action == api.HookIssueEdited {
+		body = p.PullRequest.Body
 	}
-	return newFeishuTextPayload(fmt.Sprintf("%s\n%s\n%s\n%s\n\n%s", title, link, by, operator, p.PullRequest.Body)), nil
+	text := feishuCardText(body, branches, by, operator, result, assignees)
+	return newFeishuCardPayload(title, text, color, "View pull request", p.PullRequest.HTMLURL), nil
 }
 
 // Review implements PayloadConvertor Review method
@@ -132,9 +255,16 @@ func (fc feishuConvertor) Review(p *api.PullRequestPayload, event webhook_module
 	}
 
 	title := fmt.Sprintf("[%s] Pull request review %s : #%d %s", p.Repository.FullName, action, p.Index, p.PullRequest.Title)
-	text := p.Review.Content
-
-	return newFeishuTextPayload(title + "\r\n\r\n" + text), nil
+	text := feishuCardText(p.Review.Content, "Reviewer: "+p.Sender.UserName)
+
+	color := yellowColor
+	switch event {
+	case webhook_module.HookEventPullRequestReviewApproved:
+		color = greenColor
+	case webhook_module.HookEventPullRequestReviewRejected:
+		color = redColor
+	}
+	return newFeishuCardPayload(title, text, color, "View pull request", p.PullRequest.HTMLURL), nil
 }
 
 // Repository implements PayloadConvertor Repository method
@@ -50,7 +50,16 @@ func TestFeishuPayload(t *testing.T) {
 		pl, err := fc.Push(p)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[test/repo:test] \r\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1\r\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1", pl.Content.Text)
+		assert.Equal(t, "interactive", pl.MsgType)
+		assert.Nil(t, pl.Content)
+		assert.Equal(t, "[test/repo:test] 2 new commit(s)", pl.Card.Header.Title.Content)
+		assert.Equal(t, "green", pl.Card.Header.Template)
+		require.Len(t, pl.Card.Elements, 2)
+		assert.Equal(t, "markdown", pl.Card.Elements[0].Tag)
+		assert.Equal(t, "[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1", pl.Card.Elements[0].Content)
+		assert.Equal(t, "action", pl.Card.Elements[1].Tag)
+		assert.Equal(t, "View changes", pl.Card.Elements[1].Actions[0].Text.Content)
+		assert.Equal(t, "http://localhost:3000/test/repo", pl.Card.Elements[1].Actions[0].URL)
 	})
 
 	t.Run("Issue", func(t *testing.T) {
@@ -60,13 +69,19 @@ func TestFeishuPayload(t *testing.T) {
 		pl, err := fc.Issue(p)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[Issue-test/repo #2]: opened\ncrash\nhttp://localhost:3000/test/repo/issues/2\nIssue by user1\nOperator: user1\nAssignees: user1\n\nissue body", pl.Content.Text)
+		assert.Equal(t, "[test/repo] Issue opened: #2 crash by user1", pl.Card.Header.Title.Content)
+		assert.Equal(t, "orange", pl.Card.Header.Template)
+		assert.Equal(t, "Issue by user1\nOperator: user1\nAssignees: user1\n\nissue body", pl.Card.Elements[0].Content)
+		assert.Equal(t, "View issue", pl.Card.Elements[1].Actions[0].Text.Content)
+		assert.Equal(t, "http://localhost:3000/test/repo/issues/2", pl.Card.Elements[1].Actions[0].URL)
 
 		p.Action = api.HookIssueClosed
 		pl, err = fc.Issue(p)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[Issue-test/repo #2]: closed\ncrash\nhttp://localhost:3000/test/repo/issues/2\nIssue by user1\nOperator: user1\nAssignees: user1\n\nissue body", pl.Content.Text)
+		assert.Equal(t, "[test/repo] Issue closed: #2 crash by user1", pl.Card.Header.Title.Content)
+		assert.Equal(t, "red", pl.Card.Header.Template)
+		assert.Equal(t, "Issue by user1\nOperator: user1\nAssignees: user1", pl.Card.Elements[0].Content)
 	})
 
 	t.Run("IssueComment", func(t *testing.T) {
@@ -75,7 +90,10 @@ func TestFeishuPayload(t *testing.T) {
 		pl, err := fc.IssueComment(p)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[Comment-test/repo #2]: created\ncrash\nhttp://localhost:3000/test/repo/issues/2\nIssue by user1\nOperator: user1\n\nmore info needed", pl.Content.Text)
+		assert.Equal(t, "[test/repo] New comment on issue #2 crash by user1", pl.Card.Header.Title.Content)
+		assert.Equal(t, "Issue by user1\nOperator: user1\n\nmore info needed", pl.Card.Elements[0].Content)
+		assert.Equal(t, "View issue", pl.Card.Elements[1].Actions[0].Text.Content)
+		assert.Equal(t, "http://localhost:3000/test/repo/issues/2#issuecomment-4", pl.Card.Elements[1].Actions[0].URL)
 	})
 
 	t.Run("PullRequest", func(t *testing.T) {
@@ -84,7 +102,11 @@ func TestFeishuPayload(t *testing.T) {
 		pl, err := fc.PullRequest(p)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[PullRequest-test/repo #12]: opened\nFix bug\nhttp://localhost:3000/test/repo/pulls/12\nPullRequest by user1\nOperator: user1\nAssignees: user1\n\nfixes bug #2", pl.Content.Text)
+		assert.Equal(t, "[test/repo] Pull request opened: #12 Fix bug by user1", pl.Card.Header.Title.Content)
+		assert.Equal(t, "green", pl.Card.Header.Template)
+		assert.Equal(t, "PullRequest by user1\nOperator: user1\nAssignees: user1\n\nfixes bug #2", pl.Card.Elements[0].Content)
+		assert.Equal(t, "View pull request", pl.Card.Elements[1].Actions[0].Text.Content)
+		assert.Equal(t, "http://localhost:3000/test/repo/pulls/12", pl.Card.Elements[1].Actions[0].URL)
 	})
 
 	t.Run("PullRequestComment", func(t *testing.T) {
@@ -93,7 +115,9 @@ func TestFeishuPayload(t *testing.T) {
 		pl, err := fc.IssueComment(p)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[Comment-test/repo #12]: created\nFix bug\nhttp://localhost:3000/test/repo/pulls/12\nPullRequest by user1\nOperator: user1\n\nchanges requested", pl.Content.Text)
+		assert.Equal(t, "[test/repo] New comment on pull request #12 Fix bug by user1", pl.Card.Header.Title.Content)
+		assert.Equal(t, "PullRequest by user1\nOperator: user1\n\nchanges requested", pl.Card.Elements[0].Content)
+		assert.Equal(t, "View pull request", pl.Card.Elements[1].Actions[0].Text.Content)
 	})
 
 	t.Run("Review", func(t *testing.T) {
@@ -103,7 +127,10 @@ func TestFeishuPayload(t *testing.T) {
 		pl, err := fc.Review(p, webhook_module.HookEventPullRequestReviewApproved)
 		require.NoError(t, err)
 
-		assert.Equal(t, "[test/repo] Pull request review approved : #12 Fix bug\r\n\r\ngood job", pl.Content.Text)
+		assert.Equal(t, "[test/repo] Pull request review approved : #12 Fix bug", pl.Card.Header.Title.Content)
+		assert.Equal(t, "green", pl.Card.Header.Template)
+		assert.Equal(t, "Reviewer: user1\n\ngood job", pl.Card.Elements[0].Content)
+		assert.Equal(t, "http://localhost:3000/test/repo/pulls/12", pl.Card.Elements[1].Actions[0].URL)
 	})
 
 	t.Run("Repository", func(t *testing.T) {
@@ -188,7 +215,8 @@ func TestFeishuJSONPayload(t *testing.T) {
 	var body FeishuPayload
 	err = json.NewDecoder(req.Body).Decode(&body)
 	assert.NoError(t, err)
-	assert.Equal(t, "[test/repo:test] \r\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1\r\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778) commit message - user1", body.Content.Text)
+	assert.Equal(t, "interactive", body.MsgType)
+	assert.Equal(t, "[test/repo:test] 2 new commit(s)", body.Card.Header.Title.Content)
 	assert.Equal(t, feishuGenSign(hook.Secret, body.Timestamp), body.Sign)
 
 	// a separate sign test, the result is generated by official python code, so the algo must be correct
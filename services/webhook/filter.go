@@ -0,0 +1,134 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// matchFilter reports whether one of the values matches the filter glob pattern.
+// An empty filter or a filter of "*" or "**" matches everything.
+func matchFilter(filter string, values []string, separators ...rune) bool {
+	if filter == "" || filter == "*" || filter == "**" {
+		return true
+	}
+	g, err := glob.Compile(filter, separators...)
+	if err != nil {
+		// should not really happen as the filters are validated
+		log.Debug("matchFilter failed to compile filter %q, err: %s", filter, err)
+		return false
+	}
+	for _, value := range values {
+		if g.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPayloadChangedFiles returns the files touched by the commits of a push payload, ok is false for other payloads.
+func getPayloadChangedFiles(p api.Payloader) (files []string, ok bool) {
+	pp, ok := p.(*api.PushPayload)
+	if !ok {
+		return nil, false
+	}
+	for _, commit := range pp.Commits {
+		files = append(files, commit.Added...)
+		files = append(files, commit.Removed...)
+		files = append(files, commit.Modified...)
+	}
+	return files, true
+}
+
+// getPayloadLabels returns the labels of the issue or pull request of a payload, ok is false for other payloads.
+func getPayloadLabels(p api.Payloader) (labels []*api.Label, ok bool) {
+	switch pp := p.(type) {
+	case *api.IssuePayload:
+		if pp.Issue != nil {
+			return pp.Issue.Labels, true
+		}
+	case *api.IssueCommentPayload:
+		if pp.Issue != nil {
+			return pp.Issue.Labels, true
+		}
+	case *api.PullRequestPayload:
+		if pp.PullRequest != nil {
+			return pp.PullRequest.Labels, true
+		}
+	}
+	return nil, false
+}
+
+func getPayloadSender(p api.Payloader) *api.User {
+	switch pp := p.(type) {
+	case *api.CreatePayload:
+		return pp.Sender
+	case *api.DeletePayload:
+		return pp.Sender
+	case *api.ForkPayload:
+		return pp.Sender
+	case *api.IssueCommentPayload:
+		return pp.Sender
+	case *api.ReleasePayload:
+		return pp.Sender
+	case *api.PushPayload:
+		return pp.Sender
+	case *api.IssuePayload:
+		return pp.Sender
+	case *api.PullRequestPayload:
+		return pp.Sender
+	case *api.WikiPayload:
+		return pp.Sender
+	case *api.RepositoryPayload:
+		return pp.Sender
+	case *api.PackagePayload:
+		return pp.Sender
+	case *api.WorkflowDispatchPayload:
+		return pp.Sender
+	case *api.CommitStatusPayload:
+		return pp.Sender
+	case *api.WorkflowRunPayload:
+		return pp.Sender
+	case *api.WorkflowJobPayload:
+		return pp.Sender
+	}
+	return nil
+}
+
+// checkPathFilter reports whether a push touches at least one file matching the filter,
+// "*" does not match across directories while "**" does.
+// Payloads without changed files (e.g. issues) are not affected by the path filter.
+func checkPathFilter(pathFilter string, p api.Payloader) bool {
+	files, ok := getPayloadChangedFiles(p)
+	if !ok {
+		return true
+	}
+	return matchFilter(pathFilter, files, '/')
+}
+
+// checkLabelFilter reports whether the issue or pull request of a payload has at least one label matching the filter.
+// Payloads without an issue or pull request are not affected by the label filter.
+func checkLabelFilter(labelFilter string, p api.Payloader) bool {
+	labels, ok := getPayloadLabels(p)
+	if !ok {
+		return true
+	}
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return matchFilter(labelFilter, names)
+}
+
+// checkActorFilter reports whether the user who triggered the event matches the filter.
+// Payloads without a sender are not affected by the actor filter.
+func checkActorFilter(actorFilter string, p api.Payloader) bool {
+	sender := getPayloadSender(p)
+	if sender == nil {
+		return true
+	}
+	return matchFilter(actorFilter, []string{sender.UserName})
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPathFilter(t *testing.T) {
+	push := &api.PushPayload{Commits: []*api.PayloadCommit{
+		{Added: []string{"docs/install.md"}},
+		{Modified: []string{"main.go"}, Removed: []string{"modules/old/old.go"}},
+	}}
+
+	cases := []struct {
+		filter string
+		p      api.Payloader
+		match  bool
+	}{
+		{"", push, true},
+		{"*", push, true},
+		{"**", push, true},
+
+		{"main.go", push, true},
+		{"*.go", push, true},
+		{"*.md", push, false},
+		{"**.md", push, true},
+		{"docs/**", push, true},
+		{"modules/*", push, false},
+		{"modules/**", push, true},
+		{"{web_src/**,templates/**}", push, false},
+
+		{"docs/**", &api.PushPayload{}, false},
+		{"docs/**", &api.IssuePayload{}, true},
+	}
+	for _, v := range cases {
+		assert.Equal(t, v.match, checkPathFilter(v.filter, v.p), "filter: %q payload: %T", v.filter, v.p)
+	}
+}
+
+func TestCheckLabelFilter(t *testing.T) {
+	labels := []*api.Label{{Name: "kind/bug"}, {Name: "priority/high"}}
+	issue := &api.IssuePayload{Issue: &api.Issue{Labels: labels}}
+	comment := &api.IssueCommentPayload{Issue: &api.Issue{Labels: labels}}
+	pull := &api.PullRequestPayload{PullRequest: &api.PullRequest{Labels: labels}}
+
+	cases := []struct {
+		filter string
+		p      api.Payloader
+		match  bool
+	}{
+		{"", issue, true},
+		{"*", issue, true},
+
+		{"kind/bug", issue, true},
+		{"kind/bug", comment, true},
+		{"kind/bug", pull, true},
+		{"kind/feature", pull, false},
+		{"priority/*", pull, true},
+		{"{security,kind/*}", issue, true},
+		{"security", issue, false},
+
+		{"kind/bug", &api.IssuePayload{Issue: &api.Issue{}}, false},
+		{"kind/bug", &api.PushPayload{}, true},
+	}
+	for _, v := range cases {
+		assert.Equal(t, v.match, checkLabelFilter(v.filter, v.p), "filter: %q payload: %T", v.filter, v.p)
+	}
+}
+
+func TestCheckActorFilter(t *testing.T) {
+	cases := []struct {
+		filter string
+		p      api.Payloader
+		match  bool
+	}{
+		{"", &api.PushPayload{Sender: &api.User{UserName: "user2"}}, true},
+		{"*", &api.PushPayload{Sender: &api.User{UserName: "user2"}}, true},
+
+		{"user2", &api.PushPayload{Sender: &api.User{UserName: "user2"}}, true},
+		{"user2", &api.IssuePayload{Sender: &api.User{UserName: "user5"}}, false},
+		{"{user2,user5}", &api.IssuePayload{Sender: &api.User{UserName: "user5"}}, true},
+		{"*-bot", &api.ReleasePayload{Sender: &api.User{UserName: "renovate-bot"}}, true},
+
+		{"user2", &api.PushPayload{}, true},
+	}
+	for _, v := range cases {
+		assert.Equal(t, v.match, checkActorFilter(v.filter, v.p), "filter: %q payload: %T", v.filter, v.p)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"html"
 	"net/url"
+	"strconv"
 	"strings"
 
 	user_model "code.gitea.io/gitea/models/user"
@@ -133,6 +134,9 @@ func getIssuesPayloadInfo(p *api.IssuePayload, linkFormatter linkFormatter, with
 			linkFormatter(mileStoneLink, p.Issue.Milestone.Title), titleLink)
 	case api.HookIssueDemilestoned:
 		text = fmt.Sprintf("[%s] Issue milestone cleared: %s", repoLink, titleLink)
+	case api.HookIssueSLABreached:
+		text = fmt.Sprintf("[%s] Issue %s time of %s breached: %s", repoLink, p.SLA.Kind, p.SLA.RuleName, titleLink)
+		color = redColor
 	}
 	if withSender {
 		text += " by " + linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
@@ -403,6 +407,33 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		config["icon_url"] = s.IconURL
 		config["color"] = s.Color
 	}
+	if w.Type == webhook_module.CUSTOM {
+		s := GetCustomHook(w)
+		config["http_method"] = w.HTTPMethod
+		config["body_content_type"] = s.ContentType
+		config["headers"] = s.Headers
+		config["body_template"] = s.BodyTemplate
+	}
+	if w.Type == webhook_module.KAFKA {
+		s := GetKafkaHook(w)
+		config["topic"] = s.Topic
+		config["partition_key"] = s.PartitionKey
+		config["required_acks"] = s.RequiredAcks
+		config["tls"] = strconv.FormatBool(s.TLS)
+	}
+	if w.Type == webhook_module.MATRIX {
+		s := GetMatrixHook(w)
+		config["homeserver_url"] = s.HomeserverURL
+		config["room_id"] = s.Room
+		config["message_type"] = s.MessageTypeText()
+	}
+	if w.Type == webhook_module.NATS {
+		s := GetNatsHook(w)
+		config["subject"] = s.Subject
+		config["partition_key"] = s.PartitionKey
+		config["jetstream"] = strconv.FormatBool(s.JetStream)
+		config["tls"] = strconv.FormatBool(s.TLS)
+	}
 
 	authorizationHeader, err := w.HeaderAuthorization()
 	if err != nil {
@@ -420,5 +451,29 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		Updated:             w.UpdatedUnix.AsTime(),
 		Created:             w.CreatedUnix.AsTime(),
 		BranchFilter:        w.BranchFilter,
+		PathFilter:          w.PathFilter,
+		LabelFilter:         w.LabelFilter,
+		ActorFilter:         w.ActorFilter,
 	}, nil
 }
+
+// ToHookDelivery convert models.HookTask to api.HookDelivery
+func ToHookDelivery(t *webhook_model.HookTask) *api.HookDelivery {
+	d := &api.HookDelivery{
+		ID:        t.ID,
+		UUID:      t.UUID,
+		Event:     t.EventType.Event(),
+		EventType: string(t.EventType),
+		Attempt:   t.Attempt,
+		Status:    string(t.Status()),
+		Delivered: t.Delivered.AsTime(),
+	}
+	if t.ResponseInfo != nil {
+		d.StatusCode = t.ResponseInfo.Status
+	}
+	if !t.IsDelivered && t.DeliverAfter != 0 {
+		scheduled := t.DeliverAfter.AsTime()
+		d.Scheduled = &scheduled
+	}
+	return d
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+const (
+	KafkaRequiredAcksAll    = "all"
+	KafkaRequiredAcksLeader = "leader"
+	KafkaRequiredAcksNone   = "none"
+)
+
+// KafkaMeta contains the metadata for the webhook
+type KafkaMeta struct {
+	Topic        string `json:"topic"`
+	PartitionKey string `json:"partition_key"` // "repository", "owner" or "none"
+	RequiredAcks string `json:"required_acks"` // "all", "leader" or "none"
+	TLS          bool   `json:"tls"`
+}
+
+// GetKafkaHook returns kafka metadata
+func GetKafkaHook(w *webhook_model.Webhook) *KafkaMeta {
+	s := &KafkaMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetKafkaHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// Validate checks the topic and the delivery options
+func (m *KafkaMeta) Validate() error {
+	if !isValidKafkaTopic(m.Topic) {
+		return fmt.Errorf("invalid topic %q", m.Topic)
+	}
+	switch m.PartitionKey {
+	case BrokerPartitionKeyRepository, BrokerPartitionKeyOwner, BrokerPartitionKeyNone:
+	default:
+		return fmt.Errorf("invalid partition key %q", m.PartitionKey)
+	}
+	switch m.RequiredAcks {
+	case KafkaRequiredAcksAll, KafkaRequiredAcksLeader, KafkaRequiredAcksNone:
+	default:
+		return fmt.Errorf("invalid required acks %q", m.RequiredAcks)
+	}
+	return nil
+}
+
+// isValidKafkaTopic checks the topic name like Kafka does: up to 249 characters of [a-zA-Z0-9._-]
+func isValidKafkaTopic(topic string) bool {
+	if topic == "" || topic == "." || topic == ".." || len(topic) > 249 {
+		return false
+	}
+	for _, c := range topic {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func newKafkaRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &KafkaMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newKafkaRequest meta json: %w", err)
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("newKafkaRequest: %w", err)
+	}
+	query := url.Values{"acks": []string{meta.RequiredAcks}}
+	if meta.TLS {
+		query.Set("tls", "true")
+	}
+	return newBrokerRequest(w, t, meta.Topic, meta.PartitionKey, query)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.KAFKA, newKafkaRequest)
+}
+
+// kafkaPublishResult is recorded as response body of a delivery
+type kafkaPublishResult struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+// kafkaRoundTripper produces the body of "kafka://broker:9092/topic?acks=all" requests as message to the topic
+type kafkaRoundTripper struct {
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func (rt *kafkaRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	addrs := brokerAddrs(req.URL)
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("invalid kafka brokers %q", req.URL.Host)
+	}
+	topic := strings.TrimPrefix(req.URL.Path, "/")
+
+	var requiredAcks kafka.RequiredAcks
+	switch acks := req.URL.Query().Get("acks"); acks {
+	case KafkaRequiredAcksAll, "":
+		requiredAcks = kafka.RequireAll
+	case KafkaRequiredAcksLeader:
+		requiredAcks = kafka.RequireOne
+	case KafkaRequiredAcksNone:
+		requiredAcks = kafka.RequireNone
+	default:
+		return nil, fmt.Errorf("invalid kafka required acks %q", acks)
+	}
+
+	transport := &kafka.Transport{
+		Dial:     rt.dialContext,
+		ClientID: "gitea",
+	}
+	defer transport.CloseIdleConnections()
+	if ok, _ := strconv.ParseBool(req.URL.Query().Get("tls")); ok {
+		transport.TLS = &tls.Config{InsecureSkipVerify: setting.Webhook.SkipTLSVerify}
+	}
+	if username, password, _ := brokerCredentials(req); username != "" {
+		transport.SASL = plain.Mechanism{Username: username, Password: password}
+	}
+
+	msg := kafka.Message{}
+	if req.Body != nil {
+		value, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		msg.Value = value
+	}
+	if key := req.Header.Get(brokerPartitionKeyHeader); key != "" {
+		msg.Key = []byte(key)
+	}
+	for name, values := range brokerMessageHeaders(req) {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: name, Value: []byte(strings.Join(values, ","))})
+	}
+
+	result := &kafkaPublishResult{Topic: topic}
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(addrs...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{}, // messages without key are distributed round-robin
+		RequiredAcks: requiredAcks,
+		MaxAttempts:  1, // failed deliveries are retried by the webhook retry queue
+		BatchSize:    1,
+		Transport:    transport,
+		Completion: func(messages []kafka.Message, err error) {
+			if err == nil && len(messages) == 1 {
+				result.Partition, result.Offset = messages[0].Partition, messages[0].Offset
+			}
+		},
+	}
+	err := writer.WriteMessages(req.Context(), msg)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		var writeErrors kafka.WriteErrors
+		if errors.As(err, &writeErrors) && len(writeErrors) == 1 {
+			err = writeErrors[0]
+		}
+		return nil, fmt.Errorf("kafka: %w", err)
+	}
+	return newBrokerResponse(req, result)
+}
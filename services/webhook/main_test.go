@@ -16,7 +16,7 @@ import (
 
 func TestMain(m *testing.M) {
 	// for tests, allow only loopback IPs
-	setting.Webhook.AllowedHostList = hostmatcher.MatchBuiltinLoopback
+	setting.SetWebhookAllowedHostList(hostmatcher.MatchBuiltinLoopback)
 	unittest.MainTest(m, &unittest.TestOptions{
 		SetUp: func() error {
 			setting.LoadQueueSettings()
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/sha1"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -22,6 +23,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 )
 
@@ -74,6 +76,40 @@ var messageTypeText = map[int]string{
 	2: "m.text",
 }
 
+// Validate checks the homeserver, the room and the message type of a Matrix webhook
+func (m *MatrixMeta) Validate() error {
+	if !validation.IsValidURL(m.HomeserverURL) {
+		return errors.New("homeserver_url must be an HTTP URL")
+	}
+	if m.Room == "" {
+		return errors.New("room_id is required")
+	}
+	if _, ok := messageTypeText[m.MessageType]; !ok {
+		return errors.New(`message_type must be "m.notice" or "m.text"`)
+	}
+	return nil
+}
+
+// MessageTypeText returns the msgtype of the messages of a Matrix webhook
+func (m *MatrixMeta) MessageTypeText() string {
+	return messageTypeText[m.MessageType]
+}
+
+// ParseMatrixMessageType returns the message type of a Matrix webhook for its msgtype
+func ParseMatrixMessageType(msgType string) (int, bool) {
+	for t, text := range messageTypeText {
+		if text == msgType {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// MatrixRoomMessageURL returns the URL of the Matrix client API to send messages to a room
+func MatrixRoomMessageURL(homeserverURL, roomID string) string {
+	return fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message", strings.TrimSuffix(homeserverURL, "/"), url.PathEscape(roomID))
+}
+
 // GetMatrixHook returns Matrix metadata
 func GetMatrixHook(w *webhook_model.Webhook) *MatrixMeta {
 	s := &MatrixMeta{}
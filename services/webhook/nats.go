@@ -0,0 +1,182 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NatsMeta contains the metadata for the webhook
+type NatsMeta struct {
+	Subject      string `json:"subject"`
+	PartitionKey string `json:"partition_key"` // "repository", "owner" or "none", appended to the subject as last token
+	JetStream    bool   `json:"jetstream"`     // publish to a JetStream stream and wait for the acknowledgement
+	TLS          bool   `json:"tls"`
+}
+
+// GetNatsHook returns nats metadata
+func GetNatsHook(w *webhook_model.Webhook) *NatsMeta {
+	s := &NatsMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetNatsHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// Validate checks the subject and the delivery options
+func (m *NatsMeta) Validate() error {
+	if !isValidNatsSubject(m.Subject) {
+		return fmt.Errorf("invalid subject %q", m.Subject)
+	}
+	switch m.PartitionKey {
+	case BrokerPartitionKeyRepository, BrokerPartitionKeyOwner, BrokerPartitionKeyNone:
+	default:
+		return fmt.Errorf("invalid partition key %q", m.PartitionKey)
+	}
+	return nil
+}
+
+// isValidNatsSubject checks if the subject consists of non-empty "."-separated tokens without wildcards and whitespaces
+func isValidNatsSubject(subject string) bool {
+	if subject == "" {
+		return false
+	}
+	for token := range strings.SplitSeq(subject, ".") {
+		if token == "" || token == "*" || token == ">" || strings.ContainsAny(token, " \t\r\n") {
+			return false
+		}
+	}
+	return true
+}
+
+func newNatsRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &NatsMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newNatsRequest meta json: %w", err)
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("newNatsRequest: %w", err)
+	}
+
+	key, err := getBrokerPartitionKey(meta.PartitionKey, []byte(t.PayloadContent))
+	if err != nil {
+		return nil, nil, err
+	}
+	subject := meta.Subject
+	if key != "" {
+		subject += "." + key
+	}
+
+	query := url.Values{}
+	if meta.JetStream {
+		query.Set("jetstream", "true")
+	}
+	if meta.TLS {
+		query.Set("tls", "true")
+	}
+	return newBrokerRequest(w, t, subject, meta.PartitionKey, query)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.NATS, newNatsRequest)
+}
+
+// natsDialer dials the NATS servers with the webhook dialer which checks the allowed hosts
+type natsDialer struct {
+	ctx         context.Context
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func (d *natsDialer) Dial(network, address string) (net.Conn, error) {
+	return d.dialContext(d.ctx, network, address)
+}
+
+// natsPublishResult is recorded as response body of a core NATS delivery
+type natsPublishResult struct {
+	Subject string `json:"subject"`
+}
+
+// natsRoundTripper publishes the body of "nats://server:4222/subject" requests to the subject,
+// with "?jetstream=true" the message is published to JetStream which deduplicates it by the delivery UUID
+type natsRoundTripper struct {
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func (rt *natsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	addrs := brokerAddrs(req.URL)
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("invalid nats servers %q", req.URL.Host)
+	}
+	servers := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		servers = append(servers, "nats://"+addr)
+	}
+	subject := strings.TrimPrefix(req.URL.Path, "/")
+
+	options := []nats.Option{
+		nats.Name("gitea"),
+		nats.NoReconnect(),
+		nats.SetCustomDialer(&natsDialer{ctx: req.Context(), dialContext: rt.dialContext}),
+	}
+	if ok, _ := strconv.ParseBool(req.URL.Query().Get("tls")); ok {
+		options = append(options, nats.Secure(&tls.Config{InsecureSkipVerify: setting.Webhook.SkipTLSVerify}))
+	}
+	if username, password, token := brokerCredentials(req); username != "" {
+		options = append(options, nats.UserInfo(username, password))
+	} else if token != "" {
+		options = append(options, nats.Token(token))
+	}
+
+	nc, err := nats.Connect(strings.Join(servers, ","), options...)
+	if err != nil {
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	defer nc.Close()
+
+	msg := nats.NewMsg(subject)
+	if req.Body != nil {
+		if msg.Data, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	for name, values := range brokerMessageHeaders(req) {
+		msg.Header[name] = values
+	}
+
+	if ok, _ := strconv.ParseBool(req.URL.Query().Get("jetstream")); ok {
+		js, err := nc.JetStream()
+		if err != nil {
+			return nil, fmt.Errorf("nats: %w", err)
+		}
+		ack, err := js.PublishMsg(msg, nats.MsgId(req.Header.Get("X-Gitea-Delivery")), nats.Context(req.Context()))
+		if err != nil {
+			return nil, fmt.Errorf("nats: %w", err)
+		}
+		return newBrokerResponse(req, ack)
+	}
+
+	if err := nc.PublishMsg(msg); err != nil {
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	if err := nc.FlushWithContext(req.Context()); err != nil {
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	return newBrokerResponse(req, &natsPublishResult{Subject: subject})
+}
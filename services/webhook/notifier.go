@@ -599,6 +599,30 @@ func (m *webhookNotifier) IssueChangeLabels(ctx context.Context, doer *user_mode
 	}
 }
 
+func (m *webhookNotifier) IssueSLABreached(ctx context.Context, issue *issues_model.Issue, status *issues_model.IssueSLAStatus) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+
+	doer := user_model.NewActionsUser()
+	permission, _ := access_model.GetUserRepoPermission(ctx, issue.Repo, issue.Poster)
+	if err := PrepareWebhooks(ctx, EventSource{Repository: issue.Repo}, webhook_module.HookEventIssues, &api.IssuePayload{
+		Action:     api.HookIssueSLABreached,
+		Index:      issue.Index,
+		Issue:      convert.ToAPIIssue(ctx, doer, issue),
+		Repository: convert.ToRepo(ctx, issue.Repo, permission),
+		Sender:     convert.ToUser(ctx, doer, nil),
+		SLA:        convert.ToIssueSLAStatus(status),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
 func (m *webhookNotifier) IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64) {
 	var hookAction api.HookIssueAction
 	var err error
@@ -0,0 +1,121 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// MaxBulkRedeliveries is the maximum number of deliveries which are redelivered at once
+const MaxBulkRedeliveries = 1000
+
+// retryDelay returns the delay before the given attempt, it grows exponentially
+// and is randomized between the half and the full delay to spread the retries
+func retryDelay(attempt int) time.Duration {
+	delay := setting.Webhook.MaxRetryBackoff
+	if shift := attempt - 2; shift >= 0 && shift < 32 {
+		if d := setting.Webhook.RetryBackoff << shift; d > 0 && d < delay {
+			delay = d
+		}
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// scheduleHookTaskRetry turns a failed hook task into its next attempt, it gets enqueued once it is due
+func scheduleHookTaskRetry(t *webhook_model.HookTask) {
+	t.IsDelivered = false
+	t.Attempt++
+	t.DeliverAfter = timeutil.TimeStamp(time.Now().Add(retryDelay(t.Attempt)).Unix())
+	log.Trace("Hook delivery %s failed, attempt %d scheduled at %v", t.UUID, t.Attempt, t.DeliverAfter)
+}
+
+// retryWebhookDeliveries periodically enqueues the retries which are due
+func retryWebhookDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(max(min(setting.Webhook.RetryBackoff, time.Minute), time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			enqueueDueRetries(ctx)
+		}
+	}
+}
+
+func enqueueDueRetries(ctx context.Context) {
+	ctx, _, finished := process.GetManager().AddContext(ctx, "Webhook: Enqueue due retries")
+	defer finished()
+
+	lowerID := int64(0)
+	for {
+		taskIDs, err := webhook_model.FindDueRetryHookTaskIDs(ctx, lowerID)
+		if err != nil {
+			log.Error("FindDueRetryHookTaskIDs: %v", err)
+			return
+		}
+		if len(taskIDs) == 0 {
+			return
+		}
+		lowerID = taskIDs[len(taskIDs)-1]
+
+		for _, taskID := range taskIDs {
+			// the retry is only enqueued once, even if several instances look for due retries
+			enqueued, err := webhook_model.MarkHookTaskRetryEnqueued(ctx, taskID)
+			if err != nil {
+				log.Error("MarkHookTaskRetryEnqueued[%d]: %v", taskID, err)
+				continue
+			} else if !enqueued {
+				continue
+			}
+			if err := enqueueHookTask(taskID); err != nil {
+				log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", taskID, err)
+			}
+		}
+	}
+}
+
+// RedeliverHookTasks redelivers the dead-lettered hook tasks of a webhook which were delivered in the time range,
+// a zero time leaves the range open. It returns the new hook tasks.
+func RedeliverHookTasks(ctx context.Context, w *webhook_model.Webhook, since, before timeutil.TimeStampNano) ([]*webhook_model.HookTask, error) {
+	tasks, err := db.Find[webhook_model.HookTask](ctx, webhook_model.FindHookTaskOptions{
+		ListOptions: db.ListOptions{Page: 1, PageSize: MaxBulkRedeliveries},
+		HookID:      w.ID,
+		Status:      webhook_model.HookTaskStatusDeadLetter,
+		Since:       since,
+		Before:      before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redelivered := make([]*webhook_model.HookTask, 0, len(tasks))
+	for _, t := range tasks {
+		var task *webhook_model.HookTask
+		if err := db.WithTx(ctx, func(ctx context.Context) error {
+			if err := webhook_model.ClearHookTaskDeadLetter(ctx, t); err != nil {
+				return err
+			}
+			task, err = webhook_model.ReplayHookTask(ctx, w.ID, t.UUID)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		if err := enqueueHookTask(task.ID); err != nil {
+			return nil, err
+		}
+		redelivered = append(redelivered, task)
+	}
+	return redelivered, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDelay(t *testing.T) {
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Webhook.MaxRetryBackoff, 10*time.Minute)()
+
+	cases := []struct {
+		Attempt int
+		Max     time.Duration
+	}{
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 8 * time.Minute},
+		{6, 10 * time.Minute},
+		{100, 10 * time.Minute},
+	}
+	for _, c := range cases {
+		for range 10 {
+			d := retryDelay(c.Attempt)
+			assert.GreaterOrEqual(t, d, c.Max/2, "attempt %d", c.Attempt)
+			assert.LessOrEqual(t, d, c.Max, "attempt %d", c.Attempt)
+		}
+	}
+}
+
+func TestWebhookDeliverRetry(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Webhook.MaxAttempts, 2)()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(s.Close)
+
+	hook := &webhook_model.Webhook{
+		RepoID:      3,
+		URL:         s.URL + "/webhook",
+		ContentType: webhook_model.ContentTypeJSON,
+		IsActive:    true,
+		Type:        webhook_module.GITEA,
+	}
+	require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook))
+
+	hookTask, err := webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadVersion: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, hookTask.Attempt)
+
+	findTasks := func(status webhook_model.HookTaskStatus) []*webhook_model.HookTask {
+		tasks, err := db.Find[webhook_model.HookTask](t.Context(), webhook_model.FindHookTaskOptions{HookID: hook.ID, Status: status})
+		require.NoError(t, err)
+		return tasks
+	}
+
+	// the failed delivery schedules the next attempt of the same task
+	require.NoError(t, Deliver(t.Context(), hookTask))
+	assert.False(t, hookTask.IsSucceed)
+	assert.False(t, hookTask.IsDeadLetter)
+	assert.Equal(t, webhook_model.HookTaskStatusFailed, hookTask.Status())
+	assert.Empty(t, findTasks(webhook_model.HookTaskStatusPending))
+
+	failed := findTasks(webhook_model.HookTaskStatusFailed)
+	require.Len(t, failed, 1)
+	retry := failed[0]
+	assert.Equal(t, hookTask.ID, retry.ID)
+	assert.Equal(t, hookTask.UUID, retry.UUID)
+	assert.Equal(t, 2, retry.Attempt)
+	assert.False(t, retry.IsDelivered)
+	assert.Greater(t, retry.DeliverAfter, timeutil.TimeStampNow()-1)
+
+	taskIDs, err := webhook_model.FindDueRetryHookTaskIDs(t.Context(), 0)
+	require.NoError(t, err)
+	assert.NotContains(t, taskIDs, retry.ID)
+	taskIDs, err = webhook_model.FindUndeliveredHookTaskIDs(t.Context(), 0)
+	require.NoError(t, err)
+	assert.NotContains(t, taskIDs, retry.ID)
+	enqueued, err := webhook_model.MarkHookTaskRetryEnqueued(t.Context(), retry.ID)
+	require.NoError(t, err)
+	assert.False(t, enqueued)
+
+	// the due retry is only enqueued once
+	retry.DeliverAfter = timeutil.TimeStampNow() - 1
+	require.NoError(t, webhook_model.UpdateHookTask(t.Context(), retry))
+	taskIDs, err = webhook_model.FindDueRetryHookTaskIDs(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Contains(t, taskIDs, retry.ID)
+	enqueued, err = webhook_model.MarkHookTaskRetryEnqueued(t.Context(), retry.ID)
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+	enqueued, err = webhook_model.MarkHookTaskRetryEnqueued(t.Context(), retry.ID)
+	require.NoError(t, err)
+	assert.False(t, enqueued)
+	taskIDs, err = webhook_model.FindDueRetryHookTaskIDs(t.Context(), 0)
+	require.NoError(t, err)
+	assert.NotContains(t, taskIDs, retry.ID)
+
+	// the last attempt is dead-lettered
+	retry, err = webhook_model.GetHookTaskByID(t.Context(), retry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, webhook_model.HookTaskStatusPending, retry.Status())
+	require.NoError(t, Deliver(t.Context(), retry))
+	assert.True(t, retry.IsDeadLetter)
+	assert.Equal(t, webhook_model.HookTaskStatusDeadLetter, retry.Status())
+	assert.Empty(t, findTasks(webhook_model.HookTaskStatusPending))
+	assert.Empty(t, findTasks(webhook_model.HookTaskStatusFailed))
+	assert.Len(t, findTasks(webhook_model.HookTaskStatusDeadLetter), 1)
+
+	// deliveries outside of the range are not redelivered
+	redelivered, err := RedeliverHookTasks(t.Context(), hook, 0, retry.Delivered)
+	require.NoError(t, err)
+	assert.Empty(t, redelivered)
+
+	redelivered, err = RedeliverHookTasks(t.Context(), hook, retry.Delivered, 0)
+	require.NoError(t, err)
+	require.Len(t, redelivered, 1)
+	assert.Equal(t, 1, redelivered[0].Attempt)
+	assert.NotEqual(t, retry.UUID, redelivered[0].UUID)
+
+	retry, err = webhook_model.GetHookTaskByID(t.Context(), retry.ID)
+	require.NoError(t, err)
+	assert.False(t, retry.IsDeadLetter)
+}
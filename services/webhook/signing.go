@@ -0,0 +1,168 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// signingKey is an ed25519 key used to sign webhook payloads, only the public part is known for published keys
+type signingKey struct {
+	ID         string
+	PrivateKey ed25519.PrivateKey
+	PublicKey  ed25519.PublicKey
+}
+
+func newSigningKey(publicKey ed25519.PublicKey, privateKey ed25519.PrivateKey) (*signingKey, error) {
+	fingerprint, err := util.CreatePublicKeyFingerprint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{
+		ID:         base64.RawURLEncoding.EncodeToString(fingerprint),
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}, nil
+}
+
+// ToJWK returns the public key as JSON Web Key
+func (key *signingKey) ToJWK() map[string]string {
+	return map[string]string{
+		"alg": "EdDSA",
+		"kid": key.ID,
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"use": "sig",
+		"x":   base64.RawURLEncoding.EncodeToString(key.PublicKey),
+	}
+}
+
+var (
+	// currentSigningKey signs the payloads, it is nil if signing is disabled
+	currentSigningKey *signingKey
+	// publishedSigningKeys are all keys receivers may verify signatures with, including the current key
+	publishedSigningKeys []*signingKey
+)
+
+func initSigningKeys() error {
+	currentSigningKey, publishedSigningKeys = nil, nil
+	if !setting.Webhook.SigningEnabled {
+		return nil
+	}
+
+	key, err := loadOrCreateSigningKey(setting.Webhook.SigningPrivateKeyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load webhook signing key: %w", err)
+	}
+	keys := []*signingKey{key}
+	for _, keyFile := range setting.Webhook.SigningPublishedKeyFiles {
+		published, err := loadSigningKey(keyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load published webhook signing key: %w", err)
+		}
+		if published.ID != key.ID {
+			keys = append(keys, published)
+		}
+	}
+
+	currentSigningKey, publishedSigningKeys = key, keys
+	return nil
+}
+
+// loadOrCreateSigningKey loads the private key, a new random key is generated and saved if the file does not exist
+func loadOrCreateSigningKey(keyFile string) (*signingKey, error) {
+	isExist, err := util.IsExist(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		bytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(keyFile), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: bytes}), 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := loadSigningKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if key.PrivateKey == nil {
+		return nil, fmt.Errorf("expected PRIVATE KEY in %s", keyFile)
+	}
+	return key, nil
+}
+
+// loadSigningKey loads a PEM encoded ed25519 private or public key
+func loadSigningKey(keyFile string) (*signingKey, error) {
+	bytes, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, fmt.Errorf("no valid PEM data found in %s", keyFile)
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("expected ed25519 key in %s, got %T", keyFile, key)
+		}
+		return newSigningKey(privateKey.Public().(ed25519.PublicKey), privateKey)
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("expected ed25519 key in %s, got %T", keyFile, key)
+		}
+		return newSigningKey(publicKey, nil)
+	}
+	return nil, fmt.Errorf("expected PRIVATE KEY or PUBLIC KEY, got %s in %s", block.Type, keyFile)
+}
+
+// SigningKeySet returns the public keys receivers can verify the payload signatures with as JSON Web Key Set
+func SigningKeySet() map[string][]map[string]string {
+	keys := make([]map[string]string, 0, len(publishedSigningKeys))
+	for _, key := range publishedSigningKeys {
+		keys = append(keys, key.ToJWK())
+	}
+	return map[string][]map[string]string{"keys": keys}
+}
+
+// signPayload signs the payload with the current signing key and returns the key id and the base64 encoded signature.
+// Both are empty if signing is disabled.
+func signPayload(payloadContent []byte) (keyID, signature string) {
+	if currentSigningKey == nil {
+		return "", ""
+	}
+	return currentSigningKey.ID, base64.StdEncoding.EncodeToString(ed25519.Sign(currentSigningKey.PrivateKey, payloadContent))
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+	defer test.MockVariableValue(&setting.Webhook.SigningEnabled, true)()
+	defer test.MockVariableValue(&setting.Webhook.SigningPrivateKeyFile, filepath.Join(dir, "webhook", "signing.pem"))()
+	defer test.MockVariableValue(&setting.Webhook.SigningPublishedKeyFiles, nil)()
+	defer func() { currentSigningKey, publishedSigningKeys = nil, nil }()
+
+	// a new key is generated
+	require.NoError(t, initSigningKeys())
+	require.NotNil(t, currentSigningKey)
+	keyID := currentSigningKey.ID
+	assert.FileExists(t, setting.Webhook.SigningPrivateKeyFile)
+
+	// the existing key is reused
+	require.NoError(t, initSigningKeys())
+	assert.Equal(t, keyID, currentSigningKey.ID)
+
+	payload := []byte(`{"ref":"refs/heads/main"}`)
+	signedKeyID, signature := signPayload(payload)
+	assert.Equal(t, keyID, signedKeyID)
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(currentSigningKey.PublicKey, payload, sig))
+
+	// publish the public key of the next key before rotating
+	nextPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	bytes, err := x509.MarshalPKIXPublicKey(nextPublicKey)
+	require.NoError(t, err)
+	nextKeyFile := filepath.Join(dir, "next.pem")
+	require.NoError(t, os.WriteFile(nextKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: bytes}), 0o600))
+	setting.Webhook.SigningPublishedKeyFiles = []string{nextKeyFile, setting.Webhook.SigningPrivateKeyFile}
+
+	require.NoError(t, initSigningKeys())
+	assert.Equal(t, keyID, currentSigningKey.ID)
+	keySet := SigningKeySet()["keys"]
+	require.Len(t, keySet, 2)
+	assert.Equal(t, keyID, keySet[0]["kid"])
+	assert.Equal(t, "OKP", keySet[1]["kty"])
+	assert.Equal(t, "Ed25519", keySet[1]["crv"])
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(nextPublicKey), keySet[1]["x"])
+
+	// a public key can't be used for signing
+	setting.Webhook.SigningPrivateKeyFile = nextKeyFile
+	assert.Error(t, initSigningKeys())
+}
+
+func TestAddDefaultHeadersSignature(t *testing.T) {
+	defer func() { currentSigningKey, publishedSigningKeys = nil, nil }()
+
+	w := &webhook_model.Webhook{}
+	task := &webhook_model.HookTask{UUID: "uuid", EventType: "push"}
+	payload := []byte(`{}`)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	require.NoError(t, addDefaultHeaders(req, nil, w, task, payload))
+	assert.Empty(t, req.Header.Get("X-Gitea-Signature-Ed25519"))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	currentSigningKey, err = newSigningKey(publicKey, privateKey)
+	require.NoError(t, err)
+
+	req, err = http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	require.NoError(t, addDefaultHeaders(req, nil, w, task, payload))
+	assert.Equal(t, currentSigningKey.ID, req.Header.Get("X-Gitea-Signature-Key-Id"))
+	sig, err := base64.StdEncoding.DecodeString(req.Header.Get("X-Gitea-Signature-Ed25519"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, payload, sig))
+}
@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 )
@@ -95,6 +96,12 @@ func handler(items ...int64) []int64 {
 			continue
 		}
 
+		if task.DeliverAfter > timeutil.TimeStampNow() {
+			// A retry which is not due yet, it gets enqueued again once it is due
+			log.Trace("Task[%d] is not due before %v", task.ID, task.DeliverAfter)
+			continue
+		}
+
 		if err := Deliver(ctx, task); err != nil {
 			log.Error("Unable to deliver webhook task[%d]: %v", task.ID, err)
 		}
@@ -158,6 +165,10 @@ func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook
 		}
 	}
 
+	if !checkPathFilter(w.PathFilter, p) || !checkLabelFilter(w.LabelFilter, p) || !checkActorFilter(w.ActorFilter, p) {
+		return nil
+	}
+
 	payload, err := p.JSONPayload()
 	if err != nil {
 		return fmt.Errorf("JSONPayload for %s: %w", event, err)
@@ -118,8 +118,8 @@ func (wc wechatworkConvertor) IssueComment(p *api.IssueCommentPayload) (Wechatwo
 // PullRequest implements PayloadConvertor PullRequest method
 func (wc wechatworkConvertor) PullRequest(p *api.PullRequestPayload) (WechatworkPayload, error) {
 	text, issueTitle, extraMarkdown, _ := getPullRequestPayloadInfo(p, noneLinkFormatter, true)
-	pr := fmt.Sprintf("> <font color=\"info\"> %s </font> \r\n > <font color=\"comment\">%s </font> \r\n > <font color=\"comment\">%s </font> \r\n",
-		text, issueTitle, extraMarkdown)
+	pr := fmt.Sprintf("> <font color=\"info\"> %s </font> \r\n > <font color=\"comment\">%s </font> \r\n > <font color=\"comment\">%s </font> \r\n [%s](%s)",
+		text, issueTitle, extraMarkdown, p.PullRequest.HTMLURL, p.PullRequest.HTMLURL)
 
 	return newWechatworkMarkdownPayload(pr), nil
 }
@@ -137,7 +137,7 @@ func (wc wechatworkConvertor) Review(p *api.PullRequestPayload, event webhook_mo
 		text = p.Review.Content
 	}
 
-	return newWechatworkMarkdownPayload("# " + title + "\r\n\r\n >" + text), nil
+	return newWechatworkMarkdownPayload(fmt.Sprintf("# %s\r\n\r\n >%s \n [%s](%s)", title, text, p.PullRequest.HTMLURL, p.PullRequest.HTMLURL)), nil
 }
 
 // Repository implements PayloadConvertor Repository method
@@ -166,7 +166,7 @@ func (wc wechatworkConvertor) Wiki(p *api.WikiPayload) (WechatworkPayload, error
 func (wc wechatworkConvertor) Release(p *api.ReleasePayload) (WechatworkPayload, error) {
 	text, _ := getReleasePayloadInfo(p, noneLinkFormatter, true)
 
-	return newWechatworkMarkdownPayload(text), nil
+	return newWechatworkMarkdownPayload(fmt.Sprintf("%s \n [%s](%s)", text, p.Release.HTMLURL, p.Release.HTMLURL)), nil
 }
 
 func (wc wechatworkConvertor) Package(p *api.PackagePayload) (WechatworkPayload, error) {
@@ -184,13 +184,13 @@ func (wc wechatworkConvertor) Status(p *api.CommitStatusPayload) (WechatworkPayl
 func (wc wechatworkConvertor) WorkflowRun(p *api.WorkflowRunPayload) (WechatworkPayload, error) {
 	text, _ := getWorkflowRunPayloadInfo(p, noneLinkFormatter, true)
 
-	return newWechatworkMarkdownPayload(text), nil
+	return newWechatworkMarkdownPayload(fmt.Sprintf("%s \n [%s](%s)", text, p.WorkflowRun.HTMLURL, p.WorkflowRun.HTMLURL)), nil
 }
 
 func (wc wechatworkConvertor) WorkflowJob(p *api.WorkflowJobPayload) (WechatworkPayload, error) {
 	text, _ := getWorkflowJobPayloadInfo(p, noneLinkFormatter, true)
 
-	return newWechatworkMarkdownPayload(text), nil
+	return newWechatworkMarkdownPayload(fmt.Sprintf("%s \n [%s](%s)", text, p.WorkflowJob.HTMLURL, p.WorkflowJob.HTMLURL)), nil
 }
 
 func newWechatworkRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWechatworkPayload(t *testing.T) {
+	wc := wechatworkConvertor{}
+	t.Run("Create", func(t *testing.T) {
+		p := createTestPayload()
+
+		pl, err := wc.Create(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "markdown", pl.Msgtype)
+		assert.Equal(t, "[test/repo] branch test created", pl.Markdown.Content)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		p := pushTestPayload()
+
+		pl, err := wc.Push(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "markdown", pl.Msgtype)
+		assert.Contains(t, pl.Markdown.Content, "# test/repo:test")
+		assert.Contains(t, pl.Markdown.Content, "[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778)")
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		p := issueTestPayload()
+		p.Action = api.HookIssueOpened
+
+		pl, err := wc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Contains(t, pl.Markdown.Content, "[test/repo] Issue opened: #2 crash by user1")
+		assert.Contains(t, pl.Markdown.Content, "[http://localhost:3000/test/repo/issues/2](http://localhost:3000/test/repo/issues/2)")
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		p := pullRequestTestPayload()
+
+		pl, err := wc.PullRequest(p)
+		require.NoError(t, err)
+
+		assert.Contains(t, pl.Markdown.Content, "[test/repo] Pull request opened: #12 Fix bug by user1")
+		assert.Contains(t, pl.Markdown.Content, "[http://localhost:3000/test/repo/pulls/12](http://localhost:3000/test/repo/pulls/12)")
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		p := pullReleaseTestPayload()
+		p.Release.HTMLURL = "http://localhost:3000/test/repo/releases/tag/v1.0"
+
+		pl, err := wc.Release(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo] Release created: v1.0 by user1 \n [http://localhost:3000/test/repo/releases/tag/v1.0](http://localhost:3000/test/repo/releases/tag/v1.0)", pl.Markdown.Content)
+	})
+}
+
+func TestWechatworkJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.WECHATWORK,
+		URL:        "https://qyapi.example.com/cgi-bin/webhook/send?key=key",
+		Meta:       ``,
+		HTTPMethod: "POST",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newWechatworkRequest(t.Context(), hook, task)
+	require.NotNil(t, req)
+	require.NotNil(t, reqBody)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://qyapi.example.com/cgi-bin/webhook/send?key=key", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	var body WechatworkPayload
+	err = json.NewDecoder(req.Body).Decode(&body)
+	assert.NoError(t, err)
+	assert.Equal(t, "markdown", body.Msgtype)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webpush
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	activities_model "code.gitea.io/gitea/models/activities"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type webPushNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &webPushNotifier{}
+
+// NewNotifier create a new webPushNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &webPushNotifier{}
+}
+
+func notifyMentions(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, link string, mentions []*user_model.User) {
+	if len(mentions) == 0 {
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	msg := Message{
+		Event: activities_model.WebPushEventMention,
+		Title: fmt.Sprintf("%s mentioned you in %s#%d", doer.Name, issue.Repo.FullName(), issue.Index),
+		Body:  issue.Title,
+		URL:   link,
+		Tag:   fmt.Sprintf("issue-%d", issue.ID),
+	}
+	for _, user := range mentions {
+		enqueue(doer, user.ID, msg)
+	}
+}
+
+func (n *webPushNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	notifyMentions(ctx, issue.Poster, issue, issue.HTMLURL(ctx), mentions)
+}
+
+func (n *webPushNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
+	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
+) {
+	notifyMentions(ctx, doer, issue, comment.HTMLURL(ctx), mentions)
+}
+
+func (n *webPushNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	notifyMentions(ctx, pr.Issue.Poster, pr.Issue, pr.Issue.HTMLURL(ctx), mentions)
+}
+
+func (n *webPushNotifier) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, r *issues_model.Review, comment *issues_model.Comment, mentions []*user_model.User) {
+	n.pullRequestComment(ctx, pr, comment, mentions)
+}
+
+func (n *webPushNotifier) PullRequestCodeComment(ctx context.Context, pr *issues_model.PullRequest, comment *issues_model.Comment, mentions []*user_model.User) {
+	n.pullRequestComment(ctx, pr, comment, mentions)
+}
+
+func (n *webPushNotifier) pullRequestComment(ctx context.Context, pr *issues_model.PullRequest, comment *issues_model.Comment, mentions []*user_model.User) {
+	if len(mentions) == 0 {
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := comment.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	notifyMentions(ctx, comment.Poster, pr.Issue, comment.HTMLURL(ctx), mentions)
+}
+
+func (n *webPushNotifier) PullRequestReviewRequest(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
+	if !isRequest || reviewer.IsOrganization() {
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	enqueue(doer, reviewer.ID, Message{
+		Event: activities_model.WebPushEventReviewRequest,
+		Title: fmt.Sprintf("%s requested your review on %s#%d", doer.Name, issue.Repo.FullName(), issue.Index),
+		Body:  issue.Title,
+		URL:   issue.HTMLURL(ctx),
+		Tag:   fmt.Sprintf("issue-%d", issue.ID),
+	})
+}
+
+// WorkflowRunStatusUpdate notifies the user who triggered a workflow run when it fails
+func (n *webPushNotifier) WorkflowRunStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, run *actions_model.ActionRun) {
+	if run.Status != actions_model.StatusFailure || sender.IsGiteaActions() || sender.IsGhost() {
+		return
+	}
+	if run.Repo == nil {
+		run.Repo = repo
+	}
+	enqueue(nil, sender.ID, Message{
+		Event: activities_model.WebPushEventCIFailure,
+		Title: fmt.Sprintf("Workflow %s failed in %s", run.WorkflowID, repo.FullName()),
+		Body:  run.Title,
+		URL:   run.HTMLURL(),
+		Tag:   fmt.Sprintf("run-%d", run.ID),
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webpush
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/webpush"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// Message is the payload of a web push notification, shown by the service worker of the browser
+type Message struct {
+	UserID int64                         `json:"-"`
+	Event  activities_model.WebPushEvent `json:"event"`
+	Title  string                        `json:"title"`
+	Body   string                        `json:"body"`
+	URL    string                        `json:"url"`
+	// Tag groups the notifications of the same subject in the browser
+	Tag string `json:"tag"`
+}
+
+var (
+	pushQueue  *queue.WorkerPoolQueue[*Message]
+	pushClient *http.Client
+)
+
+// Init starts the web push notifier when web push is enabled
+func Init() error {
+	if !setting.WebPush.Enabled {
+		return nil
+	}
+
+	allowedHostListValue := setting.WebPush.AllowedHostList
+	if allowedHostListValue == "" {
+		allowedHostListValue = hostmatcher.MatchBuiltinExternal
+	}
+	allowedHostMatcher := hostmatcher.ParseHostMatchList("webpush.ALLOWED_HOST_LIST", allowedHostListValue)
+	pushClient = &http.Client{
+		Timeout: setting.WebPush.DeliverTimeout,
+		Transport: &http.Transport{
+			Proxy:       proxy.Proxy(),
+			DialContext: hostmatcher.NewDialContext("webpush", allowedHostMatcher, nil, nil),
+		},
+	}
+
+	pushQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "web_push", handler)
+	if pushQueue == nil {
+		return errors.New("unable to create web_push queue")
+	}
+	go graceful.GetManager().RunWithCancel(pushQueue)
+
+	notify_service.RegisterNotifier(NewNotifier())
+	return nil
+}
+
+func handler(items ...*Message) []*Message {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, msg := range items {
+		if err := sendToUser(ctx, msg); err != nil {
+			log.Error("Unable to send web push notification to user %d: %v", msg.UserID, err)
+		}
+	}
+	return nil
+}
+
+// enqueue queues a message for a user unless the user triggered the event
+func enqueue(doer *user_model.User, receiverID int64, msg Message) {
+	if doer != nil && doer.ID == receiverID {
+		return
+	}
+	msg.UserID = receiverID
+	msg.Body = util.EllipsisDisplayString(msg.Body, 500)
+	if err := pushQueue.Push(&msg); err != nil {
+		log.Error("Unable to queue web push notification: %v", err)
+	}
+}
+
+// sendToUser sends a message to all the subscribed browsers of a user who opted in to its type of event,
+// the subscriptions the push services don't know anymore are deleted
+func sendToUser(ctx context.Context, msg *Message) error {
+	user, err := user_model.GetUserByID(ctx, msg.UserID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive || user.ProhibitLogin {
+		return nil
+	}
+
+	events, err := activities_model.GetWebPushEvents(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(events, msg.Event) {
+		return nil
+	}
+	subs, err := activities_model.GetWebPushSubscriptions(ctx, user.ID)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	vapid := &webpush.VAPID{
+		Subject:    setting.WebPush.Subject,
+		PublicKey:  setting.WebPush.VAPIDPublicKey,
+		PrivateKey: setting.WebPush.VAPIDPrivateKey,
+	}
+	for _, sub := range subs {
+		err := webpush.Send(ctx, pushClient, vapid, &webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}, payload, setting.WebPush.TTL)
+		if errors.Is(err, webpush.ErrSubscriptionGone) {
+			log.Debug("Deleting web push subscription %d of user %d gone from its push service", sub.ID, user.ID)
+			err = activities_model.DeleteWebPushSubscription(ctx, sub.ID)
+		}
+		if err != nil {
+			log.Error("Unable to send web push notification to subscription %d: %v", sub.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/generate"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestSendToUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	publicKey, privateKey, err := generate.NewVAPIDKeys()
+	require.NoError(t, err)
+	defer test.MockVariableValue(&setting.WebPush.VAPIDPublicKey, publicKey)()
+	defer test.MockVariableValue(&setting.WebPush.VAPIDPrivateKey, privateKey)()
+
+	received := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received[r.URL.Path]++
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	defer test.MockVariableValue(&pushClient, server.Client())()
+
+	uaKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	for _, path := range []string{"/active", "/gone"} {
+		require.NoError(t, activities_model.SaveWebPushSubscription(t.Context(), &activities_model.WebPushSubscription{
+			UserID:   2,
+			Endpoint: server.URL + path,
+			P256dh:   base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+			Auth:     "BTBZMqHH6r4Tts7J_aSIgg",
+		}))
+	}
+
+	msg := &Message{UserID: 2, Event: activities_model.WebPushEventMention, Title: "user1 mentioned you in user2/repo1#1"}
+
+	// nothing is sent until the user opts in to the event
+	require.NoError(t, sendToUser(t.Context(), msg))
+	assert.Empty(t, received)
+
+	require.NoError(t, activities_model.SetWebPushEvents(t.Context(), 2, []activities_model.WebPushEvent{activities_model.WebPushEventMention}))
+	require.NoError(t, sendToUser(t.Context(), msg))
+	assert.Equal(t, map[string]int{"/active": 1, "/gone": 1}, received)
+
+	// the subscription gone from its push service has been deleted
+	subs, err := activities_model.GetWebPushSubscriptions(t.Context(), 2)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, server.URL+"/active", subs[0].Endpoint)
+
+	require.NoError(t, sendToUser(t.Context(), &Message{UserID: 2, Event: activities_model.WebPushEventCIFailure}))
+	assert.Equal(t, 1, received["/active"])
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"strings"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// ProtectedPageName returns the name which the protected wiki page patterns are matched against,
+// it is the git file name of the page without the ".md" suffix, so that web edits and git pushes are checked alike.
+func ProtectedPageName(wikiName WebPath) string {
+	return strings.TrimSuffix(WebPathToGitPath(wikiName), ".md")
+}
+
+// IsPageProtected returns true if the wiki page can only be edited by the repository administrators
+func IsPageProtected(ctx context.Context, repo *repo_model.Repository, wikiName WebPath) (bool, error) {
+	pages, err := repo_model.GetProtectedWikiPages(ctx, repo.ID)
+	if err != nil {
+		return false, err
+	}
+	return repo_model.IsWikiPageProtected(pages, ProtectedPageName(wikiName))
+}
+
+// CanEditPage returns true if a user with the permission is allowed to edit the wiki page,
+// the permission to write the wiki is not checked here.
+func CanEditPage(ctx context.Context, repo *repo_model.Repository, perm access_model.Permission, wikiName WebPath) (bool, error) {
+	if perm.IsAdmin() {
+		return true, nil
+	}
+	protected, err := IsPageProtected(ctx, repo, wikiName)
+	return !protected, err
+}
+
+// assertCanEditPages returns ErrWikiPageProtected if the doer is not allowed to edit one of the wiki pages
+func assertCanEditPages(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, wikiNames ...WebPath) error {
+	pages, err := repo_model.GetProtectedWikiPages(ctx, repo.ID)
+	if err != nil || len(pages) == 0 {
+		return err
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return err
+	}
+	if perm.IsAdmin() {
+		return nil
+	}
+
+	for _, wikiName := range wikiNames {
+		protected, err := repo_model.IsWikiPageProtected(pages, ProtectedPageName(wikiName))
+		if err != nil {
+			return err
+		}
+		if protected {
+			_, title := WebPathToUserTitle(wikiName)
+			return repo_model.ErrWikiPageProtected{Title: title}
+		}
+	}
+	return nil
+}
@@ -93,6 +93,15 @@ func updateWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model
 	if err = validateWebPath(newWikiName); err != nil {
 		return err
 	}
+
+	wikiNames := []WebPath{newWikiName}
+	if !isNew && oldWikiName != newWikiName {
+		wikiNames = append(wikiNames, oldWikiName)
+	}
+	if err = assertCanEditPages(ctx, doer, repo, wikiNames...); err != nil {
+		return err
+	}
+
 	releaser, err := globallock.Lock(ctx, getWikiWorkingLockKey(repo.ID))
 	if err != nil {
 		return err
@@ -217,13 +226,14 @@ func updateWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model
 	if err := git.Push(gitRepo.Ctx, basePath, git.PushOptions{
 		Remote: DefaultRemote,
 		Branch: fmt.Sprintf("%s:%s%s", commitHash.String(), git.BranchPrefix, repo.DefaultWikiBranch),
-		Env: repo_module.FullPushingEnvironment(
+		// the protected pages have been checked above, the push doesn't need to be checked again by the hooks
+		Env: append(repo_module.FullPushingEnvironment(
 			doer,
 			doer,
 			repo,
 			repo.Name+".wiki",
 			0,
-		),
+		), repo_module.EnvIsInternal+"=true"),
 	}); err != nil {
 		log.Error("Push failed: %v", err)
 		if git.IsErrPushOutOfDate(err) || git.IsErrPushRejected(err) {
@@ -253,6 +263,10 @@ func DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model
 		return err
 	}
 
+	if err = assertCanEditPages(ctx, doer, repo, wikiName); err != nil {
+		return err
+	}
+
 	releaser, err := globallock.Lock(ctx, getWikiWorkingLockKey(repo.ID))
 	if err != nil {
 		return err
@@ -335,13 +349,14 @@ func DeleteWikiPage(ctx context.Context, doer *user_model.User, repo *repo_model
 	if err := git.Push(gitRepo.Ctx, basePath, git.PushOptions{
 		Remote: DefaultRemote,
 		Branch: fmt.Sprintf("%s:%s%s", commitHash.String(), git.BranchPrefix, repo.DefaultWikiBranch),
-		Env: repo_module.FullPushingEnvironment(
+		// the protected pages have been checked above, the push doesn't need to be checked again by the hooks
+		Env: append(repo_module.FullPushingEnvironment(
 			doer,
 			doer,
 			repo,
 			repo.Name+".wiki",
 			0,
-		),
+		), repo_module.EnvIsInternal+"=true"),
 	}); err != nil {
 		if git.IsErrPushOutOfDate(err) || git.IsErrPushRejected(err) {
 			return err
@@ -31,6 +31,7 @@ import (
 	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
+	actions_web "code.gitea.io/gitea/routers/web/repo/actions"
 	issue_service "code.gitea.io/gitea/services/issue"
 	pull_service "code.gitea.io/gitea/services/pull"
 	release_service "code.gitea.io/gitea/services/release"
@@ -39,6 +40,7 @@ import (
 	files_service "code.gitea.io/gitea/services/repository/files"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPullRequestTargetEvent(t *testing.T) {
@@ -1176,6 +1178,104 @@ jobs:
 	})
 }
 
+func TestRepositoryDispatchPublicApiJSON(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, user2.Name)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+
+		// create the repo
+		repo, err := repo_service.CreateRepository(t.Context(), user2, user2, repo_service.CreateRepoOptions{
+			Name:          "repository-dispatch-event",
+			Description:   "test repository-dispatch ci event",
+			AutoInit:      true,
+			Gitignores:    "Go",
+			License:       "MIT",
+			Readme:        "Default",
+			DefaultBranch: "main",
+			IsPrivate:     false,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, repo)
+
+		// add workflow file to the repo
+		addWorkflowToBaseResp, err := files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation: "create",
+					TreePath:  ".gitea/workflows/deploy.yml",
+					ContentReader: strings.NewReader(`
+on:
+  repository_dispatch:
+    types: [deploy]
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ github.event.client_payload.environment }}
+`),
+				},
+			},
+			Message:   "add workflow",
+			OldBranch: "main",
+			NewBranch: "main",
+			Author: &files_service.IdentityOptions{
+				GitUserName:  user2.Name,
+				GitUserEmail: user2.Email,
+			},
+			Committer: &files_service.IdentityOptions{
+				GitUserName:  user2.Name,
+				GitUserEmail: user2.Email,
+			},
+			Dates: &files_service.CommitDateOptions{
+				Author:    time.Now(),
+				Committer: time.Now(),
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, addWorkflowToBaseResp)
+
+		branch, err := git_model.GetBranch(t.Context(), repo.ID, repo.DefaultBranch)
+		assert.NoError(t, err)
+		urlStr := fmt.Sprintf("/api/v1/repos/%s/dispatches", repo.FullName())
+
+		// an event type the workflow doesn't listen to doesn't trigger it
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.CreateRepositoryDispatchOption{EventType: "test"}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionRun{RepoID: repo.ID, Event: "repository_dispatch"})
+
+		// the client payload is limited to 10 top-level properties
+		tooLargePayload := map[string]any{}
+		for i := range 11 {
+			tooLargePayload[fmt.Sprintf("key%d", i)] = i
+		}
+		req = NewRequestWithJSON(t, "POST", urlStr, &api.CreateRepositoryDispatchOption{EventType: "deploy", ClientPayload: tooLargePayload}).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", urlStr, &api.CreateRepositoryDispatchOption{
+			EventType:     "deploy",
+			ClientPayload: map[string]any{"environment": "production", "replicas": 3},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{
+			RepoID:     repo.ID,
+			Event:      "repository_dispatch",
+			Ref:        "refs/heads/main",
+			WorkflowID: "deploy.yml",
+			CommitSHA:  branch.CommitID,
+		})
+		dispatchPayload := &api.RepositoryDispatchPayload{}
+		assert.NoError(t, json.Unmarshal([]byte(run.EventPayload), dispatchPayload))
+		assert.Equal(t, "deploy", dispatchPayload.Action)
+		assert.Equal(t, "main", dispatchPayload.Branch)
+		assert.Equal(t, "production", dispatchPayload.ClientPayload["environment"])
+		assert.EqualValues(t, 3, dispatchPayload.ClientPayload["replicas"])
+	})
+}
+
 func TestWorkflowApi(t *testing.T) {
 	onGiteaRun(t, func(t *testing.T, u *url.URL) {
 		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
@@ -1604,3 +1704,58 @@ jobs:
 		assert.NotNil(t, run)
 	})
 }
+
+func TestActionRunInvalidReusableWorkflow(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+		repo, err := repo_service.CreateRepository(t.Context(), user2, user2, repo_service.CreateRepoOptions{
+			Name:          "action-invalid-reusable-workflow",
+			AutoInit:      true,
+			Readme:        "Default",
+			DefaultBranch: "main",
+		})
+		require.NoError(t, err)
+
+		// the called workflow doesn't exist
+		_, err = files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation: "create",
+					TreePath:  ".gitea/workflows/caller.yml",
+					ContentReader: strings.NewReader(`name: test
+on: push
+jobs:
+  call:
+    uses: ./.gitea/workflows/missing.yml
+`),
+				},
+			},
+			Message:   "add workflow calling a missing workflow",
+			OldBranch: "main",
+			NewBranch: "main",
+		})
+		require.NoError(t, err)
+
+		run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{RepoID: repo.ID, WorkflowID: "caller.yml"})
+		assert.Equal(t, actions_model.StatusFailure, run.Status)
+		assert.Contains(t, run.ErrorMessage, "missing.yml")
+		job := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{RunID: run.ID})
+		assert.Equal(t, actions_model.StatusFailure, job.Status)
+
+		session := loginUser(t, "user2")
+		req := NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/%s/actions/runs/%d", repo.Name, run.Index), map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		var view actions_web.ViewResponse
+		DecodeJSON(t, resp, &view)
+		assert.Equal(t, run.ErrorMessage, view.State.Run.ErrorMessage)
+		assert.False(t, view.State.Run.CanRerun)
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/%s/actions/runs/%d/rerun", repo.Name, run.Index), map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	admin_model "code.gitea.io/gitea/models/admin"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminApprovals(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Admin.RequireSecondApproval, true)()
+
+	// user2 is the second administrator
+	_, err := db.GetEngine(t.Context()).ID(2).Cols("is_admin").Update(&user_model.User{IsAdmin: true})
+	require.NoError(t, err)
+
+	user1Token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin, auth_model.AccessTokenScopeWriteOrganization)
+	user2Token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin)
+
+	t.Run("API", func(t *testing.T) {
+		var r api.AdminApprovalRequest
+		resp := MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/admin/users/user9").AddTokenAuth(user1Token), http.StatusAccepted)
+		DecodeJSON(t, resp, &r)
+		assert.Equal(t, "delete_user", r.Action)
+		assert.Equal(t, "pending", r.Status)
+		assert.Equal(t, "user1", r.Requester.UserName)
+		unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 9})
+
+		var requests []*api.AdminApprovalRequest
+		DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/approvals?status=pending").AddTokenAuth(user2Token), http.StatusOK), &requests)
+		if assert.Len(t, requests, 1) {
+			assert.Equal(t, r.ID, requests[0].ID)
+		}
+
+		// the requester can't approve their own request
+		MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("/api/v1/admin/approvals/%d/approve", r.ID)).AddTokenAuth(user1Token), http.StatusUnprocessableEntity)
+
+		resp = MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("/api/v1/admin/approvals/%d/approve", r.ID)).AddTokenAuth(user2Token), http.StatusOK)
+		DecodeJSON(t, resp, &r)
+		assert.Equal(t, "approved", r.Status)
+		assert.Equal(t, "user2", r.Approver.UserName)
+		unittest.AssertNotExistsBean(t, &user_model.User{ID: 9})
+
+		MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("/api/v1/admin/approvals/%d/reject", r.ID)).AddTokenAuth(user2Token), http.StatusUnprocessableEntity)
+		MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/approvals/9999/approve").AddTokenAuth(user2Token), http.StatusNotFound)
+	})
+
+	t.Run("DeleteOrgAsAdmin", func(t *testing.T) {
+		// user1 doesn't own org3, the deletion has to be approved
+		var r api.AdminApprovalRequest
+		DecodeJSON(t, MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/orgs/org3").AddTokenAuth(user1Token), http.StatusAccepted), &r)
+		assert.Equal(t, "delete_org", r.Action)
+		assert.Equal(t, "org3", r.TargetName)
+		unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	})
+
+	t.Run("DeleteRepoAsAdmin", func(t *testing.T) {
+		// user1 doesn't own the repositories, their deletion has to be approved
+		token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteRepository)
+		var r api.AdminApprovalRequest
+		DecodeJSON(t, MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/repos/user5/repo4").AddTokenAuth(token), http.StatusAccepted), &r)
+		assert.Equal(t, "delete_repo", r.Action)
+		assert.Equal(t, "user5/repo4", r.TargetName)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+
+		session := loginUser(t, "user1")
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/settings", map[string]string{
+			"_csrf":     GetUserCSRFToken(t, session),
+			"action":    "delete",
+			"repo_name": "repo1",
+		})
+		resp := session.MakeRequest(t, req, http.StatusSeeOther)
+		assert.Equal(t, "/user2/repo1/settings", test.RedirectURL(resp))
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		unittest.AssertExistsAndLoadBean(t, &admin_model.ApprovalRequest{Action: admin_model.ApprovalActionDeleteRepo, TargetID: 1, RequesterID: 1})
+	})
+
+	t.Run("OffboardAndAnonymizeUser", func(t *testing.T) {
+		var r api.AdminApprovalRequest
+		DecodeJSON(t, MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/admin/users/user10/offboard", &api.OffboardUserOption{}).AddTokenAuth(user1Token), http.StatusAccepted), &r)
+		assert.Equal(t, "offboard_user", r.Action)
+		DecodeJSON(t, MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/users/user10/anonymize").AddTokenAuth(user1Token), http.StatusAccepted), &r)
+		assert.Equal(t, "anonymize_user", r.Action)
+		user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+		assert.Equal(t, "user10", user10.Name)
+		assert.True(t, user10.IsActive)
+	})
+
+	t.Run("CreateAuthSource", func(t *testing.T) {
+		session := loginUser(t, "user1")
+		req := NewRequestWithValues(t, "POST", "/-/admin/auths/new", map[string]string{
+			"_csrf":            GetUserCSRFToken(t, session),
+			"type":             "4", // PAM
+			"name":             "pam-approval",
+			"pam_service_name": "gitea",
+			"is_active":        "on",
+		})
+		resp := session.MakeRequest(t, req, http.StatusSeeOther)
+		assert.Equal(t, "/-/admin/auths", test.RedirectURL(resp))
+		unittest.AssertNotExistsBean(t, &auth_model.Source{Name: "pam-approval"})
+		r := unittest.AssertExistsAndLoadBean(t, &admin_model.ApprovalRequest{Action: admin_model.ApprovalActionCreateAuthSource, TargetName: "pam-approval"})
+		assert.Equal(t, admin_model.ApprovalStatusPending, r.Status)
+
+		// the approver reviews the settings of the new source
+		resp = loginUser(t, "user2").MakeRequest(t, NewRequest(t, "GET", "/-/admin/approvals"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Cfg.ServiceName")
+
+		MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("/api/v1/admin/approvals/%d/approve", r.ID)).AddTokenAuth(user2Token), http.StatusOK)
+		unittest.AssertExistsAndLoadBean(t, &auth_model.Source{Name: "pam-approval", Type: auth_model.PAM})
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		session := loginUser(t, "user1")
+		req := NewRequestWithValues(t, "POST", "/-/admin/users/8/delete", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+		r := unittest.AssertExistsAndLoadBean(t, &admin_model.ApprovalRequest{Action: admin_model.ApprovalActionDeleteUser, TargetID: 8})
+		assert.Equal(t, admin_model.ApprovalStatusPending, r.Status)
+
+		session2 := loginUser(t, "user2")
+		resp := session2.MakeRequest(t, NewRequest(t, "GET", "/-/admin/approvals"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf("/-/admin/approvals/%d/reject", r.ID))
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/admin/approvals/%d/reject", r.ID), map[string]string{
+			"_csrf": GetUserCSRFToken(t, session2),
+		})
+		session2.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertExistsAndLoadBean(t, &admin_model.ApprovalRequest{ID: r.ID, Status: admin_model.ApprovalStatusRejected, ApproverID: 2})
+		unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+	})
+}
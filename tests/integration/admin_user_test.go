@@ -8,12 +8,20 @@ import (
 	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
+	auth_model "code.gitea.io/gitea/models/auth"
+	system_model "code.gitea.io/gitea/models/system"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	user_service "code.gitea.io/gitea/services/user"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAdminViewUsers(t *testing.T) {
@@ -107,3 +115,133 @@ func TestAdminDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminImpersonateUser(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Admin.EnableImpersonation, true)()
+
+	session := loginUser(t, "user1")
+	adminSessionID := session.GetSiteCookie(setting.SessionConfig.CookieName)
+	req := NewRequestWithValues(t, "POST", "/-/admin/users/2/impersonate", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	assert.NotEqual(t, adminSessionID, session.GetSiteCookie(setting.SessionConfig.CookieName))
+
+	// the admin has no two-factor authentication, their state mustn't be carried over to the impersonated user
+	t.Run("NoStaleSessionKeys", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.TwoFactorAuthEnforced, true)()
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo2"), http.StatusOK)
+	})
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/user/impersonation/stop")
+	assert.Equal(t, "user2", NewHTMLParser(t, resp.Body).Find("#username").AttrOr("value", ""))
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeImpersonation, Description: "user1 started to impersonate user2 for 30m0s"})
+
+	// changes are recorded
+	req = NewRequestWithValues(t, "POST", "/notifications/purge", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeImpersonation, Description: "[impersonated] user1 as user2: POST /notifications/purge"})
+
+	// credentials and security settings would outlive the impersonation
+	for _, link := range []string{"/user/settings/account", "/user/settings/security", "/user/settings/applications", "/user/settings/keys"} {
+		session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusForbidden)
+	}
+	req = NewRequestWithValues(t, "POST", "/user/settings/applications", map[string]string{
+		"_csrf":      GetUserCSRFToken(t, session),
+		"name":       "impersonated-token",
+		"scope-user": "write:user",
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+	unittest.AssertNotExistsBean(t, &auth_model.AccessToken{UID: 2, Name: "impersonated-token"})
+	session.MakeRequest(t, NewRequest(t, "GET", "/login/oauth/authorize?client_id=da7da3ba-9a13-4167-856f-3899de0b0138&redirect_uri=a&response_type=code&state=thestate"), http.StatusForbidden)
+
+	req = NewRequestWithValues(t, "POST", "/user/impersonation/stop", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "/user/impersonation/stop")
+	assert.Equal(t, "user1", NewHTMLParser(t, resp.Body).Find("#username").AttrOr("value", ""))
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeImpersonation, Description: "user1 stopped to impersonate user2"})
+
+	t.Run("Expired", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Admin.ImpersonationDuration, -time.Minute)()
+
+		req := NewRequestWithValues(t, "POST", "/-/admin/users/2/impersonate", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		assert.Equal(t, "user1", NewHTMLParser(t, resp.Body).Find("#username").AttrOr("value", ""))
+		unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeImpersonation, Description: "The impersonation of user2 by user1 has expired"})
+	})
+
+	t.Run("NotAllowedAnymore", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/-/admin/users/2/impersonate", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		assert.Equal(t, "user2", NewHTMLParser(t, resp.Body).Find("#username").AttrOr("value", ""))
+
+		// the permission is checked again on every request
+		defer test.MockVariableValue(&setting.Admin.EnableImpersonation, false)()
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), "/user/impersonation/stop")
+		assert.Equal(t, "user1", NewHTMLParser(t, resp.Body).Find("#username").AttrOr("value", ""))
+	})
+
+	t.Run("OptedOut", func(t *testing.T) {
+		org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+		require.NoError(t, user_service.UpdateUser(t.Context(), org3, &user_service.UpdateOptions{DisallowImpersonation: optional.Some(true)}))
+
+		req := NewRequestWithValues(t, "POST", "/-/admin/users/2/impersonate", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		assert.Equal(t, "user1", NewHTMLParser(t, resp.Body).Find("#username").AttrOr("value", ""))
+	})
+}
+
+func TestAdminOffboardUser(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user1")
+	req := NewRequestWithValues(t, "POST", "/-/admin/users/10/offboard", map[string]string{
+		"_csrf":          GetUserCSRFToken(t, session),
+		"issue_assignee": "user2",
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+
+	user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+	assert.False(t, user10.IsActive)
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{
+		Type:        system_model.NoticeOffboarding,
+		Description: "user1 offboarded user10: 0 repositories transferred, 3 archived (user10/repo6, user10/repo7, user10/repo8), 0 issue assignments reassigned to user2",
+	})
+}
+
+func TestAdminAnonymizeUser(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user1")
+	req := NewRequestWithValues(t, "POST", "/-/admin/users/10/anonymize", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+
+	user10 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+	assert.Equal(t, "deleted-user-10", user10.Name)
+	assert.False(t, user10.IsActive)
+	unittest.AssertExistsAndLoadBean(t, &system_model.Notice{Type: system_model.NoticeOffboarding, Description: "user1 anonymized the user 10"})
+
+	// the former name isn't redirected to the anonymized user
+	MakeRequest(t, NewRequest(t, "GET", "/user10"), http.StatusNotFound)
+}
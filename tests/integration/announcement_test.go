@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnouncements(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	user2Token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteUser)
+
+	createAnnouncement := func(t *testing.T, opts api.CreateAnnouncementOption, status int) *api.Announcement {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/admin/announcements", opts).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, status)
+		if status != http.StatusCreated {
+			return nil
+		}
+		announcement := &api.Announcement{}
+		DecodeJSON(t, resp, announcement)
+		return announcement
+	}
+	listMyAnnouncements := func(t *testing.T) (contents []string) {
+		var announcements []*api.Announcement
+		DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/user/announcements").AddTokenAuth(user2Token), http.StatusOK), &announcements)
+		for _, a := range announcements {
+			contents = append(contents, a.Content)
+		}
+		return contents
+	}
+
+	all := createAnnouncement(t, api.CreateAnnouncementOption{Type: "info", Content: "Welcome to **Gitea**", Audience: "all"}, http.StatusCreated)
+	assert.True(t, all.Active)
+	assert.True(t, all.Dismissible)
+	org := createAnnouncement(t, api.CreateAnnouncementOption{Type: "warning", Content: "org3 news", Audience: "org_members", Org: "org3", Dismissible: util.ToPointer(false)}, http.StatusCreated)
+	assert.Equal(t, "org3", org.Org)
+	admins := createAnnouncement(t, api.CreateAnnouncementOption{Type: "maintenance", Content: "admins only", Audience: "admins"}, http.StatusCreated)
+	scheduled := createAnnouncement(t, api.CreateAnnouncementOption{Type: "info", Content: "upcoming", Audience: "all", StartTime: util.ToPointer(time.Now().Add(time.Hour))}, http.StatusCreated)
+	assert.False(t, scheduled.Active)
+
+	createAnnouncement(t, api.CreateAnnouncementOption{Type: "unknown", Content: "c", Audience: "all"}, http.StatusUnprocessableEntity)
+	createAnnouncement(t, api.CreateAnnouncementOption{Type: "info", Content: "c", Audience: "org_members"}, http.StatusUnprocessableEntity)
+	createAnnouncement(t, api.CreateAnnouncementOption{Type: "info", Content: "c", Audience: "org_members", Org: "org-not-exist"}, http.StatusUnprocessableEntity)
+	createAnnouncement(t, api.CreateAnnouncementOption{
+		Type: "info", Content: "c", Audience: "all",
+		StartTime: util.ToPointer(time.Now().Add(time.Hour)), EndTime: util.ToPointer(time.Now()),
+	}, http.StatusUnprocessableEntity)
+
+	// a non-admin can't manage the announcements
+	MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/admin/announcements", api.CreateAnnouncementOption{Type: "info", Content: "c", Audience: "all"}).AddTokenAuth(user2Token), http.StatusForbidden)
+
+	var announcements []*api.Announcement
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/announcements").AddTokenAuth(adminToken), http.StatusOK)
+	DecodeJSON(t, resp, &announcements)
+	assert.Len(t, announcements, 4)
+	assert.Equal(t, "4", resp.Header().Get("X-Total-Count"))
+
+	t.Run("Banner", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/explore/repos"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Welcome to <strong>Gitea</strong>")
+		assert.NotContains(t, resp.Body.String(), "org3 news")
+		assert.NotContains(t, resp.Body.String(), "upcoming")
+
+		resp = loginUser(t, "user1").MakeRequest(t, NewRequest(t, "GET", "/explore/repos"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "admins only")
+	})
+
+	t.Run("Dismiss", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Welcome to **Gitea**", "org3 news"}, listMyAnnouncements(t))
+
+		MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("/api/v1/user/announcements/%d/dismiss", org.ID)).AddTokenAuth(user2Token), http.StatusUnprocessableEntity)
+		MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("/api/v1/user/announcements/%d/dismiss", admins.ID)).AddTokenAuth(user2Token), http.StatusNotFound)
+
+		session := loginUser(t, "user2")
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/explore/repos"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf("/user/announcements/%d/dismiss", all.ID))
+		session.MakeRequest(t, NewRequestWithValues(t, "POST", fmt.Sprintf("/user/announcements/%d/dismiss", all.ID), map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		}), http.StatusOK)
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/explore/repos"), http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), "Welcome to <strong>Gitea</strong>")
+		assert.Contains(t, resp.Body.String(), "org3 news")
+		assert.ElementsMatch(t, []string{"org3 news"}, listMyAnnouncements(t))
+	})
+
+	t.Run("Edit", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/admin/announcements/%d", scheduled.ID), api.EditAnnouncementOption{
+			Content:   util.ToPointer("now"),
+			StartTime: &time.Time{},
+		}).AddTokenAuth(adminToken)
+		edited := &api.Announcement{}
+		DecodeJSON(t, MakeRequest(t, req, http.StatusOK), edited)
+		assert.True(t, edited.Active)
+		assert.Nil(t, edited.StartTime)
+		assert.ElementsMatch(t, []string{"org3 news", "now"}, listMyAnnouncements(t))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/admin/announcements/%d", org.ID)).AddTokenAuth(adminToken), http.StatusNoContent)
+		MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/api/v1/admin/announcements/%d", org.ID)).AddTokenAuth(adminToken), http.StatusNotFound)
+		assert.ElementsMatch(t, []string{"now"}, listMyAnnouncements(t))
+	})
+}
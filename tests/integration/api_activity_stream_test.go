@@ -0,0 +1,106 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIActivityStream(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadOrganization)
+
+	// stream reads the stream until it is closed by the client
+	stream := func(t *testing.T, url, lastEventID string, expectedStatus int) string {
+		ctx, cancel := context.WithTimeout(t.Context(), 300*time.Millisecond)
+		defer cancel()
+		req := NewRequest(t, "GET", url).AddTokenAuth(token)
+		if lastEventID != "" {
+			req.SetHeader("Last-Event-ID", lastEventID)
+		}
+		req.Request = req.WithContext(ctx)
+		resp := MakeRequest(t, req, expectedStatus)
+		return resp.Body.String()
+	}
+
+	latestID, err := activities_model.GetLatestActionID(t.Context())
+	require.NoError(t, err)
+	cursor := strconv.FormatInt(latestID, 10)
+
+	for _, repo := range []string{"user2/repo1", "org3/repo3"} {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/"+repo+"/issues", &api.CreateIssueOption{Title: "streamed issue"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	t.Run("Repository", func(t *testing.T) {
+		body := stream(t, "/api/v1/repos/user2/repo1/events/stream", cursor, http.StatusOK)
+		assert.Contains(t, body, "event: activity\n")
+		assert.Contains(t, body, `"op_type":"create_issue"`)
+		assert.Contains(t, body, `streamed issue`)
+		assert.NotContains(t, body, `"full_name":"org3/repo3"`)
+
+		// the stream is resumed after the last event
+		action := &activities_model.Action{}
+		has, err := db.GetEngine(t.Context()).Where("repo_id = 1 AND user_id = act_user_id").Desc("id").Get(action)
+		require.NoError(t, err)
+		require.True(t, has)
+		assert.Contains(t, body, "id: "+strconv.FormatInt(action.ID, 10)+"\n")
+		body = stream(t, "/api/v1/repos/user2/repo1/events/stream?last_event_id="+strconv.FormatInt(action.ID, 10), "", http.StatusOK)
+		assert.NotContains(t, body, "event: activity\n")
+
+		// without a cursor only the new activities are streamed
+		body = stream(t, "/api/v1/repos/user2/repo1/events/stream", "", http.StatusOK)
+		assert.NotContains(t, body, "event: activity\n")
+
+		stream(t, "/api/v1/repos/user2/repo1/events/stream", "invalid", http.StatusBadRequest)
+	})
+
+	t.Run("Organization", func(t *testing.T) {
+		body := stream(t, "/api/v1/orgs/org3/events/stream", cursor, http.StatusOK)
+		assert.Contains(t, body, `"full_name":"org3/repo3"`)
+		assert.NotContains(t, body, `"full_name":"user2/repo1"`)
+	})
+
+	t.Run("DeletedToken", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.UI.Notification.EventSourceUpdateTime, 50*time.Millisecond)()
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		accessToken, err := auth_model.GetAccessTokenBySHA(t.Context(), token)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		defer cancel()
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			assert.NoError(t, auth_model.DeleteAccessTokenByID(t.Context(), accessToken.ID, accessToken.UID))
+		}()
+		// the stream is closed once the token has been deleted
+		start := time.Now()
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/events/stream").AddTokenAuth(token)
+		req.Request = req.WithContext(ctx)
+		MakeRequest(t, req, http.StatusOK)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("Permission", func(t *testing.T) {
+		token := getUserToken(t, "user4", auth_model.AccessTokenScopeReadRepository)
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo2/events/stream").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
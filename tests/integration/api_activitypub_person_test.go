@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/activitypub"
 	"code.gitea.io/gitea/modules/setting"
@@ -23,6 +25,7 @@ import (
 func TestActivityPubPerson(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 	defer test.MockVariableValue(&setting.Federation.Enabled, true)()
+	defer test.MockVariableValue(&setting.Federation.AllowedHostList, "loopback")()
 	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
 
 	t.Run("ExistingPerson", func(t *testing.T) {
@@ -77,12 +80,83 @@ func TestActivityPubPerson(t *testing.T) {
 		user2inboxurl := srv.URL + "/api/v1/activitypub/user-id/2/inbox"
 
 		// Signed request succeeds
-		resp, err := c.Post([]byte{}, user2inboxurl)
+		follow := ap.FollowNew(ap.IRI(srv.URL+"/api/v1/activitypub/user-id/1#follow"), ap.IRI(srv.URL+"/api/v1/activitypub/user-id/2"))
+		follow.Actor = ap.IRI(srv.URL + "/api/v1/activitypub/user-id/1")
+		body, err := follow.MarshalJSON()
+		assert.NoError(t, err)
+		resp, err := c.Post(body, user2inboxurl)
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		unittest.AssertExistsAndLoadBean(t, &activities_model.FederatedFollower{UserID: 2, ActorIRI: srv.URL + "/api/v1/activitypub/user-id/1"})
+
+		req := NewRequest(t, "GET", "/api/v1/activitypub/user-id/2/followers?page=1")
+		resp2 := MakeRequest(t, req, http.StatusOK)
+		var followers ap.OrderedCollectionPage
+		assert.NoError(t, followers.UnmarshalJSON(resp2.Body.Bytes()))
+		assert.EqualValues(t, 1, followers.TotalItems)
+		assert.Equal(t, ap.ItemCollection{ap.IRI(srv.URL + "/api/v1/activitypub/user-id/1")}, followers.OrderedItems)
+
+		// Signed request without an activity fails
+		resp, err = c.Post([]byte{}, user2inboxurl)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		// Undo the follow
+		undo := ap.UndoNew(ap.IRI(srv.URL+"/api/v1/activitypub/user-id/1#undo"), follow)
+		undo.Actor = follow.Actor
+		body, err = undo.MarshalJSON()
+		assert.NoError(t, err)
+		resp, err = c.Post(body, user2inboxurl)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		unittest.AssertNotExistsBean(t, &activities_model.FederatedFollower{UserID: 2})
 
 		// Unsigned request fails
-		req := NewRequest(t, "POST", user2inboxurl)
+		req = NewRequest(t, "POST", user2inboxurl)
 		MakeRequest(t, req, http.StatusInternalServerError)
 	})
+	t.Run("ForgedActor", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		srv := httptest.NewServer(testWebRoutes)
+		defer srv.Close()
+		defer test.MockVariableValue(&setting.AppURL, srv.URL+"/")()
+
+		user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+		publicKeyPem, err := activitypub.GetPublicKey(t.Context(), user1)
+		assert.NoError(t, err)
+		var actorIRI, ownerIRI string
+		var remote *httptest.Server
+		remote = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := ap.PersonNew(ap.IRI(actorIRI))
+			actor.Inbox = ap.IRI(actorIRI + "/inbox")
+			actor.PublicKey.ID = ap.IRI(remote.URL + "/actor#main-key")
+			actor.PublicKey.Owner = ap.IRI(ownerIRI)
+			actor.PublicKey.PublicKeyPem = publicKeyPem
+			body, _ := actor.MarshalJSON()
+			w.Header().Set("Content-Type", activitypub.ActivityStreamsContentType)
+			_, _ = w.Write(body)
+		}))
+		defer remote.Close()
+
+		c, err := activitypub.NewClient(t.Context(), user1, remote.URL+"/actor#main-key")
+		assert.NoError(t, err)
+		localActorIRI := srv.URL + "/api/v1/activitypub/user-id/1"
+		for _, forged := range []struct{ actor, owner string }{
+			// the key document of another origin claims to be user1 of this instance
+			{actor: localActorIRI, owner: localActorIRI},
+			// the key is owned by another actor
+			{actor: remote.URL + "/actor", owner: localActorIRI},
+		} {
+			actorIRI, ownerIRI = forged.actor, forged.owner
+			follow := ap.FollowNew(ap.IRI(actorIRI+"#follow"), ap.IRI(srv.URL+"/api/v1/activitypub/user-id/2"))
+			follow.Actor = ap.IRI(actorIRI)
+			body, err := follow.MarshalJSON()
+			assert.NoError(t, err)
+			resp, err := c.Post(body, srv.URL+"/api/v1/activitypub/user-id/2/inbox")
+			assert.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+			unittest.AssertNotExistsBean(t, &activities_model.FederatedFollower{UserID: 2})
+		}
+	})
 }
@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/routers"
+	"code.gitea.io/gitea/services/migrations"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityPubFederatedPullRequest(t *testing.T) {
+	defer test.MockVariableValue(&setting.Federation.Enabled, true)()
+	defer test.MockVariableValue(&setting.Federation.AllowedHostList, "loopback")()
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+	defer test.MockVariableValue(&setting.Migrations.AllowLocalNetworks, true)()
+	require.NoError(t, migrations.Init())
+	defer func() { require.NoError(t, migrations.Init()) }()
+
+	// the fork and the origin repository are on the same instance, which federates with itself
+	onGiteaRun(t, testActivityPubFederatedPullRequest)
+}
+
+func testActivityPubFederatedPullRequest(t *testing.T, _ *url.URL) {
+	origin := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	token1 := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+	token2 := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+
+	t.Run("FederationDisabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.Federation.Enabled, false)()
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/federated-forks", &api.CreateFederatedForkOption{
+			OriginURL: setting.AppURL + "user2/repo1",
+			Name:      "repo1-federated",
+		}).AddTokenAuth(token1)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/federated-forks", &api.CreateFederatedForkOption{
+		OriginURL: setting.AppURL + "user2/repo1",
+		Name:      "repo1-federated",
+	}).AddTokenAuth(token1)
+	var fork api.Repository
+	DecodeJSON(t, MakeRequest(t, req, http.StatusCreated), &fork)
+	assert.Equal(t, "user1/repo1-federated", fork.FullName)
+	federatedFork := unittest.AssertExistsAndLoadBean(t, &repo_model.FederatedFork{RepoID: fork.ID})
+	assert.Equal(t, fmt.Sprintf("%sapi/v1/activitypub/repository-id/%d", setting.AppURL, origin.ID), federatedFork.OriginIRI)
+
+	// a branch of the fork, which isn't pushed to the origin
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user1/repo1-federated/contents/federated.txt", &api.CreateFileOptions{
+		FileOptions:   api.FileOptions{NewBranchName: "federated", Message: "add federated.txt"},
+		ContentBase64: "ZmVkZXJhdGVk",
+	}).AddTokenAuth(token1)
+	MakeRequest(t, req, http.StatusCreated)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user1/repo1-federated/federated-pulls", &api.CreateFederatedPullRequestOption{
+		Head:  "not-exist",
+		Base:  "master",
+		Title: "federated pull request",
+	}).AddTokenAuth(token1)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/federated-pulls", &api.CreateFederatedPullRequestOption{
+		Head:  "master",
+		Base:  "master",
+		Title: "federated pull request",
+	}).AddTokenAuth(token2)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user1/repo1-federated/federated-pulls", &api.CreateFederatedPullRequestOption{
+		Head:  "federated",
+		Base:  "master",
+		Title: "federated pull request",
+		Body:  "offered by a fork",
+	}).AddTokenAuth(token1)
+	var tracking api.Issue
+	DecodeJSON(t, MakeRequest(t, req, http.StatusCreated), &tracking)
+	assert.Nil(t, tracking.PullRequest)
+
+	// the origin opens the pull request and accepts the offer
+	var pull *issues_model.Issue
+	require.Eventually(t, func() bool {
+		pull = unittest.GetBean(t, &issues_model.Issue{RepoID: origin.ID, Title: "federated pull request", IsPull: true})
+		return pull != nil
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "offered by a fork", pull.Content)
+	poster := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: pull.PosterID})
+	assert.Equal(t, user_model.UserTypeRemoteUser, poster.Type)
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{IssueID: pull.ID})
+	assert.Equal(t, poster.Name+"/federated", pr.HeadBranch)
+	assert.Equal(t, issues_model.PullRequestFlowAGit, pr.Flow)
+
+	pullURL := fmt.Sprintf("%suser2/repo1/pulls/%d", setting.AppURL, pull.Index)
+	require.Eventually(t, func() bool {
+		fi, err := issues_model.GetFederatedIssueByIssueID(t.Context(), tracking.ID)
+		return err == nil && fi.TicketIRI == pullURL
+	}, 10*time.Second, 100*time.Millisecond)
+
+	t.Run("Comments", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the comments of the pull request are posted to the tracking issue and the other way around
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d/comments", pull.Index), &api.CreateIssueCommentOption{
+			Body: "comment of the origin",
+		}).AddTokenAuth(token2)
+		MakeRequest(t, req, http.StatusCreated)
+		require.Eventually(t, func() bool {
+			return unittest.GetBean(t, &issues_model.Comment{IssueID: tracking.ID, Content: "comment of the origin"}) != nil
+		}, 10*time.Second, 100*time.Millisecond)
+
+		req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user1/repo1-federated/issues/%d/comments", tracking.Index), &api.CreateIssueCommentOption{
+			Body: "comment of the fork",
+		}).AddTokenAuth(token1)
+		MakeRequest(t, req, http.StatusCreated)
+		require.Eventually(t, func() bool {
+			return unittest.GetBean(t, &issues_model.Comment{IssueID: pull.ID, Content: "comment of the fork"}) != nil
+		}, 10*time.Second, 100*time.Millisecond)
+
+		// the comments received from the other side aren't sent back
+		time.Sleep(time.Second)
+		assert.Equal(t, 1, unittest.GetCount(t, &issues_model.Comment{IssueID: tracking.ID, Content: "comment of the origin"}))
+		assert.Equal(t, 1, unittest.GetCount(t, &issues_model.Comment{IssueID: pull.ID, Content: "comment of the fork"}))
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the commits pushed to the head branch of the fork update the pull request
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user1/repo1-federated/contents/federated-update.txt", &api.CreateFileOptions{
+			FileOptions:   api.FileOptions{BranchName: "federated", Message: "add federated-update.txt"},
+			ContentBase64: "dXBkYXRl",
+		}).AddTokenAuth(token1)
+		var resp api.FileResponse
+		DecodeJSON(t, MakeRequest(t, req, http.StatusCreated), &resp)
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), origin)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		require.Eventually(t, func() bool {
+			commitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+			return err == nil && commitID == resp.Commit.SHA
+		}, 10*time.Second, 100*time.Millisecond)
+		// the commits are pushed to the pull request by its author
+		assert.Equal(t, 2, unittest.GetCount(t, &issues_model.Comment{IssueID: pull.ID, Type: issues_model.CommentTypePullRequestPush, PosterID: poster.ID}))
+	})
+}
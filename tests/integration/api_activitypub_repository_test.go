@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/activitypub"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/routers"
+	"code.gitea.io/gitea/tests"
+
+	ap "github.com/go-ap/activitypub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityPubRepository(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Federation.Enabled, true)()
+	defer test.MockVariableValue(&setting.Federation.AllowedHostList, "loopback")()
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+
+	t.Run("ExistingRepository", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/api/v1/activitypub/repository-id/1")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var actor ap.Actor
+		require.NoError(t, actor.UnmarshalJSON(resp.Body.Bytes()))
+		assert.EqualValues(t, "Repository", actor.Type)
+		assert.Equal(t, "user2/repo1", actor.PreferredUsername.String())
+		assert.Regexp(t, "activitypub/repository-id/1/inbox$", actor.Inbox.GetID().String())
+		assert.Regexp(t, "activitypub/repository-id/1/followers$", actor.Followers.GetID().String())
+		assert.Regexp(t, "activitypub/user-id/2$", actor.AttributedTo.GetID().String())
+		assert.Equal(t, actor.GetID().String()+"#main-key", actor.PublicKey.ID.String())
+		assert.Regexp(t, "^-----BEGIN PUBLIC KEY-----", actor.PublicKey.PublicKeyPem)
+	})
+
+	t.Run("PrivateRepository", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+		require.True(t, repo.IsPrivate)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/activitypub/repository-id/2"), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/activitypub/repository-id/999999"), http.StatusNotFound)
+	})
+
+	t.Run("FollowRepository", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		srv := httptest.NewServer(testWebRoutes)
+		defer srv.Close()
+		defer test.MockVariableValue(&setting.AppURL, srv.URL+"/")()
+
+		// a remote actor, whose key is the one of user1 to sign its requests
+		signer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+		publicKeyPem, err := activitypub.GetPublicKey(t.Context(), signer)
+		require.NoError(t, err)
+		received := make(chan *ap.Activity, 10)
+		var remote *httptest.Server
+		remote = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/actor":
+				actor := ap.PersonNew(ap.IRI(remote.URL + "/actor"))
+				actor.Inbox = ap.IRI(remote.URL + "/inbox")
+				actor.PublicKey.ID = ap.IRI(remote.URL + "/actor#main-key")
+				actor.PublicKey.Owner = actor.ID
+				actor.PublicKey.PublicKeyPem = publicKeyPem
+				body, _ := actor.MarshalJSON()
+				w.Header().Set("Content-Type", activitypub.ActivityStreamsContentType)
+				_, _ = w.Write(body)
+			case "/inbox":
+				body, _ := io.ReadAll(r.Body)
+				item, err := ap.UnmarshalJSON(body)
+				assert.NoError(t, err, string(body))
+				activity, err := ap.ToActivity(item)
+				assert.NoError(t, err)
+				received <- activity
+				w.WriteHeader(http.StatusAccepted)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer remote.Close()
+		receive := func(t *testing.T) *ap.Activity {
+			select {
+			case activity := <-received:
+				return activity
+			case <-time.After(10 * time.Second):
+				require.FailNow(t, "Timeout: no activity was delivered to the remote inbox")
+				return nil
+			}
+		}
+
+		c, err := activitypub.NewClient(t.Context(), signer, remote.URL+"/actor#main-key")
+		require.NoError(t, err)
+		repoIRI := srv.URL + "/api/v1/activitypub/repository-id/1"
+		follow := ap.FollowNew(ap.IRI(remote.URL+"/actor#follow"), ap.IRI(repoIRI))
+		follow.Actor = ap.IRI(remote.URL + "/actor")
+		body, err := follow.MarshalJSON()
+		require.NoError(t, err)
+		resp, err := c.Post(body, repoIRI+"/inbox")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		accept := receive(t)
+		assert.Equal(t, ap.AcceptType, accept.Type)
+		assert.Equal(t, ap.IRI(repoIRI), accept.Actor.GetLink())
+
+		req := NewRequest(t, "GET", "/api/v1/activitypub/repository-id/1/followers")
+		var followers ap.OrderedCollection
+		require.NoError(t, followers.UnmarshalJSON(MakeRequest(t, req, http.StatusOK).Body.Bytes()))
+		assert.EqualValues(t, 1, followers.TotalItems)
+
+		// a new issue of the repository is delivered to its followers
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteIssue)
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{
+			Title: "federated issue",
+			Body:  "issue body",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		create := receive(t)
+		assert.Equal(t, ap.CreateType, create.Type)
+		assert.Equal(t, ap.IRI(repoIRI), create.Actor.GetLink())
+		// the ForgeFed Ticket type is unknown to the ActivityStreams decoder, so only the activity is checked
+		assert.Regexp(t, "^"+regexp.QuoteMeta(srv.URL)+"/user2/repo1/issues/[0-9]+#create$", create.ID.String())
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIAdminIndexers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	userToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin)
+
+	t.Run("ListIndexers", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/indexers").AddTokenAuth(userToken), http.StatusForbidden)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/indexers").AddTokenAuth(adminToken), http.StatusOK)
+		var indexers []*api.Indexer
+		DecodeJSON(t, resp, &indexers)
+		names := make(map[string]*api.Indexer, len(indexers))
+		for _, indexer := range indexers {
+			names[indexer.Name] = indexer
+		}
+		require.Contains(t, names, "code")
+		assert.True(t, names["code"].Enabled)
+		assert.Equal(t, "bleve", names["code"].Type)
+		require.Contains(t, names, "symbol")
+		assert.False(t, names["symbol"].Enabled)
+	})
+
+	t.Run("Reindex", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/indexers/repos/user2/repo1/reindex?indexer=unknown").AddTokenAuth(adminToken), http.StatusUnprocessableEntity)
+		MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/indexers/repos/user2/repo1/reindex?indexer=symbol").AddTokenAuth(adminToken), http.StatusUnprocessableEntity)
+		MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/indexers/repos/user2/not-exist/reindex").AddTokenAuth(adminToken), http.StatusNotFound)
+
+		require.NoError(t, repo_model.UpdateIndexerFailure(t.Context(), repo.ID, repo_model.RepoIndexerTypeCode, errors.New("broken index")))
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/indexers/failures?indexer=code").AddTokenAuth(adminToken), http.StatusOK)
+		var failures []*api.RepoIndexerStatus
+		DecodeJSON(t, resp, &failures)
+		// the most recent failure comes first
+		require.NotEmpty(t, failures)
+		assert.Equal(t, strconv.Itoa(len(failures)), resp.Header().Get("X-Total-Count"))
+		assert.Equal(t, "code", failures[0].Indexer)
+		assert.Equal(t, "user2/repo1", failures[0].Repository.FullName)
+		assert.Equal(t, "broken index", failures[0].Error)
+		assert.NotNil(t, failures[0].Failed)
+
+		MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/indexers/repos/user2/repo1/reindex?indexer=code").AddTokenAuth(adminToken), http.StatusAccepted)
+
+		// the repository is indexed again in the background, which clears the failure
+		assert.Eventually(t, func() bool {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/indexers/repos/user2/repo1").AddTokenAuth(adminToken), http.StatusOK)
+			var statuses []*api.RepoIndexerStatus
+			DecodeJSON(t, resp, &statuses)
+			for _, status := range statuses {
+				if status.Indexer == "code" {
+					return status.CommitSHA != "" && status.CommitSHA == status.HeadCommitSHA && status.Error == "" && status.Indexed != nil
+				}
+			}
+			return false
+		}, 10*time.Second, 100*time.Millisecond)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/indexers/failures?indexer=code").AddTokenAuth(adminToken), http.StatusOK)
+		failures = nil
+		DecodeJSON(t, resp, &failures)
+		for _, failure := range failures {
+			assert.NotEqual(t, "user2/repo1", failure.Repository.FullName)
+		}
+	})
+}
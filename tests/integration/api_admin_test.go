@@ -6,17 +6,21 @@ package integration
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/glob"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -304,11 +308,11 @@ func TestAPICron(t *testing.T) {
 			AddTokenAuth(token)
 		resp := MakeRequest(t, req, http.StatusOK)
 
-		assert.Equal(t, "29", resp.Header().Get("X-Total-Count"))
+		assert.Equal(t, "37", resp.Header().Get("X-Total-Count"))
 
 		var crons []api.Cron
 		DecodeJSON(t, resp, &crons)
-		assert.Len(t, crons, 29)
+		assert.Len(t, crons, 37)
 	})
 
 	t.Run("Execute", func(t *testing.T) {
@@ -339,6 +343,28 @@ func TestAPICron(t *testing.T) {
 	})
 }
 
+func TestAPIAdminReloadConfig(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer setting.SetWebhookAllowedHostList(setting.GetWebhookAllowedHostList())
+	setting.SetWebhookAllowedHostList("changed.example.com")
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin)
+	MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/config/reload").AddTokenAuth(token), http.StatusForbidden)
+
+	// the settings are reloaded from the config file
+	token = getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/config/reload").AddTokenAuth(token), http.StatusNoContent)
+	assert.Equal(t, "127.0.0.1", setting.GetWebhookAllowedHostList())
+
+	// nothing is changed if the config file is invalid
+	setting.SetWebhookAllowedHostList("changed.example.com")
+	invalidConf := filepath.Join(t.TempDir(), "app.ini")
+	assert.NoError(t, os.WriteFile(invalidConf, []byte("[mailer]\nENABLED = true\nFROM = invalid <\n"), 0o644))
+	defer test.MockVariableValue(&setting.CustomConf, invalidConf)()
+	MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/config/reload").AddTokenAuth(token), http.StatusUnprocessableEntity)
+	assert.Equal(t, "changed.example.com", setting.GetWebhookAllowedHostList())
+}
+
 func TestAPICreateUser_NotAllowedEmailDomain(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -393,3 +419,33 @@ func TestAPIEditUser_NotAllowedEmailDomain(t *testing.T) {
 	}).AddTokenAuth(token)
 	MakeRequest(t, req, http.StatusOK)
 }
+
+func TestAPIOffboardUser(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	user13 := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "user13"})
+	userToken := getUserToken(t, user13.Name, auth_model.AccessTokenScopeReadUser)
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/admin/users/user13/offboard", &api.OffboardUserOption{RepoOwner: "user-not-exist"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/admin/users/user1/offboard", &api.OffboardUserOption{}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/admin/users/user13/offboard", &api.OffboardUserOption{RepoOwner: "user2"}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var result api.OffboardUserResult
+	DecodeJSON(t, resp, &result)
+	assert.Equal(t, []string{"user13/repo11"}, result.TransferredRepos)
+	assert.Empty(t, result.ArchivedRepos)
+
+	user13 = unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: user13.ID})
+	assert.False(t, user13.IsActive)
+	assert.True(t, user13.ProhibitLogin)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user2", LowerName: "repo11"})
+	unittest.AssertNotExistsBean(t, &auth_model.AccessToken{UID: user13.ID})
+
+	// the revoked token can't be used anymore
+	req = NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(userToken)
+	MakeRequest(t, req, http.StatusUnauthorized)
+}
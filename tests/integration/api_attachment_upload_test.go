@@ -0,0 +1,190 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIResumableReleaseAssetUpload(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	session := loginUser(t, owner.LowerName)
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+
+	r := createNewReleaseUsingAPI(t, token, owner, repo, "resumable-tag", "", "Resumable", "test")
+
+	content := []byte(strings.Repeat("0123456789", 10))
+	uploadsURL := fmt.Sprintf("/api/v1/repos/%s/%s/releases/%d/assets/uploads", owner.Name, repo.Name, r.ID)
+	metadata := "filename " + base64.StdEncoding.EncodeToString([]byte("data.zip"))
+
+	t.Run("MissingTusResumable", func(t *testing.T) {
+		req := NewRequest(t, "POST", uploadsURL).
+			AddTokenAuth(token).
+			SetHeader("Upload-Length", "100").
+			SetHeader("Upload-Metadata", metadata)
+		resp := MakeRequest(t, req, http.StatusPreconditionFailed)
+		assert.Equal(t, "1.0.0", resp.Header().Get("Tus-Version"))
+	})
+
+	req := NewRequest(t, "POST", uploadsURL).
+		AddTokenAuth(token).
+		SetHeader("Tus-Resumable", "1.0.0").
+		SetHeader("Upload-Length", "100").
+		SetHeader("Upload-Metadata", metadata)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	location := resp.Header().Get("Location")
+	assert.Contains(t, location, fmt.Sprintf("/api/v1/repos/%s/%s/attachment-uploads/", owner.Name, repo.Name))
+	uploadURL := location[strings.Index(location, "/api/v1/"):]
+
+	patchChunk := func(t *testing.T, offset int, chunk []byte, checksum string, expectedStatus int) *httptest.ResponseRecorder {
+		req := NewRequestWithBody(t, "PATCH", uploadURL, bytes.NewReader(chunk)).
+			AddTokenAuth(token).
+			SetHeader("Tus-Resumable", "1.0.0").
+			SetHeader("Content-Type", "application/offset+octet-stream").
+			SetHeader("Upload-Offset", fmt.Sprint(offset))
+		if checksum != "" {
+			req.Header.Set("Upload-Checksum", checksum)
+		}
+		return MakeRequest(t, req, expectedStatus)
+	}
+	sha256Checksum := func(chunk []byte) string {
+		sum := sha256.Sum256(chunk)
+		return "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+	}
+
+	req = NewRequest(t, "PATCH", uploadURL).
+		AddTokenAuth(token).
+		SetHeader("Tus-Resumable", "1.0.0")
+	MakeRequest(t, req, http.StatusUnsupportedMediaType)
+
+	patchChunk(t, 0, content[:40], sha256Checksum(content[:39]), 460)
+	resp = patchChunk(t, 0, content[:40], sha256Checksum(content[:40]), http.StatusNoContent)
+	assert.Equal(t, "40", resp.Header().Get("Upload-Offset"))
+	patchChunk(t, 0, content[40:], "", http.StatusConflict)
+
+	req = NewRequest(t, "HEAD", uploadURL).
+		AddTokenAuth(token).
+		SetHeader("Tus-Resumable", "1.0.0")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "40", resp.Header().Get("Upload-Offset"))
+	assert.Equal(t, "100", resp.Header().Get("Upload-Length"))
+	assert.NotEmpty(t, resp.Header().Get("Upload-Expires"))
+
+	// another user can't see the upload
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+	req = NewRequest(t, "HEAD", uploadURL).
+		AddTokenAuth(otherToken).
+		SetHeader("Tus-Resumable", "1.0.0")
+	MakeRequest(t, req, http.StatusNotFound)
+
+	resp = patchChunk(t, 40, content[40:], sha256Checksum(content[40:]), http.StatusCreated)
+	var attachment api.Attachment
+	DecodeJSON(t, resp, &attachment)
+	assert.Equal(t, "data.zip", attachment.Name)
+	assert.EqualValues(t, 100, attachment.Size)
+
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attachment.ID, ReleaseID: r.ID})
+	unittest.AssertCount(t, &repo_model.AttachmentUpload{}, 0)
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequest(t, "POST", uploadsURL).
+			AddTokenAuth(token).
+			SetHeader("Tus-Resumable", "1.0.0").
+			SetHeader("Upload-Length", "100").
+			SetHeader("Upload-Metadata", metadata)
+		location := MakeRequest(t, req, http.StatusCreated).Header().Get("Location")
+		uploadURL := location[strings.Index(location, "/api/v1/"):]
+
+		req = NewRequest(t, "DELETE", uploadURL).
+			AddTokenAuth(token).
+			SetHeader("Tus-Resumable", "1.0.0")
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "HEAD", uploadURL).
+			AddTokenAuth(token).
+			SetHeader("Tus-Resumable", "1.0.0")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("AccessRevoked", func(t *testing.T) {
+		collaborator := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		require.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), repo, collaborator, perm.AccessModeWrite))
+		collaboratorToken := getUserToken(t, collaborator.LowerName, auth_model.AccessTokenScopeWriteRepository)
+
+		req := NewRequest(t, "POST", uploadsURL).
+			AddTokenAuth(collaboratorToken).
+			SetHeader("Tus-Resumable", "1.0.0").
+			SetHeader("Upload-Length", "100").
+			SetHeader("Upload-Metadata", "filename "+base64.StdEncoding.EncodeToString([]byte("revoked.zip")))
+		location := MakeRequest(t, req, http.StatusCreated).Header().Get("Location")
+		uploadURL := location[strings.Index(location, "/api/v1/"):]
+
+		// the upload can't be finished once the collaborator can't write the releases anymore
+		require.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), repo, collaborator, perm.AccessModeRead))
+		req = NewRequestWithBody(t, "PATCH", uploadURL, bytes.NewReader(content)).
+			AddTokenAuth(collaboratorToken).
+			SetHeader("Tus-Resumable", "1.0.0").
+			SetHeader("Content-Type", "application/offset+octet-stream").
+			SetHeader("Upload-Offset", "0")
+		MakeRequest(t, req, http.StatusForbidden)
+		unittest.AssertNotExistsBean(t, &repo_model.Attachment{ReleaseID: r.ID, Name: "revoked.zip"})
+	})
+}
+
+func TestAPIResumableIssueAttachmentUpload(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	session := loginUser(t, owner.LowerName)
+	// the upload is created with the issue scope and finished with the repository scope
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteRepository)
+
+	content := []byte(strings.Repeat("0123456789", 10))
+	req := NewRequest(t, "POST", fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/assets/uploads", owner.Name, repo.Name, issue.Index)).
+		AddTokenAuth(token).
+		SetHeader("Tus-Resumable", "1.0.0").
+		SetHeader("Upload-Length", "100").
+		SetHeader("Upload-Metadata", "filename "+base64.StdEncoding.EncodeToString([]byte("image.png")))
+	location := MakeRequest(t, req, http.StatusCreated).Header().Get("Location")
+	uploadURL := location[strings.Index(location, "/api/v1/"):]
+
+	req = NewRequestWithBody(t, "PATCH", uploadURL, bytes.NewReader(content)).
+		AddTokenAuth(token).
+		SetHeader("Tus-Resumable", "1.0.0").
+		SetHeader("Content-Type", "application/offset+octet-stream").
+		SetHeader("Upload-Offset", "0")
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var attachment api.Attachment
+	DecodeJSON(t, resp, &attachment)
+	assert.Equal(t, "image.png", attachment.Name)
+	assert.EqualValues(t, 100, attachment.Size)
+
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attachment.ID, IssueID: issue.ID})
+	unittest.AssertCount(t, &repo_model.AttachmentUpload{}, 0)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIConditionalRequest(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteUser)
+
+	// assertNotModified checks the response is modified without validators and not modified with its own validators
+	assertNotModified := func(t *testing.T, url string) (etag, lastModified string) {
+		resp := MakeRequest(t, NewRequest(t, "GET", url).AddTokenAuth(token), http.StatusOK)
+		etag, lastModified = resp.Header().Get("ETag"), resp.Header().Get("Last-Modified")
+		assert.NotEmpty(t, etag)
+
+		req := NewRequest(t, "GET", url).AddTokenAuth(token).SetHeader("If-None-Match", etag)
+		resp = MakeRequest(t, req, http.StatusNotModified)
+		assert.Empty(t, resp.Body.String())
+		assert.Equal(t, etag, resp.Header().Get("ETag"))
+
+		if lastModified != "" {
+			req = NewRequest(t, "GET", url).AddTokenAuth(token).SetHeader("If-Modified-Since", lastModified)
+			MakeRequest(t, req, http.StatusNotModified)
+		}
+		return etag, lastModified
+	}
+
+	t.Run("Repository", func(t *testing.T) {
+		etag, lastModified := assertNotModified(t, "/api/v1/repos/user2/repo1")
+		assert.NotEmpty(t, lastModified)
+
+		// starring doesn't update the repository but its counter is part of the ETag
+		MakeRequest(t, NewRequest(t, "PUT", "/api/v1/user/starred/user2/repo1").AddTokenAuth(token), http.StatusNoContent)
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token).SetHeader("If-None-Match", etag)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		etag, _ := assertNotModified(t, "/api/v1/repos/user2/repo1/issues/1")
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/1/comments", &api.CreateIssueCommentOption{Body: "comment"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1").AddTokenAuth(token).SetHeader("If-None-Match", etag)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+	})
+
+	t.Run("Contents", func(t *testing.T) {
+		etag, lastModified := assertNotModified(t, "/api/v1/repos/user2/repo1/contents/README.md")
+		assert.Empty(t, lastModified)
+		assertNotModified(t, "/api/v1/repos/user2/repo1/contents")
+		assertNotModified(t, "/api/v1/repos/user2/repo1/contents-ext/README.md?includes=file_content")
+
+		// the same commit has another ETag for another ref because the links contain the ref
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/contents/README.md?ref=65f1bf27bc3bf70f64657658635e66094edbcb4d").
+			AddTokenAuth(token).SetHeader("If-None-Match", etag)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlResponse struct {
+	Data   any `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+		Path    []any  `json:"path"`
+	} `json:"errors"`
+}
+
+func graphqlQuery(t *testing.T, token, query string, variables map[string]any, data any) *graphqlResponse {
+	t.Helper()
+	req := NewRequestWithJSON(t, "POST", "/api/graphql", map[string]any{"query": query, "variables": variables})
+	if token != "" {
+		req.AddTokenAuth(token)
+	}
+	resp := MakeRequest(t, req, http.StatusOK)
+	// the data is decoded into the value the pointer points to
+	result := graphqlResponse{Data: data}
+	DecodeJSON(t, resp, &result)
+	return &result
+}
+
+func TestAPIGraphQL(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	t.Run("Pagination", func(t *testing.T) {
+		const query = `query($after: String) {
+			repository(owner: "user2", name: "repo1") {
+				fullName
+				issues(first: 1, after: $after) {
+					totalCount
+					nodes { number title state author { login } }
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}`
+		var data struct {
+			Repository struct {
+				FullName string
+				Issues   struct {
+					TotalCount int
+					Nodes      []struct {
+						Number int
+						Title  string
+						State  string
+						Author struct{ Login string }
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}
+		resp := graphqlQuery(t, "", query, nil, &data)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, "user2/repo1", data.Repository.FullName)
+		issues := data.Repository.Issues
+		assert.Equal(t, 2, issues.TotalCount)
+		require.Len(t, issues.Nodes, 1)
+		assert.Equal(t, 1, issues.Nodes[0].Number)
+		assert.Equal(t, "OPEN", issues.Nodes[0].State)
+		assert.Equal(t, "user1", issues.Nodes[0].Author.Login)
+		assert.True(t, issues.PageInfo.HasNextPage)
+
+		resp = graphqlQuery(t, "", query, map[string]any{"after": issues.PageInfo.EndCursor}, &data)
+		assert.Empty(t, resp.Errors)
+		issues = data.Repository.Issues
+		require.Len(t, issues.Nodes, 1)
+		assert.Equal(t, 4, issues.Nodes[0].Number)
+		assert.Equal(t, "CLOSED", issues.Nodes[0].State)
+		assert.False(t, issues.PageInfo.HasNextPage)
+
+		resp = graphqlQuery(t, "", query, map[string]any{"after": "invalid"}, &data)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0].Message, "invalid cursor")
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					Number         int
+					HeadRefName    string
+					BaseRefName    string
+					Merged         bool
+					MergeCommitSha string
+					MergedBy       struct{ Login string }
+					Reviews        struct {
+						TotalCount int
+						Nodes      []struct {
+							State  string
+							Body   string
+							Author struct{ Login string }
+						}
+					}
+				}
+			}
+		}
+		resp := graphqlQuery(t, "", `{
+			repository(owner: "user2", name: "repo1") {
+				pullRequest(number: 2) {
+					number headRefName baseRefName merged mergeCommitSha mergedBy { login }
+					reviews { totalCount nodes { state body author { login } } }
+				}
+			}
+		}`, nil, &data)
+		assert.Empty(t, resp.Errors)
+		pr := data.Repository.PullRequest
+		assert.Equal(t, 2, pr.Number)
+		assert.Equal(t, "branch1", pr.HeadRefName)
+		assert.Equal(t, "master", pr.BaseRefName)
+		assert.True(t, pr.Merged)
+		assert.Equal(t, "1a8823cd1a9549fde083f992f6b9b87a7ab74fb3", pr.MergeCommitSha)
+		assert.Equal(t, "user2", pr.MergedBy.Login)
+		// the pending review is a draft of its author
+		assert.Equal(t, 1, pr.Reviews.TotalCount)
+		require.Len(t, pr.Reviews.Nodes, 1)
+		assert.Equal(t, "APPROVED", pr.Reviews.Nodes[0].State)
+		assert.Equal(t, "Demo Review", pr.Reviews.Nodes[0].Body)
+		assert.Equal(t, "user1", pr.Reviews.Nodes[0].Author.Login)
+	})
+
+	t.Run("Permissions", func(t *testing.T) {
+		const query = `{
+			repository(owner: "user2", name: "repo2") {
+				isPrivate
+				issues { totalCount }
+			}
+		}`
+		var data struct {
+			Repository *struct {
+				IsPrivate bool
+				Issues    *struct{ TotalCount int }
+			}
+		}
+
+		// the private repository doesn't exist for anonymous users and users without access
+		resp := graphqlQuery(t, "", query, nil, &data)
+		assert.Empty(t, resp.Errors)
+		assert.Nil(t, data.Repository)
+
+		token := getUserToken(t, "user4", auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadIssue)
+		resp = graphqlQuery(t, token, query, nil, &data)
+		assert.Empty(t, resp.Errors)
+		assert.Nil(t, data.Repository)
+
+		token = getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadIssue)
+		resp = graphqlQuery(t, token, query, nil, &data)
+		assert.Empty(t, resp.Errors)
+		require.NotNil(t, data.Repository)
+		assert.True(t, data.Repository.IsPrivate)
+		assert.NotNil(t, data.Repository.Issues)
+
+		// the fields the token has no scope for are denied, the other fields are returned
+		token = getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		resp = graphqlQuery(t, token, query, nil, &data)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0].Message, "token does not have at least one of required scope(s)")
+		assert.Equal(t, []any{"repository", "issues"}, resp.Errors[0].Path)
+		require.NotNil(t, data.Repository)
+		assert.True(t, data.Repository.IsPrivate)
+		assert.Nil(t, data.Repository.Issues)
+
+		token = getUserToken(t, "user2", auth_model.AccessTokenScopePublicOnly, auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadIssue)
+		resp = graphqlQuery(t, token, query, nil, &data)
+		assert.Empty(t, resp.Errors)
+		assert.Nil(t, data.Repository)
+	})
+
+	t.Run("Viewer", func(t *testing.T) {
+		var data struct {
+			Viewer *struct{ Login string }
+		}
+		resp := graphqlQuery(t, "", `{ viewer { login } }`, nil, &data)
+		assert.Empty(t, resp.Errors)
+		assert.Nil(t, data.Viewer)
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadUser)
+		resp = graphqlQuery(t, token, `{ viewer { login } }`, nil, &data)
+		assert.Empty(t, resp.Errors)
+		require.NotNil(t, data.Viewer)
+		assert.Equal(t, "user2", data.Viewer.Login)
+	})
+
+	t.Run("GET", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/graphql?query="+url.QueryEscape(`{ organization(login: "org3") { login } }`))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.JSONEq(t, `{"data":{"organization":{"login":"org3"}}}`, resp.Body.String())
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/graphql"), http.StatusBadRequest)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/graphql/schema"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "type Query {")
+	})
+}
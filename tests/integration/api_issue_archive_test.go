@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueArchive(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+	req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/archive").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "application/zip", resp.Header().Get("Content-Type"))
+	archive := resp.Body.Bytes()
+
+	importArchive := func(t *testing.T, token, repo string, content []byte, expectedStatus int) {
+		body := &bytes.Buffer{}
+		mpForm := multipart.NewWriter(body)
+		file, err := mpForm.CreateFormFile("archive", "issues.zip")
+		require.NoError(t, err)
+		_, err = file.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, mpForm.Close())
+
+		req := NewRequestWithBody(t, "POST", "/api/v1/repos/"+repo+"/issues/archive", body).AddTokenAuth(token)
+		req.Header.Add("Content-Type", mpForm.FormDataContentType())
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	// user4 is not an administrator of user2/repo1
+	token4 := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+	importArchive(t, token4, "user2/repo1", archive, http.StatusForbidden)
+
+	token5 := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+	importArchive(t, token5, "user5/repo4", []byte("not a zip"), http.StatusUnprocessableEntity)
+	importArchive(t, token5, "user5/repo4", archive, http.StatusNoContent)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 1})
+	assert.Equal(t, "issue1", issue.Title)
+	assert.Equal(t, "user1", issue.OriginalAuthor)
+	assert.Equal(t, 2, issue.NumComments)
+
+	// the issue numbers are already used
+	importArchive(t, token5, "user5/repo4", archive, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIBulkEditIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const urlStr = "/api/v1/repos/user2/repo1/issues/bulk"
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+
+	t.Run("Edit", func(t *testing.T) {
+		milestone := int64(1)
+		state := string(api.StateClosed)
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.BulkEditIssuesOption{
+			Indexes:      []int64{1, 2, 4, 1, 1000},
+			AddLabels:    []any{"label2"},
+			RemoveLabels: []any{"label1"},
+			AddAssignees: []string{"user2"},
+			Milestone:    &milestone,
+			State:        &state,
+			Comment:      "triaged",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var results []*api.BulkEditIssueResult
+		DecodeJSON(t, resp, &results)
+		require.Len(t, results, 4)
+
+		// the open issue gets all the changes
+		assert.EqualValues(t, 1, results[0].Index)
+		assert.Equal(t, http.StatusOK, results[0].Status)
+		require.NotNil(t, results[0].Issue)
+		assert.Equal(t, api.StateClosed, results[0].Issue.State)
+		require.Len(t, results[0].Issue.Labels, 1)
+		assert.Equal(t, "label2", results[0].Issue.Labels[0].Name)
+		assert.True(t, slices.ContainsFunc(results[0].Issue.Assignees, func(u *api.User) bool { return u.UserName == "user2" }))
+		require.NotNil(t, results[0].Issue.Milestone)
+		assert.EqualValues(t, 1, results[0].Issue.Milestone.ID)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeComment, Content: "triaged"})
+
+		// the merged pull request can't be closed, so it isn't changed at all
+		assert.EqualValues(t, 2, results[1].Index)
+		assert.Equal(t, http.StatusPreconditionFailed, results[1].Status)
+		assert.Nil(t, results[1].Issue)
+		unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: 2, Type: issues_model.CommentTypeComment, Content: "triaged"})
+
+		// the closed issue is edited and stays closed
+		assert.EqualValues(t, 4, results[2].Index)
+		assert.Equal(t, http.StatusOK, results[2].Status)
+		assert.Equal(t, api.StateClosed, results[2].Issue.State)
+
+		assert.EqualValues(t, 1000, results[3].Index)
+		assert.Equal(t, http.StatusNotFound, results[3].Status)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.BulkEditIssuesOption{
+			Indexes:      []int64{1},
+			AddAssignees: []string{"user-does-not-exist"},
+			Comment:      "not posted",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+		unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: 1, Content: "not posted"})
+
+		defer test.MockVariableValue(&setting.API.MaxResponseItems, 2)()
+		req = NewRequestWithJSON(t, "POST", urlStr, &api.BulkEditIssuesOption{Indexes: []int64{1, 2, 3}}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Permission", func(t *testing.T) {
+		// user4 can read the issues of the public repository but not edit them
+		token := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue)
+		req := NewRequestWithJSON(t, "POST", urlStr, &api.BulkEditIssuesOption{
+			Indexes: []int64{1},
+			Comment: "not posted",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var results []*api.BulkEditIssueResult
+		DecodeJSON(t, resp, &results)
+		require.Len(t, results, 1)
+		assert.Equal(t, http.StatusForbidden, results[0].Status)
+		unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: 1, Content: "not posted"})
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueFields(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteOrganization)
+
+	createField := func(t *testing.T, url string, opts *api.CreateIssueFieldOption) *api.IssueField {
+		req := NewRequestWithJSON(t, "POST", url, opts).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var field api.IssueField
+		DecodeJSON(t, resp, &field)
+		return &field
+	}
+
+	severity := createField(t, "/api/v1/repos/org3/repo3/issue_fields", &api.CreateIssueFieldOption{
+		Name:    "Severity",
+		Type:    "enum",
+		Options: []string{"low", "high"},
+	})
+	assert.Equal(t, []string{"low", "high"}, severity.Options)
+	assert.False(t, severity.IsOrgField)
+	customer := createField(t, "/api/v1/orgs/org3/issue_fields", &api.CreateIssueFieldOption{
+		Name: "Customer",
+		Type: "text",
+	})
+	assert.True(t, customer.IsOrgField)
+	createField(t, "/api/v1/orgs/org3/issue_fields", &api.CreateIssueFieldOption{Name: "Owner", Type: "user"})
+
+	t.Run("Validation", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/org3/repo3/issue_fields", &api.CreateIssueFieldOption{Name: "severity", Type: "text"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/org3/repo3/issue_fields", &api.CreateIssueFieldOption{Name: "Fix version", Type: "enum"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issue_fields").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var fields []*api.IssueField
+		DecodeJSON(t, resp, &fields)
+		require.Len(t, fields, 3)
+		assert.Equal(t, "Customer", fields[0].Name)
+		assert.Equal(t, "Owner", fields[1].Name)
+		assert.Equal(t, "Severity", fields[2].Name)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/issue_fields").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &fields)
+		assert.Len(t, fields, 2)
+
+		// the field of a repository can't be accessed from another repository
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/user2/repo1/issue_fields/%d", severity.ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/org3/repo3/issues/1/fields", &api.EditIssueFieldValuesOption{
+			Fields: map[string]string{"severity": "high", "Customer": "ACME", "Owner": "user2"},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var values []*api.IssueFieldValue
+		DecodeJSON(t, resp, &values)
+		require.Len(t, values, 3)
+		assert.Equal(t, "ACME", values[0].Value)
+		assert.Equal(t, "user2", values[1].Value)
+		assert.Equal(t, "high", values[2].Value)
+
+		req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/org3/repo3/issues/2/fields", &api.EditIssueFieldValuesOption{
+			Fields: map[string]string{"Severity": "low"},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		for _, fields := range []map[string]string{{"Severity": "medium"}, {"Unknown": "value"}, {"Owner": "not-a-user"}} {
+			req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/org3/repo3/issues/1/fields", &api.EditIssueFieldValuesOption{Fields: fields}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		}
+
+		req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues/1/fields").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &values)
+		assert.Len(t, values, 3)
+
+		// only the writers can set the values
+		readerToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue)
+		req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1/fields", &api.EditIssueFieldValuesOption{
+			Fields: map[string]string{},
+		}).AddTokenAuth(readerToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues?state=all&field=Severity:high&field=owner:user2").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var issues []*api.Issue
+		DecodeJSON(t, resp, &issues)
+		require.Len(t, issues, 1)
+		assert.EqualValues(t, 1, issues[0].Index)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues?state=all&field=Severity:low").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &issues)
+		require.Len(t, issues, 1)
+		assert.EqualValues(t, 2, issues[0].Index)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues?field=Severity").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues/export?state=all").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+		records, err := csv.NewReader(strings.NewReader(resp.Body.String())).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{"Customer", "Owner", "Severity"}, records[0][len(records[0])-3:])
+		// the issues are sorted by creation time, the newest first
+		assert.Equal(t, []string{"2", "pull6", "open", "pull"}, records[1][:4])
+		assert.Equal(t, []string{"", "", "low"}, records[1][len(records[1])-3:])
+		assert.Equal(t, []string{"1", "issue6", "open", "issue"}, records[2][:4])
+		assert.Equal(t, []string{"ACME", "user2", "high"}, records[2][len(records[2])-3:])
+	})
+
+	t.Run("Edit", func(t *testing.T) {
+		options := []string{"low", "critical"}
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/org3/repo3/issue_fields/%d", severity.ID), &api.EditIssueFieldOption{
+			Options: options,
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var field api.IssueField
+		DecodeJSON(t, resp, &field)
+		assert.Equal(t, options, field.Options)
+		unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{FieldID: severity.ID, Value: "high"})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFieldValue{FieldID: severity.ID, Value: "low"})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/orgs/org3/issue_fields/%d", customer.ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &issues_model.IssueField{ID: customer.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{FieldID: customer.ID})
+	})
+}
+
+func TestIssueFieldsSidebar(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	field := &issues_model.IssueField{RepoID: 1, Name: "Severity", Type: issues_model.IssueFieldTypeEnum, Options: []string{"low", "high"}}
+	require.NoError(t, issues_model.NewIssueField(t.Context(), field))
+
+	session := loginUser(t, "user2")
+	req := NewRequest(t, "GET", "/user2/repo1/issues/1")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	AssertHTMLElement(t, htmlDoc, fmt.Sprintf(".issue-custom-fields-form select[name=field-%d]", field.ID), true)
+
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/fields", map[string]string{
+		"_csrf":                           GetUserCSRFToken(t, session),
+		fmt.Sprintf("field-%d", field.ID): "high",
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFieldValue{IssueID: 1, FieldID: field.ID, Value: "high"})
+
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/fields", map[string]string{
+		"_csrf":                           GetUserCSRFToken(t, session),
+		fmt.Sprintf("field-%d", field.ID): "medium",
+	})
+	session.MakeRequest(t, req, http.StatusBadRequest)
+
+	req = NewRequest(t, "GET", "/user2/repo1/issues?field=Severity:high")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Equal(t, 1, htmlDoc.Find("#issue-list .flex-item").Length())
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueSavedSearches(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	ownerToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteOrganization)
+	memberToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeReadOrganization)
+
+	createSearch := func(t *testing.T, url, token string, opts *api.CreateIssueSavedSearchOption, status int) *api.IssueSavedSearch {
+		req := NewRequestWithJSON(t, "POST", url, opts).AddTokenAuth(token)
+		resp := MakeRequest(t, req, status)
+		if status != http.StatusCreated {
+			return nil
+		}
+		var search api.IssueSavedSearch
+		DecodeJSON(t, resp, &search)
+		return &search
+	}
+
+	personal := createSearch(t, "/api/v1/repos/org3/repo3/issue_searches", memberToken, &api.CreateIssueSavedSearchOption{
+		Name:  "Mine",
+		Query: "type=assigned&state=open&page=2",
+	}, http.StatusCreated)
+	assert.Equal(t, "state=open&type=assigned", personal.Query)
+	assert.Equal(t, "issues", personal.Type)
+	assert.False(t, personal.Shared)
+	assert.Equal(t, setting.AppURL+"org3/repo3/issues?state=open&type=assigned", personal.HTMLURL)
+
+	shared := createSearch(t, "/api/v1/repos/org3/repo3/issue_searches", ownerToken, &api.CreateIssueSavedSearchOption{
+		Name:   "Open pulls",
+		Query:  "state=open",
+		Type:   "pulls",
+		Shared: true,
+	}, http.StatusCreated)
+	assert.True(t, shared.Shared)
+	assert.Equal(t, "pulls", shared.Type)
+	orgWide := createSearch(t, "/api/v1/orgs/org3/issue_searches", ownerToken, &api.CreateIssueSavedSearchOption{
+		Name:  "Bugs",
+		Query: "labels=1",
+	}, http.StatusCreated)
+	assert.True(t, orgWide.Shared)
+	assert.Empty(t, orgWide.HTMLURL)
+
+	t.Run("Validation", func(t *testing.T) {
+		createSearch(t, "/api/v1/repos/org3/repo3/issue_searches", memberToken, &api.CreateIssueSavedSearchOption{Name: "mine"}, http.StatusConflict)
+		createSearch(t, "/api/v1/repos/org3/repo3/issue_searches", memberToken, &api.CreateIssueSavedSearchOption{Name: "Wrong", Type: "wrong"}, http.StatusUnprocessableEntity)
+		createSearch(t, "/api/v1/repos/org3/repo3/issue_searches", memberToken, &api.CreateIssueSavedSearchOption{Name: "Shared", Shared: true}, http.StatusForbidden)
+		createSearch(t, "/api/v1/orgs/org3/issue_searches", memberToken, &api.CreateIssueSavedSearchOption{Name: "Shared"}, http.StatusForbidden)
+		// a repository of a user has no shared searches
+		createSearch(t, "/api/v1/repos/user2/repo1/issue_searches", ownerToken, &api.CreateIssueSavedSearchOption{Name: "Shared", Shared: true}, http.StatusForbidden)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issue_searches").AddTokenAuth(memberToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var searches []*api.IssueSavedSearch
+		DecodeJSON(t, resp, &searches)
+		require.Len(t, searches, 3)
+		assert.Equal(t, "Bugs", searches[0].Name)
+		assert.Equal(t, "Mine", searches[1].Name)
+		assert.Equal(t, "Open pulls", searches[2].Name)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issue_searches?type=pulls").AddTokenAuth(ownerToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &searches)
+		require.Len(t, searches, 1)
+		assert.Equal(t, shared.ID, searches[0].ID)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/issue_searches").AddTokenAuth(memberToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &searches)
+		assert.Len(t, searches, 2)
+
+		// the personal searches of other users are private
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/org3/repo3/issue_searches/%d", personal.ID)).AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Edit", func(t *testing.T) {
+		name := "Assigned to me"
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/org3/repo3/issue_searches/%d", personal.ID), &api.EditIssueSavedSearchOption{Name: &name}).AddTokenAuth(memberToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var search api.IssueSavedSearch
+		DecodeJSON(t, resp, &search)
+		assert.Equal(t, name, search.Name)
+		assert.Equal(t, personal.Query, search.Query)
+
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/org3/repo3/issue_searches/%d", orgWide.ID), &api.EditIssueSavedSearchOption{Name: &name}).AddTokenAuth(memberToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		query := "labels=1&state=closed"
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/orgs/org3/issue_searches/%d", orgWide.ID), &api.EditIssueSavedSearchOption{Query: &query}).AddTokenAuth(ownerToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &search)
+		assert.Equal(t, query, search.Query)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/org3/repo3/issue_searches/%d", shared.ID)).AddTokenAuth(memberToken)
+		MakeRequest(t, req, http.StatusForbidden)
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/org3/repo3/issue_searches/%d", shared.ID)).AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/orgs/org3/issue_searches/%d", orgWide.ID)).AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &issues_model.IssueSavedSearch{OwnerID: 3})
+	})
+}
+
+func TestIssueSavedSearchTabs(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/saved_searches", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+		"name":  "Open bugs",
+		"query": "state=open&labels=1&page=2",
+	})
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/user2/repo1/issues?labels=1&state=open")
+	search := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSavedSearch{OwnerID: 2, RepoID: 1, Name: "Open bugs"})
+	assert.Equal(t, "labels=1&state=open", search.Query)
+
+	// the search is the active tab of the issue list with its filters
+	req = NewRequest(t, "GET", "/user2/repo1/issues?labels=1&state=open")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.Equal(t, "Open bugs", strings.TrimSpace(htmlDoc.doc.Find(".issue-saved-searches .item.active a").First().Text()))
+
+	// the personal searches aren't shown to other users and on the pull request list
+	req = NewRequest(t, "GET", "/user2/repo1/issues")
+	resp = loginUser(t, "user4").MakeRequest(t, req, http.StatusOK)
+	AssertHTMLElement(t, NewHTMLParser(t, resp.Body), ".issue-saved-searches .item", false)
+	req = NewRequest(t, "GET", "/user2/repo1/pulls")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	AssertHTMLElement(t, NewHTMLParser(t, resp.Body), ".issue-saved-searches .item", false)
+
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/repo1/issues/saved_searches/%d/delete", search.ID), map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSavedSearch{ID: search.ID})
+}
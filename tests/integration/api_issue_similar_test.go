@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPISimilarIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadIssue)
+
+	listSimilar := func(t *testing.T, url string) []*api.SimilarIssue {
+		resp := MakeRequest(t, NewRequest(t, "GET", url).AddTokenAuth(token), http.StatusOK)
+		var similar []*api.SimilarIssue
+		DecodeJSON(t, resp, &similar)
+		return similar
+	}
+
+	// only the open issue user2/repo1#1 is titled "issue1", the closed issue #4 and the pull requests are ignored
+	similar := listSimilar(t, "/api/v1/repos/user2/repo1/issues/similar?title=Issue1")
+	require.Len(t, similar, 1)
+	assert.EqualValues(t, 1, similar[0].Issue.Index)
+	assert.InDelta(t, 1, similar[0].Score, 0.001)
+
+	similar = listSimilar(t, "/api/v1/repos/user2/repo1/issues/similar?title=issue1&body=content+for+the+first+issue")
+	require.Len(t, similar, 1)
+	assert.InDelta(t, 1, similar[0].Score, 0.001)
+
+	assert.Empty(t, listSimilar(t, "/api/v1/repos/user2/repo1/issues/similar?title=issue5"))
+	assert.Empty(t, listSimilar(t, "/api/v1/repos/user2/repo1/issues/similar?title=something+unrelated"))
+	assert.Empty(t, listSimilar(t, "/api/v1/repos/user2/repo1/issues/1/similar"))
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/similar").AddTokenAuth(token), http.StatusUnprocessableEntity)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/2/similar").AddTokenAuth(token), http.StatusNotFound)
+
+	t.Run("Web", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/similar?title=issue1"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "/user2/repo1/issues/1")
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/similar?title=unrelated"), http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), "/user2/repo1/issues/1")
+	})
+}
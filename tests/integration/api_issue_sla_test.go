@@ -0,0 +1,115 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueSLARules(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteRepository)
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteRepository)
+
+	opts := &api.CreateIssueSLARuleOption{
+		Name:            "Bugs",
+		Labels:          []int64{1},
+		ExemptLabels:    []int64{2},
+		ResponseHours:   4,
+		ResolutionHours: 24,
+		StaleDays:       30,
+	}
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issue_sla_rules", opts).AddTokenAuth(otherToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issue_sla_rules", opts).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var rule api.IssueSLARule
+	DecodeJSON(t, resp, &rule)
+	assert.Equal(t, "Bugs", rule.Name)
+	assert.Equal(t, []int64{1}, rule.Labels)
+	assert.Equal(t, []int64{2}, rule.ExemptLabels)
+
+	for _, invalid := range []*api.CreateIssueSLARuleOption{
+		{Name: "Org label", Labels: []int64{3}, ResponseHours: 1},
+		{Name: "Nothing"},
+		{Name: "Negative", StaleDays: -1},
+	} {
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issue_sla_rules", invalid).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	}
+
+	resolutionHours := 1
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/user2/repo1/issue_sla_rules/%d", rule.ID), &api.EditIssueSLARuleOption{
+		ResolutionHours: &resolutionHours,
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &rule)
+	assert.Equal(t, 1, rule.ResolutionHours)
+	assert.Equal(t, 4, rule.ResponseHours)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issue_sla_rules").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var rules []*api.IssueSLARule
+	DecodeJSON(t, resp, &rules)
+	assert.Len(t, rules, 1)
+
+	// issue 1 has the label 1, it was answered within seconds and is still open since 2000
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/sla").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var statuses []*api.IssueSLAStatus
+	DecodeJSON(t, resp, &statuses)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "response", statuses[0].Kind)
+	assert.Equal(t, "met", statuses[0].State)
+	assert.NotNil(t, statuses[0].Completed)
+	assert.Equal(t, "resolution", statuses[1].Kind)
+	assert.Equal(t, "breached", statuses[1].State)
+	assert.Nil(t, statuses[1].Completed)
+
+	// issue 4 has the exempt label 2
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/4/sla").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &statuses)
+	assert.Empty(t, statuses)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/3/sla").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	session := loginUser(t, "user2")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), `data-tooltip-content="Breached"`)
+
+	t.Run("Check", func(t *testing.T) {
+		stale := &issues_model.Comment{IssueID: 1, PosterID: user_model.ActionsUserID, Type: issues_model.CommentTypeComment}
+		for range 2 {
+			require.NoError(t, issue_service.CheckIssueSLAs(t.Context(), time.Hour))
+			unittest.AssertCount(t, &issues_model.IssueSLANotice{IssueID: 1, RuleID: rule.ID}, 1)
+			unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSLANotice{IssueID: 1, RuleID: rule.ID, Kind: issues_model.IssueSLAKindResolution, IsBreach: true})
+			// the nudge updates the issue, so it isn't nudged again
+			unittest.AssertCount(t, stale, 1)
+		}
+	})
+
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/user2/repo1/issue_sla_rules/%d", rule.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertCount(t, &issues_model.IssueSLANotice{RuleID: rule.ID}, 0)
+	req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/user2/repo1/issue_sla_rules/%d", rule.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+}
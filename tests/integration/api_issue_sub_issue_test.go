@@ -0,0 +1,101 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPISubIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteRepository)
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteRepository)
+
+	editSubIssue := func(t *testing.T, method, url, token string, meta *api.IssueMeta, status int) *api.IssueHierarchy {
+		req := NewRequestWithJSON(t, method, url, meta).AddTokenAuth(token)
+		resp := MakeRequest(t, req, status)
+		if status != http.StatusOK && status != http.StatusCreated {
+			return nil
+		}
+		var hierarchy api.IssueHierarchy
+		DecodeJSON(t, resp, &hierarchy)
+		return &hierarchy
+	}
+
+	// the closed issue user2/repo1#4 and the open issue user2/repo2#2 become sub-issues of user2/repo1#1
+	hierarchy := editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Index: 4}, http.StatusCreated)
+	require.Len(t, hierarchy.SubIssues, 1)
+	assert.EqualValues(t, 4, hierarchy.SubIssues[0].Index)
+	assert.Equal(t, api.StateClosed, hierarchy.SubIssues[0].State)
+	assert.Equal(t, api.SubIssueProgress{Total: 1, Closed: 1, Percent: 100}, hierarchy.Progress)
+	hierarchy = editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Owner: "user2", Name: "repo2", Index: 2}, http.StatusCreated)
+	require.Len(t, hierarchy.SubIssues, 2)
+	assert.Equal(t, "user2/repo2", hierarchy.SubIssues[1].Repo.FullName)
+	assert.Equal(t, api.SubIssueProgress{Total: 2, Closed: 1, Percent: 50}, hierarchy.Progress)
+	assert.Nil(t, hierarchy.Parent)
+
+	// a sub-issue of another owner, a pull request, an issue with a parent and a cycle
+	editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Owner: "org3", Name: "repo3", Index: 1}, http.StatusUnprocessableEntity)
+	editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Index: 2}, http.StatusUnprocessableEntity)
+	editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Index: 4}, http.StatusConflict)
+	editSubIssue(t, "POST", "/api/v1/repos/user2/repo2/issues/2/sub_issues", token, &api.IssueMeta{Owner: "user2", Name: "repo1", Index: 1}, http.StatusUnprocessableEntity)
+	editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/1/sub_issues", otherToken, &api.IssueMeta{Index: 4}, http.StatusForbidden)
+	editSubIssue(t, "POST", "/api/v1/repos/user2/repo1/issues/2/sub_issues", token, &api.IssueMeta{Index: 4}, http.StatusNotFound)
+
+	req := NewRequest(t, "GET", "/api/v1/repos/user2/repo2/issues/2/sub_issues").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &hierarchy)
+	require.NotNil(t, hierarchy.Parent)
+	assert.EqualValues(t, 1, hierarchy.Parent.Index)
+	assert.Empty(t, hierarchy.SubIssues)
+
+	// the sub-issue of the private repository is hidden
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/sub_issues").AddTokenAuth(otherToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &hierarchy)
+	require.Len(t, hierarchy.SubIssues, 1)
+	assert.EqualValues(t, 4, hierarchy.SubIssues[0].Index)
+
+	t.Run("Web", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "1 of 2 closed")
+
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/add", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+			"issue": "user2/glob#1",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.SubIssue{ParentID: 1, IssueID: 10})
+
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/add", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+			"issue": "#404",
+		})
+		resp = session.MakeRequest(t, req, http.StatusBadRequest)
+		assert.Contains(t, resp.Body.String(), "The issue does not exist.")
+
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/remove?id=10", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{ParentID: 1, IssueID: 10})
+	})
+
+	hierarchy = editSubIssue(t, "DELETE", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Index: 4}, http.StatusOK)
+	require.Len(t, hierarchy.SubIssues, 1)
+	assert.EqualValues(t, 2, hierarchy.SubIssues[0].Index)
+	editSubIssue(t, "DELETE", "/api/v1/repos/user2/repo1/issues/1/sub_issues", token, &api.IssueMeta{Index: 4}, http.StatusNotFound)
+}
@@ -5,15 +5,18 @@ package integration
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAPIIssueTemplateList(t *testing.T) {
@@ -53,3 +56,74 @@ about: bar
 		assert.Equal(t, "error occurs when parsing issue template: count=2", resp.Header().Get("X-Gitea-Warning"))
 	})
 }
+
+func TestAPICreateIssueFromForm(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "user2"})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user2", Name: "repo1"})
+		const templateFile = ".gitea/ISSUE_TEMPLATE/bug.yaml"
+		err := createOrReplaceFileInBranch(user, repo, templateFile, repo.DefaultBranch, `name: Bug report
+about: Report a bug
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      options: [Linux, Windows]
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I searched the existing issues
+          required: true
+`)
+		require.NoError(t, err)
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+		createIssue := func(t *testing.T, opts *api.CreateIssueOption, expectedStatus int) *httptest.ResponseRecorder {
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", opts).AddTokenAuth(token)
+			return MakeRequest(t, req, expectedStatus)
+		}
+
+		resp := createIssue(t, &api.CreateIssueOption{
+			Title:    "bug from form",
+			Template: templateFile,
+			TemplateValues: map[string][]string{
+				"version": {"1.22"},
+				"os":      {"Windows"},
+				"terms":   {"I searched the existing issues"},
+			},
+		}, http.StatusCreated)
+		var issue api.Issue
+		DecodeJSON(t, resp, &issue)
+		assert.Equal(t, "### Version\n\n1.22\n\n### OS\n\nWindows\n\n### Terms\n\n- [x] I searched the existing issues\n\n", issue.Body)
+
+		for _, opts := range []*api.CreateIssueOption{
+			{Title: "missing", Template: templateFile, TemplateValues: map[string][]string{"terms": {"I searched the existing issues"}}},
+			{Title: "unchecked", Template: templateFile, TemplateValues: map[string][]string{"version": {"1.22"}}},
+			{Title: "unknown option", Template: templateFile, TemplateValues: map[string][]string{"version": {"1.22"}, "os": {"BSD"}}},
+			{Title: "body", Body: "body", Template: templateFile},
+			{Title: "unknown template", Template: ".gitea/ISSUE_TEMPLATE/unknown.yaml"},
+		} {
+			createIssue(t, opts, http.StatusUnprocessableEntity)
+		}
+
+		// the form submitted in the web UI is validated too
+		session := loginUser(t, "user2")
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", map[string]string{
+			"_csrf":              GetUserCSRFToken(t, session),
+			"title":              "bug from web form",
+			"template-file":      templateFile,
+			"form-field-version": "1.22",
+		})
+		resp = session.MakeRequest(t, req, http.StatusBadRequest)
+		assert.Contains(t, resp.Body.String(), "is required")
+	})
+}
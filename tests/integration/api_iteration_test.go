@@ -0,0 +1,134 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIteration(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteOrganization)
+	now := time.Now().Truncate(time.Second)
+
+	createIteration := func(t *testing.T, url, name string, start time.Time) *api.Iteration {
+		req := NewRequestWithJSON(t, "POST", url, &api.CreateIterationOption{
+			Name:  name,
+			Start: start,
+			End:   start.Add(7 * 24 * time.Hour),
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var it api.Iteration
+		DecodeJSON(t, resp, &it)
+		return &it
+	}
+
+	orgSprint1 := createIteration(t, "/api/v1/orgs/org3/iterations", "Sprint 1", now.Add(-24*time.Hour))
+	assert.True(t, orgSprint1.IsOrgIteration)
+	assert.Equal(t, api.StateOpen, orgSprint1.State)
+	orgSprint2 := createIteration(t, "/api/v1/orgs/org3/iterations", "Sprint 2", now.Add(6*24*time.Hour))
+	repoSprint := createIteration(t, "/api/v1/repos/org3/repo3/iterations", "Repo sprint", now)
+	assert.False(t, repoSprint.IsOrgIteration)
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/org3/repo3/iterations", &api.CreateIterationOption{
+			Name:  "Backwards",
+			Start: now,
+			End:   now.Add(-time.Hour),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// user4 is a member but not an owner of org3
+		token4 := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteOrganization)
+		req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/iterations", &api.CreateIterationOption{
+			Name:  "Sprint",
+			Start: now,
+			End:   now.Add(time.Hour),
+		}).AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/iterations?state=open").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var iterations []*api.Iteration
+	DecodeJSON(t, resp, &iterations)
+	require.Len(t, iterations, 3)
+	assert.Equal(t, orgSprint1.ID, iterations[0].ID)
+	assert.Equal(t, repoSprint.ID, iterations[1].ID)
+
+	// plan the issue org3/repo3#1 in the first sprint of the organization
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/org3/repo3/issues/1/iteration", &api.SetIssueIterationOption{IterationID: orgSprint1.ID}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusOK)
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/org3/repo3/issues/1/iteration", &api.SetIssueIterationOption{IterationID: 12345}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues/1/iteration").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var it api.Iteration
+	DecodeJSON(t, resp, &it)
+	assert.Equal(t, orgSprint1.ID, it.ID)
+
+	req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/org3/repo3/iterations/%d/issues", orgSprint1.ID)).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var issues []*api.Issue
+	DecodeJSON(t, resp, &issues)
+	require.Len(t, issues, 1)
+	assert.EqualValues(t, 1, issues[0].Index)
+
+	req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/orgs/org3/iterations/%d/burndown", orgSprint1.ID)).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var burndown api.IterationBurndown
+	DecodeJSON(t, resp, &burndown)
+	assert.Equal(t, orgSprint1.ID, burndown.Iteration.ID)
+	require.Len(t, burndown.Series, 2)
+	// the issue was planned after the start of the iteration
+	assert.Zero(t, burndown.Series[0].OpenIssues)
+	assert.Equal(t, 1, burndown.Series[1].OpenIssues)
+
+	// the issues can only be carried over to an iteration of the organization
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/orgs/org3/iterations/%d/close", orgSprint1.ID), &api.CloseIterationOption{CarryOverTo: repoSprint.ID}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/orgs/org3/iterations/%d/close", orgSprint1.ID), &api.CloseIterationOption{CarryOverTo: orgSprint2.ID}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &it)
+	assert.Equal(t, api.StateClosed, it.State)
+	assert.NotNil(t, it.Closed)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues/1/iteration").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &it)
+	assert.Equal(t, orgSprint2.ID, it.ID)
+
+	req = NewRequest(t, "GET", "/api/v1/orgs/org3/iterations/velocity").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var velocities []*api.IterationVelocity
+	DecodeJSON(t, resp, &velocities)
+	require.Len(t, velocities, 1)
+	assert.Equal(t, orgSprint1.ID, velocities[0].Iteration.ID)
+	assert.Equal(t, 1, velocities[0].CommittedIssues)
+	assert.Zero(t, velocities[0].CompletedIssues)
+	assert.Equal(t, 1, velocities[0].CarriedOverIssues)
+
+	req = NewRequest(t, "DELETE", "/api/v1/repos/org3/repo3/issues/1/iteration").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3/issues/1/iteration").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	// the iterations of the organization are managed through the organization
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/org3/repo3/iterations/%d", orgSprint2.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/orgs/org3/iterations/%d", orgSprint2.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIOrgRoles(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	ownerToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+	memberToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteOrganization, auth_model.AccessTokenScopeReadRepository)
+
+	var catalog []string
+	DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/roles/permissions").AddTokenAuth(memberToken), http.StatusOK), &catalog)
+	assert.Contains(t, catalog, "releases.write")
+	assert.Contains(t, catalog, "repo.create")
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/roles", &api.CreateOrgRoleOption{
+		Name:        "Release Manager",
+		Permissions: []string{"code.read", "settings.write"},
+	}).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	createOption := &api.CreateOrgRoleOption{
+		Name:        "Release Manager",
+		Permissions: []string{"code.read", "releases.write"},
+	}
+	MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/roles", createOption).AddTokenAuth(memberToken), http.StatusForbidden)
+	var role api.OrgRole
+	DecodeJSON(t, MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/roles", createOption).AddTokenAuth(ownerToken), http.StatusCreated), &role)
+	assert.Equal(t, []string{"code.read", "releases.write"}, role.Permissions)
+	MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/roles", createOption).AddTokenAuth(ownerToken), http.StatusConflict)
+
+	// user4 has no access to the private repo5 of org3
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo5").AddTokenAuth(memberToken), http.StatusNotFound)
+
+	// user5 isn't a member of org3
+	MakeRequest(t, NewRequestf(t, "PUT", "/api/v1/orgs/org3/roles/%d/users/user5", role.ID).AddTokenAuth(ownerToken), http.StatusUnprocessableEntity)
+	MakeRequest(t, NewRequestf(t, "PUT", "/api/v1/orgs/org3/roles/%d/users/user4", role.ID).AddTokenAuth(ownerToken), http.StatusNoContent)
+	// team 3 belongs to org6
+	MakeRequest(t, NewRequestf(t, "PUT", "/api/v1/orgs/org3/roles/%d/teams/3", role.ID).AddTokenAuth(ownerToken), http.StatusNotFound)
+	MakeRequest(t, NewRequestf(t, "PUT", "/api/v1/orgs/org3/roles/%d/teams/12", role.ID).AddTokenAuth(ownerToken), http.StatusNoContent)
+
+	var assignments []*api.OrgRoleAssignment
+	DecodeJSON(t, MakeRequest(t, NewRequestf(t, "GET", "/api/v1/orgs/org3/roles/%d/assignments", role.ID).AddTokenAuth(memberToken), http.StatusOK), &assignments)
+	if assert.Len(t, assignments, 2) {
+		assert.Equal(t, "user4", assignments[0].User.UserName)
+		assert.Equal(t, int64(12), assignments[1].Team.ID)
+	}
+
+	var roles []*api.OrgRole
+	DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/members/user4/roles").AddTokenAuth(memberToken), http.StatusOK), &roles)
+	if assert.Len(t, roles, 1) {
+		assert.Equal(t, role.ID, roles[0].ID)
+	}
+
+	var repo api.Repository
+	DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo5").AddTokenAuth(memberToken), http.StatusOK), &repo)
+	assert.True(t, repo.Permissions.Pull)
+	assert.False(t, repo.Permissions.Push)
+	assert.False(t, repo.Permissions.Admin)
+
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/orgs/org3/roles/%d", role.ID), &api.EditOrgRoleOption{
+		Permissions: []string{"repo.admin"},
+	}).AddTokenAuth(ownerToken)
+	DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &role)
+	assert.Equal(t, "Release Manager", role.Name)
+	assert.Equal(t, []string{"repo.admin"}, role.Permissions)
+	DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo5").AddTokenAuth(memberToken), http.StatusOK), &repo)
+	assert.True(t, repo.Permissions.Admin)
+
+	MakeRequest(t, NewRequestf(t, "DELETE", "/api/v1/orgs/org3/roles/%d/users/user4", role.ID).AddTokenAuth(ownerToken), http.StatusNoContent)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo5").AddTokenAuth(memberToken), http.StatusNotFound)
+
+	MakeRequest(t, NewRequestf(t, "DELETE", "/api/v1/orgs/org3/roles/%d", role.ID).AddTokenAuth(ownerToken), http.StatusNoContent)
+	MakeRequest(t, NewRequestf(t, "GET", "/api/v1/orgs/org3/roles/%d", role.ID).AddTokenAuth(ownerToken), http.StatusNotFound)
+}
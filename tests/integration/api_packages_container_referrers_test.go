@@ -0,0 +1,185 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageContainerReferrers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	session := loginUser(t, user.Name)
+	apiToken := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeReadPackage)
+
+	req := NewRequest(t, "GET", setting.AppURL+"v2/token").
+		AddBasicAuth(user.Name)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var tokenResponse struct {
+		Token string `json:"token"`
+	}
+	DecodeJSON(t, resp, &tokenResponse)
+	token := "Bearer " + tokenResponse.Token
+
+	image := "signed"
+	url := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	uploadBlob := func(t *testing.T, content []byte) digest.Digest {
+		d := digest.FromBytes(content)
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, d), bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		return d
+	}
+	uploadManifest := func(t *testing.T, reference, content string) *http.Response {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, reference), strings.NewReader(content)).
+			AddTokenAuth(token).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		return MakeRequest(t, req, http.StatusCreated).Result()
+	}
+
+	config := []byte(`{"architecture":"amd64","os":"linux","config":{}}`)
+	configDigest := uploadBlob(t, config)
+	layer := []byte("layer")
+	layerDigest := uploadBlob(t, layer)
+	emptyDigest := uploadBlob(t, []byte("{}"))
+	payload := []byte(`{"critical":{}}`)
+	payloadDigest := uploadBlob(t, payload)
+
+	imageManifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"%s","digest":"%s","size":%d},"layers":[{"mediaType":"%s","digest":"%s","size":%d}]}`,
+		oci.MediaTypeImageManifest, oci.MediaTypeImageConfig, configDigest, len(config), oci.MediaTypeImageLayerGzip, layerDigest, len(layer))
+	imageDigest := digest.FromString(imageManifest)
+	uploadManifest(t, "v1", imageManifest)
+
+	artifactManifest := func(artifactType string) string {
+		return fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","artifactType":"%s","config":{"mediaType":"%s","digest":"%s","size":2},"layers":[{"mediaType":"application/octet-stream","digest":"%s","size":%d}],"subject":{"mediaType":"%s","digest":"%s","size":%d},"annotations":{"key":"value"}}`,
+			oci.MediaTypeImageManifest, artifactType, oci.MediaTypeEmptyJSON, emptyDigest, payloadDigest, len(payload), oci.MediaTypeImageManifest, imageDigest, len(imageManifest))
+	}
+
+	getSignatureStatus := func(t *testing.T) *api.ContainerSignatureStatus {
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/container/%s/v1/signatures", user.Name, image)).
+			AddTokenAuth(apiToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var status *api.ContainerSignatureStatus
+		DecodeJSON(t, resp, &status)
+		return status
+	}
+
+	t.Run("Unsigned", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		status := getSignatureStatus(t)
+		assert.Equal(t, imageDigest.String(), status.Digest)
+		assert.False(t, status.Signed)
+		assert.Empty(t, status.Artifacts)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/container/%s/v1/badge.svg", user.Name, image))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "unsigned")
+	})
+
+	t.Run("Referrers", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		signature := artifactManifest("application/vnd.dev.cosign.artifact.sig.v1+json")
+		resp := uploadManifest(t, digest.FromString(signature).String(), signature)
+		assert.Equal(t, imageDigest.String(), resp.Header.Get("OCI-Subject"))
+
+		sbom := artifactManifest("application/spdx+json")
+		uploadManifest(t, digest.FromString(sbom).String(), sbom)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, imageDigest)).
+			AddTokenAuth(token)
+		httpResp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, oci.MediaTypeImageIndex, httpResp.Header().Get("Content-Type"))
+		var index oci.Index
+		DecodeJSON(t, httpResp, &index)
+		require.Len(t, index.Manifests, 2)
+		for _, m := range index.Manifests {
+			assert.Equal(t, oci.MediaTypeImageManifest, m.MediaType)
+			assert.Equal(t, map[string]string{"key": "value"}, m.Annotations)
+		}
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s?artifactType=%s", url, imageDigest, "application/spdx%2Bjson")).
+			AddTokenAuth(token)
+		httpResp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "artifactType", httpResp.Header().Get("OCI-Filters-Applied"))
+		index = oci.Index{}
+		DecodeJSON(t, httpResp, &index)
+		require.Len(t, index.Manifests, 1)
+		assert.Equal(t, digest.FromString(sbom), index.Manifests[0].Digest)
+		assert.Equal(t, "application/spdx+json", index.Manifests[0].ArtifactType)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, digest.FromString("unknown"))).
+			AddTokenAuth(token)
+		httpResp = MakeRequest(t, req, http.StatusOK)
+		index = oci.Index{}
+		DecodeJSON(t, httpResp, &index)
+		assert.Empty(t, index.Manifests)
+
+		req = NewRequest(t, "GET", url+"/referrers/invalid").
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		status := getSignatureStatus(t)
+		assert.True(t, status.Signed)
+		assert.False(t, status.Attested)
+		assert.True(t, status.HasSBOM)
+		assert.Len(t, status.Artifacts, 2)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/container/%s/v1/badge.svg", user.Name, image))
+		httpResp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, httpResp.Body.String(), ">signed<")
+	})
+
+	t.Run("CosignTag", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		attestation := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"%s","digest":"%s","size":%d},"layers":[{"mediaType":"application/vnd.dsse.envelope.v1+json","digest":"%s","size":%d}]}`,
+			oci.MediaTypeImageManifest, oci.MediaTypeImageConfig, configDigest, len(config), payloadDigest, len(payload))
+		tag := container_module.CosignTag(imageDigest, container_module.ArtifactKindAttestation)
+		uploadManifest(t, tag, attestation)
+
+		status := getSignatureStatus(t)
+		assert.True(t, status.Signed)
+		assert.True(t, status.Attested)
+		require.Len(t, status.Artifacts, 3)
+		assert.Equal(t, tag, status.Artifacts[2].Tag)
+		assert.Equal(t, digest.FromString(attestation).String(), status.Artifacts[2].Digest)
+
+		// signatures stored as tags are no referrers
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, imageDigest)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var index oci.Index
+		DecodeJSON(t, resp, &index)
+		assert.Len(t, index.Manifests, 2)
+	})
+
+	t.Run("NotContainer", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/unknown/1.0/signatures", user.Name)).
+			AddTokenAuth(apiToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	homebrew_module "code.gitea.io/gitea/modules/packages/homebrew"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageHomebrew(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	formulaName := "gitea-cli"
+	formulaVersion := "1.2.0"
+	formulaDescription := "Command line tool"
+
+	createBottle := func(content string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{
+			formulaName + "/" + formulaVersion + "/bin/" + formulaName:           content,
+			formulaName + "/" + formulaVersion + "/INSTALL_RECEIPT.json":         `{"runtime_dependencies":[{"full_name":"openssl@3"}]}`,
+			formulaName + "/" + formulaVersion + "/.brew/" + formulaName + ".rb": "class GiteaCli < Formula\n  desc \"" + formulaDescription + "\"\n  license \"MIT\"\nend\n",
+		} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	armBottle := createBottle("arm64")
+	linuxBottle := createBottle("x86_64")
+	armFilename := fmt.Sprintf("%s--%s.arm64_sonoma.bottle.tar.gz", formulaName, formulaVersion)
+	linuxFilename := fmt.Sprintf("%s--%s.x86_64_linux.bottle.tar.gz", formulaName, formulaVersion)
+
+	root := fmt.Sprintf("/api/packages/%s/homebrew", user.Name)
+	bottlesURL := fmt.Sprintf("%s/bottles/%s/%s", root, formulaName, formulaVersion)
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", bottlesURL+"/"+armFilename, bytes.NewReader(armBottle))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", bottlesURL+"/"+formulaName+".tar.gz", bytes.NewReader(armBottle)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", bottlesURL+"/"+armFilename, bytes.NewReader([]byte("dummy"))).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", bottlesURL+"/"+armFilename+"?cellar=none", bytes.NewReader(armBottle)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", bottlesURL+"/"+armFilename, bytes.NewReader(armBottle)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", bottlesURL+"/"+linuxFilename+"?cellar=:any_skip_relocation", bytes.NewReader(linuxBottle)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHomebrew)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Nil(t, pd.SemVer)
+		assert.Equal(t, formulaName, pd.Package.Name)
+		assert.Equal(t, formulaVersion, pd.Version.Version)
+		require.IsType(t, &homebrew_module.Metadata{}, pd.Metadata)
+		metadata := pd.Metadata.(*homebrew_module.Metadata)
+		assert.Equal(t, formulaDescription, metadata.Description)
+		assert.Equal(t, "MIT", metadata.License)
+		assert.Equal(t, []string{"openssl@3"}, metadata.Dependencies)
+		assert.Len(t, pd.Files, 2)
+
+		req = NewRequestWithBody(t, "PUT", bottlesURL+"/"+armFilename, bytes.NewReader(armBottle)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", bottlesURL+"/"+armFilename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, armBottle, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", bottlesURL+"/"+formulaName+"--"+formulaVersion+".sonoma.bottle.tar.gz")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	sha256sum := func(content []byte) string {
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:])
+	}
+
+	t.Run("FormulaJSON", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		type formula struct {
+			Name     string `json:"name"`
+			Desc     string `json:"desc"`
+			Versions struct {
+				Stable string `json:"stable"`
+			} `json:"versions"`
+			Bottle struct {
+				Stable struct {
+					RootURL string `json:"root_url"`
+					Files   map[string]struct {
+						Cellar string `json:"cellar"`
+						URL    string `json:"url"`
+						Sha256 string `json:"sha256"`
+					} `json:"files"`
+				} `json:"stable"`
+			} `json:"bottle"`
+			Dependencies []string `json:"dependencies"`
+		}
+
+		req := NewRequest(t, "GET", root+"/formula/unknown.json")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/formula/"+formulaName+".json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result formula
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, formulaName, result.Name)
+		assert.Equal(t, formulaDescription, result.Desc)
+		assert.Equal(t, formulaVersion, result.Versions.Stable)
+		assert.Equal(t, setting.AppURL+bottlesURL[1:], result.Bottle.Stable.RootURL)
+		assert.Equal(t, []string{"openssl@3"}, result.Dependencies)
+		require.Len(t, result.Bottle.Stable.Files, 2)
+		assert.Equal(t, ":any", result.Bottle.Stable.Files["arm64_sonoma"].Cellar)
+		assert.Equal(t, sha256sum(armBottle), result.Bottle.Stable.Files["arm64_sonoma"].Sha256)
+		assert.Equal(t, setting.AppURL+bottlesURL[1:]+"/"+armFilename, result.Bottle.Stable.Files["arm64_sonoma"].URL)
+		assert.Equal(t, ":any_skip_relocation", result.Bottle.Stable.Files["x86_64_linux"].Cellar)
+
+		req = NewRequest(t, "GET", root+"/formula.json")
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var results []*formula
+		DecodeJSON(t, resp, &results)
+		require.Len(t, results, 1)
+		assert.Equal(t, formulaName, results[0].Name)
+	})
+
+	t.Run("FormulaRuby", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/formula/"+formulaName+".rb")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		content := resp.Body.String()
+		assert.True(t, strings.HasPrefix(content, "class GiteaCli < Formula\n"))
+		assert.Contains(t, content, `  desc "`+formulaDescription+`"`)
+		assert.Contains(t, content, `  version "`+formulaVersion+`"`)
+		assert.Contains(t, content, `    root_url "`+setting.AppURL+bottlesURL[1:]+`"`)
+		assert.Contains(t, content, `    sha256 cellar: :any, arm64_sonoma: "`+sha256sum(armBottle)+`"`)
+		assert.Contains(t, content, `    sha256 cellar: :any_skip_relocation, x86_64_linux: "`+sha256sum(linuxBottle)+`"`)
+		assert.Contains(t, content, `  depends_on "openssl@3"`)
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageNix(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	storeHash := "3n58xw4373jp0ljirf06d8077j15pc4j"
+	storeName := "hello-2.12.1"
+	storePath := nix_module.StoreDir + "/" + storeHash + "-" + storeName
+
+	nar := []byte("nix archive content")
+	narSum := sha256.Sum256(nar)
+	narHash := "sha256:" + nix_module.EncodeBase32(narSum[:])
+	narFilename := nix_module.EncodeBase32(narSum[:]) + ".nar"
+
+	narInfo := fmt.Sprintf(`StorePath: %s
+URL: nar/%s
+Compression: none
+FileHash: %s
+FileSize: %d
+NarHash: %s
+NarSize: %d
+References: %s-%s
+System: x86_64-linux
+`, storePath, narFilename, narHash, len(nar), narHash, len(nar), storeHash, storeName)
+
+	root := fmt.Sprintf("/api/packages/%s/nix", user.Name)
+
+	t.Run("CacheInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nix-cache-info")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "StoreDir: /nix/store\n")
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", root+"/nar/"+narFilename, bytes.NewReader(nar))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", root+"/nar/invalid.zip", bytes.NewReader(nar)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// the nar has to be uploaded before the narinfo
+		req = NewRequestWithBody(t, "PUT", root+"/"+storeHash+".narinfo", strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", root+"/nar/"+narFilename, bytes.NewReader(nar)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", root+"/"+strings.Repeat("0", 32)+".narinfo", strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", root+"/"+storeHash+".narinfo", strings.NewReader(strings.Replace(narInfo, fmt.Sprintf("FileSize: %d", len(nar)), "FileSize: 1", 1))).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", root+"/"+storeHash+".narinfo", strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNix)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Equal(t, storeName, pd.Package.Name)
+		assert.Equal(t, storeHash, pd.Version.Version)
+		assert.IsType(t, &nix_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, storePath, pd.Metadata.(*nix_module.Metadata).StorePath)
+		require.Len(t, pd.Files, 1)
+		assert.Equal(t, narFilename, pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+
+		// the nar was moved out of the upload version, so the narinfo can't be uploaded again
+		req = NewRequestWithBody(t, "PUT", root+"/nar/"+narFilename, bytes.NewReader(nar)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", root+"/"+storeHash+".narinfo", strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/public-key")
+		resp := MakeRequest(t, req, http.StatusOK)
+		publicKey := resp.Body.String()
+		assert.True(t, strings.HasPrefix(publicKey, "localhost-"+user.LowerName+"-1:"))
+
+		req = NewRequest(t, "GET", root+"/"+strings.Repeat("0", 32)+".narinfo")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "HEAD", root+"/"+storeHash+".narinfo")
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", root+"/"+storeHash+".narinfo")
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		m, err := nix_module.ParseNarInfo(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, storePath, m.StorePath)
+		require.Len(t, m.Signatures, 1)
+		assert.True(t, nix_module.Verify(publicKey, m.Signatures[0], m))
+
+		req = NewRequest(t, "GET", root+"/"+m.URL)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, nar, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/nar/"+strings.Repeat("0", 52)+".nar")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/nix/%s/%s", user.Name, storeName, storeHash))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "nix-store --realise "+storePath)
+	})
+}
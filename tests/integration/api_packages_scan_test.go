@@ -0,0 +1,163 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	scan_service "code.gitea.io/gitea/services/packages/scan"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageScan(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// the scanner reports a vulnerability if the scanned directory contains the file of the package
+	scanner := filepath.Join(t.TempDir(), "scanner.sh")
+	require.NoError(t, os.WriteFile(scanner, []byte(`#!/bin/sh
+for last; do true; done
+if [ "$1" != "rootfs" ]; then
+	echo "unexpected command $1" >&2
+	exit 1
+fi
+if [ -n "$(find "$last" -name vulnerable.bin)" ]; then
+	echo '{"Results":[{"Target":"vulnerable.bin","Vulnerabilities":[{"VulnerabilityID":"CVE-2024-1234","PkgName":"libfoo","InstalledVersion":"1.0.0","FixedVersion":"1.0.1","Severity":"CRITICAL","Title":"Remote code execution"},{"VulnerabilityID":"CVE-2024-5678","PkgName":"libbar","InstalledVersion":"2.0.0","Severity":"LOW"}]}]}'
+else
+	echo '{"Results":[]}'
+fi
+`), 0o755))
+
+	defer test.MockVariableValue(&setting.Packages.Scan, setting.PackageScan{
+		Enabled:       true,
+		Command:       scanner,
+		OnUpload:      true,
+		Timeout:       setting.Packages.Scan.Timeout,
+		BlockSeverity: "HIGH",
+	})()
+
+	upload := func(t *testing.T, filename string) *packages.PackageDescriptor {
+		url := fmt.Sprintf("/api/packages/%s/generic/scanned/%s/%s", user.Name, filename, filename)
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader([]byte("content"))).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeGeneric, "scanned", filename)
+		require.NoError(t, err)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+		require.NoError(t, err)
+		require.NoError(t, scan_service.ScanVersion(t.Context(), pd))
+		return pd
+	}
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadPackage)
+
+	t.Run("Vulnerable", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		upload(t, "vulnerable.bin")
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/scanned/vulnerable.bin/vulnerabilities", user.Name)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var report *api.PackageVulnerabilityReport
+		DecodeJSON(t, resp, &report)
+		assert.Equal(t, "done", report.Status)
+		assert.NotNil(t, report.ScannedAt)
+		assert.Equal(t, "CRITICAL", report.MaxSeverity)
+		assert.Equal(t, map[string]int{"CRITICAL": 1, "LOW": 1}, report.Counts)
+		require.Len(t, report.Vulnerabilities, 2)
+		assert.Equal(t, "CVE-2024-1234", report.Vulnerabilities[0].ID)
+		assert.Equal(t, "libfoo", report.Vulnerabilities[0].PackageName)
+		assert.Equal(t, "1.0.1", report.Vulnerabilities[0].FixedVersion)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/generic/scanned/vulnerable.bin/vulnerable.bin", user.Name)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/scanned/vulnerable.bin", user.Name))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "CVE-2024-1234")
+
+		defer test.MockVariableValue(&setting.Packages.Scan.BlockSeverity, "")()
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/generic/scanned/vulnerable.bin/vulnerable.bin", user.Name)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("Clean", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		upload(t, "clean.bin")
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/scanned/clean.bin/vulnerabilities", user.Name)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var report *api.PackageVulnerabilityReport
+		DecodeJSON(t, resp, &report)
+		assert.Equal(t, "done", report.Status)
+		assert.Empty(t, report.MaxSeverity)
+		assert.Empty(t, report.Vulnerabilities)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/generic/scanned/clean.bin/clean.bin", user.Name)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		defer test.MockVariableValue(&setting.Packages.Scan.Command, filepath.Join(t.TempDir(), "missing"))()
+
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeGeneric, "scanned", "vulnerable.bin")
+		require.NoError(t, err)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+		require.NoError(t, err)
+		assert.Error(t, scan_service.ScanVersion(t.Context(), pd))
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/scanned/vulnerable.bin/vulnerabilities", user.Name)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var report *api.PackageVulnerabilityReport
+		DecodeJSON(t, resp, &report)
+		assert.Equal(t, "failed", report.Status)
+		assert.NotEmpty(t, report.Error)
+		assert.Len(t, report.Vulnerabilities, 2)
+	})
+
+	t.Run("NotScanned", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		defer test.MockVariableValue(&setting.Packages.Scan.Enabled, false)()
+
+		url := fmt.Sprintf("/api/packages/%s/generic/scanned/other.bin/other.bin", user.Name)
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader([]byte("content"))).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/scanned/other.bin/vulnerabilities", user.Name)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/.well-known/terraform.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, setting.AppSubURL+"/api/packages/-/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppSubURL+"/api/packages/-/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "consul"
+		moduleSystem := "aws"
+		moduleVersion := "1.0.0"
+		readme := "# Consul"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": `output "x" { value = 1 }`, "README.md": readme} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		root := fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/%s", user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			uploadURL := root + "/" + moduleVersion
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader([]byte("dummy"))).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+			require.NoError(t, err)
+			assert.NotNil(t, pd.SemVer)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+			require.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindModule, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, readme, pd.Metadata.(*terraform_module.Metadata).Readme)
+			require.Len(t, pd.Files, 1)
+			assert.Equal(t, "consul-aws-1.0.0.tar.gz", pd.Files[0].File.Name)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/2.0.0/download")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", root+"/"+moduleVersion+"/download")
+			resp := MakeRequest(t, req, http.StatusNoContent)
+			archiveURL := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, setting.AppURL+root[1:]+"/1.0.0/consul-aws-1.0.0.tar.gz", archiveURL)
+
+			req = NewRequest(t, "GET", strings.TrimPrefix(archiveURL, setting.AppURL[:len(setting.AppURL)-1]))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "2.1.0"
+		filename := "terraform-provider-example_2.1.0_linux_amd64.zip"
+
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("terraform-provider-example_v2.1.0")
+		w.Write([]byte("binary"))
+		zw.Close()
+		content := buf.Bytes()
+		sum := sha256.Sum256(content)
+		shasum := hex.EncodeToString(sum[:])
+
+		root := fmt.Sprintf("/api/packages/-/terraform/providers/v1/%s/%s", user.Name, providerType)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", root+"/"+providerVersion+"/"+filename, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", root+"/"+providerVersion+"/example.zip", bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", root+"/"+providerVersion+"/"+filename+"?protocols=5.0,6.0", bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeTerraform, providerType, providerVersion)
+			require.NoError(t, err)
+			pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+			require.NoError(t, err)
+			require.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindProvider, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, []string{"5.0", "6.0"}, pd.Metadata.(*terraform_module.Metadata).Protocols)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"5.0", "6.0"}, result.Versions[0].Protocols)
+			require.Len(t, result.Versions[0].Platforms, 1)
+			assert.Equal(t, "linux", result.Versions[0].Platforms[0].OS)
+			assert.Equal(t, "amd64", result.Versions[0].Platforms[0].Arch)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", root+"/"+providerVersion+"/download/darwin/arm64")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", root+"/"+providerVersion+"/download/linux/amd64")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Filename            string `json:"filename"`
+				DownloadURL         string `json:"download_url"`
+				ShasumsURL          string `json:"shasums_url"`
+				ShasumsSignatureURL string `json:"shasums_signature_url"`
+				Shasum              string `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+			assert.Equal(t, filename, result.Filename)
+			assert.Equal(t, shasum, result.Shasum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			toPath := func(u string) string {
+				return strings.TrimPrefix(u, setting.AppURL[:len(setting.AppURL)-1])
+			}
+
+			req = NewRequest(t, "GET", toPath(result.DownloadURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", toPath(result.ShasumsURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			shasums := resp.Body.Bytes()
+			assert.Equal(t, shasum+"  "+filename+"\n", string(shasums))
+
+			req = NewRequest(t, "GET", toPath(result.ShasumsSignatureURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			assert.Equal(t, strings.ToUpper(keyring[0].PrimaryKey.KeyIdString()), result.SigningKeys.GPGPublicKeys[0].KeyID)
+			_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(shasums), resp.Body, nil)
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPackageAPI(t *testing.T) {
@@ -634,6 +635,19 @@ func TestPackageCleanup(t *testing.T) {
 					MatchFullName: true,
 				},
 			},
+			{
+				Name: "RemovePrereleaseDays",
+				Versions: []version{
+					{Version: "1.0.0", ShouldExist: true, Created: 1},
+					{Version: "1.1.0-rc.1", ShouldExist: false, Created: 1},
+					{Version: "1.1.0-rc.2", ShouldExist: true},
+				},
+				Rule: &packages_model.PackageCleanupRule{
+					Enabled:              true,
+					KeepCount:            5,
+					RemovePrereleaseDays: 7,
+				},
+			},
 			{
 				Name: "Mixed",
 				Versions: func(limit, removeDays int) []version {
@@ -698,4 +712,89 @@ func TestPackageCleanup(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("API", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage, auth_model.AccessTokenScopeReadRepository)
+		rulesURL := fmt.Sprintf("/api/v1/packages/%s/-/rules", user.Name)
+
+		for _, name := range []string{"linked", "unlinked"} {
+			req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/1.0.0/file.bin", user.Name, name), bytes.NewReader([]byte{1})).
+				AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusCreated)
+			pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeGeneric, name, "1.0.0")
+			require.NoError(t, err)
+			_, err = db.GetEngine(t.Context()).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", 1, pv.ID)
+			require.NoError(t, err)
+		}
+		req := NewRequest(t, "POST", fmt.Sprintf("/api/v1/packages/%s/generic/linked/-/link/repo1", user.Name)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithJSON(t, "POST", rulesURL, &api.CreatePackageCleanupRuleOption{Type: "generic", RemovePattern: "("}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", rulesURL, &api.CreatePackageCleanupRuleOption{Type: "generic", RepoName: "unknown"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", rulesURL, &api.CreatePackageCleanupRuleOption{Type: "generic", RemoveDays: 30}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var ownerRule api.PackageCleanupRule
+		DecodeJSON(t, resp, &ownerRule)
+		assert.Nil(t, ownerRule.Repository)
+		assert.Equal(t, 30, ownerRule.RemoveDays)
+
+		req = NewRequestWithJSON(t, "POST", rulesURL, &api.CreatePackageCleanupRuleOption{Type: "generic"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithJSON(t, "POST", rulesURL, &api.CreatePackageCleanupRuleOption{Type: "generic", RepoName: "repo1", KeepCount: 1}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		var repoRule api.PackageCleanupRule
+		DecodeJSON(t, resp, &repoRule)
+		require.NotNil(t, repoRule.Repository)
+		assert.Equal(t, "repo1", repoRule.Repository.Name)
+
+		req = NewRequest(t, "GET", rulesURL).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var rules []*api.PackageCleanupRule
+		DecodeJSON(t, resp, &rules)
+		assert.Len(t, rules, 2)
+
+		preview := func(id int64) []string {
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/%d/preview", rulesURL, id)).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var pkgs []*api.Package
+			DecodeJSON(t, resp, &pkgs)
+			names := make([]string, 0, len(pkgs))
+			for _, pkg := range pkgs {
+				names = append(names, pkg.Name)
+			}
+			return names
+		}
+
+		// the rule of the repository replaces the rule of the owner for the linked package
+		assert.Equal(t, []string{"unlinked"}, preview(ownerRule.ID))
+		assert.Empty(t, preview(repoRule.ID))
+
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d", rulesURL, repoRule.ID), &api.EditPackageCleanupRuleOption{KeepCount: util.ToPointer(0)}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &repoRule)
+		assert.Equal(t, 0, repoRule.KeepCount)
+		assert.Equal(t, []string{"linked"}, preview(repoRule.ID))
+
+		// the preview doesn't remove anything
+		_, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeGeneric, "linked", "1.0.0")
+		assert.NoError(t, err)
+
+		readToken := getUserToken(t, "user4", auth_model.AccessTokenScopeReadPackage)
+		req = NewRequest(t, "GET", rulesURL).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		for _, id := range []int64{ownerRule.ID, repoRule.ID} {
+			req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", rulesURL, id)).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+		}
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d", rulesURL, ownerRule.ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
 }
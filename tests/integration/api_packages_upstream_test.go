@@ -0,0 +1,269 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	upstream_service "code.gitea.io/gitea/services/packages/upstream"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageUpstream(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	npmTarball := []byte("npm tarball content")
+	pypiFile := []byte("pypi wheel content")
+
+	var upstreamRequests atomic.Int64
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamRequests.Add(1)
+		switch r.URL.Path {
+		case "/npm/upstream-package":
+			sha512sum := sha512.Sum512(npmTarball)
+			sha1sum := sha1.Sum(npmTarball)
+			version := func(version, license string) map[string]any {
+				return map[string]any{
+					"name":    "upstream-package",
+					"version": version,
+					"license": license,
+					"dist": map[string]any{
+						"integrity": "sha512-" + base64.StdEncoding.EncodeToString(sha512sum[:]),
+						"shasum":    hex.EncodeToString(sha1sum[:]),
+						"tarball":   upstream.URL + "/npm/tarballs/upstream-package-" + version + ".tgz",
+					},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":      "upstream-package",
+				"dist-tags": map[string]string{"latest": "1.0.0", "next": "2.0.0"},
+				"versions": map[string]any{
+					"1.0.0": version("1.0.0", "MIT"),
+					"2.0.0": version("2.0.0", "GPL-3.0"),
+				},
+			})
+		case "/npm/tarballs/upstream-package-1.0.0.tgz":
+			_, _ = w.Write(npmTarball)
+		case "/pypi/pypi/upstream-package/json", "/pypi/pypi/upstream-package/1.0.0/json":
+			sha256sum := sha256.Sum256(pypiFile)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"info": map[string]any{
+					"name":        "upstream_package",
+					"summary":     "Upstream package",
+					"classifiers": []string{"License :: OSI Approved :: MIT License"},
+				},
+				"releases": map[string]any{
+					"1.0.0": []map[string]any{
+						{
+							"filename": "upstream_package-1.0.0-py3-none-any.whl",
+							"url":      upstream.URL + "/pypi/files/upstream_package-1.0.0-py3-none-any.whl",
+							"digests":  map[string]string{"sha256": hex.EncodeToString(sha256sum[:])},
+						},
+					},
+				},
+			})
+		case "/pypi/files/upstream_package-1.0.0-py3-none-any.whl":
+			_, _ = w.Write(pypiFile)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	defer test.MockVariableValue(&setting.Packages.Proxies, map[string]*setting.PackageProxy{
+		"npm": {
+			Upstream:        upstream.URL + "/npm",
+			Owners:          container.SetOf(user.LowerName),
+			CacheTTL:        time.Hour,
+			MaxSize:         -1,
+			AllowedLicenses: []string{"MIT"},
+		},
+		"pypi": {
+			Upstream:        upstream.URL + "/pypi",
+			Owners:          container.SetOf(user.LowerName),
+			CacheTTL:        time.Hour,
+			MaxSize:         -1,
+			AllowedLicenses: []string{"MIT*"},
+		},
+	})()
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		req := NewRequest(t, "GET", root+"/upstream-package")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			DistTags map[string]string `json:"dist-tags"`
+			Versions map[string]struct {
+				Dist struct {
+					Tarball string `json:"tarball"`
+				} `json:"dist"`
+			} `json:"versions"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, map[string]string{"latest": "1.0.0"}, result.DistTags)
+		require.Len(t, result.Versions, 1)
+		tarballURL := setting.AppURL + root[1:] + "/upstream-package/-/1.0.0/upstream-package-1.0.0.tgz"
+		assert.Equal(t, tarballURL, result.Versions["1.0.0"].Dist.Tarball)
+
+		// the document is cached
+		requests := upstreamRequests.Load()
+		MakeRequest(t, NewRequest(t, "GET", root+"/upstream-package"), http.StatusOK)
+		assert.Equal(t, requests, upstreamRequests.Load())
+
+		req = NewRequest(t, "GET", tarballURL)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, npmTarball, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/upstream-package/-/2.0.0/upstream-package-2.0.0.tgz")
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", root+"/unknown-package")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNpm)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", pd.Version.Version)
+		assert.Equal(t, upstream.URL+"/npm", pd.VersionProperties.GetByName(upstream_service.PropertyUpstream))
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+		fileURL := setting.AppURL + root[1:] + "/files/upstream-package/1.0.0/upstream_package-1.0.0-py3-none-any.whl"
+
+		req := NewRequest(t, "GET", root+"/simple/upstream-package")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		sha256sum := sha256.Sum256(pypiFile)
+		assert.Contains(t, resp.Body.String(), `<a href="`+fileURL+`#sha256=`+hex.EncodeToString(sha256sum[:])+`">`)
+
+		req = NewRequest(t, "GET", fileURL)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, pypiFile, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/files/upstream-package/1.0.0/unknown.whl")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypePyPI)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Equal(t, "upstream-package", pd.Package.Name)
+		assert.Equal(t, "1.0.0", pd.Version.Version)
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/api/packages/user4/npm/upstream-package")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		config := []byte(`{"architecture":"amd64","os":"linux","config":{}}`)
+		layer := []byte("layer content")
+		manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":%d},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":%d}]}`,
+			digest.FromBytes(config), len(config), digest.FromBytes(layer), len(layer))
+
+		registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/token":
+				_ = json.NewEncoder(w).Encode(map[string]string{"token": "upstream-token"})
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer upstream-token" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="registry",scope="repository:`+strings.TrimPrefix(r.URL.Path, "/v2/")+`:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/test/image/manifests/latest":
+				w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+				_, _ = w.Write([]byte(manifest))
+			case "/v2/test/image/blobs/" + digest.FromBytes(config).String():
+				_, _ = w.Write(config)
+			case "/v2/test/image/blobs/" + digest.FromBytes(layer).String():
+				_, _ = w.Write(layer)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer registry.Close()
+
+		defer test.MockVariableValue(&setting.Packages.Proxies, map[string]*setting.PackageProxy{
+			"container": {
+				Upstream: registry.URL,
+				Owners:   container.SetOf(user.LowerName),
+				CacheTTL: time.Hour,
+				MaxSize:  -1,
+			},
+		})()
+
+		req := NewRequest(t, "GET", setting.AppURL+"v2/token")
+		resp := MakeRequest(t, req, http.StatusOK)
+		var tokenResponse struct {
+			Token string `json:"token"`
+		}
+		DecodeJSON(t, resp, &tokenResponse)
+		token := "Bearer " + tokenResponse.Token
+
+		root := fmt.Sprintf("%sv2/%s/test/image", setting.AppURL, user.Name)
+
+		req = NewRequest(t, "GET", root+"/manifests/latest").
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, manifest, resp.Body.String())
+		assert.Equal(t, digest.FromBytes([]byte(manifest)).String(), resp.Header().Get("Docker-Content-Digest"))
+
+		req = NewRequest(t, "GET", root+"/manifests/"+digest.FromBytes([]byte(manifest)).String()).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, manifest, resp.Body.String())
+
+		req = NewRequest(t, "GET", root+"/blobs/"+digest.FromBytes(layer).String()).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, layer, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/manifests/unknown").
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
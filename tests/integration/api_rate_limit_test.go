@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/routers"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRateLimit(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	versionPath, err := setting.GlobMatcherCompile("/version", '/')
+	require.NoError(t, err)
+	defer test.MockVariableValue(&setting.APIRateLimit.Enabled, true)()
+	defer setting.SetAPIRateLimitRules(setting.GetAPIRateLimitRules())
+	setting.SetAPIRateLimitRules(&setting.APIRateLimitRules{
+		Default: &setting.APIRateLimitRule{
+			Name:               "test-default",
+			Period:             time.Hour,
+			AnonymousLimit:     2,
+			AuthenticatedLimit: 3,
+		},
+		Rules: []*setting.APIRateLimitRule{{
+			Name:               "test-version",
+			Methods:            container.SetOf("GET"),
+			Paths:              []*setting.GlobMatcher{versionPath},
+			Period:             time.Hour,
+			AnonymousLimit:     1,
+			AuthenticatedLimit: 1,
+		}},
+		GraphQL: &setting.APIRateLimitRule{
+			Name:               "test-graphql",
+			Period:             time.Hour,
+			AnonymousLimit:     1,
+			AuthenticatedLimit: 2,
+		},
+	})
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+
+	assertRemaining := func(t *testing.T, resp http.Header, limit, remaining int) {
+		assert.Equal(t, strconv.Itoa(limit), resp.Get("RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(remaining), resp.Get("RateLimit-Remaining"))
+		assert.Equal(t, strconv.Itoa(limit)+";w=3600", resp.Get("RateLimit-Policy"))
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		for remaining := 1; remaining >= 0; remaining-- {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1"), http.StatusOK)
+			assertRemaining(t, resp.Header(), 2, remaining)
+		}
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1"), http.StatusTooManyRequests)
+		assertRemaining(t, resp.Header(), 2, 0)
+		retryAfter, err := strconv.Atoi(resp.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.InDelta(t, 1800, retryAfter, 60)
+		assert.Contains(t, resp.Body.String(), "API rate limit exceeded")
+	})
+
+	t.Run("FailedAuth", func(t *testing.T) {
+		// the failed authentications are charged to the bucket of the IP address, which the anonymous requests exhausted
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1")
+		req.SetBasicAuth("user2", "wrong-password")
+		resp := MakeRequest(t, req, http.StatusTooManyRequests)
+		assertRemaining(t, resp.Header(), 2, 0)
+	})
+
+	t.Run("Token", func(t *testing.T) {
+		// every token has its own bucket with the authenticated budget
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		for remaining := 2; remaining >= 0; remaining-- {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token), http.StatusOK)
+			assertRemaining(t, resp.Header(), 3, remaining)
+		}
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token), http.StatusTooManyRequests)
+
+		token = getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token), http.StatusOK)
+		assertRemaining(t, resp.Header(), 3, 2)
+	})
+
+	t.Run("GraphQL", func(t *testing.T) {
+		// the GraphQL API has its own bucket
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadUser, auth_model.AccessTokenScopeReadRepository)
+		query := map[string]any{"query": `{ viewer { login } }`}
+		for remaining := 1; remaining >= 0; remaining-- {
+			resp := MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/graphql", query).AddTokenAuth(token), http.StatusOK)
+			assertRemaining(t, resp.Header(), 2, remaining)
+		}
+		MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/graphql", query).AddTokenAuth(token), http.StatusTooManyRequests)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token), http.StatusOK)
+		assertRemaining(t, resp.Header(), 3, 2)
+	})
+
+	t.Run("Rule", func(t *testing.T) {
+		// the routes matching a rule use the bucket of the rule
+		token := getUserToken(t, "user4", auth_model.AccessTokenScopeReadRepository)
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/version").AddTokenAuth(token), http.StatusOK)
+		assertRemaining(t, resp.Header(), 1, 0)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/version").AddTokenAuth(token), http.StatusTooManyRequests)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token), http.StatusOK)
+		assertRemaining(t, resp.Header(), 3, 2)
+	})
+}
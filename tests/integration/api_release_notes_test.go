@@ -0,0 +1,129 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIGenerateReleaseNotes(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, _ *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		token := getUserToken(t, user2.LowerName, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+		apiCtx := NewAPITestContext(t, user2.Name, repo1.Name, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+
+		// the config of the release notes is read from the released commit
+		_, err := files_service.ChangeRepoFiles(t.Context(), repo1, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{{
+				Operation: "create",
+				TreePath:  ".gitea/release.yml",
+				ContentReader: strings.NewReader(`changelog:
+  categories:
+    - title: Highlights
+      labels: [label1]
+`),
+			}},
+			Message:   "add release notes config",
+			OldBranch: repo1.DefaultBranch,
+			NewBranch: repo1.DefaultBranch,
+		})
+		require.NoError(t, err)
+
+		mergeFeature := func(branch string, labels ...int64) api.PullRequest {
+			_, err := files_service.ChangeRepoFiles(t.Context(), repo1, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{{
+					Operation:     "create",
+					TreePath:      branch + ".txt",
+					ContentReader: strings.NewReader(branch),
+				}},
+				Message:   "add " + branch,
+				OldBranch: repo1.DefaultBranch,
+				NewBranch: branch,
+			})
+			require.NoError(t, err)
+
+			pr, err := doAPICreatePullRequest(apiCtx, user2.Name, repo1.Name, repo1.DefaultBranch, branch)(t)
+			require.NoError(t, err)
+			if len(labels) > 0 {
+				req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/labels", user2.Name, repo1.Name, pr.Index), &api.IssueLabelsOption{
+					Labels: []any{labels[0]},
+				}).AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusOK)
+			}
+			doAPIMergePullRequest(apiCtx, user2.Name, repo1.Name, pr.Index)(t)
+			return pr
+		}
+		highlight := mergeFeature("notes-highlight", 1)
+		other := mergeFeature("notes-other")
+
+		urlStr := fmt.Sprintf("/api/v1/repos/%s/%s/releases/generate-notes", user2.Name, repo1.Name)
+		expected := fmt.Sprintf(`## What's Changed
+
+### Highlights
+
+* create a pr from notes-highlight to master by @user2 in #%d
+
+### Other Changes
+
+* create a pr from notes-other to master by @user2 in #%d
+
+**Full Changelog**: %suser2/repo1/compare/v1.1...v2.0.0
+`, highlight.Index, other.Index, setting.AppURL)
+
+		t.Run("Generate", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "POST", urlStr, &api.GenerateReleaseNotesOption{TagName: "v2.0.0"}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var notes api.ReleaseNotes
+			DecodeJSON(t, resp, &notes)
+			assert.Equal(t, "v2.0.0", notes.Title)
+			assert.Equal(t, expected, notes.Note)
+		})
+
+		t.Run("PreviousTagNotExist", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "POST", urlStr, &api.GenerateReleaseNotesOption{TagName: "v2.0.0", PreviousTagName: "v0.0.0"}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		t.Run("CreateRelease", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/%s/%s/releases", user2.Name, repo1.Name), &api.CreateReleaseOption{
+				TagName:              "v2.0.0",
+				Title:                "v2.0.0",
+				Note:                 "Introduction",
+				GenerateReleaseNotes: true,
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			var release api.Release
+			DecodeJSON(t, resp, &release)
+			assert.Equal(t, "Introduction\n\n"+expected, release.Note)
+		})
+
+		t.Run("Web", func(t *testing.T) {
+			session := loginUser(t, user2.Name)
+			req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/%s/releases/generate-notes", user2.Name, repo1.Name), map[string]string{
+				"_csrf":    GetUserCSRFToken(t, session),
+				"tag_name": "v3.0.0",
+			})
+			resp := session.MakeRequest(t, req, http.StatusOK)
+			var result map[string]string
+			DecodeJSON(t, resp, &result)
+			assert.Contains(t, result["content"], "**Full Changelog**: "+setting.AppURL+"user2/repo1/compare/v2.0.0...v3.0.0")
+		})
+	})
+}
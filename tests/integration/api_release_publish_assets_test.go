@@ -0,0 +1,194 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	debian_module "code.gitea.io/gitea/modules/packages/debian"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	release_service "code.gitea.io/gitea/services/release"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/blakesmith/ar"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestDebianPackage(name, version, architecture string) []byte {
+	control := fmt.Sprintf("Package: %s\nVersion: %s\nArchitecture: %s\nDescription: Package Description\n", name, version, architecture)
+
+	var cbuf bytes.Buffer
+	zw := gzip.NewWriter(&cbuf)
+	tw := tar.NewWriter(zw)
+	_ = tw.WriteHeader(&tar.Header{Name: "control", Mode: 0o600, Size: int64(len(control))})
+	_, _ = tw.Write([]byte(control))
+	_ = tw.Close()
+	_ = zw.Close()
+
+	var buf bytes.Buffer
+	aw := ar.NewWriter(&buf)
+	_ = aw.WriteGlobalHeader()
+	_ = aw.WriteHeader(&ar.Header{Name: "control.tar.gz", Mode: 0o600, Size: int64(cbuf.Len())})
+	_, _ = aw.Write(cbuf.Bytes())
+	return buf.Bytes()
+}
+
+func createTestImageLayout(t *testing.T) []byte {
+	config := []byte(`{"architecture":"amd64","os":"linux","config":{}}`)
+	layer := []byte("layer content")
+
+	manifest, err := json.Marshal(&oci.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageManifest,
+		Config: oci.Descriptor{
+			MediaType: oci.MediaTypeImageConfig,
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+		},
+		Layers: []oci.Descriptor{{
+			MediaType: oci.MediaTypeImageLayerGzip,
+			Digest:    digest.FromBytes(layer),
+			Size:      int64(len(layer)),
+		}},
+	})
+	require.NoError(t, err)
+
+	index, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     oci.MediaTypeImageIndex,
+		"manifests": []oci.Descriptor{{
+			MediaType: oci.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest),
+			Size:      int64(len(manifest)),
+		}},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range map[string][]byte{
+		"oci-layout": []byte(`{"imageLayoutVersion":"1.0.0"}`),
+		"index.json": index,
+		"blobs/sha256/" + digest.FromBytes(manifest).Encoded(): manifest,
+		"blobs/sha256/" + digest.FromBytes(config).Encoded():   config,
+		"blobs/sha256/" + digest.FromBytes(layer).Encoded():    layer,
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestAPIReleasePublishAssets(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	session := loginUser(t, owner.LowerName)
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+
+	repoURL := fmt.Sprintf("/api/v1/repos/%s/%s", owner.Name, repo.Name)
+
+	req := NewRequestWithJSON(t, "PATCH", repoURL, &api.EditRepoOption{
+		HasReleases:          util.ToPointer(true),
+		PublishReleaseAssets: util.ToPointer(true),
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+
+	var apiRepo *api.Repository
+	DecodeJSON(t, resp, &apiRepo)
+	assert.True(t, apiRepo.PublishReleaseAssets)
+
+	r := createNewReleaseUsingAPI(t, token, owner, repo, "publish-v1", "", "Publish Assets", "test")
+
+	uploadAsset := func(name string, content []byte) {
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/releases/%d/assets?name=%s", repoURL, r.ID, name), bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+	uploadAsset("gitea_1.0.0_amd64.deb", createTestDebianPackage("gitea", "1.0.0", "amd64"))
+	uploadAsset("image.oci.tar", createTestImageLayout(t))
+	uploadAsset("notes.txt", []byte("not a package"))
+
+	require.NoError(t, release_service.PublishAssets(t.Context(), r.ID))
+	// publishing again skips the assets which have already been published
+	require.NoError(t, release_service.PublishAssets(t.Context(), r.ID))
+
+	t.Run("Debian", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		p, err := packages_model.GetPackageByName(t.Context(), owner.ID, packages_model.TypeDebian, "gitea")
+		require.NoError(t, err)
+		assert.Equal(t, repo.ID, p.RepoID)
+
+		pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), owner.ID, packages_model.TypeDebian, "gitea", "1.0.0")
+		require.NoError(t, err)
+		pfs, err := packages_model.GetFilesByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 1)
+		assert.Equal(t, "stable|main", pfs[0].CompositeKey)
+
+		pps, err := packages_model.GetPropertiesByName(t.Context(), packages_model.PropertyTypeFile, pfs[0].ID, debian_module.PropertyDistribution)
+		require.NoError(t, err)
+		require.Len(t, pps, 1)
+		assert.Equal(t, "stable", pps[0].Value)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		p, err := packages_model.GetPackageByName(t.Context(), owner.ID, packages_model.TypeContainer, repo.LowerName)
+		require.NoError(t, err)
+		assert.Equal(t, repo.ID, p.RepoID)
+
+		_, err = packages_model.GetVersionByNameAndVersion(t.Context(), owner.ID, packages_model.TypeContainer, repo.LowerName, "publish-v1")
+		assert.NoError(t, err)
+	})
+}
+
+func TestAPIReleasePublishAssetsWithoutPackageAccess(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user4 can write the repository of user5 as a collaborator, but can't write the packages of user5
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	ownerToken := getUserToken(t, owner.LowerName, auth_model.AccessTokenScopeWriteRepository)
+	token := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+
+	repoURL := fmt.Sprintf("/api/v1/repos/%s/%s", owner.Name, repo.Name)
+
+	req := NewRequestWithJSON(t, "PATCH", repoURL, &api.EditRepoOption{
+		HasReleases:          util.ToPointer(true),
+		PublishReleaseAssets: util.ToPointer(true),
+	}).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusOK)
+
+	r := createNewReleaseUsingAPI(t, token, owner, repo, "release-1", "", "Publish Assets", "test")
+	req = NewRequestWithBody(t, "POST", fmt.Sprintf("%s/releases/%d/assets?name=gitea_1.0.0_amd64.deb", repoURL, r.ID), bytes.NewReader(createTestDebianPackage("gitea", "1.0.0", "amd64"))).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+
+	require.NoError(t, release_service.PublishAssets(t.Context(), r.ID))
+
+	_, err := packages_model.GetPackageByName(t.Context(), owner.ID, packages_model.TypeDebian, "gitea")
+	assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+}
@@ -42,3 +42,48 @@ func TestAPICreateHook(t *testing.T) {
 	assert.Equal(t, "http://example.com/", apiHook.Config["url"])
 	assert.Equal(t, "Bearer s3cr3t", apiHook.AuthorizationHeader)
 }
+
+func TestAPICreateMatrixHook(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+		Type: "matrix",
+		Config: api.CreateHookOptionConfig{
+			"homeserver_url": "https://matrix.example.org/",
+			"room_id":        "!room:example.org",
+			"access_token":   "s3cr3t",
+		},
+		Events: []string{"push"},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+
+	var apiHook *api.Hook
+	DecodeJSON(t, resp, &apiHook)
+	assert.Equal(t, "https://matrix.example.org/_matrix/client/r0/rooms/%21room:example.org/send/m.room.message", apiHook.Config["url"])
+	assert.Equal(t, "https://matrix.example.org", apiHook.Config["homeserver_url"])
+	assert.Equal(t, "m.notice", apiHook.Config["message_type"])
+	assert.Equal(t, "Bearer s3cr3t", apiHook.AuthorizationHeader)
+
+	// the access token is kept when editing the other options
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/user2/repo1/hooks/%d", apiHook.ID), api.EditHookOption{
+		Config: map[string]string{"room_id": "!other:example.org", "message_type": "m.text"},
+		Events: []string{"push"},
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &apiHook)
+	assert.Equal(t, "https://matrix.example.org/_matrix/client/r0/rooms/%21other:example.org/send/m.room.message", apiHook.Config["url"])
+	assert.Equal(t, "m.text", apiHook.Config["message_type"])
+	assert.Equal(t, "Bearer s3cr3t", apiHook.AuthorizationHeader)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+		Type:   "matrix",
+		Config: api.CreateHookOptionConfig{"homeserver_url": "https://matrix.example.org"},
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+		Type:   "matrix",
+		Config: api.CreateHookOptionConfig{"homeserver_url": "https://matrix.example.org", "room_id": "!room:example.org", "message_type": "m.image"},
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+}
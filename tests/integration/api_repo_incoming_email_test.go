@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoIncomingEmail(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	ownerToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	readerToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+	link := "/api/v1/repos/user2/repo1/incoming_email"
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.IncomingEmail.Enabled, false)()
+
+		req := NewRequest(t, "GET", link).AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	defer test.MockVariableValue(&setting.IncomingEmail.Enabled, true)()
+	defer test.MockVariableValue(&setting.IncomingEmail.ReplyToAddress, "incoming+%{token}@example.com")()
+	defer test.MockVariableValue(&setting.IncomingEmail.TokenPlaceholder, "%{token}")()
+
+	req := NewRequest(t, "GET", link).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequestWithJSON(t, "PUT", link, &api.EditIncomingEmailAliasOption{Alias: "repo1"}).AddTokenAuth(readerToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	req = NewRequestWithJSON(t, "PUT", link, &api.EditIncomingEmailAliasOption{Alias: "Repo 1"}).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "PUT", link, &api.EditIncomingEmailAliasOption{Alias: "repo1"}).AddTokenAuth(ownerToken)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var alias api.IncomingEmailAlias
+	DecodeJSON(t, resp, &alias)
+	assert.Equal(t, "repo1", alias.Alias)
+	assert.Equal(t, "incoming+issue-repo1@example.com", alias.Address)
+
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo2/incoming_email", &api.EditIncomingEmailAliasOption{Alias: "repo1"}).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusConflict)
+
+	req = NewRequest(t, "GET", link).AddTokenAuth(readerToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &alias)
+	assert.Equal(t, "repo1", alias.Alias)
+
+	req = NewRequest(t, "DELETE", link).AddTokenAuth(readerToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	req = NewRequest(t, "DELETE", link).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusNoContent)
+
+	req = NewRequest(t, "GET", link).AddTokenAuth(ownerToken)
+	MakeRequest(t, req, http.StatusNotFound)
+}
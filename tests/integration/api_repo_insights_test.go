@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoInsights(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadOrganization)
+
+	t.Run("PullRequests", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/pulls").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var insights api.PullRequestInsights
+		DecodeJSON(t, resp, &insights)
+		assert.Equal(t, 90*24*time.Hour, insights.Before.Sub(insights.Since))
+		assert.NotNil(t, insights.LeadTime)
+		assert.NotNil(t, insights.ReviewTurnaround)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/pulls?since=2000-01-01T00:00:00Z&before=2100-01-01T00:00:00Z").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &insights)
+		assert.EqualValues(t, 3, insights.Opened)
+		assert.EqualValues(t, 2, insights.ReviewTurnaround.Count)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/pulls?since=2100-01-01T00:00:00Z&before=2000-01-01T00:00:00Z").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Issues", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/issues?since=2000-01-01T00:00:00Z&before=2100-01-01T00:00:00Z").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var insights api.IssueInsights
+		DecodeJSON(t, resp, &insights)
+		assert.EqualValues(t, 2, insights.Opened)
+		assert.InDelta(t, 0.5, insights.CloseRate, 0.001)
+
+		// the insights of private repositories aren't visible to anonymous users
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo2/insights/issues")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Contributors", func(t *testing.T) {
+		var contributors []*api.ContributorStats
+		assert.Eventually(t, func() bool {
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/contributors").AddTokenAuth(token)
+			resp := MakeRequest(t, req, NoExpectedStatus)
+			if resp.Code != http.StatusOK {
+				assert.Equal(t, http.StatusAccepted, resp.Code)
+				return false
+			}
+			DecodeJSON(t, resp, &contributors)
+			return true
+		}, 30*time.Second, 500*time.Millisecond)
+		if assert.NotEmpty(t, contributors) {
+			assert.NotEmpty(t, contributors[0].Weeks)
+			assert.Positive(t, contributors[0].TotalCommits)
+		}
+
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/code-frequency").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var weeks []*api.WeeklyCommitStats
+		DecodeJSON(t, resp, &weeks)
+		assert.NotEmpty(t, weeks)
+	})
+
+	t.Run("Organization", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/orgs/org3/insights/pulls?since=2000-01-01T00:00:00Z").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var insights api.PullRequestInsights
+		DecodeJSON(t, resp, &insights)
+		assert.Positive(t, insights.Opened)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/insights/issues")
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+}
@@ -9,12 +9,15 @@ import (
 	"testing"
 	"time"
 
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/lfs"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -179,3 +182,82 @@ func TestAPILFSLocksLogged(t *testing.T) {
 		assert.Empty(t, lfsLocks.Locks)
 	}
 }
+
+func TestAPIRepoLFSLocksAdministration(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.LFS.StartServer, true)()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	for _, lock := range []*git_model.LFSLock{
+		{OwnerID: 2, Path: "first.psd"},
+		{OwnerID: 2, Path: "second.psd"},
+	} {
+		_, err := git_model.CreateLFSLock(t.Context(), repo1, lock)
+		assert.NoError(t, err)
+	}
+	_, err := git_model.CreateLFSLock(t.Context(), repo3, &git_model.LFSLock{OwnerID: 2, Path: "third.psd"})
+	assert.NoError(t, err)
+
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin, auth_model.AccessTokenScopeWriteRepository)
+	ownerToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+
+	t.Run("Repository", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/lfs/locks").AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/lfs/locks?user=user2").AddTokenAuth(ownerToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+		var locks []*api.RepoLFSLock
+		DecodeJSON(t, resp, &locks)
+		if assert.Len(t, locks, 2) {
+			assert.Equal(t, "first.psd", locks[0].Path)
+			assert.Equal(t, "user2", locks[0].Owner.UserName)
+			assert.Equal(t, "user2/repo1", locks[0].Repo.FullName)
+			assert.False(t, locks[0].Created.IsZero())
+		}
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/lfs/locks?user=nobody").AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "DELETE", "/api/v1/repos/user2/repo1/lfs/locks", &api.DeleteLFSLocksOption{}).AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "DELETE", "/api/v1/repos/user2/repo1/lfs/locks", &api.DeleteLFSLocksOption{IDs: []int64{locks[0].ID}}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithJSON(t, "DELETE", "/api/v1/repos/user2/repo1/lfs/locks", &api.DeleteLFSLocksOption{IDs: []int64{locks[0].ID}}).AddTokenAuth(ownerToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var unlocked []*api.RepoLFSLock
+		DecodeJSON(t, resp, &unlocked)
+		if assert.Len(t, unlocked, 1) {
+			assert.Equal(t, "first.psd", unlocked[0].Path)
+		}
+		unittest.AssertNotExistsBean(t, &git_model.LFSLock{ID: locks[0].ID})
+		unittest.AssertExistsAndLoadBean(t, &git_model.LFSLock{ID: locks[1].ID})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/api/v1/admin/lfs/locks").AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", "/api/v1/admin/lfs/locks?user=user2").AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var locks []*api.RepoLFSLock
+		DecodeJSON(t, resp, &locks)
+		assert.Len(t, locks, 2)
+
+		// the locks of a user are released in all repositories
+		req = NewRequestWithJSON(t, "DELETE", "/api/v1/admin/lfs/locks", &api.DeleteLFSLocksOption{Owner: "user2"}).AddTokenAuth(adminToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &locks)
+		assert.Len(t, locks, 2)
+		unittest.AssertCount(t, &git_model.LFSLock{OwnerID: 2}, 0)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoSBOM(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		require.NoError(t, createOrReplaceFileInBranch(user, repo, "go.sum", "master",
+			"github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=\n"))
+		require.NoError(t, createOrReplaceFileInBranch(user, repo, "web/package-lock.json", "master",
+			`{"lockfileVersion":3,"packages":{"":{"name":"web"},"node_modules/lodash":{"version":"4.17.21"}}}`))
+		require.NoError(t, createOrReplaceFileInBranch(user, repo, "web/node_modules/lodash/package-lock.json", "master",
+			`{"lockfileVersion":3,"packages":{"node_modules/vendored":{"version":"1.0.0"}}}`))
+
+		token := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadRepository)
+
+		t.Run("CycloneDX", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "application/vnd.cyclonedx+json", resp.Header().Get("Content-Type"))
+
+			var doc struct {
+				BOMFormat  string `json:"bomFormat"`
+				Components []struct {
+					PURL string `json:"purl"`
+				} `json:"components"`
+			}
+			DecodeJSON(t, resp, &doc)
+			assert.Equal(t, "CycloneDX", doc.BOMFormat)
+			require.Len(t, doc.Components, 2)
+			assert.Equal(t, "pkg:golang/github.com/pkg/errors@v0.9.1", doc.Components[0].PURL)
+			assert.Equal(t, "pkg:npm/lodash@4.17.21", doc.Components[1].PURL)
+		})
+
+		t.Run("SPDX", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom?format=spdx&ref=master").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "application/spdx+json", resp.Header().Get("Content-Type"))
+
+			var doc struct {
+				SPDXVersion string `json:"spdxVersion"`
+				Packages    []struct {
+					Name string `json:"name"`
+				} `json:"packages"`
+			}
+			DecodeJSON(t, resp, &doc)
+			assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+			assert.Len(t, doc.Packages, 3)
+		})
+
+		t.Run("Errors", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom?format=swid").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom?ref=unknown").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
@@ -317,3 +317,41 @@ func TestAPIGetTeamRepo(t *testing.T) {
 		AddTokenAuth(token5)
 	MakeRequest(t, req, http.StatusNotFound)
 }
+
+func TestAPINestedTeams(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+	parent := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 2})
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/teams", &api.CreateTeamOption{
+		Name:       "child",
+		Permission: "read",
+		Units:      []string{"repo.code"},
+		ParentID:   parent.ID,
+	}).AddTokenAuth(token)
+	var child api.Team
+	DecodeJSON(t, MakeRequest(t, req, http.StatusCreated), &child)
+	assert.Equal(t, parent.ID, child.ParentID)
+
+	var children []*api.Team
+	DecodeJSON(t, MakeRequest(t, NewRequestf(t, "GET", "/api/v1/teams/%d/children", parent.ID).AddTokenAuth(token), http.StatusOK), &children)
+	if assert.Len(t, children, 1) {
+		assert.Equal(t, child.ID, children[0].ID)
+	}
+
+	// the member of the child team inherits the membership of the parent team
+	MakeRequest(t, NewRequestf(t, "PUT", "/api/v1/teams/%d/members/user5", child.ID).AddTokenAuth(token), http.StatusNoContent)
+	MakeRequest(t, NewRequestf(t, "GET", "/api/v1/teams/%d/members/user5", parent.ID).AddTokenAuth(token), http.StatusOK)
+	MakeRequest(t, NewRequestf(t, "DELETE", "/api/v1/teams/%d/members/user5", parent.ID).AddTokenAuth(token), http.StatusUnprocessableEntity)
+
+	// a team can't be moved below its own child team
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/teams/%d", parent.ID), &api.EditTeamOption{ParentID: &child.ID}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	topLevel := int64(0)
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/teams/%d", child.ID), &api.EditTeamOption{ParentID: &topLevel}).AddTokenAuth(token)
+	DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &child)
+	assert.Zero(t, child.ParentID)
+	MakeRequest(t, NewRequestf(t, "GET", "/api/v1/teams/%d/members/user5", parent.ID).AddTokenAuth(token), http.StatusNotFound)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITrackedTimeReport(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token2 := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteOrganization, auth_model.AccessTokenScopeWriteRepository)
+	token4 := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue, auth_model.AccessTokenScopeWriteOrganization, auth_model.AccessTokenScopeReadRepository)
+
+	t.Run("Repository", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/times/report?group_by=milestone").AddTokenAuth(token2)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var sums []*api.TrackedTimeSum
+		DecodeJSON(t, resp, &sums)
+		require.Len(t, sums, 2)
+		assert.Equal(t, "milestone1", sums[0].Milestone)
+		assert.EqualValues(t, 3682, sums[0].Time)
+		assert.Equal(t, "user2/repo1", sums[0].Repository)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/times/report?group_by=repo").AddTokenAuth(token2)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// user4 can only read the issues of repo1, so only gets its own times
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/times/report").AddTokenAuth(token4)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &sums)
+		assert.Empty(t, sums)
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/times/report?user=user2").AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/times/export?user=user1").AddTokenAuth(token2)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(resp.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "created,user,repository,index,title,milestone,seconds,duration", lines[0])
+		assert.Contains(t, lines[1], "user1,user2/repo1,1,")
+	})
+
+	t.Run("Stopwatches", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/stopwatches").AddTokenAuth(token2)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var sws api.StopWatches
+		DecodeJSON(t, resp, &sws)
+		require.Len(t, sws, 2)
+		assert.Equal(t, "user1", sws[0].UserName)
+		assert.Equal(t, "user2", sws[1].UserName)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/stopwatches").AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("OrganizationRules", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/org3/repo3", &api.EditRepoOption{
+			HasIssues:       util.ToPointer(true),
+			InternalTracker: &api.InternalTracker{EnableTimeTracker: true},
+		}).AddTokenAuth(token2)
+		MakeRequest(t, req, http.StatusOK)
+
+		// only the owners of org3 may track time on repo3
+		req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/time_tracking_rules", &api.CreateTimeTrackingRuleOption{Repository: "repo3"}).AddTokenAuth(token2)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var rule api.TimeTrackingRule
+		DecodeJSON(t, resp, &rule)
+		assert.Equal(t, "repo3", rule.Repository)
+		assert.Empty(t, rule.Teams)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/time_tracking_rules", &api.CreateTimeTrackingRuleOption{Repository: "repo3"}).AddTokenAuth(token2)
+		MakeRequest(t, req, http.StatusConflict)
+		req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/time_tracking_rules", &api.CreateTimeTrackingRuleOption{}).AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		addTime := &api.AddTimeOption{Time: 60}
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/org3/repo3/issues/1/times", addTime).AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		// user4 is a member of team1
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/orgs/org3/time_tracking_rules/%d", rule.ID), &api.EditTimeTrackingRuleOption{Teams: []int64{2}}).AddTokenAuth(token2)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &rule)
+		assert.Equal(t, []int64{2}, rule.Teams)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/org3/repo3/issues/1/times", addTime).AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/time_tracking_rules").AddTokenAuth(token4)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var rules []*api.TimeTrackingRule
+		DecodeJSON(t, resp, &rules)
+		assert.Len(t, rules, 1)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/times/report?group_by=user").AddTokenAuth(token2)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var sums []*api.TrackedTimeSum
+		DecodeJSON(t, resp, &sums)
+		require.Len(t, sums, 1)
+		assert.Equal(t, "user4", sums[0].UserName)
+		assert.EqualValues(t, 60, sums[0].Time)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/times/report").AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/times/export").AddTokenAuth(token2)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "user4,org3/repo3,1,")
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/orgs/org3/time_tracking_rules/%d", rule.ID)).AddTokenAuth(token2)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIUserMatrixSettings(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteUser)
+
+	req := NewRequest(t, "GET", "/api/v1/user/matrix").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	defer test.MockVariableValue(&setting.Matrix.Enabled, true)()
+
+	req = NewRequest(t, "GET", "/api/v1/user/matrix").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var settings api.MatrixSettings
+	DecodeJSON(t, resp, &settings)
+	assert.Empty(t, settings.MatrixID)
+	assert.Empty(t, settings.Events)
+
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/user/matrix", &api.EditMatrixSettingsOption{
+		MatrixID: "@user2:example.org",
+		Events:   []string{"review_request", "mention"},
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &settings)
+	assert.Equal(t, "@user2:example.org", settings.MatrixID)
+	assert.Equal(t, []string{"mention", "review_request"}, settings.Events)
+
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/user/matrix", &api.EditMatrixSettingsOption{MatrixID: "user2@example.org"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/user/matrix", &api.EditMatrixSettingsOption{Events: []string{"ci_failure"}}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+}
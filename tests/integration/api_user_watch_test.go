@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -86,6 +88,26 @@ func TestAPIWatch(t *testing.T) {
 		MakeRequest(t, req, http.StatusNotFound)
 	})
 
+	t.Run("EditWatch", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/%s/subscription", repo), &api.EditWatchOption{
+			Issues:     util.ToPointer(false),
+			CIFailures: util.ToPointer(true),
+		}).AddTokenAuth(tokenWithRepoScope)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var info api.WatchInfo
+		DecodeJSON(t, resp, &info)
+		assert.True(t, info.Subscribed)
+		assert.Equal(t, api.WatchEvents{PullRequests: true, Releases: true, CIFailures: true}, info.Events)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/subscription", repo)).
+			AddTokenAuth(tokenWithRepoScope)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &info)
+		assert.Equal(t, api.WatchEvents{PullRequests: true, Releases: true, CIFailures: true}, info.Events)
+	})
+
 	t.Run("Unwatch", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
@@ -94,3 +116,29 @@ func TestAPIWatch(t *testing.T) {
 		MakeRequest(t, req, http.StatusNoContent)
 	})
 }
+
+func TestAPINotificationSettings(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteUser, auth_model.AccessTokenScopeWriteRepository)
+
+	req := NewRequest(t, "GET", "/api/v1/user/notification_settings").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var settings api.NotificationSettings
+	DecodeJSON(t, resp, &settings)
+	assert.False(t, settings.ParticipatingOnly)
+
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/user/notification_settings", &api.EditNotificationSettingsOption{
+		ParticipatingOnly: util.ToPointer(true),
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &settings)
+	assert.True(t, settings.ParticipatingOnly)
+
+	// the repositories created by the user are not watched automatically
+	req = NewRequestWithJSON(t, "POST", "/api/v1/user/repos", &api.CreateRepoOption{Name: "participating-only"}).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerID: 2, Name: "participating-only"})
+	unittest.AssertNotExistsBean(t, &repo_model.Watch{UserID: 2, RepoID: repo.ID})
+}
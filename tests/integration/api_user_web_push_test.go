@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/generate"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIUserWebPush(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteUser)
+
+	req := NewRequest(t, "GET", "/api/v1/user/web_push").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	publicKey, privateKey, err := generate.NewVAPIDKeys()
+	require.NoError(t, err)
+	defer test.MockVariableValue(&setting.WebPush.Enabled, true)()
+	defer test.MockVariableValue(&setting.WebPush.VAPIDPublicKey, publicKey)()
+	defer test.MockVariableValue(&setting.WebPush.VAPIDPrivateKey, privateKey)()
+
+	t.Run("Settings", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/user/web_push").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var settings api.WebPushSettings
+		DecodeJSON(t, resp, &settings)
+		assert.Equal(t, publicKey, settings.VAPIDPublicKey)
+		assert.Empty(t, settings.Events)
+
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/user/web_push", &api.EditWebPushSettingsOption{Events: []string{"ci_failure", "review_request"}}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &settings)
+		assert.Equal(t, []string{"review_request", "ci_failure"}, settings.Events)
+
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/user/web_push", &api.EditWebPushSettingsOption{Events: []string{"push"}}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Subscriptions", func(t *testing.T) {
+		uaKey, err := ecdh.P256().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		opt := &api.CreateWebPushSubscriptionOption{
+			Endpoint: "https://push.example.com/send/abc",
+			Keys: &api.WebPushSubscriptionKeys{
+				P256dh: base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+				Auth:   "BTBZMqHH6r4Tts7J_aSIgg",
+			},
+		}
+		req := NewRequestWithJSON(t, "POST", "/api/v1/user/web_push/subscriptions", opt).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var sub api.WebPushSubscription
+		DecodeJSON(t, resp, &sub)
+		assert.Equal(t, opt.Endpoint, sub.Endpoint)
+
+		invalid := *opt
+		invalid.Keys = &api.WebPushSubscriptionKeys{P256dh: "invalid", Auth: opt.Keys.Auth}
+		req = NewRequestWithJSON(t, "POST", "/api/v1/user/web_push/subscriptions", &invalid).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequest(t, "GET", "/api/v1/user/web_push/subscriptions").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var subs []*api.WebPushSubscription
+		DecodeJSON(t, resp, &subs)
+		require.Len(t, subs, 1)
+		assert.Equal(t, sub.ID, subs[0].ID)
+
+		// the subscriptions of other users can't be deleted
+		token4 := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteUser)
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/user/web_push/subscriptions/%d", sub.ID)).AddTokenAuth(token4)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/user/web_push/subscriptions/%d", sub.ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/tests"
@@ -132,3 +133,28 @@ func TestGetAttachment(t *testing.T) {
 		})
 	}
 }
+
+func TestGetQuarantinedAttachment(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	attach, err := repo_model.GetAttachmentByUUID(t.Context(), "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	assert.NoError(t, err)
+	_, err = storage.Attachments.Save(attach.RelativePath(), strings.NewReader("hello world"), -1)
+	assert.NoError(t, err)
+	assert.NoError(t, repo_model.SetAttachmentQuarantined(t.Context(), attach.ID, true))
+
+	link := "/attachments/" + attach.UUID
+	loginUser(t, "user2").MakeRequest(t, NewRequest(t, "GET", link), http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "GET", link), http.StatusForbidden)
+	loginUser(t, "user1").MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+
+	assert.NoError(t, system_model.InsertQuarantinedFile(t.Context(), &system_model.QuarantinedFile{
+		Subject:      system_model.QuarantineSubjectAttachment,
+		AttachmentID: attach.ID,
+		Name:         attach.Name,
+		Signature:    "Eicar-Signature",
+	}))
+	resp := loginUser(t, "user1").MakeRequest(t, NewRequest(t, "GET", "/-/admin/quarantine"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "Eicar-Signature")
+	assert.Contains(t, resp.Body.String(), link)
+}
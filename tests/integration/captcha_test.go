@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/translation"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/tests"
+)
+
+// testCaptchaType is a captcha which is solved by submitting "test-captcha=solved"
+const testCaptchaType = "test"
+
+func init() {
+	context.RegisterCaptchaProvider(testCaptchaType, context.CaptchaProviderFunc(func(ctx *context.Context) (bool, error) {
+		return ctx.Req.Form.Get("test-captcha") == "solved", nil
+	}))
+}
+
+func TestLoginCaptchaAfterFailures(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Service.EnableCaptcha, true)()
+	defer test.MockVariableValue(&setting.Service.RequireCaptchaForLogin, true)()
+	defer test.MockVariableValue(&setting.Service.LoginCaptchaFailedAttempts, 2)()
+	defer test.MockVariableValue(&setting.Service.LoginCaptchaType, testCaptchaType)()
+
+	incorrectPassword := translation.NewLocale("en-US").TrString("form.username_password_incorrect")
+	incorrectCaptcha := translation.NewLocale("en-US").TrString("form.captcha_incorrect")
+	login := func(t *testing.T, username, captcha string) {
+		req := NewRequestWithValues(t, "POST", "/user/login", map[string]string{
+			"user_name":    username,
+			"password":     userPassword,
+			"test-captcha": captcha,
+		})
+		emptyTestSession(t).MakeRequest(t, req, http.StatusSeeOther)
+	}
+
+	testLoginFailed(t, "user4", "wrongPassword", incorrectPassword)
+	login(t, "user4", "")
+
+	// the successful login has reset the failed attempts
+	testLoginFailed(t, "user4", "wrongPassword", incorrectPassword)
+	testLoginFailed(t, "user4", "wrongPassword", incorrectPassword)
+	testLoginFailed(t, "user4", userPassword, incorrectCaptcha)
+	testLoginFailed(t, "user4", "wrongPassword", incorrectCaptcha)
+
+	// other users are not affected
+	login(t, "user5", "")
+
+	login(t, "user4", "solved")
+}
+
+func TestNewIssueCaptchaForNewUsers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Service.EnableCaptcha, true)()
+	defer test.MockVariableValue(&setting.Service.IssueCaptchaType, testCaptchaType)()
+
+	session := loginUser(t, "user2")
+	newIssue := func(t *testing.T, title, captcha string, expectedStatus int) {
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", map[string]string{
+			"_csrf":        GetUserCSRFToken(t, session),
+			"title":        title,
+			"test-captcha": captcha,
+		})
+		session.MakeRequest(t, req, expectedStatus)
+	}
+
+	// the fixture accounts are old, so no captcha is required
+	newIssue(t, "issue without captcha", "", http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "issue without captcha"})
+
+	defer test.MockVariableValue(&setting.Service.IssueCaptchaAccountAge, 100*365*24*time.Hour)()
+	newIssue(t, "issue with unsolved captcha", "", http.StatusBadRequest)
+	unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: 1, Title: "issue with unsolved captcha"})
+
+	newIssue(t, "issue with captcha", "solved", http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "issue with captcha"})
+
+	// administrators never have to solve it
+	session = loginUser(t, "user1")
+	newIssue(t, "issue of an administrator", "", http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "issue of an administrator"})
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	api "code.gitea.io/gitea/modules/structs"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicensePolicy(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, _ *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteOrganization)
+
+		req := NewRequest(t, "GET", "/api/v1/orgs/org3/license_policy").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/orgs/org3/license_policy", &api.EditLicensePolicyOption{DeniedLicenses: []string{"MIT OR Apache-2.0"}}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", "/api/v1/orgs/org3/license_policy", &api.EditLicensePolicyOption{DeniedLicenses: []string{"MIT", "GPL-3.0"}}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var policy api.LicensePolicy
+		DecodeJSON(t, resp, &policy)
+		assert.Equal(t, []string{"GPL-3.0", "MIT"}, policy.DeniedLicenses)
+
+		resp4, err := createFileInBranch(user2, repo3, "package-lock.json", repo3.DefaultBranch, `{"lockfileVersion": 3, "packages": {
+  "": {"name": "web"},
+  "node_modules/lodash": {"version": "4.17.21", "license": "MIT"},
+  "node_modules/dual": {"version": "1.0.0", "license": "(MIT OR Apache-2.0)"}
+}}`)
+		require.NoError(t, err)
+
+		var compliance api.LicenseCompliance
+		assert.Eventually(t, func() bool {
+			req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/licenses/compliance").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &compliance)
+			return len(compliance.Violations) > 0
+		}, 30*time.Second, 500*time.Millisecond)
+		assert.True(t, compliance.HasPolicy)
+		// "dual" can be used under the license which isn't denied
+		assert.Equal(t, []*api.LicenseViolation{{Path: "package-lock.json", Package: "lodash", Version: "4.17.21", License: "MIT"}}, compliance.Violations)
+
+		getLicenseStatus := func(t *testing.T, ref string) *api.CommitStatus {
+			var status *api.CommitStatus
+			assert.Eventually(t, func() bool {
+				req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/commits/"+ref+"/statuses").AddTokenAuth(token)
+				resp := MakeRequest(t, req, http.StatusOK)
+				var statuses []*api.CommitStatus
+				DecodeJSON(t, resp, &statuses)
+				for _, s := range statuses {
+					if s.Context == "license-compliance" {
+						status = s
+						return true
+					}
+				}
+				return false
+			}, 30*time.Second, 500*time.Millisecond)
+			return status
+		}
+
+		t.Run("DefaultBranch", func(t *testing.T) {
+			status := getLicenseStatus(t, resp4.Commit.SHA)
+			require.NotNil(t, status)
+			assert.Equal(t, commitstatus.CommitStatusFailure, status.State)
+			assert.Equal(t, "1 denied licenses found", status.Description)
+		})
+
+		t.Run("PullRequest", func(t *testing.T) {
+			// the pull request doesn't introduce a new denied license, the violation of the base branch doesn't fail it
+			_, err := files_service.ChangeRepoFiles(t.Context(), repo3, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      "README-license.md",
+						ContentReader: strings.NewReader("license"),
+					},
+				},
+				OldBranch: repo3.DefaultBranch,
+				NewBranch: "license-pr",
+			})
+			require.NoError(t, err)
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/org3/repo3/pulls", &api.CreatePullRequestOption{
+				Head:  "license-pr",
+				Base:  repo3.DefaultBranch,
+				Title: "license check",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			status := getLicenseStatus(t, "license-pr")
+			require.NotNil(t, status)
+			assert.Equal(t, commitstatus.CommitStatusSuccess, status.State)
+		})
+
+		t.Run("Web", func(t *testing.T) {
+			session := loginUser(t, "user2")
+			req := NewRequest(t, "GET", "/org3/repo3/dependencies")
+			resp := session.MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, 1, NewHTMLParser(t, resp.Body).Find(`.ui.red.label`).Length())
+
+			req = NewRequest(t, "GET", "/org/org3/settings/license_policy")
+			resp = session.MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "GPL-3.0\nMIT", NewHTMLParser(t, resp.Body).Find(`textarea[name="denied_licenses"]`).Text())
+
+			req = NewRequestWithValues(t, "POST", "/org/org3/settings/license_policy", map[string]string{
+				"_csrf":           GetUserCSRFToken(t, session),
+				"denied_licenses": "",
+			})
+			session.MakeRequest(t, req, http.StatusSeeOther)
+
+			req = NewRequest(t, "GET", "/api/v1/orgs/org3/license_policy").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+		userToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+		setMaintenance := func(t *testing.T, opts api.EditMaintenanceModeOption) *api.MaintenanceMode {
+			req := NewRequestWithJSON(t, "PATCH", "/api/v1/admin/maintenance", opts).AddTokenAuth(adminToken)
+			mode := &api.MaintenanceMode{}
+			DecodeJSON(t, MakeRequest(t, req, http.StatusOK), mode)
+			return mode
+		}
+		defer setMaintenance(t, api.EditMaintenanceModeOption{
+			ReadOnly:  util.ToPointer(false),
+			Message:   util.ToPointer(""),
+			StartTime: &time.Time{},
+			EndTime:   &time.Time{},
+		})
+
+		u.Path = "/user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		dstPath := t.TempDir()
+		t.Run("Clone", doGitClone(dstPath, u))
+
+		mode := setMaintenance(t, api.EditMaintenanceModeOption{ReadOnly: util.ToPointer(true), Message: util.ToPointer("Backup in progress")})
+		assert.True(t, mode.Active)
+
+		t.Run("Read", func(t *testing.T) {
+			resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1"), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "Backup in progress")
+			MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(userToken), http.StatusOK)
+			t.Run("Clone", doGitClone(t.TempDir(), u))
+		})
+
+		t.Run("Write", func(t *testing.T) {
+			session := loginUser(t, "user2")
+			resp := session.MakeRequest(t, NewRequest(t, "POST", "/user2/repo1/action/star"), http.StatusBadRequest)
+			assert.Contains(t, resp.Body.String(), "Backup in progress")
+
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{Title: "issue"}).AddTokenAuth(userToken)
+			MakeRequest(t, req, http.StatusServiceUnavailable)
+
+			req = NewRequestWithBody(t, "PUT", "/api/packages/user2/generic/maintenance/1.0/file.bin", strings.NewReader("content")).AddBasicAuth("user2")
+			resp = MakeRequest(t, req, http.StatusServiceUnavailable)
+			assert.Contains(t, resp.Body.String(), "Backup in progress")
+
+			_, err := generateCommitWithNewData(t.Context(), testFileSizeSmall, dstPath, "user2@example.com", "User Two", "maintenance-")
+			require.NoError(t, err)
+			_, _, err = gitcmd.NewCommand("push", "origin", "master").RunStdString(t.Context(), &gitcmd.RunOpts{Dir: dstPath})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Backup in progress")
+		})
+
+		// a non-admin can't end the maintenance mode
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/admin/maintenance", api.EditMaintenanceModeOption{ReadOnly: util.ToPointer(false)}).AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		// the admin can end the maintenance mode in the config settings
+		adminSession := loginUser(t, "user1")
+		adminSession.MakeRequest(t, NewRequest(t, "GET", "/-/admin/config/settings"), http.StatusOK)
+		adminSession.MakeRequest(t, NewRequestWithValues(t, "POST", "/-/admin/config", map[string]string{
+			"_csrf": GetUserCSRFToken(t, adminSession),
+			"key":   "maintenance.read_only",
+			"value": "false",
+		}), http.StatusOK)
+		mode = setMaintenance(t, api.EditMaintenanceModeOption{})
+		assert.False(t, mode.Active)
+		t.Run("Push", doGitPushTestRepository(dstPath, "origin", "master"))
+
+		t.Run("ScheduledWindow", func(t *testing.T) {
+			start, end := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+			mode := setMaintenance(t, api.EditMaintenanceModeOption{StartTime: &start, EndTime: &end})
+			assert.True(t, mode.Active)
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{Title: "issue"}).AddTokenAuth(userToken)
+			MakeRequest(t, req, http.StatusServiceUnavailable)
+
+			end = time.Now().Add(-time.Minute)
+			mode = setMaintenance(t, api.EditMaintenanceModeOption{EndTime: &end})
+			assert.False(t, mode.Active)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithJSON(t, "PATCH", "/api/v1/admin/maintenance", api.EditMaintenanceModeOption{StartTime: &start, EndTime: &start}).AddTokenAuth(adminToken)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		})
+	})
+}
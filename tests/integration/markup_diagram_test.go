@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagramRenderer(t *testing.T) {
+	onGiteaRun(t, testDiagramRenderer)
+}
+
+func testDiagramRenderer(t *testing.T, _ *url.URL) {
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	_, err := createFileInBranch(user2, repo1, "docs/flow.drawio", repo1.DefaultBranch, `<mxGraphModel><root>
+<mxCell id="0"/><mxCell id="1" parent="0"/>
+<mxCell id="a" value="Start" vertex="1" parent="1"><mxGeometry x="0" y="0" width="100" height="40" as="geometry"/></mxCell>
+</root></mxGraphModel>`)
+	assert.NoError(t, err)
+
+	t.Run("FileView", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/user2/repo1/src/branch/master/docs/flow.drawio")
+		resp := MakeRequest(t, req, http.StatusOK)
+		src, _ := NewHTMLParser(t, resp.Body).Find(".file-view .diagram-view img").Attr("src")
+		assert.Contains(t, src, "data:image/svg+xml;base64,")
+	})
+
+	t.Run("MediaSVG", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/user2/repo1/media/branch/master/docs/flow.drawio?format=svg")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "image/svg+xml", resp.Header().Get("Content-Type"))
+		assert.Contains(t, resp.Header().Get("Content-Security-Policy"), "sandbox")
+		assert.Contains(t, resp.Body.String(), ">Start</tspan>")
+
+		// the source of files without a diagram renderer can't be rendered as images
+		req = NewRequest(t, "GET", "/user2/repo1/media/branch/master/README.md?format=svg")
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("PlantUMLDisabled", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/-/markup/plantuml/SyfFKj2rKt3CoKnELR1Io4ZDoSa70000")
+		MakeRequest(t, req, http.StatusSeeOther)
+
+		session := loginUser(t, "user2")
+		req = NewRequest(t, "GET", "/-/markup/plantuml/SyfFKj2rKt3CoKnELR1Io4ZDoSa70000")
+		session.MakeRequest(t, req, http.StatusNotFound)
+	})
+}
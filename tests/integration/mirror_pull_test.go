@@ -111,3 +111,60 @@ func TestMirrorPull(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, initCount, count)
 }
+
+func TestMirrorPullRefFilter(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	ctx := t.Context()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	opts := migration.MigrateOptions{
+		RepoName:        "test_mirror_filtered",
+		Mirror:          true,
+		CloneAddr:       repo_model.RepoPath(user.Name, repo.Name),
+		MirrorRefFilter: "master, feature/*",
+		MirrorSkipTags:  true,
+	}
+	mirrorRepo, err := repo_service.CreateRepositoryDirectly(ctx, user, user, repo_service.CreateRepoOptions{
+		Name:     opts.RepoName,
+		IsMirror: opts.Mirror,
+		Status:   repo_model.RepositoryBeingMigrated,
+	}, false)
+	require.NoError(t, err)
+	mirrorRepo, err = repo_service.MigrateRepositoryGitData(ctx, user, mirrorRepo, opts, nil)
+	require.NoError(t, err)
+
+	assertMirrorRefs := func(t *testing.T, expectedBranches []string, expectTags bool) {
+		gitRepo, err := gitrepo.OpenRepository(ctx, mirrorRepo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		branches, _, err := gitRepo.GetBranchNames(0, 0)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, expectedBranches, branches)
+		assert.Equal(t, expectTags, gitRepo.IsTagExist("v1.1"))
+	}
+	assertMirrorRefs(t, []string{"master", "feature/1"}, false)
+
+	m, err := repo_model.GetMirrorByRepoID(ctx, mirrorRepo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "master, feature/*", m.RefFilter)
+	assert.True(t, m.SkipTags)
+
+	// the refs which were skipped are fetched once the filters are removed
+	m.RefFilter, m.SkipTags = "", false
+	require.NoError(t, repo_model.UpdateMirror(ctx, m))
+	require.True(t, mirror_service.SyncPullMirror(ctx, mirrorRepo.ID))
+	assertMirrorRefs(t, []string{"DefaultBranch", "branch2", "develop", "feature/1", "home-md-img-check", "master", "pr-to-update", "sub-home-md-img-check"}, true)
+
+	// and the refs not matching the filters are pruned
+	m.RefFilter, m.SkipTags = "master", true
+	require.NoError(t, repo_model.UpdateMirror(ctx, m))
+	require.True(t, mirror_service.SyncPullMirror(ctx, mirrorRepo.ID))
+	assertMirrorRefs(t, []string{"master"}, false)
+
+	// the mirror isn't synced once it's larger than the max size
+	m.MaxSize = 1
+	require.NoError(t, repo_model.UpdateMirror(ctx, m))
+	assert.False(t, mirror_service.SyncPullMirror(ctx, mirrorRepo.ID))
+}
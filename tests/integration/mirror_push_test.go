@@ -11,12 +11,14 @@ import (
 	"testing"
 	"time"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -70,6 +72,10 @@ func testMirrorPush(t *testing.T, u *url.URL) {
 
 	assert.Equal(t, srcCommit.ID, mirrorCommit.ID)
 
+	pushMirror := unittest.AssertExistsAndLoadBean(t, &repo_model.PushMirror{ID: mirrors[0].ID})
+	assert.Equal(t, repo_model.PushMirrorStatusSuccess, pushMirror.Status())
+	assert.Equal(t, pushMirror.LastUpdateUnix, pushMirror.LastSuccessUnix)
+
 	// Cleanup
 	assert.True(t, doRemovePushMirror(t, session, user.Name, srcRepo.Name, mirrors[0].ID))
 	mirrors, _, err = repo_model.GetPushMirrorsByRepoID(t.Context(), srcRepo.ID, db.ListOptions{})
@@ -77,6 +83,68 @@ func testMirrorPush(t *testing.T, u *url.URL) {
 	assert.Empty(t, mirrors)
 }
 
+func TestAPIPushMirrorBranchFilter(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		setting.Migrations.AllowLocalNetworks = true
+		assert.NoError(t, migrations.Init())
+
+		_ = db.TruncateBeans(t.Context(), &repo_model.PushMirror{})
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		srcRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		mirrorRepo, err := repo_service.CreateRepositoryDirectly(t.Context(), user, user, repo_service.CreateRepoOptions{
+			Name: "test-push-mirror-filter",
+		}, true)
+		assert.NoError(t, err)
+
+		session := loginUser(t, user.Name)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		apiURL := fmt.Sprintf("/api/v1/repos/%s/%s/push_mirrors", user.Name, srcRepo.Name)
+
+		req := NewRequestWithJSON(t, "POST", apiURL, &api.CreatePushMirrorOption{
+			RemoteAddress:  fmt.Sprintf("%s%s/%s", u.String(), url.PathEscape(user.Name), url.PathEscape(mirrorRepo.Name)),
+			RemoteUsername: user.LowerName,
+			RemotePassword: userPassword,
+			Interval:       "0",
+			BranchFilter:   "release/*,master",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var pushMirror api.PushMirror
+		DecodeJSON(t, resp, &pushMirror)
+		assert.Equal(t, "release/*, master", pushMirror.BranchFilter)
+		assert.True(t, pushMirror.LFS)
+		assert.Equal(t, repo_model.PushMirrorStatusPending, pushMirror.Status.State)
+
+		req = NewRequestWithJSON(t, "POST", apiURL, &api.CreatePushMirrorOption{
+			RemoteAddress: pushMirror.RemoteAddress,
+			Interval:      "0",
+			BranchFilter:  "feature/**",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestf(t, "POST", "%s/%s/sync", apiURL, pushMirror.RemoteName).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusAccepted)
+
+		var status api.PushMirrorStatus
+		assert.Eventually(t, func() bool {
+			req := NewRequestf(t, "GET", "%s/%s/status", apiURL, pushMirror.RemoteName).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &status)
+			return status.State != repo_model.PushMirrorStatusPending
+		}, 10*time.Second, 100*time.Millisecond)
+		assert.Equal(t, repo_model.PushMirrorStatusSuccess, status.State)
+		assert.Empty(t, status.LastError)
+		assert.NotNil(t, status.LastSuccess)
+
+		req = NewRequestf(t, "GET", "%s/%s/status", apiURL, "unknown").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		assert.True(t, gitrepo.IsBranchExist(t.Context(), mirrorRepo, "master"))
+		assert.False(t, gitrepo.IsBranchExist(t.Context(), mirrorRepo, "develop"))
+		assert.False(t, gitrepo.IsBranchExist(t.Context(), mirrorRepo, "feature/1"))
+	})
+}
+
 func testCreatePushMirror(t *testing.T, session *TestSession, owner, repo, address, username, password, interval string) {
 	req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/%s/settings", url.PathEscape(owner), url.PathEscape(repo)), map[string]string{
 		"_csrf":                GetUserCSRFToken(t, session),
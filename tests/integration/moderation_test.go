@@ -0,0 +1,186 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModerationReport(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Moderation.Enabled, true)()
+	defer test.MockVariableValue(&setting.Moderation.Moderators, []string{"user4"})()
+
+	session := loginUser(t, "user2")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/-/moderation/report?type=comment&id=2")
+	session.MakeRequest(t, NewRequest(t, "GET", "/-/moderation/report?type=comment&id=2"), http.StatusOK)
+
+	req := NewRequestWithValues(t, "POST", "/-/moderation/report", map[string]string{
+		"_csrf":    GetUserCSRFToken(t, session),
+		"type":     "comment",
+		"id":       "2",
+		"category": "spam",
+		"remarks":  "advertising",
+	})
+	resp = session.MakeRequest(t, req, http.StatusSeeOther)
+	assert.Equal(t, "/user2/repo1/issues/1#issuecomment-2", test.RedirectURL(resp))
+	report := unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ReporterID: 2, ContentType: moderation_model.ReportContentComment, ContentID: 2})
+	assert.Equal(t, moderation_model.ReportStatusOpen, report.Status)
+	assert.EqualValues(t, 3, report.OwnerID)
+	assert.Equal(t, "good work!", report.ContentSnapshot)
+
+	// the queue is only visible to administrators and moderators
+	otherSession := loginUser(t, "user5")
+	otherSession.MakeRequest(t, NewRequest(t, "GET", "/-/moderation/reports"), http.StatusForbidden)
+
+	moderatorSession := loginUser(t, "user4")
+	resp = moderatorSession.MakeRequest(t, NewRequest(t, "GET", "/-/moderation/reports"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "good work!")
+
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/moderation/reports/%d", report.ID), map[string]string{
+		"_csrf":  GetUserCSRFToken(t, moderatorSession),
+		"action": "hide",
+	})
+	resp = moderatorSession.MakeRequest(t, req, http.StatusSeeOther)
+	assert.Equal(t, "/-/moderation/reports", test.RedirectURL(resp))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2}).IsHidden)
+	report = unittest.AssertExistsAndLoadBean(t, &moderation_model.AbuseReport{ID: report.ID})
+	assert.Equal(t, moderation_model.ReportStatusResolved, report.Status)
+	assert.Equal(t, moderation_model.ModerationActionHide, report.Action)
+	assert.EqualValues(t, 4, report.ResolverID)
+
+	// the hidden comment is left out of the issue and the API until it's unhidden
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "good work!")
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2"), http.StatusNotFound)
+
+	// the administrators of the repository still see the hidden comment
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "good work!")
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2").AddTokenAuth(getUserToken(t, "user2", auth_model.AccessTokenScopeReadIssue))
+	MakeRequest(t, req, http.StatusOK)
+
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/moderation/reports/%d/unhide", report.ID), map[string]string{
+		"_csrf": GetUserCSRFToken(t, moderatorSession),
+	})
+	moderatorSession.MakeRequest(t, req, http.StatusSeeOther)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2}).IsHidden)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2"), http.StatusOK)
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Moderation.Enabled, false)()
+		session.MakeRequest(t, NewRequest(t, "GET", "/-/moderation/report?type=issue&id=1"), http.StatusNotFound)
+	})
+}
+
+func TestAPIModeration(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Moderation.Enabled, true)()
+
+	session := loginUser(t, "user2")
+	for _, content := range []string{"type=issue&id=1", "type=user&id=5"} {
+		req := NewRequestWithValues(t, "POST", "/-/moderation/report?"+content, map[string]string{
+			"_csrf":    GetUserCSRFToken(t, session),
+			"category": "harassment",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+	}
+
+	token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	req := NewRequest(t, "GET", "/api/v1/moderation/reports?status=open&type=user").AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var reports []*api.AbuseReport
+	DecodeJSON(t, resp, &reports)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, "user5", reports[0].Owner.UserName)
+		assert.Equal(t, "user2", reports[0].Reporter.UserName)
+		assert.Equal(t, "harassment", reports[0].Category)
+		assert.Equal(t, setting.AppURL+"user5", reports[0].ContentURL)
+	}
+	userReport := reports[0]
+
+	createTemplate := func(expectedStatus int) *httptest.ResponseRecorder {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/moderation/templates", &api.CreateResolutionTemplateOption{
+			Name:    "Harassment warning",
+			Action:  "warn",
+			Message: "Please follow the code of conduct.",
+		}).AddTokenAuth(token)
+		return MakeRequest(t, req, expectedStatus)
+	}
+	resp = createTemplate(http.StatusCreated)
+	var resolutionTemplate api.ResolutionTemplate
+	DecodeJSON(t, resp, &resolutionTemplate)
+	createTemplate(http.StatusConflict)
+
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/moderation/reports/%d/resolve", userReport.ID), &api.ResolveAbuseReportOption{
+		TemplateID: resolutionTemplate.ID,
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var resolved api.AbuseReport
+	DecodeJSON(t, resp, &resolved)
+	assert.Equal(t, "resolved", resolved.Status)
+	assert.Equal(t, "warn", resolved.Action)
+	assert.Equal(t, "Please follow the code of conduct.", resolved.ResolutionMessage)
+	assert.Equal(t, "user1", resolved.Resolver.UserName)
+	assert.NotNil(t, resolved.Resolved)
+
+	// a resolved report can't be resolved again
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/moderation/reports/%d/resolve", userReport.ID), &api.ResolveAbuseReportOption{
+		Action: "none",
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", "/api/v1/moderation/reports?status=open").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &reports)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, "issue", reports[0].ContentType)
+	}
+
+	// a warning needs a message
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/moderation/reports/%d/resolve", reports[0].ID), &api.ResolveAbuseReportOption{
+		Action: "warn",
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// a hidden issue is not found except by the moderators and the administrators of the repository
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/moderation/reports/%d/resolve", reports[0].ID), &api.ResolveAbuseReportOption{
+		Action: "hide",
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusOK)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1").AddTokenAuth(getUserToken(t, "user1", auth_model.AccessTokenScopeReadIssue)), http.StatusOK)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+
+	req = NewRequest(t, "POST", fmt.Sprintf("/api/v1/moderation/reports/%d/unhide", reports[0].ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusOK)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1"), http.StatusOK)
+
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/moderation/templates/%d", resolutionTemplate.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/moderation/templates/%d", resolutionTemplate.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	t.Run("NotModerator", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin)
+		req := NewRequest(t, "GET", "/api/v1/moderation/reports").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
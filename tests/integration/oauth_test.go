@@ -1012,8 +1012,11 @@ func TestSignInOauthCallbackSyncSSHKeys(t *testing.T) {
 	session := emptyTestSession(t)
 	for _, c := range cases {
 		t.Run(c.testName, func(t *testing.T) {
-			defer test.MockVariableValue(&setting.OAuth2Client.Username, "")()
-			defer test.MockVariableValue(&setting.OAuth2Client.EnableAutoRegistration, true)()
+			oauth2Client := *setting.GetOAuth2Client()
+			oauth2Client.Username = ""
+			oauth2Client.EnableAutoRegistration = true
+			defer setting.SetOAuth2Client(setting.GetOAuth2Client())
+			setting.SetOAuth2Client(&oauth2Client)
 			defer test.MockVariableValue(&gothic.CompleteUserAuth, func(res http.ResponseWriter, req *http.Request) (goth.User, error) {
 				return goth.User{
 					Provider: authSource.Cfg.(*oauth2.Source).Provider,
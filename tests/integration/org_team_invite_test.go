@@ -21,7 +21,7 @@ import (
 )
 
 func TestOrgTeamEmailInvite(t *testing.T) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		t.Skip()
 		return
 	}
@@ -73,7 +73,7 @@ func TestOrgTeamEmailInvite(t *testing.T) {
 
 // Check that users are redirected to accept the invitation correctly after login
 func TestOrgTeamEmailInviteRedirectsExistingUser(t *testing.T) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		t.Skip()
 		return
 	}
@@ -149,7 +149,7 @@ func TestOrgTeamEmailInviteRedirectsExistingUser(t *testing.T) {
 
 // Check that newly signed up users are redirected to accept the invitation correctly
 func TestOrgTeamEmailInviteRedirectsNewUser(t *testing.T) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		t.Skip()
 		return
 	}
@@ -226,7 +226,7 @@ func TestOrgTeamEmailInviteRedirectsNewUser(t *testing.T) {
 
 // Check that users are redirected correctly after confirming their email
 func TestOrgTeamEmailInviteRedirectsNewUserWithActivation(t *testing.T) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		t.Skip()
 		return
 	}
@@ -299,7 +299,7 @@ func TestOrgTeamEmailInviteRedirectsNewUserWithActivation(t *testing.T) {
 // For example: an invite may have been created before the user account was created, but they may be
 // accepting the invite after having created an account separately
 func TestOrgTeamEmailInviteRedirectsExistingUserWithLogin(t *testing.T) {
-	if setting.MailService == nil {
+	if setting.GetMailService() == nil {
 		t.Skip()
 		return
 	}
@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	org_model "code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullSignOff(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, _ *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteOrganization)
+
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1", &api.EditRepoOption{
+			HasPullRequests:    util.ToPointer(true),
+			RequireSignOff:     util.ToPointer(true),
+			SignOffExemptUsers: &[]string{"user4"},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var repo api.Repository
+		DecodeJSON(t, resp, &repo)
+		assert.True(t, repo.RequireSignOff)
+		assert.Equal(t, []string{"user4"}, repo.SignOffExemptUsers)
+
+		createPull := func(t *testing.T, author *files_service.IdentityOptions, branch string, signOff bool) int64 {
+			_, err := files_service.ChangeRepoFiles(t.Context(), repo1, user2, &files_service.ChangeRepoFilesOptions{
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      branch + ".txt",
+						ContentReader: strings.NewReader(branch),
+					},
+				},
+				OldBranch: repo1.DefaultBranch,
+				NewBranch: branch,
+				Message:   "add " + branch,
+				Author:    author,
+				Signoff:   signOff,
+			})
+			require.NoError(t, err)
+
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/pulls", &api.CreatePullRequestOption{
+				Head:  branch,
+				Base:  repo1.DefaultBranch,
+				Title: branch,
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			var pr api.PullRequest
+			DecodeJSON(t, resp, &pr)
+			return pr.Index
+		}
+
+		getSignOffStatus := func(t *testing.T, branch string) *api.CommitStatus {
+			var status *api.CommitStatus
+			assert.Eventually(t, func() bool {
+				req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/commits/"+branch+"/statuses").AddTokenAuth(token)
+				resp := MakeRequest(t, req, http.StatusOK)
+				var statuses []*api.CommitStatus
+				DecodeJSON(t, resp, &statuses)
+				for _, s := range statuses {
+					if s.Context == "dco" {
+						status = s
+						return true
+					}
+				}
+				return false
+			}, 30*time.Second, 500*time.Millisecond)
+			require.NotNil(t, status)
+			return status
+		}
+
+		t.Run("NotSignedOff", func(t *testing.T) {
+			index := createPull(t, nil, "dco-unsigned", false)
+			status := getSignOffStatus(t, "dco-unsigned")
+			assert.Equal(t, commitstatus.CommitStatusFailure, status.State)
+			assert.Contains(t, status.Description, "1 of 1 commits are not signed off")
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/user2/repo1/pulls/%d/signoff", index)).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var commits []*api.PullSignOffCommit
+			DecodeJSON(t, resp, &commits)
+			require.Len(t, commits, 1)
+			assert.False(t, commits[0].SignedOff)
+			assert.False(t, commits[0].Passed)
+			assert.Equal(t, user2.GetPlaceholderEmail(), commits[0].Author.Email)
+		})
+
+		t.Run("SignedOff", func(t *testing.T) {
+			createPull(t, nil, "dco-signed", true)
+			assert.Equal(t, commitstatus.CommitStatusSuccess, getSignOffStatus(t, "dco-signed").State)
+		})
+
+		t.Run("ExemptUser", func(t *testing.T) {
+			// the commit is authored by user4, who is exempt
+			createPull(t, &files_service.IdentityOptions{GitUserName: user4.Name, GitUserEmail: user4.Email}, "dco-exempt", false)
+			assert.Equal(t, commitstatus.CommitStatusSuccess, getSignOffStatus(t, "dco-exempt").State)
+		})
+
+		t.Run("OrgPolicy", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/orgs/org3/signoff_policy").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequestWithJSON(t, "PUT", "/api/v1/orgs/org3/signoff_policy", &api.EditSignOffPolicyOption{ExemptUsers: []string{"user5", " ", "user4"}}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var policy api.SignOffPolicy
+			DecodeJSON(t, resp, &policy)
+			assert.Equal(t, []string{"user4", "user5"}, policy.ExemptUsers)
+
+			session := loginUser(t, "user2")
+			req = NewRequest(t, "GET", "/org/org3/settings/signoff_policy")
+			resp = session.MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "user4,user5", NewHTMLParser(t, resp.Body).Find(`input[name="exempt_users"]`).AttrOr("value", ""))
+
+			req = NewRequestWithValues(t, "POST", "/org/org3/settings/signoff_policy", map[string]string{
+				"_csrf": GetUserCSRFToken(t, session),
+			})
+			session.MakeRequest(t, req, http.StatusSeeOther)
+			unittest.AssertNotExistsBean(t, &org_model.SignOffPolicy{OrgID: 3})
+		})
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoDependencies(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, _ *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+		_, err := createFileInBranch(user2, repo3, "web/package.json", repo3.DefaultBranch, `{"dependencies": {"lodash": "^4.17.0"}, "devDependencies": {"eslint": "^9.0.0"}}`)
+		require.NoError(t, err)
+		_, err = createFileInBranch(user2, repo3, "web/package-lock.json", repo3.DefaultBranch, `{"lockfileVersion": 3, "packages": {
+  "": {"name": "web"},
+  "node_modules/lodash": {"version": "4.17.21"},
+  "node_modules/eslint": {"version": "9.1.0"},
+  "node_modules/ajv": {"version": "6.12.6"}
+}}`)
+		require.NoError(t, err)
+		_, err = createFileInBranch(user2, repo3, "requirements.txt", repo3.DefaultBranch, "Django==4.2.1\n")
+		require.NoError(t, err)
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository, auth_model.AccessTokenScopeReadOrganization)
+
+		var deps []*api.RepoDependency
+		assert.Eventually(t, func() bool {
+			req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/dependencies").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &deps)
+			return len(deps) == 4
+		}, 30*time.Second, 500*time.Millisecond)
+		require.Len(t, deps, 4)
+		// the direct dependencies are listed first
+		assert.Equal(t, &api.RepoDependency{
+			Ecosystem:   "npm",
+			Name:        "eslint",
+			Version:     "9.1.0",
+			Requirement: "^9.0.0",
+			Scope:       "development",
+			Direct:      true,
+			Manifest:    "web/package.json",
+			PackageURL:  "pkg:npm/eslint@9.1.0",
+		}, deps[0])
+		assert.Equal(t, "lodash", deps[1].Name)
+		assert.Equal(t, "pkg:pypi/django@4.2.1", deps[2].PackageURL)
+		assert.Equal(t, "ajv", deps[3].Name)
+		assert.False(t, deps[3].Direct)
+
+		t.Run("Filter", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3/dependencies?ecosystem=npm&direct=true&q=lod").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &deps)
+			require.Len(t, deps, 1)
+			assert.Equal(t, "lodash", deps[0].Name)
+			assert.Equal(t, "1", resp.Header().Get("X-Total-Count"))
+		})
+
+		t.Run("Dependents", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/orgs/org3/dependents?ecosystem=pypi&name=DJANGO").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			var dependents []*api.Dependent
+			DecodeJSON(t, resp, &dependents)
+			require.Len(t, dependents, 1)
+			assert.Equal(t, "org3/repo3", dependents[0].Repository.FullName)
+			assert.Equal(t, "4.2.1", dependents[0].Dependency.Version)
+
+			// the private repository isn't visible to anonymous users
+			req = NewRequest(t, "GET", "/api/v1/orgs/org3/dependents?ecosystem=pypi&name=django")
+			resp = MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &dependents)
+			assert.Empty(t, dependents)
+
+			req = NewRequest(t, "GET", "/api/v1/orgs/org3/dependents?name=django").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		})
+
+		t.Run("Web", func(t *testing.T) {
+			session := loginUser(t, "user2")
+			req := NewRequest(t, "GET", "/org3/repo3/dependencies?direct=true")
+			resp := session.MakeRequest(t, req, http.StatusOK)
+			htmlDoc := NewHTMLParser(t, resp.Body)
+			assert.Equal(t, 1, htmlDoc.Find(`a[href="/org3/repo3/dependencies/dependents?ecosystem=npm&name=lodash"]`).Length())
+			assert.Equal(t, 2, htmlDoc.Find(`a[href="/org3/repo3/src/branch/master/web/package.json"]`).Length())
+			assert.NotContains(t, resp.Body.String(), "ajv")
+
+			req = NewRequest(t, "GET", "/org3/repo3/dependencies/dependents?ecosystem=npm&name=lodash")
+			resp = session.MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, 1, NewHTMLParser(t, resp.Body).Find(`.ui.container .flex-list a[href="/org3/repo3"]`).Length())
+
+			// the dependents are looked up among the repositories of organizations
+			req = NewRequest(t, "GET", "/user2/repo1/dependencies/dependents?ecosystem=npm&name=lodash")
+			session.MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
@@ -91,3 +91,37 @@ func TestRepoGenerateToOrg(t *testing.T) {
 	session := loginUser(t, "user2")
 	testRepoGenerate(t, session, "44", "user27", "template1", "user2", "generated2")
 }
+
+func TestRepoGenerateWithVariables(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	ownerSession := loginUser(t, "user27")
+	testCreateFile(t, ownerSession, "user27", "template1", "master", "master", ".gitea/template.yaml", `files:
+  - project.txt
+variables:
+  - name: PROJECT
+    description: The name of the project
+    required: true
+`)
+	testCreateFile(t, ownerSession, "user27", "template1", "master", "master", "project.txt", "${PROJECT}")
+
+	session := loginUser(t, "user1")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/repo/create?template_id=44"), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.Equal(t, 1, htmlDoc.doc.Find(`#template_variables input[name="template_variable_PROJECT"][required]`).Length())
+
+	values := map[string]string{
+		"_csrf":         htmlDoc.GetCSRF(),
+		"uid":           "1",
+		"repo_name":     "generated-with-variables",
+		"repo_template": "44",
+		"git_content":   "true",
+	}
+	resp = session.MakeRequest(t, NewRequestWithValues(t, "POST", "/repo/create", values), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), `The template requires a value for the variable &#34;PROJECT&#34;.`)
+
+	values["template_variable_PROJECT"] = "Gadget"
+	session.MakeRequest(t, NewRequestWithValues(t, "POST", "/repo/create", values), http.StatusSeeOther)
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user1/generated-with-variables/raw/branch/master/project.txt"), http.StatusOK)
+	assert.Equal(t, "Gadget", resp.Body.String())
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoSymbols(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo_model.UpdateRepoSymbols(t.Context(), repo1, "65f1bf27bc3bf70f64657658635e66094edbcb4d", []*repo_model.RepoSymbol{
+		{Name: "Indexer", Kind: "struct", Language: "Go", Path: "indexer.go", Line: 10, EndLine: 14},
+		{Name: "Search", Kind: "method", Language: "Go", Path: "indexer.go", Line: 16, EndLine: 30, Scope: "Indexer", ScopeKind: "struct"},
+		{Name: "Search", Kind: "function", Language: "Go", Path: "search/search.go", Line: 3},
+	}))
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+
+	t.Run("Disabled", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/symbols?name=Search").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	defer test.MockVariableValue(&setting.Indexer.SymbolIndexerEnabled, true)()
+
+	t.Run("Definitions", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/symbols?name=Search").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var symbols []*api.RepoSymbol
+		DecodeJSON(t, resp, &symbols)
+		require.Len(t, symbols, 2)
+		assert.Equal(t, &api.RepoSymbol{
+			Name:      "Search",
+			Kind:      "method",
+			Language:  "Go",
+			Path:      "indexer.go",
+			Line:      16,
+			EndLine:   30,
+			Scope:     "Indexer",
+			ScopeKind: "struct",
+			CommitID:  "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+			HTMLURL:   setting.AppURL + "user2/repo1/src/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d/indexer.go#L16",
+		}, symbols[0])
+		assert.Equal(t, "search/search.go", symbols[1].Path)
+	})
+
+	t.Run("Outline", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/symbols?path=indexer.go").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var symbols []*api.RepoSymbol
+		DecodeJSON(t, resp, &symbols)
+		require.Len(t, symbols, 2)
+		assert.Equal(t, "Indexer", symbols[0].Name)
+		assert.Equal(t, "Search", symbols[1].Name)
+		assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("JumpToDefinition", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/user2/repo1/symbols?name=Indexer")
+		resp := MakeRequest(t, req, http.StatusOK)
+		var symbols []*api.RepoSymbol
+		DecodeJSON(t, resp, &symbols)
+		require.Len(t, symbols, 1)
+		assert.Equal(t, 10, symbols[0].Line)
+
+		req = NewRequest(t, "GET", "/user2/repo1/src/branch/master/README.md?display=source")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `data-symbols-link="/user2/repo1/symbols"`)
+	})
+}
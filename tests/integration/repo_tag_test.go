@@ -4,9 +4,14 @@
 package integration
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	auth_model "code.gitea.io/gitea/models/auth"
@@ -16,12 +21,15 @@ import (
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/services/release"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestCreateNewTagProtected(t *testing.T) {
@@ -120,6 +128,98 @@ func TestCreateNewTagProtected(t *testing.T) {
 	}
 }
 
+func TestTagProtectionRequirements(t *testing.T) {
+	keyDir := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPubKey, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	keyPath := filepath.Join(keyDir, "id_ed25519")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(block), 0o600))
+	// the tags are signed by a trusted key, so they are verified whoever the tagger is
+	defer test.MockVariableValue(&setting.Repository.Signing.TrustedSSHKeys, []string{string(ssh.MarshalAuthorizedKey(sshPubKey))})()
+
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+		require.NoError(t, git_model.InsertProtectedTag(t.Context(), &git_model.ProtectedTag{
+			RepoID:           repo.ID,
+			NamePattern:      "v*",
+			AllowlistUserIDs: []int64{owner.ID},
+			RequireSigned:    true,
+			EnforceSemver:    true,
+		}))
+
+		dstPath := t.TempDir()
+		u.Path = NewAPITestContext(t, owner.Name, repo.Name).GitPath()
+		u.User = url.UserPassword(owner.Name, userPassword)
+		doGitClone(dstPath, u)(t)
+
+		gitRun := func(t *testing.T, cmd *gitcmd.Command) error {
+			_, _, err := cmd.RunStdString(t.Context(), &gitcmd.RunOpts{Dir: dstPath})
+			return err
+		}
+		signedTag := func(message string) *gitcmd.Command {
+			return gitcmd.NewCommand().AddConfig("gpg.format", "ssh").AddConfig("user.signingkey", keyPath).
+				AddArguments("tag", "-s", "-m").AddDynamicArguments(message)
+		}
+
+		t.Run("Lightweight", func(t *testing.T) {
+			require.NoError(t, gitRun(t, gitcmd.NewCommand("tag", "v1.0.0")))
+			err := gitRun(t, gitcmd.NewCommand("push", "origin", "v1.0.0"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Tag v1.0.0 must be an annotated tag")
+			require.NoError(t, gitRun(t, gitcmd.NewCommand("tag", "-d", "v1.0.0")))
+		})
+
+		t.Run("Unsigned", func(t *testing.T) {
+			require.NoError(t, gitRun(t, gitcmd.NewCommand("tag", "-a", "-m", "release", "v1.0.0")))
+			err := gitRun(t, gitcmd.NewCommand("push", "origin", "v1.0.0"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Tag v1.0.0 must be signed with a verified key")
+			require.NoError(t, gitRun(t, gitcmd.NewCommand("tag", "-d", "v1.0.0")))
+		})
+
+		t.Run("NotSemver", func(t *testing.T) {
+			require.NoError(t, gitRun(t, signedTag("release").AddDynamicArguments("v1.0")))
+			err := gitRun(t, gitcmd.NewCommand("push", "origin", "v1.0"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Tag v1.0 is not a semantic version")
+		})
+
+		t.Run("Signed", func(t *testing.T) {
+			require.NoError(t, gitRun(t, signedTag("release").AddDynamicArguments("v1.0.0")))
+			require.NoError(t, gitRun(t, gitcmd.NewCommand("push", "origin", "v1.0.0")))
+		})
+
+		t.Run("Move", func(t *testing.T) {
+			require.NoError(t, gitRun(t, signedTag("moved").AddArguments("--force").AddDynamicArguments("v1.0.0")))
+			err := gitRun(t, gitcmd.NewCommand("push", "--force", "origin", "v1.0.0"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Tag v1.0.0 is a released version and can't be moved or deleted")
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			err := gitRun(t, gitcmd.NewCommand("push", "origin", ":refs/tags/v1.0.0"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Tag v1.0.0 is a released version and can't be moved or deleted")
+
+			rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{RepoID: repo.ID, TagName: "v1.0.0"})
+			err = release.DeleteReleaseByID(t.Context(), repo, rel, owner, true)
+			assert.True(t, release.IsErrProtectedTagName(err))
+		})
+
+		t.Run("CreateOnServer", func(t *testing.T) {
+			// tags created by the server are never signed
+			err := release.CreateNewTag(t.Context(), owner, repo, "master", "v2.0.0", "release")
+			assert.True(t, release.IsErrProtectedTagName(err))
+		})
+	})
+}
+
 func TestRepushTag(t *testing.T) {
 	onGiteaRun(t, func(t *testing.T, u *url.URL) {
 		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
@@ -382,6 +382,182 @@ func Test_WebhookPush(t *testing.T) {
 	})
 }
 
+func Test_WebhookCustom(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		var bodies []string
+		var eventHeader, contentType string
+		provider := newMockWebhookProvider(func(r *http.Request) {
+			content, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(content))
+			eventHeader = r.Header.Get("X-Custom-Event")
+			contentType = r.Header.Get("Content-Type")
+		}, http.StatusOK)
+		defer provider.Close()
+
+		// 1. create a new custom webhook for repo1
+		session := loginUser(t, "user2")
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/hooks/custom/new"), http.StatusOK)
+
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeAll)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "custom",
+			Config: api.CreateHookOptionConfig{
+				"url":               provider.URL(),
+				"content_type":      "json",
+				"http_method":       "PUT",
+				"body_content_type": "text/plain",
+				"headers":           "X-Custom-Event: {{.Event}}",
+				"body_template":     "{{.Payload.repository.full_name}} {{len .Payload.commits}}",
+			},
+			Events: []string{"push"},
+			Active: true,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "custom",
+			Config: api.CreateHookOptionConfig{
+				"url":           provider.URL(),
+				"content_type":  "json",
+				"body_template": "{{.Payload",
+			},
+			Events: []string{"push"},
+			Active: true,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// 2. trigger the webhook
+		testCreateFile(t, session, "user2", "repo1", "master", "", "test_webhook_custom.md", "# a test file for custom webhook")
+
+		// 3. validate the rendered request
+		assert.Equal(t, []string{"user2/repo1 1"}, bodies)
+		assert.Equal(t, "push", eventHeader)
+		assert.Equal(t, "text/plain", contentType)
+	})
+}
+
+func Test_WebhookDeliveryRedeliver(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		defer test.MockVariableValue(&setting.Webhook.MaxAttempts, 1)()
+
+		var requests int
+		provider := newMockWebhookProvider(func(r *http.Request) {
+			requests++
+		}, http.StatusInternalServerError)
+		defer provider.Close()
+
+		// 1. create a new webhook for repo1 which fails to deliver
+		session := loginUser(t, "user2")
+		testAPICreateWebhookForRepo(t, session, "user2", "repo1", provider.URL(), "push")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeAll)
+
+		var hooks []*api.Hook
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/hooks").AddTokenAuth(token), http.StatusOK)
+		DecodeJSON(t, resp, &hooks)
+		hookLink := fmt.Sprintf("/api/v1/repos/user2/repo1/hooks/%d", hooks[len(hooks)-1].ID)
+
+		// 2. trigger the webhook, the only attempt fails
+		testCreateFile(t, session, "user2", "repo1", "master", "", "test_webhook_redeliver.md", "# a test file for webhook redelivery")
+		assert.Equal(t, 1, requests)
+
+		var deliveries []*api.HookDelivery
+		resp = MakeRequest(t, NewRequest(t, "GET", hookLink+"/deliveries?status=dead_letter").AddTokenAuth(token), http.StatusOK)
+		DecodeJSON(t, resp, &deliveries)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "push", deliveries[0].Event)
+		assert.Equal(t, 1, deliveries[0].Attempt)
+		assert.Equal(t, http.StatusInternalServerError, deliveries[0].StatusCode)
+
+		MakeRequest(t, NewRequest(t, "GET", hookLink+"/deliveries?status=unknown").AddTokenAuth(token), http.StatusUnprocessableEntity)
+
+		// 3. redeliver the dead-lettered deliveries
+		var redelivered []*api.HookDelivery
+		req := NewRequestWithJSON(t, "POST", hookLink+"/deliveries/redeliver", api.RedeliverHookDeliveriesOption{
+			Since: deliveries[0].Delivered.Add(-time.Minute),
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &redelivered)
+		require.Len(t, redelivered, 1)
+		assert.NotEqual(t, deliveries[0].UUID, redelivered[0].UUID)
+		assert.Eventually(t, func() bool { return requests == 2 }, 5*time.Second, 10*time.Millisecond)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", hookLink+"/deliveries").AddTokenAuth(token), http.StatusOK)
+		DecodeJSON(t, resp, &deliveries)
+		assert.Len(t, deliveries, 2)
+
+		// 4. the redelivery failed again and is shown as dead letter
+		resp = session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/settings/hooks/%d", hooks[len(hooks)-1].ID)), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find(".ui.red.label[data-tooltip-content]").Length())
+		AssertHTMLElement(t, htmlDoc, fmt.Sprintf(`form[action="/user2/repo1/settings/hooks/%d/redeliver"]`, hooks[len(hooks)-1].ID), true)
+	})
+}
+
+func Test_WebhookBroker(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		session := loginUser(t, "user2")
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/hooks/kafka/new"), http.StatusOK)
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/hooks/nats/new"), http.StatusOK)
+
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeAll)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "kafka",
+			Config: api.CreateHookOptionConfig{
+				"url":   "kafka://kafka-1:9092,kafka-2:9092",
+				"topic": "gitea.events",
+			},
+			Events: []string{"push"},
+			Active: true,
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var hook api.Hook
+		DecodeJSON(t, resp, &hook)
+		assert.Equal(t, "gitea.events", hook.Config["topic"])
+		assert.Equal(t, "repository", hook.Config["partition_key"])
+		assert.Equal(t, "all", hook.Config["required_acks"])
+
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/user2/repo1/hooks/%d", hook.ID), api.EditHookOption{
+			Config: map[string]string{"required_acks": "leader", "tls": "true"},
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		hook = api.Hook{}
+		DecodeJSON(t, resp, &hook)
+		assert.Equal(t, "gitea.events", hook.Config["topic"])
+		assert.Equal(t, "leader", hook.Config["required_acks"])
+		assert.Equal(t, "true", hook.Config["tls"])
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "nats",
+			Config: api.CreateHookOptionConfig{
+				"url":       "nats://nats:4222",
+				"subject":   "gitea.events",
+				"jetstream": "true",
+			},
+			Events: []string{"push"},
+			Active: true,
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		hook = api.Hook{}
+		DecodeJSON(t, resp, &hook)
+		assert.Equal(t, "gitea.events", hook.Config["subject"])
+		assert.Equal(t, "none", hook.Config["partition_key"])
+		assert.Equal(t, "true", hook.Config["jetstream"])
+
+		for _, config := range []api.CreateHookOptionConfig{
+			{"url": "https://kafka-1:9092", "topic": "gitea.events"},
+			{"url": "kafka://kafka-1:9092", "topic": "gitea events"},
+			{"url": "kafka://kafka-1:9092", "topic": "gitea.events", "partition_key": "issue"},
+		} {
+			req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+				Type:   "kafka",
+				Config: config,
+				Events: []string{"push"},
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		}
+	})
+}
+
 func Test_WebhookPushDevBranch(t *testing.T) {
 	var payloads []api.PushPayload
 	var triggeredEvent string
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpamDetection(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.SpamDetection.Enabled, true)()
+	// the fixture accounts are old, so all of them are checked as new accounts
+	defer test.MockVariableValue(&setting.SpamDetection.NewAccountAge, 100*365*24*time.Hour)()
+	defer test.MockVariableValue(&setting.SpamDetection.MinAccountAge, 0)()
+
+	const spam = "cheap https://a.example.com https://b.example.com https://c.example.com"
+
+	session := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", map[string]string{
+		"_csrf":   GetUserCSRFToken(t, session),
+		"title":   "held issue",
+		"content": spam,
+	})
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "/user2/repo1/issues", test.RedirectURL(resp))
+	unittest.AssertNotExistsBean(t, &issues_model.Issue{Title: "held issue"})
+	heldIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentIssue, Title: "held issue"})
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/1/comments", &api.CreateIssueCommentOption{Body: spam + " again"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusAccepted)
+	unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: 1, Content: spam + " again"})
+	heldComment := unittest.AssertExistsAndLoadBean(t, &issues_model.HeldContent{Type: issues_model.HeldContentComment, IssueID: 1})
+
+	// harmless content is published immediately
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/1/comments", &api.CreateIssueCommentOption{Body: "harmless"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+
+	adminSession := loginUser(t, "user1")
+	resp = adminSession.MakeRequest(t, NewRequest(t, "GET", "/-/admin/held-content"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "held issue")
+
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/admin/held-content/%d/approve", heldIssue.ID), map[string]string{
+		"_csrf": GetUserCSRFToken(t, adminSession),
+	})
+	adminSession.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, PosterID: 2, Title: "held issue", Content: spam})
+	unittest.AssertNotExistsBean(t, &issues_model.HeldContent{ID: heldIssue.ID})
+
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/admin/held-content/%d/reject", heldComment.ID), map[string]string{
+		"_csrf": GetUserCSRFToken(t, adminSession),
+	})
+	adminSession.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &issues_model.HeldContent{ID: heldComment.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: 1, Content: spam + " again"})
+
+	// only administrators can moderate
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/admin/held-content/%d/approve", heldIssue.ID), map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermsAcceptance(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	user2Token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadUser)
+	session := loginUser(t, "user2")
+
+	createDocument := func(t *testing.T, opts api.CreateTermsDocumentOption, status int) *api.TermsDocument {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/admin/terms", opts).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, status)
+		if status != http.StatusCreated {
+			return nil
+		}
+		d := &api.TermsDocument{}
+		DecodeJSON(t, resp, d)
+		return d
+	}
+
+	tos := createDocument(t, api.CreateTermsDocumentOption{Kind: "tos", Version: "2026-01", Title: "Terms of Service", Content: "Be **nice**"}, http.StatusCreated)
+	assert.True(t, tos.Current)
+	createDocument(t, api.CreateTermsDocumentOption{Kind: "tos", Version: "2026-01", Title: "Terms of Service", Content: "c"}, http.StatusConflict)
+	createDocument(t, api.CreateTermsDocumentOption{Kind: "other", Version: "1", Title: "Other", Content: "c"}, http.StatusUnprocessableEntity)
+	MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/admin/terms", api.CreateTermsDocumentOption{Kind: "aup", Version: "1", Title: "AUP", Content: "c"}).AddTokenAuth(user2Token), http.StatusForbidden)
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `href="/user/terms"`)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(user2Token), http.StatusOK)
+	})
+
+	t.Run("Impersonated", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Admin.EnableImpersonation, true)()
+
+		adminSession := loginUser(t, "user1")
+		adminSession.MakeRequest(t, NewRequestWithValues(t, "POST", "/-/admin/users/2/impersonate", map[string]string{
+			"_csrf": GetUserCSRFToken(t, adminSession),
+		}), http.StatusOK)
+
+		defer test.MockVariableValue(&setting.Service.TermsAcceptanceGracePeriod, 0)()
+
+		// the consent can only be given by the user themselves
+		adminSession.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		adminSession.MakeRequest(t, NewRequestWithValues(t, "POST", "/user/terms", map[string]string{
+			"_csrf":       GetUserCSRFToken(t, adminSession),
+			"document_id": fmt.Sprint(tos.ID),
+		}), http.StatusForbidden)
+		unittest.AssertNotExistsBean(t, &user_model.TermsAcceptance{DocumentID: tos.ID, UserID: 2})
+	})
+
+	t.Run("Overdue", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Service.TermsAcceptanceGracePeriod, 0)()
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusSeeOther)
+		assert.Equal(t, "/user/terms", test.RedirectURL(resp))
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(user2Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "GET", "/user2/repo1.git/info/refs").AddBasicAuth("user2").SetHeader("User-Agent", "git/2.50.0"), http.StatusForbidden)
+
+		// the document can be read without accepting it
+		resp = session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/-/terms/%d", tos.ID)), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Be <strong>nice</strong>")
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user/terms"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf(`name="document_id" type="checkbox" value="%d"`, tos.ID))
+
+		resp = session.MakeRequest(t, NewRequestWithValues(t, "POST", "/user/terms", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		}), http.StatusSeeOther)
+		assert.Equal(t, "/user/terms", test.RedirectURL(resp))
+
+		resp = session.MakeRequest(t, NewRequestWithValues(t, "POST", "/user/terms", map[string]string{
+			"_csrf":       GetUserCSRFToken(t, session),
+			"document_id": fmt.Sprint(tos.ID),
+		}), http.StatusSeeOther)
+		assert.Equal(t, "/user/settings", test.RedirectURL(resp))
+		unittest.AssertExistsAndLoadBean(t, &user_model.TermsAcceptance{DocumentID: tos.ID, UserID: 2})
+
+		session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(user2Token), http.StatusOK)
+	})
+
+	t.Run("SignUp", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Service.EnableCaptcha, false)()
+
+		values := map[string]string{
+			"user_name": "termsUser",
+			"email":     "termsUser@example.com",
+			"password":  "examplePassword!1",
+			"retype":    "examplePassword!1",
+		}
+		resp := MakeRequest(t, NewRequestWithValues(t, "POST", "/user/sign_up", values), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf(`href="/-/terms/%d"`, tos.ID))
+		unittest.AssertNotExistsBean(t, &user_model.User{Name: "termsUser"})
+
+		values["accept_terms"] = "on"
+		MakeRequest(t, NewRequestWithValues(t, "POST", "/user/sign_up", values), http.StatusSeeOther)
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "termsUser"})
+		unittest.AssertExistsAndLoadBean(t, &user_model.TermsAcceptance{DocumentID: tos.ID, UserID: u.ID})
+	})
+
+	t.Run("Acceptances", func(t *testing.T) {
+		var docs []*api.TermsDocument
+		DecodeJSON(t, MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/terms").AddTokenAuth(adminToken), http.StatusOK), &docs)
+		if assert.Len(t, docs, 1) {
+			assert.Equal(t, "2026-01", docs[0].Version)
+		}
+
+		var acceptances []*api.TermsAcceptance
+		resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/api/v1/admin/terms/%d/acceptances", tos.ID)).AddTokenAuth(adminToken), http.StatusOK)
+		DecodeJSON(t, resp, &acceptances)
+		if assert.Len(t, acceptances, 2) {
+			assert.Equal(t, "termsUser", acceptances[0].User.UserName)
+			assert.Equal(t, "user2", acceptances[1].User.UserName)
+		}
+		assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/terms/9999/acceptances").AddTokenAuth(adminToken), http.StatusNotFound)
+	})
+}
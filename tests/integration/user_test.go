@@ -4,6 +4,8 @@
 package integration
 
 import (
+	"archive/zip"
+	"bytes"
 	"net/http"
 	"strings"
 	"testing"
@@ -20,6 +22,7 @@ import (
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestViewUser(t *testing.T) {
@@ -275,3 +278,22 @@ func TestUserLocationMapLink(t *testing.T) {
 	htmlDoc := NewHTMLParser(t, resp.Body)
 	AssertHTMLElement(t, htmlDoc, `a[href="https://example/foo/A%2Fb"]`, true)
 }
+
+func TestUserExportData(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	MakeRequest(t, NewRequest(t, "GET", "/user/settings/account/export"), http.StatusSeeOther)
+
+	session := loginUser(t, "user2")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/account/export"), http.StatusOK)
+	assert.Equal(t, "application/zip", resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Header().Get("Content-Disposition"), "user2-data.zip")
+
+	zr, err := zip.NewReader(bytes.NewReader(resp.Body.Bytes()), int64(resp.Body.Len()))
+	require.NoError(t, err)
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Subset(t, names, []string{"profile.json", "emails.json", "issues.json", "comments.json", "activity.json", "attachments.json"})
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"testing"
 
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
@@ -63,4 +64,22 @@ func TestWebfinger(t *testing.T) {
 
 	req = NewRequest(t, "GET", "/.well-known/webfinger?resource=mailto:"+user.Email)
 	MakeRequest(t, req, http.StatusNotFound)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	req = NewRequest(t, "GET", "/.well-known/webfinger?resource="+url.QueryEscape(repo.HTMLURL()))
+	resp = MakeRequest(t, req, http.StatusOK)
+	jrd = webfingerJRD{}
+	DecodeJSON(t, resp, &jrd)
+	assert.Equal(t, repo.HTMLURL(), jrd.Subject)
+	assert.Equal(t, []string{appURL.String() + "api/v1/activitypub/repository-id/1"}, jrd.Aliases)
+
+	req = NewRequest(t, "GET", "/.well-known/webfinger?resource="+url.QueryEscape(user.HTMLURL(t.Context())))
+	resp = MakeRequest(t, req, http.StatusOK)
+	jrd = webfingerJRD{}
+	DecodeJSON(t, resp, &jrd)
+	assert.Equal(t, "acct:user2@"+appURL.Host, jrd.Subject)
+
+	// private repository
+	req = NewRequest(t, "GET", "/.well-known/webfinger?resource="+url.QueryEscape(appURL.String()+"user2/repo2"))
+	MakeRequest(t, req, http.StatusNotFound)
 }
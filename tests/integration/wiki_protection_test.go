@@ -0,0 +1,139 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWikiPageProtection(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		ownerSession := loginUser(t, "user2")
+		ownerCtx := NewAPITestContext(t, "user2", "repo1", auth_model.AccessTokenScopeWriteRepository)
+		t.Run("AddUser4AsCollaboratorWithWriteAccess", doAPIAddCollaborator(ownerCtx, "user4", perm.AccessModeWrite))
+		t.Run("AddUser5AsCollaboratorWithReadAccess", doAPIAddCollaborator(ownerCtx, "user5", perm.AccessModeRead))
+
+		t.Run("ProtectHome", func(t *testing.T) {
+			req := NewRequestWithValues(t, "POST", "/user2/repo1/settings/wiki", map[string]string{
+				"_csrf":        GetUserCSRFToken(t, ownerSession),
+				"name_pattern": "Home",
+			})
+			ownerSession.MakeRequest(t, req, http.StatusSeeOther)
+			unittest.AssertExistsAndLoadBean(t, &repo_model.ProtectedWikiPage{RepoID: 1, NamePattern: "Home"})
+
+			req = NewRequest(t, "GET", "/user2/repo1/settings/wiki")
+			resp := ownerSession.MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "<pre>Home</pre>")
+		})
+
+		collaboratorSession := loginUser(t, "user4")
+		collaboratorToken := getTokenForLoggedInUser(t, collaboratorSession, auth_model.AccessTokenScopeWriteRepository)
+		editPage := func(t *testing.T, token, pageName, title string, expectedStatus int) {
+			req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/wiki/page/"+pageName, &api.CreateWikiPageOptions{
+				Title:         title,
+				ContentBase64: base64.StdEncoding.EncodeToString([]byte("edited content")),
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, expectedStatus)
+		}
+
+		t.Run("Web", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/user2/repo1/wiki/Home")
+			resp := collaboratorSession.MakeRequest(t, req, http.StatusOK)
+			htmlDoc := NewHTMLParser(t, resp.Body)
+			assert.Equal(t, 0, htmlDoc.Find(`a[href="/user2/repo1/wiki/Home?action=_edit"]`).Length())
+
+			req = NewRequest(t, "GET", "/user2/repo1/wiki/Home?action=_edit")
+			collaboratorSession.MakeRequest(t, req, http.StatusForbidden)
+
+			req = NewRequest(t, "GET", "/user2/repo1/wiki/Home")
+			resp = ownerSession.MakeRequest(t, req, http.StatusOK)
+			htmlDoc = NewHTMLParser(t, resp.Body)
+			assert.Equal(t, 1, htmlDoc.Find(`a[href="/user2/repo1/wiki/Home?action=_edit"]`).Length())
+		})
+
+		t.Run("API", func(t *testing.T) {
+			editPage(t, collaboratorToken, "Home", "Home", http.StatusForbidden)
+			editPage(t, collaboratorToken, "Page-With-Image", "Page With Image", http.StatusOK)
+
+			req := NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/wiki/page/Home").AddTokenAuth(collaboratorToken)
+			MakeRequest(t, req, http.StatusForbidden)
+
+			// a page can't be renamed to a protected name either
+			editPage(t, collaboratorToken, "Page-With-Image", "Home", http.StatusForbidden)
+
+			editPage(t, ownerCtx.Token, "Home", "Home", http.StatusOK)
+		})
+
+		t.Run("Git", func(t *testing.T) {
+			// the protection of the default branch of the code doesn't apply to the wiki
+			testAPICreateBranchProtection(t, "master", 1, http.StatusCreated)
+
+			wikiURL, _ := url.Parse(u.String() + "user2/repo1.wiki.git")
+			wikiURL.User = url.UserPassword("user4", userPassword)
+			// the wiki fixtures have no git hooks
+			repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+			assert.NoError(t, gitrepo.CreateDelegateHooks(t.Context(), repo.WikiStorageRepo()))
+
+			dstPath := t.TempDir()
+			assert.NoError(t, git.Clone(t.Context(), wikiURL.String(), dstPath, git.CloneRepoOptions{}))
+
+			doGitCheckoutWriteFileCommit(localGitAddCommitOptions{
+				LocalRepoPath:   dstPath,
+				CheckoutBranch:  "master",
+				TreeFilePath:    "Home.md",
+				TreeFileContent: "pushed content",
+			})(t)
+			doGitPushTestRepositoryFail(dstPath, "origin", "master")(t)
+
+			_, _, err := gitcmd.NewCommand("reset", "--hard", "origin/master").RunStdString(t.Context(), &gitcmd.RunOpts{Dir: dstPath})
+			assert.NoError(t, err)
+			doGitCheckoutWriteFileCommit(localGitAddCommitOptions{
+				LocalRepoPath:   dstPath,
+				CheckoutBranch:  "master",
+				TreeFilePath:    "Page-With-Image.md",
+				TreeFileContent: "pushed content",
+			})(t)
+			doGitPushTestRepository(dstPath, "origin", "master")(t)
+
+			// the protected pages don't replace the other checks of the wiki pushes
+			doGitCheckoutWriteFileCommit(localGitAddCommitOptions{
+				LocalRepoPath:   dstPath,
+				CheckoutBranch:  "master",
+				TreeFilePath:    "Page-With-Image.md",
+				TreeFileContent: "pushed for review",
+			})(t)
+			doGitPushTestRepositoryFail(dstPath, "origin", "HEAD:refs/for/master")(t)
+		})
+
+		t.Run("GitReadOnly", func(t *testing.T) {
+			wikiURL, _ := url.Parse(u.String() + "user2/repo1.wiki.git")
+			wikiURL.User = url.UserPassword("user5", userPassword)
+
+			dstPath := t.TempDir()
+			assert.NoError(t, git.Clone(t.Context(), wikiURL.String(), dstPath, git.CloneRepoOptions{}))
+
+			doGitCheckoutWriteFileCommit(localGitAddCommitOptions{
+				LocalRepoPath:   dstPath,
+				CheckoutBranch:  "master",
+				TreeFilePath:    "Page-With-Image.md",
+				TreeFileContent: "pushed by a reader",
+			})(t)
+			doGitPushTestRepositoryFail(dstPath, "origin", "master")(t)
+		})
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func wikiSearchResultTitles(t *testing.T, session *TestSession, url string) []string {
+	resp := session.MakeRequest(t, NewRequest(t, "GET", url), http.StatusOK)
+	doc := NewHTMLParser(t, resp.Body)
+	titles := doc.doc.Find(".repository.search .repo-search-result .header span.file")
+	result := make([]string, titles.Length())
+	titles.Each(func(i int, selection *goquery.Selection) {
+		result[i] = selection.Text()
+	})
+	return result
+}
+
+func TestWikiSearch(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	wiki_indexer.UpdateRepoIndexer(repo1)
+
+	anonymous := emptyTestSession(t)
+	session := loginUser(t, "user2")
+
+	t.Run("Repo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		titles := wikiSearchResultTitles(t, anonymous, "/user2/repo1/search/wiki?q=spaces")
+		if assert.Len(t, titles, 1) {
+			assert.Contains(t, titles[0], "Page With Spaced Name")
+		}
+		resp := anonymous.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/search/wiki?q=spaces"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<mark>spaces</mark>")
+
+		// the code search links to the wiki search
+		resp = anonymous.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/search?q=spaces"), http.StatusOK)
+		assert.Equal(t, 1, NewHTMLParser(t, resp.Body).Find(`a[href="/user2/repo1/search/wiki?q=spaces"]`).Length())
+
+		// the repositories without wiki can't be searched
+		anonymous.MakeRequest(t, NewRequest(t, "GET", "/user12/repo10/search/wiki?q=spaces"), http.StatusNotFound)
+	})
+
+	t.Run("Edit", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/wiki/page/Page-With-Spaced-Name", &api.CreateWikiPageOptions{
+			Title:         "Page With Spaced Name",
+			ContentBase64: base64.StdEncoding.EncodeToString([]byte("the wiki of the unicorns")),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		assert.Empty(t, wikiSearchResultTitles(t, anonymous, "/user2/repo1/search/wiki?q=spaces"))
+		titles := wikiSearchResultTitles(t, anonymous, "/user2/repo1/search/wiki?q=unicorns")
+		if assert.Len(t, titles, 1) {
+			assert.Contains(t, titles[0], "Page With Spaced Name")
+		}
+	})
+
+	t.Run("Explore", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// a page of the wiki of a private repository
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo2/wiki/new", &api.CreateWikiPageOptions{
+			Title:         "Private unicorns",
+			ContentBase64: base64.StdEncoding.EncodeToString([]byte("the private unicorns")),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		titles := wikiSearchResultTitles(t, anonymous, "/explore/wiki?q=unicorns")
+		if assert.Len(t, titles, 1) {
+			assert.Contains(t, titles[0], "user2/repo1")
+		}
+		assert.Len(t, wikiSearchResultTitles(t, session, "/explore/wiki?q=unicorns"), 2)
+
+		resp := anonymous.MakeRequest(t, NewRequest(t, "GET", "/explore/repos"), http.StatusOK)
+		assert.Equal(t, 1, NewHTMLParser(t, resp.Body).Find(`a[href="/explore/wiki"]`).Length())
+	})
+}
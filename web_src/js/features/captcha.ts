@@ -33,6 +33,21 @@ export async function initCaptcha() {
       }
       break;
     }
+    case 'geetest': {
+      if (window.initGeetest4) {
+        window.initGeetest4({captchaId: siteKey, product: 'float'}, (captcha: any) => {
+          captcha.appendTo(captchaEl);
+          captcha.onSuccess(() => {
+            // the validation result has to be submitted with the form to be verified by the server
+            const result = captcha.getValidate();
+            for (const name of ['lot_number', 'captcha_output', 'pass_token', 'gen_time']) {
+              captchaEl.parentElement.querySelector<HTMLInputElement>(`input[name=${name}]`).value = result[name];
+            }
+          });
+        });
+      }
+      break;
+    }
     case 'm-captcha': {
       const mCaptcha = await import(/* webpackChunkName: "mcaptcha-vanilla-glue" */'@mcaptcha/vanilla-glue');
 
@@ -1,7 +1,9 @@
 import {svg} from '../svg.ts';
-import {createTippy} from '../modules/tippy.ts';
+import {createTippy, showTemporaryTooltip} from '../modules/tippy.ts';
 import {toAbsoluteUrl} from '../utils.ts';
 import {addDelegatedEventListener} from '../utils/dom.ts';
+import {GET} from '../modules/fetch.ts';
+import {html} from '../utils/html.ts';
 
 function changeHash(hash: string) {
   if (window.history.pushState) {
@@ -109,6 +111,42 @@ function showLineButton() {
   });
 }
 
+type RepoSymbol = {
+  name: string,
+  kind: string,
+  path: string,
+  line: number,
+  html_url: string,
+};
+
+// it looks up the definitions of the symbol in the default branch, it jumps to the definition if there is only one,
+// otherwise a menu of the definitions is shown
+async function jumpToDefinition(el: HTMLElement, fileView: HTMLElement, name: string) {
+  const resp = await GET(`${fileView.getAttribute('data-symbols-link')}?name=${encodeURIComponent(name)}`);
+  const symbols: Array<RepoSymbol> = resp.ok ? await resp.json() : [];
+  if (!symbols.length) {
+    showTemporaryTooltip(el, fileView.getAttribute('data-symbols-not-found'));
+    return;
+  }
+  if (symbols.length === 1) {
+    window.location.href = symbols[0].html_url;
+    return;
+  }
+
+  const menu = document.createElement('div');
+  menu.classList.add('symbol-definition-menu');
+  menu.innerHTML = symbols.map((s) => html`<a class="item" role="menuitem" href="${s.html_url}">${s.path}:${s.line} <span class="text grey">${s.kind}</span></a>`).join('');
+  createTippy(el, {
+    theme: 'menu',
+    trigger: 'manual',
+    content: menu,
+    placement: 'bottom-start',
+    interactive: true,
+    showOnCreate: true,
+    onHidden: (tippy) => tippy.destroy(),
+  });
+}
+
 export function initRepoCodeView() {
   // When viewing a file or blame, there is always a ".file-view" element,
   // but the ".code-view" class is only present when viewing the "code" of a file; it is not present when viewing a PDF file.
@@ -130,6 +168,15 @@ export function initRepoCodeView() {
     showLineButton();
   });
 
+  // "Ctrl/Cmd + Click" on a symbol jumps to its definition when the symbol indexer is enabled
+  addDelegatedEventListener(document, 'click', '.file-view[data-symbols-link] .code-inner span', (el: HTMLElement, e: MouseEvent) => {
+    if (!e.ctrlKey && !e.metaKey) return;
+    const name = el.textContent.trim();
+    if (!/^[\p{L}_$][\p{L}\p{N}_$]*$/u.test(name)) return;
+    e.preventDefault();
+    jumpToDefinition(el, el.closest('.file-view'), name);
+  });
+
   // apply the selected range from the URL hash
   const onHashChange = () => {
     if (!window.location.hash) return;
@@ -2,6 +2,8 @@ import {hideElem, querySingleVisibleElem, showElem, toggleElem} from '../utils/d
 import {htmlEscape} from '../utils/html.ts';
 import {fomanticQuery} from '../modules/fomantic/base.ts';
 import {sanitizeRepoName} from './repo-common.ts';
+import {GET} from '../modules/fetch.ts';
+import {parseDom} from '../utils.ts';
 
 const {appSubUrl} = window.config;
 
@@ -13,10 +15,30 @@ function initRepoNewTemplateSearch(form: HTMLFormElement) {
   const inputRepoTemplate = form.querySelector<HTMLInputElement>('#repo_template');
   const elTemplateUnits = form.querySelector('#template_units');
   const elNonTemplate = form.querySelector('#non_template');
+  const elTemplateVariables = form.querySelector('#template_variables');
+  let templateVariablesRepoId = inputRepoTemplate.value;
+  const updateTemplateVariables = async function (hasSelectedTemplate: boolean) {
+    const repoId = inputRepoTemplate.value;
+    if (repoId === templateVariablesRepoId) return;
+    templateVariablesRepoId = repoId;
+    elTemplateVariables.innerHTML = '';
+    if (!hasSelectedTemplate) return;
+    // the variables declared by the template are rendered by the backend, the response is a full HTML page
+    const ownerId = form.querySelector<HTMLInputElement>('input[name="uid"]').value;
+    try {
+      const response = await GET(`${appSubUrl}/repo/create?template_id=${repoId}&org=${ownerId}`);
+      const respDoc = parseDom(await response.text(), 'text/html');
+      if (repoId !== inputRepoTemplate.value) return; // another template has been selected in the meantime
+      elTemplateVariables.innerHTML = respDoc.querySelector('#template_variables')?.innerHTML ?? '';
+    } catch (error) {
+      console.error('Error:', error);
+    }
+  };
   const checkTemplate = function () {
     const hasSelectedTemplate = inputRepoTemplate.value !== '' && inputRepoTemplate.value !== '0';
     toggleElem(elTemplateUnits, hasSelectedTemplate);
     toggleElem(elNonTemplate, !hasSelectedTemplate);
+    updateTemplateVariables(hasSelectedTemplate);
   };
   inputRepoTemplate.addEventListener('change', checkTemplate);
   checkTemplate();
@@ -1,4 +1,7 @@
 import {hideElem, showElem, type DOMEvent} from '../utils/dom.ts';
+import {POST} from '../modules/fetch.ts';
+import {showErrorToast} from '../modules/toast.ts';
+import {getComboMarkdownEditor} from './comp/ComboMarkdownEditor.ts';
 
 export function initRepoRelease() {
   document.addEventListener('click', (e: DOMEvent<MouseEvent>) => {
@@ -15,6 +18,7 @@ export function initRepoReleaseNew() {
   if (!document.querySelector('.repository.new.release')) return;
 
   initTagNameEditor();
+  initGenerateReleaseNotes();
 }
 
 function initTagNameEditor() {
@@ -46,3 +50,36 @@ function initTagNameEditor() {
     hideTargetInput(e.target as HTMLInputElement);
   });
 }
+
+function initGenerateReleaseNotes() {
+  const button = document.querySelector<HTMLButtonElement>('#generate-release-notes');
+  if (!button) return;
+
+  button.addEventListener('click', async () => {
+    const form = button.closest('form');
+    // the tag name and the target can only be changed before the release is created
+    const tagName = form.querySelector<HTMLInputElement>('#tag-name')?.value ?? button.getAttribute('data-tag-name');
+    const tagTarget = form.querySelector<HTMLInputElement>('input[name=tag_target]')?.value ?? button.getAttribute('data-tag-target');
+
+    const data = new FormData();
+    data.append('tag_name', tagName);
+    data.append('tag_target', tagTarget);
+    button.classList.add('is-loading');
+    try {
+      const resp = await POST(button.getAttribute('data-url'), {data});
+      const json = await resp.json();
+      if (!resp.ok) {
+        showErrorToast(json.errorMessage ?? window.config.i18n.error_occurred);
+        return;
+      }
+      const editor = getComboMarkdownEditor(form.querySelector('.combo-markdown-editor'));
+      const content = editor.value().trim();
+      editor.value(content ? `${content}\n\n${json.content}` : json.content);
+    } catch (error) {
+      console.error(error);
+      showErrorToast(window.config.i18n.error_occurred);
+    } finally {
+      button.classList.remove('is-loading');
+    }
+  });
+}
@@ -66,6 +66,7 @@ interface Window {
   grecaptcha: any,
   turnstile: any,
   hcaptcha: any,
+  initGeetest4: any,
 
   // do not add more properties here unless it is a must
 }